@@ -2,8 +2,12 @@
 package main
 
 import (
+	"crypto/subtle"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 
@@ -27,12 +31,59 @@ func main() {
 	}
 
 	s := mcpserver.NewMCPServer(cfg)
-	if err := server.ServeStdio(s); err != nil {
+
+	var err error
+	switch transport := envOrDefault("ALANCOIN_MCP_TRANSPORT", "stdio"); transport {
+	case "stdio":
+		err = server.ServeStdio(s)
+	case "http":
+		err = serveHTTP(s)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown ALANCOIN_MCP_TRANSPORT %q (want 'stdio' or 'http')\n", transport)
+		os.Exit(1)
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "MCP server error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// serveHTTP exposes the MCP server over the streamable-HTTP transport
+// (with SSE streaming) at /mcp, so hosted LLM platforms and remote
+// agents can connect to a shared endpoint instead of spawning a local
+// process. Clients must present the shared bearer token.
+func serveHTTP(s *server.MCPServer) error {
+	token := os.Getenv("ALANCOIN_MCP_AUTH_TOKEN")
+	if token == "" {
+		return fmt.Errorf("ALANCOIN_MCP_AUTH_TOKEN is required for the http transport")
+	}
+	addr := envOrDefault("ALANCOIN_MCP_ADDR", ":8090")
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", requireBearer(token, server.NewStreamableHTTPServer(s)))
+
+	fmt.Fprintf(os.Stderr, "Alancoin MCP server listening on %s/mcp\n", addr)
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return httpServer.ListenAndServe()
+}
+
+// requireBearer rejects requests that don't carry the shared bearer token.
+func requireBearer(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func envOrDefault(key, defaultValue string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -0,0 +1,382 @@
+// Package blocklist maintains counterparty address denylists: one
+// global list managed by operators (seeded by bulk import of
+// known-bad addresses) and per-agent personal lists. Payment flows —
+// session keys, escrow, gateway proxying — consult it through the
+// CheckPayment gate before moving money, and every mutation lands in
+// an audit trail.
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScopeGlobal marks entries that apply to every payer. Personal
+// entries use the owning agent's address as scope.
+const ScopeGlobal = "global"
+
+// maxAuditRecords caps the in-memory audit trail.
+const maxAuditRecords = 1000
+
+// maxPersonalEntries caps one agent's personal denylist.
+const maxPersonalEntries = 100
+
+var (
+	// ErrBlocked is wrapped by CheckPayment failures so callers can
+	// distinguish policy refusals from transport errors.
+	ErrBlocked = errors.New("blocklist: counterparty is blocked")
+
+	// ErrListFull is returned when a personal denylist is at capacity.
+	ErrListFull = errors.New("blocklist: personal denylist is full")
+)
+
+// Entry is one blocked address within a scope.
+type Entry struct {
+	Address   string    `json:"address"`
+	Scope     string    `json:"scope"` // "global" or the owning agent's address
+	Reason    string    `json:"reason,omitempty"`
+	AddedBy   string    `json:"addedBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AuditRecord is one mutation of the blocklist.
+type AuditRecord struct {
+	Action    string    `json:"action"` // "block", "unblock", "import"
+	Address   string    `json:"address,omitempty"`
+	Scope     string    `json:"scope"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason,omitempty"`
+	Count     int       `json:"count,omitempty"` // imported addresses
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Store persists blocklist entries and audit records across restarts.
+// The in-memory maps stay the read path for payment checks; mutations
+// write through.
+type Store interface {
+	SaveEntry(ctx context.Context, entry *Entry) error
+	DeleteEntry(ctx context.Context, scope, address string) error
+	ListEntries(ctx context.Context) ([]*Entry, error)
+	AppendAudit(ctx context.Context, rec *AuditRecord) error
+	ListAudit(ctx context.Context, limit int) ([]*AuditRecord, error)
+}
+
+// Service holds the denylists in memory, optionally write-through
+// persisted via a Store.
+type Service struct {
+	mu       sync.RWMutex
+	global   map[string]*Entry            // address -> entry
+	personal map[string]map[string]*Entry // owner -> address -> entry
+	audit    []AuditRecord
+	store    Store // optional: persistence across restarts
+	logger   *slog.Logger
+}
+
+// NewService creates an empty blocklist service.
+func NewService() *Service {
+	return &Service{
+		global:   make(map[string]*Entry),
+		personal: make(map[string]map[string]*Entry),
+		logger:   slog.Default(),
+	}
+}
+
+// WithStore attaches a persistence backend. Call Load afterwards to
+// hydrate the in-memory lists from it.
+func (s *Service) WithStore(store Store) *Service {
+	s.store = store
+	return s
+}
+
+// WithLogger sets the logger used for persistence failures.
+func (s *Service) WithLogger(logger *slog.Logger) *Service {
+	s.logger = logger
+	return s
+}
+
+// Load hydrates the in-memory lists and audit trail from the store.
+// Without it a restart would silently drop every imported known-bad
+// address and resume paying previously blocked counterparties.
+func (s *Service) Load(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+	entries, err := s.store.ListEntries(ctx)
+	if err != nil {
+		return err
+	}
+	audit, err := s.store.ListAudit(ctx, maxAuditRecords)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range entries {
+		cp := *e
+		if e.Scope == ScopeGlobal {
+			s.global[e.Address] = &cp
+			continue
+		}
+		list, ok := s.personal[e.Scope]
+		if !ok {
+			list = make(map[string]*Entry)
+			s.personal[e.Scope] = list
+		}
+		list[e.Address] = &cp
+	}
+	// ListAudit returns newest first; the in-memory trail is oldest first.
+	s.audit = s.audit[:0]
+	for i := len(audit) - 1; i >= 0; i-- {
+		s.audit = append(s.audit, *audit[i])
+	}
+	return nil
+}
+
+func normalizeAddr(addr string) string {
+	return strings.ToLower(strings.TrimSpace(addr))
+}
+
+// CheckPayment refuses payments to globally blocked counterparties and
+// to addresses on the payer's personal denylist. It satisfies the
+// payment-gate interfaces in sessionkeys, escrow, and gateway.
+func (s *Service) CheckPayment(_ context.Context, payerAddr, payeeAddr string) error {
+	payer, payee := normalizeAddr(payerAddr), normalizeAddr(payeeAddr)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if entry, ok := s.global[payee]; ok {
+		return fmt.Errorf("%w: %s is on the global blocklist (%s)", ErrBlocked, payee, entry.Reason)
+	}
+	if list, ok := s.personal[payer]; ok {
+		if _, ok := list[payee]; ok {
+			return fmt.Errorf("%w: %s is on your denylist", ErrBlocked, payee)
+		}
+	}
+	return nil
+}
+
+// IsBlocked reports whether a payment from payer to payee would be
+// refused, with the matching entry.
+func (s *Service) IsBlocked(payerAddr, payeeAddr string) (*Entry, bool) {
+	payer, payee := normalizeAddr(payerAddr), normalizeAddr(payeeAddr)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if entry, ok := s.global[payee]; ok {
+		cp := *entry
+		return &cp, true
+	}
+	if list, ok := s.personal[payer]; ok {
+		if entry, ok := list[payee]; ok {
+			cp := *entry
+			return &cp, true
+		}
+	}
+	return nil, false
+}
+
+// BlockGlobal adds an address to the global blocklist.
+func (s *Service) BlockGlobal(addr, reason, actor string) *Entry {
+	addr = normalizeAddr(addr)
+	entry := &Entry{
+		Address:   addr,
+		Scope:     ScopeGlobal,
+		Reason:    reason,
+		AddedBy:   actor,
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.global[addr] = entry
+	s.persistEntry(entry)
+	s.recordLocked(AuditRecord{Action: "block", Address: addr, Scope: ScopeGlobal, Actor: actor, Reason: reason})
+	cp := *entry
+	return &cp
+}
+
+// UnblockGlobal removes an address from the global blocklist. It
+// reports whether the address was present.
+func (s *Service) UnblockGlobal(addr, actor string) bool {
+	addr = normalizeAddr(addr)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.global[addr]; !ok {
+		return false
+	}
+	delete(s.global, addr)
+	s.persistRemoval(ScopeGlobal, addr)
+	s.recordLocked(AuditRecord{Action: "unblock", Address: addr, Scope: ScopeGlobal, Actor: actor})
+	return true
+}
+
+// ImportGlobal bulk-adds known-bad addresses to the global blocklist,
+// returning how many were new.
+func (s *Service) ImportGlobal(addrs []string, reason, actor string) int {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for _, raw := range addrs {
+		addr := normalizeAddr(raw)
+		if addr == "" {
+			continue
+		}
+		if _, ok := s.global[addr]; ok {
+			continue
+		}
+		entry := &Entry{
+			Address:   addr,
+			Scope:     ScopeGlobal,
+			Reason:    reason,
+			AddedBy:   actor,
+			CreatedAt: now,
+		}
+		s.global[addr] = entry
+		s.persistEntry(entry)
+		added++
+	}
+	if added > 0 {
+		s.recordLocked(AuditRecord{Action: "import", Scope: ScopeGlobal, Actor: actor, Reason: reason, Count: added})
+	}
+	return added
+}
+
+// BlockFor adds an address to one agent's personal denylist.
+func (s *Service) BlockFor(ownerAddr, addr, reason string) (*Entry, error) {
+	owner, addr := normalizeAddr(ownerAddr), normalizeAddr(addr)
+	entry := &Entry{
+		Address:   addr,
+		Scope:     owner,
+		Reason:    reason,
+		AddedBy:   owner,
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, ok := s.personal[owner]
+	if !ok {
+		list = make(map[string]*Entry)
+		s.personal[owner] = list
+	}
+	if _, exists := list[addr]; !exists && len(list) >= maxPersonalEntries {
+		return nil, ErrListFull
+	}
+	list[addr] = entry
+	s.persistEntry(entry)
+	s.recordLocked(AuditRecord{Action: "block", Address: addr, Scope: owner, Actor: owner, Reason: reason})
+	cp := *entry
+	return &cp, nil
+}
+
+// UnblockFor removes an address from one agent's personal denylist.
+// It reports whether the address was present.
+func (s *Service) UnblockFor(ownerAddr, addr string) bool {
+	owner, addr := normalizeAddr(ownerAddr), normalizeAddr(addr)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, ok := s.personal[owner]
+	if !ok {
+		return false
+	}
+	if _, ok := list[addr]; !ok {
+		return false
+	}
+	delete(list, addr)
+	s.persistRemoval(owner, addr)
+	s.recordLocked(AuditRecord{Action: "unblock", Address: addr, Scope: owner, Actor: owner})
+	return true
+}
+
+// ListGlobal returns the global blocklist.
+func (s *Service) ListGlobal() []Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]Entry, 0, len(s.global))
+	for _, e := range s.global {
+		entries = append(entries, *e)
+	}
+	return entries
+}
+
+// ListFor returns one agent's personal denylist.
+func (s *Service) ListFor(ownerAddr string) []Entry {
+	owner := normalizeAddr(ownerAddr)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]Entry, 0, len(s.personal[owner]))
+	for _, e := range s.personal[owner] {
+		entries = append(entries, *e)
+	}
+	return entries
+}
+
+// Audit returns the newest audit records, most recent first.
+func (s *Service) Audit(limit int) []AuditRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if limit <= 0 || limit > len(s.audit) {
+		limit = len(s.audit)
+	}
+	records := make([]AuditRecord, 0, limit)
+	for i := len(s.audit) - 1; i >= 0 && len(records) < limit; i-- {
+		records = append(records, s.audit[i])
+	}
+	return records
+}
+
+// recordLocked appends an audit record. Caller holds the write lock.
+func (s *Service) recordLocked(rec AuditRecord) {
+	rec.CreatedAt = time.Now()
+	s.audit = append(s.audit, rec)
+	if len(s.audit) > maxAuditRecords {
+		s.audit = s.audit[len(s.audit)-maxAuditRecords:]
+	}
+	if s.store != nil {
+		if err := s.store.AppendAudit(context.Background(), &rec); err != nil {
+			s.logger.Error("blocklist: failed to persist audit record", "action", rec.Action, "error", err)
+		}
+	}
+}
+
+// persistEntry writes an entry through to the store, best-effort.
+func (s *Service) persistEntry(e *Entry) {
+	if s.store == nil {
+		return
+	}
+	if err := s.store.SaveEntry(context.Background(), e); err != nil {
+		s.logger.Error("blocklist: failed to persist entry", "scope", e.Scope, "address", e.Address, "error", err)
+	}
+}
+
+// persistRemoval deletes an entry from the store, best-effort.
+func (s *Service) persistRemoval(scope, address string) {
+	if s.store == nil {
+		return
+	}
+	if err := s.store.DeleteEntry(context.Background(), scope, address); err != nil {
+		s.logger.Error("blocklist: failed to persist removal", "scope", scope, "address", address, "error", err)
+	}
+}
@@ -0,0 +1,248 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGlobalBlocklist(t *testing.T) {
+	svc := NewService()
+	ctx := context.Background()
+
+	if err := svc.CheckPayment(ctx, "0xbuyer", "0xseller"); err != nil {
+		t.Fatalf("expected empty blocklist to allow, got %v", err)
+	}
+
+	entry := svc.BlockGlobal("0xSeller", "phishing", "0xadmin")
+	if entry.Address != "0xseller" {
+		t.Errorf("expected lowercased address, got %s", entry.Address)
+	}
+	if entry.Scope != ScopeGlobal {
+		t.Errorf("expected global scope, got %s", entry.Scope)
+	}
+
+	// Blocked for every payer, case-insensitively.
+	err := svc.CheckPayment(ctx, "0xbuyer", "0xSELLER")
+	if !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected ErrBlocked, got %v", err)
+	}
+	if err := svc.CheckPayment(ctx, "0xother", "0xseller"); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected ErrBlocked for other payer, got %v", err)
+	}
+
+	if !svc.UnblockGlobal("0xSELLER", "0xadmin") {
+		t.Fatal("UnblockGlobal returned false")
+	}
+	if svc.UnblockGlobal("0xseller", "0xadmin") {
+		t.Fatal("double unblock returned true")
+	}
+	if err := svc.CheckPayment(ctx, "0xbuyer", "0xseller"); err != nil {
+		t.Fatalf("expected allow after unblock, got %v", err)
+	}
+}
+
+func TestPersonalDenylist(t *testing.T) {
+	svc := NewService()
+	ctx := context.Background()
+
+	if _, err := svc.BlockFor("0xAlice", "0xSeller", "slow delivery"); err != nil {
+		t.Fatalf("BlockFor: %v", err)
+	}
+
+	// Blocked for the owner, not for anyone else.
+	if err := svc.CheckPayment(ctx, "0xalice", "0xseller"); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected ErrBlocked for owner, got %v", err)
+	}
+	if err := svc.CheckPayment(ctx, "0xbob", "0xseller"); err != nil {
+		t.Fatalf("expected allow for other payer, got %v", err)
+	}
+
+	entries := svc.ListFor("0xALICE")
+	if len(entries) != 1 || entries[0].Address != "0xseller" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	if !svc.UnblockFor("0xalice", "0xseller") {
+		t.Fatal("UnblockFor returned false")
+	}
+	if svc.UnblockFor("0xalice", "0xseller") {
+		t.Fatal("double unblock returned true")
+	}
+}
+
+func TestPersonalDenylistCap(t *testing.T) {
+	svc := NewService()
+	for i := 0; i < maxPersonalEntries; i++ {
+		if _, err := svc.BlockFor("0xalice", fmt.Sprintf("0xbad%d", i), ""); err != nil {
+			t.Fatalf("BlockFor %d: %v", i, err)
+		}
+	}
+	if _, err := svc.BlockFor("0xalice", "0xonemore", ""); !errors.Is(err, ErrListFull) {
+		t.Fatalf("expected ErrListFull, got %v", err)
+	}
+	// Re-blocking an existing address is not a new entry and still works.
+	if _, err := svc.BlockFor("0xalice", "0xbad0", "updated reason"); err != nil {
+		t.Fatalf("re-block: %v", err)
+	}
+}
+
+func TestImportGlobal(t *testing.T) {
+	svc := NewService()
+	ctx := context.Background()
+
+	svc.BlockGlobal("0xknown", "existing", "0xadmin")
+
+	added := svc.ImportGlobal([]string{"0xBad1", "0xbad2", "0xknown", "", "0xbad2"}, "feed import", "0xadmin")
+	if added != 2 {
+		t.Fatalf("expected 2 new addresses, got %d", added)
+	}
+	if err := svc.CheckPayment(ctx, "0xbuyer", "0xbad1"); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected imported address blocked, got %v", err)
+	}
+	if len(svc.ListGlobal()) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(svc.ListGlobal()))
+	}
+}
+
+func TestAuditTrail(t *testing.T) {
+	svc := NewService()
+
+	svc.BlockGlobal("0xbad", "phishing", "0xadmin")
+	svc.ImportGlobal([]string{"0xbad1", "0xbad2"}, "feed", "0xadmin")
+	svc.BlockFor("0xalice", "0xseller", "slow")
+	svc.UnblockGlobal("0xbad", "0xadmin")
+
+	records := svc.Audit(0)
+	if len(records) != 4 {
+		t.Fatalf("expected 4 records, got %d", len(records))
+	}
+	// Most recent first.
+	if records[0].Action != "unblock" || records[0].Scope != ScopeGlobal {
+		t.Fatalf("unexpected first record %+v", records[0])
+	}
+	if records[1].Action != "block" || records[1].Scope != "0xalice" {
+		t.Fatalf("unexpected second record %+v", records[1])
+	}
+	if records[2].Action != "import" || records[2].Count != 2 {
+		t.Fatalf("unexpected import record %+v", records[2])
+	}
+
+	limited := svc.Audit(2)
+	if len(limited) != 2 || limited[0].Action != "unblock" {
+		t.Fatalf("unexpected limited records %+v", limited)
+	}
+}
+
+// memStore is a Store backed by maps, standing in for Postgres.
+type memStore struct {
+	entries map[string]*Entry // scope+"/"+address
+	audit   []*AuditRecord
+}
+
+func newMemStore() *memStore {
+	return &memStore{entries: make(map[string]*Entry)}
+}
+
+func (m *memStore) SaveEntry(_ context.Context, entry *Entry) error {
+	cp := *entry
+	m.entries[entry.Scope+"/"+entry.Address] = &cp
+	return nil
+}
+
+func (m *memStore) DeleteEntry(_ context.Context, scope, address string) error {
+	delete(m.entries, scope+"/"+address)
+	return nil
+}
+
+func (m *memStore) ListEntries(_ context.Context) ([]*Entry, error) {
+	out := make([]*Entry, 0, len(m.entries))
+	for _, e := range m.entries {
+		cp := *e
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func (m *memStore) AppendAudit(_ context.Context, rec *AuditRecord) error {
+	cp := *rec
+	m.audit = append(m.audit, &cp)
+	return nil
+}
+
+func (m *memStore) ListAudit(_ context.Context, limit int) ([]*AuditRecord, error) {
+	// Newest first, like the Postgres store.
+	out := make([]*AuditRecord, 0, limit)
+	for i := len(m.audit) - 1; i >= 0 && len(out) < limit; i-- {
+		cp := *m.audit[i]
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func TestStorePersistenceRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+
+	svc := NewService().WithStore(store)
+	svc.BlockGlobal("0xbad", "phishing", "0xadmin")
+	svc.ImportGlobal([]string{"0xbad1", "0xbad2"}, "feed", "0xadmin")
+	svc.BlockFor("0xalice", "0xseller", "slow")
+	svc.BlockFor("0xalice", "0xgone", "")
+	svc.UnblockFor("0xalice", "0xgone")
+	svc.UnblockGlobal("0xbad2", "0xadmin")
+
+	// A fresh service hydrated from the same store sees the same state,
+	// as it would after a restart.
+	restarted := NewService().WithStore(store)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if err := restarted.CheckPayment(ctx, "0xbuyer", "0xbad"); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected global entry to survive restart, got %v", err)
+	}
+	if err := restarted.CheckPayment(ctx, "0xbuyer", "0xbad1"); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected imported entry to survive restart, got %v", err)
+	}
+	if err := restarted.CheckPayment(ctx, "0xalice", "0xseller"); !errors.Is(err, ErrBlocked) {
+		t.Fatalf("expected personal entry to survive restart, got %v", err)
+	}
+	if err := restarted.CheckPayment(ctx, "0xbuyer", "0xbad2"); err != nil {
+		t.Fatalf("expected unblocked address to stay unblocked, got %v", err)
+	}
+	if err := restarted.CheckPayment(ctx, "0xalice", "0xgone"); err != nil {
+		t.Fatalf("expected removed personal entry to stay removed, got %v", err)
+	}
+
+	records := restarted.Audit(0)
+	if len(records) != 6 {
+		t.Fatalf("expected 6 audit records after reload, got %d", len(records))
+	}
+	if records[0].Action != "unblock" || records[0].Address != "0xbad2" {
+		t.Fatalf("unexpected newest record %+v", records[0])
+	}
+}
+
+func TestLoadWithoutStore(t *testing.T) {
+	if err := NewService().Load(context.Background()); err != nil {
+		t.Fatalf("Load without store: %v", err)
+	}
+}
+
+func TestIsBlocked(t *testing.T) {
+	svc := NewService()
+	svc.BlockGlobal("0xbad", "phishing", "0xadmin")
+	svc.BlockFor("0xalice", "0xseller", "slow")
+
+	if entry, ok := svc.IsBlocked("0xanyone", "0xbad"); !ok || entry.Scope != ScopeGlobal {
+		t.Fatalf("expected global match, got %+v ok=%v", entry, ok)
+	}
+	if entry, ok := svc.IsBlocked("0xalice", "0xseller"); !ok || entry.Scope != "0xalice" {
+		t.Fatalf("expected personal match, got %+v ok=%v", entry, ok)
+	}
+	if _, ok := svc.IsBlocked("0xbob", "0xseller"); ok {
+		t.Fatal("expected no match for other payer")
+	}
+}
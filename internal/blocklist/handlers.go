@@ -0,0 +1,192 @@
+package blocklist
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportAddresses caps one bulk import request.
+const maxImportAddresses = 1000
+
+// Handler exposes the blocklist over HTTP: admin routes for the
+// global list plus per-agent routes for personal denylists.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates the blocklist HTTP handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterAdminRoutes sets up the global blocklist routes. The caller
+// is expected to wrap the group in admin auth.
+func (h *Handler) RegisterAdminRoutes(r *gin.RouterGroup) {
+	r.GET("/admin/blocklist", h.listGlobal)
+	r.POST("/admin/blocklist", h.blockGlobal)
+	r.DELETE("/admin/blocklist/:address", h.unblockGlobal)
+	r.POST("/admin/blocklist/import", h.importGlobal)
+	r.GET("/admin/blocklist/audit", h.auditTrail)
+}
+
+// BlockRequest is the body for adding a single blocklist entry.
+type BlockRequest struct {
+	Address string `json:"address" binding:"required"`
+	Reason  string `json:"reason"`
+}
+
+// ImportRequest is the body for bulk-importing known-bad addresses.
+type ImportRequest struct {
+	Addresses []string `json:"addresses" binding:"required"`
+	Reason    string   `json:"reason"`
+}
+
+func (h *Handler) listGlobal(c *gin.Context) {
+	entries := h.service.ListGlobal()
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"count":   len(entries),
+	})
+}
+
+func (h *Handler) blockGlobal(c *gin.Context) {
+	var req BlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "address is required",
+		})
+		return
+	}
+	if strings.TrimSpace(req.Address) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_address",
+			"message": "address must not be empty",
+		})
+		return
+	}
+
+	entry := h.service.BlockGlobal(req.Address, req.Reason, actorAddr(c))
+	c.JSON(http.StatusCreated, entry)
+}
+
+func (h *Handler) unblockGlobal(c *gin.Context) {
+	if !h.service.UnblockGlobal(c.Param("address"), actorAddr(c)) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Address is not on the blocklist",
+		})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) importGlobal(c *gin.Context) {
+	var req ImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "addresses is required",
+		})
+		return
+	}
+	if len(req.Addresses) == 0 || len(req.Addresses) > maxImportAddresses {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_addresses",
+			"message": "addresses must contain between 1 and 1000 entries",
+		})
+		return
+	}
+
+	added := h.service.ImportGlobal(req.Addresses, req.Reason, actorAddr(c))
+	c.JSON(http.StatusOK, gin.H{
+		"imported":  added,
+		"submitted": len(req.Addresses),
+	})
+}
+
+func (h *Handler) auditTrail(c *gin.Context) {
+	limit := 100
+	if v := c.Query("limit"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil && parsed > 0 && parsed <= maxAuditRecords {
+			limit = parsed
+		}
+	}
+
+	records := h.service.Audit(limit)
+	c.JSON(http.StatusOK, gin.H{
+		"records": records,
+		"count":   len(records),
+	})
+}
+
+// ListDenylist handles GET /agents/:address/blocklist
+func (h *Handler) ListDenylist(c *gin.Context) {
+	entries := h.service.ListFor(c.Param("address"))
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"count":   len(entries),
+	})
+}
+
+// AddDenylistEntry handles POST /agents/:address/blocklist
+func (h *Handler) AddDenylistEntry(c *gin.Context) {
+	owner := c.Param("address")
+
+	var req BlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "address is required",
+		})
+		return
+	}
+	if strings.TrimSpace(req.Address) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_address",
+			"message": "address must not be empty",
+		})
+		return
+	}
+
+	entry, err := h.service.BlockFor(owner, req.Address, req.Reason)
+	if err != nil {
+		if errors.Is(err, ErrListFull) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":   "denylist_full",
+				"message": "Personal denylist limit reached",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to update denylist",
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, entry)
+}
+
+// RemoveDenylistEntry handles DELETE /agents/:address/blocklist/:counterparty
+func (h *Handler) RemoveDenylistEntry(c *gin.Context) {
+	if !h.service.UnblockFor(c.Param("address"), c.Param("counterparty")) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Address is not on the denylist",
+		})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// actorAddr names the authenticated caller for the audit trail.
+func actorAddr(c *gin.Context) string {
+	if addr := c.GetString("authAgentAddr"); addr != "" {
+		return addr
+	}
+	return "admin"
+}
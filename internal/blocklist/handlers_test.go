@@ -0,0 +1,157 @@
+package blocklist
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(svc *Service) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := NewHandler(svc)
+	h.RegisterAdminRoutes(&r.RouterGroup)
+	r.GET("/agents/:address/blocklist", h.ListDenylist)
+	r.POST("/agents/:address/blocklist", h.AddDenylistEntry)
+	r.DELETE("/agents/:address/blocklist/:counterparty", h.RemoveDenylistEntry)
+	return r
+}
+
+func doJSON(r *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdminBlocklistCRUD(t *testing.T) {
+	svc := NewService()
+	r := newTestRouter(svc)
+
+	w := doJSON(r, "POST", "/admin/blocklist", BlockRequest{Address: "0xBad", Reason: "phishing"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("block: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doJSON(r, "POST", "/admin/blocklist", BlockRequest{Address: "   "})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("blank address: expected 400, got %d", w.Code)
+	}
+
+	w = doJSON(r, "GET", "/admin/blocklist", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("list: expected 200, got %d", w.Code)
+	}
+	var listResp struct {
+		Entries []Entry `json:"entries"`
+		Count   int     `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &listResp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if listResp.Count != 1 || listResp.Entries[0].Address != "0xbad" {
+		t.Fatalf("unexpected list %+v", listResp)
+	}
+
+	w = doJSON(r, "DELETE", "/admin/blocklist/0xbad", nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("unblock: expected 204, got %d", w.Code)
+	}
+	w = doJSON(r, "DELETE", "/admin/blocklist/0xbad", nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unblock missing: expected 404, got %d", w.Code)
+	}
+}
+
+func TestAdminBlocklistImport(t *testing.T) {
+	svc := NewService()
+	r := newTestRouter(svc)
+
+	w := doJSON(r, "POST", "/admin/blocklist/import", ImportRequest{
+		Addresses: []string{"0xbad1", "0xbad2", "0xbad1"},
+		Reason:    "known-bad feed",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("import: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Imported  int `json:"imported"`
+		Submitted int `json:"submitted"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Imported != 2 || resp.Submitted != 3 {
+		t.Fatalf("unexpected import result %+v", resp)
+	}
+
+	w = doJSON(r, "POST", "/admin/blocklist/import", ImportRequest{Addresses: []string{}})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("empty import: expected 400, got %d", w.Code)
+	}
+}
+
+func TestAdminBlocklistAudit(t *testing.T) {
+	svc := NewService()
+	r := newTestRouter(svc)
+
+	svc.BlockGlobal("0xbad", "phishing", "0xadmin")
+	svc.UnblockGlobal("0xbad", "0xadmin")
+
+	w := doJSON(r, "GET", "/admin/blocklist/audit?limit=1", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("audit: expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Records []AuditRecord `json:"records"`
+		Count   int           `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Count != 1 || resp.Records[0].Action != "unblock" {
+		t.Fatalf("unexpected audit %+v", resp)
+	}
+}
+
+func TestPersonalDenylistHandlers(t *testing.T) {
+	svc := NewService()
+	r := newTestRouter(svc)
+
+	w := doJSON(r, "POST", "/agents/0xalice/blocklist", BlockRequest{Address: "0xSeller", Reason: "slow"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("add: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doJSON(r, "GET", "/agents/0xalice/blocklist", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("list: expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Entries []Entry `json:"entries"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].Address != "0xseller" || resp.Entries[0].Scope != "0xalice" {
+		t.Fatalf("unexpected entries %+v", resp.Entries)
+	}
+
+	w = doJSON(r, "DELETE", "/agents/0xalice/blocklist/0xseller", nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("remove: expected 204, got %d", w.Code)
+	}
+	w = doJSON(r, "DELETE", "/agents/0xalice/blocklist/0xseller", nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("remove missing: expected 404, got %d", w.Code)
+	}
+}
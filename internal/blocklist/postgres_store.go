@@ -0,0 +1,116 @@
+package blocklist
+
+import (
+	"context"
+	"database/sql"
+)
+
+// PostgresStore persists blocklist entries and audit records in PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// Compile-time check.
+var _ Store = (*PostgresStore)(nil)
+
+// NewPostgresStore creates a new Postgres-backed blocklist store.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Migrate creates the blocklist tables.
+func (p *PostgresStore) Migrate(ctx context.Context) error {
+	_, err := p.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS blocklist_entries (
+			scope      VARCHAR(42) NOT NULL,
+			address    VARCHAR(42) NOT NULL,
+			reason     TEXT NOT NULL DEFAULT '',
+			added_by   VARCHAR(64) NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (scope, address)
+		);
+		CREATE TABLE IF NOT EXISTS blocklist_audit (
+			id         BIGSERIAL PRIMARY KEY,
+			action     VARCHAR(16) NOT NULL,
+			address    VARCHAR(42) NOT NULL DEFAULT '',
+			scope      VARCHAR(42) NOT NULL,
+			actor      VARCHAR(64) NOT NULL DEFAULT '',
+			reason     TEXT NOT NULL DEFAULT '',
+			count      INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE INDEX IF NOT EXISTS idx_blocklist_audit_created ON blocklist_audit(created_at DESC);
+	`)
+	return err
+}
+
+func (p *PostgresStore) SaveEntry(ctx context.Context, entry *Entry) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO blocklist_entries (scope, address, reason, added_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (scope, address) DO UPDATE SET
+			reason     = EXCLUDED.reason,
+			added_by   = EXCLUDED.added_by,
+			created_at = EXCLUDED.created_at
+	`, entry.Scope, entry.Address, entry.Reason, entry.AddedBy, entry.CreatedAt)
+	return err
+}
+
+func (p *PostgresStore) DeleteEntry(ctx context.Context, scope, address string) error {
+	_, err := p.db.ExecContext(ctx, `
+		DELETE FROM blocklist_entries WHERE scope = $1 AND address = $2
+	`, scope, address)
+	return err
+}
+
+func (p *PostgresStore) ListEntries(ctx context.Context) ([]*Entry, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT scope, address, reason, added_by, created_at
+		FROM blocklist_entries
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var entries []*Entry
+	for rows.Next() {
+		e := &Entry{}
+		if err := rows.Scan(&e.Scope, &e.Address, &e.Reason, &e.AddedBy, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (p *PostgresStore) AppendAudit(ctx context.Context, rec *AuditRecord) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO blocklist_audit (action, address, scope, actor, reason, count, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, rec.Action, rec.Address, rec.Scope, rec.Actor, rec.Reason, rec.Count, rec.CreatedAt)
+	return err
+}
+
+func (p *PostgresStore) ListAudit(ctx context.Context, limit int) ([]*AuditRecord, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT action, address, scope, actor, reason, count, created_at
+		FROM blocklist_audit
+		ORDER BY id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var records []*AuditRecord
+	for rows.Next() {
+		rec := &AuditRecord{}
+		if err := rows.Scan(&rec.Action, &rec.Address, &rec.Scope, &rec.Actor, &rec.Reason, &rec.Count, &rec.CreatedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
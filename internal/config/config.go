@@ -29,21 +29,61 @@ type Config struct {
 
 	// Blockchain settings
 	RPCURL        string
+	WSURL         string // optional WebSocket RPC endpoint for deposit log subscriptions (WS_URL)
 	ChainID       int64
 	PrivateKey    string `json:"-"` // Hex-encoded, no 0x prefix — excluded from serialization
 	WalletAddress string
 	USDCContract  string
 	PaymasterURL  string // Circle Paymaster (future)
+	PriceFeedURL  string // Price feed for prediction oracles (optional, from PRICE_FEED_URL env var)
 
 	// Deposit watcher
 	DepositWatcherEnabled bool   // Enable on-chain deposit watcher (requires RPC_URL)
 	DepositWatcherStart   uint64 // Start block for deposit scanning (0 = latest)
 
-	// Outbound payouts (USDC transfers signed by PRIVATE_KEY).
-	// Requires RPC_URL + CHAIN_ID + PRIVATE_KEY + USDC_CONTRACT to be valid.
+	// Multi-chain deposit watching (from DEPOSIT_WATCHER_CHAINS env var).
+	// Empty = single-chain watching using the top-level RPC_URL/USDC_CONTRACT.
+	DepositChains []DepositChain
+
+	// Outbound payouts (USDC transfers signed by the wallet backend).
+	// Requires RPC_URL + CHAIN_ID + USDC_CONTRACT to be valid, plus either
+	// PRIVATE_KEY (local backend) or a configured KMS/remote backend.
 	PayoutsEnabled      bool
 	PayoutConfirmations uint64 // blocks before a tx is considered final (default 12)
 
+	// Wallet signing backend: "local" (PRIVATE_KEY in memory, default),
+	// "aws-kms", "gcp-kms", or "remote". Non-local backends keep the hot
+	// key out of process memory.
+	WalletBackend      string
+	AWSKMSKeyID        string // KMS key ID/ARN/alias (aws-kms backend)
+	AWSKMSRegion       string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string `json:"-"` // excluded from serialization
+	AWSSessionToken    string `json:"-"`
+	GCPKMSKey          string // full cryptoKeyVersion resource name (gcp-kms backend)
+	GCPKMSToken        string `json:"-"` // static OAuth token; empty = metadata server
+	RemoteSignerURL    string // signer daemon base URL (remote backend)
+	RemoteSignerToken  string `json:"-"` // bearer token for the signer daemon
+
+	// Safe multisig routing (from SAFE_ADDRESS etc.). When SafeAddress is
+	// set, withdrawals at or above MultisigMinWithdrawal and all treasury
+	// moves go through a proposal/approval flow instead of the hot wallet
+	// submitting directly.
+	SafeAddress           string
+	MultisigApprovers     []string // addresses allowed to approve/reject proposals
+	MultisigThreshold     int      // approvals required before execution (default 2)
+	MultisigMinWithdrawal string   // decimal USDC; empty = no withdrawal routing
+
+	// ETHUSDPrice converts gas costs to USD for paymaster sponsorship
+	// estimates (from ETH_USD_PRICE). Zero disables the estimate.
+	ETHUSDPrice float64
+
+	// Paymaster spend guard (from PAYMASTER_DAILY_CAP_ETH etc.). Alerts
+	// and throttles non-critical sponsorships near the daily cap or when
+	// the paymaster balance runs low; zero disables each check.
+	PaymasterDailyCapETH   float64
+	PaymasterMinBalanceETH float64
+
 	// Payment settings
 	DefaultPrice string // Default price in USDC (e.g., "0.001")
 	MinPayment   string
@@ -117,6 +157,16 @@ type Config struct {
 	WALRetention    time.Duration // How long to keep processed WAL entries (default 24h)
 	OutboxRetention time.Duration // How long to keep published outbox entries (default 24h)
 
+	// Natural-language search (/v1/search). Provider "local" keeps the
+	// rule-based recommendations; "openai" or "anthropic" upgrade them
+	// to real model output (requires LLM_API_KEY).
+	LLMProvider         string
+	LLMAPIKey           string        `json:"-"` // excluded from serialization
+	LLMModel            string        // empty = provider default
+	LLMBaseURL          string        // override for proxies and self-hosted gateways
+	LLMCacheTTL         time.Duration // recommendation cache TTL (0 = no caching)
+	LLMDailyTokenBudget int           // max LLM tokens per UTC day (0 = unlimited)
+
 	// Stripe billing
 	StripeSecretKey         string // Stripe secret key (sk_test_... or sk_live_...)
 	StripeWebhookSecret     string // Stripe webhook signing secret (whsec_...)
@@ -125,6 +175,29 @@ type Config struct {
 	StripePriceEnterpriseID string // Stripe Price ID for Enterprise plan
 }
 
+// DepositChain configures deposit watching for one chain.
+type DepositChain struct {
+	Name           string // chain name, e.g. "base", "base-sepolia", "arbitrum"
+	ChainID        int64  // 0 when the chain is not one of the known defaults
+	RPCURL         string
+	WSURL          string // optional WebSocket endpoint for log subscriptions
+	USDCContract   string
+	DepositAddress string // platform deposit address on this chain (empty = WALLET_ADDRESS)
+	StartBlock     uint64 // fallback start block if no checkpoint exists
+}
+
+// knownDepositChains maps chain names accepted in DEPOSIT_WATCHER_CHAINS to
+// their chain ID and native USDC contract. Other names are allowed but must
+// supply USDC_CONTRACT_<CHAIN> explicitly.
+var knownDepositChains = map[string]struct {
+	chainID      int64
+	usdcContract string
+}{
+	"base":         {8453, "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"},
+	"base-sepolia": {84532, DefaultUSDCContract},
+	"arbitrum":     {42161, "0xaf88d065e77c8cC2239327C5EDb3A432268e5831"},
+}
+
 // Base Sepolia defaults
 const (
 	DefaultRPCURL       = "https://sepolia.base.org"
@@ -171,11 +244,13 @@ func Load() (*Config, error) {
 		DatabaseURL:   os.Getenv("DATABASE_URL"), // Optional, uses in-memory if not set
 		RedisURL:      os.Getenv("REDIS_URL"),    // Optional, enables distributed state
 		RPCURL:        getEnv("RPC_URL", DefaultRPCURL),
+		WSURL:         os.Getenv("WS_URL"),
 		ChainID:       getEnvInt64("CHAIN_ID", DefaultChainID),
 		PrivateKey:    os.Getenv("PRIVATE_KEY"), // Required, no default
 		WalletAddress: os.Getenv("WALLET_ADDRESS"),
 		USDCContract:  getEnv("USDC_CONTRACT", DefaultUSDCContract),
 		PaymasterURL:  os.Getenv("PAYMASTER_URL"),
+		PriceFeedURL:  os.Getenv("PRICE_FEED_URL"),
 		DefaultPrice:  getEnv("DEFAULT_PRICE", DefaultPrice),
 		MinPayment:    getEnv("MIN_PAYMENT", "0.0001"),
 		MaxPayment:    getEnv("MAX_PAYMENT", "1000"),
@@ -188,15 +263,33 @@ func Load() (*Config, error) {
 			}
 			return int(rpm)
 		}(),
-		PlatformAddress:       getEnv("PLATFORM_ADDRESS", "0x0000000000000000000000000000000000000001"),
-		ReputationHMACSecret:  os.Getenv("REPUTATION_HMAC_SECRET"),
-		AdminSecret:           os.Getenv("ADMIN_SECRET"),
-		DepositWatcherEnabled: os.Getenv("DEPOSIT_WATCHER_ENABLED") == "true",
-		DepositWatcherStart:   getEnvUint64("DEPOSIT_WATCHER_START_BLOCK", 0),
-		PayoutsEnabled:        os.Getenv("PAYOUTS_ENABLED") == "true",
-		PayoutConfirmations:   getEnvUint64("PAYOUT_CONFIRMATIONS", 12),
-		SessionKeyMode:        getEnv("SESSION_KEY_MODE", "demo"),
-		ReceiptHMACSecret:     os.Getenv("RECEIPT_HMAC_SECRET"),
+		PlatformAddress:        getEnv("PLATFORM_ADDRESS", "0x0000000000000000000000000000000000000001"),
+		ReputationHMACSecret:   os.Getenv("REPUTATION_HMAC_SECRET"),
+		AdminSecret:            os.Getenv("ADMIN_SECRET"),
+		DepositWatcherEnabled:  os.Getenv("DEPOSIT_WATCHER_ENABLED") == "true",
+		DepositWatcherStart:    getEnvUint64("DEPOSIT_WATCHER_START_BLOCK", 0),
+		DepositChains:          parseDepositChains(),
+		PayoutsEnabled:         os.Getenv("PAYOUTS_ENABLED") == "true",
+		PayoutConfirmations:    getEnvUint64("PAYOUT_CONFIRMATIONS", 12),
+		WalletBackend:          getEnv("WALLET_BACKEND", "local"),
+		AWSKMSKeyID:            os.Getenv("AWS_KMS_KEY_ID"),
+		AWSKMSRegion:           os.Getenv("AWS_KMS_REGION"),
+		AWSAccessKeyID:         os.Getenv("AWS_ACCESS_KEY_ID"),
+		AWSSecretAccessKey:     os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		AWSSessionToken:        os.Getenv("AWS_SESSION_TOKEN"),
+		GCPKMSKey:              os.Getenv("GCP_KMS_KEY"),
+		GCPKMSToken:            os.Getenv("GCP_KMS_TOKEN"),
+		RemoteSignerURL:        os.Getenv("REMOTE_SIGNER_URL"),
+		RemoteSignerToken:      os.Getenv("REMOTE_SIGNER_TOKEN"),
+		SafeAddress:            os.Getenv("SAFE_ADDRESS"),
+		MultisigApprovers:      parseCSV(os.Getenv("MULTISIG_APPROVERS")),
+		MultisigThreshold:      int(getEnvInt64("MULTISIG_THRESHOLD", 2)),
+		MultisigMinWithdrawal:  os.Getenv("MULTISIG_MIN_WITHDRAWAL"),
+		ETHUSDPrice:            getEnvFloat64("ETH_USD_PRICE", 0),
+		PaymasterDailyCapETH:   getEnvFloat64("PAYMASTER_DAILY_CAP_ETH", 0),
+		PaymasterMinBalanceETH: getEnvFloat64("PAYMASTER_MIN_BALANCE_ETH", 0),
+		SessionKeyMode:         getEnv("SESSION_KEY_MODE", "demo"),
+		ReceiptHMACSecret:      os.Getenv("RECEIPT_HMAC_SECRET"),
 
 		DBMaxOpenConns:     int(getEnvInt64("POSTGRES_MAX_OPEN_CONNS", int64(DefaultDBMaxOpenConns))),
 		DBMaxIdleConns:     int(getEnvInt64("POSTGRES_MAX_IDLE_CONNS", int64(DefaultDBMaxIdleConns))),
@@ -240,6 +333,13 @@ func Load() (*Config, error) {
 		WALRetention:    getEnvDuration("WAL_RETENTION", 24*time.Hour),
 		OutboxRetention: getEnvDuration("OUTBOX_RETENTION", 24*time.Hour),
 
+		LLMProvider:         getEnv("LLM_PROVIDER", "local"),
+		LLMAPIKey:           os.Getenv("LLM_API_KEY"),
+		LLMModel:            os.Getenv("LLM_MODEL"),
+		LLMBaseURL:          os.Getenv("LLM_BASE_URL"),
+		LLMCacheTTL:         getEnvDuration("LLM_CACHE_TTL", 5*time.Minute),
+		LLMDailyTokenBudget: int(getEnvInt64("LLM_DAILY_TOKEN_BUDGET", 200000)),
+
 		StripeSecretKey:         os.Getenv("STRIPE_SECRET_KEY"),
 		StripeWebhookSecret:     os.Getenv("STRIPE_WEBHOOK_SECRET"),
 		StripePriceStarterID:    os.Getenv("STRIPE_PRICE_STARTER"),
@@ -256,20 +356,40 @@ func Load() (*Config, error) {
 
 // Validate checks that all required configuration is present
 func (c *Config) Validate() error {
-	if c.PrivateKey == "" {
-		return fmt.Errorf("PRIVATE_KEY is required")
-	}
+	switch c.WalletBackend {
+	case "", "local":
+		if c.PrivateKey == "" {
+			return fmt.Errorf("PRIVATE_KEY is required")
+		}
 
-	// Allow both with and without 0x prefix
-	key := c.PrivateKey
-	if len(key) == 66 && key[:2] == "0x" {
-		key = key[2:]
-	}
-	if len(key) != 64 {
-		return fmt.Errorf("PRIVATE_KEY must be 64 hex characters (with or without 0x prefix)")
-	}
-	if _, err := crypto.HexToECDSA(key); err != nil {
-		return fmt.Errorf("PRIVATE_KEY is not a valid secp256k1 private key: %w", err)
+		// Allow both with and without 0x prefix
+		key := c.PrivateKey
+		if len(key) == 66 && key[:2] == "0x" {
+			key = key[2:]
+		}
+		if len(key) != 64 {
+			return fmt.Errorf("PRIVATE_KEY must be 64 hex characters (with or without 0x prefix)")
+		}
+		if _, err := crypto.HexToECDSA(key); err != nil {
+			return fmt.Errorf("PRIVATE_KEY is not a valid secp256k1 private key: %w", err)
+		}
+	case "aws-kms":
+		if c.AWSKMSKeyID == "" || c.AWSKMSRegion == "" {
+			return fmt.Errorf("AWS_KMS_KEY_ID and AWS_KMS_REGION are required when WALLET_BACKEND=aws-kms")
+		}
+		if c.AWSAccessKeyID == "" || c.AWSSecretAccessKey == "" {
+			return fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required when WALLET_BACKEND=aws-kms")
+		}
+	case "gcp-kms":
+		if c.GCPKMSKey == "" {
+			return fmt.Errorf("GCP_KMS_KEY is required when WALLET_BACKEND=gcp-kms")
+		}
+	case "remote":
+		if c.RemoteSignerURL == "" {
+			return fmt.Errorf("REMOTE_SIGNER_URL is required when WALLET_BACKEND=remote")
+		}
+	default:
+		return fmt.Errorf("WALLET_BACKEND must be \"local\", \"aws-kms\", \"gcp-kms\", or \"remote\", got %q", c.WalletBackend)
 	}
 
 	if c.RPCURL == "" {
@@ -317,6 +437,17 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("PLATFORM_ADDRESS must be set to a real address in production")
 	}
 
+	// LLM provider validation
+	switch c.LLMProvider {
+	case "", "local":
+	case "openai", "anthropic":
+		if c.LLMAPIKey == "" {
+			return fmt.Errorf("LLM_API_KEY is required when LLM_PROVIDER=%s", c.LLMProvider)
+		}
+	default:
+		return fmt.Errorf("LLM_PROVIDER must be \"local\", \"openai\", or \"anthropic\", got %q", c.LLMProvider)
+	}
+
 	// Event bus backend validation
 	if c.EventBusBackend != "" && c.EventBusBackend != "memory" && c.EventBusBackend != "kafka" {
 		return fmt.Errorf("EVENTBUS_BACKEND must be \"memory\" or \"kafka\", got %q", c.EventBusBackend)
@@ -325,6 +456,27 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("KAFKA_BROKERS is required when EVENTBUS_BACKEND=kafka")
 	}
 
+	// Every configured deposit chain needs an RPC endpoint and a USDC contract
+	for _, ch := range c.DepositChains {
+		suffix := depositChainEnvSuffix(ch.Name)
+		if ch.RPCURL == "" {
+			return fmt.Errorf("RPC_URL_%s is required for deposit chain %q", suffix, ch.Name)
+		}
+		if ch.USDCContract == "" {
+			return fmt.Errorf("USDC_CONTRACT_%s is required for deposit chain %q", suffix, ch.Name)
+		}
+	}
+
+	// Safe multisig routing needs a viable approver set
+	if c.SafeAddress != "" {
+		if len(c.MultisigApprovers) == 0 {
+			return fmt.Errorf("MULTISIG_APPROVERS is required when SAFE_ADDRESS is set")
+		}
+		if c.MultisigThreshold < 1 || c.MultisigThreshold > len(c.MultisigApprovers) {
+			return fmt.Errorf("MULTISIG_THRESHOLD must be between 1 and the number of approvers, got %d", c.MultisigThreshold)
+		}
+	}
+
 	// Reject production database connections without SSL
 	if c.IsProduction() && c.DatabaseURL != "" {
 		if !strings.Contains(c.DatabaseURL, "sslmode=require") &&
@@ -365,6 +517,15 @@ func getEnvInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getEnvFloat64(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
 func getEnvUint64(key string, defaultValue uint64) uint64 {
 	if value := os.Getenv(key); value != "" {
 		if u, err := strconv.ParseUint(value, 10, 64); err == nil {
@@ -383,6 +544,40 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+// depositChainEnvSuffix converts a chain name to its env-var suffix,
+// e.g. "base-sepolia" → "BASE_SEPOLIA".
+func depositChainEnvSuffix(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// parseDepositChains reads DEPOSIT_WATCHER_CHAINS (e.g. "base,arbitrum") plus
+// the per-chain RPC_URL_<CHAIN>, WS_URL_<CHAIN>, USDC_CONTRACT_<CHAIN>,
+// DEPOSIT_ADDRESS_<CHAIN>, and DEPOSIT_WATCHER_START_BLOCK_<CHAIN> variables. Known chains default their
+// chain ID and USDC contract; the deposit address defaults to WALLET_ADDRESS.
+func parseDepositChains() []DepositChain {
+	var chains []DepositChain
+	for _, name := range parseCSV(os.Getenv("DEPOSIT_WATCHER_CHAINS")) {
+		name = strings.ToLower(name)
+		suffix := depositChainEnvSuffix(name)
+		ch := DepositChain{
+			Name:           name,
+			RPCURL:         os.Getenv("RPC_URL_" + suffix),
+			WSURL:          os.Getenv("WS_URL_" + suffix),
+			USDCContract:   os.Getenv("USDC_CONTRACT_" + suffix),
+			DepositAddress: os.Getenv("DEPOSIT_ADDRESS_" + suffix),
+			StartBlock:     getEnvUint64("DEPOSIT_WATCHER_START_BLOCK_"+suffix, 0),
+		}
+		if known, ok := knownDepositChains[name]; ok {
+			ch.ChainID = known.chainID
+			if ch.USDCContract == "" {
+				ch.USDCContract = known.usdcContract
+			}
+		}
+		chains = append(chains, ch)
+	}
+	return chains
+}
+
 func parseCSV(s string) []string {
 	if s == "" {
 		return nil
@@ -0,0 +1,506 @@
+package contracts
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AgreementHandler provides HTTP endpoints for service agreements.
+type AgreementHandler struct {
+	service *AgreementService
+}
+
+// NewAgreementHandler creates a new agreement handler.
+func NewAgreementHandler(service *AgreementService) *AgreementHandler {
+	return &AgreementHandler{service: service}
+}
+
+// RegisterRoutes sets up auth-required agreement routes. The acting agent
+// is taken from the authenticated address, so ownership middleware is not
+// needed — party checks happen in the service.
+func (h *AgreementHandler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/agreements", h.Propose)
+	r.GET("/agreements", h.ListMine)
+	r.GET("/agreements/:id", h.Get)
+	r.POST("/agreements/:id/accept", h.Accept)
+	r.POST("/agreements/:id/reject", h.Reject)
+	r.POST("/agreements/:id/terminate", h.Terminate)
+	r.POST("/agreements/:id/pause", h.Pause)
+	r.POST("/agreements/:id/resume", h.Resume)
+	r.POST("/agreements/:id/calls", h.RecordCall)
+	r.POST("/agreements/:id/renewals", h.ProposeRenewal)
+	r.POST("/agreements/:id/renewals/accept", h.AcceptRenewal)
+	r.POST("/agreements/:id/renewals/decline", h.DeclineRenewal)
+	r.POST("/agreements/:id/usage", h.ReportUsage)
+	r.PUT("/agreements/:id/usage-cap", h.SetUsageCap)
+	r.POST("/agreements/:id/amendments", h.ProposeAmendment)
+	r.POST("/agreements/:id/amendments/accept", h.AcceptAmendment)
+	r.POST("/agreements/:id/amendments/decline", h.DeclineAmendment)
+	r.POST("/agreements/:id/packs", h.ProposePack)
+	r.POST("/agreements/:id/packs/accept", h.AcceptPack)
+	r.POST("/agreements/:id/packs/decline", h.DeclinePack)
+	r.POST("/agreements/:id/disputes", h.RaiseDispute)
+	r.POST("/agreements/:id/disputes/evidence", h.SubmitDisputeEvidence)
+	r.POST("/agreements/:id/disputes/arbitrator", h.AssignDisputeArbitrator)
+	r.POST("/agreements/:id/disputes/resolve", h.ResolveDispute)
+}
+
+// Propose handles POST /v1/agreements
+func (h *AgreementHandler) Propose(c *gin.Context) {
+	var req ProposeAgreementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "buyerAddr, sellerAddr, serviceName, pricePerCall, and termDays are required",
+		})
+		return
+	}
+
+	a, err := h.service.Propose(c.Request.Context(), c.GetString("authAgentAddr"), req)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, a)
+}
+
+// ListMine handles GET /v1/agreements
+func (h *AgreementHandler) ListMine(c *gin.Context) {
+	list, err := h.service.ListAgreements(c.Request.Context(), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"agreements": list, "count": len(list)})
+}
+
+// Get handles GET /v1/agreements/:id
+func (h *AgreementHandler) Get(c *gin.Context) {
+	a, err := h.service.GetAgreement(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// Accept handles POST /v1/agreements/:id/accept
+func (h *AgreementHandler) Accept(c *gin.Context) {
+	a, err := h.service.Accept(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// Reject handles POST /v1/agreements/:id/reject
+func (h *AgreementHandler) Reject(c *gin.Context) {
+	a, err := h.service.Reject(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// Terminate handles POST /v1/agreements/:id/terminate
+func (h *AgreementHandler) Terminate(c *gin.Context) {
+	a, err := h.service.Terminate(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// PauseAgreementRequest announces a maintenance window.
+type PauseAgreementRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Pause handles POST /v1/agreements/:id/pause
+func (h *AgreementHandler) Pause(c *gin.Context) {
+	var req PauseAgreementRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_request",
+				"message": "Invalid request body",
+			})
+			return
+		}
+	}
+
+	a, err := h.service.Pause(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), req.Reason)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// Resume handles POST /v1/agreements/:id/resume
+func (h *AgreementHandler) Resume(c *gin.Context) {
+	a, err := h.service.Resume(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// RecordCallRequest reports one completed call under an agreement.
+type RecordCallRequest struct {
+	Success   *bool   `json:"success" binding:"required"`
+	LatencyMs float64 `json:"latencyMs"`
+}
+
+// RecordCall handles POST /v1/agreements/:id/calls
+func (h *AgreementHandler) RecordCall(c *gin.Context) {
+	var req RecordCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "success is required",
+		})
+		return
+	}
+
+	a, err := h.service.RecordCall(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), *req.Success, req.LatencyMs)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// ProposeRenewalRequest extends an agreement, optionally adjusting terms.
+type ProposeRenewalRequest struct {
+	ExtendDays   int    `json:"extendDays" binding:"required"`
+	PricePerCall string `json:"pricePerCall"`
+	Budget       string `json:"budget"`
+}
+
+// ProposeRenewal handles POST /v1/agreements/:id/renewals
+func (h *AgreementHandler) ProposeRenewal(c *gin.Context) {
+	var req ProposeRenewalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "extendDays is required",
+		})
+		return
+	}
+
+	a, err := h.service.ProposeRenewal(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), req.ExtendDays, req.PricePerCall, req.Budget)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// AcceptRenewal handles POST /v1/agreements/:id/renewals/accept
+func (h *AgreementHandler) AcceptRenewal(c *gin.Context) {
+	a, err := h.service.AcceptRenewal(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// DeclineRenewal handles POST /v1/agreements/:id/renewals/decline
+func (h *AgreementHandler) DeclineRenewal(c *gin.Context) {
+	a, err := h.service.DeclineRenewal(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// ReportUsageRequest reports consumed units under a metered agreement.
+type ReportUsageRequest struct {
+	Units int64 `json:"units" binding:"required"`
+}
+
+// ReportUsage handles POST /v1/agreements/:id/usage
+func (h *AgreementHandler) ReportUsage(c *gin.Context) {
+	var req ReportUsageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "units is required",
+		})
+		return
+	}
+
+	a, err := h.service.ReportUsage(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), req.Units)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// SetUsageCapRequest adjusts the buyer's per-period unit cap.
+type SetUsageCapRequest struct {
+	UnitCap *int64 `json:"unitCap" binding:"required"`
+}
+
+// SetUsageCap handles PUT /v1/agreements/:id/usage-cap
+func (h *AgreementHandler) SetUsageCap(c *gin.Context) {
+	var req SetUsageCapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "unitCap is required",
+		})
+		return
+	}
+
+	a, err := h.service.SetUsageCap(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), *req.UnitCap)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// ProposeAmendmentRequest changes agreement terms mid-contract.
+type ProposeAmendmentRequest struct {
+	PricePerCall   string   `json:"pricePerCall"`
+	Budget         string   `json:"budget"`
+	MinSuccessRate *float64 `json:"minSuccessRate"`
+	WindowSize     int      `json:"windowSize"`
+	MaxLatencyMs   *float64 `json:"maxLatencyMs"`
+	LatencyPenalty string   `json:"latencyPenalty"`
+}
+
+// ProposeAmendment handles POST /v1/agreements/:id/amendments
+func (h *AgreementHandler) ProposeAmendment(c *gin.Context) {
+	var req ProposeAmendmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	a, err := h.service.ProposeAmendment(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), Amendment{
+			PricePerCall:   req.PricePerCall,
+			Budget:         req.Budget,
+			MinSuccessRate: req.MinSuccessRate,
+			WindowSize:     req.WindowSize,
+			MaxLatencyMs:   req.MaxLatencyMs,
+			LatencyPenalty: req.LatencyPenalty,
+		})
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// AcceptAmendment handles POST /v1/agreements/:id/amendments/accept
+func (h *AgreementHandler) AcceptAmendment(c *gin.Context) {
+	a, err := h.service.AcceptAmendment(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// DeclineAmendment handles POST /v1/agreements/:id/amendments/decline
+func (h *AgreementHandler) DeclineAmendment(c *gin.Context) {
+	a, err := h.service.DeclineAmendment(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// ProposePackRequest prepays for a block of calls at a discounted rate.
+type ProposePackRequest struct {
+	Calls        int    `json:"calls" binding:"required"`
+	PricePerCall string `json:"pricePerCall" binding:"required"`
+}
+
+// ProposePack handles POST /v1/agreements/:id/packs
+func (h *AgreementHandler) ProposePack(c *gin.Context) {
+	var req ProposePackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "calls and pricePerCall are required",
+		})
+		return
+	}
+
+	a, err := h.service.ProposePack(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), req.Calls, req.PricePerCall)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// AcceptPack handles POST /v1/agreements/:id/packs/accept
+func (h *AgreementHandler) AcceptPack(c *gin.Context) {
+	a, err := h.service.AcceptPack(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// DeclinePack handles POST /v1/agreements/:id/packs/decline
+func (h *AgreementHandler) DeclinePack(c *gin.Context) {
+	a, err := h.service.DeclinePack(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// RaiseDisputeRequest opens a dispute over recorded calls or a penalty.
+type RaiseDisputeRequest struct {
+	Subject string `json:"subject" binding:"required"`
+	Reason  string `json:"reason" binding:"required"`
+}
+
+// RaiseDispute handles POST /v1/agreements/:id/disputes
+func (h *AgreementHandler) RaiseDispute(c *gin.Context) {
+	var req RaiseDisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "subject and reason are required",
+		})
+		return
+	}
+
+	a, err := h.service.RaiseDispute(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), req.Subject, req.Reason)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, a)
+}
+
+// DisputeEvidenceRequest submits evidence to the open dispute.
+type DisputeEvidenceRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// SubmitDisputeEvidence handles POST /v1/agreements/:id/disputes/evidence
+func (h *AgreementHandler) SubmitDisputeEvidence(c *gin.Context) {
+	var req DisputeEvidenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "content is required",
+		})
+		return
+	}
+
+	a, err := h.service.SubmitDisputeEvidence(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), req.Content)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// AssignArbitratorRequest assigns a neutral arbitrator to the open dispute.
+type AssignArbitratorRequest struct {
+	ArbitratorAddr string `json:"arbitratorAddr" binding:"required"`
+}
+
+// AssignDisputeArbitrator handles POST /v1/agreements/:id/disputes/arbitrator
+func (h *AgreementHandler) AssignDisputeArbitrator(c *gin.Context) {
+	var req AssignArbitratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "arbitratorAddr is required",
+		})
+		return
+	}
+
+	a, err := h.service.AssignDisputeArbitrator(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), req.ArbitratorAddr)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// ResolveDisputeRequest is the arbitrator's resolution.
+type ResolveDisputeRequest struct {
+	Resolution string `json:"resolution" binding:"required"` // refund_buyer, credit_seller, or dismissed
+	Amount     string `json:"amount"`                        // Required unless dismissed
+	Note       string `json:"note"`
+}
+
+// ResolveDispute handles POST /v1/agreements/:id/disputes/resolve
+func (h *AgreementHandler) ResolveDispute(c *gin.Context) {
+	var req ResolveDisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "resolution is required",
+		})
+		return
+	}
+
+	a, err := h.service.ResolveDispute(c.Request.Context(), c.Param("id"),
+		c.GetString("authAgentAddr"), req.Resolution, req.Amount, req.Note)
+	if err != nil {
+		h.agreementError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+func (h *AgreementHandler) agreementError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	code := "agreement_failed"
+	switch {
+	case errors.Is(err, ErrAgreementNotFound):
+		status, code = http.StatusNotFound, "not_found"
+	case errors.Is(err, ErrNotParty), errors.Is(err, ErrConsentRequired), errors.Is(err, ErrNotArbitrator):
+		status, code = http.StatusForbidden, "not_authorized"
+	case errors.Is(err, ErrInvalidAgreement):
+		status, code = http.StatusBadRequest, "validation_error"
+	case errors.Is(err, ErrAgreementNotActive), errors.Is(err, ErrAgreementExpired),
+		errors.Is(err, ErrRenewalPending), errors.Is(err, ErrNoPendingRenewal),
+		errors.Is(err, ErrAmendmentPending), errors.Is(err, ErrNoPendingAmendment),
+		errors.Is(err, ErrBudgetExhausted), errors.Is(err, ErrNotMetered),
+		errors.Is(err, ErrUsageCapExceeded), errors.Is(err, ErrAgreementPaused),
+		errors.Is(err, ErrPauseExhausted), errors.Is(err, ErrDisputeOpen),
+		errors.Is(err, ErrNoOpenDispute), errors.Is(err, ErrPackPending),
+		errors.Is(err, ErrNoPendingPack):
+		status, code = http.StatusConflict, "invalid_state"
+	}
+	c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Agreement operation failed")})
+}
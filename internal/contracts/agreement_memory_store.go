@@ -0,0 +1,129 @@
+package contracts
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// AgreementMemoryStore is an in-memory agreement store for demo/development.
+type AgreementMemoryStore struct {
+	agreements map[string]*Agreement
+	mu         sync.RWMutex
+}
+
+// NewAgreementMemoryStore creates a new in-memory agreement store.
+func NewAgreementMemoryStore() *AgreementMemoryStore {
+	return &AgreementMemoryStore{
+		agreements: make(map[string]*Agreement),
+	}
+}
+
+func (m *AgreementMemoryStore) CreateAgreement(ctx context.Context, a *Agreement) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.agreements[a.ID] = deepCopyAgreement(a)
+	return nil
+}
+
+func (m *AgreementMemoryStore) GetAgreement(ctx context.Context, id string) (*Agreement, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	a, ok := m.agreements[id]
+	if !ok {
+		return nil, ErrAgreementNotFound
+	}
+	return deepCopyAgreement(a), nil
+}
+
+func (m *AgreementMemoryStore) UpdateAgreement(ctx context.Context, a *Agreement) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.agreements[a.ID]; !ok {
+		return ErrAgreementNotFound
+	}
+	m.agreements[a.ID] = deepCopyAgreement(a)
+	return nil
+}
+
+func (m *AgreementMemoryStore) ListAgreementsByAgent(ctx context.Context, addr string) ([]*Agreement, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []*Agreement
+	for _, a := range m.agreements {
+		if a.BuyerAddr == addr || a.SellerAddr == addr {
+			out = append(out, deepCopyAgreement(a))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (m *AgreementMemoryStore) ListActiveAgreements(ctx context.Context) ([]*Agreement, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []*Agreement
+	for _, a := range m.agreements {
+		if a.Status == AgreementActive {
+			out = append(out, deepCopyAgreement(a))
+		}
+	}
+	return out, nil
+}
+
+func deepCopyAgreement(a *Agreement) *Agreement {
+	cp := *a
+	if a.Meter != nil {
+		m := *a.Meter
+		cp.Meter = &m
+	}
+	if a.LastSettledAt != nil {
+		t := *a.LastSettledAt
+		cp.LastSettledAt = &t
+	}
+	if a.StartedAt != nil {
+		t := *a.StartedAt
+		cp.StartedAt = &t
+	}
+	if a.ExpiresAt != nil {
+		t := *a.ExpiresAt
+		cp.ExpiresAt = &t
+	}
+	if a.PausedAt != nil {
+		t := *a.PausedAt
+		cp.PausedAt = &t
+	}
+	if a.Window != nil {
+		cp.Window = make([]CallRecord, len(a.Window))
+		copy(cp.Window, a.Window)
+	}
+	if a.Renewals != nil {
+		cp.Renewals = make([]Renewal, len(a.Renewals))
+		copy(cp.Renewals, a.Renewals)
+	}
+	if a.Amendments != nil {
+		cp.Amendments = make([]Amendment, len(a.Amendments))
+		copy(cp.Amendments, a.Amendments)
+	}
+	if a.Packs != nil {
+		cp.Packs = make([]CallPack, len(a.Packs))
+		copy(cp.Packs, a.Packs)
+	}
+	if a.Disputes != nil {
+		cp.Disputes = make([]Dispute, len(a.Disputes))
+		copy(cp.Disputes, a.Disputes)
+		for i := range cp.Disputes {
+			if ev := a.Disputes[i].Evidence; ev != nil {
+				cp.Disputes[i].Evidence = make([]DisputeEvidence, len(ev))
+				copy(cp.Disputes[i].Evidence, ev)
+			}
+		}
+	}
+	return &cp
+}
+
+var _ AgreementStore = (*AgreementMemoryStore)(nil)
@@ -0,0 +1,255 @@
+package contracts
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// AgreementPostgresStore persists service agreements in PostgreSQL.
+type AgreementPostgresStore struct {
+	db *sql.DB
+}
+
+// NewAgreementPostgresStore creates a new PostgreSQL-backed agreement store.
+func NewAgreementPostgresStore(db *sql.DB) *AgreementPostgresStore {
+	return &AgreementPostgresStore{db: db}
+}
+
+const agreementColumns = `id, buyer_addr, seller_addr, service_name, description,
+	billing_mode, price_per_call, meter, budget, spent, sla, status, proposed_by,
+	term_days, started_at, expires_at, total_calls, success_calls, failed_calls,
+	call_window, renewals, amendments, disputes, packs, pending_units, last_settled_at,
+	penalties_paid, latency_breached, max_pause_hours, paused_at, pause_reason,
+	paused_seconds, created_at, updated_at`
+
+func (p *AgreementPostgresStore) CreateAgreement(ctx context.Context, a *Agreement) error {
+	slaJSON, _ := json.Marshal(a.SLA)
+	windowJSON := marshalOrEmpty(a.Window)
+	renewalsJSON := marshalOrEmpty(a.Renewals)
+	amendmentsJSON := marshalOrEmpty(a.Amendments)
+	disputesJSON := marshalOrEmpty(a.Disputes)
+	packsJSON := marshalOrEmpty(a.Packs)
+	meterJSON := marshalMeter(a.Meter)
+
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO service_agreements (
+			id, buyer_addr, seller_addr, service_name, description,
+			billing_mode, price_per_call, meter, budget, spent, sla, status, proposed_by,
+			term_days, started_at, expires_at, total_calls, success_calls, failed_calls,
+			call_window, renewals, amendments, disputes, packs, pending_units, last_settled_at,
+			penalties_paid, latency_breached, max_pause_hours, paused_at, pause_reason,
+			paused_seconds, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			$6, $7, $8, $9, $10, $11, $12, $13,
+			$14, $15, $16, $17, $18, $19,
+			$20, $21, $22, $23, $24, $25, $26,
+			$27, $28, $29, $30, $31,
+			$32, $33, $34
+		)`,
+		a.ID, a.BuyerAddr, a.SellerAddr, a.ServiceName, a.Description,
+		string(a.BillingMode), nullString(a.PricePerCall), meterJSON, nullString(a.Budget),
+		a.Spent, slaJSON, string(a.Status), a.ProposedBy,
+		a.TermDays, nullTime(a.StartedAt), nullTime(a.ExpiresAt), a.TotalCalls,
+		a.SuccessCalls, a.FailedCalls,
+		windowJSON, renewalsJSON, amendmentsJSON, disputesJSON, packsJSON, a.PendingUnits, nullTime(a.LastSettledAt),
+		nullString(a.PenaltiesPaid), a.LatencyBreached, a.MaxPauseHours, nullTime(a.PausedAt),
+		nullString(a.PauseReason), a.PausedSeconds, a.CreatedAt, a.UpdatedAt,
+	)
+	return err
+}
+
+func (p *AgreementPostgresStore) GetAgreement(ctx context.Context, id string) (*Agreement, error) {
+	row := p.db.QueryRowContext(ctx,
+		`SELECT `+agreementColumns+` FROM service_agreements WHERE id = $1`, id)
+	a, err := scanAgreement(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrAgreementNotFound
+	}
+	return a, err
+}
+
+func (p *AgreementPostgresStore) UpdateAgreement(ctx context.Context, a *Agreement) error {
+	slaJSON, _ := json.Marshal(a.SLA)
+	windowJSON := marshalOrEmpty(a.Window)
+	renewalsJSON := marshalOrEmpty(a.Renewals)
+	amendmentsJSON := marshalOrEmpty(a.Amendments)
+	disputesJSON := marshalOrEmpty(a.Disputes)
+	packsJSON := marshalOrEmpty(a.Packs)
+	meterJSON := marshalMeter(a.Meter)
+
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE service_agreements SET
+			price_per_call = $1, meter = $2, budget = $3, spent = $4, sla = $5,
+			status = $6, started_at = $7, expires_at = $8, total_calls = $9,
+			success_calls = $10, failed_calls = $11, call_window = $12,
+			renewals = $13, amendments = $14, disputes = $15, packs = $16, pending_units = $17,
+			last_settled_at = $18, penalties_paid = $19, latency_breached = $20,
+			paused_at = $21, pause_reason = $22, paused_seconds = $23,
+			updated_at = $24
+		WHERE id = $25`,
+		nullString(a.PricePerCall), meterJSON, nullString(a.Budget), a.Spent, slaJSON,
+		string(a.Status), nullTime(a.StartedAt), nullTime(a.ExpiresAt), a.TotalCalls,
+		a.SuccessCalls, a.FailedCalls, windowJSON,
+		renewalsJSON, amendmentsJSON, disputesJSON, packsJSON, a.PendingUnits,
+		nullTime(a.LastSettledAt), nullString(a.PenaltiesPaid), a.LatencyBreached,
+		nullTime(a.PausedAt), nullString(a.PauseReason), a.PausedSeconds,
+		a.UpdatedAt, a.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrAgreementNotFound
+	}
+	return nil
+}
+
+func (p *AgreementPostgresStore) ListAgreementsByAgent(ctx context.Context, addr string) ([]*Agreement, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT `+agreementColumns+` FROM service_agreements
+		 WHERE buyer_addr = $1 OR seller_addr = $1
+		 ORDER BY created_at DESC`, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAgreements(rows)
+}
+
+func (p *AgreementPostgresStore) ListActiveAgreements(ctx context.Context) ([]*Agreement, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT `+agreementColumns+` FROM service_agreements WHERE status = $1`,
+		string(AgreementActive))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAgreements(rows)
+}
+
+func scanAgreements(rows *sql.Rows) ([]*Agreement, error) {
+	var out []*Agreement
+	for rows.Next() {
+		a, err := scanAgreement(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func scanAgreement(s scanner) (*Agreement, error) {
+	a := &Agreement{}
+	var (
+		billingMode    string
+		status         string
+		pricePerCall   sql.NullString
+		budget         sql.NullString
+		penaltiesPaid  sql.NullString
+		pauseReason    sql.NullString
+		startedAt      sql.NullTime
+		expiresAt      sql.NullTime
+		lastSettledAt  sql.NullTime
+		pausedAt       sql.NullTime
+		slaJSON        []byte
+		meterJSON      []byte
+		windowJSON     []byte
+		renewalsJSON   []byte
+		amendmentsJSON []byte
+		disputesJSON   []byte
+		packsJSON      []byte
+	)
+
+	err := s.Scan(
+		&a.ID, &a.BuyerAddr, &a.SellerAddr, &a.ServiceName, &a.Description,
+		&billingMode, &pricePerCall, &meterJSON, &budget, &a.Spent, &slaJSON,
+		&status, &a.ProposedBy, &a.TermDays, &startedAt, &expiresAt,
+		&a.TotalCalls, &a.SuccessCalls, &a.FailedCalls,
+		&windowJSON, &renewalsJSON, &amendmentsJSON, &disputesJSON, &packsJSON, &a.PendingUnits, &lastSettledAt,
+		&penaltiesPaid, &a.LatencyBreached, &a.MaxPauseHours, &pausedAt, &pauseReason,
+		&a.PausedSeconds, &a.CreatedAt, &a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	a.BillingMode = BillingMode(billingMode)
+	a.Status = AgreementStatus(status)
+	a.PricePerCall = pricePerCall.String
+	a.Budget = budget.String
+	a.PenaltiesPaid = penaltiesPaid.String
+	a.PauseReason = pauseReason.String
+	if startedAt.Valid {
+		t := startedAt.Time
+		a.StartedAt = &t
+	}
+	if expiresAt.Valid {
+		t := expiresAt.Time
+		a.ExpiresAt = &t
+	}
+	if lastSettledAt.Valid {
+		t := lastSettledAt.Time
+		a.LastSettledAt = &t
+	}
+	if pausedAt.Valid {
+		t := pausedAt.Time
+		a.PausedAt = &t
+	}
+	if len(slaJSON) > 0 {
+		_ = json.Unmarshal(slaJSON, &a.SLA)
+	}
+	if len(meterJSON) > 0 && string(meterJSON) != "null" {
+		_ = json.Unmarshal(meterJSON, &a.Meter)
+	}
+	if len(windowJSON) > 0 {
+		_ = json.Unmarshal(windowJSON, &a.Window)
+	}
+	if len(renewalsJSON) > 0 {
+		_ = json.Unmarshal(renewalsJSON, &a.Renewals)
+	}
+	if len(amendmentsJSON) > 0 {
+		_ = json.Unmarshal(amendmentsJSON, &a.Amendments)
+	}
+	if len(disputesJSON) > 0 {
+		_ = json.Unmarshal(disputesJSON, &a.Disputes)
+	}
+	if len(packsJSON) > 0 {
+		_ = json.Unmarshal(packsJSON, &a.Packs)
+	}
+
+	return a, nil
+}
+
+func marshalMeter(m *MeterTerms) []byte {
+	if m == nil {
+		return []byte("null")
+	}
+	data, _ := json.Marshal(m)
+	return data
+}
+
+func marshalOrEmpty(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return []byte("[]")
+	}
+	return data
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// Compile-time assertion that AgreementPostgresStore implements AgreementStore.
+var _ AgreementStore = (*AgreementPostgresStore)(nil)
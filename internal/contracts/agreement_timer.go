@@ -0,0 +1,85 @@
+package contracts
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/recovery"
+)
+
+// AgreementTimer periodically expires service agreements past their term.
+type AgreementTimer struct {
+	service  *AgreementService
+	interval time.Duration
+	logger   *slog.Logger
+	stop     chan struct{}
+	running  atomic.Bool
+}
+
+// NewAgreementTimer creates a new agreement expiry timer.
+func NewAgreementTimer(service *AgreementService, logger *slog.Logger) *AgreementTimer {
+	return &AgreementTimer{
+		service:  service,
+		interval: time.Minute,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start begins the timer loop.
+func (t *AgreementTimer) Start(ctx context.Context) {
+	t.running.Store(true)
+	defer t.running.Store(false)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.safeProcess(ctx)
+		}
+	}
+}
+
+// Stop signals the timer loop to exit.
+func (t *AgreementTimer) Stop() {
+	select {
+	case t.stop <- struct{}{}:
+	default:
+	}
+}
+
+// Running returns true if the timer loop is active.
+func (t *AgreementTimer) Running() bool {
+	return t.running.Load()
+}
+
+func (t *AgreementTimer) safeProcess(ctx context.Context) {
+	defer recovery.LogPanic(t.logger, "agreement_timer")
+
+	now := time.Now()
+	expired, err := t.service.ExpireDue(ctx, now)
+	if err != nil {
+		t.logger.Warn("agreement timer: error expiring agreements", "error", err)
+		return
+	}
+	if expired > 0 {
+		t.logger.Info("agreement timer: expired agreements", "count", expired)
+	}
+
+	settled, err := t.service.SettleUsageDue(ctx, now)
+	if err != nil {
+		t.logger.Warn("agreement timer: error settling usage", "error", err)
+		return
+	}
+	if settled > 0 {
+		t.logger.Info("agreement timer: settled metered usage", "count", settled)
+	}
+}
@@ -0,0 +1,124 @@
+package contracts
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAgreementEmitter records emitted events as "type:recipient" strings.
+type fakeAgreementEmitter struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (f *fakeAgreementEmitter) record(ev string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, ev)
+}
+
+func (f *fakeAgreementEmitter) has(ev string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, e := range f.events {
+		if e == ev {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeAgreementEmitter) EmitAgreementProposed(addr, id, by, svc string) {
+	f.record("proposed:" + addr)
+}
+func (f *fakeAgreementEmitter) EmitAgreementAccepted(addr, id, svc string) {
+	f.record("accepted:" + addr)
+}
+func (f *fakeAgreementEmitter) EmitAgreementRejected(addr, id, svc string) {
+	f.record("rejected:" + addr)
+}
+func (f *fakeAgreementEmitter) EmitAgreementBreached(addr, id string, rate, min float64) {
+	f.record("breached:" + addr)
+}
+func (f *fakeAgreementEmitter) EmitAgreementExpired(addr, id, svc string) {
+	f.record("expired:" + addr)
+}
+func (f *fakeAgreementEmitter) EmitAgreementTerminated(addr, id, by string) {
+	f.record("terminated:" + addr)
+}
+func (f *fakeAgreementEmitter) EmitAgreementSLAAtRisk(addr, id string, rate, min float64) {
+	f.record("at_risk:" + addr)
+}
+
+// waitFor polls until the emitter has seen the event; emissions are
+// fire-and-forget goroutines.
+func waitFor(t *testing.T, f *fakeAgreementEmitter, ev string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if f.has(ev) {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t.Errorf("event %q not emitted; got %v", ev, f.events)
+}
+
+func TestAgreementLifecycleWebhooks(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	fe := &fakeAgreementEmitter{}
+	svc.WithWebhookEmitter(fe)
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{})
+	waitFor(t, fe, "proposed:0xseller")
+
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	waitFor(t, fe, "accepted:0xbuyer")
+
+	if _, err := svc.Terminate(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Terminate: %v", err)
+	}
+	waitFor(t, fe, "terminated:0xbuyer")
+}
+
+func TestAgreementSLAWebhooks(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	fe := &fakeAgreementEmitter{}
+	svc.WithWebhookEmitter(fe)
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{
+		MinSuccessRate: 0.75,
+		WindowSize:     4,
+	})
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	// Three successes and one failure: 0.75 holds, but one more failure
+	// breaches — the at-risk alert fires.
+	for i := 0; i < 3; i++ {
+		if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); err != nil {
+			t.Fatalf("RecordCall: %v", err)
+		}
+	}
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", false, 50); err != nil {
+		t.Fatalf("RecordCall: %v", err)
+	}
+	waitFor(t, fe, "at_risk:0xseller")
+	if fe.has("breached:0xseller") {
+		t.Error("breached emitted before the SLA was actually violated")
+	}
+
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", false, 50); err != nil {
+		t.Fatalf("RecordCall: %v", err)
+	}
+	waitFor(t, fe, "breached:0xseller")
+}
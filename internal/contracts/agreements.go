@@ -0,0 +1,967 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// Service agreements are ongoing buyer/seller contracts billed per call,
+// distinct from the behavioral contracts (ABC) above that gate a single
+// coalition escrow. An agreement is proposed by either party, accepted by
+// the counterparty, and then bills the buyer per recorded call while
+// tracking success rate against the agreed SLA over a rolling window.
+
+var (
+	ErrAgreementNotFound  = errors.New("contracts: agreement not found")
+	ErrNotParty           = errors.New("contracts: caller is not a party to this agreement")
+	ErrConsentRequired    = errors.New("contracts: counterparty consent required")
+	ErrInvalidAgreement   = errors.New("contracts: invalid agreement terms")
+	ErrAgreementNotActive = errors.New("contracts: agreement is not active")
+	ErrAgreementExpired   = errors.New("contracts: agreement has expired")
+	ErrBudgetExhausted    = errors.New("contracts: agreement budget exhausted")
+	ErrRenewalPending     = errors.New("contracts: a renewal is already pending")
+	ErrNoPendingRenewal   = errors.New("contracts: no pending renewal")
+	ErrAmendmentPending   = errors.New("contracts: an amendment is already pending")
+	ErrNoPendingAmendment = errors.New("contracts: no pending amendment")
+	ErrAgreementPaused    = errors.New("contracts: agreement is paused")
+	ErrPauseExhausted     = errors.New("contracts: cumulative pause allowance exhausted")
+	ErrDisputeOpen        = errors.New("contracts: a dispute is already open")
+	ErrNoOpenDispute      = errors.New("contracts: no open dispute")
+	ErrNotArbitrator      = errors.New("contracts: caller is not the assigned arbitrator")
+	ErrPackPending        = errors.New("contracts: a call pack is already pending")
+	ErrNoPendingPack      = errors.New("contracts: no pending call pack")
+)
+
+// AgreementStatus represents the agreement lifecycle state.
+type AgreementStatus string
+
+const (
+	AgreementProposed   AgreementStatus = "proposed"
+	AgreementActive     AgreementStatus = "active"
+	AgreementRejected   AgreementStatus = "rejected"
+	AgreementExpired    AgreementStatus = "expired"
+	AgreementTerminated AgreementStatus = "terminated"
+	AgreementBreached   AgreementStatus = "breached" // SLA violated, billing stopped
+	AgreementPaused     AgreementStatus = "paused"   // Announced downtime; billing and SLA checks suspended
+)
+
+// defaultSLAWindow is how many recent calls the rolling success-rate check
+// covers when the proposal does not specify a window.
+const defaultSLAWindow = 20
+
+// defaultMaxPauseHours caps cumulative maintenance downtime when the
+// proposal does not specify an allowance.
+const defaultMaxPauseHours = 72
+
+// SLATerms defines the service guarantee attached to an agreement.
+type SLATerms struct {
+	MinSuccessRate float64 `json:"minSuccessRate,omitempty"` // 0–1 over the rolling window; 0 disables the check
+	WindowSize     int     `json:"windowSize,omitempty"`     // Calls per rolling window
+	MaxLatencyMs   float64 `json:"maxLatencyMs,omitempty"`   // p95 ceiling over the rolling window; 0 disables the check
+	LatencyPenalty string  `json:"latencyPenalty,omitempty"` // USDC credited to the buyer per latency breach
+}
+
+// CallRecord captures one billed call under an agreement.
+type CallRecord struct {
+	Seq       int       `json:"seq"`
+	Success   bool      `json:"success"`
+	LatencyMs float64   `json:"latencyMs,omitempty"`
+	Cost      string    `json:"cost"`
+	At        time.Time `json:"at"`
+}
+
+// RenewalStatus represents the state of a proposed renewal.
+type RenewalStatus string
+
+const (
+	RenewalPending  RenewalStatus = "pending"
+	RenewalAccepted RenewalStatus = "accepted"
+	RenewalDeclined RenewalStatus = "declined"
+)
+
+// Renewal records one extension proposal on an agreement. A renewal takes
+// effect only once the counterparty accepts; call history and SLA state
+// carry over unchanged.
+type Renewal struct {
+	ProposedBy   string        `json:"proposedBy"`
+	ExtendDays   int           `json:"extendDays"`
+	PricePerCall string        `json:"pricePerCall,omitempty"` // Empty keeps the current price
+	Budget       string        `json:"budget,omitempty"`       // Empty keeps the current budget
+	Status       RenewalStatus `json:"status"`
+	ProposedAt   time.Time     `json:"proposedAt"`
+	DecidedAt    *time.Time    `json:"decidedAt,omitempty"`
+}
+
+// Amendment records one mid-contract terms change. Like renewals, an
+// amendment takes effect only once the counterparty accepts; fields left
+// unset keep the current terms. Accepted amendments apply to all
+// subsequent calls and SLA checks.
+type Amendment struct {
+	ProposedBy     string        `json:"proposedBy"`
+	PricePerCall   string        `json:"pricePerCall,omitempty"`   // Empty keeps the current price
+	Budget         string        `json:"budget,omitempty"`         // New total ceiling; empty keeps the current budget
+	MinSuccessRate *float64      `json:"minSuccessRate,omitempty"` // Nil keeps the current threshold
+	WindowSize     int           `json:"windowSize,omitempty"`     // 0 keeps the current window
+	MaxLatencyMs   *float64      `json:"maxLatencyMs,omitempty"`   // Nil keeps the current ceiling; 0 disables it
+	LatencyPenalty string        `json:"latencyPenalty,omitempty"` // Empty keeps the current penalty
+	Status         RenewalStatus `json:"status"`
+	ProposedAt     time.Time     `json:"proposedAt"`
+	DecidedAt      *time.Time    `json:"decidedAt,omitempty"`
+}
+
+// Agreement is an ongoing per-call service contract between two agents.
+type Agreement struct {
+	ID              string          `json:"id"`
+	BuyerAddr       string          `json:"buyerAddr"`
+	SellerAddr      string          `json:"sellerAddr"`
+	ServiceName     string          `json:"serviceName"`
+	Description     string          `json:"description,omitempty"`
+	BillingMode     BillingMode     `json:"billingMode"`
+	PricePerCall    string          `json:"pricePerCall,omitempty"` // Per-call mode only
+	Meter           *MeterTerms     `json:"meter,omitempty"`        // Metered mode only
+	Budget          string          `json:"budget,omitempty"`       // Total spend ceiling; empty = unlimited
+	Spent           string          `json:"spent"`
+	SLA             SLATerms        `json:"sla"`
+	Status          AgreementStatus `json:"status"`
+	ProposedBy      string          `json:"proposedBy"`
+	TermDays        int             `json:"termDays"`
+	StartedAt       *time.Time      `json:"startedAt,omitempty"`
+	ExpiresAt       *time.Time      `json:"expiresAt,omitempty"`
+	TotalCalls      int             `json:"totalCalls"`
+	SuccessCalls    int             `json:"successCalls"`
+	FailedCalls     int             `json:"failedCalls"`
+	Window          []CallRecord    `json:"window,omitempty"` // Rolling SLA window (most recent last)
+	Renewals        []Renewal       `json:"renewals,omitempty"`
+	Amendments      []Amendment     `json:"amendments,omitempty"`
+	Disputes        []Dispute       `json:"disputes,omitempty"`
+	Packs           []CallPack      `json:"packs,omitempty"`
+	PendingUnits    int64           `json:"pendingUnits,omitempty"`    // Metered: units reported but not yet settled
+	LastSettledAt   *time.Time      `json:"lastSettledAt,omitempty"`   // Metered: end of the last billed period
+	PenaltiesPaid   string          `json:"penaltiesPaid,omitempty"`   // Cumulative latency penalty credits to the buyer
+	LatencyBreached bool            `json:"latencyBreached,omitempty"` // True while the p95 window is over the ceiling
+	MaxPauseHours   int             `json:"maxPauseHours,omitempty"`   // Cumulative pause allowance over the agreement's life
+	PausedAt        *time.Time      `json:"pausedAt,omitempty"`        // Set while paused
+	PauseReason     string          `json:"pauseReason,omitempty"`     // Set while paused
+	PausedSeconds   int64           `json:"pausedSeconds,omitempty"`   // Cumulative time spent paused
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+}
+
+// IsParty returns true if addr is the buyer or seller.
+func (a *Agreement) IsParty(addr string) bool {
+	addr = strings.ToLower(addr)
+	return addr == a.BuyerAddr || addr == a.SellerAddr
+}
+
+// Counterparty returns the other party's address, or "" if addr is not a party.
+func (a *Agreement) Counterparty(addr string) string {
+	switch strings.ToLower(addr) {
+	case a.BuyerAddr:
+		return a.SellerAddr
+	case a.SellerAddr:
+		return a.BuyerAddr
+	}
+	return ""
+}
+
+// PendingAmendment returns the open amendment proposal, if any.
+func (a *Agreement) PendingAmendment() *Amendment {
+	if n := len(a.Amendments); n > 0 && a.Amendments[n-1].Status == RenewalPending {
+		return &a.Amendments[n-1]
+	}
+	return nil
+}
+
+// PendingRenewal returns the open renewal proposal, if any.
+func (a *Agreement) PendingRenewal() *Renewal {
+	if n := len(a.Renewals); n > 0 && a.Renewals[n-1].Status == RenewalPending {
+		return &a.Renewals[n-1]
+	}
+	return nil
+}
+
+// WindowP95Latency returns the 95th-percentile latency over the rolling
+// window, or 0 when the window is empty.
+func (a *Agreement) WindowP95Latency() float64 {
+	if len(a.Window) == 0 {
+		return 0
+	}
+	lat := make([]float64, len(a.Window))
+	for i, cr := range a.Window {
+		lat[i] = cr.LatencyMs
+	}
+	sort.Float64s(lat)
+	idx := int(math.Ceil(0.95*float64(len(lat)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return lat[idx]
+}
+
+// windowAtRisk reports whether the agreement is one bad call away from
+// breaching the success-rate SLA: if the next call fails (sliding the
+// oldest record out of the window), the rolling rate drops below the
+// threshold. The caller has already checked the window is full.
+func (a *Agreement) windowAtRisk() bool {
+	ok := 0
+	for _, cr := range a.Window {
+		if cr.Success {
+			ok++
+		}
+	}
+	if a.Window[0].Success {
+		ok--
+	}
+	return float64(ok)/float64(len(a.Window)) < a.SLA.MinSuccessRate
+}
+
+// WindowSuccessRate returns the success fraction over the rolling window.
+func (a *Agreement) WindowSuccessRate() float64 {
+	if len(a.Window) == 0 {
+		return 1.0
+	}
+	ok := 0
+	for _, cr := range a.Window {
+		if cr.Success {
+			ok++
+		}
+	}
+	return float64(ok) / float64(len(a.Window))
+}
+
+// AgreementLedger is the slice of the ledger agreements need to bill calls.
+type AgreementLedger interface {
+	Transfer(ctx context.Context, from, to, amount, reference string) error
+}
+
+// AgreementEmitter sends lifecycle webhooks for service agreements.
+type AgreementEmitter interface {
+	EmitAgreementProposed(agentAddr, agreementID, proposedBy, serviceName string)
+	EmitAgreementAccepted(agentAddr, agreementID, serviceName string)
+	EmitAgreementRejected(agentAddr, agreementID, serviceName string)
+	EmitAgreementBreached(agentAddr, agreementID string, successRate, minSuccessRate float64)
+	EmitAgreementExpired(agentAddr, agreementID, serviceName string)
+	EmitAgreementTerminated(agentAddr, agreementID, terminatedBy string)
+	EmitAgreementSLAAtRisk(agentAddr, agreementID string, successRate, minSuccessRate float64)
+}
+
+// AgreementStore persists service agreements.
+type AgreementStore interface {
+	CreateAgreement(ctx context.Context, a *Agreement) error
+	GetAgreement(ctx context.Context, id string) (*Agreement, error)
+	UpdateAgreement(ctx context.Context, a *Agreement) error
+	ListAgreementsByAgent(ctx context.Context, addr string) ([]*Agreement, error)
+	ListActiveAgreements(ctx context.Context) ([]*Agreement, error)
+}
+
+// AgreementService implements service agreement business logic.
+type AgreementService struct {
+	store          AgreementStore
+	ledger         AgreementLedger
+	logger         *slog.Logger
+	webhookEmitter AgreementEmitter
+	locks          sync.Map
+}
+
+// NewAgreementService creates a new service agreement service.
+func NewAgreementService(store AgreementStore, ledger AgreementLedger) *AgreementService {
+	return &AgreementService{
+		store:  store,
+		ledger: ledger,
+		logger: slog.Default(),
+	}
+}
+
+// WithLogger sets a structured logger.
+func (s *AgreementService) WithLogger(l *slog.Logger) *AgreementService {
+	s.logger = l
+	return s
+}
+
+// WithWebhookEmitter adds a webhook emitter for lifecycle events.
+func (s *AgreementService) WithWebhookEmitter(e AgreementEmitter) *AgreementService {
+	s.webhookEmitter = e
+	return s
+}
+
+func (s *AgreementService) agreementLock(id string) *sync.Mutex {
+	v, _ := s.locks.LoadOrStore(id, &sync.Mutex{})
+	return v.(*sync.Mutex)
+}
+
+// ProposeAgreementRequest is the input for proposing a service agreement.
+type ProposeAgreementRequest struct {
+	BuyerAddr      string      `json:"buyerAddr" binding:"required"`
+	SellerAddr     string      `json:"sellerAddr" binding:"required"`
+	ServiceName    string      `json:"serviceName" binding:"required"`
+	Description    string      `json:"description"`
+	BillingMode    string      `json:"billingMode"`  // Defaults to per_call
+	PricePerCall   string      `json:"pricePerCall"` // Required for per-call mode
+	Meter          *MeterTerms `json:"meter"`        // Required for metered mode
+	Budget         string      `json:"budget"`
+	TermDays       int         `json:"termDays" binding:"required"`
+	MinSuccessRate float64     `json:"minSuccessRate"`
+	WindowSize     int         `json:"windowSize"`
+	MaxLatencyMs   float64     `json:"maxLatencyMs"`
+	LatencyPenalty string      `json:"latencyPenalty"`
+	MaxPauseHours  int         `json:"maxPauseHours"` // Defaults to 72
+}
+
+// Propose creates an agreement in proposed state. The actor must be one of
+// the two parties; the counterparty accepts or rejects.
+func (s *AgreementService) Propose(ctx context.Context, actor string, req ProposeAgreementRequest) (*Agreement, error) {
+	buyer := strings.ToLower(req.BuyerAddr)
+	seller := strings.ToLower(req.SellerAddr)
+	actor = strings.ToLower(actor)
+
+	if buyer == seller {
+		return nil, fmt.Errorf("%w: buyer and seller must differ", ErrInvalidAgreement)
+	}
+	if actor != buyer && actor != seller {
+		return nil, ErrNotParty
+	}
+	mode := BillingMode(req.BillingMode)
+	if mode == "" {
+		mode = BillingPerCall
+	}
+	var meter *MeterTerms
+	switch mode {
+	case BillingPerCall:
+		price, ok := usdc.Parse(req.PricePerCall)
+		if !ok || price.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: invalid pricePerCall %q", ErrInvalidAgreement, req.PricePerCall)
+		}
+		req.PricePerCall = usdc.Format(price)
+		if req.Budget != "" {
+			budget, ok := usdc.Parse(req.Budget)
+			if !ok || budget.Cmp(price) < 0 {
+				return nil, fmt.Errorf("%w: budget must cover at least one call", ErrInvalidAgreement)
+			}
+		}
+	case BillingMetered:
+		if req.Meter == nil || req.Meter.UnitName == "" {
+			return nil, fmt.Errorf("%w: metered agreements require meter terms", ErrInvalidAgreement)
+		}
+		if p, ok := usdc.Parse(req.Meter.PricePerUnit); !ok || p.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: invalid pricePerUnit %q", ErrInvalidAgreement, req.Meter.PricePerUnit)
+		}
+		if req.Meter.UnitCap < 0 {
+			return nil, fmt.Errorf("%w: unitCap must not be negative", ErrInvalidAgreement)
+		}
+		if req.Budget != "" {
+			if _, ok := usdc.Parse(req.Budget); !ok {
+				return nil, fmt.Errorf("%w: invalid budget %q", ErrInvalidAgreement, req.Budget)
+			}
+		}
+		m := *req.Meter
+		if m.PeriodHours <= 0 {
+			m.PeriodHours = defaultMeterPeriodHours
+		}
+		meter = &m
+		req.PricePerCall = ""
+	default:
+		return nil, fmt.Errorf("%w: unknown billing mode %q", ErrInvalidAgreement, req.BillingMode)
+	}
+	if req.TermDays <= 0 {
+		return nil, fmt.Errorf("%w: termDays must be positive", ErrInvalidAgreement)
+	}
+	if req.MinSuccessRate < 0 || req.MinSuccessRate > 1 {
+		return nil, fmt.Errorf("%w: minSuccessRate must be between 0 and 1", ErrInvalidAgreement)
+	}
+	if req.MaxLatencyMs < 0 {
+		return nil, fmt.Errorf("%w: maxLatencyMs must not be negative", ErrInvalidAgreement)
+	}
+	if req.MaxLatencyMs > 0 {
+		if p, ok := usdc.Parse(req.LatencyPenalty); !ok || p.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: latency SLA requires a positive latencyPenalty", ErrInvalidAgreement)
+		}
+	}
+	window := req.WindowSize
+	if window <= 0 {
+		window = defaultSLAWindow
+	}
+	if req.MaxPauseHours < 0 {
+		return nil, fmt.Errorf("%w: maxPauseHours must not be negative", ErrInvalidAgreement)
+	}
+	maxPause := req.MaxPauseHours
+	if maxPause == 0 {
+		maxPause = defaultMaxPauseHours
+	}
+
+	now := time.Now()
+	a := &Agreement{
+		ID:           idgen.WithPrefix("agr_"),
+		BuyerAddr:    buyer,
+		SellerAddr:   seller,
+		ServiceName:  req.ServiceName,
+		Description:  req.Description,
+		BillingMode:  mode,
+		PricePerCall: req.PricePerCall,
+		Meter:        meter,
+		Budget:       req.Budget,
+		Spent:        usdc.Format(big.NewInt(0)),
+		SLA: SLATerms{
+			MinSuccessRate: req.MinSuccessRate,
+			WindowSize:     window,
+			MaxLatencyMs:   req.MaxLatencyMs,
+			LatencyPenalty: req.LatencyPenalty,
+		},
+		Status:        AgreementProposed,
+		ProposedBy:    actor,
+		TermDays:      req.TermDays,
+		MaxPauseHours: maxPause,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.store.CreateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	if s.webhookEmitter != nil {
+		go s.webhookEmitter.EmitAgreementProposed(a.Counterparty(actor), a.ID, actor, a.ServiceName)
+	}
+	return a, nil
+}
+
+// Accept activates a proposed agreement. Only the counterparty of the
+// proposer can accept.
+func (s *AgreementService) Accept(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementProposed {
+		return nil, fmt.Errorf("%w: must be proposed to accept, current: %s", ErrInvalidAgreement, a.Status)
+	}
+	if err := requireCounterparty(a, a.ProposedBy, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expires := now.Add(time.Duration(a.TermDays) * 24 * time.Hour)
+	a.Status = AgreementActive
+	a.StartedAt = &now
+	a.ExpiresAt = &expires
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	if s.webhookEmitter != nil {
+		go s.webhookEmitter.EmitAgreementAccepted(a.ProposedBy, a.ID, a.ServiceName)
+	}
+	return a, nil
+}
+
+// Reject declines a proposed agreement. Only the counterparty can reject.
+func (s *AgreementService) Reject(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementProposed {
+		return nil, fmt.Errorf("%w: must be proposed to reject, current: %s", ErrInvalidAgreement, a.Status)
+	}
+	if err := requireCounterparty(a, a.ProposedBy, actor); err != nil {
+		return nil, err
+	}
+
+	a.Status = AgreementRejected
+	a.UpdatedAt = time.Now()
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	if s.webhookEmitter != nil {
+		go s.webhookEmitter.EmitAgreementRejected(a.ProposedBy, a.ID, a.ServiceName)
+	}
+	return a, nil
+}
+
+// Terminate ends an active or paused agreement early. Either party may
+// terminate.
+func (s *AgreementService) Terminate(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementActive && a.Status != AgreementPaused {
+		return nil, ErrAgreementNotActive
+	}
+	if !a.IsParty(actor) {
+		return nil, ErrNotParty
+	}
+
+	now := time.Now()
+	if a.BillingMode == BillingMetered && a.Meter != nil && a.PendingUnits > 0 {
+		if err := s.settlePendingUsageLocked(ctx, a, now); err != nil {
+			s.logger.Warn("final usage settlement failed", "agreement_id", a.ID, "error", err)
+		}
+	}
+	s.refundUnusedPacksLocked(ctx, a)
+	if a.PausedAt != nil {
+		a.PausedSeconds += int64(now.Sub(*a.PausedAt).Seconds())
+		a.PausedAt = nil
+		a.PauseReason = ""
+	}
+	a.Status = AgreementTerminated
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	if s.webhookEmitter != nil {
+		go s.webhookEmitter.EmitAgreementTerminated(a.Counterparty(actor), a.ID, strings.ToLower(actor))
+	}
+	return a, nil
+}
+
+// RecordCall bills one call to the buyer and updates SLA state. Only the
+// seller may report calls. A failed call is recorded but still billed —
+// the SLA check, not individual refunds, is the remedy for poor service.
+func (s *AgreementService) RecordCall(ctx context.Context, id, actor string, success bool, latencyMs float64) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == AgreementPaused {
+		return nil, ErrAgreementPaused
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	if strings.ToLower(actor) != a.SellerAddr {
+		return nil, ErrNotParty
+	}
+	if a.BillingMode == BillingMetered {
+		return nil, fmt.Errorf("%w: metered agreements bill by usage report", ErrInvalidAgreement)
+	}
+
+	now := time.Now()
+	if a.ExpiresAt != nil && now.After(*a.ExpiresAt) {
+		a.Status = AgreementExpired
+		a.UpdatedAt = now
+		if err := s.store.UpdateAgreement(ctx, a); err != nil {
+			return nil, err
+		}
+		if s.webhookEmitter != nil {
+			go s.webhookEmitter.EmitAgreementExpired(a.BuyerAddr, a.ID, a.ServiceName)
+			go s.webhookEmitter.EmitAgreementExpired(a.SellerAddr, a.ID, a.ServiceName)
+		}
+		return a, ErrAgreementExpired
+	}
+
+	cost := a.PricePerCall
+	if pack := a.ActivePack(); pack != nil {
+		// Prepaid calls were billed at pack acceptance; consume one
+		// instead of billing again.
+		pack.Remaining--
+		cost = pack.PricePerCall
+	} else {
+		price, _ := usdc.Parse(a.PricePerCall)
+		spent, _ := usdc.Parse(a.Spent)
+		newSpent := new(big.Int).Add(spent, price)
+		if a.Budget != "" {
+			budget, _ := usdc.Parse(a.Budget)
+			if newSpent.Cmp(budget) > 0 {
+				return nil, ErrBudgetExhausted
+			}
+		}
+		if err := s.ledger.Transfer(ctx, a.BuyerAddr, a.SellerAddr, a.PricePerCall, "agreement:"+a.ID); err != nil {
+			return nil, fmt.Errorf("billing call failed: %w", err)
+		}
+		a.Spent = usdc.Format(newSpent)
+	}
+	a.TotalCalls++
+	if success {
+		a.SuccessCalls++
+	} else {
+		a.FailedCalls++
+	}
+	a.Window = append(a.Window, CallRecord{
+		Seq:       a.TotalCalls,
+		Success:   success,
+		LatencyMs: latencyMs,
+		Cost:      cost,
+		At:        now,
+	})
+	if len(a.Window) > a.SLA.WindowSize {
+		a.Window = a.Window[len(a.Window)-a.SLA.WindowSize:]
+	}
+
+	if a.SLA.MinSuccessRate > 0 && len(a.Window) >= a.SLA.WindowSize {
+		rate := a.WindowSuccessRate()
+		if rate < a.SLA.MinSuccessRate {
+			a.Status = AgreementBreached
+			s.logger.Warn("agreement SLA breached",
+				"agreement_id", a.ID, "seller", a.SellerAddr,
+				"success_rate", rate, "min", a.SLA.MinSuccessRate)
+			if s.webhookEmitter != nil {
+				go s.webhookEmitter.EmitAgreementBreached(a.SellerAddr, a.ID, rate, a.SLA.MinSuccessRate)
+			}
+		} else if s.webhookEmitter != nil && a.windowAtRisk() {
+			go s.webhookEmitter.EmitAgreementSLAAtRisk(a.SellerAddr, a.ID, rate, a.SLA.MinSuccessRate)
+		}
+	}
+	if a.Status == AgreementActive {
+		s.checkLatencySLALocked(ctx, a)
+	}
+
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ProposeRenewal opens an extension proposal on an active agreement before
+// it expires. Either party may propose; terms left empty carry over.
+func (s *AgreementService) ProposeRenewal(ctx context.Context, id, actor string, extendDays int, pricePerCall, budget string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	if !a.IsParty(actor) {
+		return nil, ErrNotParty
+	}
+	if a.PendingRenewal() != nil {
+		return nil, ErrRenewalPending
+	}
+	if extendDays <= 0 {
+		return nil, fmt.Errorf("%w: extendDays must be positive", ErrInvalidAgreement)
+	}
+	if pricePerCall != "" {
+		if p, ok := usdc.Parse(pricePerCall); !ok || p.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: invalid pricePerCall %q", ErrInvalidAgreement, pricePerCall)
+		}
+	}
+	if budget != "" {
+		if b, ok := usdc.Parse(budget); !ok || b.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: invalid budget %q", ErrInvalidAgreement, budget)
+		}
+	}
+
+	a.Renewals = append(a.Renewals, Renewal{
+		ProposedBy:   strings.ToLower(actor),
+		ExtendDays:   extendDays,
+		PricePerCall: pricePerCall,
+		Budget:       budget,
+		Status:       RenewalPending,
+		ProposedAt:   time.Now(),
+	})
+	a.UpdatedAt = time.Now()
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// AcceptRenewal applies a pending renewal: the expiry moves out by the
+// proposed days and any adjusted terms take effect. Call history and SLA
+// state are untouched. Only the counterparty of the renewal's proposer
+// may accept.
+func (s *AgreementService) AcceptRenewal(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	r := a.PendingRenewal()
+	if r == nil {
+		return nil, ErrNoPendingRenewal
+	}
+	if err := requireCounterparty(a, r.ProposedBy, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	base := now
+	if a.ExpiresAt != nil && a.ExpiresAt.After(now) {
+		base = *a.ExpiresAt
+	}
+	expires := base.Add(time.Duration(r.ExtendDays) * 24 * time.Hour)
+	a.ExpiresAt = &expires
+	if r.PricePerCall != "" {
+		a.PricePerCall = r.PricePerCall
+	}
+	if r.Budget != "" {
+		a.Budget = r.Budget
+	}
+	r.Status = RenewalAccepted
+	r.DecidedAt = &now
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// DeclineRenewal rejects a pending renewal, leaving the agreement's current
+// terms and expiry unchanged.
+func (s *AgreementService) DeclineRenewal(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r := a.PendingRenewal()
+	if r == nil {
+		return nil, ErrNoPendingRenewal
+	}
+	if err := requireCounterparty(a, r.ProposedBy, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	r.Status = RenewalDeclined
+	r.DecidedAt = &now
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ProposeAmendment opens a terms-change proposal on an active agreement.
+// Either party may propose; at least one term must change.
+func (s *AgreementService) ProposeAmendment(ctx context.Context, id, actor string, amend Amendment) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	if !a.IsParty(actor) {
+		return nil, ErrNotParty
+	}
+	if a.PendingAmendment() != nil {
+		return nil, ErrAmendmentPending
+	}
+	if amend.PricePerCall == "" && amend.Budget == "" && amend.MinSuccessRate == nil &&
+		amend.WindowSize == 0 && amend.MaxLatencyMs == nil && amend.LatencyPenalty == "" {
+		return nil, fmt.Errorf("%w: amendment must change at least one term", ErrInvalidAgreement)
+	}
+	if amend.PricePerCall != "" {
+		if p, ok := usdc.Parse(amend.PricePerCall); !ok || p.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: invalid pricePerCall %q", ErrInvalidAgreement, amend.PricePerCall)
+		}
+	}
+	if amend.Budget != "" {
+		if b, ok := usdc.Parse(amend.Budget); !ok || b.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: invalid budget %q", ErrInvalidAgreement, amend.Budget)
+		}
+	}
+	if amend.MinSuccessRate != nil && (*amend.MinSuccessRate < 0 || *amend.MinSuccessRate > 1) {
+		return nil, fmt.Errorf("%w: minSuccessRate must be between 0 and 1", ErrInvalidAgreement)
+	}
+	if amend.WindowSize < 0 {
+		return nil, fmt.Errorf("%w: windowSize must be positive", ErrInvalidAgreement)
+	}
+	if amend.MaxLatencyMs != nil && *amend.MaxLatencyMs < 0 {
+		return nil, fmt.Errorf("%w: maxLatencyMs must not be negative", ErrInvalidAgreement)
+	}
+	if amend.LatencyPenalty != "" {
+		if p, ok := usdc.Parse(amend.LatencyPenalty); !ok || p.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: invalid latencyPenalty %q", ErrInvalidAgreement, amend.LatencyPenalty)
+		}
+	}
+
+	amend.ProposedBy = strings.ToLower(actor)
+	amend.Status = RenewalPending
+	amend.ProposedAt = time.Now()
+	amend.DecidedAt = nil
+	a.Amendments = append(a.Amendments, amend)
+	a.UpdatedAt = time.Now()
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// AcceptAmendment applies a pending amendment. The new terms govern all
+// subsequent calls and SLA checks; call history is untouched. Only the
+// counterparty of the amendment's proposer may accept.
+func (s *AgreementService) AcceptAmendment(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	am := a.PendingAmendment()
+	if am == nil {
+		return nil, ErrNoPendingAmendment
+	}
+	if err := requireCounterparty(a, am.ProposedBy, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if am.PricePerCall != "" {
+		a.PricePerCall = am.PricePerCall
+	}
+	if am.Budget != "" {
+		a.Budget = am.Budget
+	}
+	if am.MinSuccessRate != nil {
+		a.SLA.MinSuccessRate = *am.MinSuccessRate
+	}
+	if am.WindowSize > 0 {
+		a.SLA.WindowSize = am.WindowSize
+		if len(a.Window) > a.SLA.WindowSize {
+			a.Window = a.Window[len(a.Window)-a.SLA.WindowSize:]
+		}
+	}
+	if am.MaxLatencyMs != nil {
+		a.SLA.MaxLatencyMs = *am.MaxLatencyMs
+	}
+	if am.LatencyPenalty != "" {
+		a.SLA.LatencyPenalty = am.LatencyPenalty
+	}
+	am.Status = RenewalAccepted
+	am.DecidedAt = &now
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// DeclineAmendment rejects a pending amendment, leaving the agreement's
+// current terms unchanged.
+func (s *AgreementService) DeclineAmendment(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	am := a.PendingAmendment()
+	if am == nil {
+		return nil, ErrNoPendingAmendment
+	}
+	if err := requireCounterparty(a, am.ProposedBy, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	am.Status = RenewalDeclined
+	am.DecidedAt = &now
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// GetAgreement returns an agreement by ID.
+func (s *AgreementService) GetAgreement(ctx context.Context, id string) (*Agreement, error) {
+	return s.store.GetAgreement(ctx, id)
+}
+
+// ListAgreements returns all agreements where the agent is a party.
+func (s *AgreementService) ListAgreements(ctx context.Context, addr string) ([]*Agreement, error) {
+	return s.store.ListAgreementsByAgent(ctx, strings.ToLower(addr))
+}
+
+// ExpireDue transitions active agreements past their expiry. Called by the
+// agreement timer; returns how many agreements expired.
+func (s *AgreementService) ExpireDue(ctx context.Context, now time.Time) (int, error) {
+	active, err := s.store.ListActiveAgreements(ctx)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, a := range active {
+		if a.ExpiresAt == nil || !now.After(*a.ExpiresAt) {
+			continue
+		}
+		mu := s.agreementLock(a.ID)
+		mu.Lock()
+		cur, err := s.store.GetAgreement(ctx, a.ID)
+		if err == nil && cur.Status == AgreementActive && cur.ExpiresAt != nil && now.After(*cur.ExpiresAt) {
+			if cur.BillingMode == BillingMetered && cur.Meter != nil && cur.PendingUnits > 0 {
+				if err := s.settlePendingUsageLocked(ctx, cur, now); err != nil {
+					s.logger.Warn("final usage settlement failed", "agreement_id", cur.ID, "error", err)
+				}
+			}
+			s.refundUnusedPacksLocked(ctx, cur)
+			cur.Status = AgreementExpired
+			cur.UpdatedAt = now
+			if err := s.store.UpdateAgreement(ctx, cur); err != nil {
+				s.logger.Warn("failed to expire agreement", "agreement_id", cur.ID, "error", err)
+			} else {
+				expired++
+				if s.webhookEmitter != nil {
+					go s.webhookEmitter.EmitAgreementExpired(cur.BuyerAddr, cur.ID, cur.ServiceName)
+					go s.webhookEmitter.EmitAgreementExpired(cur.SellerAddr, cur.ID, cur.ServiceName)
+				}
+			}
+		}
+		mu.Unlock()
+	}
+	return expired, nil
+}
+
+// requireCounterparty checks that actor is the party opposite proposedBy.
+func requireCounterparty(a *Agreement, proposedBy, actor string) error {
+	actor = strings.ToLower(actor)
+	if !a.IsParty(actor) {
+		return ErrNotParty
+	}
+	if actor == strings.ToLower(proposedBy) {
+		return ErrConsentRequired
+	}
+	return nil
+}
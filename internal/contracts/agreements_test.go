@@ -0,0 +1,337 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeAgreementLedger struct {
+	transfers []string // "from->to:amount"
+	failNext  bool
+}
+
+func (f *fakeAgreementLedger) Transfer(_ context.Context, from, to, amount, _ string) error {
+	if f.failNext {
+		f.failNext = false
+		return errors.New("insufficient balance")
+	}
+	f.transfers = append(f.transfers, from+"->"+to+":"+amount)
+	return nil
+}
+
+func newTestAgreementService() (*AgreementService, *fakeAgreementLedger) {
+	fl := &fakeAgreementLedger{}
+	return NewAgreementService(NewAgreementMemoryStore(), fl), fl
+}
+
+func proposeTestAgreement(t *testing.T, svc *AgreementService, req ProposeAgreementRequest) *Agreement {
+	t.Helper()
+	if req.BuyerAddr == "" {
+		req.BuyerAddr = "0xbuyer"
+	}
+	if req.SellerAddr == "" {
+		req.SellerAddr = "0xseller"
+	}
+	if req.ServiceName == "" {
+		req.ServiceName = "translation"
+	}
+	if req.PricePerCall == "" {
+		req.PricePerCall = "0.50"
+	}
+	if req.TermDays == 0 {
+		req.TermDays = 30
+	}
+	a, err := svc.Propose(context.Background(), req.BuyerAddr, req)
+	if err != nil {
+		t.Fatalf("Propose: %v", err)
+	}
+	return a
+}
+
+func TestAgreementLifecycle(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{})
+	if a.Status != AgreementProposed {
+		t.Fatalf("status = %s, want proposed", a.Status)
+	}
+
+	// The proposer cannot accept their own proposal.
+	if _, err := svc.Accept(ctx, a.ID, "0xbuyer"); !errors.Is(err, ErrConsentRequired) {
+		t.Errorf("self-accept error = %v, want ErrConsentRequired", err)
+	}
+	// A stranger cannot accept either.
+	if _, err := svc.Accept(ctx, a.ID, "0xother"); !errors.Is(err, ErrNotParty) {
+		t.Errorf("stranger accept error = %v, want ErrNotParty", err)
+	}
+
+	a, err := svc.Accept(ctx, a.ID, "0xSELLER") // case-insensitive
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if a.Status != AgreementActive || a.ExpiresAt == nil {
+		t.Errorf("accepted agreement = %s with expiry %v, want active with expiry", a.Status, a.ExpiresAt)
+	}
+
+	a, err = svc.Terminate(ctx, a.ID, "0xbuyer")
+	if err != nil {
+		t.Fatalf("Terminate: %v", err)
+	}
+	if a.Status != AgreementTerminated {
+		t.Errorf("status = %s, want terminated", a.Status)
+	}
+}
+
+func TestRecordCallBillsAndBreaches(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{
+		MinSuccessRate: 0.8,
+		WindowSize:     5,
+	})
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	// Only the seller reports calls.
+	if _, err := svc.RecordCall(ctx, a.ID, "0xbuyer", true, 100); !errors.Is(err, ErrNotParty) {
+		t.Errorf("buyer RecordCall error = %v, want ErrNotParty", err)
+	}
+
+	// Three successes, then two failures: 3/5 < 0.8 over a full window.
+	for _, ok := range []bool{true, true, true, false} {
+		if _, err := svc.RecordCall(ctx, a.ID, "0xseller", ok, 100); err != nil {
+			t.Fatalf("RecordCall: %v", err)
+		}
+	}
+	a, err := svc.RecordCall(ctx, a.ID, "0xseller", false, 100)
+	if err != nil {
+		t.Fatalf("RecordCall: %v", err)
+	}
+
+	if a.Status != AgreementBreached {
+		t.Errorf("status = %s, want breached", a.Status)
+	}
+	if len(fl.transfers) != 5 {
+		t.Errorf("transfers = %d, want 5 (every call billed)", len(fl.transfers))
+	}
+	if a.Spent != "2.500000" {
+		t.Errorf("spent = %s, want 2.500000", a.Spent)
+	}
+	// Billing stops once breached.
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 100); !errors.Is(err, ErrAgreementNotActive) {
+		t.Errorf("post-breach RecordCall error = %v, want ErrAgreementNotActive", err)
+	}
+}
+
+func TestRecordCallRespectsBudget(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{
+		PricePerCall: "1.00",
+		Budget:       "2.00",
+	})
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); err != nil {
+			t.Fatalf("RecordCall %d: %v", i, err)
+		}
+	}
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("over-budget error = %v, want ErrBudgetExhausted", err)
+	}
+	if len(fl.transfers) != 2 {
+		t.Errorf("transfers = %d, want 2", len(fl.transfers))
+	}
+}
+
+func TestRenewalExtendsWithConsent(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{TermDays: 10})
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 80); err != nil {
+		t.Fatalf("RecordCall: %v", err)
+	}
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	originalExpiry := *a.ExpiresAt
+
+	// Seller proposes a 20-day extension at a new price.
+	if _, err := svc.ProposeRenewal(ctx, a.ID, "0xseller", 20, "0.75", ""); err != nil {
+		t.Fatalf("ProposeRenewal: %v", err)
+	}
+	// Only one renewal may be pending at a time.
+	if _, err := svc.ProposeRenewal(ctx, a.ID, "0xbuyer", 5, "", ""); !errors.Is(err, ErrRenewalPending) {
+		t.Errorf("second renewal error = %v, want ErrRenewalPending", err)
+	}
+	// The proposer cannot accept their own renewal.
+	if _, err := svc.AcceptRenewal(ctx, a.ID, "0xseller"); !errors.Is(err, ErrConsentRequired) {
+		t.Errorf("self-accept renewal error = %v, want ErrConsentRequired", err)
+	}
+
+	a, err := svc.AcceptRenewal(ctx, a.ID, "0xbuyer")
+	if err != nil {
+		t.Fatalf("AcceptRenewal: %v", err)
+	}
+
+	wantExpiry := originalExpiry.Add(20 * 24 * time.Hour)
+	if !a.ExpiresAt.Equal(wantExpiry) {
+		t.Errorf("expiry = %v, want %v", a.ExpiresAt, wantExpiry)
+	}
+	if a.PricePerCall != "0.75" {
+		t.Errorf("pricePerCall = %s, want 0.75", a.PricePerCall)
+	}
+	// Call history and SLA state survive the renewal.
+	if a.TotalCalls != 1 || len(a.Window) != 1 {
+		t.Errorf("calls = %d, window = %d, want history preserved", a.TotalCalls, len(a.Window))
+	}
+	if a.Renewals[0].Status != RenewalAccepted {
+		t.Errorf("renewal status = %s, want accepted", a.Renewals[0].Status)
+	}
+}
+
+func TestAmendmentChangesTermsWithConsent(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{
+		MinSuccessRate: 0.8,
+		WindowSize:     5,
+	})
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	// An amendment must change something.
+	if _, err := svc.ProposeAmendment(ctx, a.ID, "0xbuyer", Amendment{}); !errors.Is(err, ErrInvalidAgreement) {
+		t.Errorf("empty amendment error = %v, want ErrInvalidAgreement", err)
+	}
+
+	lower := 0.6
+	if _, err := svc.ProposeAmendment(ctx, a.ID, "0xseller", Amendment{
+		PricePerCall:   "0.25",
+		MinSuccessRate: &lower,
+	}); err != nil {
+		t.Fatalf("ProposeAmendment: %v", err)
+	}
+	// Only one amendment may be pending at a time.
+	if _, err := svc.ProposeAmendment(ctx, a.ID, "0xbuyer", Amendment{Budget: "10.00"}); !errors.Is(err, ErrAmendmentPending) {
+		t.Errorf("second amendment error = %v, want ErrAmendmentPending", err)
+	}
+	// The proposer cannot accept their own amendment.
+	if _, err := svc.AcceptAmendment(ctx, a.ID, "0xseller"); !errors.Is(err, ErrConsentRequired) {
+		t.Errorf("self-accept amendment error = %v, want ErrConsentRequired", err)
+	}
+
+	a, err := svc.AcceptAmendment(ctx, a.ID, "0xbuyer")
+	if err != nil {
+		t.Fatalf("AcceptAmendment: %v", err)
+	}
+	if a.PricePerCall != "0.25" {
+		t.Errorf("pricePerCall = %s, want 0.25", a.PricePerCall)
+	}
+	if a.SLA.MinSuccessRate != 0.6 {
+		t.Errorf("minSuccessRate = %f, want 0.6", a.SLA.MinSuccessRate)
+	}
+	if a.Amendments[0].Status != RenewalAccepted {
+		t.Errorf("amendment status = %s, want accepted", a.Amendments[0].Status)
+	}
+
+	// The relaxed SLA governs subsequent checks: 3/5 >= 0.6 is no breach.
+	for _, ok := range []bool{true, true, true, false, false} {
+		if a, err = svc.RecordCall(ctx, a.ID, "0xseller", ok, 100); err != nil {
+			t.Fatalf("RecordCall: %v", err)
+		}
+	}
+	if a.Status != AgreementActive {
+		t.Errorf("status = %s, want active under amended SLA", a.Status)
+	}
+}
+
+func TestDeclineAmendmentKeepsTerms(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{})
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	if _, err := svc.ProposeAmendment(ctx, a.ID, "0xseller", Amendment{PricePerCall: "9.99"}); err != nil {
+		t.Fatalf("ProposeAmendment: %v", err)
+	}
+	a, err := svc.DeclineAmendment(ctx, a.ID, "0xbuyer")
+	if err != nil {
+		t.Fatalf("DeclineAmendment: %v", err)
+	}
+	if a.PricePerCall != "0.500000" {
+		t.Errorf("pricePerCall = %s, want unchanged 0.500000", a.PricePerCall)
+	}
+	if a.Amendments[0].Status != RenewalDeclined {
+		t.Errorf("amendment status = %s, want declined", a.Amendments[0].Status)
+	}
+}
+
+func TestDeclineRenewalKeepsTerms(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{TermDays: 10})
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	originalExpiry := *a.ExpiresAt
+
+	if _, err := svc.ProposeRenewal(ctx, a.ID, "0xbuyer", 15, "", ""); err != nil {
+		t.Fatalf("ProposeRenewal: %v", err)
+	}
+	a, err := svc.DeclineRenewal(ctx, a.ID, "0xseller")
+	if err != nil {
+		t.Fatalf("DeclineRenewal: %v", err)
+	}
+
+	if !a.ExpiresAt.Equal(originalExpiry) {
+		t.Errorf("expiry changed to %v after decline, want %v", a.ExpiresAt, originalExpiry)
+	}
+	if a.Renewals[0].Status != RenewalDeclined {
+		t.Errorf("renewal status = %s, want declined", a.Renewals[0].Status)
+	}
+	// A fresh renewal can be proposed after the decline.
+	if _, err := svc.ProposeRenewal(ctx, a.ID, "0xbuyer", 5, "", ""); err != nil {
+		t.Errorf("renewal after decline: %v", err)
+	}
+}
+
+func TestExpireDue(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{TermDays: 1})
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	expired, err := svc.ExpireDue(ctx, time.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("ExpireDue: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expired = %d, want 1", expired)
+	}
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	if a.Status != AgreementExpired {
+		t.Errorf("status = %s, want expired", a.Status)
+	}
+}
@@ -0,0 +1,254 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// Agreement disputes mirror the escrow arbitration flow: either party
+// disputes recorded calls or a penalty assessment, both sides submit
+// evidence, and a neutral arbitrator resolves with an adjusted
+// settlement. Unlike escrow there are no locked funds — billing already
+// happened — so resolution moves money directly between the parties.
+
+// DisputeStatus represents the dispute lifecycle state.
+type DisputeStatus string
+
+const (
+	DisputeOpen        DisputeStatus = "open"
+	DisputeArbitrating DisputeStatus = "arbitrating"
+	DisputeResolved    DisputeStatus = "resolved"
+	DisputeDismissed   DisputeStatus = "dismissed"
+)
+
+// Dispute subjects: what the raising party is contesting.
+const (
+	DisputeSubjectCalls   = "calls"   // Recorded calls (billing or success flags)
+	DisputeSubjectPenalty = "penalty" // A latency penalty assessment
+)
+
+// defaultArbitrationWindow is how long an assigned arbitrator has to
+// resolve before the deadline passes.
+const defaultArbitrationWindow = 72 * time.Hour
+
+// maxDisputeTextLength bounds dispute reasons and evidence content.
+const maxDisputeTextLength = 2000
+
+// DisputeEvidence is one piece of evidence submitted during a dispute.
+type DisputeEvidence struct {
+	SubmittedBy string    `json:"submittedBy"`
+	Content     string    `json:"content"`
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+// Dispute records one contested billing or penalty on an agreement.
+type Dispute struct {
+	RaisedBy       string            `json:"raisedBy"`
+	Subject        string            `json:"subject"`
+	Reason         string            `json:"reason"`
+	Evidence       []DisputeEvidence `json:"evidence,omitempty"`
+	ArbitratorAddr string            `json:"arbitratorAddr,omitempty"`
+	Deadline       *time.Time        `json:"deadline,omitempty"` // Arbitration deadline once assigned
+	Status         DisputeStatus     `json:"status"`
+	Resolution     string            `json:"resolution,omitempty"` // refund_buyer, credit_seller, or dismissed
+	Award          string            `json:"award,omitempty"`      // USDC moved at resolution
+	Note           string            `json:"note,omitempty"`       // Arbitrator's reasoning
+	RaisedAt       time.Time         `json:"raisedAt"`
+	ResolvedAt     *time.Time        `json:"resolvedAt,omitempty"`
+}
+
+// OpenDispute returns the unresolved dispute, if any.
+func (a *Agreement) OpenDispute() *Dispute {
+	if n := len(a.Disputes); n > 0 {
+		d := &a.Disputes[n-1]
+		if d.Status == DisputeOpen || d.Status == DisputeArbitrating {
+			return d
+		}
+	}
+	return nil
+}
+
+// RaiseDispute opens a dispute on an agreement. Either party may raise
+// one; only one dispute can be open at a time. Disputes can be raised on
+// past agreements too — the billing being contested already happened.
+func (s *AgreementService) RaiseDispute(ctx context.Context, id, actor, subject, reason string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == AgreementProposed || a.Status == AgreementRejected {
+		return nil, fmt.Errorf("%w: nothing billed yet to dispute", ErrInvalidAgreement)
+	}
+	if !a.IsParty(actor) {
+		return nil, ErrNotParty
+	}
+	if a.OpenDispute() != nil {
+		return nil, ErrDisputeOpen
+	}
+	if subject != DisputeSubjectCalls && subject != DisputeSubjectPenalty {
+		return nil, fmt.Errorf("%w: subject must be %q or %q", ErrInvalidAgreement, DisputeSubjectCalls, DisputeSubjectPenalty)
+	}
+	if len(reason) == 0 || len(reason) > maxDisputeTextLength {
+		return nil, fmt.Errorf("%w: reason must be between 1 and %d characters", ErrInvalidAgreement, maxDisputeTextLength)
+	}
+
+	actor = strings.ToLower(actor)
+	now := time.Now()
+	a.Disputes = append(a.Disputes, Dispute{
+		RaisedBy: actor,
+		Subject:  subject,
+		Reason:   reason,
+		Evidence: []DisputeEvidence{{
+			SubmittedBy: actor,
+			Content:     reason,
+			SubmittedAt: now,
+		}},
+		Status:   DisputeOpen,
+		RaisedAt: now,
+	})
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	s.logger.Info("agreement dispute raised",
+		"agreement_id", a.ID, "raised_by", actor, "subject", subject)
+	return a, nil
+}
+
+// SubmitDisputeEvidence adds evidence to the open dispute. Both parties
+// may submit until the dispute is resolved.
+func (s *AgreementService) SubmitDisputeEvidence(ctx context.Context, id, actor, content string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !a.IsParty(actor) {
+		return nil, ErrNotParty
+	}
+	d := a.OpenDispute()
+	if d == nil {
+		return nil, ErrNoOpenDispute
+	}
+	if len(content) == 0 || len(content) > maxDisputeTextLength {
+		return nil, fmt.Errorf("%w: evidence must be between 1 and %d characters", ErrInvalidAgreement, maxDisputeTextLength)
+	}
+
+	now := time.Now()
+	d.Evidence = append(d.Evidence, DisputeEvidence{
+		SubmittedBy: strings.ToLower(actor),
+		Content:     content,
+		SubmittedAt: now,
+	})
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// AssignDisputeArbitrator assigns a neutral arbitrator to the open
+// dispute. Either party may assign; the arbitrator must not be a party.
+func (s *AgreementService) AssignDisputeArbitrator(ctx context.Context, id, actor, arbitratorAddr string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !a.IsParty(actor) {
+		return nil, ErrNotParty
+	}
+	d := a.OpenDispute()
+	if d == nil {
+		return nil, ErrNoOpenDispute
+	}
+	if d.Status != DisputeOpen {
+		return nil, fmt.Errorf("%w: arbitrator already assigned", ErrInvalidAgreement)
+	}
+	arb := strings.ToLower(arbitratorAddr)
+	if a.IsParty(arb) {
+		return nil, fmt.Errorf("%w: arbitrator cannot be a party", ErrInvalidAgreement)
+	}
+
+	now := time.Now()
+	deadline := now.Add(defaultArbitrationWindow)
+	d.Status = DisputeArbitrating
+	d.ArbitratorAddr = arb
+	d.Deadline = &deadline
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ResolveDispute settles the open dispute. Only the assigned arbitrator
+// may resolve. A refund_buyer resolution moves the award from the seller
+// to the buyer; credit_seller moves it the other way (e.g. a penalty
+// assessed for downtime the buyer caused); dismissed moves nothing.
+func (s *AgreementService) ResolveDispute(ctx context.Context, id, actor, resolution, amount, note string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	d := a.OpenDispute()
+	if d == nil {
+		return nil, ErrNoOpenDispute
+	}
+	if d.ArbitratorAddr == "" || strings.ToLower(actor) != d.ArbitratorAddr {
+		return nil, ErrNotArbitrator
+	}
+
+	now := time.Now()
+	switch resolution {
+	case "refund_buyer", "credit_seller":
+		award, ok := usdc.Parse(amount)
+		if !ok || award.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: resolution %q requires a positive award amount", ErrInvalidAgreement, resolution)
+		}
+		from, to := a.SellerAddr, a.BuyerAddr
+		if resolution == "credit_seller" {
+			from, to = a.BuyerAddr, a.SellerAddr
+		}
+		awardStr := usdc.Format(award)
+		if err := s.ledger.Transfer(ctx, from, to, awardStr, "agreement:"+a.ID+":dispute"); err != nil {
+			return nil, fmt.Errorf("dispute settlement failed: %w", err)
+		}
+		d.Status = DisputeResolved
+		d.Award = awardStr
+	case "dismissed":
+		d.Status = DisputeDismissed
+	default:
+		return nil, fmt.Errorf("%w: resolution must be refund_buyer, credit_seller, or dismissed", ErrInvalidAgreement)
+	}
+
+	d.Resolution = resolution
+	d.Note = note
+	d.ResolvedAt = &now
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	s.logger.Info("agreement dispute resolved",
+		"agreement_id", a.ID, "resolution", resolution, "award", d.Award,
+		"arbitrator", d.ArbitratorAddr)
+	return a, nil
+}
@@ -0,0 +1,109 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDisputeArbitrationFlow(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", false, 100); err != nil {
+		t.Fatalf("RecordCall: %v", err)
+	}
+
+	a, err := svc.RaiseDispute(ctx, a.ID, "0xbuyer", DisputeSubjectCalls, "call 1 never ran")
+	if err != nil {
+		t.Fatalf("RaiseDispute: %v", err)
+	}
+	d := a.OpenDispute()
+	if d == nil || d.Status != DisputeOpen {
+		t.Fatal("expected an open dispute")
+	}
+
+	// Second dispute is refused while one is open.
+	if _, err := svc.RaiseDispute(ctx, a.ID, "0xseller", DisputeSubjectCalls, "counter"); !errors.Is(err, ErrDisputeOpen) {
+		t.Errorf("second dispute error = %v, want ErrDisputeOpen", err)
+	}
+
+	if _, err := svc.SubmitDisputeEvidence(ctx, a.ID, "0xseller", "request log shows a 200"); err != nil {
+		t.Fatalf("SubmitDisputeEvidence: %v", err)
+	}
+
+	// Parties cannot arbitrate their own dispute.
+	if _, err := svc.AssignDisputeArbitrator(ctx, a.ID, "0xbuyer", "0xseller"); !errors.Is(err, ErrInvalidAgreement) {
+		t.Errorf("party as arbitrator error = %v, want ErrInvalidAgreement", err)
+	}
+	a, err = svc.AssignDisputeArbitrator(ctx, a.ID, "0xbuyer", "0xjudge")
+	if err != nil {
+		t.Fatalf("AssignDisputeArbitrator: %v", err)
+	}
+	if d := a.OpenDispute(); d.Status != DisputeArbitrating || d.Deadline == nil {
+		t.Error("expected arbitrating status with a deadline")
+	}
+
+	// Only the arbitrator resolves.
+	if _, err := svc.ResolveDispute(ctx, a.ID, "0xbuyer", "refund_buyer", "0.50", ""); !errors.Is(err, ErrNotArbitrator) {
+		t.Errorf("party resolve error = %v, want ErrNotArbitrator", err)
+	}
+	a, err = svc.ResolveDispute(ctx, a.ID, "0xjudge", "refund_buyer", "0.50", "call was not delivered")
+	if err != nil {
+		t.Fatalf("ResolveDispute: %v", err)
+	}
+	last := a.Disputes[len(a.Disputes)-1]
+	if last.Status != DisputeResolved || last.Award != "0.500000" {
+		t.Errorf("dispute = %+v, want resolved with award 0.500000", last)
+	}
+
+	found := false
+	for _, tr := range fl.transfers {
+		if tr == "0xseller->0xbuyer:0.500000" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("transfers = %v, want refund 0xseller->0xbuyer:0.500000", fl.transfers)
+	}
+
+	// Evidence after resolution is refused.
+	if _, err := svc.SubmitDisputeEvidence(ctx, a.ID, "0xbuyer", "more"); !errors.Is(err, ErrNoOpenDispute) {
+		t.Errorf("evidence after resolve error = %v, want ErrNoOpenDispute", err)
+	}
+}
+
+func TestDisputeDismissedMovesNothing(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+	if _, err := svc.RaiseDispute(ctx, a.ID, "0xseller", DisputeSubjectPenalty, "penalty assessed during buyer outage"); err != nil {
+		t.Fatalf("RaiseDispute: %v", err)
+	}
+	if _, err := svc.AssignDisputeArbitrator(ctx, a.ID, "0xseller", "0xjudge"); err != nil {
+		t.Fatalf("AssignDisputeArbitrator: %v", err)
+	}
+	a, err := svc.ResolveDispute(ctx, a.ID, "0xjudge", "dismissed", "", "no basis")
+	if err != nil {
+		t.Fatalf("ResolveDispute: %v", err)
+	}
+	last := a.Disputes[len(a.Disputes)-1]
+	if last.Status != DisputeDismissed || last.Award != "" {
+		t.Errorf("dispute = %+v, want dismissed with no award", last)
+	}
+	if len(fl.transfers) != 0 {
+		t.Errorf("transfers = %v, want none", fl.transfers)
+	}
+}
+
+func TestDisputeRequiresBilledActivity(t *testing.T) {
+	svc, _ := newTestAgreementService()
+
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{})
+	_, err := svc.RaiseDispute(context.Background(), a.ID, "0xbuyer", DisputeSubjectCalls, "nothing yet")
+	if !errors.Is(err, ErrInvalidAgreement) {
+		t.Errorf("dispute on proposed agreement error = %v, want ErrInvalidAgreement", err)
+	}
+}
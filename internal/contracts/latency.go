@@ -0,0 +1,49 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// checkLatencySLALocked enforces the p95-latency SLA after a call is
+// recorded. When a full rolling window breaches maxLatencyMs, the agreed
+// penalty is credited from the seller to the buyer once per excursion:
+// the check re-arms only after p95 drops back under the ceiling, so a
+// sustained breach is not charged on every call. The caller must hold the
+// agreement lock.
+func (s *AgreementService) checkLatencySLALocked(ctx context.Context, a *Agreement) {
+	if a.SLA.MaxLatencyMs <= 0 || len(a.Window) < a.SLA.WindowSize {
+		return
+	}
+
+	p95 := a.WindowP95Latency()
+	if p95 <= a.SLA.MaxLatencyMs {
+		a.LatencyBreached = false
+		return
+	}
+	if a.LatencyBreached {
+		return
+	}
+
+	a.LatencyBreached = true
+	penalty, ok := usdc.Parse(a.SLA.LatencyPenalty)
+	if !ok || penalty.Sign() <= 0 {
+		return
+	}
+	if err := s.ledger.Transfer(ctx, a.SellerAddr, a.BuyerAddr, a.SLA.LatencyPenalty, "agreement:"+a.ID+":latency-penalty"); err != nil {
+		s.logger.Warn("latency penalty transfer failed",
+			"agreement_id", a.ID, "seller", a.SellerAddr, "error", err)
+		return
+	}
+
+	paid := big.NewInt(0)
+	if a.PenaltiesPaid != "" {
+		paid, _ = usdc.Parse(a.PenaltiesPaid)
+	}
+	a.PenaltiesPaid = usdc.Format(new(big.Int).Add(paid, penalty))
+	s.logger.Warn("agreement latency SLA breached",
+		"agreement_id", a.ID, "seller", a.SellerAddr,
+		"p95_ms", p95, "max_ms", a.SLA.MaxLatencyMs, "penalty", a.SLA.LatencyPenalty)
+}
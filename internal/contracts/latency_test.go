@@ -0,0 +1,105 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func proposeLatencyAgreement(t *testing.T, svc *AgreementService) *Agreement {
+	t.Helper()
+	ctx := context.Background()
+	a, err := svc.Propose(ctx, "0xbuyer", ProposeAgreementRequest{
+		BuyerAddr:      "0xbuyer",
+		SellerAddr:     "0xseller",
+		ServiceName:    "search",
+		PricePerCall:   "0.10",
+		TermDays:       30,
+		WindowSize:     4,
+		MaxLatencyMs:   200,
+		LatencyPenalty: "1.00",
+	})
+	if err != nil {
+		t.Fatalf("Propose: %v", err)
+	}
+	if _, err := svc.Accept(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	return a
+}
+
+func TestLatencySLARequiresPenalty(t *testing.T) {
+	svc, _ := newTestAgreementService()
+
+	_, err := svc.Propose(context.Background(), "0xbuyer", ProposeAgreementRequest{
+		BuyerAddr:    "0xbuyer",
+		SellerAddr:   "0xseller",
+		ServiceName:  "search",
+		PricePerCall: "0.10",
+		TermDays:     30,
+		MaxLatencyMs: 200,
+	})
+	if !errors.Is(err, ErrInvalidAgreement) {
+		t.Errorf("latency SLA without penalty error = %v, want ErrInvalidAgreement", err)
+	}
+}
+
+func TestLatencyPenaltyFiresOncePerExcursion(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeLatencyAgreement(t, svc)
+
+	// Fast calls fill the window without breaching.
+	for i := 0; i < 4; i++ {
+		if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); err != nil {
+			t.Fatalf("RecordCall: %v", err)
+		}
+	}
+
+	// Slow calls push p95 over 200ms; the penalty fires once, not per call.
+	for i := 0; i < 3; i++ {
+		if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 500); err != nil {
+			t.Fatalf("RecordCall: %v", err)
+		}
+	}
+
+	penalties := 0
+	for _, tr := range fl.transfers {
+		if tr == "0xseller->0xbuyer:1.00" {
+			penalties++
+		}
+	}
+	if penalties != 1 {
+		t.Errorf("penalty transfers = %d, want exactly 1", penalties)
+	}
+
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	if !a.LatencyBreached {
+		t.Error("latencyBreached = false, want true while over the ceiling")
+	}
+	if a.PenaltiesPaid != "1.000000" {
+		t.Errorf("penaltiesPaid = %s, want 1.000000", a.PenaltiesPaid)
+	}
+
+	// Fast calls clear the window; the check re-arms and a new excursion
+	// is charged again.
+	for i := 0; i < 4; i++ {
+		if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); err != nil {
+			t.Fatalf("RecordCall: %v", err)
+		}
+	}
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	if a.LatencyBreached {
+		t.Error("latencyBreached = true after recovery, want re-armed")
+	}
+	for i := 0; i < 4; i++ {
+		if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 500); err != nil {
+			t.Fatalf("RecordCall: %v", err)
+		}
+	}
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	if a.PenaltiesPaid != "2.000000" {
+		t.Errorf("penaltiesPaid = %s, want 2.000000 after second excursion", a.PenaltiesPaid)
+	}
+}
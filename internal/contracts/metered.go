@@ -0,0 +1,206 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrNotMetered       = errors.New("contracts: agreement is not metered")
+	ErrUsageCapExceeded = errors.New("contracts: usage cap exceeded for this period")
+)
+
+// BillingMode selects how an agreement bills the buyer.
+type BillingMode string
+
+const (
+	BillingPerCall BillingMode = "per_call" // Fixed price per recorded call
+	BillingMetered BillingMode = "metered"  // Price per reported usage unit, settled per period
+)
+
+// defaultMeterPeriodHours is the settlement period when the proposal does
+// not specify one.
+const defaultMeterPeriodHours = 24
+
+// MeterTerms defines usage-based billing for a metered agreement.
+type MeterTerms struct {
+	UnitName     string `json:"unitName"`          // e.g. "tokens", "characters", "compute-seconds"
+	PricePerUnit string `json:"pricePerUnit"`      // USDC per unit
+	UnitCap      int64  `json:"unitCap,omitempty"` // Max units per period the buyer accepts; 0 = unlimited
+	PeriodHours  int    `json:"periodHours"`       // Settlement period
+}
+
+// ReportUsage accumulates usage units against a metered agreement. Only
+// the seller may report; units are billed in bulk at the next period
+// settlement rather than per report. Reports that would push the current
+// period past the buyer's cap are rejected whole.
+func (s *AgreementService) ReportUsage(ctx context.Context, id, actor string, units int64) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == AgreementPaused {
+		return nil, ErrAgreementPaused
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	if strings.ToLower(actor) != a.SellerAddr {
+		return nil, ErrNotParty
+	}
+	if a.BillingMode != BillingMetered || a.Meter == nil {
+		return nil, ErrNotMetered
+	}
+	if units <= 0 {
+		return nil, fmt.Errorf("%w: units must be positive", ErrInvalidAgreement)
+	}
+
+	now := time.Now()
+	if a.ExpiresAt != nil && now.After(*a.ExpiresAt) {
+		a.Status = AgreementExpired
+		a.UpdatedAt = now
+		if err := s.store.UpdateAgreement(ctx, a); err != nil {
+			return nil, err
+		}
+		return a, ErrAgreementExpired
+	}
+
+	if a.Meter.UnitCap > 0 && a.PendingUnits+units > a.Meter.UnitCap {
+		return nil, ErrUsageCapExceeded
+	}
+
+	a.PendingUnits += units
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// SetUsageCap lets the buyer adjust the per-period unit cap on a metered
+// agreement. The cap protects the buyer, so no counterparty consent is
+// needed.
+func (s *AgreementService) SetUsageCap(ctx context.Context, id, actor string, cap int64) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	if strings.ToLower(actor) != a.BuyerAddr {
+		return nil, ErrNotParty
+	}
+	if a.BillingMode != BillingMetered || a.Meter == nil {
+		return nil, ErrNotMetered
+	}
+	if cap < 0 {
+		return nil, fmt.Errorf("%w: cap must not be negative", ErrInvalidAgreement)
+	}
+
+	a.Meter.UnitCap = cap
+	a.UpdatedAt = time.Now()
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// SettleUsageDue settles pending usage on metered agreements whose period
+// has elapsed. Called by the agreement timer; returns how many agreements
+// settled.
+func (s *AgreementService) SettleUsageDue(ctx context.Context, now time.Time) (int, error) {
+	active, err := s.store.ListActiveAgreements(ctx)
+	if err != nil {
+		return 0, err
+	}
+	settled := 0
+	for _, a := range active {
+		if a.BillingMode != BillingMetered || a.Meter == nil {
+			continue
+		}
+		if !a.usagePeriodElapsed(now) {
+			continue
+		}
+		mu := s.agreementLock(a.ID)
+		mu.Lock()
+		cur, err := s.store.GetAgreement(ctx, a.ID)
+		if err == nil && cur.Status == AgreementActive && cur.usagePeriodElapsed(now) {
+			if err := s.settlePendingUsageLocked(ctx, cur, now); err != nil {
+				s.logger.Warn("usage settlement failed", "agreement_id", cur.ID, "error", err)
+			} else {
+				settled++
+			}
+		}
+		mu.Unlock()
+	}
+	return settled, nil
+}
+
+// usagePeriodElapsed reports whether the settlement period has passed
+// since the last settlement (or activation).
+func (a *Agreement) usagePeriodElapsed(now time.Time) bool {
+	since := a.StartedAt
+	if a.LastSettledAt != nil {
+		since = a.LastSettledAt
+	}
+	if since == nil {
+		return false
+	}
+	return now.Sub(*since) >= time.Duration(a.Meter.PeriodHours)*time.Hour
+}
+
+// settlePendingUsageLocked bills accumulated units to the buyer. The
+// caller must hold the agreement lock. If the remaining budget does not
+// cover the full amount, the budget remainder is billed and the agreement
+// terminates.
+func (s *AgreementService) settlePendingUsageLocked(ctx context.Context, a *Agreement, now time.Time) error {
+	a.LastSettledAt = &now
+	a.UpdatedAt = now
+
+	if a.PendingUnits <= 0 {
+		return s.store.UpdateAgreement(ctx, a)
+	}
+
+	pricePerUnit, _ := usdc.Parse(a.Meter.PricePerUnit)
+	amount := new(big.Int).Mul(pricePerUnit, big.NewInt(a.PendingUnits))
+	spent, _ := usdc.Parse(a.Spent)
+
+	exhausted := false
+	if a.Budget != "" {
+		budget, _ := usdc.Parse(a.Budget)
+		remaining := new(big.Int).Sub(budget, spent)
+		if amount.Cmp(remaining) >= 0 {
+			amount = remaining
+			exhausted = true
+		}
+	}
+
+	if amount.Sign() > 0 {
+		if err := s.ledger.Transfer(ctx, a.BuyerAddr, a.SellerAddr, usdc.Format(amount), "agreement:"+a.ID+":usage"); err != nil {
+			return fmt.Errorf("billing usage failed: %w", err)
+		}
+		a.Spent = usdc.Format(new(big.Int).Add(spent, amount))
+	}
+	a.PendingUnits = 0
+	if exhausted {
+		a.Status = AgreementTerminated
+		s.logger.Info("metered agreement budget exhausted",
+			"agreement_id", a.ID, "buyer", a.BuyerAddr, "spent", a.Spent)
+	}
+	return s.store.UpdateAgreement(ctx, a)
+}
@@ -0,0 +1,156 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func proposeMeteredAgreement(t *testing.T, svc *AgreementService, meter MeterTerms, budget string) *Agreement {
+	t.Helper()
+	a, err := svc.Propose(context.Background(), "0xbuyer", ProposeAgreementRequest{
+		BuyerAddr:   "0xbuyer",
+		SellerAddr:  "0xseller",
+		ServiceName: "inference",
+		BillingMode: string(BillingMetered),
+		Meter:       &meter,
+		Budget:      budget,
+		TermDays:    30,
+	})
+	if err != nil {
+		t.Fatalf("Propose: %v", err)
+	}
+	if _, err := svc.Accept(context.Background(), a.ID, "0xseller"); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	return a
+}
+
+func TestMeteredProposeValidation(t *testing.T) {
+	svc, _ := newTestAgreementService()
+
+	_, err := svc.Propose(context.Background(), "0xbuyer", ProposeAgreementRequest{
+		BuyerAddr:   "0xbuyer",
+		SellerAddr:  "0xseller",
+		ServiceName: "inference",
+		BillingMode: string(BillingMetered),
+		TermDays:    30,
+	})
+	if !errors.Is(err, ErrInvalidAgreement) {
+		t.Errorf("metered without meter terms error = %v, want ErrInvalidAgreement", err)
+	}
+}
+
+func TestReportUsageAndSettle(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeMeteredAgreement(t, svc, MeterTerms{
+		UnitName:     "tokens",
+		PricePerUnit: "0.000100",
+		UnitCap:      1000,
+		PeriodHours:  1,
+	}, "")
+
+	// Per-call reporting is the wrong endpoint for metered agreements.
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 100); !errors.Is(err, ErrInvalidAgreement) {
+		t.Errorf("RecordCall on metered error = %v, want ErrInvalidAgreement", err)
+	}
+	// Only the seller reports usage.
+	if _, err := svc.ReportUsage(ctx, a.ID, "0xbuyer", 100); !errors.Is(err, ErrNotParty) {
+		t.Errorf("buyer ReportUsage error = %v, want ErrNotParty", err)
+	}
+
+	if _, err := svc.ReportUsage(ctx, a.ID, "0xseller", 500); err != nil {
+		t.Fatalf("ReportUsage: %v", err)
+	}
+	// 500 pending + 600 would exceed the 1000-unit cap.
+	if _, err := svc.ReportUsage(ctx, a.ID, "0xseller", 600); !errors.Is(err, ErrUsageCapExceeded) {
+		t.Errorf("over-cap error = %v, want ErrUsageCapExceeded", err)
+	}
+
+	// The period has not elapsed yet: nothing settles.
+	if n, err := svc.SettleUsageDue(ctx, time.Now()); err != nil || n != 0 {
+		t.Errorf("early SettleUsageDue = %d, %v, want 0 settled", n, err)
+	}
+
+	n, err := svc.SettleUsageDue(ctx, time.Now().Add(2*time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("SettleUsageDue = %d, %v, want 1 settled", n, err)
+	}
+	if len(fl.transfers) != 1 || fl.transfers[0] != "0xbuyer->0xseller:0.050000" {
+		t.Errorf("transfers = %v, want one 0.050000 settlement", fl.transfers)
+	}
+
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	if a.PendingUnits != 0 {
+		t.Errorf("pendingUnits = %d, want 0 after settlement", a.PendingUnits)
+	}
+	if a.Spent != "0.050000" {
+		t.Errorf("spent = %s, want 0.050000", a.Spent)
+	}
+	if a.LastSettledAt == nil {
+		t.Error("lastSettledAt not set after settlement")
+	}
+	// The cap re-arms for the new period.
+	if _, err := svc.ReportUsage(ctx, a.ID, "0xseller", 600); err != nil {
+		t.Errorf("ReportUsage after settlement: %v", err)
+	}
+}
+
+func TestMeteredBudgetExhaustion(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeMeteredAgreement(t, svc, MeterTerms{
+		UnitName:     "tokens",
+		PricePerUnit: "0.000100",
+		PeriodHours:  1,
+	}, "0.03")
+
+	// 500 tokens would bill 0.05, but the budget only has 0.03 left.
+	if _, err := svc.ReportUsage(ctx, a.ID, "0xseller", 500); err != nil {
+		t.Fatalf("ReportUsage: %v", err)
+	}
+	if n, err := svc.SettleUsageDue(ctx, time.Now().Add(2*time.Hour)); err != nil || n != 1 {
+		t.Fatalf("SettleUsageDue = %d, %v, want 1 settled", n, err)
+	}
+
+	if len(fl.transfers) != 1 || fl.transfers[0] != "0xbuyer->0xseller:0.030000" {
+		t.Errorf("transfers = %v, want budget remainder 0.030000", fl.transfers)
+	}
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	if a.Status != AgreementTerminated {
+		t.Errorf("status = %s, want terminated on budget exhaustion", a.Status)
+	}
+	if a.Spent != "0.030000" {
+		t.Errorf("spent = %s, want 0.030000", a.Spent)
+	}
+}
+
+func TestTerminateSettlesPendingUsage(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := proposeMeteredAgreement(t, svc, MeterTerms{
+		UnitName:     "compute-seconds",
+		PricePerUnit: "0.001000",
+		PeriodHours:  24,
+	}, "")
+
+	if _, err := svc.ReportUsage(ctx, a.ID, "0xseller", 40); err != nil {
+		t.Fatalf("ReportUsage: %v", err)
+	}
+	a, err := svc.Terminate(ctx, a.ID, "0xbuyer")
+	if err != nil {
+		t.Fatalf("Terminate: %v", err)
+	}
+
+	if len(fl.transfers) != 1 || fl.transfers[0] != "0xbuyer->0xseller:0.040000" {
+		t.Errorf("transfers = %v, want final 0.040000 settlement", fl.transfers)
+	}
+	if a.PendingUnits != 0 {
+		t.Errorf("pendingUnits = %d, want 0 after final settlement", a.PendingUnits)
+	}
+}
@@ -0,0 +1,201 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// Call packs let a buyer prepay for N calls at a discounted per-call
+// rate. The buyer proposes the pack, the seller accepts — acceptance is
+// when the upfront payment moves. Recorded calls consume the pack before
+// any per-call billing, and unused calls are refunded pro-rata when the
+// agreement ends.
+
+// CallPack records one prepaid block of calls on a per-call agreement.
+type CallPack struct {
+	ProposedBy   string        `json:"proposedBy"`
+	Calls        int           `json:"calls"`
+	Remaining    int           `json:"remaining"`
+	PricePerCall string        `json:"pricePerCall"` // Discounted; below the agreement's rate
+	Status       RenewalStatus `json:"status"`
+	ProposedAt   time.Time     `json:"proposedAt"`
+	DecidedAt    *time.Time    `json:"decidedAt,omitempty"`
+	Refunded     string        `json:"refunded,omitempty"` // Pro-rata refund paid at agreement end
+}
+
+// PendingPack returns the open pack proposal, if any.
+func (a *Agreement) PendingPack() *CallPack {
+	if n := len(a.Packs); n > 0 && a.Packs[n-1].Status == RenewalPending {
+		return &a.Packs[n-1]
+	}
+	return nil
+}
+
+// ActivePack returns the accepted pack with calls remaining, if any.
+func (a *Agreement) ActivePack() *CallPack {
+	for i := range a.Packs {
+		p := &a.Packs[i]
+		if p.Status == RenewalAccepted && p.Remaining > 0 {
+			return p
+		}
+	}
+	return nil
+}
+
+// ProposePack opens a prepaid-pack proposal. Only the buyer may propose —
+// it is the buyer's money going up front — and the price must be a real
+// discount against the agreement's per-call rate.
+func (s *AgreementService) ProposePack(ctx context.Context, id, actor string, calls int, pricePerCall string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	if strings.ToLower(actor) != a.BuyerAddr {
+		return nil, ErrNotParty
+	}
+	if a.BillingMode != BillingPerCall {
+		return nil, fmt.Errorf("%w: call packs require per-call billing", ErrInvalidAgreement)
+	}
+	if a.PendingPack() != nil {
+		return nil, ErrPackPending
+	}
+	if calls <= 0 {
+		return nil, fmt.Errorf("%w: calls must be positive", ErrInvalidAgreement)
+	}
+	price, ok := usdc.Parse(pricePerCall)
+	if !ok || price.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: invalid pricePerCall %q", ErrInvalidAgreement, pricePerCall)
+	}
+	rate, _ := usdc.Parse(a.PricePerCall)
+	if price.Cmp(rate) >= 0 {
+		return nil, fmt.Errorf("%w: pack price must be below the per-call rate", ErrInvalidAgreement)
+	}
+
+	a.Packs = append(a.Packs, CallPack{
+		ProposedBy:   strings.ToLower(actor),
+		Calls:        calls,
+		Remaining:    calls,
+		PricePerCall: usdc.Format(price),
+		Status:       RenewalPending,
+		ProposedAt:   time.Now(),
+	})
+	a.UpdatedAt = time.Now()
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// AcceptPack accepts a pending pack and bills the buyer up front for the
+// whole block. Only the seller may accept.
+func (s *AgreementService) AcceptPack(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	p := a.PendingPack()
+	if p == nil {
+		return nil, ErrNoPendingPack
+	}
+	if err := requireCounterparty(a, p.ProposedBy, actor); err != nil {
+		return nil, err
+	}
+
+	price, _ := usdc.Parse(p.PricePerCall)
+	total := new(big.Int).Mul(price, big.NewInt(int64(p.Calls)))
+	spent, _ := usdc.Parse(a.Spent)
+	newSpent := new(big.Int).Add(spent, total)
+	if a.Budget != "" {
+		budget, _ := usdc.Parse(a.Budget)
+		if newSpent.Cmp(budget) > 0 {
+			return nil, ErrBudgetExhausted
+		}
+	}
+
+	if err := s.ledger.Transfer(ctx, a.BuyerAddr, a.SellerAddr, usdc.Format(total), "agreement:"+a.ID+":pack"); err != nil {
+		return nil, fmt.Errorf("billing pack failed: %w", err)
+	}
+
+	now := time.Now()
+	a.Spent = usdc.Format(newSpent)
+	p.Status = RenewalAccepted
+	p.DecidedAt = &now
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	s.logger.Info("call pack purchased",
+		"agreement_id", a.ID, "calls", p.Calls, "price_per_call", p.PricePerCall)
+	return a, nil
+}
+
+// DeclinePack rejects a pending pack proposal; nothing is billed.
+func (s *AgreementService) DeclinePack(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p := a.PendingPack()
+	if p == nil {
+		return nil, ErrNoPendingPack
+	}
+	if err := requireCounterparty(a, p.ProposedBy, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	p.Status = RenewalDeclined
+	p.DecidedAt = &now
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// refundUnusedPacksLocked refunds remaining prepaid calls pro-rata when
+// an agreement ends. The caller must hold the agreement lock; the caller
+// persists the agreement.
+func (s *AgreementService) refundUnusedPacksLocked(ctx context.Context, a *Agreement) {
+	for i := range a.Packs {
+		p := &a.Packs[i]
+		if p.Status != RenewalAccepted || p.Remaining <= 0 {
+			continue
+		}
+		price, _ := usdc.Parse(p.PricePerCall)
+		refund := new(big.Int).Mul(price, big.NewInt(int64(p.Remaining)))
+		refundStr := usdc.Format(refund)
+		if err := s.ledger.Transfer(ctx, a.SellerAddr, a.BuyerAddr, refundStr, "agreement:"+a.ID+":pack-refund"); err != nil {
+			s.logger.Warn("pack refund failed",
+				"agreement_id", a.ID, "buyer", a.BuyerAddr, "refund", refundStr, "error", err)
+			continue
+		}
+		p.Refunded = refundStr
+		p.Remaining = 0
+		s.logger.Info("unused pack calls refunded",
+			"agreement_id", a.ID, "buyer", a.BuyerAddr, "refund", refundStr)
+	}
+}
@@ -0,0 +1,92 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPackConsumedBeforePerCallBilling(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+	if _, err := svc.ProposePack(ctx, a.ID, "0xbuyer", 2, "0.40"); err != nil {
+		t.Fatalf("ProposePack: %v", err)
+	}
+	a, err := svc.AcceptPack(ctx, a.ID, "0xseller")
+	if err != nil {
+		t.Fatalf("AcceptPack: %v", err)
+	}
+	if len(fl.transfers) != 1 || fl.transfers[0] != "0xbuyer->0xseller:0.800000" {
+		t.Fatalf("transfers after purchase = %v, want upfront 0.800000", fl.transfers)
+	}
+
+	// Two calls consume the pack with no further billing.
+	for i := 0; i < 2; i++ {
+		if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); err != nil {
+			t.Fatalf("RecordCall: %v", err)
+		}
+	}
+	if len(fl.transfers) != 1 {
+		t.Errorf("transfers after pack calls = %v, want no per-call billing", fl.transfers)
+	}
+	a, _ = svc.GetAgreement(ctx, a.ID)
+	if p := a.ActivePack(); p != nil {
+		t.Errorf("remaining = %d, want exhausted pack", p.Remaining)
+	}
+	if a.Window[len(a.Window)-1].Cost != "0.400000" {
+		t.Errorf("call cost = %s, want pack rate 0.400000", a.Window[len(a.Window)-1].Cost)
+	}
+
+	// Third call falls back to per-call billing at the agreement rate.
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); err != nil {
+		t.Fatalf("RecordCall: %v", err)
+	}
+	if len(fl.transfers) != 2 || fl.transfers[1] != "0xbuyer->0xseller:0.500000" {
+		t.Errorf("transfers = %v, want per-call 0.500000 after pack exhausted", fl.transfers)
+	}
+}
+
+func TestPackRequiresDiscount(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+	if _, err := svc.ProposePack(ctx, a.ID, "0xbuyer", 10, "0.50"); !errors.Is(err, ErrInvalidAgreement) {
+		t.Errorf("pack at full rate error = %v, want ErrInvalidAgreement", err)
+	}
+	if _, err := svc.ProposePack(ctx, a.ID, "0xseller", 10, "0.40"); !errors.Is(err, ErrNotParty) {
+		t.Errorf("seller-proposed pack error = %v, want ErrNotParty", err)
+	}
+}
+
+func TestPackRefundedProRataOnTermination(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+	if _, err := svc.ProposePack(ctx, a.ID, "0xbuyer", 5, "0.40"); err != nil {
+		t.Fatalf("ProposePack: %v", err)
+	}
+	if _, err := svc.AcceptPack(ctx, a.ID, "0xseller"); err != nil {
+		t.Fatalf("AcceptPack: %v", err)
+	}
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); err != nil {
+		t.Fatalf("RecordCall: %v", err)
+	}
+
+	a, err := svc.Terminate(ctx, a.ID, "0xbuyer")
+	if err != nil {
+		t.Fatalf("Terminate: %v", err)
+	}
+	// 4 unused calls at 0.40 come back to the buyer.
+	last := fl.transfers[len(fl.transfers)-1]
+	if last != "0xseller->0xbuyer:1.600000" {
+		t.Errorf("refund transfer = %s, want 0xseller->0xbuyer:1.600000", last)
+	}
+	p := a.Packs[len(a.Packs)-1]
+	if p.Refunded != "1.600000" || p.Remaining != 0 {
+		t.Errorf("pack = %+v, want refunded 1.600000 and zero remaining", p)
+	}
+}
@@ -0,0 +1,101 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Pause suspends an active agreement for announced downtime. Only the
+// seller may pause — the point is to stop SLA evaluation and billing
+// during planned maintenance so the seller is not penalized for it. A
+// pause is refused once the cumulative allowance is used up.
+func (s *AgreementService) Pause(ctx context.Context, id, actor, reason string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == AgreementPaused {
+		return nil, ErrAgreementPaused
+	}
+	if a.Status != AgreementActive {
+		return nil, ErrAgreementNotActive
+	}
+	if strings.ToLower(actor) != a.SellerAddr {
+		return nil, ErrNotParty
+	}
+	if a.pauseAllowanceRemaining() <= 0 {
+		return nil, ErrPauseExhausted
+	}
+
+	now := time.Now()
+	a.Status = AgreementPaused
+	a.PausedAt = &now
+	a.PauseReason = reason
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	s.logger.Info("agreement paused",
+		"agreement_id", a.ID, "seller", a.SellerAddr, "reason", reason)
+	return a, nil
+}
+
+// Resume reactivates a paused agreement. Either party may resume, so a
+// buyer is never stuck waiting out an overlong pause. The expiry moves
+// out by the time spent paused, capped at the remaining cumulative
+// allowance — downtime past the allowance eats into the term.
+func (s *AgreementService) Resume(ctx context.Context, id, actor string) (*Agreement, error) {
+	mu := s.agreementLock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	a, err := s.store.GetAgreement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != AgreementPaused || a.PausedAt == nil {
+		return nil, fmt.Errorf("%w: must be paused to resume, current: %s", ErrInvalidAgreement, a.Status)
+	}
+	if !a.IsParty(actor) {
+		return nil, ErrNotParty
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(*a.PausedAt)
+	credited := elapsed
+	if remaining := a.pauseAllowanceRemaining(); credited > remaining {
+		credited = remaining
+	}
+	if a.ExpiresAt != nil {
+		expires := a.ExpiresAt.Add(credited)
+		a.ExpiresAt = &expires
+	}
+	a.PausedSeconds += int64(elapsed.Seconds())
+	a.Status = AgreementActive
+	a.PausedAt = nil
+	a.PauseReason = ""
+	a.UpdatedAt = now
+	if err := s.store.UpdateAgreement(ctx, a); err != nil {
+		return nil, err
+	}
+	s.logger.Info("agreement resumed",
+		"agreement_id", a.ID, "paused_for", elapsed.Round(time.Second).String())
+	return a, nil
+}
+
+// pauseAllowanceRemaining returns how much of the cumulative pause
+// allowance is left.
+func (a *Agreement) pauseAllowanceRemaining() time.Duration {
+	max := time.Duration(a.MaxPauseHours) * time.Hour
+	used := time.Duration(a.PausedSeconds) * time.Second
+	if used >= max {
+		return 0
+	}
+	return max - used
+}
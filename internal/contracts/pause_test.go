@@ -0,0 +1,112 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func activeTestAgreement(t *testing.T, svc *AgreementService) *Agreement {
+	t.Helper()
+	a := proposeTestAgreement(t, svc, ProposeAgreementRequest{})
+	a, err := svc.Accept(context.Background(), a.ID, "0xseller")
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	return a
+}
+
+func TestPauseStopsBillingAndResumes(t *testing.T) {
+	svc, fl := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+	before := *a.ExpiresAt
+
+	a, err := svc.Pause(ctx, a.ID, "0xseller", "model upgrade")
+	if err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	if a.Status != AgreementPaused {
+		t.Errorf("status = %s, want paused", a.Status)
+	}
+	if a.PauseReason != "model upgrade" {
+		t.Errorf("pauseReason = %q", a.PauseReason)
+	}
+
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); !errors.Is(err, ErrAgreementPaused) {
+		t.Errorf("RecordCall while paused error = %v, want ErrAgreementPaused", err)
+	}
+	if len(fl.transfers) != 0 {
+		t.Errorf("transfers while paused = %d, want 0", len(fl.transfers))
+	}
+
+	a, err = svc.Resume(ctx, a.ID, "0xbuyer")
+	if err != nil {
+		t.Fatalf("Resume: %v", err)
+	}
+	if a.Status != AgreementActive {
+		t.Errorf("status after resume = %s, want active", a.Status)
+	}
+	if a.PausedAt != nil || a.PauseReason != "" {
+		t.Error("pause state not cleared on resume")
+	}
+	if a.ExpiresAt.Before(before) {
+		t.Error("expiry moved earlier across a pause")
+	}
+
+	if _, err := svc.RecordCall(ctx, a.ID, "0xseller", true, 50); err != nil {
+		t.Fatalf("RecordCall after resume: %v", err)
+	}
+	if len(fl.transfers) != 1 {
+		t.Errorf("transfers after resume = %d, want 1", len(fl.transfers))
+	}
+}
+
+func TestPauseSellerOnly(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+	if _, err := svc.Pause(ctx, a.ID, "0xbuyer", "nope"); !errors.Is(err, ErrNotParty) {
+		t.Errorf("buyer pause error = %v, want ErrNotParty", err)
+	}
+}
+
+func TestPauseAllowanceExhausted(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+
+	// Burn the whole allowance directly; the service only ever adds to it.
+	cur, _ := svc.store.GetAgreement(ctx, a.ID)
+	cur.PausedSeconds = int64(cur.MaxPauseHours) * 3600
+	if err := svc.store.UpdateAgreement(ctx, cur); err != nil {
+		t.Fatalf("UpdateAgreement: %v", err)
+	}
+
+	if _, err := svc.Pause(ctx, a.ID, "0xseller", "again"); !errors.Is(err, ErrPauseExhausted) {
+		t.Errorf("pause past allowance error = %v, want ErrPauseExhausted", err)
+	}
+}
+
+func TestTerminateWhilePaused(t *testing.T) {
+	svc, _ := newTestAgreementService()
+	ctx := context.Background()
+
+	a := activeTestAgreement(t, svc)
+	if _, err := svc.Pause(ctx, a.ID, "0xseller", "maintenance"); err != nil {
+		t.Fatalf("Pause: %v", err)
+	}
+	a, err := svc.Terminate(ctx, a.ID, "0xbuyer")
+	if err != nil {
+		t.Fatalf("Terminate while paused: %v", err)
+	}
+	if a.Status != AgreementTerminated {
+		t.Errorf("status = %s, want terminated", a.Status)
+	}
+	if a.PausedAt != nil {
+		t.Error("pausedAt not cleared on terminate")
+	}
+}
@@ -0,0 +1,191 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var ErrInvalidThresholds = errors.New("credit: thresholds must be fractions in (0, 1] with warn <= critical")
+
+// Default utilization thresholds, as fractions of the credit limit.
+const (
+	DefaultWarnThreshold     = 0.80
+	DefaultCriticalThreshold = 0.95
+)
+
+// AlertConfig holds an agent's utilization alert thresholds.
+type AlertConfig struct {
+	AgentAddr   string    `json:"agentAddr"`
+	WarnPct     float64   `json:"warnPct"`     // 0-1 fraction; 0 = default
+	CriticalPct float64   `json:"criticalPct"` // 0-1 fraction; 0 = default
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// AlertEvent represents a credit utilization alert.
+type AlertEvent struct {
+	AgentAddr   string    `json:"agentAddr"`
+	Type        string    `json:"type"` // "utilization_warning" or "utilization_critical"
+	Threshold   float64   `json:"threshold"`
+	UsedPct     float64   `json:"usedPct"`
+	CreditUsed  string    `json:"creditUsed"`
+	CreditLimit string    `json:"creditLimit"`
+	TriggeredAt time.Time `json:"triggeredAt"`
+}
+
+// AlertNotifier is called when a utilization alert fires.
+type AlertNotifier interface {
+	NotifyAlert(ctx context.Context, event AlertEvent) error
+}
+
+// AlertChecker evaluates credit utilization against per-agent thresholds,
+// mirroring the session-key budget checker.
+type AlertChecker struct {
+	ledger   LedgerStore
+	store    Store
+	notifier AlertNotifier
+	// Tracks which alert levels have fired per agent; entries are cleared
+	// when utilization falls back below the threshold so alerts re-arm.
+	// Map key: "agentAddr:type" → true
+	fired sync.Map
+}
+
+// NewAlertChecker creates a utilization alert checker.
+func NewAlertChecker(store Store, ledgerStore LedgerStore, notifier AlertNotifier) *AlertChecker {
+	return &AlertChecker{ledger: ledgerStore, store: store, notifier: notifier}
+}
+
+// CheckUtilization evaluates one agent's credit utilization, firing each
+// configured level at most once per excursion above its threshold.
+func (a *AlertChecker) CheckUtilization(ctx context.Context, agentAddr string) {
+	if a.notifier == nil {
+		return
+	}
+
+	limit, used, err := a.ledger.GetCreditInfo(ctx, agentAddr)
+	if err != nil {
+		return
+	}
+	limitBig, ok1 := usdc.Parse(limit)
+	usedBig, ok2 := usdc.Parse(used)
+	if !ok1 || !ok2 || limitBig.Sign() <= 0 {
+		return
+	}
+	usedPct, _ := new(big.Float).Quo(new(big.Float).SetInt(usedBig), new(big.Float).SetInt(limitBig)).Float64()
+
+	warn, critical := DefaultWarnThreshold, DefaultCriticalThreshold
+	if cfg, err := a.store.GetAlertConfig(ctx, agentAddr); err == nil && cfg != nil {
+		if cfg.WarnPct > 0 {
+			warn = cfg.WarnPct
+		}
+		if cfg.CriticalPct > 0 {
+			critical = cfg.CriticalPct
+		}
+	}
+
+	levels := []struct {
+		typ       string
+		threshold float64
+	}{
+		{"utilization_warning", warn},
+		{"utilization_critical", critical},
+	}
+
+	for _, level := range levels {
+		firedKey := fmt.Sprintf("%s:%s", agentAddr, level.typ)
+		if usedPct < level.threshold {
+			a.fired.Delete(firedKey) // re-arm once utilization drops
+			continue
+		}
+		if _, loaded := a.fired.LoadOrStore(firedKey, true); loaded {
+			continue // already fired
+		}
+
+		_ = a.notifier.NotifyAlert(ctx, AlertEvent{
+			AgentAddr:   agentAddr,
+			Type:        level.typ,
+			Threshold:   level.threshold,
+			UsedPct:     usedPct,
+			CreditUsed:  used,
+			CreditLimit: limit,
+			TriggeredAt: time.Now(),
+		})
+	}
+}
+
+// WithAlertChecker sets the utilization alert checker; the timer drives it.
+func (s *Service) WithAlertChecker(a *AlertChecker) *Service {
+	s.alerts = a
+	return s
+}
+
+// WithAlertNotifier wires utilization alerts backed by the service's own
+// stores.
+func (s *Service) WithAlertNotifier(n AlertNotifier) *Service {
+	return s.WithAlertChecker(NewAlertChecker(s.store, s.ledger, n))
+}
+
+// SetAlertConfig stores an agent's utilization thresholds. Fractions must be
+// in (0, 1] with warn at or below critical.
+func (s *Service) SetAlertConfig(ctx context.Context, agentAddr string, warnPct, criticalPct float64) (*AlertConfig, error) {
+	if warnPct <= 0 || warnPct > 1 || criticalPct <= 0 || criticalPct > 1 || warnPct > criticalPct {
+		return nil, ErrInvalidThresholds
+	}
+	cfg := &AlertConfig{
+		AgentAddr:   agentAddr,
+		WarnPct:     warnPct,
+		CriticalPct: criticalPct,
+		UpdatedAt:   time.Now(),
+	}
+	if err := s.store.SetAlertConfig(ctx, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// GetAlertConfig returns an agent's thresholds, falling back to defaults.
+func (s *Service) GetAlertConfig(ctx context.Context, agentAddr string) (*AlertConfig, error) {
+	cfg, err := s.store.GetAlertConfig(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = &AlertConfig{
+			AgentAddr:   agentAddr,
+			WarnPct:     DefaultWarnThreshold,
+			CriticalPct: DefaultCriticalThreshold,
+		}
+	}
+	return cfg, nil
+}
+
+// CheckUtilizationAlerts runs the checker over every agent with a configured
+// threshold plus every agent with an active plan. Called from the timer.
+func (s *Service) CheckUtilizationAlerts(ctx context.Context) {
+	if s.alerts == nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	if cfgs, err := s.store.ListAlertConfigs(ctx); err == nil {
+		for _, cfg := range cfgs {
+			if !seen[cfg.AgentAddr] {
+				seen[cfg.AgentAddr] = true
+				s.alerts.CheckUtilization(ctx, cfg.AgentAddr)
+			}
+		}
+	}
+	if plans, err := s.store.ListActivePlans(ctx); err == nil {
+		for _, p := range plans {
+			if !seen[p.AgentAddr] {
+				seen[p.AgentAddr] = true
+				s.alerts.CheckUtilization(ctx, p.AgentAddr)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+type captureNotifier struct {
+	events []AlertEvent
+}
+
+func (c *captureNotifier) NotifyAlert(_ context.Context, event AlertEvent) error {
+	c.events = append(c.events, event)
+	return nil
+}
+
+func TestCheckUtilizationDefaults(t *testing.T) {
+	fl := newFakeLedger("0", "100.00", "85.00") // 85% utilization
+	store := NewMemoryStore()
+	notifier := &captureNotifier{}
+	checker := NewAlertChecker(store, fl, notifier)
+	ctx := context.Background()
+
+	checker.CheckUtilization(ctx, "0xagent")
+	if len(notifier.events) != 1 || notifier.events[0].Type != "utilization_warning" {
+		t.Fatalf("events = %+v, want one utilization_warning", notifier.events)
+	}
+
+	// Same level does not re-fire while still above the threshold.
+	checker.CheckUtilization(ctx, "0xagent")
+	if len(notifier.events) != 1 {
+		t.Errorf("events = %d, want still 1", len(notifier.events))
+	}
+
+	// Crossing the critical threshold fires the second level.
+	fl.creditUsed.SetInt64(96_000_000) // 96.00
+	checker.CheckUtilization(ctx, "0xagent")
+	if len(notifier.events) != 2 || notifier.events[1].Type != "utilization_critical" {
+		t.Fatalf("events = %+v, want utilization_critical second", notifier.events)
+	}
+}
+
+func TestCheckUtilizationRearmsAfterDrop(t *testing.T) {
+	fl := newFakeLedger("0", "100.00", "85.00")
+	notifier := &captureNotifier{}
+	checker := NewAlertChecker(NewMemoryStore(), fl, notifier)
+	ctx := context.Background()
+
+	checker.CheckUtilization(ctx, "0xagent")
+	fl.creditUsed.SetInt64(10_000_000) // repaid down to 10%
+	checker.CheckUtilization(ctx, "0xagent")
+	fl.creditUsed.SetInt64(85_000_000) // back up to 85%
+	checker.CheckUtilization(ctx, "0xagent")
+
+	if len(notifier.events) != 2 {
+		t.Errorf("events = %d, want 2 (alert re-armed after drop)", len(notifier.events))
+	}
+}
+
+func TestCheckUtilizationCustomThresholds(t *testing.T) {
+	fl := newFakeLedger("0", "100.00", "50.00")
+	store := NewMemoryStore()
+	svc := NewService(store, fl, slog.Default())
+	notifier := &captureNotifier{}
+	svc.WithAlertNotifier(notifier)
+	ctx := context.Background()
+
+	if _, err := svc.SetAlertConfig(ctx, "0xagent", 0.95, 0.80); !errors.Is(err, ErrInvalidThresholds) {
+		t.Errorf("inverted thresholds error = %v, want ErrInvalidThresholds", err)
+	}
+	if _, err := svc.SetAlertConfig(ctx, "0xagent", 0.40, 0.60); err != nil {
+		t.Fatalf("SetAlertConfig: %v", err)
+	}
+
+	svc.CheckUtilizationAlerts(ctx)
+	if len(notifier.events) != 1 || notifier.events[0].Threshold != 0.40 {
+		t.Fatalf("events = %+v, want one at 0.40", notifier.events)
+	}
+
+	cfg, err := svc.GetAlertConfig(ctx, "0xother")
+	if err != nil || cfg.WarnPct != DefaultWarnThreshold || cfg.CriticalPct != DefaultCriticalThreshold {
+		t.Errorf("unset config = %+v, %v, want defaults", cfg, err)
+	}
+}
@@ -0,0 +1,320 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrCollateralNotFound  = errors.New("credit: collateral not found")
+	ErrCollateralNotActive = errors.New("credit: collateral not active")
+	ErrInvalidCollateral   = errors.New("credit: invalid collateral amount")
+	ErrStakesUnavailable   = errors.New("credit: stake pledging is not configured")
+)
+
+// CollateralKind identifies what backs a pledge.
+type CollateralKind string
+
+const (
+	// CollateralLedgerLock locks a portion of the agent's available balance.
+	CollateralLedgerLock CollateralKind = "ledger_lock"
+	// CollateralStake pledges a stakes-module position via the StakePledger.
+	CollateralStake CollateralKind = "stake"
+)
+
+// CollateralStatus represents the lifecycle of a pledge.
+type CollateralStatus string
+
+const (
+	CollateralActive     CollateralStatus = "active"
+	CollateralReleased   CollateralStatus = "released"
+	CollateralLiquidated CollateralStatus = "liquidated"
+)
+
+// Collateral records a pledge that raised an agent's credit limit.
+type Collateral struct {
+	ID        string           `json:"id"`
+	AgentAddr string           `json:"agentAddr"`
+	Kind      CollateralKind   `json:"kind"`
+	Amount    string           `json:"amount"`              // USDC value pledged
+	Reference string           `json:"reference,omitempty"` // hold ref or stake position ID
+	Status    CollateralStatus `json:"status"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// StakePledger lets the stakes module back credit collateral with revenue-share
+// positions. Lock reserves a position against transfer/sale; Liquidate converts
+// it to USDC paid to the platform on default, returning the realized value.
+type StakePledger interface {
+	Lock(ctx context.Context, agentAddr, positionID string) (value string, err error)
+	Unlock(ctx context.Context, agentAddr, positionID string) error
+	Liquidate(ctx context.Context, agentAddr, positionID string) (realized string, err error)
+}
+
+// WithStakePledger wires the stakes module in as a collateral source.
+func (s *Service) WithStakePledger(p StakePledger) *Service {
+	s.stakes = p
+	return s
+}
+
+// PledgeLedgerLock locks amount from the agent's available balance as credit
+// collateral and raises the credit limit by the same amount.
+func (s *Service) PledgeLedgerLock(ctx context.Context, agentAddr, amount string) (*Collateral, error) {
+	amountBig, ok := usdc.Parse(amount)
+	if !ok || amountBig.Sign() <= 0 {
+		return nil, ErrInvalidCollateral
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	col := &Collateral{
+		ID:        idgen.WithPrefix("col_"),
+		AgentAddr: agentAddr,
+		Kind:      CollateralLedgerLock,
+		Amount:    usdc.Format(amountBig),
+		Status:    CollateralActive,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	col.Reference = "collateral:" + col.ID
+
+	// Lock funds first; only raise the limit once the hold succeeds.
+	if err := s.ledger.Hold(ctx, agentAddr, col.Amount, col.Reference); err != nil {
+		return nil, err
+	}
+	if err := s.raiseLimit(ctx, agentAddr, col.Amount); err != nil {
+		if relErr := s.ledger.ReleaseHold(ctx, agentAddr, col.Amount, col.Reference); relErr != nil {
+			s.logger.Error("credit: release after failed limit raise", "agent", agentAddr, "error", relErr)
+		}
+		return nil, err
+	}
+	if err := s.store.CreateCollateral(ctx, col); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("credit: ledger collateral pledged", "agent", agentAddr, "amount", col.Amount, "collateral", col.ID)
+	return col, nil
+}
+
+// PledgeStake pledges a stakes-module position as collateral, raising the
+// credit limit by the position's current value.
+func (s *Service) PledgeStake(ctx context.Context, agentAddr, positionID string) (*Collateral, error) {
+	if s.stakes == nil {
+		return nil, ErrStakesUnavailable
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, err := s.stakes.Lock(ctx, agentAddr, positionID)
+	if err != nil {
+		return nil, err
+	}
+	valueBig, ok := usdc.Parse(value)
+	if !ok || valueBig.Sign() <= 0 {
+		_ = s.stakes.Unlock(ctx, agentAddr, positionID)
+		return nil, ErrInvalidCollateral
+	}
+
+	col := &Collateral{
+		ID:        idgen.WithPrefix("col_"),
+		AgentAddr: agentAddr,
+		Kind:      CollateralStake,
+		Amount:    usdc.Format(valueBig),
+		Reference: positionID,
+		Status:    CollateralActive,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.raiseLimit(ctx, agentAddr, col.Amount); err != nil {
+		if ulErr := s.stakes.Unlock(ctx, agentAddr, positionID); ulErr != nil {
+			s.logger.Error("credit: stake unlock after failed limit raise", "agent", agentAddr, "error", ulErr)
+		}
+		return nil, err
+	}
+	if err := s.store.CreateCollateral(ctx, col); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("credit: stake collateral pledged", "agent", agentAddr, "position", positionID, "value", col.Amount)
+	return col, nil
+}
+
+// ReleaseCollateral returns a pledge to the agent and lowers the credit limit,
+// provided the reduced limit still covers the outstanding draw. Only the
+// pledging agent may release; actor is the authenticated caller.
+func (s *Service) ReleaseCollateral(ctx context.Context, collateralID, actor string) (*Collateral, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	col, err := s.store.GetCollateral(ctx, collateralID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(actor, col.AgentAddr) {
+		return nil, ErrNotOwner
+	}
+	if col.Status != CollateralActive {
+		return nil, ErrCollateralNotActive
+	}
+
+	if err := s.lowerLimit(ctx, col.AgentAddr, col.Amount); err != nil {
+		return nil, err
+	}
+
+	switch col.Kind {
+	case CollateralLedgerLock:
+		if err := s.ledger.ReleaseHold(ctx, col.AgentAddr, col.Amount, col.Reference); err != nil {
+			return nil, err
+		}
+	case CollateralStake:
+		if s.stakes == nil {
+			return nil, ErrStakesUnavailable
+		}
+		if err := s.stakes.Unlock(ctx, col.AgentAddr, col.Reference); err != nil {
+			return nil, err
+		}
+	}
+
+	col.Status = CollateralReleased
+	col.UpdatedAt = time.Now()
+	if err := s.store.UpdateCollateral(ctx, col); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// ListCollateral returns all pledges for an agent, newest first.
+func (s *Service) ListCollateral(ctx context.Context, agentAddr string) ([]*Collateral, error) {
+	return s.store.ListCollateralByAgent(ctx, agentAddr)
+}
+
+// MarkDefaulted transitions a plan to defaulted and liquidates the agent's
+// active collateral to cover the outstanding draw.
+func (s *Service) MarkDefaulted(ctx context.Context, planID string) (*Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, err := s.store.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Status != PlanActive {
+		return nil, ErrPlanNotActive
+	}
+
+	s.markDefaultedLocked(ctx, plan)
+	return plan, nil
+}
+
+// markDefaultedLocked defaults a plan and liquidates its collateral.
+// Caller holds s.mu and is responsible for the plan being active.
+func (s *Service) markDefaultedLocked(ctx context.Context, plan *Plan) {
+	plan.Status = PlanDefaulted
+	plan.UpdatedAt = time.Now()
+	if err := s.store.UpdatePlan(ctx, plan); err != nil {
+		s.logger.Error("credit: failed to persist default", "plan", plan.ID, "error", err)
+	}
+
+	s.liquidateCollateral(ctx, plan.AgentAddr)
+	s.chargeCosigners(ctx, plan)
+
+	s.logger.Warn("credit: plan defaulted", "plan", plan.ID, "agent", plan.AgentAddr)
+}
+
+// liquidateCollateral converts all active pledges into credit repayment.
+// Liquidation is best-effort per pledge: a failure on one does not block the rest.
+func (s *Service) liquidateCollateral(ctx context.Context, agentAddr string) {
+	cols, err := s.store.ListCollateralByAgent(ctx, agentAddr)
+	if err != nil {
+		s.logger.Error("credit: list collateral for liquidation", "agent", agentAddr, "error", err)
+		return
+	}
+
+	for _, col := range cols {
+		if col.Status != CollateralActive {
+			continue
+		}
+		realized := col.Amount
+		switch col.Kind {
+		case CollateralLedgerLock:
+			// Locked funds leave the agent's balance permanently and repay the draw.
+			if err := s.ledger.ConfirmHold(ctx, agentAddr, col.Amount, col.Reference); err != nil {
+				s.logger.Error("credit: collateral hold confirm failed", "collateral", col.ID, "error", err)
+				continue
+			}
+		case CollateralStake:
+			if s.stakes == nil {
+				continue
+			}
+			r, err := s.stakes.Liquidate(ctx, agentAddr, col.Reference)
+			if err != nil {
+				s.logger.Error("credit: stake liquidation failed", "collateral", col.ID, "error", err)
+				continue
+			}
+			realized = r
+		}
+
+		if err := s.ledger.RepayCredit(ctx, agentAddr, realized); err != nil {
+			s.logger.Error("credit: repay from collateral failed", "collateral", col.ID, "error", err)
+		}
+		if err := s.lowerLimit(ctx, agentAddr, col.Amount); err != nil {
+			s.logger.Warn("credit: lower limit after liquidation", "collateral", col.ID, "error", err)
+		}
+
+		col.Status = CollateralLiquidated
+		col.UpdatedAt = time.Now()
+		if err := s.store.UpdateCollateral(ctx, col); err != nil {
+			s.logger.Error("credit: update liquidated collateral", "collateral", col.ID, "error", err)
+		}
+		s.logger.Info("credit: collateral liquidated", "collateral", col.ID, "agent", agentAddr, "realized", realized)
+	}
+}
+
+// raiseLimit increases the agent's credit limit by amount.
+func (s *Service) raiseLimit(ctx context.Context, agentAddr, amount string) error {
+	limit, _, err := s.ledger.GetCreditInfo(ctx, agentAddr)
+	if err != nil {
+		return err
+	}
+	limitBig, ok1 := usdc.Parse(limit)
+	amountBig, ok2 := usdc.Parse(amount)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("credit: corrupted limit for %s", agentAddr)
+	}
+	limitBig.Add(limitBig, amountBig)
+	return s.ledger.SetCreditLimit(ctx, agentAddr, usdc.Format(limitBig))
+}
+
+// lowerLimit decreases the agent's credit limit by amount, refusing to drop
+// below the outstanding draw.
+func (s *Service) lowerLimit(ctx context.Context, agentAddr, amount string) error {
+	limit, used, err := s.ledger.GetCreditInfo(ctx, agentAddr)
+	if err != nil {
+		return err
+	}
+	limitBig, ok1 := usdc.Parse(limit)
+	usedBig, ok2 := usdc.Parse(used)
+	amountBig, ok3 := usdc.Parse(amount)
+	if !ok1 || !ok2 || !ok3 {
+		return fmt.Errorf("credit: corrupted limit for %s", agentAddr)
+	}
+	limitBig.Sub(limitBig, amountBig)
+	if limitBig.Sign() < 0 {
+		limitBig.SetInt64(0)
+	}
+	if limitBig.Cmp(usedBig) < 0 {
+		return fmt.Errorf("credit: reduced limit %s would fall below outstanding draw %s",
+			usdc.Format(limitBig), used)
+	}
+	return s.ledger.SetCreditLimit(ctx, agentAddr, usdc.Format(limitBig))
+}
@@ -0,0 +1,124 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+func TestPledgeLedgerLockRaisesLimit(t *testing.T) {
+	fl := newFakeLedger("50", "10", "0")
+	svc := newTestService(fl)
+
+	col, err := svc.PledgeLedgerLock(context.Background(), "0xagent", "20")
+	if err != nil {
+		t.Fatalf("PledgeLedgerLock: %v", err)
+	}
+	if col.Status != CollateralActive {
+		t.Errorf("status = %s, want active", col.Status)
+	}
+	if got := usdc.Format(fl.creditLimit); got != "30.000000" {
+		t.Errorf("credit limit = %s, want 30.0000000000", got)
+	}
+	if got := usdc.Format(fl.available); got != "30.000000" {
+		t.Errorf("available = %s, want 30.000000 (20 locked)", got)
+	}
+}
+
+func TestPledgeLedgerLockInsufficientBalance(t *testing.T) {
+	svc := newTestService(newFakeLedger("5", "10", "0"))
+	if _, err := svc.PledgeLedgerLock(context.Background(), "0xagent", "20"); err == nil {
+		t.Fatal("expected error pledging more than available balance")
+	}
+}
+
+func TestReleaseCollateralRestoresBalanceAndLimit(t *testing.T) {
+	fl := newFakeLedger("50", "10", "0")
+	svc := newTestService(fl)
+
+	col, err := svc.PledgeLedgerLock(context.Background(), "0xagent", "20")
+	if err != nil {
+		t.Fatalf("PledgeLedgerLock: %v", err)
+	}
+
+	released, err := svc.ReleaseCollateral(context.Background(), col.ID, "0xagent")
+	if err != nil {
+		t.Fatalf("ReleaseCollateral: %v", err)
+	}
+	if released.Status != CollateralReleased {
+		t.Errorf("status = %s, want released", released.Status)
+	}
+	if got := usdc.Format(fl.creditLimit); got != "10.000000" {
+		t.Errorf("credit limit = %s, want 10.000000", got)
+	}
+	if got := usdc.Format(fl.available); got != "50.000000" {
+		t.Errorf("available = %s, want 50.000000", got)
+	}
+}
+
+func TestReleaseCollateralRequiresOwner(t *testing.T) {
+	svc := newTestService(newFakeLedger("50", "10", "0"))
+
+	col, err := svc.PledgeLedgerLock(context.Background(), "0xagent", "20")
+	if err != nil {
+		t.Fatalf("PledgeLedgerLock: %v", err)
+	}
+	if _, err := svc.ReleaseCollateral(context.Background(), col.ID, "0xstranger"); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("expected ErrNotOwner, got %v", err)
+	}
+}
+
+func TestReleaseCollateralRefusedWhenDrawOutstanding(t *testing.T) {
+	fl := newFakeLedger("50", "0", "0")
+	svc := newTestService(fl)
+
+	col, err := svc.PledgeLedgerLock(context.Background(), "0xagent", "20")
+	if err != nil {
+		t.Fatalf("PledgeLedgerLock: %v", err)
+	}
+
+	// Draw against the collateral-backed limit, then try to release.
+	fl.creditUsed, _ = usdc.Parse("15")
+	if _, err := svc.ReleaseCollateral(context.Background(), col.ID, "0xagent"); err == nil {
+		t.Fatal("expected release to fail while draw exceeds reduced limit")
+	}
+}
+
+func TestMarkDefaultedLiquidatesCollateral(t *testing.T) {
+	fl := newFakeLedger("50", "0", "0")
+	svc := newTestService(fl)
+
+	if _, err := svc.PledgeLedgerLock(context.Background(), "0xagent", "20"); err != nil {
+		t.Fatalf("PledgeLedgerLock: %v", err)
+	}
+
+	// Draw the full collateral-backed limit and schedule it.
+	fl.creditUsed, _ = usdc.Parse("20")
+	plan, err := svc.CreatePlan(context.Background(), "0xagent", 2, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+
+	defaulted, err := svc.MarkDefaulted(context.Background(), plan.ID)
+	if err != nil {
+		t.Fatalf("MarkDefaulted: %v", err)
+	}
+	if defaulted.Status != PlanDefaulted {
+		t.Errorf("plan status = %s, want defaulted", defaulted.Status)
+	}
+
+	if fl.creditUsed.Sign() != 0 {
+		t.Errorf("credit used = %s, want 0 after liquidation", usdc.Format(fl.creditUsed))
+	}
+	if fl.pending.Sign() != 0 {
+		t.Errorf("pending = %s, want 0 (hold confirmed)", usdc.Format(fl.pending))
+	}
+
+	cols, _ := svc.ListCollateral(context.Background(), "0xagent")
+	if len(cols) != 1 || cols[0].Status != CollateralLiquidated {
+		t.Fatalf("expected 1 liquidated collateral, got %+v", cols)
+	}
+}
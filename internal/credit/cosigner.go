@@ -0,0 +1,213 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrCosignNotFound   = errors.New("credit: co-sign not found")
+	ErrCosignExists     = errors.New("credit: co-sign already exists for this pair")
+	ErrCosignNotPending = errors.New("credit: co-sign not pending")
+	ErrCosignNotActive  = errors.New("credit: co-sign not active")
+	ErrSelfCosign       = errors.New("credit: agent cannot co-sign its own credit line")
+	ErrNotCosigner      = errors.New("credit: caller is not the co-signer")
+)
+
+// CosignStatus represents the lifecycle of a co-sign agreement.
+type CosignStatus string
+
+const (
+	CosignPending CosignStatus = "pending" // awaiting co-signer acceptance
+	CosignActive  CosignStatus = "active"
+	CosignRevoked CosignStatus = "revoked"
+	CosignCharged CosignStatus = "charged" // liability was drawn on default
+)
+
+// Cosign is a standing agreement where a second agent accepts liability for
+// a borrower's credit line: on default, the co-signer's available balance is
+// drawn to cover whatever collateral liquidation did not.
+type Cosign struct {
+	ID            string       `json:"id"`
+	AgentAddr     string       `json:"agentAddr"`    // borrower
+	CosignerAddr  string       `json:"cosignerAddr"` // liable party
+	Status        CosignStatus `json:"status"`
+	ChargedAmount string       `json:"chargedAmount,omitempty"` // drawn on default (USDC)
+	ChargedAt     *time.Time   `json:"chargedAt,omitempty"`
+	CreatedAt     time.Time    `json:"createdAt"`
+	UpdatedAt     time.Time    `json:"updatedAt"`
+}
+
+// RequestCosign records a borrower's request for cosignerAddr to back their
+// credit line. The agreement stays pending until the co-signer accepts.
+func (s *Service) RequestCosign(ctx context.Context, agentAddr, cosignerAddr string) (*Cosign, error) {
+	if strings.EqualFold(agentAddr, cosignerAddr) {
+		return nil, ErrSelfCosign
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.store.ListCosignsByAgent(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	for _, cs := range existing {
+		if strings.EqualFold(cs.CosignerAddr, cosignerAddr) &&
+			(cs.Status == CosignPending || cs.Status == CosignActive) {
+			return nil, ErrCosignExists
+		}
+	}
+
+	now := time.Now()
+	cs := &Cosign{
+		ID:           idgen.WithPrefix("cosn_"),
+		AgentAddr:    agentAddr,
+		CosignerAddr: cosignerAddr,
+		Status:       CosignPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.store.CreateCosign(ctx, cs); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("credit: co-sign requested",
+		"cosign", cs.ID, "agent", agentAddr, "cosigner", cosignerAddr)
+	return cs, nil
+}
+
+// AcceptCosign activates a pending agreement. Only the named co-signer may
+// accept; actor is the authenticated caller.
+func (s *Service) AcceptCosign(ctx context.Context, id, actor string) (*Cosign, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, err := s.store.GetCosign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(actor, cs.CosignerAddr) {
+		return nil, ErrNotCosigner
+	}
+	if cs.Status != CosignPending {
+		return nil, ErrCosignNotPending
+	}
+
+	cs.Status = CosignActive
+	cs.UpdatedAt = time.Now()
+	if err := s.store.UpdateCosign(ctx, cs); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("credit: co-sign accepted", "cosign", cs.ID, "cosigner", cs.CosignerAddr)
+	return cs, nil
+}
+
+// RevokeCosign cancels a pending or active agreement. Either party may
+// revoke; liability only attaches at default time, so revoking beforehand
+// releases the co-signer.
+func (s *Service) RevokeCosign(ctx context.Context, id, actor string) (*Cosign, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cs, err := s.store.GetCosign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(actor, cs.CosignerAddr) && !strings.EqualFold(actor, cs.AgentAddr) {
+		return nil, ErrNotCosigner
+	}
+	if cs.Status != CosignPending && cs.Status != CosignActive {
+		return nil, ErrCosignNotActive
+	}
+
+	cs.Status = CosignRevoked
+	cs.UpdatedAt = time.Now()
+	if err := s.store.UpdateCosign(ctx, cs); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("credit: co-sign revoked", "cosign", cs.ID, "by", actor)
+	return cs, nil
+}
+
+// ListCosigns returns agreements where the agent is borrower or co-signer.
+func (s *Service) ListCosigns(ctx context.Context, agentAddr string) ([]*Cosign, error) {
+	return s.store.ListCosignsByAgent(ctx, agentAddr)
+}
+
+// chargeCosigners draws the borrower's remaining outstanding credit from
+// active co-signers' available balances after a default. Collateral
+// liquidation runs first; co-signers cover only what is left. Caller holds
+// s.mu. Best-effort per co-signer: a failed draw does not block the rest.
+func (s *Service) chargeCosigners(ctx context.Context, plan *Plan) {
+	cosigns, err := s.store.ListCosignsByAgent(ctx, plan.AgentAddr)
+	if err != nil {
+		s.logger.Error("credit: list co-signs for default", "agent", plan.AgentAddr, "error", err)
+		return
+	}
+
+	for _, cs := range cosigns {
+		if cs.Status != CosignActive || !strings.EqualFold(cs.AgentAddr, plan.AgentAddr) {
+			continue
+		}
+
+		_, used, err := s.ledger.GetCreditInfo(ctx, plan.AgentAddr)
+		if err != nil {
+			s.logger.Error("credit: co-sign charge read failed", "cosign", cs.ID, "error", err)
+			continue
+		}
+		outstanding, ok := usdc.Parse(used)
+		if !ok || outstanding.Sign() <= 0 {
+			return // nothing left to cover
+		}
+
+		bal, err := s.ledger.GetBalance(ctx, cs.CosignerAddr)
+		if err != nil {
+			s.logger.Error("credit: co-sign balance read failed", "cosign", cs.ID, "error", err)
+			continue
+		}
+		avail, ok := usdc.Parse(bal.Available)
+		if !ok || avail.Sign() <= 0 {
+			continue
+		}
+
+		charge := new(big.Int).Set(outstanding)
+		if avail.Cmp(charge) < 0 {
+			charge.Set(avail)
+		}
+		amount := usdc.Format(charge)
+
+		if err := s.ledger.Debit(ctx, cs.CosignerAddr, amount, "cosign:"+plan.ID, "credit_cosigner_charge"); err != nil {
+			s.logger.Error("credit: co-sign draw failed", "cosign", cs.ID, "amount", amount, "error", err)
+			continue
+		}
+		if err := s.ledger.RepayCredit(ctx, plan.AgentAddr, amount); err != nil {
+			s.logger.Error("credit: repay failed after co-sign draw",
+				"cosign", cs.ID, "amount", amount, "error", err)
+			continue
+		}
+
+		now := time.Now()
+		cs.Status = CosignCharged
+		cs.ChargedAmount = amount
+		cs.ChargedAt = &now
+		cs.UpdatedAt = now
+		if err := s.store.UpdateCosign(ctx, cs); err != nil {
+			s.logger.Error("credit: failed to record co-sign charge", "cosign", cs.ID, "error", err)
+		}
+
+		s.logger.Warn("credit: co-signer charged on default",
+			"cosign", cs.ID, "plan", plan.ID, "cosigner", cs.CosignerAddr, "amount", amount)
+		if s.webhookEmitter != nil {
+			go s.webhookEmitter.EmitCreditCosignerCharged(plan.AgentAddr, cs.CosignerAddr, plan.ID, amount)
+		}
+	}
+}
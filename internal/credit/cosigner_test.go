@@ -0,0 +1,129 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCosignLifecycle(t *testing.T) {
+	svc := newTestService(newFakeLedger("0", "0", "0"))
+	ctx := context.Background()
+
+	if _, err := svc.RequestCosign(ctx, "0xagent", "0xAgent"); !errors.Is(err, ErrSelfCosign) {
+		t.Errorf("self co-sign error = %v, want ErrSelfCosign", err)
+	}
+
+	cs, err := svc.RequestCosign(ctx, "0xagent", "0xtreasury")
+	if err != nil {
+		t.Fatalf("RequestCosign: %v", err)
+	}
+	if cs.Status != CosignPending {
+		t.Errorf("status = %s, want pending", cs.Status)
+	}
+
+	if _, err := svc.RequestCosign(ctx, "0xagent", "0xtreasury"); !errors.Is(err, ErrCosignExists) {
+		t.Errorf("duplicate error = %v, want ErrCosignExists", err)
+	}
+
+	// Only the named co-signer may accept.
+	if _, err := svc.AcceptCosign(ctx, cs.ID, "0xsomeoneelse"); !errors.Is(err, ErrNotCosigner) {
+		t.Errorf("accept by stranger error = %v, want ErrNotCosigner", err)
+	}
+	cs, err = svc.AcceptCosign(ctx, cs.ID, "0xtreasury")
+	if err != nil {
+		t.Fatalf("AcceptCosign: %v", err)
+	}
+	if cs.Status != CosignActive {
+		t.Errorf("status = %s, want active", cs.Status)
+	}
+
+	// Either party may revoke an active agreement.
+	cs, err = svc.RevokeCosign(ctx, cs.ID, "0xagent")
+	if err != nil {
+		t.Fatalf("RevokeCosign: %v", err)
+	}
+	if cs.Status != CosignRevoked {
+		t.Errorf("status = %s, want revoked", cs.Status)
+	}
+}
+
+func TestCosignerChargedOnDefault(t *testing.T) {
+	// The fake ledger keeps one shared balance pot; here it stands in for
+	// the co-signer's funds since the borrower has nothing left by default.
+	fl := newFakeLedger("100.00", "50.00", "30.00")
+	svc := newTestService(fl)
+	emitter := &fakeEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+
+	plan, err := svc.CreatePlan(ctx, "0xagent", 2, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+
+	cs, _ := svc.RequestCosign(ctx, "0xagent", "0xtreasury")
+	if _, err := svc.AcceptCosign(ctx, cs.ID, "0xtreasury"); err != nil {
+		t.Fatalf("AcceptCosign: %v", err)
+	}
+
+	// Drain the pot so installments cannot auto-deduct, then refill it to
+	// play the co-signer's balance at default time.
+	fl.available.SetInt64(0)
+	fl.credit(t, "100.00")
+
+	if _, err := svc.MarkDefaulted(ctx, plan.ID); err != nil {
+		t.Fatalf("MarkDefaulted: %v", err)
+	}
+
+	cs, _ = svc.store.GetCosign(ctx, cs.ID)
+	if cs.Status != CosignCharged {
+		t.Errorf("status = %s, want charged", cs.Status)
+	}
+	if cs.ChargedAmount != "30.000000" {
+		t.Errorf("charged = %s, want 30.000000", cs.ChargedAmount)
+	}
+	if cs.ChargedAt == nil {
+		t.Error("chargedAt not recorded")
+	}
+	if _, used, _ := fl.GetCreditInfo(ctx, "0xagent"); used != "0.000000" {
+		t.Errorf("outstanding = %s, want 0.000000 after co-signer draw", used)
+	}
+
+	waitForEmits(t, emitter, 1)
+	emitter.mu.Lock()
+	charged := emitter.cosignerCharged
+	emitter.mu.Unlock()
+	if charged != 1 {
+		t.Errorf("cosigner charged notices = %d, want 1", charged)
+	}
+}
+
+func TestRevokedCosignerNotCharged(t *testing.T) {
+	fl := newFakeLedger("100.00", "50.00", "30.00")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	plan, err := svc.CreatePlan(ctx, "0xagent", 2, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+
+	cs, _ := svc.RequestCosign(ctx, "0xagent", "0xtreasury")
+	if _, err := svc.AcceptCosign(ctx, cs.ID, "0xtreasury"); err != nil {
+		t.Fatalf("AcceptCosign: %v", err)
+	}
+	if _, err := svc.RevokeCosign(ctx, cs.ID, "0xtreasury"); err != nil {
+		t.Fatalf("RevokeCosign: %v", err)
+	}
+
+	if _, err := svc.MarkDefaulted(ctx, plan.ID); err != nil {
+		t.Fatalf("MarkDefaulted: %v", err)
+	}
+
+	cs, _ = svc.store.GetCosign(ctx, cs.ID)
+	if cs.Status != CosignRevoked || cs.ChargedAmount != "" {
+		t.Errorf("cosign = %s/%q, want revoked with no charge", cs.Status, cs.ChargedAmount)
+	}
+}
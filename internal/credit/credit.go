@@ -0,0 +1,414 @@
+// Package credit implements repayment workflows on top of the ledger's
+// credit-line primitives.
+//
+// The ledger tracks credit limits and outstanding draws; this package adds
+// the lifecycle around them: fixed installment plans with due dates, a timer
+// that auto-deducts payments from agent earnings, and late-payment records
+// that feed the credit scorer.
+package credit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/ledger"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrPlanNotFound       = errors.New("credit: plan not found")
+	ErrPlanNotActive      = errors.New("credit: plan not active")
+	ErrPlanExists         = errors.New("credit: agent already has an active plan")
+	ErrNothingOutstanding = errors.New("credit: no outstanding credit to schedule")
+	ErrInvalidSchedule    = errors.New("credit: invalid installment schedule")
+	ErrNothingDue         = errors.New("credit: no pending installment")
+	ErrNotOwner           = errors.New("credit: caller does not own this resource")
+)
+
+// PlanStatus represents the lifecycle of an installment plan.
+type PlanStatus string
+
+const (
+	PlanActive    PlanStatus = "active"
+	PlanCompleted PlanStatus = "completed"
+	PlanDefaulted PlanStatus = "defaulted"
+	PlanCancelled PlanStatus = "cancelled"
+)
+
+// InstallmentStatus represents the state of a single installment.
+type InstallmentStatus string
+
+const (
+	InstallmentPending InstallmentStatus = "pending"
+	InstallmentPaid    InstallmentStatus = "paid"
+	InstallmentLate    InstallmentStatus = "late"
+)
+
+// Installment is one scheduled payment within a plan.
+type Installment struct {
+	Sequence int               `json:"sequence"` // 1-based
+	Amount   string            `json:"amount"`   // USDC
+	DueAt    time.Time         `json:"dueAt"`
+	Status   InstallmentStatus `json:"status"`
+	PaidAt   *time.Time        `json:"paidAt,omitempty"`
+}
+
+// Plan converts an outstanding credit draw into N fixed payments.
+type Plan struct {
+	ID           string            `json:"id"`
+	AgentAddr    string            `json:"agentAddr"`
+	Principal    string            `json:"principal"` // Outstanding draw at plan creation (USDC)
+	Installments []*Installment    `json:"installments"`
+	Status       PlanStatus        `json:"status"`
+	LateCount    int               `json:"lateCount"`             // Installments that went late at least once
+	Delinquency  DelinquencyBucket `json:"delinquency,omitempty"` // Current overdue bucket
+	FrozenLimit  string            `json:"frozenLimit,omitempty"` // Credit limit saved while draws are frozen
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+// RepaymentStats summarizes an agent's repayment punctuality across all
+// plans. The credit scorer uses this to adjust future limits.
+type RepaymentStats struct {
+	AgentAddr      string `json:"agentAddr"`
+	TotalPlans     int    `json:"totalPlans"`
+	CompletedPlans int    `json:"completedPlans"`
+	DefaultedPlans int    `json:"defaultedPlans"`
+	PaidOnTime     int    `json:"paidOnTime"`
+	PaidLate       int    `json:"paidLate"`
+}
+
+// Store persists installment plans and collateral pledges.
+type Store interface {
+	CreatePlan(ctx context.Context, p *Plan) error
+	GetPlan(ctx context.Context, id string) (*Plan, error)
+	ListPlansByAgent(ctx context.Context, agentAddr string) ([]*Plan, error)
+	ListActivePlans(ctx context.Context) ([]*Plan, error)
+	UpdatePlan(ctx context.Context, p *Plan) error
+
+	CreateCollateral(ctx context.Context, c *Collateral) error
+	GetCollateral(ctx context.Context, id string) (*Collateral, error)
+	ListCollateralByAgent(ctx context.Context, agentAddr string) ([]*Collateral, error)
+	UpdateCollateral(ctx context.Context, c *Collateral) error
+
+	// SetAlertConfig upserts; GetAlertConfig returns (nil, nil) when unset.
+	SetAlertConfig(ctx context.Context, cfg *AlertConfig) error
+	GetAlertConfig(ctx context.Context, agentAddr string) (*AlertConfig, error)
+	ListAlertConfigs(ctx context.Context) ([]*AlertConfig, error)
+
+	// SetSweepConfig upserts; GetSweepConfig returns (nil, nil) when unset.
+	SetSweepConfig(ctx context.Context, cfg *SweepConfig) error
+	GetSweepConfig(ctx context.Context, agentAddr string) (*SweepConfig, error)
+
+	CreateCosign(ctx context.Context, cs *Cosign) error
+	GetCosign(ctx context.Context, id string) (*Cosign, error)
+	ListCosignsByAgent(ctx context.Context, agentAddr string) ([]*Cosign, error)
+	UpdateCosign(ctx context.Context, cs *Cosign) error
+
+	CreateApplication(ctx context.Context, a *Application) error
+	GetApplication(ctx context.Context, id string) (*Application, error)
+	ListPendingApplications(ctx context.Context) ([]*Application, error)
+	UpdateApplication(ctx context.Context, a *Application) error
+}
+
+// LedgerStore is the subset of ledger.Store the credit service needs:
+// balance reads, earnings deduction, credit-line management and the hold
+// lifecycle used for locked-balance collateral.
+type LedgerStore interface {
+	GetBalance(ctx context.Context, agentAddr string) (*ledger.Balance, error)
+	Debit(ctx context.Context, agentAddr, amount, reference, description string) error
+	Hold(ctx context.Context, agentAddr, amount, reference string) error
+	ConfirmHold(ctx context.Context, agentAddr, amount, reference string) error
+	ReleaseHold(ctx context.Context, agentAddr, amount, reference string) error
+	SetCreditLimit(ctx context.Context, agentAddr, limit string) error
+	RepayCredit(ctx context.Context, agentAddr, amount string) error
+	GetCreditInfo(ctx context.Context, agentAddr string) (creditLimit, creditUsed string, err error)
+}
+
+// Service manages installment plans against the ledger's credit lines.
+type Service struct {
+	store          Store
+	ledger         LedgerStore
+	stakes         StakePledger            // nil = stake collateral disabled
+	webhookEmitter WebhookEmitter          // nil = no delinquency notices
+	alerts         *AlertChecker           // nil = no utilization alerts
+	stakeRevenue   StakeRevenueAccumulator // nil = stakes take no revenue cut
+	grace          time.Duration           // 30+ bucket grace before default; 0 = defaultGracePeriod
+	logger         *slog.Logger
+	mu             sync.Mutex // serializes plan mutation + ledger deduction
+}
+
+// NewService creates a new credit service.
+func NewService(store Store, ledgerStore LedgerStore, logger *slog.Logger) *Service {
+	return &Service{store: store, ledger: ledgerStore, logger: logger}
+}
+
+// CreatePlan converts the agent's entire outstanding credit draw into a plan
+// of numPayments equal installments spaced interval apart, the first due
+// interval from now. An agent can have at most one active plan.
+func (s *Service) CreatePlan(ctx context.Context, agentAddr string, numPayments int, interval time.Duration) (*Plan, error) {
+	if numPayments < 1 || numPayments > 36 || interval < time.Hour {
+		return nil, ErrInvalidSchedule
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.store.ListPlansByAgent(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range existing {
+		if p.Status == PlanActive {
+			return nil, ErrPlanExists
+		}
+	}
+
+	_, used, err := s.ledger.GetCreditInfo(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	usedBig, ok := usdc.Parse(used)
+	if !ok || usedBig.Sign() <= 0 {
+		return nil, ErrNothingOutstanding
+	}
+
+	now := time.Now()
+	plan := &Plan{
+		ID:        idgen.WithPrefix("iplan_"),
+		AgentAddr: agentAddr,
+		Principal: usdc.Format(usedBig),
+		Status:    PlanActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	plan.Installments = splitInstallments(usedBig, numPayments, now, interval)
+
+	if err := s.store.CreatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("credit: installment plan created",
+		"plan", plan.ID, "agent", agentAddr, "principal", plan.Principal, "payments", numPayments)
+	return plan, nil
+}
+
+// splitInstallments divides principal into n payments; the last installment
+// absorbs any rounding remainder so the plan sums exactly to principal.
+func splitInstallments(principal *big.Int, n int, from time.Time, interval time.Duration) []*Installment {
+	per := new(big.Int).Div(principal, big.NewInt(int64(n)))
+	installments := make([]*Installment, 0, n)
+	allocated := new(big.Int)
+	for i := 1; i <= n; i++ {
+		amount := new(big.Int).Set(per)
+		if i == n {
+			amount.Sub(principal, allocated)
+		}
+		allocated.Add(allocated, amount)
+		installments = append(installments, &Installment{
+			Sequence: i,
+			Amount:   usdc.Format(amount),
+			DueAt:    from.Add(time.Duration(i) * interval),
+			Status:   InstallmentPending,
+		})
+	}
+	return installments
+}
+
+// GetPlan returns a plan by ID. Only the plan's owner may read it; the
+// body carries delinquency and limit data.
+func (s *Service) GetPlan(ctx context.Context, id, actor string) (*Plan, error) {
+	plan, err := s.store.GetPlan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(actor, plan.AgentAddr) {
+		return nil, ErrNotOwner
+	}
+	return plan, nil
+}
+
+// ListPlans returns all plans for an agent, newest first.
+func (s *Service) ListPlans(ctx context.Context, agentAddr string) ([]*Plan, error) {
+	return s.store.ListPlansByAgent(ctx, agentAddr)
+}
+
+// PayNext manually pays the next unpaid installment of a plan from the
+// agent's available balance. Only the plan's owner may trigger a payment;
+// actor is the authenticated caller.
+func (s *Service) PayNext(ctx context.Context, planID, actor string) (*Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, err := s.store.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(actor, plan.AgentAddr) {
+		return nil, ErrNotOwner
+	}
+	if plan.Status != PlanActive {
+		return nil, ErrPlanNotActive
+	}
+
+	inst := nextUnpaid(plan)
+	if inst == nil {
+		return nil, ErrNothingDue
+	}
+	if err := s.deduct(ctx, plan, inst, time.Now()); err != nil {
+		return nil, err
+	}
+	s.finalizeIfComplete(plan)
+	plan.UpdatedAt = time.Now()
+	if err := s.store.UpdatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ProcessDue is called by the timer. For every active plan it attempts to
+// auto-deduct installments that have come due from the agent's available
+// balance (earnings), marking installments late when funds are insufficient.
+// Returns the number of installments paid and the number newly marked late.
+func (s *Service) ProcessDue(ctx context.Context, now time.Time) (paid, late int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plans, err := s.store.ListActivePlans(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, plan := range plans {
+		changed := false
+		for _, inst := range plan.Installments {
+			if inst.Status == InstallmentPaid || inst.DueAt.After(now) {
+				continue
+			}
+			if derr := s.deduct(ctx, plan, inst, now); derr == nil {
+				paid++
+				changed = true
+				continue
+			}
+			// Insufficient earnings — mark late once; already-late stays late.
+			if inst.Status != InstallmentLate {
+				inst.Status = InstallmentLate
+				plan.LateCount++
+				late++
+				changed = true
+				s.logger.Warn("credit: installment late",
+					"plan", plan.ID, "agent", plan.AgentAddr, "sequence", inst.Sequence, "amount", inst.Amount)
+			}
+		}
+		if plan.Status == PlanActive && s.processDelinquency(ctx, plan, now) {
+			changed = true
+		}
+		if changed {
+			s.finalizeIfComplete(plan)
+			plan.UpdatedAt = now
+			if uerr := s.store.UpdatePlan(ctx, plan); uerr != nil {
+				s.logger.Warn("credit: failed to update plan", "plan", plan.ID, "error", uerr)
+			}
+		}
+	}
+	return paid, late, nil
+}
+
+// deduct moves one installment amount from the agent's available balance
+// into credit repayment and marks the installment paid.
+func (s *Service) deduct(ctx context.Context, plan *Plan, inst *Installment, now time.Time) error {
+	bal, err := s.ledger.GetBalance(ctx, plan.AgentAddr)
+	if err != nil {
+		return err
+	}
+	availBig, ok1 := usdc.Parse(bal.Available)
+	amountBig, ok2 := usdc.Parse(inst.Amount)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("credit: corrupted amounts for plan %s", plan.ID)
+	}
+	if availBig.Cmp(amountBig) < 0 {
+		return ledger.ErrInsufficientBalance
+	}
+
+	ref := fmt.Sprintf("installment:%s:%d", plan.ID, inst.Sequence)
+	if err := s.ledger.Debit(ctx, plan.AgentAddr, inst.Amount, ref, "credit_installment"); err != nil {
+		return err
+	}
+	if err := s.ledger.RepayCredit(ctx, plan.AgentAddr, inst.Amount); err != nil {
+		// Debit succeeded but repay failed — log loudly; reconciliation catches drift.
+		s.logger.Error("credit: repay failed after debit",
+			"plan", plan.ID, "agent", plan.AgentAddr, "amount", inst.Amount, "error", err)
+		return err
+	}
+
+	inst.Status = InstallmentPaid
+	inst.PaidAt = &now
+	return nil
+}
+
+func (s *Service) finalizeIfComplete(plan *Plan) {
+	for _, inst := range plan.Installments {
+		if inst.Status != InstallmentPaid {
+			return
+		}
+	}
+	plan.Status = PlanCompleted
+	s.logger.Info("credit: installment plan completed", "plan", plan.ID, "agent", plan.AgentAddr)
+}
+
+func nextUnpaid(plan *Plan) *Installment {
+	for _, inst := range plan.Installments {
+		if inst.Status != InstallmentPaid {
+			return inst
+		}
+	}
+	return nil
+}
+
+// GetRepaymentStats aggregates repayment punctuality for the scorer.
+func (s *Service) GetRepaymentStats(ctx context.Context, agentAddr string) (*RepaymentStats, error) {
+	plans, err := s.store.ListPlansByAgent(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	stats := &RepaymentStats{AgentAddr: agentAddr}
+	for _, p := range plans {
+		stats.TotalPlans++
+		switch p.Status {
+		case PlanCompleted:
+			stats.CompletedPlans++
+		case PlanDefaulted:
+			stats.DefaultedPlans++
+		}
+		for _, inst := range p.Installments {
+			if inst.Status != InstallmentPaid {
+				continue
+			}
+			if inst.PaidAt != nil && inst.PaidAt.After(inst.DueAt) {
+				stats.PaidLate++
+			} else {
+				stats.PaidOnTime++
+			}
+		}
+		stats.PaidLate += countLatePending(p)
+	}
+	return stats, nil
+}
+
+// countLatePending counts installments currently sitting in the late state.
+func countLatePending(p *Plan) int {
+	n := 0
+	for _, inst := range p.Installments {
+		if inst.Status == InstallmentLate {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,230 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/ledger"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// fakeLedger implements LedgerStore with a single agent balance.
+type fakeLedger struct {
+	available   *big.Int
+	pending     *big.Int
+	creditLimit *big.Int
+	creditUsed  *big.Int
+}
+
+func newFakeLedger(available, limit, used string) *fakeLedger {
+	a, _ := usdc.Parse(available)
+	l, _ := usdc.Parse(limit)
+	u, _ := usdc.Parse(used)
+	return &fakeLedger{available: a, pending: new(big.Int), creditLimit: l, creditUsed: u}
+}
+
+func (f *fakeLedger) GetBalance(_ context.Context, agentAddr string) (*ledger.Balance, error) {
+	return &ledger.Balance{
+		AgentAddr:   agentAddr,
+		Available:   usdc.Format(f.available),
+		CreditLimit: usdc.Format(f.creditLimit),
+		CreditUsed:  usdc.Format(f.creditUsed),
+	}, nil
+}
+
+func (f *fakeLedger) Debit(_ context.Context, _, amount, _, _ string) error {
+	v, _ := usdc.Parse(amount)
+	if f.available.Cmp(v) < 0 {
+		return ledger.ErrInsufficientBalance
+	}
+	f.available.Sub(f.available, v)
+	return nil
+}
+
+func (f *fakeLedger) RepayCredit(_ context.Context, _, amount string) error {
+	v, _ := usdc.Parse(amount)
+	f.creditUsed.Sub(f.creditUsed, v)
+	return nil
+}
+
+func (f *fakeLedger) Hold(_ context.Context, _, amount, _ string) error {
+	v, _ := usdc.Parse(amount)
+	if f.available.Cmp(v) < 0 {
+		return ledger.ErrInsufficientBalance
+	}
+	f.available.Sub(f.available, v)
+	f.pending.Add(f.pending, v)
+	return nil
+}
+
+func (f *fakeLedger) ConfirmHold(_ context.Context, _, amount, _ string) error {
+	v, _ := usdc.Parse(amount)
+	f.pending.Sub(f.pending, v)
+	return nil
+}
+
+func (f *fakeLedger) ReleaseHold(_ context.Context, _, amount, _ string) error {
+	v, _ := usdc.Parse(amount)
+	f.pending.Sub(f.pending, v)
+	f.available.Add(f.available, v)
+	return nil
+}
+
+func (f *fakeLedger) SetCreditLimit(_ context.Context, _, limit string) error {
+	v, _ := usdc.Parse(limit)
+	f.creditLimit = v
+	return nil
+}
+
+func (f *fakeLedger) GetCreditInfo(_ context.Context, _ string) (string, string, error) {
+	return usdc.Format(f.creditLimit), usdc.Format(f.creditUsed), nil
+}
+
+func newTestService(fl *fakeLedger) *Service {
+	return NewService(NewMemoryStore(), fl, slog.Default())
+}
+
+func TestCreatePlanSplitsPrincipal(t *testing.T) {
+	fl := newFakeLedger("0", "100", "10")
+	svc := newTestService(fl)
+
+	plan, err := svc.CreatePlan(context.Background(), "0xagent", 3, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+	if len(plan.Installments) != 3 {
+		t.Fatalf("expected 3 installments, got %d", len(plan.Installments))
+	}
+
+	total := new(big.Int)
+	for _, inst := range plan.Installments {
+		v, ok := usdc.Parse(inst.Amount)
+		if !ok {
+			t.Fatalf("bad installment amount %q", inst.Amount)
+		}
+		total.Add(total, v)
+	}
+	principal, _ := usdc.Parse(plan.Principal)
+	if total.Cmp(principal) != 0 {
+		t.Errorf("installments sum %s != principal %s", usdc.Format(total), plan.Principal)
+	}
+}
+
+func TestCreatePlanRequiresOutstandingDraw(t *testing.T) {
+	svc := newTestService(newFakeLedger("50", "100", "0"))
+	if _, err := svc.CreatePlan(context.Background(), "0xagent", 3, 24*time.Hour); err != ErrNothingOutstanding {
+		t.Fatalf("expected ErrNothingOutstanding, got %v", err)
+	}
+}
+
+func TestCreatePlanRejectsSecondActivePlan(t *testing.T) {
+	svc := newTestService(newFakeLedger("0", "100", "10"))
+	if _, err := svc.CreatePlan(context.Background(), "0xagent", 2, 24*time.Hour); err != nil {
+		t.Fatalf("first CreatePlan: %v", err)
+	}
+	if _, err := svc.CreatePlan(context.Background(), "0xagent", 2, 24*time.Hour); err != ErrPlanExists {
+		t.Fatalf("expected ErrPlanExists, got %v", err)
+	}
+}
+
+func TestPlanOwnershipEnforced(t *testing.T) {
+	fl := newFakeLedger("50", "100", "10")
+	svc := newTestService(fl)
+
+	plan, err := svc.CreatePlan(context.Background(), "0xagent", 2, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+
+	// Only the plan's owner can read it or trigger a payment.
+	if _, err := svc.GetPlan(context.Background(), plan.ID, "0xstranger"); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("expected ErrNotOwner from GetPlan, got %v", err)
+	}
+	if _, err := svc.PayNext(context.Background(), plan.ID, "0xstranger"); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("expected ErrNotOwner from PayNext, got %v", err)
+	}
+
+	// Address comparison is case-insensitive (auth lowercases callers).
+	if _, err := svc.PayNext(context.Background(), plan.ID, "0xAgent"); err != nil {
+		t.Fatalf("PayNext as owner: %v", err)
+	}
+}
+
+func TestProcessDueAutoDeductsAndMarksLate(t *testing.T) {
+	fl := newFakeLedger("5", "100", "10")
+	svc := newTestService(fl)
+
+	plan, err := svc.CreatePlan(context.Background(), "0xagent", 2, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+
+	// Both installments due; only the first (5 USDC) is covered by earnings.
+	paid, late, err := svc.ProcessDue(context.Background(), time.Now().Add(72*time.Hour))
+	if err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	if paid != 1 || late != 1 {
+		t.Fatalf("expected 1 paid / 1 late, got %d / %d", paid, late)
+	}
+
+	got, err := svc.GetPlan(context.Background(), plan.ID, "0xagent")
+	if err != nil {
+		t.Fatalf("GetPlan: %v", err)
+	}
+	if got.Installments[0].Status != InstallmentPaid {
+		t.Errorf("installment 1 status = %s, want paid", got.Installments[0].Status)
+	}
+	if got.Installments[1].Status != InstallmentLate {
+		t.Errorf("installment 2 status = %s, want late", got.Installments[1].Status)
+	}
+	if got.LateCount != 1 {
+		t.Errorf("LateCount = %d, want 1", got.LateCount)
+	}
+
+	// Earnings arrive — the late installment is swept on the next tick.
+	fl.available, _ = usdc.Parse("5")
+	paid, late, err = svc.ProcessDue(context.Background(), time.Now().Add(72*time.Hour))
+	if err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	if paid != 1 || late != 0 {
+		t.Fatalf("expected 1 paid / 0 late, got %d / %d", paid, late)
+	}
+
+	got, _ = svc.GetPlan(context.Background(), plan.ID, "0xagent")
+	if got.Status != PlanCompleted {
+		t.Errorf("plan status = %s, want completed", got.Status)
+	}
+	if fl.creditUsed.Sign() != 0 {
+		t.Errorf("credit used = %s, want 0", usdc.Format(fl.creditUsed))
+	}
+}
+
+func TestRepaymentStats(t *testing.T) {
+	fl := newFakeLedger("10", "100", "10")
+	svc := newTestService(fl)
+
+	if _, err := svc.CreatePlan(context.Background(), "0xagent", 2, 24*time.Hour); err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+	if _, _, err := svc.ProcessDue(context.Background(), time.Now().Add(72*time.Hour)); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+
+	stats, err := svc.GetRepaymentStats(context.Background(), "0xagent")
+	if err != nil {
+		t.Fatalf("GetRepaymentStats: %v", err)
+	}
+	if stats.TotalPlans != 1 || stats.CompletedPlans != 1 {
+		t.Errorf("stats = %+v, want 1 total / 1 completed", stats)
+	}
+	if stats.PaidLate != 2 {
+		// Both installments were deducted after their due dates.
+		t.Errorf("PaidLate = %d, want 2", stats.PaidLate)
+	}
+}
@@ -0,0 +1,173 @@
+package credit
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// DelinquencyBucket classifies how far overdue a plan's oldest unpaid
+// installment is.
+type DelinquencyBucket string
+
+const (
+	BucketCurrent  DelinquencyBucket = "current"
+	BucketEarly    DelinquencyBucket = "1-7"  // 1-7 days overdue
+	BucketSerious  DelinquencyBucket = "8-30" // 8-30 days overdue
+	BucketCritical DelinquencyBucket = "30+"  // 30+ days overdue
+)
+
+// defaultGracePeriod is how long a plan can sit in the 30+ bucket before it
+// defaults and collateral is liquidated.
+const defaultGracePeriod = 7 * 24 * time.Hour
+
+// WebhookEmitter notifies agents about delinquency stage changes.
+type WebhookEmitter interface {
+	EmitCreditDelinquent(agentAddr, planID, bucket, overdueAmount string, restricted bool)
+	EmitCreditDefaulted(agentAddr, planID, outstanding string)
+	EmitCreditCosignerCharged(agentAddr, cosignerAddr, planID, amount string)
+}
+
+// WithWebhookEmitter sets the webhook emitter for delinquency notices.
+func (s *Service) WithWebhookEmitter(e WebhookEmitter) *Service {
+	s.webhookEmitter = e
+	return s
+}
+
+// WithGracePeriod overrides how long a plan may stay 30+ days overdue before
+// it defaults.
+func (s *Service) WithGracePeriod(d time.Duration) *Service {
+	if d > 0 {
+		s.grace = d
+	}
+	return s
+}
+
+// bucketFor maps an overdue age to its delinquency bucket.
+func bucketFor(age time.Duration) DelinquencyBucket {
+	switch days := age.Hours() / 24; {
+	case age <= 0:
+		return BucketCurrent
+	case days <= 7:
+		return BucketEarly
+	case days <= 30:
+		return BucketSerious
+	default:
+		return BucketCritical
+	}
+}
+
+// overdueState returns the plan's current bucket, the age of its oldest
+// unpaid overdue installment, and the total overdue amount.
+func overdueState(plan *Plan, now time.Time) (DelinquencyBucket, time.Duration, *big.Int) {
+	var oldest time.Time
+	overdue := new(big.Int)
+	for _, inst := range plan.Installments {
+		if inst.Status == InstallmentPaid || inst.DueAt.After(now) {
+			continue
+		}
+		if oldest.IsZero() || inst.DueAt.Before(oldest) {
+			oldest = inst.DueAt
+		}
+		if v, ok := usdc.Parse(inst.Amount); ok {
+			overdue.Add(overdue, v)
+		}
+	}
+	if oldest.IsZero() {
+		return BucketCurrent, 0, overdue
+	}
+	age := now.Sub(oldest)
+	return bucketFor(age), age, overdue
+}
+
+// processDelinquency reclassifies a plan after the deduction pass and applies
+// the stage for its bucket: a notice at 1-7 days, a freeze on new credit
+// draws at 8-30 days, and default once the plan has been 30+ days overdue for
+// longer than the grace period. Caller holds s.mu. Returns true when the plan
+// changed.
+func (s *Service) processDelinquency(ctx context.Context, plan *Plan, now time.Time) bool {
+	bucket, age, overdue := overdueState(plan, now)
+
+	// Recovered: everything overdue has been paid.
+	if bucket == BucketCurrent {
+		if plan.Delinquency == "" || plan.Delinquency == BucketCurrent {
+			return false
+		}
+		s.unfreezeCredit(ctx, plan)
+		plan.Delinquency = BucketCurrent
+		s.logger.Info("credit: plan current again", "plan", plan.ID, "agent", plan.AgentAddr)
+		return true
+	}
+
+	// Past the critical bucket plus grace, the plan defaults.
+	if bucket == BucketCritical && age > 30*24*time.Hour+s.gracePeriod() {
+		s.markDefaultedLocked(ctx, plan)
+		if s.webhookEmitter != nil {
+			go s.webhookEmitter.EmitCreditDefaulted(plan.AgentAddr, plan.ID, usdc.Format(overdue))
+		}
+		return true
+	}
+
+	if bucket == plan.Delinquency {
+		return false
+	}
+
+	restricted := false
+	if bucket == BucketSerious || bucket == BucketCritical {
+		restricted = s.freezeCredit(ctx, plan)
+	}
+	plan.Delinquency = bucket
+	s.logger.Warn("credit: plan delinquent",
+		"plan", plan.ID, "agent", plan.AgentAddr, "bucket", bucket,
+		"overdue", usdc.Format(overdue), "restricted", restricted)
+	if s.webhookEmitter != nil {
+		go s.webhookEmitter.EmitCreditDelinquent(plan.AgentAddr, plan.ID, string(bucket), usdc.Format(overdue), restricted)
+	}
+	return true
+}
+
+func (s *Service) gracePeriod() time.Duration {
+	if s.grace > 0 {
+		return s.grace
+	}
+	return defaultGracePeriod
+}
+
+// freezeCredit clamps the agent's credit limit to the amount already drawn so
+// no new credit spending is possible, remembering the original limit for
+// restoration on recovery. Returns true if a freeze is in effect.
+func (s *Service) freezeCredit(ctx context.Context, plan *Plan) bool {
+	if plan.FrozenLimit != "" {
+		return true // already frozen
+	}
+	limit, used, err := s.ledger.GetCreditInfo(ctx, plan.AgentAddr)
+	if err != nil {
+		s.logger.Warn("credit: freeze failed", "plan", plan.ID, "error", err)
+		return false
+	}
+	limitBig, ok1 := usdc.Parse(limit)
+	usedBig, ok2 := usdc.Parse(used)
+	if !ok1 || !ok2 || limitBig.Cmp(usedBig) <= 0 {
+		return false // nothing left to draw anyway
+	}
+	if err := s.ledger.SetCreditLimit(ctx, plan.AgentAddr, usdc.Format(usedBig)); err != nil {
+		s.logger.Warn("credit: freeze failed", "plan", plan.ID, "error", err)
+		return false
+	}
+	plan.FrozenLimit = usdc.Format(limitBig)
+	return true
+}
+
+// unfreezeCredit restores the limit saved by freezeCredit.
+func (s *Service) unfreezeCredit(ctx context.Context, plan *Plan) {
+	if plan.FrozenLimit == "" {
+		return
+	}
+	if err := s.ledger.SetCreditLimit(ctx, plan.AgentAddr, plan.FrozenLimit); err != nil {
+		s.logger.Warn("credit: unfreeze failed", "plan", plan.ID, "error", err)
+		return
+	}
+	plan.FrozenLimit = ""
+}
@@ -0,0 +1,194 @@
+package credit
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// credit adds earnings to the fake ledger's available balance.
+func (f *fakeLedger) credit(t *testing.T, amount string) {
+	t.Helper()
+	v, ok := usdc.Parse(amount)
+	if !ok {
+		t.Fatalf("bad amount %q", amount)
+	}
+	f.available.Add(f.available, v)
+}
+
+type fakeEmitter struct {
+	mu              sync.Mutex
+	delinquent      []string // buckets in emission order
+	defaulted       int
+	cosignerCharged int
+}
+
+func (f *fakeEmitter) EmitCreditDelinquent(_, _, bucket, _ string, _ bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delinquent = append(f.delinquent, bucket)
+}
+
+func (f *fakeEmitter) EmitCreditDefaulted(_, _, _ string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.defaulted++
+}
+
+func (f *fakeEmitter) EmitCreditCosignerCharged(_, _, _, _ string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cosignerCharged++
+}
+
+func (f *fakeEmitter) snapshot() ([]string, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.delinquent...), f.defaulted
+}
+
+func (f *fakeEmitter) total() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.delinquent) + f.defaulted + f.cosignerCharged
+}
+
+func TestDelinquencyBucketsProgress(t *testing.T) {
+	fl := newFakeLedger("0", "100.00", "30.00") // no earnings to deduct from
+	svc := newTestService(fl)
+	emitter := &fakeEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+
+	plan, err := svc.CreatePlan(ctx, "0xagent", 3, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+
+	// Two days past the first due date: early bucket, notice only.
+	now := plan.CreatedAt.Add(3 * 24 * time.Hour)
+	if _, _, err := svc.ProcessDue(ctx, now); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	plan, _ = svc.GetPlan(ctx, plan.ID, "0xagent")
+	if plan.Delinquency != BucketEarly {
+		t.Errorf("bucket = %s, want %s", plan.Delinquency, BucketEarly)
+	}
+	if got, _, _ := fl.GetCreditInfo(ctx, "0xagent"); got != "100.000000" {
+		t.Errorf("limit = %s, want untouched 100.000000", got)
+	}
+
+	// Ten days past due: serious bucket, new draws frozen at current usage.
+	now = plan.CreatedAt.Add(11 * 24 * time.Hour)
+	if _, _, err := svc.ProcessDue(ctx, now); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	plan, _ = svc.GetPlan(ctx, plan.ID, "0xagent")
+	if plan.Delinquency != BucketSerious {
+		t.Errorf("bucket = %s, want %s", plan.Delinquency, BucketSerious)
+	}
+	if plan.FrozenLimit != "100.000000" {
+		t.Errorf("frozen limit = %s, want 100.000000", plan.FrozenLimit)
+	}
+	if got, _, _ := fl.GetCreditInfo(ctx, "0xagent"); got != "30.000000" {
+		t.Errorf("limit = %s, want frozen to usage 30.000000", got)
+	}
+
+	waitForEmits(t, emitter, 2)
+	buckets, defaulted := emitter.snapshot()
+	sort.Strings(buckets) // emissions are async; order is not guaranteed
+	if len(buckets) != 2 || buckets[0] != string(BucketEarly) || buckets[1] != string(BucketSerious) {
+		t.Errorf("delinquent notices = %v, want [1-7 8-30]", buckets)
+	}
+	if defaulted != 0 {
+		t.Errorf("defaulted notices = %d, want 0", defaulted)
+	}
+}
+
+func TestDelinquencyRecoveryUnfreezes(t *testing.T) {
+	fl := newFakeLedger("0", "100.00", "30.00")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	plan, err := svc.CreatePlan(ctx, "0xagent", 3, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+
+	// Freeze via the serious bucket, then fund the agent and re-run.
+	now := plan.CreatedAt.Add(11 * 24 * time.Hour)
+	if _, _, err := svc.ProcessDue(ctx, now); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	fl.credit(t, "40.00")
+	if _, _, err := svc.ProcessDue(ctx, now.Add(time.Hour)); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+
+	plan, _ = svc.GetPlan(ctx, plan.ID, "0xagent")
+	if plan.Status != PlanCompleted {
+		t.Fatalf("status = %s, want completed", plan.Status)
+	}
+	if plan.FrozenLimit != "" {
+		t.Errorf("frozen limit = %q, want cleared", plan.FrozenLimit)
+	}
+	if got, _, _ := fl.GetCreditInfo(ctx, "0xagent"); got != "100.000000" {
+		t.Errorf("limit = %s, want restored 100.000000", got)
+	}
+}
+
+func TestDelinquencyDefaultsAfterGrace(t *testing.T) {
+	fl := newFakeLedger("0", "100.00", "30.00")
+	svc := newTestService(fl)
+	emitter := &fakeEmitter{}
+	svc.WithWebhookEmitter(emitter).WithGracePeriod(48 * time.Hour)
+	ctx := context.Background()
+
+	plan, err := svc.CreatePlan(ctx, "0xagent", 3, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+
+	// 31 days overdue is inside the grace period: critical but still active.
+	now := plan.CreatedAt.Add(32 * 24 * time.Hour)
+	if _, _, err := svc.ProcessDue(ctx, now); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	plan, _ = svc.GetPlan(ctx, plan.ID, "0xagent")
+	if plan.Status != PlanActive || plan.Delinquency != BucketCritical {
+		t.Fatalf("status/bucket = %s/%s, want active/30+", plan.Status, plan.Delinquency)
+	}
+
+	// Past 30 days plus the 48h grace: the plan defaults.
+	now = plan.CreatedAt.Add(34 * 24 * time.Hour)
+	if _, _, err := svc.ProcessDue(ctx, now); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	plan, _ = svc.GetPlan(ctx, plan.ID, "0xagent")
+	if plan.Status != PlanDefaulted {
+		t.Fatalf("status = %s, want defaulted", plan.Status)
+	}
+
+	waitForEmits(t, emitter, 2)
+	_, defaulted := emitter.snapshot()
+	if defaulted != 1 {
+		t.Errorf("defaulted notices = %d, want 1", defaulted)
+	}
+}
+
+// waitForEmits waits for n total notices; emissions happen on goroutines.
+func waitForEmits(t *testing.T, f *fakeEmitter, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if f.total() >= n {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d webhook notices", n)
+}
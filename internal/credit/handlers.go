@@ -0,0 +1,445 @@
+package credit
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mbd888/alancoin/internal/reputation"
+)
+
+// Handler provides HTTP endpoints for installment plans.
+type Handler struct {
+	service *Service
+	signer  *reputation.Signer
+}
+
+// NewHandler creates a new credit handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// WithReportSigner sets the HMAC signer used for portable credit reports.
+func (h *Handler) WithReportSigner(signer *reputation.Signer) *Handler {
+	h.signer = signer
+	return h
+}
+
+// RegisterRoutes sets up owner-scoped installment plan routes alongside the
+// ledger's credit routes.
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/agents/:address/credit/installments", h.CreatePlan)
+	r.GET("/agents/:address/credit/installments", h.ListPlans)
+	r.POST("/agents/:address/credit/collateral", h.PledgeCollateral)
+	r.GET("/agents/:address/credit/collateral", h.ListCollateral)
+	r.POST("/agents/:address/credit/cosigners", h.RequestCosign)
+	r.GET("/agents/:address/credit/cosigners", h.ListCosigns)
+	r.PUT("/agents/:address/credit/alerts", h.SetAlertConfig)
+	r.GET("/agents/:address/credit/alerts", h.GetAlertConfig)
+	r.PUT("/agents/:address/credit/sweep", h.SetSweepConfig)
+	r.GET("/agents/:address/credit/sweep", h.GetSweepConfig)
+	r.GET("/agents/:address/credit/report", h.GetCreditReport)
+}
+
+// RegisterPlanRoutes sets up plan-ID routes (auth required).
+func (h *Handler) RegisterPlanRoutes(r *gin.RouterGroup) {
+	r.GET("/credit/installments/:id", h.GetPlan)
+	r.POST("/credit/installments/:id/pay", h.PayNext)
+	r.POST("/credit/collateral/:id/release", h.ReleaseCollateral)
+	r.POST("/credit/cosigners/:id/accept", h.AcceptCosign)
+	r.POST("/credit/cosigners/:id/revoke", h.RevokeCosign)
+}
+
+// RegisterAdminRoutes sets up the manual credit review queue (admin only).
+func (h *Handler) RegisterAdminRoutes(r *gin.RouterGroup) {
+	r.GET("/admin/credit/review", h.ListReviewQueue)
+	r.POST("/admin/credit/review/:id/approve", h.ApproveApplication)
+	r.POST("/admin/credit/review/:id/deny", h.DenyApplication)
+}
+
+// CreatePlanRequest configures an installment plan.
+type CreatePlanRequest struct {
+	NumPayments  int `json:"numPayments" binding:"required"`
+	IntervalDays int `json:"intervalDays"` // default 7
+}
+
+// CreatePlan handles POST /agents/:address/credit/installments
+func (h *Handler) CreatePlan(c *gin.Context) {
+	address := c.Param("address")
+
+	var req CreatePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+	if req.IntervalDays <= 0 {
+		req.IntervalDays = 7
+	}
+
+	plan, err := h.service.CreatePlan(c.Request.Context(), address, req.NumPayments,
+		time.Duration(req.IntervalDays)*24*time.Hour)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidSchedule):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_schedule", "message": "numPayments must be 1-36 and interval at least one hour"})
+		case errors.Is(err, ErrPlanExists):
+			c.JSON(http.StatusConflict, gin.H{"error": "plan_exists", "message": "Agent already has an active installment plan"})
+		case errors.Is(err, ErrNothingOutstanding):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "nothing_outstanding", "message": "No outstanding credit draw to schedule"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "plan_error", "message": "Failed to create installment plan"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, plan)
+}
+
+// ListPlans handles GET /agents/:address/credit/installments
+func (h *Handler) ListPlans(c *gin.Context) {
+	plans, err := h.service.ListPlans(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "plan_error", "message": "Failed to list installment plans"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"plans": plans, "count": len(plans)})
+}
+
+// GetPlan handles GET /credit/installments/:id
+func (h *Handler) GetPlan(c *gin.Context) {
+	plan, err := h.service.GetPlan(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		if errors.Is(err, ErrNotOwner) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_owner", "message": "Caller does not own this plan"})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{"error": "plan_not_found", "message": "Installment plan not found"})
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
+
+// ListReviewQueue handles GET /admin/credit/review
+func (h *Handler) ListReviewQueue(c *gin.Context) {
+	apps, err := h.service.ListPendingApplications(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "review_error", "message": "Failed to list review queue"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"applications": apps, "count": len(apps)})
+}
+
+// ReviewDecisionRequest carries an admin decision on an application.
+type ReviewDecisionRequest struct {
+	Limit string `json:"limit"` // approve only; empty = scorer's proposed limit
+	Notes string `json:"notes"`
+}
+
+// ApproveApplication handles POST /admin/credit/review/:id/approve
+func (h *Handler) ApproveApplication(c *gin.Context) {
+	var req ReviewDecisionRequest
+	_ = c.ShouldBindJSON(&req) // body optional
+
+	decidedBy := c.GetString("authAgentAddr")
+	if decidedBy == "" {
+		decidedBy = "admin"
+	}
+
+	app, err := h.service.ApproveApplication(c.Request.Context(), c.Param("id"), req.Limit, req.Notes, decidedBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrApplicationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "application_not_found", "message": "Application not found"})
+		case errors.Is(err, ErrApplicationDecided):
+			c.JSON(http.StatusConflict, gin.H{"error": "already_decided", "message": "Application already decided"})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "approve_failed", "message": "Failed to approve application"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, app)
+}
+
+// DenyApplication handles POST /admin/credit/review/:id/deny
+func (h *Handler) DenyApplication(c *gin.Context) {
+	var req ReviewDecisionRequest
+	_ = c.ShouldBindJSON(&req)
+
+	decidedBy := c.GetString("authAgentAddr")
+	if decidedBy == "" {
+		decidedBy = "admin"
+	}
+
+	app, err := h.service.DenyApplication(c.Request.Context(), c.Param("id"), req.Notes, decidedBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrApplicationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "application_not_found", "message": "Application not found"})
+		case errors.Is(err, ErrApplicationDecided):
+			c.JSON(http.StatusConflict, gin.H{"error": "already_decided", "message": "Application already decided"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "deny_failed", "message": "Failed to deny application"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, app)
+}
+
+// AlertConfigRequest sets utilization alert thresholds as 0-1 fractions.
+type AlertConfigRequest struct {
+	WarnPct     float64 `json:"warnPct" binding:"required"`
+	CriticalPct float64 `json:"criticalPct" binding:"required"`
+}
+
+// SetAlertConfig handles PUT /agents/:address/credit/alerts
+func (h *Handler) SetAlertConfig(c *gin.Context) {
+	var req AlertConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "message": "Invalid request body"})
+		return
+	}
+
+	cfg, err := h.service.SetAlertConfig(c.Request.Context(), c.Param("address"), req.WarnPct, req.CriticalPct)
+	if err != nil {
+		if errors.Is(err, ErrInvalidThresholds) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_thresholds", "message": "Thresholds must be fractions in (0, 1] with warnPct <= criticalPct"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "alert_error", "message": "Failed to save alert config"})
+		return
+	}
+	c.JSON(http.StatusOK, cfg)
+}
+
+// GetAlertConfig handles GET /agents/:address/credit/alerts
+func (h *Handler) GetAlertConfig(c *gin.Context) {
+	cfg, err := h.service.GetAlertConfig(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "alert_error", "message": "Failed to load alert config"})
+		return
+	}
+	c.JSON(http.StatusOK, cfg)
+}
+
+// SweepConfigRequest sets the auto-repayment fraction and priority.
+type SweepConfigRequest struct {
+	Fraction float64 `json:"fraction"`
+	Priority string  `json:"priority"` // "credit_first" (default) or "stakes_first"
+}
+
+// SetSweepConfig handles PUT /agents/:address/credit/sweep
+func (h *Handler) SetSweepConfig(c *gin.Context) {
+	var req SweepConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "message": "Invalid request body"})
+		return
+	}
+
+	cfg, err := h.service.SetSweepConfig(c.Request.Context(), c.Param("address"), req.Fraction, SweepPriority(req.Priority))
+	if err != nil {
+		if errors.Is(err, ErrInvalidSweepConfig) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_sweep_config", "message": "fraction must be 0-1 and priority credit_first or stakes_first"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "sweep_error", "message": "Failed to save sweep config"})
+		return
+	}
+	c.JSON(http.StatusOK, cfg)
+}
+
+// GetSweepConfig handles GET /agents/:address/credit/sweep
+func (h *Handler) GetSweepConfig(c *gin.Context) {
+	cfg, err := h.service.GetSweepConfig(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "sweep_error", "message": "Failed to load sweep config"})
+		return
+	}
+	c.JSON(http.StatusOK, cfg)
+}
+
+// GetCreditReport handles GET /agents/:address/credit/report
+func (h *Handler) GetCreditReport(c *gin.Context) {
+	report, err := h.service.BuildCreditReport(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "report_error", "message": "Failed to build credit report"})
+		return
+	}
+	signed := &SignedCreditReport{Report: report}
+	if h.signer != nil {
+		if sig, issued, expires, err := h.signer.Sign(report); err == nil {
+			signed.Signature = sig
+			signed.IssuedAt = issued
+			signed.ExpiresAt = expires
+		}
+	}
+	c.JSON(http.StatusOK, signed)
+}
+
+// RequestCosignRequest names the agent asked to back a credit line.
+type RequestCosignRequest struct {
+	CosignerAddr string `json:"cosignerAddr" binding:"required"`
+}
+
+// RequestCosign handles POST /agents/:address/credit/cosigners
+func (h *Handler) RequestCosign(c *gin.Context) {
+	var req RequestCosignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "message": "Invalid request body"})
+		return
+	}
+
+	cs, err := h.service.RequestCosign(c.Request.Context(), c.Param("address"), req.CosignerAddr)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSelfCosign):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "self_cosign", "message": "An agent cannot co-sign its own credit line"})
+		case errors.Is(err, ErrCosignExists):
+			c.JSON(http.StatusConflict, gin.H{"error": "cosign_exists", "message": "A co-sign already exists for this pair"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "cosign_error", "message": "Failed to request co-sign"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, cs)
+}
+
+// ListCosigns handles GET /agents/:address/credit/cosigners
+func (h *Handler) ListCosigns(c *gin.Context) {
+	cosigns, err := h.service.ListCosigns(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "cosign_error", "message": "Failed to list co-signs"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"cosigns": cosigns, "count": len(cosigns)})
+}
+
+// AcceptCosign handles POST /credit/cosigners/:id/accept
+func (h *Handler) AcceptCosign(c *gin.Context) {
+	cs, err := h.service.AcceptCosign(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.cosignError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, cs)
+}
+
+// RevokeCosign handles POST /credit/cosigners/:id/revoke
+func (h *Handler) RevokeCosign(c *gin.Context) {
+	cs, err := h.service.RevokeCosign(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		h.cosignError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, cs)
+}
+
+func (h *Handler) cosignError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrCosignNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "cosign_not_found", "message": "Co-sign not found"})
+	case errors.Is(err, ErrNotCosigner):
+		c.JSON(http.StatusForbidden, gin.H{"error": "not_cosigner", "message": "Caller is not a party to this co-sign"})
+	case errors.Is(err, ErrCosignNotPending), errors.Is(err, ErrCosignNotActive):
+		c.JSON(http.StatusConflict, gin.H{"error": "invalid_state", "message": "Co-sign is not in a state that allows this action"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "cosign_error", "message": "Co-sign operation failed"})
+	}
+}
+
+// PledgeCollateralRequest describes a collateral pledge.
+type PledgeCollateralRequest struct {
+	Kind       string `json:"kind" binding:"required"` // "ledger_lock" or "stake"
+	Amount     string `json:"amount"`                  // required for ledger_lock
+	PositionID string `json:"positionId"`              // required for stake
+}
+
+// PledgeCollateral handles POST /agents/:address/credit/collateral
+func (h *Handler) PledgeCollateral(c *gin.Context) {
+	address := c.Param("address")
+
+	var req PledgeCollateralRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "message": "Invalid request body"})
+		return
+	}
+
+	var col *Collateral
+	var err error
+	switch CollateralKind(req.Kind) {
+	case CollateralLedgerLock:
+		col, err = h.service.PledgeLedgerLock(c.Request.Context(), address, req.Amount)
+	case CollateralStake:
+		col, err = h.service.PledgeStake(c.Request.Context(), address, req.PositionID)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_kind", "message": "kind must be ledger_lock or stake"})
+		return
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidCollateral):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_collateral", "message": "Collateral amount must be a positive USDC value"})
+		case errors.Is(err, ErrStakesUnavailable):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "not_configured", "message": "Stake collateral is not available"})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "pledge_failed", "message": "Failed to pledge collateral"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, col)
+}
+
+// ListCollateral handles GET /agents/:address/credit/collateral
+func (h *Handler) ListCollateral(c *gin.Context) {
+	cols, err := h.service.ListCollateral(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "collateral_error", "message": "Failed to list collateral"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"collateral": cols, "count": len(cols)})
+}
+
+// ReleaseCollateral handles POST /credit/collateral/:id/release
+func (h *Handler) ReleaseCollateral(c *gin.Context) {
+	col, err := h.service.ReleaseCollateral(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrCollateralNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "collateral_not_found", "message": "Collateral not found"})
+		case errors.Is(err, ErrNotOwner):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_owner", "message": "Caller does not own this collateral"})
+		case errors.Is(err, ErrCollateralNotActive):
+			c.JSON(http.StatusConflict, gin.H{"error": "collateral_not_active", "message": "Collateral is not active"})
+		default:
+			c.JSON(http.StatusConflict, gin.H{"error": "release_failed", "message": "Failed to release collateral"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, col)
+}
+
+// PayNext handles POST /credit/installments/:id/pay
+func (h *Handler) PayNext(c *gin.Context) {
+	plan, err := h.service.PayNext(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPlanNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "plan_not_found", "message": "Installment plan not found"})
+		case errors.Is(err, ErrNotOwner):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_owner", "message": "Caller does not own this plan"})
+		case errors.Is(err, ErrPlanNotActive):
+			c.JSON(http.StatusConflict, gin.H{"error": "plan_not_active", "message": "Plan is not active"})
+		case errors.Is(err, ErrNothingDue):
+			c.JSON(http.StatusConflict, gin.H{"error": "nothing_due", "message": "No unpaid installment remaining"})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "payment_failed", "message": "Failed to pay installment"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, plan)
+}
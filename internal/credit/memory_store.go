@@ -0,0 +1,253 @@
+package credit
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-memory installment plan store for testing and
+// non-postgres deployments.
+type MemoryStore struct {
+	mu           sync.RWMutex
+	plans        map[string]*Plan
+	collateral   map[string]*Collateral
+	cosigns      map[string]*Cosign
+	applications map[string]*Application
+	alertConfigs map[string]*AlertConfig
+	sweepConfigs map[string]*SweepConfig
+}
+
+// NewMemoryStore creates an in-memory credit store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		plans:        make(map[string]*Plan),
+		collateral:   make(map[string]*Collateral),
+		cosigns:      make(map[string]*Cosign),
+		applications: make(map[string]*Application),
+		alertConfigs: make(map[string]*AlertConfig),
+		sweepConfigs: make(map[string]*SweepConfig),
+	}
+}
+
+func (m *MemoryStore) CreatePlan(_ context.Context, p *Plan) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.plans[p.ID] = p
+	return nil
+}
+
+func (m *MemoryStore) GetPlan(_ context.Context, id string) (*Plan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.plans[id]
+	if !ok {
+		return nil, ErrPlanNotFound
+	}
+	return p, nil
+}
+
+func (m *MemoryStore) ListPlansByAgent(_ context.Context, agentAddr string) ([]*Plan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Plan
+	for _, p := range m.plans {
+		if p.AgentAddr == agentAddr {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (m *MemoryStore) ListActivePlans(_ context.Context) ([]*Plan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Plan
+	for _, p := range m.plans {
+		if p.Status == PlanActive {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (m *MemoryStore) UpdatePlan(_ context.Context, p *Plan) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.plans[p.ID]; !ok {
+		return ErrPlanNotFound
+	}
+	m.plans[p.ID] = p
+	return nil
+}
+
+func (m *MemoryStore) CreateCollateral(_ context.Context, c *Collateral) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.collateral[c.ID] = c
+	return nil
+}
+
+func (m *MemoryStore) GetCollateral(_ context.Context, id string) (*Collateral, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.collateral[id]
+	if !ok {
+		return nil, ErrCollateralNotFound
+	}
+	return c, nil
+}
+
+func (m *MemoryStore) ListCollateralByAgent(_ context.Context, agentAddr string) ([]*Collateral, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Collateral
+	for _, c := range m.collateral {
+		if c.AgentAddr == agentAddr {
+			result = append(result, c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (m *MemoryStore) UpdateCollateral(_ context.Context, c *Collateral) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.collateral[c.ID]; !ok {
+		return ErrCollateralNotFound
+	}
+	m.collateral[c.ID] = c
+	return nil
+}
+
+func (m *MemoryStore) SetAlertConfig(_ context.Context, cfg *AlertConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.alertConfigs[cfg.AgentAddr] = cfg
+	return nil
+}
+
+func (m *MemoryStore) GetAlertConfig(_ context.Context, agentAddr string) (*AlertConfig, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.alertConfigs[agentAddr], nil
+}
+
+func (m *MemoryStore) ListAlertConfigs(_ context.Context) ([]*AlertConfig, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*AlertConfig
+	for _, cfg := range m.alertConfigs {
+		result = append(result, cfg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AgentAddr < result[j].AgentAddr
+	})
+	return result, nil
+}
+
+func (m *MemoryStore) SetSweepConfig(_ context.Context, cfg *SweepConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweepConfigs[cfg.AgentAddr] = cfg
+	return nil
+}
+
+func (m *MemoryStore) GetSweepConfig(_ context.Context, agentAddr string) (*SweepConfig, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sweepConfigs[agentAddr], nil
+}
+
+func (m *MemoryStore) CreateCosign(_ context.Context, cs *Cosign) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cosigns[cs.ID] = cs
+	return nil
+}
+
+func (m *MemoryStore) GetCosign(_ context.Context, id string) (*Cosign, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	cs, ok := m.cosigns[id]
+	if !ok {
+		return nil, ErrCosignNotFound
+	}
+	return cs, nil
+}
+
+func (m *MemoryStore) ListCosignsByAgent(_ context.Context, agentAddr string) ([]*Cosign, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Cosign
+	for _, cs := range m.cosigns {
+		if cs.AgentAddr == agentAddr || cs.CosignerAddr == agentAddr {
+			result = append(result, cs)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (m *MemoryStore) UpdateCosign(_ context.Context, cs *Cosign) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.cosigns[cs.ID]; !ok {
+		return ErrCosignNotFound
+	}
+	m.cosigns[cs.ID] = cs
+	return nil
+}
+
+func (m *MemoryStore) CreateApplication(_ context.Context, a *Application) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.applications[a.ID] = a
+	return nil
+}
+
+func (m *MemoryStore) GetApplication(_ context.Context, id string) (*Application, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	a, ok := m.applications[id]
+	if !ok {
+		return nil, ErrApplicationNotFound
+	}
+	return a, nil
+}
+
+func (m *MemoryStore) ListPendingApplications(_ context.Context) ([]*Application, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Application
+	for _, a := range m.applications {
+		if a.Status == ApplicationPending {
+			result = append(result, a)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (m *MemoryStore) UpdateApplication(_ context.Context, a *Application) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.applications[a.ID]; !ok {
+		return ErrApplicationNotFound
+	}
+	m.applications[a.ID] = a
+	return nil
+}
@@ -0,0 +1,385 @@
+package credit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+// PostgresStore persists installment plans in PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a PostgreSQL-backed credit store.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (p *PostgresStore) CreatePlan(ctx context.Context, plan *Plan) error {
+	instJSON, _ := json.Marshal(plan.Installments)
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO credit_installment_plans (id, agent_addr, principal, installments, status, late_count, delinquency, frozen_limit, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, plan.ID, plan.AgentAddr, plan.Principal, instJSON, plan.Status, plan.LateCount, plan.Delinquency, plan.FrozenLimit, plan.CreatedAt, plan.UpdatedAt)
+	return err
+}
+
+func (p *PostgresStore) GetPlan(ctx context.Context, id string) (*Plan, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id, agent_addr, principal, installments, status, late_count, delinquency, frozen_limit, created_at, updated_at
+		FROM credit_installment_plans WHERE id = $1
+	`, id)
+	return scanPlan(row)
+}
+
+func (p *PostgresStore) ListPlansByAgent(ctx context.Context, agentAddr string) ([]*Plan, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, agent_addr, principal, installments, status, late_count, delinquency, frozen_limit, created_at, updated_at
+		FROM credit_installment_plans WHERE agent_addr = $1
+		ORDER BY created_at DESC
+	`, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPlans(rows)
+}
+
+func (p *PostgresStore) ListActivePlans(ctx context.Context) ([]*Plan, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, agent_addr, principal, installments, status, late_count, delinquency, frozen_limit, created_at, updated_at
+		FROM credit_installment_plans WHERE status = 'active'
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanPlans(rows)
+}
+
+func (p *PostgresStore) UpdatePlan(ctx context.Context, plan *Plan) error {
+	instJSON, _ := json.Marshal(plan.Installments)
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE credit_installment_plans
+		SET installments = $2, status = $3, late_count = $4, delinquency = $5, frozen_limit = $6, updated_at = $7
+		WHERE id = $1
+	`, plan.ID, instJSON, plan.Status, plan.LateCount, plan.Delinquency, plan.FrozenLimit, plan.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrPlanNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) CreateCollateral(ctx context.Context, c *Collateral) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO credit_collateral (id, agent_addr, kind, amount, reference, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, c.ID, c.AgentAddr, c.Kind, c.Amount, c.Reference, c.Status, c.CreatedAt, c.UpdatedAt)
+	return err
+}
+
+func (p *PostgresStore) GetCollateral(ctx context.Context, id string) (*Collateral, error) {
+	var c Collateral
+	err := p.db.QueryRowContext(ctx, `
+		SELECT id, agent_addr, kind, amount, reference, status, created_at, updated_at
+		FROM credit_collateral WHERE id = $1
+	`, id).Scan(&c.ID, &c.AgentAddr, &c.Kind, &c.Amount, &c.Reference, &c.Status, &c.CreatedAt, &c.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrCollateralNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (p *PostgresStore) ListCollateralByAgent(ctx context.Context, agentAddr string) ([]*Collateral, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, agent_addr, kind, amount, reference, status, created_at, updated_at
+		FROM credit_collateral WHERE agent_addr = $1
+		ORDER BY created_at DESC
+	`, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Collateral
+	for rows.Next() {
+		var c Collateral
+		if err := rows.Scan(&c.ID, &c.AgentAddr, &c.Kind, &c.Amount, &c.Reference,
+			&c.Status, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &c)
+	}
+	return result, rows.Err()
+}
+
+func (p *PostgresStore) UpdateCollateral(ctx context.Context, c *Collateral) error {
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE credit_collateral SET status = $2, updated_at = $3 WHERE id = $1
+	`, c.ID, c.Status, c.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrCollateralNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) SetAlertConfig(ctx context.Context, cfg *AlertConfig) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO credit_alert_configs (agent_addr, warn_pct, critical_pct, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (agent_addr) DO UPDATE
+		SET warn_pct = EXCLUDED.warn_pct, critical_pct = EXCLUDED.critical_pct, updated_at = EXCLUDED.updated_at
+	`, cfg.AgentAddr, cfg.WarnPct, cfg.CriticalPct, cfg.UpdatedAt)
+	return err
+}
+
+func (p *PostgresStore) GetAlertConfig(ctx context.Context, agentAddr string) (*AlertConfig, error) {
+	var cfg AlertConfig
+	err := p.db.QueryRowContext(ctx, `
+		SELECT agent_addr, warn_pct, critical_pct, updated_at
+		FROM credit_alert_configs WHERE agent_addr = $1
+	`, agentAddr).Scan(&cfg.AgentAddr, &cfg.WarnPct, &cfg.CriticalPct, &cfg.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (p *PostgresStore) ListAlertConfigs(ctx context.Context) ([]*AlertConfig, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT agent_addr, warn_pct, critical_pct, updated_at
+		FROM credit_alert_configs ORDER BY agent_addr
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*AlertConfig
+	for rows.Next() {
+		var cfg AlertConfig
+		if err := rows.Scan(&cfg.AgentAddr, &cfg.WarnPct, &cfg.CriticalPct, &cfg.UpdatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &cfg)
+	}
+	return result, rows.Err()
+}
+
+func (p *PostgresStore) SetSweepConfig(ctx context.Context, cfg *SweepConfig) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO credit_sweep_configs (agent_addr, fraction, priority, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (agent_addr) DO UPDATE
+		SET fraction = EXCLUDED.fraction, priority = EXCLUDED.priority, updated_at = EXCLUDED.updated_at
+	`, cfg.AgentAddr, cfg.Fraction, cfg.Priority, cfg.UpdatedAt)
+	return err
+}
+
+func (p *PostgresStore) GetSweepConfig(ctx context.Context, agentAddr string) (*SweepConfig, error) {
+	var cfg SweepConfig
+	err := p.db.QueryRowContext(ctx, `
+		SELECT agent_addr, fraction, priority, updated_at
+		FROM credit_sweep_configs WHERE agent_addr = $1
+	`, agentAddr).Scan(&cfg.AgentAddr, &cfg.Fraction, &cfg.Priority, &cfg.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (p *PostgresStore) CreateCosign(ctx context.Context, cs *Cosign) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO credit_cosigners (id, agent_addr, cosigner_addr, status, charged_amount, charged_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, cs.ID, cs.AgentAddr, cs.CosignerAddr, cs.Status, cs.ChargedAmount, cs.ChargedAt, cs.CreatedAt, cs.UpdatedAt)
+	return err
+}
+
+func (p *PostgresStore) GetCosign(ctx context.Context, id string) (*Cosign, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id, agent_addr, cosigner_addr, status, charged_amount, charged_at, created_at, updated_at
+		FROM credit_cosigners WHERE id = $1
+	`, id)
+	return scanCosign(row)
+}
+
+func (p *PostgresStore) ListCosignsByAgent(ctx context.Context, agentAddr string) ([]*Cosign, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, agent_addr, cosigner_addr, status, charged_amount, charged_at, created_at, updated_at
+		FROM credit_cosigners WHERE agent_addr = $1 OR cosigner_addr = $1
+		ORDER BY created_at DESC
+	`, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Cosign
+	for rows.Next() {
+		cs, err := scanCosign(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cs)
+	}
+	return result, rows.Err()
+}
+
+func (p *PostgresStore) UpdateCosign(ctx context.Context, cs *Cosign) error {
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE credit_cosigners
+		SET status = $2, charged_amount = $3, charged_at = $4, updated_at = $5
+		WHERE id = $1
+	`, cs.ID, cs.Status, cs.ChargedAmount, cs.ChargedAt, cs.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrCosignNotFound
+	}
+	return nil
+}
+
+func scanCosign(row rowScanner) (*Cosign, error) {
+	var cs Cosign
+	var chargedAmount sql.NullString
+	var chargedAt sql.NullTime
+	err := row.Scan(&cs.ID, &cs.AgentAddr, &cs.CosignerAddr, &cs.Status,
+		&chargedAmount, &chargedAt, &cs.CreatedAt, &cs.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrCosignNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	cs.ChargedAmount = chargedAmount.String
+	if chargedAt.Valid {
+		cs.ChargedAt = &chargedAt.Time
+	}
+	return &cs, nil
+}
+
+func (p *PostgresStore) CreateApplication(ctx context.Context, a *Application) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO credit_applications (id, agent_addr, score, tier, proposed_limit, reason, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, a.ID, a.AgentAddr, a.Score, a.Tier, a.ProposedLimit, a.Reason, a.Status, a.CreatedAt)
+	return err
+}
+
+func (p *PostgresStore) GetApplication(ctx context.Context, id string) (*Application, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id, agent_addr, score, tier, proposed_limit, reason, status, notes, decided_by, decided_limit, decided_at, created_at
+		FROM credit_applications WHERE id = $1
+	`, id)
+	return scanApplication(row)
+}
+
+func (p *PostgresStore) ListPendingApplications(ctx context.Context) ([]*Application, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, agent_addr, score, tier, proposed_limit, reason, status, notes, decided_by, decided_limit, decided_at, created_at
+		FROM credit_applications WHERE status = 'pending'
+		ORDER BY created_at ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Application
+	for rows.Next() {
+		a, err := scanApplication(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, rows.Err()
+}
+
+func (p *PostgresStore) UpdateApplication(ctx context.Context, a *Application) error {
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE credit_applications
+		SET status = $2, notes = $3, decided_by = $4, decided_limit = $5, decided_at = $6
+		WHERE id = $1
+	`, a.ID, a.Status, a.Notes, a.DecidedBy, a.DecidedLimit, a.DecidedAt)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrApplicationNotFound
+	}
+	return nil
+}
+
+func scanApplication(row rowScanner) (*Application, error) {
+	var a Application
+	var notes, decidedBy, decidedLimit sql.NullString
+	var decidedAt sql.NullTime
+	err := row.Scan(&a.ID, &a.AgentAddr, &a.Score, &a.Tier, &a.ProposedLimit, &a.Reason,
+		&a.Status, &notes, &decidedBy, &decidedLimit, &decidedAt, &a.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrApplicationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	a.Notes = notes.String
+	a.DecidedBy = decidedBy.String
+	a.DecidedLimit = decidedLimit.String
+	if decidedAt.Valid {
+		a.DecidedAt = &decidedAt.Time
+	}
+	return &a, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPlan(row rowScanner) (*Plan, error) {
+	var plan Plan
+	var instJSON []byte
+	err := row.Scan(&plan.ID, &plan.AgentAddr, &plan.Principal, &instJSON,
+		&plan.Status, &plan.LateCount, &plan.Delinquency, &plan.FrozenLimit, &plan.CreatedAt, &plan.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrPlanNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(instJSON, &plan.Installments); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func scanPlans(rows *sql.Rows) ([]*Plan, error) {
+	var result []*Plan
+	for rows.Next() {
+		plan, err := scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, plan)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,95 @@
+package credit
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// CreditReport is a portable summary of an agent's credit standing that
+// can be presented to external lenders. It carries the current limit and
+// utilization plus the repayment track record the scorer itself uses.
+type CreditReport struct {
+	AgentAddr      string            `json:"agentAddr"`
+	CreditLimit    string            `json:"creditLimit"`
+	CreditUsed     string            `json:"creditUsed"`
+	UtilizationPct float64           `json:"utilizationPct"`
+	FrozenLimit    string            `json:"frozenLimit,omitempty"` // Limit saved while draws are frozen
+	Delinquency    DelinquencyBucket `json:"delinquency"`           // Worst bucket across active plans
+	ActivePlans    int               `json:"activePlans"`
+	Repayment      *RepaymentStats   `json:"repayment"`
+	GeneratedAt    time.Time         `json:"generatedAt"`
+}
+
+// SignedCreditReport wraps a CreditReport with HMAC signature and validity
+// window, mirroring reputation's signed score snapshots.
+type SignedCreditReport struct {
+	Report    *CreditReport `json:"report"`
+	Signature string        `json:"signature,omitempty"`
+	IssuedAt  string        `json:"issuedAt,omitempty"`
+	ExpiresAt string        `json:"expiresAt,omitempty"`
+}
+
+// BuildCreditReport assembles a credit report for an agent from the ledger's
+// current credit line and the agent's plan history.
+func (s *Service) BuildCreditReport(ctx context.Context, agentAddr string) (*CreditReport, error) {
+	limit, used, err := s.ledger.GetCreditInfo(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.GetRepaymentStats(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := s.store.ListPlansByAgent(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CreditReport{
+		AgentAddr:   agentAddr,
+		CreditLimit: limit,
+		CreditUsed:  used,
+		Delinquency: BucketCurrent,
+		Repayment:   stats,
+		GeneratedAt: time.Now().UTC(),
+	}
+	for _, p := range plans {
+		if p.Status != PlanActive {
+			continue
+		}
+		report.ActivePlans++
+		if bucketRank(p.Delinquency) > bucketRank(report.Delinquency) {
+			report.Delinquency = p.Delinquency
+		}
+		if p.FrozenLimit != "" {
+			report.FrozenLimit = p.FrozenLimit
+		}
+	}
+
+	limitBig, ok := usdc.Parse(limit)
+	usedBig, ok2 := usdc.Parse(used)
+	if ok && ok2 && limitBig.Sign() > 0 {
+		report.UtilizationPct, _ = new(big.Float).Quo(
+			new(big.Float).SetInt(usedBig),
+			new(big.Float).SetInt(limitBig),
+		).Float64()
+	}
+	return report, nil
+}
+
+// bucketRank orders delinquency buckets by severity.
+func bucketRank(b DelinquencyBucket) int {
+	switch b {
+	case BucketEarly:
+		return 1
+	case BucketSerious:
+		return 2
+	case BucketCritical:
+		return 3
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,67 @@
+package credit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/reputation"
+)
+
+func TestBuildCreditReport(t *testing.T) {
+	fl := newFakeLedger("100.00", "50.00", "25.00")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	plan, err := svc.CreatePlan(ctx, "0xagent", 2, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+	plan.Delinquency = BucketSerious
+	if err := svc.store.UpdatePlan(ctx, plan); err != nil {
+		t.Fatalf("UpdatePlan: %v", err)
+	}
+
+	report, err := svc.BuildCreditReport(ctx, "0xagent")
+	if err != nil {
+		t.Fatalf("BuildCreditReport: %v", err)
+	}
+	if report.CreditLimit != "50.000000" || report.CreditUsed != "25.000000" {
+		t.Errorf("limit/used = %s/%s, want 50.000000/25.000000", report.CreditLimit, report.CreditUsed)
+	}
+	if report.UtilizationPct < 0.49 || report.UtilizationPct > 0.51 {
+		t.Errorf("utilization = %f, want ~0.5", report.UtilizationPct)
+	}
+	if report.Delinquency != BucketSerious {
+		t.Errorf("delinquency = %s, want %s", report.Delinquency, BucketSerious)
+	}
+	if report.ActivePlans != 1 {
+		t.Errorf("activePlans = %d, want 1", report.ActivePlans)
+	}
+	if report.Repayment == nil || report.Repayment.TotalPlans != 1 {
+		t.Errorf("repayment stats = %+v, want 1 total plan", report.Repayment)
+	}
+}
+
+func TestSignedCreditReportVerifies(t *testing.T) {
+	fl := newFakeLedger("100.00", "50.00", "10.00")
+	svc := newTestService(fl)
+	signer := reputation.NewSigner("test-secret")
+	h := NewHandler(svc).WithReportSigner(signer)
+
+	report, err := svc.BuildCreditReport(context.Background(), "0xagent")
+	if err != nil {
+		t.Fatalf("BuildCreditReport: %v", err)
+	}
+	sig, _, _, err := h.signer.Sign(report)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if !signer.Verify(report, sig) {
+		t.Error("signature did not verify against the same payload")
+	}
+	report.CreditUsed = "40.000000"
+	if signer.Verify(report, sig) {
+		t.Error("signature verified after payload was tampered with")
+	}
+}
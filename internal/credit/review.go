@@ -0,0 +1,136 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrApplicationNotFound = errors.New("credit: application not found")
+	ErrApplicationDecided  = errors.New("credit: application already decided")
+)
+
+// ApplicationStatus represents the review state of a credit application.
+type ApplicationStatus string
+
+const (
+	ApplicationPending  ApplicationStatus = "pending"
+	ApplicationApproved ApplicationStatus = "approved"
+	ApplicationDenied   ApplicationStatus = "denied"
+)
+
+// Application is a credit application held for manual admin review.
+// Decisions are recorded in place so the row doubles as the audit record.
+type Application struct {
+	ID            string            `json:"id"`
+	AgentAddr     string            `json:"agentAddr"`
+	Score         float64           `json:"score"` // reputation score at application time
+	Tier          string            `json:"tier"`
+	ProposedLimit string            `json:"proposedLimit"` // what the scorer would have granted
+	Reason        string            `json:"reason"`        // why it landed in review
+	Status        ApplicationStatus `json:"status"`
+	Notes         string            `json:"notes,omitempty"`
+	DecidedBy     string            `json:"decidedBy,omitempty"`
+	DecidedLimit  string            `json:"decidedLimit,omitempty"` // approved limit (may differ from proposed)
+	DecidedAt     *time.Time        `json:"decidedAt,omitempty"`
+	CreatedAt     time.Time         `json:"createdAt"`
+}
+
+// Enqueue records an application for manual review. Implements the ledger
+// handler's CreditReviewQueue so flagged applications divert here instead of
+// the scorer auto-approving.
+func (s *Service) Enqueue(ctx context.Context, agentAddr string, score float64, tier, proposedLimit, reason string) (string, error) {
+	app := &Application{
+		ID:            idgen.WithPrefix("capp_"),
+		AgentAddr:     agentAddr,
+		Score:         score,
+		Tier:          tier,
+		ProposedLimit: proposedLimit,
+		Reason:        reason,
+		Status:        ApplicationPending,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.store.CreateApplication(ctx, app); err != nil {
+		return "", err
+	}
+	s.logger.Info("credit: application queued for review",
+		"application", app.ID, "agent", agentAddr, "proposed", proposedLimit, "reason", reason)
+	return app.ID, nil
+}
+
+// ListPendingApplications returns applications awaiting a decision.
+func (s *Service) ListPendingApplications(ctx context.Context) ([]*Application, error) {
+	return s.store.ListPendingApplications(ctx)
+}
+
+// ApproveApplication grants credit. If limit is empty the scorer's proposed
+// limit applies; admins can adjust it up or down.
+func (s *Service) ApproveApplication(ctx context.Context, applicationID, limit, notes, decidedBy string) (*Application, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, err := s.store.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status != ApplicationPending {
+		return nil, ErrApplicationDecided
+	}
+
+	if limit == "" {
+		limit = app.ProposedLimit
+	}
+	limitBig, ok := usdc.Parse(limit)
+	if !ok || limitBig.Sign() < 0 {
+		return nil, ErrInvalidCollateral
+	}
+
+	if err := s.ledger.SetCreditLimit(ctx, app.AgentAddr, usdc.Format(limitBig)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	app.Status = ApplicationApproved
+	app.Notes = notes
+	app.DecidedBy = decidedBy
+	app.DecidedLimit = usdc.Format(limitBig)
+	app.DecidedAt = &now
+	if err := s.store.UpdateApplication(ctx, app); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("credit: application approved",
+		"application", app.ID, "agent", app.AgentAddr, "limit", app.DecidedLimit, "by", decidedBy)
+	return app, nil
+}
+
+// DenyApplication rejects a pending application with a note.
+func (s *Service) DenyApplication(ctx context.Context, applicationID, notes, decidedBy string) (*Application, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, err := s.store.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status != ApplicationPending {
+		return nil, ErrApplicationDecided
+	}
+
+	now := time.Now()
+	app.Status = ApplicationDenied
+	app.Notes = notes
+	app.DecidedBy = decidedBy
+	app.DecidedAt = &now
+	if err := s.store.UpdateApplication(ctx, app); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("credit: application denied",
+		"application", app.ID, "agent", app.AgentAddr, "by", decidedBy)
+	return app, nil
+}
@@ -0,0 +1,86 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestApproveApplicationSetsLimit(t *testing.T) {
+	fl := newFakeLedger("0", "0", "0")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	appID, err := svc.Enqueue(ctx, "0xagent", 72.5, "silver", "80.000000", "proposed limit above auto-approval threshold (50 USDC)")
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	pending, err := svc.ListPendingApplications(ctx)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("ListPendingApplications = %v, %v, want 1 pending", pending, err)
+	}
+
+	app, err := svc.ApproveApplication(ctx, appID, "", "looks fine", "0xadmin")
+	if err != nil {
+		t.Fatalf("ApproveApplication: %v", err)
+	}
+	if app.Status != ApplicationApproved {
+		t.Errorf("status = %s, want approved", app.Status)
+	}
+	if app.DecidedLimit != "80.000000" {
+		t.Errorf("decided limit = %s, want proposed 80.000000", app.DecidedLimit)
+	}
+	if app.DecidedBy != "0xadmin" || app.DecidedAt == nil {
+		t.Errorf("decision audit fields not recorded: %+v", app)
+	}
+	if got, _, _ := fl.GetCreditInfo(ctx, "0xagent"); got != "80.000000" {
+		t.Errorf("credit limit = %s, want 80.000000", got)
+	}
+
+	// Second decision on the same application must be rejected.
+	if _, err := svc.ApproveApplication(ctx, appID, "", "", "0xadmin"); !errors.Is(err, ErrApplicationDecided) {
+		t.Errorf("re-approve error = %v, want ErrApplicationDecided", err)
+	}
+}
+
+func TestApproveApplicationAdjustedLimit(t *testing.T) {
+	fl := newFakeLedger("0", "0", "0")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	appID, _ := svc.Enqueue(ctx, "0xagent", 55, "bronze", "19.000000", "borderline reputation score")
+
+	app, err := svc.ApproveApplication(ctx, appID, "10.00", "reduced for borderline score", "0xadmin")
+	if err != nil {
+		t.Fatalf("ApproveApplication: %v", err)
+	}
+	if app.DecidedLimit != "10.000000" {
+		t.Errorf("decided limit = %s, want 10.000000", app.DecidedLimit)
+	}
+	if got, _, _ := fl.GetCreditInfo(ctx, "0xagent"); got != "10.000000" {
+		t.Errorf("credit limit = %s, want 10.000000", got)
+	}
+}
+
+func TestDenyApplicationLeavesLimitUnchanged(t *testing.T) {
+	fl := newFakeLedger("0", "0", "0")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	appID, _ := svc.Enqueue(ctx, "0xagent", 52, "bronze", "13.600000", "borderline reputation score")
+
+	app, err := svc.DenyApplication(ctx, appID, "too little history", "0xadmin")
+	if err != nil {
+		t.Fatalf("DenyApplication: %v", err)
+	}
+	if app.Status != ApplicationDenied {
+		t.Errorf("status = %s, want denied", app.Status)
+	}
+	if got, _, _ := fl.GetCreditInfo(ctx, "0xagent"); got != "0.000000" {
+		t.Errorf("credit limit = %s, want unchanged 0.000000", got)
+	}
+	if pending, _ := svc.ListPendingApplications(ctx); len(pending) != 0 {
+		t.Errorf("pending = %d, want 0", len(pending))
+	}
+}
@@ -0,0 +1,204 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var ErrInvalidSweepConfig = errors.New("credit: sweep fraction must be in [0, 1] with a valid priority")
+
+// SweepPriority orders auto-repayment against stakes revenue accumulation
+// when both want a cut of the same earnings.
+type SweepPriority string
+
+const (
+	SweepCreditFirst SweepPriority = "credit_first"
+	SweepStakesFirst SweepPriority = "stakes_first"
+)
+
+// SweepConfig holds an agent's auto-repayment settings: what fraction of
+// each incoming revenue credit is swept into credit repayment, and whether
+// stakes revenue accumulation takes its share first.
+type SweepConfig struct {
+	AgentAddr string        `json:"agentAddr"`
+	Fraction  float64       `json:"fraction"` // 0-1; 0 disables the sweep
+	Priority  SweepPriority `json:"priority"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// StakeRevenueAccumulator lets the stakes subsystem take its cut of incoming
+// revenue. It returns the amount actually taken, formatted as USDC.
+type StakeRevenueAccumulator interface {
+	AccumulateRevenue(ctx context.Context, agentAddr, amount string) (taken string, err error)
+}
+
+// WithStakeRevenue wires the stakes revenue accumulator into the sweep's
+// priority ordering.
+func (s *Service) WithStakeRevenue(acc StakeRevenueAccumulator) *Service {
+	s.stakeRevenue = acc
+	return s
+}
+
+// SetSweepConfig stores an agent's auto-repayment settings.
+func (s *Service) SetSweepConfig(ctx context.Context, agentAddr string, fraction float64, priority SweepPriority) (*SweepConfig, error) {
+	if priority == "" {
+		priority = SweepCreditFirst
+	}
+	if fraction < 0 || fraction > 1 || (priority != SweepCreditFirst && priority != SweepStakesFirst) {
+		return nil, ErrInvalidSweepConfig
+	}
+	cfg := &SweepConfig{
+		AgentAddr: agentAddr,
+		Fraction:  fraction,
+		Priority:  priority,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.store.SetSweepConfig(ctx, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// GetSweepConfig returns an agent's settings; nil Fraction defaults to the
+// sweep being disabled.
+func (s *Service) GetSweepConfig(ctx context.Context, agentAddr string) (*SweepConfig, error) {
+	cfg, err := s.store.GetSweepConfig(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		cfg = &SweepConfig{AgentAddr: agentAddr, Priority: SweepCreditFirst}
+	}
+	return cfg, nil
+}
+
+// OnEarnings implements ledger.EarningsListener. It runs on its own
+// goroutine after a settlement credits the agent, so it builds its own
+// context and swallows errors into logs.
+func (s *Service) OnEarnings(agentAddr, amount, reference string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cfg, err := s.store.GetSweepConfig(ctx, agentAddr)
+	if err != nil || cfg == nil || cfg.Fraction <= 0 {
+		return
+	}
+	earned, ok := usdc.Parse(amount)
+	if !ok || earned.Sign() <= 0 {
+		return
+	}
+
+	pool := new(big.Int).Set(earned)
+	if cfg.Priority == SweepStakesFirst && s.stakeRevenue != nil {
+		if taken, err := s.stakeRevenue.AccumulateRevenue(ctx, agentAddr, usdc.Format(pool)); err == nil {
+			if takenBig, ok := usdc.Parse(taken); ok && takenBig.Sign() > 0 {
+				pool.Sub(pool, takenBig)
+			}
+		}
+	}
+	if pool.Sign() <= 0 {
+		return
+	}
+
+	// sweep = pool * fraction, in micro-USDC integer math.
+	frac := big.NewInt(int64(cfg.Fraction * 1_000_000))
+	sweep := new(big.Int).Div(new(big.Int).Mul(pool, frac), big.NewInt(1_000_000))
+	if sweep.Sign() <= 0 {
+		return
+	}
+
+	swept := s.sweepIntoRepayment(ctx, agentAddr, sweep, reference)
+
+	if cfg.Priority == SweepCreditFirst && s.stakeRevenue != nil {
+		remainder := new(big.Int).Sub(pool, swept)
+		if remainder.Sign() > 0 {
+			_, _ = s.stakeRevenue.AccumulateRevenue(ctx, agentAddr, usdc.Format(remainder))
+		}
+	}
+}
+
+// sweepIntoRepayment applies up to sweep toward the agent's credit line and
+// returns the amount actually swept. With an active installment plan the
+// sweep pays upcoming installments in order (whole installments only, so the
+// plan's bookkeeping stays exact); otherwise it repays the raw draw.
+func (s *Service) sweepIntoRepayment(ctx context.Context, agentAddr string, sweep *big.Int, reference string) *big.Int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	swept := new(big.Int)
+
+	_, used, err := s.ledger.GetCreditInfo(ctx, agentAddr)
+	if err != nil {
+		return swept
+	}
+	outstanding, ok := usdc.Parse(used)
+	if !ok || outstanding.Sign() <= 0 {
+		return swept
+	}
+	if sweep.Cmp(outstanding) > 0 {
+		sweep = new(big.Int).Set(outstanding)
+	}
+
+	plan := s.activePlan(ctx, agentAddr)
+	if plan != nil {
+		now := time.Now()
+		budget := new(big.Int).Set(sweep)
+		changed := false
+		for _, inst := range plan.Installments {
+			if inst.Status == InstallmentPaid {
+				continue
+			}
+			instAmt, ok := usdc.Parse(inst.Amount)
+			if !ok || budget.Cmp(instAmt) < 0 {
+				break
+			}
+			if err := s.deduct(ctx, plan, inst, now); err != nil {
+				break
+			}
+			budget.Sub(budget, instAmt)
+			swept.Add(swept, instAmt)
+			changed = true
+		}
+		if changed {
+			s.finalizeIfComplete(plan)
+			plan.UpdatedAt = now
+			if err := s.store.UpdatePlan(ctx, plan); err != nil {
+				s.logger.Warn("credit: failed to update plan after sweep", "plan", plan.ID, "error", err)
+			}
+			s.logger.Info("credit: earnings swept into installments",
+				"agent", agentAddr, "amount", usdc.Format(swept), "reference", reference)
+		}
+		return swept
+	}
+
+	if err := s.ledger.Debit(ctx, agentAddr, usdc.Format(sweep), "sweep:"+reference, "credit_auto_sweep"); err != nil {
+		return swept
+	}
+	if err := s.ledger.RepayCredit(ctx, agentAddr, usdc.Format(sweep)); err != nil {
+		s.logger.Error("credit: repay failed after sweep debit",
+			"agent", agentAddr, "amount", usdc.Format(sweep), "error", err)
+		return swept
+	}
+	swept.Set(sweep)
+	s.logger.Info("credit: earnings swept into repayment",
+		"agent", agentAddr, "amount", usdc.Format(swept), "reference", reference)
+	return swept
+}
+
+// activePlan returns the agent's active installment plan, if any.
+func (s *Service) activePlan(ctx context.Context, agentAddr string) *Plan {
+	plans, err := s.store.ListPlansByAgent(ctx, agentAddr)
+	if err != nil {
+		return nil
+	}
+	for _, p := range plans {
+		if p.Status == PlanActive {
+			return p
+		}
+	}
+	return nil
+}
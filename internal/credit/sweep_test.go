@@ -0,0 +1,133 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+type fakeStakeRevenue struct {
+	cut      float64 // fraction of offered revenue to take
+	accepted []string
+}
+
+func (f *fakeStakeRevenue) AccumulateRevenue(_ context.Context, _, amount string) (string, error) {
+	v, _ := usdc.Parse(amount)
+	taken := new(big.Int).Div(new(big.Int).Mul(v, big.NewInt(int64(f.cut*100))), big.NewInt(100))
+	f.accepted = append(f.accepted, usdc.Format(taken))
+	return usdc.Format(taken), nil
+}
+
+func TestSweepConfigValidation(t *testing.T) {
+	svc := newTestService(newFakeLedger("0", "0", "0"))
+	ctx := context.Background()
+
+	if _, err := svc.SetSweepConfig(ctx, "0xagent", 1.5, SweepCreditFirst); !errors.Is(err, ErrInvalidSweepConfig) {
+		t.Errorf("fraction > 1 error = %v, want ErrInvalidSweepConfig", err)
+	}
+	if _, err := svc.SetSweepConfig(ctx, "0xagent", 0.5, "stakes_only"); !errors.Is(err, ErrInvalidSweepConfig) {
+		t.Errorf("bad priority error = %v, want ErrInvalidSweepConfig", err)
+	}
+
+	cfg, err := svc.SetSweepConfig(ctx, "0xagent", 0.5, "")
+	if err != nil || cfg.Priority != SweepCreditFirst {
+		t.Fatalf("SetSweepConfig = %+v, %v, want default priority credit_first", cfg, err)
+	}
+}
+
+func TestOnEarningsSweepsDraw(t *testing.T) {
+	fl := newFakeLedger("100.00", "50.00", "40.00")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	if _, err := svc.SetSweepConfig(ctx, "0xagent", 0.5, SweepCreditFirst); err != nil {
+		t.Fatalf("SetSweepConfig: %v", err)
+	}
+
+	// 20.00 of revenue arrives; half is swept into repayment.
+	svc.OnEarnings("0xagent", "20.000000", "escrow:es_1")
+
+	if _, used, _ := fl.GetCreditInfo(ctx, "0xagent"); used != "30.000000" {
+		t.Errorf("outstanding = %s, want 30.000000 after 10.00 sweep", used)
+	}
+	bal, _ := fl.GetBalance(ctx, "0xagent")
+	if bal.Available != "90.000000" {
+		t.Errorf("available = %s, want 90.000000", bal.Available)
+	}
+}
+
+func TestOnEarningsSweepCappedAtOutstanding(t *testing.T) {
+	fl := newFakeLedger("100.00", "50.00", "3.00")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	if _, err := svc.SetSweepConfig(ctx, "0xagent", 1.0, SweepCreditFirst); err != nil {
+		t.Fatalf("SetSweepConfig: %v", err)
+	}
+	svc.OnEarnings("0xagent", "20.000000", "stream:st_1")
+
+	if _, used, _ := fl.GetCreditInfo(ctx, "0xagent"); used != "0.000000" {
+		t.Errorf("outstanding = %s, want fully repaid", used)
+	}
+	bal, _ := fl.GetBalance(ctx, "0xagent")
+	if bal.Available != "97.000000" {
+		t.Errorf("available = %s, want 97.000000 (only 3.00 swept)", bal.Available)
+	}
+}
+
+func TestOnEarningsPaysInstallments(t *testing.T) {
+	fl := newFakeLedger("100.00", "50.00", "30.00")
+	svc := newTestService(fl)
+	ctx := context.Background()
+
+	plan, err := svc.CreatePlan(ctx, "0xagent", 3, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePlan: %v", err)
+	}
+	if _, err := svc.SetSweepConfig(ctx, "0xagent", 1.0, SweepCreditFirst); err != nil {
+		t.Fatalf("SetSweepConfig: %v", err)
+	}
+
+	// 25.00 covers two 10.00 installments; the partial remainder stays.
+	svc.OnEarnings("0xagent", "25.000000", "gateway:gw_1")
+
+	plan, _ = svc.GetPlan(ctx, plan.ID, "0xagent")
+	paid := 0
+	for _, inst := range plan.Installments {
+		if inst.Status == InstallmentPaid {
+			paid++
+		}
+	}
+	if paid != 2 {
+		t.Errorf("paid installments = %d, want 2", paid)
+	}
+	if _, used, _ := fl.GetCreditInfo(ctx, "0xagent"); used != "10.000000" {
+		t.Errorf("outstanding = %s, want 10.000000", used)
+	}
+}
+
+func TestOnEarningsStakesFirstPriority(t *testing.T) {
+	fl := newFakeLedger("100.00", "50.00", "40.00")
+	svc := newTestService(fl)
+	stakes := &fakeStakeRevenue{cut: 0.25}
+	svc.WithStakeRevenue(stakes)
+	ctx := context.Background()
+
+	if _, err := svc.SetSweepConfig(ctx, "0xagent", 1.0, SweepStakesFirst); err != nil {
+		t.Fatalf("SetSweepConfig: %v", err)
+	}
+
+	// Stakes take 25% of 20.00 first; the remaining 15.00 is swept.
+	svc.OnEarnings("0xagent", "20.000000", "escrow:es_2")
+
+	if len(stakes.accepted) != 1 || stakes.accepted[0] != "5.000000" {
+		t.Errorf("stakes accepted = %v, want [5.000000]", stakes.accepted)
+	}
+	if _, used, _ := fl.GetCreditInfo(ctx, "0xagent"); used != "25.000000" {
+		t.Errorf("outstanding = %s, want 25.000000 after 15.00 sweep", used)
+	}
+}
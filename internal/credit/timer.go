@@ -0,0 +1,78 @@
+package credit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/recovery"
+)
+
+// Timer periodically checks installment due dates, auto-deducting payments
+// from agent earnings and marking missed installments late.
+type Timer struct {
+	service  *Service
+	interval time.Duration
+	logger   *slog.Logger
+	stop     chan struct{}
+	running  atomic.Bool
+}
+
+// NewTimer creates a new credit installment timer.
+func NewTimer(service *Service, logger *slog.Logger) *Timer {
+	return &Timer{
+		service:  service,
+		interval: time.Minute,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start begins the timer loop.
+func (t *Timer) Start(ctx context.Context) {
+	t.running.Store(true)
+	defer t.running.Store(false)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.safeProcess(ctx)
+		}
+	}
+}
+
+// Stop signals the timer loop to exit.
+func (t *Timer) Stop() {
+	select {
+	case t.stop <- struct{}{}:
+	default:
+	}
+}
+
+// Running returns true if the timer loop is active.
+func (t *Timer) Running() bool {
+	return t.running.Load()
+}
+
+func (t *Timer) safeProcess(ctx context.Context) {
+	defer recovery.LogPanic(t.logger, "credit_timer")
+
+	paid, late, err := t.service.ProcessDue(ctx, time.Now())
+	if err != nil {
+		t.logger.Warn("credit timer: error processing due installments", "error", err)
+		return
+	}
+	if paid > 0 || late > 0 {
+		t.logger.Info("credit timer: processed installments", "paid", paid, "late", late)
+	}
+
+	t.service.CheckUtilizationAlerts(ctx)
+}
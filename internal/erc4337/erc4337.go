@@ -0,0 +1,239 @@
+// Package erc4337 implements ERC-4337 account abstraction support.
+//
+// Agents register a smart account (a counterfactual or deployed ERC-4337
+// wallet) bound to their platform identity. The platform then acts as a
+// verifying paymaster: it validates a proposed UserOperation against the
+// agent's session-key limits and returns signed paymasterAndData, so the
+// spend settles on-chain gaslessly instead of through the custodial ledger.
+package erc4337
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mbd888/alancoin/internal/idgen"
+)
+
+var (
+	ErrNotFound             = errors.New("erc4337: smart account not found")
+	ErrNotOwner             = errors.New("erc4337: caller does not own this smart account")
+	ErrInvalidAccount       = errors.New("erc4337: invalid smart account")
+	ErrAccountRevoked       = errors.New("erc4337: smart account revoked")
+	ErrInvalidUserOp        = errors.New("erc4337: invalid user operation")
+	ErrSponsorshipDenied    = errors.New("erc4337: sponsorship denied")
+	ErrSponsorshipThrottled = errors.New("erc4337: sponsorship throttled by spend guard")
+	ErrPaymasterUnavailable = errors.New("erc4337: paymaster not configured")
+)
+
+// DefaultEntryPoint is the canonical ERC-4337 v0.6 EntryPoint address.
+const DefaultEntryPoint = "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"
+
+// AccountStatus represents the lifecycle state of a smart account.
+type AccountStatus string
+
+const (
+	AccountActive  AccountStatus = "active"
+	AccountRevoked AccountStatus = "revoked"
+)
+
+// SmartAccount binds an agent's platform identity to an ERC-4337 wallet.
+type SmartAccount struct {
+	ID          string        `json:"id"`
+	OwnerAddr   string        `json:"ownerAddr"`       // platform identity (EOA) that registered the account
+	AccountAddr string        `json:"accountAddr"`     // the smart account address
+	EntryPoint  string        `json:"entryPoint"`      // EntryPoint the account targets
+	Chain       string        `json:"chain,omitempty"` // chain name, matching deposit watcher chains
+	Status      AccountStatus `json:"status"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	RevokedAt   *time.Time    `json:"revokedAt,omitempty"`
+}
+
+// Store persists smart accounts.
+type Store interface {
+	CreateAccount(ctx context.Context, a *SmartAccount) error
+	GetAccount(ctx context.Context, id string) (*SmartAccount, error)
+	GetAccountByAddress(ctx context.Context, accountAddr string) (*SmartAccount, error)
+	ListAccountsByOwner(ctx context.Context, ownerAddr string) ([]*SmartAccount, error)
+	UpdateAccount(ctx context.Context, a *SmartAccount) error
+}
+
+// SessionValidator checks a proposed spend against session-key limits.
+// Satisfied by an adapter over sessionkeys.Manager.
+type SessionValidator interface {
+	Validate(ctx context.Context, keyID, to, amount, serviceID string) error
+}
+
+// CostEstimator prices a UserOperation's total gas in USD using current
+// chain fees. Satisfied by an adapter over the payout fee oracle.
+type CostEstimator interface {
+	UserOpCostUSD(ctx context.Context, gas uint64) (string, error)
+}
+
+// GasCostRecorder attributes a sponsored operation's USD gas cost to the
+// beneficiary agent. Satisfied by an adapter over the ledger service.
+type GasCostRecorder interface {
+	RecordGasCost(ctx context.Context, agentAddr, amountUSD, reference string) error
+}
+
+// Service manages smart accounts and UserOperation sponsorship.
+type Service struct {
+	store        Store
+	sessions     SessionValidator
+	costs        CostEstimator
+	gasCosts     GasCostRecorder
+	guard        *SpendGuard
+	paymasterKey *ecdsa.PrivateKey
+	chainID      int64
+	sponsorTTL   time.Duration
+	logger       *slog.Logger
+	mu           sync.Mutex
+}
+
+// NewService creates a smart-account service.
+func NewService(store Store) *Service {
+	return &Service{
+		store:      store,
+		chainID:    1,
+		sponsorTTL: DefaultSponsorshipTTL,
+		logger:     slog.Default(),
+	}
+}
+
+// WithLogger sets the logger.
+func (s *Service) WithLogger(logger *slog.Logger) *Service {
+	s.logger = logger
+	return s
+}
+
+// WithSessionValidator gates sponsorship on session-key spending limits.
+func (s *Service) WithSessionValidator(v SessionValidator) *Service {
+	s.sessions = v
+	return s
+}
+
+// WithSpendGuard gates sponsorship on daily gas spend and paymaster balance.
+func (s *Service) WithSpendGuard(g *SpendGuard) *Service {
+	s.guard = g
+	return s
+}
+
+// WithCostEstimator enables the estimated USD cost on sponsorships.
+func (s *Service) WithCostEstimator(e CostEstimator) *Service {
+	s.costs = e
+	return s
+}
+
+// WithGasCostRecorder attributes sponsored gas costs to the beneficiary
+// agent's ledger history. Requires a cost estimator to produce the amounts.
+func (s *Service) WithGasCostRecorder(r GasCostRecorder) *Service {
+	s.gasCosts = r
+	return s
+}
+
+// WithPaymasterKey sets the key used to sign paymasterAndData.
+func (s *Service) WithPaymasterKey(key *ecdsa.PrivateKey) *Service {
+	s.paymasterKey = key
+	return s
+}
+
+// WithChainID sets the chain ID used in UserOperation hashing.
+func (s *Service) WithChainID(chainID int64) *Service {
+	if chainID > 0 {
+		s.chainID = chainID
+	}
+	return s
+}
+
+// RegisterAccountRequest is the payload for registering a smart account.
+type RegisterAccountRequest struct {
+	AccountAddr string `json:"accountAddr" binding:"required"`
+	EntryPoint  string `json:"entryPoint,omitempty"`
+	Chain       string `json:"chain,omitempty"`
+}
+
+// RegisterAccount binds a smart account address to the calling agent.
+func (s *Service) RegisterAccount(ctx context.Context, ownerAddr string, req RegisterAccountRequest) (*SmartAccount, error) {
+	if ownerAddr == "" || !common.IsHexAddress(req.AccountAddr) {
+		return nil, ErrInvalidAccount
+	}
+	entryPoint := req.EntryPoint
+	if entryPoint == "" {
+		entryPoint = DefaultEntryPoint
+	}
+	if !common.IsHexAddress(entryPoint) {
+		return nil, ErrInvalidAccount
+	}
+	accountAddr := strings.ToLower(req.AccountAddr)
+	if accountAddr == strings.ToLower(ownerAddr) {
+		return nil, ErrInvalidAccount // the smart account must be distinct from the owner EOA
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, err := s.store.GetAccountByAddress(ctx, accountAddr); err == nil && existing.Status == AccountActive {
+		return nil, ErrInvalidAccount
+	}
+
+	a := &SmartAccount{
+		ID:          idgen.WithPrefix("sacct_"),
+		OwnerAddr:   strings.ToLower(ownerAddr),
+		AccountAddr: accountAddr,
+		EntryPoint:  entryPoint,
+		Chain:       strings.ToLower(req.Chain),
+		Status:      AccountActive,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.store.CreateAccount(ctx, a); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("smart account registered",
+		"account_id", a.ID,
+		"owner", a.OwnerAddr,
+		"account_addr", a.AccountAddr,
+		"chain", a.Chain)
+	return a, nil
+}
+
+// GetAccount returns a smart account by ID.
+func (s *Service) GetAccount(ctx context.Context, id string) (*SmartAccount, error) {
+	return s.store.GetAccount(ctx, id)
+}
+
+// ListAccounts returns the agent's registered smart accounts.
+func (s *Service) ListAccounts(ctx context.Context, ownerAddr string) ([]*SmartAccount, error) {
+	return s.store.ListAccountsByOwner(ctx, strings.ToLower(ownerAddr))
+}
+
+// RevokeAccount deactivates a smart account. Only the owner may revoke.
+func (s *Service) RevokeAccount(ctx context.Context, ownerAddr, id string) (*SmartAccount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, err := s.store.GetAccount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a.OwnerAddr != strings.ToLower(ownerAddr) {
+		return nil, ErrNotOwner
+	}
+	if a.Status == AccountRevoked {
+		return nil, ErrAccountRevoked
+	}
+	now := time.Now()
+	a.Status = AccountRevoked
+	a.RevokedAt = &now
+	if err := s.store.UpdateAccount(ctx, a); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("smart account revoked", "account_id", a.ID, "owner", a.OwnerAddr)
+	return a, nil
+}
@@ -0,0 +1,95 @@
+package erc4337
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+const (
+	ownerAddr   = "0x1111111111111111111111111111111111111111"
+	accountAddr = "0x2222222222222222222222222222222222222222"
+	otherAddr   = "0x3333333333333333333333333333333333333333"
+)
+
+func newTestService() *Service {
+	return NewService(NewMemoryStore())
+}
+
+func TestRegisterAccountValidation(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: "not-an-address"}); !errors.Is(err, ErrInvalidAccount) {
+		t.Errorf("expected ErrInvalidAccount for bad address, got %v", err)
+	}
+	if _, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: ownerAddr}); !errors.Is(err, ErrInvalidAccount) {
+		t.Errorf("expected ErrInvalidAccount for owner == account, got %v", err)
+	}
+
+	a, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr, Chain: "base"})
+	if err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+	if a.EntryPoint != DefaultEntryPoint {
+		t.Errorf("expected default entry point, got %s", a.EntryPoint)
+	}
+	if a.Status != AccountActive || a.Chain != "base" {
+		t.Errorf("unexpected account: %+v", a)
+	}
+
+	// The same address cannot be registered twice while active.
+	if _, err := svc.RegisterAccount(ctx, otherAddr, RegisterAccountRequest{AccountAddr: accountAddr}); !errors.Is(err, ErrInvalidAccount) {
+		t.Errorf("expected ErrInvalidAccount for duplicate address, got %v", err)
+	}
+}
+
+func TestRevokeAccount(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	a, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr})
+	if err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+
+	if _, err := svc.RevokeAccount(ctx, otherAddr, a.ID); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("expected ErrNotOwner, got %v", err)
+	}
+
+	revoked, err := svc.RevokeAccount(ctx, ownerAddr, a.ID)
+	if err != nil {
+		t.Fatalf("RevokeAccount failed: %v", err)
+	}
+	if revoked.Status != AccountRevoked || revoked.RevokedAt == nil {
+		t.Errorf("unexpected account after revoke: %+v", revoked)
+	}
+	if _, err := svc.RevokeAccount(ctx, ownerAddr, a.ID); !errors.Is(err, ErrAccountRevoked) {
+		t.Errorf("expected ErrAccountRevoked on double revoke, got %v", err)
+	}
+
+	// The address frees up for re-registration once revoked.
+	if _, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr}); err != nil {
+		t.Errorf("expected re-registration after revoke to succeed, got %v", err)
+	}
+}
+
+func TestListAccountsScopedToOwner(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr}); err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+	if _, err := svc.RegisterAccount(ctx, otherAddr, RegisterAccountRequest{AccountAddr: "0x4444444444444444444444444444444444444444"}); err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+
+	mine, err := svc.ListAccounts(ctx, ownerAddr)
+	if err != nil {
+		t.Fatalf("ListAccounts failed: %v", err)
+	}
+	if len(mine) != 1 || mine[0].AccountAddr != accountAddr {
+		t.Errorf("unexpected accounts: %+v", mine)
+	}
+}
@@ -0,0 +1,141 @@
+package erc4337
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func safeMessage(status int, err error, fallback string) string {
+	if status < 500 {
+		return err.Error()
+	}
+	return fallback
+}
+
+// Handler provides HTTP endpoints for smart accounts and sponsorship.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new smart-account handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterProtectedRoutes sets up auth-required smart-account routes.
+func (h *Handler) RegisterProtectedRoutes(r *gin.RouterGroup) {
+	r.POST("/smart-accounts", h.RegisterAccount)
+	r.GET("/smart-accounts", h.ListAccounts)
+	r.GET("/smart-accounts/:id", h.GetAccount)
+	r.DELETE("/smart-accounts/:id", h.RevokeAccount)
+	r.POST("/smart-accounts/:id/sponsor", h.SponsorUserOp)
+}
+
+// RegisterAccount handles POST /v1/smart-accounts
+func (h *Handler) RegisterAccount(c *gin.Context) {
+	caller := c.GetString("authAgentAddr")
+
+	var req RegisterAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "message": err.Error()})
+		return
+	}
+
+	a, err := h.service.RegisterAccount(c.Request.Context(), caller, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "registration_failed"
+		if errors.Is(err, ErrInvalidAccount) {
+			status, code = http.StatusBadRequest, "invalid_account"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to register smart account")})
+		return
+	}
+	c.JSON(http.StatusCreated, a)
+}
+
+// ListAccounts handles GET /v1/smart-accounts
+func (h *Handler) ListAccounts(c *gin.Context) {
+	caller := c.GetString("authAgentAddr")
+
+	accounts, err := h.service.ListAccounts(c.Request.Context(), caller)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "list_failed", "message": "Failed to list smart accounts"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"accounts": accounts, "count": len(accounts)})
+}
+
+// GetAccount handles GET /v1/smart-accounts/:id
+func (h *Handler) GetAccount(c *gin.Context) {
+	a, err := h.service.GetAccount(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Smart account not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "get_failed", "message": "Failed to get smart account"})
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// RevokeAccount handles DELETE /v1/smart-accounts/:id
+func (h *Handler) RevokeAccount(c *gin.Context) {
+	caller := c.GetString("authAgentAddr")
+
+	a, err := h.service.RevokeAccount(c.Request.Context(), caller, c.Param("id"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "revoke_failed"
+		switch {
+		case errors.Is(err, ErrNotFound):
+			status, code = http.StatusNotFound, "not_found"
+		case errors.Is(err, ErrNotOwner):
+			status, code = http.StatusForbidden, "not_owner"
+		case errors.Is(err, ErrAccountRevoked):
+			status, code = http.StatusGone, "account_revoked"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to revoke smart account")})
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// SponsorUserOp handles POST /v1/smart-accounts/:id/sponsor
+func (h *Handler) SponsorUserOp(c *gin.Context) {
+	caller := c.GetString("authAgentAddr")
+
+	var req SponsorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "message": err.Error()})
+		return
+	}
+
+	sponsorship, err := h.service.SponsorUserOp(c.Request.Context(), caller, c.Param("id"), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "sponsorship_failed"
+		switch {
+		case errors.Is(err, ErrNotFound):
+			status, code = http.StatusNotFound, "not_found"
+		case errors.Is(err, ErrNotOwner):
+			status, code = http.StatusForbidden, "not_owner"
+		case errors.Is(err, ErrAccountRevoked):
+			status, code = http.StatusGone, "account_revoked"
+		case errors.Is(err, ErrInvalidUserOp):
+			status, code = http.StatusBadRequest, "invalid_user_op"
+		case errors.Is(err, ErrSponsorshipDenied):
+			status, code = http.StatusForbidden, "sponsorship_denied"
+		case errors.Is(err, ErrSponsorshipThrottled):
+			status, code = http.StatusTooManyRequests, "sponsorship_throttled"
+		case errors.Is(err, ErrPaymasterUnavailable):
+			status, code = http.StatusServiceUnavailable, "paymaster_unavailable"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to sponsor user operation")})
+		return
+	}
+	c.JSON(http.StatusOK, sponsorship)
+}
@@ -0,0 +1,76 @@
+package erc4337
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-memory Store for development and testing.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	accounts map[string]*SmartAccount
+}
+
+// NewMemoryStore creates an empty in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{accounts: make(map[string]*SmartAccount)}
+}
+
+func (m *MemoryStore) CreateAccount(_ context.Context, a *SmartAccount) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *a
+	m.accounts[a.ID] = &cp
+	return nil
+}
+
+func (m *MemoryStore) GetAccount(_ context.Context, id string) (*SmartAccount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	a, ok := m.accounts[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (m *MemoryStore) GetAccountByAddress(_ context.Context, accountAddr string) (*SmartAccount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, a := range m.accounts {
+		if a.AccountAddr == accountAddr {
+			cp := *a
+			return &cp, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *MemoryStore) ListAccountsByOwner(_ context.Context, ownerAddr string) ([]*SmartAccount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []*SmartAccount
+	for _, a := range m.accounts {
+		if a.OwnerAddr == ownerAddr {
+			cp := *a
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (m *MemoryStore) UpdateAccount(_ context.Context, a *SmartAccount) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.accounts[a.ID]; !ok {
+		return ErrNotFound
+	}
+	cp := *a
+	m.accounts[a.ID] = &cp
+	return nil
+}
+
+var _ Store = (*MemoryStore)(nil)
@@ -0,0 +1,199 @@
+package erc4337
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultSponsorshipTTL is how long a signed sponsorship stays valid. Long
+// enough for bundler inclusion, short enough that stale approvals can't be
+// replayed after session-key limits change.
+const DefaultSponsorshipTTL = 10 * time.Minute
+
+// SponsorRequest asks the platform to sponsor a UserOperation. The session
+// key (when the service has a validator) must authorize the spend the
+// operation performs.
+type SponsorRequest struct {
+	SessionKeyID string        `json:"sessionKeyId,omitempty"`
+	ServiceID    string        `json:"serviceId,omitempty"`
+	To           string        `json:"to,omitempty"`       // spend recipient, for session-key validation
+	Amount       string        `json:"amount,omitempty"`   // USDC amount the operation spends
+	Critical     bool          `json:"critical,omitempty"` // exempt from spend-guard throttling (not from the hard cap)
+	UserOp       UserOperation `json:"userOp" binding:"required"`
+}
+
+// Sponsorship is a signed paymaster approval for one UserOperation.
+type Sponsorship struct {
+	UserOpHash       string    `json:"userOpHash"`
+	PaymasterAndData string    `json:"paymasterAndData"`
+	Paymaster        string    `json:"paymaster"`
+	ValidAfter       time.Time `json:"validAfter"`
+	ValidUntil       time.Time `json:"validUntil"`
+	// EstimatedCostUSD is the projected gas cost of the operation at current
+	// fees, set best-effort when a cost estimator is configured.
+	EstimatedCostUSD string `json:"estimatedCostUsd,omitempty"`
+}
+
+// SponsorUserOp validates a UserOperation against the caller's registered
+// smart account (and session-key limits, when configured) and returns
+// paymasterAndData signed by the platform paymaster key. The caller submits
+// the completed operation to a bundler; gas is paid by the paymaster.
+func (s *Service) SponsorUserOp(ctx context.Context, ownerAddr, accountID string, req SponsorRequest) (*Sponsorship, error) {
+	if s.paymasterKey == nil {
+		return nil, ErrPaymasterUnavailable
+	}
+
+	a, err := s.store.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if a.OwnerAddr != strings.ToLower(ownerAddr) {
+		return nil, ErrNotOwner
+	}
+	if a.Status != AccountActive {
+		return nil, ErrAccountRevoked
+	}
+	if !strings.EqualFold(req.UserOp.Sender, a.AccountAddr) {
+		return nil, fmt.Errorf("%w: sender does not match registered account", ErrInvalidUserOp)
+	}
+
+	// Gate sponsorship on the session key's spending limits so gasless
+	// on-chain spends honor the same budgets as ledger spends.
+	if s.sessions != nil {
+		if req.SessionKeyID == "" {
+			return nil, fmt.Errorf("%w: session key required", ErrSponsorshipDenied)
+		}
+		if err := s.sessions.Validate(ctx, req.SessionKeyID, req.To, req.Amount, req.ServiceID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSponsorshipDenied, err)
+		}
+	}
+
+	// Gate on the spend guard before signing anything: worst case the
+	// paymaster prefunds the op's full gas at its own fee ceiling.
+	if s.guard != nil {
+		if err := s.guard.Authorize(ctx, req.UserOp.maxCostWei(), req.Critical); err != nil {
+			return nil, err
+		}
+	}
+
+	// Hash the operation with paymasterAndData excluded — the approval we
+	// are about to produce can't be part of what it signs.
+	op := req.UserOp
+	op.PaymasterAndData = ""
+	opHash, ok := op.Hash(common.HexToAddress(a.EntryPoint), big.NewInt(s.chainID))
+	if !ok {
+		return nil, ErrInvalidUserOp
+	}
+
+	now := time.Now()
+	validAfter := now
+	validUntil := now.Add(s.sponsorTTL)
+
+	sig, err := s.signSponsorship(opHash, validUntil, validAfter)
+	if err != nil {
+		return nil, fmt.Errorf("erc4337: sign sponsorship: %w", err)
+	}
+
+	paymaster := crypto.PubkeyToAddress(s.paymasterKey.PublicKey)
+	// VerifyingPaymaster layout: paymaster address ++ abi.encode(validUntil, validAfter) ++ signature
+	data := make([]byte, 0, 20+64+65)
+	data = append(data, paymaster.Bytes()...)
+	data = append(data, word(big.NewInt(validUntil.Unix()))...)
+	data = append(data, word(big.NewInt(validAfter.Unix()))...)
+	data = append(data, sig...)
+
+	s.logger.Info("user operation sponsored",
+		"account_id", a.ID,
+		"owner", a.OwnerAddr,
+		"user_op_hash", opHash.Hex(),
+		"session_key", req.SessionKeyID,
+		"valid_until", validUntil)
+
+	sp := &Sponsorship{
+		UserOpHash:       opHash.Hex(),
+		PaymasterAndData: hexutil.Encode(data),
+		Paymaster:        paymaster.Hex(),
+		ValidAfter:       validAfter,
+		ValidUntil:       validUntil,
+	}
+	// Best-effort: a pricing failure shouldn't block an otherwise valid
+	// sponsorship, so estimation errors are logged and dropped.
+	if s.costs != nil {
+		if gas, ok := op.totalGas(); ok {
+			if usd, err := s.costs.UserOpCostUSD(ctx, gas); err == nil {
+				sp.EstimatedCostUSD = usd
+			} else {
+				s.logger.Warn("user op cost estimate failed", "err", err)
+			}
+		}
+	}
+	// Attribute the sponsored gas cost to the beneficiary agent as a
+	// zero-sum ledger entry so per-agent P&L reflects cost-to-serve.
+	// Informational, so failures don't unwind the sponsorship.
+	if s.gasCosts != nil && sp.EstimatedCostUSD != "" {
+		if err := s.gasCosts.RecordGasCost(ctx, a.OwnerAddr, sp.EstimatedCostUSD, sp.UserOpHash); err != nil {
+			s.logger.Warn("gas cost attribution failed",
+				"owner", a.OwnerAddr, "user_op_hash", sp.UserOpHash, "err", err)
+		}
+	}
+	return sp, nil
+}
+
+// maxCostWei is the operation's worst-case gas cost: total gas at the
+// op's fee ceiling. Zero when the gas fields don't parse.
+func (op *UserOperation) maxCostWei() *big.Int {
+	gas, ok := op.totalGas()
+	if !ok {
+		return new(big.Int)
+	}
+	maxFee, ok := parseBig(op.MaxFeePerGas)
+	if !ok {
+		return new(big.Int)
+	}
+	return new(big.Int).Mul(maxFee, new(big.Int).SetUint64(gas))
+}
+
+// totalGas sums the operation's three gas components, the amount the
+// paymaster ultimately pays for.
+func (op *UserOperation) totalGas() (uint64, bool) {
+	callGas, ok1 := parseBig(op.CallGasLimit)
+	verGas, ok2 := parseBig(op.VerificationGasLimit)
+	preGas, ok3 := parseBig(op.PreVerificationGas)
+	if !ok1 || !ok2 || !ok3 {
+		return 0, false
+	}
+	sum := new(big.Int).Add(callGas, verGas)
+	sum.Add(sum, preGas)
+	if !sum.IsUint64() {
+		return 0, false
+	}
+	return sum.Uint64(), true
+}
+
+// signSponsorship signs keccak256(userOpHash ++ validUntil ++ validAfter)
+// with the paymaster key, prefixed per EIP-191 as the on-chain verifier expects.
+func (s *Service) signSponsorship(opHash common.Hash, validUntil, validAfter time.Time) ([]byte, error) {
+	msg := make([]byte, 0, 3*32)
+	msg = append(msg, opHash.Bytes()...)
+	msg = append(msg, word(big.NewInt(validUntil.Unix()))...)
+	msg = append(msg, word(big.NewInt(validAfter.Unix()))...)
+	digest := crypto.Keccak256(msg)
+
+	prefixed := crypto.Keccak256(
+		[]byte("\x19Ethereum Signed Message:\n32"),
+		digest,
+	)
+	sig, err := crypto.Sign(prefixed, s.paymasterKey)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27 // transform V from 0/1 to 27/28 for on-chain ecrecover
+	return sig, nil
+}
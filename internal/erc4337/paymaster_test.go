@@ -0,0 +1,282 @@
+package erc4337
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type fakeSessionValidator struct {
+	err   error
+	calls []string
+}
+
+func (f *fakeSessionValidator) Validate(_ context.Context, keyID, _, _, _ string) error {
+	f.calls = append(f.calls, keyID)
+	return f.err
+}
+
+func sponsorService(t *testing.T) (*Service, *fakeSessionValidator) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	sessions := &fakeSessionValidator{}
+	svc := newTestService().
+		WithPaymasterKey(key).
+		WithChainID(84532).
+		WithSessionValidator(sessions)
+	return svc, sessions
+}
+
+func testUserOp(sender string) UserOperation {
+	return UserOperation{
+		Sender:               sender,
+		Nonce:                "1",
+		CallData:             "0xdeadbeef",
+		CallGasLimit:         "100000",
+		VerificationGasLimit: "150000",
+		PreVerificationGas:   "21000",
+		MaxFeePerGas:         "0x3b9aca00",
+		MaxPriorityFeePerGas: "1000000000",
+	}
+}
+
+func TestSponsorUserOp(t *testing.T) {
+	svc, sessions := sponsorService(t)
+	ctx := context.Background()
+
+	a, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr})
+	if err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+
+	sp, err := svc.SponsorUserOp(ctx, ownerAddr, a.ID, SponsorRequest{
+		SessionKeyID: "sk_test",
+		To:           otherAddr,
+		Amount:       "1.000000",
+		UserOp:       testUserOp(accountAddr),
+	})
+	if err != nil {
+		t.Fatalf("SponsorUserOp failed: %v", err)
+	}
+	if len(sessions.calls) != 1 || sessions.calls[0] != "sk_test" {
+		t.Errorf("expected session validation call, got %v", sessions.calls)
+	}
+
+	data, err := hexutil.Decode(sp.PaymasterAndData)
+	if err != nil {
+		t.Fatalf("paymasterAndData not hex: %v", err)
+	}
+	// address (20) + validUntil (32) + validAfter (32) + signature (65)
+	if len(data) != 149 {
+		t.Fatalf("unexpected paymasterAndData length %d", len(data))
+	}
+	if !strings.EqualFold(common.BytesToAddress(data[:20]).Hex(), sp.Paymaster) {
+		t.Errorf("paymaster address mismatch")
+	}
+	if !sp.ValidUntil.After(sp.ValidAfter) {
+		t.Errorf("expected validity window, got %v .. %v", sp.ValidAfter, sp.ValidUntil)
+	}
+
+	// The signature must recover to the paymaster address.
+	msg := make([]byte, 0, 3*32)
+	opHash := common.HexToHash(sp.UserOpHash)
+	msg = append(msg, opHash.Bytes()...)
+	msg = append(msg, common.LeftPadBytes(big.NewInt(sp.ValidUntil.Unix()).Bytes(), 32)...)
+	msg = append(msg, common.LeftPadBytes(big.NewInt(sp.ValidAfter.Unix()).Bytes(), 32)...)
+	digest := crypto.Keccak256(msg)
+	prefixed := crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), digest)
+	sig := append([]byte{}, data[84:]...)
+	sig[64] -= 27
+	pub, err := crypto.SigToPub(prefixed, sig)
+	if err != nil {
+		t.Fatalf("recover signer: %v", err)
+	}
+	if got := crypto.PubkeyToAddress(*pub).Hex(); !strings.EqualFold(got, sp.Paymaster) {
+		t.Errorf("signature recovers to %s, want %s", got, sp.Paymaster)
+	}
+}
+
+func TestSponsorUserOpDenials(t *testing.T) {
+	svc, sessions := sponsorService(t)
+	ctx := context.Background()
+
+	a, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr})
+	if err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+
+	// Sender must match the registered account.
+	if _, err := svc.SponsorUserOp(ctx, ownerAddr, a.ID, SponsorRequest{
+		SessionKeyID: "sk_test", UserOp: testUserOp(otherAddr),
+	}); !errors.Is(err, ErrInvalidUserOp) {
+		t.Errorf("expected ErrInvalidUserOp for sender mismatch, got %v", err)
+	}
+
+	// A session key is mandatory once a validator is configured.
+	if _, err := svc.SponsorUserOp(ctx, ownerAddr, a.ID, SponsorRequest{
+		UserOp: testUserOp(accountAddr),
+	}); !errors.Is(err, ErrSponsorshipDenied) {
+		t.Errorf("expected ErrSponsorshipDenied without session key, got %v", err)
+	}
+
+	// Over-budget session keys deny sponsorship.
+	sessions.err = errors.New("budget exceeded")
+	if _, err := svc.SponsorUserOp(ctx, ownerAddr, a.ID, SponsorRequest{
+		SessionKeyID: "sk_test", UserOp: testUserOp(accountAddr),
+	}); !errors.Is(err, ErrSponsorshipDenied) {
+		t.Errorf("expected ErrSponsorshipDenied from validator, got %v", err)
+	}
+	sessions.err = nil
+
+	// Only the owner may request sponsorship.
+	if _, err := svc.SponsorUserOp(ctx, otherAddr, a.ID, SponsorRequest{
+		SessionKeyID: "sk_test", UserOp: testUserOp(accountAddr),
+	}); !errors.Is(err, ErrNotOwner) {
+		t.Errorf("expected ErrNotOwner, got %v", err)
+	}
+
+	// Revoked accounts are not sponsored.
+	if _, err := svc.RevokeAccount(ctx, ownerAddr, a.ID); err != nil {
+		t.Fatalf("RevokeAccount failed: %v", err)
+	}
+	if _, err := svc.SponsorUserOp(ctx, ownerAddr, a.ID, SponsorRequest{
+		SessionKeyID: "sk_test", UserOp: testUserOp(accountAddr),
+	}); !errors.Is(err, ErrAccountRevoked) {
+		t.Errorf("expected ErrAccountRevoked, got %v", err)
+	}
+}
+
+func TestSponsorUserOpWithoutPaymasterKey(t *testing.T) {
+	svc := newTestService()
+	ctx := context.Background()
+
+	a, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr})
+	if err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+	if _, err := svc.SponsorUserOp(ctx, ownerAddr, a.ID, SponsorRequest{
+		UserOp: testUserOp(accountAddr),
+	}); !errors.Is(err, ErrPaymasterUnavailable) {
+		t.Errorf("expected ErrPaymasterUnavailable, got %v", err)
+	}
+}
+
+func TestUserOpHash(t *testing.T) {
+	op := testUserOp(accountAddr)
+	entryPoint := common.HexToAddress(DefaultEntryPoint)
+
+	h1, ok := op.Hash(entryPoint, big.NewInt(84532))
+	if !ok {
+		t.Fatal("Hash failed on valid op")
+	}
+	// Deterministic.
+	h2, _ := op.Hash(entryPoint, big.NewInt(84532))
+	if h1 != h2 {
+		t.Error("hash not deterministic")
+	}
+	// Chain ID is bound into the hash.
+	h3, _ := op.Hash(entryPoint, big.NewInt(8453))
+	if h1 == h3 {
+		t.Error("expected different hash on different chain")
+	}
+	// Call data changes the hash.
+	op.CallData = "0xcafebabe"
+	h4, _ := op.Hash(entryPoint, big.NewInt(84532))
+	if h1 == h4 {
+		t.Error("expected different hash on different call data")
+	}
+
+	// Malformed fields are rejected.
+	op.Nonce = "not-a-number"
+	if _, ok := op.Hash(entryPoint, big.NewInt(84532)); ok {
+		t.Error("expected Hash to fail on bad nonce")
+	}
+}
+
+type fakeCostEstimator struct {
+	usd string
+	err error
+	gas []uint64
+}
+
+func (f *fakeCostEstimator) UserOpCostUSD(_ context.Context, gas uint64) (string, error) {
+	f.gas = append(f.gas, gas)
+	return f.usd, f.err
+}
+
+type fakeGasCostRecorder struct {
+	agents  []string
+	amounts []string
+	refs    []string
+	err     error
+}
+
+func (f *fakeGasCostRecorder) RecordGasCost(_ context.Context, agentAddr, amountUSD, reference string) error {
+	f.agents = append(f.agents, agentAddr)
+	f.amounts = append(f.amounts, amountUSD)
+	f.refs = append(f.refs, reference)
+	return f.err
+}
+
+func TestSponsorUserOpGasCostAttribution(t *testing.T) {
+	svc, _ := sponsorService(t)
+	estimator := &fakeCostEstimator{usd: "0.004200"}
+	recorder := &fakeGasCostRecorder{}
+	svc.WithCostEstimator(estimator).WithGasCostRecorder(recorder)
+	ctx := context.Background()
+
+	a, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr})
+	if err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+
+	sp, err := svc.SponsorUserOp(ctx, ownerAddr, a.ID, SponsorRequest{
+		SessionKeyID: "sk_test",
+		UserOp:       testUserOp(accountAddr),
+	})
+	if err != nil {
+		t.Fatalf("SponsorUserOp failed: %v", err)
+	}
+	if sp.EstimatedCostUSD != "0.004200" {
+		t.Errorf("EstimatedCostUSD = %q, want 0.004200", sp.EstimatedCostUSD)
+	}
+	// 100000 + 150000 + 21000 from testUserOp.
+	if len(estimator.gas) != 1 || estimator.gas[0] != 271000 {
+		t.Errorf("estimator gas = %v, want [271000]", estimator.gas)
+	}
+	if len(recorder.agents) != 1 ||
+		recorder.agents[0] != strings.ToLower(ownerAddr) ||
+		recorder.amounts[0] != "0.004200" ||
+		recorder.refs[0] != sp.UserOpHash {
+		t.Errorf("attribution = %v / %v / %v, want owner / 0.004200 / user op hash",
+			recorder.agents, recorder.amounts, recorder.refs)
+	}
+}
+
+func TestSponsorUserOpAttributionFailureNonFatal(t *testing.T) {
+	svc, _ := sponsorService(t)
+	svc.WithCostEstimator(&fakeCostEstimator{usd: "0.001000"}).
+		WithGasCostRecorder(&fakeGasCostRecorder{err: errors.New("ledger down")})
+	ctx := context.Background()
+
+	a, err := svc.RegisterAccount(ctx, ownerAddr, RegisterAccountRequest{AccountAddr: accountAddr})
+	if err != nil {
+		t.Fatalf("RegisterAccount failed: %v", err)
+	}
+
+	if _, err := svc.SponsorUserOp(ctx, ownerAddr, a.ID, SponsorRequest{
+		SessionKeyID: "sk_test",
+		UserOp:       testUserOp(accountAddr),
+	}); err != nil {
+		t.Fatalf("SponsorUserOp should succeed despite attribution failure: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package erc4337
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// PostgresStore persists smart accounts in PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new PostgreSQL-backed smart-account store.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const accountColumns = `id, owner_addr, account_addr, entry_point, chain, status, created_at, revoked_at`
+
+func (p *PostgresStore) CreateAccount(ctx context.Context, a *SmartAccount) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO smart_accounts (id, owner_addr, account_addr, entry_point, chain, status, created_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		a.ID, a.OwnerAddr, a.AccountAddr, a.EntryPoint, a.Chain, string(a.Status), a.CreatedAt, nullTime(a.RevokedAt),
+	)
+	return err
+}
+
+func (p *PostgresStore) GetAccount(ctx context.Context, id string) (*SmartAccount, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+accountColumns+` FROM smart_accounts WHERE id = $1`, id)
+	a, err := scanAccount(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return a, err
+}
+
+func (p *PostgresStore) GetAccountByAddress(ctx context.Context, accountAddr string) (*SmartAccount, error) {
+	row := p.db.QueryRowContext(ctx,
+		`SELECT `+accountColumns+` FROM smart_accounts WHERE account_addr = $1 ORDER BY created_at DESC LIMIT 1`,
+		accountAddr)
+	a, err := scanAccount(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return a, err
+}
+
+func (p *PostgresStore) ListAccountsByOwner(ctx context.Context, ownerAddr string) ([]*SmartAccount, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT `+accountColumns+` FROM smart_accounts WHERE owner_addr = $1 ORDER BY created_at DESC`,
+		ownerAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*SmartAccount
+	for rows.Next() {
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func (p *PostgresStore) UpdateAccount(ctx context.Context, a *SmartAccount) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE smart_accounts SET status = $1, revoked_at = $2 WHERE id = $3`,
+		string(a.Status), nullTime(a.RevokedAt), a.ID,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAccount(row scanner) (*SmartAccount, error) {
+	var a SmartAccount
+	var status string
+	var revokedAt sql.NullTime
+	err := row.Scan(&a.ID, &a.OwnerAddr, &a.AccountAddr, &a.EntryPoint, &a.Chain, &status, &a.CreatedAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+	a.Status = AccountStatus(status)
+	if revokedAt.Valid {
+		t := revokedAt.Time
+		a.RevokedAt = &t
+	}
+	return &a, nil
+}
+
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+var _ Store = (*PostgresStore)(nil)
@@ -0,0 +1,248 @@
+package erc4337
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/metrics"
+)
+
+// SpendAlert is raised when sponsored gas spend approaches the daily cap
+// or the paymaster's ETH balance drops below its floor.
+type SpendAlert struct {
+	Type          string    `json:"type"` // "spend_warning", "spend_cap_reached", or "balance_low"
+	SpentWei      string    `json:"spentWei,omitempty"`
+	DailyCapWei   string    `json:"dailyCapWei,omitempty"`
+	UsedPct       float64   `json:"usedPct,omitempty"`
+	BalanceWei    string    `json:"balanceWei,omitempty"`
+	MinBalanceWei string    `json:"minBalanceWei,omitempty"`
+	Throttled     bool      `json:"throttled"`
+	TriggeredAt   time.Time `json:"triggeredAt"`
+}
+
+// SpendAlertNotifier delivers spend alerts to operators.
+// Satisfied by an adapter over the webhook dispatcher.
+type SpendAlertNotifier interface {
+	NotifySpendAlert(ctx context.Context, alert SpendAlert) error
+}
+
+// BalanceReader reports the paymaster's native-token balance.
+// Satisfied by an adapter over the payout chain client.
+type BalanceReader interface {
+	ETHBalance(ctx context.Context, addr string) (*big.Int, error)
+}
+
+// SpendGuardConfig tunes the spend guard's caps and thresholds.
+type SpendGuardConfig struct {
+	DailyCapETH   float64 // max worst-case sponsored gas per UTC day; 0 = no cap
+	MinBalanceETH float64 // paymaster balance floor; 0 = no balance checks
+	WarnPct       int     // % of the cap where throttling starts (default 80)
+}
+
+// SpendGuard tracks worst-case sponsored gas spend against a daily cap and
+// the paymaster's ETH balance. Inside the warning zone (or below the
+// balance floor) only sponsorships marked critical go through; at the cap
+// everything is refused. Alerts fire once per UTC day per condition.
+type SpendGuard struct {
+	dailyCapWei   *big.Int // nil = no cap
+	minBalanceWei *big.Int // nil = no balance checks
+	warnPct       int64
+
+	balances      BalanceReader
+	paymasterAddr string
+	notifier      SpendAlertNotifier
+	logger        *slog.Logger
+
+	mu        sync.Mutex
+	day       string // UTC date the counters cover
+	spent     *big.Int
+	fired     map[string]bool
+	balance   *big.Int // cached paymaster balance
+	balanceAt time.Time
+}
+
+// balanceCacheTTL bounds how often the guard hits the RPC for the
+// paymaster balance.
+const balanceCacheTTL = time.Minute
+
+// NewSpendGuard creates a spend guard from the given caps.
+func NewSpendGuard(cfg SpendGuardConfig) *SpendGuard {
+	warnPct := int64(cfg.WarnPct)
+	if warnPct <= 0 || warnPct > 100 {
+		warnPct = 80
+	}
+	return &SpendGuard{
+		dailyCapWei:   ethToWei(cfg.DailyCapETH),
+		minBalanceWei: ethToWei(cfg.MinBalanceETH),
+		warnPct:       warnPct,
+		logger:        slog.Default(),
+		spent:         new(big.Int),
+		fired:         make(map[string]bool),
+	}
+}
+
+// WithBalanceReader enables the paymaster balance floor check.
+func (g *SpendGuard) WithBalanceReader(r BalanceReader, paymasterAddr string) *SpendGuard {
+	g.balances = r
+	g.paymasterAddr = paymasterAddr
+	return g
+}
+
+// WithNotifier sets where alerts are delivered.
+func (g *SpendGuard) WithNotifier(n SpendAlertNotifier) *SpendGuard {
+	g.notifier = n
+	return g
+}
+
+// WithLogger sets the logger.
+func (g *SpendGuard) WithLogger(logger *slog.Logger) *SpendGuard {
+	g.logger = logger
+	return g
+}
+
+// Authorize checks a sponsorship's worst-case gas cost (in wei) against
+// the daily cap and balance floor, recording the spend when allowed.
+// Returns ErrSponsorshipThrottled when the sponsorship must be refused.
+func (g *SpendGuard) Authorize(ctx context.Context, costWei *big.Int, critical bool) error {
+	if g == nil {
+		return nil
+	}
+	if costWei == nil {
+		costWei = new(big.Int)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rollDayLocked()
+
+	projected := new(big.Int).Add(g.spent, costWei)
+
+	if g.dailyCapWei != nil {
+		if projected.Cmp(g.dailyCapWei) > 0 {
+			g.alertLocked(ctx, SpendAlert{
+				Type:        "spend_cap_reached",
+				SpentWei:    g.spent.String(),
+				DailyCapWei: g.dailyCapWei.String(),
+				UsedPct:     pctOf(g.spent, g.dailyCapWei),
+				Throttled:   true,
+			})
+			metrics.PaymasterThrottleActive.Set(1)
+			return ErrSponsorshipThrottled
+		}
+
+		// Warning zone: projected spend crosses warnPct of the cap. Alert
+		// and throttle non-critical operations so headroom is preserved
+		// for the ones that matter.
+		warnAt := new(big.Int).Mul(g.dailyCapWei, big.NewInt(g.warnPct))
+		warnAt.Div(warnAt, big.NewInt(100))
+		if projected.Cmp(warnAt) >= 0 {
+			g.alertLocked(ctx, SpendAlert{
+				Type:        "spend_warning",
+				SpentWei:    g.spent.String(),
+				DailyCapWei: g.dailyCapWei.String(),
+				UsedPct:     pctOf(projected, g.dailyCapWei),
+				Throttled:   !critical,
+			})
+			metrics.PaymasterThrottleActive.Set(1)
+			if !critical {
+				return ErrSponsorshipThrottled
+			}
+		}
+	}
+
+	if g.minBalanceWei != nil && g.balances != nil {
+		if bal := g.refreshBalanceLocked(ctx); bal != nil && bal.Cmp(g.minBalanceWei) < 0 {
+			g.alertLocked(ctx, SpendAlert{
+				Type:          "balance_low",
+				BalanceWei:    bal.String(),
+				MinBalanceWei: g.minBalanceWei.String(),
+				Throttled:     !critical,
+			})
+			metrics.PaymasterThrottleActive.Set(1)
+			if !critical {
+				return ErrSponsorshipThrottled
+			}
+		}
+	}
+
+	g.spent = projected
+	metrics.PaymasterSpendGwei.Set(weiToGwei(g.spent))
+	return nil
+}
+
+// rollDayLocked resets the counters when the UTC day changes.
+// Caller holds g.mu.
+func (g *SpendGuard) rollDayLocked() {
+	today := time.Now().UTC().Format("2006-01-02")
+	if g.day == today {
+		return
+	}
+	g.day = today
+	g.spent = new(big.Int)
+	g.fired = make(map[string]bool)
+	metrics.PaymasterSpendGwei.Set(0)
+	metrics.PaymasterThrottleActive.Set(0)
+}
+
+// alertLocked delivers an alert once per day per condition type.
+// Caller holds g.mu.
+func (g *SpendGuard) alertLocked(ctx context.Context, alert SpendAlert) {
+	if g.fired[alert.Type] {
+		return
+	}
+	g.fired[alert.Type] = true
+	alert.TriggeredAt = time.Now().UTC()
+	metrics.PaymasterAlertsTotal.WithLabelValues(alert.Type).Inc()
+	g.logger.Warn("paymaster spend alert",
+		"type", alert.Type,
+		"spent_wei", alert.SpentWei,
+		"balance_wei", alert.BalanceWei,
+		"throttled", alert.Throttled)
+	if g.notifier != nil {
+		if err := g.notifier.NotifySpendAlert(ctx, alert); err != nil {
+			g.logger.Warn("paymaster alert delivery failed", "type", alert.Type, "err", err)
+		}
+	}
+}
+
+// refreshBalanceLocked returns the cached paymaster balance, refreshing it
+// when stale. A failed read keeps the previous value. Caller holds g.mu.
+func (g *SpendGuard) refreshBalanceLocked(ctx context.Context) *big.Int {
+	if time.Since(g.balanceAt) < balanceCacheTTL {
+		return g.balance
+	}
+	bal, err := g.balances.ETHBalance(ctx, g.paymasterAddr)
+	if err != nil {
+		g.logger.Warn("paymaster balance check failed", "err", err)
+		return g.balance
+	}
+	g.balance = bal
+	g.balanceAt = time.Now()
+	return g.balance
+}
+
+// pctOf returns a*100/b as a float, 0 when b is zero.
+func pctOf(a, b *big.Int) float64 {
+	if b == nil || b.Sign() == 0 {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(a), new(big.Float).SetInt(b)).Float64()
+	return f * 100
+}
+
+// ethToWei converts a float ETH amount to wei, nil when non-positive.
+func ethToWei(eth float64) *big.Int {
+	if eth <= 0 {
+		return nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(eth), big.NewFloat(1e18)).Int(nil)
+	return wei
+}
+
+func weiToGwei(wei *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e9)).Float64()
+	return f
+}
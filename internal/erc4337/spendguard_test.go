@@ -0,0 +1,187 @@
+package erc4337
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+type recordedAlerts struct {
+	alerts []SpendAlert
+}
+
+func (r *recordedAlerts) NotifySpendAlert(_ context.Context, alert SpendAlert) error {
+	r.alerts = append(r.alerts, alert)
+	return nil
+}
+
+type stubBalance struct {
+	balance *big.Int
+	err     error
+}
+
+func (s *stubBalance) ETHBalance(_ context.Context, _ string) (*big.Int, error) {
+	return s.balance, s.err
+}
+
+func TestSpendGuard_AllowsUnderCap(t *testing.T) {
+	g := NewSpendGuard(SpendGuardConfig{DailyCapETH: 1})
+	ctx := context.Background()
+
+	// 0.1 ETH each — well under the cap and the 80% warning zone.
+	cost := big.NewInt(1e17)
+	for i := 0; i < 5; i++ {
+		if err := g.Authorize(ctx, cost, false); err != nil {
+			t.Fatalf("Authorize #%d: %v", i, err)
+		}
+	}
+	if g.spent.Cmp(big.NewInt(5e17)) != 0 {
+		t.Fatalf("spent = %v, want 5e17", g.spent)
+	}
+}
+
+func TestSpendGuard_WarnZoneThrottlesNonCritical(t *testing.T) {
+	alerts := &recordedAlerts{}
+	g := NewSpendGuard(SpendGuardConfig{DailyCapETH: 1}).WithNotifier(alerts)
+	ctx := context.Background()
+
+	// Push spend to 0.7 ETH, then request 0.2 more: projected 0.9 crosses
+	// the 80% warning line.
+	if err := g.Authorize(ctx, big.NewInt(7e17), false); err != nil {
+		t.Fatalf("Authorize setup: %v", err)
+	}
+	err := g.Authorize(ctx, big.NewInt(2e17), false)
+	if !errors.Is(err, ErrSponsorshipThrottled) {
+		t.Fatalf("non-critical in warn zone: err = %v, want ErrSponsorshipThrottled", err)
+	}
+
+	// Critical sponsorships still go through (and count toward the cap).
+	if err := g.Authorize(ctx, big.NewInt(2e17), true); err != nil {
+		t.Fatalf("critical in warn zone: %v", err)
+	}
+	if g.spent.Cmp(big.NewInt(9e17)) != 0 {
+		t.Fatalf("spent = %v, want 9e17", g.spent)
+	}
+
+	if len(alerts.alerts) != 1 || alerts.alerts[0].Type != "spend_warning" {
+		t.Fatalf("alerts = %+v, want one spend_warning", alerts.alerts)
+	}
+}
+
+func TestSpendGuard_CapRefusesEvenCritical(t *testing.T) {
+	alerts := &recordedAlerts{}
+	g := NewSpendGuard(SpendGuardConfig{DailyCapETH: 1}).WithNotifier(alerts)
+	ctx := context.Background()
+
+	if err := g.Authorize(ctx, big.NewInt(9e17), true); err != nil {
+		t.Fatalf("Authorize setup: %v", err)
+	}
+	err := g.Authorize(ctx, big.NewInt(2e17), true)
+	if !errors.Is(err, ErrSponsorshipThrottled) {
+		t.Fatalf("over cap: err = %v, want ErrSponsorshipThrottled", err)
+	}
+
+	found := false
+	for _, a := range alerts.alerts {
+		if a.Type == "spend_cap_reached" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("alerts = %+v, want spend_cap_reached", alerts.alerts)
+	}
+}
+
+func TestSpendGuard_LowBalanceThrottlesNonCritical(t *testing.T) {
+	alerts := &recordedAlerts{}
+	g := NewSpendGuard(SpendGuardConfig{MinBalanceETH: 1}).
+		WithBalanceReader(&stubBalance{balance: big.NewInt(5e17)}, "0xpaymaster").
+		WithNotifier(alerts)
+	ctx := context.Background()
+
+	err := g.Authorize(ctx, big.NewInt(1e15), false)
+	if !errors.Is(err, ErrSponsorshipThrottled) {
+		t.Fatalf("low balance non-critical: err = %v, want ErrSponsorshipThrottled", err)
+	}
+	if err := g.Authorize(ctx, big.NewInt(1e15), true); err != nil {
+		t.Fatalf("low balance critical: %v", err)
+	}
+	if len(alerts.alerts) != 1 || alerts.alerts[0].Type != "balance_low" {
+		t.Fatalf("alerts = %+v, want one balance_low", alerts.alerts)
+	}
+}
+
+func TestSpendGuard_BalanceReadFailureDoesNotThrottle(t *testing.T) {
+	g := NewSpendGuard(SpendGuardConfig{MinBalanceETH: 1}).
+		WithBalanceReader(&stubBalance{err: errors.New("rpc down")}, "0xpaymaster")
+
+	// No balance has ever been read: the guard can't know it's low, so the
+	// sponsorship goes through.
+	if err := g.Authorize(context.Background(), big.NewInt(1e15), false); err != nil {
+		t.Fatalf("Authorize with failing balance read: %v", err)
+	}
+}
+
+func TestSpendGuard_DayRolloverResets(t *testing.T) {
+	alerts := &recordedAlerts{}
+	g := NewSpendGuard(SpendGuardConfig{DailyCapETH: 1}).WithNotifier(alerts)
+	ctx := context.Background()
+
+	if err := g.Authorize(ctx, big.NewInt(9e17), true); err != nil {
+		t.Fatalf("Authorize setup: %v", err)
+	}
+	// Simulate the UTC day turning over.
+	g.mu.Lock()
+	g.day = "2000-01-01"
+	g.mu.Unlock()
+
+	if err := g.Authorize(ctx, big.NewInt(1e17), false); err != nil {
+		t.Fatalf("Authorize after rollover: %v", err)
+	}
+	if g.spent.Cmp(big.NewInt(1e17)) != 0 {
+		t.Fatalf("spent after rollover = %v, want 1e17", g.spent)
+	}
+}
+
+func TestSpendGuard_AlertsFireOncePerDay(t *testing.T) {
+	alerts := &recordedAlerts{}
+	g := NewSpendGuard(SpendGuardConfig{DailyCapETH: 1}).WithNotifier(alerts)
+	ctx := context.Background()
+
+	// Critical so the warn-zone spend itself is admitted.
+	if err := g.Authorize(ctx, big.NewInt(85e16), true); err != nil {
+		t.Fatalf("Authorize setup: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		_ = g.Authorize(ctx, big.NewInt(1e16), false)
+	}
+	if len(alerts.alerts) != 1 {
+		t.Fatalf("alert count = %d, want 1 (deduped per day)", len(alerts.alerts))
+	}
+}
+
+func TestSpendGuard_NilGuardAllows(t *testing.T) {
+	var g *SpendGuard
+	if err := g.Authorize(context.Background(), big.NewInt(1), false); err != nil {
+		t.Fatalf("nil guard: %v", err)
+	}
+}
+
+func TestUserOpMaxCostWei(t *testing.T) {
+	op := &UserOperation{
+		CallGasLimit:         "100000",
+		VerificationGasLimit: "150000",
+		PreVerificationGas:   "50000",
+		MaxFeePerGas:         "1000000000", // 1 gwei
+	}
+	want := new(big.Int).Mul(big.NewInt(300000), big.NewInt(1000000000))
+	if got := op.maxCostWei(); got.Cmp(want) != 0 {
+		t.Fatalf("maxCostWei = %v, want %v", got, want)
+	}
+
+	bad := &UserOperation{CallGasLimit: "nope"}
+	if got := bad.maxCostWei(); got.Sign() != 0 {
+		t.Fatalf("unparseable op cost = %v, want 0", got)
+	}
+}
@@ -0,0 +1,94 @@
+package erc4337
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// UserOperation is an ERC-4337 v0.6 user operation. Numeric fields accept
+// decimal or 0x-prefixed hex strings; byte fields are 0x-prefixed hex.
+type UserOperation struct {
+	Sender               string `json:"sender" binding:"required"`
+	Nonce                string `json:"nonce"`
+	InitCode             string `json:"initCode,omitempty"`
+	CallData             string `json:"callData"`
+	CallGasLimit         string `json:"callGasLimit"`
+	VerificationGasLimit string `json:"verificationGasLimit"`
+	PreVerificationGas   string `json:"preVerificationGas"`
+	MaxFeePerGas         string `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     string `json:"paymasterAndData,omitempty"`
+	Signature            string `json:"signature,omitempty"`
+}
+
+// parseBig parses a decimal or 0x-prefixed hex quantity. Empty = zero.
+func parseBig(s string) (*big.Int, bool) {
+	if s == "" {
+		return new(big.Int), true
+	}
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, ok := new(big.Int).SetString(s[2:], 16)
+		return v, ok
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	return v, ok
+}
+
+// parseBytes parses a 0x-prefixed hex byte string. Empty = no bytes.
+func parseBytes(s string) ([]byte, bool) {
+	if s == "" || s == "0x" {
+		return nil, true
+	}
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// word left-pads a big.Int to a 32-byte ABI word.
+func word(v *big.Int) []byte {
+	return common.LeftPadBytes(v.Bytes(), 32)
+}
+
+// Hash computes the canonical userOpHash: the operation packed per the
+// v0.6 EntryPoint (signature excluded, dynamic fields hashed), then bound
+// to the EntryPoint address and chain ID.
+func (op *UserOperation) Hash(entryPoint common.Address, chainID *big.Int) (common.Hash, bool) {
+	nonce, ok1 := parseBig(op.Nonce)
+	callGas, ok2 := parseBig(op.CallGasLimit)
+	verGas, ok3 := parseBig(op.VerificationGasLimit)
+	preGas, ok4 := parseBig(op.PreVerificationGas)
+	maxFee, ok5 := parseBig(op.MaxFeePerGas)
+	maxPrio, ok6 := parseBig(op.MaxPriorityFeePerGas)
+	initCode, ok7 := parseBytes(op.InitCode)
+	callData, ok8 := parseBytes(op.CallData)
+	pmData, ok9 := parseBytes(op.PaymasterAndData)
+	if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6 && ok7 && ok8 && ok9) || !common.IsHexAddress(op.Sender) {
+		return common.Hash{}, false
+	}
+
+	packed := make([]byte, 0, 10*32)
+	packed = append(packed, common.LeftPadBytes(common.HexToAddress(op.Sender).Bytes(), 32)...)
+	packed = append(packed, word(nonce)...)
+	packed = append(packed, crypto.Keccak256(initCode)...)
+	packed = append(packed, crypto.Keccak256(callData)...)
+	packed = append(packed, word(callGas)...)
+	packed = append(packed, word(verGas)...)
+	packed = append(packed, word(preGas)...)
+	packed = append(packed, word(maxFee)...)
+	packed = append(packed, word(maxPrio)...)
+	packed = append(packed, crypto.Keccak256(pmData)...)
+
+	inner := crypto.Keccak256(packed)
+	outer := make([]byte, 0, 3*32)
+	outer = append(outer, inner...)
+	outer = append(outer, common.LeftPadBytes(entryPoint.Bytes(), 32)...)
+	outer = append(outer, word(chainID)...)
+
+	return crypto.Keccak256Hash(outer), true
+}
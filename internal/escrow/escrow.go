@@ -238,6 +238,12 @@ type TrustGate interface {
 	CheckCounterpartyTrust(ctx context.Context, agentAddr string) error
 }
 
+// PaymentGate vetoes payments to blocked counterparties. Returns nil
+// if the buyer is allowed to pay the seller, or an error with the reason.
+type PaymentGate interface {
+	CheckPayment(ctx context.Context, payerAddr, payeeAddr string) error
+}
+
 // CreateRequest contains the parameters for creating an escrow.
 type CreateRequest struct {
 	BuyerAddr    string `json:"buyerAddr" binding:"required"`
@@ -282,6 +288,7 @@ type Service struct {
 	receiptIssuer  ReceiptIssuer
 	webhookEmitter WebhookEmitter
 	trustGate      TrustGate           // KYA trust verification before escrow creation
+	paymentGate    PaymentGate         // counterparty blocklist check before escrow creation
 	realtime       RealtimeBroadcaster // WebSocket event broadcasting for live dashboard
 	bus            eventbus.Bus        // event bus for settlement/dispute events (optional)
 	logger         *slog.Logger
@@ -405,6 +412,13 @@ func (s *Service) WithTrustGate(tg TrustGate) *Service {
 	return s
 }
 
+// WithPaymentGate adds counterparty blocklist checks before escrow creation.
+// When configured, blocked sellers are refused before funds are locked.
+func (s *Service) WithPaymentGate(pg PaymentGate) *Service {
+	s.paymentGate = pg
+	return s
+}
+
 // validateAmount checks that the amount string is a positive number within NUMERIC(20,6) range.
 func validateAmount(amount string) error {
 	amount = strings.TrimSpace(amount)
@@ -456,6 +470,14 @@ func (s *Service) Create(ctx context.Context, req CreateRequest) (*Escrow, error
 		}
 	}
 
+	// Blocklist gate: refuse payments to blocked counterparties.
+	if s.paymentGate != nil {
+		if err := s.paymentGate.CheckPayment(ctx, req.BuyerAddr, req.SellerAddr); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("escrow: counterparty blocked: %w", err)
+		}
+	}
+
 	autoRelease := DefaultAutoRelease
 	if req.AutoRelease != "" {
 		d, err := time.ParseDuration(req.AutoRelease)
@@ -1094,6 +1116,20 @@ func (s *Service) Get(ctx context.Context, id string) (*Escrow, error) {
 	return s.store.Get(ctx, id)
 }
 
+// CountOpen returns the number of escrows still holding funds
+// (pending, delivered, disputed, or arbitrating).
+func (s *Service) CountOpen(ctx context.Context) (int, error) {
+	var total int
+	for _, status := range []Status{StatusPending, StatusDelivered, StatusDisputed, StatusArbitrating} {
+		list, err := s.store.ListByStatus(ctx, status, 10000)
+		if err != nil {
+			return 0, err
+		}
+		total += len(list)
+	}
+	return total, nil
+}
+
 // ForceCloseExpired auto-releases all expired escrows. Returns the number closed.
 func (s *Service) ForceCloseExpired(ctx context.Context) (int, error) {
 	expired, err := s.store.ListExpired(ctx, time.Now(), 100)
@@ -3342,6 +3342,55 @@ func TestEscrow_Create_TrustGateAllow(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Escrow: Create with PaymentGate (counterparty blocklist)
+// ---------------------------------------------------------------------------
+
+type mockPaymentGate struct {
+	err error
+}
+
+func (m *mockPaymentGate) CheckPayment(_ context.Context, _, _ string) error {
+	return m.err
+}
+
+func TestEscrow_Create_PaymentGateReject(t *testing.T) {
+	store := NewMemoryStore()
+	ml := newMockLedger()
+	svc := NewService(store, ml).
+		WithPaymentGate(&mockPaymentGate{err: errors.New("seller is blocked")})
+	ctx := context.Background()
+
+	_, err := svc.Create(ctx, CreateRequest{
+		BuyerAddr:  "0xbuyer",
+		SellerAddr: "0xseller",
+		Amount:     "1.00",
+	})
+	if err == nil {
+		t.Fatal("expected error from payment gate")
+	}
+}
+
+func TestEscrow_Create_PaymentGateAllow(t *testing.T) {
+	store := NewMemoryStore()
+	ml := newMockLedger()
+	svc := NewService(store, ml).
+		WithPaymentGate(&mockPaymentGate{err: nil})
+	ctx := context.Background()
+
+	esc, err := svc.Create(ctx, CreateRequest{
+		BuyerAddr:  "0xbuyer",
+		SellerAddr: "0xseller",
+		Amount:     "1.00",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if esc.Status != StatusPending {
+		t.Fatalf("expected pending, got %s", esc.Status)
+	}
+}
+
 // --- RealtimeBroadcaster tests ---
 
 type mockEscrowRealtime struct {
@@ -3456,3 +3505,46 @@ func TestRealtimeBroadcaster_EscrowDispute(t *testing.T) {
 		t.Errorf("expected 3 total events, got %d", rt.totalEvents())
 	}
 }
+
+func TestEscrow_CountOpen(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+	ctx := context.Background()
+
+	first, err := svc.Create(ctx, CreateRequest{
+		BuyerAddr:  "0xbuyer",
+		SellerAddr: "0xseller",
+		Amount:     "1.00",
+		ServiceID:  "svc_123",
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	second, err := svc.Create(ctx, CreateRequest{
+		BuyerAddr:  "0xbuyer",
+		SellerAddr: "0xseller",
+		Amount:     "2.00",
+		ServiceID:  "svc_123",
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// One pending, one delivered: both count as open.
+	if _, err := svc.MarkDelivered(ctx, second.ID, "0xseller"); err != nil {
+		t.Fatalf("MarkDelivered failed: %v", err)
+	}
+	if n, err := svc.CountOpen(ctx); err != nil || n != 2 {
+		t.Errorf("CountOpen = %d (err %v), want 2", n, err)
+	}
+
+	// Released escrows no longer hold funds.
+	if _, err := svc.Confirm(ctx, second.ID, "0xbuyer"); err != nil {
+		t.Fatalf("Confirm failed: %v", err)
+	}
+	if n, err := svc.CountOpen(ctx); err != nil || n != 1 {
+		t.Errorf("CountOpen = %d (err %v), want 1", n, err)
+	}
+	_ = first
+}
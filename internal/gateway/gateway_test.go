@@ -5104,3 +5104,82 @@ func TestRealtimeBroadcaster_SessionLifecycle(t *testing.T) {
 		t.Errorf("expected 1 session closed broadcast, got %d", n)
 	}
 }
+
+// --- PaymentGate (counterparty blocklist) tests ---
+
+type stubPaymentGate struct {
+	blocked map[string]bool
+}
+
+func (g *stubPaymentGate) CheckPayment(_ context.Context, _, payeeAddr string) error {
+	if g.blocked[payeeAddr] {
+		return fmt.Errorf("counterparty %s is blocked", payeeAddr)
+	}
+	return nil
+}
+
+func TestProxy_PaymentGateSkipsBlockedProvider(t *testing.T) {
+	server := fakeServiceEndpoint(200, map[string]interface{}{"ok": true})
+	defer server.Close()
+
+	ml := newMockLedger()
+	reg := &mockRegistry{
+		services: []ServiceCandidate{
+			{AgentAddress: "0xblocked", ServiceID: "svc1", ServiceName: "bad", Price: "0.10", Endpoint: server.URL},
+			{AgentAddress: "0xclean", ServiceID: "svc2", ServiceName: "good", Price: "0.20", Endpoint: server.URL},
+		},
+	}
+
+	svc := newTestServiceWithLogger(ml, reg).
+		WithPaymentGate(&stubPaymentGate{blocked: map[string]bool{"0xblocked": true}})
+
+	session, _ := svc.CreateSession(context.Background(), "0xbuyer", "", CreateSessionRequest{
+		MaxTotal:      "10.00",
+		MaxPerRequest: "1.00",
+	})
+
+	result, err := svc.Proxy(context.Background(), session.ID, ProxyRequest{
+		ServiceType: "translation",
+	})
+	if err != nil {
+		t.Fatalf("proxy: %v", err)
+	}
+	if result.ServiceUsed != "0xclean" {
+		t.Errorf("expected blocked provider skipped, got %s", result.ServiceUsed)
+	}
+
+	// Only the clean provider is ever paid.
+	if len(ml.settlements) != 1 {
+		t.Errorf("expected 1 settlement, got %d", len(ml.settlements))
+	}
+}
+
+func TestProxy_PaymentGateAllBlocked(t *testing.T) {
+	server := fakeServiceEndpoint(200, map[string]interface{}{"ok": true})
+	defer server.Close()
+
+	ml := newMockLedger()
+	reg := &mockRegistry{
+		services: []ServiceCandidate{
+			{AgentAddress: "0xblocked", ServiceID: "svc1", ServiceName: "bad", Price: "0.10", Endpoint: server.URL},
+		},
+	}
+
+	svc := newTestServiceWithLogger(ml, reg).
+		WithPaymentGate(&stubPaymentGate{blocked: map[string]bool{"0xblocked": true}})
+
+	session, _ := svc.CreateSession(context.Background(), "0xbuyer", "", CreateSessionRequest{
+		MaxTotal:      "10.00",
+		MaxPerRequest: "1.00",
+	})
+
+	_, err := svc.Proxy(context.Background(), session.ID, ProxyRequest{
+		ServiceType: "translation",
+	})
+	if err == nil {
+		t.Fatal("expected error when every candidate is blocked")
+	}
+	if len(ml.settlements) != 0 {
+		t.Errorf("expected no settlements, got %d", len(ml.settlements))
+	}
+}
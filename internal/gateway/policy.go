@@ -2,6 +2,13 @@ package gateway
 
 import "context"
 
+// PaymentGate vetoes payments to blocked counterparties. Returns nil
+// if the session owner is allowed to pay the provider, or an error with
+// the reason.
+type PaymentGate interface {
+	CheckPayment(ctx context.Context, payerAddr, payeeAddr string) error
+}
+
 // PolicyEvaluator evaluates spending policies for gateway sessions.
 type PolicyEvaluator interface {
 	// EvaluateProxy checks whether a proxy request should be allowed.
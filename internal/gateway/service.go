@@ -210,6 +210,7 @@ type Service struct {
 	recorder        TransactionRecorder
 	receiptIssuer   ReceiptIssuer
 	policyEvaluator PolicyEvaluator
+	paymentGate     PaymentGate // counterparty blocklist check before paying a provider
 	tenantSettings  TenantSettingsProvider
 	webhookEmitter  WebhookEmitter
 	revenue         RevenueAccumulator
@@ -279,6 +280,13 @@ func (s *Service) WithPolicyEvaluator(p PolicyEvaluator) *Service {
 	return s
 }
 
+// WithPaymentGate adds counterparty blocklist checks to candidate selection.
+// Blocked providers are skipped as if they were not offering the service.
+func (s *Service) WithPaymentGate(pg PaymentGate) *Service {
+	s.paymentGate = pg
+	return s
+}
+
 // WithTenantSettings adds a tenant settings provider for fee computation.
 func (s *Service) WithTenantSettings(ts TenantSettingsProvider) *Service {
 	s.tenantSettings = ts
@@ -756,6 +764,14 @@ func (s *Service) Proxy(ctx context.Context, sessionID string, req ProxyRequest)
 			continue
 		}
 
+		// Skip providers on the blocklist (global or the payer's denylist).
+		if s.paymentGate != nil {
+			if err := s.paymentGate.CheckPayment(ctx, agentAddr, candidate.AgentAddress); err != nil {
+				lastErr = err
+				continue
+			}
+		}
+
 		priceBig, ok := usdc.Parse(candidate.Price)
 		if !ok || priceBig.Sign() <= 0 {
 			continue
@@ -29,6 +29,7 @@ func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.GET("/intelligence/:address/credit", h.GetCreditScore)
 	r.GET("/intelligence/:address/risk", h.GetRiskScore)
 	r.GET("/intelligence/:address/trends", h.GetTrends)
+	r.GET("/credit/:address/score/history", h.GetCreditScoreHistory)
 }
 
 // GetProfile returns the full intelligence profile for an agent.
@@ -166,6 +167,73 @@ func (h *Handler) GetTrends(c *gin.Context) {
 	})
 }
 
+// GetCreditScoreHistory returns every persisted credit score recomputation
+// for an agent with the input features behind each score, so agents can see
+// why their limit changed and auditors can reproduce past decisions.
+// Query params: from, to (RFC3339), limit (default 100, max 500).
+func (h *Handler) GetCreditScoreHistory(c *gin.Context) {
+	address := strings.ToLower(c.Param("address"))
+
+	limit := 100
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-90 * 24 * time.Hour) // Default: last 90 days
+
+	if f := c.Query("from"); f != "" {
+		if parsed, err := time.Parse(time.RFC3339, f); err == nil {
+			from = parsed
+		}
+	}
+	if t := c.Query("to"); t != "" {
+		if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+			to = parsed
+		}
+	}
+
+	points, err := h.store.GetScoreHistory(c.Request.Context(), address, from, to, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to retrieve credit score history",
+		})
+		return
+	}
+
+	type creditPoint struct {
+		CreditScore   float64        `json:"creditScore"`
+		Tier          Tier           `json:"tier"`
+		CreditFactors *CreditFactors `json:"creditFactors,omitempty"`
+		ComputeRunID  string         `json:"computeRunId"`
+		CreatedAt     time.Time      `json:"createdAt"`
+	}
+	history := make([]creditPoint, 0, len(points))
+	for _, pt := range points {
+		history = append(history, creditPoint{
+			CreditScore:   pt.CreditScore,
+			Tier:          pt.Tier,
+			CreditFactors: pt.CreditFactors,
+			ComputeRunID:  pt.ComputeRunID,
+			CreatedAt:     pt.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address": address,
+		"from":    from,
+		"to":      to,
+		"history": history,
+		"count":   len(history),
+	})
+}
+
 // GetBenchmarks returns the latest network-wide benchmarks.
 func (h *Handler) GetBenchmarks(c *gin.Context) {
 	benchmarks, err := h.store.GetLatestBenchmarks(c.Request.Context())
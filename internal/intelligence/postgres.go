@@ -3,10 +3,19 @@ package intelligence
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"strings"
 	"time"
 )
 
+// nullableJSON converts an empty JSON buffer to SQL NULL.
+func nullableJSON(b []byte) interface{} {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
 // PostgresStore implements Store backed by PostgreSQL.
 type PostgresStore struct {
 	db *sql.DB
@@ -236,18 +245,22 @@ func (p *PostgresStore) SaveScoreHistory(ctx context.Context, points []*ScoreHis
 
 	stmt, err := tx.PrepareContext(ctx, `
 		INSERT INTO intelligence_score_history
-			(address, credit_score, risk_score, composite_score, tier, compute_run_id, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)`)
+			(address, credit_score, risk_score, composite_score, tier, credit_factors, compute_run_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = stmt.Close() }()
 
 	for _, pt := range points {
+		var factorsJSON []byte
+		if pt.CreditFactors != nil {
+			factorsJSON, _ = json.Marshal(pt.CreditFactors)
+		}
 		_, err := stmt.ExecContext(ctx,
 			strings.ToLower(pt.Address),
 			pt.CreditScore, pt.RiskScore, pt.CompositeScore,
-			string(pt.Tier), pt.ComputeRunID, pt.CreatedAt)
+			string(pt.Tier), nullableJSON(factorsJSON), pt.ComputeRunID, pt.CreatedAt)
 		if err != nil {
 			return err
 		}
@@ -262,7 +275,7 @@ func (p *PostgresStore) GetScoreHistory(ctx context.Context, address string, fro
 	}
 
 	const q = `
-		SELECT address, credit_score, risk_score, composite_score, tier, compute_run_id, created_at
+		SELECT address, credit_score, risk_score, composite_score, tier, credit_factors, compute_run_id, created_at
 		FROM intelligence_score_history
 		WHERE address = $1 AND created_at >= $2 AND created_at <= $3
 		ORDER BY created_at DESC
@@ -278,11 +291,18 @@ func (p *PostgresStore) GetScoreHistory(ctx context.Context, address string, fro
 	for rows.Next() {
 		pt := &ScoreHistoryPoint{}
 		var tier string
+		var factorsJSON []byte
 		if err := rows.Scan(&pt.Address, &pt.CreditScore, &pt.RiskScore,
-			&pt.CompositeScore, &tier, &pt.ComputeRunID, &pt.CreatedAt); err != nil {
+			&pt.CompositeScore, &tier, &factorsJSON, &pt.ComputeRunID, &pt.CreatedAt); err != nil {
 			return nil, err
 		}
 		pt.Tier = Tier(tier)
+		if len(factorsJSON) > 0 {
+			var factors CreditFactors
+			if err := json.Unmarshal(factorsJSON, &factors); err == nil {
+				pt.CreditFactors = &factors
+			}
+		}
 		result = append(result, pt)
 	}
 	return result, rows.Err()
@@ -112,12 +112,15 @@ type NetworkBenchmarks struct {
 }
 
 // ScoreHistoryPoint is a single point in the time-series of an agent's scores.
+// CreditFactors carries the inputs behind CreditScore so auditors can
+// reproduce the computation that produced a given limit decision.
 type ScoreHistoryPoint struct {
-	Address        string    `json:"address"`
-	CreditScore    float64   `json:"creditScore"`
-	RiskScore      float64   `json:"riskScore"`
-	CompositeScore float64   `json:"compositeScore"`
-	Tier           Tier      `json:"tier"`
-	ComputeRunID   string    `json:"computeRunId"`
-	CreatedAt      time.Time `json:"createdAt"`
+	Address        string         `json:"address"`
+	CreditScore    float64        `json:"creditScore"`
+	RiskScore      float64        `json:"riskScore"`
+	CompositeScore float64        `json:"compositeScore"`
+	Tier           Tier           `json:"tier"`
+	CreditFactors  *CreditFactors `json:"creditFactors,omitempty"`
+	ComputeRunID   string         `json:"computeRunId"`
+	CreatedAt      time.Time      `json:"createdAt"`
 }
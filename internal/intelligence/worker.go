@@ -125,12 +125,14 @@ func (w *Worker) compute(ctx context.Context) {
 	// Save score history snapshots
 	points := make([]*ScoreHistoryPoint, len(result.Profiles))
 	for i, p := range result.Profiles {
+		factors := p.Credit
 		points[i] = &ScoreHistoryPoint{
 			Address:        p.Address,
 			CreditScore:    p.CreditScore,
 			RiskScore:      p.RiskScore,
 			CompositeScore: p.CompositeScore,
 			Tier:           p.Tier,
+			CreditFactors:  &factors,
 			ComputeRunID:   runID,
 			CreatedAt:      p.ComputedAt,
 		}
@@ -36,12 +36,19 @@ type ReputationScorer interface {
 	GetScore(ctx context.Context, address string) (float64, string, error)
 }
 
+// CreditReviewQueue holds applications for manual admin review instead of
+// letting the scorer auto-approve them.
+type CreditReviewQueue interface {
+	Enqueue(ctx context.Context, agentAddr string, score float64, tier, proposedLimit, reason string) (string, error)
+}
+
 // Handler provides HTTP endpoints for ledger operations
 type Handler struct {
-	ledger     *Ledger
-	executor   WithdrawalExecutor // nil = withdrawals are pending only
-	reputation ReputationScorer   // nil = credit applications denied
-	logger     *slog.Logger
+	ledger      *Ledger
+	executor    WithdrawalExecutor // nil = withdrawals are pending only
+	reputation  ReputationScorer   // nil = credit applications denied
+	reviewQueue CreditReviewQueue  // nil = all approvals are automatic
+	logger      *slog.Logger
 }
 
 // NewHandler creates a new ledger handler
@@ -60,6 +67,12 @@ func (h *Handler) WithReputation(r ReputationScorer) *Handler {
 	return h
 }
 
+// WithReviewQueue diverts large or borderline applications to manual review.
+func (h *Handler) WithReviewQueue(q CreditReviewQueue) *Handler {
+	h.reviewQueue = q
+	return h
+}
+
 // RegisterRoutes sets up ledger routes
 func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.GET("/agents/:address/balance", h.GetBalance)
@@ -563,6 +576,37 @@ func (h *Handler) ApplyForCredit(c *gin.Context) {
 	limitBig := big.NewInt(limitMicro)
 	limitStr := usdc.Format(limitBig)
 
+	// Large limits and borderline scores go to manual review when a queue
+	// is configured; only clearly good applications auto-approve.
+	if h.reviewQueue != nil {
+		var reason string
+		switch {
+		case limitFloat > 50:
+			reason = "proposed limit above auto-approval threshold (50 USDC)"
+		case score < 60:
+			reason = "borderline reputation score"
+		}
+		if reason != "" {
+			appID, err := h.reviewQueue.Enqueue(c.Request.Context(), address, score, tier, limitStr, reason)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error":   "review_error",
+					"message": "Failed to queue application for review",
+				})
+				return
+			}
+			c.JSON(http.StatusAccepted, gin.H{
+				"status":        "pending_review",
+				"score":         score,
+				"tier":          tier,
+				"proposedLimit": limitStr,
+				"applicationId": appID,
+				"message":       "Application queued for manual review",
+			})
+			return
+		}
+	}
+
 	if err := h.ledger.StoreRef().SetCreditLimit(c.Request.Context(), address, limitStr); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "credit_error",
@@ -93,6 +93,8 @@ type Service interface {
 	PartialEscrowSettle(ctx context.Context, buyerAddr, sellerAddr, releaseAmount, refundAmount, reference string) error
 	Refund(ctx context.Context, agentAddr, amount, reference string) error
 	Withdraw(ctx context.Context, agentAddr, amount, txHash string) error
+	// Informational — records history without moving balances
+	RecordGasCost(ctx context.Context, agentAddr, amount, reference string) error
 	// Read-only
 	GetBalance(ctx context.Context, agentAddr string) (*Balance, error)
 	CanSpend(ctx context.Context, agentAddr, amount string) (bool, error)
@@ -113,6 +115,10 @@ type Store interface {
 	GetHistoryPage(ctx context.Context, agentAddr string, limit int, beforeTime time.Time, beforeID string) ([]*Entry, error)
 	HasDeposit(ctx context.Context, txHash string) (bool, error)
 
+	// RecordInfoEntry appends a history entry of the given type without
+	// touching balances (e.g. gas-cost attribution).
+	RecordInfoEntry(ctx context.Context, agentAddr, entryType, amount, reference, description string) error
+
 	// Two-phase hold operations for safe transaction execution.
 	// Hold moves funds from available → pending before on-chain transfer.
 	// ConfirmHold moves from pending → total_out after confirmation.
@@ -178,9 +184,27 @@ type Store interface {
 
 // Ledger manages agent balances
 type Ledger struct {
-	store       Store
-	eventStore  EventStore
-	auditLogger AuditLogger
+	store            Store
+	eventStore       EventStore
+	auditLogger      AuditLogger
+	earningsListener EarningsListener
+	activityListener ActivityListener
+}
+
+// EarningsListener is notified after an agent's available balance is credited
+// with revenue (hold settlements and escrow releases). Notification is
+// fire-and-forget on a separate goroutine; listeners must tolerate delivery
+// after the ledger transaction has committed.
+type EarningsListener interface {
+	OnEarnings(agentAddr, amount, reference string)
+}
+
+// ActivityListener is notified of every balance-affecting ledger event
+// (deposits, spends, transfers, holds, escrow movements), once per
+// affected agent. Like EarningsListener, notification is fire-and-forget
+// on a separate goroutine after the ledger transaction has committed.
+type ActivityListener interface {
+	OnLedgerEvent(agentAddr, eventType, amount, reference, counterparty string)
 }
 
 // New creates a new ledger
@@ -199,6 +223,24 @@ func (l *Ledger) WithAuditLogger(logger AuditLogger) *Ledger {
 	return l
 }
 
+// WithEarningsListener attaches a revenue listener to the ledger.
+func (l *Ledger) WithEarningsListener(el EarningsListener) *Ledger {
+	l.earningsListener = el
+	return l
+}
+
+// WithActivityListener attaches a balance-activity listener to the ledger.
+func (l *Ledger) WithActivityListener(al ActivityListener) *Ledger {
+	l.activityListener = al
+	return l
+}
+
+func (l *Ledger) notifyEarnings(agentAddr, amount, reference string) {
+	if l.earningsListener != nil {
+		go l.earningsListener.OnEarnings(agentAddr, amount, reference)
+	}
+}
+
 // EventStore returns the event store (may be nil).
 func (l *Ledger) EventStoreRef() EventStore {
 	return l.eventStore
@@ -210,6 +252,9 @@ func (l *Ledger) StoreRef() Store {
 }
 
 func (l *Ledger) appendEvent(ctx context.Context, agentAddr, eventType, amount, reference, counterparty string) {
+	if l.activityListener != nil {
+		go l.activityListener.OnLedgerEvent(agentAddr, eventType, amount, reference, counterparty)
+	}
 	if l.eventStore == nil {
 		return
 	}
@@ -384,6 +429,26 @@ func (l *Ledger) Withdraw(ctx context.Context, agentAddr, amount, txHash string)
 	return nil
 }
 
+// RecordGasCost attributes the USD-equivalent gas cost of a sponsored
+// transaction to the beneficiary agent. Zero-sum: the entry shows up in
+// history (so per-agent P&L includes true cost-to-serve) but no funds move.
+func (l *Ledger) RecordGasCost(ctx context.Context, agentAddr, amount, reference string) error {
+	amountBig, ok := usdc.Parse(amount)
+	if !ok || amountBig.Sign() <= 0 {
+		return ErrInvalidAmount
+	}
+
+	addr := strings.ToLower(agentAddr)
+	done := observeOp("gas_cost")
+	defer done()
+
+	if err := l.store.RecordInfoEntry(ctx, addr, "gas_cost", amount, reference, "sponsored_gas_cost"); err != nil {
+		return err
+	}
+	l.appendEvent(ctx, addr, "gas_cost", amount, reference, "")
+	return nil
+}
+
 // GetHistory returns ledger entries for an agent
 func (l *Ledger) GetHistory(ctx context.Context, agentAddr string, limit int) ([]*Entry, error) {
 	if limit <= 0 {
@@ -533,6 +598,7 @@ func (l *Ledger) SettleHold(ctx context.Context, buyerAddr, sellerAddr, amount,
 
 	l.appendEvent(ctx, buyer, "settle_hold_out", amount, reference, seller)
 	l.appendEvent(ctx, seller, "settle_hold_in", amount, reference, buyer)
+	l.notifyEarnings(seller, amount, reference)
 
 	buyerAfter, _ := l.store.GetBalance(ctx, buyer)
 	l.logAudit(ctx, buyer, "settle_hold", amount, reference, buyerBefore, buyerAfter)
@@ -573,6 +639,7 @@ func (l *Ledger) SettleHoldWithFee(ctx context.Context, buyerAddr, sellerAddr, s
 
 	l.appendEvent(ctx, buyer, "settle_hold_out", usdc.Format(totalBig), reference, seller)
 	l.appendEvent(ctx, seller, "settle_hold_in", sellerAmount, reference, buyer)
+	l.notifyEarnings(seller, sellerAmount, reference)
 	if feeBig.Sign() > 0 {
 		l.appendEvent(ctx, platform, "fee_in", feeAmount, reference, buyer)
 	}
@@ -609,6 +676,7 @@ func (l *Ledger) SettleHoldWithCallback(ctx context.Context, buyerAddr, sellerAd
 
 	l.appendEvent(ctx, buyer, "settle_hold_out", amount, reference, seller)
 	l.appendEvent(ctx, seller, "settle_hold_in", amount, reference, buyer)
+	l.notifyEarnings(seller, amount, reference)
 
 	buyerAfter, _ := l.store.GetBalance(ctx, buyer)
 	l.logAudit(ctx, buyer, "settle_hold", amount, reference, buyerBefore, buyerAfter)
@@ -650,6 +718,7 @@ func (l *Ledger) SettleHoldWithFeeAndCallback(ctx context.Context, buyerAddr, se
 
 	l.appendEvent(ctx, buyer, "settle_hold_out", usdc.Format(totalBig), reference, seller)
 	l.appendEvent(ctx, seller, "settle_hold_in", sellerAmount, reference, buyer)
+	l.notifyEarnings(seller, sellerAmount, reference)
 	if feeBig.Sign() > 0 {
 		l.appendEvent(ctx, platform, "fee_in", feeAmount, reference, buyer)
 	}
@@ -693,6 +762,9 @@ func (l *Ledger) PartialEscrowSettle(ctx context.Context, buyerAddr, sellerAddr,
 	l.appendEvent(ctx, buyer, "escrow_partial_release", releaseAmount, reference, seller)
 	l.appendEvent(ctx, buyer, "escrow_partial_refund", refundAmount, reference, "")
 	l.appendEvent(ctx, seller, "escrow_partial_receive", releaseAmount, reference, buyer)
+	if releaseBig.Sign() > 0 {
+		l.notifyEarnings(seller, releaseAmount, reference)
+	}
 
 	buyerAfter, _ := l.store.GetBalance(ctx, buyer)
 	l.logAudit(ctx, buyer, "partial_escrow_settle", releaseAmount, reference, buyerBefore, buyerAfter)
@@ -779,6 +851,7 @@ func (l *Ledger) ReleaseEscrow(ctx context.Context, buyerAddr, sellerAddr, amoun
 
 	l.appendEvent(ctx, buyer, "escrow_release", amount, reference, seller)
 	l.appendEvent(ctx, seller, "escrow_receive", amount, reference, buyer)
+	l.notifyEarnings(seller, amount, reference)
 
 	buyerAfter, _ := l.store.GetBalance(ctx, buyer)
 	l.logAudit(ctx, buyer, "escrow_release", amount, reference, buyerBefore, buyerAfter)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -3160,3 +3161,117 @@ func TestIsValidAmount_Coverage(t *testing.T) {
 		}
 	}
 }
+
+func TestLedger_RecordGasCost(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := New(store)
+	ctx := context.Background()
+
+	agent := "0x1234567890123456789012345678901234567890"
+	if err := ledger.Deposit(ctx, agent, "10.00", "0xdep_gas"); err != nil {
+		t.Fatalf("Deposit failed: %v", err)
+	}
+
+	if err := ledger.RecordGasCost(ctx, agent, "0.004200", "0xuserophash"); err != nil {
+		t.Fatalf("RecordGasCost failed: %v", err)
+	}
+
+	// Zero-sum: balance is untouched.
+	bal, err := ledger.GetBalance(ctx, agent)
+	if err != nil {
+		t.Fatalf("GetBalance failed: %v", err)
+	}
+	if bal.Available != "10.000000" {
+		t.Errorf("Expected available 10.000000 after gas cost entry, got %s", bal.Available)
+	}
+
+	// But the entry shows up in history.
+	history, err := ledger.GetHistory(ctx, agent, 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	found := false
+	for _, e := range history {
+		if e.Type == "gas_cost" && e.Amount == "0.004200" && e.Reference == "0xuserophash" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected gas_cost entry in history, got %+v", history)
+	}
+}
+
+func TestLedger_RecordGasCostInvalidAmount(t *testing.T) {
+	ledger := New(NewMemoryStore())
+	ctx := context.Background()
+
+	for _, amount := range []string{"", "abc", "0", "-1"} {
+		if err := ledger.RecordGasCost(ctx, "0xagent", amount, "ref"); err != ErrInvalidAmount {
+			t.Errorf("RecordGasCost(%q): err = %v, want ErrInvalidAmount", amount, err)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ActivityListener
+// ---------------------------------------------------------------------------
+
+type recordingActivityListener struct {
+	mu     sync.Mutex
+	events []string // "agent/type/amount"
+}
+
+func (r *recordingActivityListener) OnLedgerEvent(agentAddr, eventType, amount, reference, counterparty string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, agentAddr+"/"+eventType+"/"+amount)
+}
+
+func TestLedger_ActivityListener_NotifiedPerAgent(t *testing.T) {
+	listener := &recordingActivityListener{}
+	l := New(NewMemoryStore()).WithActivityListener(listener)
+	ctx := context.Background()
+
+	from := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	to := "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+	if err := l.Deposit(ctx, from, "20.00", "0xtx1"); err != nil {
+		t.Fatalf("Deposit failed: %v", err)
+	}
+	if err := l.Transfer(ctx, from, to, "7.00", "ref_1"); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+
+	// Notification is asynchronous; wait for all three events.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		listener.mu.Lock()
+		n := len(listener.events)
+		listener.mu.Unlock()
+		if n >= 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 3 activity events, got %d", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+	want := map[string]bool{
+		from + "/deposit/20.00":     false,
+		from + "/transfer_out/7.00": false,
+		to + "/transfer_in/7.00":    false,
+	}
+	for _, got := range listener.events {
+		if _, ok := want[got]; ok {
+			want[got] = true
+		}
+	}
+	for event, seen := range want {
+		if !seen {
+			t.Errorf("missing activity event %s (got %v)", event, listener.events)
+		}
+	}
+}
@@ -363,6 +363,22 @@ func (m *MemoryStore) GetHistoryPage(ctx context.Context, agentAddr string, limi
 	return result, nil
 }
 
+func (m *MemoryStore) RecordInfoEntry(ctx context.Context, agentAddr, entryType, amount, reference, description string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = append(m.entries, &Entry{
+		ID:          idgen.New(),
+		AgentAddr:   agentAddr,
+		Type:        entryType,
+		Amount:      amount,
+		Reference:   reference,
+		Description: description,
+		CreatedAt:   time.Now(),
+	})
+	return nil
+}
+
 func (m *MemoryStore) HasDeposit(ctx context.Context, txHash string) (bool, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -1076,6 +1076,18 @@ func (p *PostgresStore) GetHistoryPage(ctx context.Context, agentAddr string, li
 	return entries, rows.Err()
 }
 
+// RecordInfoEntry appends a history entry without touching balances.
+func (p *PostgresStore) RecordInfoEntry(ctx context.Context, agentAddr, entryType, amount, reference, description string) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO ledger_entries (id, agent_address, type, amount, reference, description, created_at)
+		VALUES ($1, $2, $3, $4::NUMERIC(20,6), $5, $6, NOW())
+	`, idgen.New(), agentAddr, entryType, amount, reference, description)
+	if err != nil {
+		return fmt.Errorf("failed to record entry: %w", err)
+	}
+	return nil
+}
+
 // HasDeposit checks if a deposit tx has already been processed
 func (p *PostgresStore) HasDeposit(ctx context.Context, txHash string) (bool, error) {
 	var count int
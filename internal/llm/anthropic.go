@@ -0,0 +1,121 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultAnthropicBaseURL = "https://api.anthropic.com"
+	defaultAnthropicModel   = "claude-3-5-haiku-latest"
+	anthropicVersion        = "2023-06-01"
+)
+
+// AnthropicProvider completes requests against the Anthropic messages API.
+type AnthropicProvider struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewAnthropicProvider creates an Anthropic provider. Empty model and
+// baseURL select the defaults.
+func NewAnthropicProvider(apiKey, model, baseURL string) *AnthropicProvider {
+	if model == "" {
+		model = defaultAnthropicModel
+	}
+	if baseURL == "" {
+		baseURL = defaultAnthropicBaseURL
+	}
+	return &AnthropicProvider{
+		apiKey:     apiKey,
+		model:      model,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name implements Provider.
+func (p *AnthropicProvider) Name() string { return "anthropic" }
+
+// Complete implements Provider.
+func (p *AnthropicProvider) Complete(ctx context.Context, req Request) (*Response, error) {
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokens
+	}
+
+	body := map[string]any{
+		"model":      p.model,
+		"max_tokens": maxTokens,
+		"messages": []map[string]string{
+			{"role": "user", "content": req.Prompt},
+		},
+	}
+	if req.System != "" {
+		body["system"] = req.System
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/messages", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("x-api-key", p.apiKey)
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("anthropic error (%d): %s", resp.StatusCode, truncateError(respBody))
+	}
+
+	var parsed struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	var text string
+	for _, block := range parsed.Content {
+		if block.Type == "text" {
+			text = block.Text
+			break
+		}
+	}
+	if text == "" {
+		return nil, fmt.Errorf("anthropic returned no completion")
+	}
+
+	return &Response{
+		Text:       text,
+		TokensUsed: parsed.Usage.InputTokens + parsed.Usage.OutputTokens,
+		Provider:   p.Name(),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package llm
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithBudget wraps a provider with a daily token budget. Once the budget
+// for the current UTC day is spent, requests carrying fallback text are
+// answered rule-based at zero cost; requests without one fail with
+// ErrBudgetExhausted. The counter resets at UTC midnight.
+func WithBudget(p Provider, dailyTokens int) Provider {
+	return &budgetProvider{inner: p, dailyTokens: dailyTokens}
+}
+
+type budgetProvider struct {
+	inner       Provider
+	dailyTokens int
+
+	mu   sync.Mutex
+	day  string // UTC date the counter applies to, e.g. "2026-08-27"
+	used int
+}
+
+// Name implements Provider.
+func (b *budgetProvider) Name() string { return b.inner.Name() }
+
+// Complete implements Provider, enforcing the daily budget.
+func (b *budgetProvider) Complete(ctx context.Context, req Request) (*Response, error) {
+	b.mu.Lock()
+	today := time.Now().UTC().Format("2006-01-02")
+	if b.day != today {
+		b.day = today
+		b.used = 0
+	}
+	exhausted := b.used >= b.dailyTokens
+	b.mu.Unlock()
+
+	if exhausted {
+		if req.Fallback != "" {
+			return &Response{Text: req.Fallback, Provider: "local"}, nil
+		}
+		return nil, ErrBudgetExhausted
+	}
+
+	resp, err := b.inner.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.used += resp.TokensUsed
+	b.mu.Unlock()
+
+	return resp, nil
+}
@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultCacheEntries bounds the in-memory response cache.
+const defaultCacheEntries = 1024
+
+// WithCache wraps a provider with an in-memory response cache keyed by
+// the prompt. Identical queries within the TTL are served without a
+// model call, which keeps repeated searches fast and free.
+func WithCache(p Provider, ttl time.Duration, maxEntries int) Provider {
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheEntries
+	}
+	return &cachedProvider{
+		inner:      p,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]cacheEntry),
+	}
+}
+
+type cacheEntry struct {
+	resp      Response
+	expiresAt time.Time
+}
+
+type cachedProvider struct {
+	inner      Provider
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+// Name implements Provider.
+func (c *cachedProvider) Name() string { return c.inner.Name() }
+
+// Complete implements Provider, consulting the cache first.
+func (c *cachedProvider) Complete(ctx context.Context, req Request) (*Response, error) {
+	key := cacheKey(req)
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		c.mu.Unlock()
+		resp := entry.resp
+		resp.Cached = true
+		return &resp, nil
+	}
+	c.mu.Unlock()
+
+	resp, err := c.inner.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if len(c.entries) >= c.maxEntries {
+		c.evictLocked()
+	}
+	c.entries[key] = cacheEntry{resp: *resp, expiresAt: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+// evictLocked drops expired entries, or an arbitrary entry if none have
+// expired, to make room for one more. Callers must hold c.mu.
+func (c *cachedProvider) evictLocked() {
+	now := time.Now()
+	evicted := false
+	for k, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, k)
+			evicted = true
+		}
+	}
+	if !evicted {
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+}
+
+// cacheKey hashes the request fields that affect the completion. The
+// fallback text is excluded: it does not change what the model sees.
+func cacheKey(req Request) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d", req.System, req.Prompt, req.MaxTokens)
+	return hex.EncodeToString(h.Sum(nil))
+}
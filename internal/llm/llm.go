@@ -0,0 +1,116 @@
+// Package llm provides a pluggable large-language-model backend for
+// natural-language features such as /v1/search recommendations.
+//
+// Providers are selected by configuration: "openai" and "anthropic" call
+// the hosted APIs, while "local" is a deterministic rule-based fallback
+// that needs no API key or network access. New wraps the selected
+// provider with response caching and a daily token budget when those are
+// configured, so hosted providers degrade gracefully instead of running
+// up a bill.
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Request is a single completion request.
+type Request struct {
+	// System is the system prompt framing the task.
+	System string
+
+	// Prompt is the user-visible prompt, including any context the
+	// caller wants the model to ground its answer in.
+	Prompt string
+
+	// Fallback is rule-based text the caller computed without a model.
+	// The local provider returns it verbatim, and the budget wrapper
+	// falls back to it when the daily token budget is exhausted.
+	Fallback string
+
+	// MaxTokens caps the completion length. Zero means the provider
+	// default.
+	MaxTokens int
+}
+
+// Response is a completion result.
+type Response struct {
+	Text       string // the completion text
+	TokensUsed int    // total tokens consumed (prompt + completion), 0 for rule-based
+	Provider   string // which provider produced the text ("openai", "anthropic", "local")
+	Cached     bool   // true when served from the response cache
+}
+
+// Provider produces completions. Implementations must be safe for
+// concurrent use.
+type Provider interface {
+	// Name identifies the provider in responses and logs.
+	Name() string
+
+	// Complete generates a completion for the request.
+	Complete(ctx context.Context, req Request) (*Response, error)
+}
+
+// ErrBudgetExhausted is returned when the daily token budget is spent
+// and the request carries no fallback text.
+var ErrBudgetExhausted = errors.New("llm: daily token budget exhausted")
+
+// Config selects and tunes a provider. Zero values mean the rule-based
+// local provider with no caching or budget.
+type Config struct {
+	Provider         string        // "local" (default), "openai", or "anthropic"
+	APIKey           string        // required for hosted providers
+	Model            string        // empty = provider default
+	BaseURL          string        // override for proxies and self-hosted gateways
+	CacheTTL         time.Duration // response cache TTL (0 = no caching)
+	DailyTokenBudget int           // max tokens per UTC day (0 = unlimited)
+}
+
+// New builds a provider from configuration, wrapping it with a token
+// budget and response cache when configured. Cache hits are checked
+// before the budget so repeated queries stay free.
+func New(cfg Config) (Provider, error) {
+	var p Provider
+	switch cfg.Provider {
+	case "", "local":
+		return LocalProvider{}, nil
+	case "openai":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("llm: APIKey is required for the openai provider")
+		}
+		p = NewOpenAIProvider(cfg.APIKey, cfg.Model, cfg.BaseURL)
+	case "anthropic":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("llm: APIKey is required for the anthropic provider")
+		}
+		p = NewAnthropicProvider(cfg.APIKey, cfg.Model, cfg.BaseURL)
+	default:
+		return nil, fmt.Errorf("llm: unknown provider %q (want \"local\", \"openai\", or \"anthropic\")", cfg.Provider)
+	}
+
+	if cfg.DailyTokenBudget > 0 {
+		p = WithBudget(p, cfg.DailyTokenBudget)
+	}
+	if cfg.CacheTTL > 0 {
+		p = WithCache(p, cfg.CacheTTL, defaultCacheEntries)
+	}
+	return p, nil
+}
+
+// LocalProvider is the rule-based fallback: it returns the request's
+// Fallback text without calling any model. It keeps natural-language
+// features working in deployments with no LLM configured.
+type LocalProvider struct{}
+
+// Name implements Provider.
+func (LocalProvider) Name() string { return "local" }
+
+// Complete implements Provider by returning the caller's rule-based text.
+func (LocalProvider) Complete(_ context.Context, req Request) (*Response, error) {
+	if req.Fallback == "" {
+		return nil, errors.New("llm: local provider requires fallback text")
+	}
+	return &Response{Text: req.Fallback, Provider: "local"}, nil
+}
@@ -0,0 +1,179 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider is a test double that counts Complete calls.
+type countingProvider struct {
+	calls  atomic.Int64
+	text   string
+	tokens int
+}
+
+func (p *countingProvider) Name() string { return "counting" }
+
+func (p *countingProvider) Complete(_ context.Context, _ Request) (*Response, error) {
+	p.calls.Add(1)
+	return &Response{Text: p.text, TokensUsed: p.tokens, Provider: p.Name()}, nil
+}
+
+func TestLocalProviderReturnsFallback(t *testing.T) {
+	resp, err := LocalProvider{}.Complete(context.Background(), Request{
+		Prompt:   "find me a translator",
+		Fallback: "Recommended: TranslateBot at 0.01 USDC/call.",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Recommended: TranslateBot at 0.01 USDC/call.", resp.Text)
+	assert.Equal(t, "local", resp.Provider)
+	assert.Zero(t, resp.TokensUsed)
+}
+
+func TestLocalProviderRequiresFallback(t *testing.T) {
+	_, err := LocalProvider{}.Complete(context.Background(), Request{Prompt: "anything"})
+	require.Error(t, err)
+}
+
+func TestNewSelectsProvider(t *testing.T) {
+	p, err := New(Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "local", p.Name())
+
+	p, err = New(Config{Provider: "openai", APIKey: "sk-test"})
+	require.NoError(t, err)
+	assert.Equal(t, "openai", p.Name())
+
+	p, err = New(Config{Provider: "anthropic", APIKey: "sk-ant-test"})
+	require.NoError(t, err)
+	assert.Equal(t, "anthropic", p.Name())
+
+	_, err = New(Config{Provider: "openai"})
+	require.Error(t, err, "hosted providers need an API key")
+
+	_, err = New(Config{Provider: "llama-in-a-box"})
+	require.Error(t, err)
+}
+
+func TestOpenAIProviderComplete(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "gpt-4o-mini", body["model"])
+		assert.Equal(t, float64(128), body["max_tokens"])
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": "Pick TranslateBot."}}},
+			"usage":   map[string]any{"total_tokens": 42},
+		})
+	}))
+	defer ts.Close()
+
+	p := NewOpenAIProvider("sk-test", "", ts.URL)
+	resp, err := p.Complete(context.Background(), Request{System: "sys", Prompt: "hi", MaxTokens: 128})
+	require.NoError(t, err)
+	assert.Equal(t, "Pick TranslateBot.", resp.Text)
+	assert.Equal(t, 42, resp.TokensUsed)
+	assert.Equal(t, "openai", resp.Provider)
+}
+
+func TestOpenAIProviderError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"message":"invalid key"}}`))
+	}))
+	defer ts.Close()
+
+	p := NewOpenAIProvider("sk-bad", "", ts.URL)
+	_, err := p.Complete(context.Background(), Request{Prompt: "hi"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestAnthropicProviderComplete(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "sk-ant-test", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "sys", body["system"])
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"content": []map[string]any{{"type": "text", "text": "Pick TranslateBot."}},
+			"usage":   map[string]any{"input_tokens": 30, "output_tokens": 12},
+		})
+	}))
+	defer ts.Close()
+
+	p := NewAnthropicProvider("sk-ant-test", "", ts.URL)
+	resp, err := p.Complete(context.Background(), Request{System: "sys", Prompt: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, "Pick TranslateBot.", resp.Text)
+	assert.Equal(t, 42, resp.TokensUsed)
+	assert.Equal(t, "anthropic", resp.Provider)
+}
+
+func TestCacheServesRepeatQueries(t *testing.T) {
+	inner := &countingProvider{text: "cached answer", tokens: 10}
+	p := WithCache(inner, time.Minute, 0)
+
+	req := Request{System: "sys", Prompt: "same question"}
+	first, err := p.Complete(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+
+	second, err := p.Complete(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, second.Cached)
+	assert.Equal(t, "cached answer", second.Text)
+	assert.Equal(t, int64(1), inner.calls.Load())
+
+	// A different prompt misses the cache.
+	_, err = p.Complete(context.Background(), Request{Prompt: "other question"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), inner.calls.Load())
+}
+
+func TestBudgetFallsBackWhenExhausted(t *testing.T) {
+	inner := &countingProvider{text: "model answer", tokens: 100}
+	p := WithBudget(inner, 150)
+
+	// First call fits the budget; second exceeds it and must fall back.
+	resp, err := p.Complete(context.Background(), Request{Prompt: "q1", Fallback: "rules"})
+	require.NoError(t, err)
+	assert.Equal(t, "model answer", resp.Text)
+
+	resp, err = p.Complete(context.Background(), Request{Prompt: "q2", Fallback: "rules"})
+	require.NoError(t, err)
+	assert.Equal(t, "model answer", resp.Text, "budget not yet exhausted at 100/150 tokens")
+
+	resp, err = p.Complete(context.Background(), Request{Prompt: "q3", Fallback: "rules"})
+	require.NoError(t, err)
+	assert.Equal(t, "rules", resp.Text)
+	assert.Equal(t, "local", resp.Provider)
+	assert.Equal(t, int64(2), inner.calls.Load())
+}
+
+func TestBudgetErrorsWithoutFallback(t *testing.T) {
+	inner := &countingProvider{text: "model answer", tokens: 100}
+	p := WithBudget(inner, 50)
+
+	_, err := p.Complete(context.Background(), Request{Prompt: "q1"})
+	require.NoError(t, err)
+
+	_, err = p.Complete(context.Background(), Request{Prompt: "q2"})
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+}
@@ -0,0 +1,126 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+)
+
+// OpenAIProvider completes requests against the OpenAI chat completions
+// API, or any OpenAI-compatible gateway via BaseURL.
+type OpenAIProvider struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOpenAIProvider creates an OpenAI provider. Empty model and baseURL
+// select the defaults.
+func NewOpenAIProvider(apiKey, model, baseURL string) *OpenAIProvider {
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+	if baseURL == "" {
+		baseURL = defaultOpenAIBaseURL
+	}
+	return &OpenAIProvider{
+		apiKey:     apiKey,
+		model:      model,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name implements Provider.
+func (p *OpenAIProvider) Name() string { return "openai" }
+
+// Complete implements Provider.
+func (p *OpenAIProvider) Complete(ctx context.Context, req Request) (*Response, error) {
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultMaxTokens
+	}
+
+	type message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+	body := map[string]any{
+		"model":      p.model,
+		"max_tokens": maxTokens,
+		"messages": []message{
+			{Role: "system", Content: req.System},
+			{Role: "user", Content: req.Prompt},
+		},
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("openai request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("openai error (%d): %s", resp.StatusCode, truncateError(respBody))
+	}
+
+	var parsed struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			TotalTokens int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if len(parsed.Choices) == 0 || parsed.Choices[0].Message.Content == "" {
+		return nil, fmt.Errorf("openai returned no completion")
+	}
+
+	return &Response{
+		Text:       parsed.Choices[0].Message.Content,
+		TokensUsed: parsed.Usage.TotalTokens,
+		Provider:   p.Name(),
+	}, nil
+}
+
+// defaultMaxTokens caps completions when the caller does not set a limit.
+const defaultMaxTokens = 512
+
+// truncateError keeps provider error bodies readable in wrapped errors.
+func truncateError(body []byte) string {
+	const max = 512
+	if len(body) > max {
+		return string(body[:max]) + "..."
+	}
+	return string(body)
+}
@@ -278,6 +278,146 @@ func (c *AlancoinClient) CancelOffer(ctx context.Context, offerID string) (json.
 	return c.Post(ctx, "/v1/offers/"+offerID+"/cancel", nil)
 }
 
+// --- Gateway sessions ---
+
+// CreateGatewaySession opens a budgeted payment-proxy session and returns
+// the session plus its token.
+func (c *AlancoinClient) CreateGatewaySession(ctx context.Context, maxTotal, maxPerRequest, strategy string, allowedTypes []string, expiresInSecs int) (json.RawMessage, error) {
+	body := map[string]any{
+		"maxTotal":      maxTotal,
+		"maxPerRequest": maxPerRequest,
+	}
+	if strategy != "" {
+		body["strategy"] = strategy
+	}
+	if len(allowedTypes) > 0 {
+		body["allowedTypes"] = allowedTypes
+	}
+	if expiresInSecs > 0 {
+		body["expiresInSecs"] = expiresInSecs
+	}
+	return c.Post(ctx, "/v1/gateway/sessions", body)
+}
+
+// GatewayProxy calls a service through the payment proxy using a session
+// token. Unlike doRequest this sends the X-Gateway-Token header and does
+// not retry: the gateway pays on our behalf, so a blind retry could
+// double-spend the session budget.
+func (c *AlancoinClient) GatewayProxy(ctx context.Context, token, serviceType string, params map[string]any, maxPrice string) (json.RawMessage, error) {
+	reqBody := map[string]any{"serviceType": serviceType}
+	if len(params) > 0 {
+		reqBody["params"] = params
+	}
+	if maxPrice != "" {
+		reqBody["maxPrice"] = maxPrice
+	}
+	data, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.APIURL+"/v1/gateway/proxy", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	req.Header.Set("X-Gateway-Token", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req) //nolint:gosec // URL constructed from trusted cfg.APIURL
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var apiErr apiError
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, apiErr.Message)
+		}
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+	}
+
+	return json.RawMessage(respBody), nil
+}
+
+// --- Stakes / Portfolio ---
+
+// ListStakeOfferings returns open revenue-share offerings.
+func (c *AlancoinClient) ListStakeOfferings(ctx context.Context, limit int) (json.RawMessage, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	return c.doRequest(ctx, http.MethodGet, "/v1/stakes/offerings", q, nil)
+}
+
+// BuyStakeShares buys shares in a revenue-share offering.
+func (c *AlancoinClient) BuyStakeShares(ctx context.Context, offeringID string, shares int) (json.RawMessage, error) {
+	body := map[string]int{"shares": shares}
+	return c.Post(ctx, "/v1/stakes/offerings/"+offeringID+"/buy", body)
+}
+
+// GetStakePortfolioPerformance returns the agent's stake positions with
+// performance metrics.
+func (c *AlancoinClient) GetStakePortfolioPerformance(ctx context.Context) (json.RawMessage, error) {
+	path := "/v1/stakes/portfolio/" + c.cfg.AgentAddress + "/performance"
+	return c.doRequest(ctx, http.MethodGet, path, nil, nil)
+}
+
+// RequestWithdrawal withdraws USDC from the agent's balance back to its
+// on-chain wallet.
+func (c *AlancoinClient) RequestWithdrawal(ctx context.Context, amount string) (json.RawMessage, error) {
+	path := "/v1/agents/" + c.cfg.AgentAddress + "/withdraw"
+	return c.Post(ctx, path, map[string]string{"amount": amount})
+}
+
+// --- Streaming payments ---
+
+// OpenStream opens a payment stream, holding the buyer's USDC up front.
+func (c *AlancoinClient) OpenStream(ctx context.Context, sellerAddr, holdAmount, pricePerTick, billingMode, serviceID string) (json.RawMessage, error) {
+	body := map[string]any{
+		"buyerAddr":    c.cfg.AgentAddress,
+		"sellerAddr":   sellerAddr,
+		"holdAmount":   holdAmount,
+		"pricePerTick": pricePerTick,
+	}
+	if billingMode != "" {
+		body["billingMode"] = billingMode
+	}
+	if serviceID != "" {
+		body["serviceId"] = serviceID
+	}
+	return c.Post(ctx, "/v1/streams", body)
+}
+
+// TickStream records a micropayment tick against an open stream.
+func (c *AlancoinClient) TickStream(ctx context.Context, streamID, amount, metadata string) (json.RawMessage, error) {
+	body := map[string]any{}
+	if amount != "" {
+		body["amount"] = amount
+	}
+	if metadata != "" {
+		body["metadata"] = metadata
+	}
+	return c.Post(ctx, "/v1/streams/"+streamID+"/tick", body)
+}
+
+// CloseStream settles a stream: spent funds go to the seller, the
+// unspent hold returns to the buyer.
+func (c *AlancoinClient) CloseStream(ctx context.Context, streamID, reason string) (json.RawMessage, error) {
+	body := map[string]any{}
+	if reason != "" {
+		body["reason"] = reason
+	}
+	return c.Post(ctx, "/v1/streams/"+streamID+"/close", body)
+}
+
 // DeliverClaim marks a claimed offer as delivered (seller action).
 func (c *AlancoinClient) DeliverClaim(ctx context.Context, claimID string) (json.RawMessage, error) {
 	return c.Post(ctx, "/v1/claims/"+claimID+"/deliver", nil)
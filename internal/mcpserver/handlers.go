@@ -57,6 +57,8 @@ func (h *Handlers) HandleCallService(ctx context.Context, req mcp.CallToolReques
 		}
 	}
 
+	progress := newProgressReporter(ctx, req)
+
 	// 1. Discover services
 	raw, err := h.client.DiscoverServices(ctx, serviceType, maxPrice, "", "")
 	if err != nil {
@@ -67,6 +69,7 @@ func (h *Handlers) HandleCallService(ctx context.Context, req mcp.CallToolReques
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	progress.report(1, 4, fmt.Sprintf("Selected %s (%s) at %s USDC", svc.Name, svc.Address, svc.Price))
 
 	// 2. Create escrow
 	escrowRaw, err := h.client.CreateEscrow(ctx, svc.Address, svc.Price, svc.ID)
@@ -78,6 +81,11 @@ func (h *Handlers) HandleCallService(ctx context.Context, req mcp.CallToolReques
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse escrow: %v", err)), nil
 	}
+	// Surface the escrow ID before the (possibly minutes-long) service
+	// call, so the user can dispute if it hangs.
+	progress.report(2, 4, fmt.Sprintf(
+		"Escrow %s created — %s USDC held. Calling the service; if this stalls, dispute_escrow with that ID recovers the funds.",
+		escrowID, svc.Price))
 
 	// 3. Call the service endpoint
 	result, err := h.client.CallEndpoint(ctx, svc.Endpoint, params, escrowID, svc.Price)
@@ -92,8 +100,11 @@ func (h *Handlers) HandleCallService(ctx context.Context, req mcp.CallToolReques
 			err, escrowID, svc.Price)), nil
 	}
 
+	progress.report(3, 4, "Service responded — confirming payment")
+
 	// 4. Auto-confirm escrow on success
 	_, confirmErr := h.client.ConfirmEscrow(ctx, escrowID)
+	progress.report(4, 4, "Done")
 
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "Service: %s (%s)\n", svc.Name, svc.Address)
@@ -833,3 +844,393 @@ func (h *Handlers) HandleCompleteClaim(ctx context.Context, req mcp.CallToolRequ
 		claimID,
 	)), nil
 }
+
+// --- Gateway Handlers ---
+
+// HandleCreateGatewaySession opens a budgeted proxy session and surfaces
+// the token the agent needs for gateway_call.
+func (h *Handlers) HandleCreateGatewaySession(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	maxTotal := req.GetString("max_total", "")
+	if maxTotal == "" {
+		return mcp.NewToolResultError("max_total is required"), nil
+	}
+	maxPerRequest := req.GetString("max_per_request", "")
+	if maxPerRequest == "" {
+		return mcp.NewToolResultError("max_per_request is required"), nil
+	}
+	strategy := req.GetString("strategy", "")
+	expiresInSecs := int(req.GetFloat("expires_in_secs", 0))
+
+	var allowedTypes []string
+	for _, t := range strings.Split(req.GetString("allowed_types", ""), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			allowedTypes = append(allowedTypes, t)
+		}
+	}
+
+	raw, err := h.client.CreateGatewaySession(ctx, maxTotal, maxPerRequest, strategy, allowedTypes, expiresInSecs)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create gateway session: %v", err)), nil
+	}
+
+	var resp struct {
+		Session map[string]any `json:"session"`
+		Token   string         `json:"token"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Gateway session opened!\n\n")
+	fmt.Fprintf(&sb, "Session token: %s\n", resp.Token)
+	fmt.Fprintf(&sb, "Budget: %s USDC held | Max per call: %s USDC\n",
+		getString(resp.Session, "maxTotal"), getString(resp.Session, "maxPerRequest"))
+	if s := getString(resp.Session, "strategy"); s != "" {
+		fmt.Fprintf(&sb, "Selection strategy: %s\n", s)
+	}
+	if len(allowedTypes) > 0 {
+		fmt.Fprintf(&sb, "Allowed service types: %s\n", strings.Join(allowedTypes, ", "))
+	}
+	if exp := getString(resp.Session, "expiresAt"); exp != "" {
+		fmt.Fprintf(&sb, "Expires: %s\n", exp)
+	}
+	sb.WriteString("\nUse gateway_call with this token to make budgeted service calls — " +
+		"no per-call escrow. Unused budget is released when the session expires.")
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// HandleGatewayCall makes a budgeted service call through the payment proxy.
+func (h *Handlers) HandleGatewayCall(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	token := req.GetString("session_token", "")
+	if token == "" {
+		return mcp.NewToolResultError("session_token is required (create one with create_gateway_session)"), nil
+	}
+	serviceType := req.GetString("service_type", "")
+	if serviceType == "" {
+		return mcp.NewToolResultError("service_type is required"), nil
+	}
+	maxPrice := req.GetString("max_price", "")
+
+	params := make(map[string]any)
+	if p, ok := req.GetArguments()["params"].(map[string]any); ok {
+		params = p
+	}
+
+	raw, err := h.client.GatewayProxy(ctx, token, serviceType, params, maxPrice)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Gateway call failed: %v", err)), nil
+	}
+
+	var resp struct {
+		Result     map[string]any `json:"result"`
+		TotalSpent string         `json:"totalSpent"`
+		Remaining  string         `json:"remaining"`
+		BudgetLow  bool           `json:"budgetLow"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Gateway call complete!\n\n")
+	fmt.Fprintf(&sb, "Service: %s (%s)\n", getString(resp.Result, "serviceName"), getString(resp.Result, "serviceUsed"))
+	fmt.Fprintf(&sb, "Paid: %s USDC | Session spent: %s USDC | Remaining: %s USDC\n",
+		getString(resp.Result, "amountPaid"), resp.TotalSpent, resp.Remaining)
+	if resp.BudgetLow {
+		sb.WriteString("Warning: session budget is running low. Open a new session before it is exhausted.\n")
+	}
+	if result, ok := resp.Result["response"]; ok && result != nil {
+		resultJSON, jsonErr := json.MarshalIndent(result, "", "  ")
+		if jsonErr == nil {
+			fmt.Fprintf(&sb, "\nService Response:\n%s", string(resultJSON))
+		}
+	}
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// --- Stakes / Portfolio Handlers ---
+
+// HandleBrowseStakeOfferings lists open revenue-share offerings.
+func (h *Handlers) HandleBrowseStakeOfferings(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	limit := int(req.GetFloat("limit", 20))
+
+	raw, err := h.client.ListStakeOfferings(ctx, limit)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list offerings: %v", err)), nil
+	}
+
+	var resp struct {
+		Offerings []map[string]any `json:"offerings"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	if len(resp.Offerings) == 0 {
+		return mcp.NewToolResultText("No stake offerings are currently open."), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Found %d stake offering(s):\n\n", len(resp.Offerings))
+	for i, o := range resp.Offerings {
+		total, _ := getFloat(o, "totalShares")
+		sold, _ := getFloat(o, "sharesSold")
+		bps, _ := getFloat(o, "revenueShareBps")
+		fmt.Fprintf(&sb, "%d. %s — agent %s\n", i+1, getString(o, "id"), getString(o, "agentAddr"))
+		fmt.Fprintf(&sb, "   Price: %s USDC/share | Available: %.0f of %.0f shares | Revenue share: %.2f%%\n",
+			getString(o, "pricePerShare"), total-sold, total, bps/100)
+		if desc := getString(o, "description"); desc != "" {
+			fmt.Fprintf(&sb, "   %s\n", desc)
+		}
+		fmt.Fprintf(&sb, "   Distributed to date: %s USDC | Status: %s\n",
+			getString(o, "totalDistributed"), getString(o, "status"))
+		if i < len(resp.Offerings)-1 {
+			sb.WriteString("\n")
+		}
+	}
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// HandleBuyStakeShares invests in a revenue-share offering.
+func (h *Handlers) HandleBuyStakeShares(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	offeringID := req.GetString("offering_id", "")
+	if offeringID == "" {
+		return mcp.NewToolResultError("offering_id is required"), nil
+	}
+	shares := int(req.GetFloat("shares", 0))
+	if shares <= 0 {
+		return mcp.NewToolResultError("shares must be a positive number"), nil
+	}
+
+	raw, err := h.client.BuyStakeShares(ctx, offeringID, shares)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Purchase failed: %v", err)), nil
+	}
+
+	var position map[string]any
+	if err := json.Unmarshal(raw, &position); err != nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"Stake purchased!\n\nPosition ID: %s\nOffering: %s\nShares: %d\nCost: %s USDC\n\n"+
+			"Your position earns a pro-rata cut of the agent's revenue distributions. "+
+			"Track it with get_stake_portfolio.",
+		getString(position, "id"), offeringID, shares, getString(position, "costBasis"),
+	)), nil
+}
+
+// HandleGetStakePortfolio reviews the agent's positions and returns.
+func (h *Handlers) HandleGetStakePortfolio(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	raw, err := h.client.GetStakePortfolioPerformance(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get portfolio: %v", err)), nil
+	}
+
+	var perf struct {
+		Positions      []map[string]any `json:"positions"`
+		Invested       string           `json:"invested"`
+		Distributed    string           `json:"distributed"`
+		MarkValue      string           `json:"markValue"`
+		TotalReturnBps float64          `json:"totalReturnBps"`
+	}
+	if err := json.Unmarshal(raw, &perf); err != nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	if len(perf.Positions) == 0 {
+		return mcp.NewToolResultText("No stake positions. Use browse_stake_offerings to find revenue shares to invest in."), nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Stake Portfolio:\n")
+	fmt.Fprintf(&sb, "  Invested: %s USDC | Distributions: %s USDC | Mark value: %s USDC\n",
+		perf.Invested, perf.Distributed, perf.MarkValue)
+	fmt.Fprintf(&sb, "  Total return: %.2f%%\n\n", perf.TotalReturnBps/100)
+
+	fmt.Fprintf(&sb, "%d position(s):\n", len(perf.Positions))
+	for i, p := range perf.Positions {
+		shares, _ := getFloat(p, "shares")
+		ret, _ := getFloat(p, "totalReturnBps")
+		fmt.Fprintf(&sb, "%d. %s — agent %s\n", i+1, getString(p, "positionId"), getString(p, "agentAddr"))
+		fmt.Fprintf(&sb, "   %.0f shares | Invested: %s USDC | Received: %s USDC | Return: %.2f%%\n",
+			shares, getString(p, "invested"), getString(p, "distributed"), ret/100)
+	}
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// --- Treasury Handlers ---
+
+// HandleGetDepositInstructions explains how to fund the agent's balance
+// and shows its current funding state.
+func (h *Handlers) HandleGetDepositInstructions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var sb strings.Builder
+	sb.WriteString("Deposit Instructions:\n")
+	sb.WriteString("  Currency: USDC\n")
+
+	if raw, err := h.client.GetNetworkStats(ctx); err == nil {
+		var resp struct {
+			Instructions map[string]any `json:"instructions"`
+		}
+		if json.Unmarshal(raw, &resp) == nil && resp.Instructions != nil {
+			if v := getString(resp.Instructions, "deposit"); v != "" {
+				fmt.Fprintf(&sb, "  Deposit: %s\n", v)
+			}
+		}
+	}
+	sb.WriteString("  Deposits are credited to your available balance once confirmed on-chain.\n")
+
+	raw, err := h.client.GetBalance(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to check balance: %v", err)), nil
+	}
+	balance, err := formatBalance(raw)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse balance: %v", err)), nil
+	}
+	sb.WriteString("\n")
+	sb.WriteString(balance)
+
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// HandleRequestWithdrawal withdraws USDC back to the agent's wallet.
+func (h *Handlers) HandleRequestWithdrawal(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	amount := req.GetString("amount", "")
+	if amount == "" {
+		return mcp.NewToolResultError("amount is required"), nil
+	}
+
+	raw, err := h.client.RequestWithdrawal(ctx, amount)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Withdrawal failed: %v", err)), nil
+	}
+
+	var resp map[string]any
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Withdrawal of %s USDC requested.\n", amount)
+	fmt.Fprintf(&sb, "  Status: %s\n", getString(resp, "status"))
+	if tx := getString(resp, "txHash"); tx != "" {
+		fmt.Fprintf(&sb, "  Transaction: %s\n", tx)
+	}
+	if note := getString(resp, "note"); note != "" {
+		fmt.Fprintf(&sb, "  %s\n", note)
+	}
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// --- Streaming Payment Handlers ---
+
+// HandleOpenStream opens a streaming micropayment channel.
+func (h *Handlers) HandleOpenStream(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	seller := req.GetString("seller", "")
+	if seller == "" {
+		return mcp.NewToolResultError("seller is required"), nil
+	}
+	holdAmount := req.GetString("hold_amount", "")
+	if holdAmount == "" {
+		return mcp.NewToolResultError("hold_amount is required"), nil
+	}
+	pricePerTick := req.GetString("price_per_tick", "")
+	if pricePerTick == "" {
+		return mcp.NewToolResultError("price_per_tick is required"), nil
+	}
+	billingMode := req.GetString("billing_mode", "")
+	serviceID := req.GetString("service_id", "")
+
+	raw, err := h.client.OpenStream(ctx, seller, holdAmount, pricePerTick, billingMode, serviceID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to open stream: %v", err)), nil
+	}
+
+	var resp struct {
+		Stream map[string]any `json:"stream"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil || resp.Stream == nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	unit := "tick"
+	switch billingMode {
+	case "per_second":
+		unit = "second"
+	case "per_minute":
+		unit = "minute"
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"Stream opened!\n\nStream ID: %s\nSeller: %s\nHold: %s USDC\nPrice: %s USDC per %s\n\n"+
+			"Your hold is spent as ticks arrive. Use tick_stream to pay per unit of work "+
+			"and close_stream to settle — unused funds return to your balance.",
+		getString(resp.Stream, "id"), seller, holdAmount, pricePerTick, unit,
+	)), nil
+}
+
+// HandleTickStream records a micropayment tick on an open stream.
+func (h *Handlers) HandleTickStream(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	streamID := req.GetString("stream_id", "")
+	if streamID == "" {
+		return mcp.NewToolResultError("stream_id is required"), nil
+	}
+	amount := req.GetString("amount", "")
+	metadata := req.GetString("metadata", "")
+
+	raw, err := h.client.TickStream(ctx, streamID, amount, metadata)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to record tick: %v", err)), nil
+	}
+
+	var resp struct {
+		Tick   map[string]any `json:"tick"`
+		Stream map[string]any `json:"stream"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil || resp.Tick == nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Tick recorded on stream %s.\n", streamID)
+	fmt.Fprintf(&sb, "  Tick #%s: %s USDC\n", getString(resp.Tick, "seq"), getString(resp.Tick, "amount"))
+	if resp.Stream != nil {
+		fmt.Fprintf(&sb, "  Spent: %s of %s USDC held\n",
+			getString(resp.Stream, "spentAmount"), getString(resp.Stream, "holdAmount"))
+	}
+	return mcp.NewToolResultText(sb.String()), nil
+}
+
+// HandleCloseStream closes and settles a payment stream.
+func (h *Handlers) HandleCloseStream(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	streamID := req.GetString("stream_id", "")
+	if streamID == "" {
+		return mcp.NewToolResultError("stream_id is required"), nil
+	}
+	reason := req.GetString("reason", "")
+
+	raw, err := h.client.CloseStream(ctx, streamID, reason)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to close stream: %v", err)), nil
+	}
+
+	var resp struct {
+		Stream map[string]any `json:"stream"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil || resp.Stream == nil {
+		return mcp.NewToolResultText(string(raw)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(
+		"Stream %s closed.\n"+
+			"  Paid to seller: %s USDC (%s ticks)\n"+
+			"  Unspent hold returned to your balance.\n"+
+			"  Status: %s",
+		streamID,
+		getString(resp.Stream, "spentAmount"),
+		getString(resp.Stream, "tickCount"),
+		getString(resp.Stream, "status"),
+	)), nil
+}
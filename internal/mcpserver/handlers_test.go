@@ -1667,3 +1667,462 @@ func TestHandleClaimOffer_MissingID(t *testing.T) {
 	text := resultText(t, result)
 	assert.Contains(t, text, "offer_id is required")
 }
+
+// ============================================================
+// Streaming payment tool tests
+// ============================================================
+
+func TestHandleOpenStream_Success(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/streams", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		assert.Equal(t, "0xBUYER", req["buyerAddr"])
+		assert.Equal(t, "0xSELLER", req["sellerAddr"])
+		assert.Equal(t, "5.00", req["holdAmount"])
+		assert.Equal(t, "0.01", req["pricePerTick"])
+		assert.Equal(t, "per_second", req["billingMode"])
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"stream":{"id":"stm_123","holdAmount":"5.00","pricePerTick":"0.01","status":"open"}}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleOpenStream(context.Background(), makeRequest(map[string]any{
+		"seller":         "0xSELLER",
+		"hold_amount":    "5.00",
+		"price_per_tick": "0.01",
+		"billing_mode":   "per_second",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "stm_123")
+	assert.Contains(t, text, "5.00 USDC")
+	assert.Contains(t, text, "per second")
+}
+
+func TestHandleOpenStream_MissingRequired(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not reach server")
+	}))
+	defer cleanup()
+
+	// Missing hold_amount
+	result, err := h.HandleOpenStream(context.Background(), makeRequest(map[string]any{
+		"seller":         "0xSELLER",
+		"price_per_tick": "0.01",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "hold_amount is required")
+}
+
+func TestHandleTickStream_Success(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/streams/stm_123/tick", r.URL.Path)
+		w.Write([]byte(`{
+			"tick":{"id":"tck_1","seq":3,"amount":"0.010000"},
+			"stream":{"id":"stm_123","spentAmount":"0.030000","holdAmount":"5.000000"}
+		}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleTickStream(context.Background(), makeRequest(map[string]any{
+		"stream_id": "stm_123",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "Tick #3")
+	assert.Contains(t, text, "0.030000 of 5.000000")
+}
+
+func TestHandleTickStream_HoldExhausted(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"error":"hold_exhausted","message":"tick would exceed held amount"}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleTickStream(context.Background(), makeRequest(map[string]any{
+		"stream_id": "stm_123",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "Failed to record tick")
+	assert.Contains(t, text, "exceed held amount")
+}
+
+func TestHandleCloseStream_Success(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/streams/stm_123/close", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		assert.Equal(t, "session complete", req["reason"])
+
+		w.Write([]byte(`{"stream":{"id":"stm_123","spentAmount":"0.030000","tickCount":3,"status":"closed"}}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleCloseStream(context.Background(), makeRequest(map[string]any{
+		"stream_id": "stm_123",
+		"reason":    "session complete",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "stm_123 closed")
+	assert.Contains(t, text, "0.030000 USDC")
+	assert.Contains(t, text, "closed")
+}
+
+func TestHandleCloseStream_MissingID(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not reach server")
+	}))
+	defer cleanup()
+
+	result, err := h.HandleCloseStream(context.Background(), makeRequest(map[string]any{}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "stream_id is required")
+}
+
+// ============================================================
+// Treasury tool tests
+// ============================================================
+
+func TestHandleGetDepositInstructions(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/platform":
+			w.Write([]byte(`{"platform":{"name":"Alancoin"},"instructions":{"deposit":"POST /v1/admin/deposits with admin auth"}}`))
+		case "/v1/agents/0xBUYER/balance":
+			w.Write([]byte(`{"balance":{"available":"2.500000","pending":"1.000000"}}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer cleanup()
+
+	result, err := h.HandleGetDepositInstructions(context.Background(), makeRequest(nil))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "Deposit Instructions")
+	assert.Contains(t, text, "POST /v1/admin/deposits")
+	assert.Contains(t, text, "2.500000")
+	assert.Contains(t, text, "Pending")
+}
+
+func TestHandleRequestWithdrawal_Completed(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/agents/0xBUYER/withdraw", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		assert.Equal(t, "10.00", req["amount"])
+
+		w.Write([]byte(`{"status":"completed","amount":"10.00","txHash":"0xabc123"}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleRequestWithdrawal(context.Background(), makeRequest(map[string]any{
+		"amount": "10.00",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "completed")
+	assert.Contains(t, text, "0xabc123")
+}
+
+func TestHandleRequestWithdrawal_Pending(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status":"pending","amount":"5.00","note":"Withdrawals are processed within 24 hours"}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleRequestWithdrawal(context.Background(), makeRequest(map[string]any{
+		"amount": "5.00",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "pending")
+	assert.Contains(t, text, "24 hours")
+}
+
+func TestHandleRequestWithdrawal_InsufficientBalance(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"insufficient_balance","message":"Insufficient balance for withdrawal"}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleRequestWithdrawal(context.Background(), makeRequest(map[string]any{
+		"amount": "9999.00",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "Withdrawal failed")
+	assert.Contains(t, text, "Insufficient balance")
+}
+
+func TestHandleRequestWithdrawal_MissingAmount(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not reach server")
+	}))
+	defer cleanup()
+
+	result, err := h.HandleRequestWithdrawal(context.Background(), makeRequest(map[string]any{}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "amount is required")
+}
+
+// ============================================================
+// Stakes / portfolio tool tests
+// ============================================================
+
+func TestHandleBrowseStakeOfferings(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/stakes/offerings", r.URL.Path)
+		w.Write([]byte(`{"offerings":[{
+			"id":"stk_1","agentAddr":"0xISSUER","pricePerShare":"1.000000",
+			"totalShares":100,"sharesSold":40,"revenueShareBps":1500,
+			"totalDistributed":"12.500000","status":"open","description":"Translation revenue"
+		}],"count":1}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleBrowseStakeOfferings(context.Background(), makeRequest(nil))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "stk_1")
+	assert.Contains(t, text, "60 of 100 shares")
+	assert.Contains(t, text, "15.00%")
+	assert.Contains(t, text, "Translation revenue")
+}
+
+func TestHandleBrowseStakeOfferings_Empty(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"offerings":[],"count":0}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleBrowseStakeOfferings(context.Background(), makeRequest(nil))
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "No stake offerings")
+}
+
+func TestHandleBuyStakeShares(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/stakes/offerings/stk_1/buy", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		assert.Equal(t, float64(5), req["shares"])
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"pos_1","offeringId":"stk_1","shares":5,"costBasis":"5.000000"}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleBuyStakeShares(context.Background(), makeRequest(map[string]any{
+		"offering_id": "stk_1",
+		"shares":      float64(5),
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "pos_1")
+	assert.Contains(t, text, "5.000000 USDC")
+}
+
+func TestHandleBuyStakeShares_InvalidShares(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not reach server")
+	}))
+	defer cleanup()
+
+	result, err := h.HandleBuyStakeShares(context.Background(), makeRequest(map[string]any{
+		"offering_id": "stk_1",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "shares must be a positive number")
+}
+
+func TestHandleGetStakePortfolio(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/stakes/portfolio/0xBUYER/performance", r.URL.Path)
+		w.Write([]byte(`{
+			"investorAddr":"0xBUYER",
+			"positions":[{
+				"positionId":"pos_1","offeringId":"stk_1","agentAddr":"0xISSUER",
+				"shares":5,"invested":"5.000000","distributed":"0.750000","totalReturnBps":1500
+			}],
+			"invested":"5.000000","distributed":"0.750000","markValue":"5.000000","totalReturnBps":1500
+		}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleGetStakePortfolio(context.Background(), makeRequest(nil))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "pos_1")
+	assert.Contains(t, text, "Total return: 15.00%")
+	assert.Contains(t, text, "0.750000 USDC")
+}
+
+func TestHandleGetStakePortfolio_Empty(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"investorAddr":"0xBUYER","positions":[],"invested":"0","distributed":"0","markValue":"0","totalReturnBps":0}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleGetStakePortfolio(context.Background(), makeRequest(nil))
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "No stake positions")
+}
+
+// ============================================================
+// Gateway tool tests
+// ============================================================
+
+func TestHandleCreateGatewaySession(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/gateway/sessions", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		assert.Equal(t, "5.00", req["maxTotal"])
+		assert.Equal(t, "0.10", req["maxPerRequest"])
+		assert.Equal(t, "reputation", req["strategy"])
+		assert.Equal(t, []any{"translation", "inference"}, req["allowedTypes"])
+		assert.Equal(t, float64(600), req["expiresInSecs"])
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"session":{
+			"id":"gws_1","agentAddr":"0xBUYER","maxTotal":"5.00","maxPerRequest":"0.10",
+			"strategy":"reputation","status":"active","expiresAt":"2026-01-01T00:10:00Z"
+		},"token":"gws_1"}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleCreateGatewaySession(context.Background(), makeRequest(map[string]any{
+		"max_total":       "5.00",
+		"max_per_request": "0.10",
+		"strategy":        "reputation",
+		"allowed_types":   "translation, inference",
+		"expires_in_secs": float64(600),
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "Session token: gws_1")
+	assert.Contains(t, text, "5.00 USDC held")
+	assert.Contains(t, text, "reputation")
+	assert.Contains(t, text, "translation, inference")
+	assert.Contains(t, text, "gateway_call")
+}
+
+func TestHandleCreateGatewaySession_MissingBudget(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not reach server")
+	}))
+	defer cleanup()
+
+	result, err := h.HandleCreateGatewaySession(context.Background(), makeRequest(map[string]any{
+		"max_total": "5.00",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "max_per_request is required")
+}
+
+func TestHandleGatewayCall(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/gateway/proxy", r.URL.Path)
+		assert.Equal(t, "gws_1", r.Header.Get("X-Gateway-Token"))
+		assert.Equal(t, "Bearer sk_test_key", r.Header.Get("Authorization"))
+
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		assert.Equal(t, "translation", req["serviceType"])
+		assert.Equal(t, "0.05", req["maxPrice"])
+		params, _ := req["params"].(map[string]any)
+		assert.Equal(t, "hola", params["text"])
+
+		w.Write([]byte(`{"result":{
+			"response":{"translated":"hello"},"serviceUsed":"0xSELLER","serviceName":"TranslateBot",
+			"amountPaid":"0.050000","totalSpent":"0.150000","remaining":"4.850000","latencyMs":42
+		},"totalSpent":"0.150000","remaining":"4.850000","budgetLow":false}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleGatewayCall(context.Background(), makeRequest(map[string]any{
+		"session_token": "gws_1",
+		"service_type":  "translation",
+		"params":        map[string]any{"text": "hola"},
+		"max_price":     "0.05",
+	}))
+	require.NoError(t, err)
+	text := resultText(t, result)
+	assert.Contains(t, text, "TranslateBot")
+	assert.Contains(t, text, "Paid: 0.050000 USDC")
+	assert.Contains(t, text, "Remaining: 4.850000 USDC")
+	assert.Contains(t, text, "hello")
+	assert.NotContains(t, text, "budget is running low")
+}
+
+func TestHandleGatewayCall_BudgetLow(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{
+			"response":{"ok":true},"serviceUsed":"0xSELLER","serviceName":"TranslateBot",
+			"amountPaid":"0.100000","totalSpent":"4.200000","remaining":"0.800000","budgetLow":true
+		},"totalSpent":"4.200000","remaining":"0.800000","budgetLow":true}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleGatewayCall(context.Background(), makeRequest(map[string]any{
+		"session_token": "gws_1",
+		"service_type":  "translation",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "budget is running low")
+}
+
+func TestHandleGatewayCall_BudgetExceeded(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"error":"budget_exceeded","message":"service price 0.20 exceeds remaining budget 0.10"}`))
+	}))
+	defer cleanup()
+
+	result, err := h.HandleGatewayCall(context.Background(), makeRequest(map[string]any{
+		"session_token": "gws_1",
+		"service_type":  "translation",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "exceeds remaining budget")
+}
+
+func TestHandleGatewayCall_MissingToken(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not reach server")
+	}))
+	defer cleanup()
+
+	result, err := h.HandleGatewayCall(context.Background(), makeRequest(map[string]any{
+		"service_type": "translation",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, resultText(t, result), "session_token is required")
+}
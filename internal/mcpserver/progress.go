@@ -0,0 +1,40 @@
+package mcpserver
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter streams notifications/progress updates during a
+// long-running tool call, so hosts can surface intermediate state (like
+// the escrow ID) while a slow service is still responding. Every report
+// is a best-effort no-op when the host didn't supply a progress token or
+// the handler runs outside a transport session.
+type progressReporter struct {
+	ctx   context.Context
+	srv   *server.MCPServer
+	token mcp.ProgressToken
+}
+
+func newProgressReporter(ctx context.Context, req mcp.CallToolRequest) *progressReporter {
+	p := &progressReporter{ctx: ctx, srv: server.ServerFromContext(ctx)}
+	if req.Params.Meta != nil {
+		p.token = req.Params.Meta.ProgressToken
+	}
+	return p
+}
+
+// report sends one progress update; progress counts toward total.
+func (p *progressReporter) report(progress, total float64, message string) {
+	if p.srv == nil || p.token == nil {
+		return
+	}
+	_ = p.srv.SendNotificationToClient(p.ctx, "notifications/progress", map[string]any{
+		"progressToken": p.token,
+		"progress":      progress,
+		"total":         total,
+		"message":       message,
+	})
+}
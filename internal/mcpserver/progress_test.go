@@ -0,0 +1,116 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession is a minimal ClientSession capturing notifications sent
+// to the client during a tool call.
+type fakeSession struct {
+	notifications chan mcp.JSONRPCNotification
+	initialized   bool
+}
+
+func newFakeSession() *fakeSession {
+	return &fakeSession{notifications: make(chan mcp.JSONRPCNotification, 16)}
+}
+
+func (s *fakeSession) Initialize()       { s.initialized = true }
+func (s *fakeSession) Initialized() bool { return s.initialized }
+func (s *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+func (s *fakeSession) SessionID() string { return "sess-test" }
+
+// TestHandleCallService_ProgressNotifications runs the tool through the
+// MCP request path with a progress token and asserts the escrow ID is
+// surfaced before the final update, so users can act on a hung call.
+func TestHandleCallService_ProgressNotifications(t *testing.T) {
+	serviceServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"result": "ok"})
+	}))
+	defer serviceServer.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/services", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"services": []map[string]any{{
+				"id": "svc-1", "name": "SlowBot", "address": "0xSELLER",
+				"type": "inference", "price": "0.100000", "endpoint": serviceServer.URL,
+			}},
+		})
+	})
+	mux.HandleFunc("/v1/escrow", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"escrow": map[string]any{"id": "esc-prog"}})
+	})
+	mux.HandleFunc("/v1/escrow/esc-prog/confirm", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"status": "released"})
+	})
+
+	h, cleanup := newTestSetup(mux)
+	defer cleanup()
+
+	srv := server.NewMCPServer("alancoin-test", "0.0.1")
+	srv.AddTool(ToolCallService, h.HandleCallService)
+
+	session := newFakeSession()
+	require.NoError(t, srv.RegisterSession(context.Background(), session))
+	session.Initialize()
+	ctx := srv.WithContext(context.Background(), session)
+
+	call := []byte(`{
+		"jsonrpc": "2.0",
+		"id": 1,
+		"method": "tools/call",
+		"params": {
+			"name": "call_service",
+			"arguments": {"service_type": "inference"},
+			"_meta": {"progressToken": "tok-1"}
+		}
+	}`)
+	resp := srv.HandleMessage(ctx, call)
+	respBytes, err := json.Marshal(resp)
+	require.NoError(t, err)
+	assert.NotContains(t, string(respBytes), `"isError":true`)
+
+	var messages []string
+	for len(session.notifications) > 0 {
+		n := <-session.notifications
+		require.Equal(t, "notifications/progress", n.Method)
+		assert.Equal(t, "tok-1", n.Params.AdditionalFields["progressToken"])
+		if msg, ok := n.Params.AdditionalFields["message"].(string); ok {
+			messages = append(messages, msg)
+		}
+	}
+
+	require.GreaterOrEqual(t, len(messages), 4)
+	assert.Contains(t, messages[0], "SlowBot")
+
+	// The escrow ID must appear before the final "Done" update.
+	var sawEscrow bool
+	for _, msg := range messages[:len(messages)-1] {
+		if strings.Contains(msg, "esc-prog") {
+			sawEscrow = true
+		}
+	}
+	assert.True(t, sawEscrow, "expected a progress message carrying the escrow ID, got %v", messages)
+}
+
+// TestProgressReporter_NoSessionIsNoop guards the direct-call path used
+// by the rest of the handler tests: without a transport session or
+// token, reporting must be silent and safe.
+func TestProgressReporter_NoSessionIsNoop(t *testing.T) {
+	req := makeRequest(nil)
+	p := newProgressReporter(context.Background(), req)
+	p.report(1, 4, "should not panic")
+}
@@ -0,0 +1,124 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MCP prompts encode the recommended tool sequences and guardrails for
+// common payment workflows, so host applications get consistent agent
+// behavior out of the box instead of each host rediscovering the safe
+// ordering of tool calls.
+
+var PromptSafePurchase = mcp.NewPrompt("safely_purchase_service",
+	mcp.WithPromptDescription("Guided workflow for buying an AI agent service with escrow protection: check funds, vet the seller, pay, verify, and dispute if needed."),
+	mcp.WithArgument("service_type",
+		mcp.ArgumentDescription("Type of service to purchase (e.g. 'translation', 'inference')"),
+		mcp.RequiredArgument()),
+	mcp.WithArgument("max_price",
+		mcp.ArgumentDescription("Maximum USDC you are willing to spend (e.g. '0.50')")),
+)
+
+var PromptDisputeDelivery = mcp.NewPrompt("dispute_bad_delivery",
+	mcp.WithPromptDescription("Guided workflow for disputing a service that delivered a bad or missing result, escalating to formal arbitration when warranted."),
+	mcp.WithArgument("escrow_id",
+		mcp.ArgumentDescription("The escrow ID from the failed purchase"),
+		mcp.RequiredArgument()),
+	mcp.WithArgument("problem",
+		mcp.ArgumentDescription("What went wrong with the delivery")),
+)
+
+var PromptEvaluateSeller = mcp.NewPrompt("evaluate_seller",
+	mcp.WithPromptDescription("Guided workflow for assessing whether an agent is trustworthy enough to transact with, combining reputation, identity, and marketplace signals."),
+	mcp.WithArgument("agent_address",
+		mcp.ArgumentDescription("The seller agent's address to evaluate (e.g. '0x1234...')"),
+		mcp.RequiredArgument()),
+)
+
+// handleSafePurchasePrompt renders the safe-purchase workflow.
+func handleSafePurchasePrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	serviceType := req.Params.Arguments["service_type"]
+	if serviceType == "" {
+		return nil, fmt.Errorf("service_type is required")
+	}
+	maxPrice := req.Params.Arguments["max_price"]
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "I want to purchase a '%s' service on Alancoin", serviceType)
+	if maxPrice != "" {
+		fmt.Fprintf(&sb, " for at most %s USDC", maxPrice)
+	}
+	sb.WriteString(". Follow this workflow:\n\n")
+	sb.WriteString("1. Call check_balance. If the available balance cannot cover the purchase, stop and call get_deposit_instructions instead of proceeding.\n")
+	fmt.Fprintf(&sb, "2. Call discover_services with service_type '%s'", serviceType)
+	if maxPrice != "" {
+		fmt.Fprintf(&sb, " and max_price '%s'", maxPrice)
+	}
+	sb.WriteString(" to list candidates.\n")
+	sb.WriteString("3. For the candidate you intend to use, call get_reputation. Prefer 'established' tier or better; treat 'new' sellers as higher risk and only use them for small amounts.\n")
+	sb.WriteString("4. Call call_service to pay and invoke the service in one step. Funds are held in escrow and auto-confirmed only on success.\n")
+	sb.WriteString("5. Verify the result actually satisfies the request before relying on it.\n")
+	sb.WriteString("6. If the call failed or the result is unusable, do NOT retry with more money — call dispute_escrow with the escrow ID and a specific reason.\n\n")
+	sb.WriteString("Guardrails: never exceed the stated budget, never pay the same seller twice for a failed delivery, and report the escrow ID in your summary so the user can follow up.")
+
+	return mcp.NewGetPromptResult(
+		"Safely purchase a service with escrow protection",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(sb.String())),
+		},
+	), nil
+}
+
+// handleDisputeDeliveryPrompt renders the dispute workflow.
+func handleDisputeDeliveryPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	escrowID := req.Params.Arguments["escrow_id"]
+	if escrowID == "" {
+		return nil, fmt.Errorf("escrow_id is required")
+	}
+	problem := req.Params.Arguments["problem"]
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "A service paid through escrow %s delivered a bad result", escrowID)
+	if problem != "" {
+		fmt.Fprintf(&sb, ": %s", problem)
+	}
+	sb.WriteString(". Follow this workflow:\n\n")
+	sb.WriteString("1. State plainly what was expected and what was actually delivered. A dispute needs a concrete, verifiable reason — not just dissatisfaction.\n")
+	fmt.Fprintf(&sb, "2. Call dispute_escrow with escrow_id '%s' and that reason. This refunds the escrowed USDC to your balance.\n", escrowID)
+	sb.WriteString("3. If the dispute is rejected, the amount is large, or the seller contests it, escalate with file_dispute to open a formal arbitration case (include the seller address and amount).\n")
+	sb.WriteString("4. Call get_reputation on the seller and mention their tier in your summary, so the user can decide whether to avoid them.\n\n")
+	sb.WriteString("Guardrails: dispute promptly (escrows auto-release after a timeout), never fabricate or exaggerate the reason, and do not purchase from the same seller again until the dispute resolves.")
+
+	return mcp.NewGetPromptResult(
+		"Dispute a bad service delivery",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(sb.String())),
+		},
+	), nil
+}
+
+// handleEvaluateSellerPrompt renders the seller-evaluation workflow.
+func handleEvaluateSellerPrompt(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	address := req.Params.Arguments["agent_address"]
+	if address == "" {
+		return nil, fmt.Errorf("agent_address is required")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Evaluate whether agent %s is trustworthy enough to transact with. Follow this workflow:\n\n", address)
+	fmt.Fprintf(&sb, "1. Call get_reputation with agent_address '%s' for their score, tier, success rate, and transaction count.\n", address)
+	fmt.Fprintf(&sb, "2. Call verify_agent with agent_address '%s' to check for a KYA identity certificate and organizational binding. No certificate is not disqualifying, but it raises the bar for large payments.\n", address)
+	sb.WriteString("3. Call browse_marketplace and note whether they have standing offers, their pricing, and remaining capacity.\n")
+	sb.WriteString("4. Summarize: trust tier, success rate, identity status, and a recommendation with a maximum amount you would risk with them.\n\n")
+	sb.WriteString("Guardrails: a high score with very few transactions is weak evidence — weight sample size. For 'new' or unverified agents, recommend starting with a small test purchase through escrow.")
+
+	return mcp.NewGetPromptResult(
+		"Evaluate a seller before transacting",
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(sb.String())),
+		},
+	), nil
+}
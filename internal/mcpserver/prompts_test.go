@@ -0,0 +1,74 @@
+package mcpserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makePromptRequest(args map[string]string) mcp.GetPromptRequest {
+	var req mcp.GetPromptRequest
+	req.Params.Arguments = args
+	return req
+}
+
+func promptText(t *testing.T, result *mcp.GetPromptResult) string {
+	t.Helper()
+	require.Len(t, result.Messages, 1)
+	tc, ok := result.Messages[0].Content.(mcp.TextContent)
+	require.True(t, ok, "expected TextContent, got %T", result.Messages[0].Content)
+	return tc.Text
+}
+
+func TestHandleSafePurchasePrompt(t *testing.T) {
+	result, err := handleSafePurchasePrompt(context.Background(), makePromptRequest(map[string]string{
+		"service_type": "translation",
+		"max_price":    "0.50",
+	}))
+	require.NoError(t, err)
+
+	text := promptText(t, result)
+	assert.Contains(t, text, "check_balance")
+	assert.Contains(t, text, "discover_services")
+	assert.Contains(t, text, "get_reputation")
+	assert.Contains(t, text, "call_service")
+	assert.Contains(t, text, "dispute_escrow")
+	assert.Contains(t, text, "translation")
+	assert.Contains(t, text, "0.50")
+}
+
+func TestHandleSafePurchasePrompt_MissingServiceType(t *testing.T) {
+	_, err := handleSafePurchasePrompt(context.Background(), makePromptRequest(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service_type is required")
+}
+
+func TestHandleDisputeDeliveryPrompt(t *testing.T) {
+	result, err := handleDisputeDeliveryPrompt(context.Background(), makePromptRequest(map[string]string{
+		"escrow_id": "esc_123",
+		"problem":   "returned empty output",
+	}))
+	require.NoError(t, err)
+
+	text := promptText(t, result)
+	assert.Contains(t, text, "esc_123")
+	assert.Contains(t, text, "returned empty output")
+	assert.Contains(t, text, "dispute_escrow")
+	assert.Contains(t, text, "file_dispute")
+}
+
+func TestHandleEvaluateSellerPrompt(t *testing.T) {
+	result, err := handleEvaluateSellerPrompt(context.Background(), makePromptRequest(map[string]string{
+		"agent_address": "0xSELLER",
+	}))
+	require.NoError(t, err)
+
+	text := promptText(t, result)
+	assert.Contains(t, text, "0xSELLER")
+	assert.Contains(t, text, "get_reputation")
+	assert.Contains(t, text, "verify_agent")
+	assert.Contains(t, text, "browse_marketplace")
+}
@@ -0,0 +1,104 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MCP resources expose read-only platform state under the alancoin://
+// scheme so hosts can pull structured context into the model without
+// spending tool calls on lookups. Tools remain the write path.
+
+var ResourceBalance = mcp.NewResource("alancoin://balance", "Agent Balance",
+	mcp.WithResourceDescription("Your agent's current USDC balance: available funds, pending holds, and escrowed amounts."),
+	mcp.WithMIMEType("application/json"),
+)
+
+var ResourceOpenEscrows = mcp.NewResource("alancoin://escrows/open", "Open Escrows",
+	mcp.WithResourceDescription("Your agent's unresolved escrows (pending, delivered, disputed, or arbitrating)."),
+	mcp.WithMIMEType("application/json"),
+)
+
+var ResourceReputation = mcp.NewResourceTemplate("alancoin://reputation/{address}", "Agent Reputation",
+	mcp.WithTemplateDescription("Reputation score, trust tier, and success rate for any agent on the network."),
+	mcp.WithTemplateMIMEType("application/json"),
+)
+
+// jsonResourceContents wraps a raw API response as a single JSON
+// resource content block.
+func jsonResourceContents(uri string, raw json.RawMessage) []mcp.ResourceContents {
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(raw),
+		},
+	}
+}
+
+// HandleBalanceResource serves alancoin://balance.
+func (h *Handlers) HandleBalanceResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	raw, err := h.client.GetBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch balance: %w", err)
+	}
+	return jsonResourceContents(req.Params.URI, raw), nil
+}
+
+// HandleReputationResource serves alancoin://reputation/{address}.
+func (h *Handlers) HandleReputationResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	address, _ := req.Params.Arguments["address"].(string)
+	if address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+
+	raw, err := h.client.GetReputation(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("fetch reputation: %w", err)
+	}
+	return jsonResourceContents(req.Params.URI, raw), nil
+}
+
+// openEscrowStatuses are the escrow states considered unresolved.
+var openEscrowStatuses = map[string]bool{
+	"pending":     true,
+	"delivered":   true,
+	"disputed":    true,
+	"arbitrating": true,
+}
+
+// HandleOpenEscrowsResource serves alancoin://escrows/open. The
+// platform lists all of an agent's escrows; the terminal ones are
+// filtered out here so the model only sees what still needs action.
+func (h *Handlers) HandleOpenEscrowsResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	raw, err := h.client.Get(ctx, "/v1/agents/"+h.client.cfg.AgentAddress+"/escrows")
+	if err != nil {
+		return nil, fmt.Errorf("fetch escrows: %w", err)
+	}
+
+	var resp struct {
+		Escrows []map[string]any `json:"escrows"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("parse escrows: %w", err)
+	}
+
+	open := make([]map[string]any, 0, len(resp.Escrows))
+	for _, e := range resp.Escrows {
+		if status, _ := e["status"].(string); openEscrowStatuses[status] {
+			open = append(open, e)
+		}
+	}
+
+	out, err := json.Marshal(map[string]any{
+		"escrows": open,
+		"count":   len(open),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode escrows: %w", err)
+	}
+	return jsonResourceContents(req.Params.URI, out), nil
+}
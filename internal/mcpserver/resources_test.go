@@ -0,0 +1,110 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeResourceRequest(uri string, args map[string]any) mcp.ReadResourceRequest {
+	var req mcp.ReadResourceRequest
+	req.Params.URI = uri
+	req.Params.Arguments = args
+	return req
+}
+
+func resourceText(t *testing.T, contents []mcp.ResourceContents) mcp.TextResourceContents {
+	t.Helper()
+	require.Len(t, contents, 1)
+	tc, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok, "expected TextResourceContents, got %T", contents[0])
+	return tc
+}
+
+func TestHandleBalanceResource(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/agents/0xBUYER/balance", r.URL.Path)
+		w.Write([]byte(`{"balance":{"available":"10.000000","pending":"1.000000"}}`))
+	}))
+	defer cleanup()
+
+	contents, err := h.HandleBalanceResource(context.Background(),
+		makeResourceRequest("alancoin://balance", nil))
+	require.NoError(t, err)
+
+	tc := resourceText(t, contents)
+	assert.Equal(t, "alancoin://balance", tc.URI)
+	assert.Equal(t, "application/json", tc.MIMEType)
+	assert.Contains(t, tc.Text, "10.000000")
+}
+
+func TestHandleReputationResource(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/reputation/0xOTHER", r.URL.Path)
+		w.Write([]byte(`{"address":"0xOTHER","score":87.5,"tier":"trusted"}`))
+	}))
+	defer cleanup()
+
+	contents, err := h.HandleReputationResource(context.Background(),
+		makeResourceRequest("alancoin://reputation/0xOTHER", map[string]any{"address": "0xOTHER"}))
+	require.NoError(t, err)
+
+	tc := resourceText(t, contents)
+	assert.Contains(t, tc.Text, "trusted")
+}
+
+func TestHandleReputationResource_MissingAddress(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("should not reach server")
+	}))
+	defer cleanup()
+
+	_, err := h.HandleReputationResource(context.Background(),
+		makeResourceRequest("alancoin://reputation/", nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "address is required")
+}
+
+func TestHandleOpenEscrowsResource_FiltersResolved(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/agents/0xBUYER/escrows", r.URL.Path)
+		w.Write([]byte(`{"escrows":[
+			{"id":"esc_1","status":"pending"},
+			{"id":"esc_2","status":"released"},
+			{"id":"esc_3","status":"disputed"},
+			{"id":"esc_4","status":"refunded"}
+		]}`))
+	}))
+	defer cleanup()
+
+	contents, err := h.HandleOpenEscrowsResource(context.Background(),
+		makeResourceRequest("alancoin://escrows/open", nil))
+	require.NoError(t, err)
+
+	tc := resourceText(t, contents)
+	var resp struct {
+		Escrows []map[string]any `json:"escrows"`
+		Count   int              `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(tc.Text), &resp))
+	assert.Equal(t, 2, resp.Count)
+	assert.Equal(t, "esc_1", resp.Escrows[0]["id"])
+	assert.Equal(t, "esc_3", resp.Escrows[1]["id"])
+}
+
+func TestHandleOpenEscrowsResource_APIError(t *testing.T) {
+	h, cleanup := newTestSetup(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"internal_error","message":"boom"}`))
+	}))
+	defer cleanup()
+
+	_, err := h.HandleOpenEscrowsResource(context.Background(),
+		makeResourceRequest("alancoin://escrows/open", nil))
+	require.Error(t, err)
+}
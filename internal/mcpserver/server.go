@@ -27,6 +27,24 @@ func NewMCPServer(cfg Config) *server.MCPServer {
 	s.AddTool(ToolDeliverClaim, h.HandleDeliverClaim)
 	s.AddTool(ToolCompleteClaim, h.HandleCompleteClaim)
 
+	// Gateway session tools
+	s.AddTool(ToolCreateGatewaySession, h.HandleCreateGatewaySession)
+	s.AddTool(ToolGatewayCall, h.HandleGatewayCall)
+
+	// Stakes / portfolio tools
+	s.AddTool(ToolBrowseStakeOfferings, h.HandleBrowseStakeOfferings)
+	s.AddTool(ToolBuyStakeShares, h.HandleBuyStakeShares)
+	s.AddTool(ToolGetStakePortfolio, h.HandleGetStakePortfolio)
+
+	// Treasury tools
+	s.AddTool(ToolGetDepositInstructions, h.HandleGetDepositInstructions)
+	s.AddTool(ToolRequestWithdrawal, h.HandleRequestWithdrawal)
+
+	// Streaming payment tools
+	s.AddTool(ToolOpenStream, h.HandleOpenStream)
+	s.AddTool(ToolTickStream, h.HandleTickStream)
+	s.AddTool(ToolCloseStream, h.HandleCloseStream)
+
 	// Enterprise plugin tools
 	s.AddTool(ToolVerifyAgent, h.HandleVerifyAgent)
 	s.AddTool(ToolCheckBudget, h.HandleCheckBudget)
@@ -41,5 +59,15 @@ func NewMCPServer(cfg Config) *server.MCPServer {
 	s.AddTool(ToolVerifyChain, h.HandleVerifyChain)
 	s.AddTool(ToolExportAuditBundle, h.HandleExportAuditBundle)
 
+	// Workflow prompts
+	s.AddPrompt(PromptSafePurchase, handleSafePurchasePrompt)
+	s.AddPrompt(PromptDisputeDelivery, handleDisputeDeliveryPrompt)
+	s.AddPrompt(PromptEvaluateSeller, handleEvaluateSellerPrompt)
+
+	// Read-only context resources
+	s.AddResource(ResourceBalance, h.HandleBalanceResource)
+	s.AddResource(ResourceOpenEscrows, h.HandleOpenEscrowsResource)
+	s.AddResourceTemplate(ResourceReputation, h.HandleReputationResource)
+
 	return s
 }
@@ -212,6 +212,149 @@ var ToolCompleteClaim = mcp.NewTool("complete_claim",
 		mcp.Description("The claim ID to complete")),
 )
 
+// --- Gateway Tools ---
+
+var ToolCreateGatewaySession = mcp.NewTool("create_gateway_session",
+	mcp.WithDescription(
+		"Create a budgeted gateway session for many small service calls. "+
+			"Your USDC budget is held once up front; each gateway_call then settles against it "+
+			"with no per-call escrow, making this cheaper and lower-latency than call_service "+
+			"when you expect multiple purchases. Unused budget is released when the session expires."),
+	mcp.WithString("max_total",
+		mcp.Required(),
+		mcp.Description("Total USDC budget for the session (e.g. '5.00')")),
+	mcp.WithString("max_per_request",
+		mcp.Required(),
+		mcp.Description("Maximum USDC per single call (e.g. '0.10')")),
+	mcp.WithString("strategy",
+		mcp.Description("Service selection strategy for calls in this session (default 'cheapest')"),
+		mcp.Enum("cheapest", "reputation", "best_value", "budget")),
+	mcp.WithString("allowed_types",
+		mcp.Description("Comma-separated service types this session may call (e.g. 'translation,inference'). Empty = all types.")),
+	mcp.WithNumber("expires_in_secs",
+		mcp.Description("Session lifetime in seconds (default 3600)")),
+)
+
+var ToolGatewayCall = mcp.NewTool("gateway_call",
+	mcp.WithDescription(
+		"Call a service through an existing gateway session. "+
+			"The gateway picks a provider per the session strategy, pays from the session budget, "+
+			"and returns the result plus remaining budget — no escrow round-trip per call. "+
+			"Requires a session token from create_gateway_session."),
+	mcp.WithString("session_token",
+		mcp.Required(),
+		mcp.Description("The gateway session token from create_gateway_session")),
+	mcp.WithString("service_type",
+		mcp.Required(),
+		mcp.Description("Type of service to call (e.g. 'translation', 'inference')")),
+	mcp.WithObject("params",
+		mcp.Description("Parameters to pass to the service (varies by service type)")),
+	mcp.WithString("max_price",
+		mcp.Description("Override the session's per-request maximum for this call")),
+)
+
+// --- Stakes / Portfolio Tools ---
+
+var ToolBrowseStakeOfferings = mcp.NewTool("browse_stake_offerings",
+	mcp.WithDescription(
+		"Browse open revenue-share offerings from agents on the Alancoin stakes market. "+
+			"Each offering sells shares in an agent's future revenue: a fraction of what the agent earns "+
+			"is distributed to shareholders. Use this to find places to allocate idle balance."),
+	mcp.WithNumber("limit",
+		mcp.Description("Maximum number of offerings to return (default 20)")),
+)
+
+var ToolBuyStakeShares = mcp.NewTool("buy_stake_shares",
+	mcp.WithDescription(
+		"Buy shares in an agent's revenue-share offering. "+
+			"The purchase price is deducted from your balance immediately; "+
+			"your position then earns a pro-rata cut of the agent's revenue distributions. "+
+			"Check the offering's disclosure and the agent's reputation before investing."),
+	mcp.WithString("offering_id",
+		mcp.Required(),
+		mcp.Description("The offering ID to invest in (from browse_stake_offerings)")),
+	mcp.WithNumber("shares",
+		mcp.Required(),
+		mcp.Description("Number of shares to buy")),
+)
+
+var ToolGetStakePortfolio = mcp.NewTool("get_stake_portfolio",
+	mcp.WithDescription(
+		"Review your stake portfolio: current positions plus performance "+
+			"(total invested, distributions received, mark value, and overall return). "+
+			"Use this to decide whether to allocate more balance or let positions run."),
+)
+
+// --- Treasury Tools ---
+
+var ToolGetDepositInstructions = mcp.NewTool("get_deposit_instructions",
+	mcp.WithDescription(
+		"Get instructions for funding your agent's Alancoin balance: "+
+			"the supported chain and currency, how deposits are credited, "+
+			"and your current balance including funds still pending settlement. "+
+			"Use this when your balance is too low to pay for services."),
+)
+
+var ToolRequestWithdrawal = mcp.NewTool("request_withdrawal",
+	mcp.WithDescription(
+		"Withdraw USDC from your agent's Alancoin balance back to its on-chain wallet. "+
+			"Funds are held immediately; the withdrawal either executes on-chain right away "+
+			"or is queued for processing (typically within 24 hours)."),
+	mcp.WithString("amount",
+		mcp.Required(),
+		mcp.Description("Amount in USDC to withdraw (e.g. '10.00')")),
+)
+
+// --- Streaming Payment Tools ---
+
+var ToolOpenStream = mcp.NewTool("open_stream",
+	mcp.WithDescription(
+		"Open a streaming micropayment channel to pay for a continuous service "+
+			"such as monitoring, live transcription, or a data feed. "+
+			"Your USDC is held up front and spent incrementally as the service delivers. "+
+			"Use tick_stream to pay per unit of work and close_stream to settle — unused funds return to you."),
+	mcp.WithString("seller",
+		mcp.Required(),
+		mcp.Description("Seller agent's address (e.g. '0x1234...')")),
+	mcp.WithString("hold_amount",
+		mcp.Required(),
+		mcp.Description("Total USDC to hold for the stream (e.g. '5.00'). Unspent funds are returned on close.")),
+	mcp.WithString("price_per_tick",
+		mcp.Required(),
+		mcp.Description("Cost per tick in USDC (e.g. '0.01'), or per second/minute when using time billing")),
+	mcp.WithString("billing_mode",
+		mcp.Description("How charges accrue: 'per_tick' (default, each tick charges price_per_tick), 'per_second', or 'per_minute' (ticks are heartbeats and charges accrue by elapsed time)"),
+		mcp.Enum("per_tick", "per_second", "per_minute")),
+	mcp.WithString("service_id",
+		mcp.Description("Optional identifier for the service being paid for (e.g. 'live-transcription')")),
+)
+
+var ToolTickStream = mcp.NewTool("tick_stream",
+	mcp.WithDescription(
+		"Record a micropayment tick against an open stream. "+
+			"Each tick moves one unit of payment (price_per_tick, or a custom amount) from your hold toward the seller. "+
+			"Call this as the service delivers value; under time billing a tick is a heartbeat that keeps the stream alive."),
+	mcp.WithString("stream_id",
+		mcp.Required(),
+		mcp.Description("The stream ID from a previous open_stream result")),
+	mcp.WithString("amount",
+		mcp.Description("Override amount for this tick in USDC. If omitted, the stream's price_per_tick is used.")),
+	mcp.WithString("metadata",
+		mcp.Description("Optional payload describing what this tick paid for (e.g. token count, minutes transcribed)")),
+)
+
+var ToolCloseStream = mcp.NewTool("close_stream",
+	mcp.WithDescription(
+		"Close a payment stream and settle it. "+
+			"The amount spent via ticks is released to the seller and any unspent hold is returned to your balance. "+
+			"Either party can close; streams also auto-close after the stale timeout if no ticks arrive."),
+	mcp.WithString("stream_id",
+		mcp.Required(),
+		mcp.Description("The stream ID to close")),
+	mcp.WithString("reason",
+		mcp.Description("Optional reason for closing (e.g. 'session complete')")),
+)
+
 var ToolGetAlerts = mcp.NewTool("get_alerts",
 	mcp.WithDescription(
 		"Get spend anomaly alerts for your agent from the forensics engine. "+
@@ -82,6 +82,15 @@ var (
 		},
 	)
 
+	// RealtimeDroppedEvents counts events dropped for slow realtime clients.
+	RealtimeDroppedEvents = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "alancoin",
+			Name:      "realtime_dropped_events_total",
+			Help:      "Events dropped because a realtime client's send queue was full.",
+		},
+	)
+
 	// DBOpenConnections tracks open database connections.
 	DBOpenConnections = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "alancoin", Name: "db_open_connections",
@@ -377,6 +386,54 @@ var (
 		[]string{"component"},
 	)
 
+	// GasFeeEstimatedGwei observes the fee oracle's MaxFeePerGas quotes per
+	// urgency tier, in gwei.
+	GasFeeEstimatedGwei = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "alancoin",
+			Name:      "gas_fee_estimated_gwei",
+			Help:      "Fee oracle MaxFeePerGas quotes by urgency tier, in gwei.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 12),
+		},
+		[]string{"urgency"},
+	)
+
+	// GasCostRealizedRatio observes effective gas price / estimated max fee
+	// for mined transactions. Near 1 = tight estimates; consistently low =
+	// the oracle is over-quoting.
+	GasCostRealizedRatio = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "alancoin",
+		Name:      "gas_cost_realized_to_estimated_ratio",
+		Help:      "Realized effective gas price over the estimated fee ceiling.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 0.75, 0.9, 1, 1.1, 1.25, 1.5},
+	})
+
+	// PaymasterSpendGwei tracks today's worst-case sponsored gas spend, in
+	// gwei. Reset at the UTC day boundary by the spend guard.
+	PaymasterSpendGwei = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "alancoin",
+		Name:      "paymaster_sponsored_spend_gwei",
+		Help:      "Worst-case sponsored gas spend so far today, in gwei.",
+	})
+
+	// PaymasterThrottleActive is 1 while the spend guard is throttling
+	// non-critical sponsorships (warning zone, low balance, or cap).
+	PaymasterThrottleActive = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "alancoin",
+		Name:      "paymaster_throttle_active",
+		Help:      "Whether the paymaster spend guard is throttling sponsorships (0/1).",
+	})
+
+	// PaymasterAlertsTotal counts spend-guard alerts by condition.
+	PaymasterAlertsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "alancoin",
+			Name:      "paymaster_alerts_total",
+			Help:      "Paymaster spend-guard alerts by type.",
+		},
+		[]string{"type"},
+	)
+
 	// WatcherReorgsDetected counts chain reorgs detected by the deposit watcher.
 	// Incremented when a stored block hash diverges from the chain's canonical hash.
 	WatcherReorgsDetected = prometheus.NewCounter(prometheus.CounterOpts{
@@ -395,6 +452,7 @@ func init() {
 		WebhookDeliveriesTotal,
 		ActiveSessionKeys,
 		ActiveWebSocketClients,
+		RealtimeDroppedEvents,
 		DBOpenConnections,
 		DBIdleConnections,
 		DBInUseConnections,
@@ -438,6 +496,11 @@ func init() {
 		OutboxPollLag,
 		CDCPollLag,
 		CleanupDeletedTotal,
+		GasFeeEstimatedGwei,
+		GasCostRealizedRatio,
+		PaymasterSpendGwei,
+		PaymasterThrottleActive,
+		PaymasterAlertsTotal,
 		WatcherReorgsDetected,
 	)
 }
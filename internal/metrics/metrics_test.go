@@ -288,6 +288,7 @@ func TestMetricRegistration_CountersAndGauges(t *testing.T) {
 		EventBusBatchesProcessed,
 		EventBusRetries,
 		EventBusDeadLettered,
+		RealtimeDroppedEvents,
 	}
 
 	for i, c := range counters {
@@ -358,6 +359,7 @@ func TestMetricIncrements_NoPanic(t *testing.T) {
 	EventBusBatchesProcessed.Inc()
 	EventBusRetries.Inc()
 	EventBusDeadLettered.Inc()
+	RealtimeDroppedEvents.Inc()
 
 	// Gauge set
 	ActiveSessionKeys.Set(5)
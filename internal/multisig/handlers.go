@@ -0,0 +1,134 @@
+package multisig
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func safeMessage(status int, err error, fallback string) string {
+	if status < 500 {
+		return err.Error()
+	}
+	return fallback
+}
+
+// Handler provides the admin-facing proposal/approval HTTP API.
+// Mount under a group with RequireAdmin — approver identity still comes
+// from the authenticated agent address, so admin access alone does not
+// grant approval rights.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new multisig handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes wires admin multisig routes on the given group.
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.POST("/admin/multisig/proposals", h.Propose)
+	r.GET("/admin/multisig/proposals", h.ListProposals)
+	r.GET("/admin/multisig/proposals/:id", h.GetProposal)
+	r.POST("/admin/multisig/proposals/:id/approve", h.Approve)
+	r.POST("/admin/multisig/proposals/:id/reject", h.Reject)
+	r.POST("/admin/multisig/proposals/:id/execute", h.Execute)
+}
+
+// Propose handles POST /v1/admin/multisig/proposals
+func (h *Handler) Propose(c *gin.Context) {
+	var req ProposeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "message": err.Error()})
+		return
+	}
+	req.ProposedBy = c.GetString("authAgentAddr")
+
+	p, err := h.service.Propose(c.Request.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "propose_failed"
+		if errors.Is(err, ErrInvalidProposal) {
+			status, code = http.StatusBadRequest, "invalid_proposal"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to create proposal")})
+		return
+	}
+	c.JSON(http.StatusCreated, p)
+}
+
+// ListProposals handles GET /v1/admin/multisig/proposals
+func (h *Handler) ListProposals(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	proposals, err := h.service.ListProposals(c.Request.Context(), ProposalStatus(c.Query("status")), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "list_failed", "message": "Failed to list proposals"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"proposals": proposals, "count": len(proposals)})
+}
+
+// GetProposal handles GET /v1/admin/multisig/proposals/:id
+func (h *Handler) GetProposal(c *gin.Context) {
+	p, err := h.service.GetProposal(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Proposal not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "get_failed", "message": "Failed to get proposal"})
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// Approve handles POST /v1/admin/multisig/proposals/:id/approve
+func (h *Handler) Approve(c *gin.Context) {
+	p, err := h.service.Approve(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	h.respond(c, p, err, "Failed to approve proposal")
+}
+
+// Reject handles POST /v1/admin/multisig/proposals/:id/reject
+func (h *Handler) Reject(c *gin.Context) {
+	p, err := h.service.Reject(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	h.respond(c, p, err, "Failed to reject proposal")
+}
+
+// Execute handles POST /v1/admin/multisig/proposals/:id/execute
+func (h *Handler) Execute(c *gin.Context) {
+	p, err := h.service.Execute(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	h.respond(c, p, err, "Failed to execute proposal")
+}
+
+// respond maps lifecycle errors onto HTTP statuses. A failed execution
+// still returns the proposal (status "failed") alongside the error body.
+func (h *Handler) respond(c *gin.Context, p *Proposal, err error, fallback string) {
+	if err == nil {
+		c.JSON(http.StatusOK, p)
+		return
+	}
+	status := http.StatusInternalServerError
+	code := "internal_error"
+	switch {
+	case errors.Is(err, ErrNotFound):
+		status, code = http.StatusNotFound, "not_found"
+	case errors.Is(err, ErrNotApprover):
+		status, code = http.StatusForbidden, "not_approver"
+	case errors.Is(err, ErrAlreadyApproved):
+		status, code = http.StatusConflict, "already_approved"
+	case errors.Is(err, ErrNotPending):
+		status, code = http.StatusConflict, "not_pending"
+	case errors.Is(err, ErrThresholdNotMet):
+		status, code = http.StatusConflict, "threshold_not_met"
+	case errors.Is(err, ErrNoExecutor):
+		status, code = http.StatusServiceUnavailable, "no_executor"
+	}
+	body := gin.H{"error": code, "message": safeMessage(status, err, fallback)}
+	if p != nil {
+		body["proposal"] = p
+	}
+	c.JSON(status, body)
+}
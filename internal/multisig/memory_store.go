@@ -0,0 +1,91 @@
+package multisig
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-memory proposal store for development and tests.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	proposals map[string]*Proposal
+}
+
+// NewMemoryStore creates an empty in-memory proposal store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{proposals: make(map[string]*Proposal)}
+}
+
+func copyProposal(p *Proposal) *Proposal {
+	c := *p
+	c.Approvals = append([]Approval(nil), p.Approvals...)
+	if p.ExecutedAt != nil {
+		t := *p.ExecutedAt
+		c.ExecutedAt = &t
+	}
+	return &c
+}
+
+func (m *MemoryStore) CreateProposal(_ context.Context, p *Proposal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.proposals[p.ID] = copyProposal(p)
+	return nil
+}
+
+func (m *MemoryStore) GetProposal(_ context.Context, id string) (*Proposal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.proposals[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyProposal(p), nil
+}
+
+func (m *MemoryStore) GetProposalByReference(_ context.Context, reference string) (*Proposal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, p := range m.proposals {
+		if p.Reference == reference {
+			return copyProposal(p), nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *MemoryStore) ListProposals(_ context.Context, status ProposalStatus, limit int) ([]*Proposal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []*Proposal
+	for _, p := range m.proposals {
+		if status != "" && p.Status != status {
+			continue
+		}
+		out = append(out, copyProposal(p))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *MemoryStore) UpdateProposal(_ context.Context, p *Proposal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.proposals[p.ID]; !ok {
+		return ErrNotFound
+	}
+	m.proposals[p.ID] = copyProposal(p)
+	return nil
+}
+
+func (m *MemoryStore) CountProposals(_ context.Context) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return int64(len(m.proposals)), nil
+}
+
+var _ Store = (*MemoryStore)(nil)
@@ -0,0 +1,400 @@
+// Package multisig routes high-value platform fund movements through a
+// Safe (Gnosis) multisig instead of the single hot wallet.
+//
+// Flow:
+//  1. Propose  — a withdrawal above the routing threshold (or an explicit
+//     treasury move) creates a pending proposal with its Safe transaction
+//     hash, so approvers can cross-check it against the Safe UI.
+//  2. Approve  — designated approvers sign off one by one. Each approver
+//     counts once; the proposer gets no implicit approval.
+//  3. Execute  — once the approval threshold is met, the configured
+//     executor submits the transfer. Nothing moves before threshold.
+//
+// Rejecting a pending proposal aborts it and, for routed withdrawals,
+// releases the agent's ledger hold.
+package multisig
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mbd888/alancoin/internal/idgen"
+)
+
+var (
+	ErrNotFound        = errors.New("multisig: proposal not found")
+	ErrNotApprover     = errors.New("multisig: caller is not a designated approver")
+	ErrAlreadyApproved = errors.New("multisig: caller already approved this proposal")
+	ErrNotPending      = errors.New("multisig: proposal is not pending")
+	ErrThresholdNotMet = errors.New("multisig: approval threshold not met")
+	ErrInvalidProposal = errors.New("multisig: invalid proposal")
+	ErrNoExecutor      = errors.New("multisig: no executor configured")
+)
+
+// ProposalKind distinguishes what the proposal moves.
+type ProposalKind string
+
+const (
+	// KindWithdrawal is an agent withdrawal routed here because it exceeds
+	// the configured threshold. Its ledger hold is already in place.
+	KindWithdrawal ProposalKind = "withdrawal"
+	// KindTreasury is an operator-initiated move of platform funds.
+	KindTreasury ProposalKind = "treasury"
+)
+
+// ProposalStatus is the lifecycle state of a proposal.
+type ProposalStatus string
+
+const (
+	StatusPending  ProposalStatus = "pending"
+	StatusExecuted ProposalStatus = "executed"
+	StatusRejected ProposalStatus = "rejected"
+	// StatusFailed means the threshold was met but execution errored.
+	// Approvals are retained; an approver may retry via Execute.
+	StatusFailed ProposalStatus = "failed"
+)
+
+// Approval records one approver's sign-off.
+type Approval struct {
+	Approver   string    `json:"approver"`
+	ApprovedAt time.Time `json:"approvedAt"`
+}
+
+// Proposal is one pending (or settled) fund movement.
+type Proposal struct {
+	ID          string         `json:"id"`
+	Kind        ProposalKind   `json:"kind"`
+	SafeAddress string         `json:"safeAddress"`
+	To          string         `json:"to"`
+	Amount      string         `json:"amount"` // decimal USDC string
+	Reference   string         `json:"reference"`
+	AgentAddr   string         `json:"agentAddr,omitempty"` // withdrawal proposals only
+	ProposedBy  string         `json:"proposedBy"`
+	Threshold   int            `json:"threshold"`
+	Approvals   []Approval     `json:"approvals"`
+	Status      ProposalStatus `json:"status"`
+	// SafeTxHash is the EIP-712 Safe transaction hash for the equivalent
+	// USDC transfer, computed so approvers can cross-check the proposal
+	// against what the Safe UI shows before signing there.
+	SafeTxHash string     `json:"safeTxHash,omitempty"`
+	TxHash     string     `json:"txHash,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	ExecutedAt *time.Time `json:"executedAt,omitempty"`
+}
+
+// approvedBy reports whether addr already approved.
+func (p *Proposal) approvedBy(addr string) bool {
+	for _, a := range p.Approvals {
+		if a.Approver == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Store persists proposals.
+type Store interface {
+	CreateProposal(ctx context.Context, p *Proposal) error
+	GetProposal(ctx context.Context, id string) (*Proposal, error)
+	GetProposalByReference(ctx context.Context, reference string) (*Proposal, error)
+	ListProposals(ctx context.Context, status ProposalStatus, limit int) ([]*Proposal, error)
+	UpdateProposal(ctx context.Context, p *Proposal) error
+	CountProposals(ctx context.Context) (int64, error)
+}
+
+// Executor submits an approved proposal's transfer and aborts rejected
+// ones. Implemented by the server, which dispatches on Kind: withdrawals
+// settle against their existing ledger hold, treasury moves go straight
+// to the payout service.
+type Executor interface {
+	Execute(ctx context.Context, p *Proposal) (txHash string, err error)
+	Abort(ctx context.Context, p *Proposal) error
+}
+
+// Config carries the Safe parameters and the approver set.
+type Config struct {
+	SafeAddress  string   // the Safe contract holding platform funds
+	USDCContract string   // token the Safe transfers
+	ChainID      int64    // chain the Safe lives on
+	Approvers    []string // addresses allowed to approve/reject
+	Threshold    int      // approvals required before execution
+}
+
+// Service manages the proposal/approval lifecycle.
+type Service struct {
+	store     Store
+	cfg       Config
+	approvers map[string]bool // lowercased cfg.Approvers
+	executor  Executor
+	logger    *slog.Logger
+	mu        sync.Mutex
+}
+
+// NewService creates a multisig service. The Safe address, a non-empty
+// approver set, and a threshold within it are required.
+func NewService(store Store, cfg Config) (*Service, error) {
+	if store == nil {
+		return nil, errors.New("multisig: store is required")
+	}
+	if !common.IsHexAddress(cfg.SafeAddress) {
+		return nil, errors.New("multisig: invalid safe address")
+	}
+	if len(cfg.Approvers) == 0 {
+		return nil, errors.New("multisig: at least one approver is required")
+	}
+	if cfg.Threshold <= 0 || cfg.Threshold > len(cfg.Approvers) {
+		return nil, errors.New("multisig: threshold must be between 1 and the number of approvers")
+	}
+	approvers := make(map[string]bool, len(cfg.Approvers))
+	for _, a := range cfg.Approvers {
+		if !common.IsHexAddress(a) {
+			return nil, errors.New("multisig: invalid approver address " + a)
+		}
+		approvers[strings.ToLower(a)] = true
+	}
+	cfg.SafeAddress = strings.ToLower(cfg.SafeAddress)
+	return &Service{
+		store:     store,
+		cfg:       cfg,
+		approvers: approvers,
+		logger:    slog.Default(),
+	}, nil
+}
+
+// WithLogger sets the logger.
+func (s *Service) WithLogger(logger *slog.Logger) *Service {
+	if logger != nil {
+		s.logger = logger
+	}
+	return s
+}
+
+// WithExecutor sets the executor that submits approved proposals.
+func (s *Service) WithExecutor(e Executor) *Service {
+	s.executor = e
+	return s
+}
+
+// ProposeRequest is the payload for creating a proposal.
+type ProposeRequest struct {
+	Kind       ProposalKind `json:"kind"`
+	To         string       `json:"to" binding:"required"`
+	Amount     string       `json:"amount" binding:"required"`
+	Reference  string       `json:"reference" binding:"required"`
+	AgentAddr  string       `json:"agentAddr,omitempty"`
+	ProposedBy string       `json:"-"`
+}
+
+// Propose records a pending fund movement. Reference is the idempotency
+// key — re-proposing the same reference returns the existing proposal.
+func (s *Service) Propose(ctx context.Context, req ProposeRequest) (*Proposal, error) {
+	if req.Kind == "" {
+		req.Kind = KindTreasury
+	}
+	if req.Kind != KindWithdrawal && req.Kind != KindTreasury {
+		return nil, ErrInvalidProposal
+	}
+	if !common.IsHexAddress(req.To) || req.Amount == "" || req.Reference == "" {
+		return nil, ErrInvalidProposal
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, err := s.store.GetProposalByReference(ctx, req.Reference); err == nil {
+		return existing, nil
+	}
+
+	// The proposal count doubles as the Safe nonce for the advisory hash.
+	// If operators execute transactions outside this service the hash may
+	// drift — it is a cross-check aid, not an on-chain commitment.
+	nonce, err := s.store.CountProposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	p := &Proposal{
+		ID:          idgen.WithPrefix("msp_"),
+		Kind:        req.Kind,
+		SafeAddress: s.cfg.SafeAddress,
+		To:          strings.ToLower(req.To),
+		Amount:      req.Amount,
+		Reference:   req.Reference,
+		AgentAddr:   strings.ToLower(req.AgentAddr),
+		ProposedBy:  strings.ToLower(req.ProposedBy),
+		Threshold:   s.cfg.Threshold,
+		Approvals:   []Approval{},
+		Status:      StatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if hash, ok := safeTransferHash(s.cfg, p.To, p.Amount, nonce); ok {
+		p.SafeTxHash = hash
+	}
+	if err := s.store.CreateProposal(ctx, p); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("multisig proposal created",
+		"proposal_id", p.ID,
+		"kind", p.Kind,
+		"to", p.To,
+		"amount", p.Amount,
+		"threshold", p.Threshold)
+	return p, nil
+}
+
+// GetProposal returns a proposal by ID.
+func (s *Service) GetProposal(ctx context.Context, id string) (*Proposal, error) {
+	return s.store.GetProposal(ctx, id)
+}
+
+// ListProposals returns proposals, optionally filtered by status.
+func (s *Service) ListProposals(ctx context.Context, status ProposalStatus, limit int) ([]*Proposal, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	return s.store.ListProposals(ctx, status, limit)
+}
+
+// Approve records an approver's sign-off and, once the threshold is met,
+// submits the transfer through the executor.
+func (s *Service) Approve(ctx context.Context, id, approver string) (*Proposal, error) {
+	approver = strings.ToLower(approver)
+	if !s.approvers[approver] {
+		return nil, ErrNotApprover
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.store.GetProposal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != StatusPending {
+		return nil, ErrNotPending
+	}
+	if p.approvedBy(approver) {
+		return nil, ErrAlreadyApproved
+	}
+
+	p.Approvals = append(p.Approvals, Approval{Approver: approver, ApprovedAt: time.Now()})
+	p.UpdatedAt = time.Now()
+	if err := s.store.UpdateProposal(ctx, p); err != nil {
+		return nil, err
+	}
+	s.logger.Info("multisig proposal approved",
+		"proposal_id", p.ID,
+		"approver", approver,
+		"approvals", len(p.Approvals),
+		"threshold", p.Threshold)
+
+	if len(p.Approvals) >= p.Threshold {
+		return s.execute(ctx, p)
+	}
+	return p, nil
+}
+
+// Reject aborts a pending proposal. For routed withdrawals the executor's
+// Abort releases the agent's ledger hold.
+func (s *Service) Reject(ctx context.Context, id, approver string) (*Proposal, error) {
+	approver = strings.ToLower(approver)
+	if !s.approvers[approver] {
+		return nil, ErrNotApprover
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.store.GetProposal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != StatusPending {
+		return nil, ErrNotPending
+	}
+
+	if s.executor != nil {
+		if err := s.executor.Abort(ctx, p); err != nil {
+			// The hold (if any) is now stuck — surface without changing state
+			// so the rejection can be retried after ops intervene.
+			s.logger.Error("multisig abort failed; proposal left pending",
+				"proposal_id", p.ID, "err", err)
+			return nil, err
+		}
+	}
+
+	p.Status = StatusRejected
+	p.UpdatedAt = time.Now()
+	if err := s.store.UpdateProposal(ctx, p); err != nil {
+		return nil, err
+	}
+	s.logger.Info("multisig proposal rejected", "proposal_id", p.ID, "approver", approver)
+	return p, nil
+}
+
+// Execute retries submission of a proposal that already met its threshold
+// but failed to execute (or had no executor configured at approval time).
+func (s *Service) Execute(ctx context.Context, id, caller string) (*Proposal, error) {
+	if !s.approvers[strings.ToLower(caller)] {
+		return nil, ErrNotApprover
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.store.GetProposal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != StatusPending && p.Status != StatusFailed {
+		return nil, ErrNotPending
+	}
+	if len(p.Approvals) < p.Threshold {
+		return nil, ErrThresholdNotMet
+	}
+	return s.execute(ctx, p)
+}
+
+// execute submits via the executor and records the outcome.
+// Caller must hold s.mu and have verified the threshold.
+func (s *Service) execute(ctx context.Context, p *Proposal) (*Proposal, error) {
+	if s.executor == nil {
+		return nil, ErrNoExecutor
+	}
+
+	txHash, err := s.executor.Execute(ctx, p)
+	p.UpdatedAt = time.Now()
+	if err != nil {
+		p.Status = StatusFailed
+		p.Error = err.Error()
+		if uerr := s.store.UpdateProposal(ctx, p); uerr != nil {
+			s.logger.Error("multisig: record failed execution", "proposal_id", p.ID, "err", uerr)
+		}
+		s.logger.Error("multisig execution failed", "proposal_id", p.ID, "err", err)
+		return p, err
+	}
+
+	now := time.Now()
+	p.Status = StatusExecuted
+	p.TxHash = txHash
+	p.Error = ""
+	p.ExecutedAt = &now
+	if err := s.store.UpdateProposal(ctx, p); err != nil {
+		// The transfer is on-chain; persisting must not undo it. Log loudly.
+		s.logger.Error("multisig: executed but update failed — manual reconcile required",
+			"proposal_id", p.ID, "tx", txHash, "err", err)
+		return p, err
+	}
+	s.logger.Info("multisig proposal executed", "proposal_id", p.ID, "tx", txHash)
+	return p, nil
+}
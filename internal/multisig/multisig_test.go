@@ -0,0 +1,319 @@
+package multisig
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	safeAddr     = "0xAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+	usdcAddr     = "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"
+	approverA    = "0x1111111111111111111111111111111111111111"
+	approverB    = "0x2222222222222222222222222222222222222222"
+	approverC    = "0x3333333333333333333333333333333333333333"
+	outsiderAddr = "0x4444444444444444444444444444444444444444"
+	recipient    = "0x5555555555555555555555555555555555555555"
+)
+
+// fakeExecutor records Execute/Abort calls and returns configured results.
+type fakeExecutor struct {
+	executed []string
+	aborted  []string
+	txHash   string
+	execErr  error
+	abortErr error
+}
+
+func (e *fakeExecutor) Execute(_ context.Context, p *Proposal) (string, error) {
+	if e.execErr != nil {
+		return "", e.execErr
+	}
+	e.executed = append(e.executed, p.ID)
+	return e.txHash, nil
+}
+
+func (e *fakeExecutor) Abort(_ context.Context, p *Proposal) error {
+	if e.abortErr != nil {
+		return e.abortErr
+	}
+	e.aborted = append(e.aborted, p.ID)
+	return nil
+}
+
+func testConfig() Config {
+	return Config{
+		SafeAddress:  safeAddr,
+		USDCContract: usdcAddr,
+		ChainID:      84532,
+		Approvers:    []string{approverA, approverB, approverC},
+		Threshold:    2,
+	}
+}
+
+func newTestService(t *testing.T) (*Service, *fakeExecutor) {
+	t.Helper()
+	svc, err := NewService(NewMemoryStore(), testConfig())
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	exec := &fakeExecutor{txHash: "0xdeadbeef"}
+	return svc.WithExecutor(exec), exec
+}
+
+func propose(t *testing.T, svc *Service, ref string) *Proposal {
+	t.Helper()
+	p, err := svc.Propose(context.Background(), ProposeRequest{
+		Kind:       KindWithdrawal,
+		To:         recipient,
+		Amount:     "500.000000",
+		Reference:  ref,
+		AgentAddr:  outsiderAddr,
+		ProposedBy: outsiderAddr,
+	})
+	if err != nil {
+		t.Fatalf("Propose: %v", err)
+	}
+	return p
+}
+
+func TestNewService_Validation(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(*Config)
+	}{
+		{"bad safe address", func(c *Config) { c.SafeAddress = "not-an-address" }},
+		{"no approvers", func(c *Config) { c.Approvers = nil }},
+		{"threshold zero", func(c *Config) { c.Threshold = 0 }},
+		{"threshold above approvers", func(c *Config) { c.Threshold = 4 }},
+		{"bad approver address", func(c *Config) { c.Approvers = []string{"bogus"} }},
+	}
+	for _, tc := range cases {
+		cfg := testConfig()
+		tc.mutate(&cfg)
+		if _, err := NewService(NewMemoryStore(), cfg); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+func TestPropose_SetsHashAndIsIdempotent(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	p := propose(t, svc, "wd-1")
+	if p.Status != StatusPending || p.Threshold != 2 {
+		t.Errorf("got status=%q threshold=%d", p.Status, p.Threshold)
+	}
+	if len(p.SafeTxHash) != 66 || !strings.HasPrefix(p.SafeTxHash, "0x") {
+		t.Errorf("SafeTxHash = %q, want 32-byte hex hash", p.SafeTxHash)
+	}
+
+	// Same reference returns the existing proposal, not a duplicate.
+	again := propose(t, svc, "wd-1")
+	if again.ID != p.ID {
+		t.Errorf("re-propose created new proposal %s, want %s", again.ID, p.ID)
+	}
+}
+
+func TestPropose_Validation(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+
+	bad := []ProposeRequest{
+		{Kind: "escrow", To: recipient, Amount: "1", Reference: "r"},
+		{Kind: KindTreasury, To: "nope", Amount: "1", Reference: "r"},
+		{Kind: KindTreasury, To: recipient, Amount: "", Reference: "r"},
+		{Kind: KindTreasury, To: recipient, Amount: "1", Reference: ""},
+	}
+	for i, req := range bad {
+		if _, err := svc.Propose(ctx, req); !errors.Is(err, ErrInvalidProposal) {
+			t.Errorf("case %d: err = %v, want ErrInvalidProposal", i, err)
+		}
+	}
+}
+
+func TestApprove_ExecutesAtThreshold(t *testing.T) {
+	svc, exec := newTestService(t)
+	ctx := context.Background()
+	p := propose(t, svc, "wd-2")
+
+	first, err := svc.Approve(ctx, p.ID, approverA)
+	if err != nil {
+		t.Fatalf("first approve: %v", err)
+	}
+	if first.Status != StatusPending || len(first.Approvals) != 1 {
+		t.Errorf("after one approval: status=%q approvals=%d", first.Status, len(first.Approvals))
+	}
+	if len(exec.executed) != 0 {
+		t.Fatal("executed before threshold")
+	}
+
+	second, err := svc.Approve(ctx, p.ID, approverB)
+	if err != nil {
+		t.Fatalf("second approve: %v", err)
+	}
+	if second.Status != StatusExecuted || second.TxHash != "0xdeadbeef" {
+		t.Errorf("after threshold: status=%q tx=%q", second.Status, second.TxHash)
+	}
+	if second.ExecutedAt == nil {
+		t.Error("ExecutedAt not set")
+	}
+	if len(exec.executed) != 1 {
+		t.Errorf("executor calls = %d, want 1", len(exec.executed))
+	}
+}
+
+func TestApprove_Denials(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+	p := propose(t, svc, "wd-3")
+
+	if _, err := svc.Approve(ctx, p.ID, outsiderAddr); !errors.Is(err, ErrNotApprover) {
+		t.Errorf("outsider approve: err = %v, want ErrNotApprover", err)
+	}
+	if _, err := svc.Approve(ctx, "msp_missing", approverA); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing proposal: err = %v, want ErrNotFound", err)
+	}
+	if _, err := svc.Approve(ctx, p.ID, approverA); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if _, err := svc.Approve(ctx, p.ID, approverA); !errors.Is(err, ErrAlreadyApproved) {
+		t.Errorf("double approve: err = %v, want ErrAlreadyApproved", err)
+	}
+}
+
+func TestReject_AbortsPendingProposal(t *testing.T) {
+	svc, exec := newTestService(t)
+	ctx := context.Background()
+	p := propose(t, svc, "wd-4")
+
+	rejected, err := svc.Reject(ctx, p.ID, approverC)
+	if err != nil {
+		t.Fatalf("Reject: %v", err)
+	}
+	if rejected.Status != StatusRejected {
+		t.Errorf("status = %q, want rejected", rejected.Status)
+	}
+	if len(exec.aborted) != 1 {
+		t.Errorf("abort calls = %d, want 1", len(exec.aborted))
+	}
+
+	// Settled proposals cannot be re-rejected.
+	if _, err := svc.Reject(ctx, p.ID, approverA); !errors.Is(err, ErrNotPending) {
+		t.Errorf("re-reject: err = %v, want ErrNotPending", err)
+	}
+}
+
+func TestReject_AbortFailureLeavesPending(t *testing.T) {
+	svc, exec := newTestService(t)
+	ctx := context.Background()
+	p := propose(t, svc, "wd-5")
+
+	exec.abortErr = errors.New("hold release failed")
+	if _, err := svc.Reject(ctx, p.ID, approverA); err == nil {
+		t.Fatal("expected error when abort fails")
+	}
+	got, err := svc.GetProposal(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("GetProposal: %v", err)
+	}
+	if got.Status != StatusPending {
+		t.Errorf("status = %q, want pending so the rejection can be retried", got.Status)
+	}
+}
+
+func TestExecute_RetriesFailedSubmission(t *testing.T) {
+	svc, exec := newTestService(t)
+	ctx := context.Background()
+	p := propose(t, svc, "wd-6")
+
+	exec.execErr = errors.New("rpc down")
+	if _, err := svc.Approve(ctx, p.ID, approverA); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	failed, err := svc.Approve(ctx, p.ID, approverB)
+	if err == nil {
+		t.Fatal("expected execution error at threshold")
+	}
+	if failed.Status != StatusFailed {
+		t.Errorf("status = %q, want failed", failed.Status)
+	}
+
+	// Approvals are retained; a manual execute retries the submission.
+	exec.execErr = nil
+	retried, err := svc.Execute(ctx, p.ID, approverA)
+	if err != nil {
+		t.Fatalf("Execute retry: %v", err)
+	}
+	if retried.Status != StatusExecuted || retried.TxHash != "0xdeadbeef" {
+		t.Errorf("after retry: status=%q tx=%q", retried.Status, retried.TxHash)
+	}
+}
+
+func TestExecute_RequiresThreshold(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+	p := propose(t, svc, "wd-7")
+
+	if _, err := svc.Execute(ctx, p.ID, approverA); !errors.Is(err, ErrThresholdNotMet) {
+		t.Errorf("err = %v, want ErrThresholdNotMet", err)
+	}
+	if _, err := svc.Execute(ctx, p.ID, outsiderAddr); !errors.Is(err, ErrNotApprover) {
+		t.Errorf("err = %v, want ErrNotApprover", err)
+	}
+}
+
+func TestListProposals_FiltersByStatus(t *testing.T) {
+	svc, _ := newTestService(t)
+	ctx := context.Background()
+	propose(t, svc, "wd-8")
+	p := propose(t, svc, "wd-9")
+	if _, err := svc.Reject(ctx, p.ID, approverA); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+
+	pending, err := svc.ListProposals(ctx, StatusPending, 0)
+	if err != nil {
+		t.Fatalf("list pending: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Errorf("pending = %d, want 1", len(pending))
+	}
+	all, err := svc.ListProposals(ctx, "", 0)
+	if err != nil {
+		t.Fatalf("list all: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("all = %d, want 2", len(all))
+	}
+}
+
+func TestSafeTransferHash(t *testing.T) {
+	cfg := testConfig()
+
+	h1, ok := safeTransferHash(cfg, recipient, "500.000000", 0)
+	if !ok || len(h1) != 66 {
+		t.Fatalf("hash = %q ok=%v", h1, ok)
+	}
+	// Deterministic for identical inputs.
+	if h2, _ := safeTransferHash(cfg, recipient, "500.000000", 0); h2 != h1 {
+		t.Error("hash not deterministic")
+	}
+	// Nonce, amount, and chain all bind into the hash.
+	if h, _ := safeTransferHash(cfg, recipient, "500.000000", 1); h == h1 {
+		t.Error("nonce not bound into hash")
+	}
+	if h, _ := safeTransferHash(cfg, recipient, "500.000001", 0); h == h1 {
+		t.Error("amount not bound into hash")
+	}
+	other := cfg
+	other.ChainID = 1
+	if h, _ := safeTransferHash(other, recipient, "500.000000", 0); h == h1 {
+		t.Error("chain ID not bound into hash")
+	}
+	// Unparseable amounts produce no hash rather than a wrong one.
+	if _, ok := safeTransferHash(cfg, recipient, "lots", 0); ok {
+		t.Error("expected no hash for invalid amount")
+	}
+}
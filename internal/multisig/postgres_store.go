@@ -0,0 +1,145 @@
+package multisig
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// PostgresStore persists proposals in PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new PostgreSQL-backed proposal store.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const proposalColumns = `id, kind, safe_address, to_addr, amount, reference, agent_addr, proposed_by, threshold, approvals, status, safe_tx_hash, tx_hash, error, created_at, updated_at, executed_at`
+
+func (p *PostgresStore) CreateProposal(ctx context.Context, pr *Proposal) error {
+	approvalsJSON, _ := json.Marshal(pr.Approvals)
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO multisig_proposals (`+proposalColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`,
+		pr.ID, string(pr.Kind), pr.SafeAddress, pr.To, pr.Amount, pr.Reference, pr.AgentAddr,
+		pr.ProposedBy, pr.Threshold, approvalsJSON, string(pr.Status), pr.SafeTxHash,
+		pr.TxHash, pr.Error, pr.CreatedAt, pr.UpdatedAt, nullTime(pr.ExecutedAt),
+	)
+	return err
+}
+
+func (p *PostgresStore) GetProposal(ctx context.Context, id string) (*Proposal, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+proposalColumns+` FROM multisig_proposals WHERE id = $1`, id)
+	pr, err := scanProposal(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return pr, err
+}
+
+func (p *PostgresStore) GetProposalByReference(ctx context.Context, reference string) (*Proposal, error) {
+	row := p.db.QueryRowContext(ctx,
+		`SELECT `+proposalColumns+` FROM multisig_proposals WHERE reference = $1 ORDER BY created_at DESC LIMIT 1`,
+		reference)
+	pr, err := scanProposal(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	return pr, err
+}
+
+func (p *PostgresStore) ListProposals(ctx context.Context, status ProposalStatus, limit int) ([]*Proposal, error) {
+	query := `SELECT ` + proposalColumns + ` FROM multisig_proposals ORDER BY created_at DESC LIMIT $1`
+	args := []interface{}{limit}
+	if status != "" {
+		query = `SELECT ` + proposalColumns + ` FROM multisig_proposals WHERE status = $1 ORDER BY created_at DESC LIMIT $2`
+		args = []interface{}{string(status), limit}
+	}
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Proposal
+	for rows.Next() {
+		pr, err := scanProposal(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, pr)
+	}
+	return out, rows.Err()
+}
+
+func (p *PostgresStore) UpdateProposal(ctx context.Context, pr *Proposal) error {
+	approvalsJSON, _ := json.Marshal(pr.Approvals)
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE multisig_proposals
+		SET approvals = $1, status = $2, tx_hash = $3, error = $4, updated_at = $5, executed_at = $6
+		WHERE id = $7`,
+		approvalsJSON, string(pr.Status), pr.TxHash, pr.Error, pr.UpdatedAt, nullTime(pr.ExecutedAt), pr.ID,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) CountProposals(ctx context.Context) (int64, error) {
+	var n int64
+	err := p.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM multisig_proposals`).Scan(&n)
+	return n, err
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProposal(row scanner) (*Proposal, error) {
+	var pr Proposal
+	var kind, status string
+	var approvalsJSON []byte
+	var executedAt sql.NullTime
+	err := row.Scan(&pr.ID, &kind, &pr.SafeAddress, &pr.To, &pr.Amount, &pr.Reference,
+		&pr.AgentAddr, &pr.ProposedBy, &pr.Threshold, &approvalsJSON, &status,
+		&pr.SafeTxHash, &pr.TxHash, &pr.Error, &pr.CreatedAt, &pr.UpdatedAt, &executedAt)
+	if err != nil {
+		return nil, err
+	}
+	pr.Kind = ProposalKind(kind)
+	pr.Status = ProposalStatus(status)
+	if len(approvalsJSON) > 0 {
+		if err := json.Unmarshal(approvalsJSON, &pr.Approvals); err != nil {
+			return nil, err
+		}
+	}
+	if pr.Approvals == nil {
+		pr.Approvals = []Approval{}
+	}
+	if executedAt.Valid {
+		t := executedAt.Time
+		pr.ExecutedAt = &t
+	}
+	return &pr, nil
+}
+
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+var _ Store = (*PostgresStore)(nil)
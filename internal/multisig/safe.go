@@ -0,0 +1,76 @@
+package multisig
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// Safe v1.3+ EIP-712 type hashes.
+var (
+	// keccak256("EIP712Domain(uint256 chainId,address verifyingContract)")
+	safeDomainTypehash = crypto.Keccak256(
+		[]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+	// keccak256("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)")
+	safeTxTypehash = crypto.Keccak256(
+		[]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+// erc20TransferCalldata builds transfer(address,uint256) calldata.
+func erc20TransferCalldata(to common.Address, amount *big.Int) []byte {
+	// 0xa9059cbb = keccak256("transfer(address,uint256)")[:4]
+	data := make([]byte, 0, 4+32+32)
+	data = append(data, 0xa9, 0x05, 0x9c, 0xbb)
+	data = append(data, common.LeftPadBytes(to.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+	return data
+}
+
+// safeTransferHash computes the Safe transaction hash for a USDC transfer
+// out of the configured Safe, so approvers can match the proposal against
+// the hash the Safe UI displays. All gas parameters are zero (the default
+// for UI-proposed transactions) and operation is CALL. Returns false when
+// the amount does not parse or the Safe config is incomplete.
+func safeTransferHash(cfg Config, to, amount string, nonce int64) (string, bool) {
+	if !common.IsHexAddress(cfg.SafeAddress) || !common.IsHexAddress(cfg.USDCContract) || cfg.ChainID <= 0 {
+		return "", false
+	}
+	amt, ok := usdc.Parse(amount)
+	if !ok || amt == nil || amt.Sign() <= 0 {
+		return "", false
+	}
+
+	data := erc20TransferCalldata(common.HexToAddress(to), amt)
+
+	word := func(v *big.Int) []byte { return common.LeftPadBytes(v.Bytes(), 32) }
+	addr := func(a common.Address) []byte { return common.LeftPadBytes(a.Bytes(), 32) }
+	zero := big.NewInt(0)
+
+	var safeTx []byte
+	safeTx = append(safeTx, safeTxTypehash...)
+	safeTx = append(safeTx, addr(common.HexToAddress(cfg.USDCContract))...) // to: the token contract
+	safeTx = append(safeTx, word(zero)...)                                  // value
+	safeTx = append(safeTx, crypto.Keccak256(data)...)                      // keccak(data)
+	safeTx = append(safeTx, word(zero)...)                                  // operation: CALL
+	safeTx = append(safeTx, word(zero)...)                                  // safeTxGas
+	safeTx = append(safeTx, word(zero)...)                                  // baseGas
+	safeTx = append(safeTx, word(zero)...)                                  // gasPrice
+	safeTx = append(safeTx, addr(common.Address{})...)                      // gasToken
+	safeTx = append(safeTx, addr(common.Address{})...)                      // refundReceiver
+	safeTx = append(safeTx, word(big.NewInt(nonce))...)                     // nonce
+	safeTxHash := crypto.Keccak256(safeTx)
+
+	var domain []byte
+	domain = append(domain, safeDomainTypehash...)
+	domain = append(domain, word(big.NewInt(cfg.ChainID))...)
+	domain = append(domain, addr(common.HexToAddress(cfg.SafeAddress))...)
+	domainHash := crypto.Keccak256(domain)
+
+	var msg []byte
+	msg = append(msg, 0x19, 0x01)
+	msg = append(msg, domainHash...)
+	msg = append(msg, safeTxHash...)
+	return common.BytesToHash(crypto.Keccak256(msg)).Hex(), true
+}
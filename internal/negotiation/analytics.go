@@ -0,0 +1,177 @@
+package negotiation
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// analyticsSampleLimit caps how much history the per-agent stats walk.
+const analyticsSampleLimit = 1000
+
+// SellerStats summarizes a seller's bidding performance so they can tune
+// their pricing and strategies.
+type SellerStats struct {
+	SellerAddr            string  `json:"sellerAddr"`
+	TotalBids             int     `json:"totalBids"`
+	Wins                  int     `json:"wins"`
+	Losses                int     `json:"losses"`
+	Withdrawn             int     `json:"withdrawn"`
+	Retracted             int     `json:"retracted"`
+	WinRate               float64 `json:"winRate"`               // Wins / decided (won+lost) bids, 0-1
+	AvgWinningDiscountPct float64 `json:"avgWinningDiscountPct"` // Mean (budget - price) / budget over winning bids
+	AvgResponseSeconds    float64 `json:"avgResponseSeconds"`    // Mean time from RFP posting to bid
+}
+
+// BuyerStats summarizes a buyer's award patterns across their RFPs.
+type BuyerStats struct {
+	BuyerAddr             string         `json:"buyerAddr"`
+	TotalRFPs             int            `json:"totalRfps"`
+	Awarded               int            `json:"awarded"`
+	Cancelled             int            `json:"cancelled"`
+	Expired               int            `json:"expired"`
+	AwardRate             float64        `json:"awardRate"` // Awarded / closed (awarded+cancelled+expired) RFPs, 0-1
+	AvgBidsPerRFP         float64        `json:"avgBidsPerRfp"`
+	AvgAwardDiscountPct   float64        `json:"avgAwardDiscountPct"`   // Mean discount of the winning price below budget
+	AvgTimeToAwardSeconds float64        `json:"avgTimeToAwardSeconds"` // Mean time from bid deadline to award
+	ModeCounts            map[string]int `json:"modeCounts"`
+}
+
+// SellerStats reports a seller's bid win rate, average winning discount,
+// and response latency over their recent bids.
+func (s *Service) SellerStats(ctx context.Context, sellerAddr string) (*SellerStats, error) {
+	seller := strings.ToLower(sellerAddr)
+	bids, err := s.store.ListBidsBySeller(ctx, seller, analyticsSampleLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &SellerStats{SellerAddr: seller}
+	rfps := map[string]*RFP{}
+	var discountSum, responseSum float64
+	var discountN, responseN int
+
+	for _, b := range bids {
+		stats.TotalBids++
+		switch b.Status {
+		case BidWon:
+			stats.Wins++
+		case BidLost, BidInvalidated:
+			stats.Losses++
+		case BidWithdrawn:
+			stats.Withdrawn++
+		case BidRetracted:
+			stats.Retracted++
+		}
+
+		rfp, ok := rfps[b.RFPID]
+		if !ok {
+			rfp, err = s.store.GetRFP(ctx, b.RFPID)
+			if err != nil {
+				continue
+			}
+			rfps[b.RFPID] = rfp
+		}
+
+		responseSum += b.CreatedAt.Sub(rfp.CreatedAt).Seconds()
+		responseN++
+
+		if b.Status == BidWon {
+			if d, ok := discountPct(b.Price, rfp.Budget); ok {
+				discountSum += d
+				discountN++
+			}
+		}
+	}
+
+	if decided := stats.Wins + stats.Losses; decided > 0 {
+		stats.WinRate = round4(float64(stats.Wins) / float64(decided))
+	}
+	if discountN > 0 {
+		stats.AvgWinningDiscountPct = round2(discountSum / float64(discountN))
+	}
+	if responseN > 0 {
+		stats.AvgResponseSeconds = round2(responseSum / float64(responseN))
+	}
+	return stats, nil
+}
+
+// BuyerStats reports a buyer's award patterns over their recent RFPs.
+func (s *Service) BuyerStats(ctx context.Context, buyerAddr string) (*BuyerStats, error) {
+	buyer := strings.ToLower(buyerAddr)
+	rfps, err := s.store.ListRFPsByBuyer(ctx, buyer, analyticsSampleLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &BuyerStats{BuyerAddr: buyer, ModeCounts: map[string]int{}}
+	var bidSum int
+	var discountSum, awardDelaySum float64
+	var discountN, awardDelayN int
+
+	for _, rfp := range rfps {
+		stats.TotalRFPs++
+		stats.ModeCounts[string(rfp.Mode)]++
+		bidSum += rfp.BidCount
+
+		switch rfp.Status {
+		case RFPAwarded:
+			stats.Awarded++
+		case RFPCancelled:
+			stats.Cancelled++
+		case RFPExpired:
+			stats.Expired++
+		}
+
+		if rfp.Status != RFPAwarded {
+			continue
+		}
+		if rfp.AwardedAt != nil {
+			awardDelaySum += rfp.AwardedAt.Sub(rfp.BidDeadline).Seconds()
+			awardDelayN++
+		}
+		if rfp.WinningBidID != "" {
+			if bid, err := s.store.GetBid(ctx, rfp.WinningBidID); err == nil {
+				if d, ok := discountPct(bid.Price, rfp.Budget); ok {
+					discountSum += d
+					discountN++
+				}
+			}
+		}
+	}
+
+	if closed := stats.Awarded + stats.Cancelled + stats.Expired; closed > 0 {
+		stats.AwardRate = round4(float64(stats.Awarded) / float64(closed))
+	}
+	if stats.TotalRFPs > 0 {
+		stats.AvgBidsPerRFP = round2(float64(bidSum) / float64(stats.TotalRFPs))
+	}
+	if discountN > 0 {
+		stats.AvgAwardDiscountPct = round2(discountSum / float64(discountN))
+	}
+	if awardDelayN > 0 {
+		stats.AvgTimeToAwardSeconds = round2(awardDelaySum / float64(awardDelayN))
+	}
+	return stats, nil
+}
+
+// discountPct returns how far a price sits below the budget, in percent.
+func discountPct(price, budget string) (float64, bool) {
+	p, ok := usdc.Parse(price)
+	if !ok {
+		return 0, false
+	}
+	b, ok := usdc.Parse(budget)
+	if !ok || b.Sign() <= 0 {
+		return 0, false
+	}
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(p), new(big.Float).SetInt(b)).Float64()
+	return (1 - ratio) * 100, true
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,85 @@
+package negotiation
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSellerStats(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	// Win one RFP at half the budget, lose another.
+	won := createTestRFP(t, svc, "open")
+	bid, err := svc.SubmitBid(ctx, won.ID, sellerAddr, BidRequest{Price: "0.500000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.Award(ctx, won.ID, buyerAddr, bid.ID); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+
+	lost := createTestRFP(t, svc, "open")
+	if _, err := svc.SubmitBid(ctx, lost.ID, sellerAddr, BidRequest{Price: "0.900000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	rival, err := svc.SubmitBid(ctx, lost.ID, sellerAddr2, BidRequest{Price: "0.200000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.Award(ctx, lost.ID, buyerAddr, rival.ID); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+
+	stats, err := svc.SellerStats(ctx, sellerAddr)
+	if err != nil {
+		t.Fatalf("SellerStats failed: %v", err)
+	}
+	if stats.TotalBids != 2 || stats.Wins != 1 || stats.Losses != 1 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+	if stats.WinRate != 0.5 {
+		t.Errorf("expected win rate 0.5, got %v", stats.WinRate)
+	}
+	if stats.AvgWinningDiscountPct != 50 {
+		t.Errorf("expected 50%% winning discount, got %v", stats.AvgWinningDiscountPct)
+	}
+}
+
+func TestBuyerStats(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	awarded := createTestRFP(t, svc, "open")
+	bid, err := svc.SubmitBid(ctx, awarded.ID, sellerAddr, BidRequest{Price: "0.750000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.Award(ctx, awarded.ID, buyerAddr, bid.ID); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+
+	cancelled := createTestRFP(t, svc, "sealed")
+	if _, err := svc.CancelRFP(ctx, cancelled.ID, buyerAddr); err != nil {
+		t.Fatalf("CancelRFP failed: %v", err)
+	}
+
+	createTestRFP(t, svc, "open") // still bidding
+
+	stats, err := svc.BuyerStats(ctx, buyerAddr)
+	if err != nil {
+		t.Fatalf("BuyerStats failed: %v", err)
+	}
+	if stats.TotalRFPs != 3 || stats.Awarded != 1 || stats.Cancelled != 1 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+	if stats.AwardRate != 0.5 {
+		t.Errorf("expected award rate 0.5, got %v", stats.AwardRate)
+	}
+	if stats.AvgAwardDiscountPct != 25 {
+		t.Errorf("expected 25%% award discount, got %v", stats.AvgAwardDiscountPct)
+	}
+	if stats.ModeCounts["open"] != 2 || stats.ModeCounts["sealed"] != 1 {
+		t.Errorf("unexpected mode counts: %v", stats.ModeCounts)
+	}
+}
@@ -0,0 +1,313 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+)
+
+var (
+	ErrExtensionNotFound = errors.New("negotiation: extension not found")
+	ErrExtensionPending  = errors.New("negotiation: extension request already pending")
+	ErrExtensionClosed   = errors.New("negotiation: extension request no longer pending")
+	ErrAlreadyVoted      = errors.New("negotiation: already voted on this extension")
+)
+
+// LeadingBidderCount is how many of the lowest-priced active bids count as
+// leading; deadline extensions need a majority of them to consent.
+const LeadingBidderCount = 3
+
+// MaxExtension caps how far a single request can push the deadline out.
+const MaxExtension = 24 * time.Hour
+
+// ExtensionStatus represents the lifecycle of an extension request.
+type ExtensionStatus string
+
+const (
+	ExtensionPending  ExtensionStatus = "pending"  // Awaiting leading-bidder votes
+	ExtensionApproved ExtensionStatus = "approved" // Consensus reached, deadline moved
+	ExtensionRejected ExtensionStatus = "rejected" // A leading bidder declined
+	ExtensionLapsed   ExtensionStatus = "lapsed"   // Deadline passed before consensus
+)
+
+// Extension is a buyer's request to push out an RFP's bidding deadline.
+// It only takes effect once a majority of the leading bidders approve.
+type Extension struct {
+	ID          string          `json:"id"`
+	RFPID       string          `json:"rfpId"`
+	ProposedBy  string          `json:"proposedBy"`
+	NewDeadline time.Time       `json:"newDeadline"`
+	Reason      string          `json:"reason,omitempty"`
+	Status      ExtensionStatus `json:"status"`
+	Approvals   []string        `json:"approvals,omitempty"`
+	Rejections  []string        `json:"rejections,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+// ProposeExtensionRequest is the input for requesting a deadline extension.
+type ProposeExtensionRequest struct {
+	ExtendBy string `json:"extendBy" binding:"required"` // Duration, e.g. "30m"
+	Reason   string `json:"reason"`
+}
+
+// WebhookEmitter notifies buyers and bidders about deadline extensions.
+type WebhookEmitter interface {
+	EmitRFPExtensionProposed(agentAddr, rfpID, extensionID, newDeadline, reason string)
+	EmitRFPExtensionRejected(agentAddr, rfpID, extensionID, rejectedBy string)
+	EmitRFPDeadlineExtended(agentAddr, rfpID, extensionID, newDeadline string)
+}
+
+// WithWebhookEmitter adds a webhook emitter for extension notifications.
+func (s *Service) WithWebhookEmitter(e WebhookEmitter) *Service {
+	s.webhookEmitter = e
+	return s
+}
+
+// ProposeExtension asks the leading bidders for more bidding time. Only the
+// buyer may propose, only while bidding is open, and only one request can be
+// pending at a time. With no active bids there is nobody to consent, so the
+// extension applies immediately.
+func (s *Service) ProposeExtension(ctx context.Context, rfpID, buyerAddr string, req ProposeExtensionRequest) (*Extension, error) {
+	extendBy, err := time.ParseDuration(req.ExtendBy)
+	if err != nil || extendBy <= 0 {
+		return nil, fmt.Errorf("invalid extendBy duration %q", req.ExtendBy)
+	}
+	if extendBy > MaxExtension {
+		return nil, fmt.Errorf("extendBy exceeds maximum of %s", MaxExtension)
+	}
+
+	mu := s.rfpLock(rfpID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	rfp, err := s.store.GetRFP(ctx, rfpID)
+	if err != nil {
+		return nil, err
+	}
+	if rfp.BuyerAddr != strings.ToLower(buyerAddr) {
+		return nil, ErrUnauthorized
+	}
+	if rfp.Status != RFPBidding || time.Now().After(rfp.BidDeadline) {
+		return nil, ErrBiddingOver
+	}
+	if pending, err := s.store.GetPendingExtension(ctx, rfpID); err != nil && !errors.Is(err, ErrExtensionNotFound) {
+		return nil, err
+	} else if pending != nil {
+		return nil, ErrExtensionPending
+	}
+
+	now := time.Now()
+	ext := &Extension{
+		ID:          idgen.WithPrefix("ext_"),
+		RFPID:       rfpID,
+		ProposedBy:  rfp.BuyerAddr,
+		NewDeadline: rfp.BidDeadline.Add(extendBy),
+		Reason:      req.Reason,
+		Status:      ExtensionPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	leading, err := s.leadingBidders(ctx, rfpID)
+	if err != nil {
+		return nil, err
+	}
+	if len(leading) == 0 {
+		ext.Status = ExtensionApproved
+		if err := s.store.CreateExtension(ctx, ext); err != nil {
+			return nil, err
+		}
+		if err := s.applyExtension(ctx, rfp, ext); err != nil {
+			return nil, err
+		}
+		return ext, nil
+	}
+
+	if err := s.store.CreateExtension(ctx, ext); err != nil {
+		return nil, err
+	}
+	if s.webhookEmitter != nil {
+		deadline := ext.NewDeadline.UTC().Format(time.RFC3339)
+		for _, seller := range leading {
+			s.webhookEmitter.EmitRFPExtensionProposed(seller, rfpID, ext.ID, deadline, ext.Reason)
+		}
+	}
+	s.logger.Info("negotiation: extension proposed",
+		"rfp", rfpID, "extension", ext.ID, "newDeadline", ext.NewDeadline, "leadingBidders", len(leading))
+
+	return ext, nil
+}
+
+// VoteExtension records a leading bidder's vote on a pending extension.
+// Approval by a majority of the leading bidders applies the new deadline;
+// any rejection closes the request immediately.
+func (s *Service) VoteExtension(ctx context.Context, extensionID, callerAddr string, approve bool) (*Extension, error) {
+	ext, err := s.store.GetExtension(ctx, extensionID)
+	if err != nil {
+		return nil, err
+	}
+
+	mu := s.rfpLock(ext.RFPID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	ext, err = s.store.GetExtension(ctx, extensionID)
+	if err != nil {
+		return nil, err
+	}
+	if ext.Status != ExtensionPending {
+		return nil, ErrExtensionClosed
+	}
+
+	rfp, err := s.store.GetRFP(ctx, ext.RFPID)
+	if err != nil {
+		return nil, err
+	}
+	if rfp.Status != RFPBidding || time.Now().After(rfp.BidDeadline) {
+		return nil, ErrBiddingOver
+	}
+
+	leading, err := s.leadingBidders(ctx, ext.RFPID)
+	if err != nil {
+		return nil, err
+	}
+	caller := strings.ToLower(callerAddr)
+	if !containsAddr(leading, caller) {
+		return nil, ErrUnauthorized
+	}
+	if containsAddr(ext.Approvals, caller) || containsAddr(ext.Rejections, caller) {
+		return nil, ErrAlreadyVoted
+	}
+
+	now := time.Now()
+	ext.UpdatedAt = now
+
+	if !approve {
+		ext.Rejections = append(ext.Rejections, caller)
+		ext.Status = ExtensionRejected
+		if err := s.store.UpdateExtension(ctx, ext); err != nil {
+			return nil, err
+		}
+		if s.webhookEmitter != nil {
+			s.webhookEmitter.EmitRFPExtensionRejected(rfp.BuyerAddr, rfp.ID, ext.ID, caller)
+		}
+		s.logger.Info("negotiation: extension rejected", "rfp", rfp.ID, "extension", ext.ID, "by", caller)
+		return ext, nil
+	}
+
+	ext.Approvals = append(ext.Approvals, caller)
+
+	// Count approvals from sellers still in the leading set; the set can
+	// shift as new bids arrive.
+	votes := 0
+	for _, seller := range leading {
+		if containsAddr(ext.Approvals, seller) {
+			votes++
+		}
+	}
+	if votes >= len(leading)/2+1 {
+		ext.Status = ExtensionApproved
+		if err := s.store.UpdateExtension(ctx, ext); err != nil {
+			return nil, err
+		}
+		if err := s.applyExtension(ctx, rfp, ext); err != nil {
+			return nil, err
+		}
+		if s.webhookEmitter != nil {
+			deadline := ext.NewDeadline.UTC().Format(time.RFC3339)
+			for _, seller := range leading {
+				s.webhookEmitter.EmitRFPDeadlineExtended(seller, rfp.ID, ext.ID, deadline)
+			}
+		}
+		return ext, nil
+	}
+
+	if err := s.store.UpdateExtension(ctx, ext); err != nil {
+		return nil, err
+	}
+	return ext, nil
+}
+
+// GetExtension returns an extension request by ID.
+func (s *Service) GetExtension(ctx context.Context, id string) (*Extension, error) {
+	return s.store.GetExtension(ctx, id)
+}
+
+// ListExtensionsByRFP returns an RFP's extension requests, newest first.
+func (s *Service) ListExtensionsByRFP(ctx context.Context, rfpID string, limit int) ([]*Extension, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.store.ListExtensionsByRFP(ctx, rfpID, limit)
+}
+
+// applyExtension moves the RFP's deadline and notifies the buyer. Callers
+// hold the RFP lock.
+func (s *Service) applyExtension(ctx context.Context, rfp *RFP, ext *Extension) error {
+	rfp.BidDeadline = ext.NewDeadline
+	rfp.UpdatedAt = time.Now()
+	if err := s.store.UpdateRFP(ctx, rfp); err != nil {
+		return err
+	}
+	if s.webhookEmitter != nil {
+		s.webhookEmitter.EmitRFPDeadlineExtended(rfp.BuyerAddr, rfp.ID, ext.ID, ext.NewDeadline.UTC().Format(time.RFC3339))
+	}
+	s.logger.Info("negotiation: deadline extended", "rfp", rfp.ID, "extension", ext.ID, "newDeadline", ext.NewDeadline)
+	return nil
+}
+
+// lapseExtension closes a pending extension once the RFP leaves the bidding
+// state. Callers hold the RFP lock.
+func (s *Service) lapseExtension(ctx context.Context, rfpID string) {
+	ext, err := s.store.GetPendingExtension(ctx, rfpID)
+	if err != nil {
+		if !errors.Is(err, ErrExtensionNotFound) {
+			s.logger.Warn("negotiation: pending extension lookup failed", "rfp", rfpID, "error", err)
+		}
+		return
+	}
+	ext.Status = ExtensionLapsed
+	ext.UpdatedAt = time.Now()
+	if err := s.store.UpdateExtension(ctx, ext); err != nil {
+		s.logger.Warn("negotiation: extension lapse failed", "extension", ext.ID, "error", err)
+	}
+}
+
+// leadingBidders returns the sellers behind the lowest-priced active bids,
+// up to LeadingBidderCount, cheapest first.
+func (s *Service) leadingBidders(ctx context.Context, rfpID string) ([]string, error) {
+	bids, err := s.store.ListBidsByRFP(ctx, rfpID, 0)
+	if err != nil {
+		return nil, err
+	}
+	var active []*Bid
+	for _, b := range bids {
+		if b.Status == BidActive {
+			active = append(active, b)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return lessBid(active[i], active[j]) })
+	if len(active) > LeadingBidderCount {
+		active = active[:LeadingBidderCount]
+	}
+	sellers := make([]string, 0, len(active))
+	for _, b := range active {
+		sellers = append(sellers, b.SellerAddr)
+	}
+	return sellers, nil
+}
+
+func containsAddr(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
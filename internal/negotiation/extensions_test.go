@@ -0,0 +1,192 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type stubEmitter struct {
+	proposed []string // recipient addresses
+	rejected []string
+	extended []string
+}
+
+func (s *stubEmitter) EmitRFPExtensionProposed(agentAddr, rfpID, extensionID, newDeadline, reason string) {
+	s.proposed = append(s.proposed, agentAddr)
+}
+
+func (s *stubEmitter) EmitRFPExtensionRejected(agentAddr, rfpID, extensionID, rejectedBy string) {
+	s.rejected = append(s.rejected, agentAddr)
+}
+
+func (s *stubEmitter) EmitRFPDeadlineExtended(agentAddr, rfpID, extensionID, newDeadline string) {
+	s.extended = append(s.extended, agentAddr)
+}
+
+func TestProposeExtensionNoBids(t *testing.T) {
+	svc, _ := newTestService()
+	emitter := &stubEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	if _, err := svc.ProposeExtension(ctx, rfp.ID, sellerAddr, ProposeExtensionRequest{ExtendBy: "30m"}); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized for non-buyer, got %v", err)
+	}
+	if _, err := svc.ProposeExtension(ctx, rfp.ID, buyerAddr, ProposeExtensionRequest{ExtendBy: "48h"}); err == nil {
+		t.Fatal("expected error for extension beyond maximum")
+	}
+
+	// No active bids: nobody to consent, so it applies immediately.
+	ext, err := svc.ProposeExtension(ctx, rfp.ID, buyerAddr, ProposeExtensionRequest{ExtendBy: "30m"})
+	if err != nil {
+		t.Fatalf("ProposeExtension failed: %v", err)
+	}
+	if ext.Status != ExtensionApproved {
+		t.Errorf("expected immediate approval, got %s", ext.Status)
+	}
+	got, err := svc.GetRFP(ctx, rfp.ID, buyerAddr)
+	if err != nil {
+		t.Fatalf("GetRFP failed: %v", err)
+	}
+	if !got.BidDeadline.After(rfp.BidDeadline) {
+		t.Error("expected deadline pushed out")
+	}
+	if len(emitter.extended) != 1 || emitter.extended[0] != buyerAddr {
+		t.Errorf("expected deadline-extended webhook to buyer, got %v", emitter.extended)
+	}
+}
+
+func TestExtensionApprovalFlow(t *testing.T) {
+	svc, _ := newTestService()
+	emitter := &stubEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	for i, seller := range []string{sellerAddr, sellerAddr2, sellerAddr3} {
+		price := fmt.Sprintf("0.50000%d", i+1)
+		if _, err := svc.SubmitBid(ctx, rfp.ID, seller, BidRequest{Price: price}); err != nil {
+			t.Fatalf("SubmitBid failed: %v", err)
+		}
+	}
+
+	ext, err := svc.ProposeExtension(ctx, rfp.ID, buyerAddr, ProposeExtensionRequest{ExtendBy: "30m", Reason: "scope grew"})
+	if err != nil {
+		t.Fatalf("ProposeExtension failed: %v", err)
+	}
+	if ext.Status != ExtensionPending {
+		t.Fatalf("expected pending extension, got %s", ext.Status)
+	}
+	if len(emitter.proposed) != 3 {
+		t.Errorf("expected proposal webhooks to 3 leading bidders, got %d", len(emitter.proposed))
+	}
+	if _, err := svc.ProposeExtension(ctx, rfp.ID, buyerAddr, ProposeExtensionRequest{ExtendBy: "30m"}); !errors.Is(err, ErrExtensionPending) {
+		t.Errorf("expected ErrExtensionPending for duplicate, got %v", err)
+	}
+
+	// The buyer is not a leading bidder and cannot vote.
+	if _, err := svc.VoteExtension(ctx, ext.ID, buyerAddr, true); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for buyer vote, got %v", err)
+	}
+
+	ext, err = svc.VoteExtension(ctx, ext.ID, sellerAddr, true)
+	if err != nil {
+		t.Fatalf("VoteExtension failed: %v", err)
+	}
+	if ext.Status != ExtensionPending {
+		t.Errorf("expected still pending after 1 of 3 votes, got %s", ext.Status)
+	}
+	if _, err := svc.VoteExtension(ctx, ext.ID, sellerAddr, true); !errors.Is(err, ErrAlreadyVoted) {
+		t.Errorf("expected ErrAlreadyVoted, got %v", err)
+	}
+
+	// Second approval reaches the 2-of-3 majority.
+	ext, err = svc.VoteExtension(ctx, ext.ID, sellerAddr2, true)
+	if err != nil {
+		t.Fatalf("VoteExtension failed: %v", err)
+	}
+	if ext.Status != ExtensionApproved {
+		t.Fatalf("expected approval at majority, got %s", ext.Status)
+	}
+	got, err := svc.GetRFP(ctx, rfp.ID, buyerAddr)
+	if err != nil {
+		t.Fatalf("GetRFP failed: %v", err)
+	}
+	if !got.BidDeadline.Equal(ext.NewDeadline) {
+		t.Errorf("expected deadline %v, got %v", ext.NewDeadline, got.BidDeadline)
+	}
+	// Buyer plus each leading bidder hears about the new deadline.
+	if len(emitter.extended) != 4 {
+		t.Errorf("expected 4 deadline-extended webhooks, got %d", len(emitter.extended))
+	}
+	if _, err := svc.VoteExtension(ctx, ext.ID, sellerAddr3, true); !errors.Is(err, ErrExtensionClosed) {
+		t.Errorf("expected ErrExtensionClosed after approval, got %v", err)
+	}
+}
+
+func TestExtensionRejection(t *testing.T) {
+	svc, _ := newTestService()
+	emitter := &stubEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.500000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	ext, err := svc.ProposeExtension(ctx, rfp.ID, buyerAddr, ProposeExtensionRequest{ExtendBy: "30m"})
+	if err != nil {
+		t.Fatalf("ProposeExtension failed: %v", err)
+	}
+	ext, err = svc.VoteExtension(ctx, ext.ID, sellerAddr, false)
+	if err != nil {
+		t.Fatalf("VoteExtension failed: %v", err)
+	}
+	if ext.Status != ExtensionRejected {
+		t.Errorf("expected rejection, got %s", ext.Status)
+	}
+	if len(emitter.rejected) != 1 || emitter.rejected[0] != buyerAddr {
+		t.Errorf("expected rejection webhook to buyer, got %v", emitter.rejected)
+	}
+	got, err := svc.GetRFP(ctx, rfp.ID, buyerAddr)
+	if err != nil {
+		t.Fatalf("GetRFP failed: %v", err)
+	}
+	if !got.BidDeadline.Equal(rfp.BidDeadline) {
+		t.Error("expected deadline unchanged after rejection")
+	}
+}
+
+func TestExtensionLapsesAtReveal(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.500000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	ext, err := svc.ProposeExtension(ctx, rfp.ID, buyerAddr, ProposeExtensionRequest{ExtendBy: "30m"})
+	if err != nil {
+		t.Fatalf("ProposeExtension failed: %v", err)
+	}
+
+	passDeadline(t, store, rfp.ID)
+	if _, err := svc.Reveal(ctx, rfp.ID); err != nil {
+		t.Fatalf("Reveal failed: %v", err)
+	}
+
+	ext, err = svc.GetExtension(ctx, ext.ID)
+	if err != nil {
+		t.Fatalf("GetExtension failed: %v", err)
+	}
+	if ext.Status != ExtensionLapsed {
+		t.Errorf("expected extension lapsed at reveal, got %s", ext.Status)
+	}
+	if _, err := svc.VoteExtension(ctx, ext.ID, sellerAddr, true); !errors.Is(err, ErrExtensionClosed) {
+		t.Errorf("expected ErrExtensionClosed after lapse, got %v", err)
+	}
+}
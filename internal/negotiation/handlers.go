@@ -0,0 +1,757 @@
+package negotiation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mbd888/alancoin/internal/validation"
+)
+
+func safeMessage(status int, err error, fallback string) string {
+	if status < 500 {
+		return err.Error()
+	}
+	return fallback
+}
+
+// Handler provides HTTP endpoints for RFP auctions.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new negotiation handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes sets up read-only RFP routes.
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/rfps", h.ListRFPs)
+	r.GET("/rfps/:id", h.GetRFP)
+	r.GET("/rfps/:id/bids", h.ListBids)
+	r.GET("/bids/:id", h.GetBid)
+	r.GET("/agents/:address/rfps", h.ListBuyerRFPs)
+	r.GET("/bid-strategies/:id", h.GetStrategy)
+	r.GET("/agents/:address/bid-strategies", h.ListSellerStrategies)
+	r.GET("/rfp-templates/:id", h.GetTemplate)
+	r.GET("/agents/:address/rfp-templates", h.ListBuyerTemplates)
+	r.GET("/rfps/:id/extensions", h.ListExtensions)
+	r.GET("/rfp-extensions/:id", h.GetExtension)
+	r.GET("/agents/:address/bid-stats", h.SellerStats)
+	r.GET("/agents/:address/award-stats", h.BuyerStats)
+}
+
+// RegisterProtectedRoutes sets up auth-required RFP routes.
+func (h *Handler) RegisterProtectedRoutes(r *gin.RouterGroup) {
+	r.POST("/rfps", h.CreateRFP)
+	r.POST("/rfps/:id/bids", h.SubmitBid)
+	r.POST("/rfps/:id/award", h.Award)
+	r.POST("/rfps/:id/cancel", h.CancelRFP)
+	r.POST("/bids/:id/withdraw", h.WithdrawBid)
+	r.POST("/bids/:id/retract", h.RetractBid)
+	r.POST("/bid-strategies", h.RegisterStrategy)
+	r.POST("/bid-strategies/:id/enable", h.EnableStrategy)
+	r.POST("/bid-strategies/:id/disable", h.DisableStrategy)
+	r.POST("/rfp-templates", h.SaveTemplate)
+	r.POST("/rfp-templates/:id/instantiate", h.InstantiateTemplate)
+	r.DELETE("/rfp-templates/:id", h.DeleteTemplate)
+	r.POST("/rfps/:id/clone", h.CloneRFP)
+	r.POST("/rfps/:id/extend", h.ProposeExtension)
+	r.POST("/rfp-extensions/:id/approve", h.ApproveExtension)
+	r.POST("/rfp-extensions/:id/reject", h.RejectExtension)
+}
+
+// CreateRFP handles POST /v1/rfps
+func (h *Handler) CreateRFP(c *gin.Context) {
+	var req CreateRFPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "serviceType and budget are required",
+		})
+		return
+	}
+
+	if errs := validation.Validate(
+		validation.ValidAmount("budget", req.Budget),
+	); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": errs.Error(),
+			"details": errs,
+		})
+		return
+	}
+
+	callerAddr := c.GetString("authAgentAddr")
+	rfp, err := h.service.CreateRFP(c.Request.Context(), callerAddr, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidPrice) || errors.Is(err, ErrInvalidMode) ||
+			errors.Is(err, ErrInvalidRubric) || errors.Is(err, ErrInvalidVisibility) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": "rfp_failed", "message": safeMessage(status, err, "Failed to create RFP")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"rfp": rfp})
+}
+
+// GetRFP handles GET /v1/rfps/:id
+func (h *Handler) GetRFP(c *gin.Context) {
+	rfp, err := h.service.GetRFP(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		if errors.Is(err, ErrRFPNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "RFP not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rfp": rfp})
+}
+
+// ListRFPs handles GET /v1/rfps?service_type=inference&limit=50
+func (h *Handler) ListRFPs(c *gin.Context) {
+	serviceType := c.Query("service_type")
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+			if limit > 200 {
+				limit = 200
+			}
+		}
+	}
+
+	rfps, err := h.service.ListRFPs(c.Request.Context(), serviceType, c.GetString("authAgentAddr"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rfps": rfps, "count": len(rfps)})
+}
+
+// ListBuyerRFPs handles GET /v1/agents/:address/rfps
+func (h *Handler) ListBuyerRFPs(c *gin.Context) {
+	address := c.Param("address")
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+			if limit > 200 {
+				limit = 200
+			}
+		}
+	}
+
+	rfps, err := h.service.ListRFPsByBuyer(c.Request.Context(), address, c.GetString("authAgentAddr"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rfps": rfps, "count": len(rfps)})
+}
+
+// SubmitBid handles POST /v1/rfps/:id/bids
+func (h *Handler) SubmitBid(c *gin.Context) {
+	var req BidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "price is required",
+		})
+		return
+	}
+
+	rfpID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	bid, err := h.service.SubmitBid(c.Request.Context(), rfpID, callerAddr, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "bid_failed"
+		switch {
+		case errors.Is(err, ErrRFPNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrBiddingOver):
+			status = http.StatusGone
+			code = "bidding_closed"
+		case errors.Is(err, ErrSelfBid):
+			status = http.StatusForbidden
+			code = "self_bid"
+		case errors.Is(err, ErrNotInvited):
+			status = http.StatusForbidden
+			code = "not_invited"
+		case errors.Is(err, ErrAlreadyBid):
+			status = http.StatusConflict
+			code = "already_bid"
+		case errors.Is(err, ErrOverBudget):
+			status = http.StatusBadRequest
+			code = "over_budget"
+		case errors.Is(err, ErrBelowFloor):
+			status = http.StatusForbidden
+			code = "below_reputation_floor"
+		case errors.Is(err, ErrCooldown):
+			status = http.StatusConflict
+			code = "cooldown_active"
+		case errors.Is(err, ErrInvalidPrice):
+			status = http.StatusBadRequest
+			code = "invalid_price"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to submit bid")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"bid": bid})
+}
+
+// ListBids handles GET /v1/rfps/:id/bids
+func (h *Handler) ListBids(c *gin.Context) {
+	rfpID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	bids, err := h.service.ListBids(c.Request.Context(), rfpID, callerAddr, limit)
+	if err != nil {
+		if errors.Is(err, ErrRFPNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "RFP not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"bids": bids, "count": len(bids)})
+}
+
+// GetBid handles GET /v1/bids/:id
+func (h *Handler) GetBid(c *gin.Context) {
+	callerAddr := c.GetString("authAgentAddr")
+	bid, err := h.service.GetBid(c.Request.Context(), c.Param("id"), callerAddr)
+	if err != nil {
+		if errors.Is(err, ErrBidNotFound) || errors.Is(err, ErrRFPNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Bid not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"bid": bid})
+}
+
+// WithdrawBid handles POST /v1/bids/:id/withdraw
+func (h *Handler) WithdrawBid(c *gin.Context) {
+	bidID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	bid, err := h.service.WithdrawBid(c.Request.Context(), bidID, callerAddr)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "internal_error"
+		switch {
+		case errors.Is(err, ErrBidNotFound) || errors.Is(err, ErrRFPNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrBidSealed):
+			status = http.StatusConflict
+			code = "bid_sealed"
+		case errors.Is(err, ErrBiddingOver):
+			status = http.StatusGone
+			code = "bidding_closed"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to withdraw bid")})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"bid": bid})
+}
+
+// RetractBid handles POST /v1/bids/:id/retract
+func (h *Handler) RetractBid(c *gin.Context) {
+	bidID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	bid, err := h.service.RetractBid(c.Request.Context(), bidID, callerAddr)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "internal_error"
+		switch {
+		case errors.Is(err, ErrBidNotFound) || errors.Is(err, ErrRFPNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrBiddingOver):
+			status = http.StatusGone
+			code = "bidding_closed"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to retract bid")})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"bid": bid})
+}
+
+// Award handles POST /v1/rfps/:id/award
+func (h *Handler) Award(c *gin.Context) {
+	var req AwardRequest
+	// Allow empty body (awards the lowest-priced active bid)
+	_ = c.ShouldBindJSON(&req)
+
+	rfpID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	bid, err := h.service.Award(c.Request.Context(), rfpID, callerAddr, req.BidID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "award_failed"
+		switch {
+		case errors.Is(err, ErrRFPNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrBidNotFound):
+			status = http.StatusNotFound
+			code = "bid_not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrBiddingOpen):
+			status = http.StatusConflict
+			code = "bidding_open"
+		case errors.Is(err, ErrBiddingOver):
+			status = http.StatusGone
+			code = "bidding_closed"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to award RFP")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bid": bid})
+}
+
+// RegisterStrategy handles POST /v1/bid-strategies
+func (h *Handler) RegisterStrategy(c *gin.Context) {
+	var req CreateStrategyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "serviceType and minPrice are required",
+		})
+		return
+	}
+
+	if errs := validation.Validate(
+		validation.ValidAmount("minPrice", req.MinPrice),
+	); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": errs.Error(),
+			"details": errs,
+		})
+		return
+	}
+
+	callerAddr := c.GetString("authAgentAddr")
+	strategy, err := h.service.RegisterStrategy(c.Request.Context(), callerAddr, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidStrategy) || errors.Is(err, ErrInvalidPrice) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": "strategy_failed", "message": safeMessage(status, err, "Failed to register strategy")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"strategy": strategy})
+}
+
+// GetStrategy handles GET /v1/bid-strategies/:id
+func (h *Handler) GetStrategy(c *gin.Context) {
+	strategy, err := h.service.GetStrategy(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrStrategyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Strategy not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"strategy": strategy})
+}
+
+// ListSellerStrategies handles GET /v1/agents/:address/bid-strategies
+func (h *Handler) ListSellerStrategies(c *gin.Context) {
+	address := c.Param("address")
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+			if limit > 200 {
+				limit = 200
+			}
+		}
+	}
+
+	strategies, err := h.service.ListStrategiesBySeller(c.Request.Context(), address, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"strategies": strategies, "count": len(strategies)})
+}
+
+// EnableStrategy handles POST /v1/bid-strategies/:id/enable
+func (h *Handler) EnableStrategy(c *gin.Context) {
+	h.setStrategyEnabled(c, true)
+}
+
+// DisableStrategy handles POST /v1/bid-strategies/:id/disable
+func (h *Handler) DisableStrategy(c *gin.Context) {
+	h.setStrategyEnabled(c, false)
+}
+
+func (h *Handler) setStrategyEnabled(c *gin.Context, enabled bool) {
+	strategyID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	strategy, err := h.service.SetStrategyEnabled(c.Request.Context(), strategyID, callerAddr, enabled)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "internal_error"
+		switch {
+		case errors.Is(err, ErrStrategyNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to update strategy")})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"strategy": strategy})
+}
+
+// SaveTemplate handles POST /v1/rfp-templates
+func (h *Handler) SaveTemplate(c *gin.Context) {
+	var req CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "name, serviceType, and budget are required",
+		})
+		return
+	}
+
+	if errs := validation.Validate(
+		validation.ValidAmount("budget", req.Budget),
+	); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": errs.Error(),
+			"details": errs,
+		})
+		return
+	}
+
+	callerAddr := c.GetString("authAgentAddr")
+	tpl, err := h.service.SaveTemplate(c.Request.Context(), callerAddr, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidPrice) || errors.Is(err, ErrInvalidMode) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": "template_failed", "message": safeMessage(status, err, "Failed to save template")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"template": tpl})
+}
+
+// GetTemplate handles GET /v1/rfp-templates/:id
+func (h *Handler) GetTemplate(c *gin.Context) {
+	tpl, err := h.service.GetTemplate(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrTemplateNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Template not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"template": tpl})
+}
+
+// ListBuyerTemplates handles GET /v1/agents/:address/rfp-templates
+func (h *Handler) ListBuyerTemplates(c *gin.Context) {
+	address := c.Param("address")
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+			if limit > 200 {
+				limit = 200
+			}
+		}
+	}
+
+	templates, err := h.service.ListTemplatesByBuyer(c.Request.Context(), address, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"templates": templates, "count": len(templates)})
+}
+
+// DeleteTemplate handles DELETE /v1/rfp-templates/:id
+func (h *Handler) DeleteTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	if err := h.service.DeleteTemplate(c.Request.Context(), templateID, callerAddr); err != nil {
+		status := http.StatusInternalServerError
+		code := "internal_error"
+		switch {
+		case errors.Is(err, ErrTemplateNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to delete template")})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": templateID})
+}
+
+// InstantiateTemplate handles POST /v1/rfp-templates/:id/instantiate
+func (h *Handler) InstantiateTemplate(c *gin.Context) {
+	var req InstantiateRequest
+	// Allow empty body (uses the saved template fields)
+	_ = c.ShouldBindJSON(&req)
+
+	templateID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	rfp, err := h.service.InstantiateTemplate(c.Request.Context(), templateID, callerAddr, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "instantiate_failed"
+		switch {
+		case errors.Is(err, ErrTemplateNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrInvalidPrice):
+			status = http.StatusBadRequest
+			code = "invalid_price"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to instantiate template")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"rfp": rfp})
+}
+
+// CloneRFP handles POST /v1/rfps/:id/clone
+func (h *Handler) CloneRFP(c *gin.Context) {
+	var req InstantiateRequest
+	// Allow empty body (reuses the source RFP's fields)
+	_ = c.ShouldBindJSON(&req)
+
+	rfpID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	rfp, err := h.service.CloneRFP(c.Request.Context(), rfpID, callerAddr, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "clone_failed"
+		switch {
+		case errors.Is(err, ErrRFPNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrInvalidPrice):
+			status = http.StatusBadRequest
+			code = "invalid_price"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to clone RFP")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"rfp": rfp})
+}
+
+// ProposeExtension handles POST /v1/rfps/:id/extend
+func (h *Handler) ProposeExtension(c *gin.Context) {
+	var req ProposeExtensionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "extendBy is required",
+		})
+		return
+	}
+
+	rfpID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	ext, err := h.service.ProposeExtension(c.Request.Context(), rfpID, callerAddr, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "extension_failed"
+		switch {
+		case errors.Is(err, ErrRFPNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrBiddingOver):
+			status = http.StatusGone
+			code = "bidding_closed"
+		case errors.Is(err, ErrExtensionPending):
+			status = http.StatusConflict
+			code = "extension_pending"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to propose extension")})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"extension": ext})
+}
+
+// ApproveExtension handles POST /v1/rfp-extensions/:id/approve
+func (h *Handler) ApproveExtension(c *gin.Context) {
+	h.voteExtension(c, true)
+}
+
+// RejectExtension handles POST /v1/rfp-extensions/:id/reject
+func (h *Handler) RejectExtension(c *gin.Context) {
+	h.voteExtension(c, false)
+}
+
+func (h *Handler) voteExtension(c *gin.Context, approve bool) {
+	extensionID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	ext, err := h.service.VoteExtension(c.Request.Context(), extensionID, callerAddr, approve)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "vote_failed"
+		switch {
+		case errors.Is(err, ErrExtensionNotFound) || errors.Is(err, ErrRFPNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrExtensionClosed):
+			status = http.StatusConflict
+			code = "extension_closed"
+		case errors.Is(err, ErrAlreadyVoted):
+			status = http.StatusConflict
+			code = "already_voted"
+		case errors.Is(err, ErrBiddingOver):
+			status = http.StatusGone
+			code = "bidding_closed"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to record extension vote")})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"extension": ext})
+}
+
+// GetExtension handles GET /v1/rfp-extensions/:id
+func (h *Handler) GetExtension(c *gin.Context) {
+	ext, err := h.service.GetExtension(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrExtensionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Extension not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"extension": ext})
+}
+
+// ListExtensions handles GET /v1/rfps/:id/extensions
+func (h *Handler) ListExtensions(c *gin.Context) {
+	rfpID := c.Param("id")
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	extensions, err := h.service.ListExtensionsByRFP(c.Request.Context(), rfpID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"extensions": extensions, "count": len(extensions)})
+}
+
+// SellerStats handles GET /v1/agents/:address/bid-stats
+func (h *Handler) SellerStats(c *gin.Context) {
+	stats, err := h.service.SellerStats(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"stats": stats})
+}
+
+// BuyerStats handles GET /v1/agents/:address/award-stats
+func (h *Handler) BuyerStats(c *gin.Context) {
+	stats, err := h.service.BuyerStats(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal_error", "message": "Internal server error"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"stats": stats})
+}
+
+// CancelRFP handles POST /v1/rfps/:id/cancel
+func (h *Handler) CancelRFP(c *gin.Context) {
+	rfpID := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	rfp, err := h.service.CancelRFP(c.Request.Context(), rfpID, callerAddr)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "internal_error"
+		switch {
+		case errors.Is(err, ErrRFPNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrBiddingOver):
+			status = http.StatusGone
+			code = "bidding_closed"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to cancel RFP")})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rfp": rfp})
+}
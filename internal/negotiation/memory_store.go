@@ -0,0 +1,406 @@
+package negotiation
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-memory store for demo/development.
+type MemoryStore struct {
+	rfps       map[string]*RFP
+	bids       map[string]*Bid
+	strategies map[string]*Strategy
+	templates  map[string]*Template
+	extensions map[string]*Extension
+	mu         sync.RWMutex
+}
+
+// NewMemoryStore creates a new in-memory negotiation store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		rfps:       make(map[string]*RFP),
+		bids:       make(map[string]*Bid),
+		strategies: make(map[string]*Strategy),
+		templates:  make(map[string]*Template),
+		extensions: make(map[string]*Extension),
+	}
+}
+
+func (m *MemoryStore) CreateRFP(ctx context.Context, r *RFP) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rfps[r.ID] = copyRFP(r)
+	return nil
+}
+
+func (m *MemoryStore) GetRFP(ctx context.Context, id string) (*RFP, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	r, ok := m.rfps[id]
+	if !ok {
+		return nil, ErrRFPNotFound
+	}
+	return copyRFP(r), nil
+}
+
+func (m *MemoryStore) UpdateRFP(ctx context.Context, r *RFP) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.rfps[r.ID]; !ok {
+		return ErrRFPNotFound
+	}
+	m.rfps[r.ID] = copyRFP(r)
+	return nil
+}
+
+func (m *MemoryStore) ListRFPs(ctx context.Context, serviceType string, limit int) ([]*RFP, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	st := strings.ToLower(serviceType)
+	var result []*RFP
+	for _, r := range m.rfps {
+		if r.Status != RFPBidding {
+			continue
+		}
+		if st != "" && strings.ToLower(r.ServiceType) != st {
+			continue
+		}
+		result = append(result, copyRFP(r))
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListRFPsByBuyer(ctx context.Context, buyerAddr string, limit int) ([]*RFP, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*RFP
+	for _, r := range m.rfps {
+		if r.BuyerAddr == buyerAddr {
+			result = append(result, copyRFP(r))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListClosingRFPs(ctx context.Context, before time.Time, limit int) ([]*RFP, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*RFP
+	for _, r := range m.rfps {
+		if r.Status == RFPBidding && r.BidDeadline.Before(before) {
+			result = append(result, copyRFP(r))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListUnawardedRFPs(ctx context.Context, revealedBefore time.Time, limit int) ([]*RFP, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*RFP
+	for _, r := range m.rfps {
+		if r.Status == RFPRevealed && r.UpdatedAt.Before(revealedBefore) {
+			result = append(result, copyRFP(r))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateBid(ctx context.Context, b *Bid) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bids[b.ID] = copyBid(b)
+	return nil
+}
+
+func (m *MemoryStore) GetBid(ctx context.Context, id string) (*Bid, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	b, ok := m.bids[id]
+	if !ok {
+		return nil, ErrBidNotFound
+	}
+	return copyBid(b), nil
+}
+
+func (m *MemoryStore) UpdateBid(ctx context.Context, b *Bid) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.bids[b.ID]; !ok {
+		return ErrBidNotFound
+	}
+	m.bids[b.ID] = copyBid(b)
+	return nil
+}
+
+func (m *MemoryStore) ListBidsByRFP(ctx context.Context, rfpID string, limit int) ([]*Bid, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Bid
+	for _, b := range m.bids {
+		if b.RFPID == rfpID {
+			result = append(result, copyBid(b))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListBidsBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Bid, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Bid
+	for _, b := range m.bids {
+		if b.SellerAddr == sellerAddr {
+			result = append(result, copyBid(b))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) GetBidBySeller(ctx context.Context, rfpID, sellerAddr string) (*Bid, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var latest *Bid
+	for _, b := range m.bids {
+		if b.RFPID != rfpID || b.SellerAddr != sellerAddr || b.Status == BidWithdrawn {
+			continue
+		}
+		if latest == nil || b.CreatedAt.After(latest.CreatedAt) {
+			latest = b
+		}
+	}
+	if latest == nil {
+		return nil, ErrBidNotFound
+	}
+	return copyBid(latest), nil
+}
+
+func (m *MemoryStore) CreateStrategy(ctx context.Context, st *Strategy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.strategies[st.ID] = copyStrategy(st)
+	return nil
+}
+
+func (m *MemoryStore) GetStrategy(ctx context.Context, id string) (*Strategy, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	st, ok := m.strategies[id]
+	if !ok {
+		return nil, ErrStrategyNotFound
+	}
+	return copyStrategy(st), nil
+}
+
+func (m *MemoryStore) UpdateStrategy(ctx context.Context, st *Strategy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.strategies[st.ID]; !ok {
+		return ErrStrategyNotFound
+	}
+	m.strategies[st.ID] = copyStrategy(st)
+	return nil
+}
+
+func (m *MemoryStore) ListStrategiesBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Strategy, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Strategy
+	for _, st := range m.strategies {
+		if st.SellerAddr == sellerAddr {
+			result = append(result, copyStrategy(st))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListEnabledStrategies(ctx context.Context, limit int) ([]*Strategy, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Strategy
+	for _, st := range m.strategies {
+		if st.Enabled {
+			result = append(result, copyStrategy(st))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateTemplate(ctx context.Context, tpl *Template) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.templates[tpl.ID] = copyTemplate(tpl)
+	return nil
+}
+
+func (m *MemoryStore) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tpl, ok := m.templates[id]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	return copyTemplate(tpl), nil
+}
+
+func (m *MemoryStore) UpdateTemplate(ctx context.Context, tpl *Template) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.templates[tpl.ID]; !ok {
+		return ErrTemplateNotFound
+	}
+	m.templates[tpl.ID] = copyTemplate(tpl)
+	return nil
+}
+
+func (m *MemoryStore) DeleteTemplate(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.templates[id]; !ok {
+		return ErrTemplateNotFound
+	}
+	delete(m.templates, id)
+	return nil
+}
+
+func (m *MemoryStore) ListTemplatesByBuyer(ctx context.Context, buyerAddr string, limit int) ([]*Template, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Template
+	for _, tpl := range m.templates {
+		if tpl.BuyerAddr == buyerAddr {
+			result = append(result, copyTemplate(tpl))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateExtension(ctx context.Context, e *Extension) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.extensions[e.ID] = copyExtension(e)
+	return nil
+}
+
+func (m *MemoryStore) GetExtension(ctx context.Context, id string) (*Extension, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.extensions[id]
+	if !ok {
+		return nil, ErrExtensionNotFound
+	}
+	return copyExtension(e), nil
+}
+
+func (m *MemoryStore) UpdateExtension(ctx context.Context, e *Extension) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.extensions[e.ID]; !ok {
+		return ErrExtensionNotFound
+	}
+	m.extensions[e.ID] = copyExtension(e)
+	return nil
+}
+
+func (m *MemoryStore) GetPendingExtension(ctx context.Context, rfpID string) (*Extension, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, e := range m.extensions {
+		if e.RFPID == rfpID && e.Status == ExtensionPending {
+			return copyExtension(e), nil
+		}
+	}
+	return nil, ErrExtensionNotFound
+}
+
+func (m *MemoryStore) ListExtensionsByRFP(ctx context.Context, rfpID string, limit int) ([]*Extension, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Extension
+	for _, e := range m.extensions {
+		if e.RFPID == rfpID {
+			result = append(result, copyExtension(e))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func copyRFP(r *RFP) *RFP {
+	cp := *r
+	if r.AwardedAt != nil {
+		t := *r.AwardedAt
+		cp.AwardedAt = &t
+	}
+	if r.Rubric != nil {
+		rb := *r.Rubric
+		cp.Rubric = &rb
+	}
+	cp.InvitedAddrs = append([]string(nil), r.InvitedAddrs...)
+	return &cp
+}
+
+func copyBid(b *Bid) *Bid {
+	cp := *b
+	if b.ScoreBreakdown != nil {
+		cp.ScoreBreakdown = make(map[string]float64, len(b.ScoreBreakdown))
+		for k, v := range b.ScoreBreakdown {
+			cp.ScoreBreakdown[k] = v
+		}
+	}
+	return &cp
+}
+
+func copyTemplate(tpl *Template) *Template {
+	cp := *tpl
+	return &cp
+}
+
+func copyStrategy(st *Strategy) *Strategy {
+	cp := *st
+	if st.LastRunAt != nil {
+		t := *st.LastRunAt
+		cp.LastRunAt = &t
+	}
+	return &cp
+}
+
+func copyExtension(e *Extension) *Extension {
+	cp := *e
+	cp.Approvals = append([]string(nil), e.Approvals...)
+	cp.Rejections = append([]string(nil), e.Rejections...)
+	return &cp
+}
+
+var _ Store = (*MemoryStore)(nil)
@@ -0,0 +1,919 @@
+// Package negotiation implements request-for-proposal (RFP) auctions for
+// agent services.
+//
+// Buyers post an RFP (service type, budget, bidding deadline). Sellers bid
+// a price at or below the budget; at the deadline the bids are ranked and
+// the buyer awards a winner. Negotiation is pure matchmaking — no funds
+// move here; the awarded pair settles through escrow, offers, or streams.
+//
+// Two bidding modes are supported:
+//
+//   - open: bids are public as they arrive and sellers may revise or
+//     withdraw them until the deadline. Later bidders can see and undercut
+//     earlier ones.
+//   - sealed: bid amounts are hidden from everyone but their owner until
+//     the deadline, bids are binding once submitted, and all bids are
+//     revealed and ranked at once. This prevents the race-to-the-bottom
+//     where each bidder undercuts the previous one by a cent.
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/traces"
+	"github.com/mbd888/alancoin/internal/usdc"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	ErrRFPNotFound  = errors.New("negotiation: rfp not found")
+	ErrBidNotFound  = errors.New("negotiation: bid not found")
+	ErrBiddingOver  = errors.New("negotiation: bidding closed")
+	ErrBiddingOpen  = errors.New("negotiation: bidding still open")
+	ErrSelfBid      = errors.New("negotiation: cannot bid on own rfp")
+	ErrAlreadyBid   = errors.New("negotiation: sealed bid already submitted")
+	ErrBidSealed    = errors.New("negotiation: sealed bids are binding until reveal")
+	ErrOverBudget   = errors.New("negotiation: bid exceeds rfp budget")
+	ErrBelowFloor   = errors.New("negotiation: seller reputation below rfp floor")
+	ErrCooldown     = errors.New("negotiation: retraction cool-down active")
+	ErrInvalidPrice = errors.New("negotiation: invalid price")
+	ErrInvalidMode  = errors.New("negotiation: invalid bidding mode")
+	ErrUnauthorized = errors.New("negotiation: not authorized")
+)
+
+// Mode selects how bids are disclosed during the bidding window.
+type Mode string
+
+const (
+	ModeOpen   Mode = "open"   // Bids visible and revisable until the deadline
+	ModeSealed Mode = "sealed" // Bids hidden and binding until revealed at the deadline
+)
+
+// RFPStatus represents the lifecycle of a request for proposals.
+type RFPStatus string
+
+const (
+	RFPBidding   RFPStatus = "bidding"   // Accepting bids until the deadline
+	RFPRevealed  RFPStatus = "revealed"  // Deadline passed, bids ranked, awaiting award
+	RFPAwarded   RFPStatus = "awarded"   // Buyer selected a winning bid
+	RFPCancelled RFPStatus = "cancelled" // Buyer withdrew the RFP
+	RFPExpired   RFPStatus = "expired"   // No award within the award window after reveal
+)
+
+// BidStatus represents the lifecycle of a bid.
+type BidStatus string
+
+const (
+	BidActive      BidStatus = "active"      // In the running
+	BidWithdrawn   BidStatus = "withdrawn"   // Seller pulled the bid for free (open mode, pre-deadline)
+	BidRetracted   BidStatus = "retracted"   // Seller pulled the bid with a reputation penalty
+	BidInvalidated BidStatus = "invalidated" // Seller's reputation fell below the RFP floor
+	BidWon         BidStatus = "won"
+	BidLost        BidStatus = "lost"
+)
+
+// DefaultBidWindow is the default bidding duration when none is given.
+const DefaultBidWindow = 1 * time.Hour
+
+// DefaultAwardWindow is how long a revealed RFP waits for an award before
+// expiring.
+const DefaultAwardWindow = 24 * time.Hour
+
+// RFP represents a buyer's request for proposals.
+type RFP struct {
+	ID             string     `json:"id"`
+	BuyerAddr      string     `json:"buyerAddr"`
+	ServiceType    string     `json:"serviceType"`
+	Description    string     `json:"description,omitempty"`
+	Budget         string     `json:"budget"`                   // Maximum acceptable price in USDC
+	MinSellerScore float64    `json:"minSellerScore,omitempty"` // Reputation floor (0-100) for bidders; 0 = no floor
+	Rubric         *Rubric    `json:"rubric,omitempty"`         // Composite bid scoring; nil = rank by price alone
+	AutoAward      bool       `json:"autoAward"`                // Award the top-scored bid automatically at reveal
+	Visibility     Visibility `json:"visibility"`               // Who can browse and bid
+	InvitedAddrs   []string   `json:"invitedAddrs,omitempty"`   // Allowed bidders for invite visibility
+	MinTier        string     `json:"minTier,omitempty"`        // Minimum reputation tier for tier visibility
+	Mode           Mode       `json:"mode"`
+	Status         RFPStatus  `json:"status"`
+	BidCount       int        `json:"bidCount"`
+	BidDeadline    time.Time  `json:"bidDeadline"`
+	WinningBidID   string     `json:"winningBidId,omitempty"`
+	AwardedAt      *time.Time `json:"awardedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// IsTerminal returns true if the RFP can no longer be awarded.
+func (r *RFP) IsTerminal() bool {
+	switch r.Status {
+	case RFPAwarded, RFPCancelled, RFPExpired:
+		return true
+	}
+	return false
+}
+
+// Bid represents a seller's bid against an RFP.
+type Bid struct {
+	ID             string             `json:"id"`
+	RFPID          string             `json:"rfpId"`
+	SellerAddr     string             `json:"sellerAddr"`
+	Price          string             `json:"price"`
+	Message        string             `json:"message,omitempty"`
+	LatencySLAMs   int                `json:"latencySlaMs,omitempty"`   // Promised response latency in milliseconds
+	PenaltyOffered string             `json:"penaltyOffered,omitempty"` // USDC penalty the seller offers on failure
+	Status         BidStatus          `json:"status"`
+	Rank           int                `json:"rank,omitempty"`           // 1 = best; set at reveal
+	Score          float64            `json:"score,omitempty"`          // Composite rubric score; set at reveal
+	ScoreBreakdown map[string]float64 `json:"scoreBreakdown,omitempty"` // Per-component scores behind Score
+	Sealed         bool               `json:"sealed,omitempty"`         // True on redacted views of hidden bids
+	CreatedAt      time.Time          `json:"createdAt"`
+	UpdatedAt      time.Time          `json:"updatedAt"`
+}
+
+// CreateRFPRequest is the input for posting an RFP.
+type CreateRFPRequest struct {
+	ServiceType    string   `json:"serviceType" binding:"required"`
+	Description    string   `json:"description"`
+	Budget         string   `json:"budget" binding:"required"`
+	MinSellerScore float64  `json:"minSellerScore"` // Reputation floor (0-100) for bidders
+	Rubric         *Rubric  `json:"rubric"`         // Optional composite scoring weights
+	AutoAward      bool     `json:"autoAward"`      // Award the top-scored bid at reveal; requires a rubric
+	Visibility     string   `json:"visibility"`     // "" = public
+	InvitedAddrs   []string `json:"invitedAddrs"`   // Allowed bidders for invite visibility
+	MinTier        string   `json:"minTier"`        // Minimum reputation tier for tier visibility
+	Mode           string   `json:"mode"`           // "" = open
+	BidsCloseIn    string   `json:"bidsCloseIn"`    // Duration, e.g. "30m"; default 1h
+}
+
+// BidRequest is the input for submitting a bid.
+type BidRequest struct {
+	Price          string `json:"price" binding:"required"`
+	Message        string `json:"message"`
+	LatencySLAMs   int    `json:"latencySlaMs"`   // Optional promised latency in milliseconds
+	PenaltyOffered string `json:"penaltyOffered"` // Optional USDC failure penalty
+}
+
+// AwardRequest selects a winning bid. An empty BidID awards the
+// lowest-priced active bid.
+type AwardRequest struct {
+	BidID string `json:"bidId"`
+}
+
+// Store persists RFP and bid data. List methods treat a limit <= 0 as
+// unlimited.
+type Store interface {
+	// RFPs
+	CreateRFP(ctx context.Context, r *RFP) error
+	GetRFP(ctx context.Context, id string) (*RFP, error)
+	UpdateRFP(ctx context.Context, r *RFP) error
+	ListRFPs(ctx context.Context, serviceType string, limit int) ([]*RFP, error)
+	ListRFPsByBuyer(ctx context.Context, buyerAddr string, limit int) ([]*RFP, error)
+	ListClosingRFPs(ctx context.Context, before time.Time, limit int) ([]*RFP, error)
+	ListUnawardedRFPs(ctx context.Context, revealedBefore time.Time, limit int) ([]*RFP, error)
+
+	// Bids
+	CreateBid(ctx context.Context, b *Bid) error
+	GetBid(ctx context.Context, id string) (*Bid, error)
+	UpdateBid(ctx context.Context, b *Bid) error
+	ListBidsByRFP(ctx context.Context, rfpID string, limit int) ([]*Bid, error)
+	ListBidsBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Bid, error)
+	GetBidBySeller(ctx context.Context, rfpID, sellerAddr string) (*Bid, error)
+
+	// Strategies
+	CreateStrategy(ctx context.Context, st *Strategy) error
+	GetStrategy(ctx context.Context, id string) (*Strategy, error)
+	UpdateStrategy(ctx context.Context, st *Strategy) error
+	ListStrategiesBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Strategy, error)
+	ListEnabledStrategies(ctx context.Context, limit int) ([]*Strategy, error)
+
+	// Templates
+	CreateTemplate(ctx context.Context, tpl *Template) error
+	GetTemplate(ctx context.Context, id string) (*Template, error)
+	UpdateTemplate(ctx context.Context, tpl *Template) error
+	DeleteTemplate(ctx context.Context, id string) error
+	ListTemplatesByBuyer(ctx context.Context, buyerAddr string, limit int) ([]*Template, error)
+
+	// Extensions
+	CreateExtension(ctx context.Context, e *Extension) error
+	GetExtension(ctx context.Context, id string) (*Extension, error)
+	UpdateExtension(ctx context.Context, e *Extension) error
+	GetPendingExtension(ctx context.Context, rfpID string) (*Extension, error)
+	ListExtensionsByRFP(ctx context.Context, rfpID string, limit int) ([]*Extension, error)
+}
+
+// Service implements RFP auction business logic.
+type Service struct {
+	store              Store
+	reputation         ReputationProvider
+	repImpact          ReputationImpactor
+	webhookEmitter     WebhookEmitter
+	retractionPenalty  int
+	retractionCooldown time.Duration
+	logger             *slog.Logger
+	locks              sync.Map
+}
+
+// NewService creates a new negotiation service.
+func NewService(store Store) *Service {
+	return &Service{
+		store:              store,
+		retractionPenalty:  DefaultRetractionPenalty,
+		retractionCooldown: DefaultRetractionCooldown,
+		logger:             slog.Default(),
+	}
+}
+
+// WithLogger sets a structured logger.
+func (s *Service) WithLogger(l *slog.Logger) *Service {
+	s.logger = l
+	return s
+}
+
+func (s *Service) rfpLock(id string) *sync.Mutex {
+	v, _ := s.locks.LoadOrStore(id, &sync.Mutex{})
+	return v.(*sync.Mutex)
+}
+
+func (s *Service) cleanupLock(id string) {
+	s.locks.Delete(id)
+}
+
+// CreateRFP posts a request for proposals and opens the bidding window.
+func (s *Service) CreateRFP(ctx context.Context, buyerAddr string, req CreateRFPRequest) (*RFP, error) {
+	ctx, span := traces.StartSpan(ctx, "negotiation.CreateRFP",
+		attribute.String("buyer", buyerAddr),
+		attribute.String("service_type", req.ServiceType),
+		attribute.String("mode", req.Mode),
+	)
+	defer span.End()
+
+	if err := validatePrice(req.Budget); err != nil {
+		return nil, err
+	}
+	if req.ServiceType == "" {
+		return nil, errors.New("serviceType is required")
+	}
+	if req.MinSellerScore < 0 || req.MinSellerScore > 100 {
+		return nil, errors.New("minSellerScore must be between 0 and 100")
+	}
+	if req.Rubric != nil {
+		if err := validateRubric(req.Rubric); err != nil {
+			return nil, err
+		}
+	}
+	if req.AutoAward && req.Rubric == nil {
+		return nil, fmt.Errorf("%w: autoAward requires a scoring rubric", ErrInvalidRubric)
+	}
+	visibility, invited, minTier, err := validateVisibility(req)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := ModeOpen
+	switch Mode(req.Mode) {
+	case "", ModeOpen:
+	case ModeSealed:
+		mode = ModeSealed
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidMode, req.Mode)
+	}
+
+	window := DefaultBidWindow
+	if req.BidsCloseIn != "" {
+		d, err := time.ParseDuration(req.BidsCloseIn)
+		if err == nil && d > 0 {
+			window = d
+		}
+	}
+
+	now := time.Now()
+	rfp := &RFP{
+		ID:             idgen.WithPrefix("rfp_"),
+		BuyerAddr:      strings.ToLower(buyerAddr),
+		ServiceType:    req.ServiceType,
+		Description:    req.Description,
+		Budget:         req.Budget,
+		MinSellerScore: req.MinSellerScore,
+		Rubric:         req.Rubric,
+		AutoAward:      req.AutoAward,
+		Visibility:     visibility,
+		InvitedAddrs:   invited,
+		MinTier:        minTier,
+		Mode:           mode,
+		Status:         RFPBidding,
+		BidDeadline:    now.Add(window),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.store.CreateRFP(ctx, rfp); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return rfp, nil
+}
+
+// GetRFP returns an RFP by ID. Non-public RFPs are not found for callers
+// outside their audience.
+func (s *Service) GetRFP(ctx context.Context, id, callerAddr string) (*RFP, error) {
+	rfp, err := s.store.GetRFP(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(ctx, rfp, callerAddr) {
+		return nil, ErrRFPNotFound
+	}
+	return rfp, nil
+}
+
+// ListRFPs returns RFPs still accepting bids that are visible to the
+// caller, optionally filtered by service type.
+func (s *Service) ListRFPs(ctx context.Context, serviceType, callerAddr string, limit int) ([]*RFP, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rfps, err := s.store.ListRFPs(ctx, serviceType, 0)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]*RFP, 0, len(rfps))
+	for _, rfp := range rfps {
+		if !s.canView(ctx, rfp, callerAddr) {
+			continue
+		}
+		visible = append(visible, rfp)
+		if len(visible) >= limit {
+			break
+		}
+	}
+	return visible, nil
+}
+
+// ListRFPsByBuyer returns a buyer's RFPs visible to the caller, newest
+// first.
+func (s *Service) ListRFPsByBuyer(ctx context.Context, buyerAddr, callerAddr string, limit int) ([]*RFP, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rfps, err := s.store.ListRFPsByBuyer(ctx, strings.ToLower(buyerAddr), 0)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]*RFP, 0, len(rfps))
+	for _, rfp := range rfps {
+		if !s.canView(ctx, rfp, callerAddr) {
+			continue
+		}
+		visible = append(visible, rfp)
+		if len(visible) >= limit {
+			break
+		}
+	}
+	return visible, nil
+}
+
+// SubmitBid places a bid against an open RFP. In open mode a seller's
+// repeat bid revises their earlier one; in sealed mode a seller gets
+// exactly one binding bid.
+func (s *Service) SubmitBid(ctx context.Context, rfpID, sellerAddr string, req BidRequest) (*Bid, error) {
+	ctx, span := traces.StartSpan(ctx, "negotiation.SubmitBid",
+		attribute.String("rfp_id", rfpID),
+		attribute.String("seller", sellerAddr),
+	)
+	defer span.End()
+
+	if err := validatePrice(req.Price); err != nil {
+		return nil, err
+	}
+	if req.PenaltyOffered != "" {
+		if err := validatePrice(req.PenaltyOffered); err != nil {
+			return nil, err
+		}
+	}
+	if req.LatencySLAMs < 0 {
+		return nil, errors.New("latencySlaMs must be non-negative")
+	}
+
+	mu := s.rfpLock(rfpID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	rfp, err := s.store.GetRFP(ctx, rfpID)
+	if err != nil {
+		return nil, err
+	}
+
+	seller := strings.ToLower(sellerAddr)
+	if seller == rfp.BuyerAddr {
+		return nil, ErrSelfBid
+	}
+	if !s.canView(ctx, rfp, seller) {
+		return nil, ErrNotInvited
+	}
+	if rfp.Status != RFPBidding || time.Now().After(rfp.BidDeadline) {
+		return nil, ErrBiddingOver
+	}
+	if rfp.MinSellerScore > 0 && s.reputation != nil {
+		score, _, err := s.reputation.GetScore(ctx, seller)
+		if err != nil || score < rfp.MinSellerScore {
+			return nil, ErrBelowFloor
+		}
+	}
+
+	price, _ := usdc.Parse(req.Price)
+	budget, _ := usdc.Parse(rfp.Budget)
+	if price.Cmp(budget) > 0 {
+		return nil, ErrOverBudget
+	}
+
+	existing, err := s.store.GetBidBySeller(ctx, rfpID, seller)
+	if err != nil && !errors.Is(err, ErrBidNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.Status == BidRetracted {
+		if wait := s.retractionCooldown - time.Since(existing.UpdatedAt); wait > 0 {
+			return nil, fmt.Errorf("%w: %s remaining", ErrCooldown, wait.Round(time.Second))
+		}
+	}
+	if existing != nil && existing.Status == BidActive {
+		if rfp.Mode == ModeSealed {
+			return nil, ErrAlreadyBid
+		}
+		// Open mode: revise the standing bid in place.
+		existing.Price = req.Price
+		existing.Message = req.Message
+		existing.LatencySLAMs = req.LatencySLAMs
+		existing.PenaltyOffered = req.PenaltyOffered
+		existing.UpdatedAt = time.Now()
+		if err := s.store.UpdateBid(ctx, existing); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	now := time.Now()
+	bid := &Bid{
+		ID:             idgen.WithPrefix("bid_"),
+		RFPID:          rfpID,
+		SellerAddr:     seller,
+		Price:          req.Price,
+		Message:        req.Message,
+		LatencySLAMs:   req.LatencySLAMs,
+		PenaltyOffered: req.PenaltyOffered,
+		Status:         BidActive,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.store.CreateBid(ctx, bid); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	rfp.BidCount++
+	rfp.UpdatedAt = now
+	if err := s.store.UpdateRFP(ctx, rfp); err != nil {
+		s.logger.Warn("negotiation: bid count update failed", "rfp", rfpID, "error", err)
+	}
+
+	return bid, nil
+}
+
+// GetBid returns a bid, redacted for callers other than its owner while
+// the RFP is sealed and still bidding.
+func (s *Service) GetBid(ctx context.Context, id, callerAddr string) (*Bid, error) {
+	bid, err := s.store.GetBid(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rfp, err := s.store.GetRFP(ctx, bid.RFPID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(ctx, rfp, callerAddr) {
+		return nil, ErrBidNotFound
+	}
+	if sealedNow(rfp) && bid.SellerAddr != strings.ToLower(callerAddr) {
+		redactBid(bid)
+	}
+	return bid, nil
+}
+
+// ListBids returns an RFP's bids. While a sealed RFP is still bidding,
+// every bid but the caller's own has its price and message hidden; after
+// the deadline all bids are visible with their reveal ranks.
+func (s *Service) ListBids(ctx context.Context, rfpID, callerAddr string, limit int) ([]*Bid, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rfp, err := s.store.GetRFP(ctx, rfpID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.canView(ctx, rfp, callerAddr) {
+		return nil, ErrRFPNotFound
+	}
+	bids, err := s.store.ListBidsByRFP(ctx, rfpID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if sealedNow(rfp) {
+		caller := strings.ToLower(callerAddr)
+		for _, b := range bids {
+			if b.SellerAddr != caller {
+				redactBid(b)
+			}
+		}
+	}
+	return bids, nil
+}
+
+// WithdrawBid pulls a seller's active bid. Sealed bids are binding and
+// cannot be withdrawn.
+func (s *Service) WithdrawBid(ctx context.Context, bidID, callerAddr string) (*Bid, error) {
+	bid, err := s.store.GetBid(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	mu := s.rfpLock(bid.RFPID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	bid, err = s.store.GetBid(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	if bid.SellerAddr != strings.ToLower(callerAddr) {
+		return nil, ErrUnauthorized
+	}
+	if bid.Status != BidActive {
+		return nil, ErrBiddingOver
+	}
+
+	rfp, err := s.store.GetRFP(ctx, bid.RFPID)
+	if err != nil {
+		return nil, err
+	}
+	if rfp.Mode == ModeSealed {
+		return nil, ErrBidSealed
+	}
+	if rfp.Status != RFPBidding || time.Now().After(rfp.BidDeadline) {
+		return nil, ErrBiddingOver
+	}
+
+	bid.Status = BidWithdrawn
+	bid.UpdatedAt = time.Now()
+	if err := s.store.UpdateBid(ctx, bid); err != nil {
+		return nil, err
+	}
+	return bid, nil
+}
+
+// Reveal transitions a bidding RFP past its deadline into the revealed
+// state, ranking active bids by price. It is a no-op if already revealed.
+func (s *Service) Reveal(ctx context.Context, rfpID string) (*RFP, error) {
+	mu := s.rfpLock(rfpID)
+	mu.Lock()
+	defer mu.Unlock()
+	return s.revealLocked(ctx, rfpID)
+}
+
+func (s *Service) revealLocked(ctx context.Context, rfpID string) (*RFP, error) {
+	rfp, err := s.store.GetRFP(ctx, rfpID)
+	if err != nil {
+		return nil, err
+	}
+	if rfp.Status == RFPRevealed {
+		return rfp, nil
+	}
+	if rfp.Status != RFPBidding {
+		return nil, ErrBiddingOver
+	}
+	if time.Now().Before(rfp.BidDeadline) {
+		return nil, ErrBiddingOpen
+	}
+
+	bids, err := s.store.ListBidsByRFP(ctx, rfpID, 0)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateBelowFloor(ctx, rfp, bids)
+	var active []*Bid
+	if rfp.Rubric != nil {
+		s.scoreBids(ctx, rfp, bids)
+		active = rankByScore(bids)
+	} else {
+		active = rankBids(bids)
+	}
+	for _, b := range active {
+		b.UpdatedAt = time.Now()
+		if err := s.store.UpdateBid(ctx, b); err != nil {
+			return nil, err
+		}
+	}
+
+	rfp.Status = RFPRevealed
+	rfp.UpdatedAt = time.Now()
+	if err := s.store.UpdateRFP(ctx, rfp); err != nil {
+		return nil, err
+	}
+	s.lapseExtension(ctx, rfpID)
+
+	s.logger.Info("negotiation: rfp revealed", "rfp", rfpID, "bids", len(active))
+	return rfp, nil
+}
+
+// Award selects the winning bid. In open mode the buyer may award at any
+// point; in sealed mode only after the deadline, when every bid is on the
+// table. An empty bidID awards the lowest-priced active bid.
+func (s *Service) Award(ctx context.Context, rfpID, buyerAddr, bidID string) (*Bid, error) {
+	ctx, span := traces.StartSpan(ctx, "negotiation.Award",
+		attribute.String("rfp_id", rfpID),
+		attribute.String("bid_id", bidID),
+	)
+	defer span.End()
+
+	mu := s.rfpLock(rfpID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	rfp, err := s.store.GetRFP(ctx, rfpID)
+	if err != nil {
+		return nil, err
+	}
+	if rfp.BuyerAddr != strings.ToLower(buyerAddr) {
+		return nil, ErrUnauthorized
+	}
+	if rfp.IsTerminal() {
+		return nil, ErrBiddingOver
+	}
+
+	if rfp.Mode == ModeSealed {
+		if rfp.Status == RFPBidding {
+			if time.Now().Before(rfp.BidDeadline) {
+				return nil, ErrBiddingOpen
+			}
+			if _, err := s.revealLocked(ctx, rfpID); err != nil {
+				return nil, err
+			}
+			if rfp, err = s.store.GetRFP(ctx, rfpID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	bids, err := s.store.ListBidsByRFP(ctx, rfpID, 0)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateBelowFloor(ctx, rfp, bids)
+
+	// With a rubric the default winner is the highest composite score;
+	// without one it is the lowest price.
+	better := lessBid
+	if rfp.Rubric != nil {
+		s.scoreBids(ctx, rfp, bids)
+		better = betterScore
+	}
+
+	var winner *Bid
+	for _, b := range bids {
+		if b.Status != BidActive {
+			continue
+		}
+		if bidID != "" {
+			if b.ID == bidID {
+				winner = b
+			}
+			continue
+		}
+		if winner == nil || better(b, winner) {
+			winner = b
+		}
+	}
+	if winner == nil {
+		return nil, ErrBidNotFound
+	}
+
+	now := time.Now()
+	winner.Status = BidWon
+	winner.UpdatedAt = now
+	if err := s.store.UpdateBid(ctx, winner); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	for _, b := range bids {
+		if b.ID == winner.ID || b.Status != BidActive {
+			continue
+		}
+		b.Status = BidLost
+		b.UpdatedAt = now
+		if err := s.store.UpdateBid(ctx, b); err != nil {
+			s.logger.Warn("negotiation: losing bid update failed", "bid", b.ID, "error", err)
+		}
+	}
+
+	rfp.Status = RFPAwarded
+	rfp.WinningBidID = winner.ID
+	rfp.AwardedAt = &now
+	rfp.UpdatedAt = now
+	if err := s.store.UpdateRFP(ctx, rfp); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	s.lapseExtension(ctx, rfpID)
+
+	s.cleanupLock(rfpID)
+	return winner, nil
+}
+
+// CancelRFP withdraws an RFP before award; all active bids are marked lost.
+func (s *Service) CancelRFP(ctx context.Context, rfpID, buyerAddr string) (*RFP, error) {
+	mu := s.rfpLock(rfpID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	rfp, err := s.store.GetRFP(ctx, rfpID)
+	if err != nil {
+		return nil, err
+	}
+	if rfp.BuyerAddr != strings.ToLower(buyerAddr) {
+		return nil, ErrUnauthorized
+	}
+	if rfp.IsTerminal() {
+		return nil, ErrBiddingOver
+	}
+
+	if err := s.closeOutBids(ctx, rfpID); err != nil {
+		return nil, err
+	}
+
+	rfp.Status = RFPCancelled
+	rfp.UpdatedAt = time.Now()
+	if err := s.store.UpdateRFP(ctx, rfp); err != nil {
+		return nil, err
+	}
+	s.lapseExtension(ctx, rfpID)
+
+	s.cleanupLock(rfpID)
+	return rfp, nil
+}
+
+// ForceCloseBidding reveals RFPs whose deadline has passed and expires
+// revealed RFPs that went unawarded for the award window. Called
+// periodically by the timer.
+func (s *Service) ForceCloseBidding(ctx context.Context) (int, error) {
+	now := time.Now()
+	processed := 0
+
+	closing, err := s.store.ListClosingRFPs(ctx, now, 100)
+	if err != nil {
+		return 0, err
+	}
+	for _, rfp := range closing {
+		if _, err := s.Reveal(ctx, rfp.ID); err != nil {
+			s.logger.Warn("negotiation: reveal failed", "rfp", rfp.ID, "error", err)
+			continue
+		}
+		if rfp.AutoAward {
+			winner, err := s.Award(ctx, rfp.ID, rfp.BuyerAddr, "")
+			if err != nil {
+				s.logger.Warn("negotiation: auto-award failed", "rfp", rfp.ID, "error", err)
+			} else {
+				s.logger.Info("negotiation: rfp auto-awarded",
+					"rfp", rfp.ID, "bid", winner.ID, "seller", winner.SellerAddr, "score", winner.Score)
+			}
+		}
+		processed++
+	}
+
+	stale, err := s.store.ListUnawardedRFPs(ctx, now.Add(-DefaultAwardWindow), 100)
+	if err != nil {
+		return processed, err
+	}
+	for _, rfp := range stale {
+		if err := s.expireRFP(ctx, rfp.ID); err != nil {
+			s.logger.Warn("negotiation: expire failed", "rfp", rfp.ID, "error", err)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (s *Service) expireRFP(ctx context.Context, rfpID string) error {
+	mu := s.rfpLock(rfpID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	rfp, err := s.store.GetRFP(ctx, rfpID)
+	if err != nil {
+		return err
+	}
+	if rfp.Status != RFPRevealed {
+		return nil
+	}
+
+	if err := s.closeOutBids(ctx, rfpID); err != nil {
+		return err
+	}
+
+	rfp.Status = RFPExpired
+	rfp.UpdatedAt = time.Now()
+	if err := s.store.UpdateRFP(ctx, rfp); err != nil {
+		return err
+	}
+
+	s.cleanupLock(rfpID)
+	return nil
+}
+
+// closeOutBids marks every active bid on the RFP as lost.
+func (s *Service) closeOutBids(ctx context.Context, rfpID string) error {
+	bids, err := s.store.ListBidsByRFP(ctx, rfpID, 0)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, b := range bids {
+		if b.Status != BidActive {
+			continue
+		}
+		b.Status = BidLost
+		b.UpdatedAt = now
+		if err := s.store.UpdateBid(ctx, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sealedNow reports whether the RFP's bids are currently hidden.
+func sealedNow(r *RFP) bool {
+	return r.Mode == ModeSealed && r.Status == RFPBidding && time.Now().Before(r.BidDeadline)
+}
+
+func redactBid(b *Bid) {
+	b.Price = ""
+	b.Message = ""
+	b.LatencySLAMs = 0
+	b.PenaltyOffered = ""
+	b.Rank = 0
+	b.Score = 0
+	b.ScoreBreakdown = nil
+	b.Sealed = true
+}
+
+// rankBids assigns 1-based ranks to active bids, cheapest first (ties
+// broken by submission time), and returns them in rank order.
+func rankBids(bids []*Bid) []*Bid {
+	var active []*Bid
+	for _, b := range bids {
+		if b.Status == BidActive {
+			active = append(active, b)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return lessBid(active[i], active[j]) })
+	for i, b := range active {
+		b.Rank = i + 1
+	}
+	return active
+}
+
+// lessBid orders bids by price ascending, earliest submission first on ties.
+func lessBid(a, b *Bid) bool {
+	pa, _ := usdc.Parse(a.Price)
+	pb, _ := usdc.Parse(b.Price)
+	switch pa.Cmp(pb) {
+	case -1:
+		return true
+	case 1:
+		return false
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+func validatePrice(price string) error {
+	price = strings.TrimSpace(price)
+	if price == "" {
+		return fmt.Errorf("%w: empty price", ErrInvalidPrice)
+	}
+	parsed, ok := usdc.Parse(price)
+	if !ok {
+		return fmt.Errorf("%w: %q is not a valid number", ErrInvalidPrice, price)
+	}
+	if parsed.Sign() <= 0 {
+		return fmt.Errorf("%w: price must be positive", ErrInvalidPrice)
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const (
+	buyerAddr   = "0x1111111111111111111111111111111111111111"
+	sellerAddr  = "0x2222222222222222222222222222222222222222"
+	sellerAddr2 = "0x3333333333333333333333333333333333333333"
+	sellerAddr3 = "0x4444444444444444444444444444444444444444"
+)
+
+func newTestService() (*Service, *MemoryStore) {
+	store := NewMemoryStore()
+	return NewService(store), store
+}
+
+func createTestRFP(t *testing.T, svc *Service, mode string) *RFP {
+	t.Helper()
+	rfp, err := svc.CreateRFP(context.Background(), buyerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "1.000000",
+		Mode:        mode,
+	})
+	if err != nil {
+		t.Fatalf("CreateRFP failed: %v", err)
+	}
+	return rfp
+}
+
+// passDeadline rewinds the RFP's bidding deadline into the past.
+func passDeadline(t *testing.T, store *MemoryStore, rfpID string) {
+	t.Helper()
+	rfp, err := store.GetRFP(context.Background(), rfpID)
+	if err != nil {
+		t.Fatalf("GetRFP failed: %v", err)
+	}
+	rfp.BidDeadline = time.Now().Add(-time.Minute)
+	if err := store.UpdateRFP(context.Background(), rfp); err != nil {
+		t.Fatalf("UpdateRFP failed: %v", err)
+	}
+}
+
+func TestCreateRFPValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.CreateRFP(ctx, buyerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "0",
+	}); !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice for zero budget, got %v", err)
+	}
+
+	if _, err := svc.CreateRFP(ctx, buyerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "1.000000",
+		Mode:        "dutch",
+	}); !errors.Is(err, ErrInvalidMode) {
+		t.Errorf("expected ErrInvalidMode, got %v", err)
+	}
+
+	rfp := createTestRFP(t, svc, "")
+	if rfp.Mode != ModeOpen {
+		t.Errorf("expected default mode open, got %s", rfp.Mode)
+	}
+	if rfp.Status != RFPBidding {
+		t.Errorf("expected status bidding, got %s", rfp.Status)
+	}
+}
+
+func TestSubmitBidRules(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, buyerAddr, BidRequest{Price: "0.500000"}); !errors.Is(err, ErrSelfBid) {
+		t.Errorf("expected ErrSelfBid, got %v", err)
+	}
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "1.500000"}); !errors.Is(err, ErrOverBudget) {
+		t.Errorf("expected ErrOverBudget, got %v", err)
+	}
+
+	bid, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.800000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if bid.Status != BidActive {
+		t.Errorf("expected active bid, got %s", bid.Status)
+	}
+
+	// Open mode: a repeat bid revises the standing one.
+	revised, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.700000"})
+	if err != nil {
+		t.Fatalf("revising bid failed: %v", err)
+	}
+	if revised.ID != bid.ID {
+		t.Errorf("expected revision to keep bid ID %s, got %s", bid.ID, revised.ID)
+	}
+	if revised.Price != "0.700000" {
+		t.Errorf("expected revised price 0.700000, got %s", revised.Price)
+	}
+
+	updated, _ := svc.GetRFP(ctx, rfp.ID, buyerAddr)
+	if updated.BidCount != 1 {
+		t.Errorf("expected bid count 1 after revision, got %d", updated.BidCount)
+	}
+
+	passDeadline(t, store, rfp.ID)
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.600000"}); !errors.Is(err, ErrBiddingOver) {
+		t.Errorf("expected ErrBiddingOver after deadline, got %v", err)
+	}
+}
+
+func TestOpenModeBidsVisible(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.800000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	// Another seller sees the standing bid and can undercut it.
+	bids, err := svc.ListBids(ctx, rfp.ID, sellerAddr2, 10)
+	if err != nil {
+		t.Fatalf("ListBids failed: %v", err)
+	}
+	if len(bids) != 1 || bids[0].Price != "0.800000" || bids[0].Sealed {
+		t.Errorf("expected visible bid at 0.800000, got %+v", bids[0])
+	}
+}
+
+func TestSealedBidsHiddenUntilDeadline(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "sealed")
+
+	b1, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.800000", Message: "fast"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.750000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	// A rival sees that a bid exists but not its price or message.
+	bids, err := svc.ListBids(ctx, rfp.ID, sellerAddr2, 10)
+	if err != nil {
+		t.Fatalf("ListBids failed: %v", err)
+	}
+	for _, b := range bids {
+		if b.SellerAddr == sellerAddr2 {
+			if b.Sealed || b.Price != "0.750000" {
+				t.Errorf("expected own bid visible, got %+v", b)
+			}
+			continue
+		}
+		if !b.Sealed || b.Price != "" || b.Message != "" {
+			t.Errorf("expected rival bid redacted, got %+v", b)
+		}
+	}
+
+	// Same redaction on direct bid lookup.
+	got, err := svc.GetBid(ctx, b1.ID, sellerAddr2)
+	if err != nil {
+		t.Fatalf("GetBid failed: %v", err)
+	}
+	if !got.Sealed || got.Price != "" {
+		t.Errorf("expected redacted bid, got %+v", got)
+	}
+
+	// Sealed bids are one-shot and binding.
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.700000"}); !errors.Is(err, ErrAlreadyBid) {
+		t.Errorf("expected ErrAlreadyBid, got %v", err)
+	}
+	if _, err := svc.WithdrawBid(ctx, b1.ID, sellerAddr); !errors.Is(err, ErrBidSealed) {
+		t.Errorf("expected ErrBidSealed, got %v", err)
+	}
+
+	// After the deadline everything is revealed and ranked at once.
+	passDeadline(t, store, rfp.ID)
+	if _, err := svc.Reveal(ctx, rfp.ID); err != nil {
+		t.Fatalf("Reveal failed: %v", err)
+	}
+	bids, err = svc.ListBids(ctx, rfp.ID, sellerAddr2, 10)
+	if err != nil {
+		t.Fatalf("ListBids failed: %v", err)
+	}
+	ranks := map[string]int{}
+	for _, b := range bids {
+		if b.Sealed || b.Price == "" {
+			t.Errorf("expected revealed bid, got %+v", b)
+		}
+		ranks[b.SellerAddr] = b.Rank
+	}
+	if ranks[sellerAddr2] != 1 || ranks[sellerAddr] != 2 {
+		t.Errorf("expected cheaper bid ranked 1, got %v", ranks)
+	}
+}
+
+func TestSealedAwardWaitsForDeadline(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "sealed")
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.800000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.750000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	if _, err := svc.Award(ctx, rfp.ID, buyerAddr, ""); !errors.Is(err, ErrBiddingOpen) {
+		t.Errorf("expected ErrBiddingOpen before deadline, got %v", err)
+	}
+
+	passDeadline(t, store, rfp.ID)
+
+	// Award auto-reveals past the deadline; default picks the lowest bid.
+	winner, err := svc.Award(ctx, rfp.ID, buyerAddr, "")
+	if err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if winner.SellerAddr != sellerAddr2 {
+		t.Errorf("expected lowest bidder to win, got %s", winner.SellerAddr)
+	}
+	if winner.Status != BidWon {
+		t.Errorf("expected won status, got %s", winner.Status)
+	}
+
+	updated, _ := svc.GetRFP(ctx, rfp.ID, buyerAddr)
+	if updated.Status != RFPAwarded || updated.WinningBidID != winner.ID {
+		t.Errorf("expected awarded RFP with winner %s, got %+v", winner.ID, updated)
+	}
+
+	bids, _ := svc.ListBids(ctx, rfp.ID, buyerAddr, 10)
+	for _, b := range bids {
+		if b.ID != winner.ID && b.Status != BidLost {
+			t.Errorf("expected losing bid, got %+v", b)
+		}
+	}
+}
+
+func TestOpenModeEarlyAward(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	bid, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.800000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	if _, err := svc.Award(ctx, rfp.ID, sellerAddr, bid.ID); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-buyer, got %v", err)
+	}
+
+	// Open mode allows accepting a bid before the deadline.
+	winner, err := svc.Award(ctx, rfp.ID, buyerAddr, bid.ID)
+	if err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if winner.ID != bid.ID {
+		t.Errorf("expected bid %s to win, got %s", bid.ID, winner.ID)
+	}
+
+	if _, err := svc.Award(ctx, rfp.ID, buyerAddr, bid.ID); !errors.Is(err, ErrBiddingOver) {
+		t.Errorf("expected ErrBiddingOver on double award, got %v", err)
+	}
+}
+
+func TestWithdrawBidOpenMode(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	bid, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.800000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	if _, err := svc.WithdrawBid(ctx, bid.ID, sellerAddr2); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+
+	withdrawn, err := svc.WithdrawBid(ctx, bid.ID, sellerAddr)
+	if err != nil {
+		t.Fatalf("WithdrawBid failed: %v", err)
+	}
+	if withdrawn.Status != BidWithdrawn {
+		t.Errorf("expected withdrawn status, got %s", withdrawn.Status)
+	}
+
+	// A withdrawn open bid can be replaced with a fresh one.
+	fresh, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.750000"})
+	if err != nil {
+		t.Fatalf("rebid after withdraw failed: %v", err)
+	}
+	if fresh.ID == bid.ID {
+		t.Error("expected a new bid after withdrawal")
+	}
+}
+
+func TestCancelRFP(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "sealed")
+
+	bid, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.800000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	if _, err := svc.CancelRFP(ctx, rfp.ID, sellerAddr); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+
+	cancelled, err := svc.CancelRFP(ctx, rfp.ID, buyerAddr)
+	if err != nil {
+		t.Fatalf("CancelRFP failed: %v", err)
+	}
+	if cancelled.Status != RFPCancelled {
+		t.Errorf("expected cancelled status, got %s", cancelled.Status)
+	}
+
+	got, _ := svc.GetBid(ctx, bid.ID, sellerAddr)
+	if got.Status != BidLost {
+		t.Errorf("expected bid lost after cancel, got %s", got.Status)
+	}
+}
+
+func TestForceCloseBidding(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+
+	sealed := createTestRFP(t, svc, "sealed")
+	if _, err := svc.SubmitBid(ctx, sealed.ID, sellerAddr, BidRequest{Price: "0.800000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	passDeadline(t, store, sealed.ID)
+
+	processed, err := svc.ForceCloseBidding(ctx)
+	if err != nil {
+		t.Fatalf("ForceCloseBidding failed: %v", err)
+	}
+	if processed != 1 {
+		t.Errorf("expected 1 processed RFP, got %d", processed)
+	}
+
+	revealed, _ := svc.GetRFP(ctx, sealed.ID, buyerAddr)
+	if revealed.Status != RFPRevealed {
+		t.Errorf("expected revealed status, got %s", revealed.Status)
+	}
+
+	// A revealed RFP unawarded past the award window expires.
+	revealed.UpdatedAt = time.Now().Add(-DefaultAwardWindow - time.Minute)
+	if err := store.UpdateRFP(ctx, revealed); err != nil {
+		t.Fatalf("UpdateRFP failed: %v", err)
+	}
+	if _, err := svc.ForceCloseBidding(ctx); err != nil {
+		t.Fatalf("ForceCloseBidding failed: %v", err)
+	}
+
+	expired, _ := svc.GetRFP(ctx, sealed.ID, buyerAddr)
+	if expired.Status != RFPExpired {
+		t.Errorf("expected expired status, got %s", expired.Status)
+	}
+}
@@ -0,0 +1,691 @@
+package negotiation
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresStore persists RFP and bid data in PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new PostgreSQL-backed negotiation store.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const rfpColumns = `id, buyer_addr, service_type, description, budget,
+	min_seller_score, rubric, auto_award, visibility, invited_addrs, min_tier,
+	mode, status, bid_count, bid_deadline,
+	winning_bid_id, awarded_at, created_at, updated_at`
+
+const bidColumns = `id, rfp_id, seller_addr, price, message,
+	latency_sla_ms, penalty_offered, status, rank, score, score_breakdown,
+	created_at, updated_at`
+
+// effectiveLimit caps unbounded list queries; a limit <= 0 means no limit.
+func effectiveLimit(limit int) int {
+	if limit <= 0 {
+		return 10000
+	}
+	return limit
+}
+
+func (p *PostgresStore) CreateRFP(ctx context.Context, r *RFP) error {
+	var rubricJSON []byte
+	if r.Rubric != nil {
+		rubricJSON, _ = json.Marshal(r.Rubric)
+	}
+	invited := r.InvitedAddrs
+	if invited == nil {
+		invited = []string{}
+	}
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO rfps (
+			id, buyer_addr, service_type, description, budget,
+			min_seller_score, rubric, auto_award, visibility, invited_addrs, min_tier,
+			mode, status, bid_count, bid_deadline,
+			winning_bid_id, awarded_at, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5::NUMERIC(20,6),
+			$6, $7, $8, $9, $10, $11,
+			$12, $13, $14, $15,
+			$16, $17, $18, $19
+		)`,
+		r.ID, r.BuyerAddr, r.ServiceType, r.Description, r.Budget,
+		r.MinSellerScore, rubricJSON, r.AutoAward, string(r.Visibility), pq.Array(invited), r.MinTier,
+		string(r.Mode), string(r.Status), r.BidCount, r.BidDeadline,
+		nullString(r.WinningBidID), nullTime(r.AwardedAt), r.CreatedAt, r.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetRFP(ctx context.Context, id string) (*RFP, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+rfpColumns+` FROM rfps WHERE id = $1`, id)
+	r, err := scanRFP(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrRFPNotFound
+	}
+	return r, err
+}
+
+func (p *PostgresStore) UpdateRFP(ctx context.Context, r *RFP) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE rfps SET
+			status = $1, bid_count = $2, winning_bid_id = $3,
+			awarded_at = $4, updated_at = $5
+		WHERE id = $6`,
+		string(r.Status), r.BidCount, nullString(r.WinningBidID),
+		nullTime(r.AwardedAt), r.UpdatedAt, r.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrRFPNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListRFPs(ctx context.Context, serviceType string, limit int) ([]*RFP, error) {
+	query := `
+		SELECT ` + rfpColumns + `
+		FROM rfps
+		WHERE status = 'bidding'`
+	args := []interface{}{effectiveLimit(limit)}
+	if serviceType != "" {
+		query += ` AND service_type = $2`
+		args = append(args, serviceType)
+	}
+	query += `
+		ORDER BY bid_deadline ASC
+		LIMIT $1`
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRFPs(rows)
+}
+
+func (p *PostgresStore) ListRFPsByBuyer(ctx context.Context, buyerAddr string, limit int) ([]*RFP, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+rfpColumns+`
+		FROM rfps
+		WHERE buyer_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, buyerAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRFPs(rows)
+}
+
+func (p *PostgresStore) ListClosingRFPs(ctx context.Context, before time.Time, limit int) ([]*RFP, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+rfpColumns+`
+		FROM rfps
+		WHERE status = 'bidding' AND bid_deadline < $1
+		ORDER BY bid_deadline ASC
+		LIMIT $2`, before, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRFPs(rows)
+}
+
+func (p *PostgresStore) ListUnawardedRFPs(ctx context.Context, revealedBefore time.Time, limit int) ([]*RFP, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+rfpColumns+`
+		FROM rfps
+		WHERE status = 'revealed' AND updated_at < $1
+		ORDER BY updated_at ASC
+		LIMIT $2`, revealedBefore, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRFPs(rows)
+}
+
+func (p *PostgresStore) CreateBid(ctx context.Context, b *Bid) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO rfp_bids (
+			id, rfp_id, seller_addr, price, message,
+			latency_sla_ms, penalty_offered, status, rank, score, score_breakdown,
+			created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4::NUMERIC(20,6), $5,
+			$6, $7::NUMERIC(20,6), $8, $9, $10, $11,
+			$12, $13
+		)`,
+		b.ID, b.RFPID, b.SellerAddr, b.Price, b.Message,
+		b.LatencySLAMs, nullString(b.PenaltyOffered), string(b.Status), b.Rank, b.Score, breakdownJSON(b),
+		b.CreatedAt, b.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetBid(ctx context.Context, id string) (*Bid, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+bidColumns+` FROM rfp_bids WHERE id = $1`, id)
+	b, err := scanBid(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrBidNotFound
+	}
+	return b, err
+}
+
+func (p *PostgresStore) UpdateBid(ctx context.Context, b *Bid) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE rfp_bids SET
+			price = $1::NUMERIC(20,6), message = $2, latency_sla_ms = $3,
+			penalty_offered = $4::NUMERIC(20,6), status = $5, rank = $6,
+			score = $7, score_breakdown = $8, updated_at = $9
+		WHERE id = $10`,
+		b.Price, b.Message, b.LatencySLAMs,
+		nullString(b.PenaltyOffered), string(b.Status), b.Rank,
+		b.Score, breakdownJSON(b), b.UpdatedAt, b.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrBidNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListBidsByRFP(ctx context.Context, rfpID string, limit int) ([]*Bid, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+bidColumns+`
+		FROM rfp_bids
+		WHERE rfp_id = $1
+		ORDER BY created_at ASC
+		LIMIT $2`, rfpID, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanBids(rows)
+}
+
+func (p *PostgresStore) ListBidsBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Bid, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+bidColumns+`
+		FROM rfp_bids
+		WHERE seller_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, sellerAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanBids(rows)
+}
+
+func (p *PostgresStore) GetBidBySeller(ctx context.Context, rfpID, sellerAddr string) (*Bid, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT `+bidColumns+`
+		FROM rfp_bids
+		WHERE rfp_id = $1 AND seller_addr = $2 AND status != 'withdrawn'
+		ORDER BY created_at DESC
+		LIMIT 1`, rfpID, sellerAddr)
+	b, err := scanBid(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrBidNotFound
+	}
+	return b, err
+}
+
+const strategyColumns = `id, seller_addr, service_type, min_price, target_margin,
+	max_concurrent, min_buyer_score, enabled, bids_placed, last_run_at,
+	created_at, updated_at`
+
+func (p *PostgresStore) CreateStrategy(ctx context.Context, st *Strategy) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO bid_strategies (
+			id, seller_addr, service_type, min_price, target_margin,
+			max_concurrent, min_buyer_score, enabled, bids_placed, last_run_at,
+			created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4::NUMERIC(20,6), $5::NUMERIC(8,4),
+			$6, $7, $8, $9, $10,
+			$11, $12
+		)`,
+		st.ID, st.SellerAddr, st.ServiceType, st.MinPrice, st.TargetMargin,
+		st.MaxConcurrent, st.MinBuyerScore, st.Enabled, st.BidsPlaced, nullTime(st.LastRunAt),
+		st.CreatedAt, st.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetStrategy(ctx context.Context, id string) (*Strategy, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+strategyColumns+` FROM bid_strategies WHERE id = $1`, id)
+	st, err := scanStrategy(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrStrategyNotFound
+	}
+	return st, err
+}
+
+func (p *PostgresStore) UpdateStrategy(ctx context.Context, st *Strategy) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE bid_strategies SET
+			enabled = $1, bids_placed = $2, last_run_at = $3,
+			updated_at = $4
+		WHERE id = $5`,
+		st.Enabled, st.BidsPlaced, nullTime(st.LastRunAt),
+		st.UpdatedAt, st.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrStrategyNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListStrategiesBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Strategy, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+strategyColumns+`
+		FROM bid_strategies
+		WHERE seller_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, sellerAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanStrategies(rows)
+}
+
+func (p *PostgresStore) ListEnabledStrategies(ctx context.Context, limit int) ([]*Strategy, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+strategyColumns+`
+		FROM bid_strategies
+		WHERE enabled
+		ORDER BY created_at ASC
+		LIMIT $1`, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanStrategies(rows)
+}
+
+const templateColumns = `id, buyer_addr, name, service_type, description,
+	budget, mode, bids_close_in, use_count, created_at, updated_at`
+
+func (p *PostgresStore) CreateTemplate(ctx context.Context, tpl *Template) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO rfp_templates (
+			id, buyer_addr, name, service_type, description,
+			budget, mode, bids_close_in, use_count, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			$6::NUMERIC(20,6), $7, $8, $9, $10, $11
+		)`,
+		tpl.ID, tpl.BuyerAddr, tpl.Name, tpl.ServiceType, tpl.Description,
+		tpl.Budget, string(tpl.Mode), nullString(tpl.BidsCloseIn), tpl.UseCount, tpl.CreatedAt, tpl.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+templateColumns+` FROM rfp_templates WHERE id = $1`, id)
+	tpl, err := scanTemplate(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrTemplateNotFound
+	}
+	return tpl, err
+}
+
+func (p *PostgresStore) UpdateTemplate(ctx context.Context, tpl *Template) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE rfp_templates SET
+			use_count = $1, updated_at = $2
+		WHERE id = $3`,
+		tpl.UseCount, tpl.UpdatedAt, tpl.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) DeleteTemplate(ctx context.Context, id string) error {
+	result, err := p.db.ExecContext(ctx, `DELETE FROM rfp_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListTemplatesByBuyer(ctx context.Context, buyerAddr string, limit int) ([]*Template, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+templateColumns+`
+		FROM rfp_templates
+		WHERE buyer_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, buyerAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*Template
+	for rows.Next() {
+		tpl, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tpl)
+	}
+	return result, rows.Err()
+}
+
+const extensionColumns = `id, rfp_id, proposed_by, new_deadline, reason,
+	status, approvals, rejections, created_at, updated_at`
+
+func (p *PostgresStore) CreateExtension(ctx context.Context, e *Extension) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO rfp_extensions (
+			id, rfp_id, proposed_by, new_deadline, reason,
+			status, approvals, rejections, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			$6, $7, $8, $9, $10
+		)`,
+		e.ID, e.RFPID, e.ProposedBy, e.NewDeadline, e.Reason,
+		string(e.Status), pq.Array(e.Approvals), pq.Array(e.Rejections), e.CreatedAt, e.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetExtension(ctx context.Context, id string) (*Extension, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+extensionColumns+` FROM rfp_extensions WHERE id = $1`, id)
+	e, err := scanExtension(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrExtensionNotFound
+	}
+	return e, err
+}
+
+func (p *PostgresStore) UpdateExtension(ctx context.Context, e *Extension) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE rfp_extensions SET
+			status = $1, approvals = $2, rejections = $3, updated_at = $4
+		WHERE id = $5`,
+		string(e.Status), pq.Array(e.Approvals), pq.Array(e.Rejections), e.UpdatedAt, e.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrExtensionNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) GetPendingExtension(ctx context.Context, rfpID string) (*Extension, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT `+extensionColumns+`
+		FROM rfp_extensions
+		WHERE rfp_id = $1 AND status = 'pending'
+		ORDER BY created_at DESC
+		LIMIT 1`, rfpID)
+	e, err := scanExtension(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrExtensionNotFound
+	}
+	return e, err
+}
+
+func (p *PostgresStore) ListExtensionsByRFP(ctx context.Context, rfpID string, limit int) ([]*Extension, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+extensionColumns+`
+		FROM rfp_extensions
+		WHERE rfp_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, rfpID, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*Extension
+	for rows.Next() {
+		e, err := scanExtension(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+	return result, rows.Err()
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRFP(s scanner) (*RFP, error) {
+	r := &RFP{}
+	var (
+		rubricJSON   []byte
+		visibility   string
+		mode         string
+		status       string
+		winningBidID sql.NullString
+		awardedAt    sql.NullTime
+	)
+
+	err := s.Scan(
+		&r.ID, &r.BuyerAddr, &r.ServiceType, &r.Description, &r.Budget,
+		&r.MinSellerScore, &rubricJSON, &r.AutoAward, &visibility, pq.Array(&r.InvitedAddrs), &r.MinTier,
+		&mode, &status, &r.BidCount, &r.BidDeadline,
+		&winningBidID, &awardedAt, &r.CreatedAt, &r.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rubricJSON) > 0 {
+		r.Rubric = &Rubric{}
+		if err := json.Unmarshal(rubricJSON, r.Rubric); err != nil {
+			return nil, err
+		}
+	}
+	r.Visibility = Visibility(visibility)
+	r.Mode = Mode(mode)
+	r.Status = RFPStatus(status)
+	r.WinningBidID = winningBidID.String
+	if awardedAt.Valid {
+		r.AwardedAt = &awardedAt.Time
+	}
+
+	return r, nil
+}
+
+func scanRFPs(rows *sql.Rows) ([]*RFP, error) {
+	var result []*RFP
+	for rows.Next() {
+		r, err := scanRFP(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
+func scanBid(s scanner) (*Bid, error) {
+	b := &Bid{}
+	var (
+		penaltyOffered sql.NullString
+		status         string
+		breakdownRaw   []byte
+	)
+
+	err := s.Scan(
+		&b.ID, &b.RFPID, &b.SellerAddr, &b.Price, &b.Message,
+		&b.LatencySLAMs, &penaltyOffered, &status, &b.Rank, &b.Score, &breakdownRaw,
+		&b.CreatedAt, &b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	b.PenaltyOffered = penaltyOffered.String
+	if len(breakdownRaw) > 0 {
+		if err := json.Unmarshal(breakdownRaw, &b.ScoreBreakdown); err != nil {
+			return nil, err
+		}
+	}
+	b.Status = BidStatus(status)
+	return b, nil
+}
+
+func scanBids(rows *sql.Rows) ([]*Bid, error) {
+	var result []*Bid
+	for rows.Next() {
+		b, err := scanBid(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+	}
+	return result, rows.Err()
+}
+
+func scanStrategy(s scanner) (*Strategy, error) {
+	st := &Strategy{}
+	var lastRunAt sql.NullTime
+
+	err := s.Scan(
+		&st.ID, &st.SellerAddr, &st.ServiceType, &st.MinPrice, &st.TargetMargin,
+		&st.MaxConcurrent, &st.MinBuyerScore, &st.Enabled, &st.BidsPlaced, &lastRunAt,
+		&st.CreatedAt, &st.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastRunAt.Valid {
+		st.LastRunAt = &lastRunAt.Time
+	}
+	return st, nil
+}
+
+func scanStrategies(rows *sql.Rows) ([]*Strategy, error) {
+	var result []*Strategy
+	for rows.Next() {
+		st, err := scanStrategy(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, st)
+	}
+	return result, rows.Err()
+}
+
+func scanTemplate(s scanner) (*Template, error) {
+	tpl := &Template{}
+	var (
+		mode        string
+		bidsCloseIn sql.NullString
+	)
+
+	err := s.Scan(
+		&tpl.ID, &tpl.BuyerAddr, &tpl.Name, &tpl.ServiceType, &tpl.Description,
+		&tpl.Budget, &mode, &bidsCloseIn, &tpl.UseCount, &tpl.CreatedAt, &tpl.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl.Mode = Mode(mode)
+	tpl.BidsCloseIn = bidsCloseIn.String
+	return tpl, nil
+}
+
+func scanExtension(s scanner) (*Extension, error) {
+	e := &Extension{}
+	var status string
+
+	err := s.Scan(
+		&e.ID, &e.RFPID, &e.ProposedBy, &e.NewDeadline, &e.Reason,
+		&status, pq.Array(&e.Approvals), pq.Array(&e.Rejections), &e.CreatedAt, &e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Status = ExtensionStatus(status)
+	return e, nil
+}
+
+// breakdownJSON serializes a bid's score breakdown for storage; nil stays
+// NULL in the database.
+func breakdownJSON(b *Bid) []byte {
+	if b.ScoreBreakdown == nil {
+		return nil
+	}
+	raw, _ := json.Marshal(b.ScoreBreakdown)
+	return raw
+}
+
+func nullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// Compile-time assertion that PostgresStore implements Store.
+var _ Store = (*PostgresStore)(nil)
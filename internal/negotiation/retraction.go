@@ -0,0 +1,118 @@
+package negotiation
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// DefaultRetractionPenalty is how many negative reputation marks a bid
+// retraction records against the seller.
+const DefaultRetractionPenalty = 1
+
+// DefaultRetractionCooldown is how long a seller must wait after
+// retracting before bidding on the same RFP again.
+const DefaultRetractionCooldown = 10 * time.Minute
+
+// ReputationImpactor records negative outcomes (bid retractions) for
+// reputation scoring. Satisfied by reputation.RegistryProvider.
+type ReputationImpactor interface {
+	RecordDispute(ctx context.Context, sellerAddr string, outcome string, amount string) error
+}
+
+// WithReputationImpactor attaches a sink for retraction penalties.
+func (s *Service) WithReputationImpactor(r ReputationImpactor) *Service {
+	s.repImpact = r
+	return s
+}
+
+// WithRetractionPolicy configures the reputation penalty and rebid
+// cool-down applied to bid retractions.
+func (s *Service) WithRetractionPolicy(penalty int, cooldown time.Duration) *Service {
+	if penalty >= 0 {
+		s.retractionPenalty = penalty
+	}
+	if cooldown >= 0 {
+		s.retractionCooldown = cooldown
+	}
+	return s
+}
+
+// RetractBid pulls a seller's active bid before selection, at the cost of
+// a reputation penalty and a cool-down before rebidding on the same RFP.
+// Unlike WithdrawBid it works in sealed mode and after the deadline —
+// anytime before the buyer awards.
+func (s *Service) RetractBid(ctx context.Context, bidID, callerAddr string) (*Bid, error) {
+	bid, err := s.store.GetBid(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	mu := s.rfpLock(bid.RFPID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	bid, err = s.store.GetBid(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	if bid.SellerAddr != strings.ToLower(callerAddr) {
+		return nil, ErrUnauthorized
+	}
+	if bid.Status != BidActive {
+		return nil, ErrBiddingOver
+	}
+
+	rfp, err := s.store.GetRFP(ctx, bid.RFPID)
+	if err != nil {
+		return nil, err
+	}
+	if rfp.IsTerminal() {
+		return nil, ErrBiddingOver
+	}
+
+	bid.Status = BidRetracted
+	bid.UpdatedAt = time.Now()
+	if err := s.store.UpdateBid(ctx, bid); err != nil {
+		return nil, err
+	}
+
+	if s.repImpact != nil {
+		for i := 0; i < s.retractionPenalty; i++ {
+			if err := s.repImpact.RecordDispute(ctx, bid.SellerAddr, "disputed", bid.Price); err != nil {
+				s.logger.Warn("negotiation: retraction penalty failed", "bid", bid.ID, "error", err)
+				break
+			}
+		}
+	}
+	s.logger.Info("negotiation: bid retracted", "bid", bid.ID, "rfp", bid.RFPID, "seller", bid.SellerAddr)
+
+	return bid, nil
+}
+
+// invalidateBelowFloor knocks out active bids whose seller's reputation
+// has dropped below the RFP's stated floor since the bid was placed.
+// Runs at reveal and award, the points where bids become decisive.
+func (s *Service) invalidateBelowFloor(ctx context.Context, rfp *RFP, bids []*Bid) {
+	if rfp.MinSellerScore <= 0 || s.reputation == nil {
+		return
+	}
+	now := time.Now()
+	for _, b := range bids {
+		if b.Status != BidActive {
+			continue
+		}
+		score, _, err := s.reputation.GetScore(ctx, b.SellerAddr)
+		if err != nil || score >= rfp.MinSellerScore {
+			continue
+		}
+		b.Status = BidInvalidated
+		b.UpdatedAt = now
+		if err := s.store.UpdateBid(ctx, b); err != nil {
+			s.logger.Warn("negotiation: bid invalidation failed", "bid", b.ID, "error", err)
+			continue
+		}
+		s.logger.Info("negotiation: bid invalidated below floor",
+			"bid", b.ID, "rfp", rfp.ID, "seller", b.SellerAddr, "score", score, "floor", rfp.MinSellerScore)
+	}
+}
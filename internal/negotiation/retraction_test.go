@@ -0,0 +1,159 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type stubImpactor struct {
+	calls []string
+}
+
+func (s *stubImpactor) RecordDispute(ctx context.Context, sellerAddr, outcome, amount string) error {
+	s.calls = append(s.calls, sellerAddr+":"+outcome)
+	return nil
+}
+
+func TestRetractBidSealedMode(t *testing.T) {
+	svc, _ := newTestService()
+	impactor := &stubImpactor{}
+	svc.WithReputationImpactor(impactor)
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "sealed")
+
+	bid, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.500000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	// Sealed bids are binding for withdrawal, but retraction works.
+	if _, err := svc.WithdrawBid(ctx, bid.ID, sellerAddr); !errors.Is(err, ErrBidSealed) {
+		t.Fatalf("expected ErrBidSealed from withdraw, got %v", err)
+	}
+	if _, err := svc.RetractBid(ctx, bid.ID, sellerAddr2); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized for foreign caller, got %v", err)
+	}
+	retracted, err := svc.RetractBid(ctx, bid.ID, sellerAddr)
+	if err != nil {
+		t.Fatalf("RetractBid failed: %v", err)
+	}
+	if retracted.Status != BidRetracted {
+		t.Errorf("expected status retracted, got %s", retracted.Status)
+	}
+	if len(impactor.calls) != DefaultRetractionPenalty {
+		t.Errorf("expected %d penalty marks, got %d", DefaultRetractionPenalty, len(impactor.calls))
+	}
+
+	// Already retracted: no double penalty.
+	if _, err := svc.RetractBid(ctx, bid.ID, sellerAddr); !errors.Is(err, ErrBiddingOver) {
+		t.Errorf("expected ErrBiddingOver on second retract, got %v", err)
+	}
+}
+
+func TestRetractionPolicy(t *testing.T) {
+	svc, _ := newTestService()
+	impactor := &stubImpactor{}
+	svc.WithReputationImpactor(impactor).WithRetractionPolicy(3, time.Hour)
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	bid, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.500000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.RetractBid(ctx, bid.ID, sellerAddr); err != nil {
+		t.Fatalf("RetractBid failed: %v", err)
+	}
+	if len(impactor.calls) != 3 {
+		t.Errorf("expected 3 penalty marks, got %d", len(impactor.calls))
+	}
+}
+
+func TestRetractionCooldown(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+	rfp := createTestRFP(t, svc, "open")
+
+	bid, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.500000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.RetractBid(ctx, bid.ID, sellerAddr); err != nil {
+		t.Fatalf("RetractBid failed: %v", err)
+	}
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.400000"}); !errors.Is(err, ErrCooldown) {
+		t.Fatalf("expected ErrCooldown on immediate rebid, got %v", err)
+	}
+
+	// Other sellers are unaffected.
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.600000"}); err != nil {
+		t.Fatalf("SubmitBid by other seller failed: %v", err)
+	}
+
+	// Rewind the retraction past the cool-down and the seller can rebid.
+	retracted, err := store.GetBid(ctx, bid.ID)
+	if err != nil {
+		t.Fatalf("GetBid failed: %v", err)
+	}
+	retracted.UpdatedAt = time.Now().Add(-DefaultRetractionCooldown - time.Minute)
+	if err := store.UpdateBid(ctx, retracted); err != nil {
+		t.Fatalf("UpdateBid failed: %v", err)
+	}
+	rebid, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.400000"})
+	if err != nil {
+		t.Fatalf("expected rebid after cool-down, got %v", err)
+	}
+	if rebid.ID == bid.ID {
+		t.Error("expected a fresh bid after retraction")
+	}
+}
+
+func TestSellerReputationFloor(t *testing.T) {
+	svc, store := newTestService()
+	scores := map[string]float64{sellerAddr: 70, sellerAddr2: 90}
+	svc.WithReputation(&stubReputation{scores: scores})
+	ctx := context.Background()
+
+	rfp, err := svc.CreateRFP(ctx, buyerAddr, CreateRFPRequest{
+		ServiceType:    "inference",
+		Budget:         "1.000000",
+		Mode:           "sealed",
+		MinSellerScore: 60,
+	})
+	if err != nil {
+		t.Fatalf("CreateRFP failed: %v", err)
+	}
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr3, BidRequest{Price: "0.300000"}); !errors.Is(err, ErrBelowFloor) {
+		t.Fatalf("expected ErrBelowFloor for unscored seller, got %v", err)
+	}
+	cheap, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.400000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.500000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	// The cheaper seller's score drops below the floor before reveal.
+	scores[sellerAddr] = 40
+	passDeadline(t, store, rfp.ID)
+
+	winner, err := svc.Award(ctx, rfp.ID, buyerAddr, "")
+	if err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if winner.SellerAddr != sellerAddr2 {
+		t.Errorf("expected the in-good-standing seller to win, got %s", winner.SellerAddr)
+	}
+	invalidated, err := store.GetBid(ctx, cheap.ID)
+	if err != nil {
+		t.Fatalf("GetBid failed: %v", err)
+	}
+	if invalidated.Status != BidInvalidated {
+		t.Errorf("expected below-floor bid invalidated, got %s", invalidated.Status)
+	}
+}
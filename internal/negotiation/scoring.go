@@ -0,0 +1,171 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// ErrInvalidRubric is returned for malformed scoring rubrics.
+var ErrInvalidRubric = errors.New("negotiation: invalid scoring rubric")
+
+// DefaultMaxLatencyMs is the latency reference used when a rubric weights
+// latency but does not set its own ceiling. SLAs at or above the ceiling
+// score zero on the latency component.
+const DefaultMaxLatencyMs = 10000
+
+// Rubric weights the components of a bid's composite score. Components are
+// normalized to 0..1 (higher is better) and combined as a weighted average,
+// so an award decision can be audited from the per-bid breakdown. A zero
+// weight drops the component entirely.
+type Rubric struct {
+	PriceWeight      float64 `json:"priceWeight"`      // Cheaper bids score higher
+	ReputationWeight float64 `json:"reputationWeight"` // Seller reputation 0-100, scaled
+	LatencyWeight    float64 `json:"latencyWeight"`    // Faster promised SLAs score higher
+	PenaltyWeight    float64 `json:"penaltyWeight"`    // Larger offered failure penalties score higher
+	MaxLatencyMs     int     `json:"maxLatencyMs,omitempty"`
+}
+
+func (r *Rubric) totalWeight() float64 {
+	return r.PriceWeight + r.ReputationWeight + r.LatencyWeight + r.PenaltyWeight
+}
+
+func validateRubric(r *Rubric) error {
+	if r.PriceWeight < 0 || r.ReputationWeight < 0 || r.LatencyWeight < 0 || r.PenaltyWeight < 0 {
+		return fmt.Errorf("%w: weights must be non-negative", ErrInvalidRubric)
+	}
+	if r.totalWeight() <= 0 {
+		return fmt.Errorf("%w: at least one weight must be positive", ErrInvalidRubric)
+	}
+	if r.MaxLatencyMs < 0 {
+		return fmt.Errorf("%w: maxLatencyMs must be non-negative", ErrInvalidRubric)
+	}
+	return nil
+}
+
+// scoreBids computes the rubric's composite score and per-component
+// breakdown for every active bid. Scores are rounded to four decimals.
+func (s *Service) scoreBids(ctx context.Context, rfp *RFP, bids []*Bid) {
+	budget, _ := usdc.Parse(rfp.Budget)
+	total := rfp.Rubric.totalWeight()
+
+	for _, b := range bids {
+		if b.Status != BidActive {
+			continue
+		}
+		breakdown := map[string]float64{}
+		composite := 0.0
+
+		if w := rfp.Rubric.PriceWeight; w > 0 {
+			c := priceComponent(b.Price, budget)
+			breakdown["price"] = round4(c)
+			composite += w * c
+		}
+		if w := rfp.Rubric.ReputationWeight; w > 0 {
+			c := 0.0
+			if s.reputation != nil {
+				if score, _, err := s.reputation.GetScore(ctx, b.SellerAddr); err == nil {
+					c = clamp01(score / 100)
+				}
+			}
+			breakdown["reputation"] = round4(c)
+			composite += w * c
+		}
+		if w := rfp.Rubric.LatencyWeight; w > 0 {
+			c := latencyComponent(b.LatencySLAMs, rfp.Rubric.MaxLatencyMs)
+			breakdown["latency"] = round4(c)
+			composite += w * c
+		}
+		if w := rfp.Rubric.PenaltyWeight; w > 0 {
+			c := penaltyComponent(b.PenaltyOffered, budget)
+			breakdown["penalty"] = round4(c)
+			composite += w * c
+		}
+
+		b.Score = round4(composite / total)
+		b.ScoreBreakdown = breakdown
+	}
+}
+
+// rankByScore assigns 1-based ranks to active bids, highest composite score
+// first (ties broken by price, then submission time), and returns them in
+// rank order.
+func rankByScore(bids []*Bid) []*Bid {
+	var active []*Bid
+	for _, b := range bids {
+		if b.Status == BidActive {
+			active = append(active, b)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return betterScore(active[i], active[j]) })
+	for i, b := range active {
+		b.Rank = i + 1
+	}
+	return active
+}
+
+// betterScore orders bids by composite score descending, cheapest first on
+// ties.
+func betterScore(a, b *Bid) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return lessBid(a, b)
+}
+
+// priceComponent scores a price against the budget: free scores 1, at
+// budget scores 0.
+func priceComponent(price string, budget *big.Int) float64 {
+	p, ok := usdc.Parse(price)
+	if !ok || budget.Sign() <= 0 {
+		return 0
+	}
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(p), new(big.Float).SetInt(budget)).Float64()
+	return clamp01(1 - ratio)
+}
+
+// latencyComponent scores a promised SLA against the rubric's latency
+// ceiling: instant scores 1, at or beyond the ceiling scores 0. A bid
+// without an SLA scores 0.
+func latencyComponent(slaMs, maxMs int) float64 {
+	if slaMs <= 0 {
+		return 0
+	}
+	if maxMs <= 0 {
+		maxMs = DefaultMaxLatencyMs
+	}
+	return clamp01(1 - float64(slaMs)/float64(maxMs))
+}
+
+// penaltyComponent scores an offered failure penalty against the budget: a
+// penalty matching the full budget scores 1.
+func penaltyComponent(penalty string, budget *big.Int) float64 {
+	if penalty == "" {
+		return 0
+	}
+	p, ok := usdc.Parse(penalty)
+	if !ok || budget.Sign() <= 0 {
+		return 0
+	}
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(p), new(big.Float).SetInt(budget)).Float64()
+	return clamp01(ratio)
+}
+
+func clamp01(f float64) float64 {
+	if f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+func round4(f float64) float64 {
+	return math.Round(f*10_000) / 10_000
+}
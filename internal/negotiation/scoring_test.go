@@ -0,0 +1,195 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func createScoredRFP(t *testing.T, svc *Service, rubric *Rubric, autoAward bool) *RFP {
+	t.Helper()
+	rfp, err := svc.CreateRFP(context.Background(), buyerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "1.000000",
+		Mode:        "sealed",
+		Rubric:      rubric,
+		AutoAward:   autoAward,
+	})
+	if err != nil {
+		t.Fatalf("CreateRFP failed: %v", err)
+	}
+	return rfp
+}
+
+func TestRubricValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.CreateRFP(ctx, buyerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "1.000000",
+		Rubric:      &Rubric{},
+	}); !errors.Is(err, ErrInvalidRubric) {
+		t.Errorf("expected ErrInvalidRubric for all-zero weights, got %v", err)
+	}
+
+	if _, err := svc.CreateRFP(ctx, buyerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "1.000000",
+		Rubric:      &Rubric{PriceWeight: -1, ReputationWeight: 2},
+	}); !errors.Is(err, ErrInvalidRubric) {
+		t.Errorf("expected ErrInvalidRubric for negative weight, got %v", err)
+	}
+
+	if _, err := svc.CreateRFP(ctx, buyerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "1.000000",
+		AutoAward:   true,
+	}); !errors.Is(err, ErrInvalidRubric) {
+		t.Errorf("expected ErrInvalidRubric for autoAward without rubric, got %v", err)
+	}
+}
+
+func TestScoredRevealRanksByComposite(t *testing.T) {
+	svc, store := newTestService()
+	svc.WithReputation(&stubReputation{scores: map[string]float64{sellerAddr: 20, sellerAddr2: 90}})
+	ctx := context.Background()
+	rfp := createScoredRFP(t, svc, &Rubric{PriceWeight: 1, ReputationWeight: 1}, false)
+
+	// The cheaper seller has a far worse reputation; equal weights make the
+	// pricier, trusted seller win: 0.5*0.6 + 0.5*0.2 = 0.4 vs 0.5*0.5 + 0.5*0.9 = 0.7.
+	cheap, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.400000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	trusted, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.500000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	passDeadline(t, store, rfp.ID)
+	if _, err := svc.Reveal(ctx, rfp.ID); err != nil {
+		t.Fatalf("Reveal failed: %v", err)
+	}
+
+	got, err := store.GetBid(ctx, trusted.ID)
+	if err != nil {
+		t.Fatalf("GetBid failed: %v", err)
+	}
+	if got.Rank != 1 {
+		t.Errorf("expected trusted seller ranked 1, got %d", got.Rank)
+	}
+	if got.Score != 0.7 {
+		t.Errorf("expected composite 0.7, got %v", got.Score)
+	}
+	if got.ScoreBreakdown["price"] != 0.5 || got.ScoreBreakdown["reputation"] != 0.9 {
+		t.Errorf("unexpected breakdown %v", got.ScoreBreakdown)
+	}
+
+	winner, err := svc.Award(ctx, rfp.ID, buyerAddr, "")
+	if err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+	if winner.ID != trusted.ID {
+		t.Errorf("expected highest-scored bid to win, got %s", winner.ID)
+	}
+	if lost, _ := store.GetBid(ctx, cheap.ID); lost.Status != BidLost {
+		t.Errorf("expected cheap bid lost, got %s", lost.Status)
+	}
+}
+
+func TestLatencyAndPenaltyComponents(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+	rubric := &Rubric{LatencyWeight: 1, PenaltyWeight: 1, MaxLatencyMs: 1000}
+	rfp := createScoredRFP(t, svc, rubric, false)
+
+	fast, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{
+		Price:          "0.900000",
+		LatencySLAMs:   250,
+		PenaltyOffered: "0.500000",
+	})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.100000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	passDeadline(t, store, rfp.ID)
+	if _, err := svc.Reveal(ctx, rfp.ID); err != nil {
+		t.Fatalf("Reveal failed: %v", err)
+	}
+
+	// latency 250/1000 -> 0.75, penalty 0.5/1.0 -> 0.5, composite 0.625.
+	got, err := store.GetBid(ctx, fast.ID)
+	if err != nil {
+		t.Fatalf("GetBid failed: %v", err)
+	}
+	if got.Score != 0.625 {
+		t.Errorf("expected composite 0.625, got %v", got.Score)
+	}
+	if got.Rank != 1 {
+		t.Errorf("expected SLA-backed bid ranked above the bare cheap one, got rank %d", got.Rank)
+	}
+}
+
+func TestAutoAwardAtReveal(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+	rfp := createScoredRFP(t, svc, &Rubric{PriceWeight: 1}, true)
+
+	best, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.300000"})
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.800000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	passDeadline(t, store, rfp.ID)
+	if _, err := svc.ForceCloseBidding(ctx); err != nil {
+		t.Fatalf("ForceCloseBidding failed: %v", err)
+	}
+
+	got, err := store.GetRFP(ctx, rfp.ID)
+	if err != nil {
+		t.Fatalf("GetRFP failed: %v", err)
+	}
+	if got.Status != RFPAwarded {
+		t.Fatalf("expected auto-awarded RFP, got %s", got.Status)
+	}
+	if got.WinningBidID != best.ID {
+		t.Errorf("expected top-scored bid to win, got %s", got.WinningBidID)
+	}
+	if got.AwardedAt == nil || time.Since(*got.AwardedAt) > time.Minute {
+		t.Error("expected a fresh award timestamp")
+	}
+}
+
+func TestSealedRedactionHidesBidTerms(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	rfp := createScoredRFP(t, svc, &Rubric{PriceWeight: 1}, false)
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{
+		Price:          "0.500000",
+		LatencySLAMs:   100,
+		PenaltyOffered: "0.200000",
+	}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+
+	bids, err := svc.ListBids(ctx, rfp.ID, sellerAddr2, 0)
+	if err != nil {
+		t.Fatalf("ListBids failed: %v", err)
+	}
+	if len(bids) != 1 {
+		t.Fatalf("expected 1 bid, got %d", len(bids))
+	}
+	b := bids[0]
+	if !b.Sealed || b.LatencySLAMs != 0 || b.PenaltyOffered != "" || b.Score != 0 || b.ScoreBreakdown != nil {
+		t.Errorf("expected bid terms redacted, got %+v", b)
+	}
+}
@@ -0,0 +1,356 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrStrategyNotFound = errors.New("negotiation: strategy not found")
+	ErrInvalidStrategy  = errors.New("negotiation: invalid strategy")
+)
+
+// DefaultMaxConcurrent is the default cap on a strategy's simultaneous
+// active bids.
+const DefaultMaxConcurrent = 5
+
+// MaxConcurrentLimit is the largest allowed concurrency cap.
+const MaxConcurrentLimit = 100
+
+// undercutStep is how far an open-mode counter bid undercuts the current
+// lowest rival: one cent.
+var undercutStep = big.NewInt(10_000)
+
+// Strategy is a seller's standing instruction to bid automatically on
+// matching RFPs. The opening bid is MinPrice marked up by TargetMargin;
+// in open mode the strategy counters rival bids down to MinPrice.
+type Strategy struct {
+	ID            string     `json:"id"`
+	SellerAddr    string     `json:"sellerAddr"`
+	ServiceType   string     `json:"serviceType"`
+	MinPrice      string     `json:"minPrice"`                // Never bid below this
+	TargetMargin  string     `json:"targetMargin"`            // Fractional markup for the opening bid, e.g. "0.25"
+	MaxConcurrent int        `json:"maxConcurrent"`           // Cap on simultaneous active bids
+	MinBuyerScore float64    `json:"minBuyerScore,omitempty"` // Reputation floor (0-100) for buyers; 0 = no floor
+	Enabled       bool       `json:"enabled"`
+	BidsPlaced    int        `json:"bidsPlaced"`
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// CreateStrategyRequest is the input for registering a bidding strategy.
+type CreateStrategyRequest struct {
+	ServiceType   string  `json:"serviceType" binding:"required"`
+	MinPrice      string  `json:"minPrice" binding:"required"`
+	TargetMargin  string  `json:"targetMargin"`  // "" = 0 (bid at MinPrice)
+	MaxConcurrent int     `json:"maxConcurrent"` // 0 = default (5)
+	MinBuyerScore float64 `json:"minBuyerScore"`
+}
+
+// ReputationProvider supplies buyer trust scores for strategy floors.
+type ReputationProvider interface {
+	GetScore(ctx context.Context, address string) (score float64, tier string, err error)
+}
+
+// WithReputation attaches a reputation provider so strategies can enforce
+// buyer score floors.
+func (s *Service) WithReputation(r ReputationProvider) *Service {
+	s.reputation = r
+	return s
+}
+
+// RegisterStrategy creates an automated bidding strategy for a seller.
+func (s *Service) RegisterStrategy(ctx context.Context, sellerAddr string, req CreateStrategyRequest) (*Strategy, error) {
+	if err := validatePrice(req.MinPrice); err != nil {
+		return nil, err
+	}
+	if req.ServiceType == "" {
+		return nil, fmt.Errorf("%w: serviceType is required", ErrInvalidStrategy)
+	}
+
+	margin := req.TargetMargin
+	if margin == "" {
+		margin = "0"
+	}
+	if _, err := parseMargin(margin); err != nil {
+		return nil, err
+	}
+
+	maxConcurrent := req.MaxConcurrent
+	if maxConcurrent == 0 {
+		maxConcurrent = DefaultMaxConcurrent
+	}
+	if maxConcurrent < 0 || maxConcurrent > MaxConcurrentLimit {
+		return nil, fmt.Errorf("%w: maxConcurrent must be between 1 and %d", ErrInvalidStrategy, MaxConcurrentLimit)
+	}
+	if req.MinBuyerScore < 0 || req.MinBuyerScore > 100 {
+		return nil, fmt.Errorf("%w: minBuyerScore must be between 0 and 100", ErrInvalidStrategy)
+	}
+
+	now := time.Now()
+	strategy := &Strategy{
+		ID:            idgen.WithPrefix("strat_"),
+		SellerAddr:    strings.ToLower(sellerAddr),
+		ServiceType:   req.ServiceType,
+		MinPrice:      req.MinPrice,
+		TargetMargin:  margin,
+		MaxConcurrent: maxConcurrent,
+		MinBuyerScore: req.MinBuyerScore,
+		Enabled:       true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if err := s.store.CreateStrategy(ctx, strategy); err != nil {
+		return nil, err
+	}
+	return strategy, nil
+}
+
+// GetStrategy returns a strategy by ID.
+func (s *Service) GetStrategy(ctx context.Context, id string) (*Strategy, error) {
+	return s.store.GetStrategy(ctx, id)
+}
+
+// ListStrategiesBySeller returns a seller's strategies, newest first.
+func (s *Service) ListStrategiesBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Strategy, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.store.ListStrategiesBySeller(ctx, strings.ToLower(sellerAddr), limit)
+}
+
+// SetStrategyEnabled toggles a strategy on or off. Only its seller may.
+func (s *Service) SetStrategyEnabled(ctx context.Context, id, callerAddr string, enabled bool) (*Strategy, error) {
+	strategy, err := s.store.GetStrategy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if strategy.SellerAddr != strings.ToLower(callerAddr) {
+		return nil, ErrUnauthorized
+	}
+	if strategy.Enabled == enabled {
+		return strategy, nil
+	}
+	strategy.Enabled = enabled
+	strategy.UpdatedAt = time.Now()
+	if err := s.store.UpdateStrategy(ctx, strategy); err != nil {
+		return nil, err
+	}
+	return strategy, nil
+}
+
+// RunStrategies evaluates every enabled strategy against RFPs still in
+// bidding and places or counters bids on its seller's behalf. Called
+// periodically by the timer; returns the number of bids placed.
+func (s *Service) RunStrategies(ctx context.Context) (int, error) {
+	strategies, err := s.store.ListEnabledStrategies(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	placed := 0
+	for _, strategy := range strategies {
+		n, err := s.runStrategy(ctx, strategy)
+		if err != nil {
+			s.logger.Warn("negotiation: strategy run failed", "strategy", strategy.ID, "error", err)
+			continue
+		}
+		placed += n
+	}
+	return placed, nil
+}
+
+func (s *Service) runStrategy(ctx context.Context, strategy *Strategy) (int, error) {
+	minBig, ok := usdc.Parse(strategy.MinPrice)
+	if !ok {
+		return 0, fmt.Errorf("%w: bad min price %q", ErrInvalidStrategy, strategy.MinPrice)
+	}
+	margin, err := parseMargin(strategy.TargetMargin)
+	if err != nil {
+		return 0, err
+	}
+	target := markup(minBig, margin)
+
+	active, err := s.countActiveBids(ctx, strategy.SellerAddr)
+	if err != nil {
+		return 0, err
+	}
+
+	rfps, err := s.store.ListRFPs(ctx, strategy.ServiceType, 100)
+	if err != nil {
+		return 0, err
+	}
+
+	placed := 0
+	now := time.Now()
+	for _, rfp := range rfps {
+		if active >= strategy.MaxConcurrent {
+			break
+		}
+		if rfp.BuyerAddr == strategy.SellerAddr || now.After(rfp.BidDeadline) {
+			continue
+		}
+		if !s.canView(ctx, rfp, strategy.SellerAddr) {
+			continue // Invite-only or tier-gated RFP the seller can't see
+		}
+
+		budget, _ := usdc.Parse(rfp.Budget)
+		if minBig.Cmp(budget) > 0 {
+			continue // Buyer's budget is below our floor
+		}
+		if strategy.MinBuyerScore > 0 && s.reputation != nil {
+			score, _, err := s.reputation.GetScore(ctx, rfp.BuyerAddr)
+			if err != nil || score < strategy.MinBuyerScore {
+				continue
+			}
+		}
+		if rfp.MinSellerScore > 0 && s.reputation != nil {
+			score, _, err := s.reputation.GetScore(ctx, strategy.SellerAddr)
+			if err != nil || score < rfp.MinSellerScore {
+				continue // Below the RFP's bidder floor; don't churn SubmitBid errors
+			}
+		}
+
+		price, fresh, err := s.strategyPrice(ctx, rfp, strategy.SellerAddr, minBig, target, budget)
+		if err != nil {
+			s.logger.Warn("negotiation: strategy pricing failed", "strategy", strategy.ID, "rfp", rfp.ID, "error", err)
+			continue
+		}
+		if price == nil {
+			continue // Nothing to place or improve
+		}
+
+		if _, err := s.SubmitBid(ctx, rfp.ID, strategy.SellerAddr, BidRequest{
+			Price:   usdc.Format(price),
+			Message: "auto-bid by strategy " + strategy.ID,
+		}); err != nil {
+			s.logger.Warn("negotiation: auto-bid failed", "strategy", strategy.ID, "rfp", rfp.ID, "error", err)
+			continue
+		}
+		placed++
+		if fresh {
+			active++
+		}
+	}
+
+	if placed > 0 || strategy.LastRunAt == nil {
+		strategy.BidsPlaced += placed
+		strategy.LastRunAt = &now
+		strategy.UpdatedAt = now
+		if err := s.store.UpdateStrategy(ctx, strategy); err != nil {
+			s.logger.Warn("negotiation: strategy stats update failed", "strategy", strategy.ID, "error", err)
+		}
+	}
+
+	return placed, nil
+}
+
+// strategyPrice decides what the strategy should bid on an RFP, or nil if
+// no bid should be placed. fresh is true when this would be a new bid
+// rather than a revision of a standing one.
+func (s *Service) strategyPrice(ctx context.Context, rfp *RFP, sellerAddr string, minBig, target, budget *big.Int) (price *big.Int, fresh bool, err error) {
+	existing, err := s.store.GetBidBySeller(ctx, rfp.ID, sellerAddr)
+	if err != nil && !errors.Is(err, ErrBidNotFound) {
+		return nil, false, err
+	}
+	hasBid := existing != nil && existing.Status == BidActive
+
+	desired := new(big.Int).Set(target)
+	if desired.Cmp(budget) > 0 {
+		desired.Set(budget)
+	}
+
+	if rfp.Mode == ModeSealed {
+		if hasBid {
+			return nil, false, nil // Sealed bids are one-shot
+		}
+		return desired, true, nil
+	}
+
+	// Open mode: undercut the lowest rival bid by a cent, never below the
+	// strategy floor.
+	if rival, err := s.lowestRivalBid(ctx, rfp.ID, sellerAddr); err != nil {
+		return nil, false, err
+	} else if rival != nil && rival.Cmp(desired) <= 0 {
+		counter := new(big.Int).Sub(rival, undercutStep)
+		if counter.Cmp(minBig) < 0 {
+			return nil, false, nil // Cannot beat the rival without going below floor
+		}
+		desired = counter
+	}
+
+	if hasBid {
+		current, _ := usdc.Parse(existing.Price)
+		if current.Cmp(desired) <= 0 {
+			return nil, false, nil // Standing bid is already at or below the target
+		}
+	}
+	return desired, !hasBid, nil
+}
+
+// lowestRivalBid returns the lowest active bid on the RFP from any other
+// seller, or nil if there is none.
+func (s *Service) lowestRivalBid(ctx context.Context, rfpID, sellerAddr string) (*big.Int, error) {
+	bids, err := s.store.ListBidsByRFP(ctx, rfpID, 0)
+	if err != nil {
+		return nil, err
+	}
+	var lowest *big.Int
+	for _, b := range bids {
+		if b.Status != BidActive || b.SellerAddr == sellerAddr {
+			continue
+		}
+		price, ok := usdc.Parse(b.Price)
+		if !ok {
+			continue
+		}
+		if lowest == nil || price.Cmp(lowest) < 0 {
+			lowest = price
+		}
+	}
+	return lowest, nil
+}
+
+func (s *Service) countActiveBids(ctx context.Context, sellerAddr string) (int, error) {
+	bids, err := s.store.ListBidsBySeller(ctx, sellerAddr, 0)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, b := range bids {
+		if b.Status == BidActive {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// markup returns price scaled by (1 + margin), computed in basis points
+// to avoid float truncation.
+func markup(price *big.Int, margin float64) *big.Int {
+	if margin == 0 {
+		return new(big.Int).Set(price)
+	}
+	bp := int64(math.Round(margin * 10_000))
+	result := new(big.Int).Mul(price, big.NewInt(10_000+bp))
+	return result.Quo(result, big.NewInt(10_000))
+}
+
+func parseMargin(margin string) (float64, error) {
+	m, err := strconv.ParseFloat(margin, 64)
+	if err != nil || m < 0 || m > 10 {
+		return 0, fmt.Errorf("%w: targetMargin must be a fraction between 0 and 10", ErrInvalidStrategy)
+	}
+	return m, nil
+}
@@ -0,0 +1,271 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubReputation struct {
+	scores map[string]float64
+}
+
+func (s *stubReputation) GetScore(ctx context.Context, address string) (float64, string, error) {
+	return s.scores[address], "", nil
+}
+
+func registerTestStrategy(t *testing.T, svc *Service, req CreateStrategyRequest) *Strategy {
+	t.Helper()
+	strategy, err := svc.RegisterStrategy(context.Background(), sellerAddr, req)
+	if err != nil {
+		t.Fatalf("RegisterStrategy failed: %v", err)
+	}
+	return strategy
+}
+
+func TestRegisterStrategyValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.RegisterStrategy(ctx, sellerAddr, CreateStrategyRequest{
+		ServiceType: "inference",
+		MinPrice:    "0",
+	}); !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+	if _, err := svc.RegisterStrategy(ctx, sellerAddr, CreateStrategyRequest{
+		ServiceType:  "inference",
+		MinPrice:     "0.500000",
+		TargetMargin: "-0.1",
+	}); !errors.Is(err, ErrInvalidStrategy) {
+		t.Errorf("expected ErrInvalidStrategy for negative margin, got %v", err)
+	}
+	if _, err := svc.RegisterStrategy(ctx, sellerAddr, CreateStrategyRequest{
+		ServiceType:   "inference",
+		MinPrice:      "0.500000",
+		MaxConcurrent: MaxConcurrentLimit + 1,
+	}); !errors.Is(err, ErrInvalidStrategy) {
+		t.Errorf("expected ErrInvalidStrategy for concurrency cap, got %v", err)
+	}
+	if _, err := svc.RegisterStrategy(ctx, sellerAddr, CreateStrategyRequest{
+		ServiceType:   "inference",
+		MinPrice:      "0.500000",
+		MinBuyerScore: 150,
+	}); !errors.Is(err, ErrInvalidStrategy) {
+		t.Errorf("expected ErrInvalidStrategy for score floor, got %v", err)
+	}
+
+	strategy := registerTestStrategy(t, svc, CreateStrategyRequest{
+		ServiceType: "inference",
+		MinPrice:    "0.500000",
+	})
+	if !strategy.Enabled {
+		t.Error("expected new strategy enabled")
+	}
+	if strategy.MaxConcurrent != DefaultMaxConcurrent {
+		t.Errorf("expected default concurrency %d, got %d", DefaultMaxConcurrent, strategy.MaxConcurrent)
+	}
+	if strategy.TargetMargin != "0" {
+		t.Errorf("expected default margin 0, got %s", strategy.TargetMargin)
+	}
+}
+
+func TestStrategyBidsSealedRFP(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	rfp := createTestRFP(t, svc, "sealed")
+	strategy := registerTestStrategy(t, svc, CreateStrategyRequest{
+		ServiceType:  "inference",
+		MinPrice:     "0.500000",
+		TargetMargin: "0.2",
+	})
+
+	placed, err := svc.RunStrategies(ctx)
+	if err != nil {
+		t.Fatalf("RunStrategies failed: %v", err)
+	}
+	if placed != 1 {
+		t.Fatalf("expected 1 bid placed, got %d", placed)
+	}
+
+	// Opening bid is MinPrice marked up by the target margin.
+	bids, _ := svc.ListBids(ctx, rfp.ID, sellerAddr, 10)
+	if len(bids) != 1 || bids[0].Price != "0.600000" {
+		t.Fatalf("expected one bid at 0.600000, got %+v", bids)
+	}
+
+	// Sealed bids are one-shot: a second run places nothing.
+	if placed, _ = svc.RunStrategies(ctx); placed != 0 {
+		t.Errorf("expected no bids on second run, got %d", placed)
+	}
+
+	updated, _ := svc.GetStrategy(ctx, strategy.ID)
+	if updated.BidsPlaced != 1 {
+		t.Errorf("expected bidsPlaced 1, got %d", updated.BidsPlaced)
+	}
+	if updated.LastRunAt == nil {
+		t.Error("expected lastRunAt set")
+	}
+}
+
+func TestStrategyCountersOpenBids(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	rfp := createTestRFP(t, svc, "open")
+	registerTestStrategy(t, svc, CreateStrategyRequest{
+		ServiceType:  "inference",
+		MinPrice:     "0.500000",
+		TargetMargin: "0.2",
+	})
+
+	if placed, err := svc.RunStrategies(ctx); err != nil || placed != 1 {
+		t.Fatalf("expected opening bid, got placed=%d err=%v", placed, err)
+	}
+
+	// A rival undercuts; the strategy counters a cent below, still above
+	// its floor.
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.550000"}); err != nil {
+		t.Fatalf("rival bid failed: %v", err)
+	}
+	if placed, err := svc.RunStrategies(ctx); err != nil || placed != 1 {
+		t.Fatalf("expected counter bid, got placed=%d err=%v", placed, err)
+	}
+	own, err := svc.GetBid(ctx, mustOwnBid(t, svc, rfp.ID), sellerAddr)
+	if err != nil {
+		t.Fatalf("GetBid failed: %v", err)
+	}
+	if own.Price != "0.540000" {
+		t.Errorf("expected counter at 0.540000, got %s", own.Price)
+	}
+
+	// A rival at the floor cannot be beaten; the strategy stands pat.
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.500000"}); err != nil {
+		t.Fatalf("rival bid failed: %v", err)
+	}
+	if placed, _ := svc.RunStrategies(ctx); placed != 0 {
+		t.Errorf("expected no counter below floor, got %d", placed)
+	}
+	own, _ = svc.GetBid(ctx, own.ID, sellerAddr)
+	if own.Price != "0.540000" {
+		t.Errorf("expected bid unchanged at 0.540000, got %s", own.Price)
+	}
+}
+
+func mustOwnBid(t *testing.T, svc *Service, rfpID string) string {
+	t.Helper()
+	bids, err := svc.ListBids(context.Background(), rfpID, sellerAddr, 0)
+	if err != nil {
+		t.Fatalf("ListBids failed: %v", err)
+	}
+	for _, b := range bids {
+		if b.SellerAddr == sellerAddr {
+			return b.ID
+		}
+	}
+	t.Fatal("no own bid found")
+	return ""
+}
+
+func TestStrategySkipsUnsuitableRFPs(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	// Budget below the strategy floor.
+	if _, err := svc.CreateRFP(ctx, buyerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "0.400000",
+	}); err != nil {
+		t.Fatalf("CreateRFP failed: %v", err)
+	}
+	// The strategy's own seller is the buyer here.
+	if _, err := svc.CreateRFP(ctx, sellerAddr, CreateRFPRequest{
+		ServiceType: "inference",
+		Budget:      "1.000000",
+	}); err != nil {
+		t.Fatalf("CreateRFP failed: %v", err)
+	}
+	// Wrong service type.
+	if _, err := svc.CreateRFP(ctx, buyerAddr, CreateRFPRequest{
+		ServiceType: "translation",
+		Budget:      "1.000000",
+	}); err != nil {
+		t.Fatalf("CreateRFP failed: %v", err)
+	}
+
+	registerTestStrategy(t, svc, CreateStrategyRequest{
+		ServiceType: "inference",
+		MinPrice:    "0.500000",
+	})
+
+	if placed, err := svc.RunStrategies(ctx); err != nil || placed != 0 {
+		t.Errorf("expected no bids, got placed=%d err=%v", placed, err)
+	}
+}
+
+func TestStrategyReputationFloor(t *testing.T) {
+	svc, _ := newTestService()
+	svc.WithReputation(&stubReputation{scores: map[string]float64{buyerAddr: 30}})
+	ctx := context.Background()
+
+	createTestRFP(t, svc, "open")
+	registerTestStrategy(t, svc, CreateStrategyRequest{
+		ServiceType:   "inference",
+		MinPrice:      "0.500000",
+		MinBuyerScore: 60,
+	})
+
+	if placed, err := svc.RunStrategies(ctx); err != nil || placed != 0 {
+		t.Errorf("expected low-score buyer skipped, got placed=%d err=%v", placed, err)
+	}
+
+	svc.WithReputation(&stubReputation{scores: map[string]float64{buyerAddr: 75}})
+	if placed, err := svc.RunStrategies(ctx); err != nil || placed != 1 {
+		t.Errorf("expected bid on trusted buyer, got placed=%d err=%v", placed, err)
+	}
+}
+
+func TestStrategyMaxConcurrent(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	createTestRFP(t, svc, "open")
+	createTestRFP(t, svc, "open")
+	registerTestStrategy(t, svc, CreateStrategyRequest{
+		ServiceType:   "inference",
+		MinPrice:      "0.500000",
+		MaxConcurrent: 1,
+	})
+
+	if placed, err := svc.RunStrategies(ctx); err != nil || placed != 1 {
+		t.Errorf("expected concurrency cap to hold at 1 bid, got placed=%d err=%v", placed, err)
+	}
+}
+
+func TestSetStrategyEnabled(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	createTestRFP(t, svc, "open")
+	strategy := registerTestStrategy(t, svc, CreateStrategyRequest{
+		ServiceType: "inference",
+		MinPrice:    "0.500000",
+	})
+
+	if _, err := svc.SetStrategyEnabled(ctx, strategy.ID, sellerAddr2, false); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+
+	disabled, err := svc.SetStrategyEnabled(ctx, strategy.ID, sellerAddr, false)
+	if err != nil {
+		t.Fatalf("SetStrategyEnabled failed: %v", err)
+	}
+	if disabled.Enabled {
+		t.Error("expected strategy disabled")
+	}
+
+	if placed, _ := svc.RunStrategies(ctx); placed != 0 {
+		t.Errorf("expected disabled strategy to place nothing, got %d", placed)
+	}
+}
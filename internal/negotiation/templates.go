@@ -0,0 +1,180 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+)
+
+var ErrTemplateNotFound = errors.New("negotiation: template not found")
+
+// Template is a saved RFP payload a buyer can reissue without rebuilding
+// it; recurring buyers post near-identical RFPs on a schedule.
+type Template struct {
+	ID          string    `json:"id"`
+	BuyerAddr   string    `json:"buyerAddr"`
+	Name        string    `json:"name"`
+	ServiceType string    `json:"serviceType"`
+	Description string    `json:"description,omitempty"`
+	Budget      string    `json:"budget"`
+	Mode        Mode      `json:"mode"`
+	BidsCloseIn string    `json:"bidsCloseIn,omitempty"` // Duration applied at instantiation; "" = default
+	UseCount    int       `json:"useCount"`              // RFPs instantiated from this template
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// CreateTemplateRequest is the input for saving an RFP template.
+type CreateTemplateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	ServiceType string `json:"serviceType" binding:"required"`
+	Description string `json:"description"`
+	Budget      string `json:"budget" binding:"required"`
+	Mode        string `json:"mode"`
+	BidsCloseIn string `json:"bidsCloseIn"`
+}
+
+// InstantiateRequest optionally overrides template fields when creating
+// an RFP from a template or cloning a past RFP.
+type InstantiateRequest struct {
+	Budget      string `json:"budget"`      // "" = keep saved budget
+	BidsCloseIn string `json:"bidsCloseIn"` // "" = saved window or default
+}
+
+// SaveTemplate stores a reusable RFP payload for the buyer.
+func (s *Service) SaveTemplate(ctx context.Context, buyerAddr string, req CreateTemplateRequest) (*Template, error) {
+	if err := validatePrice(req.Budget); err != nil {
+		return nil, err
+	}
+	if req.Name == "" || req.ServiceType == "" {
+		return nil, errors.New("name and serviceType are required")
+	}
+
+	mode := ModeOpen
+	switch Mode(req.Mode) {
+	case "", ModeOpen:
+	case ModeSealed:
+		mode = ModeSealed
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidMode, req.Mode)
+	}
+
+	if req.BidsCloseIn != "" {
+		if d, err := time.ParseDuration(req.BidsCloseIn); err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid bidsCloseIn %q", req.BidsCloseIn)
+		}
+	}
+
+	now := time.Now()
+	tpl := &Template{
+		ID:          idgen.WithPrefix("rfpt_"),
+		BuyerAddr:   strings.ToLower(buyerAddr),
+		Name:        req.Name,
+		ServiceType: req.ServiceType,
+		Description: req.Description,
+		Budget:      req.Budget,
+		Mode:        mode,
+		BidsCloseIn: req.BidsCloseIn,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.store.CreateTemplate(ctx, tpl); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+// GetTemplate returns a template by ID.
+func (s *Service) GetTemplate(ctx context.Context, id string) (*Template, error) {
+	return s.store.GetTemplate(ctx, id)
+}
+
+// ListTemplatesByBuyer returns a buyer's template catalog, newest first.
+func (s *Service) ListTemplatesByBuyer(ctx context.Context, buyerAddr string, limit int) ([]*Template, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.store.ListTemplatesByBuyer(ctx, strings.ToLower(buyerAddr), limit)
+}
+
+// DeleteTemplate removes a template. Only its buyer may.
+func (s *Service) DeleteTemplate(ctx context.Context, id, callerAddr string) error {
+	tpl, err := s.store.GetTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if tpl.BuyerAddr != strings.ToLower(callerAddr) {
+		return ErrUnauthorized
+	}
+	return s.store.DeleteTemplate(ctx, id)
+}
+
+// InstantiateTemplate creates a fresh RFP from a saved template. Only the
+// template's buyer may instantiate it.
+func (s *Service) InstantiateTemplate(ctx context.Context, templateID, callerAddr string, req InstantiateRequest) (*RFP, error) {
+	tpl, err := s.store.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if tpl.BuyerAddr != strings.ToLower(callerAddr) {
+		return nil, ErrUnauthorized
+	}
+
+	budget := tpl.Budget
+	if req.Budget != "" {
+		budget = req.Budget
+	}
+	window := tpl.BidsCloseIn
+	if req.BidsCloseIn != "" {
+		window = req.BidsCloseIn
+	}
+
+	rfp, err := s.CreateRFP(ctx, callerAddr, CreateRFPRequest{
+		ServiceType: tpl.ServiceType,
+		Description: tpl.Description,
+		Budget:      budget,
+		Mode:        string(tpl.Mode),
+		BidsCloseIn: window,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tpl.UseCount++
+	tpl.UpdatedAt = time.Now()
+	if err := s.store.UpdateTemplate(ctx, tpl); err != nil {
+		s.logger.Warn("negotiation: template use count update failed", "template", templateID, "error", err)
+	}
+
+	return rfp, nil
+}
+
+// CloneRFP reissues a past RFP as a new one with a fresh deadline. Only
+// the original buyer may clone, but the source RFP can be in any state.
+func (s *Service) CloneRFP(ctx context.Context, rfpID, callerAddr string, req InstantiateRequest) (*RFP, error) {
+	src, err := s.store.GetRFP(ctx, rfpID)
+	if err != nil {
+		return nil, err
+	}
+	if src.BuyerAddr != strings.ToLower(callerAddr) {
+		return nil, ErrUnauthorized
+	}
+
+	budget := src.Budget
+	if req.Budget != "" {
+		budget = req.Budget
+	}
+
+	return s.CreateRFP(ctx, callerAddr, CreateRFPRequest{
+		ServiceType: src.ServiceType,
+		Description: src.Description,
+		Budget:      budget,
+		Mode:        string(src.Mode),
+		BidsCloseIn: req.BidsCloseIn,
+	})
+}
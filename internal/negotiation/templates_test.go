@@ -0,0 +1,155 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func saveTestTemplate(t *testing.T, svc *Service) *Template {
+	t.Helper()
+	tpl, err := svc.SaveTemplate(context.Background(), buyerAddr, CreateTemplateRequest{
+		Name:        "weekly inference batch",
+		ServiceType: "inference",
+		Description: "batch of 10k prompts",
+		Budget:      "1.000000",
+		Mode:        "sealed",
+		BidsCloseIn: "30m",
+	})
+	if err != nil {
+		t.Fatalf("SaveTemplate failed: %v", err)
+	}
+	return tpl
+}
+
+func TestSaveTemplateValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.SaveTemplate(ctx, buyerAddr, CreateTemplateRequest{
+		Name:        "bad",
+		ServiceType: "inference",
+		Budget:      "0",
+	}); !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+	if _, err := svc.SaveTemplate(ctx, buyerAddr, CreateTemplateRequest{
+		Name:        "bad",
+		ServiceType: "inference",
+		Budget:      "1.000000",
+		Mode:        "dutch",
+	}); !errors.Is(err, ErrInvalidMode) {
+		t.Errorf("expected ErrInvalidMode, got %v", err)
+	}
+	if _, err := svc.SaveTemplate(ctx, buyerAddr, CreateTemplateRequest{
+		Name:        "bad",
+		ServiceType: "inference",
+		Budget:      "1.000000",
+		BidsCloseIn: "whenever",
+	}); err == nil {
+		t.Error("expected error for bad duration")
+	}
+
+	tpl := saveTestTemplate(t, svc)
+	if tpl.Mode != ModeSealed || tpl.UseCount != 0 {
+		t.Errorf("unexpected template %+v", tpl)
+	}
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	tpl := saveTestTemplate(t, svc)
+
+	if _, err := svc.InstantiateTemplate(ctx, tpl.ID, sellerAddr, InstantiateRequest{}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for foreign caller, got %v", err)
+	}
+
+	rfp, err := svc.InstantiateTemplate(ctx, tpl.ID, buyerAddr, InstantiateRequest{})
+	if err != nil {
+		t.Fatalf("InstantiateTemplate failed: %v", err)
+	}
+	if rfp.ServiceType != tpl.ServiceType || rfp.Budget != tpl.Budget || rfp.Mode != tpl.Mode {
+		t.Errorf("expected RFP to mirror template, got %+v", rfp)
+	}
+	if rfp.Status != RFPBidding {
+		t.Errorf("expected fresh bidding RFP, got %s", rfp.Status)
+	}
+
+	// Overrides apply without touching the saved template.
+	override, err := svc.InstantiateTemplate(ctx, tpl.ID, buyerAddr, InstantiateRequest{Budget: "2.000000"})
+	if err != nil {
+		t.Fatalf("InstantiateTemplate with override failed: %v", err)
+	}
+	if override.Budget != "2.000000" {
+		t.Errorf("expected overridden budget, got %s", override.Budget)
+	}
+
+	updated, _ := svc.GetTemplate(ctx, tpl.ID)
+	if updated.UseCount != 2 {
+		t.Errorf("expected use count 2, got %d", updated.UseCount)
+	}
+	if updated.Budget != "1.000000" {
+		t.Errorf("expected template budget unchanged, got %s", updated.Budget)
+	}
+}
+
+func TestCloneRFP(t *testing.T) {
+	svc, store := newTestService()
+	ctx := context.Background()
+
+	src := createTestRFP(t, svc, "sealed")
+	if _, err := svc.SubmitBid(ctx, src.ID, sellerAddr, BidRequest{Price: "0.800000"}); err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	passDeadline(t, store, src.ID)
+	if _, err := svc.Award(ctx, src.ID, buyerAddr, ""); err != nil {
+		t.Fatalf("Award failed: %v", err)
+	}
+
+	if _, err := svc.CloneRFP(ctx, src.ID, sellerAddr, InstantiateRequest{}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-buyer, got %v", err)
+	}
+
+	// An awarded RFP clones into a fresh bidding round with no bids.
+	clone, err := svc.CloneRFP(ctx, src.ID, buyerAddr, InstantiateRequest{})
+	if err != nil {
+		t.Fatalf("CloneRFP failed: %v", err)
+	}
+	if clone.ID == src.ID {
+		t.Error("expected a new RFP ID")
+	}
+	if clone.Status != RFPBidding || clone.BidCount != 0 || clone.WinningBidID != "" {
+		t.Errorf("expected clean clone, got %+v", clone)
+	}
+	if clone.Mode != ModeSealed || clone.Budget != src.Budget {
+		t.Errorf("expected clone to keep mode and budget, got %+v", clone)
+	}
+	if !clone.BidDeadline.After(src.BidDeadline) {
+		t.Error("expected a fresh deadline on the clone")
+	}
+}
+
+func TestDeleteTemplate(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	tpl := saveTestTemplate(t, svc)
+
+	if err := svc.DeleteTemplate(ctx, tpl.ID, sellerAddr); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+	if err := svc.DeleteTemplate(ctx, tpl.ID, buyerAddr); err != nil {
+		t.Fatalf("DeleteTemplate failed: %v", err)
+	}
+	if _, err := svc.GetTemplate(ctx, tpl.ID); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("expected ErrTemplateNotFound, got %v", err)
+	}
+
+	templates, err := svc.ListTemplatesByBuyer(ctx, buyerAddr, 10)
+	if err != nil {
+		t.Fatalf("ListTemplatesByBuyer failed: %v", err)
+	}
+	if len(templates) != 0 {
+		t.Errorf("expected empty catalog, got %d", len(templates))
+	}
+}
@@ -0,0 +1,86 @@
+package negotiation
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/recovery"
+)
+
+// Timer periodically reveals RFPs past their bidding deadline, expires
+// revealed RFPs that were never awarded, and runs automated bidding
+// strategies against open RFPs.
+type Timer struct {
+	service  *Service
+	interval time.Duration
+	logger   *slog.Logger
+	stop     chan struct{}
+	running  atomic.Bool
+}
+
+// NewTimer creates a new RFP deadline timer.
+func NewTimer(service *Service, logger *slog.Logger) *Timer {
+	return &Timer{
+		service:  service,
+		interval: 15 * time.Second,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start begins the timer loop.
+func (t *Timer) Start(ctx context.Context) {
+	t.running.Store(true)
+	defer t.running.Store(false)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.safeClose(ctx)
+		}
+	}
+}
+
+// Stop signals the timer loop to exit.
+func (t *Timer) Stop() {
+	select {
+	case t.stop <- struct{}{}:
+	default:
+	}
+}
+
+// Running returns true if the timer loop is active.
+func (t *Timer) Running() bool {
+	return t.running.Load()
+}
+
+func (t *Timer) safeClose(ctx context.Context) {
+	defer recovery.LogPanic(t.logger, "negotiation_timer")
+
+	processed, err := t.service.ForceCloseBidding(ctx)
+	if err != nil {
+		t.logger.Warn("negotiation timer: error closing bidding", "error", err)
+		return
+	}
+	if processed > 0 {
+		t.logger.Info("negotiation timer: processed rfp deadlines", "count", processed)
+	}
+
+	placed, err := t.service.RunStrategies(ctx)
+	if err != nil {
+		t.logger.Warn("negotiation timer: error running strategies", "error", err)
+		return
+	}
+	if placed > 0 {
+		t.logger.Info("negotiation timer: placed strategy bids", "count", placed)
+	}
+}
@@ -0,0 +1,89 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidVisibility = errors.New("negotiation: invalid visibility")
+	ErrNotInvited        = errors.New("negotiation: not invited to this rfp")
+)
+
+// Visibility controls who can see and bid on an RFP.
+type Visibility string
+
+const (
+	VisibilityPublic Visibility = "public" // Anyone can browse and bid
+	VisibilityInvite Visibility = "invite" // Only addresses on the invite list
+	VisibilityTier   Visibility = "tier"   // Only sellers at or above a reputation tier
+)
+
+// tierRanks orders the reputation tiers used by tier-gated RFPs; names
+// match internal/reputation.
+var tierRanks = map[string]int{
+	"new":         0,
+	"emerging":    1,
+	"established": 2,
+	"trusted":     3,
+	"elite":       4,
+}
+
+// validateVisibility checks a create request's visibility settings and
+// returns the normalized visibility, invite list, and minimum tier.
+func validateVisibility(req CreateRFPRequest) (Visibility, []string, string, error) {
+	switch Visibility(req.Visibility) {
+	case "", VisibilityPublic:
+		return VisibilityPublic, nil, "", nil
+	case VisibilityInvite:
+		if len(req.InvitedAddrs) == 0 {
+			return "", nil, "", fmt.Errorf("%w: invite visibility requires invitedAddrs", ErrInvalidVisibility)
+		}
+		invited := make([]string, 0, len(req.InvitedAddrs))
+		for _, addr := range req.InvitedAddrs {
+			addr = strings.ToLower(strings.TrimSpace(addr))
+			if addr != "" {
+				invited = append(invited, addr)
+			}
+		}
+		if len(invited) == 0 {
+			return "", nil, "", fmt.Errorf("%w: invite visibility requires invitedAddrs", ErrInvalidVisibility)
+		}
+		return VisibilityInvite, invited, "", nil
+	case VisibilityTier:
+		tier := strings.ToLower(req.MinTier)
+		if _, ok := tierRanks[tier]; !ok || tier == "new" {
+			return "", nil, "", fmt.Errorf("%w: minTier must be one of emerging, established, trusted, elite", ErrInvalidVisibility)
+		}
+		return VisibilityTier, nil, tier, nil
+	default:
+		return "", nil, "", fmt.Errorf("%w: %q", ErrInvalidVisibility, req.Visibility)
+	}
+}
+
+// canView reports whether a caller may see an RFP and bid on it. The buyer
+// always can; others depend on the RFP's visibility.
+func (s *Service) canView(ctx context.Context, rfp *RFP, callerAddr string) bool {
+	caller := strings.ToLower(callerAddr)
+	if caller == rfp.BuyerAddr {
+		return true
+	}
+	switch rfp.Visibility {
+	case "", VisibilityPublic:
+		return true
+	case VisibilityInvite:
+		return containsAddr(rfp.InvitedAddrs, caller)
+	case VisibilityTier:
+		if caller == "" || s.reputation == nil {
+			return false
+		}
+		_, tier, err := s.reputation.GetScore(ctx, caller)
+		if err != nil {
+			return false
+		}
+		return tierRanks[strings.ToLower(tier)] >= tierRanks[rfp.MinTier]
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package negotiation
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type tierReputation struct {
+	tiers map[string]string
+}
+
+func (s *tierReputation) GetScore(ctx context.Context, address string) (float64, string, error) {
+	return 0, s.tiers[address], nil
+}
+
+func createVisibilityRFP(t *testing.T, svc *Service, visibility string, invited []string, minTier string) *RFP {
+	t.Helper()
+	rfp, err := svc.CreateRFP(context.Background(), buyerAddr, CreateRFPRequest{
+		ServiceType:  "inference",
+		Budget:       "1.000000",
+		Mode:         "open",
+		Visibility:   visibility,
+		InvitedAddrs: invited,
+		MinTier:      minTier,
+	})
+	if err != nil {
+		t.Fatalf("CreateRFP failed: %v", err)
+	}
+	return rfp
+}
+
+func TestVisibilityValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	cases := []CreateRFPRequest{
+		{ServiceType: "inference", Budget: "1.000000", Visibility: "secret"},
+		{ServiceType: "inference", Budget: "1.000000", Visibility: "invite"},
+		{ServiceType: "inference", Budget: "1.000000", Visibility: "tier"},
+		{ServiceType: "inference", Budget: "1.000000", Visibility: "tier", MinTier: "new"},
+		{ServiceType: "inference", Budget: "1.000000", Visibility: "tier", MinTier: "legendary"},
+	}
+	for i, req := range cases {
+		if _, err := svc.CreateRFP(ctx, buyerAddr, req); !errors.Is(err, ErrInvalidVisibility) {
+			t.Errorf("case %d: expected ErrInvalidVisibility, got %v", i, err)
+		}
+	}
+
+	rfp := createVisibilityRFP(t, svc, "", nil, "")
+	if rfp.Visibility != VisibilityPublic {
+		t.Errorf("expected default visibility public, got %q", rfp.Visibility)
+	}
+}
+
+func TestInviteOnlyRFP(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	rfp := createVisibilityRFP(t, svc, "invite", []string{sellerAddr}, "")
+
+	// Uninvited sellers can't see or bid on the RFP.
+	if _, err := svc.GetRFP(ctx, rfp.ID, sellerAddr2); !errors.Is(err, ErrRFPNotFound) {
+		t.Errorf("expected ErrRFPNotFound for uninvited seller, got %v", err)
+	}
+	if list, err := svc.ListRFPs(ctx, "", sellerAddr2, 10); err != nil || len(list) != 0 {
+		t.Errorf("expected invite RFP hidden from listing, got %d err=%v", len(list), err)
+	}
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.500000"}); !errors.Is(err, ErrNotInvited) {
+		t.Errorf("expected ErrNotInvited, got %v", err)
+	}
+
+	// The buyer and invited sellers see it normally.
+	if _, err := svc.GetRFP(ctx, rfp.ID, buyerAddr); err != nil {
+		t.Errorf("buyer should see own RFP: %v", err)
+	}
+	if list, err := svc.ListRFPs(ctx, "", sellerAddr, 10); err != nil || len(list) != 1 {
+		t.Errorf("expected invited seller to see RFP, got %d err=%v", len(list), err)
+	}
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.500000"}); err != nil {
+		t.Errorf("invited seller bid failed: %v", err)
+	}
+}
+
+func TestTierGatedRFP(t *testing.T) {
+	svc, _ := newTestService()
+	svc.WithReputation(&tierReputation{tiers: map[string]string{
+		sellerAddr:  "established",
+		sellerAddr2: "elite",
+	}})
+	ctx := context.Background()
+	rfp := createVisibilityRFP(t, svc, "tier", nil, "trusted")
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr, BidRequest{Price: "0.500000"}); !errors.Is(err, ErrNotInvited) {
+		t.Errorf("expected ErrNotInvited for below-tier seller, got %v", err)
+	}
+	if list, err := svc.ListRFPs(ctx, "", sellerAddr, 10); err != nil || len(list) != 0 {
+		t.Errorf("expected tier RFP hidden from below-tier seller, got %d err=%v", len(list), err)
+	}
+
+	if _, err := svc.SubmitBid(ctx, rfp.ID, sellerAddr2, BidRequest{Price: "0.500000"}); err != nil {
+		t.Errorf("elite seller bid failed: %v", err)
+	}
+	if list, err := svc.ListRFPs(ctx, "", sellerAddr2, 10); err != nil || len(list) != 1 {
+		t.Errorf("expected tier RFP visible to elite seller, got %d err=%v", len(list), err)
+	}
+}
@@ -0,0 +1,91 @@
+package paywall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Verifier checks and settles x402 payments. Satisfied by Facilitator;
+// tests substitute fakes.
+type Verifier interface {
+	Verify(ctx context.Context, payment *PaymentPayload, reqs PaymentRequirements) (*VerifyResponse, error)
+	Settle(ctx context.Context, payment *PaymentPayload, reqs PaymentRequirements) (*SettlementResponse, error)
+}
+
+// VerifyResponse is the facilitator's answer to a verification request.
+type VerifyResponse struct {
+	IsValid       bool   `json:"isValid"`
+	InvalidReason string `json:"invalidReason,omitempty"`
+}
+
+// Facilitator is an HTTP client for an x402 facilitator service, which
+// validates signed payment authorizations and submits them on-chain so
+// the resource server never touches keys or RPCs.
+type Facilitator struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewFacilitator creates a client for the facilitator at baseURL.
+func NewFacilitator(baseURL string) *Facilitator {
+	return &Facilitator{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// facilitatorRequest is the body both /verify and /settle accept.
+type facilitatorRequest struct {
+	X402Version         int                 `json:"x402Version"`
+	PaymentPayload      *PaymentPayload     `json:"paymentPayload"`
+	PaymentRequirements PaymentRequirements `json:"paymentRequirements"`
+}
+
+// Verify asks the facilitator whether the payment is valid for the given
+// requirements (signature, amount, asset, expiry, replay).
+func (f *Facilitator) Verify(ctx context.Context, payment *PaymentPayload, reqs PaymentRequirements) (*VerifyResponse, error) {
+	var out VerifyResponse
+	if err := f.post(ctx, "/verify", payment, reqs, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Settle asks the facilitator to submit the authorization on-chain.
+func (f *Facilitator) Settle(ctx context.Context, payment *PaymentPayload, reqs PaymentRequirements) (*SettlementResponse, error) {
+	var out SettlementResponse
+	if err := f.post(ctx, "/settle", payment, reqs, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (f *Facilitator) post(ctx context.Context, path string, payment *PaymentPayload, reqs PaymentRequirements, out interface{}) error {
+	body, err := json.Marshal(facilitatorRequest{
+		X402Version:         X402Version,
+		PaymentPayload:      payment,
+		PaymentRequirements: reqs,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("paywall: facilitator %s returned %d", path, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
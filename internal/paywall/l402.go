@@ -0,0 +1,175 @@
+package paywall
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderWWWAuthenticate carries the L402 challenge on 402 responses.
+const HeaderWWWAuthenticate = "WWW-Authenticate"
+
+// l402Scheme is the Authorization scheme for Lightning-paid requests:
+// "L402 <macaroon>:<preimage-hex>".
+const l402Scheme = "L402"
+
+// LightningInvoice is a BOLT-11 invoice the paywall can challenge with.
+type LightningInvoice struct {
+	PaymentHash string // hex-encoded 32-byte payment hash
+	Invoice     string // BOLT-11 payment request
+}
+
+// LightningInvoicer creates invoices on the operator's Lightning node.
+// Wired by the server with an adapter over an LND (or similar) client;
+// the paywall itself stays node-agnostic.
+type LightningInvoicer interface {
+	CreateInvoice(ctx context.Context, amountMsat int64, memo string) (*LightningInvoice, error)
+}
+
+// l402State holds everything the optional Lightning rail needs: the
+// invoicer, the USDC→msat conversion, the macaroon-minting secret, and
+// the set of already-redeemed payment hashes (L402 tokens here are
+// single-use — one invoice pays for one request, which is the point of
+// the rail for sub-dust micro-payments).
+type l402State struct {
+	invoicer    LightningInvoicer
+	msatPerUnit int64
+	secret      []byte
+
+	mu       sync.Mutex
+	redeemed map[string]time.Time
+}
+
+// WithLightning enables the L402 payment path. msatPerUnit is the price
+// of one atomic unit of the configured asset in millisatoshi, set by the
+// operator (e.g. for USDC at $0.000001/unit it tracks the BTC/USD rate).
+func (p *Paywall) WithLightning(invoicer LightningInvoicer, msatPerUnit int64) *Paywall {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		// Without entropy we cannot mint unforgeable macaroons; leave
+		// the rail disabled rather than issue weak ones.
+		p.logger.Error("l402 disabled: no entropy for macaroon secret", "err", err)
+		return p
+	}
+	p.l402 = &l402State{
+		invoicer:    invoicer,
+		msatPerUnit: msatPerUnit,
+		secret:      secret,
+		redeemed:    make(map[string]time.Time),
+	}
+	return p
+}
+
+// l402Challenge attaches a WWW-Authenticate L402 challenge to a 402
+// response: a fresh invoice for the route's price plus a macaroon bound
+// to its payment hash. Best-effort — if the node is unreachable the
+// response still carries the x402 terms.
+func (p *Paywall) l402Challenge(c *gin.Context, reqs PaymentRequirements) {
+	amountMsat, ok := l402Msat(reqs.MaxAmountRequired, p.l402.msatPerUnit)
+	if !ok {
+		return
+	}
+	inv, err := p.l402.invoicer.CreateInvoice(c.Request.Context(), amountMsat, reqs.Description)
+	if err != nil {
+		p.logger.Warn("l402 invoice creation failed", "err", err)
+		return
+	}
+	c.Header(HeaderWWWAuthenticate,
+		fmt.Sprintf("%s macaroon=%q, invoice=%q", l402Scheme, p.l402.mint(inv.PaymentHash), inv.Invoice))
+}
+
+// acceptL402 reports whether the request carries a valid, unredeemed
+// L402 token, redeeming it if so.
+func (p *Paywall) acceptL402(c *gin.Context) bool {
+	auth := c.GetHeader("Authorization")
+	if !strings.HasPrefix(auth, l402Scheme+" ") {
+		return false
+	}
+	macaroon, preimageHex, found := strings.Cut(strings.TrimPrefix(auth, l402Scheme+" "), ":")
+	if !found {
+		return false
+	}
+	paymentHash, ok := p.l402.verify(macaroon)
+	if !ok {
+		return false
+	}
+	preimage, err := hex.DecodeString(preimageHex)
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256(preimage)
+	if hex.EncodeToString(digest[:]) != strings.ToLower(paymentHash) {
+		return false
+	}
+	return p.l402.redeem(paymentHash)
+}
+
+// mint produces a macaroon binding the payment hash under the secret:
+// base64(hash || HMAC-SHA256(secret, hash)).
+func (l *l402State) mint(paymentHash string) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(strings.ToLower(paymentHash)))
+	return base64.StdEncoding.EncodeToString(append([]byte(strings.ToLower(paymentHash)), mac.Sum(nil)...))
+}
+
+// verify checks a macaroon's HMAC and returns the payment hash it binds.
+func (l *l402State) verify(macaroon string) (string, bool) {
+	raw, err := base64.StdEncoding.DecodeString(macaroon)
+	if err != nil || len(raw) != 64+sha256.Size {
+		return "", false
+	}
+	paymentHash, tag := raw[:64], raw[64:]
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write(paymentHash)
+	if !hmac.Equal(tag, mac.Sum(nil)) {
+		return "", false
+	}
+	return string(paymentHash), true
+}
+
+// redeem marks a payment hash spent; false means it was already used.
+func (l *l402State) redeem(paymentHash string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := strings.ToLower(paymentHash)
+	if _, used := l.redeemed[key]; used {
+		return false
+	}
+	l.redeemed[key] = time.Now()
+	// Redeemed hashes only need to outlive their invoice's validity;
+	// drop entries older than a day to bound the map.
+	cutoff := time.Now().Add(-24 * time.Hour)
+	for hash, at := range l.redeemed {
+		if at.Before(cutoff) {
+			delete(l.redeemed, hash)
+		}
+	}
+	return true
+}
+
+// l402Msat converts an atomic-unit amount to millisatoshi.
+func l402Msat(amount string, msatPerUnit int64) (int64, bool) {
+	if msatPerUnit <= 0 {
+		return 0, false
+	}
+	var units int64
+	for _, r := range amount {
+		if r < '0' || r > '9' || units > (1<<53) {
+			return 0, false
+		}
+		units = units*10 + int64(r-'0')
+	}
+	if units <= 0 {
+		return 0, false
+	}
+	return units * msatPerUnit, true
+}
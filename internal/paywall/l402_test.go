@@ -0,0 +1,132 @@
+package paywall
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+// fakeInvoicer issues invoices whose payment hash commits to a preimage
+// the test knows, mimicking a Lightning node.
+type fakeInvoicer struct {
+	preimage []byte
+	memos    []string
+	msats    []int64
+	err      error
+}
+
+func (f *fakeInvoicer) CreateInvoice(_ context.Context, amountMsat int64, memo string) (*LightningInvoice, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.msats = append(f.msats, amountMsat)
+	f.memos = append(f.memos, memo)
+	hash := sha256.Sum256(f.preimage)
+	return &LightningInvoice{
+		PaymentHash: hex.EncodeToString(hash[:]),
+		Invoice:     "lnbc1fakeinvoice",
+	}, nil
+}
+
+var l402ChallengeRe = regexp.MustCompile(`^L402 macaroon="([^"]+)", invoice="([^"]+)"$`)
+
+func TestProtect_L402ChallengeAndRedemption(t *testing.T) {
+	preimage := []byte("thirty-two byte test preimage !!")
+	inv := &fakeInvoicer{preimage: preimage}
+	p := testPaywall(t, &fakeVerifier{}).WithLightning(inv, 10) // 10 msat per unit
+	r := testRouter(p)
+
+	// Unpaid request: 402 with both the x402 body and an L402 challenge.
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/paid", nil))
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+	challenge := w.Header().Get(HeaderWWWAuthenticate)
+	m := l402ChallengeRe.FindStringSubmatch(challenge)
+	if m == nil {
+		t.Fatalf("WWW-Authenticate = %q, not an L402 challenge", challenge)
+	}
+	macaroon, invoice := m[1], m[2]
+	if invoice != "lnbc1fakeinvoice" {
+		t.Errorf("invoice = %q", invoice)
+	}
+	if len(inv.msats) != 1 || inv.msats[0] != 10000 { // 1000 units * 10 msat
+		t.Errorf("invoice msats = %v, want [10000]", inv.msats)
+	}
+
+	// Pay the invoice (we hold the preimage) and retry.
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set("Authorization", "L402 "+macaroon+":"+hex.EncodeToString(preimage))
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("paid status = %d, want 200; body %s", w.Code, w.Body.String())
+	}
+
+	// Tokens are single-use: replaying the same preimage demands again.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set("Authorization", "L402 "+macaroon+":"+hex.EncodeToString(preimage))
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("replay status = %d, want 402", w.Code)
+	}
+}
+
+func TestProtect_L402WrongPreimageRejected(t *testing.T) {
+	preimage := []byte("thirty-two byte test preimage !!")
+	p := testPaywall(t, &fakeVerifier{}).WithLightning(&fakeInvoicer{preimage: preimage}, 10)
+	r := testRouter(p)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/paid", nil))
+	macaroon := l402ChallengeRe.FindStringSubmatch(w.Header().Get(HeaderWWWAuthenticate))[1]
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set("Authorization", "L402 "+macaroon+":"+hex.EncodeToString([]byte("wrong preimage")))
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402 for wrong preimage", w.Code)
+	}
+}
+
+func TestProtect_L402ForgedMacaroonRejected(t *testing.T) {
+	preimage := []byte("thirty-two byte test preimage !!")
+	p := testPaywall(t, &fakeVerifier{}).WithLightning(&fakeInvoicer{preimage: preimage}, 10)
+
+	// Mint with a different secret — the HMAC must not verify.
+	hash := sha256.Sum256(preimage)
+	forger := &l402State{secret: []byte("attacker secret")}
+	forged := forger.mint(hex.EncodeToString(hash[:]))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set("Authorization", "L402 "+forged+":"+hex.EncodeToString(preimage))
+	testRouter(p).ServeHTTP(w, req)
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402 for forged macaroon", w.Code)
+	}
+}
+
+func TestProtect_L402InvoicerDownStillServesX402Terms(t *testing.T) {
+	p := testPaywall(t, &fakeVerifier{}).WithLightning(&fakeInvoicer{err: errors.New("node offline")}, 10)
+
+	w := httptest.NewRecorder()
+	testRouter(p).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/paid", nil))
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+	if got := w.Header().Get(HeaderWWWAuthenticate); got != "" {
+		t.Errorf("WWW-Authenticate = %q, want empty when the node is down", got)
+	}
+	if len(w.Body.Bytes()) == 0 {
+		t.Error("402 body empty; x402 terms should still be offered")
+	}
+}
@@ -0,0 +1,49 @@
+package paywall
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NetworkLedger is the NetworkID reported in settlement responses and
+// receipts for payments debited from an internal ledger balance instead
+// of settled on-chain.
+const NetworkLedger = "alancoin-ledger"
+
+// LedgerPayer debits a registered agent's internal balance in lieu of an
+// on-chain x402 payment. amount is in atomic units of the configured
+// asset; the returned reference identifies the ledger movement and takes
+// the place of a transaction hash. Wired by the server with an adapter
+// over the ledger service.
+type LedgerPayer interface {
+	DebitForResource(ctx context.Context, payer, amount, resource string) (reference string, err error)
+}
+
+// WithLedgerPayer enables ledger-funded payments for callers the server's
+// auth middleware has already identified.
+func (p *Paywall) WithLedgerPayer(lp LedgerPayer) *Paywall {
+	p.ledger = lp
+	return p
+}
+
+// tryLedgerPayment attempts to charge the authenticated caller's ledger
+// balance for amount. It returns the synthetic settlement on success and
+// false when the caller is unidentified, ledger payments are disabled, or
+// the debit fails — in which case the caller falls back to the on-chain
+// x402 flow.
+func (p *Paywall) tryLedgerPayment(c *gin.Context, amount string, reqs PaymentRequirements) (*SettlementResponse, bool) {
+	if p.ledger == nil {
+		return nil, false
+	}
+	payer := c.GetString("authAgentAddr")
+	if payer == "" {
+		return nil, false
+	}
+	ref, err := p.ledger.DebitForResource(c.Request.Context(), payer, amount, reqs.Resource)
+	if err != nil {
+		p.logger.Warn("ledger payment failed, falling back to x402", "payer", payer, "err", err)
+		return nil, false
+	}
+	return &SettlementResponse{Success: true, TxHash: ref, NetworkID: NetworkLedger}, true
+}
@@ -0,0 +1,43 @@
+package paywall
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryPriceStore is an in-memory PriceStore for development and tests.
+type MemoryPriceStore struct {
+	mu     sync.Mutex
+	prices map[string]RoutePrice
+}
+
+var _ PriceStore = (*MemoryPriceStore)(nil)
+
+// NewMemoryPriceStore creates an empty in-memory price store.
+func NewMemoryPriceStore() *MemoryPriceStore {
+	return &MemoryPriceStore{prices: make(map[string]RoutePrice)}
+}
+
+func (s *MemoryPriceStore) ListRoutePrices(_ context.Context) ([]RoutePrice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]RoutePrice, 0, len(s.prices))
+	for _, p := range s.prices {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (s *MemoryPriceStore) UpsertRoutePrice(_ context.Context, price RoutePrice) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prices[priceKey(price.Route, price.Tier)] = price
+	return nil
+}
+
+func (s *MemoryPriceStore) DeleteRoutePrice(_ context.Context, route, tier string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.prices, priceKey(route, tier))
+	return nil
+}
@@ -0,0 +1,306 @@
+package paywall
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Config describes the payment terms every protected route shares.
+type Config struct {
+	Network           string        // x402 network name, e.g. "base", "base-sepolia"
+	PayTo             string        // 0x-prefixed payee address
+	Asset             string        // 0x-prefixed token contract (USDC)
+	FacilitatorURL    string        // x402 facilitator base URL
+	MaxTimeoutSeconds int           // settlement deadline offered to clients (default 60)
+	QuotaTTL          time.Duration // how long a metered payment's calls stay usable (default 1h)
+	ReceiptTTL        time.Duration // how long settled-payment receipts stay retrievable (default 24h)
+}
+
+// Paywall protects gin routes behind x402 payments.
+type Paywall struct {
+	cfg        Config
+	verifier   Verifier
+	quotas     *quotaStore
+	receipts   *receiptStore
+	receiptKey *ecdsa.PrivateKey
+	ledger     LedgerPayer
+	pricebook  *PriceBook
+	tiers      TierResolver
+	l402       *l402State
+	logger     *slog.Logger
+}
+
+// New creates a paywall from the given config.
+func New(cfg Config) (*Paywall, error) {
+	if cfg.Network == "" {
+		return nil, errors.New("paywall: network is required")
+	}
+	if !isHexAddress(cfg.PayTo) {
+		return nil, errors.New("paywall: valid payTo address is required")
+	}
+	if !isHexAddress(cfg.Asset) {
+		return nil, errors.New("paywall: valid asset address is required")
+	}
+	if cfg.FacilitatorURL == "" {
+		return nil, errors.New("paywall: facilitator URL is required")
+	}
+	if cfg.MaxTimeoutSeconds <= 0 {
+		cfg.MaxTimeoutSeconds = 60
+	}
+	if cfg.QuotaTTL <= 0 {
+		cfg.QuotaTTL = time.Hour
+	}
+	if cfg.ReceiptTTL <= 0 {
+		cfg.ReceiptTTL = 24 * time.Hour
+	}
+	return &Paywall{
+		cfg:      cfg,
+		verifier: NewFacilitator(cfg.FacilitatorURL),
+		quotas:   newQuotaStore(),
+		receipts: newReceiptStore(cfg.ReceiptTTL),
+		logger:   slog.Default(),
+	}, nil
+}
+
+// WithLogger sets the logger.
+func (p *Paywall) WithLogger(logger *slog.Logger) *Paywall {
+	p.logger = logger
+	return p
+}
+
+// WithVerifier overrides the facilitator client (used in tests).
+func (p *Paywall) WithVerifier(v Verifier) *Paywall {
+	p.verifier = v
+	return p
+}
+
+// Protect returns middleware that requires an x402 payment of amount
+// (atomic units of the configured asset) before the handler runs.
+// When WithLedgerPayer is configured, callers the server's auth
+// middleware has identified are charged from their ledger balance
+// instead, with the on-chain flow as fallback.
+//
+// The payment is settled before the handler executes: gin cannot add the
+// X-PAYMENT-RESPONSE header once the handler has written the body, and a
+// settled-but-failed request is recoverable (the client holds a receipt)
+// while a served-but-unsettled one is not.
+func (p *Paywall) Protect(amount, description string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		amount, description := p.resolvePrice(c, amount, description)
+		reqs := p.requirements(c, amount, description)
+
+		if settlement, ok := p.tryLedgerPayment(c, amount, reqs); ok {
+			p.recordReceipt(c.GetString("authAgentAddr"), reqs, settlement)
+			c.Header(HeaderPaymentResponse, EncodeSettlement(*settlement))
+			c.Next()
+			return
+		}
+
+		if p.l402 != nil && p.acceptL402(c) {
+			c.Next()
+			return
+		}
+
+		header := c.GetHeader(HeaderPayment)
+		if header == "" {
+			p.demand(c, reqs, "")
+			return
+		}
+
+		payment, err := DecodePayment(header)
+		if err != nil {
+			p.demand(c, reqs, err.Error())
+			return
+		}
+		if payment.Network != p.cfg.Network {
+			p.demand(c, reqs, "payment network does not match")
+			return
+		}
+
+		settlement, ok := p.verifyAndSettle(c, payment, reqs)
+		if !ok {
+			return
+		}
+		p.recordReceipt(payment.Payload.Authorization.From, reqs, settlement)
+
+		c.Header(HeaderPaymentResponse, EncodeSettlement(*settlement))
+		c.Next()
+	}
+}
+
+// ProtectMetered is Protect for chatty clients: one payment of amount buys
+// calls requests instead of one. The settled payment's EIP-3009 nonce acts
+// as the bearer credential — the client replays the same X-PAYMENT header
+// on subsequent calls and the middleware consumes quota locally without
+// another facilitator round trip. Every response carries X-Quota-Limit and
+// X-Quota-Remaining; once the quota is exhausted or its TTL passes, the
+// next request gets a fresh 402.
+func (p *Paywall) ProtectMetered(amount string, calls int, description string) gin.HandlerFunc {
+	if calls < 1 {
+		calls = 1
+	}
+	return func(c *gin.Context) {
+		amount, description := p.resolvePrice(c, amount, description)
+		reqs := p.requirements(c, amount, description)
+
+		// Identified callers with a ledger balance buy call blocks
+		// internally: consume an existing block, or debit a fresh one
+		// when the previous block is spent or expired.
+		if p.ledger != nil && c.GetString("authAgentAddr") != "" {
+			payer := strings.ToLower(c.GetString("authAgentAddr"))
+			key := "ledger:" + payer
+			if q, known := p.quotas.consume(key); known && q.remaining >= 0 {
+				setQuotaHeaders(c, q)
+				c.Next()
+				return
+			}
+			if settlement, ok := p.tryLedgerPayment(c, amount, reqs); ok {
+				p.recordReceipt(payer, reqs, settlement)
+				q := p.quotas.grant(key, payer, calls, p.cfg.QuotaTTL)
+				setQuotaHeaders(c, *q)
+				c.Header(HeaderPaymentResponse, EncodeSettlement(*settlement))
+				c.Next()
+				return
+			}
+			// Debit failed — fall through to the on-chain flow.
+		}
+
+		if p.l402 != nil && p.acceptL402(c) {
+			c.Next()
+			return
+		}
+
+		header := c.GetHeader(HeaderPayment)
+		if header == "" {
+			p.demand(c, reqs, "")
+			return
+		}
+
+		payment, err := DecodePayment(header)
+		if err != nil {
+			p.demand(c, reqs, err.Error())
+			return
+		}
+		if payment.Network != p.cfg.Network {
+			p.demand(c, reqs, "payment network does not match")
+			return
+		}
+
+		nonce := payment.Payload.Authorization.Nonce
+		if q, known := p.quotas.consume(nonce); known {
+			if q.remaining < 0 {
+				p.demand(c, reqs, "payment quota exhausted")
+				return
+			}
+			setQuotaHeaders(c, q)
+			c.Next()
+			return
+		}
+
+		settlement, ok := p.verifyAndSettle(c, payment, reqs)
+		if !ok {
+			return
+		}
+		p.recordReceipt(payment.Payload.Authorization.From, reqs, settlement)
+
+		q := p.quotas.grant(nonce, strings.ToLower(payment.Payload.Authorization.From), calls, p.cfg.QuotaTTL)
+		setQuotaHeaders(c, *q)
+		c.Header(HeaderPaymentResponse, EncodeSettlement(*settlement))
+		c.Next()
+	}
+}
+
+// verifyAndSettle runs the facilitator round trip shared by Protect and
+// ProtectMetered. On failure it has already written the response and
+// returns ok=false.
+func (p *Paywall) verifyAndSettle(c *gin.Context, payment *PaymentPayload, reqs PaymentRequirements) (*SettlementResponse, bool) {
+	ctx := c.Request.Context()
+	verdict, err := p.verifier.Verify(ctx, payment, reqs)
+	if err != nil {
+		p.logger.Error("x402 verification unavailable", "err", err)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable,
+			gin.H{"error": "facilitator_unavailable", "message": "Payment verification is temporarily unavailable"})
+		return nil, false
+	}
+	if !verdict.IsValid {
+		p.demand(c, reqs, verdict.InvalidReason)
+		return nil, false
+	}
+
+	settlement, err := p.verifier.Settle(ctx, payment, reqs)
+	if err != nil {
+		p.logger.Error("x402 settlement failed", "err", err)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable,
+			gin.H{"error": "settlement_failed", "message": "Payment settlement is temporarily unavailable"})
+		return nil, false
+	}
+	if !settlement.Success {
+		p.demand(c, reqs, settlement.Error)
+		return nil, false
+	}
+	return settlement, true
+}
+
+// setQuotaHeaders reports the metered payment's state to the client.
+func setQuotaHeaders(c *gin.Context, q quota) {
+	c.Header(HeaderQuotaLimit, strconv.Itoa(q.limit))
+	c.Header(HeaderQuotaRemaining, strconv.Itoa(q.remaining))
+}
+
+// demand aborts with the x402 402 response listing what we accept. When
+// the Lightning rail is enabled the response also carries an L402
+// challenge, so clients can pay over whichever rail suits the amount.
+func (p *Paywall) demand(c *gin.Context, reqs PaymentRequirements, reason string) {
+	if p.l402 != nil {
+		p.l402Challenge(c, reqs)
+	}
+	c.AbortWithStatusJSON(http.StatusPaymentRequired, PaymentRequired{
+		X402Version: X402Version,
+		Error:       reason,
+		Accepts:     []PaymentRequirements{reqs},
+	})
+}
+
+// requirements builds the payment terms for the requested resource.
+func (p *Paywall) requirements(c *gin.Context, amount, description string) PaymentRequirements {
+	scheme := "https"
+	if c.Request.TLS == nil {
+		scheme = "http"
+	}
+	return PaymentRequirements{
+		Scheme:            SchemeExact,
+		Network:           p.cfg.Network,
+		MaxAmountRequired: amount,
+		Resource:          scheme + "://" + c.Request.Host + c.Request.URL.Path,
+		Description:       description,
+		MimeType:          "application/json",
+		PayTo:             p.cfg.PayTo,
+		MaxTimeoutSeconds: p.cfg.MaxTimeoutSeconds,
+		Asset:             p.cfg.Asset,
+	}
+}
+
+// isHexAddress is a minimal 0x-prefixed 20-byte hex check, duplicated from
+// internal/usdc to keep this package dependency-light.
+func isHexAddress(s string) bool {
+	if !strings.HasPrefix(s, "0x") || len(s) != 42 {
+		return false
+	}
+	for _, r := range s[2:] {
+		switch {
+		case r >= '0' && r <= '9':
+		case r >= 'a' && r <= 'f':
+		case r >= 'A' && r <= 'F':
+		default:
+			return false
+		}
+	}
+	return true
+}
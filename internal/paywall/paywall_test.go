@@ -0,0 +1,606 @@
+package paywall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const (
+	testPayTo = "0x1111111111111111111111111111111111111111"
+	testAsset = "0x2222222222222222222222222222222222222222"
+)
+
+type fakeVerifier struct {
+	verify     VerifyResponse
+	verifyErr  error
+	settle     SettlementResponse
+	settleErr  error
+	verifyReqs []PaymentRequirements
+	settled    int
+}
+
+func (f *fakeVerifier) Verify(_ context.Context, _ *PaymentPayload, reqs PaymentRequirements) (*VerifyResponse, error) {
+	f.verifyReqs = append(f.verifyReqs, reqs)
+	if f.verifyErr != nil {
+		return nil, f.verifyErr
+	}
+	v := f.verify
+	return &v, nil
+}
+
+func (f *fakeVerifier) Settle(_ context.Context, _ *PaymentPayload, _ PaymentRequirements) (*SettlementResponse, error) {
+	f.settled++
+	if f.settleErr != nil {
+		return nil, f.settleErr
+	}
+	s := f.settle
+	return &s, nil
+}
+
+func testPaywall(t *testing.T, v Verifier) *Paywall {
+	t.Helper()
+	p, err := New(Config{
+		Network:        "base-sepolia",
+		PayTo:          testPayTo,
+		Asset:          testAsset,
+		FacilitatorURL: "http://facilitator.invalid",
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return p.WithVerifier(v)
+}
+
+func testRouter(p *Paywall) *gin.Engine {
+	r := gin.New()
+	r.GET("/paid", p.Protect("1000", "test resource"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func testPaymentHeader(t *testing.T) string {
+	t.Helper()
+	header, err := EncodePayment(&PaymentPayload{
+		X402Version: X402Version,
+		Scheme:      SchemeExact,
+		Network:     "base-sepolia",
+		Payload: ExactEVMPayload{
+			Signature: "0xsig",
+			Authorization: ExactEVMAuthorization{
+				From:  "0x3333333333333333333333333333333333333333",
+				To:    testPayTo,
+				Value: "1000",
+				Nonce: "0x01",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("EncodePayment: %v", err)
+	}
+	return header
+}
+
+func TestNewValidation(t *testing.T) {
+	base := Config{Network: "base", PayTo: testPayTo, Asset: testAsset, FacilitatorURL: "http://f"}
+
+	if _, err := New(base); err != nil {
+		t.Fatalf("valid config rejected: %v", err)
+	}
+	for name, mutate := range map[string]func(*Config){
+		"network":     func(c *Config) { c.Network = "" },
+		"payTo":       func(c *Config) { c.PayTo = "bogus" },
+		"asset":       func(c *Config) { c.Asset = "" },
+		"facilitator": func(c *Config) { c.FacilitatorURL = "" },
+	} {
+		cfg := base
+		mutate(&cfg)
+		if _, err := New(cfg); err == nil {
+			t.Errorf("expected error for invalid %s", name)
+		}
+	}
+}
+
+func TestProtect_NoPaymentReturns402Schema(t *testing.T) {
+	p := testPaywall(t, &fakeVerifier{})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Host = "api.example.com"
+	testRouter(p).ServeHTTP(w, req)
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+	var body PaymentRequired
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("402 body not valid JSON: %v", err)
+	}
+	if body.X402Version != X402Version {
+		t.Errorf("x402Version = %d, want %d", body.X402Version, X402Version)
+	}
+	if len(body.Accepts) != 1 {
+		t.Fatalf("accepts = %+v, want exactly one entry", body.Accepts)
+	}
+	reqs := body.Accepts[0]
+	if reqs.Scheme != SchemeExact ||
+		reqs.Network != "base-sepolia" ||
+		reqs.MaxAmountRequired != "1000" ||
+		reqs.PayTo != testPayTo ||
+		reqs.Asset != testAsset ||
+		reqs.Resource != "http://api.example.com/paid" {
+		t.Errorf("unexpected requirements: %+v", reqs)
+	}
+}
+
+func TestProtect_ValidPaymentServesAndSettles(t *testing.T) {
+	fake := &fakeVerifier{
+		verify: VerifyResponse{IsValid: true},
+		settle: SettlementResponse{Success: true, TxHash: "0xsettled", NetworkID: "base-sepolia"},
+	}
+	p := testPaywall(t, fake)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set(HeaderPayment, testPaymentHeader(t))
+	testRouter(p).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", w.Code, w.Body.String())
+	}
+	if fake.settled != 1 {
+		t.Errorf("settled %d times, want 1", fake.settled)
+	}
+	settlement, err := DecodeSettlement(w.Header().Get(HeaderPaymentResponse))
+	if err != nil {
+		t.Fatalf("X-PAYMENT-RESPONSE not decodable: %v", err)
+	}
+	if !settlement.Success || settlement.TxHash != "0xsettled" {
+		t.Errorf("settlement = %+v, want success with tx hash", settlement)
+	}
+}
+
+func TestProtect_InvalidPaymentRejected(t *testing.T) {
+	fake := &fakeVerifier{verify: VerifyResponse{IsValid: false, InvalidReason: "insufficient amount"}}
+	p := testPaywall(t, fake)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set(HeaderPayment, testPaymentHeader(t))
+	testRouter(p).ServeHTTP(w, req)
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+	var body PaymentRequired
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Error != "insufficient amount" {
+		t.Errorf("error = %q, want facilitator's invalid reason", body.Error)
+	}
+	if fake.settled != 0 {
+		t.Errorf("settled %d times, want 0", fake.settled)
+	}
+}
+
+func TestProtect_MalformedHeaderRejected(t *testing.T) {
+	p := testPaywall(t, &fakeVerifier{})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set(HeaderPayment, "not-base64!!")
+	testRouter(p).ServeHTTP(w, req)
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+}
+
+func TestProtect_FacilitatorDown(t *testing.T) {
+	p := testPaywall(t, &fakeVerifier{verifyErr: errors.New("connection refused")})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set(HeaderPayment, testPaymentHeader(t))
+	testRouter(p).ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", w.Code)
+	}
+}
+
+func meteredRouter(p *Paywall, calls int) *gin.Engine {
+	r := gin.New()
+	r.GET("/metered", p.ProtectMetered("1000", calls, "metered resource"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func TestProtectMetered_OnePaymentManyCalls(t *testing.T) {
+	fake := &fakeVerifier{
+		verify: VerifyResponse{IsValid: true},
+		settle: SettlementResponse{Success: true, TxHash: "0xsettled"},
+	}
+	p := testPaywall(t, fake)
+	r := meteredRouter(p, 3)
+	header := testPaymentHeader(t)
+
+	// Calls 1-3 all succeed off a single settlement, with the quota
+	// headers counting down.
+	for i, wantRemaining := range []string{"2", "1", "0"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/metered", nil)
+		req.Header.Set(HeaderPayment, header)
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("call %d: status = %d, want 200; body %s", i+1, w.Code, w.Body.String())
+		}
+		if got := w.Header().Get(HeaderQuotaLimit); got != "3" {
+			t.Errorf("call %d: %s = %q, want 3", i+1, HeaderQuotaLimit, got)
+		}
+		if got := w.Header().Get(HeaderQuotaRemaining); got != wantRemaining {
+			t.Errorf("call %d: %s = %q, want %s", i+1, HeaderQuotaRemaining, got, wantRemaining)
+		}
+	}
+	if fake.settled != 1 {
+		t.Errorf("settled %d times, want 1", fake.settled)
+	}
+
+	// Call 4 is out of quota: fresh 402 demanding a new payment.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/metered", nil)
+	req.Header.Set(HeaderPayment, header)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("exhausted call: status = %d, want 402", w.Code)
+	}
+	var body PaymentRequired
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Error != "payment quota exhausted" {
+		t.Errorf("error = %q, want quota exhausted reason", body.Error)
+	}
+	if fake.settled != 1 {
+		t.Errorf("exhausted call triggered settlement; settled = %d", fake.settled)
+	}
+}
+
+func TestProtectMetered_NoPaymentReturns402(t *testing.T) {
+	p := testPaywall(t, &fakeVerifier{})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/metered", nil)
+	meteredRouter(p, 100).ServeHTTP(w, req)
+
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+}
+
+func TestProtectMetered_ExpiredQuotaDemandsNewPayment(t *testing.T) {
+	fake := &fakeVerifier{
+		verify: VerifyResponse{IsValid: true},
+		settle: SettlementResponse{Success: true},
+	}
+	p := testPaywall(t, fake)
+	r := meteredRouter(p, 100)
+	header := testPaymentHeader(t)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/metered", nil)
+	req.Header.Set(HeaderPayment, header)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("first call: status = %d", w.Code)
+	}
+
+	// Age the quota past its TTL; the replayed header must trigger a
+	// fresh facilitator round trip, not serve from stale quota.
+	p.quotas.mu.Lock()
+	for _, q := range p.quotas.quotas {
+		q.expiresAt = time.Now().Add(-time.Second)
+	}
+	p.quotas.mu.Unlock()
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/metered", nil)
+	req.Header.Set(HeaderPayment, header)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("post-expiry call: status = %d, want 200", w.Code)
+	}
+	if fake.settled != 2 {
+		t.Errorf("settled %d times, want 2 (expiry forces re-settlement)", fake.settled)
+	}
+	if got := w.Header().Get(HeaderQuotaRemaining); got != "99" {
+		t.Errorf("%s = %q, want 99 after fresh grant", HeaderQuotaRemaining, got)
+	}
+}
+
+type fakeLedger struct {
+	debits []string // "payer amount resource"
+	err    error
+}
+
+func (f *fakeLedger) DebitForResource(_ context.Context, payer, amount, resource string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.debits = append(f.debits, payer+" "+amount+" "+resource)
+	return "ledger-ref-1", nil
+}
+
+const testAgentAddr = "0x4444444444444444444444444444444444444444"
+
+func authedRouter(p *Paywall) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("authAgentAddr", testAgentAddr) })
+	r.GET("/paid", p.Protect("1000", "test resource"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func TestProtect_LedgerFundedPayment(t *testing.T) {
+	fake := &fakeVerifier{}
+	lp := &fakeLedger{}
+	p := testPaywall(t, fake).WithLedgerPayer(lp)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Host = "api.example.com"
+	authedRouter(p).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", w.Code, w.Body.String())
+	}
+	if len(lp.debits) != 1 || lp.debits[0] != testAgentAddr+" 1000 http://api.example.com/paid" {
+		t.Errorf("debits = %v", lp.debits)
+	}
+	if fake.settled != 0 {
+		t.Errorf("on-chain settle called %d times for a ledger payment", fake.settled)
+	}
+	settlement, err := DecodeSettlement(w.Header().Get(HeaderPaymentResponse))
+	if err != nil {
+		t.Fatalf("X-PAYMENT-RESPONSE: %v", err)
+	}
+	if !settlement.Success || settlement.NetworkID != NetworkLedger || settlement.TxHash != "ledger-ref-1" {
+		t.Errorf("settlement = %+v, want ledger-funded success", settlement)
+	}
+	if receipt, ok := p.receipts.get("ledger-ref-1"); !ok || receipt.Network != NetworkLedger || receipt.Payer != testAgentAddr {
+		t.Errorf("receipt = %+v, %v", receipt, ok)
+	}
+}
+
+func TestProtect_LedgerDebitFailureFallsBackToX402(t *testing.T) {
+	lp := &fakeLedger{err: errors.New("insufficient balance")}
+	p := testPaywall(t, &fakeVerifier{}).WithLedgerPayer(lp)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	authedRouter(p).ServeHTTP(w, req)
+
+	// No X-PAYMENT header and the debit failed: standard 402 demand.
+	if w.Code != http.StatusPaymentRequired {
+		t.Fatalf("status = %d, want 402", w.Code)
+	}
+	var body PaymentRequired
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || len(body.Accepts) != 1 {
+		t.Errorf("402 body should list on-chain payment terms: %s", w.Body.String())
+	}
+}
+
+func TestProtect_UnregisteredCallerUsesX402(t *testing.T) {
+	fake := &fakeVerifier{
+		verify: VerifyResponse{IsValid: true},
+		settle: SettlementResponse{Success: true, TxHash: "0xchain"},
+	}
+	lp := &fakeLedger{}
+	p := testPaywall(t, fake).WithLedgerPayer(lp)
+
+	// No auth middleware — caller is anonymous but carries a payment.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set(HeaderPayment, testPaymentHeader(t))
+	testRouter(p).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if len(lp.debits) != 0 {
+		t.Errorf("ledger debited for an unregistered caller: %v", lp.debits)
+	}
+	if fake.settled != 1 {
+		t.Errorf("settled %d times, want 1", fake.settled)
+	}
+}
+
+func TestProtectMetered_LedgerBuysCallBlocks(t *testing.T) {
+	lp := &fakeLedger{}
+	p := testPaywall(t, &fakeVerifier{}).WithLedgerPayer(lp)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("authAgentAddr", testAgentAddr) })
+	r.GET("/metered", p.ProtectMetered("1000", 2, "metered resource"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	// Three calls: the first debit buys a block of 2, the third call
+	// exhausts it and triggers a second debit automatically.
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metered", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("call %d: status = %d", i+1, w.Code)
+		}
+	}
+	if len(lp.debits) != 2 {
+		t.Errorf("debits = %d, want 2 (blocks of 2 calls across 3 requests)", len(lp.debits))
+	}
+}
+
+func TestReceipts_SignedAndRetrievable(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	fake := &fakeVerifier{
+		verify: VerifyResponse{IsValid: true},
+		settle: SettlementResponse{Success: true, TxHash: "0xAbCd", NetworkID: "base-sepolia"},
+	}
+	p := testPaywall(t, fake).WithReceiptSigner(key)
+	r := testRouter(p)
+	r.GET("/v1/receipts/:txHash", p.GetReceipt)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Host = "api.example.com"
+	req.Header.Set(HeaderPayment, testPaymentHeader(t))
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("paid request: status = %d", w.Code)
+	}
+
+	// Lookup is case-insensitive on the tx hash.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/receipts/0xabcd", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("receipt lookup: status = %d; body %s", w.Code, w.Body.String())
+	}
+	var receipt Receipt
+	if err := json.Unmarshal(w.Body.Bytes(), &receipt); err != nil {
+		t.Fatalf("receipt body: %v", err)
+	}
+	if receipt.TxHash != "0xAbCd" ||
+		receipt.Amount != "1000" ||
+		receipt.Asset != testAsset ||
+		receipt.Payer != "0x3333333333333333333333333333333333333333" ||
+		receipt.Resource == "" {
+		t.Errorf("unexpected receipt: %+v", receipt)
+	}
+
+	// The signature must recover to the signer's address.
+	sig, err := hexutil.Decode(receipt.Signature)
+	if err != nil || len(sig) != 65 {
+		t.Fatalf("signature %q not 65 hex bytes: %v", receipt.Signature, err)
+	}
+	sig[64] -= 27
+	msg := receipt.SigningMessage()
+	hash := ethcrypto.Keccak256([]byte("\x19Ethereum Signed Message:\n" + strconv.Itoa(len(msg)) + msg))
+	pub, err := ethcrypto.SigToPub(hash, sig)
+	if err != nil {
+		t.Fatalf("SigToPub: %v", err)
+	}
+	if got, want := ethcrypto.PubkeyToAddress(*pub), ethcrypto.PubkeyToAddress(key.PublicKey); got != want {
+		t.Errorf("signature recovers to %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+func TestReceipts_UnsignedWithoutKey(t *testing.T) {
+	fake := &fakeVerifier{
+		verify: VerifyResponse{IsValid: true},
+		settle: SettlementResponse{Success: true, TxHash: "0x01"},
+	}
+	p := testPaywall(t, fake)
+	r := testRouter(p)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/paid", nil)
+	req.Header.Set(HeaderPayment, testPaymentHeader(t))
+	r.ServeHTTP(w, req)
+
+	receipt, ok := p.receipts.get("0x01")
+	if !ok {
+		t.Fatal("receipt not recorded")
+	}
+	if receipt.Signature != "" {
+		t.Errorf("signature = %q, want empty without a signing key", receipt.Signature)
+	}
+}
+
+func TestReceipts_UnknownHash404(t *testing.T) {
+	p := testPaywall(t, &fakeVerifier{})
+	r := gin.New()
+	r.GET("/v1/receipts/:txHash", p.GetReceipt)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/receipts/0xmissing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", w.Code)
+	}
+}
+
+func TestDecodePayment(t *testing.T) {
+	header := testPaymentHeader(t)
+	p, err := DecodePayment(header)
+	if err != nil {
+		t.Fatalf("DecodePayment: %v", err)
+	}
+	if p.Payload.Authorization.Value != "1000" {
+		t.Errorf("authorization value = %q, want 1000", p.Payload.Authorization.Value)
+	}
+
+	if _, err := DecodePayment("@@@"); !errors.Is(err, ErrInvalidPayment) {
+		t.Errorf("bad base64: err = %v, want ErrInvalidPayment", err)
+	}
+
+	wrongVersion, _ := EncodePayment(&PaymentPayload{X402Version: 99, Scheme: SchemeExact})
+	if _, err := DecodePayment(wrongVersion); !errors.Is(err, ErrVersionMismatch) {
+		t.Errorf("wrong version: err = %v, want ErrVersionMismatch", err)
+	}
+
+	wrongScheme, _ := EncodePayment(&PaymentPayload{X402Version: X402Version, Scheme: "subscription"})
+	if _, err := DecodePayment(wrongScheme); !errors.Is(err, ErrSchemeUnsupported) {
+		t.Errorf("wrong scheme: err = %v, want ErrSchemeUnsupported", err)
+	}
+}
+
+func TestFacilitatorClient(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		var body facilitatorRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("facilitator body not JSON: %v", err)
+		}
+		if body.X402Version != X402Version || body.PaymentPayload == nil {
+			t.Errorf("unexpected facilitator request: %+v", body)
+		}
+		switch r.URL.Path {
+		case "/verify":
+			_ = json.NewEncoder(w).Encode(VerifyResponse{IsValid: true})
+		case "/settle":
+			_ = json.NewEncoder(w).Encode(SettlementResponse{Success: true, TxHash: "0xabc"})
+		}
+	}))
+	defer srv.Close()
+
+	f := NewFacilitator(srv.URL)
+	payment := &PaymentPayload{X402Version: X402Version, Scheme: SchemeExact, Network: "base"}
+	ctx := context.Background()
+
+	verdict, err := f.Verify(ctx, payment, PaymentRequirements{})
+	if err != nil || !verdict.IsValid {
+		t.Fatalf("Verify = %+v, %v", verdict, err)
+	}
+	if gotPath != "/verify" {
+		t.Errorf("path = %q, want /verify", gotPath)
+	}
+
+	settlement, err := f.Settle(ctx, payment, PaymentRequirements{})
+	if err != nil || !settlement.Success || settlement.TxHash != "0xabc" {
+		t.Fatalf("Settle = %+v, %v", settlement, err)
+	}
+	if gotPath != "/settle" {
+		t.Errorf("path = %q, want /settle", gotPath)
+	}
+}
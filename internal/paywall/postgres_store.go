@@ -0,0 +1,57 @@
+package paywall
+
+import (
+	"context"
+	"database/sql"
+)
+
+// PostgresPriceStore persists route prices in PostgreSQL.
+type PostgresPriceStore struct {
+	db *sql.DB
+}
+
+var _ PriceStore = (*PostgresPriceStore)(nil)
+
+// NewPostgresPriceStore creates a PostgreSQL-backed price store.
+func NewPostgresPriceStore(db *sql.DB) *PostgresPriceStore {
+	return &PostgresPriceStore{db: db}
+}
+
+func (p *PostgresPriceStore) ListRoutePrices(ctx context.Context) ([]RoutePrice, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT route, tier, amount, description, updated_at
+		FROM route_prices
+		ORDER BY route, tier
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []RoutePrice
+	for rows.Next() {
+		var price RoutePrice
+		if err := rows.Scan(&price.Route, &price.Tier, &price.Amount, &price.Description, &price.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, price)
+	}
+	return out, rows.Err()
+}
+
+func (p *PostgresPriceStore) UpsertRoutePrice(ctx context.Context, price RoutePrice) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO route_prices (route, tier, amount, description, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (route, tier) DO UPDATE
+		SET amount = EXCLUDED.amount, description = EXCLUDED.description, updated_at = EXCLUDED.updated_at
+	`, price.Route, price.Tier, price.Amount, price.Description, price.UpdatedAt)
+	return err
+}
+
+func (p *PostgresPriceStore) DeleteRoutePrice(ctx context.Context, route, tier string) error {
+	_, err := p.db.ExecContext(ctx, `
+		DELETE FROM route_prices WHERE route = $1 AND tier = $2
+	`, route, tier)
+	return err
+}
@@ -0,0 +1,236 @@
+package paywall
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mbd888/alancoin/internal/tiermath"
+)
+
+// RoutePrice is one row of the pricing table: what a caller of the given
+// reputation tier pays for the given route. Tier "" is the default price
+// used when no tier-specific override exists.
+type RoutePrice struct {
+	Route       string    `json:"route"`  // gin route pattern, e.g. "/v1/data/:id"
+	Tier        string    `json:"tier"`   // reputation tier, "" for the default
+	Amount      string    `json:"amount"` // atomic units of the configured asset
+	Description string    `json:"description,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// PriceStore persists route prices.
+type PriceStore interface {
+	ListRoutePrices(ctx context.Context) ([]RoutePrice, error)
+	UpsertRoutePrice(ctx context.Context, price RoutePrice) error
+	DeleteRoutePrice(ctx context.Context, route, tier string) error
+}
+
+// TierResolver reports an agent's reputation tier so tiered prices can
+// apply. Wired by the server with an adapter over the reputation
+// provider; "" means no tier (use the default price).
+type TierResolver interface {
+	TierFor(ctx context.Context, agentAddr string) string
+}
+
+// PriceBook caches the pricing table in memory so price resolution never
+// touches the database on the request path. Admin mutations reload it
+// immediately; Run keeps it fresh against out-of-band database edits.
+type PriceBook struct {
+	store PriceStore
+
+	mu     sync.RWMutex
+	prices map[string]RoutePrice // keyed by route + "\x00" + tier
+}
+
+// NewPriceBook creates a price book over the given store. Call Reload
+// (or Run) to populate it.
+func NewPriceBook(store PriceStore) *PriceBook {
+	return &PriceBook{store: store, prices: make(map[string]RoutePrice)}
+}
+
+// Reload replaces the cached table with the store's current contents.
+func (b *PriceBook) Reload(ctx context.Context) error {
+	rows, err := b.store.ListRoutePrices(ctx)
+	if err != nil {
+		return err
+	}
+	fresh := make(map[string]RoutePrice, len(rows))
+	for _, row := range rows {
+		fresh[priceKey(row.Route, row.Tier)] = row
+	}
+	b.mu.Lock()
+	b.prices = fresh
+	b.mu.Unlock()
+	return nil
+}
+
+// Run reloads the table every interval until ctx is cancelled, so prices
+// edited directly in the database still take effect without a redeploy.
+func (b *PriceBook) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = b.Reload(ctx)
+		}
+	}
+}
+
+// PriceFor resolves the price for a route and caller tier: the
+// tier-specific row wins, then the route's default row, then a miss.
+func (b *PriceBook) PriceFor(route, tier string) (RoutePrice, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if tier != "" {
+		if p, ok := b.prices[priceKey(route, tier)]; ok {
+			return p, true
+		}
+	}
+	p, ok := b.prices[priceKey(route, "")]
+	return p, ok
+}
+
+func priceKey(route, tier string) string {
+	return route + "\x00" + tier
+}
+
+// WithPriceBook makes protected routes consult the pricing table,
+// falling back to the amount literal passed to Protect/ProtectMetered
+// when no row matches.
+func (p *Paywall) WithPriceBook(b *PriceBook) *Paywall {
+	p.pricebook = b
+	return p
+}
+
+// WithTierResolver enables per-tier price overrides for identified callers.
+func (p *Paywall) WithTierResolver(r TierResolver) *Paywall {
+	p.tiers = r
+	return p
+}
+
+// resolvePrice applies the pricing table to the request, keeping the
+// code literal as the fallback so unpriced routes keep working.
+func (p *Paywall) resolvePrice(c *gin.Context, amount, description string) (string, string) {
+	if p.pricebook == nil {
+		return amount, description
+	}
+	tier := ""
+	if p.tiers != nil {
+		if addr := c.GetString("authAgentAddr"); addr != "" {
+			tier = p.tiers.TierFor(c.Request.Context(), addr)
+		}
+	}
+	price, ok := p.pricebook.PriceFor(c.FullPath(), tier)
+	if !ok {
+		return amount, description
+	}
+	if price.Description != "" {
+		description = price.Description
+	}
+	return price.Amount, description
+}
+
+// ListPrices is the handler for GET /v1/admin/route-prices.
+func (b *PriceBook) ListPrices(c *gin.Context) {
+	rows, err := b.store.ListRoutePrices(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": "Failed to list route prices",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"prices": rows, "count": len(rows)})
+}
+
+// UpsertPrice is the handler for PUT /v1/admin/route-prices. The change
+// takes effect immediately.
+func (b *PriceBook) UpsertPrice(c *gin.Context) {
+	var req struct {
+		Route       string `json:"route" binding:"required"`
+		Tier        string `json:"tier"`
+		Amount      string `json:"amount" binding:"required"`
+		Description string `json:"description"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "message": err.Error()})
+		return
+	}
+	if !strings.HasPrefix(req.Route, "/") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_route", "message": "Route must be a path starting with /"})
+		return
+	}
+	if req.Tier != "" {
+		if _, ok := tiermath.TierIndex[req.Tier]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_tier", "message": "Unknown reputation tier"})
+			return
+		}
+	}
+	if !isAtomicAmount(req.Amount) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_amount", "message": "Amount must be a positive integer in atomic units"})
+		return
+	}
+
+	price := RoutePrice{
+		Route:       req.Route,
+		Tier:        req.Tier,
+		Amount:      req.Amount,
+		Description: req.Description,
+		UpdatedAt:   time.Now().UTC(),
+	}
+	ctx := c.Request.Context()
+	if err := b.store.UpsertRoutePrice(ctx, price); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "upsert_failed", "message": "Failed to save route price"})
+		return
+	}
+	if err := b.Reload(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "reload_failed", "message": "Price saved but reload failed"})
+		return
+	}
+	c.JSON(http.StatusOK, price)
+}
+
+// DeletePrice is the handler for DELETE /v1/admin/route-prices. Route and
+// tier come from query parameters; deleting a missing row is a no-op.
+func (b *PriceBook) DeletePrice(c *gin.Context) {
+	route := c.Query("route")
+	if route == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_route", "message": "route query parameter is required"})
+		return
+	}
+	ctx := c.Request.Context()
+	if err := b.store.DeleteRoutePrice(ctx, route, c.Query("tier")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "delete_failed", "message": "Failed to delete route price"})
+		return
+	}
+	if err := b.Reload(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "reload_failed", "message": "Price deleted but reload failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": true})
+}
+
+// isAtomicAmount accepts positive base-10 integers (no sign, no decimals),
+// the unit x402 amounts are quoted in.
+func isAtomicAmount(s string) bool {
+	if s == "" || len(s) > 30 {
+		return false
+	}
+	nonZero := false
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+		if r != '0' {
+			nonZero = true
+		}
+	}
+	return nonZero
+}
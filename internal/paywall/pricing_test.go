@@ -0,0 +1,185 @@
+package paywall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fixedTiers struct{ tier string }
+
+func (f fixedTiers) TierFor(context.Context, string) string { return f.tier }
+
+func seedPrices(t *testing.T, store PriceStore, prices ...RoutePrice) {
+	t.Helper()
+	for _, p := range prices {
+		if err := store.UpsertRoutePrice(context.Background(), p); err != nil {
+			t.Fatalf("UpsertRoutePrice: %v", err)
+		}
+	}
+}
+
+func TestPriceBook_TierOverrideThenDefault(t *testing.T) {
+	store := NewMemoryPriceStore()
+	seedPrices(t, store,
+		RoutePrice{Route: "/paid", Tier: "", Amount: "1000"},
+		RoutePrice{Route: "/paid", Tier: "elite", Amount: "500"},
+	)
+	b := NewPriceBook(store)
+	if err := b.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if p, ok := b.PriceFor("/paid", "elite"); !ok || p.Amount != "500" {
+		t.Errorf("elite price = %+v, %v", p, ok)
+	}
+	if p, ok := b.PriceFor("/paid", "new"); !ok || p.Amount != "1000" {
+		t.Errorf("untiered price should fall back to default: %+v, %v", p, ok)
+	}
+	if p, ok := b.PriceFor("/paid", ""); !ok || p.Amount != "1000" {
+		t.Errorf("anonymous price = %+v, %v", p, ok)
+	}
+	if _, ok := b.PriceFor("/other", ""); ok {
+		t.Error("unpriced route resolved")
+	}
+}
+
+func TestProtect_PricesFromBook(t *testing.T) {
+	store := NewMemoryPriceStore()
+	seedPrices(t, store,
+		RoutePrice{Route: "/paid", Tier: "", Amount: "2000", Description: "repriced"},
+		RoutePrice{Route: "/paid", Tier: "elite", Amount: "900"},
+	)
+	b := NewPriceBook(store)
+	if err := b.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	// Anonymous caller sees the route's default price, not the literal.
+	p := testPaywall(t, &fakeVerifier{}).WithPriceBook(b)
+	w := httptest.NewRecorder()
+	testRouter(p).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/paid", nil))
+	var body PaymentRequired
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	if len(body.Accepts) != 1 || body.Accepts[0].MaxAmountRequired != "2000" || body.Accepts[0].Description != "repriced" {
+		t.Errorf("accepts = %+v, want repriced default", body.Accepts)
+	}
+
+	// Elite caller gets the tier override.
+	p = testPaywall(t, &fakeVerifier{}).WithPriceBook(b).WithTierResolver(fixedTiers{"elite"})
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("authAgentAddr", testAgentAddr) })
+	r.GET("/paid", p.Protect("1000", "test resource"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/paid", nil))
+	body = PaymentRequired{}
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	if len(body.Accepts) != 1 || body.Accepts[0].MaxAmountRequired != "900" {
+		t.Errorf("accepts = %+v, want elite override", body.Accepts)
+	}
+
+	// Unpriced route keeps the code literal.
+	p = testPaywall(t, &fakeVerifier{}).WithPriceBook(NewPriceBook(NewMemoryPriceStore()))
+	w = httptest.NewRecorder()
+	testRouter(p).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/paid", nil))
+	body = PaymentRequired{}
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	if len(body.Accepts) != 1 || body.Accepts[0].MaxAmountRequired != "1000" {
+		t.Errorf("accepts = %+v, want code literal fallback", body.Accepts)
+	}
+}
+
+func adminRouter(b *PriceBook) *gin.Engine {
+	r := gin.New()
+	r.GET("/v1/admin/route-prices", b.ListPrices)
+	r.PUT("/v1/admin/route-prices", b.UpsertPrice)
+	r.DELETE("/v1/admin/route-prices", b.DeletePrice)
+	return r
+}
+
+func TestPricingAdmin_UpsertReloadsImmediately(t *testing.T) {
+	b := NewPriceBook(NewMemoryPriceStore())
+	r := adminRouter(b)
+
+	payload, _ := json.Marshal(gin.H{"route": "/paid", "tier": "trusted", "amount": "750", "description": "discount"})
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/v1/admin/route-prices", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("upsert: status = %d; body %s", w.Code, w.Body.String())
+	}
+
+	if p, ok := b.PriceFor("/paid", "trusted"); !ok || p.Amount != "750" {
+		t.Errorf("price not live after upsert: %+v, %v", p, ok)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/admin/route-prices", nil))
+	var list struct {
+		Count int `json:"count"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Count != 1 {
+		t.Errorf("count = %d, want 1", list.Count)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/admin/route-prices?route=/paid&tier=trusted", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("delete: status = %d", w.Code)
+	}
+	if _, ok := b.PriceFor("/paid", "trusted"); ok {
+		t.Error("price still live after delete")
+	}
+}
+
+func TestPricingAdmin_Validation(t *testing.T) {
+	b := NewPriceBook(NewMemoryPriceStore())
+	r := adminRouter(b)
+
+	for name, payload := range map[string]gin.H{
+		"missing route":   {"amount": "100"},
+		"relative route":  {"route": "paid", "amount": "100"},
+		"unknown tier":    {"route": "/paid", "tier": "platinum", "amount": "100"},
+		"decimal amount":  {"route": "/paid", "amount": "1.5"},
+		"zero amount":     {"route": "/paid", "amount": "000"},
+		"negative amount": {"route": "/paid", "amount": "-5"},
+	} {
+		body, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/v1/admin/route-prices", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", name, w.Code)
+		}
+	}
+}
+
+func TestPriceBook_RunReloadsPeriodically(t *testing.T) {
+	store := NewMemoryPriceStore()
+	b := NewPriceBook(store)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go b.Run(ctx, 5*time.Millisecond)
+
+	seedPrices(t, store, RoutePrice{Route: "/paid", Amount: "123"})
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if p, ok := b.PriceFor("/paid", ""); ok && p.Amount == "123" {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("price never appeared via background reload")
+}
@@ -0,0 +1,85 @@
+package paywall
+
+import (
+	"sync"
+	"time"
+)
+
+// Quota headers returned on metered routes so clients can pace themselves
+// without parsing bodies.
+const (
+	HeaderQuotaLimit     = "X-Quota-Limit"
+	HeaderQuotaRemaining = "X-Quota-Remaining"
+)
+
+// quota tracks how many calls a settled payment has left. The EIP-3009
+// nonce uniquely identifies the payment, so the X-PAYMENT header doubles
+// as the bearer credential for the remaining calls.
+type quota struct {
+	payer     string
+	limit     int
+	remaining int
+	expiresAt time.Time
+}
+
+// quotaStore is an in-memory nonce → quota map. Per-process state, like
+// the paymaster spend guard's daily counters: a restart just means
+// clients pay again.
+type quotaStore struct {
+	mu     sync.Mutex
+	quotas map[string]*quota
+}
+
+func newQuotaStore() *quotaStore {
+	return &quotaStore{quotas: make(map[string]*quota)}
+}
+
+// grant registers a freshly settled payment worth limit calls, with the
+// first call already consumed.
+func (s *quotaStore) grant(nonce, payer string, limit int, ttl time.Duration) *quota {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := &quota{
+		payer:     payer,
+		limit:     limit,
+		remaining: limit - 1,
+		expiresAt: time.Now().Add(ttl),
+	}
+	s.quotas[nonce] = q
+	s.sweepLocked()
+	return q
+}
+
+// consume spends one call from the nonce's quota. The second return is
+// false when the payment is unknown or expired; an exhausted quota is
+// returned with ok=true and remaining -1 so the caller can distinguish
+// "exhausted" from "never paid".
+func (s *quotaStore) consume(nonce string) (quota, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q, ok := s.quotas[nonce]
+	if !ok {
+		return quota{}, false
+	}
+	if time.Now().After(q.expiresAt) {
+		delete(s.quotas, nonce)
+		return quota{}, false
+	}
+	if q.remaining > 0 {
+		q.remaining--
+	} else {
+		q.remaining = -1 // signal exhaustion without underflowing
+	}
+	return *q, true
+}
+
+// sweepLocked drops expired quotas so the map doesn't grow unboundedly.
+// Caller holds s.mu.
+func (s *quotaStore) sweepLocked() {
+	now := time.Now()
+	for nonce, q := range s.quotas {
+		if now.After(q.expiresAt) {
+			delete(s.quotas, nonce)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package paywall
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gin-gonic/gin"
+)
+
+// Receipt proves a settled x402 payment: what was bought, for how much,
+// and the on-chain transaction that paid for it. When the paywall has a
+// signing key, Signature is an EIP-191 personal-sign signature over
+// SigningMessage, so any Ethereum library can recover the seller's
+// address and a buyer agent can hand the receipt to its principal as
+// third-party-verifiable proof of purchase.
+type Receipt struct {
+	TxHash    string    `json:"txHash"`
+	Resource  string    `json:"resource"`
+	Amount    string    `json:"amount"` // atomic units of Asset
+	Asset     string    `json:"asset"`
+	Network   string    `json:"network"`
+	Payer     string    `json:"payer"`
+	PaidAt    time.Time `json:"paidAt"`
+	Signature string    `json:"signature,omitempty"` // 0x-prefixed, 65 bytes r||s||v
+}
+
+// SigningMessage is the canonical string the receipt signature covers.
+// Kept pipe-delimited like the session-key delegation format so verifiers
+// can rebuild it without JSON canonicalization concerns.
+func (r *Receipt) SigningMessage() string {
+	return fmt.Sprintf("AlancoinReceipt|%s|%s|%s|%s|%s|%s|%d",
+		strings.ToLower(r.TxHash),
+		r.Resource,
+		r.Amount,
+		strings.ToLower(r.Asset),
+		r.Network,
+		strings.ToLower(r.Payer),
+		r.PaidAt.Unix(),
+	)
+}
+
+// receiptStore keeps recent receipts in memory, keyed by lowercase tx
+// hash. Like quotas, receipts are per-process: the on-chain transfer
+// remains the durable record.
+type receiptStore struct {
+	mu       sync.Mutex
+	receipts map[string]Receipt
+	ttl      time.Duration
+}
+
+func newReceiptStore(ttl time.Duration) *receiptStore {
+	return &receiptStore{receipts: make(map[string]Receipt), ttl: ttl}
+}
+
+func (s *receiptStore) put(r Receipt) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.receipts[strings.ToLower(r.TxHash)] = r
+	cutoff := time.Now().Add(-s.ttl)
+	for hash, old := range s.receipts {
+		if old.PaidAt.Before(cutoff) {
+			delete(s.receipts, hash)
+		}
+	}
+}
+
+func (s *receiptStore) get(txHash string) (Receipt, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.receipts[strings.ToLower(txHash)]
+	if !ok || time.Since(r.PaidAt) > s.ttl {
+		return Receipt{}, false
+	}
+	return r, true
+}
+
+// WithReceiptSigner sets the key receipts are signed with, typically the
+// platform key so the signer address is publicly known.
+func (p *Paywall) WithReceiptSigner(key *ecdsa.PrivateKey) *Paywall {
+	p.receiptKey = key
+	return p
+}
+
+// recordReceipt stores (and signs, when a key is configured) a receipt
+// for a just-settled payment. Best-effort: a receipt that fails to sign
+// is stored unsigned rather than failing the paid request.
+func (p *Paywall) recordReceipt(payer string, reqs PaymentRequirements, settlement *SettlementResponse) {
+	if settlement.TxHash == "" {
+		return
+	}
+	network := reqs.Network
+	if settlement.NetworkID != "" {
+		network = settlement.NetworkID
+	}
+	r := Receipt{
+		TxHash:   settlement.TxHash,
+		Resource: reqs.Resource,
+		Amount:   reqs.MaxAmountRequired,
+		Asset:    reqs.Asset,
+		Network:  network,
+		Payer:    strings.ToLower(payer),
+		PaidAt:   time.Now().UTC().Truncate(time.Second),
+	}
+	if p.receiptKey != nil {
+		sig, err := signReceipt(&r, p.receiptKey)
+		if err != nil {
+			p.logger.Error("receipt signing failed", "txHash", r.TxHash, "err", err)
+		} else {
+			r.Signature = sig
+		}
+	}
+	p.receipts.put(r)
+}
+
+// signReceipt produces an EIP-191 personal-sign signature over the
+// receipt's canonical message.
+func signReceipt(r *Receipt, key *ecdsa.PrivateKey) (string, error) {
+	msg := r.SigningMessage()
+	prefixed := crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(msg), msg)))
+	sig, err := crypto.Sign(prefixed, key)
+	if err != nil {
+		return "", err
+	}
+	sig[64] += 27 // V as 27/28 per Ethereum convention
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+// GetReceipt is the handler for GET /v1/receipts/:txHash. It is exported
+// so the server can mount it alongside whatever routes the paywall
+// protects.
+func (p *Paywall) GetReceipt(c *gin.Context) {
+	txHash := c.Param("txHash")
+	receipt, ok := p.receipts.get(txHash)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "receipt_not_found",
+			"message": "No receipt for that transaction hash",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, receipt)
+}
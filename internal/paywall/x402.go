@@ -0,0 +1,145 @@
+// Package paywall implements the x402 payment-required protocol so
+// generic x402 client libraries can pay for Alancoin-protected endpoints
+// without custom integration.
+//
+// Flow:
+//  1. Client requests a protected resource with no payment attached.
+//  2. Server replies 402 with a PaymentRequired body listing the accepted
+//     payment requirements (scheme, network, asset, amount, payee).
+//  3. Client signs an EIP-3009 transfer authorization and retries with the
+//     X-PAYMENT header (base64 JSON payload).
+//  4. Server verifies and settles the payment through an x402 facilitator,
+//     serves the resource, and attaches the settlement receipt in the
+//     X-PAYMENT-RESPONSE header.
+package paywall
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// X402Version is the protocol version this implementation speaks.
+const X402Version = 1
+
+// Header names defined by the x402 protocol.
+const (
+	HeaderPayment         = "X-PAYMENT"
+	HeaderPaymentResponse = "X-PAYMENT-RESPONSE"
+)
+
+// SchemeExact is the only payment scheme currently supported: an exact
+// EIP-3009 transferWithAuthorization for the full amount.
+const SchemeExact = "exact"
+
+var (
+	ErrInvalidPayment    = errors.New("paywall: invalid payment payload")
+	ErrVersionMismatch   = errors.New("paywall: unsupported x402 version")
+	ErrSchemeUnsupported = errors.New("paywall: unsupported payment scheme")
+)
+
+// PaymentRequirements describes one way a client can pay for a resource.
+// Field names follow the x402 wire format (camelCase JSON).
+type PaymentRequirements struct {
+	Scheme            string            `json:"scheme"`
+	Network           string            `json:"network"`
+	MaxAmountRequired string            `json:"maxAmountRequired"` // atomic units of Asset
+	Resource          string            `json:"resource"`
+	Description       string            `json:"description,omitempty"`
+	MimeType          string            `json:"mimeType,omitempty"`
+	PayTo             string            `json:"payTo"`
+	MaxTimeoutSeconds int               `json:"maxTimeoutSeconds"`
+	Asset             string            `json:"asset"` // token contract address
+	Extra             map[string]string `json:"extra,omitempty"`
+}
+
+// PaymentRequired is the 402 response body.
+type PaymentRequired struct {
+	X402Version int                   `json:"x402Version"`
+	Error       string                `json:"error,omitempty"`
+	Accepts     []PaymentRequirements `json:"accepts"`
+}
+
+// ExactEVMAuthorization mirrors the EIP-3009 transferWithAuthorization
+// parameters the client signed.
+type ExactEVMAuthorization struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Value       string `json:"value"` // atomic units
+	ValidAfter  string `json:"validAfter"`
+	ValidBefore string `json:"validBefore"`
+	Nonce       string `json:"nonce"`
+}
+
+// ExactEVMPayload is the "exact" scheme's payload: a signed EIP-3009
+// authorization the facilitator can submit on-chain.
+type ExactEVMPayload struct {
+	Signature     string                `json:"signature"`
+	Authorization ExactEVMAuthorization `json:"authorization"`
+}
+
+// PaymentPayload is the decoded X-PAYMENT header.
+type PaymentPayload struct {
+	X402Version int             `json:"x402Version"`
+	Scheme      string          `json:"scheme"`
+	Network     string          `json:"network"`
+	Payload     ExactEVMPayload `json:"payload"`
+}
+
+// SettlementResponse is the facilitator's settle result, also returned to
+// the client base64-encoded in the X-PAYMENT-RESPONSE header.
+type SettlementResponse struct {
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+	TxHash    string `json:"txHash,omitempty"`
+	NetworkID string `json:"networkId,omitempty"`
+}
+
+// DecodePayment parses a base64-encoded X-PAYMENT header value.
+func DecodePayment(header string) (*PaymentPayload, error) {
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayment, err)
+	}
+	var p PaymentPayload
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayment, err)
+	}
+	if p.X402Version != X402Version {
+		return nil, fmt.Errorf("%w: got %d", ErrVersionMismatch, p.X402Version)
+	}
+	if p.Scheme != SchemeExact {
+		return nil, fmt.Errorf("%w: %q", ErrSchemeUnsupported, p.Scheme)
+	}
+	return &p, nil
+}
+
+// EncodePayment is the inverse of DecodePayment. Exported for clients and
+// tests that need to construct X-PAYMENT headers.
+func EncodePayment(p *PaymentPayload) (string, error) {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// EncodeSettlement renders the X-PAYMENT-RESPONSE header value.
+func EncodeSettlement(s SettlementResponse) string {
+	raw, _ := json.Marshal(s)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+// DecodeSettlement parses an X-PAYMENT-RESPONSE header value.
+func DecodeSettlement(header string) (*SettlementResponse, error) {
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		return nil, err
+	}
+	var s SettlementResponse
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
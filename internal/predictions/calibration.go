@@ -0,0 +1,109 @@
+package predictions
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// CalibrationPoint is one settled wager with the market-implied probability
+// the agent accepted, for computing calibration curves (implied confidence
+// vs realized outcome).
+type CalibrationPoint struct {
+	PredictionID          string    `json:"predictionId"`
+	WagerID               string    `json:"wagerId"`
+	Category              Category  `json:"category,omitempty"`
+	Side                  Side      `json:"side"`
+	Amount                string    `json:"amount"`
+	ImpliedProbabilityBps int       `json:"impliedProbabilityBps"` // of the chosen side, after the wager landed
+	Outcome               Side      `json:"outcome"`
+	Correct               bool      `json:"correct"`
+	WageredAt             time.Time `json:"wageredAt"`
+	SettledAt             time.Time `json:"settledAt"`
+}
+
+// CalibrationHistory returns the agent's full settled wager history with
+// the implied probability each wager was placed at, replayed from each
+// market's wager sequence. Voided markets are excluded — they have no
+// outcome to calibrate against.
+func (s *Service) CalibrationHistory(ctx context.Context, agentAddr string) ([]*CalibrationPoint, error) {
+	settled, err := s.store.ListSettledPredictions(ctx, "", time.Time{}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []*CalibrationPoint
+	for _, p := range settled {
+		wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(wagers, func(i, j int) bool { return wagers[i].CreatedAt.Before(wagers[j].CreatedAt) })
+
+		settledAt := time.Time{}
+		if p.SettledAt != nil {
+			settledAt = *p.SettledAt
+		}
+		yes := new(big.Int)
+		no := new(big.Int)
+		for _, w := range wagers {
+			amt, _ := usdc.Parse(w.Amount)
+			if w.Side == SideYes {
+				yes.Add(yes, amt)
+			} else {
+				no.Add(no, amt)
+			}
+			if w.AgentAddr != agentAddr {
+				continue
+			}
+			pot := new(big.Int).Add(yes, no)
+			implied := probabilityBps(yes, pot)
+			if w.Side == SideNo {
+				implied = 10000 - implied
+			}
+			points = append(points, &CalibrationPoint{
+				PredictionID:          p.ID,
+				WagerID:               w.ID,
+				Category:              p.Category,
+				Side:                  w.Side,
+				Amount:                w.Amount,
+				ImpliedProbabilityBps: implied,
+				Outcome:               p.Outcome,
+				Correct:               w.Side == p.Outcome,
+				WageredAt:             w.CreatedAt,
+				SettledAt:             settledAt,
+			})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].WageredAt.Before(points[j].WageredAt) })
+	return points, nil
+}
+
+// calibrationCSV renders calibration points as CSV for researchers.
+func calibrationCSV(points []*CalibrationPoint) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"wagered_at", "settled_at", "prediction_id", "wager_id", "category", "side", "amount", "implied_probability_bps", "outcome", "correct"})
+	for _, pt := range points {
+		_ = w.Write([]string{
+			pt.WageredAt.UTC().Format(time.RFC3339),
+			pt.SettledAt.UTC().Format(time.RFC3339),
+			pt.PredictionID,
+			pt.WagerID,
+			string(pt.Category),
+			string(pt.Side),
+			pt.Amount,
+			strconv.Itoa(pt.ImpliedProbabilityBps),
+			string(pt.Outcome),
+			strconv.FormatBool(pt.Correct),
+		})
+	}
+	w.Flush()
+	return buf.Bytes()
+}
@@ -0,0 +1,80 @@
+package predictions
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCalibrationHistoryRepliesImpliedProbability(t *testing.T) {
+	svc, _ := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+
+	p := resolvedMarket(t, svc) // bettorAddr 10 yes, bettor2 5 no, outcome yes
+	rewindChallenge(t, svc, p.ID)
+	if _, err := svc.Finalize(ctx, p.ID); err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+
+	points, err := svc.CalibrationHistory(ctx, bettorAddr)
+	if err != nil {
+		t.Fatalf("CalibrationHistory failed: %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("expected 1 calibration point, got %d", len(points))
+	}
+	pt := points[0]
+	// First wager: the whole pot was on yes when it landed.
+	if pt.ImpliedProbabilityBps != 10000 || !pt.Correct || pt.Side != SideYes || pt.Outcome != SideYes {
+		t.Errorf("unexpected point: %+v", pt)
+	}
+
+	// The no bettor accepted a 5/15 implied probability (rounded up from the yes floor) and lost.
+	points, err = svc.CalibrationHistory(ctx, bettor2)
+	if err != nil {
+		t.Fatalf("CalibrationHistory failed: %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("expected 1 calibration point, got %d", len(points))
+	}
+	if points[0].ImpliedProbabilityBps != 3334 || points[0].Correct {
+		t.Errorf("unexpected point: %+v", points[0])
+	}
+
+	// Agents with no settled wagers export an empty history.
+	empty, err := svc.CalibrationHistory(ctx, adminAddr)
+	if err != nil {
+		t.Fatalf("CalibrationHistory failed: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("expected empty history, got %d points", len(empty))
+	}
+}
+
+func TestCalibrationCSV(t *testing.T) {
+	svc, _ := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+
+	p := resolvedMarket(t, svc)
+	rewindChallenge(t, svc, p.ID)
+	if _, err := svc.Finalize(ctx, p.ID); err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+
+	points, err := svc.CalibrationHistory(ctx, bettor2)
+	if err != nil {
+		t.Fatalf("CalibrationHistory failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(calibrationCSV(points))), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header + 1 row, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "wagered_at,settled_at,prediction_id") {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], ",no,5.000000,3334,yes,false") {
+		t.Errorf("unexpected row: %s", lines[1])
+	}
+}
@@ -0,0 +1,159 @@
+package predictions
+
+import (
+	"context"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+)
+
+// DefaultDisputeWindow is how long a recorded outcome stays open to
+// challenge before the pot can settle.
+const DefaultDisputeWindow = 24 * time.Hour
+
+// maxEvidenceLength bounds dispute evidence content.
+const maxEvidenceLength = 10000
+
+// DisputeStatus represents the lifecycle of a resolution challenge.
+type DisputeStatus string
+
+const (
+	DisputeOpen       DisputeStatus = "open"
+	DisputeConfirmed  DisputeStatus = "confirmed"  // original outcome upheld
+	DisputeOverturned DisputeStatus = "overturned" // outcome flipped
+)
+
+// Dispute challenges a recorded outcome during the challenge window,
+// pausing settlement until an admin rules on it.
+type Dispute struct {
+	ID             string        `json:"id"`
+	PredictionID   string        `json:"predictionId"`
+	ChallengerAddr string        `json:"challengerAddr"`
+	Evidence       string        `json:"evidence"`
+	Status         DisputeStatus `json:"status"`
+	Note           string        `json:"note,omitempty"` // ruling rationale
+	RuledBy        string        `json:"ruledBy,omitempty"`
+	RuledAt        *time.Time    `json:"ruledAt,omitempty"`
+	CreatedAt      time.Time     `json:"createdAt"`
+}
+
+// DisputeResolution challenges a recorded outcome. Only agents with a wager
+// on the prediction can dispute, and only while the challenge window is
+// open. Settlement pauses until an admin rules.
+func (s *Service) DisputeResolution(ctx context.Context, predictionID, challengerAddr, evidence string) (*Dispute, error) {
+	if evidence == "" || len(evidence) > maxEvidenceLength {
+		return nil, ErrInvalidDispute
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.store.GetPrediction(ctx, predictionID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != PredictionResolved {
+		return nil, ErrPredictionClosed
+	}
+	if p.ChallengeEndsAt == nil || !time.Now().Before(*p.ChallengeEndsAt) {
+		return nil, ErrWindowClosed
+	}
+
+	wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+	hasStake := false
+	for _, w := range wagers {
+		if w.AgentAddr == challengerAddr {
+			hasStake = true
+			break
+		}
+	}
+	if !hasStake {
+		return nil, ErrUnauthorized
+	}
+
+	now := time.Now()
+	d := &Dispute{
+		ID:             idgen.WithPrefix("pdsp_"),
+		PredictionID:   p.ID,
+		ChallengerAddr: challengerAddr,
+		Evidence:       evidence,
+		Status:         DisputeOpen,
+		CreatedAt:      now,
+	}
+	if err := s.store.CreateDispute(ctx, d); err != nil {
+		return nil, err
+	}
+
+	p.Status = PredictionDisputed
+	p.UpdatedAt = now
+	if err := s.store.UpdatePrediction(ctx, p); err != nil {
+		return nil, err
+	}
+	s.logger.Info("predictions: resolution disputed",
+		"prediction", p.ID, "dispute", d.ID, "challenger", challengerAddr)
+	return d, nil
+}
+
+// RuleDispute is the admin ruling on an open dispute: confirm upholds the
+// recorded outcome, overturn flips it. Either way the pot settles
+// immediately — the challenge window does not restart.
+func (s *Service) RuleDispute(ctx context.Context, disputeID, ruledBy string, overturn bool, note string) (*Dispute, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, err := s.store.GetDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status != DisputeOpen {
+		return nil, ErrInvalidDispute
+	}
+	p, err := s.store.GetPrediction(ctx, d.PredictionID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != PredictionDisputed {
+		return nil, ErrPredictionClosed
+	}
+
+	if overturn {
+		if p.Outcome == SideYes {
+			p.Outcome = SideNo
+		} else {
+			p.Outcome = SideYes
+		}
+		d.Status = DisputeOverturned
+	} else {
+		d.Status = DisputeConfirmed
+	}
+	if err := s.settleLocked(ctx, p); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	d.Note = note
+	d.RuledBy = ruledBy
+	d.RuledAt = &now
+	if err := s.store.UpdateDispute(ctx, d); err != nil {
+		return nil, err
+	}
+	s.logger.Info("predictions: dispute ruled",
+		"dispute", d.ID, "prediction", p.ID, "status", d.Status, "ruledBy", ruledBy)
+	return d, nil
+}
+
+// ListDisputes returns a prediction's dispute history.
+func (s *Service) ListDisputes(ctx context.Context, predictionID string, limit int) ([]*Dispute, error) {
+	if _, err := s.store.GetPrediction(ctx, predictionID); err != nil {
+		return nil, err
+	}
+	return s.store.ListDisputesByPrediction(ctx, predictionID, limit)
+}
+
+// ListOpenDisputes returns the admin ruling queue.
+func (s *Service) ListOpenDisputes(ctx context.Context, limit int) ([]*Dispute, error) {
+	return s.store.ListOpenDisputes(ctx, limit)
+}
@@ -0,0 +1,146 @@
+package predictions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const adminAddr = "0x4444444444444444444444444444444444444444"
+
+// resolvedMarket sets up a resolved yes/no market inside its challenge
+// window: bettorAddr staked 10 on yes, bettor2 staked 5 on no, outcome yes.
+func resolvedMarket(t *testing.T, svc *Service) *Prediction {
+	t.Helper()
+	ctx := context.Background()
+	p := createTestPrediction(t, svc, &OracleSpec{
+		Source: SourceRegistry, Metric: "transaction_count", Subject: creatorAddr, Op: OpGTE, Value: 100,
+	})
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "10.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideNo, "5.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	rewindDeadline(t, svc, p.ID)
+	resolved, err := svc.Resolve(ctx, p.ID, bettorAddr, "")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	return resolved
+}
+
+func TestDisputeValidation(t *testing.T) {
+	svc, _ := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+	p := resolvedMarket(t, svc)
+
+	if _, err := svc.DisputeResolution(ctx, p.ID, bettor2, ""); !errors.Is(err, ErrInvalidDispute) {
+		t.Errorf("expected ErrInvalidDispute for empty evidence, got %v", err)
+	}
+	// Only agents with money on the line can challenge.
+	if _, err := svc.DisputeResolution(ctx, p.ID, adminAddr, "the feed glitched"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-wagerer, got %v", err)
+	}
+	// Once the window lapses, the outcome stands.
+	rewindChallenge(t, svc, p.ID)
+	if _, err := svc.DisputeResolution(ctx, p.ID, bettor2, "too late"); !errors.Is(err, ErrWindowClosed) {
+		t.Errorf("expected ErrWindowClosed, got %v", err)
+	}
+}
+
+func TestDisputeConfirmSettlesOriginalOutcome(t *testing.T) {
+	svc, ledger := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+	p := resolvedMarket(t, svc)
+
+	d, err := svc.DisputeResolution(ctx, p.ID, bettor2, "oracle counted duplicate transactions")
+	if err != nil {
+		t.Fatalf("DisputeResolution failed: %v", err)
+	}
+	disputed, _ := svc.GetPrediction(ctx, p.ID)
+	if disputed.Status != PredictionDisputed {
+		t.Errorf("expected disputed status, got %s", disputed.Status)
+	}
+
+	// Settlement is paused while the dispute is open, even past the window.
+	rewindChallenge(t, svc, p.ID)
+	if _, err := svc.Finalize(ctx, p.ID); !errors.Is(err, ErrPredictionClosed) {
+		t.Errorf("expected ErrPredictionClosed while disputed, got %v", err)
+	}
+
+	ruled, err := svc.RuleDispute(ctx, d.ID, adminAddr, false, "oracle reading checks out")
+	if err != nil {
+		t.Fatalf("RuleDispute failed: %v", err)
+	}
+	if ruled.Status != DisputeConfirmed || ruled.RuledBy != adminAddr || ruled.RuledAt == nil {
+		t.Errorf("unexpected ruling: %+v", ruled)
+	}
+	settled, _ := svc.GetPrediction(ctx, p.ID)
+	if settled.Status != PredictionSettled || settled.Outcome != SideYes {
+		t.Errorf("unexpected settlement: %+v", settled)
+	}
+	if got := ledger.balance(bettorAddr); got != "105.000000" {
+		t.Errorf("expected original winner paid, balance %s", got)
+	}
+
+	// The ruling is final.
+	if _, err := svc.RuleDispute(ctx, d.ID, adminAddr, true, "second thoughts"); !errors.Is(err, ErrInvalidDispute) {
+		t.Errorf("expected ErrInvalidDispute on ruled dispute, got %v", err)
+	}
+}
+
+func TestDisputeOverturnFlipsOutcome(t *testing.T) {
+	svc, ledger := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+	p := resolvedMarket(t, svc)
+
+	d, err := svc.DisputeResolution(ctx, p.ID, bettor2, "oracle counted duplicate transactions")
+	if err != nil {
+		t.Fatalf("DisputeResolution failed: %v", err)
+	}
+	ruled, err := svc.RuleDispute(ctx, d.ID, adminAddr, true, "duplicates confirmed, true count below threshold")
+	if err != nil {
+		t.Fatalf("RuleDispute failed: %v", err)
+	}
+	if ruled.Status != DisputeOverturned {
+		t.Errorf("expected overturned, got %s", ruled.Status)
+	}
+	settled, _ := svc.GetPrediction(ctx, p.ID)
+	if settled.Status != PredictionSettled || settled.Outcome != SideNo {
+		t.Errorf("unexpected settlement: %+v", settled)
+	}
+	// The challenger now takes the whole 15 USDC pot off a 5 USDC stake.
+	if got := ledger.balance(bettor2); got != "110.000000" {
+		t.Errorf("expected challenger at 110.000000, got %s", got)
+	}
+	if got := ledger.balance(bettorAddr); got != "90.000000" {
+		t.Errorf("expected original winner at 90.000000, got %s", got)
+	}
+
+	history, err := svc.ListDisputes(ctx, p.ID, 0)
+	if err != nil {
+		t.Fatalf("ListDisputes failed: %v", err)
+	}
+	if len(history) != 1 || history[0].Status != DisputeOverturned {
+		t.Errorf("unexpected dispute history: %+v", history)
+	}
+}
+
+func TestDisputeWindowOverride(t *testing.T) {
+	svc, _ := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	svc.WithDisputeWindow(time.Minute)
+	p := resolvedMarket(t, svc)
+
+	if p.ChallengeEndsAt == nil {
+		t.Fatal("expected challenge window")
+	}
+	if until := time.Until(*p.ChallengeEndsAt); until > time.Minute || until < 30*time.Second {
+		t.Errorf("expected ~1m challenge window, ends in %s", until)
+	}
+}
@@ -0,0 +1,102 @@
+package predictions
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Follow subscribes one agent to another's predictions: followers get
+// webhook and realtime notifications for new claims and resolutions, and
+// the followed predictors make up the follower's personalized feed.
+type Follow struct {
+	FollowerAddr  string    `json:"followerAddr"`
+	PredictorAddr string    `json:"predictorAddr"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// Follow subscribes followerAddr to predictorAddr's predictions. Following
+// twice is a no-op.
+func (s *Service) Follow(ctx context.Context, followerAddr, predictorAddr string) (*Follow, error) {
+	if followerAddr == "" || predictorAddr == "" || followerAddr == predictorAddr {
+		return nil, ErrInvalidFollow
+	}
+	f := &Follow{
+		FollowerAddr:  followerAddr,
+		PredictorAddr: predictorAddr,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.store.CreateFollow(ctx, f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Unfollow removes a subscription.
+func (s *Service) Unfollow(ctx context.Context, followerAddr, predictorAddr string) error {
+	return s.store.DeleteFollow(ctx, followerAddr, predictorAddr)
+}
+
+// ListFollowing returns who an agent follows.
+func (s *Service) ListFollowing(ctx context.Context, followerAddr string, limit int) ([]*Follow, error) {
+	return s.store.ListFollowing(ctx, followerAddr, limit)
+}
+
+// Feed returns predictions from every predictor the agent follows, newest
+// first.
+func (s *Service) Feed(ctx context.Context, followerAddr string, limit int) ([]*Prediction, error) {
+	following, err := s.store.ListFollowing(ctx, followerAddr, 0)
+	if err != nil {
+		return nil, err
+	}
+	var feed []*Prediction
+	for _, f := range following {
+		predictions, err := s.store.ListPredictionsByCreator(ctx, f.PredictorAddr, 0)
+		if err != nil {
+			return nil, err
+		}
+		feed = append(feed, predictions...)
+	}
+	sort.Slice(feed, func(i, j int) bool { return feed[i].CreatedAt.After(feed[j].CreatedAt) })
+	if limit > 0 && len(feed) > limit {
+		feed = feed[:limit]
+	}
+	return feed, nil
+}
+
+// notifyCreated fans a new prediction out to the creator's followers.
+func (s *Service) notifyCreated(ctx context.Context, p *Prediction) {
+	if s.realtime != nil {
+		s.realtime.BroadcastPredictionCreated(p.ID, p.CreatorAddr, p.Claim, string(p.Category))
+	}
+	if s.webhooks == nil {
+		return
+	}
+	followers, err := s.store.ListFollowers(ctx, p.CreatorAddr, 0)
+	if err != nil {
+		s.logger.Warn("predictions: failed to list followers", "creator", p.CreatorAddr, "error", err)
+		return
+	}
+	for _, f := range followers {
+		s.webhooks.EmitPredictionCreated(f.FollowerAddr, p.ID, p.CreatorAddr, p.Claim, string(p.Category))
+	}
+}
+
+// notifyResolved fans a recorded outcome (or void) out to the creator's
+// followers.
+func (s *Service) notifyResolved(ctx context.Context, p *Prediction, outcome string) {
+	if s.realtime != nil {
+		s.realtime.BroadcastPredictionResolved(p.ID, p.CreatorAddr, outcome)
+	}
+	if s.webhooks == nil {
+		return
+	}
+	followers, err := s.store.ListFollowers(ctx, p.CreatorAddr, 0)
+	if err != nil {
+		s.logger.Warn("predictions: failed to list followers", "creator", p.CreatorAddr, "error", err)
+		return
+	}
+	for _, f := range followers {
+		s.webhooks.EmitPredictionResolved(f.FollowerAddr, p.ID, p.CreatorAddr, outcome)
+	}
+}
@@ -0,0 +1,124 @@
+package predictions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFollowValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.Follow(ctx, bettorAddr, bettorAddr); !errors.Is(err, ErrInvalidFollow) {
+		t.Errorf("expected ErrInvalidFollow for self-follow, got %v", err)
+	}
+	if _, err := svc.Follow(ctx, "", creatorAddr); !errors.Is(err, ErrInvalidFollow) {
+		t.Errorf("expected ErrInvalidFollow for empty follower, got %v", err)
+	}
+
+	if _, err := svc.Follow(ctx, bettorAddr, creatorAddr); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	// Following twice is a no-op.
+	if _, err := svc.Follow(ctx, bettorAddr, creatorAddr); err != nil {
+		t.Fatalf("repeat Follow failed: %v", err)
+	}
+	following, err := svc.ListFollowing(ctx, bettorAddr, 0)
+	if err != nil {
+		t.Fatalf("ListFollowing failed: %v", err)
+	}
+	if len(following) != 1 || following[0].PredictorAddr != creatorAddr {
+		t.Errorf("unexpected following list: %+v", following)
+	}
+
+	if err := svc.Unfollow(ctx, bettorAddr, creatorAddr); err != nil {
+		t.Fatalf("Unfollow failed: %v", err)
+	}
+	following, _ = svc.ListFollowing(ctx, bettorAddr, 0)
+	if len(following) != 0 {
+		t.Errorf("expected empty following list after unfollow, got %+v", following)
+	}
+}
+
+func TestFollowersNotifiedOnCreateAndResolve(t *testing.T) {
+	svc, _ := newTestService()
+	emitter := &fakeEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+
+	if _, err := svc.Follow(ctx, bettorAddr, creatorAddr); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	if _, err := svc.Follow(ctx, bettor2, creatorAddr); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+
+	p := createTestPrediction(t, svc, nil)
+	if len(emitter.created) != 2 {
+		t.Fatalf("expected 2 created events, got %d", len(emitter.created))
+	}
+	for _, e := range emitter.created {
+		if e.predictionID != p.ID {
+			t.Errorf("unexpected created event: %+v", e)
+		}
+	}
+
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "4.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideNo, "4.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	rewindDeadline(t, svc, p.ID)
+	if _, err := svc.Resolve(ctx, p.ID, creatorAddr, SideYes); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if len(emitter.resolved) != 2 {
+		t.Fatalf("expected 2 resolved events, got %d", len(emitter.resolved))
+	}
+	if emitter.resolved[0].detail != "yes" {
+		t.Errorf("expected yes outcome in event, got %q", emitter.resolved[0].detail)
+	}
+}
+
+func TestFeedMergesFollowedPredictors(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	first := createTestPrediction(t, svc, nil)
+	second, err := svc.CreatePrediction(ctx, bettor2, CreatePredictionRequest{
+		Claim: "other predictor's claim", Deadline: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreatePrediction failed: %v", err)
+	}
+
+	// Following only the first creator scopes the feed.
+	if _, err := svc.Follow(ctx, bettorAddr, creatorAddr); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	feed, err := svc.Feed(ctx, bettorAddr, 0)
+	if err != nil {
+		t.Fatalf("Feed failed: %v", err)
+	}
+	if len(feed) != 1 || feed[0].ID != first.ID {
+		t.Errorf("unexpected feed: %+v", feed)
+	}
+
+	if _, err := svc.Follow(ctx, bettorAddr, bettor2); err != nil {
+		t.Fatalf("Follow failed: %v", err)
+	}
+	feed, err = svc.Feed(ctx, bettorAddr, 0)
+	if err != nil {
+		t.Fatalf("Feed failed: %v", err)
+	}
+	if len(feed) != 2 {
+		t.Fatalf("expected 2 feed entries, got %d", len(feed))
+	}
+	// Newest first.
+	if feed[0].ID != second.ID {
+		t.Errorf("expected newest prediction first, got %s", feed[0].ID)
+	}
+}
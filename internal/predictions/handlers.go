@@ -0,0 +1,535 @@
+package predictions
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func safeMessage(status int, err error, fallback string) string {
+	if status < 500 {
+		return err.Error()
+	}
+	return fallback
+}
+
+// Handler provides HTTP endpoints for the prediction market.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new predictions handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes sets up read-only prediction routes.
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/predictions", h.ListPredictions)
+	r.GET("/predictions/leaderboard", h.Leaderboard)
+	r.GET("/predictions/odds", h.ListOdds)
+	r.GET("/predictions/:id", h.GetPrediction)
+	r.GET("/predictions/:id/odds", h.Odds)
+	r.GET("/predictions/:id/wagers", h.ListWagers)
+	r.GET("/predictions/:id/disputes", h.ListDisputes)
+	r.GET("/agents/:address/predictions", h.ListCreatorPredictions)
+	r.GET("/agents/:address/following", h.ListFollowing)
+	r.GET("/agents/:address/predictions/record", h.AgentRecord)
+	r.GET("/agents/:address/predictions/calibration", h.Calibration)
+	r.GET("/agents/:address/wagers", h.ListAgentWagers)
+}
+
+// RegisterProtectedRoutes sets up auth-required prediction routes.
+func (h *Handler) RegisterProtectedRoutes(r *gin.RouterGroup) {
+	r.POST("/predictions", h.CreatePrediction)
+	r.POST("/predictions/:id/wagers", h.PlaceWager)
+	r.POST("/predictions/:id/resolve", h.Resolve)
+	r.POST("/predictions/:id/dispute", h.DisputeResolution)
+	r.POST("/predictions/:id/finalize", h.Finalize)
+	r.GET("/predictions/feed", h.Feed)
+	r.POST("/agents/:address/follow", h.Follow)
+	r.DELETE("/agents/:address/follow", h.Unfollow)
+}
+
+// RegisterAdminRoutes sets up the dispute ruling routes. The caller is
+// expected to guard the group with auth.RequireAdmin().
+func (h *Handler) RegisterAdminRoutes(r *gin.RouterGroup) {
+	r.GET("/admin/predictions/disputes", h.ListOpenDisputes)
+	r.POST("/admin/predictions/disputes/:id/confirm", h.ConfirmDispute)
+	r.POST("/admin/predictions/disputes/:id/overturn", h.OverturnDispute)
+}
+
+// CreatePrediction handles POST /predictions
+func (h *Handler) CreatePrediction(c *gin.Context) {
+	var req CreatePredictionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	prediction, err := h.service.CreatePrediction(c.Request.Context(), c.GetString("authAgentAddr"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidPrediction), errors.Is(err, ErrInvalidOracle):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_prediction", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "create_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to create prediction"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, prediction)
+}
+
+// GetPrediction handles GET /predictions/:id
+func (h *Handler) GetPrediction(c *gin.Context) {
+	prediction, err := h.service.GetPrediction(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Prediction not found"})
+		return
+	}
+	c.JSON(http.StatusOK, prediction)
+}
+
+// ListPredictions handles GET /predictions?category=<category>
+func (h *Handler) ListPredictions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	predictions, err := h.service.ListPredictions(c.Request.Context(), Category(c.Query("category")), limit)
+	if err != nil {
+		if errors.Is(err, ErrInvalidPrediction) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_category", "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list predictions"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"predictions": predictions, "count": len(predictions)})
+}
+
+// Leaderboard handles GET /predictions/leaderboard?category=<category>&days=<n>
+func (h *Handler) Leaderboard(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	entries, err := h.service.Leaderboard(c.Request.Context(), Category(c.Query("category")),
+		time.Duration(days)*24*time.Hour, limit)
+	if err != nil {
+		if errors.Is(err, ErrInvalidPrediction) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_category", "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "leaderboard_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to build leaderboard"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"leaderboard": entries, "count": len(entries), "days": days})
+}
+
+// Odds handles GET /predictions/:id/odds
+func (h *Handler) Odds(c *gin.Context) {
+	odds, err := h.service.Odds(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrPredictionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Prediction not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "odds_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to compute odds"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, odds)
+}
+
+// ListOdds handles GET /predictions/odds?category=<category>
+func (h *Handler) ListOdds(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	odds, err := h.service.ListOdds(c.Request.Context(), Category(c.Query("category")), limit)
+	if err != nil {
+		if errors.Is(err, ErrInvalidPrediction) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_category", "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "odds_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to compute odds"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"odds": odds, "count": len(odds)})
+}
+
+// AgentRecord handles GET /agents/:address/predictions/record?days=<n>
+func (h *Handler) AgentRecord(c *gin.Context) {
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+	records, err := h.service.AgentRecord(c.Request.Context(), c.Param("address"),
+		time.Duration(days)*24*time.Hour)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "record_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to build record"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"record": records, "days": days})
+}
+
+// Calibration handles GET /agents/:address/predictions/calibration?format=csv
+func (h *Handler) Calibration(c *gin.Context) {
+	addr := c.Param("address")
+	points, err := h.service.CalibrationHistory(c.Request.Context(), addr)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "calibration_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to build calibration history"),
+		})
+		return
+	}
+	switch c.Query("format") {
+	case "csv":
+		filename := "alancoin-calibration-" + addr + "-" + time.Now().UTC().Format("20060102T150405Z") + ".csv"
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "text/csv", calibrationCSV(points))
+	default:
+		c.JSON(http.StatusOK, gin.H{"calibration": points, "count": len(points)})
+	}
+}
+
+// ListCreatorPredictions handles GET /agents/:address/predictions
+func (h *Handler) ListCreatorPredictions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	predictions, err := h.service.ListPredictionsByCreator(c.Request.Context(), c.Param("address"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list predictions"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"predictions": predictions, "count": len(predictions)})
+}
+
+// PlaceWagerRequest is the input for backing a side of a claim.
+type PlaceWagerRequest struct {
+	Side   Side   `json:"side" binding:"required"`
+	Amount string `json:"amount" binding:"required"`
+}
+
+// PlaceWager handles POST /predictions/:id/wagers
+func (h *Handler) PlaceWager(c *gin.Context) {
+	var req PlaceWagerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	wager, err := h.service.PlaceWager(c.Request.Context(), c.GetString("authAgentAddr"), c.Param("id"), req.Side, req.Amount)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPredictionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Prediction not found"})
+		case errors.Is(err, ErrInvalidWager):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_wager", "message": err.Error()})
+		case errors.Is(err, ErrPredictionClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "prediction_closed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusPaymentRequired, gin.H{
+				"error":   "wager_failed",
+				"message": safeMessage(http.StatusPaymentRequired, err, "Failed to place wager"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, wager)
+}
+
+// ResolveRequest carries the creator's attested outcome for oracle-less
+// claims; it is ignored for oracle-backed ones.
+type ResolveRequest struct {
+	Outcome Side `json:"outcome,omitempty"`
+}
+
+// Resolve handles POST /predictions/:id/resolve
+func (h *Handler) Resolve(c *gin.Context) {
+	var req ResolveRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_request",
+				"message": "Invalid request body",
+			})
+			return
+		}
+	}
+
+	prediction, err := h.service.Resolve(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"), req.Outcome)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPredictionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Prediction not found"})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_creator", "message": err.Error()})
+		case errors.Is(err, ErrInvalidPrediction):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_outcome", "message": err.Error()})
+		case errors.Is(err, ErrNotDue):
+			c.JSON(http.StatusConflict, gin.H{"error": "not_due", "message": err.Error()})
+		case errors.Is(err, ErrPredictionClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "prediction_closed", "message": err.Error()})
+		case errors.Is(err, ErrOracleFailed):
+			c.JSON(http.StatusBadGateway, gin.H{"error": "oracle_failed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "resolve_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to resolve prediction"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, prediction)
+}
+
+// Follow handles POST /agents/:address/follow
+func (h *Handler) Follow(c *gin.Context) {
+	follow, err := h.service.Follow(c.Request.Context(), c.GetString("authAgentAddr"), c.Param("address"))
+	if err != nil {
+		if errors.Is(err, ErrInvalidFollow) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_follow", "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "follow_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to follow predictor"),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, follow)
+}
+
+// Unfollow handles DELETE /agents/:address/follow
+func (h *Handler) Unfollow(c *gin.Context) {
+	if err := h.service.Unfollow(c.Request.Context(), c.GetString("authAgentAddr"), c.Param("address")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "unfollow_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to unfollow predictor"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "unfollowed"})
+}
+
+// ListFollowing handles GET /agents/:address/following
+func (h *Handler) ListFollowing(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	following, err := h.service.ListFollowing(c.Request.Context(), c.Param("address"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list follows"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"following": following, "count": len(following)})
+}
+
+// Feed handles GET /predictions/feed
+func (h *Handler) Feed(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	feed, err := h.service.Feed(c.Request.Context(), c.GetString("authAgentAddr"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "feed_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to build feed"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"predictions": feed, "count": len(feed)})
+}
+
+// DisputeRequest carries the challenger's evidence.
+type DisputeRequest struct {
+	Evidence string `json:"evidence" binding:"required"`
+}
+
+// DisputeResolution handles POST /predictions/:id/dispute
+func (h *Handler) DisputeResolution(c *gin.Context) {
+	var req DisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	dispute, err := h.service.DisputeResolution(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"), req.Evidence)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPredictionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Prediction not found"})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_wagerer", "message": err.Error()})
+		case errors.Is(err, ErrInvalidDispute):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_dispute", "message": err.Error()})
+		case errors.Is(err, ErrWindowClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "window_closed", "message": err.Error()})
+		case errors.Is(err, ErrPredictionClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "prediction_closed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "dispute_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to dispute resolution"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, dispute)
+}
+
+// Finalize handles POST /predictions/:id/finalize
+func (h *Handler) Finalize(c *gin.Context) {
+	prediction, err := h.service.Finalize(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPredictionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Prediction not found"})
+		case errors.Is(err, ErrWindowOpen):
+			c.JSON(http.StatusConflict, gin.H{"error": "window_open", "message": err.Error()})
+		case errors.Is(err, ErrPredictionClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "prediction_closed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "finalize_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to finalize prediction"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, prediction)
+}
+
+// ListDisputes handles GET /predictions/:id/disputes
+func (h *Handler) ListDisputes(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	disputes, err := h.service.ListDisputes(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		if errors.Is(err, ErrPredictionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Prediction not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list disputes"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"disputes": disputes, "count": len(disputes)})
+}
+
+// ListOpenDisputes handles GET /admin/predictions/disputes
+func (h *Handler) ListOpenDisputes(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	disputes, err := h.service.ListOpenDisputes(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list disputes"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"disputes": disputes, "count": len(disputes)})
+}
+
+// RuleRequest carries an optional rationale for the ruling.
+type RuleRequest struct {
+	Note string `json:"note,omitempty"`
+}
+
+// ConfirmDispute handles POST /admin/predictions/disputes/:id/confirm
+func (h *Handler) ConfirmDispute(c *gin.Context) {
+	h.ruleDispute(c, false)
+}
+
+// OverturnDispute handles POST /admin/predictions/disputes/:id/overturn
+func (h *Handler) OverturnDispute(c *gin.Context) {
+	h.ruleDispute(c, true)
+}
+
+func (h *Handler) ruleDispute(c *gin.Context, overturn bool) {
+	var req RuleRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_request",
+				"message": "Invalid request body",
+			})
+			return
+		}
+	}
+
+	dispute, err := h.service.RuleDispute(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"), overturn, req.Note)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrDisputeNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Dispute not found"})
+		case errors.Is(err, ErrInvalidDispute):
+			c.JSON(http.StatusConflict, gin.H{"error": "already_ruled", "message": err.Error()})
+		case errors.Is(err, ErrPredictionClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "prediction_closed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "rule_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to rule on dispute"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, dispute)
+}
+
+// ListWagers handles GET /predictions/:id/wagers
+func (h *Handler) ListWagers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	wagers, err := h.service.ListWagers(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		if errors.Is(err, ErrPredictionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Prediction not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list wagers"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"wagers": wagers, "count": len(wagers)})
+}
+
+// ListAgentWagers handles GET /agents/:address/wagers
+func (h *Handler) ListAgentWagers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	wagers, err := h.service.ListAgentWagers(c.Request.Context(), c.Param("address"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list wagers"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"wagers": wagers, "count": len(wagers)})
+}
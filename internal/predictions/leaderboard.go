@@ -0,0 +1,222 @@
+package predictions
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// Leaderboard windows are clamped to this range.
+const (
+	MinWindow = 24 * time.Hour
+	MaxWindow = 365 * 24 * time.Hour
+)
+
+// LeaderboardEntry ranks one agent's forecasting record over a window. Only
+// settled predictions count; voided markets carry no skill signal and
+// outcomes still inside the challenge window could yet be overturned.
+type LeaderboardEntry struct {
+	AgentAddr   string `json:"agentAddr"`
+	Wagers      int    `json:"wagers"`
+	Correct     int    `json:"correct"`
+	AccuracyBps int    `json:"accuracyBps"` // correct / wagers, basis points
+	Staked      string `json:"staked"`      // USDC wagered in the window
+	NetProfit   string `json:"netProfit"`   // USDC, payouts minus stakes (may be negative)
+}
+
+// CategoryRecord is an agent's leaderboard entry within one category, for the
+// agent profile page.
+type CategoryRecord struct {
+	Category Category `json:"category"`
+	LeaderboardEntry
+}
+
+// Leaderboard ranks agents by accuracy over the window, optionally within a
+// category. Ties break on correct count, then volume staked.
+func (s *Service) Leaderboard(ctx context.Context, category Category, window time.Duration, limit int) ([]*LeaderboardEntry, error) {
+	if !validCategory(category) {
+		return nil, ErrInvalidPrediction
+	}
+	entries, err := s.tallyWindow(ctx, category, window)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*LeaderboardEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AccuracyBps != result[j].AccuracyBps {
+			return result[i].AccuracyBps > result[j].AccuracyBps
+		}
+		if result[i].Correct != result[j].Correct {
+			return result[i].Correct > result[j].Correct
+		}
+		return result[i].Staked > result[j].Staked
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// AgentRecord returns one agent's per-category forecasting record over the
+// window, for the profile page. Uncategorized predictions group under an
+// empty category.
+func (s *Service) AgentRecord(ctx context.Context, agentAddr string, window time.Duration) ([]*CategoryRecord, error) {
+	if window < MinWindow {
+		window = MinWindow
+	}
+	if window > MaxWindow {
+		window = MaxWindow
+	}
+	resolved, err := s.store.ListSettledPredictions(ctx, "", time.Now().Add(-window), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory := make(map[Category]*CategoryRecord)
+	staked := make(map[Category]*big.Int)
+	profit := make(map[Category]*big.Int)
+	for _, p := range resolved {
+		wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range wagers {
+			if w.AgentAddr != agentAddr {
+				continue
+			}
+			r := byCategory[p.Category]
+			if r == nil {
+				r = &CategoryRecord{Category: p.Category, LeaderboardEntry: LeaderboardEntry{AgentAddr: agentAddr}}
+				byCategory[p.Category] = r
+				staked[p.Category] = new(big.Int)
+				profit[p.Category] = new(big.Int)
+			}
+			r.Wagers++
+			amt, _ := usdc.Parse(w.Amount)
+			staked[p.Category].Add(staked[p.Category], amt)
+			profit[p.Category].Sub(profit[p.Category], amt)
+			if w.Side == p.Outcome {
+				r.Correct++
+			}
+			if w.Payout != "" {
+				payout, _ := usdc.Parse(w.Payout)
+				profit[p.Category].Add(profit[p.Category], payout)
+			}
+		}
+	}
+
+	records := make([]*CategoryRecord, 0, len(byCategory))
+	for category, r := range byCategory {
+		r.AccuracyBps = r.Correct * 10000 / r.Wagers
+		r.Staked = usdc.Format(staked[category])
+		r.NetProfit = usdc.Format(profit[category])
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Category < records[j].Category })
+	return records, nil
+}
+
+// WagerOutcome is one settled wager result with when it settled, so
+// consumers (reputation) can decay older calls.
+type WagerOutcome struct {
+	PredictionID string    `json:"predictionId"`
+	Correct      bool      `json:"correct"`
+	SettledAt    time.Time `json:"settledAt"`
+}
+
+// WagerOutcomes returns the agent's settled wager results within the window,
+// clamped like the leaderboards.
+func (s *Service) WagerOutcomes(ctx context.Context, agentAddr string, window time.Duration) ([]*WagerOutcome, error) {
+	if window < MinWindow {
+		window = MinWindow
+	}
+	if window > MaxWindow {
+		window = MaxWindow
+	}
+	settled, err := s.store.ListSettledPredictions(ctx, "", time.Now().Add(-window), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var outcomes []*WagerOutcome
+	for _, p := range settled {
+		wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range wagers {
+			if w.AgentAddr != agentAddr {
+				continue
+			}
+			o := &WagerOutcome{PredictionID: p.ID, Correct: w.Side == p.Outcome}
+			if p.SettledAt != nil {
+				o.SettledAt = *p.SettledAt
+			} else if p.ResolvedAt != nil {
+				o.SettledAt = *p.ResolvedAt
+			}
+			outcomes = append(outcomes, o)
+		}
+	}
+	return outcomes, nil
+}
+
+// tallyWindow aggregates every wager on predictions settled within the
+// window into per-agent entries. An empty category tallies all categories.
+func (s *Service) tallyWindow(ctx context.Context, category Category, window time.Duration) (map[string]*LeaderboardEntry, error) {
+	if window < MinWindow {
+		window = MinWindow
+	}
+	if window > MaxWindow {
+		window = MaxWindow
+	}
+	resolved, err := s.store.ListSettledPredictions(ctx, category, time.Now().Add(-window), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]*LeaderboardEntry)
+	staked := make(map[string]*big.Int)
+	profit := make(map[string]*big.Int)
+	for _, p := range resolved {
+		wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range wagers {
+			e := entries[w.AgentAddr]
+			if e == nil {
+				e = &LeaderboardEntry{AgentAddr: w.AgentAddr}
+				entries[w.AgentAddr] = e
+				staked[w.AgentAddr] = new(big.Int)
+				profit[w.AgentAddr] = new(big.Int)
+			}
+			e.Wagers++
+			amt, _ := usdc.Parse(w.Amount)
+			staked[w.AgentAddr].Add(staked[w.AgentAddr], amt)
+			profit[w.AgentAddr].Sub(profit[w.AgentAddr], amt)
+			if w.Side == p.Outcome {
+				e.Correct++
+			}
+			if w.Payout != "" {
+				payout, _ := usdc.Parse(w.Payout)
+				profit[w.AgentAddr].Add(profit[w.AgentAddr], payout)
+			}
+		}
+	}
+
+	for addr, e := range entries {
+		if e.Wagers > 0 {
+			e.AccuracyBps = e.Correct * 10000 / e.Wagers
+		}
+		e.Staked = usdc.Format(staked[addr])
+		e.NetProfit = usdc.Format(profit[addr])
+	}
+	return entries, nil
+}
@@ -0,0 +1,124 @@
+package predictions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCategoryValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+		Claim: "x", Category: "sports", Deadline: time.Now().Add(time.Hour),
+	}); !errors.Is(err, ErrInvalidPrediction) {
+		t.Errorf("expected ErrInvalidPrediction for unknown category, got %v", err)
+	}
+
+	p, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+		Claim: "x", Category: CategoryMarket, Deadline: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreatePrediction failed: %v", err)
+	}
+	if p.Category != CategoryMarket {
+		t.Errorf("expected market category, got %q", p.Category)
+	}
+
+	byCategory, err := svc.ListPredictions(ctx, CategoryMarket, 0)
+	if err != nil {
+		t.Fatalf("ListPredictions failed: %v", err)
+	}
+	if len(byCategory) != 1 {
+		t.Errorf("expected 1 market prediction, got %d", len(byCategory))
+	}
+	empty, err := svc.ListPredictions(ctx, CategoryServiceQuality, 0)
+	if err != nil {
+		t.Fatalf("ListPredictions failed: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("expected no service_quality predictions, got %d", len(empty))
+	}
+	if _, err := svc.ListPredictions(ctx, "sports", 0); !errors.Is(err, ErrInvalidPrediction) {
+		t.Errorf("expected ErrInvalidPrediction for unknown category filter, got %v", err)
+	}
+}
+
+func TestLeaderboardRanksByAccuracy(t *testing.T) {
+	svc, _ := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+
+	// Two oracle-backed claims that both resolve yes: bettorAddr calls both
+	// right, bettor2 calls both wrong.
+	for i := 0; i < 2; i++ {
+		p, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+			Claim:    "agent clears 100 transactions",
+			Category: CategoryAgentPerformance,
+			Deadline: time.Now().Add(time.Hour),
+			Oracle:   &OracleSpec{Source: SourceRegistry, Metric: "transaction_count", Subject: creatorAddr, Op: OpGTE, Value: 100},
+		})
+		if err != nil {
+			t.Fatalf("CreatePrediction failed: %v", err)
+		}
+		if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "10.000000"); err != nil {
+			t.Fatalf("PlaceWager failed: %v", err)
+		}
+		if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideNo, "5.000000"); err != nil {
+			t.Fatalf("PlaceWager failed: %v", err)
+		}
+		rewindDeadline(t, svc, p.ID)
+		if _, err := svc.Resolve(ctx, p.ID, bettorAddr, ""); err != nil {
+			t.Fatalf("Resolve failed: %v", err)
+		}
+		rewindChallenge(t, svc, p.ID)
+		if _, err := svc.Finalize(ctx, p.ID); err != nil {
+			t.Fatalf("Finalize failed: %v", err)
+		}
+	}
+
+	board, err := svc.Leaderboard(ctx, CategoryAgentPerformance, 30*24*time.Hour, 0)
+	if err != nil {
+		t.Fatalf("Leaderboard failed: %v", err)
+	}
+	if len(board) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(board))
+	}
+	top := board[0]
+	if top.AgentAddr != bettorAddr || top.AccuracyBps != 10000 || top.Correct != 2 {
+		t.Errorf("unexpected leader: %+v", top)
+	}
+	if top.Staked != "20.000000" || top.NetProfit != "10.000000" {
+		t.Errorf("unexpected leader money: staked=%s profit=%s", top.Staked, top.NetProfit)
+	}
+	bottom := board[1]
+	if bottom.AgentAddr != bettor2 || bottom.AccuracyBps != 0 || bottom.NetProfit != "-10.000000" {
+		t.Errorf("unexpected runner-up: %+v", bottom)
+	}
+
+	// Other categories stay empty; the all-category board includes everyone.
+	other, err := svc.Leaderboard(ctx, CategoryMarket, 30*24*time.Hour, 0)
+	if err != nil {
+		t.Fatalf("Leaderboard failed: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("expected empty market board, got %d entries", len(other))
+	}
+	all, err := svc.Leaderboard(ctx, "", 30*24*time.Hour, 1)
+	if err != nil {
+		t.Fatalf("Leaderboard failed: %v", err)
+	}
+	if len(all) != 1 || all[0].AgentAddr != bettorAddr {
+		t.Errorf("expected truncated all-category board led by winner, got %+v", all)
+	}
+
+	records, err := svc.AgentRecord(ctx, bettor2, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("AgentRecord failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Category != CategoryAgentPerformance || records[0].Wagers != 2 {
+		t.Errorf("unexpected record: %+v", records)
+	}
+}
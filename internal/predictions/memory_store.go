@@ -0,0 +1,325 @@
+package predictions
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-memory store for demo/development.
+type MemoryStore struct {
+	predictions map[string]*Prediction
+	wagers      map[string]*Wager
+	disputes    map[string]*Dispute
+	follows     map[string]*Follow
+	mu          sync.RWMutex
+}
+
+// NewMemoryStore creates a new in-memory predictions store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		predictions: make(map[string]*Prediction),
+		wagers:      make(map[string]*Wager),
+		disputes:    make(map[string]*Dispute),
+		follows:     make(map[string]*Follow),
+	}
+}
+
+func (m *MemoryStore) CreatePrediction(ctx context.Context, p *Prediction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.predictions[p.ID] = copyPrediction(p)
+	return nil
+}
+
+func (m *MemoryStore) GetPrediction(ctx context.Context, id string) (*Prediction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.predictions[id]
+	if !ok {
+		return nil, ErrPredictionNotFound
+	}
+	return copyPrediction(p), nil
+}
+
+func (m *MemoryStore) UpdatePrediction(ctx context.Context, p *Prediction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.predictions[p.ID]; !ok {
+		return ErrPredictionNotFound
+	}
+	m.predictions[p.ID] = copyPrediction(p)
+	return nil
+}
+
+func (m *MemoryStore) ListOpenPredictions(ctx context.Context, category Category, limit int) ([]*Prediction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Prediction
+	for _, p := range m.predictions {
+		if p.Status != PredictionOpen {
+			continue
+		}
+		if category != "" && p.Category != category {
+			continue
+		}
+		result = append(result, copyPrediction(p))
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListResolvedPredictions(ctx context.Context, before time.Time, limit int) ([]*Prediction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Prediction
+	for _, p := range m.predictions {
+		if p.Status != PredictionResolved || p.ChallengeEndsAt == nil || p.ChallengeEndsAt.After(before) {
+			continue
+		}
+		result = append(result, copyPrediction(p))
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListSettledPredictions(ctx context.Context, category Category, since time.Time, limit int) ([]*Prediction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Prediction
+	for _, p := range m.predictions {
+		if p.Status != PredictionSettled || p.ResolvedAt == nil || p.ResolvedAt.Before(since) {
+			continue
+		}
+		if category != "" && p.Category != category {
+			continue
+		}
+		result = append(result, copyPrediction(p))
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListPredictionsByCreator(ctx context.Context, creatorAddr string, limit int) ([]*Prediction, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Prediction
+	for _, p := range m.predictions {
+		if p.CreatorAddr == creatorAddr {
+			result = append(result, copyPrediction(p))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateWager(ctx context.Context, w *Wager) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.wagers[w.ID] = copyWager(w)
+	return nil
+}
+
+func (m *MemoryStore) UpdateWager(ctx context.Context, w *Wager) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.wagers[w.ID]; !ok {
+		return ErrInvalidWager
+	}
+	m.wagers[w.ID] = copyWager(w)
+	return nil
+}
+
+func (m *MemoryStore) ListWagersByPrediction(ctx context.Context, predictionID string, limit int) ([]*Wager, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Wager
+	for _, w := range m.wagers {
+		if w.PredictionID == predictionID {
+			result = append(result, copyWager(w))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListWagersByAgent(ctx context.Context, agentAddr string, limit int) ([]*Wager, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Wager
+	for _, w := range m.wagers {
+		if w.AgentAddr == agentAddr {
+			result = append(result, copyWager(w))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateDispute(ctx context.Context, d *Dispute) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.disputes[d.ID] = copyDispute(d)
+	return nil
+}
+
+func (m *MemoryStore) GetDispute(ctx context.Context, id string) (*Dispute, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	d, ok := m.disputes[id]
+	if !ok {
+		return nil, ErrDisputeNotFound
+	}
+	return copyDispute(d), nil
+}
+
+func (m *MemoryStore) UpdateDispute(ctx context.Context, d *Dispute) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.disputes[d.ID]; !ok {
+		return ErrDisputeNotFound
+	}
+	m.disputes[d.ID] = copyDispute(d)
+	return nil
+}
+
+func (m *MemoryStore) GetOpenDisputeByPrediction(ctx context.Context, predictionID string) (*Dispute, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, d := range m.disputes {
+		if d.PredictionID == predictionID && d.Status == DisputeOpen {
+			return copyDispute(d), nil
+		}
+	}
+	return nil, ErrDisputeNotFound
+}
+
+func (m *MemoryStore) ListDisputesByPrediction(ctx context.Context, predictionID string, limit int) ([]*Dispute, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Dispute
+	for _, d := range m.disputes {
+		if d.PredictionID == predictionID {
+			result = append(result, copyDispute(d))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListOpenDisputes(ctx context.Context, limit int) ([]*Dispute, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Dispute
+	for _, d := range m.disputes {
+		if d.Status == DisputeOpen {
+			result = append(result, copyDispute(d))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateFollow(ctx context.Context, f *Follow) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := f.FollowerAddr + "|" + f.PredictorAddr
+	if _, ok := m.follows[key]; ok {
+		return nil
+	}
+	cp := *f
+	m.follows[key] = &cp
+	return nil
+}
+
+func (m *MemoryStore) DeleteFollow(ctx context.Context, followerAddr, predictorAddr string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.follows, followerAddr+"|"+predictorAddr)
+	return nil
+}
+
+func (m *MemoryStore) ListFollowing(ctx context.Context, followerAddr string, limit int) ([]*Follow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Follow
+	for _, f := range m.follows {
+		if f.FollowerAddr == followerAddr {
+			cp := *f
+			result = append(result, &cp)
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListFollowers(ctx context.Context, predictorAddr string, limit int) ([]*Follow, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Follow
+	for _, f := range m.follows {
+		if f.PredictorAddr == predictorAddr {
+			cp := *f
+			result = append(result, &cp)
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func copyPrediction(p *Prediction) *Prediction {
+	cp := *p
+	if p.Oracle != nil {
+		o := *p.Oracle
+		cp.Oracle = &o
+	}
+	if p.ResolvedAt != nil {
+		t := *p.ResolvedAt
+		cp.ResolvedAt = &t
+	}
+	if p.ChallengeEndsAt != nil {
+		t := *p.ChallengeEndsAt
+		cp.ChallengeEndsAt = &t
+	}
+	if p.SettledAt != nil {
+		t := *p.SettledAt
+		cp.SettledAt = &t
+	}
+	return &cp
+}
+
+func copyDispute(d *Dispute) *Dispute {
+	cp := *d
+	if d.RuledAt != nil {
+		t := *d.RuledAt
+		cp.RuledAt = &t
+	}
+	return &cp
+}
+
+func copyWager(w *Wager) *Wager {
+	cp := *w
+	return &cp
+}
+
+var _ Store = (*MemoryStore)(nil)
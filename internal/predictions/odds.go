@@ -0,0 +1,118 @@
+package predictions
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// OddsPoint is the market-implied probability after one wager landed, for
+// charting odds movement.
+type OddsPoint struct {
+	Time              time.Time `json:"time"`
+	ProbabilityYesBps int       `json:"probabilityYesBps"`
+	Pot               string    `json:"pot"` // USDC staked so far
+}
+
+// MarketOdds aggregates a prediction's stake distribution into implied
+// probabilities. An empty market reads as an even split.
+type MarketOdds struct {
+	PredictionID      string           `json:"predictionId"`
+	Status            PredictionStatus `json:"status"`
+	StakedYes         string           `json:"stakedYes"`
+	StakedNo          string           `json:"stakedNo"`
+	Pot               string           `json:"pot"`
+	ProbabilityYesBps int              `json:"probabilityYesBps"`
+	ProbabilityNoBps  int              `json:"probabilityNoBps"`
+	Wagers            int              `json:"wagers"`
+	History           []OddsPoint      `json:"history,omitempty"`
+}
+
+// Odds returns a prediction's current market-implied probabilities plus the
+// odds movement over time, replayed from its wager history.
+func (s *Service) Odds(ctx context.Context, predictionID string) (*MarketOdds, error) {
+	p, err := s.store.GetPrediction(ctx, predictionID)
+	if err != nil {
+		return nil, err
+	}
+	wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(wagers, func(i, j int) bool { return wagers[i].CreatedAt.Before(wagers[j].CreatedAt) })
+
+	odds := oddsFor(p)
+	odds.Wagers = len(wagers)
+	odds.History = make([]OddsPoint, 0, len(wagers))
+	yes := new(big.Int)
+	no := new(big.Int)
+	for _, w := range wagers {
+		amt, _ := usdc.Parse(w.Amount)
+		if w.Side == SideYes {
+			yes.Add(yes, amt)
+		} else {
+			no.Add(no, amt)
+		}
+		pot := new(big.Int).Add(yes, no)
+		odds.History = append(odds.History, OddsPoint{
+			Time:              w.CreatedAt,
+			ProbabilityYesBps: probabilityBps(yes, pot),
+			Pot:               usdc.Format(pot),
+		})
+	}
+	return odds, nil
+}
+
+// ListOdds returns current odds for every open prediction, optionally
+// filtered by category, without per-market history.
+func (s *Service) ListOdds(ctx context.Context, category Category, limit int) ([]*MarketOdds, error) {
+	if !validCategory(category) {
+		return nil, ErrInvalidPrediction
+	}
+	open, err := s.store.ListOpenPredictions(ctx, category, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*MarketOdds, 0, len(open))
+	for _, p := range open {
+		wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+		if err != nil {
+			return nil, err
+		}
+		odds := oddsFor(p)
+		odds.Wagers = len(wagers)
+		result = append(result, odds)
+	}
+	return result, nil
+}
+
+// oddsFor computes current implied probabilities from a prediction's pools.
+func oddsFor(p *Prediction) *MarketOdds {
+	yes, _ := usdc.Parse(p.StakedYes)
+	no, _ := usdc.Parse(p.StakedNo)
+	pot := new(big.Int).Add(yes, no)
+	pYes := probabilityBps(yes, pot)
+	return &MarketOdds{
+		PredictionID:      p.ID,
+		Status:            p.Status,
+		StakedYes:         p.StakedYes,
+		StakedNo:          p.StakedNo,
+		Pot:               usdc.Format(pot),
+		ProbabilityYesBps: pYes,
+		ProbabilityNoBps:  10000 - pYes,
+	}
+}
+
+// probabilityBps is the fraction of the pot on yes, in basis points. An
+// empty pot reads as an even split rather than dividing by zero.
+func probabilityBps(yes, pot *big.Int) int {
+	if pot.Sign() == 0 {
+		return 5000
+	}
+	bps := new(big.Int).Mul(yes, big.NewInt(10000))
+	bps.Div(bps, pot)
+	return int(bps.Int64())
+}
@@ -0,0 +1,93 @@
+package predictions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOddsTrackStakeDistribution(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	p := createTestPrediction(t, svc, nil)
+
+	// Empty market reads as an even split.
+	odds, err := svc.Odds(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("Odds failed: %v", err)
+	}
+	if odds.ProbabilityYesBps != 5000 || odds.ProbabilityNoBps != 5000 || odds.Wagers != 0 {
+		t.Errorf("unexpected empty-market odds: %+v", odds)
+	}
+
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "10.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideNo, "30.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+
+	odds, err = svc.Odds(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("Odds failed: %v", err)
+	}
+	if odds.ProbabilityYesBps != 2500 || odds.ProbabilityNoBps != 7500 {
+		t.Errorf("expected 25/75 split, got %d/%d", odds.ProbabilityYesBps, odds.ProbabilityNoBps)
+	}
+	if odds.Pot != "40.000000" || odds.Wagers != 2 {
+		t.Errorf("unexpected market: %+v", odds)
+	}
+	// History replays the movement: all-in on yes, then diluted to 25%.
+	if len(odds.History) != 2 {
+		t.Fatalf("expected 2 history points, got %d", len(odds.History))
+	}
+	if odds.History[0].ProbabilityYesBps != 10000 || odds.History[0].Pot != "10.000000" {
+		t.Errorf("unexpected first point: %+v", odds.History[0])
+	}
+	if odds.History[1].ProbabilityYesBps != 2500 || odds.History[1].Pot != "40.000000" {
+		t.Errorf("unexpected second point: %+v", odds.History[1])
+	}
+
+	if _, err := svc.Odds(ctx, "prd_missing"); !errors.Is(err, ErrPredictionNotFound) {
+		t.Errorf("expected ErrPredictionNotFound, got %v", err)
+	}
+}
+
+func TestListOddsFiltersOpenMarkets(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	market, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+		Claim: "ETH closes above 5k", Category: CategoryMarket, Deadline: time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreatePrediction failed: %v", err)
+	}
+	if _, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+		Claim: "agent stays above 99% uptime", Category: CategoryServiceQuality, Deadline: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("CreatePrediction failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettorAddr, market.ID, SideYes, "5.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+
+	all, err := svc.ListOdds(ctx, "", 0)
+	if err != nil {
+		t.Fatalf("ListOdds failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected odds for 2 open markets, got %d", len(all))
+	}
+	scoped, err := svc.ListOdds(ctx, CategoryMarket, 0)
+	if err != nil {
+		t.Fatalf("ListOdds failed: %v", err)
+	}
+	if len(scoped) != 1 || scoped[0].PredictionID != market.ID || scoped[0].ProbabilityYesBps != 10000 {
+		t.Errorf("unexpected scoped odds: %+v", scoped)
+	}
+	if _, err := svc.ListOdds(ctx, "sports", 0); !errors.Is(err, ErrInvalidPrediction) {
+		t.Errorf("expected ErrInvalidPrediction for unknown category, got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package predictions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OracleSource identifies where an oracle reads its value from.
+type OracleSource string
+
+const (
+	// SourceRegistry reads a metric from the agent registry.
+	SourceRegistry OracleSource = "registry_metric"
+	// SourceHTTP reads a numeric field from an external JSON endpoint.
+	SourceHTTP OracleSource = "http"
+	// SourcePrice reads an asset price from the configured price feed.
+	SourcePrice OracleSource = "price"
+)
+
+// Comparison operators for oracle specs.
+const (
+	OpGTE = "gte"
+	OpLTE = "lte"
+)
+
+// OracleSpec pins a claim to a machine-verifiable condition: a source value
+// compared against a threshold. The claim resolves yes when the comparison
+// holds at the deadline.
+type OracleSpec struct {
+	Source  OracleSource `json:"source"`
+	Metric  string       `json:"metric,omitempty"`  // registry: metric name
+	Subject string       `json:"subject,omitempty"` // registry: agent address
+	URL     string       `json:"url,omitempty"`     // http: JSON endpoint
+	Field   string       `json:"field,omitempty"`   // http: dot-path to a numeric field
+	Asset   string       `json:"asset,omitempty"`   // price: asset symbol
+	Op      string       `json:"op"`                // gte or lte
+	Value   float64      `json:"value"`             // threshold
+}
+
+func (o *OracleSpec) validate() error {
+	if o.Op != OpGTE && o.Op != OpLTE {
+		return ErrInvalidOracle
+	}
+	switch o.Source {
+	case SourceRegistry:
+		if o.Metric == "" || o.Subject == "" {
+			return ErrInvalidOracle
+		}
+	case SourceHTTP:
+		if !strings.HasPrefix(o.URL, "https://") && !strings.HasPrefix(o.URL, "http://") {
+			return ErrInvalidOracle
+		}
+		if o.Field == "" {
+			return ErrInvalidOracle
+		}
+	case SourcePrice:
+		if o.Asset == "" {
+			return ErrInvalidOracle
+		}
+	default:
+		return ErrInvalidOracle
+	}
+	return nil
+}
+
+// holds reports whether the observed value satisfies the spec's comparison.
+func (o *OracleSpec) holds(observed float64) bool {
+	if o.Op == OpLTE {
+		return observed <= o.Value
+	}
+	return observed >= o.Value
+}
+
+// Oracle observes the value an OracleSpec compares against.
+type Oracle interface {
+	Observe(ctx context.Context, spec *OracleSpec) (float64, error)
+}
+
+// MetricProvider reads a named metric for an agent. The server wires this to
+// the registry.
+type MetricProvider interface {
+	AgentMetric(ctx context.Context, agentAddr, metric string) (float64, error)
+}
+
+// RegistryOracle resolves claims about registry metrics such as success_rate
+// or transaction_count.
+type RegistryOracle struct {
+	metrics MetricProvider
+}
+
+// NewRegistryOracle creates an oracle backed by a registry metric provider.
+func NewRegistryOracle(m MetricProvider) *RegistryOracle {
+	return &RegistryOracle{metrics: m}
+}
+
+func (r *RegistryOracle) Observe(ctx context.Context, spec *OracleSpec) (float64, error) {
+	return r.metrics.AgentMetric(ctx, spec.Subject, spec.Metric)
+}
+
+// HTTPOracle resolves claims against an external JSON endpoint. The response
+// must contain a numeric field at the spec's dot-path (e.g. "data.count").
+type HTTPOracle struct {
+	client *http.Client
+}
+
+// NewHTTPOracle creates an oracle that fetches external JSON endpoints.
+func NewHTTPOracle() *HTTPOracle {
+	return &HTTPOracle{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (h *HTTPOracle) Observe(ctx context.Context, spec *OracleSpec) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, spec.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("oracle endpoint returned %d", resp.StatusCode)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, err
+	}
+	return numberAt(body, spec.Field)
+}
+
+// numberAt walks a dot-path through decoded JSON and returns the numeric
+// value at the end of it.
+func numberAt(body map[string]interface{}, path string) (float64, error) {
+	var current interface{} = body
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("field %q not found", path)
+		}
+		current, ok = obj[key]
+		if !ok {
+			return 0, fmt.Errorf("field %q not found", path)
+		}
+	}
+	switch v := current.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("field %q is not numeric", path)
+	}
+}
+
+// PriceProvider quotes an asset price. The server wires this to the
+// configured price feed.
+type PriceProvider interface {
+	Price(ctx context.Context, asset string) (float64, error)
+}
+
+// PriceOracle resolves claims about asset prices.
+type PriceOracle struct {
+	feed PriceProvider
+}
+
+// NewPriceOracle creates an oracle backed by a price feed.
+func NewPriceOracle(feed PriceProvider) *PriceOracle {
+	return &PriceOracle{feed: feed}
+}
+
+func (p *PriceOracle) Observe(ctx context.Context, spec *OracleSpec) (float64, error) {
+	return p.feed.Price(ctx, spec.Asset)
+}
+
+// HTTPPriceFeed is a PriceProvider that queries a JSON endpoint. The asset is
+// appended as a query parameter and the response must carry a numeric
+// "price" field.
+type HTTPPriceFeed struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPriceFeed creates a price feed against the given endpoint.
+func NewHTTPPriceFeed(url string) *HTTPPriceFeed {
+	return &HTTPPriceFeed{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (f *HTTPPriceFeed) Price(ctx context.Context, asset string) (float64, error) {
+	sep := "?"
+	if strings.Contains(f.url, "?") {
+		sep = "&"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url+sep+"asset="+asset, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("price feed returned %d", resp.StatusCode)
+	}
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, err
+	}
+	return numberAt(body, "price")
+}
@@ -0,0 +1,86 @@
+package predictions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOracleSpecValidation(t *testing.T) {
+	cases := []struct {
+		name string
+		spec OracleSpec
+	}{
+		{"bad op", OracleSpec{Source: SourceRegistry, Metric: "success_rate", Subject: creatorAddr, Op: "eq"}},
+		{"registry without subject", OracleSpec{Source: SourceRegistry, Metric: "success_rate", Op: OpGTE}},
+		{"http without field", OracleSpec{Source: SourceHTTP, URL: "https://example.com/stats", Op: OpGTE}},
+		{"http with bad scheme", OracleSpec{Source: SourceHTTP, URL: "ftp://example.com", Field: "count", Op: OpGTE}},
+		{"price without asset", OracleSpec{Source: SourcePrice, Op: OpLTE}},
+		{"unknown source", OracleSpec{Source: "chain", Op: OpGTE}},
+	}
+	for _, tc := range cases {
+		if err := tc.spec.validate(); err == nil {
+			t.Errorf("%s: expected validation error", tc.name)
+		}
+	}
+
+	good := OracleSpec{Source: SourceHTTP, URL: "https://example.com/stats", Field: "data.count", Op: OpGTE, Value: 10}
+	if err := good.validate(); err != nil {
+		t.Errorf("expected valid spec, got %v", err)
+	}
+}
+
+func TestHTTPOracleObservesNestedField(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"count": 42, "label": "ok"}}`))
+	}))
+	defer srv.Close()
+
+	oracle := NewHTTPOracle()
+	spec := &OracleSpec{Source: SourceHTTP, URL: srv.URL, Field: "data.count", Op: OpGTE, Value: 40}
+	observed, err := oracle.Observe(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if observed != 42 {
+		t.Errorf("expected 42, got %v", observed)
+	}
+	if !spec.holds(observed) {
+		t.Error("expected 42 >= 40 to hold")
+	}
+
+	spec.Field = "data.label"
+	if _, err := oracle.Observe(context.Background(), spec); err == nil {
+		t.Error("expected error for non-numeric field")
+	}
+	spec.Field = "data.missing"
+	if _, err := oracle.Observe(context.Background(), spec); err == nil {
+		t.Error("expected error for missing field")
+	}
+}
+
+func TestHTTPPriceFeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("asset") != "ETH" {
+			http.Error(w, "unknown asset", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"price": 2500.5}`))
+	}))
+	defer srv.Close()
+
+	oracle := NewPriceOracle(NewHTTPPriceFeed(srv.URL))
+	observed, err := oracle.Observe(context.Background(), &OracleSpec{Source: SourcePrice, Asset: "ETH", Op: OpLTE, Value: 3000})
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if observed != 2500.5 {
+		t.Errorf("expected 2500.5, got %v", observed)
+	}
+	if _, err := oracle.Observe(context.Background(), &OracleSpec{Source: SourcePrice, Asset: "DOGE"}); err == nil {
+		t.Error("expected error for unknown asset")
+	}
+}
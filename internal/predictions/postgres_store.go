@@ -0,0 +1,469 @@
+package predictions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// PostgresStore persists predictions data in PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new PostgreSQL-backed predictions store.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const predictionColumns = `id, creator_addr, claim, category, oracle, deadline,
+	staked_yes, staked_no, status, outcome, resolved_by, resolved_at,
+	challenge_ends_at, settled_at, created_at, updated_at`
+
+const wagerColumns = `id, prediction_id, agent_addr, side, amount, payout, created_at`
+
+// effectiveLimit caps unbounded list queries; a limit <= 0 means no limit.
+func effectiveLimit(limit int) int {
+	if limit <= 0 {
+		return 10000
+	}
+	return limit
+}
+
+func (p *PostgresStore) CreatePrediction(ctx context.Context, pr *Prediction) error {
+	var oracleJSON []byte
+	if pr.Oracle != nil {
+		oracleJSON, _ = json.Marshal(pr.Oracle)
+	}
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO predictions (
+			id, creator_addr, claim, category, oracle, deadline,
+			staked_yes, staked_no, status, outcome, resolved_by, resolved_at,
+			challenge_ends_at, settled_at, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6,
+			$7::NUMERIC(20,6), $8::NUMERIC(20,6), $9, $10, $11, $12,
+			$13, $14, $15, $16
+		)`,
+		pr.ID, pr.CreatorAddr, pr.Claim, string(pr.Category), oracleJSON, pr.Deadline,
+		pr.StakedYes, pr.StakedNo, string(pr.Status), string(pr.Outcome), pr.ResolvedBy, nullTime(pr.ResolvedAt),
+		nullTime(pr.ChallengeEndsAt), nullTime(pr.SettledAt), pr.CreatedAt, pr.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetPrediction(ctx context.Context, id string) (*Prediction, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+predictionColumns+` FROM predictions WHERE id = $1`, id)
+	pr, err := scanPrediction(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrPredictionNotFound
+	}
+	return pr, err
+}
+
+func (p *PostgresStore) UpdatePrediction(ctx context.Context, pr *Prediction) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE predictions SET
+			staked_yes = $1::NUMERIC(20,6), staked_no = $2::NUMERIC(20,6),
+			status = $3, outcome = $4, resolved_by = $5, resolved_at = $6,
+			challenge_ends_at = $7, settled_at = $8, updated_at = $9
+		WHERE id = $10`,
+		pr.StakedYes, pr.StakedNo, string(pr.Status), string(pr.Outcome), pr.ResolvedBy, nullTime(pr.ResolvedAt),
+		nullTime(pr.ChallengeEndsAt), nullTime(pr.SettledAt), pr.UpdatedAt, pr.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrPredictionNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListOpenPredictions(ctx context.Context, category Category, limit int) ([]*Prediction, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+predictionColumns+`
+		FROM predictions
+		WHERE status = 'open' AND ($1 = '' OR category = $1)
+		ORDER BY deadline ASC
+		LIMIT $2`, string(category), effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanPredictions(rows)
+}
+
+func (p *PostgresStore) ListResolvedPredictions(ctx context.Context, before time.Time, limit int) ([]*Prediction, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+predictionColumns+`
+		FROM predictions
+		WHERE status = 'resolved' AND challenge_ends_at <= $1
+		ORDER BY challenge_ends_at ASC
+		LIMIT $2`, before, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanPredictions(rows)
+}
+
+func (p *PostgresStore) ListSettledPredictions(ctx context.Context, category Category, since time.Time, limit int) ([]*Prediction, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+predictionColumns+`
+		FROM predictions
+		WHERE status = 'settled' AND resolved_at >= $1 AND ($2 = '' OR category = $2)
+		ORDER BY resolved_at DESC
+		LIMIT $3`, since, string(category), effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanPredictions(rows)
+}
+
+func (p *PostgresStore) ListPredictionsByCreator(ctx context.Context, creatorAddr string, limit int) ([]*Prediction, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+predictionColumns+`
+		FROM predictions
+		WHERE creator_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, creatorAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanPredictions(rows)
+}
+
+func (p *PostgresStore) CreateWager(ctx context.Context, w *Wager) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO prediction_wagers (
+			id, prediction_id, agent_addr, side, amount, payout, created_at
+		) VALUES ($1, $2, $3, $4, $5::NUMERIC(20,6), $6, $7)`,
+		w.ID, w.PredictionID, w.AgentAddr, string(w.Side), w.Amount, nullString(w.Payout), w.CreatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) UpdateWager(ctx context.Context, w *Wager) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE prediction_wagers SET payout = $1::NUMERIC(20,6) WHERE id = $2`,
+		nullString(w.Payout), w.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvalidWager
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListWagersByPrediction(ctx context.Context, predictionID string, limit int) ([]*Wager, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+wagerColumns+`
+		FROM prediction_wagers
+		WHERE prediction_id = $1
+		ORDER BY created_at ASC
+		LIMIT $2`, predictionID, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanWagers(rows)
+}
+
+func (p *PostgresStore) ListWagersByAgent(ctx context.Context, agentAddr string, limit int) ([]*Wager, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+wagerColumns+`
+		FROM prediction_wagers
+		WHERE agent_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, agentAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanWagers(rows)
+}
+
+const disputeColumns = `id, prediction_id, challenger_addr, evidence, status, note, ruled_by, ruled_at, created_at`
+
+func (p *PostgresStore) CreateDispute(ctx context.Context, d *Dispute) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO prediction_disputes (
+			id, prediction_id, challenger_addr, evidence, status, note, ruled_by, ruled_at, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		d.ID, d.PredictionID, d.ChallengerAddr, d.Evidence, string(d.Status), d.Note, nullString(d.RuledBy), nullTime(d.RuledAt), d.CreatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetDispute(ctx context.Context, id string) (*Dispute, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+disputeColumns+` FROM prediction_disputes WHERE id = $1`, id)
+	d, err := scanDispute(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrDisputeNotFound
+	}
+	return d, err
+}
+
+func (p *PostgresStore) UpdateDispute(ctx context.Context, d *Dispute) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE prediction_disputes SET status = $1, note = $2, ruled_by = $3, ruled_at = $4 WHERE id = $5`,
+		string(d.Status), d.Note, nullString(d.RuledBy), nullTime(d.RuledAt), d.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrDisputeNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) GetOpenDisputeByPrediction(ctx context.Context, predictionID string) (*Dispute, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT `+disputeColumns+` FROM prediction_disputes
+		WHERE prediction_id = $1 AND status = 'open'`, predictionID)
+	d, err := scanDispute(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrDisputeNotFound
+	}
+	return d, err
+}
+
+func (p *PostgresStore) ListDisputesByPrediction(ctx context.Context, predictionID string, limit int) ([]*Dispute, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+disputeColumns+`
+		FROM prediction_disputes
+		WHERE prediction_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, predictionID, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanDisputes(rows)
+}
+
+func (p *PostgresStore) ListOpenDisputes(ctx context.Context, limit int) ([]*Dispute, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+disputeColumns+`
+		FROM prediction_disputes
+		WHERE status = 'open'
+		ORDER BY created_at ASC
+		LIMIT $1`, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanDisputes(rows)
+}
+
+func (p *PostgresStore) CreateFollow(ctx context.Context, f *Follow) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO prediction_follows (follower_addr, predictor_addr, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (follower_addr, predictor_addr) DO NOTHING`,
+		f.FollowerAddr, f.PredictorAddr, f.CreatedAt)
+	return err
+}
+
+func (p *PostgresStore) DeleteFollow(ctx context.Context, followerAddr, predictorAddr string) error {
+	_, err := p.db.ExecContext(ctx, `
+		DELETE FROM prediction_follows
+		WHERE follower_addr = $1 AND predictor_addr = $2`, followerAddr, predictorAddr)
+	return err
+}
+
+func (p *PostgresStore) ListFollowing(ctx context.Context, followerAddr string, limit int) ([]*Follow, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT follower_addr, predictor_addr, created_at
+		FROM prediction_follows
+		WHERE follower_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, followerAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanFollows(rows)
+}
+
+func (p *PostgresStore) ListFollowers(ctx context.Context, predictorAddr string, limit int) ([]*Follow, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT follower_addr, predictor_addr, created_at
+		FROM prediction_follows
+		WHERE predictor_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, predictorAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanFollows(rows)
+}
+
+func scanFollows(rows *sql.Rows) ([]*Follow, error) {
+	var result []*Follow
+	for rows.Next() {
+		f := &Follow{}
+		if err := rows.Scan(&f.FollowerAddr, &f.PredictorAddr, &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+	return result, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPrediction(s scanner) (*Prediction, error) {
+	pr := &Prediction{}
+	var (
+		category      string
+		oracleJSON    []byte
+		status        string
+		outcome       string
+		resolvedBy    sql.NullString
+		resolvedAt    sql.NullTime
+		challengeEnds sql.NullTime
+		settledAt     sql.NullTime
+	)
+	err := s.Scan(
+		&pr.ID, &pr.CreatorAddr, &pr.Claim, &category, &oracleJSON, &pr.Deadline,
+		&pr.StakedYes, &pr.StakedNo, &status, &outcome, &resolvedBy, &resolvedAt,
+		&challengeEnds, &settledAt, &pr.CreatedAt, &pr.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if challengeEnds.Valid {
+		t := challengeEnds.Time
+		pr.ChallengeEndsAt = &t
+	}
+	if settledAt.Valid {
+		t := settledAt.Time
+		pr.SettledAt = &t
+	}
+	if len(oracleJSON) > 0 {
+		pr.Oracle = &OracleSpec{}
+		if err := json.Unmarshal(oracleJSON, pr.Oracle); err != nil {
+			return nil, err
+		}
+	}
+	pr.Category = Category(category)
+	pr.Status = PredictionStatus(status)
+	pr.Outcome = Side(outcome)
+	pr.ResolvedBy = resolvedBy.String
+	if resolvedAt.Valid {
+		t := resolvedAt.Time
+		pr.ResolvedAt = &t
+	}
+	return pr, nil
+}
+
+func scanPredictions(rows *sql.Rows) ([]*Prediction, error) {
+	var result []*Prediction
+	for rows.Next() {
+		pr, err := scanPrediction(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pr)
+	}
+	return result, rows.Err()
+}
+
+func scanWager(s scanner) (*Wager, error) {
+	w := &Wager{}
+	var (
+		side   string
+		payout sql.NullString
+	)
+	err := s.Scan(
+		&w.ID, &w.PredictionID, &w.AgentAddr, &side, &w.Amount, &payout, &w.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	w.Side = Side(side)
+	w.Payout = payout.String
+	return w, nil
+}
+
+func scanWagers(rows *sql.Rows) ([]*Wager, error) {
+	var result []*Wager
+	for rows.Next() {
+		w, err := scanWager(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+	return result, rows.Err()
+}
+
+func scanDispute(s scanner) (*Dispute, error) {
+	d := &Dispute{}
+	var (
+		status  string
+		ruledBy sql.NullString
+		ruledAt sql.NullTime
+	)
+	err := s.Scan(
+		&d.ID, &d.PredictionID, &d.ChallengerAddr, &d.Evidence, &status, &d.Note, &ruledBy, &ruledAt, &d.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Status = DisputeStatus(status)
+	d.RuledBy = ruledBy.String
+	if ruledAt.Valid {
+		t := ruledAt.Time
+		d.RuledAt = &t
+	}
+	return d, nil
+}
+
+func scanDisputes(rows *sql.Rows) ([]*Dispute, error) {
+	var result []*Dispute
+	for rows.Next() {
+		d, err := scanDispute(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+	return result, rows.Err()
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+var _ Store = (*PostgresStore)(nil)
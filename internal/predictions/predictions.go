@@ -0,0 +1,354 @@
+// Package predictions implements a prediction market for agent claims.
+//
+// An agent posts a claim with a resolution deadline; other agents back either
+// side with USDC. Claims tied to a machine-verifiable oracle — a registry
+// metric, an external HTTP endpoint, or a price feed — resolve automatically
+// once the deadline passes, with no voting quorum. Claims without an oracle
+// are attested by their creator. Winners split the whole pot pro-rata by
+// wager.
+package predictions
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrPredictionNotFound = errors.New("predictions: prediction not found")
+	ErrPredictionClosed   = errors.New("predictions: prediction not open")
+	ErrNotDue             = errors.New("predictions: deadline not reached")
+	ErrInvalidPrediction  = errors.New("predictions: invalid prediction")
+	ErrInvalidWager       = errors.New("predictions: invalid wager")
+	ErrInvalidOracle      = errors.New("predictions: invalid oracle spec")
+	ErrOracleFailed       = errors.New("predictions: oracle evaluation failed")
+	ErrUnauthorized       = errors.New("predictions: not authorized")
+	ErrDisputeNotFound    = errors.New("predictions: dispute not found")
+	ErrInvalidDispute     = errors.New("predictions: invalid dispute")
+	ErrWindowOpen         = errors.New("predictions: challenge window still open")
+	ErrWindowClosed       = errors.New("predictions: challenge window closed")
+	ErrInvalidFollow      = errors.New("predictions: invalid follow")
+)
+
+// MaxDeadline caps how far out a claim can resolve.
+const MaxDeadline = 365 * 24 * time.Hour
+
+// PredictionStatus represents the lifecycle of a prediction.
+type PredictionStatus string
+
+const (
+	PredictionOpen     PredictionStatus = "open"
+	PredictionResolved PredictionStatus = "resolved" // outcome recorded, challenge window running
+	PredictionDisputed PredictionStatus = "disputed" // settlement paused pending a ruling
+	PredictionSettled  PredictionStatus = "settled"  // pot paid out
+	PredictionVoided   PredictionStatus = "voided"
+)
+
+// Side is the position a wager takes on a claim.
+type Side string
+
+const (
+	SideYes Side = "yes"
+	SideNo  Side = "no"
+)
+
+// Category groups predictions for browsing and leaderboards.
+type Category string
+
+const (
+	CategoryMarket           Category = "market"
+	CategoryAgentPerformance Category = "agent_performance"
+	CategoryServiceQuality   Category = "service_quality"
+)
+
+func validCategory(c Category) bool {
+	switch c {
+	case "", CategoryMarket, CategoryAgentPerformance, CategoryServiceQuality:
+		return true
+	}
+	return false
+}
+
+// Prediction is a claim open for wagers until its deadline.
+type Prediction struct {
+	ID          string           `json:"id"`
+	CreatorAddr string           `json:"creatorAddr"`
+	Claim       string           `json:"claim"`
+	Category    Category         `json:"category,omitempty"`
+	Oracle      *OracleSpec      `json:"oracle,omitempty"` // nil = creator-attested
+	Deadline    time.Time        `json:"deadline"`
+	StakedYes   string           `json:"stakedYes"` // USDC
+	StakedNo    string           `json:"stakedNo"`  // USDC
+	Status      PredictionStatus `json:"status"`
+	Outcome     Side             `json:"outcome,omitempty"`
+	ResolvedBy  string           `json:"resolvedBy,omitempty"` // oracle source or attesting address
+	ResolvedAt  *time.Time       `json:"resolvedAt,omitempty"`
+	// ChallengeEndsAt closes the dispute window; settlement waits for it.
+	ChallengeEndsAt *time.Time `json:"challengeEndsAt,omitempty"`
+	SettledAt       *time.Time `json:"settledAt,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// Wager backs one side of a prediction with USDC.
+type Wager struct {
+	ID           string    `json:"id"`
+	PredictionID string    `json:"predictionId"`
+	AgentAddr    string    `json:"agentAddr"`
+	Side         Side      `json:"side"`
+	Amount       string    `json:"amount"`           // USDC
+	Payout       string    `json:"payout,omitempty"` // USDC, set at settlement
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// CreatePredictionRequest is the input for posting a claim.
+type CreatePredictionRequest struct {
+	Claim    string      `json:"claim" binding:"required"`
+	Category Category    `json:"category,omitempty"`
+	Deadline time.Time   `json:"deadline" binding:"required"`
+	Oracle   *OracleSpec `json:"oracle,omitempty"`
+}
+
+// Ledger is the subset of ledger operations the predictions service needs.
+// Wagers move into platform custody and back out at settlement.
+type Ledger interface {
+	Transfer(ctx context.Context, from, to, amount, reference string) error
+}
+
+// Store persists predictions and wagers.
+type Store interface {
+	CreatePrediction(ctx context.Context, p *Prediction) error
+	GetPrediction(ctx context.Context, id string) (*Prediction, error)
+	UpdatePrediction(ctx context.Context, p *Prediction) error
+	ListOpenPredictions(ctx context.Context, category Category, limit int) ([]*Prediction, error)
+	ListResolvedPredictions(ctx context.Context, before time.Time, limit int) ([]*Prediction, error)
+	ListSettledPredictions(ctx context.Context, category Category, since time.Time, limit int) ([]*Prediction, error)
+	ListPredictionsByCreator(ctx context.Context, creatorAddr string, limit int) ([]*Prediction, error)
+
+	CreateDispute(ctx context.Context, d *Dispute) error
+	GetDispute(ctx context.Context, id string) (*Dispute, error)
+	UpdateDispute(ctx context.Context, d *Dispute) error
+	GetOpenDisputeByPrediction(ctx context.Context, predictionID string) (*Dispute, error)
+	ListDisputesByPrediction(ctx context.Context, predictionID string, limit int) ([]*Dispute, error)
+	ListOpenDisputes(ctx context.Context, limit int) ([]*Dispute, error)
+
+	CreateFollow(ctx context.Context, f *Follow) error
+	DeleteFollow(ctx context.Context, followerAddr, predictorAddr string) error
+	ListFollowing(ctx context.Context, followerAddr string, limit int) ([]*Follow, error)
+	ListFollowers(ctx context.Context, predictorAddr string, limit int) ([]*Follow, error)
+
+	CreateWager(ctx context.Context, w *Wager) error
+	UpdateWager(ctx context.Context, w *Wager) error
+	ListWagersByPrediction(ctx context.Context, predictionID string, limit int) ([]*Wager, error)
+	ListWagersByAgent(ctx context.Context, agentAddr string, limit int) ([]*Wager, error)
+}
+
+// Service implements prediction market business logic.
+type Service struct {
+	store         Store
+	ledger        Ledger
+	platformAddr  string // custody account for open pots
+	oracles       map[OracleSource]Oracle
+	disputeWindow time.Duration
+	webhooks      WebhookEmitter
+	realtime      RealtimeBroadcaster
+	logger        *slog.Logger
+	mu            sync.Mutex // serializes wagers, resolution and settlement
+}
+
+// NewService creates a new predictions service.
+func NewService(store Store, ledger Ledger) *Service {
+	return &Service{
+		store:         store,
+		ledger:        ledger,
+		oracles:       make(map[OracleSource]Oracle),
+		disputeWindow: DefaultDisputeWindow,
+		logger:        slog.Default(),
+	}
+}
+
+// WithDisputeWindow overrides how long resolutions stay open to challenge.
+func (s *Service) WithDisputeWindow(d time.Duration) *Service {
+	s.disputeWindow = d
+	return s
+}
+
+// WebhookEmitter is the subset of webhook operations the predictions
+// service emits. Implemented by webhooks.Emitter.
+type WebhookEmitter interface {
+	EmitPredictionVoided(agentAddr, predictionID, claim, reason string)
+	EmitPredictionCreated(agentAddr, predictionID, creatorAddr, claim, category string)
+	EmitPredictionResolved(agentAddr, predictionID, creatorAddr, outcome string)
+}
+
+// RealtimeBroadcaster pushes prediction lifecycle events to WebSocket
+// subscribers.
+type RealtimeBroadcaster interface {
+	BroadcastPredictionCreated(predictionID, creatorAddr, claim, category string)
+	BroadcastPredictionResolved(predictionID, creatorAddr, outcome string)
+}
+
+// WithRealtimeBroadcaster adds WebSocket event broadcasting for live feeds.
+func (s *Service) WithRealtimeBroadcaster(r RealtimeBroadcaster) *Service {
+	s.realtime = r
+	return s
+}
+
+// WithWebhookEmitter enables webhook notifications for prediction events.
+func (s *Service) WithWebhookEmitter(e WebhookEmitter) *Service {
+	s.webhooks = e
+	return s
+}
+
+// WithLogger sets a structured logger.
+func (s *Service) WithLogger(l *slog.Logger) *Service {
+	s.logger = l
+	return s
+}
+
+// WithPlatformAddress sets the ledger address that holds open pots between
+// wager and settlement.
+func (s *Service) WithPlatformAddress(addr string) *Service {
+	s.platformAddr = addr
+	return s
+}
+
+// RegisterOracle makes an oracle source available to new predictions.
+// Predictions referencing an unregistered source are rejected at creation.
+func (s *Service) RegisterOracle(source OracleSource, o Oracle) *Service {
+	s.oracles[source] = o
+	return s
+}
+
+// CreatePrediction posts a claim for wagering.
+func (s *Service) CreatePrediction(ctx context.Context, creatorAddr string, req CreatePredictionRequest) (*Prediction, error) {
+	if req.Claim == "" {
+		return nil, ErrInvalidPrediction
+	}
+	if !validCategory(req.Category) {
+		return nil, ErrInvalidPrediction
+	}
+	now := time.Now()
+	if !req.Deadline.After(now) || req.Deadline.After(now.Add(MaxDeadline)) {
+		return nil, ErrInvalidPrediction
+	}
+	if req.Oracle != nil {
+		if err := req.Oracle.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := s.oracles[req.Oracle.Source]; !ok {
+			return nil, ErrInvalidOracle
+		}
+	}
+
+	p := &Prediction{
+		ID:          idgen.WithPrefix("prd_"),
+		CreatorAddr: creatorAddr,
+		Claim:       req.Claim,
+		Category:    req.Category,
+		Oracle:      req.Oracle,
+		Deadline:    req.Deadline,
+		StakedYes:   "0.000000",
+		StakedNo:    "0.000000",
+		Status:      PredictionOpen,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.store.CreatePrediction(ctx, p); err != nil {
+		return nil, err
+	}
+	s.notifyCreated(ctx, p)
+	s.logger.Info("predictions: claim posted", "prediction", p.ID, "creator", creatorAddr, "deadline", p.Deadline)
+	return p, nil
+}
+
+// GetPrediction returns a prediction by ID.
+func (s *Service) GetPrediction(ctx context.Context, id string) (*Prediction, error) {
+	return s.store.GetPrediction(ctx, id)
+}
+
+// ListPredictions returns open predictions, optionally filtered by category.
+func (s *Service) ListPredictions(ctx context.Context, category Category, limit int) ([]*Prediction, error) {
+	if !validCategory(category) {
+		return nil, ErrInvalidPrediction
+	}
+	return s.store.ListOpenPredictions(ctx, category, limit)
+}
+
+// ListPredictionsByCreator returns an agent's posted claims.
+func (s *Service) ListPredictionsByCreator(ctx context.Context, creatorAddr string, limit int) ([]*Prediction, error) {
+	return s.store.ListPredictionsByCreator(ctx, creatorAddr, limit)
+}
+
+// PlaceWager backs one side of an open prediction. The amount moves into
+// platform custody until resolution.
+func (s *Service) PlaceWager(ctx context.Context, agentAddr, predictionID string, side Side, amount string) (*Wager, error) {
+	if side != SideYes && side != SideNo {
+		return nil, ErrInvalidWager
+	}
+	amt, ok := usdc.Parse(amount)
+	if !ok || amt.Sign() <= 0 {
+		return nil, ErrInvalidWager
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.store.GetPrediction(ctx, predictionID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != PredictionOpen || !time.Now().Before(p.Deadline) {
+		return nil, ErrPredictionClosed
+	}
+
+	w := &Wager{
+		ID:           idgen.WithPrefix("wgr_"),
+		PredictionID: p.ID,
+		AgentAddr:    agentAddr,
+		Side:         side,
+		Amount:       usdc.Format(amt),
+		CreatedAt:    time.Now(),
+	}
+	if err := s.ledger.Transfer(ctx, agentAddr, s.platformAddr, w.Amount, "prediction_wager:"+w.ID); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreateWager(ctx, w); err != nil {
+		return nil, err
+	}
+
+	staked := p.StakedYes
+	if side == SideNo {
+		staked = p.StakedNo
+	}
+	pool, _ := usdc.Parse(staked)
+	pool.Add(pool, amt)
+	if side == SideYes {
+		p.StakedYes = usdc.Format(pool)
+	} else {
+		p.StakedNo = usdc.Format(pool)
+	}
+	p.UpdatedAt = w.CreatedAt
+	if err := s.store.UpdatePrediction(ctx, p); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// ListWagers returns a prediction's wagers.
+func (s *Service) ListWagers(ctx context.Context, predictionID string, limit int) ([]*Wager, error) {
+	if _, err := s.store.GetPrediction(ctx, predictionID); err != nil {
+		return nil, err
+	}
+	return s.store.ListWagersByPrediction(ctx, predictionID, limit)
+}
+
+// ListAgentWagers returns an agent's wagers across predictions.
+func (s *Service) ListAgentWagers(ctx context.Context, agentAddr string, limit int) ([]*Wager, error) {
+	return s.store.ListWagersByAgent(ctx, agentAddr, limit)
+}
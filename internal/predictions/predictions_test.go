@@ -0,0 +1,360 @@
+package predictions
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+const (
+	creatorAddr  = "0x1111111111111111111111111111111111111111"
+	bettorAddr   = "0x2222222222222222222222222222222222222222"
+	bettor2      = "0x3333333333333333333333333333333333333333"
+	platformAddr = "0x9999999999999999999999999999999999999999"
+)
+
+// fakeLedger tracks balances in memory and fails transfers that the payer
+// cannot cover, mirroring the real ledger's behavior. Transfers to addresses
+// in failTo always fail, simulating e.g. a frozen recipient account.
+type fakeLedger struct {
+	mu       sync.Mutex
+	balances map[string]*big.Int
+	failTo   map[string]bool
+}
+
+func newFakeLedger(funded ...string) *fakeLedger {
+	l := &fakeLedger{balances: make(map[string]*big.Int)}
+	for _, addr := range funded {
+		l.balances[addr] = big.NewInt(100_000_000) // 100 USDC
+	}
+	return l
+}
+
+func (l *fakeLedger) Transfer(ctx context.Context, from, to, amount, reference string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	amt, ok := usdc.Parse(amount)
+	if !ok {
+		return errors.New("fake ledger: bad amount")
+	}
+	if l.failTo[to] {
+		return errors.New("fake ledger: recipient unavailable")
+	}
+	bal := l.balances[from]
+	if bal == nil || bal.Cmp(amt) < 0 {
+		return errors.New("fake ledger: insufficient balance")
+	}
+	bal.Sub(bal, amt)
+	if l.balances[to] == nil {
+		l.balances[to] = new(big.Int)
+	}
+	l.balances[to].Add(l.balances[to], amt)
+	return nil
+}
+
+func (l *fakeLedger) balance(addr string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bal := l.balances[addr]
+	if bal == nil {
+		bal = new(big.Int)
+	}
+	return usdc.Format(bal)
+}
+
+// fixedOracle observes a constant value, or fails.
+type fixedOracle struct {
+	value float64
+	err   error
+}
+
+func (f *fixedOracle) Observe(ctx context.Context, spec *OracleSpec) (float64, error) {
+	return f.value, f.err
+}
+
+func newTestService() (*Service, *fakeLedger) {
+	ledger := newFakeLedger(creatorAddr, bettorAddr, bettor2)
+	svc := NewService(NewMemoryStore(), ledger).WithPlatformAddress(platformAddr)
+	return svc, ledger
+}
+
+func createTestPrediction(t *testing.T, svc *Service, oracle *OracleSpec) *Prediction {
+	t.Helper()
+	p, err := svc.CreatePrediction(context.Background(), creatorAddr, CreatePredictionRequest{
+		Claim:    "agent clears 100 transactions this quarter",
+		Deadline: time.Now().Add(time.Hour),
+		Oracle:   oracle,
+	})
+	if err != nil {
+		t.Fatalf("CreatePrediction failed: %v", err)
+	}
+	return p
+}
+
+// rewindDeadline backdates a prediction's deadline so it can be resolved.
+func rewindDeadline(t *testing.T, svc *Service, predictionID string) {
+	t.Helper()
+	p, err := svc.store.GetPrediction(context.Background(), predictionID)
+	if err != nil {
+		t.Fatalf("GetPrediction failed: %v", err)
+	}
+	p.Deadline = time.Now().Add(-time.Minute)
+	if err := svc.store.UpdatePrediction(context.Background(), p); err != nil {
+		t.Fatalf("UpdatePrediction failed: %v", err)
+	}
+}
+
+// rewindChallenge backdates a resolved prediction's challenge window so it
+// can be finalized.
+func rewindChallenge(t *testing.T, svc *Service, predictionID string) {
+	t.Helper()
+	p, err := svc.store.GetPrediction(context.Background(), predictionID)
+	if err != nil {
+		t.Fatalf("GetPrediction failed: %v", err)
+	}
+	past := time.Now().Add(-time.Minute)
+	p.ChallengeEndsAt = &past
+	if err := svc.store.UpdatePrediction(context.Background(), p); err != nil {
+		t.Fatalf("UpdatePrediction failed: %v", err)
+	}
+}
+
+func TestCreatePredictionValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+		Claim: "", Deadline: time.Now().Add(time.Hour),
+	}); !errors.Is(err, ErrInvalidPrediction) {
+		t.Errorf("expected ErrInvalidPrediction for empty claim, got %v", err)
+	}
+	if _, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+		Claim: "past", Deadline: time.Now().Add(-time.Hour),
+	}); !errors.Is(err, ErrInvalidPrediction) {
+		t.Errorf("expected ErrInvalidPrediction for past deadline, got %v", err)
+	}
+	// Oracle specs must be well-formed and reference a registered source.
+	if _, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+		Claim: "x", Deadline: time.Now().Add(time.Hour),
+		Oracle: &OracleSpec{Source: SourceRegistry, Op: "between", Metric: "success_rate", Subject: creatorAddr},
+	}); !errors.Is(err, ErrInvalidOracle) {
+		t.Errorf("expected ErrInvalidOracle for bad op, got %v", err)
+	}
+	if _, err := svc.CreatePrediction(ctx, creatorAddr, CreatePredictionRequest{
+		Claim: "x", Deadline: time.Now().Add(time.Hour),
+		Oracle: &OracleSpec{Source: SourceRegistry, Op: OpGTE, Metric: "success_rate", Subject: creatorAddr},
+	}); !errors.Is(err, ErrInvalidOracle) {
+		t.Errorf("expected ErrInvalidOracle for unregistered source, got %v", err)
+	}
+}
+
+func TestWagerAndOracleSettlement(t *testing.T) {
+	svc, ledger := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+
+	p := createTestPrediction(t, svc, &OracleSpec{
+		Source: SourceRegistry, Metric: "transaction_count", Subject: creatorAddr, Op: OpGTE, Value: 100,
+	})
+
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "10.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideNo, "5.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	p, _ = svc.GetPrediction(ctx, p.ID)
+	if p.StakedYes != "10.000000" || p.StakedNo != "5.000000" {
+		t.Errorf("unexpected pools: yes=%s no=%s", p.StakedYes, p.StakedNo)
+	}
+
+	// Not due yet; anyone can trigger once it is.
+	if _, err := svc.Resolve(ctx, p.ID, bettorAddr, ""); !errors.Is(err, ErrNotDue) {
+		t.Errorf("expected ErrNotDue, got %v", err)
+	}
+	rewindDeadline(t, svc, p.ID)
+	resolved, err := svc.Resolve(ctx, p.ID, bettorAddr, "")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.Status != PredictionResolved || resolved.Outcome != SideYes || resolved.ResolvedBy != string(SourceRegistry) {
+		t.Errorf("unexpected resolution: %+v", resolved)
+	}
+	if resolved.ChallengeEndsAt == nil {
+		t.Fatal("expected challenge window on resolved prediction")
+	}
+
+	// Nothing pays out until the challenge window clears.
+	if got := ledger.balance(bettorAddr); got != "90.000000" {
+		t.Errorf("expected no payout before finalize, balance %s", got)
+	}
+	if _, err := svc.Finalize(ctx, p.ID); !errors.Is(err, ErrWindowOpen) {
+		t.Errorf("expected ErrWindowOpen, got %v", err)
+	}
+	rewindChallenge(t, svc, p.ID)
+	settled, err := svc.Finalize(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	if settled.Status != PredictionSettled || settled.SettledAt == nil {
+		t.Errorf("unexpected settlement: %+v", settled)
+	}
+
+	// Winner takes the whole 15 USDC pot; the loser paid 5.
+	if got := ledger.balance(bettorAddr); got != "105.000000" {
+		t.Errorf("expected winner at 105.000000, got %s", got)
+	}
+	if got := ledger.balance(bettor2); got != "95.000000" {
+		t.Errorf("expected loser at 95.000000, got %s", got)
+	}
+	wagers, _ := svc.ListWagers(ctx, p.ID, 0)
+	for _, w := range wagers {
+		if w.Side == SideYes && w.Payout != "15.000000" {
+			t.Errorf("expected winning payout 15.000000, got %s", w.Payout)
+		}
+	}
+
+	// Settled claims cannot take wagers or resolve again.
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "1.000000"); !errors.Is(err, ErrPredictionClosed) {
+		t.Errorf("expected ErrPredictionClosed, got %v", err)
+	}
+	if _, err := svc.Resolve(ctx, p.ID, bettorAddr, ""); !errors.Is(err, ErrPredictionClosed) {
+		t.Errorf("expected ErrPredictionClosed, got %v", err)
+	}
+}
+
+func TestFailedPayoutStaysInPlatformCustody(t *testing.T) {
+	svc, ledger := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+
+	p := createTestPrediction(t, svc, &OracleSpec{
+		Source: SourceRegistry, Metric: "transaction_count", Subject: creatorAddr, Op: OpGTE, Value: 100,
+	})
+
+	// Two winners and a loser: pot 21, winning pool 15. The first winner's
+	// share is 14, the second's 7.
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "10.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideYes, "5.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, creatorAddr, p.ID, SideNo, "6.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+
+	// The first winner's payout fails; their share must stay with the
+	// platform, not get folded into the last winner's dust payout.
+	ledger.failTo = map[string]bool{bettorAddr: true}
+
+	rewindDeadline(t, svc, p.ID)
+	if _, err := svc.Resolve(ctx, p.ID, bettorAddr, ""); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	rewindChallenge(t, svc, p.ID)
+	if _, err := svc.Finalize(ctx, p.ID); err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+
+	if got := ledger.balance(bettorAddr); got != "90.000000" {
+		t.Errorf("expected failed winner unpaid at 90.000000, got %s", got)
+	}
+	if got := ledger.balance(bettor2); got != "102.000000" {
+		t.Errorf("expected second winner at 102.000000 (own share only), got %s", got)
+	}
+	if got := ledger.balance(platformAddr); got != "14.000000" {
+		t.Errorf("expected failed share retained by platform, got %s", got)
+	}
+
+	wagers, _ := svc.ListWagers(ctx, p.ID, 0)
+	for _, w := range wagers {
+		switch w.AgentAddr {
+		case bettorAddr:
+			if w.Payout != "" {
+				t.Errorf("expected no recorded payout for failed winner, got %s", w.Payout)
+			}
+		case bettor2:
+			if w.Payout != "7.000000" {
+				t.Errorf("expected second winner payout 7.000000, got %s", w.Payout)
+			}
+		}
+	}
+}
+
+func TestOneSidedMarketVoids(t *testing.T) {
+	svc, ledger := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+
+	p := createTestPrediction(t, svc, &OracleSpec{
+		Source: SourceRegistry, Metric: "transaction_count", Subject: creatorAddr, Op: OpGTE, Value: 100,
+	})
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "10.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+
+	rewindDeadline(t, svc, p.ID)
+	resolved, err := svc.Resolve(ctx, p.ID, bettorAddr, "")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.Status != PredictionVoided {
+		t.Errorf("expected voided, got %s", resolved.Status)
+	}
+	if got := ledger.balance(bettorAddr); got != "100.000000" {
+		t.Errorf("expected full refund, balance %s", got)
+	}
+}
+
+func TestOracleFailureLeavesOpen(t *testing.T) {
+	svc, _ := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{err: errors.New("feed down")})
+	ctx := context.Background()
+
+	p := createTestPrediction(t, svc, &OracleSpec{
+		Source: SourceRegistry, Metric: "success_rate", Subject: creatorAddr, Op: OpGTE, Value: 0.9,
+	})
+	rewindDeadline(t, svc, p.ID)
+	if _, err := svc.Resolve(ctx, p.ID, bettorAddr, ""); !errors.Is(err, ErrOracleFailed) {
+		t.Errorf("expected ErrOracleFailed, got %v", err)
+	}
+	p, _ = svc.GetPrediction(ctx, p.ID)
+	if p.Status != PredictionOpen {
+		t.Errorf("expected prediction still open after oracle failure, got %s", p.Status)
+	}
+}
+
+func TestCreatorAttestedResolution(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	p := createTestPrediction(t, svc, nil)
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "4.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideNo, "4.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+
+	rewindDeadline(t, svc, p.ID)
+	// Only the creator may attest, and must pick a side.
+	if _, err := svc.Resolve(ctx, p.ID, bettorAddr, SideYes); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+	if _, err := svc.Resolve(ctx, p.ID, creatorAddr, ""); !errors.Is(err, ErrInvalidPrediction) {
+		t.Errorf("expected ErrInvalidPrediction for missing outcome, got %v", err)
+	}
+	resolved, err := svc.Resolve(ctx, p.ID, creatorAddr, SideNo)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.Outcome != SideNo || resolved.ResolvedBy != creatorAddr {
+		t.Errorf("unexpected resolution: %+v", resolved)
+	}
+}
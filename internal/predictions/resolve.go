@@ -0,0 +1,200 @@
+package predictions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// Resolve records a prediction's outcome once its deadline has passed.
+// Oracle-backed claims can be resolved by anyone — the oracle decides the
+// outcome. Creator-attested claims require the creator, who supplies the
+// outcome. The pot is not paid yet: settlement waits out the challenge
+// window so a glitched oracle reading can be disputed.
+func (s *Service) Resolve(ctx context.Context, predictionID, callerAddr string, attested Side) (*Prediction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.store.GetPrediction(ctx, predictionID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != PredictionOpen {
+		return nil, ErrPredictionClosed
+	}
+	if time.Now().Before(p.Deadline) {
+		return nil, ErrNotDue
+	}
+
+	var outcome Side
+	if p.Oracle != nil {
+		oracle, ok := s.oracles[p.Oracle.Source]
+		if !ok {
+			return nil, ErrOracleFailed
+		}
+		observed, err := oracle.Observe(ctx, p.Oracle)
+		if err != nil {
+			s.logger.Warn("predictions: oracle observation failed",
+				"prediction", p.ID, "source", p.Oracle.Source, "error", err)
+			return nil, fmt.Errorf("%w: %v", ErrOracleFailed, err)
+		}
+		outcome = SideNo
+		if p.Oracle.holds(observed) {
+			outcome = SideYes
+		}
+		p.ResolvedBy = string(p.Oracle.Source)
+	} else {
+		if callerAddr != p.CreatorAddr {
+			return nil, ErrUnauthorized
+		}
+		if attested != SideYes && attested != SideNo {
+			return nil, ErrInvalidPrediction
+		}
+		outcome = attested
+		p.ResolvedBy = callerAddr
+	}
+
+	yes, _ := usdc.Parse(p.StakedYes)
+	no, _ := usdc.Parse(p.StakedNo)
+	if yes.Sign() == 0 || no.Sign() == 0 {
+		// Nobody took the other side; void and refund rather than letting
+		// one side collect its own money back as a "win". There is nothing
+		// to dispute, so voids skip the challenge window.
+		if err := s.voidLocked(ctx, p, "one_sided"); err != nil {
+			return nil, err
+		}
+		s.notifyResolved(ctx, p, "void")
+		return p, nil
+	}
+
+	now := time.Now()
+	ends := now.Add(s.disputeWindow)
+	p.Outcome = outcome
+	p.Status = PredictionResolved
+	p.ResolvedAt = &now
+	p.ChallengeEndsAt = &ends
+	p.UpdatedAt = now
+	if err := s.store.UpdatePrediction(ctx, p); err != nil {
+		return nil, err
+	}
+	s.notifyResolved(ctx, p, string(outcome))
+	s.logger.Info("predictions: outcome recorded",
+		"prediction", p.ID, "outcome", outcome, "resolvedBy", p.ResolvedBy, "challengeEndsAt", ends)
+	return p, nil
+}
+
+// voidLocked refunds every wager, closes the prediction, and notifies the
+// creator. Caller holds s.mu.
+func (s *Service) voidLocked(ctx context.Context, p *Prediction, reason string) error {
+	wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+	if err != nil {
+		return err
+	}
+	for _, w := range wagers {
+		if err := s.ledger.Transfer(ctx, s.platformAddr, w.AgentAddr, w.Amount, "prediction_refund:"+w.ID); err != nil {
+			s.logger.Error("predictions: refund failed", "wager", w.ID, "error", err)
+			continue
+		}
+		w.Payout = w.Amount
+		if err := s.store.UpdateWager(ctx, w); err != nil {
+			s.logger.Error("predictions: failed to persist refund", "wager", w.ID, "error", err)
+		}
+	}
+	now := time.Now()
+	p.Status = PredictionVoided
+	p.ResolvedAt = &now
+	p.SettledAt = &now
+	p.UpdatedAt = now
+	if err := s.store.UpdatePrediction(ctx, p); err != nil {
+		return err
+	}
+	if s.webhooks != nil {
+		s.webhooks.EmitPredictionVoided(p.CreatorAddr, p.ID, p.Claim, reason)
+	}
+	s.logger.Info("predictions: claim voided", "prediction", p.ID, "reason", reason, "wagers", len(wagers))
+	return nil
+}
+
+// settleLocked pays the pot out to the side matching p.Outcome pro-rata by
+// wager, the last winner absorbing rounding dust. Caller holds s.mu and has
+// verified the challenge window is clear.
+func (s *Service) settleLocked(ctx context.Context, p *Prediction) error {
+	wagers, err := s.store.ListWagersByPrediction(ctx, p.ID, 0)
+	if err != nil {
+		return err
+	}
+
+	pot := new(big.Int)
+	winPool := new(big.Int)
+	var winners []*Wager
+	for _, w := range wagers {
+		amt, _ := usdc.Parse(w.Amount)
+		pot.Add(pot, amt)
+		if w.Side == p.Outcome {
+			winners = append(winners, w)
+			winPool.Add(winPool, amt)
+		}
+	}
+
+	// allocated tracks each winner's computed share whether or not the
+	// transfer succeeds, so the last winner's dust payout never absorbs a
+	// failed share — those stay in platform custody for retry.
+	allocated := new(big.Int)
+	for i, w := range winners {
+		amt, _ := usdc.Parse(w.Amount)
+		// payout = pot * wager / winPool
+		payout := new(big.Int).Mul(pot, amt)
+		payout.Div(payout, winPool)
+		if i == len(winners)-1 {
+			payout = new(big.Int).Sub(pot, allocated)
+		}
+		allocated.Add(allocated, payout)
+		if payout.Sign() <= 0 {
+			continue
+		}
+		if err := s.ledger.Transfer(ctx, s.platformAddr, w.AgentAddr, usdc.Format(payout), "prediction_payout:"+w.ID); err != nil {
+			s.logger.Error("predictions: payout failed", "wager", w.ID, "amount", usdc.Format(payout), "error", err)
+			continue
+		}
+		w.Payout = usdc.Format(payout)
+		if err := s.store.UpdateWager(ctx, w); err != nil {
+			s.logger.Error("predictions: failed to persist payout", "wager", w.ID, "error", err)
+		}
+	}
+
+	now := time.Now()
+	p.Status = PredictionSettled
+	p.SettledAt = &now
+	p.UpdatedAt = now
+	if err := s.store.UpdatePrediction(ctx, p); err != nil {
+		return err
+	}
+	s.logger.Info("predictions: pot settled",
+		"prediction", p.ID, "outcome", p.Outcome, "pot", usdc.Format(pot), "winners", len(winners))
+	return nil
+}
+
+// Finalize settles a resolved prediction after its challenge window passes
+// undisputed. Anyone can trigger it.
+func (s *Service) Finalize(ctx context.Context, predictionID string) (*Prediction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.store.GetPrediction(ctx, predictionID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != PredictionResolved {
+		return nil, ErrPredictionClosed
+	}
+	if p.ChallengeEndsAt != nil && time.Now().Before(*p.ChallengeEndsAt) {
+		return nil, ErrWindowOpen
+	}
+	if err := s.settleLocked(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
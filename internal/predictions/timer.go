@@ -0,0 +1,159 @@
+package predictions
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/recovery"
+)
+
+// ResolutionGrace is how long past its deadline a prediction may sit
+// unresolved before the sweep voids it and refunds all wagers. Oracle-backed
+// claims get retried through the grace period; creator-attested claims get
+// that long for the creator to show up.
+const ResolutionGrace = 7 * 24 * time.Hour
+
+// EnforceDeadlines sweeps open predictions past their deadline:
+// oracle-backed claims are auto-resolved, and anything still unresolved once
+// the grace period lapses is voided with wagers refunded and the creator
+// notified.
+func (s *Service) EnforceDeadlines(ctx context.Context, now time.Time) (resolved, voided int, err error) {
+	open, err := s.store.ListOpenPredictions(ctx, "", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, p := range open {
+		if now.Before(p.Deadline) {
+			continue
+		}
+		if p.Oracle != nil {
+			if r, err := s.Resolve(ctx, p.ID, "", ""); err == nil {
+				if r.Status == PredictionVoided {
+					voided++
+				} else {
+					resolved++
+				}
+				continue
+			}
+			// Oracle failures leave the claim open; keep retrying until
+			// the grace period runs out, then fall through to void.
+		}
+		if now.Before(p.Deadline.Add(ResolutionGrace)) {
+			continue
+		}
+		s.mu.Lock()
+		fresh, err := s.store.GetPrediction(ctx, p.ID)
+		if err != nil || fresh.Status != PredictionOpen {
+			s.mu.Unlock()
+			continue
+		}
+		if err := s.voidLocked(ctx, fresh, "resolution_overdue"); err != nil {
+			s.logger.Error("predictions: failed to void overdue claim", "prediction", p.ID, "error", err)
+			s.mu.Unlock()
+			continue
+		}
+		s.mu.Unlock()
+		voided++
+	}
+	return resolved, voided, nil
+}
+
+// FinalizeDue settles resolved predictions whose challenge window has
+// lapsed undisputed.
+func (s *Service) FinalizeDue(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.store.ListResolvedPredictions(ctx, now, 0)
+	if err != nil {
+		return 0, err
+	}
+	settled := 0
+	for _, p := range due {
+		if _, err := s.Finalize(ctx, p.ID); err != nil {
+			s.logger.Warn("predictions: failed to finalize", "prediction", p.ID, "error", err)
+			continue
+		}
+		settled++
+	}
+	return settled, nil
+}
+
+// Timer periodically enforces resolution deadlines and settles predictions
+// whose challenge window has lapsed.
+type Timer struct {
+	service  *Service
+	interval time.Duration
+	logger   *slog.Logger
+	stop     chan struct{}
+	running  atomic.Bool
+}
+
+// NewTimer creates a new predictions enforcement timer.
+func NewTimer(service *Service, logger *slog.Logger) *Timer {
+	return &Timer{
+		service:  service,
+		interval: time.Minute,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start begins the timer loop.
+func (t *Timer) Start(ctx context.Context) {
+	t.running.Store(true)
+	defer t.running.Store(false)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.safeEnforce(ctx)
+			t.safeFinalize(ctx)
+		}
+	}
+}
+
+// Stop signals the timer loop to exit.
+func (t *Timer) Stop() {
+	select {
+	case t.stop <- struct{}{}:
+	default:
+	}
+}
+
+// Running returns true if the timer loop is active.
+func (t *Timer) Running() bool {
+	return t.running.Load()
+}
+
+func (t *Timer) safeEnforce(ctx context.Context) {
+	defer recovery.LogPanic(t.logger, "predictions_timer")
+
+	resolved, voided, err := t.service.EnforceDeadlines(ctx, time.Now())
+	if err != nil {
+		t.logger.Warn("predictions timer: error enforcing deadlines", "error", err)
+		return
+	}
+	if resolved > 0 || voided > 0 {
+		t.logger.Info("predictions timer: enforced deadlines", "resolved", resolved, "voided", voided)
+	}
+}
+
+func (t *Timer) safeFinalize(ctx context.Context) {
+	defer recovery.LogPanic(t.logger, "predictions_timer")
+
+	settled, err := t.service.FinalizeDue(ctx, time.Now())
+	if err != nil {
+		t.logger.Warn("predictions timer: error finalizing", "error", err)
+		return
+	}
+	if settled > 0 {
+		t.logger.Info("predictions timer: settled markets", "count", settled)
+	}
+}
@@ -0,0 +1,161 @@
+package predictions
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type voidedEvent struct {
+	agentAddr    string
+	predictionID string
+	reason       string
+}
+
+type followEvent struct {
+	agentAddr    string
+	predictionID string
+	detail       string // category for created, outcome for resolved
+}
+
+type fakeEmitter struct {
+	mu       sync.Mutex
+	voided   []voidedEvent
+	created  []followEvent
+	resolved []followEvent
+}
+
+func (f *fakeEmitter) EmitPredictionVoided(agentAddr, predictionID, claim, reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.voided = append(f.voided, voidedEvent{agentAddr, predictionID, reason})
+}
+
+func (f *fakeEmitter) EmitPredictionCreated(agentAddr, predictionID, creatorAddr, claim, category string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = append(f.created, followEvent{agentAddr, predictionID, category})
+}
+
+func (f *fakeEmitter) EmitPredictionResolved(agentAddr, predictionID, creatorAddr, outcome string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resolved = append(f.resolved, followEvent{agentAddr, predictionID, outcome})
+}
+
+func TestEnforceDeadlinesResolvesOracleBacked(t *testing.T) {
+	svc, _ := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+
+	p := createTestPrediction(t, svc, &OracleSpec{
+		Source: SourceRegistry, Metric: "transaction_count", Subject: creatorAddr, Op: OpGTE, Value: 100,
+	})
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "10.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideNo, "5.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+
+	// Not yet due: nothing happens.
+	resolved, voided, err := svc.EnforceDeadlines(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("EnforceDeadlines failed: %v", err)
+	}
+	if resolved != 0 || voided != 0 {
+		t.Errorf("expected no-op before deadline, got resolved=%d voided=%d", resolved, voided)
+	}
+
+	rewindDeadline(t, svc, p.ID)
+	resolved, voided, err = svc.EnforceDeadlines(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("EnforceDeadlines failed: %v", err)
+	}
+	if resolved != 1 || voided != 0 {
+		t.Errorf("expected 1 resolution, got resolved=%d voided=%d", resolved, voided)
+	}
+	got, _ := svc.GetPrediction(ctx, p.ID)
+	if got.Status != PredictionResolved || got.Outcome != SideYes {
+		t.Errorf("unexpected state after sweep: %+v", got)
+	}
+}
+
+func TestEnforceDeadlinesVoidsOverdue(t *testing.T) {
+	svc, ledger := newTestService()
+	emitter := &fakeEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+
+	// Creator-attested claim the creator never resolves.
+	p := createTestPrediction(t, svc, nil)
+	if _, err := svc.PlaceWager(ctx, bettorAddr, p.ID, SideYes, "10.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	if _, err := svc.PlaceWager(ctx, bettor2, p.ID, SideNo, "5.000000"); err != nil {
+		t.Fatalf("PlaceWager failed: %v", err)
+	}
+	rewindDeadline(t, svc, p.ID)
+
+	// Within the grace period the creator can still show up.
+	_, voided, err := svc.EnforceDeadlines(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("EnforceDeadlines failed: %v", err)
+	}
+	if voided != 0 {
+		t.Errorf("expected no void inside grace period, got %d", voided)
+	}
+
+	_, voided, err = svc.EnforceDeadlines(ctx, time.Now().Add(ResolutionGrace+time.Hour))
+	if err != nil {
+		t.Fatalf("EnforceDeadlines failed: %v", err)
+	}
+	if voided != 1 {
+		t.Fatalf("expected 1 void past grace, got %d", voided)
+	}
+	got, _ := svc.GetPrediction(ctx, p.ID)
+	if got.Status != PredictionVoided {
+		t.Errorf("expected voided, got %s", got.Status)
+	}
+	// Held stakes released.
+	if ledger.balance(bettorAddr) != "100.000000" || ledger.balance(bettor2) != "100.000000" {
+		t.Errorf("expected full refunds, got %s / %s", ledger.balance(bettorAddr), ledger.balance(bettor2))
+	}
+	// Creator notified.
+	if len(emitter.voided) != 1 || emitter.voided[0].agentAddr != creatorAddr || emitter.voided[0].reason != "resolution_overdue" {
+		t.Errorf("unexpected webhook events: %+v", emitter.voided)
+	}
+}
+
+func TestFinalizeDueSettlesLapsedWindows(t *testing.T) {
+	svc, ledger := newTestService()
+	svc.RegisterOracle(SourceRegistry, &fixedOracle{value: 150})
+	ctx := context.Background()
+	p := resolvedMarket(t, svc)
+
+	// Window still open: nothing settles.
+	settled, err := svc.FinalizeDue(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("FinalizeDue failed: %v", err)
+	}
+	if settled != 0 {
+		t.Errorf("expected nothing settled inside window, got %d", settled)
+	}
+
+	rewindChallenge(t, svc, p.ID)
+	settled, err = svc.FinalizeDue(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("FinalizeDue failed: %v", err)
+	}
+	if settled != 1 {
+		t.Fatalf("expected 1 settlement, got %d", settled)
+	}
+	got, _ := svc.GetPrediction(ctx, p.ID)
+	if got.Status != PredictionSettled {
+		t.Errorf("expected settled, got %s", got.Status)
+	}
+	if ledger.balance(bettorAddr) != "105.000000" {
+		t.Errorf("expected winner paid, balance %s", ledger.balance(bettorAddr))
+	}
+}
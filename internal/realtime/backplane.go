@@ -0,0 +1,100 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/redis/go-redis/v9"
+)
+
+// backplaneChannel is the Redis pub/sub channel shared by all instances.
+const backplaneChannel = "alancoin:realtime"
+
+// backplaneEnvelope carries an event between instances. The private
+// target travels explicitly because Event never serializes it.
+type backplaneEnvelope struct {
+	Instance string `json:"instance"`
+	Target   string `json:"target,omitempty"`
+	Event    *Event `json:"event"`
+}
+
+// Backplane fans local broadcasts out to other server instances over
+// Redis pub/sub, so clients behind a load balancer see every event no
+// matter which instance they are connected to. Each instance publishes
+// its own broadcasts and re-broadcasts what the others publish; an
+// instance ID in the envelope filters out the echo of its own messages.
+//
+// Sequence numbers stay per-instance: a replayed or resumed client
+// talks to one instance, and that instance assigns a fresh local Seq to
+// every event, remote or not.
+type Backplane struct {
+	hub      *Hub
+	rdb      *redis.Client
+	logger   *slog.Logger
+	instance string
+}
+
+// NewBackplane attaches a Redis backplane to the hub. Must be called
+// before Hub.Run starts.
+func NewBackplane(hub *Hub, rdb *redis.Client, logger *slog.Logger) *Backplane {
+	b := &Backplane{
+		hub:      hub,
+		rdb:      rdb,
+		logger:   logger,
+		instance: idgen.WithPrefix("rt_"),
+	}
+	hub.backplane = b
+	return b
+}
+
+// publish forwards a locally broadcast event to the other instances.
+// Called from the hub's broadcast loop on a separate goroutine.
+func (b *Backplane) publish(event *Event) {
+	payload, err := json.Marshal(backplaneEnvelope{
+		Instance: b.instance,
+		Target:   event.target,
+		Event:    event,
+	})
+	if err != nil {
+		return
+	}
+	if err := b.rdb.Publish(context.Background(), backplaneChannel, payload).Err(); err != nil {
+		b.logger.Warn("realtime backplane publish failed", "error", err)
+	}
+}
+
+// Run subscribes to the backplane channel and re-broadcasts events from
+// other instances to local clients. Blocks until ctx is cancelled.
+func (b *Backplane) Run(ctx context.Context) {
+	pubsub := b.rdb.Subscribe(ctx, backplaneChannel)
+	defer pubsub.Close() //nolint:errcheck
+
+	b.logger.Info("realtime backplane started", "instance", b.instance)
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			b.logger.Info("realtime backplane stopped")
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var env backplaneEnvelope
+			if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil || env.Event == nil {
+				b.logger.Warn("realtime backplane dropped malformed message", "error", err)
+				continue
+			}
+			if env.Instance == b.instance {
+				continue // echo of our own publish
+			}
+			env.Event.Seq = 0 // local sequence assigned on broadcast
+			env.Event.target = env.Target
+			env.Event.remote = true
+			b.hub.Broadcast(env.Event)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// startBackplaneHub wires a hub to the shared miniredis and starts both
+// the hub and its backplane loop.
+func startBackplaneHub(t *testing.T, ctx context.Context, mr *miniredis.Miniredis) *Hub {
+	t.Helper()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() }) //nolint:errcheck
+
+	h := NewHub(slog.Default())
+	b := NewBackplane(h, rdb, slog.Default())
+	go h.Run(ctx)
+	go b.Run(ctx)
+	time.Sleep(50 * time.Millisecond)
+	return h
+}
+
+func TestBackplane_FansOutAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hubA := startBackplaneHub(t, ctx, mr)
+	hubB := startBackplaneHub(t, ctx, mr)
+
+	clientB := &Client{hub: hubB, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}}
+	hubB.register <- clientB
+	time.Sleep(50 * time.Millisecond)
+
+	hubA.Broadcast(&Event{
+		Type:      EventTransaction,
+		Timestamp: time.Now(),
+		Data:      map[string]interface{}{"amount": "5.00"},
+	})
+
+	select {
+	case msg := <-clientB.send:
+		var event Event
+		if err := json.Unmarshal(msg, &event); err != nil {
+			t.Fatalf("event not JSON: %v", err)
+		}
+		if event.Type != EventTransaction {
+			t.Errorf("type = %s, want transaction", event.Type)
+		}
+		if event.Seq == 0 {
+			t.Error("remote event missing local sequence number")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("event broadcast on instance A never reached instance B's client")
+	}
+}
+
+func TestBackplane_PrivateEventsKeepTarget(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hubA := startBackplaneHub(t, ctx, mr)
+	hubB := startBackplaneHub(t, ctx, mr)
+
+	owner := &Client{hub: hubB, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}, agent: "0xabc"}
+	other := &Client{hub: hubB, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}, agent: "0xdef"}
+	hubB.register <- owner
+	hubB.register <- other
+	time.Sleep(50 * time.Millisecond)
+
+	hubA.BroadcastPrivate("0xABC", EventBalanceChange, map[string]interface{}{"delta": "-5.00"})
+
+	// Skip public chatter (e.g. presence) on both streams.
+	if event := nextEventOfType(t, owner.send, EventBalanceChange); event == nil {
+		t.Fatal("private event never crossed the backplane")
+	}
+	if event := drainForType(other.send, EventBalanceChange, 200*time.Millisecond); event != nil {
+		t.Error("non-owner received private event")
+	}
+}
+
+func TestBackplane_IgnoresOwnEcho(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := startBackplaneHub(t, ctx, mr)
+
+	client := &Client{hub: h, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}}
+	h.register <- client
+	time.Sleep(50 * time.Millisecond)
+
+	h.Broadcast(&Event{Type: EventMilestone, Timestamp: time.Now()})
+
+	// Exactly one copy: the local delivery, not a second via the echo.
+	select {
+	case <-client.send:
+	case <-time.After(2 * time.Second):
+		t.Fatal("local delivery missing")
+	}
+	select {
+	case msg := <-client.send:
+		t.Errorf("duplicate delivery via backplane echo: %s", msg)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
@@ -0,0 +1,145 @@
+package realtime
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Compact binary frames for high-frequency consumers. A client opts in
+// at connection time with ?encoding=binary; events then arrive as
+// WebSocket binary messages instead of JSON text, saving the envelope
+// overhead on every frame. JSON remains the default and the only
+// encoding for SSE.
+//
+// Frame layout (integers are encoding/binary varints):
+//
+//	[0]          frame version, currently 1
+//	[1]          event type code; 0 means an inline type string follows
+//	(code 0)     uvarint length + type string
+//	uvarint      sequence number
+//	varint       unix-millisecond timestamp
+//	uvarint      length + JSON-encoded data payload
+
+// binaryFrameVersion guards against layout changes.
+const binaryFrameVersion = 1
+
+// binaryTypeCodes assigns wire codes to event types: index+1 is the
+// code. Append only — reordering breaks deployed consumers.
+var binaryTypeCodes = []EventType{
+	EventTransaction,
+	EventAgentJoined,
+	EventMilestone,
+	EventPriceAlert,
+	EventCoalition,
+	EventCreditAlert,
+	EventSessionCreated,
+	EventSessionClosed,
+	EventProxySettlement,
+	EventEscrowCreated,
+	EventEscrowDelivered,
+	EventEscrowConfirmed,
+	EventEscrowDisputed,
+	EventStreamOpened,
+	EventStreamClosed,
+	EventPredictionCreated,
+	EventPredictionResolved,
+	EventSessionKeyUsage,
+	EventBalanceChange,
+	EventWebhookFailure,
+	EventSubscriptionAck,
+	EventReplayComplete,
+	EventPresence,
+	EventNetworkStats,
+}
+
+var binaryCodeByType = func() map[EventType]byte {
+	m := make(map[EventType]byte, len(binaryTypeCodes))
+	for i, t := range binaryTypeCodes {
+		m[t] = byte(i + 1)
+	}
+	return m
+}()
+
+// encodeBinaryEvent frames one event in the compact binary layout.
+func encodeBinaryEvent(event *Event) []byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, binaryFrameVersion)
+	if code, ok := binaryCodeByType[event.Type]; ok {
+		buf = append(buf, code)
+	} else {
+		buf = append(buf, 0)
+		buf = binary.AppendUvarint(buf, uint64(len(event.Type)))
+		buf = append(buf, event.Type...)
+	}
+	buf = binary.AppendUvarint(buf, uint64(event.Seq))
+	buf = binary.AppendVarint(buf, event.Timestamp.UnixMilli())
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		data = []byte("null")
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+var errBadBinaryFrame = errors.New("malformed binary frame")
+
+// decodeBinaryEvent parses a compact binary frame back into an Event.
+// The reference decoder for client implementations; the server only
+// encodes.
+func decodeBinaryEvent(frame []byte) (*Event, error) {
+	if len(frame) < 2 || frame[0] != binaryFrameVersion {
+		return nil, errBadBinaryFrame
+	}
+	event := &Event{}
+	code := frame[1]
+	rest := frame[2:]
+
+	if code == 0 {
+		n, read := binary.Uvarint(rest)
+		if read <= 0 || uint64(len(rest)-read) < n {
+			return nil, errBadBinaryFrame
+		}
+		event.Type = EventType(rest[read : read+int(n)])
+		rest = rest[read+int(n):]
+	} else {
+		if int(code) > len(binaryTypeCodes) {
+			return nil, errBadBinaryFrame
+		}
+		event.Type = binaryTypeCodes[code-1]
+	}
+
+	seq, read := binary.Uvarint(rest)
+	if read <= 0 {
+		return nil, errBadBinaryFrame
+	}
+	event.Seq = int64(seq)
+	rest = rest[read:]
+
+	millis, read := binary.Varint(rest)
+	if read <= 0 {
+		return nil, errBadBinaryFrame
+	}
+	event.Timestamp = time.UnixMilli(millis).UTC()
+	rest = rest[read:]
+
+	n, read := binary.Uvarint(rest)
+	if read <= 0 || uint64(len(rest)-read) < n {
+		return nil, errBadBinaryFrame
+	}
+	if err := json.Unmarshal(rest[read:read+int(n)], &event.Data); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// encodeFor serializes an event for one client in its negotiated
+// encoding.
+func (h *Hub) encodeFor(c *Client, event *Event) []byte {
+	if c.binary {
+		return encodeBinaryEvent(event)
+	}
+	return h.serialize(event)
+}
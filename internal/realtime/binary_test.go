@@ -0,0 +1,102 @@
+package realtime
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestBinaryFrame_RoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond).UTC()
+	event := &Event{
+		Type:      EventTransaction,
+		Timestamp: now,
+		Seq:       42,
+		Data:      map[string]interface{}{"amount": "5.00", "from": "0xabc"},
+	}
+
+	frame := encodeBinaryEvent(event)
+	got, err := decodeBinaryEvent(frame)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if got.Type != EventTransaction || got.Seq != 42 || !got.Timestamp.Equal(now) {
+		t.Errorf("round trip = %+v, want %+v", got, event)
+	}
+	data := got.Data.(map[string]interface{})
+	if data["amount"] != "5.00" || data["from"] != "0xabc" {
+		t.Errorf("data = %v", data)
+	}
+}
+
+func TestBinaryFrame_UnknownTypeInline(t *testing.T) {
+	event := &Event{Type: "custom_event", Timestamp: time.Now(), Data: nil}
+	got, err := decodeBinaryEvent(encodeBinaryEvent(event))
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if got.Type != "custom_event" {
+		t.Errorf("type = %s, want custom_event", got.Type)
+	}
+}
+
+func TestBinaryFrame_RejectsMalformed(t *testing.T) {
+	for _, frame := range [][]byte{nil, {1}, {2, 1, 0}, {1, 200, 0, 0, 0}} {
+		if _, err := decodeBinaryEvent(frame); err == nil {
+			t.Errorf("decode(%v) should fail", frame)
+		}
+	}
+}
+
+func TestBinaryEncoding_Negotiation(t *testing.T) {
+	h := NewHub(slog.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go h.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.HandleWebSocket(w, r)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "?encoding=binary"
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if resp != nil && resp.Body != nil {
+		defer resp.Body.Close() //nolint:errcheck
+	}
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	h.Broadcast(&Event{
+		Type:      EventTransaction,
+		Timestamp: time.Now(),
+		Data:      map[string]interface{}{"amount": "5.00"},
+	})
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	msgType, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read event: %v", err)
+	}
+	if msgType != websocket.BinaryMessage {
+		t.Fatalf("message type = %d, want binary", msgType)
+	}
+	event, err := decodeBinaryEvent(msg)
+	if err != nil {
+		t.Fatalf("frame not decodable: %v", err)
+	}
+	if event.Type != EventTransaction || event.Seq == 0 {
+		t.Errorf("decoded event = %+v", event)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -49,6 +50,7 @@ const (
 	EventMilestone   EventType = "milestone"
 	EventPriceAlert  EventType = "price_alert"
 	EventCoalition   EventType = "coalition"
+	EventCreditAlert EventType = "credit_alert"
 
 	// Gateway session lifecycle events
 	EventSessionCreated  EventType = "session_created"
@@ -64,6 +66,19 @@ const (
 	// Stream lifecycle events
 	EventStreamOpened EventType = "stream_opened"
 	EventStreamClosed EventType = "stream_closed"
+
+	// Prediction lifecycle events
+	EventPredictionCreated  EventType = "prediction_created"
+	EventPredictionResolved EventType = "prediction_resolved"
+
+	// Periodic network-wide stats tick; see the "stats" topic
+	EventNetworkStats EventType = "network_stats"
+
+	// Private per-agent events, delivered only to the owner's
+	// authenticated connections via BroadcastPrivate
+	EventSessionKeyUsage EventType = "session_key_usage"
+	EventBalanceChange   EventType = "balance_change"
+	EventWebhookFailure  EventType = "webhook_failure"
 )
 
 // Event represents a real-time event
@@ -71,6 +86,16 @@ type Event struct {
 	Type      EventType   `json:"type"`
 	Timestamp time.Time   `json:"timestamp"`
 	Data      interface{} `json:"data"`
+	Seq       int64       `json:"seq,omitempty"` // assigned at broadcast; SSE resume cursor
+
+	// target, when set, addresses the event to one agent: only that
+	// agent's authenticated connections receive it, regardless of
+	// filters. Set via BroadcastPrivate; never serialized.
+	target string
+
+	// remote marks events received from another instance via the
+	// backplane, which must not be re-published. Never serialized.
+	remote bool
 }
 
 // Subscription filters for a client
@@ -84,17 +109,31 @@ type Subscription struct {
 
 // Client represents a WebSocket connection
 type Client struct {
-	hub  *Hub
-	conn *websocket.Conn
-	send chan []byte
-	mu   sync.RWMutex
-	sub  Subscription
-	ip   string // remote IP for per-IP tracking
+	hub    *Hub
+	conn   *websocket.Conn
+	send   chan []byte
+	mu     sync.RWMutex
+	sub    Subscription
+	topics map[string]struct{} // non-nil = topic mode; see topics.go
+	agent  string              // authenticated agent address (lowercase); "" = unauthenticated
+	ip     string              // remote IP for per-IP tracking
+	binary bool                // compact binary frames negotiated; see binary.go
+
+	// dropped counts consecutive events lost to a full send queue;
+	// reset on every successful send. See maxConsecutiveDrops.
+	dropped atomic.Int64
 }
 
 // MaxClients is the maximum number of concurrent WebSocket connections.
 const MaxClients = 10000
 
+// maxConsecutiveDrops is the backpressure policy boundary: a stalled
+// client first loses individual events (counted, never blocking the
+// broadcast loop), and is disconnected once this many are dropped in a
+// row. A client that can catch up — sequence replay exists for exactly
+// this — keeps its connection.
+const maxConsecutiveDrops = 32
+
 // Hub manages all WebSocket connections
 type Hub struct {
 	clients    map[*Client]bool
@@ -111,10 +150,27 @@ type Hub struct {
 	ipConns  map[string]int
 	maxPerIP int
 
+	// Optional cross-instance fanout; attached via NewBackplane
+	// before Run starts.
+	backplane *Backplane
+
+	// Per-agent liveness; see presence.go
+	presMu   sync.Mutex
+	presence map[string]*presenceEntry
+
+	// Recent events for replay after a reconnect; every broadcast gets
+	// a monotonic Seq from nextSeq. SSE clients resume via
+	// Last-Event-ID (sse.go), WebSocket clients via a replay control
+	// message (topics.go).
+	histMu  sync.Mutex
+	history []*Event
+	nextSeq atomic.Int64
+
 	// Stats
-	totalEvents  atomic.Int64
-	totalClients atomic.Int64
-	peakClients  atomic.Int64
+	totalEvents   atomic.Int64
+	totalClients  atomic.Int64
+	peakClients   atomic.Int64
+	droppedEvents atomic.Int64
 }
 
 // NewHub creates a new WebSocket hub
@@ -125,6 +181,7 @@ func NewHub(logger *slog.Logger) *Hub {
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		ipConns:    make(map[string]int),
+		presence:   make(map[string]*presenceEntry),
 		maxPerIP:   100, // Max 100 WS connections from a single IP
 		logger:     logger,
 		done:       make(chan struct{}),
@@ -161,12 +218,15 @@ func (h *Hub) Run(ctx context.Context) {
 			}
 			n := len(h.clients)
 			h.mu.Unlock()
+			h.presenceConnect(client.agent)
 			metrics.ActiveWebSocketClients.Set(float64(n))
 			h.logger.Info("client connected", "total", n)
 
 		case client := <-h.unregister:
 			h.mu.Lock()
+			removed := false
 			if _, ok := h.clients[client]; ok {
+				removed = true
 				delete(h.clients, client)
 				close(client.send)
 				if client.ip != "" {
@@ -182,19 +242,34 @@ func (h *Hub) Run(ctx context.Context) {
 			}
 			n := len(h.clients)
 			h.mu.Unlock()
+			if removed {
+				h.presenceDisconnect(client.agent)
+			}
 			metrics.ActiveWebSocketClients.Set(float64(n))
 			h.logger.Info("client disconnected", "total", n)
 
 		case event := <-h.broadcast:
 			h.totalEvents.Add(1)
+			event.Seq = h.nextSeq.Add(1)
+			h.recordHistory(event)
+			if h.backplane != nil && !event.remote {
+				go h.backplane.publish(event)
+			}
 			h.mu.RLock()
 			var slow []*Client
 			for client := range h.clients {
 				if h.shouldSend(client, event) {
 					select {
-					case client.send <- h.serialize(event):
+					case client.send <- h.encodeFor(client, event):
+						client.dropped.Store(0)
 					default:
-						slow = append(slow, client)
+						// Full queue: drop this event for this client
+						// rather than block the loop for everyone.
+						h.droppedEvents.Add(1)
+						metrics.RealtimeDroppedEvents.Inc()
+						if client.dropped.Add(1) >= maxConsecutiveDrops {
+							slow = append(slow, client)
+						}
 					}
 				}
 			}
@@ -206,6 +281,9 @@ func (h *Hub) Run(ctx context.Context) {
 					if _, ok := h.clients[client]; ok {
 						close(client.send)
 						delete(h.clients, client)
+						h.presenceDisconnect(client.agent)
+						h.logger.Warn("evicting slow client",
+							"dropped", client.dropped.Load())
 					}
 				}
 				h.mu.Unlock()
@@ -218,8 +296,22 @@ func (h *Hub) Run(ctx context.Context) {
 func (h *Hub) shouldSend(client *Client, event *Event) bool {
 	client.mu.RLock()
 	sub := client.sub
+	topics := client.topics
 	client.mu.RUnlock()
 
+	// Private events go only to the addressed agent's authenticated
+	// connections, bypassing topic and filter checks — it's their data.
+	if event.target != "" {
+		return client.agent != "" && client.agent == event.target
+	}
+
+	// Topic mode: the event must match at least one subscribed topic.
+	// An empty (but non-nil) set means the client unsubscribed from
+	// everything and wants silence until it subscribes again.
+	if topics != nil {
+		return matchesTopics(topics, event)
+	}
+
 	// All events subscribed
 	if sub.AllEvents {
 		return true
@@ -281,6 +373,35 @@ func (h *Hub) serialize(event *Event) []byte {
 	return data
 }
 
+// replayHistorySize bounds the replay history. Clients that fall
+// further behind miss events, like a reconnecting client always did
+// before sequence numbers existed.
+const replayHistorySize = 1024
+
+// recordHistory appends a broadcast event to the replay ring.
+func (h *Hub) recordHistory(event *Event) {
+	h.histMu.Lock()
+	defer h.histMu.Unlock()
+	h.history = append(h.history, event)
+	if len(h.history) > replayHistorySize {
+		h.history = h.history[len(h.history)-replayHistorySize:]
+	}
+}
+
+// historySince returns recorded events with a sequence number greater
+// than lastSeq, oldest first.
+func (h *Hub) historySince(lastSeq int64) []*Event {
+	h.histMu.Lock()
+	defer h.histMu.Unlock()
+	var out []*Event
+	for _, ev := range h.history {
+		if ev.Seq > lastSeq {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
 // Broadcast sends an event to all matching clients
 func (h *Hub) Broadcast(event *Event) {
 	select {
@@ -290,6 +411,21 @@ func (h *Hub) Broadcast(event *Event) {
 	}
 }
 
+// BroadcastPrivate sends an event only to the agent's authenticated
+// connections: escrow updates, session-key usage, balance changes —
+// per-agent data that must not reach the public firehose.
+func (h *Hub) BroadcastPrivate(agentAddr string, eventType EventType, data map[string]interface{}) {
+	if agentAddr == "" {
+		return
+	}
+	h.Broadcast(&Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+		target:    strings.ToLower(agentAddr),
+	})
+}
+
 // BroadcastTransaction sends a transaction event
 func (h *Hub) BroadcastTransaction(tx map[string]interface{}) {
 	h.Broadcast(&Event{
@@ -335,6 +471,15 @@ func (h *Hub) BroadcastStreamEvent(eventType EventType, data map[string]interfac
 	})
 }
 
+// BroadcastPredictionEvent sends a prediction lifecycle event.
+func (h *Hub) BroadcastPredictionEvent(eventType EventType, data map[string]interface{}) {
+	h.Broadcast(&Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+}
+
 // Stats returns hub statistics
 func (h *Hub) Stats() map[string]interface{} {
 	h.mu.RLock()
@@ -345,11 +490,21 @@ func (h *Hub) Stats() map[string]interface{} {
 		"totalEvents":      h.totalEvents.Load(),
 		"totalClients":     h.totalClients.Load(),
 		"peakClients":      h.peakClients.Load(),
+		"droppedEvents":    h.droppedEvents.Load(),
 	}
 }
 
-// HandleWebSocket upgrades HTTP to WebSocket
+// HandleWebSocket upgrades HTTP to WebSocket without binding an agent
+// identity; the connection only receives public events.
 func (h *Hub) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	h.HandleWebSocketAuthed(w, r, "")
+}
+
+// HandleWebSocketAuthed upgrades HTTP to WebSocket and binds the
+// authenticated agent's address to the connection, making it eligible
+// for BroadcastPrivate events. Callers must have validated the identity
+// (e.g. via API key) before upgrading.
+func (h *Hub) HandleWebSocketAuthed(w http.ResponseWriter, r *http.Request, agentAddr string) {
 	// Reject upgrades after the hub has stopped to prevent orphaned connections.
 	select {
 	case <-h.done:
@@ -400,11 +555,13 @@ func (h *Hub) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}
 
 	client := &Client{
-		hub:  h,
-		conn: conn,
-		send: make(chan []byte, 256),
-		sub:  Subscription{AllEvents: true}, // Default: all events
-		ip:   ip,
+		hub:    h,
+		conn:   conn,
+		send:   make(chan []byte, 256),
+		sub:    Subscription{AllEvents: true}, // Default: all events
+		agent:  strings.ToLower(agentAddr),
+		ip:     ip,
+		binary: r.URL.Query().Get("encoding") == "binary",
 	}
 
 	h.register <- client
@@ -437,6 +594,13 @@ func (c *Client) readPump() {
 			break
 		}
 
+		// Topic control messages carry an "action" field; see topics.go.
+		var ctrl controlMessage
+		if err := json.Unmarshal(message, &ctrl); err == nil && ctrl.Action != "" {
+			c.handleControl(ctrl)
+			continue
+		}
+
 		// Parse subscription update — cap filter array sizes to prevent DoS.
 		var sub Subscription
 		if err := json.Unmarshal(message, &sub); err == nil {
@@ -452,6 +616,7 @@ func (c *Client) readPump() {
 			}
 			c.mu.Lock()
 			c.sub = sub
+			c.topics = nil // a full-filter replacement leaves topic mode
 			c.mu.Unlock()
 		}
 	}
@@ -474,7 +639,11 @@ func (c *Client) writePump() {
 				return
 			}
 
-			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			msgType := websocket.TextMessage
+			if c.binary {
+				msgType = websocket.BinaryMessage
+			}
+			if err := c.conn.WriteMessage(msgType, message); err != nil {
 				c.hub.logger.Warn("websocket write error", "error", err)
 				return
 			}
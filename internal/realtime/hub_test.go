@@ -927,18 +927,65 @@ func TestHub_SlowClientDisconnected(t *testing.T) {
 	// Fill the slow client's send buffer
 	slowClient.send <- []byte("fill")
 
-	// Now broadcast — the slow client should be disconnected
-	h.Broadcast(&Event{
-		Type:      EventMilestone,
-		Timestamp: time.Now(),
-		Data:      map[string]interface{}{"event": "test"},
-	})
-	time.Sleep(100 * time.Millisecond)
+	// Events are dropped (not blocking the hub) until the client has
+	// fallen maxConsecutiveDrops behind, then it is disconnected.
+	for i := 0; i < maxConsecutiveDrops; i++ {
+		h.Broadcast(&Event{
+			Type:      EventMilestone,
+			Timestamp: time.Now(),
+			Data:      map[string]interface{}{"event": "test"},
+		})
+	}
+	time.Sleep(200 * time.Millisecond)
 
 	stats := h.Stats()
 	if stats["connectedClients"].(int) != 0 {
 		t.Errorf("Expected slow client to be disconnected, got %v connected", stats["connectedClients"])
 	}
+	if dropped := stats["droppedEvents"].(int64); dropped < maxConsecutiveDrops {
+		t.Errorf("droppedEvents = %d, want >= %d", dropped, maxConsecutiveDrops)
+	}
+}
+
+func TestHub_SlowClientDropsBeforeDisconnect(t *testing.T) {
+	h := NewHub(slog.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go h.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+
+	slowClient := &Client{
+		hub:  h,
+		send: make(chan []byte, 1),
+		sub:  Subscription{AllEvents: true},
+	}
+	h.register <- slowClient
+	time.Sleep(30 * time.Millisecond)
+	slowClient.send <- []byte("fill")
+
+	// A few drops are tolerated without eviction.
+	for i := 0; i < maxConsecutiveDrops/2; i++ {
+		h.Broadcast(&Event{Type: EventMilestone, Timestamp: time.Now()})
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	stats := h.Stats()
+	if stats["connectedClients"].(int) != 1 {
+		t.Errorf("client evicted after %d drops, want tolerance up to %d",
+			maxConsecutiveDrops/2, maxConsecutiveDrops)
+	}
+	if dropped := stats["droppedEvents"].(int64); dropped != maxConsecutiveDrops/2 {
+		t.Errorf("droppedEvents = %d, want %d", dropped, maxConsecutiveDrops/2)
+	}
+
+	// Draining the queue resets the consecutive-drop counter.
+	<-slowClient.send
+	h.Broadcast(&Event{Type: EventMilestone, Timestamp: time.Now()})
+	time.Sleep(100 * time.Millisecond)
+	if got := slowClient.dropped.Load(); got != 0 {
+		t.Errorf("dropped counter = %d after successful send, want 0", got)
+	}
 }
 
 // ---------------------------------------------------------------------------
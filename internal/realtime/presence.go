@@ -0,0 +1,110 @@
+package realtime
+
+import (
+	"strings"
+	"time"
+)
+
+// Presence tracking. An agent is online while it has at least one
+// authenticated realtime connection (WebSocket or SSE) or has sent a
+// heartbeat within presenceTTL. Connection transitions broadcast a
+// public presence event; heartbeat expiry is evaluated lazily on read,
+// so a heartbeat-only agent fades offline without an explicit event.
+
+// EventPresence announces an agent going online or offline; Data
+// carries agentAddr and online.
+const EventPresence EventType = "presence"
+
+// presenceTTL is how long a heartbeat keeps an agent online without an
+// open connection.
+const presenceTTL = 90 * time.Second
+
+// presenceEntry tracks one agent's liveness: open authenticated
+// connections plus the most recent explicit heartbeat.
+type presenceEntry struct {
+	conns     int
+	heartbeat time.Time
+}
+
+func (e *presenceEntry) online(now time.Time) bool {
+	return e != nil && (e.conns > 0 || now.Sub(e.heartbeat) < presenceTTL)
+}
+
+// PresenceInfo is the queryable liveness snapshot for one agent.
+type PresenceInfo struct {
+	AgentAddr         string     `json:"agentAddr"`
+	Online            bool       `json:"online"`
+	ActiveConnections int        `json:"activeConnections"`
+	LastSeen          *time.Time `json:"lastSeen,omitempty"`
+}
+
+// presenceConnect records an authenticated connection opening.
+func (h *Hub) presenceConnect(agentAddr string) {
+	h.updatePresence(agentAddr, +1, false)
+}
+
+// presenceDisconnect records an authenticated connection closing.
+func (h *Hub) presenceDisconnect(agentAddr string) {
+	h.updatePresence(agentAddr, -1, false)
+}
+
+// Heartbeat marks an agent as recently alive without an open
+// connection, e.g. from a poll-only agent's keepalive endpoint.
+func (h *Hub) Heartbeat(agentAddr string) {
+	h.updatePresence(agentAddr, 0, true)
+}
+
+func (h *Hub) updatePresence(agentAddr string, delta int, heartbeat bool) {
+	if agentAddr == "" {
+		return
+	}
+	addr := strings.ToLower(agentAddr)
+	now := time.Now()
+
+	h.presMu.Lock()
+	e := h.presence[addr]
+	wasOnline := e.online(now)
+	if e == nil {
+		e = &presenceEntry{}
+		h.presence[addr] = e
+	}
+	e.conns += delta
+	if e.conns < 0 {
+		e.conns = 0
+	}
+	if heartbeat {
+		e.heartbeat = now
+	}
+	isOnline := e.online(now)
+	h.presMu.Unlock()
+
+	if wasOnline != isOnline {
+		h.Broadcast(&Event{
+			Type:      EventPresence,
+			Timestamp: now,
+			Data: map[string]interface{}{
+				"agentAddr": addr,
+				"online":    isOnline,
+			},
+		})
+	}
+}
+
+// Presence reports an agent's current liveness.
+func (h *Hub) Presence(agentAddr string) PresenceInfo {
+	addr := strings.ToLower(agentAddr)
+	now := time.Now()
+
+	h.presMu.Lock()
+	e := h.presence[addr]
+	info := PresenceInfo{AgentAddr: addr, Online: e.online(now)}
+	if e != nil {
+		info.ActiveConnections = e.conns
+		if !e.heartbeat.IsZero() && now.Sub(e.heartbeat) < presenceTTL {
+			seen := e.heartbeat
+			info.LastSeen = &seen
+		}
+	}
+	h.presMu.Unlock()
+	return info
+}
@@ -0,0 +1,134 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func startPresenceHub(t *testing.T) (*Hub, context.CancelFunc) {
+	t.Helper()
+	h := NewHub(slog.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	go h.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+	return h, cancel
+}
+
+// waitPresenceEvent reads events from a watcher until a presence event
+// arrives or the deadline passes.
+func waitPresenceEvent(t *testing.T, watcher *Client) map[string]interface{} {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case msg := <-watcher.send:
+			var event Event
+			if err := json.Unmarshal(msg, &event); err != nil {
+				t.Fatalf("event not JSON: %v", err)
+			}
+			if event.Type == EventPresence {
+				return event.Data.(map[string]interface{})
+			}
+		case <-deadline:
+			t.Fatal("no presence event received")
+		}
+	}
+}
+
+func TestPresence_ConnectionLifecycle(t *testing.T) {
+	h, cancel := startPresenceHub(t)
+	defer cancel()
+
+	watcher := &Client{hub: h, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}}
+	h.register <- watcher
+	time.Sleep(30 * time.Millisecond)
+
+	agent := &Client{hub: h, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}, agent: "0xabc"}
+	h.register <- agent
+	time.Sleep(30 * time.Millisecond)
+
+	data := waitPresenceEvent(t, watcher)
+	if data["agentAddr"] != "0xabc" || data["online"] != true {
+		t.Errorf("online event = %v", data)
+	}
+
+	info := h.Presence("0xABC")
+	if !info.Online || info.ActiveConnections != 1 {
+		t.Errorf("Presence = %+v, want online with 1 connection", info)
+	}
+
+	h.unregister <- agent
+	time.Sleep(30 * time.Millisecond)
+
+	data = waitPresenceEvent(t, watcher)
+	if data["agentAddr"] != "0xabc" || data["online"] != false {
+		t.Errorf("offline event = %v", data)
+	}
+	if h.Presence("0xabc").Online {
+		t.Error("agent still online after disconnect")
+	}
+}
+
+func TestPresence_SecondConnectionKeepsOnline(t *testing.T) {
+	h, cancel := startPresenceHub(t)
+	defer cancel()
+
+	first := &Client{hub: h, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}, agent: "0xabc"}
+	second := &Client{hub: h, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}, agent: "0xabc"}
+	h.register <- first
+	h.register <- second
+	time.Sleep(30 * time.Millisecond)
+
+	h.unregister <- first
+	time.Sleep(30 * time.Millisecond)
+
+	info := h.Presence("0xabc")
+	if !info.Online || info.ActiveConnections != 1 {
+		t.Errorf("Presence = %+v, want still online with 1 connection", info)
+	}
+}
+
+func TestPresence_Heartbeat(t *testing.T) {
+	h, cancel := startPresenceHub(t)
+	defer cancel()
+
+	if h.Presence("0xdef").Online {
+		t.Error("unknown agent should be offline")
+	}
+
+	h.Heartbeat("0xDEF")
+	info := h.Presence("0xdef")
+	if !info.Online || info.ActiveConnections != 0 {
+		t.Errorf("Presence = %+v, want online via heartbeat", info)
+	}
+	if info.LastSeen == nil {
+		t.Error("heartbeat should set lastSeen")
+	}
+
+	// Expiry is lazy: age the heartbeat past the TTL and read again.
+	h.presMu.Lock()
+	h.presence["0xdef"].heartbeat = time.Now().Add(-presenceTTL - time.Second)
+	h.presMu.Unlock()
+	if h.Presence("0xdef").Online {
+		t.Error("stale heartbeat should read as offline")
+	}
+}
+
+func TestPresence_UnauthenticatedConnectionsIgnored(t *testing.T) {
+	h, cancel := startPresenceHub(t)
+	defer cancel()
+
+	anon := &Client{hub: h, send: make(chan []byte, 16), sub: Subscription{AllEvents: true}}
+	h.register <- anon
+	time.Sleep(30 * time.Millisecond)
+
+	h.presMu.Lock()
+	n := len(h.presence)
+	h.presMu.Unlock()
+	if n != 0 {
+		t.Errorf("presence entries = %d, want 0 for anonymous connections", n)
+	}
+}
@@ -0,0 +1,152 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestShouldSend_PrivateEvents(t *testing.T) {
+	h := testHub()
+	owner := &Client{hub: h, send: make(chan []byte, 4), agent: "0xabc", sub: Subscription{AllEvents: true}}
+	other := &Client{agent: "0xdef", sub: Subscription{AllEvents: true}}
+	anon := &Client{sub: Subscription{AllEvents: true}}
+
+	private := &Event{Type: EventSessionKeyUsage, target: "0xabc"}
+	if !h.shouldSend(owner, private) {
+		t.Error("owner should receive their private event")
+	}
+	if h.shouldSend(other, private) {
+		t.Error("other agents should not receive someone else's private event")
+	}
+	if h.shouldSend(anon, private) {
+		t.Error("unauthenticated clients should never receive private events")
+	}
+
+	// Private events bypass topic filters: an owner in topic mode with
+	// unrelated topics still gets addressed events.
+	owner.handleControl(controlMessage{Action: "subscribe", Topics: []string{"escrow"}})
+	if !h.shouldSend(owner, private) {
+		t.Error("private event should bypass topic filters for the owner")
+	}
+}
+
+func TestBroadcastPrivate_OnlyOwnerReceives(t *testing.T) {
+	h := testHub()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go h.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+
+	owner := &Client{hub: h, send: make(chan []byte, 4), agent: "0xabc", sub: Subscription{AllEvents: true}}
+	bystander := &Client{hub: h, send: make(chan []byte, 4), sub: Subscription{AllEvents: true}}
+	h.register <- owner
+	h.register <- bystander
+	time.Sleep(30 * time.Millisecond)
+
+	// Mixed-case address normalizes to the bound identity.
+	h.BroadcastPrivate("0xABC", EventBalanceChange, map[string]interface{}{"delta": "-5.00"})
+
+	// Skip public chatter (e.g. presence) on both streams.
+	if event := nextEventOfType(t, owner.send, EventBalanceChange); event == nil {
+		t.Fatal("owner did not receive private event")
+	}
+	if event := drainForType(bystander.send, EventBalanceChange, 100*time.Millisecond); event != nil {
+		t.Error("bystander received a private event")
+	}
+}
+
+// nextEventOfType reads events until one of the wanted type arrives.
+func nextEventOfType(t *testing.T, ch chan []byte, want EventType) *Event {
+	t.Helper()
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case msg := <-ch:
+			var event Event
+			if err := json.Unmarshal(msg, &event); err != nil {
+				t.Fatalf("event not JSON: %v", err)
+			}
+			if event.Type == want {
+				return &event
+			}
+		case <-deadline:
+			return nil
+		}
+	}
+}
+
+// drainForType reads events for the given window and reports whether
+// one of the wanted type appeared.
+func drainForType(ch chan []byte, want EventType, window time.Duration) *Event {
+	deadline := time.After(window)
+	for {
+		select {
+		case msg := <-ch:
+			var event Event
+			if json.Unmarshal(msg, &event) == nil && event.Type == want {
+				return &event
+			}
+		case <-deadline:
+			return nil
+		}
+	}
+}
+
+func TestHandleWebSocketAuthed_BindsAgent(t *testing.T) {
+	h := NewHub(slog.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go h.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.HandleWebSocketAuthed(w, r, "0xABC")
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if resp != nil && resp.Body != nil {
+		defer resp.Body.Close() //nolint:errcheck
+	}
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	h.BroadcastPrivate("0xabc", EventSessionKeyUsage, map[string]interface{}{
+		"sessionKeyId": "sk_1",
+		"amount":       "0.50",
+	})
+
+	// The connection's own presence event may arrive first.
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("Failed to read private event: %v", err)
+		}
+		var event Event
+		if err := json.Unmarshal(msg, &event); err != nil {
+			t.Fatalf("event not JSON: %v", err)
+		}
+		if event.Type == EventPresence {
+			continue
+		}
+		if event.Type != EventSessionKeyUsage {
+			t.Errorf("type = %s, want session_key_usage", event.Type)
+		}
+		return
+	}
+}
@@ -0,0 +1,126 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Server-Sent Events fallback. GET /v1/events mirrors the WebSocket
+// stream for environments where WebSockets are impractical (serverless
+// agents, strict proxies). Every broadcast event gets a monotonic
+// sequence number, kept in a short in-memory history; clients resume
+// after a disconnect by sending the standard Last-Event-ID header (or a
+// lastEventId query parameter) and missed events are replayed first.
+// Filtering uses the same topics as the WebSocket protocol, passed as a
+// comma-separated "topics" query parameter since SSE is one-way.
+
+// sseKeepAlive is the interval for comment keep-alives, which stop
+// idle-connection timeouts in proxies.
+const sseKeepAlive = 30 * time.Second
+
+// writeSSEEvent frames one event in SSE wire format.
+func writeSSEEvent(w io.Writer, seq int64, eventType EventType, data []byte) {
+	if seq > 0 {
+		fmt.Fprintf(w, "id: %d\n", seq)
+	}
+	if eventType != "" {
+		fmt.Fprintf(w, "event: %s\n", eventType)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
+// ServeSSE streams events over Server-Sent Events. agentAddr binds an
+// authenticated identity, as in HandleWebSocketAuthed; pass "" for
+// connections that should only see public events.
+func (h *Hub) ServeSSE(w http.ResponseWriter, r *http.Request, agentAddr string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// Same admission control as the WebSocket path.
+	select {
+	case <-h.done:
+		http.Error(w, "server shutting down", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+	select {
+	case h.connSem <- struct{}{}:
+	default:
+		http.Error(w, "too many connections", http.StatusServiceUnavailable)
+		return
+	}
+
+	client := &Client{
+		hub:   h,
+		send:  make(chan []byte, 256),
+		sub:   Subscription{AllEvents: true},
+		agent: strings.ToLower(agentAddr),
+	}
+	if raw := r.URL.Query().Get("topics"); raw != "" {
+		topics := make(map[string]struct{})
+		for _, topic := range strings.Split(raw, ",") {
+			if topic = strings.TrimSpace(topic); validTopic(topic) {
+				topics[topic] = struct{}{}
+			}
+		}
+		client.topics = topics
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// Replay missed events before joining the live stream.
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("lastEventId")
+	}
+	if lastSeq, err := strconv.ParseInt(lastID, 10, 64); err == nil && lastSeq > 0 {
+		for _, ev := range h.historySince(lastSeq) {
+			if h.shouldSend(client, ev) {
+				writeSSEEvent(w, ev.Seq, ev.Type, h.serialize(ev))
+			}
+		}
+	}
+	flusher.Flush()
+
+	h.register <- client
+	defer func() { h.unregister <- client }()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-client.send:
+			if !ok {
+				return // hub closed us (slow client or shutdown)
+			}
+			// Pull seq and type back out for the SSE framing; the hub
+			// hands every client the same serialized payload.
+			var meta struct {
+				Seq  int64     `json:"seq"`
+				Type EventType `json:"type"`
+			}
+			_ = json.Unmarshal(msg, &meta)
+			writeSSEEvent(w, meta.Seq, meta.Type, msg)
+			flusher.Flush()
+
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseFrame is one parsed SSE event.
+type sseFrame struct {
+	id    string
+	event string
+	data  string
+}
+
+// readSSEFrame reads lines until a blank line, skipping comments.
+func readSSEFrame(r *bufio.Reader) (sseFrame, error) {
+	var f sseFrame
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return f, err
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			if f.data != "" {
+				return f, nil
+			}
+		case strings.HasPrefix(line, ":"):
+			// keep-alive comment
+		case strings.HasPrefix(line, "id: "):
+			f.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			f.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			f.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func startSSEHub(t *testing.T) (*Hub, *httptest.Server, context.CancelFunc) {
+	t.Helper()
+	h := NewHub(slog.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	go h.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeSSE(w, r, "")
+	}))
+	return h, server, func() {
+		server.Close()
+		cancel()
+	}
+}
+
+func dialSSE(t *testing.T, url string, headers map[string]string) (*bufio.Reader, func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		t.Fatalf("SSE request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		cancel()
+		t.Fatalf("SSE status = %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		cancel()
+		t.Fatalf("Content-Type = %s", ct)
+	}
+	return bufio.NewReader(resp.Body), func() {
+		cancel()
+		resp.Body.Close() //nolint:errcheck
+	}
+}
+
+func TestServeSSE_StreamsEvents(t *testing.T) {
+	h, server, stop := startSSEHub(t)
+	defer stop()
+
+	r, closeConn := dialSSE(t, server.URL, nil)
+	defer closeConn()
+	time.Sleep(100 * time.Millisecond)
+
+	h.Broadcast(&Event{
+		Type:      EventTransaction,
+		Timestamp: time.Now(),
+		Data:      map[string]interface{}{"amount": "5.00"},
+	})
+
+	frame, err := readSSEFrame(r)
+	if err != nil {
+		t.Fatalf("failed to read frame: %v", err)
+	}
+	if frame.event != string(EventTransaction) {
+		t.Errorf("event = %q, want transaction", frame.event)
+	}
+	if frame.id == "" {
+		t.Error("frame missing id for resume")
+	}
+	var event Event
+	if err := json.Unmarshal([]byte(frame.data), &event); err != nil {
+		t.Fatalf("data not JSON: %v", err)
+	}
+	if event.Seq == 0 {
+		t.Error("event payload missing seq")
+	}
+}
+
+func TestServeSSE_ResumesFromLastEventID(t *testing.T) {
+	h, server, stop := startSSEHub(t)
+	defer stop()
+
+	// Record three events with no clients connected.
+	for i := 1; i <= 3; i++ {
+		h.Broadcast(&Event{
+			Type:      EventMilestone,
+			Timestamp: time.Now(),
+			Data:      map[string]interface{}{"n": i},
+		})
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// Resuming after seq 1 replays events 2 and 3.
+	r, closeConn := dialSSE(t, server.URL, map[string]string{"Last-Event-ID": "1"})
+	defer closeConn()
+
+	for _, want := range []string{"2", "3"} {
+		frame, err := readSSEFrame(r)
+		if err != nil {
+			t.Fatalf("failed to read replayed frame: %v", err)
+		}
+		if frame.id != want {
+			t.Errorf("replayed id = %q, want %q", frame.id, want)
+		}
+	}
+}
+
+func TestServeSSE_TopicFilter(t *testing.T) {
+	h, server, stop := startSSEHub(t)
+	defer stop()
+
+	r, closeConn := dialSSE(t, server.URL+"?topics=escrow", nil)
+	defer closeConn()
+	time.Sleep(100 * time.Millisecond)
+
+	h.Broadcast(&Event{Type: EventTransaction, Timestamp: time.Now(), Data: map[string]interface{}{"amount": "5.00"}})
+	h.Broadcast(&Event{Type: EventEscrowCreated, Timestamp: time.Now(), Data: map[string]interface{}{"escrowId": "esc_1"}})
+
+	frame, err := readSSEFrame(r)
+	if err != nil {
+		t.Fatalf("failed to read frame: %v", err)
+	}
+	if frame.event != string(EventEscrowCreated) {
+		t.Errorf("received %q, want escrow_created (transaction should be filtered)", frame.event)
+	}
+}
@@ -0,0 +1,192 @@
+package realtime
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Topic-based subscriptions. Instead of replacing the whole filter,
+// clients send control messages to add or remove named topics:
+//
+//	{"action": "subscribe", "topics": ["transactions", "escrow", "agent:0xabc"]}
+//	{"action": "unsubscribe", "topics": ["escrow"]}
+//
+// A client with topics receives events matching ANY of them; static
+// topics group related event types, and "agent:<addr>" matches every
+// event involving that address. Each control message is acknowledged
+// with a subscription_ack event listing the active topics. Messages
+// without an "action" field are treated as legacy full-filter
+// replacements and clear any topic subscriptions.
+//
+// Clients that track the "seq" field on events can recover from a
+// brief disconnect without losing events:
+//
+//	{"action": "replay", "lastSeq": 1041}
+//
+// replays recorded events after that sequence number (newest
+// replayHistorySize events are kept), filtered as usual, followed by a
+// replay_complete event.
+
+// EventSubscriptionAck acknowledges a subscribe/unsubscribe control
+// message; Data carries the active and unknown topics.
+const EventSubscriptionAck EventType = "subscription_ack"
+
+// agentTopicPrefix marks dynamic per-agent topics, e.g. "agent:0xabc".
+const agentTopicPrefix = "agent:"
+
+// maxTopics caps topics per client to prevent unbounded growth.
+const maxTopics = 100
+
+// topicEventTypes maps static topic names to the event types they cover.
+var topicEventTypes = map[string][]EventType{
+	"transactions": {EventTransaction},
+	"commentary":   {EventMilestone, EventAgentJoined},
+	"alerts":       {EventPriceAlert, EventCreditAlert},
+	"coalitions":   {EventCoalition},
+	"sessions":     {EventSessionCreated, EventSessionClosed, EventProxySettlement},
+	"escrow":       {EventEscrowCreated, EventEscrowDelivered, EventEscrowConfirmed, EventEscrowDisputed},
+	"streams":      {EventStreamOpened, EventStreamClosed},
+	"predictions":  {EventPredictionCreated, EventPredictionResolved},
+	"presence":     {EventPresence},
+	"stats":        {EventNetworkStats},
+}
+
+// EventReplayComplete ends a replay; Data carries the number of events
+// replayed and the hub's latest sequence number.
+const EventReplayComplete EventType = "replay_complete"
+
+// controlMessage is a subscribe/unsubscribe/replay request from a client.
+type controlMessage struct {
+	Action  string   `json:"action"`
+	Topics  []string `json:"topics"`
+	LastSeq int64    `json:"lastSeq"`
+}
+
+// validTopic reports whether a topic name is a known static topic or a
+// well-formed agent topic.
+func validTopic(topic string) bool {
+	if strings.HasPrefix(topic, agentTopicPrefix) {
+		return len(topic) > len(agentTopicPrefix)
+	}
+	_, ok := topicEventTypes[topic]
+	return ok
+}
+
+// topicMatches reports whether one topic covers an event.
+func topicMatches(topic string, event *Event) bool {
+	if addr, ok := strings.CutPrefix(topic, agentTopicPrefix); ok {
+		data, ok := event.Data.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		from, _ := data["from"].(string)
+		to, _ := data["to"].(string)
+		author, _ := data["authorAddr"].(string)
+		return addr == from || addr == to || addr == author
+	}
+	for _, t := range topicEventTypes[topic] {
+		if t == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// handleControl applies a subscribe/unsubscribe message and queues an
+// acknowledgement listing the client's active topics.
+func (c *Client) handleControl(msg controlMessage) {
+	var unknown []string
+
+	if msg.Action == "replay" {
+		c.replay(msg.LastSeq)
+		return
+	}
+
+	c.mu.Lock()
+	switch msg.Action {
+	case "subscribe":
+		if c.topics == nil {
+			c.topics = make(map[string]struct{})
+		}
+		for _, topic := range msg.Topics {
+			if !validTopic(topic) {
+				unknown = append(unknown, topic)
+				continue
+			}
+			if len(c.topics) >= maxTopics {
+				break
+			}
+			c.topics[topic] = struct{}{}
+		}
+	case "unsubscribe":
+		for _, topic := range msg.Topics {
+			delete(c.topics, topic)
+		}
+	default:
+		c.mu.Unlock()
+		return
+	}
+	active := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		active = append(active, topic)
+	}
+	c.mu.Unlock()
+	sort.Strings(active)
+
+	ack := c.hub.encodeFor(c, &Event{
+		Type:      EventSubscriptionAck,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"topics":  active,
+			"unknown": unknown,
+		},
+	})
+	// Non-blocking, like broadcast: a slow client loses the ack, not
+	// the connection.
+	select {
+	case c.send <- ack:
+	default:
+	}
+}
+
+// replay queues recorded events after lastSeq that pass the client's
+// filters, then a replay_complete event. Runs on the read pump, so a
+// full send buffer aborts the replay instead of blocking reads.
+func (c *Client) replay(lastSeq int64) {
+	var replayed int
+	for _, ev := range c.hub.historySince(lastSeq) {
+		if !c.hub.shouldSend(c, ev) {
+			continue
+		}
+		select {
+		case c.send <- c.hub.encodeFor(c, ev):
+			replayed++
+		default:
+			return // too slow to catch up; client can retry
+		}
+	}
+
+	done := c.hub.encodeFor(c, &Event{
+		Type:      EventReplayComplete,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"replayed":  replayed,
+			"latestSeq": c.hub.nextSeq.Load(),
+		},
+	})
+	select {
+	case c.send <- done:
+	default:
+	}
+}
+
+// matchesTopics reports whether any subscribed topic covers the event.
+func matchesTopics(topics map[string]struct{}, event *Event) bool {
+	for topic := range topics {
+		if topicMatches(topic, event) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,270 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ---------------------------------------------------------------------------
+// topicMatches and handleControl
+// ---------------------------------------------------------------------------
+
+func TestTopicMatches(t *testing.T) {
+	escrowEvent := &Event{Type: EventEscrowCreated}
+	txEvent := &Event{
+		Type: EventTransaction,
+		Data: map[string]interface{}{"from": "0xabc", "to": "0xdef"},
+	}
+
+	if !topicMatches("escrow", escrowEvent) {
+		t.Error("escrow topic should cover escrow_created")
+	}
+	if topicMatches("escrow", txEvent) {
+		t.Error("escrow topic should not cover transactions")
+	}
+	if !topicMatches("transactions", txEvent) {
+		t.Error("transactions topic should cover transactions")
+	}
+	if !topicMatches("agent:0xabc", txEvent) {
+		t.Error("agent topic should match from address")
+	}
+	if !topicMatches("agent:0xdef", txEvent) {
+		t.Error("agent topic should match to address")
+	}
+	if topicMatches("agent:0xother", txEvent) {
+		t.Error("agent topic should not match unrelated address")
+	}
+	if topicMatches("agent:0xabc", escrowEvent) {
+		t.Error("agent topic should not match events without address data")
+	}
+}
+
+func TestHandleControl_SubscribeUnsubscribe(t *testing.T) {
+	client := &Client{hub: testHub(), send: make(chan []byte, 4)}
+
+	client.handleControl(controlMessage{
+		Action: "subscribe",
+		Topics: []string{"escrow", "agent:0xabc", "nosuch"},
+	})
+
+	var ack Event
+	select {
+	case msg := <-client.send:
+		if err := json.Unmarshal(msg, &ack); err != nil {
+			t.Fatalf("ack not JSON: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no ack received")
+	}
+	if ack.Type != EventSubscriptionAck {
+		t.Errorf("ack type = %s", ack.Type)
+	}
+	data := ack.Data.(map[string]interface{})
+	topics, _ := data["topics"].([]interface{})
+	if len(topics) != 2 {
+		t.Errorf("active topics = %v, want escrow + agent", topics)
+	}
+	unknown, _ := data["unknown"].([]interface{})
+	if len(unknown) != 1 || unknown[0] != "nosuch" {
+		t.Errorf("unknown = %v", unknown)
+	}
+
+	client.handleControl(controlMessage{Action: "unsubscribe", Topics: []string{"escrow"}})
+	<-client.send // drain second ack
+
+	client.mu.RLock()
+	_, hasEscrow := client.topics["escrow"]
+	_, hasAgent := client.topics["agent:0xabc"]
+	client.mu.RUnlock()
+	if hasEscrow || !hasAgent {
+		t.Errorf("topics after unsubscribe: escrow=%v agent=%v", hasEscrow, hasAgent)
+	}
+}
+
+func TestShouldSend_TopicMode(t *testing.T) {
+	h := testHub()
+	client := &Client{hub: h, send: make(chan []byte, 4)}
+	client.handleControl(controlMessage{Action: "subscribe", Topics: []string{"escrow", "agent:0xabc"}})
+	<-client.send // drain ack
+
+	// Topics are OR'd: either the escrow group or any event involving
+	// the agent matches.
+	if !h.shouldSend(client, &Event{Type: EventEscrowDisputed}) {
+		t.Error("escrow event should match escrow topic")
+	}
+	if !h.shouldSend(client, &Event{
+		Type: EventTransaction,
+		Data: map[string]interface{}{"from": "0xabc"},
+	}) {
+		t.Error("agent's transaction should match agent topic")
+	}
+	if h.shouldSend(client, &Event{Type: EventMilestone}) {
+		t.Error("unrelated event should not match")
+	}
+
+	// Unsubscribing from everything silences the client.
+	client.handleControl(controlMessage{Action: "unsubscribe", Topics: []string{"escrow", "agent:0xabc"}})
+	<-client.send
+	if h.shouldSend(client, &Event{Type: EventEscrowDisputed}) {
+		t.Error("client with no topics should receive nothing")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Sequence replay
+// ---------------------------------------------------------------------------
+
+func TestReplay_FiltersAndCompletes(t *testing.T) {
+	h := NewHub(slog.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+
+	// Record a mix of events with no clients connected.
+	h.Broadcast(&Event{Type: EventTransaction, Timestamp: time.Now(), Data: map[string]interface{}{"amount": 5.0}})
+	h.Broadcast(&Event{Type: EventEscrowCreated, Timestamp: time.Now(), Data: map[string]interface{}{"escrowId": "esc_1"}})
+	h.Broadcast(&Event{Type: EventEscrowConfirmed, Timestamp: time.Now(), Data: map[string]interface{}{"escrowId": "esc_1"}})
+	time.Sleep(100 * time.Millisecond)
+
+	client := &Client{hub: h, send: make(chan []byte, 16)}
+	client.handleControl(controlMessage{Action: "subscribe", Topics: []string{"escrow"}})
+	<-client.send // drain ack
+
+	client.handleControl(controlMessage{Action: "replay", LastSeq: 0})
+
+	var types []EventType
+	var last Event
+	for {
+		select {
+		case msg := <-client.send:
+			if err := json.Unmarshal(msg, &last); err != nil {
+				t.Fatalf("replayed event not JSON: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("replay never completed")
+		}
+		if last.Type == EventReplayComplete {
+			break
+		}
+		types = append(types, last.Type)
+	}
+
+	// The transaction fails the topic filter; both escrow events replay
+	// in order.
+	if len(types) != 2 || types[0] != EventEscrowCreated || types[1] != EventEscrowConfirmed {
+		t.Errorf("replayed types = %v, want escrow_created then escrow_confirmed", types)
+	}
+	data := last.Data.(map[string]interface{})
+	if replayed, _ := data["replayed"].(float64); replayed != 2 {
+		t.Errorf("replayed = %v, want 2", data["replayed"])
+	}
+	if latest, _ := data["latestSeq"].(float64); latest != 3 {
+		t.Errorf("latestSeq = %v, want 3", data["latestSeq"])
+	}
+
+	// Replaying from the latest sequence is an empty, immediate replay.
+	client.handleControl(controlMessage{Action: "replay", LastSeq: 3})
+	select {
+	case msg := <-client.send:
+		var done Event
+		if err := json.Unmarshal(msg, &done); err != nil || done.Type != EventReplayComplete {
+			t.Fatalf("expected replay_complete, got %s (err %v)", msg, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no replay_complete for up-to-date client")
+	}
+}
+
+func TestRecordHistory_Bounded(t *testing.T) {
+	h := testHub()
+	for i := 0; i < replayHistorySize+50; i++ {
+		h.recordHistory(&Event{Seq: h.nextSeq.Add(1), Type: EventTransaction})
+	}
+
+	h.histMu.Lock()
+	n := len(h.history)
+	h.histMu.Unlock()
+	if n != replayHistorySize {
+		t.Errorf("history length = %d, want %d", n, replayHistorySize)
+	}
+
+	// The oldest 50 events fell off the ring.
+	if got := h.historySince(0); len(got) != replayHistorySize || got[0].Seq != 51 {
+		t.Errorf("historySince(0) = %d events starting at seq %d, want %d starting at 51",
+			len(got), got[0].Seq, replayHistorySize)
+	}
+	if got := h.historySince(int64(replayHistorySize + 40)); len(got) != 10 {
+		t.Errorf("historySince near tip = %d events, want 10", len(got))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Full WebSocket round trip
+// ---------------------------------------------------------------------------
+
+func TestTopicSubscription_Lifecycle(t *testing.T) {
+	h := NewHub(slog.Default())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go h.Run(ctx)
+	time.Sleep(30 * time.Millisecond)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.HandleWebSocket(w, r)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if resp != nil && resp.Body != nil {
+		defer resp.Body.Close() //nolint:errcheck
+	}
+	if err != nil {
+		t.Fatalf("Failed to dial websocket: %v", err)
+	}
+	defer conn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	sub := `{"action":"subscribe","topics":["escrow"]}`
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(sub)); err != nil {
+		t.Fatalf("Failed to write control message: %v", err)
+	}
+
+	// First message is the acknowledgement.
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read ack: %v", err)
+	}
+	var ack Event
+	if err := json.Unmarshal(msg, &ack); err != nil || ack.Type != EventSubscriptionAck {
+		t.Fatalf("expected subscription_ack, got %s (err %v)", msg, err)
+	}
+
+	// A transaction is filtered out; an escrow event comes through.
+	h.Broadcast(&Event{Type: EventTransaction, Timestamp: time.Now(), Data: map[string]interface{}{"amount": 5.0}})
+	h.Broadcast(&Event{Type: EventEscrowCreated, Timestamp: time.Now(), Data: map[string]interface{}{"escrowId": "esc_1"}})
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, msg, err = conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read event: %v", err)
+	}
+	var event Event
+	if err := json.Unmarshal(msg, &event); err != nil {
+		t.Fatalf("event not JSON: %v", err)
+	}
+	if event.Type != EventEscrowCreated {
+		t.Errorf("received %s, want escrow_created (transaction should be filtered)", event.Type)
+	}
+}
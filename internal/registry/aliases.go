@@ -0,0 +1,123 @@
+package registry
+
+import (
+	"sort"
+	"strings"
+)
+
+// serviceTypeAliases maps common words and abbreviations to canonical
+// service types. It backs both exact filter normalization and the
+// free-text matching in ParseSearchIntent.
+var serviceTypeAliases = map[string]string{
+	"translate":   "translation",
+	"translator":  "translation",
+	"embed":       "embedding",
+	"embeddings":  "embedding",
+	"transcribe":  "audio",
+	"speech":      "audio",
+	"picture":     "image",
+	"photo":       "image",
+	"llm":         "inference",
+	"completion":  "inference",
+	"summarize":   "inference",
+	"review":      "code",
+	"programming": "code",
+	"scrape":      "data",
+	"dataset":     "data",
+}
+
+// CanonicalServiceType resolves a type filter to a canonical service
+// type: exact type names pass through, aliases are translated, and
+// close misspellings ("translaton") are matched by edit distance. The
+// second return reports whether a canonical type was found.
+func CanonicalServiceType(t string) (string, bool) {
+	t = strings.ToLower(strings.TrimSpace(t))
+	if t == "" {
+		return "", false
+	}
+	if IsKnownServiceType(t) {
+		return t, true
+	}
+	if canonical, ok := serviceTypeAliases[t]; ok {
+		return canonical, true
+	}
+
+	// Tolerate small typos against the known types. The threshold scales
+	// with length so short types like "code" don't match loosely.
+	best, bestDist := "", typoThreshold(t)+1
+	for _, known := range KnownServiceTypes {
+		if d := levenshtein(t, known); d < bestDist {
+			best, bestDist = known, d
+		}
+	}
+	if best != "" {
+		return best, true
+	}
+	return "", false
+}
+
+// SuggestServiceTypes returns the known types closest to an
+// unrecognized filter, nearest first, for "did you mean" hints.
+func SuggestServiceTypes(t string) []string {
+	t = strings.ToLower(strings.TrimSpace(t))
+	if t == "" {
+		return nil
+	}
+
+	type candidate struct {
+		name string
+		dist int
+	}
+	var candidates []candidate
+	for _, known := range KnownServiceTypes {
+		if d := levenshtein(t, known); d <= len(known)/2 {
+			candidates = append(candidates, candidate{known, d})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	suggestions := make([]string, 0, 3)
+	for _, c := range candidates {
+		if len(suggestions) == 3 {
+			break
+		}
+		suggestions = append(suggestions, c.name)
+	}
+	return suggestions
+}
+
+// typoThreshold is the maximum edit distance treated as a typo rather
+// than a different word.
+func typoThreshold(t string) int {
+	if len(t) <= 4 {
+		return 1
+	}
+	return 2
+}
+
+// levenshtein computes the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, min(curr[j-1]+1, prev[j-1]+cost))
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
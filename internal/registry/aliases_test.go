@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalServiceType(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  string
+		found bool
+	}{
+		{"translation", "translation", true}, // exact
+		{"  Inference ", "inference", true},  // case and whitespace
+		{"llm", "inference", true},           // alias
+		{"translate", "translation", true},   // alias
+		{"translaton", "translation", true},  // typo
+		{"embeding", "embedding", true},      // typo
+		{"cod", "code", true},                // short typo within threshold 1
+		{"xyz", "", false},                   // nothing close
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, found := CanonicalServiceType(tt.in)
+		assert.Equal(t, tt.found, found, "input %q", tt.in)
+		assert.Equal(t, tt.want, got, "input %q", tt.in)
+	}
+}
+
+func TestSuggestServiceTypes(t *testing.T) {
+	suggestions := SuggestServiceTypes("translaton")
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, "translation", suggestions[0])
+
+	assert.Empty(t, SuggestServiceTypes("zzzzzzzzzzzz"))
+	assert.Empty(t, SuggestServiceTypes(""))
+}
+
+func TestListServicesResolvesAliases(t *testing.T) {
+	store := NewMemoryStore()
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Lingua", []Service{
+		{Type: "translation", Name: "Translate", Price: "0.01"},
+	})
+
+	for _, typ := range []string{"llm", "translate", "translaton"} {
+		services, err := store.ListServices(context.Background(), AgentQuery{ServiceType: typ})
+		require.NoError(t, err)
+		if typ == "llm" {
+			assert.Empty(t, services, "llm resolves to inference, not translation")
+		} else {
+			require.Len(t, services, 1, "type %q", typ)
+			assert.Equal(t, "Translate", services[0].Name)
+		}
+	}
+}
+
+func TestDiscoverServicesDidYouMean(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Lingua", []Service{
+		{Type: "translation", Name: "Translate", Price: "0.01"},
+	})
+
+	// A recoverable typo is resolved and finds results.
+	code, body := doDiscover(t, h, "type=translaton")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Services, 1)
+
+	// An unresolvable filter returns suggestions instead of results.
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/services", h.DiscoverServices)
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/services?type=transltn", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Count      int      `json:"count"`
+		DidYouMean []string `json:"didYouMean"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Zero(t, resp.Count)
+	assert.Contains(t, resp.DidYouMean, "translation")
+}
@@ -0,0 +1,183 @@
+package registry
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAnalyticsQueries caps how many distinct queries are tracked.
+// When full, the least-seen query is dropped to admit a new one.
+const maxAnalyticsQueries = 1000
+
+// QueryStat is the aggregate record for one normalized search query.
+// Queries are stored anonymized: only the query text and counts, never
+// who searched.
+type QueryStat struct {
+	Query      string    `json:"query"`
+	Count      int64     `json:"count"`       // Times searched
+	ZeroResult int64     `json:"zeroResults"` // Searches that found nothing
+	Selections int64     `json:"selections"`  // Click-throughs to a service
+	LastSeen   time.Time `json:"lastSeen"`
+}
+
+// SearchAnalytics aggregates search queries in memory so operators can
+// see what agents look for and where the marketplace has no supply.
+type SearchAnalytics struct {
+	mu      sync.Mutex
+	queries map[string]*QueryStat
+}
+
+// NewSearchAnalytics creates an empty analytics collector.
+func NewSearchAnalytics() *SearchAnalytics {
+	return &SearchAnalytics{queries: make(map[string]*QueryStat)}
+}
+
+// normalizeQuery collapses case and whitespace so "Cheap LLM " and
+// "cheap llm" aggregate together.
+func normalizeQuery(q string) string {
+	return strings.Join(strings.Fields(strings.ToLower(q)), " ")
+}
+
+// RecordQuery records one search and how many results it returned.
+func (a *SearchAnalytics) RecordQuery(q string, resultCount int) {
+	key := normalizeQuery(q)
+	if key == "" {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stat := a.stat(key)
+	if stat == nil {
+		return
+	}
+	stat.Count++
+	if resultCount == 0 {
+		stat.ZeroResult++
+	}
+	stat.LastSeen = time.Now()
+}
+
+// RecordSelection records that a searcher picked a service from the
+// results of a query (click-through).
+func (a *SearchAnalytics) RecordSelection(q string) {
+	key := normalizeQuery(q)
+	if key == "" {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stat := a.stat(key)
+	if stat == nil {
+		return
+	}
+	stat.Selections++
+	stat.LastSeen = time.Now()
+}
+
+// stat returns the entry for key, creating it (and evicting the
+// least-seen query if at capacity) as needed. Caller holds the lock.
+func (a *SearchAnalytics) stat(key string) *QueryStat {
+	if stat, ok := a.queries[key]; ok {
+		return stat
+	}
+	if len(a.queries) >= maxAnalyticsQueries {
+		var evict string
+		var evictCount int64 = -1
+		for k, s := range a.queries {
+			if evictCount == -1 || s.Count < evictCount {
+				evict, evictCount = k, s.Count
+			}
+		}
+		delete(a.queries, evict)
+	}
+	stat := &QueryStat{Query: key}
+	a.queries[key] = stat
+	return stat
+}
+
+// TopQueries returns the most-searched queries, busiest first.
+func (a *SearchAnalytics) TopQueries(n int) []QueryStat {
+	return a.top(n, func(s *QueryStat) int64 { return s.Count })
+}
+
+// ZeroResultQueries returns queries that most often found nothing —
+// the demand the marketplace is not supplying.
+func (a *SearchAnalytics) ZeroResultQueries(n int) []QueryStat {
+	return a.top(n, func(s *QueryStat) int64 { return s.ZeroResult })
+}
+
+func (a *SearchAnalytics) top(n int, metric func(*QueryStat) int64) []QueryStat {
+	a.mu.Lock()
+	stats := make([]QueryStat, 0, len(a.queries))
+	for _, s := range a.queries {
+		if metric(s) > 0 {
+			stats = append(stats, *s)
+		}
+	}
+	a.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if mi, mj := metric(&stats[i]), metric(&stats[j]); mi != mj {
+			return mi > mj
+		}
+		return stats[i].Query < stats[j].Query
+	})
+	if n > 0 && len(stats) > n {
+		stats = stats[:n]
+	}
+	return stats
+}
+
+// SetAnalytics attaches a search analytics collector. Without one,
+// Search and SelectSearchResult record nothing.
+func (h *Handler) SetAnalytics(a *SearchAnalytics) {
+	h.analytics = a
+}
+
+// SelectSearchResult handles POST /search/select — clients report which
+// service they picked after a search so operators can see click-through.
+func (h *Handler) SelectSearchResult(c *gin.Context) {
+	var req struct {
+		Query     string `json:"query" binding:"required"`
+		ServiceID string `json:"serviceId"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "query is required",
+		})
+		return
+	}
+
+	if h.analytics != nil {
+		h.analytics.RecordSelection(req.Query)
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SearchAnalyticsReport handles GET /admin/search/analytics — top
+// queries and zero-result queries for marketplace operators.
+func (h *Handler) SearchAnalyticsReport(c *gin.Context) {
+	if h.analytics == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "analytics_disabled",
+			"message": "Search analytics is not enabled",
+		})
+		return
+	}
+
+	limit := parseIntQuery(c, "limit", 20)
+	c.JSON(http.StatusOK, gin.H{
+		"topQueries":        h.analytics.TopQueries(limit),
+		"zeroResultQueries": h.analytics.ZeroResultQueries(limit),
+	})
+}
@@ -0,0 +1,133 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchAnalyticsAggregates(t *testing.T) {
+	a := NewSearchAnalytics()
+
+	a.RecordQuery("cheap translation", 3)
+	a.RecordQuery("Cheap  Translation ", 2) // normalizes to the same key
+	a.RecordQuery("quantum compute", 0)
+	a.RecordQuery("quantum compute", 0)
+	a.RecordSelection("cheap translation")
+
+	top := a.TopQueries(10)
+	require.Len(t, top, 2)
+	assert.Equal(t, "cheap translation", top[0].Query)
+	assert.Equal(t, int64(2), top[0].Count)
+	assert.Equal(t, int64(1), top[0].Selections)
+
+	zero := a.ZeroResultQueries(10)
+	require.Len(t, zero, 1)
+	assert.Equal(t, "quantum compute", zero[0].Query)
+	assert.Equal(t, int64(2), zero[0].ZeroResult)
+}
+
+func TestSearchAnalyticsEvictsLeastSeen(t *testing.T) {
+	a := NewSearchAnalytics()
+
+	a.RecordQuery("popular", 1)
+	a.RecordQuery("popular", 1)
+	for i := 0; len(a.queries) < maxAnalyticsQueries; i++ {
+		a.RecordQuery(fmt.Sprintf("filler %d", i), 1)
+	}
+
+	a.RecordQuery("newcomer", 1)
+	assert.Len(t, a.queries, maxAnalyticsQueries)
+	_, ok := a.queries["popular"]
+	assert.True(t, ok, "most-seen query survives eviction")
+	_, ok = a.queries["newcomer"]
+	assert.True(t, ok)
+}
+
+func TestSearchRecordsAnalytics(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+	a := NewSearchAnalytics()
+	h.SetAnalytics(a)
+
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Lingua", []Service{
+		{Type: "translation", Name: "Translate", Price: "0.01"},
+	})
+
+	code, _ := doSearch(t, h, "q=cheap+translation")
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doSearch(t, h, "q=quantum+compute")
+	require.Equal(t, http.StatusOK, code)
+
+	top := a.TopQueries(10)
+	require.Len(t, top, 2)
+	zero := a.ZeroResultQueries(10)
+	require.Len(t, zero, 1)
+	assert.Equal(t, "quantum compute", zero[0].Query)
+}
+
+func TestSelectSearchResult(t *testing.T) {
+	h := NewHandler(NewMemoryStore())
+	a := NewSearchAnalytics()
+	h.SetAnalytics(a)
+	a.RecordQuery("cheap translation", 1)
+
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.POST("/search/select", h.SelectSearchResult)
+	req := httptest.NewRequest("POST", "/search/select",
+		strings.NewReader(`{"query":"cheap translation","serviceId":"svc_1"}`))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	top := a.TopQueries(10)
+	require.Len(t, top, 1)
+	assert.Equal(t, int64(1), top[0].Selections)
+
+	// Missing query is rejected.
+	w = httptest.NewRecorder()
+	_, r = gin.CreateTestContext(w)
+	r.POST("/search/select", h.SelectSearchResult)
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/search/select", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSearchAnalyticsReport(t *testing.T) {
+	h := NewHandler(NewMemoryStore())
+	a := NewSearchAnalytics()
+	h.SetAnalytics(a)
+	a.RecordQuery("cheap translation", 1)
+	a.RecordQuery("quantum compute", 0)
+
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/admin/search/analytics", h.SearchAnalyticsReport)
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/admin/search/analytics?limit=5", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		TopQueries        []QueryStat `json:"topQueries"`
+		ZeroResultQueries []QueryStat `json:"zeroResultQueries"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.TopQueries, 2)
+	require.Len(t, resp.ZeroResultQueries, 1)
+	assert.Equal(t, "quantum compute", resp.ZeroResultQueries[0].Query)
+}
+
+func TestSearchAnalyticsReportDisabled(t *testing.T) {
+	h := NewHandler(NewMemoryStore())
+
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/admin/search/analytics", h.SearchAnalyticsReport)
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/admin/search/analytics", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,193 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultListCacheTTL bounds how stale a cached service listing may get
+// on multi-node deployments, where another node's writes do not pass
+// through this process's invalidation hooks.
+const DefaultListCacheTTL = 30 * time.Second
+
+// maxListCacheEntries bounds the number of distinct queries cached.
+const maxListCacheEntries = 512
+
+// CachedStore decorates a Store with a per-query cache for ListServices,
+// which otherwise re-scans up to 1000 services and recomputes scores on
+// every search request. Entries are keyed by the normalized query and
+// dropped whenever an agent or service is created, updated, or deleted.
+type CachedStore struct {
+	Store
+
+	ttl time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]listCacheEntry
+}
+
+type listCacheEntry struct {
+	listings  []ServiceListing
+	expiresAt time.Time
+}
+
+// NewCachedStore wraps a store with ListServices caching. A zero ttl
+// selects DefaultListCacheTTL.
+func NewCachedStore(store Store, ttl time.Duration) *CachedStore {
+	if ttl <= 0 {
+		ttl = DefaultListCacheTTL
+	}
+	return &CachedStore{
+		Store:   store,
+		ttl:     ttl,
+		entries: make(map[string]listCacheEntry),
+	}
+}
+
+// Compile-time interface check
+var _ Store = (*CachedStore)(nil)
+
+// ListServices serves cached results for repeated queries, falling
+// through to the underlying store on misses. Callers get a copy of the
+// cached slice, so in-place enrichment and filtering by handlers cannot
+// corrupt the cache.
+func (c *CachedStore) ListServices(ctx context.Context, query AgentQuery) ([]ServiceListing, error) {
+	key := listCacheKey(query)
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return copyListings(entry.listings), nil
+	}
+
+	listings, err := c.Store.ListServices(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if len(c.entries) >= maxListCacheEntries {
+		c.evictLocked()
+	}
+	c.entries[key] = listCacheEntry{
+		listings:  copyListings(listings),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	c.mu.Unlock()
+
+	return listings, nil
+}
+
+// Invalidate drops all cached listings. Exposed for callers that change
+// service visibility through side channels (e.g. bulk imports).
+func (c *CachedStore) Invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[string]listCacheEntry)
+	c.mu.Unlock()
+}
+
+// evictLocked drops expired entries, or everything if none have expired.
+// Callers must hold c.mu.
+func (c *CachedStore) evictLocked() {
+	now := time.Now()
+	evicted := false
+	for k, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, k)
+			evicted = true
+		}
+	}
+	if !evicted {
+		c.entries = make(map[string]listCacheEntry)
+	}
+}
+
+// --- Write-through invalidation ---
+
+func (c *CachedStore) CreateAgent(ctx context.Context, agent *Agent) error {
+	if err := c.Store.CreateAgent(ctx, agent); err != nil {
+		return err
+	}
+	c.Invalidate()
+	return nil
+}
+
+func (c *CachedStore) UpdateAgent(ctx context.Context, agent *Agent) error {
+	if err := c.Store.UpdateAgent(ctx, agent); err != nil {
+		return err
+	}
+	c.Invalidate()
+	return nil
+}
+
+func (c *CachedStore) DeleteAgent(ctx context.Context, address string) error {
+	if err := c.Store.DeleteAgent(ctx, address); err != nil {
+		return err
+	}
+	c.Invalidate()
+	return nil
+}
+
+func (c *CachedStore) AddService(ctx context.Context, agentAddress string, service *Service) error {
+	if err := c.Store.AddService(ctx, agentAddress, service); err != nil {
+		return err
+	}
+	c.Invalidate()
+	return nil
+}
+
+func (c *CachedStore) UpdateService(ctx context.Context, agentAddress string, service *Service) error {
+	if err := c.Store.UpdateService(ctx, agentAddress, service); err != nil {
+		return err
+	}
+	c.Invalidate()
+	return nil
+}
+
+func (c *CachedStore) RemoveService(ctx context.Context, agentAddress, serviceID string) error {
+	if err := c.Store.RemoveService(ctx, agentAddress, serviceID); err != nil {
+		return err
+	}
+	c.Invalidate()
+	return nil
+}
+
+// listCacheKey normalizes a query into a cache key. Limits are clamped
+// to the same bounds the stores apply so equivalent queries share an
+// entry.
+func listCacheKey(query AgentQuery) string {
+	limit := query.Limit
+	if limit == 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	active := "nil"
+	if query.Active != nil {
+		active = fmt.Sprintf("%t", *query.Active)
+	}
+	return strings.Join([]string{
+		strings.ToLower(query.ServiceType),
+		query.MinPrice,
+		query.MaxPrice,
+		active,
+		fmt.Sprintf("%d:%d", limit, offset),
+	}, "|")
+}
+
+// copyListings shallow-copies a result slice so handlers can enrich and
+// filter in place without mutating the cached entry.
+func copyListings(listings []ServiceListing) []ServiceListing {
+	out := make([]ServiceListing, len(listings))
+	copy(out, listings)
+	return out
+}
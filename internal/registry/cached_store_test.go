@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingListStore counts ListServices calls against the wrapped store.
+type countingListStore struct {
+	Store
+	listCalls int
+}
+
+func (s *countingListStore) ListServices(ctx context.Context, query AgentQuery) ([]ServiceListing, error) {
+	s.listCalls++
+	return s.Store.ListServices(ctx, query)
+}
+
+func newCachedTestStore(t *testing.T, ttl time.Duration) (*CachedStore, *countingListStore) {
+	t.Helper()
+	inner := &countingListStore{Store: NewMemoryStore()}
+	cached := NewCachedStore(inner, ttl)
+
+	ctx := context.Background()
+	addr := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	require.NoError(t, cached.CreateAgent(ctx, &Agent{Address: addr, Name: "Lingua"}))
+	require.NoError(t, cached.AddService(ctx, addr, &Service{
+		Type: "translation", Name: "Translate", Price: "0.01", Active: true,
+	}))
+	return cached, inner
+}
+
+func TestCachedStore_ServesRepeatedQueriesFromCache(t *testing.T) {
+	cached, inner := newCachedTestStore(t, time.Minute)
+	ctx := context.Background()
+
+	query := AgentQuery{ServiceType: "translation"}
+	first, err := cached.ListServices(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Equal(t, 1, inner.listCalls)
+
+	second, err := cached.ListServices(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, inner.listCalls, "second identical query must be served from cache")
+
+	// A different query misses the cache.
+	_, err = cached.ListServices(ctx, AgentQuery{ServiceType: "code"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.listCalls)
+}
+
+func TestCachedStore_NormalizesEquivalentQueries(t *testing.T) {
+	cached, inner := newCachedTestStore(t, time.Minute)
+	ctx := context.Background()
+
+	_, err := cached.ListServices(ctx, AgentQuery{ServiceType: "Translation", Limit: 0})
+	require.NoError(t, err)
+	_, err = cached.ListServices(ctx, AgentQuery{ServiceType: "translation", Limit: 100})
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.listCalls, "default and explicit limit 100 share an entry")
+}
+
+func TestCachedStore_InvalidatesOnServiceWrites(t *testing.T) {
+	cached, inner := newCachedTestStore(t, time.Minute)
+	ctx := context.Background()
+
+	query := AgentQuery{ServiceType: "translation"}
+	_, err := cached.ListServices(ctx, query)
+	require.NoError(t, err)
+
+	err = cached.AddService(ctx, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", &Service{
+		Type: "translation", Name: "Translate Pro", Price: "0.02", Active: true,
+	})
+	require.NoError(t, err)
+
+	listings, err := cached.ListServices(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.listCalls, "write must drop the cached entry")
+	assert.Len(t, listings, 2)
+
+	require.NoError(t, cached.RemoveService(ctx, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", listings[0].ID))
+	listings, err = cached.ListServices(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.listCalls)
+	assert.Len(t, listings, 1)
+}
+
+func TestCachedStore_ExpiredEntriesRefresh(t *testing.T) {
+	cached, inner := newCachedTestStore(t, time.Millisecond)
+	ctx := context.Background()
+
+	query := AgentQuery{ServiceType: "translation"}
+	_, err := cached.ListServices(ctx, query)
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = cached.ListServices(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.listCalls, "expired entry must fall through to the store")
+}
+
+func TestCachedStore_CallersCannotCorruptCache(t *testing.T) {
+	cached, _ := newCachedTestStore(t, time.Minute)
+	ctx := context.Background()
+
+	query := AgentQuery{ServiceType: "translation"}
+	first, err := cached.ListServices(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	// Handlers enrich and filter results in place.
+	first[0].ReputationScore = 99
+	first[0].AgentName = "Mutated"
+
+	second, err := cached.ListServices(ctx, query)
+	require.NoError(t, err)
+	assert.Equal(t, "Lingua", second[0].AgentName)
+	assert.Zero(t, second[0].ReputationScore)
+}
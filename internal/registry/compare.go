@@ -0,0 +1,207 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// maxCompareIDs caps how many services one comparison can cover.
+const maxCompareIDs = 10
+
+// DisputeRateProvider supplies per-seller dispute rates for the
+// comparison view. Defined here so registry doesn't depend on the
+// escrow package; the server wires an adapter.
+type DisputeRateProvider interface {
+	// GetDisputeRate returns the fraction of a seller's escrows that
+	// ended in dispute, and whether any history exists.
+	GetDisputeRate(ctx context.Context, agentAddr string) (float64, bool)
+}
+
+// ServiceComparison is one row of the side-by-side comparison.
+type ServiceComparison struct {
+	ServiceID      string  `json:"serviceId"`
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	Description    string  `json:"description,omitempty"`
+	AgentAddress   string  `json:"agentAddress"`
+	AgentName      string  `json:"agentName"`
+	Price          string  `json:"price"`
+	Active         bool    `json:"active"`
+	ReputationTier string  `json:"reputationTier"`
+	Reputation     float64 `json:"reputationScore"`
+	SuccessRate    float64 `json:"successRate"`
+	TxCount        int64   `json:"transactionCount"`
+	Verified       bool    `json:"verified"`
+	P95LatencyMs   int64   `json:"p95LatencyMs,omitempty"`
+	DisputeRate    float64 `json:"disputeRate"`
+	HasDisputeData bool    `json:"hasDisputeData"`
+
+	// SLA reflects the seller's performance guarantee, if verified.
+	SLA *ServiceSLA `json:"sla,omitempty"`
+}
+
+// ServiceSLA summarizes the guarantee terms backing a verified service.
+type ServiceSLA struct {
+	GuaranteedSuccessRate float64 `json:"guaranteedSuccessRate"`
+	PremiumRate           float64 `json:"premiumRate"`
+}
+
+// SetDisputeRates attaches a dispute-rate provider for comparisons.
+func (h *Handler) SetDisputeRates(p DisputeRateProvider) {
+	h.disputes = p
+}
+
+// CompareServices handles GET /services/compare?ids=a,b,c — a
+// normalized side-by-side view of shortlisted services so buyers can
+// weigh price, reputation, latency, disputes, and SLA terms before
+// committing.
+func (h *Handler) CompareServices(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	raw := strings.TrimSpace(c.Query("ids"))
+	if raw == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "missing_ids",
+			"message": "ids query parameter is required (comma-separated service IDs)",
+		})
+		return
+	}
+
+	ids := make([]string, 0, maxCompareIDs+1)
+	seen := make(map[string]bool)
+	for _, id := range strings.Split(raw, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 || len(ids) > maxCompareIDs {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_ids",
+			"message": "Provide between 1 and 10 service IDs",
+		})
+		return
+	}
+
+	// One listing pass covers all requested services; inactive ones
+	// are included so a shortlisted service that just went dark still
+	// shows up rather than silently vanishing.
+	listings, err := h.store.ListServices(ctx, AgentQuery{Limit: 1000})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to list services",
+		})
+		return
+	}
+
+	matched := make([]ServiceListing, 0, len(ids))
+	for _, l := range listings {
+		if seen[l.Service.ID] {
+			matched = append(matched, l)
+		}
+	}
+	h.enrichWithReputation(ctx, matched)
+	h.enrichWithVerification(ctx, matched)
+
+	byID := make(map[string]*ServiceListing, len(matched))
+	for i := range matched {
+		byID[matched[i].Service.ID] = &matched[i]
+	}
+
+	comparisons := make([]ServiceComparison, 0, len(ids))
+	var notFound []string
+	for _, id := range ids {
+		l, ok := byID[id]
+		if !ok {
+			notFound = append(notFound, id)
+			continue
+		}
+
+		entry := ServiceComparison{
+			ServiceID:      l.Service.ID,
+			Name:           l.Name,
+			Type:           l.Type,
+			Description:    l.Description,
+			AgentAddress:   l.AgentAddress,
+			AgentName:      l.AgentName,
+			Price:          l.Price,
+			Active:         l.Active,
+			ReputationTier: l.ReputationTier,
+			Reputation:     l.ReputationScore,
+			SuccessRate:    l.SuccessRate,
+			TxCount:        l.TxCount,
+			Verified:       l.Verified,
+		}
+		if h.latency != nil {
+			if p95, ok := h.latency.GetP95Latency(ctx, l.AgentAddress); ok {
+				entry.P95LatencyMs = p95.Milliseconds()
+			}
+		}
+		if h.disputes != nil {
+			if rate, ok := h.disputes.GetDisputeRate(ctx, l.AgentAddress); ok {
+				entry.DisputeRate = rate
+				entry.HasDisputeData = true
+			}
+		}
+		if l.Verified && l.GuaranteedSuccessRate > 0 {
+			entry.SLA = &ServiceSLA{
+				GuaranteedSuccessRate: l.GuaranteedSuccessRate,
+				PremiumRate:           l.GuaranteePremiumRate,
+			}
+		}
+		comparisons = append(comparisons, entry)
+	}
+
+	resp := gin.H{
+		"services": comparisons,
+		"count":    len(comparisons),
+	}
+	if len(notFound) > 0 {
+		resp["notFound"] = notFound
+	}
+	if summary := compareSummary(comparisons); len(summary) > 0 {
+		resp["summary"] = summary
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// compareSummary highlights the standouts so buyer agents don't have
+// to re-derive them client-side.
+func compareSummary(entries []ServiceComparison) gin.H {
+	if len(entries) < 2 {
+		return nil
+	}
+
+	cheapest, bestRep := &entries[0], &entries[0]
+	for i := range entries[1:] {
+		e := &entries[i+1]
+		if priceLess(e.Price, cheapest.Price) {
+			cheapest = e
+		}
+		if e.Reputation > bestRep.Reputation {
+			bestRep = e
+		}
+	}
+	return gin.H{
+		"cheapest":          cheapest.ServiceID,
+		"highestReputation": bestRep.ServiceID,
+	}
+}
+
+// priceLess compares two USDC price strings; unparseable prices never
+// win the "cheapest" slot.
+func priceLess(a, b string) bool {
+	aAmount, aOK := usdc.Parse(a)
+	bAmount, bOK := usdc.Parse(b)
+	if !aOK || !bOK {
+		return false
+	}
+	return aAmount.Cmp(bAmount) < 0
+}
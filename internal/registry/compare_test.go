@@ -0,0 +1,119 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDisputeProvider is a test double for DisputeRateProvider.
+type stubDisputeProvider struct {
+	rates map[string]float64
+}
+
+func (s *stubDisputeProvider) GetDisputeRate(_ context.Context, agentAddr string) (float64, bool) {
+	rate, ok := s.rates[agentAddr]
+	return rate, ok
+}
+
+type compareResponse struct {
+	Services []ServiceComparison `json:"services"`
+	Count    int                 `json:"count"`
+	NotFound []string            `json:"notFound"`
+	Summary  struct {
+		Cheapest          string `json:"cheapest"`
+		HighestReputation string `json:"highestReputation"`
+	} `json:"summary"`
+}
+
+func doCompare(t *testing.T, h *Handler, query string) (int, compareResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/services/compare", h.CompareServices)
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/services/compare?"+query, nil))
+
+	var body compareResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	}
+	return w.Code, body
+}
+
+func seedCompareAgents(store *MemoryStore) {
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "CheapBot", []Service{
+		{ID: "svc_cheap", Type: "translation", Name: "Budget Translate", Price: "0.01"},
+	})
+	seedAgent(store, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "EliteBot", []Service{
+		{ID: "svc_elite", Type: "translation", Name: "Premium Translate", Price: "0.10"},
+	})
+}
+
+func TestCompareServices_MissingIDs(t *testing.T) {
+	h := NewHandler(NewMemoryStore())
+
+	code, _ := doCompare(t, h, "")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doCompare(t, h, "ids=,,")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestCompareServices_SideBySide(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+	seedCompareAgents(store)
+
+	h.SetReputation(&stubRepProvider{scores: map[string]struct {
+		score float64
+		tier  string
+	}{
+		"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": {20, "new"},
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb": {90, "elite"},
+	}})
+	h.SetLatency(&stubLatencyProvider{p95: map[string]time.Duration{
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb": 150 * time.Millisecond,
+	}})
+	h.SetDisputeRates(&stubDisputeProvider{rates: map[string]float64{
+		"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": 0.25,
+	}})
+
+	code, body := doCompare(t, h, "ids=svc_cheap,svc_elite")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 2, body.Count)
+
+	// Rows come back in request order.
+	cheap, elite := body.Services[0], body.Services[1]
+	assert.Equal(t, "svc_cheap", cheap.ServiceID)
+	assert.Equal(t, "CheapBot", cheap.AgentName)
+	assert.Equal(t, 20.0, cheap.Reputation)
+	assert.Equal(t, 0.25, cheap.DisputeRate)
+	assert.True(t, cheap.HasDisputeData)
+	assert.Zero(t, cheap.P95LatencyMs)
+
+	assert.Equal(t, "svc_elite", elite.ServiceID)
+	assert.Equal(t, "elite", elite.ReputationTier)
+	assert.Equal(t, int64(150), elite.P95LatencyMs)
+	assert.False(t, elite.HasDisputeData)
+
+	assert.Equal(t, "svc_cheap", body.Summary.Cheapest)
+	assert.Equal(t, "svc_elite", body.Summary.HighestReputation)
+}
+
+func TestCompareServices_UnknownIDsReported(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+	seedCompareAgents(store)
+
+	code, body := doCompare(t, h, "ids=svc_cheap,svc_missing")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, body.Count)
+	assert.Equal(t, []string{"svc_missing"}, body.NotFound)
+}
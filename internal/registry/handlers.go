@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mbd888/alancoin/internal/llm"
 	"github.com/mbd888/alancoin/internal/logging"
 	"github.com/mbd888/alancoin/internal/metrics"
 	"github.com/mbd888/alancoin/internal/security"
@@ -31,10 +32,16 @@ type VerificationProvider interface {
 // Handler provides HTTP handlers for the registry API
 type Handler struct {
 	store               Store
-	verifier            TxVerifier           // optional on-chain verifier
-	reputation          ReputationProvider   // optional reputation enrichment
-	verification        VerificationProvider // optional verified agent enrichment
-	allowLocalEndpoints bool                 // skip SSRF check for localhost (demo/dev mode)
+	verifier            TxVerifier              // optional on-chain verifier
+	reputation          ReputationProvider      // optional reputation enrichment
+	verification        VerificationProvider    // optional verified agent enrichment
+	recommender         llm.Provider            // optional LLM for /search recommendations
+	latency             LatencyProvider         // optional latency data for weighted ranking
+	history             PurchaseHistoryProvider // optional purchase history for personalized search
+	analytics           *SearchAnalytics        // optional search analytics for operators
+	savedSearches       *SavedSearches          // optional saved searches with match alerts
+	disputes            DisputeRateProvider     // optional dispute rates for comparisons
+	allowLocalEndpoints bool                    // skip SSRF check for localhost (demo/dev mode)
 }
 
 // NewHandler creates a new registry handler
@@ -73,6 +80,16 @@ func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 
 	// Discovery
 	r.GET("/services", h.DiscoverServices)
+	r.GET("/services/compare", h.CompareServices)
+
+	// Natural-language search with recommendation text
+	r.GET("/search", h.Search)
+	r.POST("/search/select", h.SelectSearchResult)
+
+	// Saved searches with match alerts
+	r.POST("/agents/:address/saved-searches", h.CreateSavedSearch)
+	r.GET("/agents/:address/saved-searches", h.ListSavedSearches)
+	r.DELETE("/agents/:address/saved-searches/:searchId", h.DeleteSavedSearch)
 
 	// Transactions
 	r.GET("/agents/:address/transactions", h.ListTransactions)
@@ -450,6 +467,9 @@ func (h *Handler) AddService(c *gin.Context) {
 		"type", service.Type,
 	)
 
+	// Alert agents whose saved searches this new service satisfies
+	h.savedSearches.NotifyMatches(address, service)
+
 	c.JSON(http.StatusCreated, service)
 }
 
@@ -515,6 +535,9 @@ func (h *Handler) UpdateService(c *gin.Context) {
 		return
 	}
 
+	// A reprice can bring a service under a saved search's cap
+	h.savedSearches.NotifyMatches(address, &service)
+
 	c.JSON(http.StatusOK, service)
 }
 
@@ -565,6 +588,12 @@ func (h *Handler) DiscoverServices(c *gin.Context) {
 		Offset:      parseIntQuery(c, "offset", 0),
 	}
 
+	// Resolve aliases and typos ("llm", "translaton") so the echoed
+	// query shows the canonical type actually filtered on
+	if canonical, ok := CanonicalServiceType(query.ServiceType); ok {
+		query.ServiceType = canonical
+	}
+
 	// Default to active services only
 	active := c.Query("includeInactive") != "true"
 	query.Active = &active
@@ -598,19 +627,42 @@ func (h *Handler) DiscoverServices(c *gin.Context) {
 
 	// Sort by requested strategy
 	sortBy := c.DefaultQuery("sortBy", "price")
-	h.sortServices(services, sortBy)
+	queryEcho := gin.H{
+		"type":         query.ServiceType,
+		"minPrice":     query.MinPrice,
+		"maxPrice":     query.MaxPrice,
+		"sortBy":       sortBy,
+		"verifiedOnly": verifiedOnly,
+	}
+	if sortBy == "weighted" {
+		weights, err := parseRankWeights(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_weights",
+				"message": err.Error(),
+			})
+			return
+		}
+		h.rankWeighted(ctx, services, weights)
+		queryEcho["weights"] = weights
+	} else {
+		h.sortServices(services, sortBy)
+	}
 
-	c.JSON(http.StatusOK, gin.H{
+	resp := gin.H{
 		"services": services,
 		"count":    len(services),
-		"query": gin.H{
-			"type":         query.ServiceType,
-			"minPrice":     query.MinPrice,
-			"maxPrice":     query.MaxPrice,
-			"sortBy":       sortBy,
-			"verifiedOnly": verifiedOnly,
-		},
-	})
+		"query":    queryEcho,
+	}
+
+	// Suggest close type names when an unrecognized filter found nothing
+	if rawType := c.Query("type"); len(services) == 0 && rawType != "" && !IsKnownServiceType(query.ServiceType) {
+		if suggestions := SuggestServiceTypes(rawType); len(suggestions) > 0 {
+			resp["didYouMean"] = suggestions
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
 // enrichWithReputation adds reputation data to service listings.
@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SearchIntent is the structured interpretation of a natural-language
+// search query. It is returned in the /search response so clients can
+// confirm what was understood and replay the same filters explicitly
+// (type=, minPrice=, maxPrice=, sortBy=, verifiedOnly=) on subsequent
+// paginated requests instead of re-parsing the prose each page.
+type SearchIntent struct {
+	ServiceType  string `json:"type,omitempty"`
+	MinPrice     string `json:"minPrice,omitempty"`
+	MaxPrice     string `json:"maxPrice,omitempty"`
+	SortBy       string `json:"sortBy"`
+	VerifiedOnly bool   `json:"verifiedOnly,omitempty"`
+}
+
+// maxPriceRe matches "under $0.05", "below 0.01", "less than $1" etc.
+var maxPriceRe = regexp.MustCompile(`(?i)(?:under|below|less than|at most|cheaper than|max)\s*\$?\s*(\d+(?:\.\d+)?)`)
+
+// minPriceRe matches "over $0.05", "above 0.01", "at least $1" etc.
+var minPriceRe = regexp.MustCompile(`(?i)(?:over|above|at least|more than)\s*\$?\s*(\d+(?:\.\d+)?)`)
+
+// ParseSearchIntent converts a natural-language query into structured
+// filters using simple rules: known type names and synonyms, price
+// bounds, sort preference, and a verified-only restriction.
+func ParseSearchIntent(q string) SearchIntent {
+	lower := strings.ToLower(q)
+	intent := SearchIntent{SortBy: "value"}
+
+	for _, t := range KnownServiceTypes {
+		if strings.Contains(lower, t) {
+			intent.ServiceType = t
+			break
+		}
+	}
+	if intent.ServiceType == "" {
+		for word, t := range serviceTypeAliases {
+			if strings.Contains(lower, word) {
+				intent.ServiceType = t
+				break
+			}
+		}
+	}
+
+	if m := maxPriceRe.FindStringSubmatch(q); m != nil {
+		intent.MaxPrice = m[1]
+	}
+	if m := minPriceRe.FindStringSubmatch(q); m != nil {
+		intent.MinPrice = m[1]
+	}
+
+	switch {
+	case strings.Contains(lower, "cheap"), strings.Contains(lower, "lowest price"):
+		intent.SortBy = "price"
+	case strings.Contains(lower, "best"), strings.Contains(lower, "reliable"),
+		strings.Contains(lower, "trusted"), strings.Contains(lower, "reputable"):
+		intent.SortBy = "reputation"
+	}
+
+	if strings.Contains(lower, "verified") || strings.Contains(lower, "guaranteed") {
+		intent.VerifiedOnly = true
+	}
+
+	return intent
+}
+
+// applyIntentOverrides lets explicit filter parameters take precedence
+// over the parsed intent, so clients can correct or replay it verbatim.
+func applyIntentOverrides(intent SearchIntent, get func(string) string) SearchIntent {
+	if v := get("type"); v != "" {
+		if canonical, ok := CanonicalServiceType(v); ok {
+			intent.ServiceType = canonical
+		} else {
+			intent.ServiceType = v
+		}
+	}
+	if v := get("minPrice"); v != "" {
+		intent.MinPrice = v
+	}
+	if v := get("maxPrice"); v != "" {
+		intent.MaxPrice = v
+	}
+	if v := get("sortBy"); v != "" {
+		intent.SortBy = v
+	}
+	if v := get("verifiedOnly"); v != "" {
+		intent.VerifiedOnly = v == "true"
+	}
+	return intent
+}
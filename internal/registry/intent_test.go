@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchIntent(t *testing.T) {
+	tests := []struct {
+		name string
+		q    string
+		want SearchIntent
+	}{
+		{
+			name: "type and max price",
+			q:    "translation under $0.02",
+			want: SearchIntent{ServiceType: "translation", MaxPrice: "0.02", SortBy: "value"},
+		},
+		{
+			name: "synonym maps to type",
+			q:    "transcribe this recording",
+			want: SearchIntent{ServiceType: "audio", SortBy: "value"},
+		},
+		{
+			name: "min price phrase",
+			q:    "inference over $0.05",
+			want: SearchIntent{ServiceType: "inference", MinPrice: "0.05", SortBy: "value"},
+		},
+		{
+			name: "both price bounds",
+			q:    "code review at least 0.01 and at most 0.10",
+			want: SearchIntent{ServiceType: "code", MinPrice: "0.01", MaxPrice: "0.10", SortBy: "value"},
+		},
+		{
+			name: "cheap sorts by price",
+			q:    "cheap embeddings",
+			want: SearchIntent{ServiceType: "embedding", SortBy: "price"},
+		},
+		{
+			name: "trusted sorts by reputation",
+			q:    "trusted translator",
+			want: SearchIntent{ServiceType: "translation", SortBy: "reputation"},
+		},
+		{
+			name: "verified restriction",
+			q:    "verified image generation",
+			want: SearchIntent{ServiceType: "image", SortBy: "value", VerifiedOnly: true},
+		},
+		{
+			name: "no signals",
+			q:    "help me out",
+			want: SearchIntent{SortBy: "value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseSearchIntent(tt.q))
+		})
+	}
+}
+
+func TestApplyIntentOverrides(t *testing.T) {
+	parsed := ParseSearchIntent("cheap translation under $0.02")
+
+	params := map[string]string{
+		"type":         "code",
+		"maxPrice":     "0.50",
+		"sortBy":       "reputation",
+		"verifiedOnly": "true",
+	}
+	got := applyIntentOverrides(parsed, func(k string) string { return params[k] })
+
+	assert.Equal(t, SearchIntent{
+		ServiceType:  "code",
+		MaxPrice:     "0.50",
+		SortBy:       "reputation",
+		VerifiedOnly: true,
+	}, got)
+
+	// Absent parameters leave the parsed intent untouched.
+	same := applyIntentOverrides(parsed, func(string) string { return "" })
+	assert.Equal(t, parsed, same)
+}
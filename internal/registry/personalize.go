@@ -0,0 +1,99 @@
+package registry
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// SellerHistory summarizes a buyer's past dealings with one seller.
+type SellerHistory struct {
+	Successes int // confirmed payments / released escrows
+	Failures  int // failed transactions
+	Disputes  int // escrows the buyer disputed or had refunded
+}
+
+// PurchaseHistoryProvider supplies per-seller history for a buyer,
+// keyed by lowercase seller address. This decouples the registry from
+// the ledger, escrow, and gateway subsystems that record the history.
+type PurchaseHistoryProvider interface {
+	GetSellerHistory(ctx context.Context, buyerAddr string) (map[string]SellerHistory, error)
+}
+
+// SetPurchaseHistory attaches a history provider for personalized search.
+func (h *Handler) SetPurchaseHistory(p PurchaseHistoryProvider) {
+	h.history = p
+}
+
+// personalBias converts a seller history into a re-ranking bias. Sellers
+// the buyer succeeded with float up (capped, so volume cannot drown out
+// problems); failures and especially disputes push sellers down.
+func personalBias(hist SellerHistory) float64 {
+	successes := hist.Successes
+	if successes > 10 {
+		successes = 10
+	}
+	return float64(successes) - 2*float64(hist.Failures) - 5*float64(hist.Disputes)
+}
+
+// applyPersonalization re-ranks listings by the buyer's history with
+// each seller. The sort is stable, so sellers the buyer has no history
+// with keep their relative order from the base ranking.
+func (h *Handler) applyPersonalization(ctx context.Context, buyerAddr string, services []ServiceListing) error {
+	history, err := h.history.GetSellerHistory(ctx, buyerAddr)
+	if err != nil {
+		return err
+	}
+
+	for i := range services {
+		if hist, ok := history[strings.ToLower(services[i].AgentAddress)]; ok {
+			services[i].PersonalBias = personalBias(hist)
+		}
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].PersonalBias > services[j].PersonalBias
+	})
+	return nil
+}
+
+// TransactionHistoryProvider derives seller history from the registry's
+// own transaction log: confirmed payments count as successes, failed
+// ones as failures. Escrow disputes are layered on by the server, which
+// has access to the escrow service.
+type TransactionHistoryProvider struct {
+	store Store
+}
+
+// NewTransactionHistoryProvider creates a provider over a registry store.
+func NewTransactionHistoryProvider(store Store) *TransactionHistoryProvider {
+	return &TransactionHistoryProvider{store: store}
+}
+
+// historyTxLimit bounds how much history informs personalization.
+const historyTxLimit = 200
+
+// GetSellerHistory implements PurchaseHistoryProvider.
+func (p *TransactionHistoryProvider) GetSellerHistory(ctx context.Context, buyerAddr string) (map[string]SellerHistory, error) {
+	txs, err := p.store.ListTransactions(ctx, buyerAddr, historyTxLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	buyer := strings.ToLower(buyerAddr)
+	history := make(map[string]SellerHistory)
+	for _, tx := range txs {
+		if strings.ToLower(tx.From) != buyer {
+			continue // only outgoing payments say anything about sellers
+		}
+		seller := strings.ToLower(tx.To)
+		hist := history[seller]
+		switch tx.Status {
+		case "confirmed":
+			hist.Successes++
+		case "failed":
+			hist.Failures++
+		}
+		history[seller] = hist
+	}
+	return history, nil
+}
@@ -334,6 +334,9 @@ func (p *PostgresStore) GetService(ctx context.Context, agentAddress, serviceID
 
 // ListServices implements the Store interface for listing services
 func (p *PostgresStore) ListServices(ctx context.Context, query AgentQuery) ([]ServiceListing, error) {
+	if canonical, ok := CanonicalServiceType(query.ServiceType); ok {
+		query.ServiceType = canonical
+	}
 	filter := ServiceFilter{
 		Type:     query.ServiceType,
 		MinPrice: query.MinPrice,
@@ -0,0 +1,191 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LatencyProvider supplies observed latency for agents. This decouples
+// the registry from whichever subsystem measures calls (e.g. the
+// gateway health monitor). Agents without observations return ok=false
+// and score neutrally.
+type LatencyProvider interface {
+	GetP95Latency(ctx context.Context, agentAddr string) (p95 time.Duration, ok bool)
+}
+
+// SetLatency attaches a latency provider for weighted discovery ranking.
+func (h *Handler) SetLatency(p LatencyProvider) {
+	h.latency = p
+}
+
+// RankWeights weight the objectives of weighted discovery ranking.
+// Weights are relative: they are normalized by their sum, so callers
+// can pass any non-negative scale.
+type RankWeights struct {
+	Price       float64 `json:"price"`
+	Reputation  float64 `json:"reputation"`
+	Latency     float64 `json:"latency"`
+	SuccessRate float64 `json:"successRate"`
+}
+
+// DefaultRankWeights favors price and reputation, with success rate and
+// latency as tie-breakers.
+func DefaultRankWeights() RankWeights {
+	return RankWeights{Price: 0.35, Reputation: 0.35, Latency: 0.10, SuccessRate: 0.20}
+}
+
+func (w RankWeights) sum() float64 {
+	return w.Price + w.Reputation + w.Latency + w.SuccessRate
+}
+
+func (w RankWeights) validate() error {
+	if w.Price < 0 || w.Reputation < 0 || w.Latency < 0 || w.SuccessRate < 0 {
+		return fmt.Errorf("weights must be non-negative")
+	}
+	if w.sum() == 0 {
+		return fmt.Errorf("at least one weight must be positive")
+	}
+	return nil
+}
+
+// ScoreBreakdown explains a weighted ranking result. Component scores
+// are normalized to 0–1 before weighting; Total is the weighted average,
+// so results are directly comparable across queries.
+type ScoreBreakdown struct {
+	PriceScore       float64     `json:"priceScore"`
+	ReputationScore  float64     `json:"reputationScore"`
+	LatencyScore     float64     `json:"latencyScore"`
+	SuccessRateScore float64     `json:"successRateScore"`
+	Weights          RankWeights `json:"weights"`
+	Total            float64     `json:"total"`
+}
+
+// rankWeighted scores and sorts listings by the weighted objectives,
+// attaching a per-result breakdown explaining the ranking.
+func (h *Handler) rankWeighted(ctx context.Context, services []ServiceListing, weights RankWeights) {
+	if len(services) == 0 {
+		return
+	}
+
+	// Collect prices and latencies for relative normalization.
+	prices := make([]float64, len(services))
+	minPrice, maxPrice := 0.0, 0.0
+	for i := range services {
+		p, err := strconv.ParseFloat(services[i].Price, 64)
+		if err != nil || p < 0 {
+			p = 0
+		}
+		prices[i] = p
+		if i == 0 || p < minPrice {
+			minPrice = p
+		}
+		if p > maxPrice {
+			maxPrice = p
+		}
+	}
+
+	latencies := make([]float64, len(services))
+	hasLatency := make([]bool, len(services))
+	minLat, maxLat := 0.0, 0.0
+	if h.latency != nil {
+		first := true
+		for i := range services {
+			p95, ok := h.latency.GetP95Latency(ctx, services[i].AgentAddress)
+			if !ok {
+				continue
+			}
+			l := float64(p95.Milliseconds())
+			latencies[i] = l
+			hasLatency[i] = true
+			if first || l < minLat {
+				minLat = l
+			}
+			if l > maxLat {
+				maxLat = l
+			}
+			first = false
+		}
+	}
+
+	wSum := weights.sum()
+	for i := range services {
+		b := &ScoreBreakdown{Weights: weights}
+
+		// Cheaper is better; all-equal prices score full marks.
+		if maxPrice > minPrice {
+			b.PriceScore = (maxPrice - prices[i]) / (maxPrice - minPrice)
+		} else {
+			b.PriceScore = 1
+		}
+
+		// Reputation is 0–100; verified agents keep their sort boost.
+		rep := services[i].ReputationScore
+		if services[i].Verified {
+			rep += 10
+		}
+		b.ReputationScore = clamp01(rep / 100)
+
+		// Faster is better; agents without observations score neutral.
+		switch {
+		case !hasLatency[i]:
+			b.LatencyScore = 0.5
+		case maxLat > minLat:
+			b.LatencyScore = (maxLat - latencies[i]) / (maxLat - minLat)
+		default:
+			b.LatencyScore = 1
+		}
+
+		b.SuccessRateScore = clamp01(services[i].SuccessRate)
+
+		b.Total = (weights.Price*b.PriceScore +
+			weights.Reputation*b.ReputationScore +
+			weights.Latency*b.LatencyScore +
+			weights.SuccessRate*b.SuccessRateScore) / wSum
+		services[i].Breakdown = b
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Breakdown.Total > services[j].Breakdown.Total
+	})
+}
+
+// parseRankWeights reads the weight query parameters, keeping the
+// default for any weight the caller does not override.
+func parseRankWeights(c *gin.Context) (RankWeights, error) {
+	weights := DefaultRankWeights()
+	for param, field := range map[string]*float64{
+		"weightPrice":       &weights.Price,
+		"weightReputation":  &weights.Reputation,
+		"weightLatency":     &weights.Latency,
+		"weightSuccessRate": &weights.SuccessRate,
+	} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return weights, fmt.Errorf("%s must be a number, got %q", param, raw)
+		}
+		*field = v
+	}
+	if err := weights.validate(); err != nil {
+		return weights, err
+	}
+	return weights, nil
+}
+
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,157 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLatencyProvider is a test double for LatencyProvider.
+type stubLatencyProvider struct {
+	p95 map[string]time.Duration
+}
+
+func (s *stubLatencyProvider) GetP95Latency(_ context.Context, agentAddr string) (time.Duration, bool) {
+	d, ok := s.p95[agentAddr]
+	return d, ok
+}
+
+type weightedResponse struct {
+	Services []struct {
+		AgentName string          `json:"agentName"`
+		Breakdown *ScoreBreakdown `json:"scoreBreakdown"`
+	} `json:"services"`
+	Query struct {
+		Weights *RankWeights `json:"weights"`
+	} `json:"query"`
+}
+
+func doDiscover(t *testing.T, h *Handler, query string) (int, weightedResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/services", h.DiscoverServices)
+	req := httptest.NewRequest("GET", "/services?"+query, nil)
+	r.ServeHTTP(w, req)
+
+	var body weightedResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	}
+	return w.Code, body
+}
+
+func seedRankingAgents(t *testing.T) (*MemoryStore, *Handler) {
+	t.Helper()
+	store := NewMemoryStore()
+	h := NewHandler(store)
+
+	addrCheap := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	addrGood := "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	seedAgent(store, addrCheap, "CheapBot", []Service{
+		{Type: "inference", Name: "Budget Infer", Price: "0.01"},
+	})
+	seedAgent(store, addrGood, "EliteBot", []Service{
+		{Type: "inference", Name: "Premium Infer", Price: "0.10"},
+	})
+
+	h.SetReputation(&stubRepProvider{scores: map[string]struct {
+		score float64
+		tier  string
+	}{
+		addrCheap: {score: 20, tier: "emerging"},
+		addrGood:  {score: 90, tier: "elite"},
+	}})
+	return store, h
+}
+
+func TestDiscoverServices_WeightedDefaultRanking(t *testing.T) {
+	_, h := seedRankingAgents(t)
+
+	code, body := doDiscover(t, h, "sortBy=weighted")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Services, 2)
+
+	// Every result carries a breakdown, and the echoed weights are the defaults.
+	require.NotNil(t, body.Query.Weights)
+	assert.Equal(t, DefaultRankWeights(), *body.Query.Weights)
+	for _, svc := range body.Services {
+		require.NotNil(t, svc.Breakdown)
+		assert.GreaterOrEqual(t, svc.Breakdown.Total, 0.0)
+		assert.LessOrEqual(t, svc.Breakdown.Total, 1.0)
+	}
+	assert.GreaterOrEqual(t, body.Services[0].Breakdown.Total, body.Services[1].Breakdown.Total)
+}
+
+func TestDiscoverServices_WeightsSteerRanking(t *testing.T) {
+	_, h := seedRankingAgents(t)
+
+	// Price-only weights favor the cheap service.
+	code, body := doDiscover(t, h, "sortBy=weighted&weightPrice=1&weightReputation=0&weightLatency=0&weightSuccessRate=0")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Services, 2)
+	assert.Equal(t, "CheapBot", body.Services[0].AgentName)
+	assert.Equal(t, 1.0, body.Services[0].Breakdown.PriceScore)
+
+	// Reputation-only weights favor the elite service.
+	code, body = doDiscover(t, h, "sortBy=weighted&weightPrice=0&weightReputation=1&weightLatency=0&weightSuccessRate=0")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "EliteBot", body.Services[0].AgentName)
+	assert.Equal(t, 0.9, body.Services[0].Breakdown.ReputationScore)
+}
+
+func TestDiscoverServices_WeightedLatency(t *testing.T) {
+	_, h := seedRankingAgents(t)
+	h.SetLatency(&stubLatencyProvider{p95: map[string]time.Duration{
+		"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": 2 * time.Second,
+		"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb": 100 * time.Millisecond,
+	}})
+
+	code, body := doDiscover(t, h, "sortBy=weighted&weightPrice=0&weightReputation=0&weightLatency=1&weightSuccessRate=0")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Services, 2)
+	assert.Equal(t, "EliteBot", body.Services[0].AgentName)
+	assert.Equal(t, 1.0, body.Services[0].Breakdown.LatencyScore)
+	assert.Equal(t, 0.0, body.Services[1].Breakdown.LatencyScore)
+}
+
+func TestDiscoverServices_WeightedNeutralLatencyWithoutData(t *testing.T) {
+	_, h := seedRankingAgents(t)
+
+	code, body := doDiscover(t, h, "sortBy=weighted")
+	require.Equal(t, http.StatusOK, code)
+	for _, svc := range body.Services {
+		assert.Equal(t, 0.5, svc.Breakdown.LatencyScore, "no latency provider means a neutral score")
+	}
+}
+
+func TestDiscoverServices_InvalidWeights(t *testing.T) {
+	_, h := seedRankingAgents(t)
+
+	code, _ := doDiscover(t, h, "sortBy=weighted&weightPrice=-1")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doDiscover(t, h, "sortBy=weighted&weightPrice=0&weightReputation=0&weightLatency=0&weightSuccessRate=0")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doDiscover(t, h, "sortBy=weighted&weightPrice=abc")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestDiscoverServices_NonWeightedSortHasNoBreakdown(t *testing.T) {
+	_, h := seedRankingAgents(t)
+
+	code, body := doDiscover(t, h, "sortBy=price")
+	require.Equal(t, http.StatusOK, code)
+	for _, svc := range body.Services {
+		assert.Nil(t, svc.Breakdown)
+	}
+	assert.Nil(t, body.Query.Weights)
+}
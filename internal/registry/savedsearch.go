@@ -0,0 +1,258 @@
+package registry
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// maxSavedSearchesPerAgent caps standing searches per agent so one
+// caller cannot grow the match scan unboundedly.
+const maxSavedSearchesPerAgent = 20
+
+// SavedSearch is a standing search an agent wants alerts for: "tell me
+// when a translation service under $0.002 appears".
+type SavedSearch struct {
+	ID           string    `json:"id"`
+	AgentAddress string    `json:"agentAddress"`
+	Query        string    `json:"query,omitempty"` // Original free-text query, if saved from one
+	ServiceType  string    `json:"type,omitempty"`
+	MaxPrice     string    `json:"maxPrice,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// matches reports whether a service satisfies the saved criteria.
+func (s *SavedSearch) matches(svc *Service) bool {
+	if !svc.Active {
+		return false
+	}
+	if s.ServiceType != "" && svc.Type != s.ServiceType {
+		return false
+	}
+	if s.MaxPrice != "" {
+		maxAmount, ok := usdc.Parse(s.MaxPrice)
+		if !ok {
+			return false
+		}
+		svcAmount, ok := usdc.Parse(svc.Price)
+		if !ok || svcAmount.Cmp(maxAmount) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SavedSearchNotifier delivers a match alert to the search owner. The
+// server wires webhook and realtime implementations behind it.
+type SavedSearchNotifier interface {
+	NotifySavedSearchMatch(ownerAddr string, search SavedSearch, sellerAddr string, svc Service)
+}
+
+// SavedSearches holds standing searches in memory and fans out alerts
+// when new or repriced services match.
+type SavedSearches struct {
+	mu       sync.RWMutex
+	searches map[string]*SavedSearch // by ID
+	notifier SavedSearchNotifier
+}
+
+// NewSavedSearches creates an empty saved-search registry.
+func NewSavedSearches() *SavedSearches {
+	return &SavedSearches{searches: make(map[string]*SavedSearch)}
+}
+
+// WithNotifier attaches the alert sink. Without one, matches are
+// silently dropped.
+func (ss *SavedSearches) WithNotifier(n SavedSearchNotifier) *SavedSearches {
+	ss.notifier = n
+	return ss
+}
+
+// Save stores a search and returns it with an ID assigned.
+func (ss *SavedSearches) Save(search SavedSearch) (*SavedSearch, error) {
+	search.AgentAddress = strings.ToLower(search.AgentAddress)
+	search.ID = idgen.WithPrefix("ssr_")
+	search.CreatedAt = time.Now()
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	count := 0
+	for _, existing := range ss.searches {
+		if existing.AgentAddress == search.AgentAddress {
+			count++
+		}
+	}
+	if count >= maxSavedSearchesPerAgent {
+		return nil, ErrTooManySavedSearches
+	}
+	ss.searches[search.ID] = &search
+	return &search, nil
+}
+
+// ListByAgent returns an agent's saved searches.
+func (ss *SavedSearches) ListByAgent(agentAddr string) []SavedSearch {
+	agentAddr = strings.ToLower(agentAddr)
+
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	var result []SavedSearch
+	for _, s := range ss.searches {
+		if s.AgentAddress == agentAddr {
+			result = append(result, *s)
+		}
+	}
+	return result
+}
+
+// Delete removes a search if it belongs to the agent. It reports
+// whether the search existed and was owned by the caller.
+func (ss *SavedSearches) Delete(id, agentAddr string) bool {
+	agentAddr = strings.ToLower(agentAddr)
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	s, ok := ss.searches[id]
+	if !ok || s.AgentAddress != agentAddr {
+		return false
+	}
+	delete(ss.searches, id)
+	return true
+}
+
+// NotifyMatches alerts every saved search the service now satisfies.
+// Sellers are not alerted about their own services.
+func (ss *SavedSearches) NotifyMatches(sellerAddr string, svc *Service) {
+	if ss == nil || ss.notifier == nil {
+		return
+	}
+	sellerAddr = strings.ToLower(sellerAddr)
+
+	ss.mu.RLock()
+	var matched []SavedSearch
+	for _, s := range ss.searches {
+		if s.AgentAddress != sellerAddr && s.matches(svc) {
+			matched = append(matched, *s)
+		}
+	}
+	ss.mu.RUnlock()
+
+	for _, s := range matched {
+		ss.notifier.NotifySavedSearchMatch(s.AgentAddress, s, sellerAddr, *svc)
+	}
+}
+
+// SetSavedSearches attaches the saved-search registry. Without one,
+// saved-search endpoints return 503 and no alerts fire.
+func (h *Handler) SetSavedSearches(ss *SavedSearches) {
+	h.savedSearches = ss
+}
+
+// SaveSearchRequest is the payload for saving a search. Criteria can
+// be given explicitly or parsed from a free-text query; explicit
+// fields win.
+type SaveSearchRequest struct {
+	Query    string `json:"query"`
+	Type     string `json:"type"`
+	MaxPrice string `json:"maxPrice"`
+}
+
+// CreateSavedSearch handles POST /agents/:address/saved-searches
+func (h *Handler) CreateSavedSearch(c *gin.Context) {
+	if h.savedSearches == nil {
+		savedSearchesDisabled(c)
+		return
+	}
+	address := c.Param("address")
+
+	var req SaveSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	search := SavedSearch{
+		AgentAddress: address,
+		Query:        req.Query,
+		ServiceType:  req.Type,
+		MaxPrice:     req.MaxPrice,
+	}
+	if req.Query != "" {
+		intent := ParseSearchIntent(req.Query)
+		if search.ServiceType == "" {
+			search.ServiceType = intent.ServiceType
+		}
+		if search.MaxPrice == "" {
+			search.MaxPrice = intent.MaxPrice
+		}
+	}
+	if canonical, ok := CanonicalServiceType(search.ServiceType); ok {
+		search.ServiceType = canonical
+	}
+	if search.ServiceType == "" && search.MaxPrice == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "missing_criteria",
+			"message": "A service type or max price criterion is required",
+		})
+		return
+	}
+
+	saved, err := h.savedSearches.Save(search)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "too_many_saved_searches",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, saved)
+}
+
+// ListSavedSearches handles GET /agents/:address/saved-searches
+func (h *Handler) ListSavedSearches(c *gin.Context) {
+	if h.savedSearches == nil {
+		savedSearchesDisabled(c)
+		return
+	}
+	searches := h.savedSearches.ListByAgent(c.Param("address"))
+	if searches == nil {
+		searches = []SavedSearch{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"savedSearches": searches,
+		"count":         len(searches),
+	})
+}
+
+// DeleteSavedSearch handles DELETE /agents/:address/saved-searches/:searchId
+func (h *Handler) DeleteSavedSearch(c *gin.Context) {
+	if h.savedSearches == nil {
+		savedSearchesDisabled(c)
+		return
+	}
+	if !h.savedSearches.Delete(c.Param("searchId"), c.Param("address")) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Saved search not found",
+		})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func savedSearchesDisabled(c *gin.Context) {
+	c.JSON(http.StatusServiceUnavailable, gin.H{
+		"error":   "saved_searches_disabled",
+		"message": "Saved searches are not enabled",
+	})
+}
@@ -0,0 +1,169 @@
+package registry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSearchNotifier records saved-search match alerts.
+type stubSearchNotifier struct {
+	alerts []struct {
+		owner  string
+		search SavedSearch
+		seller string
+		svc    Service
+	}
+}
+
+func (s *stubSearchNotifier) NotifySavedSearchMatch(ownerAddr string, search SavedSearch, sellerAddr string, svc Service) {
+	s.alerts = append(s.alerts, struct {
+		owner  string
+		search SavedSearch
+		seller string
+		svc    Service
+	}{ownerAddr, search, sellerAddr, svc})
+}
+
+func TestSavedSearchesMatchAndNotify(t *testing.T) {
+	notifier := &stubSearchNotifier{}
+	ss := NewSavedSearches().WithNotifier(notifier)
+
+	saved, err := ss.Save(SavedSearch{
+		AgentAddress: "0xBUYER",
+		ServiceType:  "translation",
+		MaxPrice:     "0.002",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, saved.ID)
+
+	// Too expensive: no alert.
+	ss.NotifyMatches("0xseller", &Service{Type: "translation", Price: "0.01", Active: true})
+	assert.Empty(t, notifier.alerts)
+
+	// Wrong type: no alert.
+	ss.NotifyMatches("0xseller", &Service{Type: "code", Price: "0.001", Active: true})
+	assert.Empty(t, notifier.alerts)
+
+	// Inactive: no alert.
+	ss.NotifyMatches("0xseller", &Service{Type: "translation", Price: "0.001"})
+	assert.Empty(t, notifier.alerts)
+
+	// Matching service alerts the owner.
+	ss.NotifyMatches("0xseller", &Service{ID: "svc_1", Type: "translation", Price: "0.001", Active: true})
+	require.Len(t, notifier.alerts, 1)
+	assert.Equal(t, "0xbuyer", notifier.alerts[0].owner)
+	assert.Equal(t, saved.ID, notifier.alerts[0].search.ID)
+	assert.Equal(t, "svc_1", notifier.alerts[0].svc.ID)
+
+	// The owner's own services never alert them.
+	ss.NotifyMatches("0xBUYER", &Service{Type: "translation", Price: "0.001", Active: true})
+	assert.Len(t, notifier.alerts, 1)
+}
+
+func TestSavedSearchesOwnershipAndLimit(t *testing.T) {
+	ss := NewSavedSearches()
+
+	saved, err := ss.Save(SavedSearch{AgentAddress: "0xaaa", ServiceType: "code"})
+	require.NoError(t, err)
+
+	assert.Len(t, ss.ListByAgent("0xAAA"), 1)
+	assert.Empty(t, ss.ListByAgent("0xbbb"))
+
+	// Another agent cannot delete it.
+	assert.False(t, ss.Delete(saved.ID, "0xbbb"))
+	assert.True(t, ss.Delete(saved.ID, "0xaaa"))
+	assert.Empty(t, ss.ListByAgent("0xaaa"))
+
+	for i := 0; i < maxSavedSearchesPerAgent; i++ {
+		_, err := ss.Save(SavedSearch{AgentAddress: "0xccc", ServiceType: "code"})
+		require.NoError(t, err)
+	}
+	_, err = ss.Save(SavedSearch{AgentAddress: "0xccc", ServiceType: "code"})
+	assert.ErrorIs(t, err, ErrTooManySavedSearches)
+}
+
+func newSavedSearchRouter(h *Handler) *gin.Engine {
+	_, r := gin.CreateTestContext(httptest.NewRecorder())
+	r.POST("/agents/:address/saved-searches", h.CreateSavedSearch)
+	r.GET("/agents/:address/saved-searches", h.ListSavedSearches)
+	r.POST("/agents/:address/services", h.AddService)
+	r.PUT("/agents/:address/services/:serviceId", h.UpdateService)
+	return r
+}
+
+func TestCreateSavedSearchParsesQuery(t *testing.T) {
+	h := NewHandler(NewMemoryStore())
+	h.SetSavedSearches(NewSavedSearches())
+	r := newSavedSearchRouter(h)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/agents/0xBUYER/saved-searches",
+		strings.NewReader(`{"query":"translation under $0.002"}`))
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var saved SavedSearch
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &saved))
+	assert.Equal(t, "translation", saved.ServiceType)
+	assert.Equal(t, "0.002", saved.MaxPrice)
+
+	// No usable criteria is rejected.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/agents/0xBUYER/saved-searches",
+		strings.NewReader(`{"query":"anything good"}`))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSavedSearchAlertsOnNewAndRepricedServices(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+	h.SetAllowLocalEndpoints(true)
+	notifier := &stubSearchNotifier{}
+	h.SetSavedSearches(NewSavedSearches().WithNotifier(notifier))
+	r := newSavedSearchRouter(h)
+
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Seller", nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/agents/0xBUYER/saved-searches",
+		strings.NewReader(`{"type":"translation","maxPrice":"0.002"}`))
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// A new service above the cap does not alert.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/agents/0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/services",
+		strings.NewReader(`{"type":"translation","name":"Translate","price":"0.01"}`))
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, notifier.alerts)
+
+	var svc Service
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &svc))
+
+	// Repricing it under the cap fires the alert.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/agents/0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/services/"+svc.ID,
+		strings.NewReader(`{"type":"translation","name":"Translate","price":"0.001","active":true}`))
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, notifier.alerts, 1)
+	assert.Equal(t, "0xbuyer", notifier.alerts[0].owner)
+	assert.Equal(t, "0.001", notifier.alerts[0].svc.Price)
+
+	// A matching brand-new service alerts too.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/agents/0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa/services",
+		strings.NewReader(`{"type":"translation","name":"Budget Translate","price":"0.0015"}`))
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Len(t, notifier.alerts, 2)
+}
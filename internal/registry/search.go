@@ -0,0 +1,188 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mbd888/alancoin/internal/llm"
+	"github.com/mbd888/alancoin/internal/logging"
+)
+
+// SetRecommender attaches an LLM provider for search recommendations.
+// Without one, Search falls back to rule-based text.
+func (h *Handler) SetRecommender(p llm.Provider) {
+	h.recommender = p
+}
+
+// Search handles GET /search — natural-language service discovery.
+// The query is parsed into a SearchIntent (service type, price bounds,
+// sort preference, verified-only), explicit filter parameters override
+// the parsed values, matching services are listed, and a recommendation
+// is produced by the configured LLM provider, falling back to rule-based
+// text when no provider is set or the call fails. The intent is echoed
+// back as "interpreted" so clients can replay it on paginated requests.
+func (h *Handler) Search(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "missing_query",
+			"message": "q query parameter is required",
+		})
+		return
+	}
+
+	intent := applyIntentOverrides(ParseSearchIntent(q), c.Query)
+
+	query := AgentQuery{
+		ServiceType: intent.ServiceType,
+		MinPrice:    intent.MinPrice,
+		MaxPrice:    intent.MaxPrice,
+		Limit:       parseIntQuery(c, "limit", 10),
+		Offset:      parseIntQuery(c, "offset", 0),
+	}
+	active := true
+	query.Active = &active
+
+	services, err := h.store.ListServices(ctx, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to search services",
+		})
+		return
+	}
+
+	h.enrichWithReputation(ctx, services)
+	h.enrichWithVerification(ctx, services)
+
+	if intent.VerifiedOnly {
+		filtered := services[:0]
+		for _, svc := range services {
+			if svc.Verified {
+				filtered = append(filtered, svc)
+			}
+		}
+		services = filtered
+	}
+
+	h.sortServices(services, intent.SortBy)
+
+	// Opt-in personalization: bias results by the caller's history with
+	// each seller before the recommendation is built.
+	personalized := false
+	if c.Query("personalize") == "true" {
+		buyerAddr := strings.ToLower(strings.TrimSpace(c.Query("agent")))
+		if buyerAddr == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "missing_agent",
+				"message": "agent query parameter is required when personalize=true",
+			})
+			return
+		}
+		if h.history != nil {
+			if err := h.applyPersonalization(ctx, buyerAddr, services); err != nil {
+				logging.L(ctx).Warn("search personalization unavailable", "error", err)
+			} else {
+				personalized = true
+			}
+		}
+	}
+
+	if h.analytics != nil {
+		h.analytics.RecordQuery(q, len(services))
+	}
+
+	recommendation := ruleBasedRecommendation(q, intent.ServiceType, services)
+	source := "local"
+	cached := false
+
+	if h.recommender != nil && len(services) > 0 {
+		resp, llmErr := h.recommender.Complete(ctx, llm.Request{
+			System:    searchSystemPrompt,
+			Prompt:    buildSearchPrompt(q, services),
+			Fallback:  recommendation,
+			MaxTokens: 256,
+		})
+		if llmErr != nil {
+			logging.L(ctx).Warn("search recommendation fell back to rule-based",
+				"provider", h.recommender.Name(), "error", llmErr)
+		} else {
+			recommendation = resp.Text
+			source = resp.Provider
+			cached = resp.Cached
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":        q,
+		"services":     services,
+		"count":        len(services),
+		"personalized": personalized,
+		"interpreted":  intent,
+		"recommendation": gin.H{
+			"text":   recommendation,
+			"source": source,
+			"cached": cached,
+		},
+	})
+}
+
+// searchSystemPrompt frames the recommendation task for the model.
+const searchSystemPrompt = "You help AI agents pick a paid service from the Alancoin marketplace. " +
+	"Given a natural-language request and candidate services, recommend the best fit in 2-3 sentences. " +
+	"Weigh price, reputation tier, and verification. Mention the service name and price. " +
+	"Only recommend listed candidates; never invent services."
+
+// buildSearchPrompt renders the query and top candidates for the model.
+func buildSearchPrompt(q string, services []ServiceListing) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Request: %s\n\nCandidates:\n", q)
+	for i, svc := range services {
+		if i >= 5 {
+			break
+		}
+		fmt.Fprintf(&sb, "%d. %s by %s — type %s, %s USDC/call, reputation %s (%.0f)",
+			i+1, svc.Name, svc.AgentName, svc.Type, svc.Price, svc.ReputationTier, svc.ReputationScore)
+		if svc.Verified {
+			sb.WriteString(", verified")
+		}
+		if svc.Description != "" {
+			fmt.Fprintf(&sb, " — %s", svc.Description)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ruleBasedRecommendation builds the recommendation without a model.
+// It is also what hosted providers fall back to on errors or when the
+// daily token budget runs out.
+func ruleBasedRecommendation(q, serviceType string, services []ServiceListing) string {
+	if len(services) == 0 {
+		if serviceType != "" {
+			return fmt.Sprintf("No active %s services matched your query. Try broadening the search or raising the price cap.", serviceType)
+		}
+		return "No active services matched your query. Try different keywords — known types include " +
+			strings.Join(KnownServiceTypes, ", ") + "."
+	}
+
+	top := services[0]
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Recommended: %s by %s at %s USDC/call", top.Name, top.AgentName, top.Price)
+	if top.ReputationTier != "" && top.ReputationTier != "new" {
+		fmt.Fprintf(&sb, " (%s reputation)", top.ReputationTier)
+	}
+	if top.Verified {
+		sb.WriteString(", verified with a performance guarantee")
+	}
+	sb.WriteString(".")
+	if len(services) > 1 {
+		alt := services[1]
+		fmt.Fprintf(&sb, " Alternative: %s by %s at %s USDC/call.", alt.Name, alt.AgentName, alt.Price)
+	}
+	return sb.String()
+}
@@ -0,0 +1,287 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mbd888/alancoin/internal/llm"
+)
+
+// stubRecommender is a test double for the LLM provider.
+type stubRecommender struct {
+	text    string
+	err     error
+	lastReq llm.Request
+}
+
+func (s *stubRecommender) Name() string { return "stub" }
+
+func (s *stubRecommender) Complete(_ context.Context, req llm.Request) (*llm.Response, error) {
+	s.lastReq = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &llm.Response{Text: s.text, TokensUsed: 10, Provider: "stub"}, nil
+}
+
+type searchResponse struct {
+	Query    string `json:"query"`
+	Count    int    `json:"count"`
+	Services []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"services"`
+	Interpreted struct {
+		Type     string `json:"type"`
+		MaxPrice string `json:"maxPrice"`
+		SortBy   string `json:"sortBy"`
+	} `json:"interpreted"`
+	Recommendation struct {
+		Text   string `json:"text"`
+		Source string `json:"source"`
+		Cached bool   `json:"cached"`
+	} `json:"recommendation"`
+}
+
+func doSearch(t *testing.T, h *Handler, query string) (int, searchResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/search", h.Search)
+	req := httptest.NewRequest("GET", "/search?"+query, nil)
+	r.ServeHTTP(w, req)
+
+	var body searchResponse
+	if w.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	}
+	return w.Code, body
+}
+
+func TestSearch_MissingQuery(t *testing.T) {
+	h := NewHandler(NewMemoryStore())
+
+	code, _ := doSearch(t, h, "")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestSearch_RuleBased(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Lingua", []Service{
+		{Type: "translation", Name: "Translate", Price: "0.01"},
+	})
+	seedAgent(store, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "CodeBot", []Service{
+		{Type: "code", Name: "Review", Price: "0.05"},
+	})
+
+	code, body := doSearch(t, h, "q=cheap+translation+under+$0.02")
+	require.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, "translation", body.Interpreted.Type)
+	assert.Equal(t, "0.02", body.Interpreted.MaxPrice)
+	assert.Equal(t, "price", body.Interpreted.SortBy)
+
+	require.Equal(t, 1, body.Count)
+	assert.Equal(t, "Translate", body.Services[0].Name)
+
+	assert.Equal(t, "local", body.Recommendation.Source)
+	assert.Contains(t, body.Recommendation.Text, "Translate")
+	assert.Contains(t, body.Recommendation.Text, "Lingua")
+}
+
+func TestSearch_SynonymAndNoMatches(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+
+	code, body := doSearch(t, h, "q=translate+this+document")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "translation", body.Interpreted.Type)
+	assert.Equal(t, 0, body.Count)
+	assert.Contains(t, body.Recommendation.Text, "No active translation services")
+}
+
+func TestSearch_WithRecommender(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Lingua", []Service{
+		{Type: "translation", Name: "Translate", Price: "0.01"},
+	})
+
+	rec := &stubRecommender{text: "Translate by Lingua is the clear pick."}
+	h.SetRecommender(rec)
+
+	code, body := doSearch(t, h, "q=reliable+translation")
+	require.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, "stub", body.Recommendation.Source)
+	assert.Equal(t, "Translate by Lingua is the clear pick.", body.Recommendation.Text)
+
+	// The provider saw the candidates and a rule-based fallback.
+	assert.Contains(t, rec.lastReq.Prompt, "Translate by Lingua")
+	assert.Contains(t, rec.lastReq.Fallback, "Translate")
+}
+
+func TestSearch_RecommenderErrorFallsBack(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Lingua", []Service{
+		{Type: "translation", Name: "Translate", Price: "0.01"},
+	})
+	h.SetRecommender(&stubRecommender{err: errors.New("rate limited")})
+
+	code, body := doSearch(t, h, "q=translation")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "local", body.Recommendation.Source)
+	assert.Contains(t, body.Recommendation.Text, "Translate")
+}
+
+func TestSearch_ExplicitFiltersOverrideIntent(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Lingua", []Service{
+		{Type: "translation", Name: "Translate", Price: "0.01"},
+	})
+	seedAgent(store, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "CodeBot", []Service{
+		{Type: "code", Name: "Review", Price: "0.05"},
+	})
+
+	// The query says translation but the explicit type parameter wins,
+	// and the echoed intent reflects the override.
+	code, body := doSearch(t, h, "q=cheap+translation&type=code")
+	require.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, "code", body.Interpreted.Type)
+	assert.Equal(t, "price", body.Interpreted.SortBy)
+	require.Equal(t, 1, body.Count)
+	assert.Equal(t, "Review", body.Services[0].Name)
+}
+
+func TestSearch_PaginationReplaysIntent(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "A", []Service{
+		{Type: "translation", Name: "First", Price: "0.01"},
+	})
+	seedAgent(store, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "B", []Service{
+		{Type: "translation", Name: "Second", Price: "0.02"},
+	})
+	seedAgent(store, "0xcccccccccccccccccccccccccccccccccccccccc", "C", []Service{
+		{Type: "translation", Name: "Third", Price: "0.03"},
+	})
+
+	// Second page using the explicit filters echoed from the first.
+	code, body := doSearch(t, h, "q=cheap+translation&type=translation&sortBy=price&limit=2&offset=2")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 1, body.Count)
+	assert.Equal(t, "Third", body.Services[0].Name)
+}
+
+// stubHistoryProvider is a test double for PurchaseHistoryProvider.
+type stubHistoryProvider struct {
+	history map[string]SellerHistory
+	err     error
+}
+
+func (s *stubHistoryProvider) GetSellerHistory(_ context.Context, _ string) (map[string]SellerHistory, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.history, nil
+}
+
+func seedPersonalizationAgents(store *MemoryStore) {
+	seedAgent(store, "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "Trusty", []Service{
+		{Type: "translation", Name: "Good Translate", Price: "0.02"},
+	})
+	seedAgent(store, "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "Cheapo", []Service{
+		{Type: "translation", Name: "Cheap Translate", Price: "0.01"},
+	})
+	seedAgent(store, "0xcccccccccccccccccccccccccccccccccccccccc", "Shady", []Service{
+		{Type: "translation", Name: "Sketchy Translate", Price: "0.005"},
+	})
+}
+
+func TestSearch_PersonalizeReordersByHistory(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+	seedPersonalizationAgents(store)
+
+	h.SetPurchaseHistory(&stubHistoryProvider{history: map[string]SellerHistory{
+		"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": {Successes: 4},
+		"0xcccccccccccccccccccccccccccccccccccccccc": {Disputes: 2},
+	}})
+
+	code, body := doSearch(t, h, "q=translation&personalize=true&agent=0xBUYER")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Services, 3)
+
+	// Successful history floats Trusty above the cheaper options;
+	// disputes sink Shady below the neutral seller.
+	assert.Equal(t, "Good Translate", body.Services[0].Name)
+	assert.Equal(t, "Sketchy Translate", body.Services[2].Name)
+	assert.Contains(t, body.Recommendation.Text, "Good Translate")
+}
+
+func TestSearch_PersonalizeRequiresAgent(t *testing.T) {
+	h := NewHandler(NewMemoryStore())
+	h.SetPurchaseHistory(&stubHistoryProvider{})
+
+	code, _ := doSearch(t, h, "q=translation&personalize=true")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestSearch_PersonalizeProviderErrorKeepsBaseOrder(t *testing.T) {
+	store := NewMemoryStore()
+	h := NewHandler(store)
+	seedPersonalizationAgents(store)
+	h.SetPurchaseHistory(&stubHistoryProvider{err: errors.New("db down")})
+
+	code, body := doSearch(t, h, "q=cheap+translation&personalize=true&agent=0xBUYER")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Services, 3)
+	assert.Equal(t, "Sketchy Translate", body.Services[0].Name, "price order preserved on provider failure")
+}
+
+func TestTransactionHistoryProvider(t *testing.T) {
+	store := NewMemoryStore()
+	buyer := "0xdddddddddddddddddddddddddddddddddddddddd"
+	sellerA := "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	sellerB := "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	seedAgent(store, buyer, "Buyer", nil)
+	seedAgent(store, sellerA, "SellerA", nil)
+	seedAgent(store, sellerB, "SellerB", nil)
+
+	seedTx(store, buyer, sellerA, "0.10", "confirmed")
+	seedTx(store, buyer, sellerA, "0.10", "confirmed")
+	seedTx(store, buyer, sellerB, "0.10", "failed")
+	// Incoming payments say nothing about sellers.
+	seedTx(store, sellerA, buyer, "0.10", "confirmed")
+
+	history, err := NewTransactionHistoryProvider(store).GetSellerHistory(context.Background(), buyer)
+	require.NoError(t, err)
+	assert.Equal(t, SellerHistory{Successes: 2}, history[sellerA])
+	assert.Equal(t, SellerHistory{Failures: 1}, history[sellerB])
+	_, ok := history[buyer]
+	assert.False(t, ok)
+}
+
+func TestPersonalBias(t *testing.T) {
+	assert.Equal(t, 4.0, personalBias(SellerHistory{Successes: 4}))
+	assert.Equal(t, 10.0, personalBias(SellerHistory{Successes: 50}), "success credit is capped")
+	assert.Equal(t, -10.0, personalBias(SellerHistory{Disputes: 2}))
+	assert.Equal(t, 1.0, personalBias(SellerHistory{Successes: 5, Failures: 2}))
+}
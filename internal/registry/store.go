@@ -287,6 +287,9 @@ func (m *MemoryStore) ListServices(ctx context.Context, query AgentQuery) ([]Ser
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	if canonical, ok := CanonicalServiceType(query.ServiceType); ok {
+		query.ServiceType = canonical
+	}
 	if query.Limit == 0 {
 		query.Limit = 100
 	}
@@ -16,6 +16,8 @@ var (
 	ErrServiceNotFound    = errors.New("registry: service not found")
 	ErrInvalidAddress     = errors.New("registry: invalid wallet address")
 	ErrInvalidServiceType = errors.New("registry: invalid service type")
+
+	ErrTooManySavedSearches = errors.New("registry: saved search limit reached")
 )
 
 // -----------------------------------------------------------------------------
@@ -132,6 +134,13 @@ type ServiceListing struct {
 	Verified              bool    `json:"verified"`                        // Has active performance guarantee
 	GuaranteedSuccessRate float64 `json:"guaranteedSuccessRate,omitempty"` // Guaranteed success rate (e.g. 95.0)
 	GuaranteePremiumRate  float64 `json:"guaranteePremiumRate,omitempty"`  // Premium rate charged (e.g. 0.05)
+
+	// Breakdown explains the weighted ranking score (sortBy=weighted only)
+	Breakdown *ScoreBreakdown `json:"scoreBreakdown,omitempty"`
+
+	// PersonalBias is the re-ranking bias from the caller's purchase
+	// history (personalized search only)
+	PersonalBias float64 `json:"personalBias,omitempty"`
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,79 @@
+package reputation
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+)
+
+// Prediction-market accuracy feeds the published score as a bounded blend:
+// once an agent has enough settled wagers, ForecastWeight of the score comes
+// from forecast accuracy. Samples decay with a half-life so stale calls fade
+// rather than locking in an early hot streak.
+const (
+	// ForecastWeight is the fraction of the published score driven by
+	// forecast accuracy once the minimum sample size is met.
+	ForecastWeight = 0.10
+
+	// ForecastWindow is how far back settled wagers count.
+	ForecastWindow = 90 * 24 * time.Hour
+
+	// ForecastMinSample is the minimum settled wagers before accuracy
+	// affects the score; below it the signal is too noisy to publish.
+	ForecastMinSample = 10
+
+	// forecastHalfLife halves a sample's weight every 30 days.
+	forecastHalfLife = 30 * 24 * time.Hour
+)
+
+// ForecastSample is one settled prediction-market wager outcome.
+type ForecastSample struct {
+	Correct   bool
+	SettledAt time.Time
+}
+
+// ForecastProvider supplies an agent's settled wager outcomes within a
+// window. Implemented by the predictions service.
+type ForecastProvider interface {
+	ForecastSamples(ctx context.Context, address string, window time.Duration) ([]ForecastSample, error)
+}
+
+// WithForecasts attaches a prediction-market accuracy provider. When set,
+// forecast accuracy blends into published scores per ForecastWeight.
+func (p *RegistryProvider) WithForecasts(provider ForecastProvider) *RegistryProvider {
+	p.forecasts = provider
+	return p
+}
+
+// forecastScore returns the agent's decayed forecast accuracy on a 0-100
+// scale. ok is false when no provider is wired, the lookup fails, or the
+// agent has fewer than ForecastMinSample settled wagers.
+func (p *RegistryProvider) forecastScore(ctx context.Context, address string) (float64, bool) {
+	if p.forecasts == nil {
+		return 0, false
+	}
+	samples, err := p.forecasts.ForecastSamples(ctx, strings.ToLower(address), ForecastWindow)
+	if err != nil || len(samples) < ForecastMinSample {
+		return 0, false
+	}
+
+	now := time.Now()
+	var total, correct float64
+	for _, s := range samples {
+		w := math.Pow(0.5, now.Sub(s.SettledAt).Hours()/forecastHalfLife.Hours())
+		total += w
+		if s.Correct {
+			correct += w
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return correct / total * 100, true
+}
+
+// blendForecast mixes forecast accuracy into a base score at ForecastWeight.
+func blendForecast(base, forecast float64) float64 {
+	return (1-ForecastWeight)*base + ForecastWeight*forecast
+}
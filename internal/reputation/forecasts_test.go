@@ -0,0 +1,74 @@
+package reputation
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeForecasts struct {
+	samples []ForecastSample
+	err     error
+}
+
+func (f *fakeForecasts) ForecastSamples(ctx context.Context, address string, window time.Duration) ([]ForecastSample, error) {
+	return f.samples, f.err
+}
+
+func recentSamples(n, correct int) []ForecastSample {
+	samples := make([]ForecastSample, 0, n)
+	for i := 0; i < n; i++ {
+		samples = append(samples, ForecastSample{Correct: i < correct, SettledAt: time.Now().Add(-time.Hour)})
+	}
+	return samples
+}
+
+func TestForecastScoreNeedsMinimumSample(t *testing.T) {
+	provider := NewRegistryProvider(nil)
+	if _, ok := provider.forecastScore(context.Background(), "0xabc"); ok {
+		t.Error("expected no forecast score without a provider")
+	}
+
+	provider.WithForecasts(&fakeForecasts{samples: recentSamples(ForecastMinSample-1, ForecastMinSample-1)})
+	if _, ok := provider.forecastScore(context.Background(), "0xabc"); ok {
+		t.Error("expected no forecast score below minimum sample size")
+	}
+
+	provider.WithForecasts(&fakeForecasts{samples: recentSamples(ForecastMinSample, ForecastMinSample/2)})
+	score, ok := provider.forecastScore(context.Background(), "0xabc")
+	if !ok {
+		t.Fatal("expected forecast score at minimum sample size")
+	}
+	if score < 49 || score > 51 {
+		t.Errorf("expected ~50 for half-correct record, got %f", score)
+	}
+}
+
+func TestForecastScoreDecaysOldSamples(t *testing.T) {
+	// Ten fresh wrong calls against ten correct calls from two half-lives
+	// ago: the correct calls carry a quarter of the weight each, so
+	// accuracy lands around 10/(40+10) = 20%, well below a raw 50%.
+	samples := recentSamples(10, 0)
+	old := time.Now().Add(-2 * forecastHalfLife)
+	for i := 0; i < 10; i++ {
+		samples = append(samples, ForecastSample{Correct: true, SettledAt: old})
+	}
+
+	provider := NewRegistryProvider(nil).WithForecasts(&fakeForecasts{samples: samples})
+	score, ok := provider.forecastScore(context.Background(), "0xabc")
+	if !ok {
+		t.Fatal("expected forecast score")
+	}
+	if score < 15 || score > 25 {
+		t.Errorf("expected decayed accuracy ~20, got %f", score)
+	}
+}
+
+func TestBlendForecast(t *testing.T) {
+	if got := blendForecast(50, 100); got != 55 {
+		t.Errorf("expected 55, got %f", got)
+	}
+	if got := blendForecast(50, 0); got != 45 {
+		t.Errorf("expected 45, got %f", got)
+	}
+}
@@ -23,6 +23,7 @@ type RegistryProvider struct {
 	store           registry.Store
 	disputes        sync.Map                // address → *disputeRecord
 	traceRankScores tracerank.ScoreProvider // optional; nil if TraceRank not wired
+	forecasts       ForecastProvider        // optional; nil if predictions not wired
 }
 
 // NewRegistryProvider creates a provider backed by the registry
@@ -111,6 +112,7 @@ func (p *RegistryProvider) GetAllAgentMetrics(ctx context.Context) (map[string]*
 // GetScore returns the reputation score and tier for a single agent.
 // Implements registry.ReputationProvider.
 // If dispute data is available, a penalty is applied for high dispute rates.
+// If prediction-market data is available, forecast accuracy blends in.
 func (p *RegistryProvider) GetScore(ctx context.Context, address string) (float64, string, error) {
 	metrics, err := p.GetAgentMetrics(ctx, address)
 	if err != nil {
@@ -139,6 +141,14 @@ func (p *RegistryProvider) GetScore(ctx context.Context, address string) (float6
 		score.Tier = getTier(score.Score)
 	}
 
+	// Blend in prediction-market accuracy once the agent has enough
+	// settled wagers. See forecasts.go for weight, decay, and sample-size
+	// constants.
+	if forecast, ok := p.forecastScore(ctx, address); ok {
+		score.Score = blendForecast(score.Score, forecast)
+		score.Tier = getTier(score.Score)
+	}
+
 	return score.Score, string(score.Tier), nil
 }
 
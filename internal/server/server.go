@@ -29,18 +29,22 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/mbd888/alancoin/internal/admin"
 	"github.com/mbd888/alancoin/internal/arbitration"
 	"github.com/mbd888/alancoin/internal/auth"
 	"github.com/mbd888/alancoin/internal/billing"
+	"github.com/mbd888/alancoin/internal/blocklist"
 	"github.com/mbd888/alancoin/internal/cache"
 	"github.com/mbd888/alancoin/internal/chargeback"
 	"github.com/mbd888/alancoin/internal/circuitbreaker"
 	"github.com/mbd888/alancoin/internal/compliance"
 	"github.com/mbd888/alancoin/internal/config"
 	"github.com/mbd888/alancoin/internal/contracts"
+	"github.com/mbd888/alancoin/internal/credit"
 	"github.com/mbd888/alancoin/internal/dashboard"
+	"github.com/mbd888/alancoin/internal/erc4337"
 	"github.com/mbd888/alancoin/internal/escrow"
 	"github.com/mbd888/alancoin/internal/eventbus"
 	"github.com/mbd888/alancoin/internal/flywheel"
@@ -50,10 +54,14 @@ import (
 	"github.com/mbd888/alancoin/internal/intelligence"
 	"github.com/mbd888/alancoin/internal/kya"
 	"github.com/mbd888/alancoin/internal/ledger"
+	"github.com/mbd888/alancoin/internal/llm"
 	"github.com/mbd888/alancoin/internal/logging"
 	"github.com/mbd888/alancoin/internal/metrics"
+	"github.com/mbd888/alancoin/internal/multisig"
+	"github.com/mbd888/alancoin/internal/negotiation"
 	"github.com/mbd888/alancoin/internal/offers"
 	"github.com/mbd888/alancoin/internal/policy"
+	"github.com/mbd888/alancoin/internal/predictions"
 	"github.com/mbd888/alancoin/internal/ratelimit"
 	"github.com/mbd888/alancoin/internal/realtime"
 	"github.com/mbd888/alancoin/internal/receipts"
@@ -62,6 +70,7 @@ import (
 	"github.com/mbd888/alancoin/internal/reputation"
 	"github.com/mbd888/alancoin/internal/security"
 	"github.com/mbd888/alancoin/internal/sessionkeys"
+	"github.com/mbd888/alancoin/internal/stakes"
 	"github.com/mbd888/alancoin/internal/streams"
 	"github.com/mbd888/alancoin/internal/supervisor"
 	"github.com/mbd888/alancoin/internal/tenant"
@@ -69,6 +78,7 @@ import (
 	"github.com/mbd888/alancoin/internal/traces"
 	"github.com/mbd888/alancoin/internal/usdc"
 	"github.com/mbd888/alancoin/internal/validation"
+	"github.com/mbd888/alancoin/internal/wallet"
 	"github.com/mbd888/alancoin/internal/watcher"
 	"github.com/mbd888/alancoin/internal/webhooks"
 	"github.com/mbd888/alancoin/internal/withdrawals"
@@ -88,15 +98,27 @@ type Server struct {
 	ledger                 *ledger.Ledger
 	ledgerService          ledger.Service // supervised access for payment paths
 	webhooks               *webhooks.Dispatcher
+	webhookDeadLetters     webhooks.DeadLetterStore
 	realtimeHub            *realtime.Hub
+	realtimeBackplane      *realtime.Backplane // nil if Redis not configured
 	escrowService          *escrow.Service
 	escrowTimer            *escrow.Timer
+	blocklistService       *blocklist.Service
 	multiStepEscrowService *escrow.MultiStepService
 	coalitionService       *escrow.CoalitionService
 	coalitionTimer         *escrow.CoalitionTimer
 	contractService        *contracts.Service
+	agreementService       *contracts.AgreementService
+	agreementTimer         *contracts.AgreementTimer
 	offerService           *offers.Service
 	offerTimer             *offers.Timer
+	negotiationService     *negotiation.Service
+	negotiationTimer       *negotiation.Timer
+	stakesService          *stakes.Service
+	stakesTimer            *stakes.Timer
+	predictionsService     *predictions.Service
+	predictionsTimer       *predictions.Timer
+	smartAccounts          *erc4337.Service // ERC-4337 smart accounts + paymaster sponsorship
 	workflowService        *workflows.Service
 	streamService          *streams.Service
 	streamTimer            *streams.Timer
@@ -128,21 +150,26 @@ type Server struct {
 	denialExporter         admin.DenialExporter   // denial log exporter for admin API
 	reconcileRunner        *reconciliation.Runner // cross-subsystem reconciliation
 	reconcileTimer         *reconciliation.Timer  // periodic reconciliation
-	depositWatcher         *watcher.Watcher       // on-chain USDC deposit watcher (optional)
+	depositWatcher         *watcher.Multi         // on-chain USDC deposit watchers, one per chain (optional)
 	payoutService          *usdc.PayoutService    // on-chain USDC outbound payouts (optional)
 	payoutClient           *usdc.EthClient        // tracked so server can Close() on shutdown
 	withdrawalService      *withdrawals.Service   // agent-initiated withdrawals via ledger + payouts
+	multisigService        *multisig.Service      // Safe multisig routing for large fund movements (optional)
+	txManager              *wallet.Manager        // outbound tx nonce tracking + stuck-tx replacement (optional)
 	gatewayResolver        *gateway.Resolver      // stored so wireSubsystems can attach discovery boosters
 	policyEvaluator        *policy.Evaluator      // retained so compliance can attach a denial sink post-hoc
 	kyaService             *kya.Service           // KYA identity certificates
 	chargebackService      *chargeback.Service    // FinOps cost attribution
 	arbitrationService     *arbitration.Service   // Dispute resolution
 	forensicsService       *forensics.Service     // Spend anomaly detection
+	creditService          *credit.Service        // Credit installment plans
+	creditTimer            *credit.Timer          // Installment due-date enforcement
 	intelligenceStore      intelligence.Store     // Unified agent intelligence profiles
 	intelligenceWorker     *intelligence.Worker   // Periodic intelligence computation
 	eventBus               eventbus.Bus           // Settlement event bus (MemoryBus or KafkaBus)
 	eventBusMemory         *eventbus.MemoryBus    // Non-nil only when using MemoryBus (for DLQ admin endpoints)
 	outbox                 *eventbus.Outbox       // Transactional outbox for exactly-once event publishing
+	webhookOutbox          *webhooks.Outbox       // Transactional outbox for webhook dispatch
 	walStore               *eventbus.WALStore     // WAL for crash recovery (stored for cleanup worker)
 	db                     *sql.DB                // nil if using in-memory
 	redisClient            *cache.RedisClient     // nil if Redis not configured
@@ -207,6 +234,12 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 	// Create realtime hub for WebSocket streaming (before init blocks so adapters can reference it)
 	s.realtimeHub = realtime.NewHub(s.logger)
 	s.logger.Info("realtime streaming enabled")
+	if s.redisClient != nil {
+		// Cross-instance fanout: broadcasts reach clients connected to
+		// any instance behind the load balancer.
+		s.realtimeBackplane = realtime.NewBackplane(s.realtimeHub, s.redisClient.Client(), s.logger)
+		s.logger.Info("realtime redis backplane enabled")
+	}
 
 	// Initialize storage (Postgres if DATABASE_URL set, otherwise in-memory)
 	if cfg.DatabaseURL != "" {
@@ -228,7 +261,7 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		}
 
 		s.db = db
-		s.registry = registry.NewPostgresStore(db)
+		s.registry = registry.NewCachedStore(registry.NewPostgresStore(db), registry.DefaultListCacheTTL)
 		s.logger.Info("using PostgreSQL storage", "url", maskDSN(cfg.DatabaseURL))
 
 		// Session keys with Postgres
@@ -258,10 +291,17 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		s.ledger = ledger.NewWithEvents(ledgerStore, eventStore).
 			WithAuditLogger(auditLogger)
 		baselineStore := supervisor.NewPostgresBaselineStore(db)
+		declarativeRules := supervisor.NewDeclarativeRuleSet().
+			WithStore(supervisor.NewPostgresRuleStore(db)).
+			WithLogger(s.logger)
+		if err := declarativeRules.Load(ctx); err != nil {
+			s.logger.Warn("failed to load declarative rules from storage", "error", err)
+		}
 		s.ledgerService = supervisor.New(s.ledger,
 			supervisor.WithLogger(s.logger),
 			supervisor.WithBaselineStore(baselineStore),
 			supervisor.WithBalanceConcentration(),
+			supervisor.WithDeclarativeRules(declarativeRules),
 		)
 		s.denialExporter = &adminDenialExportAdapter{store: baselineStore}
 		s.eventWriter = supervisor.NewEventWriter(baselineStore, s.logger)
@@ -276,7 +316,10 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		if err := webhookStore.Migrate(ctx); err != nil {
 			s.logger.Warn("failed to migrate webhook store", "error", err)
 		}
-		s.webhooks = webhooks.NewDispatcher(webhookStore)
+		s.webhookDeadLetters = webhooks.NewPostgresDeadLetterStore(db)
+		s.webhooks = webhooks.NewDispatcher(webhookStore).
+			WithDeadLetterStore(s.webhookDeadLetters).
+			WithEventLog(webhooks.NewPostgresEventLog(db))
 		s.logger.Info("webhooks enabled")
 
 		// Escrow with PostgreSQL store
@@ -291,10 +334,25 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 			WithLogger(s.logger)
 		s.coalitionTimer = escrow.NewCoalitionTimer(s.coalitionService, coalitionStore, s.logger)
 		s.contractService = contracts.NewService(contracts.NewPostgresStore(db)).WithLogger(s.logger)
+		s.agreementService = contracts.NewAgreementService(
+			contracts.NewAgreementPostgresStore(db), s.ledgerService,
+		).WithLogger(s.logger)
+		s.agreementTimer = contracts.NewAgreementTimer(s.agreementService, s.logger)
 		offerStore := offers.NewPostgresStore(db)
 		s.offerService = offers.NewService(offerStore, &escrowLedgerAdapter{s.ledgerService}).
 			WithLogger(s.logger)
 		s.offerTimer = offers.NewTimer(s.offerService, s.logger)
+		s.negotiationService = negotiation.NewService(negotiation.NewPostgresStore(db)).
+			WithLogger(s.logger)
+		s.negotiationTimer = negotiation.NewTimer(s.negotiationService, s.logger)
+		s.stakesService = stakes.NewService(stakes.NewPostgresStore(db), s.ledgerService).
+			WithLogger(s.logger)
+		s.stakesTimer = stakes.NewTimer(s.stakesService, s.logger)
+		s.predictionsService = predictions.NewService(predictions.NewPostgresStore(db), s.ledgerService).
+			WithLogger(s.logger)
+		s.predictionsTimer = predictions.NewTimer(s.predictionsService, s.logger)
+		s.smartAccounts = erc4337.NewService(erc4337.NewPostgresStore(db)).
+			WithLogger(s.logger)
 		s.workflowService = workflows.NewService(workflows.NewPostgresStore(db), &escrowLedgerAdapter{s.ledgerService}).
 			WithLogger(s.logger)
 		s.logger.Info("escrow enabled (postgres)")
@@ -319,6 +377,11 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		s.forensicsService = forensics.NewService(forensics.NewPostgresStore(db), forensics.DefaultConfig(), s.logger)
 		s.logger.Info("forensics enabled (postgres)")
 
+		// Credit installment plans (postgres)
+		s.creditService = credit.NewService(credit.NewPostgresStore(db), ledgerStore, s.logger)
+		s.creditTimer = credit.NewTimer(s.creditService, s.logger)
+		s.logger.Info("credit installments enabled (postgres)")
+
 		// Streams with PostgreSQL store (streaming micropayments)
 		streamStore := streams.NewPostgresStore(db)
 		s.streamService = streams.NewService(streamStore, &streamLedgerAdapter{s.ledgerService})
@@ -334,6 +397,14 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		s.receiptService = receipts.NewService(receiptStore, receiptSigner)
 		s.logger.Info("receipt signing enabled (postgres)")
 
+		// Safe multisig routing (optional — gated by SAFE_ADDRESS).
+		// Built before payouts so initPayoutService can wire the router.
+		if cfg.SafeAddress != "" {
+			if err := s.initMultisigService(cfg, multisig.NewPostgresStore(db)); err != nil {
+				s.logger.Warn("multisig routing disabled", "reason", err)
+			}
+		}
+
 		// Outbound USDC payouts (optional — gated by PAYOUTS_ENABLED).
 		if cfg.PayoutsEnabled {
 			payoutStore := usdc.NewPostgresPayoutStore(db)
@@ -472,7 +543,7 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		s.logger.Info("reconciliation enabled (postgres)")
 
 	} else {
-		s.registry = registry.NewMemoryStore()
+		s.registry = registry.NewCachedStore(registry.NewMemoryStore(), registry.DefaultListCacheTTL)
 		s.logger.Info("using in-memory storage (data will not persist)")
 
 		// Session keys with in-memory store
@@ -494,11 +565,18 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		s.ledgerService = supervisor.New(s.ledger,
 			supervisor.WithLogger(s.logger),
 			supervisor.WithBalanceConcentration(),
+			supervisor.WithDeclarativeRules(supervisor.NewDeclarativeRuleSet()),
 		)
+		// Without Postgres, admin-authored risk rules live in process
+		// memory only and are lost on restart.
+		s.logger.Warn("declarative risk rules are in-memory; rules reset on restart")
 		s.logger.Info("agent balance tracking enabled (in-memory)")
 
 		// Webhooks with in-memory store
-		s.webhooks = webhooks.NewDispatcher(webhooks.NewMemoryStore())
+		s.webhookDeadLetters = webhooks.NewMemoryDeadLetterStore()
+		s.webhooks = webhooks.NewDispatcher(webhooks.NewMemoryStore()).
+			WithDeadLetterStore(s.webhookDeadLetters).
+			WithEventLog(webhooks.NewMemoryEventLog())
 
 		// Escrow with in-memory store
 		escrowStore := escrow.NewMemoryStore()
@@ -512,10 +590,25 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 			WithLogger(s.logger)
 		s.coalitionTimer = escrow.NewCoalitionTimer(s.coalitionService, coalitionStore, s.logger)
 		s.contractService = contracts.NewService(contracts.NewMemoryStore()).WithLogger(s.logger)
+		s.agreementService = contracts.NewAgreementService(
+			contracts.NewAgreementMemoryStore(), s.ledgerService,
+		).WithLogger(s.logger)
+		s.agreementTimer = contracts.NewAgreementTimer(s.agreementService, s.logger)
 		offerStore := offers.NewMemoryStore()
 		s.offerService = offers.NewService(offerStore, &escrowLedgerAdapter{s.ledgerService}).
 			WithLogger(s.logger)
 		s.offerTimer = offers.NewTimer(s.offerService, s.logger)
+		s.negotiationService = negotiation.NewService(negotiation.NewMemoryStore()).
+			WithLogger(s.logger)
+		s.negotiationTimer = negotiation.NewTimer(s.negotiationService, s.logger)
+		s.stakesService = stakes.NewService(stakes.NewMemoryStore(), s.ledgerService).
+			WithLogger(s.logger)
+		s.stakesTimer = stakes.NewTimer(s.stakesService, s.logger)
+		s.predictionsService = predictions.NewService(predictions.NewMemoryStore(), s.ledgerService).
+			WithLogger(s.logger)
+		s.predictionsTimer = predictions.NewTimer(s.predictionsService, s.logger)
+		s.smartAccounts = erc4337.NewService(erc4337.NewMemoryStore()).
+			WithLogger(s.logger)
 		s.workflowService = workflows.NewService(workflows.NewMemoryStore(), &escrowLedgerAdapter{s.ledgerService}).
 			WithLogger(s.logger)
 		s.logger.Info("escrow enabled (in-memory)")
@@ -540,6 +633,11 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		s.forensicsService = forensics.NewService(forensics.NewMemoryStore(), forensics.DefaultConfig(), s.logger)
 		s.logger.Info("forensics enabled (in-memory)")
 
+		// Credit installment plans (in-memory)
+		s.creditService = credit.NewService(credit.NewMemoryStore(), memStore, s.logger)
+		s.creditTimer = credit.NewTimer(s.creditService, s.logger)
+		s.logger.Info("credit installments enabled (in-memory)")
+
 		// Streams with in-memory store (streaming micropayments)
 		streamStore := streams.NewMemoryStore()
 		s.streamService = streams.NewService(streamStore, &streamLedgerAdapter{s.ledgerService})
@@ -551,6 +649,14 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 		s.receiptService = receipts.NewService(receipts.NewMemoryStore(), receiptSigner)
 		s.logger.Info("receipt signing enabled (in-memory)")
 
+		// Safe multisig routing (optional — gated by SAFE_ADDRESS).
+		// Built before payouts so initPayoutService can wire the router.
+		if cfg.SafeAddress != "" {
+			if err := s.initMultisigService(cfg, multisig.NewMemoryStore()); err != nil {
+				s.logger.Warn("multisig routing disabled", "reason", err)
+			}
+		}
+
 		// Outbound USDC payouts (optional — gated by PAYOUTS_ENABLED).
 		if cfg.PayoutsEnabled {
 			if err := s.initPayoutService(ctx, cfg, usdc.NewMemoryPayoutStore()); err != nil {
@@ -678,30 +784,69 @@ func New(cfg *config.Config, opts ...Option) (*Server, error) {
 
 	s.logger.Info("API authentication enabled")
 
-	// Initialize deposit watcher (optional — only if explicitly enabled)
-	if cfg.DepositWatcherEnabled {
-		var checkpoint watcher.CheckpointStore
-		if s.db != nil {
-			checkpoint = watcher.NewPostgresCheckpoint(s.db, "deposit_watcher")
+	// Wire the smart-account paymaster: session keys gate sponsorship, and
+	// the platform key signs paymasterAndData. Without a parseable key the
+	// service still serves registrations but declines sponsorship.
+	if s.smartAccounts != nil {
+		s.smartAccounts.
+			WithChainID(cfg.ChainID).
+			WithSessionValidator(&erc4337SessionAdapter{mgr: s.sessionMgr})
+		if key, err := ethcrypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x")); err != nil {
+			s.logger.Warn("smart-account paymaster key unavailable", "error", err)
 		} else {
-			checkpoint = watcher.NewMemoryCheckpoint()
-		}
-		watcherCfg := watcher.Config{
-			RPCURL:          cfg.RPCURL,
-			USDCContract:    common.HexToAddress(cfg.USDCContract),
-			PlatformAddress: common.HexToAddress(cfg.WalletAddress),
-			StartBlock:      cfg.DepositWatcherStart,
-		}
-		s.depositWatcher = watcher.New(
-			watcherCfg,
-			&watcherCreditorAdapter{store: s.ledger.StoreRef()},
-			&watcherAgentResolverAdapter{reg: s.registry},
-			checkpoint,
-			s.logger,
-		)
-		s.logger.Info("deposit watcher configured",
-			"rpc_url", cfg.RPCURL,
-			"usdc_contract", cfg.USDCContract)
+			s.smartAccounts.WithPaymasterKey(key)
+		}
+	}
+
+	// Initialize deposit watchers (optional — only if explicitly enabled).
+	// With DEPOSIT_WATCHER_CHAINS set, one watcher runs per configured chain;
+	// otherwise a single watcher uses the top-level RPC settings.
+	if cfg.DepositWatcherEnabled {
+		chains := cfg.DepositChains
+		if len(chains) == 0 {
+			chains = []config.DepositChain{{
+				RPCURL:       cfg.RPCURL,
+				WSURL:        cfg.WSURL,
+				USDCContract: cfg.USDCContract,
+				StartBlock:   cfg.DepositWatcherStart,
+			}}
+		}
+		watchers := make([]*watcher.Watcher, 0, len(chains))
+		for _, ch := range chains {
+			checkpointKey := "deposit_watcher"
+			if ch.Name != "" {
+				checkpointKey = "deposit_watcher:" + ch.Name
+			}
+			var checkpoint watcher.CheckpointStore
+			if s.db != nil {
+				checkpoint = watcher.NewPostgresCheckpoint(s.db, checkpointKey)
+			} else {
+				checkpoint = watcher.NewMemoryCheckpoint()
+			}
+			depositAddr := ch.DepositAddress
+			if depositAddr == "" {
+				depositAddr = cfg.WalletAddress
+			}
+			watchers = append(watchers, watcher.New(
+				watcher.Config{
+					Chain:           ch.Name,
+					RPCURL:          ch.RPCURL,
+					WSURL:           ch.WSURL,
+					USDCContract:    common.HexToAddress(ch.USDCContract),
+					PlatformAddress: common.HexToAddress(depositAddr),
+					StartBlock:      ch.StartBlock,
+				},
+				&watcherCreditorAdapter{store: s.ledger.StoreRef()},
+				&watcherAgentResolverAdapter{reg: s.registry},
+				checkpoint,
+				s.logger,
+			))
+			s.logger.Info("deposit watcher configured",
+				"chain", ch.Name,
+				"rpc_url", ch.RPCURL,
+				"usdc_contract", ch.USDCContract)
+		}
+		s.depositWatcher = watcher.NewMulti(watchers, s.logger)
 	} else {
 		s.logger.Warn("deposit watcher not enabled (set DEPOSIT_WATCHER_ENABLED=true to enable)")
 	}
@@ -934,14 +1079,45 @@ func (s *Server) setupRoutes() {
 			})
 			return
 		}
-		if _, err := s.authMgr.ValidateKey(c.Request.Context(), apiKey); err != nil {
+		key, err := s.authMgr.ValidateKey(c.Request.Context(), apiKey)
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error":   "unauthorized",
 				"message": "Invalid API key.",
 			})
 			return
 		}
-		s.realtimeHub.HandleWebSocket(c.Writer, c.Request)
+		// Bind the key's agent to the connection so it receives its
+		// private events (escrows, session-key usage, balance changes).
+		s.realtimeHub.HandleWebSocketAuthed(c.Writer, c.Request, key.AgentAddr)
+	})
+
+	// SSE fallback mirroring the WebSocket stream, for environments where
+	// WebSockets are impractical. Same auth; resume via Last-Event-ID.
+	s.router.GET("/v1/events", func(c *gin.Context) {
+		apiKey := c.GetHeader("Authorization")
+		if apiKey == "" {
+			apiKey = c.GetHeader("X-API-Key")
+		}
+		if apiKey == "" {
+			apiKey = c.Query("token")
+		}
+		if apiKey == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "API key required. Pass 'Authorization' header or 'token' query parameter.",
+			})
+			return
+		}
+		key, err := s.authMgr.ValidateKey(c.Request.Context(), apiKey)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "Invalid API key.",
+			})
+			return
+		}
+		s.realtimeHub.ServeSSE(c.Writer, c.Request, key.AgentAddr)
 	})
 
 	// API info endpoints
@@ -966,13 +1142,60 @@ func (s *Server) setupRoutes() {
 		reputationProvider.WithTraceRank(traceRankScoreProvider)
 	}
 
+	// Wire prediction-market accuracy into reputation scoring
+	if s.predictionsService != nil {
+		reputationProvider.WithForecasts(&predictionForecastAdapter{s.predictionsService})
+	}
+
 	registryHandler.SetReputation(reputationProvider)
 
+	// Wire the LLM provider into natural-language search. The "local"
+	// provider keeps search working rule-based with no API key.
+	if llmProvider, err := llm.New(llm.Config{
+		Provider:         s.cfg.LLMProvider,
+		APIKey:           s.cfg.LLMAPIKey,
+		Model:            s.cfg.LLMModel,
+		BaseURL:          s.cfg.LLMBaseURL,
+		CacheTTL:         s.cfg.LLMCacheTTL,
+		DailyTokenBudget: s.cfg.LLMDailyTokenBudget,
+	}); err != nil {
+		s.logger.Warn("LLM provider unavailable, search recommendations are rule-based", "error", err)
+	} else {
+		registryHandler.SetRecommender(llmProvider)
+		s.logger.Info("Search recommendations enabled", "provider", llmProvider.Name())
+	}
+
+	// Personalized search: bias results by the caller's payment history,
+	// with escrow outcomes layered over the registry transaction log.
+	registryHandler.SetPurchaseHistory(&searchHistoryAdapter{
+		txHistory: registry.NewTransactionHistoryProvider(s.registry),
+		escrows:   s.escrowService,
+	})
+
+	// Dispute rates for the service comparison view, derived from
+	// escrow outcomes on the seller side
+	registryHandler.SetDisputeRates(&escrowDisputeRateAdapter{escrows: s.escrowService})
+
+	// Search analytics: anonymized query aggregates for operators
+	registryHandler.SetAnalytics(registry.NewSearchAnalytics())
+
+	// Saved searches: alert agents when a new or repriced service
+	// matches their standing criteria
+	registryHandler.SetSavedSearches(registry.NewSavedSearches().
+		WithNotifier(&savedSearchNotifier{emitter: s.webhookEmitter, hub: s.realtimeHub}))
+
 	// Wire reputation into supervisor so spending rules are tier-aware
 	if sv, ok := s.ledgerService.(*supervisor.Supervisor); ok {
 		sv.SetReputation(reputationProvider)
 	}
 
+	// Wire reputation into negotiation so bidding strategies can enforce
+	// buyer score floors
+	if s.negotiationService != nil {
+		s.negotiationService.WithReputation(reputationProvider)
+		s.negotiationService.WithReputationImpactor(reputationProvider)
+	}
+
 	// Wire reputation impact tracking into escrow (dispute/confirm outcomes)
 	s.escrowService.WithReputationImpactor(reputationProvider)
 	if s.coalitionService != nil {
@@ -984,13 +1207,26 @@ func (s *Server) setupRoutes() {
 		s.escrowService.WithTrustGate(&kyaTrustGateAdapter{s.kyaService})
 	}
 
+	// Wire registry-backed disclosures into stake offerings
+	if s.stakesService != nil {
+		s.stakesService.WithDisclosures(&stakesDisclosureAdapter{
+			reg:    s.registry,
+			rep:    reputationProvider,
+			kyaSvc: s.kyaService,
+		})
+	}
+
 	// PUBLIC ROUTES (no auth required)
 	// These are the discovery/read endpoints
 	v1.GET("/platform", s.platformHandler)
 	v1.GET("/agents", cacheControl(30), registryHandler.ListAgents)
 	v1.GET("/agents/:address", cacheControl(15), registryHandler.GetAgent)
 	v1.GET("/services", cacheControl(30), registryHandler.DiscoverServices)
+	v1.GET("/services/compare", cacheControl(15), registryHandler.CompareServices)
+	v1.GET("/search", registryHandler.Search)
+	v1.POST("/search/select", registryHandler.SelectSearchResult)
 	v1.GET("/agents/:address/transactions", registryHandler.ListTransactions)
+	v1.GET("/agents/:address/presence", s.getPresence)
 	v1.GET("/network/stats", cacheControl(60), registryHandler.GetNetworkStats)
 	v1.GET("/network/stats/enhanced", cacheControl(60), s.enhancedStatsHandler) // Demo-friendly extended stats
 	v1.GET("/feed", cacheControl(10), registryHandler.GetPublicFeed)
@@ -1015,11 +1251,25 @@ func (s *Server) setupRoutes() {
 		// Agent mutations (must own the agent)
 		protected.DELETE("/agents/:address", auth.RequireOwnership(s.authMgr, "address"), registryHandler.DeleteAgent)
 
+		// Presence heartbeat for agents without a realtime connection
+		protected.POST("/agents/:address/presence/heartbeat", auth.RequireOwnership(s.authMgr, "address"), s.postHeartbeat)
+
 		// Service management (must own the agent)
 		protected.POST("/agents/:address/services", auth.RequireOwnership(s.authMgr, "address"), registryHandler.AddService)
 		protected.PUT("/agents/:address/services/:serviceId", auth.RequireOwnership(s.authMgr, "address"), registryHandler.UpdateService)
 		protected.DELETE("/agents/:address/services/:serviceId", auth.RequireOwnership(s.authMgr, "address"), registryHandler.RemoveService)
 
+		// Saved searches with match alerts (must own the agent)
+		protected.POST("/agents/:address/saved-searches", auth.RequireOwnership(s.authMgr, "address"), registryHandler.CreateSavedSearch)
+		protected.GET("/agents/:address/saved-searches", auth.RequireOwnership(s.authMgr, "address"), registryHandler.ListSavedSearches)
+		protected.DELETE("/agents/:address/saved-searches/:searchId", auth.RequireOwnership(s.authMgr, "address"), registryHandler.DeleteSavedSearch)
+
+		// Personal counterparty denylists (must own the agent)
+		blocklistHandler := blocklist.NewHandler(s.blocklistService)
+		protected.POST("/agents/:address/blocklist", auth.RequireOwnership(s.authMgr, "address"), blocklistHandler.AddDenylistEntry)
+		protected.GET("/agents/:address/blocklist", auth.RequireOwnership(s.authMgr, "address"), blocklistHandler.ListDenylist)
+		protected.DELETE("/agents/:address/blocklist/:counterparty", auth.RequireOwnership(s.authMgr, "address"), blocklistHandler.RemoveDenylistEntry)
+
 		// API key management
 		protected.GET("/auth/keys", authHandler.ListKeys)
 		protected.POST("/auth/keys", authHandler.CreateKey)
@@ -1203,6 +1453,23 @@ func (s *Server) setupRoutes() {
 		protectedCredit.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireOwnership(s.authMgr, "address"))
 		protectedCredit.POST("/agents/:address/credit/apply", ledgerHandler.ApplyForCredit)
 
+		// Installment plan routes (owner-scoped creation/listing, authed plan ops)
+		if s.creditService != nil {
+			ledgerHandler.WithReviewQueue(s.creditService)
+
+			creditHandler := credit.NewHandler(s.creditService)
+			creditHandler.WithReportSigner(reputation.NewSigner(s.cfg.ReputationHMACSecret))
+			creditHandler.RegisterRoutes(protectedCredit)
+
+			authedPlans := v1.Group("")
+			authedPlans.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+			creditHandler.RegisterPlanRoutes(authedPlans)
+
+			adminCreditReview := v1.Group("")
+			adminCreditReview.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAdmin())
+			creditHandler.RegisterAdminRoutes(adminCreditReview)
+		}
+
 		// Protected ledger routes
 		protectedLedger := v1.Group("")
 		protectedLedger.Use(auth.Middleware(s.authMgr), tenantRL)
@@ -1258,10 +1525,30 @@ func (s *Server) setupRoutes() {
 		adminOps.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAdmin())
 		adminHandler.RegisterRoutes(adminOps)
 
+		// Search demand analytics (admin-only)
+		adminOps.GET("/admin/search/analytics", registryHandler.SearchAnalyticsReport)
+
+		// Declarative risk rules (admin-only): CRUD, toggles, backtests
+		if sv, ok := s.ledgerService.(*supervisor.Supervisor); ok {
+			if set := sv.DeclarativeRules(); set != nil {
+				supervisor.NewRulesHandler(set).
+					WithHistory(sv.BaselineHistory()).
+					RegisterAdminRoutes(adminOps)
+			}
+		}
+
+		// Global counterparty blocklist (admin-only): CRUD, bulk import, audit trail
+		blocklist.NewHandler(s.blocklistService).RegisterAdminRoutes(adminOps)
+
 		// Outbound USDC payouts (registers 503 stubs when disabled so
 		// callers can tell "disabled" from "unreachable").
 		usdc.NewPayoutHandler(s.payoutService).RegisterRoutes(adminOps)
 
+		// Tracked outbound transaction status (nonce/fee-bump observability)
+		if s.txManager != nil {
+			wallet.NewHandler(s.txManager).RegisterRoutes(adminOps)
+		}
+
 		// Event bus observability (admin-only)
 		if s.eventBus != nil {
 			adminOps.GET("/admin/eventbus/stats", func(c *gin.Context) {
@@ -1292,6 +1579,14 @@ func (s *Server) setupRoutes() {
 		withdrawals.NewHandler(s.withdrawalService).RegisterRoutes(protectedWithdraw)
 	}
 
+	// Safe multisig proposal/approval flow (admin-gated; approver identity
+	// still comes from the authenticated agent address)
+	if s.multisigService != nil {
+		adminMultisig := v1.Group("")
+		adminMultisig.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAdmin())
+		multisig.NewHandler(s.multisigService).RegisterRoutes(adminMultisig)
+	}
+
 	// Reputation routes
 	// reputationProvider is already created above for discovery enrichment
 	//
@@ -1397,6 +1692,9 @@ func (s *Server) setupRoutes() {
 			webhookStore = webhooks.NewMemoryStore()
 		}
 		webhookHandler := webhooks.NewHandler(webhookStore, s.webhooks)
+		if s.webhookDeadLetters != nil {
+			webhookHandler = webhookHandler.WithDeadLetters(s.webhookDeadLetters)
+		}
 
 		// Protected webhook management routes
 		protectedWebhooks := v1.Group("")
@@ -1405,6 +1703,12 @@ func (s *Server) setupRoutes() {
 			protectedWebhooks.POST("/agents/:address/webhooks", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.CreateWebhook)
 			protectedWebhooks.GET("/agents/:address/webhooks", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.ListWebhooks)
 			protectedWebhooks.DELETE("/agents/:address/webhooks/:webhookId", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.DeleteWebhook)
+			protectedWebhooks.POST("/agents/:address/webhooks/:webhookId/rotate-secret", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.RotateSecret)
+			protectedWebhooks.GET("/agents/:address/webhooks/:webhookId/deliveries", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.ListDeliveries)
+			protectedWebhooks.POST("/agents/:address/webhooks/:webhookId/replay", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.ReplayWebhook)
+			protectedWebhooks.POST("/agents/:address/webhooks/:webhookId/enable", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.EnableWebhook)
+			protectedWebhooks.GET("/agents/:address/webhooks/dead-letters", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.ListDeadLetters)
+			protectedWebhooks.POST("/agents/:address/webhooks/dead-letters/:deadLetterId/redeliver", auth.RequireOwnership(s.authMgr, "address"), webhookHandler.RedeliverDeadLetter)
 		}
 	}
 
@@ -1466,6 +1770,15 @@ func (s *Server) setupRoutes() {
 		contractHandler.RegisterProtectedRoutes(protectedContracts)
 	}
 
+	// Service agreement routes (per-call buyer/seller contracts)
+	if s.agreementService != nil {
+		agreementHandler := contracts.NewAgreementHandler(s.agreementService)
+
+		authedAgreements := v1.Group("")
+		authedAgreements.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+		agreementHandler.RegisterRoutes(authedAgreements)
+	}
+
 	// Standing offers / marketplace routes
 	if s.offerService != nil {
 		offerHandler := offers.NewHandler(s.offerService)
@@ -1479,6 +1792,57 @@ func (s *Server) setupRoutes() {
 		offerHandler.RegisterProtectedRoutes(protectedOffers)
 	}
 
+	// RFP auction routes
+	if s.negotiationService != nil {
+		negotiationHandler := negotiation.NewHandler(s.negotiationService)
+
+		authedRFPs := v1.Group("")
+		authedRFPs.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+		negotiationHandler.RegisterRoutes(authedRFPs)
+
+		protectedRFPs := v1.Group("")
+		protectedRFPs.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+		negotiationHandler.RegisterProtectedRoutes(protectedRFPs)
+	}
+
+	// Revenue-share stakes routes
+	if s.stakesService != nil {
+		stakesHandler := stakes.NewHandler(s.stakesService)
+
+		authedStakes := v1.Group("")
+		authedStakes.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+		stakesHandler.RegisterRoutes(authedStakes)
+
+		protectedStakes := v1.Group("")
+		protectedStakes.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+		stakesHandler.RegisterProtectedRoutes(protectedStakes)
+	}
+
+	// Prediction market routes
+	if s.predictionsService != nil {
+		predictionsHandler := predictions.NewHandler(s.predictionsService)
+
+		authedPredictions := v1.Group("")
+		authedPredictions.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+		predictionsHandler.RegisterRoutes(authedPredictions)
+
+		protectedPredictions := v1.Group("")
+		protectedPredictions.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+		predictionsHandler.RegisterProtectedRoutes(protectedPredictions)
+
+		adminPredictions := v1.Group("")
+		adminPredictions.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAdmin())
+		predictionsHandler.RegisterAdminRoutes(adminPredictions)
+	}
+
+	// Smart accounts (ERC-4337) — registration and paymaster sponsorship
+	if s.smartAccounts != nil {
+		smartAccountsHandler := erc4337.NewHandler(s.smartAccounts)
+		protectedSmartAccounts := v1.Group("")
+		protectedSmartAccounts.Use(auth.Middleware(s.authMgr), tenantRL, auth.RequireAuth(s.authMgr))
+		smartAccountsHandler.RegisterProtectedRoutes(protectedSmartAccounts)
+	}
+
 	// Workflow budget management routes (enterprise cost attribution)
 	if s.workflowService != nil {
 		wfHandler := workflows.NewHandler(s.workflowService)
@@ -1543,6 +1907,9 @@ func (s *Server) setupRoutes() {
 
 	// Streaming micropayment routes (per-tick payments for continuous services)
 	if s.streamService != nil {
+		if s.reputationSigner != nil {
+			s.streamService.WithCheckpointSigner(s.reputationSigner)
+		}
 		streamHandler := streams.NewHandler(s.streamService)
 		if s.sessionMgr != nil {
 			streamHandler = streamHandler.WithScopeChecker(s.sessionMgr)
@@ -1746,6 +2113,9 @@ func (s *Server) readinessHandler(c *gin.Context) {
 	checks["escrow_timer"] = timerStatus(s.escrowTimer)
 	checks["coalition_timer"] = timerStatus(s.coalitionTimer)
 	checks["offers_timer"] = timerStatus(s.offerTimer)
+	checks["negotiation_timer"] = timerStatus(s.negotiationTimer)
+	checks["stakes_timer"] = timerStatus(s.stakesTimer)
+	checks["predictions_timer"] = timerStatus(s.predictionsTimer)
 	checks["stream_timer"] = timerStatus(s.streamTimer)
 	checks["gateway_timer"] = timerStatus(s.gatewayTimer)
 	checks["reconcile_timer"] = timerStatus(s.reconcileTimer)
@@ -1816,10 +2186,7 @@ func (s *Server) platformHandler(c *gin.Context) {
 // enhancedStatsHandler returns extended network stats for demos
 // Aggregates data from multiple sources: registry, session keys, gas
 func (s *Server) enhancedStatsHandler(c *gin.Context) {
-	ctx := c.Request.Context()
-
-	// Get base stats from registry
-	baseStats, err := s.registry.GetNetworkStats(ctx)
+	enhanced, err := s.collectEnhancedStats(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "internal_error",
@@ -1827,9 +2194,20 @@ func (s *Server) enhancedStatsHandler(c *gin.Context) {
 		})
 		return
 	}
+	c.JSON(http.StatusOK, enhanced)
+}
+
+// collectEnhancedStats aggregates the enhanced-stats payload shared by
+// the HTTP endpoint and the periodic realtime stats tick.
+func (s *Server) collectEnhancedStats(ctx context.Context) (map[string]interface{}, error) {
+	// Get base stats from registry
+	baseStats, err := s.registry.GetNetworkStats(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Build enhanced response
-	enhanced := gin.H{
+	enhanced := map[string]interface{}{
 		"totalAgents":       baseStats.TotalAgents,
 		"totalServices":     baseStats.TotalServices,
 		"totalTransactions": baseStats.TotalTransactions,
@@ -1845,7 +2223,44 @@ func (s *Server) enhancedStatsHandler(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, enhanced)
+	// Add open escrow count
+	if s.escrowService != nil {
+		open, err := s.escrowService.CountOpen(ctx)
+		if err == nil {
+			enhanced["openEscrows"] = open
+		}
+	}
+
+	return enhanced, nil
+}
+
+// statsTickInterval is how often the stats tick is broadcast on the
+// realtime "stats" topic.
+const statsTickInterval = 15 * time.Second
+
+// broadcastStatsTicks periodically pushes enhanced stats to realtime
+// subscribers so dashboards can update without polling.
+func (s *Server) broadcastStatsTicks(ctx context.Context) {
+	ticker := time.NewTicker(statsTickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats, err := s.collectEnhancedStats(ctx)
+			if err != nil {
+				s.logger.Debug("stats tick skipped", "error", err)
+				continue
+			}
+			s.realtimeHub.Broadcast(&realtime.Event{
+				Type:      realtime.EventNetworkStats,
+				Timestamp: time.Now(),
+				Data:      stats,
+			})
+		}
+	}
 }
 
 // -----------------------------------------------------------------------------
@@ -1885,6 +2300,12 @@ func (s *Server) Run(ctx context.Context) error {
 	if s.realtimeHub != nil {
 		go s.realtimeHub.Run(runCtx)
 	}
+	if s.realtimeBackplane != nil {
+		go s.realtimeBackplane.Run(runCtx)
+	}
+	if s.realtimeHub != nil {
+		go s.broadcastStatsTicks(runCtx)
+	}
 
 	// Start event bus (settlement event consumers)
 	if s.eventBus != nil {
@@ -1897,6 +2318,12 @@ func (s *Server) Run(ctx context.Context) error {
 		s.logger.Info("outbox poller started (100ms interval)")
 	}
 
+	// Start webhook outbox relay (dispatches events written in state-change transactions)
+	if s.webhookOutbox != nil && s.webhooks != nil {
+		go s.webhookOutbox.Relay(runCtx, s.webhooks, time.Second)
+		s.logger.Info("webhook outbox relay started (1s interval)")
+	}
+
 	// Start CDC watcher (ledger → event bus)
 	if s.db != nil && s.eventBus != nil && s.cfg.CDCEnabled {
 		cdc := eventbus.NewCDC(s.db, s.eventBus, s.logger)
@@ -1919,6 +2346,11 @@ func (s *Server) Run(ctx context.Context) error {
 		s.logger.Info("eventbus cleanup worker started (1h interval)")
 	}
 
+	// Start wallet transaction manager (stuck-tx detection + replacement)
+	if s.txManager != nil {
+		go s.txManager.Start(runCtx)
+	}
+
 	// Start deposit watcher
 	if s.depositWatcher != nil {
 		go func() {
@@ -1943,11 +2375,36 @@ func (s *Server) Run(ctx context.Context) error {
 		go s.offerTimer.Start(runCtx)
 	}
 
+	// Start RFP deadline timer
+	if s.negotiationTimer != nil {
+		go s.negotiationTimer.Start(runCtx)
+	}
+
+	// Start stake distribution timer
+	if s.stakesTimer != nil {
+		go s.stakesTimer.Start(runCtx)
+	}
+
+	// Start prediction deadline enforcement timer
+	if s.predictionsTimer != nil {
+		go s.predictionsTimer.Start(runCtx)
+	}
+
 	// Start stream stale-close timer
 	if s.streamTimer != nil {
 		go s.streamTimer.Start(runCtx)
 	}
 
+	// Start credit installment timer
+	if s.creditTimer != nil {
+		go s.creditTimer.Start(runCtx)
+	}
+
+	// Start service agreement expiry timer
+	if s.agreementTimer != nil {
+		go s.agreementTimer.Start(runCtx)
+	}
+
 	// Start gateway session expiry timer
 	if s.gatewayTimer != nil {
 		go s.gatewayTimer.Start(runCtx)
@@ -2077,6 +2534,12 @@ drainLoop:
 		s.logger.Info("deposit watcher stopped")
 	}
 
+	// Stop wallet transaction manager
+	if s.txManager != nil {
+		s.txManager.Stop()
+		s.logger.Info("transaction manager stopped")
+	}
+
 	// Release the payout RPC connection; safe to call when unused.
 	if s.payoutClient != nil {
 		s.payoutClient.Close()
@@ -2101,6 +2564,30 @@ drainLoop:
 		s.logger.Info("offers timer stopped")
 	}
 
+	// Stop RFP deadline timer
+	if s.negotiationTimer != nil {
+		s.negotiationTimer.Stop()
+		s.logger.Info("negotiation timer stopped")
+	}
+
+	// Stop stake distribution timer
+	if s.stakesTimer != nil {
+		s.stakesTimer.Stop()
+		s.logger.Info("stakes timer stopped")
+	}
+
+	// Stop prediction deadline enforcement timer
+	if s.predictionsTimer != nil {
+		s.predictionsTimer.Stop()
+		s.logger.Info("predictions timer stopped")
+	}
+
+	// Stop service agreement timer
+	if s.agreementTimer != nil {
+		s.agreementTimer.Stop()
+		s.logger.Info("agreement timer stopped")
+	}
+
 	// Stop stream timer
 	if s.streamTimer != nil {
 		s.streamTimer.Stop()
@@ -2397,6 +2884,19 @@ func sortTimelineItems(items []TimelineItem) {
 	}
 }
 
+// getPresence reports whether an agent currently has realtime
+// connections or a recent heartbeat. Public: buyers use it to prefer
+// sellers that are actually online.
+func (s *Server) getPresence(c *gin.Context) {
+	c.JSON(http.StatusOK, s.realtimeHub.Presence(c.Param("address")))
+}
+
+// postHeartbeat keeps a poll-only agent's presence alive.
+func (s *Server) postHeartbeat(c *gin.Context) {
+	s.realtimeHub.Heartbeat(c.Param("address"))
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
 // realtimeEventEmitter adapts realtime.Hub to sessionkeys.EventEmitter
 type realtimeEventEmitter struct {
 	hub *realtime.Hub
@@ -2410,19 +2910,53 @@ func (e *realtimeEventEmitter) EmitTransaction(tx map[string]interface{}) {
 
 func (e *realtimeEventEmitter) EmitSessionKeyUsed(keyID, agentAddr, amount string) {
 	if e.hub != nil {
-		e.hub.Broadcast(&realtime.Event{
-			Type:      realtime.EventTransaction,
-			Timestamp: time.Now(),
-			Data: map[string]interface{}{
-				"sessionKeyId": keyID,
-				"agentAddr":    agentAddr,
-				"amount":       amount,
-				"event":        "session_key_used",
-			},
+		// Session-key usage is the owner's business, not the public
+		// firehose's — deliver on their private channel only.
+		e.hub.BroadcastPrivate(agentAddr, realtime.EventSessionKeyUsage, map[string]interface{}{
+			"sessionKeyId": keyID,
+			"agentAddr":    agentAddr,
+			"amount":       amount,
+			"event":        "session_key_used",
 		})
 	}
 }
 
+// ledgerActivityNotifier adapts realtime.Hub to ledger.ActivityListener:
+// every balance-affecting ledger event lands on the owning agent's
+// private channel, so dashboards can show live activity without
+// polling or filtering the public feed.
+type ledgerActivityNotifier struct {
+	hub *realtime.Hub
+}
+
+func (n *ledgerActivityNotifier) OnLedgerEvent(agentAddr, eventType, amount, reference, counterparty string) {
+	data := map[string]interface{}{
+		"event":  eventType,
+		"amount": amount,
+	}
+	if reference != "" {
+		data["reference"] = reference
+	}
+	if counterparty != "" {
+		data["counterparty"] = counterparty
+	}
+	n.hub.BroadcastPrivate(agentAddr, realtime.EventBalanceChange, data)
+}
+
+// webhookFailureNotifier adapts realtime.Hub to webhooks.FailureListener.
+type webhookFailureNotifier struct {
+	hub *realtime.Hub
+}
+
+func (n *webhookFailureNotifier) OnDeliveryFailure(agentAddr, webhookID, url, lastError string, consecutiveFailures int) {
+	n.hub.BroadcastPrivate(agentAddr, realtime.EventWebhookFailure, map[string]interface{}{
+		"webhookId":           webhookID,
+		"url":                 url,
+		"lastError":           lastError,
+		"consecutiveFailures": consecutiveFailures,
+	})
+}
+
 // gatewayRecorderAdapter adapts registry.Store to gateway.TransactionRecorder
 type gatewayRecorderAdapter struct {
 	r registry.Store
@@ -2571,6 +3105,105 @@ func (a *gatewayRegistryAdapter) ListServices(ctx context.Context, serviceType,
 	return candidates, nil
 }
 
+// searchHistoryAdapter implements registry.PurchaseHistoryProvider by
+// layering escrow outcomes over the registry's transaction-derived
+// seller history, so personalized search sees disputes too.
+type searchHistoryAdapter struct {
+	txHistory *registry.TransactionHistoryProvider
+	escrows   *escrow.Service
+}
+
+func (a *searchHistoryAdapter) GetSellerHistory(ctx context.Context, buyerAddr string) (map[string]registry.SellerHistory, error) {
+	history, err := a.txHistory.GetSellerHistory(ctx, buyerAddr)
+	if err != nil {
+		return nil, err
+	}
+	if a.escrows == nil {
+		return history, nil
+	}
+
+	escrows, err := a.escrows.ListByAgent(ctx, buyerAddr, 200)
+	if err != nil {
+		// Transaction history alone is still useful.
+		slog.Debug("escrow history unavailable for personalization", "error", err)
+		return history, nil
+	}
+
+	buyer := strings.ToLower(buyerAddr)
+	for _, esc := range escrows {
+		if esc.BuyerAddr != buyer {
+			continue
+		}
+		seller := strings.ToLower(esc.SellerAddr)
+		hist := history[seller]
+		switch esc.Status {
+		case escrow.StatusDisputed, escrow.StatusArbitrating, escrow.StatusRefunded:
+			hist.Disputes++
+		case escrow.StatusReleased:
+			hist.Successes++
+		}
+		history[seller] = hist
+	}
+	return history, nil
+}
+
+// escrowDisputeRateAdapter implements registry.DisputeRateProvider by
+// scanning a seller's recent escrows for disputed outcomes.
+type escrowDisputeRateAdapter struct {
+	escrows *escrow.Service
+}
+
+func (a *escrowDisputeRateAdapter) GetDisputeRate(ctx context.Context, agentAddr string) (float64, bool) {
+	if a.escrows == nil {
+		return 0, false
+	}
+	escrows, err := a.escrows.ListByAgent(ctx, agentAddr, 200)
+	if err != nil {
+		return 0, false
+	}
+
+	seller := strings.ToLower(agentAddr)
+	total, disputed := 0, 0
+	for _, esc := range escrows {
+		if esc.SellerAddr != seller {
+			continue
+		}
+		total++
+		switch esc.Status {
+		case escrow.StatusDisputed, escrow.StatusArbitrating, escrow.StatusRefunded:
+			disputed++
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(disputed) / float64(total), true
+}
+
+// savedSearchNotifier adapts webhooks.Emitter and realtime.Hub to
+// registry.SavedSearchNotifier: match alerts go out as search.match
+// webhooks and as price alerts on the owner's private channel.
+type savedSearchNotifier struct {
+	emitter *webhooks.Emitter
+	hub     *realtime.Hub
+}
+
+func (n *savedSearchNotifier) NotifySavedSearchMatch(ownerAddr string, search registry.SavedSearch, sellerAddr string, svc registry.Service) {
+	if n.emitter != nil {
+		n.emitter.EmitSearchMatch(ownerAddr, search.ID, sellerAddr, svc.ID, svc.Type, svc.Price)
+	}
+	if n.hub != nil {
+		n.hub.BroadcastPrivate(ownerAddr, realtime.EventPriceAlert, map[string]interface{}{
+			"searchId":    search.ID,
+			"query":       search.Query,
+			"sellerAddr":  sellerAddr,
+			"serviceId":   svc.ID,
+			"serviceType": svc.Type,
+			"price":       svc.Price,
+		})
+	}
+}
+
 // webhookAlertNotifier adapts webhooks.Dispatcher to sessionkeys.AlertNotifier.
 type webhookAlertNotifier struct {
 	d *webhooks.Dispatcher
@@ -2598,6 +3231,45 @@ func (n *webhookAlertNotifier) NotifyAlert(ctx context.Context, alert sessionkey
 	})
 }
 
+// creditAlertNotifier adapts webhooks.Dispatcher and realtime.Hub to
+// credit.AlertNotifier so utilization alerts reach both channels.
+type creditAlertNotifier struct {
+	d   *webhooks.Dispatcher
+	hub *realtime.Hub
+}
+
+func (n *creditAlertNotifier) NotifyAlert(ctx context.Context, alert credit.AlertEvent) error {
+	if n.hub != nil {
+		// Credit positions are private to the agent; keep them off the
+		// public firehose.
+		n.hub.BroadcastPrivate(alert.AgentAddr, realtime.EventCreditAlert, map[string]interface{}{
+			"agentAddr":   alert.AgentAddr,
+			"alertType":   alert.Type,
+			"threshold":   alert.Threshold,
+			"usedPct":     alert.UsedPct,
+			"creditUsed":  alert.CreditUsed,
+			"creditLimit": alert.CreditLimit,
+		})
+	}
+
+	eventType := webhooks.EventCreditUtilizationWarning
+	if alert.Type == "utilization_critical" {
+		eventType = webhooks.EventCreditUtilizationCritical
+	}
+	return n.d.DispatchToAgent(ctx, alert.AgentAddr, &webhooks.Event{
+		ID:        alert.AgentAddr + ":" + alert.Type,
+		Type:      eventType,
+		Timestamp: alert.TriggeredAt,
+		Data: map[string]interface{}{
+			"agentAddr":   alert.AgentAddr,
+			"threshold":   alert.Threshold,
+			"usedPct":     alert.UsedPct,
+			"creditUsed":  alert.CreditUsed,
+			"creditLimit": alert.CreditLimit,
+		},
+	})
+}
+
 // receiptIssuerAdapter adapts receipts.Service to the ReceiptIssuer interface
 // used by gateway, streams, escrow, and session keys. A single adapter satisfies
 // all four payment paths via Go structural typing.
@@ -2698,12 +3370,63 @@ func (s *Server) wireSubsystems(cfg *config.Config) {
 
 	// Webhook emitter into all payment subsystems.
 	s.webhookEmitter = webhooks.NewEmitter(s.webhooks, s.logger)
+	if s.db != nil {
+		// Transactional outbox: EmitInTx writes events inside the caller's
+		// transaction; the relay dispatches them after commit.
+		s.webhookOutbox = webhooks.NewOutbox(s.db, s.logger)
+		s.webhookEmitter.WithOutbox(s.webhookOutbox)
+		s.logger.Info("webhook outbox enabled")
+	}
 	s.gatewayService.WithWebhookEmitter(s.webhookEmitter)
 	s.escrowService.WithWebhookEmitter(s.webhookEmitter)
 	s.streamService.WithWebhookEmitter(s.webhookEmitter)
+
+	// Counterparty blocklist into all payment paths: session keys refuse
+	// blocked recipients, escrow refuses blocked sellers, and the gateway
+	// skips blocked providers during candidate selection.
+	s.blocklistService = blocklist.NewService().WithLogger(s.logger)
+	if s.db != nil {
+		blStore := blocklist.NewPostgresStore(s.db)
+		ctx := context.Background()
+		if err := blStore.Migrate(ctx); err != nil {
+			s.logger.Warn("failed to migrate blocklist store", "error", err)
+		}
+		s.blocklistService.WithStore(blStore)
+		if err := s.blocklistService.Load(ctx); err != nil {
+			s.logger.Warn("failed to load blocklist from storage", "error", err)
+		}
+	} else {
+		// Without Postgres the lists live in process memory only; imported
+		// known-bad addresses must be replayed after every restart.
+		s.logger.Warn("blocklist is in-memory; entries and audit reset on restart")
+	}
+	s.sessionMgr.WithPaymentGate(s.blocklistService)
+	s.escrowService.WithPaymentGate(s.blocklistService)
+	s.gatewayService.WithPaymentGate(s.blocklistService)
 	if s.complianceService != nil {
 		s.complianceService.WithWebhooks(s.webhookEmitter)
 	}
+	if s.creditService != nil {
+		s.creditService.WithWebhookEmitter(s.webhookEmitter)
+		s.creditService.WithAlertNotifier(&creditAlertNotifier{d: s.webhooks, hub: s.realtimeHub})
+		if s.ledger != nil {
+			s.ledger.WithEarningsListener(s.creditService)
+		}
+	}
+	if s.realtimeHub != nil {
+		// Private per-agent channels: ledger activity and webhook delivery
+		// failures go straight to the owner's authenticated connections.
+		if s.ledger != nil {
+			s.ledger.WithActivityListener(&ledgerActivityNotifier{hub: s.realtimeHub})
+		}
+		s.webhooks.WithFailureListener(&webhookFailureNotifier{hub: s.realtimeHub})
+	}
+	if s.agreementService != nil {
+		s.agreementService.WithWebhookEmitter(s.webhookEmitter)
+	}
+	if s.negotiationService != nil {
+		s.negotiationService.WithWebhookEmitter(s.webhookEmitter)
+	}
 	if s.coalitionService != nil {
 		s.coalitionService.WithWebhookEmitter(s.webhookEmitter)
 		if s.realtimeHub != nil {
@@ -2713,12 +3436,21 @@ func (s *Server) wireSubsystems(cfg *config.Config) {
 			s.coalitionService.WithContractChecker(&coalitionContractAdapter{s.contractService})
 		}
 	}
+	if s.stakesService != nil {
+		s.stakesService.WithWebhookEmitter(s.webhookEmitter)
+	}
+	if s.predictionsService != nil {
+		s.predictionsService.WithWebhookEmitter(s.webhookEmitter)
+	}
 
 	// Realtime broadcasting into gateway, escrow, and streams.
 	if s.realtimeHub != nil {
 		s.gatewayService.WithRealtimeBroadcaster(&gatewayRealtimeAdapter{s.realtimeHub})
 		s.escrowService.WithRealtimeBroadcaster(&escrowRealtimeAdapter{s.realtimeHub})
 		s.streamService.WithRealtimeBroadcaster(&streamRealtimeAdapter{s.realtimeHub})
+		if s.predictionsService != nil {
+			s.predictionsService.WithRealtimeBroadcaster(&predictionRealtimeAdapter{s.realtimeHub})
+		}
 	}
 
 	// Recorder + platform address.
@@ -2730,6 +3462,23 @@ func (s *Server) wireSubsystems(cfg *config.Config) {
 		s.offerService.WithRecorder(&gatewayRecorderAdapter{s.registry})
 	}
 	s.gatewayService.WithPlatformAddress(cfg.PlatformAddress)
+	if s.stakesService != nil {
+		s.stakesService.WithPlatformAddress(cfg.PlatformAddress)
+		if s.creditService != nil {
+			s.creditService.WithStakePledger(s.stakesService)
+			s.creditService.WithStakeRevenue(s.stakesService)
+		}
+	}
+	if s.predictionsService != nil {
+		s.predictionsService.WithPlatformAddress(cfg.PlatformAddress)
+		s.predictionsService.RegisterOracle(predictions.SourceRegistry,
+			predictions.NewRegistryOracle(&predictionMetricsAdapter{s.registry}))
+		s.predictionsService.RegisterOracle(predictions.SourceHTTP, predictions.NewHTTPOracle())
+		if cfg.PriceFeedURL != "" {
+			s.predictionsService.RegisterOracle(predictions.SourcePrice,
+				predictions.NewPriceOracle(predictions.NewHTTPPriceFeed(cfg.PriceFeedURL)))
+		}
+	}
 
 	// Receipt issuer into all payment paths.
 	if s.receiptService != nil {
@@ -2899,13 +3648,39 @@ func (a *supervisorDenialSinkAdapter) RecordSupervisorDenial(ctx context.Context
 	}
 }
 
+// buildPayoutWallet constructs the signing wallet for the configured
+// backend. Only the "local" backend holds the key in process memory;
+// the KMS and remote backends sign out of process.
+func buildPayoutWallet(ctx context.Context, cfg *config.Config) (usdc.Wallet, error) {
+	switch cfg.WalletBackend {
+	case "", "local":
+		if cfg.PrivateKey == "" {
+			return nil, errors.New("PAYOUTS_ENABLED=true but PRIVATE_KEY is not set")
+		}
+		return usdc.NewEthWalletFromHex(cfg.PrivateKey)
+	case "aws-kms":
+		backend := usdc.NewAWSKMSBackend(cfg.AWSKMSKeyID, cfg.AWSKMSRegion, usdc.AWSCredentials{
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			SessionToken:    cfg.AWSSessionToken,
+		})
+		return usdc.NewBackendWallet(ctx, backend)
+	case "gcp-kms":
+		var tokens usdc.TokenSource = usdc.NewMetadataTokenSource()
+		if cfg.GCPKMSToken != "" {
+			tokens = usdc.StaticTokenSource(cfg.GCPKMSToken)
+		}
+		return usdc.NewBackendWallet(ctx, usdc.NewGCPKMSBackend(cfg.GCPKMSKey, tokens))
+	case "remote":
+		return usdc.NewBackendWallet(ctx, usdc.NewRemoteSigner(cfg.RemoteSignerURL, cfg.RemoteSignerToken))
+	}
+	return nil, fmt.Errorf("unknown wallet backend %q", cfg.WalletBackend)
+}
+
 // initPayoutService dials the configured RPC and constructs a PayoutService
 // using the provided store. Returns an error — logged but not fatal — when
 // the required config is missing or the RPC is unreachable.
 func (s *Server) initPayoutService(ctx context.Context, cfg *config.Config, store usdc.PayoutStore) error {
-	if cfg.PrivateKey == "" {
-		return errors.New("PAYOUTS_ENABLED=true but PRIVATE_KEY is not set")
-	}
 	if cfg.RPCURL == "" {
 		return errors.New("PAYOUTS_ENABLED=true but RPC_URL is not set")
 	}
@@ -2913,7 +3688,7 @@ func (s *Server) initPayoutService(ctx context.Context, cfg *config.Config, stor
 		return errors.New("PAYOUTS_ENABLED=true but USDC_CONTRACT is not set")
 	}
 
-	wallet, err := usdc.NewEthWalletFromHex(cfg.PrivateKey)
+	payoutWallet, err := buildPayoutWallet(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("build wallet: %w", err)
 	}
@@ -2924,13 +3699,17 @@ func (s *Server) initPayoutService(ctx context.Context, cfg *config.Config, stor
 		RPCURL:       cfg.RPCURL,
 		USDCContract: cfg.USDCContract,
 	}
-	client, err := usdc.NewEthClient(ctx, chain)
+	client, err := usdc.NewEthClient(ctx, chain, usdc.WithDynamicFees())
 	if err != nil {
 		return fmt.Errorf("dial RPC: %w", err)
 	}
 
+	// One nonce manager shared between the payout service and the tx
+	// manager so everything signing from this wallet serializes nonces.
+	nonces := usdc.NewInMemoryNonceManager()
+
 	payoutCfg := usdc.PayoutConfig{Confirmations: cfg.PayoutConfirmations}
-	svc, err := usdc.NewPayoutService(chain, client, wallet, usdc.NewInMemoryNonceManager(), store, payoutCfg, s.logger)
+	svc, err := usdc.NewPayoutService(chain, client, payoutWallet, nonces, store, payoutCfg, s.logger)
 	if err != nil {
 		client.Close()
 		return fmt.Errorf("construct payout service: %w", err)
@@ -2940,11 +3719,54 @@ func (s *Server) initPayoutService(ctx context.Context, cfg *config.Config, stor
 	s.payoutService = svc
 	s.logger.Info("payouts enabled",
 		"chain_id", cfg.ChainID,
-		"from", wallet.Address(),
+		"from", payoutWallet.Address(),
+		"wallet_backend", cfg.WalletBackend,
 		"usdc_contract", cfg.USDCContract,
 		"confirmations", payoutCfg.Confirmations,
 	)
 
+	// Transaction manager: tracks nonces and replaces stuck/underpriced
+	// transactions with fee-bumped rebroadcasts. Runs alongside the payout
+	// service on the same wallet and nonce manager.
+	txMgr, err := wallet.NewManager(client, payoutWallet, nonces,
+		wallet.Config{Confirmations: cfg.PayoutConfirmations}, s.logger)
+	if err != nil {
+		s.logger.Warn("transaction manager disabled", "reason", err)
+	} else {
+		s.txManager = txMgr
+	}
+
+	// Paymaster USD cost estimates: price sponsored gas off the fee oracle
+	// when the RPC supports eth_feeHistory and an ETH/USD price is set.
+	if s.smartAccounts != nil && client.Oracle() != nil && cfg.ETHUSDPrice > 0 {
+		s.smartAccounts.WithCostEstimator(&erc4337CostAdapter{oracle: client.Oracle(), ethUSD: cfg.ETHUSDPrice})
+		s.logger.Info("paymaster cost estimates enabled", "eth_usd_price", cfg.ETHUSDPrice)
+		// With USD estimates available, attribute each sponsored op's gas
+		// cost to its beneficiary as an informational ledger entry.
+		if s.ledgerService != nil {
+			s.smartAccounts.WithGasCostRecorder(s.ledgerService)
+		}
+	}
+
+	// Paymaster spend guard: alerts operators and throttles non-critical
+	// sponsorships when sponsored gas spend nears its daily cap or the
+	// paymaster's ETH balance runs low.
+	if s.smartAccounts != nil && (cfg.PaymasterDailyCapETH > 0 || cfg.PaymasterMinBalanceETH > 0) {
+		guard := erc4337.NewSpendGuard(erc4337.SpendGuardConfig{
+			DailyCapETH:   cfg.PaymasterDailyCapETH,
+			MinBalanceETH: cfg.PaymasterMinBalanceETH,
+		}).
+			WithLogger(s.logger).
+			WithNotifier(&paymasterAlertNotifier{d: s.webhooks, operator: cfg.PlatformAddress})
+		if key, keyErr := ethcrypto.HexToECDSA(strings.TrimPrefix(cfg.PrivateKey, "0x")); keyErr == nil {
+			guard.WithBalanceReader(client, ethcrypto.PubkeyToAddress(key.PublicKey).Hex())
+		}
+		s.smartAccounts.WithSpendGuard(guard)
+		s.logger.Info("paymaster spend guard enabled",
+			"daily_cap_eth", cfg.PaymasterDailyCapETH,
+			"min_balance_eth", cfg.PaymasterMinBalanceETH)
+	}
+
 	// Agent-initiated withdrawals compose the ledger's Hold/Confirm/Release
 	// with this payout service. Ledger must be present — it always is in
 	// both startup paths by the time this runs.
@@ -2961,9 +3783,179 @@ func (s *Server) initPayoutService(ctx context.Context, cfg *config.Config, stor
 			s.logger.Info("withdrawals enabled")
 		}
 	}
+
+	// Large withdrawals divert into the multisig proposal flow when a Safe
+	// is configured; the executor below settles them once approved.
+	if s.multisigService != nil && s.withdrawalService != nil && cfg.MultisigMinWithdrawal != "" {
+		s.withdrawalService.WithRouter(&multisigRouterAdapter{svc: s.multisigService}, cfg.MultisigMinWithdrawal)
+		s.logger.Info("withdrawal multisig routing enabled", "min_amount", cfg.MultisigMinWithdrawal)
+	}
+	return nil
+}
+
+// initMultisigService constructs the Safe multisig proposal service using
+// the provided store. Returns an error — logged but not fatal — when the
+// approver configuration is invalid.
+func (s *Server) initMultisigService(cfg *config.Config, store multisig.Store) error {
+	svc, err := multisig.NewService(store, multisig.Config{
+		SafeAddress:  cfg.SafeAddress,
+		USDCContract: cfg.USDCContract,
+		ChainID:      cfg.ChainID,
+		Approvers:    cfg.MultisigApprovers,
+		Threshold:    cfg.MultisigThreshold,
+	})
+	if err != nil {
+		return err
+	}
+	s.multisigService = svc.WithLogger(s.logger).WithExecutor(&multisigExecutorAdapter{server: s})
+	s.logger.Info("multisig routing enabled",
+		"safe", cfg.SafeAddress,
+		"approvers", len(cfg.MultisigApprovers),
+		"threshold", cfg.MultisigThreshold,
+		"min_withdrawal", cfg.MultisigMinWithdrawal,
+	)
 	return nil
 }
 
+// multisigRouterAdapter implements withdrawals.Router against the multisig
+// service: a routed withdrawal becomes a pending proposal whose reference
+// is the withdrawal's clientRef.
+type multisigRouterAdapter struct {
+	svc *multisig.Service
+}
+
+func (a *multisigRouterAdapter) Propose(ctx context.Context, rp withdrawals.RouteProposal) (string, error) {
+	p, err := a.svc.Propose(ctx, multisig.ProposeRequest{
+		Kind:       multisig.KindWithdrawal,
+		To:         rp.ToAddr,
+		Amount:     rp.Amount,
+		Reference:  rp.ClientRef,
+		AgentAddr:  rp.AgentAddr,
+		ProposedBy: rp.AgentAddr,
+	})
+	if err != nil {
+		return "", err
+	}
+	return p.ID, nil
+}
+
+// multisigExecutorAdapter implements multisig.Executor by dispatching on
+// the proposal kind: routed withdrawals settle against their existing
+// ledger hold, treasury moves go straight to the payout service.
+type multisigExecutorAdapter struct {
+	server *Server
+}
+
+func (a *multisigExecutorAdapter) Execute(ctx context.Context, p *multisig.Proposal) (string, error) {
+	switch p.Kind {
+	case multisig.KindWithdrawal:
+		if a.server.withdrawalService == nil {
+			return "", errors.New("withdrawal service unavailable")
+		}
+		w, err := a.server.withdrawalService.ExecuteRouted(ctx, withdrawals.Request{
+			AgentAddr: p.AgentAddr,
+			ToAddr:    p.To,
+			Amount:    p.Amount,
+			ClientRef: p.Reference,
+		})
+		if err != nil {
+			if w != nil {
+				return w.TxHash, err
+			}
+			return "", err
+		}
+		return w.TxHash, nil
+	case multisig.KindTreasury:
+		if a.server.payoutService == nil {
+			return "", errors.New("payout service unavailable")
+		}
+		amt, ok := usdc.Parse(p.Amount)
+		if !ok || amt == nil || amt.Sign() <= 0 {
+			return "", fmt.Errorf("invalid amount %q", p.Amount)
+		}
+		payout, err := a.server.payoutService.Send(ctx, usdc.TransferRequest{
+			ChainID:   a.server.payoutService.ChainID(),
+			ToAddr:    p.To,
+			Amount:    amt,
+			ClientRef: p.Reference,
+		})
+		if err != nil {
+			if payout != nil {
+				return payout.TxHash, err
+			}
+			return "", err
+		}
+		return payout.TxHash, nil
+	}
+	return "", fmt.Errorf("unknown proposal kind %q", p.Kind)
+}
+
+func (a *multisigExecutorAdapter) Abort(ctx context.Context, p *multisig.Proposal) error {
+	// Only routed withdrawals hold ledger funds; treasury proposals have
+	// nothing to unwind.
+	if p.Kind != multisig.KindWithdrawal {
+		return nil
+	}
+	if a.server.withdrawalService == nil {
+		return errors.New("withdrawal service unavailable")
+	}
+	return a.server.withdrawalService.AbortRouted(ctx, withdrawals.Request{
+		AgentAddr: p.AgentAddr,
+		ToAddr:    p.To,
+		Amount:    p.Amount,
+		ClientRef: p.Reference,
+	})
+}
+
+// paymasterAlertNotifier adapts webhooks.Dispatcher to
+// erc4337.SpendAlertNotifier, delivering spend-guard alerts to the
+// platform operator's subscriptions.
+type paymasterAlertNotifier struct {
+	d        *webhooks.Dispatcher
+	operator string
+}
+
+func (n *paymasterAlertNotifier) NotifySpendAlert(ctx context.Context, alert erc4337.SpendAlert) error {
+	eventType := webhooks.EventPaymasterSpendWarning
+	switch alert.Type {
+	case "spend_cap_reached":
+		eventType = webhooks.EventPaymasterSpendCapReached
+	case "balance_low":
+		eventType = webhooks.EventPaymasterBalanceLow
+	}
+	return n.d.DispatchToAgent(ctx, n.operator, &webhooks.Event{
+		ID:        "paymaster:" + alert.Type + ":" + alert.TriggeredAt.Format("2006-01-02"),
+		Type:      eventType,
+		Timestamp: alert.TriggeredAt,
+		Data: map[string]interface{}{
+			"spentWei":      alert.SpentWei,
+			"dailyCapWei":   alert.DailyCapWei,
+			"usedPct":       alert.UsedPct,
+			"balanceWei":    alert.BalanceWei,
+			"minBalanceWei": alert.MinBalanceWei,
+			"throttled":     alert.Throttled,
+		},
+	})
+}
+
+// erc4337CostAdapter implements erc4337.CostEstimator by pricing sponsored
+// gas at the fee oracle's standard tier and converting to USD with the
+// configured ETH/USD price.
+type erc4337CostAdapter struct {
+	oracle *usdc.FeeOracle
+	ethUSD float64
+}
+
+func (a *erc4337CostAdapter) UserOpCostUSD(ctx context.Context, gas uint64) (string, error) {
+	quote, err := a.oracle.Quote(ctx, usdc.UrgencyStandard)
+	if err != nil {
+		return "", err
+	}
+	wei := new(big.Int).Mul(quote.MaxFeePerGas, new(big.Int).SetUint64(gas))
+	eth, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18)).Float64()
+	return strconv.FormatFloat(eth*a.ethUSD, 'f', 6, 64), nil
+}
+
 // ledgerWithdrawAdapter implements withdrawals.Ledger against the ledger service.
 // ledger.Service is already an interface, so the field is a value, not a pointer.
 type ledgerWithdrawAdapter struct {
@@ -3127,13 +4119,17 @@ func (a *coalitionRealtimeAdapter) BroadcastCoalitionEvent(eventType string, coa
 }
 
 func (a *coalitionRealtimeAdapter) BroadcastEscrowEvent(eventType, escrowID, buyer, seller, amount, status string) {
-	a.hub.BroadcastEscrowEvent(realtime.EventType(eventType), map[string]interface{}{
+	data := map[string]interface{}{
 		"escrowId": escrowID,
 		"from":     buyer,
 		"to":       seller,
 		"amount":   amount,
 		"status":   status,
-	})
+	}
+	a.hub.BroadcastEscrowEvent(realtime.EventType(eventType), data)
+	// Same event on both parties' private channels.
+	a.hub.BroadcastPrivate(buyer, realtime.EventType(eventType), data)
+	a.hub.BroadcastPrivate(seller, realtime.EventType(eventType), data)
 }
 
 // gatewayRealtimeAdapter adapts realtime.Hub to gateway.RealtimeBroadcaster
@@ -3184,13 +4180,17 @@ func (a *escrowRealtimeAdapter) BroadcastCoalitionEvent(eventType string, coalit
 }
 
 func (a *escrowRealtimeAdapter) BroadcastEscrowEvent(eventType, escrowID, buyer, seller, amount, status string) {
-	a.hub.BroadcastEscrowEvent(realtime.EventType(eventType), map[string]interface{}{
+	data := map[string]interface{}{
 		"escrowId": escrowID,
 		"from":     buyer,
 		"to":       seller,
 		"amount":   amount,
 		"status":   status,
-	})
+	}
+	a.hub.BroadcastEscrowEvent(realtime.EventType(eventType), data)
+	// Same event on both parties' private channels.
+	a.hub.BroadcastPrivate(buyer, realtime.EventType(eventType), data)
+	a.hub.BroadcastPrivate(seller, realtime.EventType(eventType), data)
 }
 
 // adminCoalitionAdapter adapts escrow.CoalitionService to admin.CoalitionService
@@ -3626,6 +4626,15 @@ func billingProviderName(cfg *config.Config) string {
 	return "noop"
 }
 
+// erc4337SessionAdapter adapts sessionkeys.Manager to erc4337.SessionValidator
+type erc4337SessionAdapter struct {
+	mgr *sessionkeys.Manager
+}
+
+func (a *erc4337SessionAdapter) Validate(ctx context.Context, keyID, to, amount, serviceID string) error {
+	return a.mgr.Validate(ctx, keyID, to, amount, serviceID)
+}
+
 // watcherCreditorAdapter adapts ledger.Store to watcher.Creditor
 type watcherCreditorAdapter struct {
 	store ledger.Store
@@ -3838,6 +4847,101 @@ func (a *kyaTrustGateAdapter) CheckCounterpartyTrust(ctx context.Context, agentA
 	return nil
 }
 
+// --- Stakes disclosure adapter (registry/reputation → offering disclosures) ---
+
+type stakesDisclosureAdapter struct {
+	reg    registry.Store
+	rep    *reputation.RegistryProvider
+	kyaSvc *kya.Service
+}
+
+func (a *stakesDisclosureAdapter) AgentDisclosure(ctx context.Context, agentAddr string) (*stakes.AgentDisclosure, error) {
+	agent, err := a.reg.GetAgent(ctx, agentAddr)
+	if err != nil {
+		return nil, err
+	}
+	d := &stakes.AgentDisclosure{
+		AgentName:        agent.Name,
+		AgentSince:       agent.CreatedAt,
+		TotalRevenue:     agent.Stats.TotalReceived,
+		TransactionCount: agent.Stats.TransactionCount,
+		SuccessRate:      agent.Stats.SuccessRate,
+	}
+	if a.rep != nil {
+		d.DisputeRate = a.rep.DisputeRate(agentAddr)
+	}
+	if a.kyaSvc != nil {
+		if cert, err := a.kyaSvc.GetByAgent(ctx, agentAddr); err == nil && cert.IsValid() {
+			d.Verified = true
+		}
+	}
+	return d, nil
+}
+
+// --- Prediction oracle adapter (registry stats → oracle metrics) ---
+
+type predictionMetricsAdapter struct {
+	reg registry.Store
+}
+
+func (a *predictionMetricsAdapter) AgentMetric(ctx context.Context, agentAddr, metric string) (float64, error) {
+	agent, err := a.reg.GetAgent(ctx, agentAddr)
+	if err != nil {
+		return 0, err
+	}
+	switch metric {
+	case "success_rate":
+		return agent.Stats.SuccessRate, nil
+	case "transaction_count":
+		return float64(agent.Stats.TransactionCount), nil
+	case "total_received":
+		return strconv.ParseFloat(agent.Stats.TotalReceived, 64)
+	default:
+		return 0, fmt.Errorf("unknown registry metric %q", metric)
+	}
+}
+
+// --- Prediction realtime adapter ---
+
+type predictionRealtimeAdapter struct {
+	hub *realtime.Hub
+}
+
+func (a *predictionRealtimeAdapter) BroadcastPredictionCreated(predictionID, creatorAddr, claim, category string) {
+	a.hub.BroadcastPredictionEvent(realtime.EventPredictionCreated, map[string]interface{}{
+		"predictionId": predictionID,
+		"creatorAddr":  creatorAddr,
+		"claim":        claim,
+		"category":     category,
+	})
+}
+
+func (a *predictionRealtimeAdapter) BroadcastPredictionResolved(predictionID, creatorAddr, outcome string) {
+	a.hub.BroadcastPredictionEvent(realtime.EventPredictionResolved, map[string]interface{}{
+		"predictionId": predictionID,
+		"creatorAddr":  creatorAddr,
+		"outcome":      outcome,
+	})
+}
+
+// --- Prediction forecast adapter (settled wagers → reputation samples) ---
+
+type predictionForecastAdapter struct {
+	svc *predictions.Service
+}
+
+func (a *predictionForecastAdapter) ForecastSamples(ctx context.Context, address string, window time.Duration) ([]reputation.ForecastSample, error) {
+	outcomes, err := a.svc.WagerOutcomes(ctx, address, window)
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]reputation.ForecastSample, 0, len(outcomes))
+	for _, o := range outcomes {
+		samples = append(samples, reputation.ForecastSample{Correct: o.Correct, SettledAt: o.SettledAt})
+	}
+	return samples, nil
+}
+
 // --- Event bus adapter ---
 
 type eventBusGatewayAdapter struct {
@@ -38,12 +38,19 @@ type ServiceResolver interface {
 	GetAgentForService(ctx context.Context, serviceID string) (string, error)
 }
 
+// PaymentGate vetoes payments to blocked counterparties. Returns nil
+// if the key owner is allowed to pay the recipient, or an error with the reason.
+type PaymentGate interface {
+	CheckPayment(ctx context.Context, payerAddr, payeeAddr string) error
+}
+
 // Manager handles session key operations
 type Manager struct {
 	store       Store
 	resolver    ServiceResolver
 	policyStore PolicyStore           // optional: policy engine for additional constraints
 	auditLog    DelegationAuditLogger // optional: delegation event audit trail
+	paymentGate PaymentGate           // optional: counterparty blocklist check
 	keyLocks    sync.Map              // per-key locks to prevent nonce TOCTOU replay
 }
 
@@ -115,6 +122,12 @@ func (m *Manager) WithDelegationAuditLogger(al DelegationAuditLogger) *Manager {
 	return m
 }
 
+// WithPaymentGate adds counterparty blocklist checks to transaction validation.
+func (m *Manager) WithPaymentGate(pg PaymentGate) *Manager {
+	m.paymentGate = pg
+	return m
+}
+
 // AuditLogger returns the manager's delegation audit logger (may be nil).
 func (m *Manager) AuditLogger() DelegationAuditLogger {
 	return m.auditLog
@@ -935,6 +948,13 @@ func (m *Manager) validateTransaction(ctx context.Context, key *SessionKey, to s
 		return ErrKeyNotYetValid
 	}
 
+	// Check counterparty blocklist
+	if m.paymentGate != nil {
+		if err := m.paymentGate.CheckPayment(ctx, key.OwnerAddr, to); err != nil {
+			return &ValidationError{Code: "counterparty_blocked", Message: err.Error()}
+		}
+	}
+
 	// Parse amount
 	amountBig, ok := usdc.Parse(amount)
 	if !ok {
@@ -2,6 +2,7 @@ package sessionkeys
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -96,6 +97,44 @@ func TestSessionKeyValidation(t *testing.T) {
 	}
 }
 
+type blockingPaymentGate struct {
+	blocked map[string]bool
+}
+
+func (g *blockingPaymentGate) CheckPayment(_ context.Context, _, payeeAddr string) error {
+	if g.blocked[payeeAddr] {
+		return errors.New("counterparty is blocked")
+	}
+	return nil
+}
+
+func TestSessionKeyPaymentGate(t *testing.T) {
+	store := NewMemoryStore()
+	mgr := NewManager(store, nil).
+		WithPaymentGate(&blockingPaymentGate{blocked: map[string]bool{"0xbad": true}})
+	ctx := context.Background()
+
+	req := &SessionKeyRequest{
+		PublicKey: "0x1234567890123456789012345678901234567890",
+		MaxPerDay: "10.00",
+		ExpiresIn: "1h",
+		AllowAny:  true,
+	}
+	key, _ := mgr.Create(ctx, "0x1234", req)
+
+	// Unblocked recipient passes.
+	if err := mgr.Validate(ctx, key.ID, "0xgood", "0.50", ""); err != nil {
+		t.Errorf("Expected valid, got: %v", err)
+	}
+
+	// Blocked recipient is refused, case-insensitively.
+	err := mgr.Validate(ctx, key.ID, "0xBAD", "0.50", "")
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Code != "counterparty_blocked" {
+		t.Errorf("Expected counterparty_blocked, got: %v", err)
+	}
+}
+
 func TestSessionKeyRevocation(t *testing.T) {
 	store := NewMemoryStore()
 	mgr := NewManager(store, nil)
@@ -0,0 +1,121 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNoDisclosure = errors.New("stakes: no disclosure available")
+
+// disclosureMaxAge is how long a generated disclosure stays fresh before the
+// timer (or an on-demand read) regenerates it.
+const disclosureMaxAge = 24 * time.Hour
+
+// AgentDisclosure is the due-diligence picture of an issuing agent, pulled
+// from the registry and reputation systems rather than self-reported.
+type AgentDisclosure struct {
+	AgentName        string    `json:"agentName,omitempty"`
+	AgentSince       time.Time `json:"agentSince"`
+	TotalRevenue     string    `json:"totalRevenue"` // lifetime USDC received
+	TransactionCount int64     `json:"transactionCount"`
+	SuccessRate      float64   `json:"successRate"`
+	DisputeRate      float64   `json:"disputeRate"`
+	Verified         bool      `json:"verified"` // holds a valid KYA certificate
+}
+
+// Disclosure attaches provider facts to an offering with a generation time
+// so investors can tell how current the picture is.
+type Disclosure struct {
+	AgentDisclosure
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// DisclosureProvider supplies registry-backed facts about an issuing agent.
+type DisclosureProvider interface {
+	AgentDisclosure(ctx context.Context, agentAddr string) (*AgentDisclosure, error)
+}
+
+// WithDisclosures sets the provider used to generate offering disclosures.
+// Without one, offerings simply carry no disclosure.
+func (s *Service) WithDisclosures(p DisclosureProvider) *Service {
+	s.disclosures = p
+	return s
+}
+
+// buildDisclosure generates a disclosure for the agent. Best-effort: a
+// provider failure is logged and leaves the offering without one rather than
+// blocking creation.
+func (s *Service) buildDisclosure(ctx context.Context, agentAddr string) *Disclosure {
+	if s.disclosures == nil {
+		return nil
+	}
+	facts, err := s.disclosures.AgentDisclosure(ctx, agentAddr)
+	if err != nil {
+		s.logger.Warn("stakes: disclosure generation failed", "agent", agentAddr, "error", err)
+		return nil
+	}
+	return &Disclosure{AgentDisclosure: *facts, GeneratedAt: time.Now()}
+}
+
+// GetDisclosure returns an offering's disclosure, regenerating it first if
+// it has gone stale.
+func (s *Service) GetDisclosure(ctx context.Context, offeringID string) (*Disclosure, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offering, err := s.store.GetOffering(ctx, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	if offering.Disclosure != nil && time.Since(offering.Disclosure.GeneratedAt) < disclosureMaxAge {
+		return offering.Disclosure, nil
+	}
+
+	if d := s.buildDisclosure(ctx, offering.AgentAddr); d != nil {
+		offering.Disclosure = d
+		offering.UpdatedAt = time.Now()
+		if err := s.store.UpdateOffering(ctx, offering); err != nil {
+			return nil, err
+		}
+	}
+	if offering.Disclosure == nil {
+		return nil, ErrNoDisclosure
+	}
+	return offering.Disclosure, nil
+}
+
+// RefreshDisclosures regenerates stale disclosures on open offerings.
+// Returns how many were refreshed. Called by the timer.
+func (s *Service) RefreshDisclosures(ctx context.Context, now time.Time) (int, error) {
+	if s.disclosures == nil {
+		return 0, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offerings, err := s.store.ListOfferings(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	for _, offering := range offerings {
+		if offering.Disclosure != nil && now.Sub(offering.Disclosure.GeneratedAt) < disclosureMaxAge {
+			continue
+		}
+		d := s.buildDisclosure(ctx, offering.AgentAddr)
+		if d == nil {
+			continue
+		}
+		offering.Disclosure = d
+		offering.UpdatedAt = now
+		if err := s.store.UpdateOffering(ctx, offering); err != nil {
+			s.logger.Error("stakes: failed to persist disclosure", "offering", offering.ID, "error", err)
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, nil
+}
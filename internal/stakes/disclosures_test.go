@@ -0,0 +1,113 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type stubDisclosureProvider struct {
+	calls int
+	fail  bool
+}
+
+func (p *stubDisclosureProvider) AgentDisclosure(ctx context.Context, agentAddr string) (*AgentDisclosure, error) {
+	p.calls++
+	if p.fail {
+		return nil, errors.New("registry unavailable")
+	}
+	return &AgentDisclosure{
+		AgentName:        "Test Agent",
+		TotalRevenue:     "120.000000",
+		TransactionCount: 42,
+		SuccessRate:      0.97,
+		DisputeRate:      0.01,
+		Verified:         true,
+	}, nil
+}
+
+func TestOfferingCarriesDisclosure(t *testing.T) {
+	svc, _ := newTestService()
+	provider := &stubDisclosureProvider{}
+	svc.WithDisclosures(provider)
+
+	offering := createTestOffering(t, svc)
+	if offering.Disclosure == nil {
+		t.Fatal("expected disclosure generated at creation")
+	}
+	if offering.Disclosure.TotalRevenue != "120.000000" || !offering.Disclosure.Verified {
+		t.Errorf("unexpected disclosure: %+v", offering.Disclosure)
+	}
+	if offering.Disclosure.GeneratedAt.IsZero() {
+		t.Error("expected generation timestamp")
+	}
+
+	// A fresh disclosure is served without another provider call.
+	before := provider.calls
+	d, err := svc.GetDisclosure(context.Background(), offering.ID)
+	if err != nil {
+		t.Fatalf("GetDisclosure failed: %v", err)
+	}
+	if provider.calls != before {
+		t.Errorf("expected cached disclosure, provider called %d more times", provider.calls-before)
+	}
+	if d.AgentName != "Test Agent" {
+		t.Errorf("unexpected disclosure: %+v", d)
+	}
+}
+
+func TestProviderFailureDoesNotBlockOffering(t *testing.T) {
+	svc, _ := newTestService()
+	svc.WithDisclosures(&stubDisclosureProvider{fail: true})
+
+	offering := createTestOffering(t, svc)
+	if offering.Disclosure != nil {
+		t.Errorf("expected no disclosure on provider failure, got %+v", offering.Disclosure)
+	}
+	if _, err := svc.GetDisclosure(context.Background(), offering.ID); !errors.Is(err, ErrNoDisclosure) {
+		t.Errorf("expected ErrNoDisclosure, got %v", err)
+	}
+}
+
+func TestRefreshDisclosures(t *testing.T) {
+	svc, _ := newTestService()
+	provider := &stubDisclosureProvider{}
+	svc.WithDisclosures(provider)
+	ctx := context.Background()
+
+	offering := createTestOffering(t, svc)
+
+	// Everything fresh: nothing to do.
+	refreshed, err := svc.RefreshDisclosures(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("RefreshDisclosures failed: %v", err)
+	}
+	if refreshed != 0 {
+		t.Errorf("expected no refresh for fresh disclosures, got %d", refreshed)
+	}
+
+	// Past the max age the timer regenerates it.
+	refreshed, err = svc.RefreshDisclosures(ctx, time.Now().Add(disclosureMaxAge+time.Hour))
+	if err != nil {
+		t.Fatalf("RefreshDisclosures failed: %v", err)
+	}
+	if refreshed != 1 {
+		t.Errorf("expected 1 refresh, got %d", refreshed)
+	}
+
+	// An offering without a disclosure (provider was down at creation)
+	// picks one up on the next pass.
+	got, _ := svc.GetOffering(ctx, offering.ID)
+	got.Disclosure = nil
+	if err := svc.store.UpdateOffering(ctx, got); err != nil {
+		t.Fatalf("UpdateOffering failed: %v", err)
+	}
+	refreshed, err = svc.RefreshDisclosures(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("RefreshDisclosures failed: %v", err)
+	}
+	if refreshed != 1 {
+		t.Errorf("expected missing disclosure backfilled, got %d", refreshed)
+	}
+}
@@ -0,0 +1,86 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var ErrInvalidCadence = errors.New("stakes: invalid distribution cadence")
+
+// Cadence is how often an offering's pool is distributed automatically.
+// Offerings with no cadence only distribute when the agent triggers a run.
+type Cadence string
+
+const (
+	CadenceDaily   Cadence = "daily"
+	CadenceWeekly  Cadence = "weekly"
+	CadenceMonthly Cadence = "monthly"
+)
+
+// interval returns the minimum gap between scheduled runs. The second return
+// is false for unknown cadence values; a manual-only ("") cadence is valid
+// but has no interval.
+func (c Cadence) interval() (time.Duration, bool) {
+	switch c {
+	case "":
+		return 0, true
+	case CadenceDaily:
+		return 24 * time.Hour, true
+	case CadenceWeekly:
+		return 7 * 24 * time.Hour, true
+	case CadenceMonthly:
+		return 30 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
+// RunDistributions distributes every scheduled offering that is due at now:
+// its cadence interval has elapsed since the last distribution (or since
+// creation for the first run) and the accrued pool meets the offering's
+// minimum. Returns how many offerings paid out. Called by the timer.
+func (s *Service) RunDistributions(ctx context.Context, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offerings, err := s.store.ListScheduledOfferings(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, offering := range offerings {
+		interval, ok := offering.Cadence.interval()
+		if !ok || interval == 0 || offering.SharesSold == 0 {
+			continue
+		}
+		anchor := offering.CreatedAt
+		if offering.LastDistributedAt != nil {
+			anchor = *offering.LastDistributedAt
+		}
+		if now.Before(anchor.Add(interval)) {
+			continue
+		}
+
+		pool, ok := usdc.Parse(offering.Accrued)
+		if !ok || pool.Sign() <= 0 {
+			continue
+		}
+		if minDist, ok := usdc.Parse(offering.MinDistribution); ok && pool.Cmp(minDist) < 0 {
+			continue
+		}
+
+		if _, err := s.distributeLocked(ctx, offering, now); err != nil {
+			if !errors.Is(err, ErrNothingAccrued) {
+				s.logger.Error("stakes: scheduled distribution failed",
+					"offering", offering.ID, "error", err)
+			}
+			continue
+		}
+		ran++
+	}
+	return ran, nil
+}
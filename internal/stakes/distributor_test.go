@@ -0,0 +1,146 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func createScheduledOffering(t *testing.T, svc *Service, cadence Cadence, minDistribution string) *Offering {
+	t.Helper()
+	offering, err := svc.CreateOffering(context.Background(), agentAddr, CreateOfferingRequest{
+		RevenueShareBps: 2000,
+		TotalShares:     100,
+		PricePerShare:   "0.100000",
+		Cadence:         cadence,
+		MinDistribution: minDistribution,
+	})
+	if err != nil {
+		t.Fatalf("CreateOffering failed: %v", err)
+	}
+	return offering
+}
+
+func TestCadenceValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	_, err := svc.CreateOffering(ctx, agentAddr, CreateOfferingRequest{
+		RevenueShareBps: 2000, TotalShares: 100, PricePerShare: "0.100000", Cadence: "hourly",
+	})
+	if !errors.Is(err, ErrInvalidCadence) {
+		t.Errorf("expected ErrInvalidCadence, got %v", err)
+	}
+
+	_, err = svc.CreateOffering(ctx, agentAddr, CreateOfferingRequest{
+		RevenueShareBps: 2000, TotalShares: 100, PricePerShare: "0.100000", MinDistribution: "bogus",
+	})
+	if !errors.Is(err, ErrInvalidOffering) {
+		t.Errorf("expected ErrInvalidOffering for bad minimum, got %v", err)
+	}
+
+	offering := createScheduledOffering(t, svc, CadenceWeekly, "1.500000")
+	if offering.Cadence != CadenceWeekly || offering.MinDistribution != "1.500000" {
+		t.Errorf("expected schedule persisted, got %s / %s", offering.Cadence, offering.MinDistribution)
+	}
+}
+
+func TestRunDistributionsHonorsCadence(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	offering := createScheduledOffering(t, svc, CadenceDaily, "")
+
+	if _, err := svc.BuyShares(ctx, offering.ID, investorAddr, 40); err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+
+	// First run anchors at creation: not due yet.
+	ran, err := svc.RunDistributions(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("RunDistributions failed: %v", err)
+	}
+	if ran != 0 {
+		t.Errorf("expected no distributions before the interval elapses, got %d", ran)
+	}
+
+	ran, err = svc.RunDistributions(ctx, time.Now().Add(25*time.Hour))
+	if err != nil {
+		t.Fatalf("RunDistributions failed: %v", err)
+	}
+	if ran != 1 {
+		t.Fatalf("expected 1 distribution after a day, got %d", ran)
+	}
+	offering, _ = svc.GetOffering(ctx, offering.ID)
+	if offering.Accrued != "0.000000" || offering.TotalDistributed != "2.000000" {
+		t.Errorf("expected drained pool, got accrued=%s distributed=%s", offering.Accrued, offering.TotalDistributed)
+	}
+	if offering.LastDistributedAt == nil {
+		t.Fatal("expected LastDistributedAt to be set")
+	}
+
+	// The next run re-anchors at the last distribution.
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	ran, _ = svc.RunDistributions(ctx, time.Now().Add(26*time.Hour))
+	if ran != 0 {
+		t.Errorf("expected no distribution an hour after the last run, got %d", ran)
+	}
+	ran, _ = svc.RunDistributions(ctx, time.Now().Add(50*time.Hour))
+	if ran != 1 {
+		t.Errorf("expected 1 distribution a day after the last run, got %d", ran)
+	}
+}
+
+func TestRunDistributionsRespectsMinimum(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	offering := createScheduledOffering(t, svc, CadenceDaily, "3.000000")
+
+	if _, err := svc.BuyShares(ctx, offering.ID, investorAddr, 40); err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+
+	// Pool holds 2 USDC, below the 3 USDC minimum: held over.
+	ran, err := svc.RunDistributions(ctx, time.Now().Add(25*time.Hour))
+	if err != nil {
+		t.Fatalf("RunDistributions failed: %v", err)
+	}
+	if ran != 0 {
+		t.Errorf("expected pool below minimum to be held, got %d runs", ran)
+	}
+
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	ran, err = svc.RunDistributions(ctx, time.Now().Add(25*time.Hour))
+	if err != nil {
+		t.Fatalf("RunDistributions failed: %v", err)
+	}
+	if ran != 1 {
+		t.Fatalf("expected distribution once the pool clears the minimum, got %d", ran)
+	}
+	offering, _ = svc.GetOffering(ctx, offering.ID)
+	if offering.TotalDistributed != "4.000000" {
+		t.Errorf("expected 4.000000 distributed, got %s", offering.TotalDistributed)
+	}
+
+	// The minimum never gates manual distributions.
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	dist, err := svc.Distribute(ctx, offering.ID, agentAddr)
+	if err != nil {
+		t.Fatalf("Distribute failed: %v", err)
+	}
+	if dist.TotalAmount != "2.000000" {
+		t.Errorf("expected manual distribution of 2.000000, got %s", dist.TotalAmount)
+	}
+}
@@ -0,0 +1,598 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func safeMessage(status int, err error, fallback string) string {
+	if status < 500 {
+		return err.Error()
+	}
+	return fallback
+}
+
+// Handler provides HTTP endpoints for revenue-share stakes.
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new stakes handler.
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes sets up read-only stakes routes.
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/stakes/offerings", h.ListOfferings)
+	r.GET("/stakes/offerings/:id", h.GetOffering)
+	r.GET("/stakes/offerings/:id/distributions", h.ListDistributions)
+	r.GET("/stakes/offerings/:id/disclosure", h.GetDisclosure)
+	r.GET("/agents/:address/stakes/offerings", h.ListAgentOfferings)
+	r.GET("/stakes/portfolio/:address", h.Portfolio)
+	r.GET("/stakes/portfolio/:address/performance", h.PortfolioPerformance)
+	r.GET("/stakes/portfolio/:address/statement", h.PortfolioStatement)
+	r.GET("/stakes/positions/:id/vesting", h.VestingSchedule)
+	r.GET("/stakes/positions/:id/transfers", h.ListPositionTransfers)
+	r.GET("/stakes/transfers/:id", h.GetTransfer)
+	r.GET("/agents/:address/stakes/transfers", h.ListAgentTransfers)
+	r.GET("/stakes/market", h.ListMarket)
+	r.GET("/stakes/listings/:id", h.GetListing)
+	r.GET("/agents/:address/stakes/listings", h.ListSellerListings)
+}
+
+// RegisterProtectedRoutes sets up auth-required stakes routes.
+func (h *Handler) RegisterProtectedRoutes(r *gin.RouterGroup) {
+	r.POST("/stakes/offerings", h.CreateOffering)
+	r.POST("/stakes/offerings/:id/close", h.CloseOffering)
+	r.POST("/stakes/offerings/:id/buy", h.BuyShares)
+	r.POST("/stakes/offerings/:id/distribute", h.Distribute)
+	r.POST("/stakes/positions/:id/list", h.CreateListing)
+	r.POST("/stakes/positions/:id/transfer", h.ProposeTransfer)
+	r.POST("/stakes/transfers/:id/accept", h.AcceptTransfer)
+	r.POST("/stakes/transfers/:id/decline", h.DeclineTransfer)
+	r.POST("/stakes/transfers/:id/cancel", h.CancelTransfer)
+	r.POST("/stakes/listings/:id/buy", h.BuyListing)
+	r.POST("/stakes/listings/:id/cancel", h.CancelListing)
+}
+
+// CreateOffering handles POST /stakes/offerings
+func (h *Handler) CreateOffering(c *gin.Context) {
+	var req CreateOfferingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	offering, err := h.service.CreateOffering(c.Request.Context(), c.GetString("authAgentAddr"), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidOffering), errors.Is(err, ErrInvalidVesting),
+			errors.Is(err, ErrInvalidCadence), errors.Is(err, ErrInvalidTiers):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_offering", "message": err.Error()})
+		case errors.Is(err, ErrShareOvercommit):
+			c.JSON(http.StatusConflict, gin.H{"error": "share_overcommit", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "create_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to create offering"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, offering)
+}
+
+// GetOffering handles GET /stakes/offerings/:id
+func (h *Handler) GetOffering(c *gin.Context) {
+	offering, err := h.service.GetOffering(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Offering not found"})
+		return
+	}
+	c.JSON(http.StatusOK, offering)
+}
+
+// ListOfferings handles GET /stakes/offerings
+func (h *Handler) ListOfferings(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offerings, err := h.service.ListOfferings(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list offerings"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"offerings": offerings, "count": len(offerings)})
+}
+
+// ListAgentOfferings handles GET /agents/:address/stakes/offerings
+func (h *Handler) ListAgentOfferings(c *gin.Context) {
+	address := c.Param("address")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offerings, err := h.service.ListOfferingsByAgent(c.Request.Context(), address, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list offerings"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"offerings": offerings, "count": len(offerings)})
+}
+
+// CloseOffering handles POST /stakes/offerings/:id/close
+func (h *Handler) CloseOffering(c *gin.Context) {
+	offering, err := h.service.CloseOffering(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrOfferingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_owner", "message": err.Error()})
+		case errors.Is(err, ErrOfferingClosed):
+			c.JSON(http.StatusConflict, gin.H{"error": "offering_closed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "close_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to close offering"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, offering)
+}
+
+// BuySharesRequest is the input for a primary-market purchase.
+type BuySharesRequest struct {
+	Shares int `json:"shares" binding:"required"`
+}
+
+// BuyShares handles POST /stakes/offerings/:id/buy
+func (h *Handler) BuyShares(c *gin.Context) {
+	var req BuySharesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	position, err := h.service.BuyShares(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"), req.Shares)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrOfferingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrInvalidShares):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_shares", "message": err.Error()})
+		case errors.Is(err, ErrOfferingClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "offering_closed", "message": err.Error()})
+		case errors.Is(err, ErrSelfStake):
+			c.JSON(http.StatusForbidden, gin.H{"error": "self_stake", "message": err.Error()})
+		case errors.Is(err, ErrSoldOut):
+			c.JSON(http.StatusConflict, gin.H{"error": "sold_out", "message": err.Error()})
+		default:
+			c.JSON(http.StatusPaymentRequired, gin.H{
+				"error":   "purchase_failed",
+				"message": safeMessage(http.StatusPaymentRequired, err, "Purchase failed"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, position)
+}
+
+// Distribute handles POST /stakes/offerings/:id/distribute
+func (h *Handler) Distribute(c *gin.Context) {
+	dist, err := h.service.Distribute(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrOfferingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_owner", "message": err.Error()})
+		case errors.Is(err, ErrNothingAccrued):
+			c.JSON(http.StatusConflict, gin.H{"error": "nothing_accrued", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "distribute_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Distribution failed"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, dist)
+}
+
+// ListDistributions handles GET /stakes/offerings/:id/distributions
+func (h *Handler) ListDistributions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	dists, err := h.service.ListDistributions(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list distributions"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"distributions": dists, "count": len(dists)})
+}
+
+// GetDisclosure handles GET /stakes/offerings/:id/disclosure
+func (h *Handler) GetDisclosure(c *gin.Context) {
+	disclosure, err := h.service.GetDisclosure(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrOfferingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrNoDisclosure):
+			c.JSON(http.StatusNotFound, gin.H{"error": "no_disclosure", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "disclosure_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to load disclosure"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, disclosure)
+}
+
+// Portfolio handles GET /stakes/portfolio/:address
+func (h *Handler) Portfolio(c *gin.Context) {
+	address := c.Param("address")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	positions, err := h.service.Portfolio(c.Request.Context(), address, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list positions"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"positions": positions, "count": len(positions)})
+}
+
+// PortfolioPerformance handles GET /stakes/portfolio/:address/performance
+func (h *Handler) PortfolioPerformance(c *gin.Context) {
+	perf, err := h.service.PortfolioPerformance(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "performance_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to compute performance"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, perf)
+}
+
+// PortfolioStatement handles GET /stakes/portfolio/:address/statement?since=<rfc3339>&until=<rfc3339>&format=<json|csv>
+// Returns the investor's period statement as JSON by default, or as a CSV
+// attachment when format=csv.
+func (h *Handler) PortfolioStatement(c *gin.Context) {
+	var since time.Time
+	until := time.Now()
+	if v := c.Query("since"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_request",
+				"message": "since must be RFC3339",
+			})
+			return
+		}
+		since = parsed
+	}
+	if v := c.Query("until"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_request",
+				"message": "until must be RFC3339",
+			})
+			return
+		}
+		until = parsed
+	}
+
+	stmt, err := h.service.Statement(c.Request.Context(), c.Param("address"), since, until)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "statement_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to build statement"),
+		})
+		return
+	}
+
+	switch c.Query("format") {
+	case "csv":
+		filename := "alancoin-stakes-" + stmt.InvestorAddr + "-" + stmt.GeneratedAt.UTC().Format("20060102T150405Z") + ".csv"
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "text/csv", statementCSV(stmt))
+	default:
+		c.JSON(http.StatusOK, stmt)
+	}
+}
+
+// VestingSchedule handles GET /stakes/positions/:id/vesting
+func (h *Handler) VestingSchedule(c *gin.Context) {
+	status, err := h.service.VestingSchedule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Position not found"})
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// CreateListingRequest is the input for putting a position up for resale.
+type CreateListingRequest struct {
+	Price string `json:"price" binding:"required"`
+}
+
+// CreateListing handles POST /stakes/positions/:id/list
+func (h *Handler) CreateListing(c *gin.Context) {
+	var req CreateListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	listing, err := h.service.CreateListing(c.Request.Context(), c.GetString("authAgentAddr"), c.Param("id"), req.Price)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_owner", "message": err.Error()})
+		case errors.Is(err, ErrInvalidPrice):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_price", "message": err.Error()})
+		case errors.Is(err, ErrPositionLocked):
+			c.JSON(http.StatusConflict, gin.H{"error": "position_locked", "message": err.Error()})
+		case errors.Is(err, ErrAlreadyListed):
+			c.JSON(http.StatusConflict, gin.H{"error": "already_listed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "list_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to create listing"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, listing)
+}
+
+// ProposeTransferRequest is the input for offering a position to another agent.
+type ProposeTransferRequest struct {
+	ToAddr string `json:"toAddr" binding:"required"`
+	Price  string `json:"price,omitempty"` // omit for a gift
+}
+
+// ProposeTransfer handles POST /stakes/positions/:id/transfer
+func (h *Handler) ProposeTransfer(c *gin.Context) {
+	var req ProposeTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	transfer, err := h.service.ProposeTransfer(c.Request.Context(), c.GetString("authAgentAddr"), c.Param("id"), req.ToAddr, req.Price)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrPositionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_owner", "message": err.Error()})
+		case errors.Is(err, ErrInvalidTransfer):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_transfer", "message": err.Error()})
+		case errors.Is(err, ErrSelfTransfer):
+			c.JSON(http.StatusForbidden, gin.H{"error": "self_transfer", "message": err.Error()})
+		case errors.Is(err, ErrPositionLocked):
+			c.JSON(http.StatusConflict, gin.H{"error": "position_locked", "message": err.Error()})
+		case errors.Is(err, ErrAlreadyListed):
+			c.JSON(http.StatusConflict, gin.H{"error": "already_listed", "message": err.Error()})
+		case errors.Is(err, ErrTransferPending):
+			c.JSON(http.StatusConflict, gin.H{"error": "transfer_pending", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "transfer_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to propose transfer"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, transfer)
+}
+
+// AcceptTransfer handles POST /stakes/transfers/:id/accept
+func (h *Handler) AcceptTransfer(c *gin.Context) {
+	transfer, err := h.service.AcceptTransfer(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTransferNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_recipient", "message": err.Error()})
+		case errors.Is(err, ErrTransferClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "transfer_closed", "message": err.Error()})
+		case errors.Is(err, ErrPositionLocked):
+			c.JSON(http.StatusConflict, gin.H{"error": "position_locked", "message": err.Error()})
+		default:
+			c.JSON(http.StatusPaymentRequired, gin.H{
+				"error":   "transfer_failed",
+				"message": safeMessage(http.StatusPaymentRequired, err, "Transfer failed"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, transfer)
+}
+
+// DeclineTransfer handles POST /stakes/transfers/:id/decline
+func (h *Handler) DeclineTransfer(c *gin.Context) {
+	h.closeTransfer(c, h.service.DeclineTransfer, "not_recipient")
+}
+
+// CancelTransfer handles POST /stakes/transfers/:id/cancel
+func (h *Handler) CancelTransfer(c *gin.Context) {
+	h.closeTransfer(c, h.service.CancelTransfer, "not_sender")
+}
+
+func (h *Handler) closeTransfer(c *gin.Context, close func(context.Context, string, string) (*Transfer, error), authCode string) {
+	transfer, err := close(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTransferNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": authCode, "message": err.Error()})
+		case errors.Is(err, ErrTransferClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "transfer_closed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "transfer_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to close transfer"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, transfer)
+}
+
+// GetTransfer handles GET /stakes/transfers/:id
+func (h *Handler) GetTransfer(c *gin.Context) {
+	transfer, err := h.service.GetTransfer(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Transfer not found"})
+		return
+	}
+	c.JSON(http.StatusOK, transfer)
+}
+
+// ListPositionTransfers handles GET /stakes/positions/:id/transfers
+func (h *Handler) ListPositionTransfers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	transfers, err := h.service.ListPositionTransfers(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list transfers"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"transfers": transfers, "count": len(transfers)})
+}
+
+// ListAgentTransfers handles GET /agents/:address/stakes/transfers
+func (h *Handler) ListAgentTransfers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	transfers, err := h.service.ListAgentTransfers(c.Request.Context(), c.Param("address"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list transfers"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"transfers": transfers, "count": len(transfers)})
+}
+
+// GetListing handles GET /stakes/listings/:id
+func (h *Handler) GetListing(c *gin.Context) {
+	listing, err := h.service.GetListing(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Listing not found"})
+		return
+	}
+	c.JSON(http.StatusOK, listing)
+}
+
+// ListMarket handles GET /stakes/market
+func (h *Handler) ListMarket(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	listings, err := h.service.ListMarket(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list market"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"listings": listings, "count": len(listings)})
+}
+
+// ListSellerListings handles GET /agents/:address/stakes/listings
+func (h *Handler) ListSellerListings(c *gin.Context) {
+	address := c.Param("address")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	listings, err := h.service.ListListingsBySeller(c.Request.Context(), address, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": safeMessage(http.StatusInternalServerError, err, "Failed to list listings"),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"listings": listings, "count": len(listings)})
+}
+
+// BuyListing handles POST /stakes/listings/:id/buy
+func (h *Handler) BuyListing(c *gin.Context) {
+	listing, err := h.service.BuyListing(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrListingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrListingClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "listing_closed", "message": err.Error()})
+		case errors.Is(err, ErrSelfTrade):
+			c.JSON(http.StatusForbidden, gin.H{"error": "self_trade", "message": err.Error()})
+		case errors.Is(err, ErrPositionLocked):
+			c.JSON(http.StatusConflict, gin.H{"error": "position_locked", "message": err.Error()})
+		default:
+			c.JSON(http.StatusPaymentRequired, gin.H{
+				"error":   "trade_failed",
+				"message": safeMessage(http.StatusPaymentRequired, err, "Trade failed"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, listing)
+}
+
+// CancelListing handles POST /stakes/listings/:id/cancel
+func (h *Handler) CancelListing(c *gin.Context) {
+	listing, err := h.service.CancelListing(c.Request.Context(), c.Param("id"), c.GetString("authAgentAddr"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrListingNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": err.Error()})
+		case errors.Is(err, ErrUnauthorized):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not_owner", "message": err.Error()})
+		case errors.Is(err, ErrListingClosed):
+			c.JSON(http.StatusGone, gin.H{"error": "listing_closed", "message": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "cancel_failed",
+				"message": safeMessage(http.StatusInternalServerError, err, "Failed to cancel listing"),
+			})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, listing)
+}
@@ -0,0 +1,229 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrListingNotFound = errors.New("stakes: listing not found")
+	ErrListingClosed   = errors.New("stakes: listing not open")
+	ErrAlreadyListed   = errors.New("stakes: position already listed")
+	ErrInvalidPrice    = errors.New("stakes: invalid listing price")
+	ErrSelfTrade       = errors.New("stakes: cannot buy own listing")
+)
+
+// DefaultTradeFeeBps is the platform's cut of secondary-market trades (1%).
+const DefaultTradeFeeBps = 100
+
+// ListingStatus represents the lifecycle of a market listing.
+type ListingStatus string
+
+const (
+	ListingOpen      ListingStatus = "open"
+	ListingSold      ListingStatus = "sold"
+	ListingCancelled ListingStatus = "cancelled"
+)
+
+// Listing is a fixed-price resale of a position on the secondary market.
+// A sale transfers the position — and with it all future distribution
+// rights — to the buyer; the seller keeps distributions already received.
+type Listing struct {
+	ID         string        `json:"id"`
+	PositionID string        `json:"positionId"`
+	OfferingID string        `json:"offeringId"`
+	SellerAddr string        `json:"sellerAddr"`
+	Price      string        `json:"price"` // USDC
+	FeeBps     int           `json:"feeBps"`
+	Status     ListingStatus `json:"status"`
+	BuyerAddr  string        `json:"buyerAddr,omitempty"`
+	FeeAmount  string        `json:"feeAmount,omitempty"` // platform fee taken at sale
+	CreatedAt  time.Time     `json:"createdAt"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+}
+
+// WithTradeFee sets the basis-point platform fee on secondary-market trades.
+func (s *Service) WithTradeFee(bps int) *Service {
+	if bps >= 0 && bps <= 10000 {
+		s.tradeFeeBps = bps
+	}
+	return s
+}
+
+// CreateListing puts a position up for fixed-price resale. Positions pledged
+// as credit collateral cannot be listed until released.
+func (s *Service) CreateListing(ctx context.Context, sellerAddr, positionID, price string) (*Listing, error) {
+	priceBig, ok := usdc.Parse(price)
+	if !ok || priceBig.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	position, err := s.store.GetPosition(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+	if position.InvestorAddr != sellerAddr {
+		return nil, ErrUnauthorized
+	}
+	if position.Status != PositionActive {
+		return nil, ErrPositionNotFound
+	}
+	if position.Locked {
+		return nil, ErrPositionLocked
+	}
+	if existing, err := s.store.GetOpenListingByPosition(ctx, positionID); err == nil && existing != nil {
+		return nil, ErrAlreadyListed
+	}
+	if _, err := s.store.GetPendingTransferByPosition(ctx, positionID); err == nil {
+		return nil, ErrTransferPending
+	}
+
+	now := time.Now()
+	listing := &Listing{
+		ID:         idgen.WithPrefix("lst_"),
+		PositionID: position.ID,
+		OfferingID: position.OfferingID,
+		SellerAddr: sellerAddr,
+		Price:      usdc.Format(priceBig),
+		FeeBps:     s.tradeFeeBps,
+		Status:     ListingOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.store.CreateListing(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("stakes: position listed",
+		"listing", listing.ID, "position", position.ID, "seller", sellerAddr, "price", listing.Price)
+	return listing, nil
+}
+
+// CancelListing takes an open listing off the market.
+func (s *Service) CancelListing(ctx context.Context, listingID, callerAddr string) (*Listing, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	listing, err := s.store.GetListing(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.SellerAddr != callerAddr {
+		return nil, ErrUnauthorized
+	}
+	if listing.Status != ListingOpen {
+		return nil, ErrListingClosed
+	}
+
+	listing.Status = ListingCancelled
+	listing.UpdatedAt = time.Now()
+	if err := s.store.UpdateListing(ctx, listing); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// BuyListing settles a secondary-market trade: the buyer pays the listed
+// price, the platform takes its fee, and the position — with all future
+// distribution rights — transfers to the buyer at a fresh cost basis.
+func (s *Service) BuyListing(ctx context.Context, listingID, buyerAddr string) (*Listing, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	listing, err := s.store.GetListing(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.Status != ListingOpen {
+		return nil, ErrListingClosed
+	}
+	if listing.SellerAddr == buyerAddr {
+		return nil, ErrSelfTrade
+	}
+
+	position, err := s.store.GetPosition(ctx, listing.PositionID)
+	if err != nil {
+		return nil, err
+	}
+	if position.InvestorAddr != listing.SellerAddr || position.Status != PositionActive {
+		return nil, ErrListingClosed
+	}
+	if position.Locked {
+		return nil, ErrPositionLocked
+	}
+
+	priceBig, ok := usdc.Parse(listing.Price)
+	if !ok {
+		return nil, ErrInvalidPrice
+	}
+	// fee = price * bps / 10000; the seller receives the remainder.
+	fee := new(big.Int).Mul(priceBig, big.NewInt(int64(listing.FeeBps)))
+	fee.Div(fee, big.NewInt(10000))
+	proceeds := new(big.Int).Sub(priceBig, fee)
+
+	// Take the fee first so a buyer who can't cover the full price fails
+	// before the seller is paid; refund it if the payout leg fails.
+	if fee.Sign() > 0 && s.platformAddr != "" {
+		if err := s.ledger.Transfer(ctx, buyerAddr, s.platformAddr, usdc.Format(fee), "stakes_trade_fee:"+listing.ID); err != nil {
+			return nil, err
+		}
+	} else {
+		proceeds = priceBig
+		fee = new(big.Int)
+	}
+	if err := s.ledger.Transfer(ctx, buyerAddr, listing.SellerAddr, usdc.Format(proceeds), "stakes_trade:"+listing.ID); err != nil {
+		if fee.Sign() > 0 {
+			if refundErr := s.ledger.Transfer(ctx, s.platformAddr, buyerAddr, usdc.Format(fee), "stakes_trade_fee_refund:"+listing.ID); refundErr != nil {
+				s.logger.Error("stakes: trade fee refund failed",
+					"listing", listing.ID, "buyer", buyerAddr, "amount", usdc.Format(fee), "error", refundErr)
+			}
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	position.InvestorAddr = buyerAddr
+	position.CostBasis = listing.Price
+	position.DistributedTotal = "0.000000"
+	position.UpdatedAt = now
+	if err := s.store.UpdatePosition(ctx, position); err != nil {
+		return nil, err
+	}
+
+	listing.Status = ListingSold
+	listing.BuyerAddr = buyerAddr
+	listing.FeeAmount = usdc.Format(fee)
+	listing.UpdatedAt = now
+	if err := s.store.UpdateListing(ctx, listing); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("stakes: position traded",
+		"listing", listing.ID, "position", position.ID,
+		"seller", listing.SellerAddr, "buyer", buyerAddr,
+		"price", listing.Price, "fee", listing.FeeAmount)
+	return listing, nil
+}
+
+// GetListing returns a listing by ID.
+func (s *Service) GetListing(ctx context.Context, id string) (*Listing, error) {
+	return s.store.GetListing(ctx, id)
+}
+
+// ListMarket returns open listings for browsing.
+func (s *Service) ListMarket(ctx context.Context, limit int) ([]*Listing, error) {
+	return s.store.ListOpenListings(ctx, limit)
+}
+
+// ListListingsBySeller returns an investor's listings, open and settled.
+func (s *Service) ListListingsBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Listing, error) {
+	return s.store.ListListingsBySeller(ctx, sellerAddr, limit)
+}
@@ -0,0 +1,151 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func createTestPosition(t *testing.T, svc *Service) *Position {
+	t.Helper()
+	offering := createTestOffering(t, svc)
+	position, err := svc.BuyShares(context.Background(), offering.ID, investorAddr, 20)
+	if err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	return position
+}
+
+func TestCreateListingValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	if _, err := svc.CreateListing(ctx, investorAddr, position.ID, "0"); !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("expected ErrInvalidPrice, got %v", err)
+	}
+	if _, err := svc.CreateListing(ctx, investor2, position.ID, "3.000000"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-owner, got %v", err)
+	}
+
+	if _, err := svc.CreateListing(ctx, investorAddr, position.ID, "3.000000"); err != nil {
+		t.Fatalf("CreateListing failed: %v", err)
+	}
+	if _, err := svc.CreateListing(ctx, investorAddr, position.ID, "4.000000"); !errors.Is(err, ErrAlreadyListed) {
+		t.Errorf("expected ErrAlreadyListed, got %v", err)
+	}
+
+	// A listed position cannot be pledged as collateral.
+	if _, err := svc.Lock(ctx, investorAddr, position.ID); !errors.Is(err, ErrAlreadyListed) {
+		t.Errorf("expected ErrAlreadyListed for lock of listed position, got %v", err)
+	}
+}
+
+func TestBuyListingTransfersPosition(t *testing.T) {
+	svc, ledger := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	listing, err := svc.CreateListing(ctx, investorAddr, position.ID, "4.000000")
+	if err != nil {
+		t.Fatalf("CreateListing failed: %v", err)
+	}
+
+	if _, err := svc.BuyListing(ctx, listing.ID, investorAddr); !errors.Is(err, ErrSelfTrade) {
+		t.Errorf("expected ErrSelfTrade, got %v", err)
+	}
+
+	sold, err := svc.BuyListing(ctx, listing.ID, investor2)
+	if err != nil {
+		t.Fatalf("BuyListing failed: %v", err)
+	}
+	if sold.Status != ListingSold || sold.BuyerAddr != investor2 {
+		t.Errorf("expected sold listing to buyer, got %s/%s", sold.Status, sold.BuyerAddr)
+	}
+	// 1% platform fee on a 4 USDC trade.
+	if sold.FeeAmount != "0.040000" {
+		t.Errorf("expected fee 0.040000, got %s", sold.FeeAmount)
+	}
+	if got := ledger.balance(platformAddr); got != "0.040000" {
+		t.Errorf("expected platform balance 0.040000, got %s", got)
+	}
+	// Seller paid 2 for the position, receives 3.96 net: 100 - 2 + 3.96.
+	if got := ledger.balance(investorAddr); got != "101.960000" {
+		t.Errorf("expected seller balance 101.960000, got %s", got)
+	}
+
+	position, _ = svc.GetPosition(ctx, position.ID)
+	if position.InvestorAddr != investor2 {
+		t.Errorf("expected position owned by buyer, got %s", position.InvestorAddr)
+	}
+	if position.CostBasis != "4.000000" || position.DistributedTotal != "0.000000" {
+		t.Errorf("expected fresh basis 4.000000/0.000000, got %s/%s", position.CostBasis, position.DistributedTotal)
+	}
+
+	// Future distributions now pay the buyer.
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	if _, err := svc.Distribute(ctx, position.OfferingID, agentAddr); err != nil {
+		t.Fatalf("Distribute failed: %v", err)
+	}
+	position, _ = svc.GetPosition(ctx, position.ID)
+	if position.DistributedTotal != "2.000000" {
+		t.Errorf("expected buyer to receive 2.000000 distribution, got %s", position.DistributedTotal)
+	}
+
+	// Sold listings cannot be bought again.
+	if _, err := svc.BuyListing(ctx, listing.ID, investorAddr); !errors.Is(err, ErrListingClosed) {
+		t.Errorf("expected ErrListingClosed, got %v", err)
+	}
+}
+
+func TestCancelListing(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	listing, err := svc.CreateListing(ctx, investorAddr, position.ID, "3.000000")
+	if err != nil {
+		t.Fatalf("CreateListing failed: %v", err)
+	}
+
+	if _, err := svc.CancelListing(ctx, listing.ID, investor2); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+	cancelled, err := svc.CancelListing(ctx, listing.ID, investorAddr)
+	if err != nil {
+		t.Fatalf("CancelListing failed: %v", err)
+	}
+	if cancelled.Status != ListingCancelled {
+		t.Errorf("expected cancelled, got %s", cancelled.Status)
+	}
+
+	// The position can be listed again once the old listing is cancelled.
+	if _, err := svc.CreateListing(ctx, investorAddr, position.ID, "3.500000"); err != nil {
+		t.Errorf("expected relist after cancel, got %v", err)
+	}
+}
+
+func TestBuyListingInsufficientFunds(t *testing.T) {
+	svc, ledger := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	listing, err := svc.CreateListing(ctx, investorAddr, position.ID, "500.000000")
+	if err != nil {
+		t.Fatalf("CreateListing failed: %v", err)
+	}
+	if _, err := svc.BuyListing(ctx, listing.ID, investor2); err == nil {
+		t.Fatal("expected trade to fail on insufficient balance")
+	}
+
+	// Nothing moved and the listing stays open.
+	if got := ledger.balance(investor2); got != "100.000000" {
+		t.Errorf("expected buyer balance unchanged, got %s", got)
+	}
+	listing, _ = svc.GetListing(ctx, listing.ID)
+	if listing.Status != ListingOpen {
+		t.Errorf("expected listing still open, got %s", listing.Status)
+	}
+}
@@ -0,0 +1,360 @@
+package stakes
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore is an in-memory store for demo/development.
+type MemoryStore struct {
+	offerings     map[string]*Offering
+	positions     map[string]*Position
+	distributions map[string]*Distribution
+	listings      map[string]*Listing
+	transfers     map[string]*Transfer
+	mu            sync.RWMutex
+}
+
+// NewMemoryStore creates a new in-memory stakes store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		offerings:     make(map[string]*Offering),
+		positions:     make(map[string]*Position),
+		distributions: make(map[string]*Distribution),
+		listings:      make(map[string]*Listing),
+		transfers:     make(map[string]*Transfer),
+	}
+}
+
+func (m *MemoryStore) CreateOffering(ctx context.Context, o *Offering) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.offerings[o.ID] = copyOffering(o)
+	return nil
+}
+
+func (m *MemoryStore) GetOffering(ctx context.Context, id string) (*Offering, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	o, ok := m.offerings[id]
+	if !ok {
+		return nil, ErrOfferingNotFound
+	}
+	return copyOffering(o), nil
+}
+
+func (m *MemoryStore) UpdateOffering(ctx context.Context, o *Offering) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.offerings[o.ID]; !ok {
+		return ErrOfferingNotFound
+	}
+	m.offerings[o.ID] = copyOffering(o)
+	return nil
+}
+
+func (m *MemoryStore) ListOfferings(ctx context.Context, limit int) ([]*Offering, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Offering
+	for _, o := range m.offerings {
+		if o.Status != OfferingOpen {
+			continue
+		}
+		result = append(result, copyOffering(o))
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListOfferingsByAgent(ctx context.Context, agentAddr string, limit int) ([]*Offering, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Offering
+	for _, o := range m.offerings {
+		if o.AgentAddr == agentAddr {
+			result = append(result, copyOffering(o))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListScheduledOfferings(ctx context.Context, limit int) ([]*Offering, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Offering
+	for _, o := range m.offerings {
+		if o.Cadence == "" {
+			continue
+		}
+		result = append(result, copyOffering(o))
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreatePosition(ctx context.Context, p *Position) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.positions[p.ID] = copyPosition(p)
+	return nil
+}
+
+func (m *MemoryStore) GetPosition(ctx context.Context, id string) (*Position, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.positions[id]
+	if !ok {
+		return nil, ErrPositionNotFound
+	}
+	return copyPosition(p), nil
+}
+
+func (m *MemoryStore) UpdatePosition(ctx context.Context, p *Position) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.positions[p.ID]; !ok {
+		return ErrPositionNotFound
+	}
+	m.positions[p.ID] = copyPosition(p)
+	return nil
+}
+
+func (m *MemoryStore) ListPositionsByInvestor(ctx context.Context, investorAddr string, limit int) ([]*Position, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Position
+	for _, p := range m.positions {
+		if p.InvestorAddr == investorAddr {
+			result = append(result, copyPosition(p))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListPositionsByOffering(ctx context.Context, offeringID string, limit int) ([]*Position, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Position
+	for _, p := range m.positions {
+		if p.OfferingID == offeringID {
+			result = append(result, copyPosition(p))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateDistribution(ctx context.Context, d *Distribution) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.distributions[d.ID] = copyDistribution(d)
+	return nil
+}
+
+func (m *MemoryStore) ListDistributionsByOffering(ctx context.Context, offeringID string, limit int) ([]*Distribution, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Distribution
+	for _, d := range m.distributions {
+		if d.OfferingID == offeringID {
+			result = append(result, copyDistribution(d))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateListing(ctx context.Context, l *Listing) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listings[l.ID] = copyListing(l)
+	return nil
+}
+
+func (m *MemoryStore) GetListing(ctx context.Context, id string) (*Listing, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	l, ok := m.listings[id]
+	if !ok {
+		return nil, ErrListingNotFound
+	}
+	return copyListing(l), nil
+}
+
+func (m *MemoryStore) UpdateListing(ctx context.Context, l *Listing) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.listings[l.ID]; !ok {
+		return ErrListingNotFound
+	}
+	m.listings[l.ID] = copyListing(l)
+	return nil
+}
+
+func (m *MemoryStore) GetOpenListingByPosition(ctx context.Context, positionID string) (*Listing, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, l := range m.listings {
+		if l.PositionID == positionID && l.Status == ListingOpen {
+			return copyListing(l), nil
+		}
+	}
+	return nil, ErrListingNotFound
+}
+
+func (m *MemoryStore) ListOpenListings(ctx context.Context, limit int) ([]*Listing, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Listing
+	for _, l := range m.listings {
+		if l.Status != ListingOpen {
+			continue
+		}
+		result = append(result, copyListing(l))
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListListingsBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Listing, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Listing
+	for _, l := range m.listings {
+		if l.SellerAddr == sellerAddr {
+			result = append(result, copyListing(l))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) CreateTransfer(ctx context.Context, t *Transfer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transfers[t.ID] = copyTransfer(t)
+	return nil
+}
+
+func (m *MemoryStore) GetTransfer(ctx context.Context, id string) (*Transfer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	t, ok := m.transfers[id]
+	if !ok {
+		return nil, ErrTransferNotFound
+	}
+	return copyTransfer(t), nil
+}
+
+func (m *MemoryStore) UpdateTransfer(ctx context.Context, t *Transfer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.transfers[t.ID]; !ok {
+		return ErrTransferNotFound
+	}
+	m.transfers[t.ID] = copyTransfer(t)
+	return nil
+}
+
+func (m *MemoryStore) GetPendingTransferByPosition(ctx context.Context, positionID string) (*Transfer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, t := range m.transfers {
+		if t.PositionID == positionID && t.Status == TransferPending {
+			return copyTransfer(t), nil
+		}
+	}
+	return nil, ErrTransferNotFound
+}
+
+func (m *MemoryStore) ListTransfersByPosition(ctx context.Context, positionID string, limit int) ([]*Transfer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Transfer
+	for _, t := range m.transfers {
+		if t.PositionID == positionID {
+			result = append(result, copyTransfer(t))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListTransfersByAgent(ctx context.Context, agentAddr string, limit int) ([]*Transfer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var result []*Transfer
+	for _, t := range m.transfers {
+		if t.FromAddr == agentAddr || t.ToAddr == agentAddr {
+			result = append(result, copyTransfer(t))
+			if limit > 0 && len(result) >= limit {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func copyOffering(o *Offering) *Offering {
+	cp := *o
+	if o.ShareTiers != nil {
+		cp.ShareTiers = append([]ShareTier(nil), o.ShareTiers...)
+	}
+	if o.Vesting != nil {
+		v := *o.Vesting
+		cp.Vesting = &v
+	}
+	if o.LastDistributedAt != nil {
+		t := *o.LastDistributedAt
+		cp.LastDistributedAt = &t
+	}
+	if o.Disclosure != nil {
+		d := *o.Disclosure
+		cp.Disclosure = &d
+	}
+	return &cp
+}
+
+func copyPosition(p *Position) *Position {
+	cp := *p
+	return &cp
+}
+
+func copyDistribution(d *Distribution) *Distribution {
+	cp := *d
+	cp.Payouts = append([]Payout(nil), d.Payouts...)
+	return &cp
+}
+
+func copyListing(l *Listing) *Listing {
+	cp := *l
+	return &cp
+}
+
+func copyTransfer(t *Transfer) *Transfer {
+	cp := *t
+	return &cp
+}
+
+var _ Store = (*MemoryStore)(nil)
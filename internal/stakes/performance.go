@@ -0,0 +1,154 @@
+package stakes
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// PositionPerformance summarizes one position's returns. Realized return is
+// distributions received against cost; unrealized marks the shares at the
+// offering's current share price. SimpleIRR annualizes the total return over
+// the holding period — a linear approximation, not a true discounted IRR.
+type PositionPerformance struct {
+	PositionID     string         `json:"positionId"`
+	OfferingID     string         `json:"offeringId"`
+	AgentAddr      string         `json:"agentAddr"`
+	Shares         int            `json:"shares"`
+	Status         PositionStatus `json:"status"`
+	Invested       string         `json:"invested"`    // USDC cost basis
+	Distributed    string         `json:"distributed"` // USDC received in distributions
+	MarkValue      string         `json:"markValue"`   // USDC at the current share price
+	RealizedBps    int            `json:"realizedBps"` // distributed / invested
+	TotalReturnBps int            `json:"totalReturnBps"`
+	SimpleIRRBps   int            `json:"simpleIrrBps"`
+	HeldDays       int            `json:"heldDays"`
+}
+
+// PortfolioPerformance aggregates an investor's positions. The aggregate IRR
+// annualizes the combined return over the capital-weighted holding period.
+type PortfolioPerformance struct {
+	InvestorAddr   string                 `json:"investorAddr"`
+	Positions      []*PositionPerformance `json:"positions"`
+	Invested       string                 `json:"invested"`
+	Distributed    string                 `json:"distributed"`
+	MarkValue      string                 `json:"markValue"`
+	RealizedBps    int                    `json:"realizedBps"`
+	TotalReturnBps int                    `json:"totalReturnBps"`
+	SimpleIRRBps   int                    `json:"simpleIrrBps"`
+}
+
+const secondsPerYear = 365 * 24 * 60 * 60
+
+// bpsOf returns numerator/denominator in basis points, 0 for a zero denominator.
+func bpsOf(numerator, denominator *big.Int) int {
+	if denominator.Sign() <= 0 {
+		return 0
+	}
+	bps := new(big.Int).Mul(numerator, big.NewInt(10000))
+	bps.Div(bps, denominator)
+	return int(bps.Int64())
+}
+
+// annualizeBps spreads a total return over the holding period. Periods under
+// a day count as one day so fresh positions don't annualize to absurdity.
+func annualizeBps(totalReturnBps int, held time.Duration) int {
+	seconds := int64(held / time.Second)
+	if seconds < 24*60*60 {
+		seconds = 24 * 60 * 60
+	}
+	return int(int64(totalReturnBps) * secondsPerYear / seconds)
+}
+
+// positionPerformance computes one position's metrics at now.
+func positionPerformance(offering *Offering, position *Position, now time.Time) *PositionPerformance {
+	invested, _ := usdc.Parse(position.CostBasis)
+	if invested == nil {
+		invested = new(big.Int)
+	}
+	distributed, _ := usdc.Parse(position.DistributedTotal)
+	if distributed == nil {
+		distributed = new(big.Int)
+	}
+
+	mark := new(big.Int)
+	if position.Status == PositionActive {
+		if price, ok := usdc.Parse(offering.PricePerShare); ok {
+			mark.Mul(price, big.NewInt(int64(position.Shares)))
+		}
+	}
+
+	// gain = distributed + mark - invested; negative until the position
+	// has earned (or is worth) more than it cost.
+	gain := new(big.Int).Add(distributed, mark)
+	gain.Sub(gain, invested)
+
+	held := now.Sub(position.CreatedAt)
+	totalReturnBps := bpsOf(gain, invested)
+	return &PositionPerformance{
+		PositionID:     position.ID,
+		OfferingID:     position.OfferingID,
+		AgentAddr:      position.AgentAddr,
+		Shares:         position.Shares,
+		Status:         position.Status,
+		Invested:       usdc.Format(invested),
+		Distributed:    usdc.Format(distributed),
+		MarkValue:      usdc.Format(mark),
+		RealizedBps:    bpsOf(distributed, invested),
+		TotalReturnBps: totalReturnBps,
+		SimpleIRRBps:   annualizeBps(totalReturnBps, held),
+		HeldDays:       int(held / (24 * time.Hour)),
+	}
+}
+
+// PortfolioPerformance computes per-position and aggregate return metrics
+// for everything the investor holds.
+func (s *Service) PortfolioPerformance(ctx context.Context, investorAddr string) (*PortfolioPerformance, error) {
+	positions, err := s.store.ListPositionsByInvestor(ctx, investorAddr, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	perf := &PortfolioPerformance{
+		InvestorAddr: investorAddr,
+		Positions:    make([]*PositionPerformance, 0, len(positions)),
+	}
+
+	totalInvested := new(big.Int)
+	totalDistributed := new(big.Int)
+	totalMark := new(big.Int)
+	weightedHeld := new(big.Int) // invested-weighted holding seconds
+	for _, position := range positions {
+		offering, err := s.store.GetOffering(ctx, position.OfferingID)
+		if err != nil {
+			return nil, err
+		}
+		pp := positionPerformance(offering, position, now)
+		perf.Positions = append(perf.Positions, pp)
+
+		invested, _ := usdc.Parse(pp.Invested)
+		distributed, _ := usdc.Parse(pp.Distributed)
+		mark, _ := usdc.Parse(pp.MarkValue)
+		totalInvested.Add(totalInvested, invested)
+		totalDistributed.Add(totalDistributed, distributed)
+		totalMark.Add(totalMark, mark)
+		heldSeconds := int64(now.Sub(position.CreatedAt) / time.Second)
+		weightedHeld.Add(weightedHeld, new(big.Int).Mul(invested, big.NewInt(heldSeconds)))
+	}
+
+	gain := new(big.Int).Add(totalDistributed, totalMark)
+	gain.Sub(gain, totalInvested)
+	perf.Invested = usdc.Format(totalInvested)
+	perf.Distributed = usdc.Format(totalDistributed)
+	perf.MarkValue = usdc.Format(totalMark)
+	perf.RealizedBps = bpsOf(totalDistributed, totalInvested)
+	perf.TotalReturnBps = bpsOf(gain, totalInvested)
+	if totalInvested.Sign() > 0 {
+		avgHeld := new(big.Int).Div(weightedHeld, totalInvested)
+		perf.SimpleIRRBps = annualizeBps(perf.TotalReturnBps, time.Duration(avgHeld.Int64())*time.Second)
+	}
+	return perf, nil
+}
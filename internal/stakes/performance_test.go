@@ -0,0 +1,95 @@
+package stakes
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPositionPerformanceMetrics(t *testing.T) {
+	now := time.Now()
+	offering := &Offering{ID: "off_1", PricePerShare: "0.100000"}
+	position := &Position{
+		ID:               "pos_1",
+		OfferingID:       "off_1",
+		Shares:           40,
+		CostBasis:        "4.000000",
+		DistributedTotal: "2.000000",
+		Status:           PositionActive,
+		CreatedAt:        now.Add(-365 * 24 * time.Hour),
+	}
+
+	pp := positionPerformance(offering, position, now)
+	if pp.Invested != "4.000000" || pp.Distributed != "2.000000" || pp.MarkValue != "4.000000" {
+		t.Errorf("unexpected amounts: invested=%s distributed=%s mark=%s", pp.Invested, pp.Distributed, pp.MarkValue)
+	}
+	// 2 realized on 4 invested, mark flat: 50% realized and total return.
+	if pp.RealizedBps != 5000 || pp.TotalReturnBps != 5000 {
+		t.Errorf("expected 5000 bps realized and total, got %d / %d", pp.RealizedBps, pp.TotalReturnBps)
+	}
+	// Held exactly a year, so the annualized return equals the total return.
+	if pp.SimpleIRRBps != 5000 {
+		t.Errorf("expected 5000 bps IRR over one year, got %d", pp.SimpleIRRBps)
+	}
+	if pp.HeldDays != 365 {
+		t.Errorf("expected 365 held days, got %d", pp.HeldDays)
+	}
+
+	// A liquidated position marks to zero: only distributions count.
+	position.Status = PositionLiquidated
+	pp = positionPerformance(offering, position, now)
+	if pp.MarkValue != "0.000000" || pp.TotalReturnBps != -5000 {
+		t.Errorf("expected zero mark and -5000 bps, got mark=%s total=%d", pp.MarkValue, pp.TotalReturnBps)
+	}
+}
+
+func TestAnnualizeBpsFloorsHoldingPeriod(t *testing.T) {
+	// A position held an hour annualizes as if held a day.
+	if got, want := annualizeBps(100, time.Hour), annualizeBps(100, 24*time.Hour); got != want {
+		t.Errorf("expected sub-day holds to floor at one day: %d != %d", got, want)
+	}
+	if got := annualizeBps(100, 365*24*time.Hour); got != 100 {
+		t.Errorf("expected one-year hold to pass through, got %d", got)
+	}
+}
+
+func TestPortfolioPerformanceAggregates(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	offering := createTestOffering(t, svc)
+
+	if _, err := svc.BuyShares(ctx, offering.ID, investorAddr, 40); err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	if _, err := svc.Distribute(ctx, offering.ID, agentAddr); err != nil {
+		t.Fatalf("Distribute failed: %v", err)
+	}
+
+	perf, err := svc.PortfolioPerformance(ctx, investorAddr)
+	if err != nil {
+		t.Fatalf("PortfolioPerformance failed: %v", err)
+	}
+	if len(perf.Positions) != 1 {
+		t.Fatalf("expected 1 position, got %d", len(perf.Positions))
+	}
+	// 40 shares at 0.10 cost 4; the full 2 USDC pool went to the only holder.
+	if perf.Invested != "4.000000" || perf.Distributed != "2.000000" || perf.MarkValue != "4.000000" {
+		t.Errorf("unexpected aggregates: invested=%s distributed=%s mark=%s",
+			perf.Invested, perf.Distributed, perf.MarkValue)
+	}
+	if perf.RealizedBps != 5000 || perf.TotalReturnBps != 5000 {
+		t.Errorf("expected 5000 bps realized and total, got %d / %d", perf.RealizedBps, perf.TotalReturnBps)
+	}
+
+	// An investor with no positions reports flat zeros, not an error.
+	perf, err = svc.PortfolioPerformance(ctx, investor2)
+	if err != nil {
+		t.Fatalf("PortfolioPerformance failed: %v", err)
+	}
+	if len(perf.Positions) != 0 || perf.Invested != "0.000000" || perf.TotalReturnBps != 0 {
+		t.Errorf("expected empty portfolio, got %+v", perf)
+	}
+}
@@ -0,0 +1,96 @@
+package stakes
+
+import (
+	"context"
+	"time"
+)
+
+// Lock implements credit.StakePledger. It reserves a position as credit
+// collateral — a locked position cannot be listed or traded — and returns
+// its current value (the holder's cost basis).
+func (s *Service) Lock(ctx context.Context, agentAddr, positionID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	position, err := s.store.GetPosition(ctx, positionID)
+	if err != nil {
+		return "", err
+	}
+	if position.InvestorAddr != agentAddr {
+		return "", ErrUnauthorized
+	}
+	if position.Status != PositionActive {
+		return "", ErrPositionNotFound
+	}
+	if position.Locked {
+		return "", ErrPositionLocked
+	}
+	if existing, err := s.store.GetOpenListingByPosition(ctx, positionID); err == nil && existing != nil {
+		return "", ErrAlreadyListed
+	}
+
+	position.Locked = true
+	position.UpdatedAt = time.Now()
+	if err := s.store.UpdatePosition(ctx, position); err != nil {
+		return "", err
+	}
+	return position.CostBasis, nil
+}
+
+// Unlock implements credit.StakePledger, returning a pledged position to the
+// holder's control.
+func (s *Service) Unlock(ctx context.Context, agentAddr, positionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	position, err := s.store.GetPosition(ctx, positionID)
+	if err != nil {
+		return err
+	}
+	if position.InvestorAddr != agentAddr {
+		return ErrUnauthorized
+	}
+	if !position.Locked {
+		return nil
+	}
+
+	position.Locked = false
+	position.UpdatedAt = time.Now()
+	return s.store.UpdatePosition(ctx, position)
+}
+
+// Liquidate implements credit.StakePledger. On credit default the platform
+// takes over the position — and its future distribution rights — and the
+// realized value (the defaulter's cost basis) repays the outstanding draw.
+func (s *Service) Liquidate(ctx context.Context, agentAddr, positionID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	position, err := s.store.GetPosition(ctx, positionID)
+	if err != nil {
+		return "", err
+	}
+	if position.InvestorAddr != agentAddr {
+		return "", ErrUnauthorized
+	}
+	if position.Status != PositionActive {
+		return "", ErrPositionNotFound
+	}
+
+	realized := position.CostBasis
+	if s.platformAddr != "" {
+		position.InvestorAddr = s.platformAddr
+	} else {
+		// No custody account configured: retire the position instead.
+		position.Status = PositionLiquidated
+	}
+	position.Locked = false
+	position.UpdatedAt = time.Now()
+	if err := s.store.UpdatePosition(ctx, position); err != nil {
+		return "", err
+	}
+
+	s.logger.Warn("stakes: position liquidated",
+		"position", position.ID, "agent", agentAddr, "realized", realized)
+	return realized, nil
+}
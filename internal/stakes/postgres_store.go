@@ -0,0 +1,594 @@
+package stakes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// PostgresStore persists stakes data in PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new PostgreSQL-backed stakes store.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const offeringColumns = `id, agent_addr, description, revenue_share_bps, share_tiers,
+	total_shares, shares_sold, price_per_share, accrued, total_distributed,
+	vesting, cadence, min_distribution, last_distributed_at, disclosure, status, created_at, updated_at`
+
+const positionColumns = `id, offering_id, agent_addr, investor_addr, shares,
+	cost_basis, distributed_total, vesting_start, locked, status, created_at, updated_at`
+
+const listingColumns = `id, position_id, offering_id, seller_addr, price,
+	fee_bps, status, buyer_addr, fee_amount, created_at, updated_at`
+
+const transferColumns = `id, position_id, offering_id, from_addr, to_addr,
+	price, status, created_at, updated_at`
+
+// effectiveLimit caps unbounded list queries; a limit <= 0 means no limit.
+func effectiveLimit(limit int) int {
+	if limit <= 0 {
+		return 10000
+	}
+	return limit
+}
+
+func (p *PostgresStore) CreateOffering(ctx context.Context, o *Offering) error {
+	var vestingJSON []byte
+	if o.Vesting != nil {
+		vestingJSON, _ = json.Marshal(o.Vesting)
+	}
+	var disclosureJSON []byte
+	if o.Disclosure != nil {
+		disclosureJSON, _ = json.Marshal(o.Disclosure)
+	}
+	var tiersJSON []byte
+	if len(o.ShareTiers) > 0 {
+		tiersJSON, _ = json.Marshal(o.ShareTiers)
+	}
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO stake_offerings (
+			id, agent_addr, description, revenue_share_bps, share_tiers,
+			total_shares, shares_sold, price_per_share, accrued, total_distributed,
+			vesting, cadence, min_distribution, last_distributed_at, disclosure, status, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			$6, $7, $8::NUMERIC(20,6), $9::NUMERIC(20,6), $10::NUMERIC(20,6),
+			$11, $12, $13::NUMERIC(20,6), $14, $15, $16, $17, $18
+		)`,
+		o.ID, o.AgentAddr, o.Description, o.RevenueShareBps, tiersJSON,
+		o.TotalShares, o.SharesSold, o.PricePerShare, o.Accrued, o.TotalDistributed,
+		vestingJSON, string(o.Cadence), o.MinDistribution, nullTime(o.LastDistributedAt), disclosureJSON, string(o.Status), o.CreatedAt, o.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetOffering(ctx context.Context, id string) (*Offering, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+offeringColumns+` FROM stake_offerings WHERE id = $1`, id)
+	o, err := scanOffering(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrOfferingNotFound
+	}
+	return o, err
+}
+
+func (p *PostgresStore) UpdateOffering(ctx context.Context, o *Offering) error {
+	var disclosureJSON []byte
+	if o.Disclosure != nil {
+		disclosureJSON, _ = json.Marshal(o.Disclosure)
+	}
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE stake_offerings SET
+			shares_sold = $1, accrued = $2::NUMERIC(20,6),
+			total_distributed = $3::NUMERIC(20,6), last_distributed_at = $4, disclosure = $5, status = $6, updated_at = $7
+		WHERE id = $8`,
+		o.SharesSold, o.Accrued, o.TotalDistributed, nullTime(o.LastDistributedAt), disclosureJSON, string(o.Status), o.UpdatedAt, o.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrOfferingNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListOfferings(ctx context.Context, limit int) ([]*Offering, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+offeringColumns+`
+		FROM stake_offerings
+		WHERE status = 'open'
+		ORDER BY created_at DESC
+		LIMIT $1`, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanOfferings(rows)
+}
+
+func (p *PostgresStore) ListOfferingsByAgent(ctx context.Context, agentAddr string, limit int) ([]*Offering, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+offeringColumns+`
+		FROM stake_offerings
+		WHERE agent_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, agentAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanOfferings(rows)
+}
+
+func (p *PostgresStore) ListScheduledOfferings(ctx context.Context, limit int) ([]*Offering, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+offeringColumns+`
+		FROM stake_offerings
+		WHERE cadence <> ''
+		ORDER BY created_at ASC
+		LIMIT $1`, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanOfferings(rows)
+}
+
+func (p *PostgresStore) CreatePosition(ctx context.Context, pos *Position) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO stake_positions (
+			id, offering_id, agent_addr, investor_addr, shares,
+			cost_basis, distributed_total, vesting_start, locked, status, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			$6::NUMERIC(20,6), $7::NUMERIC(20,6), $8, $9, $10, $11, $12
+		)`,
+		pos.ID, pos.OfferingID, pos.AgentAddr, pos.InvestorAddr, pos.Shares,
+		pos.CostBasis, pos.DistributedTotal, pos.VestingStart, pos.Locked, string(pos.Status), pos.CreatedAt, pos.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetPosition(ctx context.Context, id string) (*Position, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+positionColumns+` FROM stake_positions WHERE id = $1`, id)
+	pos, err := scanPosition(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrPositionNotFound
+	}
+	return pos, err
+}
+
+func (p *PostgresStore) UpdatePosition(ctx context.Context, pos *Position) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE stake_positions SET
+			investor_addr = $1, cost_basis = $2::NUMERIC(20,6),
+			distributed_total = $3::NUMERIC(20,6), locked = $4, status = $5, updated_at = $6
+		WHERE id = $7`,
+		pos.InvestorAddr, pos.CostBasis, pos.DistributedTotal,
+		pos.Locked, string(pos.Status), pos.UpdatedAt, pos.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrPositionNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) ListPositionsByInvestor(ctx context.Context, investorAddr string, limit int) ([]*Position, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+positionColumns+`
+		FROM stake_positions
+		WHERE investor_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, investorAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanPositions(rows)
+}
+
+func (p *PostgresStore) ListPositionsByOffering(ctx context.Context, offeringID string, limit int) ([]*Position, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+positionColumns+`
+		FROM stake_positions
+		WHERE offering_id = $1
+		ORDER BY created_at ASC
+		LIMIT $2`, offeringID, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanPositions(rows)
+}
+
+func (p *PostgresStore) CreateDistribution(ctx context.Context, d *Distribution) error {
+	payoutsJSON, _ := json.Marshal(d.Payouts)
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO stake_distributions (
+			id, offering_id, total_amount, payouts, created_at
+		) VALUES ($1, $2, $3::NUMERIC(20,6), $4, $5)`,
+		d.ID, d.OfferingID, d.TotalAmount, payoutsJSON, d.CreatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) ListDistributionsByOffering(ctx context.Context, offeringID string, limit int) ([]*Distribution, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, offering_id, total_amount, payouts, created_at
+		FROM stake_distributions
+		WHERE offering_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, offeringID, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*Distribution
+	for rows.Next() {
+		d := &Distribution{}
+		var payoutsJSON []byte
+		if err := rows.Scan(&d.ID, &d.OfferingID, &d.TotalAmount, &payoutsJSON, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		if len(payoutsJSON) > 0 {
+			if err := json.Unmarshal(payoutsJSON, &d.Payouts); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, d)
+	}
+	return result, rows.Err()
+}
+
+func (p *PostgresStore) CreateListing(ctx context.Context, l *Listing) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO stake_listings (
+			id, position_id, offering_id, seller_addr, price,
+			fee_bps, status, buyer_addr, fee_amount, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5::NUMERIC(20,6),
+			$6, $7, $8, $9::NUMERIC(20,6), $10, $11
+		)`,
+		l.ID, l.PositionID, l.OfferingID, l.SellerAddr, l.Price,
+		l.FeeBps, string(l.Status), nullString(l.BuyerAddr), nullString(l.FeeAmount), l.CreatedAt, l.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetListing(ctx context.Context, id string) (*Listing, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+listingColumns+` FROM stake_listings WHERE id = $1`, id)
+	l, err := scanListing(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrListingNotFound
+	}
+	return l, err
+}
+
+func (p *PostgresStore) UpdateListing(ctx context.Context, l *Listing) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE stake_listings SET
+			status = $1, buyer_addr = $2, fee_amount = $3::NUMERIC(20,6), updated_at = $4
+		WHERE id = $5`,
+		string(l.Status), nullString(l.BuyerAddr), nullString(l.FeeAmount), l.UpdatedAt, l.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrListingNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) GetOpenListingByPosition(ctx context.Context, positionID string) (*Listing, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT `+listingColumns+`
+		FROM stake_listings
+		WHERE position_id = $1 AND status = 'open'
+		ORDER BY created_at DESC
+		LIMIT 1`, positionID)
+	l, err := scanListing(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrListingNotFound
+	}
+	return l, err
+}
+
+func (p *PostgresStore) ListOpenListings(ctx context.Context, limit int) ([]*Listing, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+listingColumns+`
+		FROM stake_listings
+		WHERE status = 'open'
+		ORDER BY created_at DESC
+		LIMIT $1`, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanListings(rows)
+}
+
+func (p *PostgresStore) ListListingsBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Listing, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+listingColumns+`
+		FROM stake_listings
+		WHERE seller_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, sellerAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanListings(rows)
+}
+
+func (p *PostgresStore) CreateTransfer(ctx context.Context, t *Transfer) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO stake_transfers (
+			id, position_id, offering_id, from_addr, to_addr,
+			price, status, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			$6::NUMERIC(20,6), $7, $8, $9
+		)`,
+		t.ID, t.PositionID, t.OfferingID, t.FromAddr, t.ToAddr,
+		nullString(t.Price), string(t.Status), t.CreatedAt, t.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetTransfer(ctx context.Context, id string) (*Transfer, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+transferColumns+` FROM stake_transfers WHERE id = $1`, id)
+	t, err := scanTransfer(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrTransferNotFound
+	}
+	return t, err
+}
+
+func (p *PostgresStore) UpdateTransfer(ctx context.Context, t *Transfer) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE stake_transfers SET status = $1, updated_at = $2 WHERE id = $3`,
+		string(t.Status), t.UpdatedAt, t.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTransferNotFound
+	}
+	return nil
+}
+
+func (p *PostgresStore) GetPendingTransferByPosition(ctx context.Context, positionID string) (*Transfer, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT `+transferColumns+`
+		FROM stake_transfers
+		WHERE position_id = $1 AND status = 'pending'
+		ORDER BY created_at DESC
+		LIMIT 1`, positionID)
+	t, err := scanTransfer(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrTransferNotFound
+	}
+	return t, err
+}
+
+func (p *PostgresStore) ListTransfersByPosition(ctx context.Context, positionID string, limit int) ([]*Transfer, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+transferColumns+`
+		FROM stake_transfers
+		WHERE position_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, positionID, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanTransfers(rows)
+}
+
+func (p *PostgresStore) ListTransfersByAgent(ctx context.Context, agentAddr string, limit int) ([]*Transfer, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT `+transferColumns+`
+		FROM stake_transfers
+		WHERE from_addr = $1 OR to_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, agentAddr, effectiveLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanTransfers(rows)
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOffering(s scanner) (*Offering, error) {
+	o := &Offering{}
+	var (
+		tiersJSON       []byte
+		vestingJSON     []byte
+		cadence         string
+		lastDistributed sql.NullTime
+		disclosureJSON  []byte
+		status          string
+	)
+	err := s.Scan(
+		&o.ID, &o.AgentAddr, &o.Description, &o.RevenueShareBps, &tiersJSON,
+		&o.TotalShares, &o.SharesSold, &o.PricePerShare, &o.Accrued, &o.TotalDistributed,
+		&vestingJSON, &cadence, &o.MinDistribution, &lastDistributed, &disclosureJSON, &status, &o.CreatedAt, &o.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(tiersJSON) > 0 {
+		if err := json.Unmarshal(tiersJSON, &o.ShareTiers); err != nil {
+			return nil, err
+		}
+	}
+	if len(vestingJSON) > 0 {
+		o.Vesting = &Vesting{}
+		if err := json.Unmarshal(vestingJSON, o.Vesting); err != nil {
+			return nil, err
+		}
+	}
+	if len(disclosureJSON) > 0 {
+		o.Disclosure = &Disclosure{}
+		if err := json.Unmarshal(disclosureJSON, o.Disclosure); err != nil {
+			return nil, err
+		}
+	}
+	o.Cadence = Cadence(cadence)
+	if lastDistributed.Valid {
+		t := lastDistributed.Time
+		o.LastDistributedAt = &t
+	}
+	o.Status = OfferingStatus(status)
+	return o, nil
+}
+
+func scanOfferings(rows *sql.Rows) ([]*Offering, error) {
+	var result []*Offering
+	for rows.Next() {
+		o, err := scanOffering(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, o)
+	}
+	return result, rows.Err()
+}
+
+func scanPosition(s scanner) (*Position, error) {
+	p := &Position{}
+	var status string
+	err := s.Scan(
+		&p.ID, &p.OfferingID, &p.AgentAddr, &p.InvestorAddr, &p.Shares,
+		&p.CostBasis, &p.DistributedTotal, &p.VestingStart, &p.Locked, &status, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Status = PositionStatus(status)
+	return p, nil
+}
+
+func scanPositions(rows *sql.Rows) ([]*Position, error) {
+	var result []*Position
+	for rows.Next() {
+		p, err := scanPosition(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
+
+func scanListing(s scanner) (*Listing, error) {
+	l := &Listing{}
+	var (
+		status    string
+		buyerAddr sql.NullString
+		feeAmount sql.NullString
+	)
+	err := s.Scan(
+		&l.ID, &l.PositionID, &l.OfferingID, &l.SellerAddr, &l.Price,
+		&l.FeeBps, &status, &buyerAddr, &feeAmount, &l.CreatedAt, &l.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	l.Status = ListingStatus(status)
+	l.BuyerAddr = buyerAddr.String
+	l.FeeAmount = feeAmount.String
+	return l, nil
+}
+
+func scanListings(rows *sql.Rows) ([]*Listing, error) {
+	var result []*Listing
+	for rows.Next() {
+		l, err := scanListing(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, l)
+	}
+	return result, rows.Err()
+}
+
+func scanTransfer(s scanner) (*Transfer, error) {
+	t := &Transfer{}
+	var (
+		price  sql.NullString
+		status string
+	)
+	err := s.Scan(
+		&t.ID, &t.PositionID, &t.OfferingID, &t.FromAddr, &t.ToAddr,
+		&price, &status, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	t.Price = price.String
+	t.Status = TransferStatus(status)
+	return t, nil
+}
+
+func scanTransfers(rows *sql.Rows) ([]*Transfer, error) {
+	var result []*Transfer
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+func nullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+var _ Store = (*PostgresStore)(nil)
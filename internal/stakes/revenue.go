@@ -0,0 +1,224 @@
+package stakes
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// Payout is one investor's slice of a distribution.
+type Payout struct {
+	PositionID   string `json:"positionId"`
+	InvestorAddr string `json:"investorAddr"`
+	Amount       string `json:"amount"` // USDC
+}
+
+// Distribution records one payout of an offering's accrued pool. It doubles
+// as the period statement: totals, per-position amounts and the run time.
+type Distribution struct {
+	ID          string    `json:"id"`
+	OfferingID  string    `json:"offeringId"`
+	TotalAmount string    `json:"totalAmount"` // USDC
+	Payouts     []Payout  `json:"payouts"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// AccumulateRevenue implements credit.StakeRevenueAccumulator. For each of
+// the agent's offerings with sold shares, the offered revenue fraction moves
+// from the agent's balance into platform custody and accrues to the
+// offering's pool. Returns the total taken, formatted as USDC.
+func (s *Service) AccumulateRevenue(ctx context.Context, agentAddr, amount string) (string, error) {
+	earned, ok := usdc.Parse(amount)
+	if !ok || earned.Sign() <= 0 {
+		return "0.000000", nil
+	}
+	if s.platformAddr == "" {
+		return "0.000000", nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offerings, err := s.store.ListOfferingsByAgent(ctx, agentAddr, 0)
+	if err != nil {
+		return "0.000000", err
+	}
+
+	taken := new(big.Int)
+	for _, offering := range offerings {
+		if offering.SharesSold == 0 {
+			continue
+		}
+		// cut = earned * bps / 10000, at the share tier currently in force
+		cut := new(big.Int).Mul(earned, big.NewInt(int64(effectiveShareBps(offering))))
+		cut.Div(cut, big.NewInt(10000))
+		if cut.Sign() <= 0 {
+			continue
+		}
+
+		if err := s.ledger.Transfer(ctx, agentAddr, s.platformAddr, usdc.Format(cut), "stakes_accrual:"+offering.ID); err != nil {
+			s.logger.Warn("stakes: revenue accrual transfer failed",
+				"offering", offering.ID, "agent", agentAddr, "amount", usdc.Format(cut), "error", err)
+			continue
+		}
+
+		accrued, _ := usdc.Parse(offering.Accrued)
+		if accrued == nil {
+			accrued = new(big.Int)
+		}
+		accrued.Add(accrued, cut)
+		offering.Accrued = usdc.Format(accrued)
+		offering.UpdatedAt = time.Now()
+		if err := s.store.UpdateOffering(ctx, offering); err != nil {
+			s.logger.Error("stakes: failed to persist accrual", "offering", offering.ID, "error", err)
+			continue
+		}
+		taken.Add(taken, cut)
+	}
+	return usdc.Format(taken), nil
+}
+
+// Distribute pays an offering's accrued pool out on demand. Only the issuing
+// agent can trigger a manual distribution; scheduled offerings are also run
+// automatically by the distributor timer.
+func (s *Service) Distribute(ctx context.Context, offeringID, callerAddr string) (*Distribution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offering, err := s.store.GetOffering(ctx, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	if offering.AgentAddr != callerAddr {
+		return nil, ErrUnauthorized
+	}
+	return s.distributeLocked(ctx, offering, time.Now())
+}
+
+// distributeLocked pays out an offering's pool pro-rata by shares, scaled by
+// each position's vested fraction. The unvested remainder stays in the pool
+// for later runs; once every holder is fully vested the last payout absorbs
+// rounding dust so the pool drains exactly. Caller holds s.mu.
+func (s *Service) distributeLocked(ctx context.Context, offering *Offering, now time.Time) (*Distribution, error) {
+	pool, ok := usdc.Parse(offering.Accrued)
+	if !ok || pool.Sign() <= 0 {
+		return nil, ErrNothingAccrued
+	}
+
+	positions, err := s.store.ListPositionsByOffering(ctx, offering.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+	holders := make([]*Position, 0, len(positions))
+	totalShares := 0
+	for _, p := range positions {
+		if p.Status != PositionActive {
+			continue
+		}
+		holders = append(holders, p)
+		totalShares += p.Shares
+	}
+	if totalShares == 0 {
+		return nil, ErrNothingAccrued
+	}
+	sort.Slice(holders, func(i, j int) bool { return holders[i].CreatedAt.Before(holders[j].CreatedAt) })
+
+	dist := &Distribution{
+		ID:         idgen.WithPrefix("dist_"),
+		OfferingID: offering.ID,
+		CreatedAt:  now,
+	}
+
+	fullyVested := true
+	vested := make([]int, len(holders))
+	for i, p := range holders {
+		vested[i] = vestedBps(offering, p, dist.CreatedAt)
+		if vested[i] < 10000 {
+			fullyVested = false
+		}
+	}
+
+	allocated := new(big.Int)
+	for i, p := range holders {
+		// share = pool * shares * vestedBps / (totalShares * 10000)
+		share := new(big.Int).Mul(pool, big.NewInt(int64(p.Shares)))
+		share.Mul(share, big.NewInt(int64(vested[i])))
+		share.Div(share, big.NewInt(int64(totalShares)*10000))
+		if fullyVested && i == len(holders)-1 {
+			share = new(big.Int).Sub(pool, allocated)
+		}
+		if share.Sign() <= 0 {
+			continue
+		}
+
+		if err := s.ledger.Transfer(ctx, s.platformAddr, p.InvestorAddr, usdc.Format(share), "stakes_dist:"+dist.ID); err != nil {
+			s.logger.Error("stakes: distribution transfer failed",
+				"distribution", dist.ID, "position", p.ID, "amount", usdc.Format(share), "error", err)
+			continue
+		}
+		allocated.Add(allocated, share)
+
+		received, _ := usdc.Parse(p.DistributedTotal)
+		if received == nil {
+			received = new(big.Int)
+		}
+		received.Add(received, share)
+		p.DistributedTotal = usdc.Format(received)
+		p.UpdatedAt = dist.CreatedAt
+		if err := s.store.UpdatePosition(ctx, p); err != nil {
+			s.logger.Error("stakes: failed to persist payout", "position", p.ID, "error", err)
+		}
+		dist.Payouts = append(dist.Payouts, Payout{
+			PositionID:   p.ID,
+			InvestorAddr: p.InvestorAddr,
+			Amount:       usdc.Format(share),
+		})
+	}
+
+	if allocated.Sign() == 0 {
+		// Nothing vested yet — leave the pool untouched rather than
+		// recording an empty distribution.
+		return nil, ErrNothingAccrued
+	}
+
+	dist.TotalAmount = usdc.Format(allocated)
+	if err := s.store.CreateDistribution(ctx, dist); err != nil {
+		return nil, err
+	}
+
+	// Unvested or failed-transfer amounts stay in the pool for the next run.
+	remaining := new(big.Int).Sub(pool, allocated)
+	offering.Accrued = usdc.Format(remaining)
+	distributed, _ := usdc.Parse(offering.TotalDistributed)
+	if distributed == nil {
+		distributed = new(big.Int)
+	}
+	distributed.Add(distributed, allocated)
+	offering.TotalDistributed = usdc.Format(distributed)
+	offering.LastDistributedAt = &dist.CreatedAt
+	offering.UpdatedAt = dist.CreatedAt
+	if err := s.store.UpdateOffering(ctx, offering); err != nil {
+		return nil, err
+	}
+
+	if s.webhooks != nil {
+		for _, payout := range dist.Payouts {
+			s.webhooks.EmitStakeDistributionPaid(payout.InvestorAddr, dist.ID, offering.ID,
+				payout.PositionID, payout.Amount, dist.TotalAmount)
+		}
+	}
+
+	s.logger.Info("stakes: pool distributed",
+		"distribution", dist.ID, "offering", offering.ID,
+		"total", dist.TotalAmount, "payouts", len(dist.Payouts))
+	return dist, nil
+}
+
+// ListDistributions returns an offering's distribution history.
+func (s *Service) ListDistributions(ctx context.Context, offeringID string, limit int) ([]*Distribution, error) {
+	return s.store.ListDistributionsByOffering(ctx, offeringID, limit)
+}
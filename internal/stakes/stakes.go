@@ -0,0 +1,375 @@
+// Package stakes implements revenue-share investing in agents.
+//
+// An agent opens an offering that sells a fixed number of shares in a slice
+// of its future revenue. Investors buy shares with USDC; the sweep pipeline
+// routes the offered revenue fraction into the offering's pool, and the
+// distributor pays the pool out pro-rata by shares. Positions are first-class
+// assets: they can be resold on the secondary market or pledged as credit
+// collateral.
+package stakes
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrOfferingNotFound = errors.New("stakes: offering not found")
+	ErrOfferingClosed   = errors.New("stakes: offering not open")
+	ErrPositionNotFound = errors.New("stakes: position not found")
+	ErrInvalidOffering  = errors.New("stakes: invalid offering terms")
+	ErrInvalidShares    = errors.New("stakes: invalid share count")
+	ErrSoldOut          = errors.New("stakes: not enough shares remaining")
+	ErrSelfStake        = errors.New("stakes: cannot invest in own offering")
+	ErrShareOvercommit  = errors.New("stakes: combined revenue share exceeds 100%")
+	ErrNothingAccrued   = errors.New("stakes: nothing accrued to distribute")
+	ErrPositionLocked   = errors.New("stakes: position pledged as collateral")
+	ErrUnauthorized     = errors.New("stakes: not authorized")
+)
+
+// MaxShares caps how finely a single offering can be divided.
+const MaxShares = 1_000_000
+
+// OfferingStatus represents the lifecycle of a revenue-share offering.
+type OfferingStatus string
+
+const (
+	OfferingOpen   OfferingStatus = "open"
+	OfferingClosed OfferingStatus = "closed"
+)
+
+// PositionStatus represents the lifecycle of an investor position.
+type PositionStatus string
+
+const (
+	PositionActive     PositionStatus = "active"
+	PositionLiquidated PositionStatus = "liquidated"
+)
+
+// Offering sells shares in a fixed fraction of an agent's future revenue.
+type Offering struct {
+	ID                string         `json:"id"`
+	AgentAddr         string         `json:"agentAddr"`
+	Description       string         `json:"description,omitempty"`
+	RevenueShareBps   int            `json:"revenueShareBps"`      // fraction of revenue routed to the pool, basis points
+	ShareTiers        []ShareTier    `json:"shareTiers,omitempty"` // optional step-down schedule; first tier matches RevenueShareBps
+	TotalShares       int            `json:"totalShares"`
+	SharesSold        int            `json:"sharesSold"`
+	PricePerShare     string         `json:"pricePerShare"` // USDC
+	Accrued           string         `json:"accrued"`       // USDC awaiting distribution
+	TotalDistributed  string         `json:"totalDistributed"`
+	Vesting           *Vesting       `json:"vesting,omitempty"`         // nil = fully vested from purchase
+	Cadence           Cadence        `json:"cadence,omitempty"`         // "" = manual distributions only
+	MinDistribution   string         `json:"minDistribution,omitempty"` // USDC; scheduled runs skip smaller pools
+	LastDistributedAt *time.Time     `json:"lastDistributedAt,omitempty"`
+	Disclosure        *Disclosure    `json:"disclosure,omitempty"` // registry-backed due-diligence facts
+	Status            OfferingStatus `json:"status"`
+	CreatedAt         time.Time      `json:"createdAt"`
+	UpdatedAt         time.Time      `json:"updatedAt"`
+}
+
+// Position is an investor's stake in an offering. Cost basis resets when a
+// position changes hands on the secondary market, so portfolio accounting
+// always reflects what the current holder actually paid.
+type Position struct {
+	ID               string         `json:"id"`
+	OfferingID       string         `json:"offeringId"`
+	AgentAddr        string         `json:"agentAddr"` // issuing agent, denormalized for portfolio views
+	InvestorAddr     string         `json:"investorAddr"`
+	Shares           int            `json:"shares"`
+	CostBasis        string         `json:"costBasis"`        // USDC paid by the current holder
+	DistributedTotal string         `json:"distributedTotal"` // USDC received by the current holder
+	VestingStart     time.Time      `json:"vestingStart"`     // survives secondary-market transfers
+	Locked           bool           `json:"locked"`           // pledged as credit collateral
+	Status           PositionStatus `json:"status"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt"`
+}
+
+// CreateOfferingRequest is the input for opening an offering.
+type CreateOfferingRequest struct {
+	Description     string      `json:"description"`
+	RevenueShareBps int         `json:"revenueShareBps" binding:"required"`
+	ShareTiers      []ShareTier `json:"shareTiers,omitempty"`
+	TotalShares     int         `json:"totalShares" binding:"required"`
+	PricePerShare   string      `json:"pricePerShare" binding:"required"`
+	Vesting         *Vesting    `json:"vesting,omitempty"`
+	Cadence         Cadence     `json:"cadence,omitempty"`
+	MinDistribution string      `json:"minDistribution,omitempty"`
+}
+
+// Ledger is the subset of ledger operations the stakes service needs. All
+// money movement — purchases, revenue accrual into custody, distributions and
+// secondary-market trades — is a balance transfer.
+type Ledger interface {
+	Transfer(ctx context.Context, from, to, amount, reference string) error
+}
+
+// Store persists offerings, positions, distributions and market listings.
+type Store interface {
+	CreateOffering(ctx context.Context, o *Offering) error
+	GetOffering(ctx context.Context, id string) (*Offering, error)
+	UpdateOffering(ctx context.Context, o *Offering) error
+	ListOfferings(ctx context.Context, limit int) ([]*Offering, error)
+	ListOfferingsByAgent(ctx context.Context, agentAddr string, limit int) ([]*Offering, error)
+	ListScheduledOfferings(ctx context.Context, limit int) ([]*Offering, error)
+
+	CreatePosition(ctx context.Context, p *Position) error
+	GetPosition(ctx context.Context, id string) (*Position, error)
+	UpdatePosition(ctx context.Context, p *Position) error
+	ListPositionsByInvestor(ctx context.Context, investorAddr string, limit int) ([]*Position, error)
+	ListPositionsByOffering(ctx context.Context, offeringID string, limit int) ([]*Position, error)
+
+	CreateDistribution(ctx context.Context, d *Distribution) error
+	ListDistributionsByOffering(ctx context.Context, offeringID string, limit int) ([]*Distribution, error)
+
+	CreateTransfer(ctx context.Context, t *Transfer) error
+	GetTransfer(ctx context.Context, id string) (*Transfer, error)
+	UpdateTransfer(ctx context.Context, t *Transfer) error
+	GetPendingTransferByPosition(ctx context.Context, positionID string) (*Transfer, error)
+	ListTransfersByPosition(ctx context.Context, positionID string, limit int) ([]*Transfer, error)
+	ListTransfersByAgent(ctx context.Context, agentAddr string, limit int) ([]*Transfer, error)
+
+	CreateListing(ctx context.Context, l *Listing) error
+	GetListing(ctx context.Context, id string) (*Listing, error)
+	UpdateListing(ctx context.Context, l *Listing) error
+	GetOpenListingByPosition(ctx context.Context, positionID string) (*Listing, error)
+	ListOpenListings(ctx context.Context, limit int) ([]*Listing, error)
+	ListListingsBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Listing, error)
+}
+
+// Service implements revenue-share offering business logic.
+type Service struct {
+	store        Store
+	ledger       Ledger
+	platformAddr string // custody account for accrued pools and trade fees
+	tradeFeeBps  int
+	disclosures  DisclosureProvider
+	webhooks     WebhookEmitter
+	logger       *slog.Logger
+	mu           sync.Mutex // serializes offering/position mutation + transfers
+}
+
+// NewService creates a new stakes service.
+func NewService(store Store, ledger Ledger) *Service {
+	return &Service{
+		store:       store,
+		ledger:      ledger,
+		tradeFeeBps: DefaultTradeFeeBps,
+		logger:      slog.Default(),
+	}
+}
+
+// WithLogger sets a structured logger.
+func (s *Service) WithLogger(l *slog.Logger) *Service {
+	s.logger = l
+	return s
+}
+
+// WithPlatformAddress sets the ledger address that holds accrued revenue
+// pools between distributions and collects secondary-market trade fees.
+func (s *Service) WithPlatformAddress(addr string) *Service {
+	s.platformAddr = addr
+	return s
+}
+
+// CreateOffering opens a revenue-share offering for the agent.
+func (s *Service) CreateOffering(ctx context.Context, agentAddr string, req CreateOfferingRequest) (*Offering, error) {
+	if req.RevenueShareBps < 1 || req.RevenueShareBps > 10000 {
+		return nil, ErrInvalidOffering
+	}
+	if req.TotalShares < 1 || req.TotalShares > MaxShares {
+		return nil, ErrInvalidOffering
+	}
+	price, ok := usdc.Parse(req.PricePerShare)
+	if !ok || price.Sign() <= 0 {
+		return nil, ErrInvalidOffering
+	}
+	if req.Vesting != nil {
+		if err := req.Vesting.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.ShareTiers) > 0 {
+		if err := validateShareTiers(req.ShareTiers, req.RevenueShareBps); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := req.Cadence.interval(); !ok {
+		return nil, ErrInvalidCadence
+	}
+	minDist := new(big.Int)
+	if req.MinDistribution != "" {
+		minDist, ok = usdc.Parse(req.MinDistribution)
+		if !ok || minDist.Sign() < 0 {
+			return nil, ErrInvalidOffering
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An agent cannot promise more than 100% of its revenue across open offerings.
+	existing, err := s.store.ListOfferingsByAgent(ctx, agentAddr, 0)
+	if err != nil {
+		return nil, err
+	}
+	committed := req.RevenueShareBps
+	for _, o := range existing {
+		if o.Status == OfferingOpen {
+			committed += o.RevenueShareBps
+		}
+	}
+	if committed > 10000 {
+		return nil, ErrShareOvercommit
+	}
+
+	now := time.Now()
+	offering := &Offering{
+		ID:               idgen.WithPrefix("off_"),
+		AgentAddr:        agentAddr,
+		Description:      req.Description,
+		RevenueShareBps:  req.RevenueShareBps,
+		ShareTiers:       req.ShareTiers,
+		TotalShares:      req.TotalShares,
+		PricePerShare:    usdc.Format(price),
+		Accrued:          "0.000000",
+		TotalDistributed: "0.000000",
+		Vesting:          req.Vesting,
+		Cadence:          req.Cadence,
+		MinDistribution:  usdc.Format(minDist),
+		Disclosure:       s.buildDisclosure(ctx, agentAddr),
+		Status:           OfferingOpen,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if err := s.store.CreateOffering(ctx, offering); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("stakes: offering created",
+		"offering", offering.ID, "agent", agentAddr,
+		"shareBps", req.RevenueShareBps, "shares", req.TotalShares, "price", offering.PricePerShare)
+	return offering, nil
+}
+
+// GetOffering returns an offering by ID.
+func (s *Service) GetOffering(ctx context.Context, id string) (*Offering, error) {
+	return s.store.GetOffering(ctx, id)
+}
+
+// ListOfferings returns open offerings for browsing.
+func (s *Service) ListOfferings(ctx context.Context, limit int) ([]*Offering, error) {
+	return s.store.ListOfferings(ctx, limit)
+}
+
+// ListOfferingsByAgent returns all offerings issued by an agent.
+func (s *Service) ListOfferingsByAgent(ctx context.Context, agentAddr string, limit int) ([]*Offering, error) {
+	return s.store.ListOfferingsByAgent(ctx, agentAddr, limit)
+}
+
+// CloseOffering stops further share sales. Existing positions keep accruing
+// and distributing; closing only takes unsold shares off the market.
+func (s *Service) CloseOffering(ctx context.Context, offeringID, callerAddr string) (*Offering, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offering, err := s.store.GetOffering(ctx, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	if offering.AgentAddr != callerAddr {
+		return nil, ErrUnauthorized
+	}
+	if offering.Status != OfferingOpen {
+		return nil, ErrOfferingClosed
+	}
+
+	offering.Status = OfferingClosed
+	offering.UpdatedAt = time.Now()
+	if err := s.store.UpdateOffering(ctx, offering); err != nil {
+		return nil, err
+	}
+	return offering, nil
+}
+
+// BuyShares purchases shares from an open offering. The purchase price goes
+// straight to the issuing agent; the investor's claim is on future revenue.
+func (s *Service) BuyShares(ctx context.Context, offeringID, investorAddr string, shares int) (*Position, error) {
+	if shares < 1 {
+		return nil, ErrInvalidShares
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offering, err := s.store.GetOffering(ctx, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	if offering.Status != OfferingOpen {
+		return nil, ErrOfferingClosed
+	}
+	if offering.AgentAddr == investorAddr {
+		return nil, ErrSelfStake
+	}
+	if offering.TotalShares-offering.SharesSold < shares {
+		return nil, ErrSoldOut
+	}
+
+	price, ok := usdc.Parse(offering.PricePerShare)
+	if !ok {
+		return nil, ErrInvalidOffering
+	}
+	cost := new(big.Int).Mul(price, big.NewInt(int64(shares)))
+
+	now := time.Now()
+	position := &Position{
+		ID:               idgen.WithPrefix("pos_"),
+		OfferingID:       offering.ID,
+		AgentAddr:        offering.AgentAddr,
+		InvestorAddr:     investorAddr,
+		Shares:           shares,
+		CostBasis:        usdc.Format(cost),
+		DistributedTotal: "0.000000",
+		VestingStart:     now,
+		Status:           PositionActive,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := s.ledger.Transfer(ctx, investorAddr, offering.AgentAddr, position.CostBasis, "stakes_purchase:"+position.ID); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreatePosition(ctx, position); err != nil {
+		return nil, err
+	}
+
+	offering.SharesSold += shares
+	offering.UpdatedAt = now
+	if err := s.store.UpdateOffering(ctx, offering); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("stakes: shares purchased",
+		"position", position.ID, "offering", offering.ID,
+		"investor", investorAddr, "shares", shares, "cost", position.CostBasis)
+	return position, nil
+}
+
+// GetPosition returns a position by ID.
+func (s *Service) GetPosition(ctx context.Context, id string) (*Position, error) {
+	return s.store.GetPosition(ctx, id)
+}
+
+// Portfolio returns all positions held by an investor.
+func (s *Service) Portfolio(ctx context.Context, investorAddr string, limit int) ([]*Position, error) {
+	return s.store.ListPositionsByInvestor(ctx, investorAddr, limit)
+}
@@ -0,0 +1,266 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+const (
+	agentAddr    = "0x1111111111111111111111111111111111111111"
+	investorAddr = "0x2222222222222222222222222222222222222222"
+	investor2    = "0x3333333333333333333333333333333333333333"
+	platformAddr = "0x9999999999999999999999999999999999999999"
+)
+
+// fakeLedger tracks balances in memory and fails transfers that the payer
+// cannot cover, mirroring the real ledger's behavior.
+type fakeLedger struct {
+	mu       sync.Mutex
+	balances map[string]*big.Int
+}
+
+func newFakeLedger(funded ...string) *fakeLedger {
+	l := &fakeLedger{balances: make(map[string]*big.Int)}
+	for _, addr := range funded {
+		l.balances[addr] = big.NewInt(100_000_000) // 100 USDC
+	}
+	return l
+}
+
+func (l *fakeLedger) Transfer(ctx context.Context, from, to, amount, reference string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	amt, ok := usdc.Parse(amount)
+	if !ok {
+		return errors.New("fake ledger: bad amount")
+	}
+	bal := l.balances[from]
+	if bal == nil || bal.Cmp(amt) < 0 {
+		return errors.New("fake ledger: insufficient balance")
+	}
+	bal.Sub(bal, amt)
+	if l.balances[to] == nil {
+		l.balances[to] = new(big.Int)
+	}
+	l.balances[to].Add(l.balances[to], amt)
+	return nil
+}
+
+func (l *fakeLedger) balance(addr string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bal := l.balances[addr]
+	if bal == nil {
+		bal = new(big.Int)
+	}
+	return usdc.Format(bal)
+}
+
+func newTestService() (*Service, *fakeLedger) {
+	ledger := newFakeLedger(agentAddr, investorAddr, investor2)
+	svc := NewService(NewMemoryStore(), ledger).WithPlatformAddress(platformAddr)
+	return svc, ledger
+}
+
+func createTestOffering(t *testing.T, svc *Service) *Offering {
+	t.Helper()
+	offering, err := svc.CreateOffering(context.Background(), agentAddr, CreateOfferingRequest{
+		RevenueShareBps: 2000, // 20%
+		TotalShares:     100,
+		PricePerShare:   "0.100000",
+	})
+	if err != nil {
+		t.Fatalf("CreateOffering failed: %v", err)
+	}
+	return offering
+}
+
+func TestCreateOfferingValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	cases := []CreateOfferingRequest{
+		{RevenueShareBps: 0, TotalShares: 100, PricePerShare: "0.100000"},
+		{RevenueShareBps: 10001, TotalShares: 100, PricePerShare: "0.100000"},
+		{RevenueShareBps: 2000, TotalShares: 0, PricePerShare: "0.100000"},
+		{RevenueShareBps: 2000, TotalShares: MaxShares + 1, PricePerShare: "0.100000"},
+		{RevenueShareBps: 2000, TotalShares: 100, PricePerShare: "0"},
+	}
+	for i, req := range cases {
+		if _, err := svc.CreateOffering(ctx, agentAddr, req); !errors.Is(err, ErrInvalidOffering) {
+			t.Errorf("case %d: expected ErrInvalidOffering, got %v", i, err)
+		}
+	}
+
+	// Combined open offerings cannot exceed 100% of revenue.
+	createTestOffering(t, svc)
+	if _, err := svc.CreateOffering(ctx, agentAddr, CreateOfferingRequest{
+		RevenueShareBps: 9000, TotalShares: 10, PricePerShare: "1.000000",
+	}); !errors.Is(err, ErrShareOvercommit) {
+		t.Errorf("expected ErrShareOvercommit, got %v", err)
+	}
+}
+
+func TestBuyShares(t *testing.T) {
+	svc, ledger := newTestService()
+	ctx := context.Background()
+	offering := createTestOffering(t, svc)
+
+	if _, err := svc.BuyShares(ctx, offering.ID, agentAddr, 10); !errors.Is(err, ErrSelfStake) {
+		t.Errorf("expected ErrSelfStake, got %v", err)
+	}
+	if _, err := svc.BuyShares(ctx, offering.ID, investorAddr, 101); !errors.Is(err, ErrSoldOut) {
+		t.Errorf("expected ErrSoldOut, got %v", err)
+	}
+
+	position, err := svc.BuyShares(ctx, offering.ID, investorAddr, 30)
+	if err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	if position.CostBasis != "3.000000" {
+		t.Errorf("expected cost basis 3.000000, got %s", position.CostBasis)
+	}
+	if got := ledger.balance(agentAddr); got != "103.000000" {
+		t.Errorf("expected agent balance 103.000000, got %s", got)
+	}
+
+	offering, _ = svc.GetOffering(ctx, offering.ID)
+	if offering.SharesSold != 30 {
+		t.Errorf("expected 30 shares sold, got %d", offering.SharesSold)
+	}
+
+	// Closed offerings stop selling.
+	if _, err := svc.CloseOffering(ctx, offering.ID, investorAddr); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-owner close, got %v", err)
+	}
+	if _, err := svc.CloseOffering(ctx, offering.ID, agentAddr); err != nil {
+		t.Fatalf("CloseOffering failed: %v", err)
+	}
+	if _, err := svc.BuyShares(ctx, offering.ID, investor2, 10); !errors.Is(err, ErrOfferingClosed) {
+		t.Errorf("expected ErrOfferingClosed, got %v", err)
+	}
+}
+
+func TestAccumulateAndDistribute(t *testing.T) {
+	svc, ledger := newTestService()
+	ctx := context.Background()
+	offering := createTestOffering(t, svc)
+
+	pos1, err := svc.BuyShares(ctx, offering.ID, investorAddr, 30)
+	if err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	if _, err := svc.BuyShares(ctx, offering.ID, investor2, 10); err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+
+	// 20% of 10 USDC accrues to the pool.
+	taken, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000")
+	if err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	if taken != "2.000000" {
+		t.Errorf("expected 2.000000 taken, got %s", taken)
+	}
+	offering, _ = svc.GetOffering(ctx, offering.ID)
+	if offering.Accrued != "2.000000" {
+		t.Errorf("expected accrued 2.000000, got %s", offering.Accrued)
+	}
+
+	if _, err := svc.Distribute(ctx, offering.ID, investorAddr); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-owner distribute, got %v", err)
+	}
+	dist, err := svc.Distribute(ctx, offering.ID, agentAddr)
+	if err != nil {
+		t.Fatalf("Distribute failed: %v", err)
+	}
+	if dist.TotalAmount != "2.000000" || len(dist.Payouts) != 2 {
+		t.Fatalf("expected full pool across 2 payouts, got %s / %d", dist.TotalAmount, len(dist.Payouts))
+	}
+
+	// 30/40 and 10/40 of the pool, pro-rata by shares.
+	pos1, _ = svc.GetPosition(ctx, pos1.ID)
+	if pos1.DistributedTotal != "1.500000" {
+		t.Errorf("expected 1.500000 distributed to 30-share position, got %s", pos1.DistributedTotal)
+	}
+	if got := ledger.balance(investor2); got != "99.500000" { // 100 - 1 cost + 0.5 payout
+		t.Errorf("expected investor2 balance 97.500000, got %s", got)
+	}
+
+	offering, _ = svc.GetOffering(ctx, offering.ID)
+	if offering.Accrued != "0.000000" || offering.TotalDistributed != "2.000000" {
+		t.Errorf("expected drained pool, got accrued=%s distributed=%s", offering.Accrued, offering.TotalDistributed)
+	}
+
+	if _, err := svc.Distribute(ctx, offering.ID, agentAddr); !errors.Is(err, ErrNothingAccrued) {
+		t.Errorf("expected ErrNothingAccrued on empty pool, got %v", err)
+	}
+}
+
+func TestAccumulateSkipsUnsoldOfferings(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	createTestOffering(t, svc)
+
+	taken, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000")
+	if err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	if taken != "0.000000" {
+		t.Errorf("expected no cut with zero investors, got %s", taken)
+	}
+}
+
+func TestPledgeLifecycle(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	offering := createTestOffering(t, svc)
+	position, err := svc.BuyShares(ctx, offering.ID, investorAddr, 20)
+	if err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+
+	if _, err := svc.Lock(ctx, investor2, position.ID); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-owner lock, got %v", err)
+	}
+	value, err := svc.Lock(ctx, investorAddr, position.ID)
+	if err != nil {
+		t.Fatalf("Lock failed: %v", err)
+	}
+	if value != "2.000000" {
+		t.Errorf("expected locked value 2.000000, got %s", value)
+	}
+	if _, err := svc.Lock(ctx, investorAddr, position.ID); !errors.Is(err, ErrPositionLocked) {
+		t.Errorf("expected ErrPositionLocked on double lock, got %v", err)
+	}
+
+	// Locked positions cannot go to market.
+	if _, err := svc.CreateListing(ctx, investorAddr, position.ID, "3.000000"); !errors.Is(err, ErrPositionLocked) {
+		t.Errorf("expected ErrPositionLocked for listing, got %v", err)
+	}
+
+	if err := svc.Unlock(ctx, investorAddr, position.ID); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	position, _ = svc.GetPosition(ctx, position.ID)
+	if position.Locked {
+		t.Error("expected position unlocked")
+	}
+
+	realized, err := svc.Liquidate(ctx, investorAddr, position.ID)
+	if err != nil {
+		t.Fatalf("Liquidate failed: %v", err)
+	}
+	if realized != "2.000000" {
+		t.Errorf("expected realized 2.000000, got %s", realized)
+	}
+	position, _ = svc.GetPosition(ctx, position.ID)
+	if position.InvestorAddr != platformAddr {
+		t.Errorf("expected platform to take over position, got %s", position.InvestorAddr)
+	}
+}
@@ -0,0 +1,131 @@
+package stakes
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// WebhookEmitter is the subset of the webhook emitter the stakes service
+// uses. Nil is fine; events are simply not emitted.
+type WebhookEmitter interface {
+	EmitStakeDistributionPaid(agentAddr, distributionID, offeringID, positionID, amount, totalAmount string)
+}
+
+// WithWebhookEmitter sets the emitter used to notify investors of payouts.
+func (s *Service) WithWebhookEmitter(e WebhookEmitter) *Service {
+	s.webhooks = e
+	return s
+}
+
+// StatementLine is one payout received by an investor within a statement
+// period.
+type StatementLine struct {
+	DistributionID string    `json:"distributionId"`
+	OfferingID     string    `json:"offeringId"`
+	AgentAddr      string    `json:"agentAddr"`
+	PositionID     string    `json:"positionId"`
+	Amount         string    `json:"amount"` // USDC
+	PaidAt         time.Time `json:"paidAt"`
+}
+
+// Statement summarizes the distributions an investor received over a period,
+// so investing agents can reconcile against their ledger without polling the
+// portfolio endpoint.
+type Statement struct {
+	InvestorAddr  string          `json:"investorAddr"`
+	PeriodStart   time.Time       `json:"periodStart"`
+	PeriodEnd     time.Time       `json:"periodEnd"`
+	Lines         []StatementLine `json:"lines"`
+	TotalReceived string          `json:"totalReceived"` // USDC
+	GeneratedAt   time.Time       `json:"generatedAt"`
+}
+
+// Statement builds an investor's period statement from the distribution
+// history of every offering they hold or have held a position in. A payout is
+// included when it lands within [start, end).
+func (s *Service) Statement(ctx context.Context, investorAddr string, start, end time.Time) (*Statement, error) {
+	positions, err := s.store.ListPositionsByInvestor(ctx, investorAddr, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := &Statement{
+		InvestorAddr: investorAddr,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		GeneratedAt:  time.Now(),
+	}
+
+	seen := make(map[string]bool)
+	total := new(big.Int)
+	for _, p := range positions {
+		if seen[p.OfferingID] {
+			continue
+		}
+		seen[p.OfferingID] = true
+
+		offering, err := s.store.GetOffering(ctx, p.OfferingID)
+		if err != nil {
+			return nil, err
+		}
+		dists, err := s.store.ListDistributionsByOffering(ctx, p.OfferingID, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dists {
+			if d.CreatedAt.Before(start) || !d.CreatedAt.Before(end) {
+				continue
+			}
+			for _, payout := range d.Payouts {
+				// Match on who was paid, not who holds the position
+				// now: a transferred position's earlier payouts went
+				// to the previous holder and are excluded.
+				if payout.InvestorAddr != investorAddr {
+					continue
+				}
+				stmt.Lines = append(stmt.Lines, StatementLine{
+					DistributionID: d.ID,
+					OfferingID:     offering.ID,
+					AgentAddr:      offering.AgentAddr,
+					PositionID:     payout.PositionID,
+					Amount:         payout.Amount,
+					PaidAt:         d.CreatedAt,
+				})
+				if amt, ok := usdc.Parse(payout.Amount); ok {
+					total.Add(total, amt)
+				}
+			}
+		}
+	}
+
+	sort.Slice(stmt.Lines, func(i, j int) bool { return stmt.Lines[i].PaidAt.Before(stmt.Lines[j].PaidAt) })
+	stmt.TotalReceived = usdc.Format(total)
+	return stmt, nil
+}
+
+// statementCSV renders a statement for download, one row per payout with a
+// trailing total row.
+func statementCSV(stmt *Statement) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"paid_at", "distribution_id", "offering_id", "agent_addr", "position_id", "amount"})
+	for _, line := range stmt.Lines {
+		w.Write([]string{
+			line.PaidAt.UTC().Format(time.RFC3339),
+			line.DistributionID,
+			line.OfferingID,
+			line.AgentAddr,
+			line.PositionID,
+			line.Amount,
+		})
+	}
+	w.Write([]string{"", "", "", "", "total", stmt.TotalReceived})
+	w.Flush()
+	return buf.Bytes()
+}
@@ -0,0 +1,103 @@
+package stakes
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type emittedPayout struct {
+	agentAddr      string
+	distributionID string
+	offeringID     string
+	positionID     string
+	amount         string
+	totalAmount    string
+}
+
+type fakeEmitter struct {
+	events []emittedPayout
+}
+
+func (f *fakeEmitter) EmitStakeDistributionPaid(agentAddr, distributionID, offeringID, positionID, amount, totalAmount string) {
+	f.events = append(f.events, emittedPayout{agentAddr, distributionID, offeringID, positionID, amount, totalAmount})
+}
+
+func TestDistributionEmitsWebhooks(t *testing.T) {
+	svc, _ := newTestService()
+	emitter := &fakeEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	dist, err := svc.Distribute(ctx, position.OfferingID, agentAddr)
+	if err != nil {
+		t.Fatalf("Distribute failed: %v", err)
+	}
+
+	if len(emitter.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(emitter.events))
+	}
+	ev := emitter.events[0]
+	if ev.agentAddr != investorAddr || ev.positionID != position.ID {
+		t.Errorf("event addressed wrong: %+v", ev)
+	}
+	if ev.distributionID != dist.ID || ev.amount != "2.000000" || ev.totalAmount != dist.TotalAmount {
+		t.Errorf("event amounts wrong: %+v", ev)
+	}
+}
+
+func TestStatementCoversPeriod(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+			t.Fatalf("AccumulateRevenue failed: %v", err)
+		}
+		if _, err := svc.Distribute(ctx, position.OfferingID, agentAddr); err != nil {
+			t.Fatalf("Distribute failed: %v", err)
+		}
+	}
+
+	stmt, err := svc.Statement(ctx, investorAddr, time.Time{}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Statement failed: %v", err)
+	}
+	if len(stmt.Lines) != 2 || stmt.TotalReceived != "4.000000" {
+		t.Fatalf("expected 2 lines totalling 4.000000, got %d / %s", len(stmt.Lines), stmt.TotalReceived)
+	}
+	line := stmt.Lines[0]
+	if line.OfferingID != position.OfferingID || line.PositionID != position.ID || line.AgentAddr != agentAddr {
+		t.Errorf("unexpected statement line: %+v", line)
+	}
+
+	// Payouts outside the window are excluded.
+	future, err := svc.Statement(ctx, investorAddr, time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Statement failed: %v", err)
+	}
+	if len(future.Lines) != 0 || future.TotalReceived != "0.000000" {
+		t.Errorf("expected empty future statement, got %+v", future)
+	}
+
+	// A non-investor gets an empty statement, not an error.
+	other, err := svc.Statement(ctx, investor2, time.Time{}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Statement failed: %v", err)
+	}
+	if len(other.Lines) != 0 {
+		t.Errorf("expected no lines for a non-investor, got %d", len(other.Lines))
+	}
+
+	// CSV render: header, one row per line, trailing total.
+	rows := strings.Split(strings.TrimSpace(string(statementCSV(stmt))), "\n")
+	if len(rows) != 4 || !strings.HasSuffix(rows[3], "total,4.000000") {
+		t.Errorf("unexpected CSV: %q", rows)
+	}
+}
@@ -0,0 +1,93 @@
+package stakes
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var ErrInvalidTiers = errors.New("stakes: invalid share tier schedule")
+
+// ShareTier is one step of a declining revenue-share schedule. The tier
+// applies while cumulative investor returns sit below UntilMultipleBps of
+// invested principal (15000 = 1.5x recouped); zero marks the final,
+// uncapped tier.
+type ShareTier struct {
+	UntilMultipleBps int `json:"untilMultipleBps"`
+	Bps              int `json:"bps"`
+}
+
+// validateShareTiers checks a step-down schedule: the first tier must match
+// the offering's headline share, thresholds must rise strictly, shares must
+// fall strictly, and the last tier must be uncapped.
+func validateShareTiers(tiers []ShareTier, headlineBps int) error {
+	if len(tiers) < 2 {
+		return ErrInvalidTiers
+	}
+	if tiers[0].Bps != headlineBps {
+		return ErrInvalidTiers
+	}
+	for i, t := range tiers {
+		if t.Bps < 1 || t.Bps > 10000 {
+			return ErrInvalidTiers
+		}
+		last := i == len(tiers)-1
+		if last {
+			if t.UntilMultipleBps != 0 {
+				return ErrInvalidTiers
+			}
+			continue
+		}
+		if t.UntilMultipleBps <= 0 {
+			return ErrInvalidTiers
+		}
+		if i > 0 && t.UntilMultipleBps <= tiers[i-1].UntilMultipleBps {
+			return ErrInvalidTiers
+		}
+		if t.Bps <= tiers[i+1].Bps {
+			return ErrInvalidTiers
+		}
+	}
+	return nil
+}
+
+// effectiveShareBps returns the revenue share currently in force. Flat
+// offerings use the headline share; tiered offerings step down once
+// cumulative returns (distributed plus already-accrued) cross each tier's
+// multiple of invested principal.
+func effectiveShareBps(o *Offering) int {
+	if len(o.ShareTiers) == 0 {
+		return o.RevenueShareBps
+	}
+
+	price, ok := usdc.Parse(o.PricePerShare)
+	if !ok {
+		return o.RevenueShareBps
+	}
+	principal := new(big.Int).Mul(price, big.NewInt(int64(o.SharesSold)))
+	if principal.Sign() <= 0 {
+		return o.ShareTiers[0].Bps
+	}
+
+	distributed, _ := usdc.Parse(o.TotalDistributed)
+	if distributed == nil {
+		distributed = new(big.Int)
+	}
+	accrued, _ := usdc.Parse(o.Accrued)
+	if accrued == nil {
+		accrued = new(big.Int)
+	}
+	returned := new(big.Int).Add(distributed, accrued)
+
+	// multipleBps = returned * 10000 / principal
+	multiple := new(big.Int).Mul(returned, big.NewInt(10000))
+	multiple.Div(multiple, principal)
+
+	for _, t := range o.ShareTiers {
+		if t.UntilMultipleBps == 0 || multiple.Cmp(big.NewInt(int64(t.UntilMultipleBps))) < 0 {
+			return t.Bps
+		}
+	}
+	return o.ShareTiers[len(o.ShareTiers)-1].Bps
+}
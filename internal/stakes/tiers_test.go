@@ -0,0 +1,102 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestShareTierValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	cases := [][]ShareTier{
+		{{UntilMultipleBps: 15000, Bps: 2000}},                                                   // single tier
+		{{UntilMultipleBps: 15000, Bps: 1000}, {Bps: 500}},                                       // first tier != headline
+		{{UntilMultipleBps: 15000, Bps: 2000}, {UntilMultipleBps: 1, Bps: 500}},                  // last tier capped
+		{{UntilMultipleBps: 15000, Bps: 2000}, {UntilMultipleBps: 10000, Bps: 1000}, {Bps: 500}}, // thresholds not rising
+		{{UntilMultipleBps: 15000, Bps: 2000}, {Bps: 3000}},                                      // share steps up
+	}
+	for i, tiers := range cases {
+		_, err := svc.CreateOffering(ctx, agentAddr, CreateOfferingRequest{
+			RevenueShareBps: 2000, TotalShares: 100, PricePerShare: "0.100000", ShareTiers: tiers,
+		})
+		if !errors.Is(err, ErrInvalidTiers) {
+			t.Errorf("case %d: expected ErrInvalidTiers, got %v", i, err)
+		}
+	}
+
+	offering, err := svc.CreateOffering(ctx, agentAddr, CreateOfferingRequest{
+		RevenueShareBps: 2000, TotalShares: 100, PricePerShare: "0.100000",
+		ShareTiers: []ShareTier{{UntilMultipleBps: 15000, Bps: 2000}, {Bps: 500}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOffering failed: %v", err)
+	}
+	if len(offering.ShareTiers) != 2 {
+		t.Errorf("expected tier schedule persisted, got %+v", offering.ShareTiers)
+	}
+}
+
+func TestAccrualStepsDownAfterRecoup(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	// 20% until investors recoup 1.5x, then 5%.
+	offering, err := svc.CreateOffering(ctx, agentAddr, CreateOfferingRequest{
+		RevenueShareBps: 2000, TotalShares: 100, PricePerShare: "0.100000",
+		ShareTiers: []ShareTier{{UntilMultipleBps: 15000, Bps: 2000}, {Bps: 500}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOffering failed: %v", err)
+	}
+	// 40 shares at 0.10 = 4 USDC principal; 1.5x = 6 USDC returned.
+	if _, err := svc.BuyShares(ctx, offering.ID, investorAddr, 40); err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+
+	// Three sweeps at 20% of 10 USDC each take the pool to the 6 USDC cap.
+	for i := 0; i < 3; i++ {
+		taken, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000")
+		if err != nil {
+			t.Fatalf("AccumulateRevenue failed: %v", err)
+		}
+		if taken != "2.000000" {
+			t.Errorf("sweep %d: expected 2.000000 at the first tier, got %s", i, taken)
+		}
+	}
+
+	// Past 1.5x the share steps down to 5%.
+	taken, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000")
+	if err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	if taken != "0.500000" {
+		t.Errorf("expected 0.500000 at the stepped-down tier, got %s", taken)
+	}
+
+	// Distributing doesn't reset the step-down: returns stay cumulative.
+	if _, err := svc.Distribute(ctx, offering.ID, agentAddr); err != nil {
+		t.Fatalf("Distribute failed: %v", err)
+	}
+	taken, err = svc.AccumulateRevenue(ctx, agentAddr, "10.000000")
+	if err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	if taken != "0.500000" {
+		t.Errorf("expected 0.500000 after distribution, got %s", taken)
+	}
+}
+
+func TestFlatOfferingIgnoresTierMath(t *testing.T) {
+	offering := &Offering{
+		RevenueShareBps:  2000,
+		SharesSold:       40,
+		PricePerShare:    "0.100000",
+		Accrued:          "100.000000",
+		TotalDistributed: "100.000000",
+	}
+	if got := effectiveShareBps(offering); got != 2000 {
+		t.Errorf("expected flat 2000 bps, got %d", got)
+	}
+}
@@ -0,0 +1,90 @@
+package stakes
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/recovery"
+)
+
+// Timer periodically runs scheduled distributions for offerings whose
+// cadence interval has elapsed, and refreshes stale offering disclosures.
+type Timer struct {
+	service  *Service
+	interval time.Duration
+	logger   *slog.Logger
+	stop     chan struct{}
+	running  atomic.Bool
+}
+
+// NewTimer creates a new stake distribution timer.
+func NewTimer(service *Service, logger *slog.Logger) *Timer {
+	return &Timer{
+		service:  service,
+		interval: time.Minute,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start begins the timer loop.
+func (t *Timer) Start(ctx context.Context) {
+	t.running.Store(true)
+	defer t.running.Store(false)
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.safeDistribute(ctx)
+			t.safeRefreshDisclosures(ctx)
+		}
+	}
+}
+
+// Stop signals the timer loop to exit.
+func (t *Timer) Stop() {
+	select {
+	case t.stop <- struct{}{}:
+	default:
+	}
+}
+
+// Running returns true if the timer loop is active.
+func (t *Timer) Running() bool {
+	return t.running.Load()
+}
+
+func (t *Timer) safeDistribute(ctx context.Context) {
+	defer recovery.LogPanic(t.logger, "stakes_timer")
+
+	ran, err := t.service.RunDistributions(ctx, time.Now())
+	if err != nil {
+		t.logger.Warn("stakes timer: error distributing", "error", err)
+		return
+	}
+	if ran > 0 {
+		t.logger.Info("stakes timer: ran scheduled distributions", "count", ran)
+	}
+}
+
+func (t *Timer) safeRefreshDisclosures(ctx context.Context) {
+	defer recovery.LogPanic(t.logger, "stakes_timer")
+
+	refreshed, err := t.service.RefreshDisclosures(ctx, time.Now())
+	if err != nil {
+		t.logger.Warn("stakes timer: error refreshing disclosures", "error", err)
+		return
+	}
+	if refreshed > 0 {
+		t.logger.Info("stakes timer: refreshed disclosures", "count", refreshed)
+	}
+}
@@ -0,0 +1,220 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+	"github.com/mbd888/alancoin/internal/validation"
+)
+
+var (
+	ErrTransferNotFound = errors.New("stakes: transfer not found")
+	ErrTransferClosed   = errors.New("stakes: transfer no longer pending")
+	ErrTransferPending  = errors.New("stakes: position has a pending transfer")
+	ErrInvalidTransfer  = errors.New("stakes: invalid transfer terms")
+	ErrSelfTransfer     = errors.New("stakes: cannot transfer to self")
+)
+
+// TransferStatus represents the lifecycle of a position transfer.
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"
+	TransferAccepted  TransferStatus = "accepted"
+	TransferDeclined  TransferStatus = "declined"
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// Transfer moves a position to another agent once the recipient accepts.
+// A priced transfer settles the payment at acceptance; an unpriced one is a
+// gift. Records are kept in every terminal state as the audit history.
+type Transfer struct {
+	ID         string         `json:"id"`
+	PositionID string         `json:"positionId"`
+	OfferingID string         `json:"offeringId"`
+	FromAddr   string         `json:"fromAddr"`
+	ToAddr     string         `json:"toAddr"`
+	Price      string         `json:"price,omitempty"` // USDC; "" = gift
+	Status     TransferStatus `json:"status"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+// ProposeTransfer offers a position to another agent, optionally for a price.
+// The position stays with the sender until the recipient accepts.
+func (s *Service) ProposeTransfer(ctx context.Context, callerAddr, positionID, toAddr, price string) (*Transfer, error) {
+	if !validation.IsValidEthAddress(toAddr) {
+		return nil, ErrInvalidTransfer
+	}
+	// Auth-derived addresses are lowercase; normalize the recipient so the
+	// acceptance checks and the resulting position owner match them.
+	toAddr = strings.ToLower(toAddr)
+	if price != "" {
+		parsed, ok := usdc.Parse(price)
+		if !ok || parsed.Sign() <= 0 {
+			return nil, ErrInvalidTransfer
+		}
+		price = usdc.Format(parsed)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	position, err := s.store.GetPosition(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+	if position.InvestorAddr != callerAddr {
+		return nil, ErrUnauthorized
+	}
+	if toAddr == callerAddr {
+		return nil, ErrSelfTransfer
+	}
+	if position.Status != PositionActive {
+		return nil, ErrPositionNotFound
+	}
+	if position.Locked {
+		return nil, ErrPositionLocked
+	}
+	if _, err := s.store.GetOpenListingByPosition(ctx, positionID); err == nil {
+		return nil, ErrAlreadyListed
+	}
+	if _, err := s.store.GetPendingTransferByPosition(ctx, positionID); err == nil {
+		return nil, ErrTransferPending
+	}
+
+	now := time.Now()
+	transfer := &Transfer{
+		ID:         idgen.WithPrefix("xfr_"),
+		PositionID: position.ID,
+		OfferingID: position.OfferingID,
+		FromAddr:   callerAddr,
+		ToAddr:     toAddr,
+		Price:      price,
+		Status:     TransferPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.store.CreateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("stakes: transfer proposed",
+		"transfer", transfer.ID, "position", position.ID,
+		"from", callerAddr, "to", toAddr, "price", price)
+	return transfer, nil
+}
+
+// AcceptTransfer completes a pending transfer as the recipient, settling the
+// payment first when one is attached. The position moves to the recipient
+// with a fresh distribution tally; vesting progress carries over.
+func (s *Service) AcceptTransfer(ctx context.Context, transferID, callerAddr string) (*Transfer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transfer, err := s.store.GetTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToAddr != callerAddr {
+		return nil, ErrUnauthorized
+	}
+	if transfer.Status != TransferPending {
+		return nil, ErrTransferClosed
+	}
+
+	position, err := s.store.GetPosition(ctx, transfer.PositionID)
+	if err != nil {
+		return nil, err
+	}
+	// The sender may have lost, sold or pledged the position since proposing.
+	if position.InvestorAddr != transfer.FromAddr || position.Status != PositionActive {
+		return nil, ErrTransferClosed
+	}
+	if position.Locked {
+		return nil, ErrPositionLocked
+	}
+
+	if transfer.Price != "" {
+		if err := s.ledger.Transfer(ctx, transfer.ToAddr, transfer.FromAddr, transfer.Price, "stakes_transfer:"+transfer.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	position.InvestorAddr = transfer.ToAddr
+	if transfer.Price != "" {
+		position.CostBasis = transfer.Price
+	}
+	position.DistributedTotal = "0.000000"
+	position.UpdatedAt = now
+	if err := s.store.UpdatePosition(ctx, position); err != nil {
+		return nil, err
+	}
+
+	transfer.Status = TransferAccepted
+	transfer.UpdatedAt = now
+	if err := s.store.UpdateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("stakes: transfer accepted",
+		"transfer", transfer.ID, "position", position.ID,
+		"from", transfer.FromAddr, "to", transfer.ToAddr, "price", transfer.Price)
+	return transfer, nil
+}
+
+// DeclineTransfer rejects a pending transfer as the recipient.
+func (s *Service) DeclineTransfer(ctx context.Context, transferID, callerAddr string) (*Transfer, error) {
+	return s.closeTransfer(ctx, transferID, callerAddr, TransferDeclined)
+}
+
+// CancelTransfer withdraws a pending transfer as the sender.
+func (s *Service) CancelTransfer(ctx context.Context, transferID, callerAddr string) (*Transfer, error) {
+	return s.closeTransfer(ctx, transferID, callerAddr, TransferCancelled)
+}
+
+func (s *Service) closeTransfer(ctx context.Context, transferID, callerAddr string, status TransferStatus) (*Transfer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transfer, err := s.store.GetTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if status == TransferDeclined && transfer.ToAddr != callerAddr {
+		return nil, ErrUnauthorized
+	}
+	if status == TransferCancelled && transfer.FromAddr != callerAddr {
+		return nil, ErrUnauthorized
+	}
+	if transfer.Status != TransferPending {
+		return nil, ErrTransferClosed
+	}
+
+	transfer.Status = status
+	transfer.UpdatedAt = time.Now()
+	if err := s.store.UpdateTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// GetTransfer returns a transfer by ID.
+func (s *Service) GetTransfer(ctx context.Context, id string) (*Transfer, error) {
+	return s.store.GetTransfer(ctx, id)
+}
+
+// ListPositionTransfers returns a position's transfer history.
+func (s *Service) ListPositionTransfers(ctx context.Context, positionID string, limit int) ([]*Transfer, error) {
+	return s.store.ListTransfersByPosition(ctx, positionID, limit)
+}
+
+// ListAgentTransfers returns transfers sent or received by an agent.
+func (s *Service) ListAgentTransfers(ctx context.Context, agentAddr string, limit int) ([]*Transfer, error) {
+	return s.store.ListTransfersByAgent(ctx, agentAddr, limit)
+}
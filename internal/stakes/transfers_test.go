@@ -0,0 +1,187 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestProposeTransferValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	if _, err := svc.ProposeTransfer(ctx, investorAddr, position.ID, "not-an-address", ""); !errors.Is(err, ErrInvalidTransfer) {
+		t.Errorf("expected ErrInvalidTransfer for bad recipient, got %v", err)
+	}
+	if _, err := svc.ProposeTransfer(ctx, investorAddr, position.ID, investor2, "bogus"); !errors.Is(err, ErrInvalidTransfer) {
+		t.Errorf("expected ErrInvalidTransfer for bad price, got %v", err)
+	}
+	if _, err := svc.ProposeTransfer(ctx, investor2, position.ID, investorAddr, ""); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-owner, got %v", err)
+	}
+	if _, err := svc.ProposeTransfer(ctx, investorAddr, position.ID, investorAddr, ""); !errors.Is(err, ErrSelfTransfer) {
+		t.Errorf("expected ErrSelfTransfer, got %v", err)
+	}
+
+	// A listed position cannot also be up for transfer, and vice versa.
+	if _, err := svc.CreateListing(ctx, investorAddr, position.ID, "3.000000"); err != nil {
+		t.Fatalf("CreateListing failed: %v", err)
+	}
+	if _, err := svc.ProposeTransfer(ctx, investorAddr, position.ID, investor2, ""); !errors.Is(err, ErrAlreadyListed) {
+		t.Errorf("expected ErrAlreadyListed, got %v", err)
+	}
+
+	position2 := createTestPosition(t, svc)
+	if _, err := svc.ProposeTransfer(ctx, investorAddr, position2.ID, investor2, ""); err != nil {
+		t.Fatalf("ProposeTransfer failed: %v", err)
+	}
+	if _, err := svc.ProposeTransfer(ctx, investorAddr, position2.ID, investor2, ""); !errors.Is(err, ErrTransferPending) {
+		t.Errorf("expected ErrTransferPending on double propose, got %v", err)
+	}
+	if _, err := svc.CreateListing(ctx, investorAddr, position2.ID, "3.000000"); !errors.Is(err, ErrTransferPending) {
+		t.Errorf("expected ErrTransferPending on listing, got %v", err)
+	}
+}
+
+func TestGiftTransferLifecycle(t *testing.T) {
+	svc, ledger := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	transfer, err := svc.ProposeTransfer(ctx, investorAddr, position.ID, investor2, "")
+	if err != nil {
+		t.Fatalf("ProposeTransfer failed: %v", err)
+	}
+	if transfer.Price != "" || transfer.Status != TransferPending {
+		t.Errorf("unexpected transfer: %+v", transfer)
+	}
+
+	// Only the recipient can accept.
+	if _, err := svc.AcceptTransfer(ctx, transfer.ID, investorAddr); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+
+	before := ledger.balance(investor2)
+	if _, err := svc.AcceptTransfer(ctx, transfer.ID, investor2); err != nil {
+		t.Fatalf("AcceptTransfer failed: %v", err)
+	}
+	if got := ledger.balance(investor2); got != before {
+		t.Errorf("expected no payment for a gift, balance moved %s -> %s", before, got)
+	}
+
+	position, _ = svc.GetPosition(ctx, position.ID)
+	if position.InvestorAddr != investor2 {
+		t.Errorf("expected position moved to recipient, got %s", position.InvestorAddr)
+	}
+	// A gift keeps the sender's cost basis; distributions restart for the
+	// new holder.
+	if position.CostBasis != "2.000000" || position.DistributedTotal != "0.000000" {
+		t.Errorf("unexpected basis after gift: cost=%s distributed=%s", position.CostBasis, position.DistributedTotal)
+	}
+
+	// Terminal transfers cannot be re-accepted, and history is kept.
+	if _, err := svc.AcceptTransfer(ctx, transfer.ID, investor2); !errors.Is(err, ErrTransferClosed) {
+		t.Errorf("expected ErrTransferClosed, got %v", err)
+	}
+	history, err := svc.ListPositionTransfers(ctx, position.ID, 0)
+	if err != nil {
+		t.Fatalf("ListPositionTransfers failed: %v", err)
+	}
+	if len(history) != 1 || history[0].Status != TransferAccepted {
+		t.Errorf("expected accepted transfer in history, got %+v", history)
+	}
+}
+
+func TestProposeTransferNormalizesRecipient(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	// Wallets emit EIP-55 checksummed addresses; auth-derived caller
+	// addresses are always lowercase.
+	checksummed := "0xAbCDef0123456789aBcdEF0123456789abCdef01"
+	lowered := "0xabcdef0123456789abcdef0123456789abcdef01"
+
+	transfer, err := svc.ProposeTransfer(ctx, investorAddr, position.ID, checksummed, "")
+	if err != nil {
+		t.Fatalf("ProposeTransfer failed: %v", err)
+	}
+	if transfer.ToAddr != lowered {
+		t.Errorf("expected recipient stored lowercase, got %s", transfer.ToAddr)
+	}
+
+	if _, err := svc.AcceptTransfer(ctx, transfer.ID, lowered); err != nil {
+		t.Fatalf("AcceptTransfer with lowercase caller failed: %v", err)
+	}
+	position, _ = svc.GetPosition(ctx, position.ID)
+	if position.InvestorAddr != lowered {
+		t.Errorf("expected position owned by lowercase address, got %s", position.InvestorAddr)
+	}
+}
+
+func TestPricedTransferSettlesPayment(t *testing.T) {
+	svc, ledger := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	transfer, err := svc.ProposeTransfer(ctx, investorAddr, position.ID, investor2, "5.000000")
+	if err != nil {
+		t.Fatalf("ProposeTransfer failed: %v", err)
+	}
+	if _, err := svc.AcceptTransfer(ctx, transfer.ID, investor2); err != nil {
+		t.Fatalf("AcceptTransfer failed: %v", err)
+	}
+
+	// Buyer paid 5, seller started at 100 minus a 2 USDC purchase.
+	if got := ledger.balance(investor2); got != "95.000000" {
+		t.Errorf("expected recipient to pay 5.000000, balance %s", got)
+	}
+	if got := ledger.balance(investorAddr); got != "103.000000" {
+		t.Errorf("expected sender credited, balance %s", got)
+	}
+
+	position, _ = svc.GetPosition(ctx, position.ID)
+	if position.InvestorAddr != investor2 || position.CostBasis != "5.000000" {
+		t.Errorf("expected new holder with priced basis, got %s / %s", position.InvestorAddr, position.CostBasis)
+	}
+}
+
+func TestDeclineAndCancelTransfer(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	position := createTestPosition(t, svc)
+
+	transfer, err := svc.ProposeTransfer(ctx, investorAddr, position.ID, investor2, "")
+	if err != nil {
+		t.Fatalf("ProposeTransfer failed: %v", err)
+	}
+	if _, err := svc.DeclineTransfer(ctx, transfer.ID, investorAddr); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected only recipient to decline, got %v", err)
+	}
+	if _, err := svc.DeclineTransfer(ctx, transfer.ID, investor2); err != nil {
+		t.Fatalf("DeclineTransfer failed: %v", err)
+	}
+
+	// Declining frees the position for another attempt; the sender can
+	// cancel that one.
+	transfer, err = svc.ProposeTransfer(ctx, investorAddr, position.ID, investor2, "")
+	if err != nil {
+		t.Fatalf("ProposeTransfer after decline failed: %v", err)
+	}
+	if _, err := svc.CancelTransfer(ctx, transfer.ID, investor2); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected only sender to cancel, got %v", err)
+	}
+	if _, err := svc.CancelTransfer(ctx, transfer.ID, investorAddr); err != nil {
+		t.Fatalf("CancelTransfer failed: %v", err)
+	}
+
+	position, _ = svc.GetPosition(ctx, position.ID)
+	if position.InvestorAddr != investorAddr {
+		t.Errorf("expected position unmoved, got %s", position.InvestorAddr)
+	}
+	history, _ := svc.ListAgentTransfers(ctx, investor2, 0)
+	if len(history) != 2 {
+		t.Errorf("expected 2 transfers in recipient history, got %d", len(history))
+	}
+}
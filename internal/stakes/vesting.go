@@ -0,0 +1,89 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidVesting = errors.New("stakes: invalid vesting schedule")
+
+// Vesting ramps a position's revenue share up over time: nothing vests
+// before the cliff, then the vested fraction grows linearly from purchase
+// until the full duration has elapsed. Vesting follows the position, so a
+// secondary-market buyer inherits the seller's progress.
+type Vesting struct {
+	CliffDays    int `json:"cliffDays"`
+	DurationDays int `json:"durationDays"`
+}
+
+func (v *Vesting) validate() error {
+	if v.DurationDays < 1 || v.CliffDays < 0 || v.CliffDays > v.DurationDays {
+		return ErrInvalidVesting
+	}
+	return nil
+}
+
+// vestedBps returns how much of a position has vested at now, in basis
+// points. Offerings without vesting are fully vested from purchase.
+func vestedBps(offering *Offering, position *Position, now time.Time) int {
+	if offering.Vesting == nil {
+		return 10000
+	}
+	start := position.VestingStart
+	if start.IsZero() {
+		start = position.CreatedAt
+	}
+	elapsed := now.Sub(start)
+	if elapsed < time.Duration(offering.Vesting.CliffDays)*24*time.Hour {
+		return 0
+	}
+	duration := time.Duration(offering.Vesting.DurationDays) * 24 * time.Hour
+	if elapsed >= duration {
+		return 10000
+	}
+	// Second precision avoids int64 overflow on long schedules.
+	return int(int64(elapsed/time.Second) * 10000 / int64(duration/time.Second))
+}
+
+// VestingStatus describes where a position sits on its vesting schedule.
+type VestingStatus struct {
+	PositionID    string     `json:"positionId"`
+	OfferingID    string     `json:"offeringId"`
+	Vesting       *Vesting   `json:"vesting,omitempty"` // nil = no vesting, fully vested
+	VestingStart  time.Time  `json:"vestingStart"`
+	CliffAt       *time.Time `json:"cliffAt,omitempty"`
+	FullyVestedAt *time.Time `json:"fullyVestedAt,omitempty"`
+	VestedBps     int        `json:"vestedBps"`
+}
+
+// VestingSchedule returns a position's vesting terms and current progress.
+func (s *Service) VestingSchedule(ctx context.Context, positionID string) (*VestingStatus, error) {
+	position, err := s.store.GetPosition(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+	offering, err := s.store.GetOffering(ctx, position.OfferingID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := position.VestingStart
+	if start.IsZero() {
+		start = position.CreatedAt
+	}
+	status := &VestingStatus{
+		PositionID:   position.ID,
+		OfferingID:   offering.ID,
+		Vesting:      offering.Vesting,
+		VestingStart: start,
+		VestedBps:    vestedBps(offering, position, time.Now()),
+	}
+	if offering.Vesting != nil {
+		cliffAt := start.Add(time.Duration(offering.Vesting.CliffDays) * 24 * time.Hour)
+		fullAt := start.Add(time.Duration(offering.Vesting.DurationDays) * 24 * time.Hour)
+		status.CliffAt = &cliffAt
+		status.FullyVestedAt = &fullAt
+	}
+	return status, nil
+}
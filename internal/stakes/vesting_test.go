@@ -0,0 +1,165 @@
+package stakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func createVestedOffering(t *testing.T, svc *Service, cliffDays, durationDays int) *Offering {
+	t.Helper()
+	offering, err := svc.CreateOffering(context.Background(), agentAddr, CreateOfferingRequest{
+		RevenueShareBps: 2000,
+		TotalShares:     100,
+		PricePerShare:   "0.100000",
+		Vesting:         &Vesting{CliffDays: cliffDays, DurationDays: durationDays},
+	})
+	if err != nil {
+		t.Fatalf("CreateOffering failed: %v", err)
+	}
+	return offering
+}
+
+// rewindVesting backdates a position's vesting start by the given number of days.
+func rewindVesting(t *testing.T, svc *Service, positionID string, days int) {
+	t.Helper()
+	position, err := svc.store.GetPosition(context.Background(), positionID)
+	if err != nil {
+		t.Fatalf("GetPosition failed: %v", err)
+	}
+	position.VestingStart = position.VestingStart.Add(-time.Duration(days) * 24 * time.Hour)
+	if err := svc.store.UpdatePosition(context.Background(), position); err != nil {
+		t.Fatalf("UpdatePosition failed: %v", err)
+	}
+}
+
+func TestVestingValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	cases := []*Vesting{
+		{CliffDays: 0, DurationDays: 0},
+		{CliffDays: -1, DurationDays: 30},
+		{CliffDays: 40, DurationDays: 30},
+	}
+	for i, v := range cases {
+		_, err := svc.CreateOffering(ctx, agentAddr, CreateOfferingRequest{
+			RevenueShareBps: 2000, TotalShares: 100, PricePerShare: "0.100000", Vesting: v,
+		})
+		if !errors.Is(err, ErrInvalidVesting) {
+			t.Errorf("case %d: expected ErrInvalidVesting, got %v", i, err)
+		}
+	}
+}
+
+func TestDistributeBeforeCliffPaysNothing(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	offering := createVestedOffering(t, svc, 10, 100)
+
+	if _, err := svc.BuyShares(ctx, offering.ID, investorAddr, 40); err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+
+	if _, err := svc.Distribute(ctx, offering.ID, agentAddr); !errors.Is(err, ErrNothingAccrued) {
+		t.Errorf("expected nothing distributable before cliff, got %v", err)
+	}
+	offering, _ = svc.GetOffering(ctx, offering.ID)
+	if offering.Accrued != "2.000000" {
+		t.Errorf("expected pool untouched, got %s", offering.Accrued)
+	}
+}
+
+func TestDistributeProRatesByVestedFraction(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	offering := createVestedOffering(t, svc, 10, 100)
+
+	position, err := svc.BuyShares(ctx, offering.ID, investorAddr, 40)
+	if err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	rewindVesting(t, svc, position.ID, 50) // halfway through the schedule
+
+	if _, err := svc.AccumulateRevenue(ctx, agentAddr, "10.000000"); err != nil {
+		t.Fatalf("AccumulateRevenue failed: %v", err)
+	}
+	dist, err := svc.Distribute(ctx, offering.ID, agentAddr)
+	if err != nil {
+		t.Fatalf("Distribute failed: %v", err)
+	}
+	// 50% vested → half of the 2 USDC pool pays out, half stays accrued.
+	if dist.TotalAmount != "1.000000" {
+		t.Errorf("expected 1.000000 distributed at 50%% vested, got %s", dist.TotalAmount)
+	}
+	offering, _ = svc.GetOffering(ctx, offering.ID)
+	if offering.Accrued != "1.000000" {
+		t.Errorf("expected 1.000000 left in pool, got %s", offering.Accrued)
+	}
+
+	// Fully vested: the rest of the pool drains.
+	rewindVesting(t, svc, position.ID, 50)
+	dist, err = svc.Distribute(ctx, offering.ID, agentAddr)
+	if err != nil {
+		t.Fatalf("Distribute failed: %v", err)
+	}
+	if dist.TotalAmount != "1.000000" {
+		t.Errorf("expected remaining 1.000000 distributed, got %s", dist.TotalAmount)
+	}
+	offering, _ = svc.GetOffering(ctx, offering.ID)
+	if offering.Accrued != "0.000000" {
+		t.Errorf("expected drained pool, got %s", offering.Accrued)
+	}
+}
+
+func TestVestingScheduleEndpoint(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	offering := createVestedOffering(t, svc, 10, 100)
+
+	position, err := svc.BuyShares(ctx, offering.ID, investorAddr, 40)
+	if err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+
+	status, err := svc.VestingSchedule(ctx, position.ID)
+	if err != nil {
+		t.Fatalf("VestingSchedule failed: %v", err)
+	}
+	if status.VestedBps != 0 {
+		t.Errorf("expected 0 bps vested at purchase, got %d", status.VestedBps)
+	}
+	if status.CliffAt == nil || status.FullyVestedAt == nil {
+		t.Fatal("expected cliff and full-vest timestamps")
+	}
+	if got := status.FullyVestedAt.Sub(status.VestingStart); got != 100*24*time.Hour {
+		t.Errorf("expected 100-day schedule, got %v", got)
+	}
+
+	rewindVesting(t, svc, position.ID, 25)
+	status, err = svc.VestingSchedule(ctx, position.ID)
+	if err != nil {
+		t.Fatalf("VestingSchedule failed: %v", err)
+	}
+	if status.VestedBps != 2500 {
+		t.Errorf("expected 2500 bps at a quarter through, got %d", status.VestedBps)
+	}
+
+	// Unvested offerings report fully vested immediately.
+	plain := createTestOffering(t, svc)
+	p2, err := svc.BuyShares(ctx, plain.ID, investor2, 10)
+	if err != nil {
+		t.Fatalf("BuyShares failed: %v", err)
+	}
+	status, err = svc.VestingSchedule(ctx, p2.ID)
+	if err != nil {
+		t.Fatalf("VestingSchedule failed: %v", err)
+	}
+	if status.VestedBps != 10000 || status.Vesting != nil {
+		t.Errorf("expected fully vested with no schedule, got %d bps", status.VestedBps)
+	}
+}
@@ -0,0 +1,165 @@
+package streams
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// earningsStreamLimit caps how many seller streams a single summary scans.
+const earningsStreamLimit = 1000
+
+// CounterpartyEarnings aggregates settled revenue from one buyer.
+type CounterpartyEarnings struct {
+	BuyerAddr string `json:"buyerAddr"`
+	Earned    string `json:"earned"`
+	Streams   int    `json:"streams"`
+}
+
+// DailyEarnings aggregates settled revenue for one UTC day.
+type DailyEarnings struct {
+	Day     string `json:"day"` // YYYY-MM-DD
+	Earned  string `json:"earned"`
+	Streams int    `json:"streams"`
+}
+
+// ServiceEarnings aggregates settled revenue for one service.
+type ServiceEarnings struct {
+	ServiceID string `json:"serviceId"` // Empty for streams not tied to a service
+	Earned    string `json:"earned"`
+	Streams   int    `json:"streams"`
+}
+
+// EarningsSummary is a seller-facing view of stream revenue. Settled figures
+// cover streams closed within the window; open-stream exposure reflects
+// current state regardless of age.
+type EarningsSummary struct {
+	SellerAddr     string                 `json:"sellerAddr"`
+	WindowDays     int                    `json:"windowDays"`
+	TotalEarned    string                 `json:"totalEarned"`
+	SettledStreams int                    `json:"settledStreams"`
+	OpenStreams    int                    `json:"openStreams"`
+	OpenAccrued    string                 `json:"openAccrued"` // Ticked but not yet settled
+	OpenHeld       string                 `json:"openHeld"`    // Buyer funds currently held
+	ByCounterparty []CounterpartyEarnings `json:"byCounterparty"`
+	ByDay          []DailyEarnings        `json:"byDay"`
+	ByService      []ServiceEarnings      `json:"byService"`
+}
+
+// Earnings summarizes a seller's stream revenue over the last windowDays
+// (default 30) without replaying ledger history.
+func (s *Service) Earnings(ctx context.Context, sellerAddr string, windowDays int) (*EarningsSummary, error) {
+	if windowDays <= 0 {
+		windowDays = 30
+	}
+
+	seller := strings.ToLower(sellerAddr)
+	all, err := s.store.ListBySeller(ctx, seller, earningsStreamLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -windowDays)
+
+	total := new(big.Int)
+	openAccrued := new(big.Int)
+	openHeld := new(big.Int)
+	settledCount := 0
+	openCount := 0
+
+	type bucket struct {
+		earned  *big.Int
+		streams int
+	}
+	byBuyer := make(map[string]*bucket)
+	byDay := make(map[string]*bucket)
+	byService := make(map[string]*bucket)
+	addTo := func(m map[string]*bucket, key string, amt *big.Int) {
+		b, ok := m[key]
+		if !ok {
+			b = &bucket{earned: new(big.Int)}
+			m[key] = b
+		}
+		b.earned.Add(b.earned, amt)
+		b.streams++
+	}
+
+	for _, st := range all {
+		switch st.Status {
+		case StatusOpen:
+			openCount++
+			if spent, ok := usdc.Parse(st.SpentAmount); ok {
+				openAccrued.Add(openAccrued, spent)
+			}
+			if hold, ok := usdc.Parse(st.HoldAmount); ok {
+				openHeld.Add(openHeld, hold)
+			}
+		case StatusClosed, StatusStaleClosed:
+			if st.ClosedAt == nil || st.ClosedAt.Before(cutoff) {
+				continue
+			}
+			amt, ok := usdc.Parse(st.SpentAmount)
+			if !ok || amt.Sign() <= 0 {
+				continue
+			}
+			settledCount++
+			total.Add(total, amt)
+			addTo(byBuyer, st.BuyerAddr, amt)
+			addTo(byDay, st.ClosedAt.UTC().Format("2006-01-02"), amt)
+			addTo(byService, st.ServiceID, amt)
+		}
+	}
+
+	summary := &EarningsSummary{
+		SellerAddr:     seller,
+		WindowDays:     windowDays,
+		TotalEarned:    usdc.Format(total),
+		SettledStreams: settledCount,
+		OpenStreams:    openCount,
+		OpenAccrued:    usdc.Format(openAccrued),
+		OpenHeld:       usdc.Format(openHeld),
+		ByCounterparty: make([]CounterpartyEarnings, 0, len(byBuyer)),
+		ByDay:          make([]DailyEarnings, 0, len(byDay)),
+		ByService:      make([]ServiceEarnings, 0, len(byService)),
+	}
+
+	type keyed struct {
+		key string
+		b   *bucket
+	}
+	sorted := func(m map[string]*bucket, byAmount bool) []keyed {
+		out := make([]keyed, 0, len(m))
+		for k, b := range m {
+			out = append(out, keyed{k, b})
+		}
+		sort.Slice(out, func(i, j int) bool {
+			if byAmount {
+				return out[i].b.earned.Cmp(out[j].b.earned) > 0
+			}
+			return out[i].key > out[j].key
+		})
+		return out
+	}
+
+	for _, e := range sorted(byBuyer, true) {
+		summary.ByCounterparty = append(summary.ByCounterparty, CounterpartyEarnings{
+			BuyerAddr: e.key, Earned: usdc.Format(e.b.earned), Streams: e.b.streams,
+		})
+	}
+	for _, e := range sorted(byDay, false) {
+		summary.ByDay = append(summary.ByDay, DailyEarnings{
+			Day: e.key, Earned: usdc.Format(e.b.earned), Streams: e.b.streams,
+		})
+	}
+	for _, e := range sorted(byService, true) {
+		summary.ByService = append(summary.ByService, ServiceEarnings{
+			ServiceID: e.key, Earned: usdc.Format(e.b.earned), Streams: e.b.streams,
+		})
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,174 @@
+package streams
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEarningsSummary(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+	ctx := context.Background()
+
+	seller := "0x2222222222222222222222222222222222222222"
+	buyerA := "0x1111111111111111111111111111111111111111"
+	buyerB := "0x3333333333333333333333333333333333333333"
+
+	openAndSpend := func(buyer, serviceID string, ticks int) *Stream {
+		t.Helper()
+		stream, err := svc.Open(ctx, OpenRequest{
+			BuyerAddr:    buyer,
+			SellerAddr:   seller,
+			HoldAmount:   "1.000000",
+			PricePerTick: "0.001000",
+			ServiceID:    serviceID,
+		})
+		if err != nil {
+			t.Fatalf("Open failed: %v", err)
+		}
+		for i := 0; i < ticks; i++ {
+			if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+				t.Fatalf("tick failed: %v", err)
+			}
+		}
+		return stream
+	}
+
+	// Two settled streams from buyer A, one from buyer B, one still open.
+	s1 := openAndSpend(buyerA, "svc_translate", 3) // 0.003
+	s2 := openAndSpend(buyerA, "svc_translate", 2) // 0.002
+	s3 := openAndSpend(buyerB, "svc_gpu", 5)       // 0.005
+	open := openAndSpend(buyerB, "svc_gpu", 4)     // 0.004 accrued, stays open
+
+	for _, id := range []string{s1.ID, s2.ID, s3.ID} {
+		if _, err := svc.Close(ctx, id, seller, "done"); err != nil {
+			t.Fatalf("close failed: %v", err)
+		}
+	}
+
+	summary, err := svc.Earnings(ctx, seller, 0)
+	if err != nil {
+		t.Fatalf("Earnings failed: %v", err)
+	}
+
+	if summary.WindowDays != 30 {
+		t.Errorf("expected default window 30, got %d", summary.WindowDays)
+	}
+	if summary.TotalEarned != "0.010000" {
+		t.Errorf("expected total 0.010000, got %s", summary.TotalEarned)
+	}
+	if summary.SettledStreams != 3 {
+		t.Errorf("expected 3 settled streams, got %d", summary.SettledStreams)
+	}
+	if summary.OpenStreams != 1 {
+		t.Errorf("expected 1 open stream, got %d", summary.OpenStreams)
+	}
+	if summary.OpenAccrued != "0.004000" {
+		t.Errorf("expected open accrued 0.004000, got %s", summary.OpenAccrued)
+	}
+	if summary.OpenHeld != "1.000000" {
+		t.Errorf("expected open held 1.000000, got %s", summary.OpenHeld)
+	}
+
+	if len(summary.ByCounterparty) != 2 {
+		t.Fatalf("expected 2 counterparties, got %d", len(summary.ByCounterparty))
+	}
+	// Buyer A earned most (0.005 == buyer B — actually A: 0.005, B: 0.005)
+	for _, cp := range summary.ByCounterparty {
+		switch cp.BuyerAddr {
+		case buyerA:
+			if cp.Earned != "0.005000" || cp.Streams != 2 {
+				t.Errorf("buyer A: expected 0.005000 over 2 streams, got %s over %d", cp.Earned, cp.Streams)
+			}
+		case buyerB:
+			if cp.Earned != "0.005000" || cp.Streams != 1 {
+				t.Errorf("buyer B: expected 0.005000 over 1 stream, got %s over %d", cp.Earned, cp.Streams)
+			}
+		default:
+			t.Errorf("unexpected counterparty %s", cp.BuyerAddr)
+		}
+	}
+
+	if len(summary.ByService) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(summary.ByService))
+	}
+	for _, se := range summary.ByService {
+		switch se.ServiceID {
+		case "svc_translate":
+			if se.Earned != "0.005000" {
+				t.Errorf("svc_translate: expected 0.005000, got %s", se.Earned)
+			}
+		case "svc_gpu":
+			if se.Earned != "0.005000" {
+				t.Errorf("svc_gpu: expected 0.005000, got %s", se.Earned)
+			}
+		}
+	}
+
+	if len(summary.ByDay) != 1 {
+		t.Fatalf("expected 1 day bucket, got %d", len(summary.ByDay))
+	}
+	if summary.ByDay[0].Earned != "0.010000" || summary.ByDay[0].Streams != 3 {
+		t.Errorf("expected today's bucket 0.010000 over 3 streams, got %s over %d",
+			summary.ByDay[0].Earned, summary.ByDay[0].Streams)
+	}
+
+	_ = open
+}
+
+func TestEarningsWindowExcludesOldStreams(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+	ctx := context.Background()
+
+	seller := "0x2222222222222222222222222222222222222222"
+	stream, err := svc.Open(ctx, OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   seller,
+		HoldAmount:   "1.000000",
+		PricePerTick: "0.001000",
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	svc.RecordTick(ctx, stream.ID, TickRequest{})
+	if _, err := svc.Close(ctx, stream.ID, seller, "done"); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	// Backdate the close beyond the window
+	stored, _ := store.Get(ctx, stream.ID)
+	old := time.Now().UTC().AddDate(0, 0, -10)
+	stored.ClosedAt = &old
+	store.Update(ctx, stored)
+
+	summary, err := svc.Earnings(ctx, seller, 7)
+	if err != nil {
+		t.Fatalf("Earnings failed: %v", err)
+	}
+	if summary.TotalEarned != "0.000000" {
+		t.Errorf("expected no earnings inside 7-day window, got %s", summary.TotalEarned)
+	}
+	if summary.SettledStreams != 0 {
+		t.Errorf("expected 0 settled streams in window, got %d", summary.SettledStreams)
+	}
+}
+
+func TestEarningsEmptySeller(t *testing.T) {
+	store := NewMemoryStore()
+	svc := NewService(store, newMockLedger())
+
+	summary, err := svc.Earnings(context.Background(), "0x9999999999999999999999999999999999999999", 30)
+	if err != nil {
+		t.Fatalf("Earnings failed: %v", err)
+	}
+	if summary.TotalEarned != "0.000000" || summary.OpenStreams != 0 {
+		t.Errorf("expected empty summary, got %+v", summary)
+	}
+	if len(summary.ByCounterparty) != 0 || len(summary.ByDay) != 0 || len(summary.ByService) != 0 {
+		t.Error("expected empty breakdown slices")
+	}
+}
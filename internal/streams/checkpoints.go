@@ -0,0 +1,105 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrCheckpointsDisabled = errors.New("streams: checkpoint signing not configured")
+	ErrInvalidCheckpoint   = errors.New("streams: invalid checkpoint")
+)
+
+// checkpointEvery controls how often a signed checkpoint is attached to
+// tick responses (every Nth tick).
+const checkpointEvery = 10
+
+// CheckpointSigner signs and verifies checkpoint payloads.
+// Satisfied by *reputation.Signer.
+type CheckpointSigner interface {
+	Sign(payload interface{}) (signature, issuedAt, expiresAt string, err error)
+	Verify(payload interface{}, signature string) bool
+}
+
+// CheckpointPayload is the signed portion of a checkpoint. The signature
+// covers the canonical JSON of exactly these fields.
+type CheckpointPayload struct {
+	StreamID   string `json:"streamId"`
+	Cumulative string `json:"cumulative"` // Spent amount at issue time
+	TickCount  int    `json:"tickCount"`
+	IssuedAt   string `json:"issuedAt"` // RFC3339
+}
+
+// Checkpoint is a signed proof of consumption that either party can store
+// off-platform. Presenting a valid checkpoint at close time establishes an
+// authoritative floor on the settled amount.
+type Checkpoint struct {
+	CheckpointPayload
+	Signature string `json:"signature"`
+}
+
+// WithCheckpointSigner enables signed consumption checkpoints.
+func (s *Service) WithCheckpointSigner(signer CheckpointSigner) *Service {
+	s.checkpointSigner = signer
+	return s
+}
+
+// SignedCheckpoint builds and signs a checkpoint for the stream's current
+// spent amount and tick count.
+func (s *Service) SignedCheckpoint(stream *Stream) (*Checkpoint, error) {
+	if s.checkpointSigner == nil {
+		return nil, ErrCheckpointsDisabled
+	}
+	payload := CheckpointPayload{
+		StreamID:   stream.ID,
+		Cumulative: stream.SpentAmount,
+		TickCount:  stream.TickCount,
+		IssuedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+	sig, _, _, err := s.checkpointSigner.Sign(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign checkpoint: %w", err)
+	}
+	return &Checkpoint{CheckpointPayload: payload, Signature: sig}, nil
+}
+
+// IssueCheckpoint returns a signed checkpoint for the stream's current
+// state. Only the buyer or seller may request one.
+func (s *Service) IssueCheckpoint(ctx context.Context, streamID, callerAddr string) (*Checkpoint, error) {
+	stream, err := s.store.Get(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	caller := strings.ToLower(callerAddr)
+	if caller != stream.BuyerAddr && caller != stream.SellerAddr {
+		return nil, ErrUnauthorized
+	}
+
+	return s.SignedCheckpoint(stream)
+}
+
+// verifyCheckpoint validates a checkpoint against a stream and returns its
+// cumulative amount in micro-units.
+func (s *Service) verifyCheckpoint(stream *Stream, cp *Checkpoint) (*big.Int, error) {
+	if s.checkpointSigner == nil {
+		return nil, ErrCheckpointsDisabled
+	}
+	if cp.StreamID != stream.ID {
+		return nil, fmt.Errorf("%w: stream ID mismatch", ErrInvalidCheckpoint)
+	}
+	if !s.checkpointSigner.Verify(cp.CheckpointPayload, cp.Signature) {
+		return nil, fmt.Errorf("%w: signature verification failed", ErrInvalidCheckpoint)
+	}
+	cum, ok := usdc.Parse(cp.Cumulative)
+	if !ok || cum.Sign() < 0 {
+		return nil, fmt.Errorf("%w: malformed cumulative amount", ErrInvalidCheckpoint)
+	}
+	return cum, nil
+}
@@ -0,0 +1,195 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mbd888/alancoin/internal/reputation"
+)
+
+func newCheckpointTestService() (*Service, *MemoryStore, *mockLedger) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger).WithCheckpointSigner(reputation.NewSigner("test-secret"))
+	return svc, store, ledger
+}
+
+func openCheckpointStream(t *testing.T, svc *Service) *Stream {
+	t.Helper()
+	stream, err := svc.Open(context.Background(), OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x2222222222222222222222222222222222222222",
+		HoldAmount:   "1.000000",
+		PricePerTick: "0.001000",
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	return stream
+}
+
+func TestCheckpointIssueAndVerify(t *testing.T) {
+	svc, _, _ := newCheckpointTestService()
+	ctx := context.Background()
+	stream := openCheckpointStream(t, svc)
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick failed: %v", err)
+		}
+	}
+
+	cp, err := svc.IssueCheckpoint(ctx, stream.ID, stream.BuyerAddr)
+	if err != nil {
+		t.Fatalf("IssueCheckpoint failed: %v", err)
+	}
+	if cp.Cumulative != "0.003000" {
+		t.Errorf("expected cumulative 0.003000, got %s", cp.Cumulative)
+	}
+	if cp.TickCount != 3 {
+		t.Errorf("expected tick count 3, got %d", cp.TickCount)
+	}
+	if cp.Signature == "" {
+		t.Error("expected a signature")
+	}
+
+	// Round-trips through the signer
+	signer := reputation.NewSigner("test-secret")
+	if !signer.Verify(cp.CheckpointPayload, cp.Signature) {
+		t.Error("checkpoint signature did not verify")
+	}
+}
+
+func TestCheckpointRequiresParty(t *testing.T) {
+	svc, _, _ := newCheckpointTestService()
+	stream := openCheckpointStream(t, svc)
+
+	_, err := svc.IssueCheckpoint(context.Background(), stream.ID, "0x3333333333333333333333333333333333333333")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestCheckpointDisabledWithoutSigner(t *testing.T) {
+	store := NewMemoryStore()
+	svc := NewService(store, newMockLedger())
+	stream := openCheckpointStream(t, svc)
+
+	_, err := svc.IssueCheckpoint(context.Background(), stream.ID, stream.BuyerAddr)
+	if !errors.Is(err, ErrCheckpointsDisabled) {
+		t.Errorf("expected ErrCheckpointsDisabled, got %v", err)
+	}
+}
+
+func TestCloseWithCheckpointRaisesFloor(t *testing.T) {
+	svc, store, ledger := newCheckpointTestService()
+	ctx := context.Background()
+	stream := openCheckpointStream(t, svc)
+
+	for i := 0; i < 5; i++ {
+		if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick failed: %v", err)
+		}
+	}
+	cp, err := svc.IssueCheckpoint(ctx, stream.ID, stream.SellerAddr)
+	if err != nil {
+		t.Fatalf("IssueCheckpoint failed: %v", err)
+	}
+
+	// Simulate the recorded state regressing below the attested checkpoint
+	stored, _ := store.Get(ctx, stream.ID)
+	stored.SpentAmount = "0.002000"
+	stored.TickCount = 2
+	store.Update(ctx, stored)
+
+	closed, err := svc.CloseWithCheckpoint(ctx, stream.ID, stream.SellerAddr, "done", cp)
+	if err != nil {
+		t.Fatalf("close with checkpoint failed: %v", err)
+	}
+	if closed.SpentAmount != "0.005000" {
+		t.Errorf("expected settled amount 0.005000, got %s", closed.SpentAmount)
+	}
+	if got := ledger.settlements[stream.ID]; got != "0.005000" {
+		t.Errorf("expected SettleHold for 0.005000, got %s", got)
+	}
+}
+
+func TestCloseWithTamperedCheckpoint(t *testing.T) {
+	svc, _, _ := newCheckpointTestService()
+	ctx := context.Background()
+	stream := openCheckpointStream(t, svc)
+
+	svc.RecordTick(ctx, stream.ID, TickRequest{})
+	cp, err := svc.IssueCheckpoint(ctx, stream.ID, stream.SellerAddr)
+	if err != nil {
+		t.Fatalf("IssueCheckpoint failed: %v", err)
+	}
+
+	cp.Cumulative = "0.999000" // inflate the attested amount
+
+	_, err = svc.CloseWithCheckpoint(ctx, stream.ID, stream.SellerAddr, "done", cp)
+	if !errors.Is(err, ErrInvalidCheckpoint) {
+		t.Errorf("expected ErrInvalidCheckpoint, got %v", err)
+	}
+}
+
+func TestCloseCheckpointWrongStream(t *testing.T) {
+	svc, _, _ := newCheckpointTestService()
+	ctx := context.Background()
+	first := openCheckpointStream(t, svc)
+
+	second, err := svc.Open(ctx, OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x4444444444444444444444444444444444444444",
+		HoldAmount:   "1.000000",
+		PricePerTick: "0.001000",
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	cp, err := svc.IssueCheckpoint(ctx, first.ID, first.BuyerAddr)
+	if err != nil {
+		t.Fatalf("IssueCheckpoint failed: %v", err)
+	}
+
+	_, err = svc.CloseWithCheckpoint(ctx, second.ID, second.BuyerAddr, "done", cp)
+	if !errors.Is(err, ErrInvalidCheckpoint) {
+		t.Errorf("expected ErrInvalidCheckpoint, got %v", err)
+	}
+}
+
+func TestCloseCheckpointClampedToHold(t *testing.T) {
+	svc, _, ledger := newCheckpointTestService()
+	ctx := context.Background()
+	stream := openCheckpointStream(t, svc)
+
+	svc.RecordTick(ctx, stream.ID, TickRequest{})
+
+	// Forge a checkpoint above the hold with a valid signature; the floor
+	// must still be capped at the held amount.
+	signer := reputation.NewSigner("test-secret")
+	payload := CheckpointPayload{
+		StreamID:   stream.ID,
+		Cumulative: "5.000000",
+		TickCount:  10,
+		IssuedAt:   "2026-01-01T00:00:00Z",
+	}
+	sig, _, _, err := signer.Sign(payload)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	cp := &Checkpoint{CheckpointPayload: payload, Signature: sig}
+
+	closed, err := svc.CloseWithCheckpoint(ctx, stream.ID, stream.BuyerAddr, "done", cp)
+	if err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if closed.SpentAmount != "1.000000" {
+		t.Errorf("expected spent clamped to hold 1.000000, got %s", closed.SpentAmount)
+	}
+	if got := ledger.settlements[stream.ID]; got != "1.000000" {
+		t.Errorf("expected SettleHold for 1.000000, got %s", got)
+	}
+}
@@ -0,0 +1,305 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/logging"
+	"github.com/mbd888/alancoin/internal/retry"
+	"github.com/mbd888/alancoin/internal/traces"
+	"github.com/mbd888/alancoin/internal/usdc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// DefaultArbitrationDeadline is the time given for arbitration after assignment.
+const DefaultArbitrationDeadline = 72 * time.Hour
+
+// maxDisputeTextLength bounds dispute reasons and evidence content.
+const maxDisputeTextLength = 10000
+
+// DisputePending returns true if the stream has an unresolved dispute.
+func (s *Stream) DisputePending() bool {
+	return s.Status == StatusDisputed && s.Resolution == ""
+}
+
+// Dispute lets the buyer contest the tail of an open stream before close.
+// Only the contested amount stays frozen in the buyer's pending balance:
+// the undisputed portion settles to the seller immediately and the unused
+// hold is released back to the buyer. The contested tail is then subject to
+// evidence submission and arbitrator resolution, like escrow.
+func (s *Service) Dispute(ctx context.Context, streamID, callerAddr, contestedAmount, reason string) (_ *Stream, retErr error) {
+	ctx, span := traces.StartSpan(ctx, "streams.Dispute",
+		attribute.String("stream_id", streamID),
+		attribute.String("contested_amount", contestedAmount),
+	)
+	defer func() {
+		if retErr != nil {
+			span.RecordError(retErr)
+			span.SetStatus(codes.Error, retErr.Error())
+		}
+		span.End()
+	}()
+
+	if len(reason) == 0 || len(reason) > maxDisputeTextLength {
+		return nil, fmt.Errorf("reason must be between 1 and %d characters", maxDisputeTextLength)
+	}
+
+	unlock := s.locks.Lock(streamID)
+	defer unlock()
+
+	stream, err := s.store.Get(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToLower(callerAddr) != stream.BuyerAddr {
+		return nil, ErrUnauthorized
+	}
+	if stream.IsTerminal() {
+		return nil, ErrAlreadyClosed
+	}
+	if stream.Status != StatusOpen {
+		return nil, ErrInvalidStatus
+	}
+
+	contested, ok := usdc.Parse(contestedAmount)
+	if !ok || contested.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	spentBig, _ := usdc.Parse(stream.SpentAmount)
+	holdBig, _ := usdc.Parse(stream.HoldAmount)
+	if contested.Cmp(spentBig) > 0 {
+		return nil, fmt.Errorf("%w: contested amount exceeds spent amount", ErrInvalidAmount)
+	}
+
+	undisputed := new(big.Int).Sub(spentBig, contested)
+	unused := new(big.Int).Sub(holdBig, spentBig)
+
+	// Same fail-safe ordering as settle(): release the buyer's unused hold
+	// first, then settle the undisputed portion to the seller. The contested
+	// tail stays in the buyer's pending balance until arbitration. Wallet-
+	// funded streams skip the release — the unused cap returns to the wallet
+	// below instead.
+	if unused.Sign() > 0 && stream.WalletID == "" {
+		if err := s.ledger.ReleaseHold(ctx, stream.BuyerAddr, usdc.Format(unused), stream.ID); err != nil {
+			return nil, fmt.Errorf("failed to release unused hold: %w", err)
+		}
+	}
+	if undisputed.Sign() > 0 {
+		undisputedStr := usdc.Format(undisputed)
+		if err := s.ledger.SettleHold(ctx, stream.BuyerAddr, stream.SellerAddr, undisputedStr, stream.ID); err != nil {
+			logging.L(ctx).Error("CRITICAL: stream dispute released unused hold but undisputed settle failed — seller owed money",
+				"stream", stream.ID, "seller", stream.SellerAddr, "amount", undisputedStr, "error", err)
+			return nil, fmt.Errorf("failed to settle undisputed portion (seller owed %s — requires reconciliation): %w",
+				undisputedStr, err)
+		}
+	}
+
+	// Wallet-funded: free the cap for new sub-streams. The full spent amount
+	// (including the contested tail, which stays frozen in the buyer's
+	// pending balance) is recorded as consumed so the wallet never releases
+	// it at close — resolution moves it directly.
+	if stream.WalletID != "" {
+		s.releaseToWallet(ctx, stream)
+	}
+
+	now := time.Now()
+	stream.Status = StatusDisputed
+	stream.ClosedAt = &now
+	stream.CloseReason = "disputed"
+	stream.DisputedAmount = usdc.Format(contested)
+	stream.DisputeReason = reason
+	stream.DisputeEvidence = []DisputeEvidence{{
+		SubmittedBy: stream.BuyerAddr,
+		Content:     reason,
+		SubmittedAt: now,
+	}}
+	stream.UpdatedAt = now
+
+	if err := retry.Do(ctx, 3, 50*time.Millisecond, func() error {
+		return s.store.Update(ctx, stream)
+	}); err != nil {
+		logging.L(ctx).Error("CRITICAL: stream dispute funds moved but status update failed",
+			"stream", stream.ID, "error", err)
+		return nil, fmt.Errorf("failed to update stream after dispute (requires manual resolution): %w", err)
+	}
+
+	streamsClosed.WithLabelValues(string(StatusDisputed)).Inc()
+	streamDuration.Observe(time.Since(stream.CreatedAt).Seconds())
+
+	// The undisputed portion is legitimate seller revenue.
+	if undisputed.Sign() > 0 {
+		undisputedStr := usdc.Format(undisputed)
+		if s.recorder != nil {
+			if err := s.recorder.RecordTransaction(ctx, stream.ID, stream.BuyerAddr, stream.SellerAddr, undisputedStr, stream.ServiceID, "confirmed"); err != nil {
+				logging.L(ctx).Error("stream dispute: failed to record transaction", "stream_id", stream.ID, "error", err)
+			}
+		}
+		if s.revenue != nil {
+			if err := s.revenue.AccumulateRevenue(ctx, stream.SellerAddr, undisputedStr, "stream_dispute:"+stream.ID); err != nil {
+				logging.L(ctx).Error("stream dispute: failed to accumulate revenue", "stream_id", stream.ID, "error", err)
+			}
+		}
+	}
+
+	if s.webhookEmitter != nil {
+		go s.webhookEmitter.EmitStreamClosed(stream.BuyerAddr, stream.ID, stream.SellerAddr, stream.SpentAmount, string(stream.Status))
+	}
+	if s.realtime != nil {
+		go s.realtime.BroadcastStreamClosed(stream.ID, stream.BuyerAddr, stream.SellerAddr, stream.SpentAmount, string(stream.Status))
+	}
+
+	return stream, nil
+}
+
+// SubmitDisputeEvidence adds evidence to a pending dispute. Both parties
+// may submit evidence until the dispute is resolved.
+func (s *Service) SubmitDisputeEvidence(ctx context.Context, streamID, callerAddr, content string) (*Stream, error) {
+	if len(content) == 0 || len(content) > maxDisputeTextLength {
+		return nil, fmt.Errorf("evidence content must be between 1 and %d characters", maxDisputeTextLength)
+	}
+
+	unlock := s.locks.Lock(streamID)
+	defer unlock()
+
+	stream, err := s.store.Get(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	caller := strings.ToLower(callerAddr)
+	if caller != stream.BuyerAddr && caller != stream.SellerAddr {
+		return nil, ErrUnauthorized
+	}
+	if !stream.DisputePending() {
+		return nil, ErrInvalidStatus
+	}
+
+	now := time.Now()
+	stream.DisputeEvidence = append(stream.DisputeEvidence, DisputeEvidence{
+		SubmittedBy: caller,
+		Content:     content,
+		SubmittedAt: now,
+	})
+	stream.UpdatedAt = now
+
+	if err := s.store.Update(ctx, stream); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// AssignDisputeArbitrator assigns an arbitrator to a pending dispute.
+// Either party may assign; the arbitrator must be a third party.
+func (s *Service) AssignDisputeArbitrator(ctx context.Context, streamID, callerAddr, arbitratorAddr string) (*Stream, error) {
+	unlock := s.locks.Lock(streamID)
+	defer unlock()
+
+	stream, err := s.store.Get(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	caller := strings.ToLower(callerAddr)
+	if caller != stream.BuyerAddr && caller != stream.SellerAddr {
+		return nil, ErrUnauthorized
+	}
+
+	arb := strings.ToLower(arbitratorAddr)
+	if arb == stream.BuyerAddr || arb == stream.SellerAddr {
+		return nil, fmt.Errorf("arbitrator cannot be buyer or seller: %w", ErrUnauthorized)
+	}
+
+	if !stream.DisputePending() || stream.ArbitratorAddr != "" {
+		return nil, ErrInvalidStatus
+	}
+
+	now := time.Now()
+	deadline := now.Add(DefaultArbitrationDeadline)
+	stream.ArbitratorAddr = arb
+	stream.ArbitrationDeadline = &deadline
+	stream.UpdatedAt = now
+
+	if err := s.store.Update(ctx, stream); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// ResolveDispute settles the contested tail. Only the assigned arbitrator
+// may resolve: "release" pays the seller, "refund" returns the tail to the
+// buyer.
+func (s *Service) ResolveDispute(ctx context.Context, streamID, callerAddr, resolution string) (_ *Stream, retErr error) {
+	ctx, span := traces.StartSpan(ctx, "streams.ResolveDispute",
+		attribute.String("stream_id", streamID),
+		attribute.String("resolution", resolution),
+	)
+	defer func() {
+		if retErr != nil {
+			span.RecordError(retErr)
+			span.SetStatus(codes.Error, retErr.Error())
+		}
+		span.End()
+	}()
+
+	unlock := s.locks.Lock(streamID)
+	defer unlock()
+
+	stream, err := s.store.Get(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !stream.DisputePending() {
+		return nil, ErrInvalidStatus
+	}
+	if stream.ArbitratorAddr == "" {
+		return nil, fmt.Errorf("%w: no arbitrator assigned", ErrUnauthorized)
+	}
+	if strings.ToLower(callerAddr) != stream.ArbitratorAddr {
+		return nil, ErrUnauthorized
+	}
+
+	ref := stream.ID + ":dispute"
+	switch resolution {
+	case "release":
+		if err := retry.Do(ctx, 3, 50*time.Millisecond, func() error {
+			return s.ledger.SettleHold(ctx, stream.BuyerAddr, stream.SellerAddr, stream.DisputedAmount, ref)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to release contested amount: %w", err)
+		}
+		stream.Resolution = "released"
+		if s.revenue != nil {
+			if err := s.revenue.AccumulateRevenue(ctx, stream.SellerAddr, stream.DisputedAmount, "stream_arb_release:"+stream.ID); err != nil {
+				logging.L(ctx).Error("stream resolve: failed to accumulate revenue", "stream_id", stream.ID, "error", err)
+			}
+		}
+	case "refund":
+		if err := retry.Do(ctx, 3, 50*time.Millisecond, func() error {
+			return s.ledger.ReleaseHold(ctx, stream.BuyerAddr, stream.DisputedAmount, ref)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to refund contested amount: %w", err)
+		}
+		stream.Resolution = "refunded"
+	default:
+		return nil, fmt.Errorf("invalid resolution: %s (must be release or refund)", resolution)
+	}
+
+	now := time.Now()
+	stream.ResolvedAt = &now
+	stream.UpdatedAt = now
+
+	if err := retry.Do(ctx, 3, 50*time.Millisecond, func() error {
+		return s.store.Update(ctx, stream)
+	}); err != nil {
+		logging.L(ctx).Error("CRITICAL: stream dispute resolved but status update failed",
+			"stream", stream.ID, "resolution", stream.Resolution, "error", err)
+		return nil, fmt.Errorf("failed to update stream after resolution (requires manual resolution): %w", err)
+	}
+
+	return stream, nil
+}
@@ -0,0 +1,204 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func openDisputeStream(t *testing.T, svc *Service, ticks int) *Stream {
+	t.Helper()
+	ctx := context.Background()
+	stream, err := svc.Open(ctx, OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x2222222222222222222222222222222222222222",
+		HoldAmount:   "1.000000",
+		PricePerTick: "0.001000",
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for i := 0; i < ticks; i++ {
+		if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick failed: %v", err)
+		}
+	}
+	return stream
+}
+
+func TestDisputeFreezesOnlyContestedTail(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+	ctx := context.Background()
+
+	stream := openDisputeStream(t, svc, 5) // spent = 0.005
+
+	disputed, err := svc.Dispute(ctx, stream.ID, stream.BuyerAddr, "0.002000", "last two ticks delivered garbage")
+	if err != nil {
+		t.Fatalf("Dispute failed: %v", err)
+	}
+
+	if disputed.Status != StatusDisputed {
+		t.Errorf("expected status disputed, got %s", disputed.Status)
+	}
+	if !disputed.DisputePending() {
+		t.Error("expected a pending dispute")
+	}
+	if disputed.DisputedAmount != "0.002000" {
+		t.Errorf("expected disputed amount 0.002000, got %s", disputed.DisputedAmount)
+	}
+	if len(disputed.DisputeEvidence) != 1 {
+		t.Errorf("expected reason seeded as evidence, got %d entries", len(disputed.DisputeEvidence))
+	}
+
+	// Unused hold released to buyer, undisputed portion settled to seller,
+	// contested tail still frozen.
+	if got := ledger.releases[stream.ID]; got != "0.995000" {
+		t.Errorf("expected unused release 0.995000, got %s", got)
+	}
+	if got := ledger.settlements[stream.ID]; got != "0.003000" {
+		t.Errorf("expected undisputed settle 0.003000, got %s", got)
+	}
+
+	// Disputed stream is terminal for ticks and close
+	if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); !errors.Is(err, ErrAlreadyClosed) {
+		t.Errorf("expected ErrAlreadyClosed on tick, got %v", err)
+	}
+	if _, err := svc.Close(ctx, stream.ID, stream.BuyerAddr, ""); !errors.Is(err, ErrAlreadyClosed) {
+		t.Errorf("expected ErrAlreadyClosed on close, got %v", err)
+	}
+}
+
+func TestDisputeOnlyBuyer(t *testing.T) {
+	svc := NewService(NewMemoryStore(), newMockLedger())
+	stream := openDisputeStream(t, svc, 2)
+
+	_, err := svc.Dispute(context.Background(), stream.ID, stream.SellerAddr, "0.001000", "nope")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestDisputeExceedsSpent(t *testing.T) {
+	svc := NewService(NewMemoryStore(), newMockLedger())
+	stream := openDisputeStream(t, svc, 2) // spent = 0.002
+
+	_, err := svc.Dispute(context.Background(), stream.ID, stream.BuyerAddr, "0.005000", "too much")
+	if !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount, got %v", err)
+	}
+}
+
+func TestDisputeArbitrationRelease(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+	ctx := context.Background()
+
+	stream := openDisputeStream(t, svc, 5)
+	arbitrator := "0x9999999999999999999999999999999999999999"
+
+	if _, err := svc.Dispute(ctx, stream.ID, stream.BuyerAddr, "0.002000", "bad output"); err != nil {
+		t.Fatalf("Dispute failed: %v", err)
+	}
+
+	// Seller responds with evidence
+	updated, err := svc.SubmitDisputeEvidence(ctx, stream.ID, stream.SellerAddr, "delivery logs attached")
+	if err != nil {
+		t.Fatalf("SubmitDisputeEvidence failed: %v", err)
+	}
+	if len(updated.DisputeEvidence) != 2 {
+		t.Errorf("expected 2 evidence entries, got %d", len(updated.DisputeEvidence))
+	}
+
+	// A party cannot be the arbitrator
+	if _, err := svc.AssignDisputeArbitrator(ctx, stream.ID, stream.SellerAddr, stream.BuyerAddr); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for party arbitrator, got %v", err)
+	}
+
+	updated, err = svc.AssignDisputeArbitrator(ctx, stream.ID, stream.SellerAddr, arbitrator)
+	if err != nil {
+		t.Fatalf("AssignDisputeArbitrator failed: %v", err)
+	}
+	if updated.ArbitratorAddr != arbitrator {
+		t.Errorf("expected arbitrator %s, got %s", arbitrator, updated.ArbitratorAddr)
+	}
+	if updated.ArbitrationDeadline == nil {
+		t.Error("expected an arbitration deadline")
+	}
+
+	// Only the arbitrator resolves
+	if _, err := svc.ResolveDispute(ctx, stream.ID, stream.SellerAddr, "release"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for non-arbitrator, got %v", err)
+	}
+
+	resolved, err := svc.ResolveDispute(ctx, stream.ID, arbitrator, "release")
+	if err != nil {
+		t.Fatalf("ResolveDispute failed: %v", err)
+	}
+	if resolved.Resolution != "released" {
+		t.Errorf("expected resolution released, got %s", resolved.Resolution)
+	}
+	if resolved.ResolvedAt == nil {
+		t.Error("expected resolvedAt to be set")
+	}
+	if got := ledger.settlements[stream.ID+":dispute"]; got != "0.002000" {
+		t.Errorf("expected contested settle 0.002000, got %s", got)
+	}
+
+	// Dispute is no longer pending: evidence and re-resolution rejected
+	if _, err := svc.SubmitDisputeEvidence(ctx, stream.ID, stream.BuyerAddr, "more"); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus after resolution, got %v", err)
+	}
+	if _, err := svc.ResolveDispute(ctx, stream.ID, arbitrator, "refund"); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus on double resolution, got %v", err)
+	}
+}
+
+func TestDisputeResolveRefund(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+	ctx := context.Background()
+
+	stream := openDisputeStream(t, svc, 3)
+	arbitrator := "0x9999999999999999999999999999999999999999"
+
+	if _, err := svc.Dispute(ctx, stream.ID, stream.BuyerAddr, "0.003000", "nothing delivered"); err != nil {
+		t.Fatalf("Dispute failed: %v", err)
+	}
+	if _, err := svc.AssignDisputeArbitrator(ctx, stream.ID, stream.BuyerAddr, arbitrator); err != nil {
+		t.Fatalf("AssignDisputeArbitrator failed: %v", err)
+	}
+
+	resolved, err := svc.ResolveDispute(ctx, stream.ID, arbitrator, "refund")
+	if err != nil {
+		t.Fatalf("ResolveDispute failed: %v", err)
+	}
+	if resolved.Resolution != "refunded" {
+		t.Errorf("expected resolution refunded, got %s", resolved.Resolution)
+	}
+	if got := ledger.releases[stream.ID+":dispute"]; got != "0.003000" {
+		t.Errorf("expected contested refund 0.003000, got %s", got)
+	}
+	// Fully contested spend: nothing settled to seller at dispute time
+	if _, ok := ledger.settlements[stream.ID]; ok {
+		t.Error("expected no undisputed settlement when the full spend is contested")
+	}
+}
+
+func TestResolveWithoutArbitrator(t *testing.T) {
+	svc := NewService(NewMemoryStore(), newMockLedger())
+	ctx := context.Background()
+
+	stream := openDisputeStream(t, svc, 2)
+	if _, err := svc.Dispute(ctx, stream.ID, stream.BuyerAddr, "0.001000", "bad"); err != nil {
+		t.Fatalf("Dispute failed: %v", err)
+	}
+
+	_, err := svc.ResolveDispute(ctx, stream.ID, "0x9999999999999999999999999999999999999999", "release")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized without an arbitrator, got %v", err)
+	}
+}
@@ -44,7 +44,10 @@ func (h *Handler) WithScopeChecker(sc ScopeChecker) *Handler {
 func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.GET("/streams/:id", h.GetStream)
 	r.GET("/streams/:id/ticks", h.ListTicks)
+	r.GET("/stream-wallets/:id", h.GetWallet)
+	r.GET("/stream-wallets/:id/streams", h.ListWalletStreams)
 	r.GET("/agents/:address/streams", h.ListStreams)
+	r.GET("/agents/:address/streams/earnings", h.GetStreamEarnings)
 	r.POST("/streams/recommend-hold", h.RecommendHoldAmount)
 }
 
@@ -53,6 +56,13 @@ func (h *Handler) RegisterProtectedRoutes(r *gin.RouterGroup) {
 	r.POST("/streams", h.OpenStream)
 	r.POST("/streams/:id/tick", h.TickStream)
 	r.POST("/streams/:id/close", h.CloseStream)
+	r.GET("/streams/:id/checkpoint", h.GetCheckpoint)
+	r.POST("/streams/:id/dispute", h.DisputeStream)
+	r.POST("/streams/:id/dispute/evidence", h.SubmitDisputeEvidence)
+	r.POST("/streams/:id/dispute/arbitrator", h.AssignDisputeArbitrator)
+	r.POST("/streams/:id/dispute/resolve", h.ResolveDispute)
+	r.POST("/stream-wallets", h.OpenWallet)
+	r.POST("/stream-wallets/:id/close", h.CloseWallet)
 }
 
 // OpenStream handles POST /v1/streams
@@ -111,6 +121,30 @@ func (h *Handler) OpenStream(c *gin.Context) {
 			status = http.StatusBadRequest
 			code = "invalid_amount"
 			msg = err.Error()
+		} else if errors.Is(err, ErrInvalidBilling) {
+			status = http.StatusBadRequest
+			code = "invalid_billing_mode"
+			msg = err.Error()
+		} else if errors.Is(err, ErrInvalidTopUp) {
+			status = http.StatusBadRequest
+			code = "invalid_top_up"
+			msg = err.Error()
+		} else if errors.Is(err, ErrWalletNotFound) {
+			status = http.StatusNotFound
+			code = "wallet_not_found"
+			msg = err.Error()
+		} else if errors.Is(err, ErrWalletExhausted) {
+			status = http.StatusConflict
+			code = "wallet_exhausted"
+			msg = err.Error()
+		} else if errors.Is(err, ErrUnauthorized) {
+			status = http.StatusForbidden
+			code = "unauthorized"
+			msg = err.Error()
+		} else if errors.Is(err, ErrInvalidStatus) {
+			status = http.StatusConflict
+			code = "invalid_state"
+			msg = err.Error()
 		}
 		c.JSON(status, gin.H{"error": code, "message": msg})
 		return
@@ -170,6 +204,34 @@ func (h *Handler) ListStreams(c *gin.Context) {
 	})
 }
 
+// GetStreamEarnings handles GET /v1/agents/:address/streams/earnings
+// Summarizes the agent's revenue as a stream seller: settled totals by
+// counterparty, day, and service, plus open-stream exposure.
+func (h *Handler) GetStreamEarnings(c *gin.Context) {
+	address := c.Param("address")
+
+	days := 30
+	if d := c.Query("days"); d != "" {
+		if parsed, err := strconv.Atoi(d); err == nil && parsed > 0 {
+			days = parsed
+			if days > 365 {
+				days = 365
+			}
+		}
+	}
+
+	summary, err := h.service.Earnings(c.Request.Context(), address, days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to summarize earnings",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"earnings": summary})
+}
+
 // TickStream handles POST /v1/streams/:id/tick
 func (h *Handler) TickStream(c *gin.Context) {
 	id := c.Param("id")
@@ -219,10 +281,20 @@ func (h *Handler) TickStream(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	resp := gin.H{
 		"tick":   tick,
 		"stream": updatedStream,
-	})
+	}
+
+	// Attach a signed checkpoint periodically so either party can archive
+	// proof of consumption off-platform.
+	if tick.Seq%checkpointEvery == 0 {
+		if cp, err := h.service.SignedCheckpoint(updatedStream); err == nil {
+			resp["checkpoint"] = cp
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
 // CloseStream handles POST /v1/streams/:id/close
@@ -233,7 +305,7 @@ func (h *Handler) CloseStream(c *gin.Context) {
 	var req CloseRequest
 	_ = c.ShouldBindJSON(&req)
 
-	stream, err := h.service.Close(c.Request.Context(), id, callerAddr, req.Reason)
+	stream, err := h.service.CloseWithCheckpoint(c.Request.Context(), id, callerAddr, req.Reason, req.Checkpoint)
 	if err != nil {
 		status := http.StatusInternalServerError
 		code := "close_failed"
@@ -247,6 +319,12 @@ func (h *Handler) CloseStream(c *gin.Context) {
 		case errors.Is(err, ErrAlreadyClosed):
 			status = http.StatusConflict
 			code = "already_closed"
+		case errors.Is(err, ErrInvalidCheckpoint):
+			status = http.StatusBadRequest
+			code = "invalid_checkpoint"
+		case errors.Is(err, ErrCheckpointsDisabled):
+			status = http.StatusServiceUnavailable
+			code = "checkpoints_unavailable"
 		}
 		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to close stream")})
 		return
@@ -255,6 +333,149 @@ func (h *Handler) CloseStream(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"stream": stream})
 }
 
+// disputeError maps dispute service errors to HTTP responses.
+func disputeError(c *gin.Context, err error, fallback string) {
+	status := http.StatusInternalServerError
+	code := "dispute_failed"
+	switch {
+	case errors.Is(err, ErrStreamNotFound):
+		status = http.StatusNotFound
+		code = "not_found"
+	case errors.Is(err, ErrUnauthorized):
+		status = http.StatusForbidden
+		code = "unauthorized"
+	case errors.Is(err, ErrAlreadyClosed):
+		status = http.StatusConflict
+		code = "already_closed"
+	case errors.Is(err, ErrInvalidStatus):
+		status = http.StatusConflict
+		code = "invalid_state"
+	case errors.Is(err, ErrInvalidAmount):
+		status = http.StatusBadRequest
+		code = "invalid_amount"
+	}
+	c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, fallback)})
+}
+
+// DisputeStream handles POST /v1/streams/:id/dispute
+func (h *Handler) DisputeStream(c *gin.Context) {
+	id := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	var req DisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "contestedAmount and reason are required",
+		})
+		return
+	}
+
+	stream, err := h.service.Dispute(c.Request.Context(), id, callerAddr, req.ContestedAmount, req.Reason)
+	if err != nil {
+		disputeError(c, err, "Failed to dispute stream")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"stream": stream})
+}
+
+// SubmitDisputeEvidence handles POST /v1/streams/:id/dispute/evidence
+func (h *Handler) SubmitDisputeEvidence(c *gin.Context) {
+	id := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	var req EvidenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "content is required",
+		})
+		return
+	}
+
+	stream, err := h.service.SubmitDisputeEvidence(c.Request.Context(), id, callerAddr, req.Content)
+	if err != nil {
+		disputeError(c, err, "Failed to submit evidence")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"stream": stream})
+}
+
+// AssignDisputeArbitrator handles POST /v1/streams/:id/dispute/arbitrator
+func (h *Handler) AssignDisputeArbitrator(c *gin.Context) {
+	id := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	var req ArbitratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "arbitratorAddr is required",
+		})
+		return
+	}
+
+	stream, err := h.service.AssignDisputeArbitrator(c.Request.Context(), id, callerAddr, req.ArbitratorAddr)
+	if err != nil {
+		disputeError(c, err, "Failed to assign arbitrator")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"stream": stream})
+}
+
+// ResolveDispute handles POST /v1/streams/:id/dispute/resolve
+func (h *Handler) ResolveDispute(c *gin.Context) {
+	id := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	var req ResolveDisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "resolution is required",
+		})
+		return
+	}
+
+	stream, err := h.service.ResolveDispute(c.Request.Context(), id, callerAddr, req.Resolution)
+	if err != nil {
+		disputeError(c, err, "Failed to resolve dispute")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"stream": stream})
+}
+
+// GetCheckpoint handles GET /v1/streams/:id/checkpoint
+func (h *Handler) GetCheckpoint(c *gin.Context) {
+	id := c.Param("id")
+	callerAddr := c.GetString("authAgentAddr")
+
+	cp, err := h.service.IssueCheckpoint(c.Request.Context(), id, callerAddr)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "checkpoint_failed"
+		switch {
+		case errors.Is(err, ErrStreamNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+		case errors.Is(err, ErrCheckpointsDisabled):
+			status = http.StatusServiceUnavailable
+			code = "checkpoints_unavailable"
+		}
+		c.JSON(status, gin.H{"error": code, "message": safeMessage(status, err, "Failed to issue checkpoint")})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"checkpoint": cp})
+}
+
 // ListTicks handles GET /v1/streams/:id/ticks
 func (h *Handler) ListTicks(c *gin.Context) {
 	id := c.Param("id")
@@ -321,3 +542,135 @@ func (h *Handler) RecommendHoldAmount(c *gin.Context) {
 		"expectedTicks":   req.TickRate * req.DurationSec,
 	})
 }
+
+// OpenWallet handles POST /v1/stream-wallets
+func (h *Handler) OpenWallet(c *gin.Context) {
+	var req WalletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	if errs := validation.Validate(
+		validation.ValidAddress("buyer_addr", req.BuyerAddr),
+		validation.ValidAmount("hold_amount", req.HoldAmount),
+	); len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": errs.Error(),
+			"details": errs,
+		})
+		return
+	}
+
+	// Verify the authenticated agent is the buyer
+	callerAddr := c.GetString("authAgentAddr")
+	if !strings.EqualFold(callerAddr, req.BuyerAddr) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "unauthorized",
+			"message": "Authenticated agent must be the buyer",
+		})
+		return
+	}
+
+	wallet, err := h.service.OpenWallet(c.Request.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "wallet_failed"
+		msg := "Failed to open wallet"
+		if errors.Is(err, ErrInvalidAmount) {
+			status = http.StatusBadRequest
+			code = "invalid_amount"
+			msg = err.Error()
+		}
+		c.JSON(status, gin.H{"error": code, "message": msg})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"wallet": wallet})
+}
+
+// GetWallet handles GET /v1/stream-wallets/:id
+func (h *Handler) GetWallet(c *gin.Context) {
+	wallet, err := h.service.GetWallet(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrWalletNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "not_found",
+				"message": "Wallet not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to retrieve wallet",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"wallet": wallet})
+}
+
+// ListWalletStreams handles GET /v1/stream-wallets/:id/streams
+func (h *Handler) ListWalletStreams(c *gin.Context) {
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+			if limit > 200 {
+				limit = 200
+			}
+		}
+	}
+
+	streams, err := h.service.ListWalletStreams(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "internal_error",
+			"message": "Failed to list wallet streams",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"streams": streams,
+		"count":   len(streams),
+	})
+}
+
+// CloseWallet handles POST /v1/stream-wallets/:id/close
+func (h *Handler) CloseWallet(c *gin.Context) {
+	callerAddr := c.GetString("authAgentAddr")
+
+	wallet, err := h.service.CloseWallet(c.Request.Context(), c.Param("id"), callerAddr)
+	if err != nil {
+		status := http.StatusInternalServerError
+		code := "close_failed"
+		msg := "Failed to close wallet"
+		switch {
+		case errors.Is(err, ErrWalletNotFound):
+			status = http.StatusNotFound
+			code = "not_found"
+			msg = "Wallet not found"
+		case errors.Is(err, ErrUnauthorized):
+			status = http.StatusForbidden
+			code = "unauthorized"
+			msg = err.Error()
+		case errors.Is(err, ErrAlreadyClosed):
+			status = http.StatusConflict
+			code = "already_closed"
+			msg = err.Error()
+		case errors.Is(err, ErrWalletHasOpen):
+			status = http.StatusConflict
+			code = "wallet_has_open_streams"
+			msg = err.Error()
+		}
+		c.JSON(status, gin.H{"error": code, "message": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"wallet": wallet})
+}
@@ -12,6 +12,7 @@ import (
 type MemoryStore struct {
 	streams map[string]*Stream
 	ticks   map[string][]*Tick // streamID → ticks
+	wallets map[string]*Wallet
 	mu      sync.RWMutex
 }
 
@@ -20,6 +21,7 @@ func NewMemoryStore() *MemoryStore {
 	return &MemoryStore{
 		streams: make(map[string]*Stream),
 		ticks:   make(map[string][]*Tick),
+		wallets: make(map[string]*Wallet),
 	}
 }
 
@@ -41,6 +43,7 @@ func (m *MemoryStore) Get(_ context.Context, id string) (*Stream, error) {
 	}
 	// Return a copy to prevent races on the shared pointer
 	cp := *stream
+	cp.DisputeEvidence = append([]DisputeEvidence(nil), stream.DisputeEvidence...)
 	return &cp, nil
 }
 
@@ -54,6 +57,7 @@ func (m *MemoryStore) Update(_ context.Context, stream *Stream) error {
 	// Store a deep copy to prevent the caller's retained pointer from
 	// mutating the stored state outside the lock.
 	cp := *stream
+	cp.DisputeEvidence = append([]DisputeEvidence(nil), stream.DisputeEvidence...)
 	m.streams[stream.ID] = &cp
 	return nil
 }
@@ -82,6 +86,53 @@ func (m *MemoryStore) ListByAgent(_ context.Context, agentAddr string, limit int
 	return result, nil
 }
 
+func (m *MemoryStore) ListBySeller(_ context.Context, sellerAddr string, limit int) ([]*Stream, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	addr := strings.ToLower(sellerAddr)
+	var result []*Stream
+	for _, s := range m.streams {
+		if s.SellerAddr == addr {
+			cp := *s
+			result = append(result, &cp)
+		}
+	}
+
+	// Sort by created_at descending
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) ListByWallet(_ context.Context, walletID string, limit int) ([]*Stream, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*Stream
+	for _, s := range m.streams {
+		if s.WalletID == walletID {
+			cp := *s
+			result = append(result, &cp)
+		}
+	}
+
+	// Sort by created_at descending
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
 func (m *MemoryStore) ListByStatus(_ context.Context, status Status, limit int) ([]*Stream, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -172,5 +223,38 @@ func (m *MemoryStore) GetLastTick(_ context.Context, streamID string) (*Tick, er
 	return &cp, nil
 }
 
+func (m *MemoryStore) CreateWallet(_ context.Context, wallet *Wallet) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cp := *wallet
+	m.wallets[wallet.ID] = &cp
+	return nil
+}
+
+func (m *MemoryStore) GetWallet(_ context.Context, id string) (*Wallet, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	wallet, ok := m.wallets[id]
+	if !ok {
+		return nil, ErrWalletNotFound
+	}
+	cp := *wallet
+	return &cp, nil
+}
+
+func (m *MemoryStore) UpdateWallet(_ context.Context, wallet *Wallet) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.wallets[wallet.ID]; !ok {
+		return ErrWalletNotFound
+	}
+	cp := *wallet
+	m.wallets[wallet.ID] = &cp
+	return nil
+}
+
 // Compile-time assertion that MemoryStore implements Store.
 var _ Store = (*MemoryStore)(nil)
@@ -32,6 +32,13 @@ var (
 		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
 	})
 
+	streamTopUps = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "alancoin",
+		Subsystem: "streams",
+		Name:      "top_ups_total",
+		Help:      "Total auto-top-up attempts by result.",
+	}, []string{"result"}) // "ok", "declined"
+
 	streamSettlementAmount = prometheus.NewHistogram(prometheus.HistogramOpts{
 		Namespace: "alancoin",
 		Subsystem: "streams",
@@ -46,6 +53,7 @@ func init() {
 		streamsOpened,
 		streamsClosed,
 		streamTicksTotal,
+		streamTopUps,
 		streamDuration,
 		streamSettlementAmount,
 	)
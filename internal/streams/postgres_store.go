@@ -3,6 +3,7 @@ package streams
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"strings"
 	"time"
 )
@@ -18,21 +19,34 @@ func NewPostgresStore(db *sql.DB) *PostgresStore {
 }
 
 func (p *PostgresStore) Create(ctx context.Context, s *Stream) error {
-	_, err := p.db.ExecContext(ctx, `
+	evidence, err := marshalEvidence(s.DisputeEvidence)
+	if err != nil {
+		return err
+	}
+	_, err = p.db.ExecContext(ctx, `
 		INSERT INTO streams (
-			id, buyer_addr, seller_addr, service_id, session_key_id,
-			hold_amount, spent_amount, price_per_tick, tick_count,
+			id, buyer_addr, seller_addr, service_id, session_key_id, wallet_id,
+			hold_amount, spent_amount, price_per_tick, billing_mode, tick_count,
 			status, stale_timeout_secs, last_tick_at, closed_at, close_reason,
+			top_up_amount, top_up_threshold, top_up_cap, topped_up_amount, top_up_count,
+			disputed_amount, dispute_reason, dispute_evidence, arbitrator_addr,
+			arbitration_deadline, resolution, resolved_at,
 			created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5,
-			$6::NUMERIC(20,6), $7::NUMERIC(20,6), $8::NUMERIC(20,6), $9,
-			$10, $11, $12, $13, $14,
-			$15, $16
+			$1, $2, $3, $4, $5, $6,
+			$7::NUMERIC(20,6), $8::NUMERIC(20,6), $9::NUMERIC(20,6), $10, $11,
+			$12, $13, $14, $15, $16,
+			$17, $18, $19, $20, $21,
+			$22, $23, $24, $25,
+			$26, $27, $28,
+			$29, $30
 		)`,
-		s.ID, s.BuyerAddr, s.SellerAddr, nullString(s.ServiceID), nullString(s.SessionKeyID),
-		s.HoldAmount, s.SpentAmount, s.PricePerTick, s.TickCount,
+		s.ID, s.BuyerAddr, s.SellerAddr, nullString(s.ServiceID), nullString(s.SessionKeyID), nullString(s.WalletID),
+		s.HoldAmount, s.SpentAmount, s.PricePerTick, string(billingOrDefault(s.BillingMode)), s.TickCount,
 		string(s.Status), s.StaleTimeoutSec, nullTime(s.LastTickAt), nullTime(s.ClosedAt), nullString(s.CloseReason),
+		nullString(s.TopUpAmount), nullString(s.TopUpThreshold), nullString(s.TopUpCap), nullString(s.ToppedUpAmount), s.TopUpCount,
+		nullString(s.DisputedAmount), nullString(s.DisputeReason), evidence, nullString(s.ArbitratorAddr),
+		nullTime(s.ArbitrationDeadline), nullString(s.Resolution), nullTime(s.ResolvedAt),
 		s.CreatedAt, s.UpdatedAt,
 	)
 	return err
@@ -40,9 +54,12 @@ func (p *PostgresStore) Create(ctx context.Context, s *Stream) error {
 
 func (p *PostgresStore) Get(ctx context.Context, id string) (*Stream, error) {
 	row := p.db.QueryRowContext(ctx, `
-		SELECT id, buyer_addr, seller_addr, service_id, session_key_id,
-		       hold_amount, spent_amount, price_per_tick, tick_count,
+		SELECT id, buyer_addr, seller_addr, service_id, session_key_id, wallet_id,
+		       hold_amount, spent_amount, price_per_tick, billing_mode, tick_count,
 		       status, stale_timeout_secs, last_tick_at, closed_at, close_reason,
+		       top_up_amount, top_up_threshold, top_up_cap, topped_up_amount, top_up_count,
+		       disputed_amount, dispute_reason, dispute_evidence, arbitrator_addr,
+		       arbitration_deadline, resolution, resolved_at,
 		       created_at, updated_at
 		FROM streams WHERE id = $1`, id)
 
@@ -54,15 +71,29 @@ func (p *PostgresStore) Get(ctx context.Context, id string) (*Stream, error) {
 }
 
 func (p *PostgresStore) Update(ctx context.Context, s *Stream) error {
+	evidence, err := marshalEvidence(s.DisputeEvidence)
+	if err != nil {
+		return err
+	}
 	result, err := p.db.ExecContext(ctx, `
 		UPDATE streams SET
-			spent_amount = $1::NUMERIC(20,6), tick_count = $2,
-			status = $3, last_tick_at = $4, closed_at = $5,
-			close_reason = $6, updated_at = $7
-		WHERE id = $8`,
-		s.SpentAmount, s.TickCount,
+			hold_amount = $1::NUMERIC(20,6), spent_amount = $2::NUMERIC(20,6), tick_count = $3,
+			status = $4, last_tick_at = $5, closed_at = $6,
+			close_reason = $7,
+			topped_up_amount = $8, top_up_count = $9,
+			disputed_amount = $10, dispute_reason = $11, dispute_evidence = $12,
+			arbitrator_addr = $13, arbitration_deadline = $14,
+			resolution = $15, resolved_at = $16,
+			updated_at = $17
+		WHERE id = $18`,
+		s.HoldAmount, s.SpentAmount, s.TickCount,
 		string(s.Status), nullTime(s.LastTickAt), nullTime(s.ClosedAt),
-		nullString(s.CloseReason), s.UpdatedAt,
+		nullString(s.CloseReason),
+		nullString(s.ToppedUpAmount), s.TopUpCount,
+		nullString(s.DisputedAmount), nullString(s.DisputeReason), evidence,
+		nullString(s.ArbitratorAddr), nullTime(s.ArbitrationDeadline),
+		nullString(s.Resolution), nullTime(s.ResolvedAt),
+		s.UpdatedAt,
 		s.ID,
 	)
 	if err != nil {
@@ -80,9 +111,12 @@ func (p *PostgresStore) Update(ctx context.Context, s *Stream) error {
 
 func (p *PostgresStore) ListByAgent(ctx context.Context, agentAddr string, limit int) ([]*Stream, error) {
 	rows, err := p.db.QueryContext(ctx, `
-		SELECT id, buyer_addr, seller_addr, service_id, session_key_id,
-		       hold_amount, spent_amount, price_per_tick, tick_count,
+		SELECT id, buyer_addr, seller_addr, service_id, session_key_id, wallet_id,
+		       hold_amount, spent_amount, price_per_tick, billing_mode, tick_count,
 		       status, stale_timeout_secs, last_tick_at, closed_at, close_reason,
+		       top_up_amount, top_up_threshold, top_up_cap, topped_up_amount, top_up_count,
+		       disputed_amount, dispute_reason, dispute_evidence, arbitrator_addr,
+		       arbitration_deadline, resolution, resolved_at,
 		       created_at, updated_at
 		FROM streams
 		WHERE buyer_addr = $1 OR seller_addr = $1
@@ -96,11 +130,56 @@ func (p *PostgresStore) ListByAgent(ctx context.Context, agentAddr string, limit
 	return scanStreams(rows)
 }
 
+func (p *PostgresStore) ListBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Stream, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, buyer_addr, seller_addr, service_id, session_key_id, wallet_id,
+		       hold_amount, spent_amount, price_per_tick, billing_mode, tick_count,
+		       status, stale_timeout_secs, last_tick_at, closed_at, close_reason,
+		       top_up_amount, top_up_threshold, top_up_cap, topped_up_amount, top_up_count,
+		       disputed_amount, dispute_reason, dispute_evidence, arbitrator_addr,
+		       arbitration_deadline, resolution, resolved_at,
+		       created_at, updated_at
+		FROM streams
+		WHERE seller_addr = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, sellerAddr, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanStreams(rows)
+}
+
+func (p *PostgresStore) ListByWallet(ctx context.Context, walletID string, limit int) ([]*Stream, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, buyer_addr, seller_addr, service_id, session_key_id, wallet_id,
+		       hold_amount, spent_amount, price_per_tick, billing_mode, tick_count,
+		       status, stale_timeout_secs, last_tick_at, closed_at, close_reason,
+		       top_up_amount, top_up_threshold, top_up_cap, topped_up_amount, top_up_count,
+		       disputed_amount, dispute_reason, dispute_evidence, arbitrator_addr,
+		       arbitration_deadline, resolution, resolved_at,
+		       created_at, updated_at
+		FROM streams
+		WHERE wallet_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`, walletID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanStreams(rows)
+}
+
 func (p *PostgresStore) ListByStatus(ctx context.Context, status Status, limit int) ([]*Stream, error) {
 	rows, err := p.db.QueryContext(ctx, `
-		SELECT id, buyer_addr, seller_addr, service_id, session_key_id,
-		       hold_amount, spent_amount, price_per_tick, tick_count,
+		SELECT id, buyer_addr, seller_addr, service_id, session_key_id, wallet_id,
+		       hold_amount, spent_amount, price_per_tick, billing_mode, tick_count,
 		       status, stale_timeout_secs, last_tick_at, closed_at, close_reason,
+		       top_up_amount, top_up_threshold, top_up_cap, topped_up_amount, top_up_count,
+		       disputed_amount, dispute_reason, dispute_evidence, arbitrator_addr,
+		       arbitration_deadline, resolution, resolved_at,
 		       created_at, updated_at
 		FROM streams
 		WHERE status = $1
@@ -116,9 +195,12 @@ func (p *PostgresStore) ListByStatus(ctx context.Context, status Status, limit i
 
 func (p *PostgresStore) ListStale(ctx context.Context, before time.Time, limit int) ([]*Stream, error) {
 	rows, err := p.db.QueryContext(ctx, `
-		SELECT id, buyer_addr, seller_addr, service_id, session_key_id,
-		       hold_amount, spent_amount, price_per_tick, tick_count,
+		SELECT id, buyer_addr, seller_addr, service_id, session_key_id, wallet_id,
+		       hold_amount, spent_amount, price_per_tick, billing_mode, tick_count,
 		       status, stale_timeout_secs, last_tick_at, closed_at, close_reason,
+		       top_up_amount, top_up_threshold, top_up_cap, topped_up_amount, top_up_count,
+		       disputed_amount, dispute_reason, dispute_evidence, arbitrator_addr,
+		       arbitration_deadline, resolution, resolved_at,
 		       created_at, updated_at
 		FROM streams
 		WHERE status = 'open'
@@ -178,6 +260,70 @@ func (p *PostgresStore) GetLastTick(ctx context.Context, streamID string) (*Tick
 	return t, err
 }
 
+func (p *PostgresStore) CreateWallet(ctx context.Context, w *Wallet) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO stream_wallets (
+			id, buyer_addr, hold_amount, allocated_amount, spent_amount,
+			stream_count, status, closed_at, created_at, updated_at
+		) VALUES (
+			$1, $2, $3::NUMERIC(20,6), $4::NUMERIC(20,6), $5::NUMERIC(20,6),
+			$6, $7, $8, $9, $10
+		)`,
+		w.ID, w.BuyerAddr, w.HoldAmount, w.AllocatedAmount, w.SpentAmount,
+		w.StreamCount, string(w.Status), nullTime(w.ClosedAt), w.CreatedAt, w.UpdatedAt,
+	)
+	return err
+}
+
+func (p *PostgresStore) GetWallet(ctx context.Context, id string) (*Wallet, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id, buyer_addr, hold_amount, allocated_amount, spent_amount,
+		       stream_count, status, closed_at, created_at, updated_at
+		FROM stream_wallets WHERE id = $1`, id)
+
+	w := &Wallet{}
+	var status string
+	var closedAt sql.NullTime
+	err := row.Scan(
+		&w.ID, &w.BuyerAddr, &w.HoldAmount, &w.AllocatedAmount, &w.SpentAmount,
+		&w.StreamCount, &status, &closedAt, &w.CreatedAt, &w.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrWalletNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	w.Status = WalletStatus(status)
+	if closedAt.Valid {
+		w.ClosedAt = &closedAt.Time
+	}
+	return w, nil
+}
+
+func (p *PostgresStore) UpdateWallet(ctx context.Context, w *Wallet) error {
+	result, err := p.db.ExecContext(ctx, `
+		UPDATE stream_wallets SET
+			allocated_amount = $1::NUMERIC(20,6), spent_amount = $2::NUMERIC(20,6),
+			stream_count = $3, status = $4, closed_at = $5, updated_at = $6
+		WHERE id = $7`,
+		w.AllocatedAmount, w.SpentAmount,
+		w.StreamCount, string(w.Status), nullTime(w.ClosedAt), w.UpdatedAt,
+		w.ID,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrWalletNotFound
+	}
+	return nil
+}
+
 // --- scanners ---
 
 // scanner is satisfied by both *sql.Row and *sql.Rows.
@@ -190,16 +336,34 @@ func scanStream(sc scanner) (*Stream, error) {
 	var (
 		serviceID    sql.NullString
 		sessionKeyID sql.NullString
+		walletID     sql.NullString
 		lastTickAt   sql.NullTime
 		closedAt     sql.NullTime
 		closeReason  sql.NullString
 		status       string
+		billingMode  string
+
+		topUpAmount    sql.NullString
+		topUpThreshold sql.NullString
+		topUpCap       sql.NullString
+		toppedUpAmount sql.NullString
+
+		disputedAmount      sql.NullString
+		disputeReason       sql.NullString
+		disputeEvidence     []byte
+		arbitratorAddr      sql.NullString
+		arbitrationDeadline sql.NullTime
+		resolution          sql.NullString
+		resolvedAt          sql.NullTime
 	)
 
 	err := sc.Scan(
-		&s.ID, &s.BuyerAddr, &s.SellerAddr, &serviceID, &sessionKeyID,
-		&s.HoldAmount, &s.SpentAmount, &s.PricePerTick, &s.TickCount,
+		&s.ID, &s.BuyerAddr, &s.SellerAddr, &serviceID, &sessionKeyID, &walletID,
+		&s.HoldAmount, &s.SpentAmount, &s.PricePerTick, &billingMode, &s.TickCount,
 		&status, &s.StaleTimeoutSec, &lastTickAt, &closedAt, &closeReason,
+		&topUpAmount, &topUpThreshold, &topUpCap, &toppedUpAmount, &s.TopUpCount,
+		&disputedAmount, &disputeReason, &disputeEvidence, &arbitratorAddr,
+		&arbitrationDeadline, &resolution, &resolvedAt,
 		&s.CreatedAt, &s.UpdatedAt,
 	)
 	if err != nil {
@@ -207,15 +371,36 @@ func scanStream(sc scanner) (*Stream, error) {
 	}
 
 	s.Status = Status(status)
+	s.BillingMode = BillingMode(billingMode)
 	s.ServiceID = serviceID.String
 	s.SessionKeyID = sessionKeyID.String
+	s.WalletID = walletID.String
 	s.CloseReason = closeReason.String
+	s.TopUpAmount = topUpAmount.String
+	s.TopUpThreshold = topUpThreshold.String
+	s.TopUpCap = topUpCap.String
+	s.ToppedUpAmount = toppedUpAmount.String
+	s.DisputedAmount = disputedAmount.String
+	s.DisputeReason = disputeReason.String
+	s.ArbitratorAddr = arbitratorAddr.String
+	s.Resolution = resolution.String
 	if lastTickAt.Valid {
 		s.LastTickAt = &lastTickAt.Time
 	}
 	if closedAt.Valid {
 		s.ClosedAt = &closedAt.Time
 	}
+	if arbitrationDeadline.Valid {
+		s.ArbitrationDeadline = &arbitrationDeadline.Time
+	}
+	if resolvedAt.Valid {
+		s.ResolvedAt = &resolvedAt.Time
+	}
+	if len(disputeEvidence) > 0 {
+		if err := json.Unmarshal(disputeEvidence, &s.DisputeEvidence); err != nil {
+			return nil, err
+		}
+	}
 
 	return s, nil
 }
@@ -259,6 +444,23 @@ func scanTicks(rows *sql.Rows) ([]*Tick, error) {
 	return result, rows.Err()
 }
 
+// marshalEvidence serializes dispute evidence for JSONB storage, keeping
+// nil slices as empty arrays.
+func marshalEvidence(ev []DisputeEvidence) ([]byte, error) {
+	if len(ev) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(ev)
+}
+
+// billingOrDefault normalizes an unset billing mode to per_tick for storage.
+func billingOrDefault(m BillingMode) BillingMode {
+	if m == "" {
+		return BillingPerTick
+	}
+	return m
+}
+
 // nullString converts an empty Go string to sql.NullString.
 func nullString(s string) sql.NullString {
 	if s == "" {
@@ -24,6 +24,8 @@ import (
 type WebhookEmitter interface {
 	EmitStreamOpened(sellerAddr, streamID, buyerAddr, holdAmount string)
 	EmitStreamClosed(buyerAddr, streamID, sellerAddr, spentAmount, status string)
+	EmitStreamTopUp(buyerAddr, streamID, sellerAddr, amount, newHoldAmount string)
+	EmitStreamTopUpDeclined(buyerAddr, streamID, sellerAddr, amount, reason string)
 }
 
 // RealtimeBroadcaster pushes stream lifecycle events to WebSocket clients.
@@ -34,15 +36,17 @@ type RealtimeBroadcaster interface {
 
 // Service implements streaming micropayment business logic.
 type Service struct {
-	store          Store
-	ledger         LedgerService
-	recorder       TransactionRecorder
-	revenue        RevenueAccumulator
-	receiptIssuer  ReceiptIssuer
-	webhookEmitter WebhookEmitter
-	realtime       RealtimeBroadcaster
-	bus            eventbus.Bus // event bus for settlement events (optional)
-	locks          syncutil.ShardedMutex
+	store            Store
+	ledger           LedgerService
+	recorder         TransactionRecorder
+	revenue          RevenueAccumulator
+	receiptIssuer    ReceiptIssuer
+	webhookEmitter   WebhookEmitter
+	realtime         RealtimeBroadcaster
+	checkpointSigner CheckpointSigner
+	bus              eventbus.Bus // event bus for settlement events (optional)
+	locks            syncutil.ShardedMutex
+	walletLocks      syncutil.ShardedMutex // separate pool: wallet locks nest inside stream locks
 }
 
 // NewService creates a new streaming micropayment service.
@@ -118,6 +122,22 @@ func (s *Service) Open(ctx context.Context, req OpenRequest) (_ *Stream, retErr
 		return nil, fmt.Errorf("%w: pricePerTick must be positive", ErrInvalidAmount)
 	}
 
+	billing := BillingMode(req.BillingMode)
+	switch billing {
+	case "", BillingPerTick:
+		billing = BillingPerTick
+	case BillingPerSecond, BillingPerMinute:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBilling, req.BillingMode)
+	}
+
+	if err := validateTopUp(req, holdBig); err != nil {
+		return nil, err
+	}
+	if req.WalletID != "" && req.TopUpAmount != "" {
+		return nil, fmt.Errorf("%w: auto-top-up is not supported on wallet-funded streams", ErrInvalidTopUp)
+	}
+
 	staleTimeout := req.StaleTimeoutSec
 	if staleTimeout <= 0 {
 		staleTimeout = int(DefaultStaleTimeout.Seconds())
@@ -130,24 +150,41 @@ func (s *Service) Open(ctx context.Context, req OpenRequest) (_ *Stream, retErr
 		SellerAddr:      strings.ToLower(req.SellerAddr),
 		ServiceID:       req.ServiceID,
 		SessionKeyID:    req.SessionKeyID,
+		WalletID:        req.WalletID,
 		HoldAmount:      req.HoldAmount,
 		SpentAmount:     "0.000000",
 		PricePerTick:    req.PricePerTick,
+		BillingMode:     billing,
 		TickCount:       0,
 		Status:          StatusOpen,
 		StaleTimeoutSec: staleTimeout,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
+	if req.TopUpAmount != "" {
+		stream.TopUpAmount = req.TopUpAmount
+		stream.TopUpThreshold = req.TopUpThreshold
+		stream.TopUpCap = req.TopUpCap
+		stream.ToppedUpAmount = "0.000000"
+	}
 
-	// Hold buyer funds
-	if err := s.ledger.Hold(ctx, stream.BuyerAddr, stream.HoldAmount, stream.ID); err != nil {
+	// Fund the stream: wallet-backed streams reserve their cap from the
+	// wallet's hold; standalone streams place their own ledger hold.
+	if stream.WalletID != "" {
+		if err := s.allocateFromWallet(ctx, stream.WalletID, stream.BuyerAddr, holdBig); err != nil {
+			return nil, err
+		}
+	} else if err := s.ledger.Hold(ctx, stream.BuyerAddr, stream.HoldAmount, stream.ID); err != nil {
 		return nil, fmt.Errorf("failed to hold stream funds: %w", err)
 	}
 
 	if err := s.store.Create(ctx, stream); err != nil {
 		// Best-effort release if store fails
-		_ = s.ledger.ReleaseHold(ctx, stream.BuyerAddr, stream.HoldAmount, stream.ID)
+		if stream.WalletID != "" {
+			s.releaseToWallet(ctx, stream)
+		} else {
+			_ = s.ledger.ReleaseHold(ctx, stream.BuyerAddr, stream.HoldAmount, stream.ID)
+		}
 		return nil, fmt.Errorf("failed to create stream record: %w", err)
 	}
 
@@ -192,15 +229,31 @@ func (s *Service) RecordTick(ctx context.Context, streamID string, req TickReque
 		return nil, nil, ErrInvalidStatus
 	}
 
-	// Determine tick amount: use request amount or pricePerTick
-	tickAmount := req.Amount
-	if tickAmount == "" {
-		tickAmount = stream.PricePerTick
-	}
+	now := time.Now()
 
-	tickBig, ok := usdc.Parse(tickAmount)
-	if !ok || tickBig.Sign() <= 0 {
-		return nil, nil, ErrInvalidAmount
+	// Determine tick amount. Time-billed streams compute the charge from
+	// elapsed wall time since the last tick; per-tick streams use the
+	// request amount or pricePerTick.
+	var tickBig *big.Int
+	var tickAmount string
+	if stream.TimeBilled() {
+		if req.Amount != "" {
+			return nil, nil, fmt.Errorf("%w: amount is computed from elapsed time on time-billed streams", ErrInvalidAmount)
+		}
+		// Zero is fine here: a heartbeat arriving within the same billing
+		// unit still refreshes LastTickAt and keeps the stream alive.
+		tickBig = elapsedCharge(stream, now)
+		tickAmount = usdc.Format(tickBig)
+	} else {
+		tickAmount = req.Amount
+		if tickAmount == "" {
+			tickAmount = stream.PricePerTick
+		}
+		var ok bool
+		tickBig, ok = usdc.Parse(tickAmount)
+		if !ok || tickBig.Sign() <= 0 {
+			return nil, nil, ErrInvalidAmount
+		}
 	}
 
 	// Check that tick won't exceed hold
@@ -208,6 +261,12 @@ func (s *Service) RecordTick(ctx context.Context, streamID string, req TickReque
 	holdBig, _ := usdc.Parse(stream.HoldAmount)
 	newSpent := new(big.Int).Add(spentBig, tickBig)
 
+	// Auto-top-up: grow the hold before the exhaustion check so a tick that
+	// would drain the balance can still land.
+	if stream.AutoTopUp() {
+		holdBig = s.maybeTopUp(ctx, stream, newSpent, holdBig)
+	}
+
 	if newSpent.Cmp(holdBig) > 0 {
 		return nil, nil, ErrHoldExhausted
 	}
@@ -224,7 +283,6 @@ func (s *Service) RecordTick(ctx context.Context, streamID string, req TickReque
 		}
 	}
 
-	now := time.Now()
 	tick := &Tick{
 		ID:         idgen.WithPrefix("tick_"),
 		StreamID:   streamID,
@@ -253,8 +311,38 @@ func (s *Service) RecordTick(ctx context.Context, streamID string, req TickReque
 	return tick, stream, nil
 }
 
+// elapsedCharge computes the charge accrued on a time-billed stream since
+// the last tick (or since opening, for the first tick). PricePerTick is
+// treated as the rate per second or per minute; partial units are prorated
+// down to the microdollar.
+func elapsedCharge(stream *Stream, now time.Time) *big.Int {
+	since := stream.CreatedAt
+	if stream.LastTickAt != nil {
+		since = *stream.LastTickAt
+	}
+	elapsedMs := now.Sub(since).Milliseconds()
+	if elapsedMs < 0 {
+		elapsedMs = 0
+	}
+	rate, _ := usdc.Parse(stream.PricePerTick)
+	denomMs := int64(1000)
+	if stream.BillingMode == BillingPerMinute {
+		denomMs = 60_000
+	}
+	charge := new(big.Int).Mul(rate, big.NewInt(elapsedMs))
+	return charge.Div(charge, big.NewInt(denomMs))
+}
+
 // Close settles a stream: pays seller for spent amount, refunds unused hold to buyer.
-func (s *Service) Close(ctx context.Context, streamID, callerAddr, reason string) (_ *Stream, retErr error) {
+func (s *Service) Close(ctx context.Context, streamID, callerAddr, reason string) (*Stream, error) {
+	return s.CloseWithCheckpoint(ctx, streamID, callerAddr, reason, nil)
+}
+
+// CloseWithCheckpoint settles a stream like Close. If a signed checkpoint is
+// supplied it is verified and its cumulative amount (capped at the hold)
+// becomes the authoritative floor on the settled spent amount — protecting
+// the seller against a counterparty disputing consumption already attested.
+func (s *Service) CloseWithCheckpoint(ctx context.Context, streamID, callerAddr, reason string, cp *Checkpoint) (_ *Stream, retErr error) {
 	ctx, span := traces.StartSpan(ctx, "streams.Close",
 		attribute.String("stream_id", streamID),
 		attribute.String("caller", callerAddr),
@@ -284,6 +372,24 @@ func (s *Service) Close(ctx context.Context, streamID, callerAddr, reason string
 		return nil, ErrAlreadyClosed
 	}
 
+	if cp != nil {
+		cum, err := s.verifyCheckpoint(stream, cp)
+		if err != nil {
+			return nil, err
+		}
+		spentBig, _ := usdc.Parse(stream.SpentAmount)
+		holdBig, _ := usdc.Parse(stream.HoldAmount)
+		if cum.Cmp(holdBig) > 0 {
+			cum = holdBig
+		}
+		if cum.Cmp(spentBig) > 0 {
+			stream.SpentAmount = usdc.Format(cum)
+			if cp.TickCount > stream.TickCount {
+				stream.TickCount = cp.TickCount
+			}
+		}
+	}
+
 	return s.settle(ctx, stream, StatusClosed, reason, unlock)
 }
 
@@ -332,8 +438,10 @@ func (s *Service) settle(ctx context.Context, stream *Stream, status Status, rea
 	holdBig, _ := usdc.Parse(stream.HoldAmount)
 	unused := new(big.Int).Sub(holdBig, spentBig)
 
-	// 1. Release unused hold back to buyer first (fail-safe order).
-	if unused.Sign() > 0 {
+	// 1. Release unused hold back to buyer first (fail-safe order). For
+	// wallet-funded streams the unused cap returns to the wallet instead —
+	// the funds never had their own ledger hold.
+	if unused.Sign() > 0 && stream.WalletID == "" {
 		unusedStr := usdc.Format(unused)
 		if err := s.ledger.ReleaseHold(ctx, stream.BuyerAddr, unusedStr, stream.ID); err != nil {
 			return nil, fmt.Errorf("failed to release unused hold: %w", err)
@@ -359,6 +467,12 @@ func (s *Service) settle(ctx context.Context, stream *Stream, status Status, rea
 		}
 	}
 
+	// Wallet-funded: return the cap to the wallet so the unused portion is
+	// available to new sub-streams and the spent portion is marked consumed.
+	if stream.WalletID != "" {
+		s.releaseToWallet(ctx, stream)
+	}
+
 	now := time.Now()
 	stream.Status = status
 	stream.CloseReason = reason
@@ -22,6 +22,8 @@ var (
 	ErrAlreadyClosed    = errors.New("streams: already closed")
 	ErrInvalidTickSeq   = errors.New("streams: tick sequence number invalid")
 	ErrDuplicateTickSeq = errors.New("streams: duplicate tick sequence number")
+	ErrInvalidBilling   = errors.New("streams: invalid billing mode")
+	ErrInvalidTopUp     = errors.New("streams: invalid top-up configuration")
 )
 
 // Status represents the state of a stream.
@@ -38,24 +40,61 @@ const (
 // DefaultStaleTimeout is the default inactivity threshold before auto-closing.
 const DefaultStaleTimeout = 60 * time.Second
 
+// BillingMode selects how tick charges are computed.
+type BillingMode string
+
+const (
+	BillingPerTick   BillingMode = "per_tick"   // Each tick charges a discrete amount
+	BillingPerSecond BillingMode = "per_second" // Charges accrue by elapsed seconds between ticks
+	BillingPerMinute BillingMode = "per_minute" // Charges accrue by elapsed minutes between ticks
+)
+
 // Stream represents an open or closed payment stream.
 type Stream struct {
-	ID              string     `json:"id"`
-	BuyerAddr       string     `json:"buyerAddr"`
-	SellerAddr      string     `json:"sellerAddr"`
-	ServiceID       string     `json:"serviceId,omitempty"`
-	SessionKeyID    string     `json:"sessionKeyId,omitempty"`
-	HoldAmount      string     `json:"holdAmount"`   // Total held from buyer
-	SpentAmount     string     `json:"spentAmount"`  // Accumulated tick value
-	PricePerTick    string     `json:"pricePerTick"` // Cost per tick unit
-	TickCount       int        `json:"tickCount"`    // Number of ticks received
-	Status          Status     `json:"status"`
-	StaleTimeoutSec int        `json:"staleTimeoutSecs"` // Seconds before auto-close
-	LastTickAt      *time.Time `json:"lastTickAt,omitempty"`
-	ClosedAt        *time.Time `json:"closedAt,omitempty"`
-	CloseReason     string     `json:"closeReason,omitempty"`
-	CreatedAt       time.Time  `json:"createdAt"`
-	UpdatedAt       time.Time  `json:"updatedAt"`
+	ID              string      `json:"id"`
+	BuyerAddr       string      `json:"buyerAddr"`
+	SellerAddr      string      `json:"sellerAddr"`
+	ServiceID       string      `json:"serviceId,omitempty"`
+	SessionKeyID    string      `json:"sessionKeyId,omitempty"`
+	WalletID        string      `json:"walletId,omitempty"`    // Set when funded from a stream wallet; HoldAmount is the cap
+	HoldAmount      string      `json:"holdAmount"`            // Total held from buyer
+	SpentAmount     string      `json:"spentAmount"`           // Accumulated tick value
+	PricePerTick    string      `json:"pricePerTick"`          // Cost per tick, second, or minute depending on billing mode
+	BillingMode     BillingMode `json:"billingMode,omitempty"` // Empty = per_tick
+	TickCount       int         `json:"tickCount"`             // Number of ticks received
+	Status          Status      `json:"status"`
+	StaleTimeoutSec int         `json:"staleTimeoutSecs"` // Seconds before auto-close
+	LastTickAt      *time.Time  `json:"lastTickAt,omitempty"`
+	ClosedAt        *time.Time  `json:"closedAt,omitempty"`
+	CloseReason     string      `json:"closeReason,omitempty"`
+
+	// Auto-top-up configuration (empty = disabled). When the remaining
+	// balance falls below the threshold, the hold grows by TopUpAmount
+	// until the total hold reaches TopUpCap.
+	TopUpAmount    string `json:"topUpAmount,omitempty"`
+	TopUpThreshold string `json:"topUpThreshold,omitempty"`
+	TopUpCap       string `json:"topUpCap,omitempty"`
+	ToppedUpAmount string `json:"toppedUpAmount,omitempty"` // Cumulative amount added by top-ups
+	TopUpCount     int    `json:"topUpCount,omitempty"`
+
+	// Dispute fields (set when the buyer contests the tick tail before close)
+	DisputedAmount      string            `json:"disputedAmount,omitempty"` // Contested tail frozen pending arbitration
+	DisputeReason       string            `json:"disputeReason,omitempty"`
+	DisputeEvidence     []DisputeEvidence `json:"disputeEvidence,omitempty"`
+	ArbitratorAddr      string            `json:"arbitratorAddr,omitempty"`
+	ArbitrationDeadline *time.Time        `json:"arbitrationDeadline,omitempty"`
+	Resolution          string            `json:"resolution,omitempty"` // "released" or "refunded"; empty while pending
+	ResolvedAt          *time.Time        `json:"resolvedAt,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DisputeEvidence is a single piece of evidence submitted during a dispute.
+type DisputeEvidence struct {
+	SubmittedBy string    `json:"submittedBy"`
+	Content     string    `json:"content"`
+	SubmittedAt time.Time `json:"submittedAt"`
 }
 
 // IsTerminal returns true if the stream is in a final state.
@@ -67,6 +106,17 @@ func (s *Stream) IsTerminal() bool {
 	return false
 }
 
+// TimeBilled returns true if charges accrue by elapsed time between
+// heartbeat ticks rather than discrete tick amounts.
+func (s *Stream) TimeBilled() bool {
+	return s.BillingMode == BillingPerSecond || s.BillingMode == BillingPerMinute
+}
+
+// AutoTopUp returns true if the buyer configured automatic hold top-ups.
+func (s *Stream) AutoTopUp() bool {
+	return s.TopUpAmount != ""
+}
+
 // Tick represents a single micropayment event in a stream.
 type Tick struct {
 	ID         string    `json:"id"`
@@ -84,12 +134,18 @@ type Store interface {
 	Get(ctx context.Context, id string) (*Stream, error)
 	Update(ctx context.Context, stream *Stream) error
 	ListByAgent(ctx context.Context, agentAddr string, limit int) ([]*Stream, error)
+	ListBySeller(ctx context.Context, sellerAddr string, limit int) ([]*Stream, error)
 	ListByStatus(ctx context.Context, status Status, limit int) ([]*Stream, error)
+	ListByWallet(ctx context.Context, walletID string, limit int) ([]*Stream, error)
 	ListStale(ctx context.Context, before time.Time, limit int) ([]*Stream, error)
 
 	CreateTick(ctx context.Context, tick *Tick) error
 	ListTicks(ctx context.Context, streamID string, limit int) ([]*Tick, error)
 	GetLastTick(ctx context.Context, streamID string) (*Tick, error)
+
+	CreateWallet(ctx context.Context, wallet *Wallet) error
+	GetWallet(ctx context.Context, id string) (*Wallet, error)
+	UpdateWallet(ctx context.Context, wallet *Wallet) error
 }
 
 // LedgerService abstracts ledger operations so streams doesn't import ledger.
@@ -120,9 +176,14 @@ type OpenRequest struct {
 	SellerAddr      string `json:"sellerAddr" binding:"required"`
 	HoldAmount      string `json:"holdAmount" binding:"required"`
 	PricePerTick    string `json:"pricePerTick" binding:"required"`
+	BillingMode     string `json:"billingMode"` // "" = per_tick; per_second/per_minute accrue by elapsed time
 	ServiceID       string `json:"serviceId"`
 	SessionKeyID    string `json:"sessionKeyId"`
 	StaleTimeoutSec int    `json:"staleTimeoutSecs"` // 0 = use default (60s)
+	TopUpAmount     string `json:"topUpAmount"`      // Optional: hold increment when balance runs low
+	TopUpThreshold  string `json:"topUpThreshold"`   // Remaining balance that triggers a top-up
+	TopUpCap        string `json:"topUpCap"`         // Maximum total hold after top-ups
+	WalletID        string `json:"walletId"`         // Optional: fund from a stream wallet; holdAmount becomes the sub-stream cap
 }
 
 // TickRequest contains the parameters for recording a tick.
@@ -134,5 +195,27 @@ type TickRequest struct {
 
 // CloseRequest contains optional close parameters.
 type CloseRequest struct {
-	Reason string `json:"reason"`
+	Reason     string      `json:"reason"`
+	Checkpoint *Checkpoint `json:"checkpoint,omitempty"` // Signed floor on the settled amount
+}
+
+// DisputeRequest contains the parameters for contesting a stream's tick tail.
+type DisputeRequest struct {
+	ContestedAmount string `json:"contestedAmount" binding:"required"`
+	Reason          string `json:"reason" binding:"required"`
+}
+
+// EvidenceRequest adds evidence to a pending stream dispute.
+type EvidenceRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// ArbitratorRequest assigns an arbitrator to a disputed stream.
+type ArbitratorRequest struct {
+	ArbitratorAddr string `json:"arbitratorAddr" binding:"required"`
+}
+
+// ResolveDisputeRequest resolves a disputed stream.
+type ResolveDisputeRequest struct {
+	Resolution string `json:"resolution" binding:"required"` // "release" or "refund"
 }
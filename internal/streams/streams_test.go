@@ -855,6 +855,9 @@ func (m *mockWebhook) EmitStreamClosed(_, _, _, _, _ string) {
 	m.closeEvents++
 }
 
+func (m *mockWebhook) EmitStreamTopUp(_, _, _, _, _ string)         {}
+func (m *mockWebhook) EmitStreamTopUpDeclined(_, _, _, _, _ string) {}
+
 func TestRevenueAccumulatorCalledOnClose(t *testing.T) {
 	store := NewMemoryStore()
 	ledger := newMockLedger()
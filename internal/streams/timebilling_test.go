@@ -0,0 +1,159 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+func openTimeBilledStream(t *testing.T, svc *Service, mode string) *Stream {
+	t.Helper()
+	stream, err := svc.Open(context.Background(), OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x2222222222222222222222222222222222222222",
+		HoldAmount:   "10.000000",
+		PricePerTick: "0.010000",
+		BillingMode:  mode,
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	return stream
+}
+
+func TestElapsedCharge(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mode     BillingMode
+		rate     string
+		elapsed  time.Duration
+		expected string
+	}{
+		{"per_second whole", BillingPerSecond, "0.010000", 90 * time.Second, "0.900000"},
+		{"per_second partial", BillingPerSecond, "0.010000", 500 * time.Millisecond, "0.005000"},
+		{"per_minute whole", BillingPerMinute, "0.600000", 90 * time.Second, "0.900000"},
+		{"per_minute partial", BillingPerMinute, "0.600000", 30 * time.Second, "0.300000"},
+		{"zero elapsed", BillingPerSecond, "0.010000", 0, "0.000000"},
+		{"clock skew clamps to zero", BillingPerSecond, "0.010000", -5 * time.Second, "0.000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last := base
+			stream := &Stream{
+				PricePerTick: tt.rate,
+				BillingMode:  tt.mode,
+				CreatedAt:    base,
+				LastTickAt:   &last,
+			}
+			got := usdc.Format(elapsedCharge(stream, base.Add(tt.elapsed)))
+			if got != tt.expected {
+				t.Errorf("expected charge %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestOpenBillingModes(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+
+	// Default is per_tick
+	stream := openTimeBilledStream(t, svc, "")
+	if stream.BillingMode != BillingPerTick {
+		t.Errorf("expected default billing per_tick, got %s", stream.BillingMode)
+	}
+
+	stream = openTimeBilledStream(t, svc, "per_second")
+	if stream.BillingMode != BillingPerSecond || !stream.TimeBilled() {
+		t.Errorf("expected time-billed per_second stream, got %s", stream.BillingMode)
+	}
+
+	// Unknown modes are rejected
+	_, err := svc.Open(context.Background(), OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x2222222222222222222222222222222222222222",
+		HoldAmount:   "10.000000",
+		PricePerTick: "0.010000",
+		BillingMode:  "per_fortnight",
+	})
+	if !errors.Is(err, ErrInvalidBilling) {
+		t.Errorf("expected ErrInvalidBilling, got %v", err)
+	}
+}
+
+func TestTimeBilledTickAccruesElapsed(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+	ctx := context.Background()
+
+	stream := openTimeBilledStream(t, svc, "per_second")
+
+	// Backdate the stream so roughly 5 seconds have "elapsed" at 0.01/s
+	stored, _ := store.Get(ctx, stream.ID)
+	stored.CreatedAt = time.Now().Add(-5 * time.Second)
+	store.Update(ctx, stored)
+
+	tick, updated, err := svc.RecordTick(ctx, stream.ID, TickRequest{})
+	if err != nil {
+		t.Fatalf("tick failed: %v", err)
+	}
+
+	charged, _ := usdc.Parse(tick.Amount)
+	low, _ := usdc.Parse("0.050000")
+	high, _ := usdc.Parse("0.060000")
+	if charged.Cmp(low) < 0 || charged.Cmp(high) > 0 {
+		t.Errorf("expected charge in [0.050000, 0.060000], got %s", tick.Amount)
+	}
+	if updated.SpentAmount != tick.Cumulative {
+		t.Errorf("expected spent %s, got %s", tick.Cumulative, updated.SpentAmount)
+	}
+	if updated.LastTickAt == nil {
+		t.Error("expected LastTickAt to be set")
+	}
+}
+
+func TestTimeBilledTickRejectsExplicitAmount(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+
+	stream := openTimeBilledStream(t, svc, "per_second")
+
+	_, _, err := svc.RecordTick(context.Background(), stream.ID, TickRequest{Amount: "0.005000"})
+	if !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount, got %v", err)
+	}
+}
+
+func TestTimeBilledHeartbeatAllowsZeroCharge(t *testing.T) {
+	store := NewMemoryStore()
+	ledger := newMockLedger()
+	svc := NewService(store, ledger)
+	ctx := context.Background()
+
+	// Per-minute stream ticked immediately: sub-unit elapsed time rounds
+	// down to a zero charge but the heartbeat still counts.
+	stream := openTimeBilledStream(t, svc, "per_minute")
+
+	tick, updated, err := svc.RecordTick(ctx, stream.ID, TickRequest{})
+	if err != nil {
+		t.Fatalf("heartbeat tick failed: %v", err)
+	}
+	if tick.Amount != "0.000000" {
+		t.Errorf("expected zero charge, got %s", tick.Amount)
+	}
+	if updated.TickCount != 1 {
+		t.Errorf("expected tick count 1, got %d", updated.TickCount)
+	}
+	if updated.LastTickAt == nil {
+		t.Error("expected LastTickAt to be refreshed by heartbeat")
+	}
+}
@@ -0,0 +1,86 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/mbd888/alancoin/internal/logging"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// validateTopUp checks the optional auto-top-up configuration on an open
+// request. All three fields must be supplied together or not at all.
+func validateTopUp(req OpenRequest, holdBig *big.Int) error {
+	if req.TopUpAmount == "" && req.TopUpThreshold == "" && req.TopUpCap == "" {
+		return nil
+	}
+
+	amount, ok := usdc.Parse(req.TopUpAmount)
+	if !ok || amount.Sign() <= 0 {
+		return fmt.Errorf("%w: topUpAmount must be positive", ErrInvalidTopUp)
+	}
+	threshold, ok := usdc.Parse(req.TopUpThreshold)
+	if !ok || threshold.Sign() <= 0 {
+		return fmt.Errorf("%w: topUpThreshold must be positive", ErrInvalidTopUp)
+	}
+	capBig, ok := usdc.Parse(req.TopUpCap)
+	if !ok || capBig.Cmp(holdBig) <= 0 {
+		return fmt.Errorf("%w: topUpCap must exceed the initial hold", ErrInvalidTopUp)
+	}
+	return nil
+}
+
+// maybeTopUp grows the stream's hold by the configured top-up amount when the
+// remaining balance after the current tick would fall below the threshold.
+// The last top-up before the cap is truncated so the hold never exceeds it.
+// A webhook fires on both success and decline so the buyer can react before
+// the stream exhausts. Returns the (possibly updated) hold.
+//
+// The caller holds the stream's shard lock and persists HoldAmount via the
+// store.Update that follows the tick.
+func (s *Service) maybeTopUp(ctx context.Context, stream *Stream, newSpent, holdBig *big.Int) *big.Int {
+	threshold, _ := usdc.Parse(stream.TopUpThreshold)
+	remaining := new(big.Int).Sub(holdBig, newSpent)
+	if remaining.Cmp(threshold) >= 0 {
+		return holdBig
+	}
+
+	topUp, _ := usdc.Parse(stream.TopUpAmount)
+	capBig, _ := usdc.Parse(stream.TopUpCap)
+	headroom := new(big.Int).Sub(capBig, holdBig)
+	if headroom.Sign() <= 0 {
+		return holdBig // Cap reached; the stream exhausts naturally from here
+	}
+	if topUp.Cmp(headroom) > 0 {
+		topUp = headroom
+	}
+
+	amount := usdc.Format(topUp)
+	if err := s.ledger.Hold(ctx, stream.BuyerAddr, amount, stream.ID); err != nil {
+		logging.L(ctx).Warn("stream auto-top-up declined",
+			"stream", stream.ID, "buyer", stream.BuyerAddr, "amount", amount, "error", err)
+		streamTopUps.WithLabelValues("declined").Inc()
+		if s.webhookEmitter != nil {
+			go s.webhookEmitter.EmitStreamTopUpDeclined(stream.BuyerAddr, stream.ID, stream.SellerAddr, amount, err.Error())
+		}
+		return holdBig
+	}
+
+	newHold := new(big.Int).Add(holdBig, topUp)
+	topped := big.NewInt(0)
+	if prev, ok := usdc.Parse(stream.ToppedUpAmount); ok {
+		topped = prev
+	}
+	topped.Add(topped, topUp)
+
+	stream.HoldAmount = usdc.Format(newHold)
+	stream.ToppedUpAmount = usdc.Format(topped)
+	stream.TopUpCount++
+
+	streamTopUps.WithLabelValues("ok").Inc()
+	if s.webhookEmitter != nil {
+		go s.webhookEmitter.EmitStreamTopUp(stream.BuyerAddr, stream.ID, stream.SellerAddr, amount, stream.HoldAmount)
+	}
+	return newHold
+}
@@ -0,0 +1,223 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingEmitter captures top-up webhook emissions for assertions.
+type recordingEmitter struct {
+	mu       sync.Mutex
+	topUps   []string // amounts topped up
+	declines []string // declined amounts
+}
+
+func (r *recordingEmitter) EmitStreamOpened(_, _, _, _ string)    {}
+func (r *recordingEmitter) EmitStreamClosed(_, _, _, _, _ string) {}
+
+func (r *recordingEmitter) EmitStreamTopUp(_, _, _, amount, _ string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.topUps = append(r.topUps, amount)
+}
+
+func (r *recordingEmitter) EmitStreamTopUpDeclined(_, _, _, amount, _ string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.declines = append(r.declines, amount)
+}
+
+func (r *recordingEmitter) counts() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.topUps), len(r.declines)
+}
+
+// waitForEmits polls until the emitter has seen the expected webhook counts;
+// emissions happen on goroutines so counts lag the RecordTick return.
+func waitForEmits(t *testing.T, e *recordingEmitter, wantOK, wantDeclined int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		ok, declined := e.counts()
+		if ok == wantOK && declined == wantDeclined {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	ok, declined := e.counts()
+	t.Errorf("expected %d top-up and %d declined webhooks, got %d/%d", wantOK, wantDeclined, ok, declined)
+}
+
+func openTopUpStream(t *testing.T, svc *Service) *Stream {
+	t.Helper()
+	stream, err := svc.Open(context.Background(), OpenRequest{
+		BuyerAddr:      "0x1111111111111111111111111111111111111111",
+		SellerAddr:     "0x2222222222222222222222222222222222222222",
+		HoldAmount:     "0.010000",
+		PricePerTick:   "0.002000",
+		TopUpAmount:    "0.010000",
+		TopUpThreshold: "0.004000",
+		TopUpCap:       "0.025000",
+	})
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	return stream
+}
+
+func TestTopUpValidation(t *testing.T) {
+	svc := NewService(NewMemoryStore(), newMockLedger())
+	ctx := context.Background()
+
+	base := OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x2222222222222222222222222222222222222222",
+		HoldAmount:   "1.000000",
+		PricePerTick: "0.001000",
+	}
+
+	// Partial config: amount without threshold/cap
+	req := base
+	req.TopUpAmount = "0.500000"
+	if _, err := svc.Open(ctx, req); !errors.Is(err, ErrInvalidTopUp) {
+		t.Errorf("expected ErrInvalidTopUp for partial config, got %v", err)
+	}
+
+	// Cap not above initial hold
+	req = base
+	req.TopUpAmount = "0.500000"
+	req.TopUpThreshold = "0.100000"
+	req.TopUpCap = "1.000000"
+	if _, err := svc.Open(ctx, req); !errors.Is(err, ErrInvalidTopUp) {
+		t.Errorf("expected ErrInvalidTopUp for cap at hold, got %v", err)
+	}
+
+	// Valid config
+	req.TopUpCap = "5.000000"
+	stream, err := svc.Open(ctx, req)
+	if err != nil {
+		t.Fatalf("Open with valid top-up config failed: %v", err)
+	}
+	if !stream.AutoTopUp() {
+		t.Error("expected auto-top-up to be enabled")
+	}
+	if stream.ToppedUpAmount != "0.000000" {
+		t.Errorf("expected toppedUpAmount 0.000000, got %s", stream.ToppedUpAmount)
+	}
+}
+
+func TestTopUpTriggersBelowThreshold(t *testing.T) {
+	svc := NewService(NewMemoryStore(), newMockLedger())
+	emitter := &recordingEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+
+	stream := openTopUpStream(t, svc) // hold 0.010, tick 0.002, threshold 0.004
+
+	// Three ticks leave 0.004 remaining — at the threshold, no top-up yet.
+	var updated *Stream
+	var err error
+	for i := 0; i < 3; i++ {
+		if _, updated, err = svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick %d failed: %v", i+1, err)
+		}
+	}
+	if updated.TopUpCount != 0 {
+		t.Fatalf("expected no top-up at the threshold, got %d", updated.TopUpCount)
+	}
+
+	// Fourth tick drops remaining below threshold → hold grows by 0.010.
+	if _, updated, err = svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+		t.Fatalf("tick 4 failed: %v", err)
+	}
+	if updated.TopUpCount != 1 {
+		t.Errorf("expected 1 top-up, got %d", updated.TopUpCount)
+	}
+	if updated.HoldAmount != "0.020000" {
+		t.Errorf("expected hold 0.020000 after top-up, got %s", updated.HoldAmount)
+	}
+	if updated.ToppedUpAmount != "0.010000" {
+		t.Errorf("expected toppedUpAmount 0.010000, got %s", updated.ToppedUpAmount)
+	}
+	waitForEmits(t, emitter, 1, 0)
+}
+
+func TestTopUpTruncatedAtCap(t *testing.T) {
+	svc := NewService(NewMemoryStore(), newMockLedger())
+	ctx := context.Background()
+
+	stream := openTopUpStream(t, svc) // cap 0.025: second top-up is only 0.005
+
+	var updated *Stream
+	var err error
+	for i := 0; i < 9; i++ {
+		if _, updated, err = svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick %d failed: %v", i+1, err)
+		}
+	}
+	if updated.HoldAmount != "0.025000" {
+		t.Errorf("expected hold capped at 0.025000, got %s", updated.HoldAmount)
+	}
+	if updated.ToppedUpAmount != "0.015000" {
+		t.Errorf("expected toppedUpAmount 0.015000, got %s", updated.ToppedUpAmount)
+	}
+	if updated.TopUpCount != 2 {
+		t.Errorf("expected 2 top-ups, got %d", updated.TopUpCount)
+	}
+
+	// Cap reached (spent 0.018, remaining 0.007): three more ticks exhaust.
+	for i := 0; i < 3; i++ {
+		if _, updated, err = svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick past cap failed: %v", err)
+		}
+	}
+	if _, _, err = svc.RecordTick(ctx, stream.ID, TickRequest{}); !errors.Is(err, ErrHoldExhausted) {
+		t.Errorf("expected ErrHoldExhausted once cap is reached, got %v", err)
+	}
+	if updated.SpentAmount != "0.024000" {
+		t.Errorf("expected spent 0.024000, got %s", updated.SpentAmount)
+	}
+}
+
+func TestTopUpDeclinedKeepsStreamAlive(t *testing.T) {
+	ledger := newMockLedger()
+	svc := NewService(NewMemoryStore(), ledger)
+	emitter := &recordingEmitter{}
+	svc.WithWebhookEmitter(emitter)
+	ctx := context.Background()
+
+	stream := openTopUpStream(t, svc)
+
+	// Buyer's balance runs out before the first top-up fires.
+	ledger.mu.Lock()
+	ledger.holdErr = errors.New("insufficient balance")
+	ledger.mu.Unlock()
+
+	// Tick 4 trips the threshold; the top-up is declined but the tick lands.
+	var updated *Stream
+	var err error
+	for i := 0; i < 4; i++ {
+		if _, updated, err = svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick %d failed: %v", i+1, err)
+		}
+	}
+	if updated.TopUpCount != 0 {
+		t.Errorf("expected no successful top-ups, got %d", updated.TopUpCount)
+	}
+	if updated.HoldAmount != "0.010000" {
+		t.Errorf("expected hold unchanged at 0.010000, got %s", updated.HoldAmount)
+	}
+	waitForEmits(t, emitter, 0, 1)
+
+	// The original hold still bounds spending.
+	if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+		t.Fatalf("tick 5 failed: %v", err)
+	}
+	if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); !errors.Is(err, ErrHoldExhausted) {
+		t.Errorf("expected ErrHoldExhausted, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/logging"
+	"github.com/mbd888/alancoin/internal/retry"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrWalletNotFound  = errors.New("streams: wallet not found")
+	ErrWalletExhausted = errors.New("streams: wallet exhausted — cap would exceed unallocated balance")
+	ErrWalletHasOpen   = errors.New("streams: wallet has open sub-streams")
+)
+
+// WalletStatus represents the state of a stream wallet.
+type WalletStatus string
+
+const (
+	WalletOpen   WalletStatus = "open"
+	WalletClosed WalletStatus = "closed"
+)
+
+// Wallet is a single funded hold that backs multiple concurrent sub-streams
+// to different sellers. Each sub-stream reserves a cap from the unallocated
+// balance instead of placing its own ledger hold, so a buyer streaming to
+// many counterparties fragments only one hold.
+//
+// Invariant: AllocatedAmount + SpentAmount <= HoldAmount. Allocated covers
+// the caps of open sub-streams; Spent covers amounts settled (or frozen in
+// a dispute) by closed sub-streams. The remainder is released to the buyer
+// when the wallet closes.
+type Wallet struct {
+	ID              string       `json:"id"`
+	BuyerAddr       string       `json:"buyerAddr"`
+	HoldAmount      string       `json:"holdAmount"`
+	AllocatedAmount string       `json:"allocatedAmount"` // Reserved by open sub-streams
+	SpentAmount     string       `json:"spentAmount"`     // Consumed by closed sub-streams
+	StreamCount     int          `json:"streamCount"`     // Sub-streams opened over the wallet's lifetime
+	Status          WalletStatus `json:"status"`
+	ClosedAt        *time.Time   `json:"closedAt,omitempty"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	UpdatedAt       time.Time    `json:"updatedAt"`
+}
+
+// WalletRequest contains the parameters for opening a stream wallet.
+type WalletRequest struct {
+	BuyerAddr  string `json:"buyerAddr" binding:"required"`
+	HoldAmount string `json:"holdAmount" binding:"required"`
+}
+
+// OpenWallet holds funds from the buyer once; sub-streams opened against the
+// wallet draw caps from it without additional holds.
+func (s *Service) OpenWallet(ctx context.Context, req WalletRequest) (*Wallet, error) {
+	holdBig, ok := usdc.Parse(req.HoldAmount)
+	if !ok || holdBig.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	now := time.Now()
+	wallet := &Wallet{
+		ID:              idgen.WithPrefix("swal_"),
+		BuyerAddr:       strings.ToLower(req.BuyerAddr),
+		HoldAmount:      req.HoldAmount,
+		AllocatedAmount: "0.000000",
+		SpentAmount:     "0.000000",
+		Status:          WalletOpen,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := s.ledger.Hold(ctx, wallet.BuyerAddr, wallet.HoldAmount, wallet.ID); err != nil {
+		return nil, fmt.Errorf("failed to hold wallet funds: %w", err)
+	}
+
+	if err := s.store.CreateWallet(ctx, wallet); err != nil {
+		_ = s.ledger.ReleaseHold(ctx, wallet.BuyerAddr, wallet.HoldAmount, wallet.ID)
+		return nil, fmt.Errorf("failed to create wallet record: %w", err)
+	}
+
+	return wallet, nil
+}
+
+// GetWallet returns a wallet by ID.
+func (s *Service) GetWallet(ctx context.Context, id string) (*Wallet, error) {
+	return s.store.GetWallet(ctx, id)
+}
+
+// ListWalletStreams returns the sub-streams opened against a wallet.
+func (s *Service) ListWalletStreams(ctx context.Context, walletID string, limit int) ([]*Stream, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.store.ListByWallet(ctx, walletID, limit)
+}
+
+// CloseWallet releases the wallet's unconsumed balance back to the buyer.
+// All sub-streams must be closed first — their caps return to the wallet on
+// close, so AllocatedAmount is zero once none remain open.
+func (s *Service) CloseWallet(ctx context.Context, walletID, callerAddr string) (*Wallet, error) {
+	unlock := s.walletLocks.Lock(walletID)
+	defer unlock()
+
+	wallet, err := s.store.GetWallet(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToLower(callerAddr) != wallet.BuyerAddr {
+		return nil, ErrUnauthorized
+	}
+	if wallet.Status != WalletOpen {
+		return nil, ErrAlreadyClosed
+	}
+
+	allocated, _ := usdc.Parse(wallet.AllocatedAmount)
+	if allocated.Sign() > 0 {
+		return nil, ErrWalletHasOpen
+	}
+
+	holdBig, _ := usdc.Parse(wallet.HoldAmount)
+	spentBig, _ := usdc.Parse(wallet.SpentAmount)
+	remaining := new(big.Int).Sub(holdBig, spentBig)
+	if remaining.Sign() > 0 {
+		if err := s.ledger.ReleaseHold(ctx, wallet.BuyerAddr, usdc.Format(remaining), wallet.ID); err != nil {
+			return nil, fmt.Errorf("failed to release wallet hold: %w", err)
+		}
+	}
+
+	now := time.Now()
+	wallet.Status = WalletClosed
+	wallet.ClosedAt = &now
+	wallet.UpdatedAt = now
+
+	// Funds already released; retry the status update to avoid double release.
+	if err := retry.Do(ctx, 3, 50*time.Millisecond, func() error {
+		return s.store.UpdateWallet(ctx, wallet)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update wallet after release (requires manual resolution): %w", err)
+	}
+
+	return wallet, nil
+}
+
+// allocateFromWallet reserves a sub-stream's cap from the wallet's
+// unallocated balance. Called from Open for wallet-funded streams in place
+// of a ledger hold.
+func (s *Service) allocateFromWallet(ctx context.Context, walletID, buyerAddr string, capBig *big.Int) error {
+	unlock := s.walletLocks.Lock(walletID)
+	defer unlock()
+
+	wallet, err := s.store.GetWallet(ctx, walletID)
+	if err != nil {
+		return err
+	}
+	if wallet.BuyerAddr != buyerAddr {
+		return ErrUnauthorized
+	}
+	if wallet.Status != WalletOpen {
+		return ErrInvalidStatus
+	}
+
+	holdBig, _ := usdc.Parse(wallet.HoldAmount)
+	allocated, _ := usdc.Parse(wallet.AllocatedAmount)
+	spentBig, _ := usdc.Parse(wallet.SpentAmount)
+	available := new(big.Int).Sub(holdBig, new(big.Int).Add(allocated, spentBig))
+	if capBig.Cmp(available) > 0 {
+		return ErrWalletExhausted
+	}
+
+	wallet.AllocatedAmount = usdc.Format(new(big.Int).Add(allocated, capBig))
+	wallet.StreamCount++
+	wallet.UpdatedAt = time.Now()
+	return s.store.UpdateWallet(ctx, wallet)
+}
+
+// releaseToWallet returns a closing sub-stream's cap to the wallet: the spent
+// portion moves to the wallet's consumed total and the unused remainder
+// becomes available for new sub-streams. No ledger movement happens here —
+// the funds never left the wallet's hold.
+func (s *Service) releaseToWallet(ctx context.Context, stream *Stream) {
+	unlock := s.walletLocks.Lock(stream.WalletID)
+	defer unlock()
+
+	wallet, err := s.store.GetWallet(ctx, stream.WalletID)
+	if err != nil {
+		logging.L(ctx).Error("stream close: wallet lookup failed — wallet counters stale",
+			"stream", stream.ID, "wallet", stream.WalletID, "error", err)
+		return
+	}
+
+	capBig, _ := usdc.Parse(stream.HoldAmount)
+	streamSpent, _ := usdc.Parse(stream.SpentAmount)
+	allocated, _ := usdc.Parse(wallet.AllocatedAmount)
+	walletSpent, _ := usdc.Parse(wallet.SpentAmount)
+
+	allocated.Sub(allocated, capBig)
+	if allocated.Sign() < 0 {
+		allocated.SetInt64(0)
+	}
+	wallet.AllocatedAmount = usdc.Format(allocated)
+	wallet.SpentAmount = usdc.Format(walletSpent.Add(walletSpent, streamSpent))
+	wallet.UpdatedAt = time.Now()
+
+	if err := retry.Do(ctx, 3, 50*time.Millisecond, func() error {
+		return s.store.UpdateWallet(ctx, wallet)
+	}); err != nil {
+		logging.L(ctx).Error("stream close: wallet update failed — wallet counters stale",
+			"stream", stream.ID, "wallet", stream.WalletID, "error", err)
+	}
+}
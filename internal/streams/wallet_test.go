@@ -0,0 +1,266 @@
+package streams
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func openTestWallet(t *testing.T, svc *Service, hold string) *Wallet {
+	t.Helper()
+	wallet, err := svc.OpenWallet(context.Background(), WalletRequest{
+		BuyerAddr:  "0x1111111111111111111111111111111111111111",
+		HoldAmount: hold,
+	})
+	if err != nil {
+		t.Fatalf("OpenWallet failed: %v", err)
+	}
+	return wallet
+}
+
+func openWalletSubStream(t *testing.T, svc *Service, walletID, seller, cap string) *Stream {
+	t.Helper()
+	stream, err := svc.Open(context.Background(), OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   seller,
+		HoldAmount:   cap,
+		PricePerTick: "0.001000",
+		WalletID:     walletID,
+	})
+	if err != nil {
+		t.Fatalf("Open sub-stream failed: %v", err)
+	}
+	return stream
+}
+
+func TestWalletAllocatesSubStreams(t *testing.T) {
+	ledger := newMockLedger()
+	svc := NewService(NewMemoryStore(), ledger)
+	ctx := context.Background()
+
+	wallet := openTestWallet(t, svc, "1.000000")
+	if got := ledger.holds[wallet.ID]; got != "1.000000" {
+		t.Fatalf("expected wallet hold 1.000000, got %s", got)
+	}
+
+	s1 := openWalletSubStream(t, svc, wallet.ID, "0x2222222222222222222222222222222222222222", "0.400000")
+	s2 := openWalletSubStream(t, svc, wallet.ID, "0x3333333333333333333333333333333333333333", "0.500000")
+
+	// Sub-streams reserve caps from the wallet, not new ledger holds.
+	if _, ok := ledger.holds[s1.ID]; ok {
+		t.Error("sub-stream should not place its own ledger hold")
+	}
+
+	updated, err := svc.GetWallet(ctx, wallet.ID)
+	if err != nil {
+		t.Fatalf("GetWallet failed: %v", err)
+	}
+	if updated.AllocatedAmount != "0.900000" {
+		t.Errorf("expected allocated 0.900000, got %s", updated.AllocatedAmount)
+	}
+	if updated.StreamCount != 2 {
+		t.Errorf("expected stream count 2, got %d", updated.StreamCount)
+	}
+
+	// Only 0.100000 unallocated remains.
+	if _, err := svc.Open(ctx, OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x4444444444444444444444444444444444444444",
+		HoldAmount:   "0.200000",
+		PricePerTick: "0.001000",
+		WalletID:     wallet.ID,
+	}); !errors.Is(err, ErrWalletExhausted) {
+		t.Errorf("expected ErrWalletExhausted, got %v", err)
+	}
+
+	streams, err := svc.ListWalletStreams(ctx, wallet.ID, 10)
+	if err != nil {
+		t.Fatalf("ListWalletStreams failed: %v", err)
+	}
+	if len(streams) != 2 {
+		t.Errorf("expected 2 wallet streams, got %d", len(streams))
+	}
+	_ = s2
+}
+
+func TestWalletSubStreamCloseReturnsCap(t *testing.T) {
+	ledger := newMockLedger()
+	svc := NewService(NewMemoryStore(), ledger)
+	ctx := context.Background()
+
+	wallet := openTestWallet(t, svc, "1.000000")
+	stream := openWalletSubStream(t, svc, wallet.ID, "0x2222222222222222222222222222222222222222", "0.400000")
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick failed: %v", err)
+		}
+	}
+
+	closed, err := svc.Close(ctx, stream.ID, stream.BuyerAddr, "done")
+	if err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if closed.Status != StatusClosed {
+		t.Errorf("expected status closed, got %s", closed.Status)
+	}
+
+	// Spent settles to the seller; the unused cap returns to the wallet with
+	// no ledger release.
+	if got := ledger.settlements[stream.ID]; got != "0.003000" {
+		t.Errorf("expected settlement 0.003000, got %s", got)
+	}
+	if _, ok := ledger.releases[stream.ID]; ok {
+		t.Error("unused cap should return to the wallet, not be released")
+	}
+
+	updated, _ := svc.GetWallet(ctx, wallet.ID)
+	if updated.AllocatedAmount != "0.000000" {
+		t.Errorf("expected allocated 0.000000 after close, got %s", updated.AllocatedAmount)
+	}
+	if updated.SpentAmount != "0.003000" {
+		t.Errorf("expected wallet spent 0.003000, got %s", updated.SpentAmount)
+	}
+
+	// Freed capacity can back a new sub-stream at nearly the full hold.
+	if _, err := svc.Open(ctx, OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x3333333333333333333333333333333333333333",
+		HoldAmount:   "0.997000",
+		PricePerTick: "0.001000",
+		WalletID:     wallet.ID,
+	}); err != nil {
+		t.Errorf("expected freed capacity to be reusable, got %v", err)
+	}
+}
+
+func TestWalletClose(t *testing.T) {
+	ledger := newMockLedger()
+	svc := NewService(NewMemoryStore(), ledger)
+	ctx := context.Background()
+
+	wallet := openTestWallet(t, svc, "1.000000")
+	stream := openWalletSubStream(t, svc, wallet.ID, "0x2222222222222222222222222222222222222222", "0.400000")
+
+	// Cannot close while a sub-stream is open
+	if _, err := svc.CloseWallet(ctx, wallet.ID, wallet.BuyerAddr); !errors.Is(err, ErrWalletHasOpen) {
+		t.Errorf("expected ErrWalletHasOpen, got %v", err)
+	}
+
+	if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+		t.Fatalf("tick failed: %v", err)
+	}
+	if _, err := svc.Close(ctx, stream.ID, stream.BuyerAddr, ""); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Only the buyer may close
+	if _, err := svc.CloseWallet(ctx, wallet.ID, "0x2222222222222222222222222222222222222222"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+
+	closed, err := svc.CloseWallet(ctx, wallet.ID, wallet.BuyerAddr)
+	if err != nil {
+		t.Fatalf("CloseWallet failed: %v", err)
+	}
+	if closed.Status != WalletClosed {
+		t.Errorf("expected status closed, got %s", closed.Status)
+	}
+	// Hold 1.000000 minus 0.001000 consumed returns to the buyer.
+	if got := ledger.releases[wallet.ID]; got != "0.999000" {
+		t.Errorf("expected release 0.999000, got %s", got)
+	}
+
+	if _, err := svc.CloseWallet(ctx, wallet.ID, wallet.BuyerAddr); !errors.Is(err, ErrAlreadyClosed) {
+		t.Errorf("expected ErrAlreadyClosed, got %v", err)
+	}
+}
+
+func TestWalletSubStreamRestrictions(t *testing.T) {
+	svc := NewService(NewMemoryStore(), newMockLedger())
+	ctx := context.Background()
+
+	wallet := openTestWallet(t, svc, "1.000000")
+
+	// Only the wallet's buyer can draw from it
+	if _, err := svc.Open(ctx, OpenRequest{
+		BuyerAddr:    "0x5555555555555555555555555555555555555555",
+		SellerAddr:   "0x2222222222222222222222222222222222222222",
+		HoldAmount:   "0.100000",
+		PricePerTick: "0.001000",
+		WalletID:     wallet.ID,
+	}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized for foreign buyer, got %v", err)
+	}
+
+	// Auto-top-up does not combine with wallet funding
+	if _, err := svc.Open(ctx, OpenRequest{
+		BuyerAddr:      "0x1111111111111111111111111111111111111111",
+		SellerAddr:     "0x2222222222222222222222222222222222222222",
+		HoldAmount:     "0.100000",
+		PricePerTick:   "0.001000",
+		WalletID:       wallet.ID,
+		TopUpAmount:    "0.100000",
+		TopUpThreshold: "0.010000",
+		TopUpCap:       "0.500000",
+	}); !errors.Is(err, ErrInvalidTopUp) {
+		t.Errorf("expected ErrInvalidTopUp, got %v", err)
+	}
+
+	// Unknown wallet
+	if _, err := svc.Open(ctx, OpenRequest{
+		BuyerAddr:    "0x1111111111111111111111111111111111111111",
+		SellerAddr:   "0x2222222222222222222222222222222222222222",
+		HoldAmount:   "0.100000",
+		PricePerTick: "0.001000",
+		WalletID:     "swal_missing",
+	}); !errors.Is(err, ErrWalletNotFound) {
+		t.Errorf("expected ErrWalletNotFound, got %v", err)
+	}
+}
+
+func TestWalletDisputeFreezesContestedTail(t *testing.T) {
+	ledger := newMockLedger()
+	svc := NewService(NewMemoryStore(), ledger)
+	ctx := context.Background()
+
+	wallet := openTestWallet(t, svc, "1.000000")
+	stream := openWalletSubStream(t, svc, wallet.ID, "0x2222222222222222222222222222222222222222", "0.400000")
+
+	for i := 0; i < 5; i++ {
+		if _, _, err := svc.RecordTick(ctx, stream.ID, TickRequest{}); err != nil {
+			t.Fatalf("tick failed: %v", err)
+		}
+	}
+
+	// Contest 0.002 of the 0.005 spent: undisputed settles, the unused cap
+	// returns to the wallet, the contested tail stays frozen.
+	if _, err := svc.Dispute(ctx, stream.ID, stream.BuyerAddr, "0.002000", "bad ticks"); err != nil {
+		t.Fatalf("Dispute failed: %v", err)
+	}
+	if got := ledger.settlements[stream.ID]; got != "0.003000" {
+		t.Errorf("expected undisputed settle 0.003000, got %s", got)
+	}
+	if _, ok := ledger.releases[stream.ID]; ok {
+		t.Error("wallet sub-stream dispute should not release to the buyer directly")
+	}
+
+	updated, _ := svc.GetWallet(ctx, wallet.ID)
+	if updated.AllocatedAmount != "0.000000" {
+		t.Errorf("expected allocated 0.000000, got %s", updated.AllocatedAmount)
+	}
+	// Full spent (including the contested tail) is consumed from the wallet,
+	// so wallet close will not release the frozen funds.
+	if updated.SpentAmount != "0.005000" {
+		t.Errorf("expected wallet spent 0.005000, got %s", updated.SpentAmount)
+	}
+
+	closedWallet, err := svc.CloseWallet(ctx, wallet.ID, wallet.BuyerAddr)
+	if err != nil {
+		t.Fatalf("CloseWallet failed: %v", err)
+	}
+	if got := ledger.releases[wallet.ID]; got != "0.995000" {
+		t.Errorf("expected wallet release 0.995000, got %s", got)
+	}
+	_ = closedWallet
+}
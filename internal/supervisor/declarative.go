@@ -0,0 +1,501 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+// Declarative rules let operators express risk policy as data instead
+// of code: JSON conditions over transaction, agent, and velocity
+// features, evaluated alongside the built-in rules. Rules can be
+// created, backtested against recorded spend events, and toggled at
+// runtime without a redeploy.
+
+// Condition features. Numeric features are USDC amounts (or percent
+// for counterparty_pct); string features compare exactly.
+const (
+	FeatureAmount          = "amount"           // requested amount, USDC
+	FeatureTier            = "tier"             // reputation tier
+	FeatureOpType          = "op_type"          // hold, escrow_lock, spend, transfer, withdraw
+	FeatureCounterparty    = "counterparty"     // counterparty address
+	FeatureSpent1m         = "spent_1m"         // spend in the last minute, USDC
+	FeatureSpent5m         = "spent_5m"         // spend in the last 5 minutes, USDC
+	FeatureSpent1h         = "spent_1h"         // spend in the last hour, USDC
+	FeatureTotalSpent      = "total_spent"      // lifetime spend, USDC
+	FeatureCounterpartyPct = "counterparty_pct" // % of volume to this counterparty
+)
+
+// featureKinds maps each known feature to "number" or "string".
+var featureKinds = map[string]string{
+	FeatureAmount:          "number",
+	FeatureTier:            "string",
+	FeatureOpType:          "string",
+	FeatureCounterparty:    "string",
+	FeatureSpent1m:         "number",
+	FeatureSpent5m:         "number",
+	FeatureSpent1h:         "number",
+	FeatureTotalSpent:      "number",
+	FeatureCounterpartyPct: "number",
+}
+
+// RuleCondition is one comparison. All conditions on a rule must hold
+// (AND) for the rule to match.
+type RuleCondition struct {
+	Feature string   `json:"feature"`
+	Op      string   `json:"op"`               // gt, gte, lt, lte, eq, ne, in
+	Value   string   `json:"value,omitempty"`  // single comparand
+	Values  []string `json:"values,omitempty"` // comparands for "in"
+}
+
+// DeclarativeRule is an operator-defined risk rule.
+type DeclarativeRule struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Action      string          `json:"action"` // "deny" or "flag"
+	Conditions  []RuleCondition `json:"conditions"`
+	Enabled     bool            `json:"enabled"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+// Validate checks the rule is well-formed before it is stored.
+func (r *DeclarativeRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("rule name is required")
+	}
+	if r.Action != "deny" && r.Action != "flag" {
+		return fmt.Errorf("action must be %q or %q", "deny", "flag")
+	}
+	if len(r.Conditions) == 0 {
+		return fmt.Errorf("at least one condition is required")
+	}
+	for i, cond := range r.Conditions {
+		kind, ok := featureKinds[cond.Feature]
+		if !ok {
+			return fmt.Errorf("condition %d: unknown feature %q", i, cond.Feature)
+		}
+		switch cond.Op {
+		case "gt", "gte", "lt", "lte":
+			if kind != "number" {
+				return fmt.Errorf("condition %d: op %q requires a numeric feature", i, cond.Op)
+			}
+			if _, err := strconv.ParseFloat(cond.Value, 64); err != nil {
+				return fmt.Errorf("condition %d: value %q is not numeric", i, cond.Value)
+			}
+		case "eq", "ne":
+			if kind == "number" {
+				if _, err := strconv.ParseFloat(cond.Value, 64); err != nil {
+					return fmt.Errorf("condition %d: value %q is not numeric", i, cond.Value)
+				}
+			} else if cond.Value == "" {
+				return fmt.Errorf("condition %d: value is required", i)
+			}
+		case "in":
+			if kind != "string" {
+				return fmt.Errorf("condition %d: op %q requires a string feature", i, cond.Op)
+			}
+			if len(cond.Values) == 0 {
+				return fmt.Errorf("condition %d: values are required for op %q", i, cond.Op)
+			}
+		default:
+			return fmt.Errorf("condition %d: unknown op %q", i, cond.Op)
+		}
+	}
+	return nil
+}
+
+// matches evaluates all conditions against the current features. A
+// condition over a graph-derived feature fails when no graph data is
+// available rather than erroring.
+func (r *DeclarativeRule) matches(graph *SpendGraph, ec *EvalContext) bool {
+	for _, cond := range r.Conditions {
+		if !evalCondition(cond, graph, ec) {
+			return false
+		}
+	}
+	return true
+}
+
+func evalCondition(cond RuleCondition, graph *SpendGraph, ec *EvalContext) bool {
+	if featureKinds[cond.Feature] == "string" {
+		actual := stringFeature(cond.Feature, ec)
+		switch cond.Op {
+		case "eq":
+			return strings.EqualFold(actual, cond.Value)
+		case "ne":
+			return !strings.EqualFold(actual, cond.Value)
+		case "in":
+			for _, v := range cond.Values {
+				if strings.EqualFold(actual, v) {
+					return true
+				}
+			}
+			return false
+		}
+		return false
+	}
+
+	actual, ok := numericFeature(cond.Feature, graph, ec)
+	if !ok {
+		return false
+	}
+	expected, err := strconv.ParseFloat(cond.Value, 64)
+	if err != nil {
+		return false
+	}
+	switch cond.Op {
+	case "gt":
+		return actual > expected
+	case "gte":
+		return actual >= expected
+	case "lt":
+		return actual < expected
+	case "lte":
+		return actual <= expected
+	case "eq":
+		return actual == expected
+	case "ne":
+		return actual != expected
+	}
+	return false
+}
+
+func stringFeature(feature string, ec *EvalContext) string {
+	switch feature {
+	case FeatureTier:
+		return ec.Tier
+	case FeatureOpType:
+		return ec.OpType
+	case FeatureCounterparty:
+		return ec.Counterparty
+	}
+	return ""
+}
+
+func numericFeature(feature string, graph *SpendGraph, ec *EvalContext) (float64, bool) {
+	if feature == FeatureAmount {
+		return usdcFloat(ec.Amount), true
+	}
+	if graph == nil {
+		return 0, false
+	}
+	snap := graph.GetNode(ec.AgentAddr)
+	if snap == nil {
+		return 0, false
+	}
+	switch feature {
+	case FeatureSpent1m:
+		return usdcFloat(snap.WindowTotals[0]), true
+	case FeatureSpent5m:
+		return usdcFloat(snap.WindowTotals[1]), true
+	case FeatureSpent1h:
+		return usdcFloat(snap.WindowTotals[2]), true
+	case FeatureTotalSpent:
+		return usdcFloat(snap.TotalSpent), true
+	case FeatureCounterpartyPct:
+		if ec.Counterparty == "" || snap.TotalSpent.Sign() == 0 {
+			return 0, false
+		}
+		edge := graph.GetEdge(ec.AgentAddr, ec.Counterparty)
+		if edge == nil {
+			return 0, true
+		}
+		scaled := new(big.Int).Mul(edge.Volume, big.NewInt(100))
+		pct := new(big.Int).Div(scaled, snap.TotalSpent)
+		return float64(pct.Int64()), true
+	}
+	return 0, false
+}
+
+// usdcFloat converts a 6-decimal USDC amount to a float for condition
+// comparisons. Precision loss is acceptable for thresholds.
+func usdcFloat(v *big.Int) float64 {
+	if v == nil {
+		return 0
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(v), big.NewFloat(1e6)).Float64()
+	return f
+}
+
+// RuleStore persists declarative rules across restarts. The in-memory
+// map stays the read path for evaluation; mutations write through.
+type RuleStore interface {
+	SaveRule(ctx context.Context, rule *DeclarativeRule) error
+	DeleteRule(ctx context.Context, id string) error
+	ListRules(ctx context.Context) ([]*DeclarativeRule, error)
+}
+
+// DeclarativeRuleSet holds operator-defined rules and evaluates them
+// as one EvalRule inside the engine, after the built-in rules.
+type DeclarativeRuleSet struct {
+	mu     sync.RWMutex
+	rules  map[string]*DeclarativeRule
+	store  RuleStore // optional: persistence across restarts
+	logger *slog.Logger
+}
+
+// NewDeclarativeRuleSet creates an empty rule set.
+func NewDeclarativeRuleSet() *DeclarativeRuleSet {
+	return &DeclarativeRuleSet{
+		rules:  make(map[string]*DeclarativeRule),
+		logger: slog.Default(),
+	}
+}
+
+// WithStore attaches a persistence backend. Call Load afterwards to
+// hydrate the set from it.
+func (rs *DeclarativeRuleSet) WithStore(store RuleStore) *DeclarativeRuleSet {
+	rs.store = store
+	return rs
+}
+
+// WithLogger sets the logger used for persistence failures.
+func (rs *DeclarativeRuleSet) WithLogger(logger *slog.Logger) *DeclarativeRuleSet {
+	rs.logger = logger
+	return rs
+}
+
+// Load hydrates the rule set from the store. Without it a restart
+// would silently drop every admin-authored rule, including enabled
+// deny rules.
+func (rs *DeclarativeRuleSet) Load(ctx context.Context) error {
+	if rs.store == nil {
+		return nil
+	}
+	rules, err := rs.store.ListRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	for _, r := range rules {
+		cp := *r
+		rs.rules[r.ID] = &cp
+	}
+	return nil
+}
+
+// persistRule writes a rule through to the store, best-effort.
+func (rs *DeclarativeRuleSet) persistRule(r *DeclarativeRule) {
+	if rs.store == nil {
+		return
+	}
+	if err := rs.store.SaveRule(context.Background(), r); err != nil {
+		rs.logger.Error("supervisor: failed to persist declarative rule", "rule", r.ID, "error", err)
+	}
+}
+
+// persistRemoval deletes a rule from the store, best-effort.
+func (rs *DeclarativeRuleSet) persistRemoval(id string) {
+	if rs.store == nil {
+		return
+	}
+	if err := rs.store.DeleteRule(context.Background(), id); err != nil {
+		rs.logger.Error("supervisor: failed to persist rule removal", "rule", id, "error", err)
+	}
+}
+
+// Compile-time check: the set plugs into the rule engine.
+var _ EvalRule = (*DeclarativeRuleSet)(nil)
+
+func (rs *DeclarativeRuleSet) Name() string { return "declarative" }
+
+// Evaluate runs every enabled rule; the first matching deny wins, and
+// a matching flag is returned when nothing denies.
+func (rs *DeclarativeRuleSet) Evaluate(_ context.Context, graph *SpendGraph, ec *EvalContext) *Verdict {
+	rules := rs.List()
+
+	var flagged *Verdict
+	for _, rule := range rules {
+		if !rule.Enabled || !rule.matches(graph, ec) {
+			continue
+		}
+		v := &Verdict{
+			Rule:   "declarative:" + rule.Name,
+			Reason: fmt.Sprintf("matched declarative rule %q (%s)", rule.Name, rule.ID),
+		}
+		if rule.Action == "deny" {
+			v.Action = Deny
+			return v
+		}
+		v.Action = Flag
+		if flagged == nil {
+			flagged = v
+		}
+	}
+	return flagged
+}
+
+// Create validates and stores a new rule, returning it with an ID.
+// Rules start disabled so they can be backtested first.
+func (rs *DeclarativeRuleSet) Create(rule DeclarativeRule) (*DeclarativeRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	rule.ID = idgen.WithPrefix("rule_")
+	rule.Enabled = false
+	rule.CreatedAt = time.Now()
+	rule.UpdatedAt = rule.CreatedAt
+
+	rs.mu.Lock()
+	rs.rules[rule.ID] = &rule
+	rs.persistRule(&rule)
+	rs.mu.Unlock()
+	return &rule, nil
+}
+
+// Update replaces a rule's definition, keeping its ID and enablement.
+func (rs *DeclarativeRuleSet) Update(id string, rule DeclarativeRule) (*DeclarativeRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	existing, ok := rs.rules[id]
+	if !ok {
+		return nil, fmt.Errorf("rule %s not found", id)
+	}
+	rule.ID = existing.ID
+	rule.Enabled = existing.Enabled
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+	rs.rules[id] = &rule
+	rs.persistRule(&rule)
+	return &rule, nil
+}
+
+// Delete removes a rule. It reports whether the rule existed.
+func (rs *DeclarativeRuleSet) Delete(id string) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if _, ok := rs.rules[id]; !ok {
+		return false
+	}
+	delete(rs.rules, id)
+	rs.persistRemoval(id)
+	return true
+}
+
+// SetEnabled toggles a rule at runtime. It reports whether the rule
+// exists.
+func (rs *DeclarativeRuleSet) SetEnabled(id string, enabled bool) bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rule, ok := rs.rules[id]
+	if !ok {
+		return false
+	}
+	rule.Enabled = enabled
+	rule.UpdatedAt = time.Now()
+	rs.persistRule(rule)
+	return true
+}
+
+// Get returns a copy of one rule.
+func (rs *DeclarativeRuleSet) Get(id string) (*DeclarativeRule, bool) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	rule, ok := rs.rules[id]
+	if !ok {
+		return nil, false
+	}
+	cp := *rule
+	return &cp, true
+}
+
+// List returns all rules, oldest first for stable evaluation order.
+func (rs *DeclarativeRuleSet) List() []*DeclarativeRule {
+	rs.mu.RLock()
+	rules := make([]*DeclarativeRule, 0, len(rs.rules))
+	for _, r := range rs.rules {
+		cp := *r
+		rules = append(rules, &cp)
+	}
+	rs.mu.RUnlock()
+
+	sort.Slice(rules, func(i, j int) bool {
+		if !rules[i].CreatedAt.Equal(rules[j].CreatedAt) {
+			return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+		}
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// BacktestResult summarizes a dry run of a candidate rule over
+// recorded spend events.
+type BacktestResult struct {
+	Events    int             `json:"events"`
+	Matches   int             `json:"matches"`
+	MatchRate float64         `json:"matchRate"`
+	Samples   []BacktestMatch `json:"samples,omitempty"`
+}
+
+// BacktestMatch is one historical event a candidate rule would have
+// hit.
+type BacktestMatch struct {
+	AgentAddr    string    `json:"agentAddr"`
+	Counterparty string    `json:"counterparty,omitempty"`
+	Amount       string    `json:"amount"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+const maxBacktestSamples = 20
+
+// Backtest replays recorded spend events through a candidate rule
+// without enforcing it. Events carry only amount and counterparty, so
+// conditions over tier or live velocity windows do not match here —
+// the result measures the transaction-shaped conditions.
+func Backtest(ctx context.Context, rule *DeclarativeRule, store BaselineStore, since time.Time) (*BacktestResult, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	events, err := store.GetRecentSpendEvents(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BacktestResult{Events: len(events)}
+	for _, ev := range events {
+		ec := &EvalContext{
+			AgentAddr:    ev.AgentAddr,
+			Counterparty: ev.Counterparty,
+			Amount:       ev.Amount,
+			OpType:       "spend",
+		}
+		if !rule.matches(nil, ec) {
+			continue
+		}
+		result.Matches++
+		if len(result.Samples) < maxBacktestSamples {
+			result.Samples = append(result.Samples, BacktestMatch{
+				AgentAddr:    ev.AgentAddr,
+				Counterparty: ev.Counterparty,
+				Amount:       usdc.Format(ev.Amount),
+				CreatedAt:    ev.CreatedAt,
+			})
+		}
+	}
+	if result.Events > 0 {
+		result.MatchRate = float64(result.Matches) / float64(result.Events)
+	}
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package supervisor
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RulesHandler exposes the declarative rule set over the admin API:
+// CRUD, enable/disable, and backtesting against recorded spend events.
+type RulesHandler struct {
+	set     *DeclarativeRuleSet
+	history BaselineStore // optional, needed for backtests
+}
+
+// NewRulesHandler creates the admin handler for declarative rules.
+func NewRulesHandler(set *DeclarativeRuleSet) *RulesHandler {
+	return &RulesHandler{set: set}
+}
+
+// WithHistory attaches the spend-event store used for backtests.
+func (h *RulesHandler) WithHistory(store BaselineStore) *RulesHandler {
+	h.history = store
+	return h
+}
+
+// RegisterAdminRoutes sets up the declarative rule routes. The caller
+// is expected to wrap the group in admin auth.
+func (h *RulesHandler) RegisterAdminRoutes(r *gin.RouterGroup) {
+	r.GET("/admin/risk/rules", h.listRules)
+	r.POST("/admin/risk/rules", h.createRule)
+	r.PUT("/admin/risk/rules/:ruleId", h.updateRule)
+	r.DELETE("/admin/risk/rules/:ruleId", h.deleteRule)
+	r.POST("/admin/risk/rules/:ruleId/enable", h.setEnabled(true))
+	r.POST("/admin/risk/rules/:ruleId/disable", h.setEnabled(false))
+	r.POST("/admin/risk/rules/test", h.testRule)
+}
+
+func (h *RulesHandler) listRules(c *gin.Context) {
+	rules := h.set.List()
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+func (h *RulesHandler) createRule(c *gin.Context) {
+	var rule DeclarativeRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	created, err := h.set.Create(rule)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_rule",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *RulesHandler) updateRule(c *gin.Context) {
+	var rule DeclarativeRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	id := c.Param("ruleId")
+	if _, ok := h.set.Get(id); !ok {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Rule not found",
+		})
+		return
+	}
+	updated, err := h.set.Update(id, rule)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_rule",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *RulesHandler) deleteRule(c *gin.Context) {
+	if !h.set.Delete(c.Param("ruleId")) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Rule not found",
+		})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RulesHandler) setEnabled(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("ruleId")
+		if !h.set.SetEnabled(id, enabled) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "not_found",
+				"message": "Rule not found",
+			})
+			return
+		}
+		rule, _ := h.set.Get(id)
+		c.JSON(http.StatusOK, rule)
+	}
+}
+
+// testRule backtests a candidate rule (from the request body, not the
+// stored set) against recorded spend events, so operators can gauge
+// impact before enabling anything.
+func (h *RulesHandler) testRule(c *gin.Context) {
+	if h.history == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error":   "history_unavailable",
+			"message": "Spend-event history is not enabled; backtests require a baseline store",
+		})
+		return
+	}
+
+	var rule DeclarativeRule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	hours := 24
+	if v := c.Query("hours"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > 720 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_hours",
+				"message": "hours must be between 1 and 720",
+			})
+			return
+		}
+		hours = parsed
+	}
+
+	result, err := Backtest(c.Request.Context(), &rule, h.history, time.Now().Add(-time.Duration(hours)*time.Hour))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "backtest_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,308 @@
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func validRule() DeclarativeRule {
+	return DeclarativeRule{
+		Name:   "large_new_agent_tx",
+		Action: "deny",
+		Conditions: []RuleCondition{
+			{Feature: FeatureTier, Op: "eq", Value: "new"},
+			{Feature: FeatureAmount, Op: "gt", Value: "3"},
+		},
+	}
+}
+
+func TestDeclarativeRuleValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*DeclarativeRule)
+		wantOK bool
+	}{
+		{"valid", func(*DeclarativeRule) {}, true},
+		{"missing name", func(r *DeclarativeRule) { r.Name = "" }, false},
+		{"bad action", func(r *DeclarativeRule) { r.Action = "block" }, false},
+		{"no conditions", func(r *DeclarativeRule) { r.Conditions = nil }, false},
+		{"unknown feature", func(r *DeclarativeRule) { r.Conditions[0].Feature = "vibes" }, false},
+		{"unknown op", func(r *DeclarativeRule) { r.Conditions[0].Op = "matches" }, false},
+		{"numeric op on string feature", func(r *DeclarativeRule) {
+			r.Conditions[0] = RuleCondition{Feature: FeatureTier, Op: "gt", Value: "5"}
+		}, false},
+		{"non-numeric threshold", func(r *DeclarativeRule) { r.Conditions[1].Value = "lots" }, false},
+		{"in without values", func(r *DeclarativeRule) {
+			r.Conditions[0] = RuleCondition{Feature: FeatureOpType, Op: "in"}
+		}, false},
+		{"in with values", func(r *DeclarativeRule) {
+			r.Conditions[0] = RuleCondition{Feature: FeatureOpType, Op: "in", Values: []string{"spend", "transfer"}}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := validRule()
+			tt.mutate(&rule)
+			err := rule.Validate()
+			if tt.wantOK && err != nil {
+				t.Fatalf("expected valid rule, got %v", err)
+			}
+			if !tt.wantOK && err == nil {
+				t.Fatal("expected validation error, got nil")
+			}
+		})
+	}
+}
+
+func TestDeclarativeRuleSetEvaluate(t *testing.T) {
+	set := NewDeclarativeRuleSet()
+	rule, err := set.Create(validRule())
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	graph := NewSpendGraph()
+	ec := &EvalContext{
+		AgentAddr: "0xagent",
+		Amount:    mustParse("5"),
+		OpType:    "spend",
+		Tier:      "new",
+	}
+
+	// Rules start disabled: no verdict until enabled.
+	if v := set.Evaluate(context.Background(), graph, ec); v != nil {
+		t.Fatalf("disabled rule produced verdict %+v", v)
+	}
+	if !set.SetEnabled(rule.ID, true) {
+		t.Fatal("SetEnabled returned false")
+	}
+
+	v := set.Evaluate(context.Background(), graph, ec)
+	if v == nil || v.Action != Deny {
+		t.Fatalf("expected deny, got %+v", v)
+	}
+	if v.Rule != "declarative:large_new_agent_tx" {
+		t.Fatalf("unexpected rule name %q", v.Rule)
+	}
+
+	// Established agents don't match the tier condition.
+	ec.Tier = "established"
+	if v := set.Evaluate(context.Background(), graph, ec); v != nil {
+		t.Fatalf("non-matching rule produced verdict %+v", v)
+	}
+}
+
+func TestDeclarativeVelocityFeature(t *testing.T) {
+	set := NewDeclarativeRuleSet()
+	rule, err := set.Create(DeclarativeRule{
+		Name:   "hot_hour",
+		Action: "flag",
+		Conditions: []RuleCondition{
+			{Feature: FeatureSpent1h, Op: "gte", Value: "10"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	set.SetEnabled(rule.ID, true)
+
+	graph := NewSpendGraph()
+	ec := &EvalContext{AgentAddr: "0xagent", Amount: mustParse("1"), OpType: "spend", Tier: "new"}
+
+	// Under the threshold — and with no graph history at all — no match.
+	if v := set.Evaluate(context.Background(), graph, ec); v != nil {
+		t.Fatalf("expected no verdict, got %+v", v)
+	}
+
+	graph.RecordEvent("0xagent", "0xcounterparty", mustParse("12"), time.Now())
+	v := set.Evaluate(context.Background(), graph, ec)
+	if v == nil || v.Action != Flag {
+		t.Fatalf("expected flag, got %+v", v)
+	}
+}
+
+func TestDeclarativeRuleSetCRUD(t *testing.T) {
+	set := NewDeclarativeRuleSet()
+
+	rule, err := set.Create(validRule())
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if rule.Enabled {
+		t.Fatal("new rules must start disabled")
+	}
+
+	updated := validRule()
+	updated.Description = "tightened"
+	updated.Conditions[1].Value = "2"
+	set.SetEnabled(rule.ID, true)
+	got, err := set.Update(rule.ID, updated)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if !got.Enabled {
+		t.Fatal("update must preserve enablement")
+	}
+	if got.Conditions[1].Value != "2" {
+		t.Fatalf("update did not apply: %+v", got.Conditions)
+	}
+
+	if _, err := set.Update("rule_missing", validRule()); err == nil {
+		t.Fatal("expected error updating missing rule")
+	}
+	if !set.Delete(rule.ID) {
+		t.Fatal("delete returned false")
+	}
+	if set.Delete(rule.ID) {
+		t.Fatal("double delete returned true")
+	}
+}
+
+// memRuleStore is a RuleStore backed by a map, standing in for Postgres.
+type memRuleStore struct {
+	rules map[string]*DeclarativeRule
+}
+
+func newMemRuleStore() *memRuleStore {
+	return &memRuleStore{rules: make(map[string]*DeclarativeRule)}
+}
+
+func (m *memRuleStore) SaveRule(_ context.Context, rule *DeclarativeRule) error {
+	cp := *rule
+	m.rules[rule.ID] = &cp
+	return nil
+}
+
+func (m *memRuleStore) DeleteRule(_ context.Context, id string) error {
+	delete(m.rules, id)
+	return nil
+}
+
+func (m *memRuleStore) ListRules(_ context.Context) ([]*DeclarativeRule, error) {
+	out := make([]*DeclarativeRule, 0, len(m.rules))
+	for _, r := range m.rules {
+		cp := *r
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func TestRuleStorePersistenceRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newMemRuleStore()
+
+	set := NewDeclarativeRuleSet().WithStore(store)
+	kept, err := set.Create(validRule())
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	set.SetEnabled(kept.ID, true)
+
+	dropped := validRule()
+	dropped.Name = "short_lived"
+	created, err := set.Create(dropped)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	set.Delete(created.ID)
+
+	// A fresh set hydrated from the same store sees the surviving rule
+	// with its enablement intact, as it would after a restart.
+	restarted := NewDeclarativeRuleSet().WithStore(store)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	got, ok := restarted.Get(kept.ID)
+	if !ok {
+		t.Fatal("expected rule to survive restart")
+	}
+	if !got.Enabled {
+		t.Fatal("expected enablement to survive restart")
+	}
+	if _, ok := restarted.Get(created.ID); ok {
+		t.Fatal("expected deleted rule to stay deleted")
+	}
+	if len(restarted.List()) != 1 {
+		t.Fatalf("expected 1 rule after reload, got %d", len(restarted.List()))
+	}
+}
+
+func TestLoadWithoutStore(t *testing.T) {
+	if err := NewDeclarativeRuleSet().Load(context.Background()); err != nil {
+		t.Fatalf("load without store: %v", err)
+	}
+}
+
+func TestBacktest(t *testing.T) {
+	store := NewMemoryBaselineStore()
+	ctx := context.Background()
+	now := time.Now()
+	for _, amt := range []string{"1", "4", "8"} {
+		err := store.AppendSpendEvent(ctx, &SpendEventRecord{
+			AgentAddr:    "0xagent",
+			Counterparty: "0xcounterparty",
+			Amount:       mustParse(amt),
+			CreatedAt:    now,
+		})
+		if err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	rule := DeclarativeRule{
+		Name:   "over_three",
+		Action: "deny",
+		Conditions: []RuleCondition{
+			{Feature: FeatureAmount, Op: "gt", Value: "3"},
+		},
+	}
+	result, err := Backtest(ctx, &rule, store, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("backtest: %v", err)
+	}
+	if result.Events != 3 || result.Matches != 2 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if len(result.Samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(result.Samples))
+	}
+
+	bad := rule
+	bad.Action = "block"
+	if _, err := Backtest(ctx, &bad, store, now.Add(-time.Hour)); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestSupervisorWithDeclarativeRules(t *testing.T) {
+	set := NewDeclarativeRuleSet()
+	sv := New(&mockLedgerService{}, WithDeclarativeRules(set))
+	if sv.DeclarativeRules() != set {
+		t.Fatal("DeclarativeRules accessor mismatch")
+	}
+
+	rule, err := set.Create(DeclarativeRule{
+		Name:   "no_withdrawals",
+		Action: "deny",
+		Conditions: []RuleCondition{
+			{Feature: FeatureOpType, Op: "eq", Value: "withdraw"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	set.SetEnabled(rule.ID, true)
+
+	v := sv.engine.Evaluate(context.Background(), sv.graph, &EvalContext{
+		AgentAddr: "0xagent",
+		Amount:    mustParse("1"),
+		OpType:    "withdraw",
+		Tier:      "elite",
+	})
+	if v == nil || v.Action != Deny {
+		t.Fatalf("expected declarative deny through the engine, got %+v", v)
+	}
+}
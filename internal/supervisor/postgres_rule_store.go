@@ -0,0 +1,68 @@
+package supervisor
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+// PostgresRuleStore implements RuleStore backed by PostgreSQL. Rules
+// are stored as JSONB so schema changes to DeclarativeRule need no
+// migration.
+type PostgresRuleStore struct {
+	db *sql.DB
+}
+
+// Compile-time check.
+var _ RuleStore = (*PostgresRuleStore)(nil)
+
+// NewPostgresRuleStore creates a new Postgres-backed rule store.
+func NewPostgresRuleStore(db *sql.DB) *PostgresRuleStore {
+	return &PostgresRuleStore{db: db}
+}
+
+func (s *PostgresRuleStore) SaveRule(ctx context.Context, rule *DeclarativeRule) error {
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO declarative_rules (id, rule, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			rule       = EXCLUDED.rule,
+			updated_at = EXCLUDED.updated_at
+	`, rule.ID, data)
+	return err
+}
+
+func (s *PostgresRuleStore) DeleteRule(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM declarative_rules WHERE id = $1
+	`, id)
+	return err
+}
+
+func (s *PostgresRuleStore) ListRules(ctx context.Context) ([]*DeclarativeRule, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT rule FROM declarative_rules
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var out []*DeclarativeRule
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		rule := &DeclarativeRule{}
+		if err := json.Unmarshal(data, rule); err != nil {
+			return nil, err
+		}
+		out = append(out, rule)
+	}
+	return out, rows.Err()
+}
@@ -56,6 +56,10 @@ type Supervisor struct {
 	// denialSink (optional) is notified synchronously on every denial.
 	// Typically backed by the compliance aggregator. Must return quickly.
 	denialSink DenialSink
+
+	// declarativeRules (optional) holds the admin-managed rule set so
+	// the admin API can reach it.
+	declarativeRules *DeclarativeRuleSet
 }
 
 // DenialSink is notified whenever the supervisor denies an operation.
@@ -131,6 +135,28 @@ func WithBalanceConcentration() Option {
 	}
 }
 
+// WithDeclarativeRules appends the admin-managed declarative rule set
+// to the engine, after the built-in rules. Apply this option last so
+// later options don't rebuild the engine without it.
+func WithDeclarativeRules(set *DeclarativeRuleSet) Option {
+	return func(s *Supervisor) {
+		s.declarativeRules = set
+		s.engine = NewRuleEngine(append(s.engine.rules, set)...)
+	}
+}
+
+// DeclarativeRules returns the admin-managed rule set, or nil when the
+// option was not applied.
+func (s *Supervisor) DeclarativeRules() *DeclarativeRuleSet {
+	return s.declarativeRules
+}
+
+// BaselineHistory returns the spend-event store, or nil without
+// WithBaselineStore. Used by the admin API for rule backtests.
+func (s *Supervisor) BaselineHistory() BaselineStore {
+	return s.denialStore
+}
+
 // New creates a Supervisor wrapping inner with default rules.
 func New(inner ledger.Service, opts ...Option) *Supervisor {
 	s := &Supervisor{
@@ -393,6 +419,11 @@ func (s *Supervisor) Refund(ctx context.Context, agentAddr, amount, reference st
 	return s.inner.Refund(ctx, agentAddr, amount, reference)
 }
 
+// RecordGasCost is informational only — nothing for the supervisor to evaluate.
+func (s *Supervisor) RecordGasCost(ctx context.Context, agentAddr, amount, reference string) error {
+	return s.inner.RecordGasCost(ctx, agentAddr, amount, reference)
+}
+
 func (s *Supervisor) GetBalance(ctx context.Context, agentAddr string) (*ledger.Balance, error) {
 	return s.inner.GetBalance(ctx, agentAddr)
 }
@@ -98,6 +98,10 @@ func (m *mockLedgerService) Withdraw(_ context.Context, _, _, _ string) error {
 	m.record("Withdraw")
 	return m.err
 }
+func (m *mockLedgerService) RecordGasCost(_ context.Context, _, _, _ string) error {
+	m.record("RecordGasCost")
+	return m.err
+}
 func (m *mockLedgerService) GetBalance(_ context.Context, _ string) (*ledger.Balance, error) {
 	m.record("GetBalance")
 	return &ledger.Balance{Available: "100.000000"}, nil
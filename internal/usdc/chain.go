@@ -90,6 +90,9 @@ type TransferRequest struct {
 	ClientRef string
 	Nonce     uint64
 	FeeQuote  FeeQuote
+	// Urgency picks the fee oracle tier when dynamic fees are enabled.
+	// Empty means Standard; ignored by clients without an oracle.
+	Urgency Urgency
 }
 
 // SubmittedTx is the result of a successful SendTransfer call.
@@ -41,6 +41,10 @@ type EthClient struct {
 	// gasLimitFallback is used when EstimateGas fails. ERC-20 transfer is
 	// typically ~55k gas; 90k gives plenty of headroom without wasting.
 	gasLimitFallback uint64
+	// dynamicFees enables the history-based fee oracle when the backend
+	// supports eth_feeHistory; oracle stays nil otherwise.
+	dynamicFees bool
+	oracle      *FeeOracle
 }
 
 // Option tweaks EthClient construction without bloating NewEthClient's signature.
@@ -56,6 +60,14 @@ func WithGasLimitFallback(g uint64) Option {
 	return func(c *EthClient) { c.gasLimitFallback = g }
 }
 
+// WithDynamicFees quotes fees from recent chain history (eth_feeHistory)
+// per the request's urgency tier instead of the static headroom formula.
+// Falls back to the static path when the backend lacks fee history or the
+// oracle call fails.
+func WithDynamicFees() Option {
+	return func(c *EthClient) { c.dynamicFees = true }
+}
+
 // NewEthClient dials the chain's RPC URL and returns a ready-to-use client.
 // Closing is the caller's responsibility via Close().
 func NewEthClient(ctx context.Context, chain Chain, opts ...Option) (*EthClient, error) {
@@ -83,9 +95,31 @@ func newEthClientWithBackend(chain Chain, backend rpcBackend, opts ...Option) *E
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.dynamicFees {
+		if fh, ok := backend.(feeHistorer); ok {
+			c.oracle = NewFeeOracle(fh)
+		}
+	}
 	return c
 }
 
+// Oracle returns the fee oracle, or nil when dynamic fees are disabled or
+// unsupported. Exposed so other fee consumers (e.g. the paymaster's cost
+// estimates) can share the cached quotes.
+func (c *EthClient) Oracle() *FeeOracle { return c.oracle }
+
+// ETHBalance returns addr's native-token balance at the latest block.
+// Errors when the backend doesn't expose eth_getBalance (e.g. test stubs).
+func (c *EthClient) ETHBalance(ctx context.Context, addr string) (*big.Int, error) {
+	br, ok := c.backend.(interface {
+		BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	})
+	if !ok {
+		return nil, errors.New("usdc: backend does not support balance queries")
+	}
+	return br.BalanceAt(ctx, common.HexToAddress(addr), nil)
+}
+
 // Close releases the underlying RPC connection. Safe to call on a client
 // created with a stub backend (no-op in that case).
 func (c *EthClient) Close() {
@@ -112,7 +146,22 @@ func (c *EthClient) PendingNonce(ctx context.Context, address string) (uint64, e
 //   - baseFee := latest block header.BaseFee
 //   - maxFee := baseFee * (1 + feeBumpPct/100) + priorityFee
 //   - gas := EstimateGas of the transfer call, or gasLimitFallback on error
+//
+// With dynamic fees enabled, the base fee, ceiling, and tip come from the
+// fee oracle's urgency tier instead; the static path remains the fallback.
 func (c *EthClient) EstimateFee(ctx context.Context, req TransferRequest) (FeeQuote, error) {
+	if c.oracle != nil {
+		if quote, err := c.oracle.Quote(ctx, req.Urgency); err == nil {
+			return FeeQuote{
+				BaseFee:              quote.BaseFee,
+				MaxFeePerGas:         quote.MaxFeePerGas,
+				MaxPriorityFeePerGas: quote.MaxPriorityFeePerGas,
+				EstimatedGas:         c.estimateGas(ctx, req),
+				QuotedAt:             time.Now().UTC(),
+			}, nil
+		}
+	}
+
 	tip, err := c.backend.SuggestGasTipCap(ctx)
 	if err != nil {
 		return FeeQuote{}, fmt.Errorf("usdc: suggest tip: %w", err)
@@ -131,32 +180,33 @@ func (c *EthClient) EstimateFee(ctx context.Context, req TransferRequest) (FeeQu
 	maxFee := new(big.Int).Add(head.BaseFee, bump)
 	maxFee.Add(maxFee, tip)
 
-	// Try eth_estimateGas; fall back to the configured default if the node
-	// rejects the call (e.g. due to insufficient balance at estimation time).
-	fromAddr := common.HexToAddress(req.FromAddr)
-	data, err := EncodeTransferCall(req.ToAddr, req.Amount)
-	if err != nil {
-		return FeeQuote{}, err
-	}
-	callMsg := ethereum.CallMsg{
-		From: fromAddr,
-		To:   &c.usdcAddr,
-		Data: data,
-	}
-	gas, estErr := c.backend.EstimateGas(ctx, callMsg)
-	if estErr != nil || gas == 0 {
-		gas = c.gasLimitFallback
-	}
-
 	return FeeQuote{
 		BaseFee:              new(big.Int).Set(head.BaseFee),
 		MaxFeePerGas:         maxFee,
 		MaxPriorityFeePerGas: new(big.Int).Set(tip),
-		EstimatedGas:         gas,
+		EstimatedGas:         c.estimateGas(ctx, req),
 		QuotedAt:             time.Now().UTC(),
 	}, nil
 }
 
+// estimateGas runs eth_estimateGas for a transfer, falling back to the
+// configured default when the node rejects the call or encoding fails.
+func (c *EthClient) estimateGas(ctx context.Context, req TransferRequest) uint64 {
+	data, err := EncodeTransferCall(req.ToAddr, req.Amount)
+	if err != nil {
+		return c.gasLimitFallback
+	}
+	gas, err := c.backend.EstimateGas(ctx, ethereum.CallMsg{
+		From: common.HexToAddress(req.FromAddr),
+		To:   &c.usdcAddr,
+		Data: data,
+	})
+	if err != nil || gas == 0 {
+		return c.gasLimitFallback
+	}
+	return gas
+}
+
 // SendTransfer implements ChainClient.
 //
 // Builds an EIP-1559 tx calling USDC.transfer(to, amount), has the Wallet
@@ -0,0 +1,226 @@
+package usdc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+
+	"github.com/mbd888/alancoin/internal/metrics"
+)
+
+// Urgency selects how aggressively a transfer bids for inclusion.
+// Low suits background treasury moves, Standard is the default for
+// payouts, High is used for stuck-transaction replacements where speed
+// matters more than cost.
+type Urgency string
+
+const (
+	UrgencyLow      Urgency = "low"
+	UrgencyStandard Urgency = "standard"
+	UrgencyHigh     Urgency = "high"
+)
+
+// urgencyTiers maps each urgency to the priority-fee percentile targeted
+// across the history window and the base-fee headroom (%) added on top of
+// the next block's base fee.
+var urgencyTiers = map[Urgency]struct {
+	percentile  float64
+	headroomPct int64
+}{
+	UrgencyLow:      {percentile: 25, headroomPct: 10},
+	UrgencyStandard: {percentile: 50, headroomPct: 25},
+	UrgencyHigh:     {percentile: 90, headroomPct: 50},
+}
+
+// oraclePercentiles is the reward-percentile list sent to eth_feeHistory,
+// in the same order tiers are resolved from the response.
+var oraclePercentiles = []float64{25, 50, 90}
+
+// feeHistorer is the one eth_feeHistory call the oracle needs.
+// ethclient.Client satisfies it.
+type feeHistorer interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// OracleQuote is one urgency tier's fee recommendation.
+type OracleQuote struct {
+	BaseFee *big.Int // next block's base fee
+	// BaseFeeTrendPct is the % change of the base fee across the history
+	// window: positive = fees rising. Rising trends widen the headroom so
+	// a quote stays includable while the tx sits in the mempool.
+	BaseFeeTrendPct      float64
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	Urgency              Urgency
+	QuotedAt             time.Time
+}
+
+// FeeOracle derives EIP-1559 fee recommendations from recent chain history
+// (eth_feeHistory) instead of a single node-suggested tip: the priority
+// fee targets a percentile of what recent blocks actually paid, and the
+// base-fee headroom scales with the observed trend. Quotes are cached
+// briefly so concurrent senders share one RPC call.
+type FeeOracle struct {
+	backend  feeHistorer
+	window   uint64        // blocks of history to consider
+	cacheTTL time.Duration // how long a fetched history stays fresh
+
+	mu        sync.Mutex
+	quotes    map[Urgency]OracleQuote
+	fetchedAt time.Time
+}
+
+// NewFeeOracle creates an oracle over the given fee-history source.
+func NewFeeOracle(backend feeHistorer) *FeeOracle {
+	return &FeeOracle{
+		backend:  backend,
+		window:   20,
+		cacheTTL: 10 * time.Second,
+		quotes:   make(map[Urgency]OracleQuote),
+	}
+}
+
+// Quote returns the fee recommendation for the given urgency, refreshing
+// the shared history window when the cache has gone stale. An empty
+// urgency resolves to Standard.
+func (o *FeeOracle) Quote(ctx context.Context, u Urgency) (OracleQuote, error) {
+	if u == "" {
+		u = UrgencyStandard
+	}
+	if _, ok := urgencyTiers[u]; !ok {
+		return OracleQuote{}, errors.New("usdc: unknown urgency " + string(u))
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if time.Since(o.fetchedAt) > o.cacheTTL {
+		if err := o.refreshLocked(ctx); err != nil {
+			return OracleQuote{}, err
+		}
+	}
+	return o.quotes[u], nil
+}
+
+// refreshLocked pulls one fee-history window and rebuilds every tier's
+// quote from it. Caller holds o.mu.
+func (o *FeeOracle) refreshLocked(ctx context.Context) error {
+	hist, err := o.backend.FeeHistory(ctx, o.window, nil, oraclePercentiles)
+	if err != nil {
+		return err
+	}
+	if len(hist.BaseFee) < 2 {
+		return errors.New("usdc: fee history window too small")
+	}
+
+	// BaseFee has window+1 entries; the last one is the next block's.
+	nextBase := hist.BaseFee[len(hist.BaseFee)-1]
+	trendPct := baseFeeTrendPct(hist.BaseFee)
+	now := time.Now().UTC()
+
+	for urgency, tier := range urgencyTiers {
+		tip := percentileTip(hist.Reward, tierIndex(tier.percentile))
+
+		// Headroom over the next base fee: the tier's static cushion plus
+		// the observed upward trend (rising fees need a higher ceiling to
+		// stay includable), capped so a spike cannot quote absurd fees.
+		headroom := tier.headroomPct
+		if trendPct > 0 {
+			extra := int64(trendPct)
+			if extra > 100 {
+				extra = 100
+			}
+			headroom += extra
+		}
+		maxFee := new(big.Int).Mul(nextBase, big.NewInt(100+headroom))
+		maxFee.Div(maxFee, big.NewInt(100))
+		maxFee.Add(maxFee, tip)
+
+		o.quotes[urgency] = OracleQuote{
+			BaseFee:              new(big.Int).Set(nextBase),
+			BaseFeeTrendPct:      trendPct,
+			MaxFeePerGas:         maxFee,
+			MaxPriorityFeePerGas: tip,
+			Urgency:              urgency,
+			QuotedAt:             now,
+		}
+		metrics.GasFeeEstimatedGwei.WithLabelValues(string(urgency)).Observe(weiToGwei(maxFee))
+	}
+	o.fetchedAt = now
+	return nil
+}
+
+// tierIndex maps a percentile to its position in oraclePercentiles.
+func tierIndex(percentile float64) int {
+	for i, p := range oraclePercentiles {
+		if p == percentile {
+			return i
+		}
+	}
+	return 1 // standard
+}
+
+// percentileTip returns the median of the chosen percentile's per-block
+// rewards — a robust middle of "what txs at this priority actually paid".
+func percentileTip(rewards [][]*big.Int, idx int) *big.Int {
+	var vals []*big.Int
+	for _, block := range rewards {
+		if idx < len(block) && block[idx] != nil {
+			vals = append(vals, block[idx])
+		}
+	}
+	if len(vals) == 0 {
+		return big.NewInt(1) // empty blocks everywhere; bid the minimum
+	}
+	sort.Slice(vals, func(i, j int) bool { return vals[i].Cmp(vals[j]) < 0 })
+	return new(big.Int).Set(vals[len(vals)/2])
+}
+
+// baseFeeTrendPct compares the newer half of the window against the older
+// half and returns the % change.
+func baseFeeTrendPct(baseFees []*big.Int) float64 {
+	half := len(baseFees) / 2
+	older := avgBig(baseFees[:half])
+	newer := avgBig(baseFees[half:])
+	if older == 0 {
+		return 0
+	}
+	return (newer - older) / older * 100
+}
+
+func avgBig(vals []*big.Int) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	sum := new(big.Int)
+	for _, v := range vals {
+		if v != nil {
+			sum.Add(sum, v)
+		}
+	}
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(sum), big.NewFloat(float64(len(vals)))).Float64()
+	return f
+}
+
+func weiToGwei(wei *big.Int) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e9)).Float64()
+	return f
+}
+
+// RecordRealizedCost observes how the effective gas price of a mined tx
+// compares to the estimated fee ceiling. A ratio near 1 means estimates
+// are tight; consistently low ratios mean the oracle is over-quoting.
+func RecordRealizedCost(estimatedMaxFee, effectiveGasPrice *big.Int) {
+	if estimatedMaxFee == nil || effectiveGasPrice == nil || estimatedMaxFee.Sign() <= 0 {
+		return
+	}
+	ratio, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(effectiveGasPrice),
+		new(big.Float).SetInt(estimatedMaxFee),
+	).Float64()
+	metrics.GasCostRealizedRatio.Observe(ratio)
+}
@@ -0,0 +1,177 @@
+package usdc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+)
+
+// stubFeeHistory serves a canned eth_feeHistory response and counts calls
+// so tests can assert on caching.
+type stubFeeHistory struct {
+	calls int
+	hist  *ethereum.FeeHistory
+	err   error
+}
+
+func (s *stubFeeHistory) FeeHistory(_ context.Context, _ uint64, _ *big.Int, _ []float64) (*ethereum.FeeHistory, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.hist, nil
+}
+
+// flatHistory builds a window of `blocks` blocks with a constant base fee
+// and constant per-percentile rewards.
+func flatHistory(blocks int, baseFee int64, rewards [3]int64) *ethereum.FeeHistory {
+	h := &ethereum.FeeHistory{}
+	for i := 0; i <= blocks; i++ {
+		h.BaseFee = append(h.BaseFee, big.NewInt(baseFee))
+	}
+	for i := 0; i < blocks; i++ {
+		h.Reward = append(h.Reward, []*big.Int{
+			big.NewInt(rewards[0]), big.NewInt(rewards[1]), big.NewInt(rewards[2]),
+		})
+	}
+	return h
+}
+
+func TestFeeOracle_TiersOrdered(t *testing.T) {
+	stub := &stubFeeHistory{hist: flatHistory(20, 1_000_000_000, [3]int64{100, 500, 2000})}
+	o := NewFeeOracle(stub)
+	ctx := context.Background()
+
+	low, err := o.Quote(ctx, UrgencyLow)
+	if err != nil {
+		t.Fatalf("Quote(low): %v", err)
+	}
+	std, _ := o.Quote(ctx, UrgencyStandard)
+	high, _ := o.Quote(ctx, UrgencyHigh)
+
+	if low.MaxPriorityFeePerGas.Int64() != 100 ||
+		std.MaxPriorityFeePerGas.Int64() != 500 ||
+		high.MaxPriorityFeePerGas.Int64() != 2000 {
+		t.Fatalf("tips = %v / %v / %v, want percentile rewards 100/500/2000",
+			low.MaxPriorityFeePerGas, std.MaxPriorityFeePerGas, high.MaxPriorityFeePerGas)
+	}
+	if low.MaxFeePerGas.Cmp(std.MaxFeePerGas) >= 0 || std.MaxFeePerGas.Cmp(high.MaxFeePerGas) >= 0 {
+		t.Fatalf("max fees not ordered: low=%v std=%v high=%v",
+			low.MaxFeePerGas, std.MaxFeePerGas, high.MaxFeePerGas)
+	}
+
+	// Flat history: headroom is the static tier cushion only.
+	// low = base*1.10 + tip
+	want := big.NewInt(1_100_000_000 + 100)
+	if low.MaxFeePerGas.Cmp(want) != 0 {
+		t.Fatalf("low MaxFeePerGas = %v, want %v", low.MaxFeePerGas, want)
+	}
+	if low.BaseFeeTrendPct != 0 {
+		t.Fatalf("flat history trend = %v, want 0", low.BaseFeeTrendPct)
+	}
+}
+
+func TestFeeOracle_RisingTrendWidensHeadroom(t *testing.T) {
+	// Base fee doubles across the window: the newer half averages well above
+	// the older half, so the quote should carry extra headroom.
+	hist := flatHistory(20, 0, [3]int64{1, 1, 1})
+	for i := range hist.BaseFee {
+		hist.BaseFee[i] = big.NewInt(1_000_000_000 + int64(i)*100_000_000)
+	}
+	o := NewFeeOracle(&stubFeeHistory{hist: hist})
+
+	q, err := o.Quote(context.Background(), UrgencyStandard)
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	if q.BaseFeeTrendPct <= 0 {
+		t.Fatalf("trend = %v, want positive", q.BaseFeeTrendPct)
+	}
+
+	// Static standard headroom is 25%; with the rising trend the ceiling
+	// must exceed nextBase * 1.25 + tip.
+	nextBase := hist.BaseFee[len(hist.BaseFee)-1]
+	static := new(big.Int).Mul(nextBase, big.NewInt(125))
+	static.Div(static, big.NewInt(100))
+	if q.MaxFeePerGas.Cmp(static) <= 0 {
+		t.Fatalf("MaxFeePerGas = %v, want > static headroom %v", q.MaxFeePerGas, static)
+	}
+}
+
+func TestFeeOracle_CachesWithinTTL(t *testing.T) {
+	stub := &stubFeeHistory{hist: flatHistory(20, 1_000_000_000, [3]int64{1, 2, 3})}
+	o := NewFeeOracle(stub)
+	ctx := context.Background()
+
+	for _, u := range []Urgency{UrgencyLow, UrgencyStandard, UrgencyHigh, ""} {
+		if _, err := o.Quote(ctx, u); err != nil {
+			t.Fatalf("Quote(%q): %v", u, err)
+		}
+	}
+	if stub.calls != 1 {
+		t.Fatalf("backend calls = %d, want 1 (shared cache)", stub.calls)
+	}
+
+	o.fetchedAt = time.Now().Add(-time.Minute) // expire the cache
+	if _, err := o.Quote(ctx, UrgencyStandard); err != nil {
+		t.Fatalf("Quote after expiry: %v", err)
+	}
+	if stub.calls != 2 {
+		t.Fatalf("backend calls = %d, want 2 after cache expiry", stub.calls)
+	}
+}
+
+func TestFeeOracle_EmptyUrgencyIsStandard(t *testing.T) {
+	o := NewFeeOracle(&stubFeeHistory{hist: flatHistory(20, 1_000_000_000, [3]int64{10, 20, 30})})
+	q, err := o.Quote(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Quote(\"\"): %v", err)
+	}
+	if q.Urgency != UrgencyStandard {
+		t.Fatalf("urgency = %q, want standard", q.Urgency)
+	}
+}
+
+func TestFeeOracle_UnknownUrgency(t *testing.T) {
+	o := NewFeeOracle(&stubFeeHistory{hist: flatHistory(20, 1, [3]int64{1, 1, 1})})
+	if _, err := o.Quote(context.Background(), Urgency("instant")); err == nil {
+		t.Fatal("expected error for unknown urgency")
+	}
+}
+
+func TestFeeOracle_BackendErrorPropagates(t *testing.T) {
+	o := NewFeeOracle(&stubFeeHistory{err: errors.New("rpc down")})
+	if _, err := o.Quote(context.Background(), UrgencyStandard); err == nil {
+		t.Fatal("expected error from failing backend")
+	}
+}
+
+func TestPercentileTip(t *testing.T) {
+	rewards := [][]*big.Int{
+		{big.NewInt(5)},
+		{big.NewInt(1)},
+		{big.NewInt(9)},
+	}
+	if got := percentileTip(rewards, 0); got.Int64() != 5 {
+		t.Fatalf("median tip = %v, want 5", got)
+	}
+	// No data at the requested percentile: bid the 1-wei minimum.
+	if got := percentileTip(rewards, 2); got.Int64() != 1 {
+		t.Fatalf("empty-percentile tip = %v, want 1", got)
+	}
+	if got := percentileTip(nil, 0); got.Int64() != 1 {
+		t.Fatalf("no-blocks tip = %v, want 1", got)
+	}
+}
+
+func TestRecordRealizedCost_Guards(t *testing.T) {
+	// Must not panic or divide by zero on missing data.
+	RecordRealizedCost(nil, big.NewInt(1))
+	RecordRealizedCost(big.NewInt(1), nil)
+	RecordRealizedCost(big.NewInt(0), big.NewInt(1))
+	RecordRealizedCost(big.NewInt(100), big.NewInt(80))
+}
@@ -0,0 +1,164 @@
+package usdc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// AWSCredentials are static SigV4 credentials. SessionToken is optional
+// (set for STS/instance-role credentials).
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AWSKMSBackend signs via an asymmetric AWS KMS key
+// (KeySpec ECC_SECG_P256K1, KeyUsage SIGN_VERIFY). Requests go straight to
+// the KMS JSON API with SigV4 — no SDK dependency.
+type AWSKMSBackend struct {
+	keyID    string
+	region   string
+	creds    AWSCredentials
+	endpoint string // overridable for tests
+	client   *http.Client
+}
+
+// NewAWSKMSBackend creates a backend for the given KMS key ID (or ARN/alias).
+func NewAWSKMSBackend(keyID, region string, creds AWSCredentials) *AWSKMSBackend {
+	return &AWSKMSBackend{
+		keyID:    keyID,
+		region:   region,
+		creds:    creds,
+		endpoint: fmt.Sprintf("https://kms.%s.amazonaws.com/", region),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// PublicKey fetches the key's DER SubjectPublicKeyInfo and extracts the
+// uncompressed secp256k1 point.
+func (b *AWSKMSBackend) PublicKey(ctx context.Context) ([]byte, error) {
+	var resp struct {
+		PublicKey []byte `json:"PublicKey"` // base64 handled by encoding/json
+	}
+	if err := b.call(ctx, "TrentService.GetPublicKey", map[string]interface{}{
+		"KeyId": b.keyID,
+	}, &resp); err != nil {
+		return nil, err
+	}
+	return publicKeyFromSPKI(resp.PublicKey)
+}
+
+// SignDigest signs the raw digest (MessageType DIGEST) and returns the DER
+// signature.
+func (b *AWSKMSBackend) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var resp struct {
+		Signature []byte `json:"Signature"`
+	}
+	if err := b.call(ctx, "TrentService.Sign", map[string]interface{}{
+		"KeyId":            b.keyID,
+		"Message":          base64.StdEncoding.EncodeToString(digest),
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// call performs one SigV4-signed KMS JSON API request.
+func (b *AWSKMSBackend) call(ctx context.Context, target string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("usdc: aws kms: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("usdc: aws kms: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+	b.signV4(req, payload, time.Now().UTC())
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("usdc: aws kms: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("usdc: aws kms: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("usdc: aws kms: %s returned %d: %s", target, resp.StatusCode, string(data))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("usdc: aws kms: decode response: %w", err)
+	}
+	return nil
+}
+
+// signV4 applies AWS Signature Version 4 to the request.
+func (b *AWSKMSBackend) signV4(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if b.creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", b.creds.SessionToken)
+	}
+
+	payloadHash := sha256.Sum256(payload)
+	signedHeaders := "content-type;host;x-amz-date;x-amz-target"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-date:" + amzDate + "\n" +
+		"x-amz-target:" + req.Header.Get("X-Amz-Target") + "\n"
+	if b.creds.SessionToken != "" {
+		signedHeaders = "content-type;host;x-amz-date;x-amz-security-token;x-amz-target"
+		canonicalHeaders = "content-type:" + req.Header.Get("Content-Type") + "\n" +
+			"host:" + req.URL.Host + "\n" +
+			"x-amz-date:" + amzDate + "\n" +
+			"x-amz-security-token:" + b.creds.SessionToken + "\n" +
+			"x-amz-target:" + req.Header.Get("X-Amz-Target") + "\n"
+	}
+
+	canonicalRequest := "POST\n/\n\n" + canonicalHeaders + "\n" + signedHeaders + "\n" +
+		hex.EncodeToString(payloadHash[:])
+	crHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := dateStamp + "/" + b.region + "/kms/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(crHash[:])
+
+	signingKey := hmacSHA256(
+		hmacSHA256(
+			hmacSHA256(
+				hmacSHA256([]byte("AWS4"+b.creds.SecretAccessKey), dateStamp),
+				b.region),
+			"kms"),
+		"aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization",
+		"AWS4-HMAC-SHA256 Credential="+b.creds.AccessKeyID+"/"+scope+
+			", SignedHeaders="+signedHeaders+
+			", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+var _ SigningBackend = (*AWSKMSBackend)(nil)
@@ -205,6 +205,9 @@ func (s *PayoutService) Send(ctx context.Context, req TransferRequest) (*Payout,
 	payout.Status = receipt.Status
 	now := time.Now().UTC()
 	payout.FinalizedAt = &now
+	if receipt.Status == TxStatusSuccess {
+		RecordRealizedCost(req.FeeQuote.MaxFeePerGas, receipt.EffectiveGas)
+	}
 	if err := s.store.Put(ctx, payout); err != nil {
 		return nil, fmt.Errorf("persist final payout: %w", err)
 	}
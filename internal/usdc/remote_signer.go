@@ -0,0 +1,107 @@
+package usdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RemoteSigner signs via a self-hosted signer daemon (e.g. an HSM gateway)
+// speaking a minimal JSON protocol:
+//
+//	GET  {base}/publicKey            -> {"publicKey": "0x04..."}
+//	POST {base}/sign {"digest":"0x"} -> {"signature": "0x..."}
+//
+// The signature may be DER or raw r||s(/||v). An optional bearer token
+// authenticates requests.
+type RemoteSigner struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewRemoteSigner creates a backend for the given signer base URL.
+func NewRemoteSigner(baseURL, token string) *RemoteSigner {
+	return &RemoteSigner{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (r *RemoteSigner) PublicKey(ctx context.Context) ([]byte, error) {
+	var resp struct {
+		PublicKey string `json:"publicKey"`
+	}
+	if err := r.call(ctx, http.MethodGet, "/publicKey", nil, &resp); err != nil {
+		return nil, err
+	}
+	pub, err := hexutil.Decode(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("usdc: remote signer: decode public key: %w", err)
+	}
+	return pub, nil
+}
+
+func (r *RemoteSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	body := map[string]string{"digest": hexutil.Encode(digest)}
+	var resp struct {
+		Signature string `json:"signature"`
+	}
+	if err := r.call(ctx, http.MethodPost, "/sign", body, &resp); err != nil {
+		return nil, err
+	}
+	sig, err := hexutil.Decode(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("usdc: remote signer: decode signature: %w", err)
+	}
+	return sig, nil
+}
+
+func (r *RemoteSigner) call(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("usdc: remote signer: marshal request: %w", err)
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, r.baseURL+path, payload)
+	if err != nil {
+		return fmt.Errorf("usdc: remote signer: build request: %w", err)
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("usdc: remote signer: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("usdc: remote signer: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("usdc: remote signer: %s returned %d: %s", path, resp.StatusCode, string(data))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("usdc: remote signer: decode response: %w", err)
+	}
+	return nil
+}
+
+var _ SigningBackend = (*RemoteSigner)(nil)
@@ -0,0 +1,146 @@
+package usdc
+
+import (
+	"context"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SigningBackend performs secp256k1 signing without exposing the private
+// key to this process. Implementations call out to AWS KMS, GCP Cloud KMS,
+// or a remote signer daemon; the hot key never lives in process memory.
+//
+// Implementations must be safe for concurrent use.
+type SigningBackend interface {
+	// PublicKey returns the uncompressed 65-byte secp256k1 public key
+	// (0x04-prefixed point).
+	PublicKey(ctx context.Context) ([]byte, error)
+
+	// SignDigest signs a 32-byte digest. The signature may be ASN.1 DER
+	// or raw r||s (64 bytes) / r||s||v (65 bytes); BackendWallet
+	// normalizes either form.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// BackendWallet adapts a SigningBackend to the Wallet interface. It caches
+// the address derived from the backend's public key at construction and
+// normalizes backend signatures to Ethereum's 65-byte r||s||v form with a
+// low S value (EIP-2) and a recovered V.
+type BackendWallet struct {
+	backend SigningBackend
+	pubkey  []byte // uncompressed, 65 bytes
+	address string
+	mu      sync.Mutex
+}
+
+// NewBackendWallet resolves the backend's public key and returns a Wallet
+// bound to it. Fails fast when the backend is unreachable or the key is
+// not an uncompressed secp256k1 point.
+func NewBackendWallet(ctx context.Context, backend SigningBackend) (*BackendWallet, error) {
+	if backend == nil {
+		return nil, errors.New("usdc: BackendWallet requires a signing backend")
+	}
+	pub, err := backend.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("usdc: backend public key: %w", err)
+	}
+	if len(pub) != 65 || pub[0] != 0x04 {
+		return nil, fmt.Errorf("usdc: backend returned %d-byte public key, want 65-byte uncompressed point", len(pub))
+	}
+	pubkey, err := crypto.UnmarshalPubkey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("usdc: parse backend public key: %w", err)
+	}
+	return &BackendWallet{
+		backend: backend,
+		pubkey:  pub,
+		address: strings.ToLower(crypto.PubkeyToAddress(*pubkey).Hex()),
+	}, nil
+}
+
+// Address returns the 0x-prefixed lowercase address.
+func (w *BackendWallet) Address() string {
+	return w.address
+}
+
+// Sign signs the 32-byte digest via the backend and returns a 65-byte
+// r || s || v signature with v in {0, 1}, matching EthWallet.Sign.
+func (w *BackendWallet) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("usdc: BackendWallet.Sign requires a 32-byte digest, got %d", len(digest))
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	raw, err := w.backend.SignDigest(ctx, digest)
+	if err != nil {
+		return nil, fmt.Errorf("usdc: backend sign: %w", err)
+	}
+	r, s, err := parseBackendSignature(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize to low S (EIP-2); KMS backends sign with either half.
+	curveN := crypto.S256().Params().N
+	if s.Cmp(new(big.Int).Rsh(curveN, 1)) > 0 {
+		s = new(big.Int).Sub(curveN, s)
+	}
+
+	sig := make([]byte, 65)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+
+	// The backend doesn't tell us the recovery id — find it by recovering.
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		recovered, err := crypto.Ecrecover(digest, sig)
+		if err == nil && string(recovered) == string(w.pubkey) {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("usdc: backend signature does not recover to wallet key")
+}
+
+// ecdsaSignature is the ASN.1 DER structure KMS services return.
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// parseBackendSignature accepts DER, raw r||s (64 bytes), or r||s||v
+// (65 bytes, v discarded — recovery is recomputed).
+func parseBackendSignature(raw []byte) (*big.Int, *big.Int, error) {
+	switch len(raw) {
+	case 64, 65:
+		return new(big.Int).SetBytes(raw[:32]), new(big.Int).SetBytes(raw[32:64]), nil
+	}
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(raw, &sig); err != nil {
+		return nil, nil, fmt.Errorf("usdc: parse backend signature: %w", err)
+	}
+	return sig.R, sig.S, nil
+}
+
+// spki is the ASN.1 SubjectPublicKeyInfo wrapper KMS services return keys in.
+type spki struct {
+	Algorithm asn1.RawValue
+	PublicKey asn1.BitString
+}
+
+// publicKeyFromSPKI extracts the uncompressed point from a DER-encoded
+// SubjectPublicKeyInfo.
+func publicKeyFromSPKI(der []byte) ([]byte, error) {
+	var info spki
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("usdc: parse SubjectPublicKeyInfo: %w", err)
+	}
+	return info.PublicKey.Bytes, nil
+}
+
+var _ Wallet = (*BackendWallet)(nil)
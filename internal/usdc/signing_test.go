@@ -0,0 +1,197 @@
+package usdc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// derBackend signs locally but speaks the backend protocol (DER signatures,
+// SPKI-free uncompressed pubkey), standing in for a KMS.
+type derBackend struct {
+	priv  *ecdsa.PrivateKey
+	highS bool // return the high-S half to exercise normalization
+	raw   bool // return raw r||s instead of DER
+}
+
+func (b *derBackend) PublicKey(context.Context) ([]byte, error) {
+	return crypto.FromECDSAPub(&b.priv.PublicKey), nil
+}
+
+func (b *derBackend) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, b.priv)
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if b.highS {
+		s = new(big.Int).Sub(crypto.S256().Params().N, s)
+	}
+	if b.raw {
+		out := make([]byte, 64)
+		r.FillBytes(out[:32])
+		s.FillBytes(out[32:])
+		return out, nil
+	}
+	return asn1.Marshal(ecdsaSignature{R: r, S: s})
+}
+
+func testDigest() []byte {
+	return crypto.Keccak256([]byte("test payload"))
+}
+
+func TestBackendWallet_AddressAndSign(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	w, err := NewBackendWallet(ctx, &derBackend{priv: priv})
+	if err != nil {
+		t.Fatalf("NewBackendWallet failed: %v", err)
+	}
+	want := strings.ToLower(crypto.PubkeyToAddress(priv.PublicKey).Hex())
+	if w.Address() != want {
+		t.Errorf("address = %s, want %s", w.Address(), want)
+	}
+
+	digest := testDigest()
+	sig, err := w.Sign(ctx, digest)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if len(sig) != 65 {
+		t.Fatalf("expected 65-byte signature, got %d", len(sig))
+	}
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		t.Fatalf("recover failed: %v", err)
+	}
+	if got := strings.ToLower(crypto.PubkeyToAddress(*pub).Hex()); got != want {
+		t.Errorf("signature recovers to %s, want %s", got, want)
+	}
+}
+
+func TestBackendWallet_NormalizesHighS(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	w, err := NewBackendWallet(ctx, &derBackend{priv: priv, highS: true})
+	if err != nil {
+		t.Fatalf("NewBackendWallet failed: %v", err)
+	}
+	digest := testDigest()
+	sig, err := w.Sign(ctx, digest)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	s := new(big.Int).SetBytes(sig[32:64])
+	halfN := new(big.Int).Rsh(crypto.S256().Params().N, 1)
+	if s.Cmp(halfN) > 0 {
+		t.Error("expected low-S signature after normalization")
+	}
+	if _, err := crypto.SigToPub(digest, sig); err != nil {
+		t.Errorf("normalized signature does not recover: %v", err)
+	}
+}
+
+func TestBackendWallet_AcceptsRawSignature(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	w, err := NewBackendWallet(ctx, &derBackend{priv: priv, raw: true})
+	if err != nil {
+		t.Fatalf("NewBackendWallet failed: %v", err)
+	}
+	if _, err := w.Sign(ctx, testDigest()); err != nil {
+		t.Errorf("Sign failed on raw r||s signature: %v", err)
+	}
+}
+
+func TestBackendWallet_RejectsBadPublicKey(t *testing.T) {
+	if _, err := NewBackendWallet(context.Background(), badKeyBackend{}); err == nil {
+		t.Error("expected error for compressed public key")
+	}
+}
+
+type badKeyBackend struct{}
+
+func (badKeyBackend) PublicKey(context.Context) ([]byte, error) {
+	return []byte{0x02, 0x01}, nil // compressed / truncated
+}
+
+func (badKeyBackend) SignDigest(context.Context, []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func TestRemoteSigner(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := &derBackend{priv: priv}
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer sekrit" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/publicKey":
+			pub, _ := local.PublicKey(r.Context())
+			_ = json.NewEncoder(w).Encode(map[string]string{"publicKey": hexutil.Encode(pub)})
+		case "/sign":
+			var req struct {
+				Digest string `json:"digest"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			digest, _ := hexutil.Decode(req.Digest)
+			sig, _ := local.SignDigest(r.Context(), digest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"signature": hexutil.Encode(sig)})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	w, err := NewBackendWallet(ctx, NewRemoteSigner(srv.URL, "sekrit"))
+	if err != nil {
+		t.Fatalf("NewBackendWallet failed: %v", err)
+	}
+	digest := testDigest()
+	sig, err := w.Sign(ctx, digest)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	pub, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		t.Fatalf("recover failed: %v", err)
+	}
+	want := strings.ToLower(crypto.PubkeyToAddress(priv.PublicKey).Hex())
+	if got := strings.ToLower(crypto.PubkeyToAddress(*pub).Hex()); got != want {
+		t.Errorf("signature recovers to %s, want %s", got, want)
+	}
+
+	// Wrong token is rejected.
+	if _, err := NewBackendWallet(ctx, NewRemoteSigner(srv.URL, "wrong")); err == nil {
+		t.Error("expected error with bad auth token")
+	}
+}
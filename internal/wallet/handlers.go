@@ -0,0 +1,54 @@
+package wallet
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler exposes tracked-transaction status over HTTP.
+// Mount under an admin-gated group — it reveals nonces and fee strategy.
+type Handler struct {
+	mgr *Manager
+}
+
+// NewHandler creates a transaction-status handler.
+func NewHandler(mgr *Manager) *Handler {
+	return &Handler{mgr: mgr}
+}
+
+// RegisterRoutes wires admin wallet-transaction routes on the given group.
+func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
+	r.GET("/admin/wallet/transactions", h.ListTransactions)
+	r.GET("/admin/wallet/transactions/:id", h.GetTransaction)
+}
+
+// ListTransactions handles GET /v1/admin/wallet/transactions
+func (h *Handler) ListTransactions(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	txs := h.mgr.List(TxState(c.Query("state")), limit)
+	c.JSON(http.StatusOK, gin.H{"transactions": txs, "count": len(txs)})
+}
+
+// GetTransaction handles GET /v1/admin/wallet/transactions/:id
+// Accepts either a manager ID (mtx_...) or any broadcast tx hash,
+// including hashes superseded by a fee bump.
+func (h *Handler) GetTransaction(c *gin.Context) {
+	id := c.Param("id")
+	tx, err := h.mgr.Get(id)
+	if err != nil && strings.HasPrefix(id, "0x") {
+		tx, err = h.mgr.GetByHash(id)
+	}
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not_found", "message": "Transaction not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "get_failed", "message": "Failed to get transaction"})
+		return
+	}
+	c.JSON(http.StatusOK, tx)
+}
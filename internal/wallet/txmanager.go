@@ -0,0 +1,492 @@
+// Package wallet manages the platform wallet's outbound transactions.
+//
+// The Manager layers three things over the usdc chain primitives:
+//   - nonce tracking: each submission reserves a nonce through the shared
+//     NonceManager so concurrent senders cannot collide;
+//   - stuck-transaction detection: a background sweep polls receipts and
+//     flags transactions the mempool has not included within StuckAfter;
+//   - automatic replacement: stuck transactions are rebroadcast at the
+//     same nonce with fees bumped past the relay minimum, up to
+//     MaxReplacements times.
+//
+// Callers (e.g. the withdrawal handler) can query a transaction's state —
+// pending, replaced, confirmed, or failed — by ID or by any of its hashes,
+// including hashes superseded by a fee bump.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+var (
+	ErrNotFound       = errors.New("wallet: transaction not found")
+	ErrInvalidRequest = errors.New("wallet: invalid transfer request")
+)
+
+// TxState is the manager's view of a tracked transaction.
+type TxState string
+
+const (
+	StatePending TxState = "pending" // broadcast, awaiting inclusion + confirmations
+	// StateReplaced means the transaction was rebroadcast with bumped fees
+	// at least once and is still awaiting inclusion. The superseded hashes
+	// remain queryable and resolve to the same record.
+	StateReplaced  TxState = "replaced"
+	StateConfirmed TxState = "confirmed"
+	StateFailed    TxState = "failed" // mined but reverted
+)
+
+// ManagedTx is the durable record of one tracked transfer.
+type ManagedTx struct {
+	ID             string     `json:"id"`
+	ClientRef      string     `json:"clientRef,omitempty"`
+	TxHash         string     `json:"txHash"` // latest broadcast hash
+	ReplacedHashes []string   `json:"replacedHashes,omitempty"`
+	Nonce          uint64     `json:"nonce"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	Amount         string     `json:"amount"` // smallest USDC unit, decimal string
+	State          TxState    `json:"state"`
+	Attempts       int        `json:"attempts"` // broadcast count; 1 = never replaced
+	MaxFeePerGas   string     `json:"maxFeePerGas"`
+	MaxPriorityFee string     `json:"maxPriorityFeePerGas"`
+	BlockNumber    uint64     `json:"blockNumber,omitempty"`
+	SubmittedAt    time.Time  `json:"submittedAt"`
+	LastBroadcast  time.Time  `json:"lastBroadcastAt"`
+	ConfirmedAt    *time.Time `json:"confirmedAt,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+func (t *ManagedTx) terminal() bool {
+	return t.State == StateConfirmed || t.State == StateFailed
+}
+
+// Config tunes the sweep/replacement behavior. Zero fields take defaults.
+type Config struct {
+	Confirmations   uint64        // blocks before declaring Confirmed (default 12)
+	StuckAfter      time.Duration // rebroadcast when unmined this long (default 90s)
+	BumpPct         uint64        // fee increase per replacement; most relays require >= 10 (default 15)
+	MaxReplacements int           // replacement attempts per tx (default 3)
+	PollInterval    time.Duration // receipt sweep interval (default 5s)
+}
+
+func applyDefaults(cfg Config) Config {
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = 12
+	}
+	if cfg.StuckAfter == 0 {
+		cfg.StuckAfter = 90 * time.Second
+	}
+	if cfg.BumpPct == 0 {
+		cfg.BumpPct = 15
+	}
+	if cfg.MaxReplacements == 0 {
+		cfg.MaxReplacements = 3
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	return cfg
+}
+
+// Manager tracks outbound transfers from submission to confirmation.
+type Manager struct {
+	client usdc.ChainClient
+	wallet usdc.Wallet
+	nonces usdc.NonceManager
+	cfg    Config
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	txs    map[string]*ManagedTx // by ID
+	byHash map[string]string     // every broadcast hash (incl. replaced) -> ID
+
+	running  atomic.Bool
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewManager wires a transaction manager over the given chain client,
+// signing wallet, and nonce manager. Share the nonce manager with any
+// other sender using the same wallet (e.g. the payout service) so nonce
+// issuance stays serialized.
+func NewManager(client usdc.ChainClient, w usdc.Wallet, nonces usdc.NonceManager, cfg Config, logger *slog.Logger) (*Manager, error) {
+	if client == nil || w == nil || nonces == nil {
+		return nil, errors.New("wallet: client, wallet, and nonces are required")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Manager{
+		client: client,
+		wallet: w,
+		nonces: nonces,
+		cfg:    applyDefaults(cfg),
+		logger: logger,
+		txs:    make(map[string]*ManagedTx),
+		byHash: make(map[string]string),
+		stop:   make(chan struct{}),
+	}, nil
+}
+
+// Submit reserves a nonce, quotes fees, broadcasts a USDC transfer, and
+// starts tracking it. Returns the pending record immediately; inclusion
+// is driven by the background sweep (or Await).
+func (m *Manager) Submit(ctx context.Context, to string, amount *big.Int, clientRef string) (*ManagedTx, error) {
+	if !common.IsHexAddress(to) || amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidRequest
+	}
+	from := strings.ToLower(m.wallet.Address())
+
+	onchain, err := m.client.PendingNonce(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := m.nonces.Next(ctx, from, onchain)
+	if err != nil {
+		return nil, err
+	}
+
+	req := usdc.TransferRequest{
+		ChainID:   m.client.ChainID(),
+		FromAddr:  from,
+		ToAddr:    strings.ToLower(to),
+		Amount:    amount,
+		ClientRef: clientRef,
+		Nonce:     nonce,
+		Urgency:   usdc.UrgencyStandard,
+	}
+	quote, err := m.client.EstimateFee(ctx, req)
+	if err != nil {
+		m.nonces.Release(from, nonce, false)
+		return nil, err
+	}
+	req.FeeQuote = quote
+
+	sub, err := m.client.SendTransfer(ctx, req, m.wallet)
+	if err != nil {
+		m.nonces.Release(from, nonce, false)
+		return nil, err
+	}
+	m.nonces.Release(from, nonce, true)
+
+	now := time.Now().UTC()
+	tx := &ManagedTx{
+		ID:             idgen.WithPrefix("mtx_"),
+		ClientRef:      clientRef,
+		TxHash:         sub.TxHash,
+		Nonce:          nonce,
+		From:           from,
+		To:             req.ToAddr,
+		Amount:         amount.String(),
+		State:          StatePending,
+		Attempts:       1,
+		MaxFeePerGas:   quote.MaxFeePerGas.String(),
+		MaxPriorityFee: quote.MaxPriorityFeePerGas.String(),
+		SubmittedAt:    now,
+		LastBroadcast:  now,
+	}
+
+	m.mu.Lock()
+	m.txs[tx.ID] = tx
+	m.byHash[strings.ToLower(sub.TxHash)] = tx.ID
+	m.mu.Unlock()
+
+	m.logger.Info("wallet: transfer submitted",
+		"tx_id", tx.ID, "tx_hash", tx.TxHash, "nonce", nonce, "to", tx.To, "amount", tx.Amount)
+	return copyTx(tx), nil
+}
+
+// Get returns a tracked transaction by ID.
+func (m *Manager) Get(id string) (*ManagedTx, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tx, ok := m.txs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyTx(tx), nil
+}
+
+// GetByHash resolves any broadcast hash — including hashes superseded by
+// a fee bump — to its transaction record.
+func (m *Manager) GetByHash(hash string) (*ManagedTx, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id, ok := m.byHash[strings.ToLower(hash)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyTx(m.txs[id]), nil
+}
+
+// List returns tracked transactions, newest first, optionally filtered by state.
+func (m *Manager) List(state TxState, limit int) []*ManagedTx {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*ManagedTx
+	for _, tx := range m.txs {
+		if state != "" && tx.State != state {
+			continue
+		}
+		out = append(out, copyTx(tx))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SubmittedAt.After(out[j].SubmittedAt) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// Await blocks until the transaction reaches a terminal state or the
+// context is done, re-checking at the sweep interval. For callers that
+// need synchronous semantics (e.g. a withdrawal request in flight).
+func (m *Manager) Await(ctx context.Context, id string) (*ManagedTx, error) {
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		tx, err := m.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if tx.terminal() {
+			return tx, nil
+		}
+		select {
+		case <-ctx.Done():
+			return tx, ctx.Err()
+		case <-ticker.C:
+			m.sweep(ctx)
+		}
+	}
+}
+
+// Start runs the receipt sweep until the context is cancelled or Stop is
+// called. Blocks; run it in a goroutine.
+func (m *Manager) Start(ctx context.Context) {
+	if !m.running.CompareAndSwap(false, true) {
+		return
+	}
+	defer m.running.Store(false)
+
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.sweep(ctx)
+		}
+	}
+}
+
+// Stop terminates the sweep loop. Safe to call multiple times.
+func (m *Manager) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+}
+
+// Running reports whether the sweep loop is active.
+func (m *Manager) Running() bool {
+	return m.running.Load()
+}
+
+// sweep checks every in-flight transaction once.
+func (m *Manager) sweep(ctx context.Context) {
+	m.mu.Lock()
+	var inflight []*ManagedTx
+	for _, tx := range m.txs {
+		if !tx.terminal() {
+			inflight = append(inflight, tx)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, tx := range inflight {
+		if ctx.Err() != nil {
+			return
+		}
+		m.check(ctx, tx.ID)
+	}
+}
+
+// check polls receipts for one transaction (latest hash first, then any
+// replaced hashes, since a superseded broadcast can still be the one that
+// mines) and replaces it when stuck.
+func (m *Manager) check(ctx context.Context, id string) {
+	m.mu.Lock()
+	tx, ok := m.txs[id]
+	if !ok || tx.terminal() {
+		m.mu.Unlock()
+		return
+	}
+	hashes := append([]string{tx.TxHash}, tx.ReplacedHashes...)
+	lastBroadcast := tx.LastBroadcast
+	attempts := tx.Attempts
+	m.mu.Unlock()
+
+	mined := false
+	for _, h := range hashes {
+		receipt, err := m.client.GetReceipt(ctx, h, m.cfg.Confirmations)
+		if err != nil {
+			continue // not known to the chain under this hash
+		}
+		switch receipt.Status {
+		case usdc.TxStatusSuccess:
+			m.finalize(id, h, StateConfirmed, receipt.BlockNumber, receipt.EffectiveGas, "")
+			return
+		case usdc.TxStatusFailed:
+			m.finalize(id, h, StateFailed, receipt.BlockNumber, receipt.EffectiveGas, "transaction reverted")
+			return
+		case usdc.TxStatusPending:
+			if receipt.BlockNumber > 0 {
+				mined = true // included, accruing confirmations — not stuck
+			}
+		}
+	}
+
+	if mined || time.Since(lastBroadcast) < m.cfg.StuckAfter {
+		return
+	}
+	if attempts > m.cfg.MaxReplacements {
+		m.logger.Warn("wallet: transaction still unmined after max replacements",
+			"tx_id", id, "attempts", attempts)
+		return
+	}
+	m.replace(ctx, id)
+}
+
+// finalize records a terminal state under the hash that actually mined.
+func (m *Manager) finalize(id, minedHash string, state TxState, block uint64, effectiveGas *big.Int, errMsg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tx, ok := m.txs[id]
+	if !ok || tx.terminal() {
+		return
+	}
+	if !strings.EqualFold(tx.TxHash, minedHash) {
+		// An earlier, supposedly replaced broadcast won the race. Promote it
+		// to the canonical hash; the newer one will be rejected by the chain.
+		for i, h := range tx.ReplacedHashes {
+			if strings.EqualFold(h, minedHash) {
+				tx.ReplacedHashes[i] = tx.TxHash
+				tx.TxHash = minedHash
+				break
+			}
+		}
+	}
+	tx.State = state
+	tx.BlockNumber = block
+	tx.Error = errMsg
+	if state == StateConfirmed {
+		now := time.Now().UTC()
+		tx.ConfirmedAt = &now
+		if maxFee, ok := new(big.Int).SetString(tx.MaxFeePerGas, 10); ok {
+			usdc.RecordRealizedCost(maxFee, effectiveGas)
+		}
+	}
+	m.logger.Info("wallet: transfer finalized",
+		"tx_id", tx.ID, "tx_hash", tx.TxHash, "state", tx.State, "block", block)
+}
+
+// replace rebroadcasts a stuck transaction at the same nonce with fees
+// bumped at least BumpPct above the previous attempt (relays reject
+// replacements below their bump minimum, typically 10%).
+func (m *Manager) replace(ctx context.Context, id string) {
+	m.mu.Lock()
+	tx, ok := m.txs[id]
+	if !ok || tx.terminal() {
+		m.mu.Unlock()
+		return
+	}
+	prevFee, _ := new(big.Int).SetString(tx.MaxFeePerGas, 10)
+	prevTip, _ := new(big.Int).SetString(tx.MaxPriorityFee, 10)
+	amount, _ := new(big.Int).SetString(tx.Amount, 10)
+	req := usdc.TransferRequest{
+		ChainID:   m.client.ChainID(),
+		FromAddr:  tx.From,
+		ToAddr:    tx.To,
+		Amount:    amount,
+		ClientRef: tx.ClientRef,
+		Nonce:     tx.Nonce,
+		Urgency:   usdc.UrgencyHigh,
+	}
+	m.mu.Unlock()
+
+	quote, err := m.client.EstimateFee(ctx, req)
+	if err != nil {
+		m.logger.Warn("wallet: re-quote for replacement failed", "tx_id", id, "err", err)
+		return
+	}
+	quote.MaxFeePerGas = bumped(quote.MaxFeePerGas, prevFee, m.cfg.BumpPct)
+	quote.MaxPriorityFeePerGas = bumped(quote.MaxPriorityFeePerGas, prevTip, m.cfg.BumpPct)
+	req.FeeQuote = quote
+
+	sub, err := m.client.SendTransfer(ctx, req, m.wallet)
+	if err != nil {
+		// "nonce too low" and friends usually mean the original broadcast
+		// just mined; the next sweep will pick its receipt up.
+		m.logger.Warn("wallet: replacement broadcast failed", "tx_id", id, "nonce", req.Nonce, "err", err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tx, ok = m.txs[id]
+	if !ok || tx.terminal() {
+		return
+	}
+	tx.ReplacedHashes = append(tx.ReplacedHashes, tx.TxHash)
+	tx.TxHash = sub.TxHash
+	tx.State = StateReplaced
+	tx.Attempts++
+	tx.MaxFeePerGas = quote.MaxFeePerGas.String()
+	tx.MaxPriorityFee = quote.MaxPriorityFeePerGas.String()
+	tx.LastBroadcast = time.Now().UTC()
+	m.byHash[strings.ToLower(sub.TxHash)] = tx.ID
+
+	m.logger.Info("wallet: stuck transaction replaced",
+		"tx_id", tx.ID, "nonce", tx.Nonce, "attempt", tx.Attempts,
+		"tx_hash", tx.TxHash, "max_fee", tx.MaxFeePerGas)
+}
+
+// bumped returns max(fresh, prev * (100+pct)/100) so a replacement always
+// clears the relay's minimum bump even if the fresh quote dropped.
+func bumped(fresh, prev *big.Int, pct uint64) *big.Int {
+	if prev == nil {
+		return fresh
+	}
+	min := new(big.Int).Mul(prev, big.NewInt(int64(100+pct))) //nolint:gosec // bump pct fits int64
+	min.Div(min, big.NewInt(100))
+	if fresh == nil || fresh.Cmp(min) < 0 {
+		return min
+	}
+	return fresh
+}
+
+func copyTx(tx *ManagedTx) *ManagedTx {
+	c := *tx
+	c.ReplacedHashes = append([]string(nil), tx.ReplacedHashes...)
+	if tx.ConfirmedAt != nil {
+		t := *tx.ConfirmedAt
+		c.ConfirmedAt = &t
+	}
+	return &c
+}
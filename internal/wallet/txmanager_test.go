@@ -0,0 +1,284 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/usdc"
+)
+
+const (
+	testFrom = "0x1111111111111111111111111111111111111111"
+	testTo   = "0x2222222222222222222222222222222222222222"
+)
+
+// stubChain is a controllable usdc.ChainClient: tests set receipts per
+// hash and inspect recorded broadcasts.
+type stubChain struct {
+	mu sync.Mutex
+
+	nonce    uint64
+	sends    []usdc.TransferRequest
+	receipts map[string]usdc.TxReceipt // hash -> receipt; absent = not found
+	sendErr  error
+}
+
+func newStubChain() *stubChain {
+	return &stubChain{receipts: map[string]usdc.TxReceipt{}}
+}
+
+func (c *stubChain) ChainID() int64 { return 84532 }
+
+func (c *stubChain) PendingNonce(context.Context, string) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nonce, nil
+}
+
+func (c *stubChain) EstimateFee(context.Context, usdc.TransferRequest) (usdc.FeeQuote, error) {
+	return usdc.FeeQuote{
+		BaseFee:              big.NewInt(100),
+		MaxFeePerGas:         big.NewInt(1000),
+		MaxPriorityFeePerGas: big.NewInt(100),
+		EstimatedGas:         60_000,
+		QuotedAt:             time.Now(),
+	}, nil
+}
+
+func (c *stubChain) SendTransfer(_ context.Context, req usdc.TransferRequest, _ usdc.Wallet) (usdc.SubmittedTx, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sendErr != nil {
+		return usdc.SubmittedTx{}, c.sendErr
+	}
+	c.sends = append(c.sends, req)
+	return usdc.SubmittedTx{
+		TxHash:      fmt.Sprintf("0xhash%d", len(c.sends)),
+		ChainID:     84532,
+		Nonce:       req.Nonce,
+		SubmittedAt: time.Now(),
+	}, nil
+}
+
+func (c *stubChain) GetReceipt(_ context.Context, txHash string, _ uint64) (usdc.TxReceipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.receipts[txHash]
+	if !ok {
+		return usdc.TxReceipt{TxHash: txHash, Status: usdc.TxStatusPending}, usdc.ErrReceiptNotFound
+	}
+	return r, nil
+}
+
+func (c *stubChain) setReceipt(hash string, status usdc.TxStatus, block uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.receipts[hash] = usdc.TxReceipt{TxHash: hash, Status: status, BlockNumber: block}
+}
+
+func (c *stubChain) sendCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.sends)
+}
+
+func newTestManager(t *testing.T) (*Manager, *stubChain) {
+	t.Helper()
+	chain := newStubChain()
+	w, err := usdc.NewStubWallet(testFrom, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewManager(chain, w, usdc.NewInMemoryNonceManager(), Config{
+		Confirmations: 2,
+		StuckAfter:    time.Minute,
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return m, chain
+}
+
+// backdate marks the tx's last broadcast as old enough to be stuck.
+func backdate(m *Manager, id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.txs[id].LastBroadcast = time.Now().Add(-time.Hour)
+}
+
+func TestSubmit_TracksPendingTx(t *testing.T) {
+	m, chain := newTestManager(t)
+	chain.nonce = 7
+
+	tx, err := m.Submit(context.Background(), testTo, big.NewInt(1_000_000), "ref-1")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if tx.State != StatePending || tx.Nonce != 7 || tx.Attempts != 1 {
+		t.Errorf("got state=%q nonce=%d attempts=%d", tx.State, tx.Nonce, tx.Attempts)
+	}
+	byHash, err := m.GetByHash(tx.TxHash)
+	if err != nil || byHash.ID != tx.ID {
+		t.Errorf("GetByHash = %v, %v", byHash, err)
+	}
+}
+
+func TestSubmit_Validation(t *testing.T) {
+	m, chain := newTestManager(t)
+	ctx := context.Background()
+
+	if _, err := m.Submit(ctx, "nope", big.NewInt(1), "r"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("bad address: err = %v", err)
+	}
+	if _, err := m.Submit(ctx, testTo, big.NewInt(0), "r"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("zero amount: err = %v", err)
+	}
+
+	// Broadcast failure releases the nonce for reuse.
+	chain.sendErr = errors.New("mempool full")
+	if _, err := m.Submit(ctx, testTo, big.NewInt(1), "r"); err == nil {
+		t.Fatal("expected send error")
+	}
+	chain.sendErr = nil
+	tx, err := m.Submit(ctx, testTo, big.NewInt(1), "r2")
+	if err != nil {
+		t.Fatalf("Submit after failure: %v", err)
+	}
+	if tx.Nonce != 0 {
+		t.Errorf("nonce = %d, want 0 (reused after failed send)", tx.Nonce)
+	}
+}
+
+func TestSweep_ConfirmsMinedTx(t *testing.T) {
+	m, chain := newTestManager(t)
+	ctx := context.Background()
+
+	tx, err := m.Submit(ctx, testTo, big.NewInt(1), "ref-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.setReceipt(tx.TxHash, usdc.TxStatusSuccess, 100)
+	m.sweep(ctx)
+
+	got, _ := m.Get(tx.ID)
+	if got.State != StateConfirmed || got.BlockNumber != 100 || got.ConfirmedAt == nil {
+		t.Errorf("got state=%q block=%d confirmedAt=%v", got.State, got.BlockNumber, got.ConfirmedAt)
+	}
+}
+
+func TestSweep_RevertedTxFails(t *testing.T) {
+	m, chain := newTestManager(t)
+	ctx := context.Background()
+
+	tx, _ := m.Submit(ctx, testTo, big.NewInt(1), "ref-1")
+	chain.setReceipt(tx.TxHash, usdc.TxStatusFailed, 100)
+	m.sweep(ctx)
+
+	got, _ := m.Get(tx.ID)
+	if got.State != StateFailed || got.Error == "" {
+		t.Errorf("got state=%q error=%q", got.State, got.Error)
+	}
+}
+
+func TestSweep_ReplacesStuckTx(t *testing.T) {
+	m, chain := newTestManager(t)
+	ctx := context.Background()
+
+	tx, _ := m.Submit(ctx, testTo, big.NewInt(1), "ref-1")
+	backdate(m, tx.ID)
+	m.sweep(ctx)
+
+	got, _ := m.Get(tx.ID)
+	if got.State != StateReplaced || got.Attempts != 2 {
+		t.Fatalf("got state=%q attempts=%d", got.State, got.Attempts)
+	}
+	if got.TxHash == tx.TxHash {
+		t.Error("hash unchanged after replacement")
+	}
+	if len(got.ReplacedHashes) != 1 || got.ReplacedHashes[0] != tx.TxHash {
+		t.Errorf("replaced hashes = %v", got.ReplacedHashes)
+	}
+	// Replacement kept the nonce and bumped fees >= 15% over the original.
+	if chain.sends[1].Nonce != chain.sends[0].Nonce {
+		t.Errorf("replacement nonce = %d, want %d", chain.sends[1].Nonce, chain.sends[0].Nonce)
+	}
+	if fee := chain.sends[1].FeeQuote.MaxFeePerGas; fee.Cmp(big.NewInt(1150)) < 0 {
+		t.Errorf("replacement max fee = %s, want >= 1150", fee)
+	}
+	// The superseded hash still resolves to the same record.
+	if byOld, err := m.GetByHash(tx.TxHash); err != nil || byOld.ID != tx.ID {
+		t.Errorf("old hash lookup = %v, %v", byOld, err)
+	}
+}
+
+func TestSweep_IncludedTxIsNotReplaced(t *testing.T) {
+	m, chain := newTestManager(t)
+	ctx := context.Background()
+
+	tx, _ := m.Submit(ctx, testTo, big.NewInt(1), "ref-1")
+	backdate(m, tx.ID)
+	// Mined but below confirmation depth: pending with a block number.
+	chain.setReceipt(tx.TxHash, usdc.TxStatusPending, 100)
+	m.sweep(ctx)
+
+	got, _ := m.Get(tx.ID)
+	if got.State != StatePending || got.Attempts != 1 {
+		t.Errorf("included tx was replaced: state=%q attempts=%d", got.State, got.Attempts)
+	}
+}
+
+func TestSweep_OriginalMinesAfterReplacement(t *testing.T) {
+	m, chain := newTestManager(t)
+	ctx := context.Background()
+
+	tx, _ := m.Submit(ctx, testTo, big.NewInt(1), "ref-1")
+	backdate(m, tx.ID)
+	m.sweep(ctx)
+
+	// The first broadcast wins the race despite the fee-bumped replacement.
+	chain.setReceipt(tx.TxHash, usdc.TxStatusSuccess, 200)
+	m.sweep(ctx)
+
+	got, _ := m.Get(tx.ID)
+	if got.State != StateConfirmed {
+		t.Fatalf("state = %q, want confirmed", got.State)
+	}
+	if got.TxHash != tx.TxHash {
+		t.Errorf("canonical hash = %s, want the mined original %s", got.TxHash, tx.TxHash)
+	}
+}
+
+func TestSweep_RespectsMaxReplacements(t *testing.T) {
+	m, chain := newTestManager(t)
+	m.cfg.MaxReplacements = 1
+	ctx := context.Background()
+
+	tx, _ := m.Submit(ctx, testTo, big.NewInt(1), "ref-1")
+	backdate(m, tx.ID)
+	m.sweep(ctx) // first replacement
+	backdate(m, tx.ID)
+	m.sweep(ctx) // at cap — no further broadcast
+
+	if n := chain.sendCount(); n != 2 {
+		t.Errorf("broadcasts = %d, want 2 (original + one replacement)", n)
+	}
+}
+
+func TestBumped(t *testing.T) {
+	// Fresh quote below the minimum bump gets raised to prev * 1.15.
+	if got := bumped(big.NewInt(1000), big.NewInt(1000), 15); got.Cmp(big.NewInt(1150)) != 0 {
+		t.Errorf("bumped = %s, want 1150", got)
+	}
+	// Fresh quote above the minimum is used as-is.
+	if got := bumped(big.NewInt(5000), big.NewInt(1000), 15); got.Cmp(big.NewInt(5000)) != 0 {
+		t.Errorf("bumped = %s, want 5000", got)
+	}
+	if got := bumped(big.NewInt(42), nil, 15); got.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("bumped with nil prev = %s, want 42", got)
+	}
+}
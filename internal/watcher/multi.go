@@ -0,0 +1,65 @@
+package watcher
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Multi runs one Watcher per configured chain concurrently. Each chain keeps
+// its own RPC connection, checkpoint, and backoff state, so one chain stalling
+// or failing does not block deposits landing on the others.
+type Multi struct {
+	watchers []*Watcher
+	logger   *slog.Logger
+}
+
+// NewMulti creates a multi-chain watcher from per-chain watchers.
+func NewMulti(watchers []*Watcher, logger *slog.Logger) *Multi {
+	return &Multi{watchers: watchers, logger: logger}
+}
+
+// Start runs all chain watchers and blocks until every one has exited.
+// A single chain's failure is logged and does not stop the others; the
+// first non-context error is returned once all watchers are done.
+func (m *Multi) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for _, w := range m.watchers {
+		wg.Add(1)
+		go func(w *Watcher) {
+			defer wg.Done()
+			if err := w.Start(ctx); err != nil && ctx.Err() == nil {
+				m.logger.Error("deposit watcher stopped with error",
+					"chain", w.cfg.Chain, "error", err)
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// Stop signals every chain watcher to stop.
+func (m *Multi) Stop() {
+	for _, w := range m.watchers {
+		w.Stop()
+	}
+}
+
+// Running returns whether any chain watcher is currently running.
+func (m *Multi) Running() bool {
+	for _, w := range m.watchers {
+		if w.Running() {
+			return true
+		}
+	}
+	return false
+}
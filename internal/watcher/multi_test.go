@@ -0,0 +1,107 @@
+package watcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestProcessTransfer_ChainTaggedDescription(t *testing.T) {
+	agentAddr := "0xaaaa000000000000000000000000000000000001"
+	creditor := newMockCreditor()
+	agents := &mockAgentResolver{agents: map[string]bool{agentAddr: true}}
+	cp := NewMemoryCheckpoint()
+
+	w := New(Config{
+		Chain:           "arbitrum",
+		PlatformAddress: common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678"),
+		USDCContract:    common.HexToAddress("0xaf88d065e77c8cC2239327C5EDb3A432268e5831"),
+	}, creditor, agents, cp, noopLogger())
+
+	data := make([]byte, 32)
+	copy(data[29:], []byte{0x0F, 0x42, 0x40}) // 1.000000 USDC
+
+	vLog := types.Log{
+		Topics: []common.Hash{
+			transferEventSig,
+			common.BytesToHash(common.HexToAddress(agentAddr).Bytes()),
+			common.BytesToHash(common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678").Bytes()),
+		},
+		Data:        data,
+		TxHash:      common.HexToHash("0xdeadbeef02"),
+		BlockNumber: 200,
+	}
+
+	if err := w.processTransfer(context.Background(), vLog); err != nil {
+		t.Fatal(err)
+	}
+
+	creditor.mu.Lock()
+	defer creditor.mu.Unlock()
+	if len(creditor.credits) != 1 {
+		t.Fatalf("expected 1 credit, got %d", len(creditor.credits))
+	}
+	if !strings.Contains(creditor.credits[0].Description, "(arbitrum block 200)") {
+		t.Errorf("expected chain-tagged description, got %q", creditor.credits[0].Description)
+	}
+}
+
+func TestProcessTransfer_UntaggedDescriptionWithoutChain(t *testing.T) {
+	agentAddr := "0xaaaa000000000000000000000000000000000001"
+	creditor := newMockCreditor()
+	agents := &mockAgentResolver{agents: map[string]bool{agentAddr: true}}
+	cp := NewMemoryCheckpoint()
+
+	w := New(Config{
+		PlatformAddress: common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678"),
+		USDCContract:    common.HexToAddress("0x036CbD53842c5426634e7929541eC2318f3dCF7e"),
+	}, creditor, agents, cp, noopLogger())
+
+	data := make([]byte, 32)
+	copy(data[29:], []byte{0x0F, 0x42, 0x40})
+
+	vLog := types.Log{
+		Topics: []common.Hash{
+			transferEventSig,
+			common.BytesToHash(common.HexToAddress(agentAddr).Bytes()),
+			common.BytesToHash(common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678").Bytes()),
+		},
+		Data:        data,
+		TxHash:      common.HexToHash("0xdeadbeef03"),
+		BlockNumber: 300,
+	}
+
+	if err := w.processTransfer(context.Background(), vLog); err != nil {
+		t.Fatal(err)
+	}
+
+	creditor.mu.Lock()
+	defer creditor.mu.Unlock()
+	if got := creditor.credits[0].Description; got != "on-chain USDC deposit from "+agentAddr+" (block 300)" {
+		t.Errorf("unexpected description: %q", got)
+	}
+}
+
+func TestMulti_RunningAggregates(t *testing.T) {
+	creditor := newMockCreditor()
+	agents := &mockAgentResolver{agents: map[string]bool{}}
+
+	base := New(Config{Chain: "base"}, creditor, agents, NewMemoryCheckpoint(), noopLogger())
+	arb := New(Config{Chain: "arbitrum"}, creditor, agents, NewMemoryCheckpoint(), noopLogger())
+	m := NewMulti([]*Watcher{base, arb}, noopLogger())
+
+	if m.Running() {
+		t.Fatal("expected not running before start")
+	}
+	arb.running.Store(true)
+	if !m.Running() {
+		t.Fatal("expected running while any chain watcher runs")
+	}
+	arb.running.Store(false)
+
+	// Stop with no watchers started must not block or panic.
+	m.Stop()
+}
@@ -0,0 +1,56 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotePending_TracksHighestBlock(t *testing.T) {
+	w := New(Config{}, newMockCreditor(), &mockAgentResolver{}, NewMemoryCheckpoint(), noopLogger())
+
+	w.notePending(100)
+	w.notePending(90) // lower block must not regress the marker
+	if got := w.pendingBlock.Load(); got != 100 {
+		t.Fatalf("expected pending block 100, got %d", got)
+	}
+	w.notePending(110)
+	if got := w.pendingBlock.Load(); got != 110 {
+		t.Fatalf("expected pending block 110, got %d", got)
+	}
+}
+
+func TestClearPending_OnlyPastSafeBlock(t *testing.T) {
+	w := New(Config{}, newMockCreditor(), &mockAgentResolver{}, NewMemoryCheckpoint(), noopLogger())
+	w.notePending(100)
+
+	w.clearPending(99) // not yet confirmed past the pending block
+	if got := w.pendingBlock.Load(); got != 100 {
+		t.Fatalf("expected pending block to remain, got %d", got)
+	}
+	w.clearPending(100)
+	if got := w.pendingBlock.Load(); got != 0 {
+		t.Fatalf("expected pending block cleared, got %d", got)
+	}
+}
+
+func TestBackoffDelay_FastWhilePending(t *testing.T) {
+	w := New(Config{PollInterval: 15 * time.Second}, newMockCreditor(), &mockAgentResolver{}, NewMemoryCheckpoint(), noopLogger())
+
+	if got := w.backoffDelay(); got != 15*time.Second {
+		t.Fatalf("expected normal interval, got %v", got)
+	}
+	w.notePending(100)
+	if got := w.backoffDelay(); got != fastPollInterval {
+		t.Fatalf("expected fast interval while pending, got %v", got)
+	}
+	// Failures still back off even with a pending block.
+	w.consecutiveFails = 1
+	if got := w.backoffDelay(); got != 30*time.Second {
+		t.Fatalf("expected backoff interval, got %v", got)
+	}
+	w.consecutiveFails = 0
+	w.clearPending(100)
+	if got := w.backoffDelay(); got != 15*time.Second {
+		t.Fatalf("expected normal interval after clear, got %v", got)
+	}
+}
@@ -48,7 +48,9 @@ type CheckpointStore interface {
 
 // Config holds watcher configuration.
 type Config struct {
+	Chain           string // chain name (e.g. "base", "arbitrum") for logs and deposit tagging; empty = untagged
 	RPCURL          string
+	WSURL           string // optional WebSocket endpoint for eth_subscribe; empty = polling only
 	USDCContract    common.Address
 	PlatformAddress common.Address
 	PollInterval    time.Duration
@@ -61,13 +63,16 @@ type Config struct {
 type Watcher struct {
 	cfg              Config
 	client           *ethclient.Client
+	wsClient         *ethclient.Client // optional, for eth_subscribe
 	creditor         Creditor
 	agents           AgentResolver
 	checkpoint       CheckpointStore
 	logger           *slog.Logger
 	stop             chan struct{}
 	running          atomic.Bool
-	consecutiveFails int // tracks consecutive poll failures for backoff
+	subscribed       atomic.Bool   // whether a live log subscription is active
+	pendingBlock     atomic.Uint64 // highest block seen via subscription, awaiting confirmation
+	consecutiveFails int           // tracks consecutive poll failures for backoff
 }
 
 // New creates a new deposit watcher.
@@ -81,6 +86,9 @@ func New(cfg Config, creditor Creditor, agents AgentResolver, checkpoint Checkpo
 	if cfg.BatchSize == 0 {
 		cfg.BatchSize = 1000
 	}
+	if logger != nil && cfg.Chain != "" {
+		logger = logger.With("chain", cfg.Chain)
+	}
 	return &Watcher{
 		cfg:        cfg,
 		creditor:   creditor,
@@ -100,6 +108,17 @@ func (w *Watcher) Start(ctx context.Context) error {
 	w.client = client
 	defer client.Close()
 
+	// WebSocket endpoint is optional; a failed dial just means polling only.
+	if w.cfg.WSURL != "" {
+		wsClient, wsErr := ethclient.DialContext(ctx, w.cfg.WSURL)
+		if wsErr != nil {
+			w.logger.Warn("watcher: websocket dial failed, polling only", "error", wsErr)
+		} else {
+			w.wsClient = wsClient
+			defer wsClient.Close()
+		}
+	}
+
 	w.running.Store(true)
 	defer w.running.Store(false)
 
@@ -107,11 +126,17 @@ func (w *Watcher) Start(ctx context.Context) error {
 		"usdc_contract", w.cfg.USDCContract.Hex(),
 		"platform_address", w.cfg.PlatformAddress.Hex(),
 		"confirmations", w.cfg.Confirmations,
-		"poll_interval", w.cfg.PollInterval)
+		"poll_interval", w.cfg.PollInterval,
+		"websocket", w.wsClient != nil)
 
 	// Initial poll immediately
 	w.safePoll(ctx)
 
+	notify := make(chan struct{}, 1)
+	if w.wsClient != nil {
+		w.subscribe(ctx, notify)
+	}
+
 	for {
 		delay := w.backoffDelay()
 		select {
@@ -119,8 +144,15 @@ func (w *Watcher) Start(ctx context.Context) error {
 			return ctx.Err()
 		case <-w.stop:
 			return nil
+		case <-notify:
+			// A transfer was seen over the subscription — poll right away
+			// instead of waiting out the tick.
+			w.safePoll(ctx)
 		case <-time.After(delay):
 			w.safePoll(ctx)
+			if w.wsClient != nil && !w.subscribed.Load() {
+				w.subscribe(ctx, notify)
+			}
 		}
 	}
 }
@@ -164,11 +196,20 @@ func (w *Watcher) safePoll(ctx context.Context) {
 	}
 }
 
+// fastPollInterval is used while a subscribed transfer is waiting out the
+// confirmation moat, so the credit lands seconds after finality instead of
+// on the next regular tick.
+const fastPollInterval = 2 * time.Second
+
 // backoffDelay returns the poll interval with exponential backoff on consecutive failures.
-// On success (consecutiveFails == 0), returns the configured PollInterval.
+// On success (consecutiveFails == 0), returns the configured PollInterval — or the
+// fast interval while a subscription-seen transfer is pending confirmation.
 // On failure, doubles the interval up to 64x the base (e.g., 15s → 16min max).
 func (w *Watcher) backoffDelay() time.Duration {
 	if w.consecutiveFails == 0 {
+		if w.pendingBlock.Load() != 0 && fastPollInterval < w.cfg.PollInterval {
+			return fastPollInterval
+		}
 		return w.cfg.PollInterval
 	}
 	shift := w.consecutiveFails
@@ -178,6 +219,67 @@ func (w *Watcher) backoffDelay() time.Duration {
 	return w.cfg.PollInterval * time.Duration(1<<uint(shift))
 }
 
+// subscribe opens an eth_subscribe stream for USDC Transfer logs to the
+// platform address. Subscribed logs are never credited directly — they arrive
+// at the chain head, inside the confirmation moat — they only wake the
+// checkpointed poll and switch it to the fast interval until the transfer is
+// final. Returns false if the subscription could not be established; the
+// caller keeps polling and retries on the next tick.
+func (w *Watcher) subscribe(ctx context.Context, notify chan<- struct{}) bool {
+	logs := make(chan types.Log, 16)
+	sub, err := w.wsClient.SubscribeFilterLogs(ctx, w.transferQuery(nil, nil), logs)
+	if err != nil {
+		w.logger.Warn("watcher: log subscription failed, staying on polling", "error", err)
+		return false
+	}
+	w.subscribed.Store(true)
+	w.logger.Info("watcher: subscribed to Transfer logs over websocket")
+
+	go func() {
+		defer w.subscribed.Store(false)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-sub.Err():
+				if err != nil && ctx.Err() == nil {
+					w.logger.Warn("watcher: log subscription dropped, falling back to polling", "error", err)
+				}
+				return
+			case vLog := <-logs:
+				w.notePending(vLog.BlockNumber)
+				select {
+				case notify <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return true
+}
+
+// notePending records the highest block seen via subscription that the poll
+// has not yet confirmed past.
+func (w *Watcher) notePending(block uint64) {
+	for {
+		cur := w.pendingBlock.Load()
+		if cur >= block {
+			return
+		}
+		if w.pendingBlock.CompareAndSwap(cur, block) {
+			return
+		}
+	}
+}
+
+// clearPending drops the pending marker once the poll has processed past it.
+func (w *Watcher) clearPending(safeBlock uint64) {
+	if p := w.pendingBlock.Load(); p != 0 && safeBlock >= p {
+		w.pendingBlock.CompareAndSwap(p, 0)
+	}
+}
+
 // fetchCanonicalHash returns the canonical hash for the given block via the
 // connected ethclient. Split out so checkReorg can be unit-tested with a fake.
 func (w *Watcher) fetchCanonicalHash(ctx context.Context, block uint64) (string, error) {
@@ -267,6 +369,7 @@ func (w *Watcher) poll(ctx context.Context) error {
 
 	fromBlock := lastBlock + 1
 	if fromBlock > safeBlock {
+		w.clearPending(lastBlock)
 		return nil // caught up
 	}
 
@@ -313,19 +416,20 @@ func (w *Watcher) poll(ctx context.Context) error {
 			"to_block", safeBlock)
 	}
 
+	w.clearPending(safeBlock)
 	span.SetAttributes(attribute.Int("deposits.processed", totalProcessed))
 	return nil
 }
 
-func (w *Watcher) processBatch(ctx context.Context, fromBlock, toBlock uint64) (int, error) {
-	// Build filter for USDC Transfer events TO the platform address
-	// Transfer(address indexed from, address indexed to, uint256 value)
-	// Topic[0] = event sig, Topic[2] = to address (indexed)
+// transferQuery builds the filter for USDC Transfer events TO the platform address.
+// Transfer(address indexed from, address indexed to, uint256 value)
+// Topic[0] = event sig, Topic[2] = to address (indexed)
+// Nil block bounds produce the open-ended query used for subscriptions.
+func (w *Watcher) transferQuery(fromBlock, toBlock *big.Int) ethereum.FilterQuery {
 	platformAddrPadded := common.BytesToHash(w.cfg.PlatformAddress.Bytes())
-
-	query := ethereum.FilterQuery{
-		FromBlock: new(big.Int).SetUint64(fromBlock),
-		ToBlock:   new(big.Int).SetUint64(toBlock),
+	return ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
 		Addresses: []common.Address{w.cfg.USDCContract},
 		Topics: [][]common.Hash{
 			{transferEventSig},   // event signature
@@ -333,6 +437,10 @@ func (w *Watcher) processBatch(ctx context.Context, fromBlock, toBlock uint64) (
 			{platformAddrPadded}, // to: platform address only
 		},
 	}
+}
+
+func (w *Watcher) processBatch(ctx context.Context, fromBlock, toBlock uint64) (int, error) {
+	query := w.transferQuery(new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(toBlock))
 
 	logs, err := w.client.FilterLogs(ctx, query)
 	if err != nil {
@@ -380,6 +488,9 @@ func (w *Watcher) processTransfer(ctx context.Context, vLog types.Log) error {
 		attribute.String("from", fromAddrLower),
 		traces.Amount(amountStr),
 	)
+	if w.cfg.Chain != "" {
+		span.SetAttributes(attribute.String("chain", w.cfg.Chain))
+	}
 
 	// Skip zero-value transfers
 	if amount.Sign() == 0 {
@@ -406,8 +517,11 @@ func (w *Watcher) processTransfer(ctx context.Context, vLog types.Log) error {
 		return nil
 	}
 
-	// Credit the agent's balance
+	// Credit the agent's balance, tagging the deposit with the chain it landed on
 	desc := fmt.Sprintf("on-chain USDC deposit from %s (block %d)", fromAddrLower, vLog.BlockNumber)
+	if w.cfg.Chain != "" {
+		desc = fmt.Sprintf("on-chain USDC deposit from %s (%s block %d)", fromAddrLower, w.cfg.Chain, vLog.BlockNumber)
+	}
 	if err := w.creditor.Credit(ctx, fromAddrLower, amountStr, txHash, desc); err != nil {
 		span.SetStatus(codes.Error, "credit failed")
 		return fmt.Errorf("credit agent %s: %w", fromAddrLower, err)
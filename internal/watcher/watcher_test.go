@@ -20,19 +20,20 @@ type mockCreditor struct {
 }
 
 type creditCall struct {
-	AgentAddr string
-	Amount    string
-	TxHash    string
+	AgentAddr   string
+	Amount      string
+	TxHash      string
+	Description string
 }
 
 func newMockCreditor() *mockCreditor {
 	return &mockCreditor{deposits: make(map[string]bool)}
 }
 
-func (m *mockCreditor) Credit(_ context.Context, agentAddr, amount, txHash, _ string) error {
+func (m *mockCreditor) Credit(_ context.Context, agentAddr, amount, txHash, description string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.credits = append(m.credits, creditCall{agentAddr, amount, txHash})
+	m.credits = append(m.credits, creditCall{agentAddr, amount, txHash, description})
 	m.deposits[txHash] = true
 	return nil
 }
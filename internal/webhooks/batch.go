@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/logging"
+	"github.com/mbd888/alancoin/internal/recovery"
+)
+
+// Batching defaults and caps. A subscription that sets only one of the
+// two knobs gets the default for the other.
+const (
+	defaultBatchWindow = 10 * time.Second
+	maxBatchWindow     = 300 // seconds
+	defaultBatchEvents = 100
+	maxBatchEvents     = 100
+)
+
+// EventTypeBatch is the X-Alancoin-Event header value on batched
+// deliveries; the payload is {"events": [...], "count": N}.
+const EventTypeBatch EventType = "batch"
+
+// batchPayload is the body of a batched delivery.
+type batchPayload struct {
+	Events []*Event `json:"events"`
+	Count  int      `json:"count"`
+}
+
+// pendingBatch accumulates events for one subscription until the window
+// timer fires or the batch fills up.
+type pendingBatch struct {
+	ctx    context.Context // detached context from the first enqueue
+	sub    *Subscription
+	events []*Event
+	timer  *time.Timer
+}
+
+// batching reports whether the subscription opted into batched delivery.
+func (s *Subscription) batching() bool {
+	return s.BatchWindowSeconds > 0 || s.BatchMaxEvents > 0
+}
+
+// batchWindow is the flush interval, defaulted when only the size knob
+// was set.
+func (s *Subscription) batchWindow() time.Duration {
+	if s.BatchWindowSeconds > 0 {
+		return time.Duration(s.BatchWindowSeconds) * time.Second
+	}
+	return defaultBatchWindow
+}
+
+// batchLimit is the flush size, defaulted when only the window knob was
+// set.
+func (s *Subscription) batchLimit() int {
+	if s.BatchMaxEvents > 0 {
+		return s.BatchMaxEvents
+	}
+	return defaultBatchEvents
+}
+
+// deliver routes an event to immediate or batched delivery depending on
+// the subscription's configuration, applying the endpoint's concurrency
+// and rate caps and skipping endpoints whose circuit is open. Batched
+// endpoints are already throttled by their flush window.
+func (d *Dispatcher) deliver(ctx context.Context, sub *Subscription, event *Event) {
+	if sub.batching() {
+		d.enqueueBatch(ctx, sub, event)
+		return
+	}
+	lim := d.limiter(sub.ID)
+	if !lim.allow() {
+		slog.Debug("webhook delivery skipped: circuit open", "subscription_id", sub.ID, "event_id", event.ID)
+		return
+	}
+	if err := lim.acquire(ctx); err != nil {
+		lim.cancelProbe()
+		return
+	}
+	defer lim.release()
+	d.send(ctx, sub, event)
+}
+
+// enqueueBatch adds an event to the subscription's pending batch,
+// starting the window timer on the first event and flushing immediately
+// when the batch fills.
+func (d *Dispatcher) enqueueBatch(ctx context.Context, sub *Subscription, event *Event) {
+	d.batchMu.Lock()
+	b := d.batches[sub.ID]
+	if b == nil {
+		// Detached so a batch outlives the request that enqueued its
+		// first event.
+		b = &pendingBatch{ctx: context.WithoutCancel(ctx)}
+		d.batches[sub.ID] = b
+		b.timer = time.AfterFunc(sub.batchWindow(), func() {
+			defer recovery.LogPanic(logging.L(b.ctx), "webhook_batch_flush")
+			d.flushBatch(sub.ID)
+		})
+	}
+	b.sub = sub // keep the freshest subscription copy for signing
+	b.events = append(b.events, event)
+	full := len(b.events) >= sub.batchLimit()
+	d.batchMu.Unlock()
+
+	if full {
+		d.flushBatch(sub.ID)
+	}
+}
+
+// flushBatch takes the subscription's pending batch, if any, and sends
+// it as one delivery.
+func (d *Dispatcher) flushBatch(subscriptionID string) {
+	d.batchMu.Lock()
+	b := d.batches[subscriptionID]
+	if b == nil {
+		d.batchMu.Unlock()
+		return
+	}
+	delete(d.batches, subscriptionID)
+	b.timer.Stop()
+	d.batchMu.Unlock()
+
+	d.sendBatch(b.ctx, b.sub, b.events)
+}
+
+// sendBatch delivers accumulated events as a single array payload
+// through the normal retry path. Each event keeps its own seq; the
+// synthetic batch ID is only delivery bookkeeping.
+func (d *Dispatcher) sendBatch(ctx context.Context, sub *Subscription, events []*Event) {
+	payload, err := json.Marshal(batchPayload{Events: events, Count: len(events)})
+	if err != nil {
+		d.updateError(ctx, sub, "failed to marshal batch")
+		return
+	}
+	batchEvent := &Event{
+		ID:        idgen.WithPrefix("batch_"),
+		Type:      EventTypeBatch,
+		Timestamp: time.Now(),
+	}
+	d.sendPayload(ctx, sub, batchEvent, payload, map[string]string{
+		"X-Alancoin-Batch": strconv.Itoa(len(events)),
+	})
+}
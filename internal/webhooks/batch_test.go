@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type capturedBatch struct {
+	eventHeader string
+	batchHeader string
+	body        batchPayload
+}
+
+func batchCaptureServer(t *testing.T) (*httptest.Server, func() []capturedBatch) {
+	t.Helper()
+	var mu sync.Mutex
+	var got []capturedBatch
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body batchPayload
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		got = append(got, capturedBatch{
+			eventHeader: r.Header.Get("X-Alancoin-Event"),
+			batchHeader: r.Header.Get("X-Alancoin-Batch"),
+			body:        body,
+		})
+		mu.Unlock()
+		w.WriteHeader(200)
+	}))
+	return server, func() []capturedBatch {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]capturedBatch(nil), got...)
+	}
+}
+
+func TestDispatch_BatchFlushesWhenFull(t *testing.T) {
+	store := NewMemoryStore()
+	server, batches := batchCaptureServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:                 "wh1",
+		AgentAddr:          "0xabc",
+		URL:                server.URL,
+		Events:             []EventType{"*"},
+		BatchWindowSeconds: 60, // window never fires in this test
+		BatchMaxEvents:     3,
+		Active:             true,
+	})
+
+	d := NewDispatcher(store)
+	d.urlValidator = noopValidator
+
+	for _, id := range []string{"evt_1", "evt_2", "evt_3"} {
+		d.Dispatch(ctx, &Event{ID: id, Type: EventPaymentReceived, Timestamp: time.Now()})
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	got := batches()
+	if len(got) != 1 {
+		t.Fatalf("deliveries = %d, want one full batch", len(got))
+	}
+	b := got[0]
+	if b.eventHeader != "batch" || b.batchHeader != "3" {
+		t.Errorf("headers = event %q batch %q, want batch/3", b.eventHeader, b.batchHeader)
+	}
+	if b.body.Count != 3 || len(b.body.Events) != 3 {
+		t.Fatalf("body = %+v, want 3 events", b.body)
+	}
+	// Each Dispatch call delivers from its own goroutine, so assert
+	// membership rather than ordering.
+	ids := map[string]bool{}
+	for _, e := range b.body.Events {
+		ids[e.ID] = true
+	}
+	if !ids["evt_1"] || !ids["evt_2"] || !ids["evt_3"] {
+		t.Errorf("batch ids = %v, want all three events", ids)
+	}
+}
+
+func TestDispatch_BatchFlushesOnWindow(t *testing.T) {
+	store := NewMemoryStore()
+	server, batches := batchCaptureServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:                 "wh1",
+		AgentAddr:          "0xabc",
+		URL:                server.URL,
+		Events:             []EventType{"*"},
+		BatchWindowSeconds: 1,
+		Active:             true,
+	})
+
+	d := NewDispatcher(store)
+	d.urlValidator = noopValidator
+
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+	d.Dispatch(ctx, &Event{ID: "evt_2", Type: EventPaymentSent, Timestamp: time.Now()})
+
+	time.Sleep(300 * time.Millisecond)
+	if n := len(batches()); n != 0 {
+		t.Fatalf("batch flushed before window elapsed (%d deliveries)", n)
+	}
+
+	time.Sleep(1200 * time.Millisecond)
+	got := batches()
+	if len(got) != 1 || got[0].body.Count != 2 {
+		t.Fatalf("deliveries = %+v, want one batch of 2 after the window", got)
+	}
+}
+
+func TestDispatch_UnbatchedSubscriptionDeliversImmediately(t *testing.T) {
+	store := NewMemoryStore()
+	server, batches := batchCaptureServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{"*"},
+		Active:    true,
+	})
+
+	d := NewDispatcher(store)
+	d.urlValidator = noopValidator
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+	time.Sleep(300 * time.Millisecond)
+
+	got := batches()
+	if len(got) != 1 {
+		t.Fatalf("deliveries = %d, want 1", len(got))
+	}
+	if got[0].eventHeader != string(EventPaymentReceived) || got[0].batchHeader != "" {
+		t.Errorf("headers = %+v, want per-event delivery without batch header", got[0])
+	}
+}
@@ -0,0 +1,185 @@
+package webhooks
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+)
+
+// Per-endpoint delivery limits. The global semaphore caps total in-flight
+// deliveries across all endpoints; these caps stop a single slow or
+// failing endpoint from monopolising it, and the circuit breaker stops
+// hammering an endpoint that persistently fails. The breaker complements
+// the store-backed graduated suspension: it is purely in-process, lets a
+// half-open probe through when the window elapses, and notifies the
+// owner's other endpoints when it first opens.
+const (
+	maxPerEndpointConcurrency = 4   // in-flight deliveries per endpoint
+	endpointRatePerMinute     = 120 // sustained deliveries per endpoint per minute
+	endpointRateBurst         = 20  // token bucket depth
+	breakerFailureThreshold   = 5   // consecutive failures before the circuit opens
+	breakerOpenDuration       = time.Minute
+)
+
+// endpointLimiter holds one endpoint's concurrency slots, rate tokens and
+// circuit breaker state.
+type endpointLimiter struct {
+	slots chan struct{} // per-endpoint concurrency
+
+	// stateMu serializes subscription status bookkeeping
+	// (updateSuccess/updateError): with concurrent deliveries to one
+	// endpoint the failure counter and timestamps are shared state.
+	stateMu sync.Mutex
+
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+	openUntil  time.Time // zero = circuit closed
+	probing    bool      // a half-open probe is in flight
+	notified   bool      // owner already told about the current open circuit
+}
+
+// limiter returns the endpoint's limiter, creating it on first use.
+func (d *Dispatcher) limiter(subscriptionID string) *endpointLimiter {
+	if v, ok := d.limiters.Load(subscriptionID); ok {
+		return v.(*endpointLimiter)
+	}
+	lim := &endpointLimiter{
+		slots:      make(chan struct{}, maxPerEndpointConcurrency),
+		tokens:     endpointRateBurst,
+		lastRefill: time.Now(),
+	}
+	v, _ := d.limiters.LoadOrStore(subscriptionID, lim)
+	return v.(*endpointLimiter)
+}
+
+// acquire blocks until the endpoint has a free concurrency slot and a
+// rate token, or the context is cancelled.
+func (l *endpointLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if wait := l.takeToken(); wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			<-l.slots
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (l *endpointLimiter) release() { <-l.slots }
+
+// takeToken refills the bucket and takes one token, returning how long
+// the caller must wait before sending if the bucket was empty.
+func (l *endpointLimiter) takeToken() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.tokens += now.Sub(l.lastRefill).Seconds() * endpointRatePerMinute / 60
+	if l.tokens > endpointRateBurst {
+		l.tokens = endpointRateBurst
+	}
+	l.lastRefill = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(time.Minute) / endpointRatePerMinute)
+}
+
+// allow reports whether the breaker permits a delivery. An open circuit
+// blocks everything until the window elapses, then lets a single
+// half-open probe through; the probe's outcome closes or re-opens it.
+func (l *endpointLimiter) allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.openUntil.IsZero() {
+		return true
+	}
+	if time.Now().Before(l.openUntil) {
+		return false
+	}
+	if l.probing {
+		return false
+	}
+	l.probing = true
+	return true
+}
+
+// cancelProbe releases a half-open probe that never ran (context
+// cancelled before sending), so the next delivery can probe instead.
+func (l *endpointLimiter) cancelProbe() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.probing = false
+}
+
+// onSuccess closes the circuit.
+func (l *endpointLimiter) onSuccess() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.openUntil = time.Time{}
+	l.probing = false
+	l.notified = false
+}
+
+// onFailure opens (or re-opens, after a failed probe) the circuit once
+// the failure streak reaches the threshold. It returns true on the
+// closed-to-open transition the owner has not yet been notified about.
+func (l *endpointLimiter) onFailure(consecutiveFailures int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.probing {
+		l.probing = false
+		l.openUntil = time.Now().Add(breakerOpenDuration)
+		return false
+	}
+	if consecutiveFailures < breakerFailureThreshold {
+		return false
+	}
+	l.openUntil = time.Now().Add(breakerOpenDuration)
+	if l.notified {
+		return false
+	}
+	l.notified = true
+	return true
+}
+
+// notifyEndpointSuspended tells the owner, through their other active
+// endpoints, that a webhook has been circuit-broken. Delivered as a
+// normal webhook.endpoint.suspended event so owners can alert on it.
+func (d *Dispatcher) notifyEndpointSuspended(sub *Subscription) {
+	ctx := context.Background()
+	subs, err := d.store.GetByAgent(ctx, sub.AgentAddr)
+	if err != nil {
+		slog.Warn("webhook breaker notification failed", "subscription_id", sub.ID, "error", err)
+		return
+	}
+	event := &Event{
+		ID:        idgen.WithPrefix("evt_"),
+		Type:      EventWebhookSuspended,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"webhookId":           sub.ID,
+			"url":                 sub.URL,
+			"lastError":           sub.LastError,
+			"consecutiveFailures": sub.ConsecutiveFailures,
+		},
+	}
+	for _, other := range subs {
+		if other.ID == sub.ID || !other.Active || other.isSuspended() || !other.matchesEvent(EventWebhookSuspended) {
+			continue
+		}
+		d.send(ctx, other, event)
+	}
+}
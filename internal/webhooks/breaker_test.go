@@ -0,0 +1,185 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEndpointLimiter_BreakerTransitions(t *testing.T) {
+	l := &endpointLimiter{}
+
+	if !l.allow() {
+		t.Fatal("closed circuit should allow")
+	}
+	if l.onFailure(breakerFailureThreshold - 1) {
+		t.Error("below threshold should not open")
+	}
+	if !l.allow() {
+		t.Error("circuit opened below threshold")
+	}
+
+	if !l.onFailure(breakerFailureThreshold) {
+		t.Error("crossing threshold should report the open transition")
+	}
+	if l.allow() {
+		t.Error("open circuit should block")
+	}
+	if l.onFailure(breakerFailureThreshold + 1) {
+		t.Error("already-notified open circuit should not re-notify")
+	}
+
+	// Force the window to elapse: exactly one half-open probe goes through.
+	l.mu.Lock()
+	l.openUntil = time.Now().Add(-time.Second)
+	l.mu.Unlock()
+	if !l.allow() {
+		t.Error("elapsed window should allow a probe")
+	}
+	if l.allow() {
+		t.Error("second delivery during probe should block")
+	}
+
+	// Failed probe re-opens without another notification.
+	if l.onFailure(breakerFailureThreshold + 2) {
+		t.Error("failed probe should not re-notify")
+	}
+	if l.allow() {
+		t.Error("failed probe should re-open the circuit")
+	}
+
+	// Successful probe closes the circuit and re-arms notification.
+	l.mu.Lock()
+	l.openUntil = time.Now().Add(-time.Second)
+	l.mu.Unlock()
+	if !l.allow() {
+		t.Fatal("probe after re-open")
+	}
+	l.onSuccess()
+	if !l.allow() {
+		t.Error("closed circuit after successful probe should allow")
+	}
+	if !l.onFailure(breakerFailureThreshold) {
+		t.Error("notification should re-arm after a success")
+	}
+}
+
+func TestDispatch_PerEndpointConcurrencyCap(t *testing.T) {
+	var inflight, peak, done atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		inflight.Add(-1)
+		done.Add(1)
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	store := NewMemoryStore()
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{"*"},
+		Active:    true,
+	})
+
+	d := newTestDispatcher(store)
+	for i := 0; i < 2*maxPerEndpointConcurrency; i++ {
+		d.DispatchToAgent(ctx, "0xabc", &Event{
+			ID:        "evt_conc",
+			Type:      EventPaymentReceived,
+			Timestamp: time.Now(),
+		})
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for done.Load() < 2*maxPerEndpointConcurrency && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if done.Load() < 2*maxPerEndpointConcurrency {
+		t.Fatalf("only %d of %d deliveries completed", done.Load(), 2*maxPerEndpointConcurrency)
+	}
+	if p := peak.Load(); p > maxPerEndpointConcurrency {
+		t.Errorf("peak in-flight = %d, want <= %d", p, maxPerEndpointConcurrency)
+	}
+}
+
+func TestCircuitOpen_NotifiesOwnersOtherEndpoints(t *testing.T) {
+	// Failing endpoint: 400 means one attempt per delivery, no retries.
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	}))
+	defer failing.Close()
+
+	notified := make(chan []byte, 1)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		select {
+		case notified <- body:
+		default:
+		}
+		w.WriteHeader(200)
+	}))
+	defer healthy.Close()
+
+	store := NewMemoryStore()
+	ctx := context.Background()
+	subA := &Subscription{
+		ID:        "wh_failing",
+		AgentAddr: "0xabc",
+		URL:       failing.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+	}
+	store.Create(ctx, subA)
+	store.Create(ctx, &Subscription{
+		ID:        "wh_healthy",
+		AgentAddr: "0xabc",
+		URL:       healthy.URL,
+		Events:    []EventType{EventWebhookSuspended},
+		Active:    true,
+	})
+
+	d := newTestDispatcher(store)
+	event := &Event{ID: "evt_fail", Type: EventPaymentReceived, Timestamp: time.Now()}
+	for i := 0; i < breakerFailureThreshold; i++ {
+		d.send(ctx, subA, event)
+	}
+
+	var body []byte
+	select {
+	case body = <-notified:
+	case <-time.After(3 * time.Second):
+		t.Fatal("owner notification not delivered")
+	}
+
+	var got Event
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("notification body: %v", err)
+	}
+	if got.Type != EventWebhookSuspended {
+		t.Errorf("type = %s, want %s", got.Type, EventWebhookSuspended)
+	}
+	if got.Data["webhookId"] != "wh_failing" {
+		t.Errorf("webhookId = %v", got.Data["webhookId"])
+	}
+
+	// The open circuit drops further deliveries on the normal path.
+	if !d.limiter(subA.ID).allow() {
+		return
+	}
+	t.Error("circuit should be open after threshold failures")
+}
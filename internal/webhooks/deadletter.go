@@ -0,0 +1,179 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/security"
+)
+
+// DeadLetter is a delivery that exhausted its retries. The original
+// payload is kept verbatim so manual redelivery carries exactly the
+// bytes (and therefore the signature base) the receiver missed.
+type DeadLetter struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscriptionId"`
+	AgentAddr      string          `json:"agentAddr"`
+	EventID        string          `json:"eventId,omitempty"`
+	EventType      EventType       `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	LastError      string          `json:"lastError"`
+	Attempts       int             `json:"attempts"`
+	FailedAt       time.Time       `json:"failedAt"`
+}
+
+// DeadLetterStore persists failed deliveries for later inspection and
+// redelivery.
+type DeadLetterStore interface {
+	Add(ctx context.Context, dl *DeadLetter) error
+	Get(ctx context.Context, id string) (*DeadLetter, error)
+	ListByAgent(ctx context.Context, agentAddr string, limit int) ([]*DeadLetter, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// WithDeadLetterStore makes the dispatcher record deliveries that
+// exhaust their retries instead of dropping them.
+func (d *Dispatcher) WithDeadLetterStore(store DeadLetterStore) *Dispatcher {
+	d.deadLetters = store
+	return d
+}
+
+// deadLetter records an exhausted delivery. Uses a detached context so a
+// delivery that died because the request context was cancelled (e.g.
+// shutdown) is still captured.
+func (d *Dispatcher) deadLetter(ctx context.Context, sub *Subscription, event *Event, payload []byte, lastErr string, attempts int) {
+	if d.deadLetters == nil {
+		return
+	}
+	dl := &DeadLetter{
+		ID:             idgen.WithPrefix("dl_"),
+		SubscriptionID: sub.ID,
+		AgentAddr:      sub.AgentAddr,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        payload,
+		LastError:      lastErr,
+		Attempts:       attempts,
+		FailedAt:       time.Now(),
+	}
+	if err := d.deadLetters.Add(context.WithoutCancel(ctx), dl); err != nil {
+		slog.Warn("webhook dead-letter store failed", "subscription_id", sub.ID, "error", err)
+	}
+}
+
+// Redeliver makes one synchronous attempt to deliver a dead-lettered
+// payload to its subscription, with a fresh signature over the original
+// bytes. The caller removes the dead letter on success.
+func (d *Dispatcher) Redeliver(ctx context.Context, dl *DeadLetter) error {
+	sub, err := d.store.Get(ctx, dl.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("subscription no longer exists: %w", err)
+	}
+
+	validate := d.urlValidator
+	if validate == nil {
+		validate = security.ValidateEndpointURL
+	}
+	if err := validate(sub.URL); err != nil {
+		return fmt.Errorf("URL validation failed: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", sub.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Alancoin-Event", string(dl.EventType))
+	req.Header.Set("X-Alancoin-Redelivery", "true")
+	req.Header.Set("X-Alancoin-Payload-Version", strconv.Itoa(sub.payloadVersion()))
+	if secrets := sub.signingSecrets(); len(secrets) > 0 {
+		req.Header.Set("X-Alancoin-Signature", signatureHeader(dl.Payload, time.Now(), secrets))
+	}
+
+	start := time.Now()
+	resp, err := d.client.Do(req) //nolint:gosec // URL validated above
+	latency := time.Since(start).Milliseconds()
+	attempt := DeliveryAttempt{
+		SubscriptionID: sub.ID,
+		EventID:        dl.EventID,
+		EventType:      dl.EventType,
+		Attempt:        0, // manual redelivery
+		LatencyMS:      latency,
+		At:             start,
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+		d.deliveries.record(attempt)
+		return err
+	}
+	_ = resp.Body.Close()
+	attempt.StatusCode = resp.StatusCode
+	attempt.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	d.deliveries.record(attempt)
+	if !attempt.Success {
+		return fmt.Errorf("receiver returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MemoryDeadLetterStore is an in-memory DeadLetterStore for development
+// and tests.
+type MemoryDeadLetterStore struct {
+	mu      sync.RWMutex
+	letters map[string]*DeadLetter
+}
+
+var _ DeadLetterStore = (*MemoryDeadLetterStore)(nil)
+
+// NewMemoryDeadLetterStore creates an empty in-memory dead-letter store.
+func NewMemoryDeadLetterStore() *MemoryDeadLetterStore {
+	return &MemoryDeadLetterStore{letters: make(map[string]*DeadLetter)}
+}
+
+func (m *MemoryDeadLetterStore) Add(_ context.Context, dl *DeadLetter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.letters[dl.ID] = dl
+	return nil
+}
+
+func (m *MemoryDeadLetterStore) Get(_ context.Context, id string) (*DeadLetter, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if dl, ok := m.letters[id]; ok {
+		return dl, nil
+	}
+	return nil, fmt.Errorf("dead letter not found")
+}
+
+func (m *MemoryDeadLetterStore) ListByAgent(_ context.Context, agentAddr string, limit int) ([]*DeadLetter, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []*DeadLetter
+	for _, dl := range m.letters {
+		if dl.AgentAddr == agentAddr {
+			out = append(out, dl)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].FailedAt.After(out[j].FailedAt) })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *MemoryDeadLetterStore) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.letters, id)
+	return nil
+}
@@ -0,0 +1,212 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newDLQDispatcher(store Store, dlq DeadLetterStore) *Dispatcher {
+	d := NewDispatcherWithRetry(store, RetryConfig{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+		MaxFailures: 50,
+	})
+	d.urlValidator = noopValidator
+	return d.WithDeadLetterStore(dlq)
+}
+
+func TestSend_DeadLettersExhaustedDelivery(t *testing.T) {
+	store := NewMemoryStore()
+	dlq := NewMemoryDeadLetterStore()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+	})
+
+	d := newDLQDispatcher(store, dlq)
+	event := &Event{
+		ID:        "evt_1",
+		Type:      EventPaymentReceived,
+		Timestamp: time.Now(),
+		Data:      map[string]interface{}{"amount": "5.00"},
+	}
+	d.Dispatch(ctx, event)
+
+	time.Sleep(300 * time.Millisecond)
+
+	letters, err := dlq.ListByAgent(ctx, "0xabc", 10)
+	if err != nil || len(letters) != 1 {
+		t.Fatalf("dead letters = %v, %v; want exactly one", letters, err)
+	}
+	dl := letters[0]
+	if dl.SubscriptionID != "wh1" || dl.EventID != "evt_1" || dl.EventType != EventPaymentReceived {
+		t.Errorf("unexpected dead letter: %+v", dl)
+	}
+	if dl.Attempts != 2 {
+		t.Errorf("attempts = %d, want 2", dl.Attempts)
+	}
+	var replay Event
+	if err := json.Unmarshal(dl.Payload, &replay); err != nil || replay.ID != "evt_1" {
+		t.Errorf("payload not the original event: %s", dl.Payload)
+	}
+}
+
+func TestSend_NoDeadLetterOnSuccess(t *testing.T) {
+	store := NewMemoryStore()
+	dlq := NewMemoryDeadLetterStore()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+	})
+
+	d := newDLQDispatcher(store, dlq)
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+
+	time.Sleep(200 * time.Millisecond)
+
+	if letters, _ := dlq.ListByAgent(ctx, "0xabc", 10); len(letters) != 0 {
+		t.Errorf("dead letters = %v, want none for a delivered event", letters)
+	}
+}
+
+func TestRedeliver_SendsOriginalPayload(t *testing.T) {
+	store := NewMemoryStore()
+
+	var gotBody atomic.Value
+	var gotRedelivery atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody.Store(string(body))
+		gotRedelivery.Store(r.Header.Get("X-Alancoin-Redelivery"))
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+		Secret:    "s3cret",
+	})
+
+	d := newDLQDispatcher(store, NewMemoryDeadLetterStore())
+	dl := &DeadLetter{
+		ID:             "dl_1",
+		SubscriptionID: "wh1",
+		AgentAddr:      "0xabc",
+		EventType:      EventPaymentReceived,
+		Payload:        json.RawMessage(`{"id":"evt_1"}`),
+	}
+	if err := d.Redeliver(ctx, dl); err != nil {
+		t.Fatalf("Redeliver: %v", err)
+	}
+	if gotBody.Load() != `{"id":"evt_1"}` {
+		t.Errorf("body = %q, want original payload", gotBody.Load())
+	}
+	if gotRedelivery.Load() != "true" {
+		t.Errorf("X-Alancoin-Redelivery = %q, want true", gotRedelivery.Load())
+	}
+}
+
+func TestDeadLetterHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := NewMemoryStore()
+	dlq := NewMemoryDeadLetterStore()
+
+	var delivered atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered.Add(1)
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+	})
+	dlq.Add(ctx, &DeadLetter{
+		ID:             "dl_1",
+		SubscriptionID: "wh1",
+		AgentAddr:      "0xabc",
+		EventType:      EventPaymentReceived,
+		Payload:        json.RawMessage(`{"id":"evt_1"}`),
+		LastError:      "status 500",
+		Attempts:       5,
+		FailedAt:       time.Now(),
+	})
+
+	d := newDLQDispatcher(store, dlq)
+	h := NewHandler(store, d).WithDeadLetters(dlq)
+	r := gin.New()
+	h.RegisterRoutes(r.Group("/v1"))
+
+	// Owner can list their dead letters.
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/agents/0xabc/webhooks/dead-letters", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("list: status = %d; body %s", w.Code, w.Body.String())
+	}
+	var list struct {
+		Count int `json:"count"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &list)
+	if list.Count != 1 {
+		t.Errorf("count = %d, want 1", list.Count)
+	}
+
+	// Another agent cannot redeliver it.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/agents/0xother/webhooks/dead-letters/dl_1/redeliver", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("foreign redeliver: status = %d, want 403", w.Code)
+	}
+
+	// Owner redelivery succeeds and removes the entry.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/agents/0xabc/webhooks/dead-letters/dl_1/redeliver", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("redeliver: status = %d; body %s", w.Code, w.Body.String())
+	}
+	if delivered.Load() != 1 {
+		t.Errorf("delivered %d times, want 1", delivered.Load())
+	}
+	if letters, _ := dlq.ListByAgent(ctx, "0xabc", 10); len(letters) != 0 {
+		t.Errorf("dead letter not removed after redelivery: %v", letters)
+	}
+}
@@ -0,0 +1,68 @@
+package webhooks
+
+import (
+	"sync"
+	"time"
+)
+
+// DeliveryAttempt is one row of a webhook's delivery history: what we
+// sent, what came back, and how long it took.
+type DeliveryAttempt struct {
+	SubscriptionID  string    `json:"subscriptionId"`
+	EventID         string    `json:"eventId,omitempty"`
+	EventType       EventType `json:"eventType"`
+	Attempt         int       `json:"attempt"` // 1-based; 0 for manual redeliveries
+	StatusCode      int       `json:"statusCode,omitempty"`
+	Error           string    `json:"error,omitempty"`
+	ResponseExcerpt string    `json:"responseExcerpt,omitempty"`
+	LatencyMS       int64     `json:"latencyMs"`
+	Success         bool      `json:"success"`
+	At              time.Time `json:"at"`
+}
+
+// maxDeliveryHistory bounds the per-subscription ring buffer.
+const maxDeliveryHistory = 100
+
+// maxResponseExcerpt bounds how much of a receiver's response body is
+// kept for debugging.
+const maxResponseExcerpt = 256
+
+// deliveryLog keeps recent delivery attempts per subscription in memory.
+// It is a debugging aid, not an audit trail: history is per-process and
+// the oldest entries fall off after maxDeliveryHistory attempts.
+type deliveryLog struct {
+	mu       sync.Mutex
+	attempts map[string][]DeliveryAttempt // keyed by subscription ID
+}
+
+func newDeliveryLog() *deliveryLog {
+	return &deliveryLog{attempts: make(map[string][]DeliveryAttempt)}
+}
+
+func (l *deliveryLog) record(a DeliveryAttempt) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	log := append(l.attempts[a.SubscriptionID], a)
+	if len(log) > maxDeliveryHistory {
+		log = log[len(log)-maxDeliveryHistory:]
+	}
+	l.attempts[a.SubscriptionID] = log
+}
+
+// list returns up to limit attempts for a subscription, newest first.
+func (l *deliveryLog) list(subscriptionID string, limit int) []DeliveryAttempt {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	log := l.attempts[subscriptionID]
+	out := make([]DeliveryAttempt, 0, len(log))
+	for i := len(log) - 1; i >= 0 && (limit <= 0 || len(out) < limit); i-- {
+		out = append(out, log[i])
+	}
+	return out
+}
+
+// DeliveryHistory returns a subscription's recent delivery attempts,
+// newest first.
+func (d *Dispatcher) DeliveryHistory(subscriptionID string, limit int) []DeliveryAttempt {
+	return d.deliveries.list(subscriptionID, limit)
+}
@@ -0,0 +1,141 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDeliveryHistory_RecordsRetriesNewestFirst(t *testing.T) {
+	store := NewMemoryStore()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(500)
+			w.Write([]byte("upstream exploded"))
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+	})
+
+	d := NewDispatcherWithRetry(store, RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+		MaxFailures: 50,
+	})
+	d.urlValidator = noopValidator
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+
+	time.Sleep(300 * time.Millisecond)
+
+	history := d.DeliveryHistory("wh1", 0)
+	if len(history) != 2 {
+		t.Fatalf("history = %+v, want 2 attempts", history)
+	}
+	// Newest first: the successful retry, then the 500.
+	if !history[0].Success || history[0].StatusCode != 200 || history[0].Attempt != 2 {
+		t.Errorf("latest attempt = %+v, want successful attempt 2 with status 200", history[0])
+	}
+	if history[1].Success || history[1].StatusCode != 500 || history[1].Attempt != 1 {
+		t.Errorf("first attempt = %+v, want failed attempt 1 with status 500", history[1])
+	}
+	if history[1].ResponseExcerpt != "upstream exploded" {
+		t.Errorf("excerpt = %q, want receiver's error body", history[1].ResponseExcerpt)
+	}
+	for _, a := range history {
+		if a.EventID != "evt_1" || a.LatencyMS < 0 {
+			t.Errorf("attempt missing event/latency data: %+v", a)
+		}
+	}
+}
+
+func TestDeliveryLog_TrimsToMaxHistory(t *testing.T) {
+	l := newDeliveryLog()
+	for i := 0; i < maxDeliveryHistory+10; i++ {
+		l.record(DeliveryAttempt{SubscriptionID: "wh1", Attempt: i + 1})
+	}
+	got := l.list("wh1", 0)
+	if len(got) != maxDeliveryHistory {
+		t.Fatalf("len = %d, want %d", len(got), maxDeliveryHistory)
+	}
+	if got[0].Attempt != maxDeliveryHistory+10 {
+		t.Errorf("newest attempt = %d, want %d", got[0].Attempt, maxDeliveryHistory+10)
+	}
+}
+
+func TestListDeliveriesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := NewMemoryStore()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       "https://example.com/hook",
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+	})
+
+	d := NewDispatcher(store)
+	d.deliveries.record(DeliveryAttempt{
+		SubscriptionID: "wh1",
+		EventID:        "evt_1",
+		EventType:      EventPaymentReceived,
+		Attempt:        1,
+		StatusCode:     200,
+		Success:        true,
+		At:             time.Now(),
+	})
+
+	h := NewHandler(store, d)
+	r := gin.New()
+	h.RegisterRoutes(r.Group("/v1"))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/agents/0xabc/webhooks/wh1/deliveries", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d; body %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Deliveries []DeliveryAttempt `json:"deliveries"`
+		Count      int               `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Count != 1 || len(resp.Deliveries) != 1 || resp.Deliveries[0].EventID != "evt_1" {
+		t.Errorf("response = %+v, want the recorded attempt", resp)
+	}
+
+	// Another agent cannot read the history.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/agents/0xother/webhooks/wh1/deliveries", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("foreign read: status = %d, want 403", w.Code)
+	}
+
+	// Unknown webhook is a 404.
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/agents/0xabc/webhooks/missing/deliveries", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown webhook: status = %d, want 404", w.Code)
+	}
+}
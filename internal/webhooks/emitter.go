@@ -2,6 +2,7 @@ package webhooks
 
 import (
 	"context"
+	"database/sql"
 	"log/slog"
 	"sync"
 	"time"
@@ -34,6 +35,7 @@ func init() {
 // All methods are fire-and-forget: errors are logged but never returned.
 type Emitter struct {
 	d              *Dispatcher
+	outbox         *Outbox // nil = EmitInTx falls back to fire-and-forget dispatch
 	logger         *slog.Logger
 	wg             sync.WaitGroup
 	shutdownCtx    context.Context
@@ -46,6 +48,35 @@ func NewEmitter(d *Dispatcher, logger *slog.Logger) *Emitter {
 	return &Emitter{d: d, logger: logger, shutdownCtx: ctx, shutdownCancel: cancel}
 }
 
+// WithOutbox routes transactional emits through a webhook outbox so
+// events survive a crash between DB commit and dispatch.
+func (e *Emitter) WithOutbox(o *Outbox) *Emitter {
+	e.outbox = o
+	return e
+}
+
+// EmitInTx records an event in the webhook outbox within the caller's
+// transaction: the event is dispatched by the relay only if the
+// transaction commits, and cannot be lost once it has. Without an
+// outbox (in-memory mode) it degrades to an immediate fire-and-forget
+// emit after returning nil, which keeps callers portable across both
+// configurations.
+func (e *Emitter) EmitInTx(ctx context.Context, tx *sql.Tx, agentAddr string, eventType EventType, data map[string]interface{}) error {
+	if e == nil || e.d == nil {
+		return nil
+	}
+	if e.outbox == nil || tx == nil {
+		e.emit(agentAddr, eventType, data)
+		return nil
+	}
+	webhookEmitTotal.WithLabelValues(string(eventType)).Inc()
+	if err := e.outbox.WriteInTx(ctx, tx, agentAddr, NewOutboxEvent(eventType, data)); err != nil {
+		webhookEmitErrors.WithLabelValues(string(eventType)).Inc()
+		return err
+	}
+	return nil
+}
+
 // Shutdown cancels in-flight webhook deliveries and waits for them to complete.
 func (e *Emitter) Shutdown(timeout time.Duration) {
 	e.shutdownCancel()
@@ -197,6 +228,29 @@ func (e *Emitter) EmitStreamClosed(buyerAddr, streamID, sellerAddr, spentAmount,
 	})
 }
 
+// EmitStreamTopUp emits a stream.topped_up event after an automatic hold top-up.
+func (e *Emitter) EmitStreamTopUp(buyerAddr, streamID, sellerAddr, amount, newHoldAmount string) {
+	e.emit(buyerAddr, EventStreamTopUp, map[string]interface{}{
+		"streamId":      streamID,
+		"buyerAddr":     buyerAddr,
+		"sellerAddr":    sellerAddr,
+		"amount":        amount,
+		"newHoldAmount": newHoldAmount,
+	})
+}
+
+// EmitStreamTopUpDeclined emits a stream.top_up_declined event when an
+// automatic top-up could not be funded.
+func (e *Emitter) EmitStreamTopUpDeclined(buyerAddr, streamID, sellerAddr, amount, reason string) {
+	e.emit(buyerAddr, EventStreamTopUpDeclined, map[string]interface{}{
+		"streamId":   streamID,
+		"buyerAddr":  buyerAddr,
+		"sellerAddr": sellerAddr,
+		"amount":     amount,
+		"reason":     reason,
+	})
+}
+
 // --- KYA events ---
 
 func (e *Emitter) EmitKYAIssued(agentAddr, certID, trustTier string) {
@@ -231,6 +285,42 @@ func (e *Emitter) EmitChargebackBudgetExceeded(agentAddr, costCenterName, attemp
 	})
 }
 
+// --- Credit events ---
+
+// EmitCreditDelinquent emits a credit.plan.delinquent event when a plan moves
+// into a worse delinquency bucket.
+func (e *Emitter) EmitCreditDelinquent(agentAddr, planID, bucket, overdueAmount string, restricted bool) {
+	e.emit(agentAddr, EventCreditDelinquent, map[string]interface{}{
+		"planId":        planID,
+		"agentAddr":     agentAddr,
+		"bucket":        bucket,
+		"overdueAmount": overdueAmount,
+		"restricted":    restricted,
+	})
+}
+
+// EmitCreditCosignerCharged emits a credit.cosigner.charged event to both the
+// borrower and the co-signer whose balance covered the default.
+func (e *Emitter) EmitCreditCosignerCharged(agentAddr, cosignerAddr, planID, amount string) {
+	data := map[string]interface{}{
+		"planId":       planID,
+		"agentAddr":    agentAddr,
+		"cosignerAddr": cosignerAddr,
+		"amount":       amount,
+	}
+	e.emit(cosignerAddr, EventCreditCosignerCharged, data)
+	e.emit(agentAddr, EventCreditCosignerCharged, data)
+}
+
+// EmitCreditDefaulted emits a credit.plan.defaulted event.
+func (e *Emitter) EmitCreditDefaulted(agentAddr, planID, outstanding string) {
+	e.emit(agentAddr, EventCreditDefaulted, map[string]interface{}{
+		"planId":      planID,
+		"agentAddr":   agentAddr,
+		"outstanding": outstanding,
+	})
+}
+
 // --- Arbitration events ---
 
 func (e *Emitter) EmitArbitrationCaseFiled(buyerAddr, caseID, escrowID, amount string) {
@@ -306,3 +396,160 @@ func (e *Emitter) EmitScoreAlert(agentAddr string, oldScore, newScore float64, r
 		"reason":      reason,
 	})
 }
+
+// --- Service agreement events ---
+
+// EmitAgreementProposed emits an agreement.proposed event to the counterparty.
+func (e *Emitter) EmitAgreementProposed(agentAddr, agreementID, proposedBy, serviceName string) {
+	e.emit(agentAddr, EventAgreementProposed, map[string]interface{}{
+		"agreementId": agreementID,
+		"proposedBy":  proposedBy,
+		"serviceName": serviceName,
+	})
+}
+
+// EmitAgreementAccepted emits an agreement.accepted event.
+func (e *Emitter) EmitAgreementAccepted(agentAddr, agreementID, serviceName string) {
+	e.emit(agentAddr, EventAgreementAccepted, map[string]interface{}{
+		"agreementId": agreementID,
+		"serviceName": serviceName,
+	})
+}
+
+// EmitAgreementRejected emits an agreement.rejected event.
+func (e *Emitter) EmitAgreementRejected(agentAddr, agreementID, serviceName string) {
+	e.emit(agentAddr, EventAgreementRejected, map[string]interface{}{
+		"agreementId": agreementID,
+		"serviceName": serviceName,
+	})
+}
+
+// EmitAgreementBreached emits an agreement.breached event when the rolling
+// success rate falls below the agreed SLA.
+func (e *Emitter) EmitAgreementBreached(agentAddr, agreementID string, successRate, minSuccessRate float64) {
+	e.emit(agentAddr, EventAgreementBreached, map[string]interface{}{
+		"agreementId":    agreementID,
+		"successRate":    successRate,
+		"minSuccessRate": minSuccessRate,
+	})
+}
+
+// EmitAgreementExpired emits an agreement.expired event.
+func (e *Emitter) EmitAgreementExpired(agentAddr, agreementID, serviceName string) {
+	e.emit(agentAddr, EventAgreementExpired, map[string]interface{}{
+		"agreementId": agreementID,
+		"serviceName": serviceName,
+	})
+}
+
+// EmitAgreementTerminated emits an agreement.terminated event.
+func (e *Emitter) EmitAgreementTerminated(agentAddr, agreementID, terminatedBy string) {
+	e.emit(agentAddr, EventAgreementTerminated, map[string]interface{}{
+		"agreementId":  agreementID,
+		"terminatedBy": terminatedBy,
+	})
+}
+
+// EmitAgreementSLAAtRisk emits an agreement.sla.at_risk event when the
+// rolling window is one bad call away from breaching, so on-call staff can
+// intervene before the guarantee is forfeited.
+func (e *Emitter) EmitAgreementSLAAtRisk(agentAddr, agreementID string, successRate, minSuccessRate float64) {
+	e.emit(agentAddr, EventAgreementSLAAtRisk, map[string]interface{}{
+		"agreementId":    agreementID,
+		"successRate":    successRate,
+		"minSuccessRate": minSuccessRate,
+	})
+}
+
+// --- RFP auction events ---
+
+// EmitRFPExtensionProposed emits an rfp.extension.proposed event to a
+// leading bidder whose consent is needed for a deadline extension.
+func (e *Emitter) EmitRFPExtensionProposed(agentAddr, rfpID, extensionID, newDeadline, reason string) {
+	e.emit(agentAddr, EventRFPExtensionProposed, map[string]interface{}{
+		"rfpId":       rfpID,
+		"extensionId": extensionID,
+		"newDeadline": newDeadline,
+		"reason":      reason,
+	})
+}
+
+// EmitRFPExtensionRejected emits an rfp.extension.rejected event to the buyer.
+func (e *Emitter) EmitRFPExtensionRejected(agentAddr, rfpID, extensionID, rejectedBy string) {
+	e.emit(agentAddr, EventRFPExtensionRejected, map[string]interface{}{
+		"rfpId":       rfpID,
+		"extensionId": extensionID,
+		"rejectedBy":  rejectedBy,
+	})
+}
+
+// EmitRFPDeadlineExtended emits an rfp.deadline.extended event once an
+// extension takes effect.
+func (e *Emitter) EmitRFPDeadlineExtended(agentAddr, rfpID, extensionID, newDeadline string) {
+	e.emit(agentAddr, EventRFPDeadlineExtended, map[string]interface{}{
+		"rfpId":       rfpID,
+		"extensionId": extensionID,
+		"newDeadline": newDeadline,
+	})
+}
+
+// --- Stake events ---
+
+// EmitStakeDistributionPaid emits a stake.distribution.paid event to an
+// investor whose position received a share of a revenue distribution.
+func (e *Emitter) EmitStakeDistributionPaid(agentAddr, distributionID, offeringID, positionID, amount, totalAmount string) {
+	e.emit(agentAddr, EventStakeDistributionPaid, map[string]interface{}{
+		"distributionId": distributionID,
+		"offeringId":     offeringID,
+		"positionId":     positionID,
+		"amount":         amount,
+		"totalAmount":    totalAmount,
+	})
+}
+
+// --- Prediction events ---
+
+// EmitPredictionVoided emits a prediction.voided event to the creator of a
+// prediction that was voided with all wagers refunded.
+func (e *Emitter) EmitPredictionVoided(agentAddr, predictionID, claim, reason string) {
+	e.emit(agentAddr, EventPredictionVoided, map[string]interface{}{
+		"predictionId": predictionID,
+		"claim":        claim,
+		"reason":       reason,
+	})
+}
+
+// EmitPredictionCreated emits a prediction.created event to a follower of
+// the predictor who posted the claim.
+func (e *Emitter) EmitPredictionCreated(agentAddr, predictionID, creatorAddr, claim, category string) {
+	e.emit(agentAddr, EventPredictionCreated, map[string]interface{}{
+		"predictionId": predictionID,
+		"creatorAddr":  creatorAddr,
+		"claim":        claim,
+		"category":     category,
+	})
+}
+
+// EmitPredictionResolved emits a prediction.resolved event to a follower of
+// the predictor whose claim just had an outcome recorded.
+func (e *Emitter) EmitPredictionResolved(agentAddr, predictionID, creatorAddr, outcome string) {
+	e.emit(agentAddr, EventPredictionResolved, map[string]interface{}{
+		"predictionId": predictionID,
+		"creatorAddr":  creatorAddr,
+		"outcome":      outcome,
+	})
+}
+
+// --- Saved search events ---
+
+// EmitSearchMatch emits a search.match event to the owner of a saved
+// search that a new or repriced service now satisfies.
+func (e *Emitter) EmitSearchMatch(agentAddr, searchID, sellerAddr, serviceID, serviceType, price string) {
+	e.emit(agentAddr, EventSearchMatch, map[string]interface{}{
+		"searchId":    searchID,
+		"sellerAddr":  sellerAddr,
+		"serviceId":   serviceID,
+		"serviceType": serviceType,
+		"price":       price,
+	})
+}
@@ -145,6 +145,8 @@ func TestEmitter_AllStreamEvents(t *testing.T) {
 
 	e.EmitStreamOpened("0xseller", "stream_1", "0xbuyer", "20.00")
 	e.EmitStreamClosed("0xbuyer", "stream_1", "0xseller", "15.00", "completed")
+	e.EmitStreamTopUp("0xbuyer", "stream_1", "0xseller", "5.00", "25.00")
+	e.EmitStreamTopUpDeclined("0xbuyer", "stream_1", "0xseller", "5.00", "insufficient balance")
 }
 
 // TestEmitter_AllKYAEvents verifies KYA emit methods.
@@ -0,0 +1,155 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// StoredEvent is a dispatched event in the durable log, stamped with a
+// monotonically increasing sequence number consumers use as a replay
+// cursor.
+type StoredEvent struct {
+	Seq       int64  `json:"seq"`
+	AgentAddr string `json:"agentAddr,omitempty"` // empty for broadcast events
+	Event     *Event `json:"event"`
+}
+
+// EventLogStore persists dispatched events for replay. Append assigns
+// and returns the sequence number.
+type EventLogStore interface {
+	Append(ctx context.Context, agentAddr string, event *Event) (int64, error)
+	ListAfter(ctx context.Context, afterSeq int64, limit int) ([]*StoredEvent, error)
+	ListSince(ctx context.Context, since time.Time, limit int) ([]*StoredEvent, error)
+}
+
+// maxReplayEvents caps how many events a single replay or catch-up run
+// will redeliver.
+const maxReplayEvents = 1000
+
+// WithEventLog makes the dispatcher record every dispatched event so
+// consumers can replay missed deliveries from a sequence cursor.
+func (d *Dispatcher) WithEventLog(store EventLogStore) *Dispatcher {
+	d.eventLog = store
+	return d
+}
+
+// logEvent appends an event to the durable log and stamps its sequence
+// number into the event (and therefore the delivery payload). Log
+// failures don't block delivery.
+func (d *Dispatcher) logEvent(ctx context.Context, agentAddr string, event *Event) {
+	if d.eventLog == nil {
+		return
+	}
+	seq, err := d.eventLog.Append(ctx, agentAddr, event)
+	if err != nil {
+		slog.Warn("webhook event log append failed", "event_id", event.ID, "error", err)
+		return
+	}
+	event.Seq = seq
+}
+
+// Replay redelivers logged events after the given sequence cursor to one
+// subscription, in order, applying the subscription's event filters. It
+// sends synchronously (each delivery retries as usual) and returns how
+// many events were redelivered.
+func (d *Dispatcher) Replay(ctx context.Context, sub *Subscription, afterSeq int64) (int, error) {
+	if d.eventLog == nil {
+		return 0, fmt.Errorf("event log not enabled")
+	}
+	records, err := d.eventLog.ListAfter(ctx, afterSeq, maxReplayEvents)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read event log: %w", err)
+	}
+	return d.redeliver(ctx, sub, records), nil
+}
+
+// catchUp redelivers events logged since a subscription's last
+// successful delivery. Used when a webhook is re-enabled.
+func (d *Dispatcher) catchUp(ctx context.Context, sub *Subscription, since time.Time) (int, error) {
+	if d.eventLog == nil {
+		return 0, nil
+	}
+	records, err := d.eventLog.ListSince(ctx, since, maxReplayEvents)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read event log: %w", err)
+	}
+	return d.redeliver(ctx, sub, records), nil
+}
+
+func (d *Dispatcher) redeliver(ctx context.Context, sub *Subscription, records []*StoredEvent) int {
+	sent := 0
+	for _, rec := range records {
+		if rec.AgentAddr != "" && rec.AgentAddr != sub.AgentAddr {
+			continue
+		}
+		if !sub.matchesEvent(rec.Event.Type) {
+			continue
+		}
+		d.send(ctx, sub, rec.Event)
+		sent++
+	}
+	return sent
+}
+
+// MemoryEventLog is an in-memory EventLogStore for development and
+// tests. Retention is bounded; the oldest entries fall off.
+type MemoryEventLog struct {
+	mu      sync.RWMutex
+	nextSeq int64
+	events  []*StoredEvent
+}
+
+var _ EventLogStore = (*MemoryEventLog)(nil)
+
+const maxMemoryEventLog = 10000
+
+// NewMemoryEventLog creates an empty in-memory event log.
+func NewMemoryEventLog() *MemoryEventLog {
+	return &MemoryEventLog{}
+}
+
+func (m *MemoryEventLog) Append(_ context.Context, agentAddr string, event *Event) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextSeq++
+	cp := *event
+	cp.Seq = m.nextSeq
+	m.events = append(m.events, &StoredEvent{Seq: m.nextSeq, AgentAddr: agentAddr, Event: &cp})
+	if len(m.events) > maxMemoryEventLog {
+		m.events = m.events[len(m.events)-maxMemoryEventLog:]
+	}
+	return m.nextSeq, nil
+}
+
+func (m *MemoryEventLog) ListAfter(_ context.Context, afterSeq int64, limit int) ([]*StoredEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []*StoredEvent
+	for _, rec := range m.events {
+		if rec.Seq > afterSeq {
+			out = append(out, rec)
+			if limit > 0 && len(out) >= limit {
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *MemoryEventLog) ListSince(_ context.Context, since time.Time, limit int) ([]*StoredEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []*StoredEvent
+	for _, rec := range m.events {
+		if !rec.Event.Timestamp.Before(since) {
+			out = append(out, rec)
+			if limit > 0 && len(out) >= limit {
+				break
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,223 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type capturedDelivery struct {
+	seq  string
+	body Event
+}
+
+// captureServer records delivered events in order.
+func captureServer(t *testing.T) (*httptest.Server, func() []capturedDelivery) {
+	t.Helper()
+	var mu sync.Mutex
+	var got []capturedDelivery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var evt Event
+		_ = json.NewDecoder(r.Body).Decode(&evt)
+		mu.Lock()
+		got = append(got, capturedDelivery{seq: r.Header.Get("X-Alancoin-Sequence"), body: evt})
+		mu.Unlock()
+		w.WriteHeader(200)
+	}))
+	return server, func() []capturedDelivery {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]capturedDelivery(nil), got...)
+	}
+}
+
+func TestDispatch_AssignsSequenceNumbers(t *testing.T) {
+	store := NewMemoryStore()
+	server, deliveries := captureServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+	})
+
+	d := NewDispatcher(store).WithEventLog(NewMemoryEventLog())
+	d.urlValidator = noopValidator
+
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+	d.Dispatch(ctx, &Event{ID: "evt_2", Type: EventPaymentReceived, Timestamp: time.Now()})
+	time.Sleep(300 * time.Millisecond)
+
+	got := deliveries()
+	if len(got) != 2 {
+		t.Fatalf("delivered %d events, want 2", len(got))
+	}
+	seqs := map[string]int64{}
+	for _, g := range got {
+		seqs[g.body.ID] = g.body.Seq
+	}
+	if seqs["evt_1"] != 1 || seqs["evt_2"] != 2 {
+		t.Errorf("payload seqs = %v, want evt_1=1 evt_2=2", seqs)
+	}
+	for _, g := range got {
+		if g.seq == "" || g.seq == "0" {
+			t.Errorf("missing X-Alancoin-Sequence header on delivery of %s", g.body.ID)
+		}
+	}
+}
+
+func TestReplay_RedeliversAfterCursor(t *testing.T) {
+	store := NewMemoryStore()
+	server, deliveries := captureServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	sub := &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{"payment.*"},
+		Active:    true,
+	}
+	store.Create(ctx, sub)
+
+	d := NewDispatcher(store).WithEventLog(NewMemoryEventLog())
+	d.urlValidator = noopValidator
+
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+	d.Dispatch(ctx, &Event{ID: "evt_2", Type: EventPaymentSent, Timestamp: time.Now()})
+	d.Dispatch(ctx, &Event{ID: "evt_3", Type: EventEscrowCreated, Timestamp: time.Now()}) // filtered out
+	time.Sleep(300 * time.Millisecond)
+	before := len(deliveries())
+
+	sent, err := d.Replay(ctx, sub, 1)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("replayed %d events after seq 1, want 1 (evt_2; evt_3 is filtered)", sent)
+	}
+	got := deliveries()
+	if len(got) != before+1 {
+		t.Fatalf("deliveries = %d, want %d", len(got), before+1)
+	}
+	last := got[len(got)-1]
+	if last.body.ID != "evt_2" || last.body.Seq != 2 {
+		t.Errorf("replayed event = %+v, want evt_2 with original seq 2", last.body)
+	}
+}
+
+func TestEnableWebhook_CatchesUpMissedEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := NewMemoryStore()
+	server, deliveries := captureServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	lastSuccess := time.Now().Add(-time.Hour)
+	store.Create(ctx, &Subscription{
+		ID:          "wh1",
+		AgentAddr:   "0xabc",
+		URL:         server.URL,
+		Events:      []EventType{EventPaymentReceived},
+		Active:      false, // deactivated while the consumer was down
+		LastSuccess: &lastSuccess,
+	})
+
+	d := NewDispatcher(store).WithEventLog(NewMemoryEventLog())
+	d.urlValidator = noopValidator
+
+	// Dispatched while inactive: logged but not delivered.
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+	time.Sleep(200 * time.Millisecond)
+	if n := len(deliveries()); n != 0 {
+		t.Fatalf("inactive webhook received %d deliveries", n)
+	}
+
+	h := NewHandler(store, d)
+	r := gin.New()
+	h.RegisterRoutes(r.Group("/v1"))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/agents/0xabc/webhooks/wh1/enable", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("enable: status = %d; body %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Active  bool `json:"active"`
+		CatchUp bool `json:"catchUp"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if !resp.Active || !resp.CatchUp {
+		t.Errorf("response = %+v, want active with catch-up started", resp)
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	got := deliveries()
+	if len(got) != 1 || got[0].body.ID != "evt_1" {
+		t.Fatalf("catch-up deliveries = %+v, want the missed evt_1", got)
+	}
+	if sub, _ := store.Get(ctx, "wh1"); !sub.Active {
+		t.Errorf("subscription not reactivated")
+	}
+}
+
+func TestReplayWebhook_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := NewMemoryStore()
+	server, deliveries := captureServer(t)
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{"*"},
+		Active:    true,
+	})
+
+	d := NewDispatcher(store).WithEventLog(NewMemoryEventLog())
+	d.urlValidator = noopValidator
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+	time.Sleep(200 * time.Millisecond)
+	before := len(deliveries())
+
+	h := NewHandler(store, d)
+	r := gin.New()
+	h.RegisterRoutes(r.Group("/v1"))
+
+	// Foreign agent is rejected.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/agents/0xother/webhooks/wh1/replay", bytes.NewReader([]byte(`{"afterSeq":0}`)))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("foreign replay: status = %d, want 403", w.Code)
+	}
+
+	// Owner replay is accepted and redelivers in the background.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/v1/agents/0xabc/webhooks/wh1/replay", bytes.NewReader([]byte(`{"afterSeq":0}`)))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("replay: status = %d; body %s", w.Code, w.Body.String())
+	}
+	time.Sleep(300 * time.Millisecond)
+	if got := deliveries(); len(got) != before+1 {
+		t.Errorf("deliveries after replay = %d, want %d", len(got), before+1)
+	}
+}
@@ -0,0 +1,137 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMatchesEventPattern(t *testing.T) {
+	cases := []struct {
+		pattern EventType
+		event   EventType
+		want    bool
+	}{
+		{EventPaymentReceived, EventPaymentReceived, true},
+		{EventPaymentReceived, EventPaymentSent, false},
+		{"payment.*", EventPaymentReceived, true},
+		{"payment.*", EventPaymentSent, true},
+		{"payment.*", EventEscrowCreated, false},
+		{"gateway.*", EventGatewaySessionCreated, true},
+		{"gateway.session.*", EventGatewaySessionClosed, true},
+		{"gateway.session.*", EventGatewayProxySuccess, false},
+		{"*", EventForensicsAlertCritical, true},
+		{"payment", EventPaymentReceived, false}, // no bare-prefix matching
+	}
+	for _, tc := range cases {
+		if got := matchesEventPattern(tc.pattern, tc.event); got != tc.want {
+			t.Errorf("matchesEventPattern(%q, %q) = %v, want %v", tc.pattern, tc.event, got, tc.want)
+		}
+	}
+}
+
+func TestValidEventPattern(t *testing.T) {
+	for _, valid := range []EventType{EventPaymentReceived, "*", "escrow.*", "credit.*"} {
+		if !validEventPattern(valid) {
+			t.Errorf("validEventPattern(%q) = false, want true", valid)
+		}
+	}
+	for _, invalid := range []EventType{"", "payment.bogus", "nosuch.*", "payment"} {
+		if validEventPattern(invalid) {
+			t.Errorf("validEventPattern(%q) = true, want false", invalid)
+		}
+	}
+}
+
+func TestDispatch_WildcardSubscription(t *testing.T) {
+	store := NewMemoryStore()
+
+	var delivered atomic.Int32
+	var gotVersion atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered.Add(1)
+		gotVersion.Store(r.Header.Get("X-Alancoin-Payload-Version"))
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{"escrow.*"},
+		Active:    true,
+	})
+
+	d := NewDispatcher(store)
+	d.urlValidator = noopValidator
+
+	d.Dispatch(ctx, &Event{ID: "evt_1", Type: EventEscrowCreated, Timestamp: time.Now()})
+	time.Sleep(200 * time.Millisecond)
+	if delivered.Load() != 1 {
+		t.Fatalf("escrow.created delivered %d times, want 1", delivered.Load())
+	}
+	if gotVersion.Load() != "1" {
+		t.Errorf("X-Alancoin-Payload-Version = %q, want 1", gotVersion.Load())
+	}
+
+	// An event outside the wildcard's prefix is filtered out.
+	d.InvalidateSubsCache()
+	d.Dispatch(ctx, &Event{ID: "evt_2", Type: EventPaymentReceived, Timestamp: time.Now()})
+	time.Sleep(200 * time.Millisecond)
+	if delivered.Load() != 1 {
+		t.Errorf("payment.received delivered to an escrow.* subscription")
+	}
+}
+
+func TestCreateWebhook_FiltersAndVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewHandler(NewMemoryStore(), NewDispatcher(NewMemoryStore()))
+	r := gin.New()
+	h.RegisterRoutes(r.Group("/v1"))
+
+	// Public IP literal so URL validation passes without DNS.
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/agents/0xabc/webhooks", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"url":"https://93.184.216.34/hook","events":["payment.*","escrow.released"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d; body %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Webhook struct {
+			Events         []string `json:"events"`
+			PayloadVersion int      `json:"payloadVersion"`
+		} `json:"webhook"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Webhook.PayloadVersion != CurrentPayloadVersion {
+		t.Errorf("payloadVersion = %d, want pinned to current (%d)", resp.Webhook.PayloadVersion, CurrentPayloadVersion)
+	}
+	if len(resp.Webhook.Events) != 2 || resp.Webhook.Events[0] != "payment.*" {
+		t.Errorf("events = %v, want wildcard preserved", resp.Webhook.Events)
+	}
+
+	if w := post(`{"url":"https://93.184.216.34/hook","events":["nosuch.*"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown wildcard: status = %d, want 400", w.Code)
+	}
+	if w := post(`{"url":"https://93.184.216.34/hook","events":["payment.bogus"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown type: status = %d, want 400", w.Code)
+	}
+	if w := post(`{"url":"https://93.184.216.34/hook","events":["*"],"payloadVersion":99}`); w.Code != http.StatusBadRequest {
+		t.Errorf("future version: status = %d, want 400", w.Code)
+	}
+}
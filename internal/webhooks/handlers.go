@@ -1,23 +1,29 @@
 package webhooks
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mbd888/alancoin/internal/idgen"
+	"github.com/mbd888/alancoin/internal/logging"
+	"github.com/mbd888/alancoin/internal/recovery"
 )
 
 // Handler provides HTTP endpoints for webhook management
 type Handler struct {
-	store      Store
-	dispatcher *Dispatcher
+	store       Store
+	dispatcher  *Dispatcher
+	deadLetters DeadLetterStore
 }
 
 // NewHandler creates a new webhook handler
@@ -28,17 +34,45 @@ func NewHandler(store Store, dispatcher *Dispatcher) *Handler {
 	}
 }
 
+// WithDeadLetters enables the dead-letter inspection and redelivery
+// endpoints.
+func (h *Handler) WithDeadLetters(store DeadLetterStore) *Handler {
+	h.deadLetters = store
+	return h
+}
+
 // RegisterRoutes sets up webhook routes
 func (h *Handler) RegisterRoutes(r *gin.RouterGroup) {
 	r.POST("/agents/:address/webhooks", h.CreateWebhook)
 	r.GET("/agents/:address/webhooks", h.ListWebhooks)
 	r.DELETE("/agents/:address/webhooks/:webhookId", h.DeleteWebhook)
+	r.POST("/agents/:address/webhooks/:webhookId/rotate-secret", h.RotateSecret)
+	r.GET("/agents/:address/webhooks/:webhookId/deliveries", h.ListDeliveries)
+	r.POST("/agents/:address/webhooks/:webhookId/replay", h.ReplayWebhook)
+	r.POST("/agents/:address/webhooks/:webhookId/enable", h.EnableWebhook)
+	r.GET("/agents/:address/webhooks/dead-letters", h.ListDeadLetters)
+	r.POST("/agents/:address/webhooks/dead-letters/:deadLetterId/redeliver", h.RedeliverDeadLetter)
 }
 
+// secretRotationOverlap is how long deliveries stay dual-signed with the
+// previous secret after a rotation.
+const secretRotationOverlap = 24 * time.Hour
+
 // CreateWebhookRequest for creating a webhook subscription
 type CreateWebhookRequest struct {
 	URL    string   `json:"url" binding:"required"`
-	Events []string `json:"events" binding:"required"`
+	Events []string `json:"events" binding:"required"` // exact types, "*", or prefix wildcards like "escrow.*"
+	// PayloadVersion pins the delivery payload schema for this endpoint.
+	// Zero means the current version.
+	PayloadVersion int `json:"payloadVersion"`
+	// Setting either knob opts the endpoint into batched delivery:
+	// events arrive as arrays, flushed every BatchWindowSeconds or when
+	// BatchMaxEvents are pending, whichever comes first.
+	BatchWindowSeconds int `json:"batchWindowSeconds"`
+	BatchMaxEvents     int `json:"batchMaxEvents"`
+	// Template reshapes deliveries for receivers with their own format
+	// (Slack, PagerDuty). See PayloadTemplate for the placeholder syntax.
+	Template PayloadTemplate `json:"template"`
 }
 
 // CreateWebhook handles POST /agents/:address/webhooks
@@ -63,55 +97,81 @@ func (h *Handler) CreateWebhook(c *gin.Context) {
 		return
 	}
 
-	// Validate events against known types
-	validEvents := map[EventType]bool{
-		EventPaymentReceived:         true,
-		EventPaymentSent:             true,
-		EventSessionKeyUsed:          true,
-		EventSessionKeyCreated:       true,
-		EventSessionKeyRevoked:       true,
-		EventBalanceDeposit:          true,
-		EventBalanceWithdraw:         true,
-		EventSessionKeyBudgetWarning: true,
-		EventSessionKeyExpiring:      true,
-		EventGatewaySessionCreated:   true,
-		EventGatewaySessionClosed:    true,
-		EventGatewayProxySuccess:     true,
-		EventGatewaySettlementFailed: true,
-		EventEscrowCreated:           true,
-		EventEscrowDelivered:         true,
-		EventEscrowReleased:          true,
-		EventEscrowRefunded:          true,
-		EventEscrowDisputed:          true,
-		EventStreamOpened:            true,
-		EventStreamClosed:            true,
-		EventStreamSettled:           true,
-	}
+	// Validate event filters: exact known types, "*", or prefix
+	// wildcards that cover at least one known type.
 	events := make([]EventType, 0, len(req.Events))
 	for _, e := range req.Events {
 		et := EventType(e)
-		if !validEvents[et] {
+		if !validEventPattern(et) {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error":   "invalid_event_type",
-				"message": fmt.Sprintf("Unknown event type: %s", e),
+				"message": fmt.Sprintf("Unknown event type or pattern: %s", e),
 			})
 			return
 		}
 		events = append(events, et)
 	}
 
+	version := req.PayloadVersion
+	if version == 0 {
+		version = CurrentPayloadVersion
+	}
+	if version < 1 || version > CurrentPayloadVersion {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_payload_version",
+			"message": fmt.Sprintf("Payload version must be between 1 and %d", CurrentPayloadVersion),
+		})
+		return
+	}
+
+	if req.BatchWindowSeconds < 0 || req.BatchWindowSeconds > maxBatchWindow {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_batch_config",
+			"message": fmt.Sprintf("batchWindowSeconds must be between 0 and %d", maxBatchWindow),
+		})
+		return
+	}
+	if req.BatchMaxEvents < 0 || req.BatchMaxEvents > maxBatchEvents {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_batch_config",
+			"message": fmt.Sprintf("batchMaxEvents must be between 0 and %d", maxBatchEvents),
+		})
+		return
+	}
+
+	if len(req.Template) > 0 {
+		if req.BatchWindowSeconds > 0 || req.BatchMaxEvents > 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_template",
+				"message": "Templates apply to per-event deliveries and cannot be combined with batching",
+			})
+			return
+		}
+		if err := validateTemplate(req.Template); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_template",
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+
 	// Generate ID and secret
 	id := idgen.WithPrefix("wh_")
 	secret := generateSecret()
 
 	sub := &Subscription{
-		ID:        id,
-		AgentAddr: address,
-		URL:       req.URL,
-		Secret:    secret,
-		Events:    events,
-		Active:    true,
-		CreatedAt: time.Now(),
+		ID:                 id,
+		AgentAddr:          address,
+		URL:                req.URL,
+		Secret:             secret,
+		Events:             events,
+		PayloadVersion:     version,
+		BatchWindowSeconds: req.BatchWindowSeconds,
+		BatchMaxEvents:     req.BatchMaxEvents,
+		Template:           req.Template,
+		Active:             true,
+		CreatedAt:          time.Now(),
 	}
 
 	if err := h.store.Create(c.Request.Context(), sub); err != nil {
@@ -124,20 +184,69 @@ func (h *Handler) CreateWebhook(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{
 		"webhook": gin.H{
-			"id":        sub.ID,
-			"url":       sub.URL,
-			"events":    sub.Events,
-			"active":    sub.Active,
-			"createdAt": sub.CreatedAt,
+			"id":                 sub.ID,
+			"url":                sub.URL,
+			"events":             sub.Events,
+			"payloadVersion":     sub.PayloadVersion,
+			"batchWindowSeconds": sub.BatchWindowSeconds,
+			"batchMaxEvents":     sub.BatchMaxEvents,
+			"template":           sub.Template,
+			"active":             sub.Active,
+			"createdAt":          sub.CreatedAt,
 		},
 		"secret": secret, // Only shown once!
 		"usage": gin.H{
-			"signature": "Verify with HMAC-SHA256(payload, secret)",
+			"signature": "Header is t=<unix>,v1=<hex>; verify v1 == HMAC-SHA256(secret, '<t>.<payload>') and that t is recent",
 			"header":    "X-Alancoin-Signature",
+			"docs":      "docs/webhooks.md",
 		},
 	})
 }
 
+// RotateSecret handles POST /agents/:address/webhooks/:webhookId/rotate-secret.
+// The old secret keeps verifying deliveries for the overlap window, during
+// which payloads carry two v1 signatures.
+func (h *Handler) RotateSecret(c *gin.Context) {
+	address := c.Param("address")
+	webhookID := c.Param("webhookId")
+
+	webhook, err := h.store.Get(c.Request.Context(), webhookID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Webhook not found",
+		})
+		return
+	}
+	if webhook.AgentAddr != address {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Webhook does not belong to this agent",
+		})
+		return
+	}
+
+	expiry := time.Now().Add(secretRotationOverlap)
+	webhook.PrevSecret = webhook.Secret
+	webhook.PrevSecretExpiresAt = &expiry
+	webhook.Secret = generateSecret()
+
+	if err := h.store.Update(c.Request.Context(), webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "rotate_failed",
+			"message": "Failed to rotate webhook secret",
+		})
+		return
+	}
+	h.dispatcher.InvalidateSubsCache()
+
+	c.JSON(http.StatusOK, gin.H{
+		"secret":              webhook.Secret, // Only shown once!
+		"prevSecretExpiresAt": expiry,
+		"message":             "Deliveries are dual-signed with the previous secret until it expires",
+	})
+}
+
 // ListWebhooks handles GET /agents/:address/webhooks
 func (h *Handler) ListWebhooks(c *gin.Context) {
 	address := c.Param("address")
@@ -155,13 +264,17 @@ func (h *Handler) ListWebhooks(c *gin.Context) {
 	webhooks := make([]gin.H, len(subs))
 	for i, sub := range subs {
 		webhooks[i] = gin.H{
-			"id":          sub.ID,
-			"url":         sub.URL,
-			"events":      sub.Events,
-			"active":      sub.Active,
-			"createdAt":   sub.CreatedAt,
-			"lastSuccess": sub.LastSuccess,
-			"lastError":   sub.LastError,
+			"id":                 sub.ID,
+			"url":                sub.URL,
+			"events":             sub.Events,
+			"payloadVersion":     sub.payloadVersion(),
+			"batchWindowSeconds": sub.BatchWindowSeconds,
+			"batchMaxEvents":     sub.BatchMaxEvents,
+			"template":           sub.Template,
+			"active":             sub.Active,
+			"createdAt":          sub.CreatedAt,
+			"lastSuccess":        sub.LastSuccess,
+			"lastError":          sub.LastError,
 		}
 	}
 
@@ -203,6 +316,239 @@ func (h *Handler) DeleteWebhook(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// ListDeliveries handles GET /agents/:address/webhooks/:webhookId/deliveries.
+// History is in-memory and per-process — a debugging aid for integrators,
+// not an audit trail.
+func (h *Handler) ListDeliveries(c *gin.Context) {
+	address := c.Param("address")
+	webhookID := c.Param("webhookId")
+
+	webhook, err := h.store.Get(c.Request.Context(), webhookID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Webhook not found",
+		})
+		return
+	}
+	if webhook.AgentAddr != address {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Webhook does not belong to this agent",
+		})
+		return
+	}
+
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if n, err := strconv.Atoi(l); err == nil && n > 0 && n <= maxDeliveryHistory {
+			limit = n
+		}
+	}
+
+	deliveries := h.dispatcher.DeliveryHistory(webhookID, limit)
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"count":      len(deliveries),
+	})
+}
+
+// ReplayRequest asks for redelivery of logged events after a sequence
+// cursor.
+type ReplayRequest struct {
+	AfterSeq int64 `json:"afterSeq"`
+}
+
+// ReplayWebhook handles POST /agents/:address/webhooks/:webhookId/replay.
+// Redelivery runs in the background — each event goes through the normal
+// delivery path with retries — so the response only acknowledges the
+// replay was started. Consumers dedupe by event ID or sequence number.
+func (h *Handler) ReplayWebhook(c *gin.Context) {
+	if h.dispatcher.eventLog == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{
+			"error":   "replay_disabled",
+			"message": "Event log is not enabled",
+		})
+		return
+	}
+	address := c.Param("address")
+	webhookID := c.Param("webhookId")
+
+	webhook, err := h.store.Get(c.Request.Context(), webhookID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Webhook not found",
+		})
+		return
+	}
+	if webhook.AgentAddr != address {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Webhook does not belong to this agent",
+		})
+		return
+	}
+
+	var req ReplayRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.AfterSeq < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Body must contain a non-negative afterSeq cursor",
+		})
+		return
+	}
+
+	ctx := context.WithoutCancel(c.Request.Context())
+	go func() {
+		defer recovery.LogPanic(logging.L(ctx), "webhook_replay")
+		if _, err := h.dispatcher.Replay(ctx, webhook, req.AfterSeq); err != nil {
+			slog.Warn("webhook replay failed", "subscription_id", webhook.ID, "error", err)
+		}
+	}()
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status":    "replay_started",
+		"afterSeq":  req.AfterSeq,
+		"maxEvents": maxReplayEvents,
+	})
+}
+
+// EnableWebhook handles POST /agents/:address/webhooks/:webhookId/enable.
+// Reactivates a deactivated or suspended webhook and, when the event log
+// is enabled, catches it up on events dispatched since its last
+// successful delivery.
+func (h *Handler) EnableWebhook(c *gin.Context) {
+	address := c.Param("address")
+	webhookID := c.Param("webhookId")
+
+	webhook, err := h.store.Get(c.Request.Context(), webhookID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Webhook not found",
+		})
+		return
+	}
+	if webhook.AgentAddr != address {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Webhook does not belong to this agent",
+		})
+		return
+	}
+
+	webhook.Active = true
+	webhook.ConsecutiveFailures = 0
+	webhook.SuspendedUntil = nil
+	if err := h.store.Update(c.Request.Context(), webhook); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "enable_failed",
+			"message": "Failed to enable webhook",
+		})
+		return
+	}
+	h.dispatcher.InvalidateSubsCache()
+
+	catchUp := h.dispatcher.eventLog != nil && webhook.LastSuccess != nil
+	if catchUp {
+		since := *webhook.LastSuccess
+		ctx := context.WithoutCancel(c.Request.Context())
+		go func() {
+			defer recovery.LogPanic(logging.L(ctx), "webhook_catchup")
+			if _, err := h.dispatcher.catchUp(ctx, webhook, since); err != nil {
+				slog.Warn("webhook catch-up failed", "subscription_id", webhook.ID, "error", err)
+			}
+		}()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"active":  true,
+		"catchUp": catchUp,
+	})
+}
+
+// ListDeadLetters handles GET /agents/:address/webhooks/dead-letters
+func (h *Handler) ListDeadLetters(c *gin.Context) {
+	if h.deadLetters == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{
+			"error":   "dead_letters_disabled",
+			"message": "Dead-letter storage is not enabled",
+		})
+		return
+	}
+	address := c.Param("address")
+
+	limit := 50
+	if l := c.Query("limit"); l != "" {
+		if n, err := strconv.Atoi(l); err == nil && n > 0 && n <= 500 {
+			limit = n
+		}
+	}
+
+	letters, err := h.deadLetters.ListByAgent(c.Request.Context(), address, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "list_failed",
+			"message": "Failed to list dead letters",
+		})
+		return
+	}
+	if letters == nil {
+		letters = []*DeadLetter{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"deadLetters": letters,
+		"count":       len(letters),
+	})
+}
+
+// RedeliverDeadLetter handles POST /agents/:address/webhooks/dead-letters/:deadLetterId/redeliver.
+// One synchronous attempt; the dead letter is removed on success and kept
+// for another try on failure.
+func (h *Handler) RedeliverDeadLetter(c *gin.Context) {
+	if h.deadLetters == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{
+			"error":   "dead_letters_disabled",
+			"message": "Dead-letter storage is not enabled",
+		})
+		return
+	}
+	address := c.Param("address")
+	deadLetterID := c.Param("deadLetterId")
+
+	dl, err := h.deadLetters.Get(c.Request.Context(), deadLetterID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "not_found",
+			"message": "Dead letter not found",
+		})
+		return
+	}
+	if dl.AgentAddr != address {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "forbidden",
+			"message": "Dead letter does not belong to this agent",
+		})
+		return
+	}
+
+	if err := h.dispatcher.Redeliver(c.Request.Context(), dl); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "redelivery_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+	if err := h.deadLetters.Delete(c.Request.Context(), deadLetterID); err != nil {
+		// Delivered but not cleaned up; the entry will simply show up
+		// again and a second redelivery is harmless for the receiver.
+		c.JSON(http.StatusOK, gin.H{"delivered": true, "removed": false})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"delivered": true, "removed": true})
+}
+
 // validateWebhookURL checks that a webhook URL is safe to call.
 // Blocks private/internal IPs to prevent SSRF attacks.
 func validateWebhookURL(rawURL string) error {
@@ -0,0 +1,166 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/mbd888/alancoin/internal/idgen"
+)
+
+// Outbox implements the transactional outbox pattern for webhook
+// dispatch, mirroring eventbus.Outbox.
+//
+// Webhook events emitted after a DB write (escrow confirmed, payment
+// recorded) are lost if the process crashes between the commit and the
+// dispatch. Writing the event to webhook_outbox IN THE SAME TRANSACTION
+// as the state change closes that gap: a background relay reads
+// unpublished rows, hands them to the dispatcher, and marks them. If
+// the relay crashes it re-reads unpublished rows on restart.
+//
+// Usage:
+//
+//	// In the state-change transaction:
+//	tx, _ := db.BeginTx(ctx, nil)
+//	escrowStore.MarkReleased(tx, ...)
+//	emitter.EmitInTx(ctx, tx, sellerAddr, webhooks.EventEscrowReleased, data)
+//	tx.Commit()
+//
+//	// Background relay (started once):
+//	go outbox.Relay(ctx, dispatcher, time.Second)
+type Outbox struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// NewOutbox creates a webhook outbox backed by PostgreSQL.
+func NewOutbox(db *sql.DB, logger *slog.Logger) *Outbox {
+	return &Outbox{db: db, logger: logger}
+}
+
+// WriteInTx records an event for dispatch within an existing
+// transaction. The event only becomes visible to the relay after the
+// transaction commits. agentAddr targets the event at one agent's
+// webhooks; empty means broadcast by event type.
+func (o *Outbox) WriteInTx(ctx context.Context, tx *sql.Tx, agentAddr string, event *Event) error {
+	dataJSON, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO webhook_outbox (id, agent_address, event_type, data, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, event.ID, agentAddr, string(event.Type), dataJSON, event.Timestamp)
+	return err
+}
+
+// Write records an event in its own transaction (non-transactional
+// path). Use WriteInTx when you need atomicity with another operation.
+func (o *Outbox) Write(ctx context.Context, agentAddr string, event *Event) error {
+	dataJSON, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	_, err = o.db.ExecContext(ctx, `
+		INSERT INTO webhook_outbox (id, agent_address, event_type, data, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, event.ID, agentAddr, string(event.Type), dataJSON, event.Timestamp)
+	return err
+}
+
+// Relay continuously reads unpublished events from the outbox and hands
+// them to the dispatcher, marking rows once dispatch is accepted (the
+// dispatcher's own retry and dead-letter machinery owns delivery from
+// there). Runs until ctx is cancelled.
+func (o *Outbox) Relay(ctx context.Context, d *Dispatcher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Final drain
+			o.dispatchBatch(ctx, d)
+			return
+		case <-ticker.C:
+			o.dispatchBatch(ctx, d)
+		}
+	}
+}
+
+func (o *Outbox) dispatchBatch(ctx context.Context, d *Dispatcher) {
+	// SELECT FOR UPDATE SKIP LOCKED: only one relay processes each row,
+	// even with multiple server instances.
+	rows, err := o.db.QueryContext(ctx, `
+		SELECT id, agent_address, event_type, data, created_at
+		FROM webhook_outbox
+		WHERE published = FALSE
+		ORDER BY created_at ASC
+		LIMIT 100
+		FOR UPDATE SKIP LOCKED
+	`)
+	if err != nil {
+		if ctx.Err() == nil {
+			o.logger.Error("webhook outbox: poll query failed", "error", err)
+		}
+		return
+	}
+	defer func() { _ = rows.Close() }()
+
+	var dispatchedIDs []string
+	for rows.Next() {
+		var id, agentAddr, eventType string
+		var dataJSON []byte
+		var createdAt time.Time
+		if err := rows.Scan(&id, &agentAddr, &eventType, &dataJSON, &createdAt); err != nil {
+			o.logger.Error("webhook outbox: scan failed", "error", err)
+			continue
+		}
+
+		event := &Event{ID: id, Type: EventType(eventType), Timestamp: createdAt}
+		if len(dataJSON) > 0 {
+			if err := json.Unmarshal(dataJSON, &event.Data); err != nil {
+				o.logger.Error("webhook outbox: bad payload, skipping", "id", id, "error", err)
+				dispatchedIDs = append(dispatchedIDs, id) // poison row; don't retry forever
+				continue
+			}
+		}
+
+		if agentAddr != "" {
+			err = d.DispatchToAgent(ctx, agentAddr, event)
+		} else {
+			err = d.Dispatch(ctx, event)
+		}
+		if err != nil {
+			o.logger.Warn("webhook outbox: dispatch failed, will retry", "id", id, "error", err)
+			continue
+		}
+		dispatchedIDs = append(dispatchedIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		o.logger.Error("webhook outbox: row iteration failed", "error", err)
+	}
+
+	if len(dispatchedIDs) == 0 {
+		return
+	}
+	if _, err := o.db.ExecContext(ctx, `
+		UPDATE webhook_outbox SET published = TRUE, published_at = NOW() WHERE id = ANY($1)
+	`, pq.Array(dispatchedIDs)); err != nil {
+		o.logger.Error("webhook outbox: mark published failed", "error", err)
+	}
+}
+
+// NewOutboxEvent builds an event for outbox writes, stamping the ID the
+// relay will later dispatch under.
+func NewOutboxEvent(eventType EventType, data map[string]interface{}) *Event {
+	return &Event{
+		ID:        idgen.WithPrefix("evt_"),
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+}
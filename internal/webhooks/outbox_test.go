@@ -0,0 +1,127 @@
+package webhooks
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestOutbox_DispatchBatch_DispatchesAndMarks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	store := NewMemoryStore()
+	var delivered atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered.Add(1)
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{"*"},
+		Active:    true,
+	})
+
+	d := NewDispatcher(store)
+	d.urlValidator = noopValidator
+	outbox := NewOutbox(db, slog.Default())
+
+	rows := sqlmock.NewRows([]string{"id", "agent_address", "event_type", "data", "created_at"}).
+		AddRow("evt_1", "0xabc", string(EventEscrowReleased), []byte(`{"escrowId":"esc_1"}`), time.Now()).
+		AddRow("evt_2", "", string(EventPaymentReceived), []byte(`{"amount":"5.00"}`), time.Now())
+
+	mock.ExpectQuery("SELECT id, agent_address, event_type, data, created_at").
+		WillReturnRows(rows)
+	mock.ExpectExec("UPDATE webhook_outbox SET published = TRUE").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	outbox.dispatchBatch(ctx, d)
+
+	time.Sleep(300 * time.Millisecond)
+	if delivered.Load() != 2 {
+		t.Errorf("delivered %d events, want 2 (agent-targeted + broadcast)", delivered.Load())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestOutbox_DispatchBatch_EmptyResult(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	outbox := NewOutbox(db, slog.Default())
+	rows := sqlmock.NewRows([]string{"id", "agent_address", "event_type", "data", "created_at"})
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+
+	outbox.dispatchBatch(context.Background(), NewDispatcher(NewMemoryStore()))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestEmitter_EmitInTx_WritesOutboxRow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO webhook_outbox").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	e := NewEmitter(NewDispatcher(NewMemoryStore()), slog.Default()).
+		WithOutbox(NewOutbox(db, slog.Default()))
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.EmitInTx(ctx, tx, "0xabc", EventEscrowReleased, map[string]interface{}{"escrowId": "esc_1"}); err != nil {
+		t.Fatalf("EmitInTx: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet mock expectations: %v", err)
+	}
+}
+
+func TestEmitter_EmitInTx_FallsBackWithoutOutbox(t *testing.T) {
+	// Without an outbox (or without a tx) EmitInTx degrades to the
+	// fire-and-forget emit path and never errors.
+	e := NewEmitter(NewDispatcher(NewMemoryStore()), slog.Default())
+	ctx := context.Background()
+	if err := e.EmitInTx(ctx, nil, "0xabc", EventPaymentReceived, map[string]interface{}{"amount": "5.00"}); err != nil {
+		t.Fatalf("EmitInTx without outbox: %v", err)
+	}
+
+	// Nil emitter and nil dispatcher are no-ops, matching the other
+	// emit methods.
+	var nilEmitter *Emitter
+	if err := nilEmitter.EmitInTx(ctx, nil, "0xabc", EventPaymentReceived, nil); err != nil {
+		t.Fatalf("nil emitter: %v", err)
+	}
+}
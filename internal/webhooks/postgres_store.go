@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
 )
 
 // PostgresStore persists webhook subscriptions in PostgreSQL
@@ -24,7 +25,13 @@ func (p *PostgresStore) Migrate(ctx context.Context) error {
 			agent_address         VARCHAR(42) NOT NULL,
 			url                   TEXT NOT NULL,
 			secret                VARCHAR(64) NOT NULL,
+			prev_secret           VARCHAR(64),
+			prev_secret_expires_at TIMESTAMPTZ,
 			events                JSONB NOT NULL,
+			payload_version       INTEGER NOT NULL DEFAULT 1,
+			batch_window_seconds  INTEGER NOT NULL DEFAULT 0,
+			batch_max_events      INTEGER NOT NULL DEFAULT 0,
+			template              JSONB,
 			active                BOOLEAN DEFAULT TRUE,
 			created_at            TIMESTAMPTZ DEFAULT NOW(),
 			last_success          TIMESTAMPTZ,
@@ -43,25 +50,37 @@ func (p *PostgresStore) Create(ctx context.Context, sub *Subscription) error {
 		return err
 	}
 
+	var templateJSON []byte
+	if len(sub.Template) > 0 {
+		if templateJSON, err = json.Marshal(sub.Template); err != nil {
+			return err
+		}
+	}
+
+	version := sub.payloadVersion()
 	_, err = p.db.ExecContext(ctx, `
-		INSERT INTO webhooks (id, agent_address, url, secret, events, active, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`, sub.ID, sub.AgentAddr, sub.URL, sub.Secret, eventsJSON, sub.Active, sub.CreatedAt)
+		INSERT INTO webhooks (id, agent_address, url, secret, prev_secret, prev_secret_expires_at, events, payload_version, batch_window_seconds, batch_max_events, template, active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, sub.ID, sub.AgentAddr, sub.URL, sub.Secret, nullString(sub.PrevSecret), sub.PrevSecretExpiresAt, eventsJSON, version,
+		sub.BatchWindowSeconds, sub.BatchMaxEvents, templateJSON, sub.Active, sub.CreatedAt)
 	return err
 }
 
 func (p *PostgresStore) Get(ctx context.Context, id string) (*Subscription, error) {
 	sub := &Subscription{}
 	var eventsJSON []byte
+	var templateJSON []byte
+	var prevSecret sql.NullString
+	var prevSecretExpires sql.NullTime
 	var lastSuccess sql.NullTime
 	var lastError sql.NullString
 
 	err := p.db.QueryRowContext(ctx, `
-		SELECT id, agent_address, url, secret, events, active, created_at, last_success, last_error, consecutive_failures
+		SELECT id, agent_address, url, secret, prev_secret, prev_secret_expires_at, events, payload_version, batch_window_seconds, batch_max_events, template, active, created_at, last_success, last_error, consecutive_failures
 		FROM webhooks WHERE id = $1
 	`, id).Scan(
-		&sub.ID, &sub.AgentAddr, &sub.URL, &sub.Secret, &eventsJSON,
-		&sub.Active, &sub.CreatedAt, &lastSuccess, &lastError, &sub.ConsecutiveFailures,
+		&sub.ID, &sub.AgentAddr, &sub.URL, &sub.Secret, &prevSecret, &prevSecretExpires, &eventsJSON,
+		&sub.PayloadVersion, &sub.BatchWindowSeconds, &sub.BatchMaxEvents, &templateJSON, &sub.Active, &sub.CreatedAt, &lastSuccess, &lastError, &sub.ConsecutiveFailures,
 	)
 	if err != nil {
 		return nil, err
@@ -70,7 +89,16 @@ func (p *PostgresStore) Get(ctx context.Context, id string) (*Subscription, erro
 	if err := json.Unmarshal(eventsJSON, &sub.Events); err != nil {
 		return nil, err
 	}
+	if len(templateJSON) > 0 {
+		if err := json.Unmarshal(templateJSON, &sub.Template); err != nil {
+			return nil, err
+		}
+	}
 
+	sub.PrevSecret = prevSecret.String
+	if prevSecretExpires.Valid {
+		sub.PrevSecretExpiresAt = &prevSecretExpires.Time
+	}
 	if lastSuccess.Valid {
 		sub.LastSuccess = &lastSuccess.Time
 	}
@@ -81,7 +109,7 @@ func (p *PostgresStore) Get(ctx context.Context, id string) (*Subscription, erro
 
 func (p *PostgresStore) GetByAgent(ctx context.Context, agentAddr string) ([]*Subscription, error) {
 	rows, err := p.db.QueryContext(ctx, `
-		SELECT id, agent_address, url, secret, events, active, created_at, last_success, last_error, consecutive_failures
+		SELECT id, agent_address, url, secret, prev_secret, prev_secret_expires_at, events, payload_version, batch_window_seconds, batch_max_events, template, active, created_at, last_success, last_error, consecutive_failures
 		FROM webhooks WHERE agent_address = $1 ORDER BY created_at DESC
 	`, agentAddr)
 	if err != nil {
@@ -93,15 +121,20 @@ func (p *PostgresStore) GetByAgent(ctx context.Context, agentAddr string) ([]*Su
 }
 
 func (p *PostgresStore) GetByEvent(ctx context.Context, eventType EventType) ([]*Subscription, error) {
-	// Use json.Marshal to safely encode the event type for JSONB query
-	eventsJSON, _ := json.Marshal([]string{string(eventType)})
-
-	// Query active webhooks that include this event type
+	// Query active webhooks whose filters cover this event type: an
+	// exact entry, the catch-all "*", or a dotted-prefix wildcard such
+	// as "payment.*" (entry minus the trailing "*" is a prefix of the
+	// event type).
 	rows, err := p.db.QueryContext(ctx, `
-		SELECT id, agent_address, url, secret, events, active, created_at, last_success, last_error, consecutive_failures
+		SELECT id, agent_address, url, secret, prev_secret, prev_secret_expires_at, events, payload_version, batch_window_seconds, batch_max_events, template, active, created_at, last_success, last_error, consecutive_failures
 		FROM webhooks
-		WHERE active = TRUE AND events @> $1::jsonb
-	`, string(eventsJSON))
+		WHERE active = TRUE AND EXISTS (
+			SELECT 1 FROM jsonb_array_elements_text(events) AS filter(pattern)
+			WHERE pattern = $1
+			   OR pattern = '*'
+			   OR (pattern LIKE '%.*' AND left($1, length(pattern) - 1) = left(pattern, length(pattern) - 1))
+		)
+	`, string(eventType))
 	if err != nil {
 		return nil, err
 	}
@@ -116,9 +149,13 @@ func (p *PostgresStore) Update(ctx context.Context, sub *Subscription) error {
 			active = $1,
 			last_success = $2,
 			last_error = $3,
-			consecutive_failures = $4
-		WHERE id = $5
-	`, sub.Active, sub.LastSuccess, sub.LastError, sub.ConsecutiveFailures, sub.ID)
+			consecutive_failures = $4,
+			secret = $5,
+			prev_secret = $6,
+			prev_secret_expires_at = $7
+		WHERE id = $8
+	`, sub.Active, sub.LastSuccess, sub.LastError, sub.ConsecutiveFailures,
+		sub.Secret, nullString(sub.PrevSecret), sub.PrevSecretExpiresAt, sub.ID)
 	return err
 }
 
@@ -132,12 +169,15 @@ func (p *PostgresStore) scanSubscriptions(rows *sql.Rows) ([]*Subscription, erro
 	for rows.Next() {
 		sub := &Subscription{}
 		var eventsJSON []byte
+		var templateJSON []byte
+		var prevSecret sql.NullString
+		var prevSecretExpires sql.NullTime
 		var lastSuccess sql.NullTime
 		var lastError sql.NullString
 
 		if err := rows.Scan(
-			&sub.ID, &sub.AgentAddr, &sub.URL, &sub.Secret, &eventsJSON,
-			&sub.Active, &sub.CreatedAt, &lastSuccess, &lastError, &sub.ConsecutiveFailures,
+			&sub.ID, &sub.AgentAddr, &sub.URL, &sub.Secret, &prevSecret, &prevSecretExpires, &eventsJSON,
+			&sub.PayloadVersion, &sub.BatchWindowSeconds, &sub.BatchMaxEvents, &templateJSON, &sub.Active, &sub.CreatedAt, &lastSuccess, &lastError, &sub.ConsecutiveFailures,
 		); err != nil {
 			return nil, err
 		}
@@ -145,7 +185,16 @@ func (p *PostgresStore) scanSubscriptions(rows *sql.Rows) ([]*Subscription, erro
 		if err := json.Unmarshal(eventsJSON, &sub.Events); err != nil {
 			return nil, err
 		}
+		if len(templateJSON) > 0 {
+			if err := json.Unmarshal(templateJSON, &sub.Template); err != nil {
+				return nil, err
+			}
+		}
 
+		sub.PrevSecret = prevSecret.String
+		if prevSecretExpires.Valid {
+			sub.PrevSecretExpiresAt = &prevSecretExpires.Time
+		}
 		if lastSuccess.Valid {
 			sub.LastSuccess = &lastSuccess.Time
 		}
@@ -155,3 +204,160 @@ func (p *PostgresStore) scanSubscriptions(rows *sql.Rows) ([]*Subscription, erro
 	}
 	return subs, rows.Err()
 }
+
+// nullString maps "" to SQL NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// PostgresDeadLetterStore persists dead-lettered deliveries in PostgreSQL.
+type PostgresDeadLetterStore struct {
+	db *sql.DB
+}
+
+var _ DeadLetterStore = (*PostgresDeadLetterStore)(nil)
+
+// NewPostgresDeadLetterStore creates a PostgreSQL-backed dead-letter store.
+func NewPostgresDeadLetterStore(db *sql.DB) *PostgresDeadLetterStore {
+	return &PostgresDeadLetterStore{db: db}
+}
+
+func (p *PostgresDeadLetterStore) Add(ctx context.Context, dl *DeadLetter) error {
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO webhook_dead_letters (id, subscription_id, agent_address, event_id, event_type, payload, last_error, attempts, failed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, dl.ID, dl.SubscriptionID, dl.AgentAddr, nullString(dl.EventID), string(dl.EventType), []byte(dl.Payload), dl.LastError, dl.Attempts, dl.FailedAt)
+	return err
+}
+
+func (p *PostgresDeadLetterStore) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	row := p.db.QueryRowContext(ctx, `
+		SELECT id, subscription_id, agent_address, event_id, event_type, payload, last_error, attempts, failed_at
+		FROM webhook_dead_letters WHERE id = $1
+	`, id)
+	return scanDeadLetter(row.Scan)
+}
+
+func (p *PostgresDeadLetterStore) ListByAgent(ctx context.Context, agentAddr string, limit int) ([]*DeadLetter, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id, subscription_id, agent_address, event_id, event_type, payload, last_error, attempts, failed_at
+		FROM webhook_dead_letters WHERE agent_address = $1
+		ORDER BY failed_at DESC
+		LIMIT $2
+	`, agentAddr, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []*DeadLetter
+	for rows.Next() {
+		dl, err := scanDeadLetter(rows.Scan)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, dl)
+	}
+	return out, rows.Err()
+}
+
+func (p *PostgresDeadLetterStore) Delete(ctx context.Context, id string) error {
+	_, err := p.db.ExecContext(ctx, `DELETE FROM webhook_dead_letters WHERE id = $1`, id)
+	return err
+}
+
+// PostgresEventLog persists dispatched events in PostgreSQL for replay.
+type PostgresEventLog struct {
+	db *sql.DB
+}
+
+var _ EventLogStore = (*PostgresEventLog)(nil)
+
+// NewPostgresEventLog creates a PostgreSQL-backed event log.
+func NewPostgresEventLog(db *sql.DB) *PostgresEventLog {
+	return &PostgresEventLog{db: db}
+}
+
+func (p *PostgresEventLog) Append(ctx context.Context, agentAddr string, event *Event) (int64, error) {
+	dataJSON, err := json.Marshal(event.Data)
+	if err != nil {
+		return 0, err
+	}
+	var seq int64
+	err = p.db.QueryRowContext(ctx, `
+		INSERT INTO webhook_event_log (agent_address, event_id, event_type, event_timestamp, data)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING seq
+	`, nullString(agentAddr), event.ID, string(event.Type), event.Timestamp, dataJSON).Scan(&seq)
+	return seq, err
+}
+
+func (p *PostgresEventLog) ListAfter(ctx context.Context, afterSeq int64, limit int) ([]*StoredEvent, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT seq, agent_address, event_id, event_type, event_timestamp, data
+		FROM webhook_event_log WHERE seq > $1
+		ORDER BY seq
+		LIMIT $2
+	`, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanStoredEvents(rows)
+}
+
+func (p *PostgresEventLog) ListSince(ctx context.Context, since time.Time, limit int) ([]*StoredEvent, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT seq, agent_address, event_id, event_type, event_timestamp, data
+		FROM webhook_event_log WHERE event_timestamp >= $1
+		ORDER BY seq
+		LIMIT $2
+	`, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanStoredEvents(rows)
+}
+
+func scanStoredEvents(rows *sql.Rows) ([]*StoredEvent, error) {
+	var out []*StoredEvent
+	for rows.Next() {
+		rec := &StoredEvent{Event: &Event{}}
+		var agentAddr sql.NullString
+		var eventType string
+		var dataJSON []byte
+		if err := rows.Scan(
+			&rec.Seq, &agentAddr, &rec.Event.ID, &eventType, &rec.Event.Timestamp, &dataJSON,
+		); err != nil {
+			return nil, err
+		}
+		rec.AgentAddr = agentAddr.String
+		rec.Event.Type = EventType(eventType)
+		rec.Event.Seq = rec.Seq
+		if len(dataJSON) > 0 {
+			if err := json.Unmarshal(dataJSON, &rec.Event.Data); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
+
+func scanDeadLetter(scan func(...any) error) (*DeadLetter, error) {
+	dl := &DeadLetter{}
+	var eventID sql.NullString
+	var eventType string
+	var payload []byte
+	if err := scan(
+		&dl.ID, &dl.SubscriptionID, &dl.AgentAddr, &eventID, &eventType,
+		&payload, &dl.LastError, &dl.Attempts, &dl.FailedAt,
+	); err != nil {
+		return nil, err
+	}
+	dl.EventID = eventID.String
+	dl.EventType = EventType(eventType)
+	dl.Payload = payload
+	return dl, nil
+}
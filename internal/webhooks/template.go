@@ -0,0 +1,153 @@
+package webhooks
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// PayloadTemplate reshapes event deliveries for receivers that expect
+// their own format (Slack, PagerDuty, internal tooling). It is a JSON
+// object whose string values may embed {{path}} placeholders resolved
+// against the event:
+//
+//	{ "text": "Payment of {{data.amount}} from {{data.buyerAddr}}",
+//	  "channel": "#payments",
+//	  "amount": "{{data.amount}}" }
+//
+// Paths are id, type, seq, timestamp, or data.<key>[.<key>...]. A value
+// that is exactly one placeholder keeps the referenced field's native
+// JSON type; mixed text renders placeholders as strings. Values without
+// placeholders are static. Nested objects and arrays are walked.
+type PayloadTemplate map[string]interface{}
+
+// maxTemplateFields bounds top-level template size.
+const maxTemplateFields = 32
+
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_.-]+)\s*\}\}`)
+
+// validateTemplate rejects templates whose placeholders can never
+// resolve, so typos surface at creation instead of as empty fields.
+func validateTemplate(tmpl PayloadTemplate) error {
+	if len(tmpl) > maxTemplateFields {
+		return fmt.Errorf("template must have at most %d fields", maxTemplateFields)
+	}
+	var check func(v interface{}) error
+	check = func(v interface{}) error {
+		switch val := v.(type) {
+		case string:
+			for _, m := range templatePlaceholder.FindAllStringSubmatch(val, -1) {
+				path := m[1]
+				root := path
+				if i := strings.IndexByte(path, '.'); i >= 0 {
+					root = path[:i]
+				}
+				switch root {
+				case "id", "type", "seq", "timestamp":
+					if root != path {
+						return fmt.Errorf("placeholder %q: %s has no sub-fields", m[0], root)
+					}
+				case "data":
+				default:
+					return fmt.Errorf("placeholder %q: path must start with id, type, seq, timestamp or data", m[0])
+				}
+			}
+		case map[string]interface{}:
+			for _, nested := range val {
+				if err := check(nested); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			for _, nested := range val {
+				if err := check(nested); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for key, v := range tmpl {
+		if key == "" {
+			return fmt.Errorf("template field names must not be empty")
+		}
+		if err := check(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyTemplate renders the template against an event. Missing paths
+// resolve to an empty string (or nil for whole-placeholder values).
+func applyTemplate(tmpl PayloadTemplate, event *Event) map[string]interface{} {
+	out := make(map[string]interface{}, len(tmpl))
+	for key, v := range tmpl {
+		out[key] = renderTemplateValue(v, event)
+	}
+	return out
+}
+
+func renderTemplateValue(v interface{}, event *Event) interface{} {
+	switch val := v.(type) {
+	case string:
+		// A value that is exactly one placeholder keeps the field's
+		// native type (numbers stay numbers, objects stay objects).
+		if m := templatePlaceholder.FindStringSubmatch(val); m != nil && m[0] == val {
+			resolved, _ := lookupEventField(event, m[1])
+			return resolved
+		}
+		return templatePlaceholder.ReplaceAllStringFunc(val, func(ph string) string {
+			path := templatePlaceholder.FindStringSubmatch(ph)[1]
+			resolved, ok := lookupEventField(event, path)
+			if !ok || resolved == nil {
+				return ""
+			}
+			return fmt.Sprintf("%v", resolved)
+		})
+	case map[string]interface{}:
+		nested := make(map[string]interface{}, len(val))
+		for k, nv := range val {
+			nested[k] = renderTemplateValue(nv, event)
+		}
+		return nested
+	case []interface{}:
+		nested := make([]interface{}, len(val))
+		for i, nv := range val {
+			nested[i] = renderTemplateValue(nv, event)
+		}
+		return nested
+	default:
+		return v // static non-string values pass through
+	}
+}
+
+// lookupEventField resolves a placeholder path against an event.
+func lookupEventField(event *Event, path string) (interface{}, bool) {
+	switch path {
+	case "id":
+		return event.ID, true
+	case "type":
+		return string(event.Type), true
+	case "seq":
+		return event.Seq, true
+	case "timestamp":
+		return event.Timestamp.Format(time.RFC3339), true
+	}
+	if rest, ok := strings.CutPrefix(path, "data."); ok {
+		var cur interface{} = event.Data
+		for _, part := range strings.Split(rest, ".") {
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			cur, ok = m[part]
+			if !ok {
+				return nil, false
+			}
+		}
+		return cur, true
+	}
+	return nil, false
+}
@@ -0,0 +1,148 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestApplyTemplate(t *testing.T) {
+	event := &Event{
+		ID:        "evt_1",
+		Type:      EventPaymentReceived,
+		Timestamp: time.Date(2026, 8, 27, 12, 0, 0, 0, time.UTC),
+		Seq:       42,
+		Data: map[string]interface{}{
+			"amount":   "5.00",
+			"fromAddr": "0xabc",
+			"nested":   map[string]interface{}{"fee": 0.25},
+		},
+	}
+
+	tmpl := PayloadTemplate{
+		"text":    "Payment of {{data.amount}} from {{data.fromAddr}}",
+		"channel": "#payments",
+		"amount":  "{{data.amount}}",
+		"fee":     "{{data.nested.fee}}",
+		"missing": "{{data.nope}}",
+		"wrapped": map[string]interface{}{"event": "{{type}}"},
+	}
+	out := applyTemplate(tmpl, event)
+
+	if got := out["text"]; got != "Payment of 5.00 from 0xabc" {
+		t.Errorf("text = %v", got)
+	}
+	if got := out["channel"]; got != "#payments" {
+		t.Errorf("static field = %v, want verbatim", got)
+	}
+	if got := out["amount"]; got != "5.00" {
+		t.Errorf("amount = %v", got)
+	}
+	// Whole-placeholder values keep the field's native type.
+	if got := out["fee"]; got != 0.25 {
+		t.Errorf("fee = %v (%T), want native 0.25", got, got)
+	}
+	if got := out["missing"]; got != nil {
+		t.Errorf("missing path = %v, want nil", got)
+	}
+	nested, ok := out["wrapped"].(map[string]interface{})
+	if !ok || nested["event"] != string(EventPaymentReceived) {
+		t.Errorf("nested object = %v", out["wrapped"])
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	valid := PayloadTemplate{"text": "{{data.amount}} at {{timestamp}}", "n": 3}
+	if err := validateTemplate(valid); err != nil {
+		t.Errorf("valid template rejected: %v", err)
+	}
+	if err := validateTemplate(PayloadTemplate{"text": "{{event.amount}}"}); err == nil {
+		t.Error("unknown placeholder root accepted")
+	}
+	if err := validateTemplate(PayloadTemplate{"text": "{{id.sub}}"}); err == nil {
+		t.Error("sub-field on scalar root accepted")
+	}
+	if err := validateTemplate(PayloadTemplate{"": "x"}); err == nil {
+		t.Error("empty field name accepted")
+	}
+}
+
+func TestDispatch_TemplatedSubscriptionSendsRenderedBody(t *testing.T) {
+	var body []byte
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(200)
+		close(done)
+	}))
+	defer server.Close()
+
+	store := NewMemoryStore()
+	ctx := context.Background()
+	store.Create(ctx, &Subscription{
+		ID:        "wh1",
+		AgentAddr: "0xabc",
+		URL:       server.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Template:  PayloadTemplate{"text": "got {{data.amount}}", "source": "alancoin"},
+		Active:    true,
+	})
+
+	d := newTestDispatcher(store)
+	d.DispatchToAgent(ctx, "0xabc", &Event{
+		ID:        "evt_1",
+		Type:      EventPaymentReceived,
+		Timestamp: time.Now(),
+		Data:      map[string]interface{}{"amount": "5.00"},
+	})
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("delivery not received")
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("body not JSON: %v", err)
+	}
+	if got["text"] != "got 5.00" || got["source"] != "alancoin" {
+		t.Errorf("rendered body = %s", body)
+	}
+	if _, hasID := got["id"]; hasID {
+		t.Error("templated body leaked standard payload fields")
+	}
+}
+
+func TestCreateWebhook_TemplateValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewHandler(NewMemoryStore(), NewDispatcher(NewMemoryStore()))
+	r := gin.New()
+	h.RegisterRoutes(r.Group("/v1"))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/agents/0xabc/webhooks", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"url":"https://93.184.216.34/hook","events":["*"],"template":{"text":"{{data.amount}}"}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("valid template: status = %d; body %s", w.Code, w.Body.String())
+	}
+	if w := post(`{"url":"https://93.184.216.34/hook","events":["*"],"template":{"text":"{{bogus.path}}"}}`); w.Code != http.StatusBadRequest {
+		t.Errorf("bad placeholder: status = %d, want 400", w.Code)
+	}
+	if w := post(`{"url":"https://93.184.216.34/hook","events":["*"],"template":{"text":"hi"},"batchMaxEvents":5}`); w.Code != http.StatusBadRequest {
+		t.Errorf("template + batching: status = %d, want 400", w.Code)
+	}
+}
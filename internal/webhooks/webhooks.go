@@ -15,10 +15,13 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -61,14 +64,19 @@ const (
 	EventEscrowDisputed  EventType = "escrow.disputed"
 
 	// Stream lifecycle events
-	EventStreamOpened  EventType = "stream.opened"
-	EventStreamClosed  EventType = "stream.closed"
-	EventStreamSettled EventType = "stream.settled"
+	EventStreamOpened        EventType = "stream.opened"
+	EventStreamClosed        EventType = "stream.closed"
+	EventStreamSettled       EventType = "stream.settled"
+	EventStreamTopUp         EventType = "stream.topped_up"
+	EventStreamTopUpDeclined EventType = "stream.top_up_declined"
 
 	// KYA identity events
 	EventKYAIssued  EventType = "kya.certificate.issued"
 	EventKYARevoked EventType = "kya.certificate.revoked"
 
+	// Saved search events
+	EventSearchMatch EventType = "search.match"
+
 	// Chargeback events
 	EventChargebackBudgetWarning  EventType = "chargeback.budget.warning"
 	EventChargebackBudgetExceeded EventType = "chargeback.budget.exceeded"
@@ -86,29 +94,171 @@ const (
 
 	// Compliance events
 	EventComplianceIncidentCritical EventType = "compliance.incident.critical"
+
+	// Credit repayment events
+	EventCreditDelinquent      EventType = "credit.plan.delinquent"
+	EventCreditDefaulted       EventType = "credit.plan.defaulted"
+	EventCreditCosignerCharged EventType = "credit.cosigner.charged"
+
+	// Credit utilization alerts
+	EventCreditUtilizationWarning  EventType = "credit.utilization.warning"
+	EventCreditUtilizationCritical EventType = "credit.utilization.critical"
+
+	// Service agreement lifecycle events
+	EventAgreementProposed   EventType = "agreement.proposed"
+	EventAgreementAccepted   EventType = "agreement.accepted"
+	EventAgreementRejected   EventType = "agreement.rejected"
+	EventAgreementBreached   EventType = "agreement.breached"
+	EventAgreementExpired    EventType = "agreement.expired"
+	EventAgreementTerminated EventType = "agreement.terminated"
+	EventAgreementSLAAtRisk  EventType = "agreement.sla.at_risk"
+
+	// RFP auction events
+	EventRFPExtensionProposed EventType = "rfp.extension.proposed"
+	EventRFPExtensionRejected EventType = "rfp.extension.rejected"
+	EventRFPDeadlineExtended  EventType = "rfp.deadline.extended"
+
+	// Stake events
+	EventStakeDistributionPaid EventType = "stake.distribution.paid"
+
+	// Prediction events
+	EventPredictionVoided   EventType = "prediction.voided"
+	EventPredictionCreated  EventType = "prediction.created"
+	EventPredictionResolved EventType = "prediction.resolved"
+
+	// Paymaster spend-guard alerts (operator-facing)
+	EventPaymasterSpendWarning    EventType = "paymaster.spend.warning"
+	EventPaymasterSpendCapReached EventType = "paymaster.spend.cap_reached"
+	EventPaymasterBalanceLow      EventType = "paymaster.balance.low"
+
+	// Webhook health events, delivered to the owner's other endpoints
+	EventWebhookSuspended EventType = "webhook.endpoint.suspended"
 )
 
+// allEventTypes is the canonical list of dispatchable event types. New
+// event constants must be added here to be subscribable.
+var allEventTypes = []EventType{
+	EventPaymentReceived, EventPaymentSent,
+	EventSessionKeyUsed, EventSessionKeyCreated, EventSessionKeyRevoked,
+	EventBalanceDeposit, EventBalanceWithdraw,
+	EventSessionKeyBudgetWarning, EventSessionKeyExpiring,
+	EventGatewaySessionCreated, EventGatewaySessionClosed,
+	EventGatewayProxySuccess, EventGatewaySettlementFailed,
+	EventEscrowCreated, EventEscrowDelivered, EventEscrowReleased,
+	EventEscrowRefunded, EventEscrowDisputed,
+	EventStreamOpened, EventStreamClosed, EventStreamSettled,
+	EventStreamTopUp, EventStreamTopUpDeclined,
+	EventKYAIssued, EventKYARevoked,
+	EventChargebackBudgetWarning, EventChargebackBudgetExceeded,
+	EventArbitrationCaseFiled, EventArbitrationCaseResolved,
+	EventForensicsAlertCritical,
+	EventIntelligenceTierTransition, EventIntelligenceScoreAlert,
+	EventComplianceIncidentCritical,
+	EventCreditDelinquent, EventCreditDefaulted, EventCreditCosignerCharged,
+	EventCreditUtilizationWarning, EventCreditUtilizationCritical,
+	EventAgreementProposed, EventAgreementAccepted, EventAgreementRejected,
+	EventAgreementBreached, EventAgreementExpired, EventAgreementTerminated,
+	EventAgreementSLAAtRisk,
+	EventRFPExtensionProposed, EventRFPExtensionRejected, EventRFPDeadlineExtended,
+	EventStakeDistributionPaid,
+	EventPredictionVoided, EventPredictionCreated, EventPredictionResolved,
+	EventPaymasterSpendWarning, EventPaymasterSpendCapReached, EventPaymasterBalanceLow,
+	EventWebhookSuspended,
+}
+
+// validEventPattern reports whether a subscription filter is acceptable:
+// an exact event type, the catch-all "*", or a dotted-prefix wildcard
+// like "payment.*" that matches at least one known event type.
+func validEventPattern(pattern EventType) bool {
+	if pattern == "*" {
+		return true
+	}
+	for _, et := range allEventTypes {
+		if matchesEventPattern(pattern, et) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesEventPattern reports whether a single filter entry covers an
+// event type. "escrow.*" matches every event under the escrow prefix;
+// "*" matches everything.
+func matchesEventPattern(pattern, et EventType) bool {
+	if pattern == et || pattern == "*" {
+		return true
+	}
+	p := string(pattern)
+	if strings.HasSuffix(p, ".*") {
+		return strings.HasPrefix(string(et), p[:len(p)-1])
+	}
+	return false
+}
+
 // Event represents a webhook event
 type Event struct {
 	ID        string                 `json:"id"`
 	Type      EventType              `json:"type"`
 	Timestamp time.Time              `json:"timestamp"`
 	Data      map[string]interface{} `json:"data"`
+	Seq       int64                  `json:"seq,omitempty"` // event log sequence number; consumers use it as a replay cursor
 }
 
 // Subscription represents a webhook subscription
 type Subscription struct {
-	ID                  string      `json:"id"`
-	AgentAddr           string      `json:"agentAddr"`
-	URL                 string      `json:"url"`
-	Secret              string      `json:"-"` // Used for HMAC signing
-	Events              []EventType `json:"events"`
-	Active              bool        `json:"active"`
-	CreatedAt           time.Time   `json:"createdAt"`
-	LastSuccess         *time.Time  `json:"lastSuccess,omitempty"`
-	LastError           string      `json:"lastError,omitempty"`
-	ConsecutiveFailures int         `json:"consecutiveFailures"`
-	SuspendedUntil      *time.Time  `json:"suspendedUntil,omitempty"`
+	ID                  string          `json:"id"`
+	AgentAddr           string          `json:"agentAddr"`
+	URL                 string          `json:"url"`
+	Secret              string          `json:"-"` // Used for HMAC signing
+	PrevSecret          string          `json:"-"` // Previous secret, honored until PrevSecretExpiresAt
+	PrevSecretExpiresAt *time.Time      `json:"prevSecretExpiresAt,omitempty"`
+	Events              []EventType     `json:"events"`                       // exact types, "*", or prefix wildcards like "payment.*"
+	PayloadVersion      int             `json:"payloadVersion"`               // pinned at creation; 0 means version 1 (pre-versioning rows)
+	BatchWindowSeconds  int             `json:"batchWindowSeconds,omitempty"` // >0 enables batching: flush at most this often
+	BatchMaxEvents      int             `json:"batchMaxEvents,omitempty"`     // >0 enables batching: flush when this many events are pending
+	Template            PayloadTemplate `json:"template,omitempty"`           // reshapes per-event payloads; empty = standard payload
+	Active              bool            `json:"active"`
+	CreatedAt           time.Time       `json:"createdAt"`
+	LastSuccess         *time.Time      `json:"lastSuccess,omitempty"`
+	LastError           string          `json:"lastError,omitempty"`
+	ConsecutiveFailures int             `json:"consecutiveFailures"`
+	SuspendedUntil      *time.Time      `json:"suspendedUntil,omitempty"`
+}
+
+// CurrentPayloadVersion is the payload schema new subscriptions are
+// pinned to. Bump it when the delivery payload changes shape, keep the
+// old shape reproducible in marshalEventPayload, and existing endpoints
+// keep receiving what they signed up for.
+const CurrentPayloadVersion = 1
+
+// payloadVersion returns the subscription's pinned payload schema
+// version, treating pre-versioning rows (0) as version 1.
+func (s *Subscription) payloadVersion() int {
+	if s.PayloadVersion <= 0 {
+		return 1
+	}
+	return s.PayloadVersion
+}
+
+// matchesEvent reports whether the subscription's event filters cover an
+// event type.
+func (s *Subscription) matchesEvent(et EventType) bool {
+	for _, pattern := range s.Events {
+		if matchesEventPattern(pattern, et) {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalEventPayload renders an event in the payload schema a
+// subscription is pinned to. Version 1 is the bare event object; future
+// versions get their own case here.
+func marshalEventPayload(event *Event, version int) ([]byte, error) {
+	switch version {
+	default: // version 1
+		return json.Marshal(event)
+	}
 }
 
 // RetryConfig controls exponential backoff for webhook delivery
@@ -155,9 +305,24 @@ type Dispatcher struct {
 	store        Store
 	client       *http.Client
 	retry        RetryConfig
-	sem          chan struct{} // concurrency limiter
-	urlValidator URLValidator  // nil = use security.ValidateEndpointURL
-	subsCache    sync.Map      // map[string]*subsCacheEntry — keyed by event type or agent addr
+	sem          chan struct{}   // concurrency limiter
+	urlValidator URLValidator    // nil = use security.ValidateEndpointURL
+	subsCache    sync.Map        // map[string]*subsCacheEntry — keyed by event type or agent addr
+	deadLetters  DeadLetterStore // nil = exhausted deliveries are dropped
+	deliveries   *deliveryLog    // recent per-subscription attempt history
+	eventLog     EventLogStore   // nil = no durable event log, replay disabled
+	limiters     sync.Map        // map[string]*endpointLimiter — per-endpoint caps and breaker
+	batchMu      sync.Mutex
+	batches      map[string]*pendingBatch // events waiting to flush, keyed by subscription ID
+
+	failureListener FailureListener // nil = delivery failures are not surfaced
+}
+
+// FailureListener is notified when a webhook delivery exhausts its
+// retries, so owners can be told out of band (e.g. on a private
+// realtime channel). Called on a separate goroutine.
+type FailureListener interface {
+	OnDeliveryFailure(agentAddr, webhookID, url, lastError string, consecutiveFailures int)
 }
 
 const maxConcurrentWebhooks = 50
@@ -174,8 +339,10 @@ func NewDispatcher(store Store) *Dispatcher {
 				IdleConnTimeout:     90 * time.Second,
 			},
 		},
-		retry: DefaultRetryConfig(),
-		sem:   make(chan struct{}, maxConcurrentWebhooks),
+		retry:      DefaultRetryConfig(),
+		sem:        make(chan struct{}, maxConcurrentWebhooks),
+		deliveries: newDeliveryLog(),
+		batches:    make(map[string]*pendingBatch),
 	}
 }
 
@@ -186,11 +353,20 @@ func NewDispatcherWithRetry(store Store, retryCfg RetryConfig) *Dispatcher {
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		retry: retryCfg,
-		sem:   make(chan struct{}, maxConcurrentWebhooks),
+		retry:      retryCfg,
+		sem:        make(chan struct{}, maxConcurrentWebhooks),
+		deliveries: newDeliveryLog(),
+		batches:    make(map[string]*pendingBatch),
 	}
 }
 
+// WithFailureListener makes the dispatcher report delivery failures to
+// the listener in addition to recording them on the subscription.
+func (d *Dispatcher) WithFailureListener(fl FailureListener) *Dispatcher {
+	d.failureListener = fl
+	return d
+}
+
 // Dispatch sends an event to all relevant subscribers
 func (d *Dispatcher) Dispatch(ctx context.Context, event *Event) error {
 	ctx, span := traces.StartSpan(ctx, "webhooks.Dispatch",
@@ -198,6 +374,8 @@ func (d *Dispatcher) Dispatch(ctx context.Context, event *Event) error {
 	)
 	defer span.End()
 
+	d.logEvent(ctx, "", event)
+
 	subs, err := d.getByEventCached(ctx, event.Type)
 	if err != nil {
 		span.RecordError(err)
@@ -220,7 +398,7 @@ func (d *Dispatcher) Dispatch(ctx context.Context, event *Event) error {
 		go func(s *Subscription) {
 			defer func() { <-d.sem }()
 			defer recovery.LogPanic(logging.L(ctx), "webhook_dispatch")
-			d.send(ctx, s, event)
+			d.deliver(ctx, s, event)
 		}(sub)
 	}
 
@@ -235,6 +413,8 @@ func (d *Dispatcher) DispatchToAgent(ctx context.Context, agentAddr string, even
 	)
 	defer span.End()
 
+	d.logEvent(ctx, agentAddr, event)
+
 	subs, err := d.store.GetByAgent(ctx, agentAddr)
 	if err != nil {
 		span.RecordError(err)
@@ -248,21 +428,22 @@ func (d *Dispatcher) DispatchToAgent(ctx context.Context, agentAddr string, even
 		}
 
 		// Check if subscribed to this event type
-		for _, et := range sub.Events {
-			if et == event.Type {
-				select {
-				case d.sem <- struct{}{}:
-				case <-ctx.Done():
-					return ctx.Err()
-				}
-				go func(s *Subscription) {
-					defer func() { <-d.sem }()
-					defer recovery.LogPanic(logging.L(ctx), "webhook_dispatch")
-					d.send(ctx, s, event)
-				}(sub)
-				break
-			}
+		if !sub.matchesEvent(event.Type) {
+			continue
 		}
+		select {
+		case d.sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		// Copy so goroutines can safely mutate subscription fields,
+		// matching getByEventCached on the broadcast path.
+		subCopy := *sub
+		go func(s *Subscription) {
+			defer func() { <-d.sem }()
+			defer recovery.LogPanic(logging.L(ctx), "webhook_dispatch")
+			d.deliver(ctx, s, event)
+		}(&subCopy)
 	}
 
 	return nil
@@ -314,21 +495,32 @@ func (d *Dispatcher) InvalidateSubsCache() {
 }
 
 func (d *Dispatcher) send(ctx context.Context, sub *Subscription, event *Event) {
+	var payload []byte
+	var err error
+	if len(sub.Template) > 0 {
+		payload, err = json.Marshal(applyTemplate(sub.Template, event))
+	} else {
+		payload, err = marshalEventPayload(event, sub.payloadVersion())
+	}
+	if err != nil {
+		d.updateError(ctx, sub, "failed to marshal event")
+		return
+	}
+	d.sendPayload(ctx, sub, event, payload, nil)
+}
+
+// sendPayload delivers a pre-marshalled payload with retries. The event
+// supplies header and bookkeeping metadata; extraHeaders are added to
+// every attempt (used by batched deliveries).
+func (d *Dispatcher) sendPayload(ctx context.Context, sub *Subscription, event *Event, payload []byte, extraHeaders map[string]string) {
 	ctx, span := traces.StartSpan(ctx, "webhooks.send",
 		attribute.String("subscription_id", sub.ID),
 		attribute.String("url", sub.URL),
 	)
 	defer span.End()
 
-	payload, err := json.Marshal(event)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "marshal failed")
-		d.updateError(ctx, sub, "failed to marshal event")
-		return
-	}
-
 	var lastErr string
+	attempts := 0
 	for attempt := 0; attempt < d.retry.MaxAttempts; attempt++ {
 		// Wait before retry (no wait on first attempt)
 		if attempt > 0 {
@@ -349,9 +541,11 @@ func (d *Dispatcher) send(ctx context.Context, sub *Subscription, event *Event)
 			case <-time.After(delay):
 			case <-ctx.Done():
 				d.updateError(ctx, sub, "context cancelled during retry")
+				d.deadLetter(ctx, sub, event, payload, "context cancelled during retry", attempts)
 				return
 			}
 		}
+		attempts = attempt + 1
 
 		// Re-validate URL + DNS on every attempt to prevent DNS rebinding SSRF.
 		validate := d.urlValidator
@@ -379,20 +573,51 @@ func (d *Dispatcher) send(ctx context.Context, sub *Subscription, event *Event)
 		req.Header.Set("X-Alancoin-Event", string(event.Type))
 		req.Header.Set("X-Alancoin-Timestamp", fmt.Sprintf("%d", event.Timestamp.Unix()))
 		req.Header.Set("X-Alancoin-Delivery-Attempt", fmt.Sprintf("%d", attempt+1))
+		req.Header.Set("X-Alancoin-Payload-Version", strconv.Itoa(sub.payloadVersion()))
+		if event.Seq > 0 {
+			req.Header.Set("X-Alancoin-Sequence", strconv.FormatInt(event.Seq, 10))
+		}
+		for k, v := range extraHeaders {
+			req.Header.Set(k, v)
+		}
 
-		if sub.Secret != "" {
-			signature := d.sign(payload, sub.Secret)
-			req.Header.Set("X-Alancoin-Signature", signature)
+		if secrets := sub.signingSecrets(); len(secrets) > 0 {
+			req.Header.Set("X-Alancoin-Signature", signatureHeader(payload, time.Now(), secrets))
 		}
 
+		start := time.Now()
 		resp, err := d.client.Do(req) //nolint:gosec // URL validated above
+		latency := time.Since(start).Milliseconds()
 		if err != nil {
 			lastErr = fmt.Sprintf("request failed: %v", err)
+			d.deliveries.record(DeliveryAttempt{
+				SubscriptionID: sub.ID,
+				EventID:        event.ID,
+				EventType:      event.Type,
+				Attempt:        attempt + 1,
+				Error:          lastErr,
+				LatencyMS:      latency,
+				At:             start,
+			})
 			continue
 		}
+		excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseExcerpt))
 		_ = resp.Body.Close()
 
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		success := resp.StatusCode >= 200 && resp.StatusCode < 300
+		d.deliveries.record(DeliveryAttempt{
+			SubscriptionID:  sub.ID,
+			EventID:         event.ID,
+			EventType:       event.Type,
+			Attempt:         attempt + 1,
+			StatusCode:      resp.StatusCode,
+			ResponseExcerpt: string(excerpt),
+			LatencyMS:       latency,
+			Success:         success,
+			At:              start,
+		})
+
+		if success {
 			metrics.WebhookDeliveriesTotal.WithLabelValues("success").Inc()
 			d.updateSuccess(ctx, sub)
 			return
@@ -409,20 +634,62 @@ func (d *Dispatcher) send(ctx context.Context, sub *Subscription, event *Event)
 	// All attempts exhausted
 	metrics.WebhookDeliveriesTotal.WithLabelValues("failed").Inc()
 	d.updateError(ctx, sub, lastErr)
+	d.deadLetter(ctx, sub, event, payload, lastErr, attempts)
+}
+
+// signingSecrets returns the secrets a delivery must be signed with: the
+// current secret plus, during a rotation overlap, the previous one so
+// receivers can cut over without dropping events.
+func (s *Subscription) signingSecrets() []string {
+	if s.Secret == "" {
+		return nil
+	}
+	secrets := []string{s.Secret}
+	if s.PrevSecret != "" && s.PrevSecretExpiresAt != nil && time.Now().Before(*s.PrevSecretExpiresAt) {
+		secrets = append(secrets, s.PrevSecret)
+	}
+	return secrets
+}
+
+// signatureHeader builds the X-Alancoin-Signature value:
+//
+//	t=<unix>,v1=<hex>[,v1=<hex>]
+//
+// with one v1 entry per secret (two during rotation overlap). Each digest
+// is HMAC-SHA256(secret, "<t>.<payload>") so the timestamp is
+// tamper-evident; receivers should recompute the digest and reject
+// timestamps outside their tolerance to block replays. Verification is
+// documented in docs/webhooks.md.
+func signatureHeader(payload []byte, t time.Time, secrets []string) string {
+	ts := t.Unix()
+	header := fmt.Sprintf("t=%d", ts)
+	for _, secret := range secrets {
+		header += ",v1=" + signPayload(payload, secret, ts)
+	}
+	return header
 }
 
-func (d *Dispatcher) sign(payload []byte, secret string) string {
+// signPayload computes the v1 digest: HMAC-SHA256(secret, "<ts>.<payload>").
+func signPayload(payload []byte, secret string, ts int64) string {
 	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%d.", ts)
 	h.Write(payload)
 	return hex.EncodeToString(h.Sum(nil))
 }
 
 func (d *Dispatcher) updateSuccess(ctx context.Context, sub *Subscription) {
+	lim := d.limiter(sub.ID)
+	// Concurrent deliveries to one endpoint share the subscription's
+	// status fields; serialize the bookkeeping per endpoint.
+	lim.stateMu.Lock()
+	defer lim.stateMu.Unlock()
+
 	now := time.Now()
 	sub.LastSuccess = &now
 	sub.LastError = ""
 	sub.ConsecutiveFailures = 0
 	sub.SuspendedUntil = nil
+	lim.onSuccess()
 	if err := d.store.Update(ctx, sub); err != nil {
 		slog.Warn("webhook subscription success update failed", "subscription_id", sub.ID, "error", err)
 	}
@@ -430,6 +697,10 @@ func (d *Dispatcher) updateSuccess(ctx context.Context, sub *Subscription) {
 }
 
 func (d *Dispatcher) updateError(ctx context.Context, sub *Subscription, errMsg string) {
+	lim := d.limiter(sub.ID)
+	lim.stateMu.Lock()
+	defer lim.stateMu.Unlock()
+
 	sub.LastError = errMsg
 	sub.ConsecutiveFailures++
 
@@ -454,6 +725,18 @@ func (d *Dispatcher) updateError(ctx context.Context, sub *Subscription, errMsg
 		}
 	}
 
+	if d.failureListener != nil {
+		go d.failureListener.OnDeliveryFailure(sub.AgentAddr, sub.ID, sub.URL, errMsg, sub.ConsecutiveFailures)
+	}
+
+	if lim.onFailure(sub.ConsecutiveFailures) {
+		slog.Warn("webhook circuit opened", "subscription_id", sub.ID, "consecutive_failures", sub.ConsecutiveFailures)
+		go func() {
+			defer recovery.LogPanic(logging.L(ctx), "webhook_breaker_notify")
+			d.notifyEndpointSuspended(sub)
+		}()
+	}
+
 	if err := d.store.Update(ctx, sub); err != nil {
 		slog.Warn("webhook subscription error update failed", "subscription_id", sub.ID, "error", err)
 	}
@@ -512,11 +795,8 @@ func (m *MemoryStore) GetByEvent(ctx context.Context, eventType EventType) ([]*S
 	defer m.mu.RUnlock()
 	var result []*Subscription
 	for _, sub := range m.subs {
-		for _, et := range sub.Events {
-			if et == eventType {
-				result = append(result, sub)
-				break
-			}
+		if sub.matchesEvent(eventType) {
+			result = append(result, sub)
 		}
 	}
 	return result, nil
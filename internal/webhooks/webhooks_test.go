@@ -6,9 +6,12 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -105,36 +108,60 @@ func TestMemoryStore_GetByEvent(t *testing.T) {
 // Signature tests
 // ---------------------------------------------------------------------------
 
-func TestSign(t *testing.T) {
-	d := newTestDispatcher(NewMemoryStore())
+// expectedDigest recomputes the v1 digest the way a receiver would.
+func expectedDigest(payload []byte, secret string, ts int64) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%d.", ts)
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
 
+func TestSignatureHeader(t *testing.T) {
 	payload := []byte(`{"type":"payment.received","data":{}}`)
 	secret := "test_secret_key"
+	now := time.Unix(1735689600, 0)
 
-	sig := d.sign(payload, secret)
-
-	// Verify manually
-	h := hmac.New(sha256.New, []byte(secret))
-	h.Write(payload)
-	expected := hex.EncodeToString(h.Sum(nil))
-
-	if sig != expected {
-		t.Errorf("Signature mismatch: got %s, want %s", sig, expected)
+	header := signatureHeader(payload, now, []string{secret})
+	want := fmt.Sprintf("t=1735689600,v1=%s", expectedDigest(payload, secret, 1735689600))
+	if header != want {
+		t.Errorf("header = %s, want %s", header, want)
 	}
 }
 
-func TestSign_DifferentSecrets(t *testing.T) {
-	d := newTestDispatcher(NewMemoryStore())
-
+func TestSignatureHeader_TimestampBound(t *testing.T) {
 	payload := []byte(`{"test": true}`)
-	sig1 := d.sign(payload, "secret1")
-	sig2 := d.sign(payload, "secret2")
-
+	sig1 := signPayload(payload, "secret", 100)
+	sig2 := signPayload(payload, "secret", 101)
 	if sig1 == sig2 {
+		t.Error("Changing the timestamp should change the signature")
+	}
+	if signPayload(payload, "secret1", 100) == signPayload(payload, "secret2", 100) {
 		t.Error("Different secrets should produce different signatures")
 	}
 }
 
+func TestSigningSecrets_RotationOverlap(t *testing.T) {
+	sub := &Subscription{Secret: "new_secret"}
+	if got := sub.signingSecrets(); len(got) != 1 || got[0] != "new_secret" {
+		t.Errorf("secrets = %v, want just the current secret", got)
+	}
+
+	// During the overlap both secrets sign.
+	future := time.Now().Add(time.Hour)
+	sub.PrevSecret = "old_secret"
+	sub.PrevSecretExpiresAt = &future
+	if got := sub.signingSecrets(); len(got) != 2 || got[1] != "old_secret" {
+		t.Errorf("secrets = %v, want current then previous", got)
+	}
+
+	// After the overlap the old secret is dropped.
+	past := time.Now().Add(-time.Minute)
+	sub.PrevSecretExpiresAt = &past
+	if got := sub.signingSecrets(); len(got) != 1 {
+		t.Errorf("secrets = %v, want just the current secret after expiry", got)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Dispatch tests
 // ---------------------------------------------------------------------------
@@ -247,13 +274,81 @@ func TestDispatch_IncludesSignature(t *testing.T) {
 		t.Fatal("Expected signature header")
 	}
 
-	// Verify signature
-	h := hmac.New(sha256.New, []byte(secret))
-	h.Write(gotBody)
-	expected := hex.EncodeToString(h.Sum(nil))
+	// Verify the way a receiver would: parse t, recompute the digest.
+	var ts int64
+	var sigs []string
+	for _, part := range strings.Split(gotSig, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			ts, _ = strconv.ParseInt(v, 10, 64)
+		case "v1":
+			sigs = append(sigs, v)
+		}
+	}
+	if ts == 0 || len(sigs) != 1 {
+		t.Fatalf("Malformed signature header: %s", gotSig)
+	}
+	if sigs[0] != expectedDigest(gotBody, secret, ts) {
+		t.Errorf("Signature mismatch: %s", gotSig)
+	}
+}
+
+func TestDispatch_DualSignsDuringRotationOverlap(t *testing.T) {
+	store := NewMemoryStore()
 
-	if gotSig != expected {
-		t.Errorf("Signature mismatch: %s != %s", gotSig, expected)
+	var mu sync.Mutex
+	var gotSig string
+	var gotBody []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		gotSig = r.Header.Get("X-Alancoin-Signature")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	overlap := time.Now().Add(time.Hour)
+	store.Create(ctx, &Subscription{
+		ID:                  "wh1",
+		URL:                 server.URL,
+		Events:              []EventType{EventPaymentReceived},
+		Active:              true,
+		Secret:              "new_secret",
+		PrevSecret:          "old_secret",
+		PrevSecretExpiresAt: &overlap,
+	})
+
+	d := newTestDispatcher(store)
+	d.Dispatch(ctx, &Event{Type: EventPaymentReceived, Timestamp: time.Now()})
+
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	var ts int64
+	var sigs []string
+	for _, part := range strings.Split(gotSig, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			ts, _ = strconv.ParseInt(v, 10, 64)
+		case "v1":
+			sigs = append(sigs, v)
+		}
+	}
+	if len(sigs) != 2 {
+		t.Fatalf("Expected 2 signatures during overlap, got %d (%s)", len(sigs), gotSig)
+	}
+	if sigs[0] != expectedDigest(gotBody, "new_secret", ts) {
+		t.Error("First signature should verify with the new secret")
+	}
+	if sigs[1] != expectedDigest(gotBody, "old_secret", ts) {
+		t.Error("Second signature should verify with the old secret")
 	}
 }
 
@@ -647,3 +742,50 @@ func intStr(n int) string {
 	}
 	return s
 }
+
+// ---------------------------------------------------------------------------
+// FailureListener
+// ---------------------------------------------------------------------------
+
+type recordingFailureListener struct {
+	ch chan string
+}
+
+func (r *recordingFailureListener) OnDeliveryFailure(agentAddr, webhookID, url, lastError string, consecutiveFailures int) {
+	select {
+	case r.ch <- agentAddr + "/" + webhookID:
+	default:
+	}
+}
+
+func TestFailureListener_NotifiedOnDeliveryFailure(t *testing.T) {
+	// 400 means one attempt per delivery, no retries.
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(400)
+	}))
+	defer failing.Close()
+
+	store := NewMemoryStore()
+	ctx := context.Background()
+	sub := &Subscription{
+		ID:        "wh_fail",
+		AgentAddr: "0xowner",
+		URL:       failing.URL,
+		Events:    []EventType{EventPaymentReceived},
+		Active:    true,
+	}
+	store.Create(ctx, sub)
+
+	listener := &recordingFailureListener{ch: make(chan string, 1)}
+	d := newTestDispatcher(store).WithFailureListener(listener)
+	d.send(ctx, sub, &Event{ID: "evt_1", Type: EventPaymentReceived, Timestamp: time.Now()})
+
+	select {
+	case got := <-listener.ch:
+		if got != "0xowner/wh_fail" {
+			t.Errorf("notification = %s, want 0xowner/wh_fail", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("failure listener not notified")
+	}
+}
@@ -113,5 +113,11 @@ func (h *Handler) Withdraw(c *gin.Context) {
 		c.JSON(status, body)
 		return
 	}
+	// Routed to multisig approval: the hold is placed but nothing is
+	// on-chain yet. 202 tells the client to poll rather than assume success.
+	if w.Status == "pending_approval" {
+		c.JSON(http.StatusAccepted, gin.H{"withdrawal": w})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"withdrawal": w})
 }
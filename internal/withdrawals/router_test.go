@@ -0,0 +1,150 @@
+package withdrawals
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// --- stub router ---
+
+type stubRouter struct {
+	mu sync.Mutex
+
+	calls []RouteProposal
+	id    string
+	err   error
+}
+
+func (r *stubRouter) Propose(_ context.Context, p RouteProposal) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return "", r.err
+	}
+	r.calls = append(r.calls, p)
+	return r.id, nil
+}
+
+func TestWithdraw_LargeAmountRoutesToProposal(t *testing.T) {
+	svc, l, p := newService(t)
+	router := &stubRouter{id: "msp_1"}
+	svc.WithRouter(router, "100")
+
+	w, err := svc.Withdraw(context.Background(), Request{
+		AgentAddr: testAgent, ToAddr: testToAddr, Amount: "150.000000", ClientRef: "big-1",
+	})
+	if err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	if w.Status != "pending_approval" || w.ProposalID != "msp_1" {
+		t.Errorf("got status=%q proposal=%q, want pending_approval/msp_1", w.Status, w.ProposalID)
+	}
+	holds, confirms, releases := l.counts()
+	if holds != 1 || confirms != 0 || releases != 0 {
+		t.Errorf("holds/confirms/releases = %d/%d/%d, want 1/0/0", holds, confirms, releases)
+	}
+	if p.calls != 0 {
+		t.Errorf("payout submitted for routed withdrawal")
+	}
+	if len(router.calls) != 1 || router.calls[0].ClientRef != "big-1" {
+		t.Errorf("router calls = %+v", router.calls)
+	}
+}
+
+func TestWithdraw_SmallAmountBypassesRouter(t *testing.T) {
+	svc, l, _ := newService(t)
+	router := &stubRouter{id: "msp_1"}
+	svc.WithRouter(router, "100")
+
+	w, err := svc.Withdraw(context.Background(), Request{
+		AgentAddr: testAgent, ToAddr: testToAddr, Amount: "99.999999", ClientRef: "small-1",
+	})
+	if err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	if w.Status != "success" {
+		t.Errorf("status = %q, want success", w.Status)
+	}
+	if len(router.calls) != 0 {
+		t.Errorf("router invoked below threshold")
+	}
+	if _, confirms, _ := l.counts(); confirms != 1 {
+		t.Errorf("expected confirmed hold")
+	}
+}
+
+func TestWithdraw_RouteErrorReleasesHold(t *testing.T) {
+	svc, l, _ := newService(t)
+	svc.WithRouter(&stubRouter{err: errors.New("store down")}, "100")
+
+	_, err := svc.Withdraw(context.Background(), Request{
+		AgentAddr: testAgent, ToAddr: testToAddr, Amount: "150", ClientRef: "big-2",
+	})
+	if err == nil {
+		t.Fatal("expected error when routing fails")
+	}
+	if _, _, releases := l.counts(); releases != 1 {
+		t.Errorf("hold not released after route failure")
+	}
+}
+
+func TestExecuteRouted_SettlesExistingHold(t *testing.T) {
+	svc, l, p := newService(t)
+
+	w, err := svc.ExecuteRouted(context.Background(), Request{
+		AgentAddr: testAgent, ToAddr: testToAddr, Amount: "150", ClientRef: "big-3",
+	})
+	if err != nil {
+		t.Fatalf("ExecuteRouted: %v", err)
+	}
+	if w.Status != "success" || w.TxHash != "0xabc" {
+		t.Errorf("got status=%q tx=%q", w.Status, w.TxHash)
+	}
+	holds, confirms, _ := l.counts()
+	if holds != 0 {
+		t.Errorf("ExecuteRouted placed a new hold; should settle the existing one")
+	}
+	if confirms != 1 {
+		t.Errorf("existing hold not confirmed")
+	}
+	if p.calls != 1 {
+		t.Errorf("payout calls = %d, want 1", p.calls)
+	}
+}
+
+func TestAbortRouted_ReleasesHold(t *testing.T) {
+	svc, l, _ := newService(t)
+
+	if err := svc.AbortRouted(context.Background(), Request{
+		AgentAddr: testAgent, Amount: "150", ClientRef: "big-4",
+	}); err != nil {
+		t.Fatalf("AbortRouted: %v", err)
+	}
+	if _, _, releases := l.counts(); releases != 1 {
+		t.Errorf("hold not released on abort")
+	}
+	if len(l.releases) == 1 && l.releases[0].Ref != "withdraw:big-4" {
+		t.Errorf("release ref = %q, want withdraw:big-4", l.releases[0].Ref)
+	}
+}
+
+func TestAmountAtLeast(t *testing.T) {
+	cases := []struct {
+		amount, min string
+		want        bool
+	}{
+		{"100", "100", true},
+		{"100.000001", "100", true},
+		{"99.999999", "100", false},
+		{"150", "", false}, // no threshold = never route
+		{"abc", "100", false},
+		{"150", "abc", false},
+	}
+	for _, c := range cases {
+		if got := amountAtLeast(c.amount, c.min); got != c.want {
+			t.Errorf("amountAtLeast(%q, %q) = %v, want %v", c.amount, c.min, got, c.want)
+		}
+	}
+}
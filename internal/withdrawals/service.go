@@ -5,15 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math/big"
 	"strings"
 )
 
 // Service runs the withdrawal two-phase flow.
 // Construct once at startup and share across goroutines.
 type Service struct {
-	ledger  Ledger
-	payouts Payouts
-	logger  *slog.Logger
+	ledger   Ledger
+	payouts  Payouts
+	router   Router
+	routeMin string // decimal USDC; withdrawals at or above this route via the Router
+	logger   *slog.Logger
 }
 
 // NewService returns a ready-to-use Service. Both ledger and payouts are required.
@@ -27,6 +30,15 @@ func NewService(ledger Ledger, payouts Payouts, logger *slog.Logger) (*Service,
 	return &Service{ledger: ledger, payouts: payouts, logger: logger}, nil
 }
 
+// WithRouter diverts withdrawals of minAmount or more into the router's
+// proposal flow instead of submitting them directly. An empty or invalid
+// minAmount routes nothing.
+func (s *Service) WithRouter(r Router, minAmount string) *Service {
+	s.router = r
+	s.routeMin = minAmount
+	return s
+}
+
 // Withdraw reserves funds via the ledger, submits an on-chain payout, and
 // either finalizes the debit (success) or releases it (failure/drop).
 //
@@ -54,6 +66,71 @@ func (s *Service) Withdraw(ctx context.Context, req Request) (*Withdrawal, error
 		return nil, fmt.Errorf("%w: %v", ErrLedgerHold, err)
 	}
 
+	// Large withdrawals divert into the multisig proposal flow: the hold
+	// stays in place and the payout waits for the approval threshold.
+	if s.router != nil && amountAtLeast(req.Amount, s.routeMin) {
+		proposalID, err := s.router.Propose(ctx, RouteProposal{
+			AgentAddr: agent,
+			ToAddr:    to,
+			Amount:    req.Amount,
+			ClientRef: req.ClientRef,
+		})
+		if err != nil {
+			// Nothing is pending on-chain yet — safe to release the hold.
+			if relErr := s.ledger.ReleaseHold(ctx, agent, req.Amount, ref); relErr != nil {
+				s.logger.Error("withdrawals: release hold after route error failed",
+					"agent", agent, "ref", ref, "route_err", err, "release_err", relErr)
+				return nil, fmt.Errorf("withdrawals: route proposal: %v; release also failed: %v", err, relErr)
+			}
+			return nil, fmt.Errorf("withdrawals: route proposal: %w", err)
+		}
+		s.logger.Info("withdrawals: routed to multisig approval",
+			"agent", agent, "ref", ref, "amount", req.Amount, "proposal", proposalID)
+		return &Withdrawal{
+			ClientRef:  req.ClientRef,
+			AgentAddr:  agent,
+			ToAddr:     to,
+			Amount:     req.Amount,
+			Status:     "pending_approval",
+			ProposalID: proposalID,
+		}, nil
+	}
+
+	return s.settle(ctx, agent, to, ref, req)
+}
+
+// ExecuteRouted submits a previously routed withdrawal whose multisig
+// proposal has been approved. The hold taken at proposal time is settled
+// exactly as a direct withdrawal would be.
+func (s *Service) ExecuteRouted(ctx context.Context, req Request) (*Withdrawal, error) {
+	if req.ClientRef == "" {
+		return nil, ErrMissingRef
+	}
+	agent := strings.ToLower(req.AgentAddr)
+	to := strings.ToLower(req.ToAddr)
+	return s.settle(ctx, agent, to, holdReference(req.ClientRef), req)
+}
+
+// AbortRouted releases the hold of a routed withdrawal whose proposal was
+// rejected.
+func (s *Service) AbortRouted(ctx context.Context, req Request) error {
+	if req.ClientRef == "" {
+		return ErrMissingRef
+	}
+	agent := strings.ToLower(req.AgentAddr)
+	ref := holdReference(req.ClientRef)
+	if err := s.ledger.ReleaseHold(ctx, agent, req.Amount, ref); err != nil {
+		s.logger.Error("withdrawals: release hold for rejected proposal failed",
+			"agent", agent, "ref", ref, "err", err)
+		return fmt.Errorf("withdrawals: release hold: %w", err)
+	}
+	return nil
+}
+
+// settle runs the payout half of the two-phase flow against an
+// already-placed hold: submit on-chain, then confirm (success) or
+// release (failure/drop) the hold.
+func (s *Service) settle(ctx context.Context, agent, to, ref string, req Request) (*Withdrawal, error) {
 	result, payoutErr := s.payouts.Send(ctx, to, req.Amount, req.ClientRef)
 	if payoutErr != nil {
 		// Receipt-poll timeout: the tx hash exists and may still settle on
@@ -143,6 +220,21 @@ func (s *Service) Withdraw(ctx context.Context, req Request) (*Withdrawal, error
 // --- small validation helpers (kept here rather than in withdrawals.go
 // so the data types file stays declaration-only) ---
 
+// amountAtLeast reports whether amount >= min, both decimal USDC strings.
+// Returns false when min is empty or either side does not parse, so a
+// missing routing threshold never diverts a withdrawal.
+func amountAtLeast(amount, min string) bool {
+	if min == "" {
+		return false
+	}
+	a, aok := new(big.Rat).SetString(amount)
+	m, mok := new(big.Rat).SetString(min)
+	if !aok || !mok {
+		return false
+	}
+	return a.Cmp(m) >= 0
+}
+
 func isValidAddr(s string) bool {
 	if len(s) != 42 || (s[0] != '0') || (s[1] != 'x' && s[1] != 'X') {
 		return false
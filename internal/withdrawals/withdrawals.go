@@ -33,6 +33,22 @@ type Payouts interface {
 	Send(ctx context.Context, to, amount, clientRef string) (Result, error)
 }
 
+// Router diverts withdrawals above a configured amount into a multisig
+// proposal flow instead of submitting them directly. Propose returns the
+// proposal ID; the hold stays in place until the proposal is approved
+// (ExecuteRouted) or rejected (AbortRouted).
+type Router interface {
+	Propose(ctx context.Context, p RouteProposal) (proposalID string, err error)
+}
+
+// RouteProposal is the withdrawal detail handed to the Router.
+type RouteProposal struct {
+	AgentAddr string
+	ToAddr    string
+	Amount    string
+	ClientRef string
+}
+
 // Result is the withdraw-relevant projection of a usdc.Payout.
 // Kept small so we don't leak usdc internals into the withdrawal API.
 type Result struct {
@@ -60,8 +76,9 @@ type Withdrawal struct {
 	AgentAddr   string
 	ToAddr      string
 	Amount      string
-	Status      string // "success" | "failed" | "dropped" | "pending"
+	Status      string // "success" | "failed" | "dropped" | "pending" | "pending_approval"
 	TxHash      string
+	ProposalID  string // set when Status is "pending_approval" (routed to multisig)
 	SubmittedAt time.Time
 	FinalizedAt *time.Time
 	Error       string
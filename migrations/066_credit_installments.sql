@@ -0,0 +1,19 @@
+-- +goose Up
+-- Installment repayment plans for ledger credit draws
+
+CREATE TABLE credit_installment_plans (
+    id           TEXT PRIMARY KEY,
+    agent_addr   TEXT NOT NULL,
+    principal    TEXT NOT NULL,
+    installments JSONB NOT NULL DEFAULT '[]',
+    status       TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'defaulted', 'cancelled')),
+    late_count   INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_credit_plans_agent ON credit_installment_plans (agent_addr, created_at DESC);
+CREATE INDEX idx_credit_plans_active ON credit_installment_plans (status) WHERE status = 'active';
+
+-- +goose Down
+DROP TABLE IF EXISTS credit_installment_plans;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Collateral pledges backing enlarged credit limits
+
+CREATE TABLE credit_collateral (
+    id         TEXT PRIMARY KEY,
+    agent_addr TEXT NOT NULL,
+    kind       TEXT NOT NULL CHECK (kind IN ('ledger_lock', 'stake')),
+    amount     TEXT NOT NULL,
+    reference  TEXT NOT NULL DEFAULT '',
+    status     TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'released', 'liquidated')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_credit_collateral_agent ON credit_collateral (agent_addr, created_at DESC);
+CREATE INDEX idx_credit_collateral_active ON credit_collateral (status) WHERE status = 'active';
+
+-- +goose Down
+DROP TABLE IF EXISTS credit_collateral;
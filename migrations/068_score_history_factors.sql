@@ -0,0 +1,8 @@
+-- +goose Up
+-- Persist credit score input features alongside each history point so
+-- past limit decisions can be reproduced.
+
+ALTER TABLE intelligence_score_history ADD COLUMN credit_factors JSONB;
+
+-- +goose Down
+ALTER TABLE intelligence_score_history DROP COLUMN credit_factors;
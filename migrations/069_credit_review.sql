@@ -0,0 +1,23 @@
+-- +goose Up
+-- Manual review queue for flagged credit applications
+
+CREATE TABLE credit_applications (
+    id             TEXT PRIMARY KEY,
+    agent_addr     TEXT NOT NULL,
+    score          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    tier           TEXT NOT NULL DEFAULT '',
+    proposed_limit TEXT NOT NULL,
+    reason         TEXT NOT NULL DEFAULT '',
+    status         TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'denied')),
+    notes          TEXT,
+    decided_by     TEXT,
+    decided_limit  TEXT,
+    decided_at     TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_credit_applications_pending ON credit_applications (created_at) WHERE status = 'pending';
+CREATE INDEX idx_credit_applications_agent ON credit_applications (agent_addr, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS credit_applications;
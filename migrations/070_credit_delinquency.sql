@@ -0,0 +1,9 @@
+-- +goose Up
+-- Delinquency bucket tracking and credit-freeze state for installment plans
+
+ALTER TABLE credit_installment_plans ADD COLUMN delinquency TEXT NOT NULL DEFAULT '';
+ALTER TABLE credit_installment_plans ADD COLUMN frozen_limit TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE credit_installment_plans DROP COLUMN IF EXISTS frozen_limit;
+ALTER TABLE credit_installment_plans DROP COLUMN IF EXISTS delinquency;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Co-signed credit lines: a second agent accepts liability on default
+
+CREATE TABLE credit_cosigners (
+    id             TEXT PRIMARY KEY,
+    agent_addr     TEXT NOT NULL,
+    cosigner_addr  TEXT NOT NULL,
+    status         TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'revoked', 'charged')),
+    charged_amount TEXT,
+    charged_at     TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_credit_cosigners_agent ON credit_cosigners (agent_addr, created_at DESC);
+CREATE INDEX idx_credit_cosigners_cosigner ON credit_cosigners (cosigner_addr, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS credit_cosigners;
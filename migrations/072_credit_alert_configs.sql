@@ -0,0 +1,12 @@
+-- +goose Up
+-- Per-agent credit utilization alert thresholds
+
+CREATE TABLE credit_alert_configs (
+    agent_addr   TEXT PRIMARY KEY,
+    warn_pct     DOUBLE PRECISION NOT NULL,
+    critical_pct DOUBLE PRECISION NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS credit_alert_configs;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Per-agent auto-repayment sweep settings
+
+CREATE TABLE credit_sweep_configs (
+    agent_addr TEXT PRIMARY KEY,
+    fraction   DOUBLE PRECISION NOT NULL,
+    priority   TEXT NOT NULL DEFAULT 'credit_first' CHECK (priority IN ('credit_first', 'stakes_first')),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS credit_sweep_configs;
@@ -0,0 +1,33 @@
+-- +goose Up
+-- Per-call service agreements between buyer and seller agents
+
+CREATE TABLE service_agreements (
+    id             TEXT PRIMARY KEY,
+    buyer_addr     TEXT NOT NULL,
+    seller_addr    TEXT NOT NULL,
+    service_name   TEXT NOT NULL,
+    description    TEXT NOT NULL DEFAULT '',
+    price_per_call TEXT NOT NULL,
+    budget         TEXT,
+    spent          TEXT NOT NULL DEFAULT '0.000000',
+    sla            JSONB NOT NULL DEFAULT '{}',
+    status         TEXT NOT NULL DEFAULT 'proposed',
+    proposed_by    TEXT NOT NULL,
+    term_days      INTEGER NOT NULL,
+    started_at     TIMESTAMPTZ,
+    expires_at     TIMESTAMPTZ,
+    total_calls    INTEGER NOT NULL DEFAULT 0,
+    success_calls  INTEGER NOT NULL DEFAULT 0,
+    failed_calls   INTEGER NOT NULL DEFAULT 0,
+    call_window    JSONB NOT NULL DEFAULT '[]',
+    renewals       JSONB NOT NULL DEFAULT '[]',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_service_agreements_buyer ON service_agreements(buyer_addr);
+CREATE INDEX idx_service_agreements_seller ON service_agreements(seller_addr);
+CREATE INDEX idx_service_agreements_status ON service_agreements(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS service_agreements;
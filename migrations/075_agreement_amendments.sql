@@ -0,0 +1,7 @@
+-- +goose Up
+-- Amendment history for service agreements
+
+ALTER TABLE service_agreements ADD COLUMN amendments JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS amendments;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Usage-based (metered) billing for service agreements
+
+ALTER TABLE service_agreements ADD COLUMN billing_mode TEXT NOT NULL DEFAULT 'per_call';
+ALTER TABLE service_agreements ADD COLUMN meter JSONB;
+ALTER TABLE service_agreements ADD COLUMN pending_units BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE service_agreements ADD COLUMN last_settled_at TIMESTAMPTZ;
+ALTER TABLE service_agreements ALTER COLUMN price_per_call DROP NOT NULL;
+
+-- +goose Down
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS billing_mode;
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS meter;
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS pending_units;
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS last_settled_at;
+ALTER TABLE service_agreements ALTER COLUMN price_per_call SET NOT NULL;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- p95-latency SLA penalty state for service agreements
+
+ALTER TABLE service_agreements ADD COLUMN penalties_paid TEXT;
+ALTER TABLE service_agreements ADD COLUMN latency_breached BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS penalties_paid;
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS latency_breached;
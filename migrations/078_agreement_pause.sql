@@ -0,0 +1,13 @@
+-- +goose Up
+-- Pause/resume state for service agreements (announced maintenance windows)
+
+ALTER TABLE service_agreements ADD COLUMN max_pause_hours INT NOT NULL DEFAULT 72;
+ALTER TABLE service_agreements ADD COLUMN paused_at TIMESTAMPTZ;
+ALTER TABLE service_agreements ADD COLUMN pause_reason TEXT;
+ALTER TABLE service_agreements ADD COLUMN paused_seconds BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS max_pause_hours;
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS paused_at;
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS pause_reason;
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS paused_seconds;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Dispute/arbitration records for service agreements
+
+ALTER TABLE service_agreements ADD COLUMN disputes JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS disputes;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Prepaid call packs for per-call service agreements
+
+ALTER TABLE service_agreements ADD COLUMN packs JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE service_agreements DROP COLUMN IF EXISTS packs;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Time-based billing for payment streams (per_second / per_minute modes)
+
+ALTER TABLE streams ADD COLUMN billing_mode TEXT NOT NULL DEFAULT 'per_tick';
+
+-- +goose Down
+ALTER TABLE streams DROP COLUMN IF EXISTS billing_mode;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Dispute state for payment streams (contested tick tail + arbitration)
+
+ALTER TABLE streams ADD COLUMN disputed_amount NUMERIC(20,6);
+ALTER TABLE streams ADD COLUMN dispute_reason TEXT;
+ALTER TABLE streams ADD COLUMN dispute_evidence JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE streams ADD COLUMN arbitrator_addr TEXT;
+ALTER TABLE streams ADD COLUMN arbitration_deadline TIMESTAMPTZ;
+ALTER TABLE streams ADD COLUMN resolution TEXT;
+ALTER TABLE streams ADD COLUMN resolved_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE streams DROP COLUMN IF EXISTS disputed_amount;
+ALTER TABLE streams DROP COLUMN IF EXISTS dispute_reason;
+ALTER TABLE streams DROP COLUMN IF EXISTS dispute_evidence;
+ALTER TABLE streams DROP COLUMN IF EXISTS arbitrator_addr;
+ALTER TABLE streams DROP COLUMN IF EXISTS arbitration_deadline;
+ALTER TABLE streams DROP COLUMN IF EXISTS resolution;
+ALTER TABLE streams DROP COLUMN IF EXISTS resolved_at;
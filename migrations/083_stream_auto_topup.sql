@@ -0,0 +1,15 @@
+-- +goose Up
+-- Auto-top-up configuration and counters for payment streams
+
+ALTER TABLE streams ADD COLUMN top_up_amount NUMERIC(20,6);
+ALTER TABLE streams ADD COLUMN top_up_threshold NUMERIC(20,6);
+ALTER TABLE streams ADD COLUMN top_up_cap NUMERIC(20,6);
+ALTER TABLE streams ADD COLUMN topped_up_amount NUMERIC(20,6);
+ALTER TABLE streams ADD COLUMN top_up_count INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE streams DROP COLUMN IF EXISTS top_up_amount;
+ALTER TABLE streams DROP COLUMN IF EXISTS top_up_threshold;
+ALTER TABLE streams DROP COLUMN IF EXISTS top_up_cap;
+ALTER TABLE streams DROP COLUMN IF EXISTS topped_up_amount;
+ALTER TABLE streams DROP COLUMN IF EXISTS top_up_count;
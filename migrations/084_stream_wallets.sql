@@ -0,0 +1,30 @@
+-- +goose Up
+-- Stream wallets: one funded hold backing multiple concurrent sub-streams
+
+CREATE TABLE IF NOT EXISTS stream_wallets (
+    id                  VARCHAR(36) PRIMARY KEY,
+    buyer_addr          VARCHAR(42) NOT NULL,
+    hold_amount         NUMERIC(20,6) NOT NULL,
+    allocated_amount    NUMERIC(20,6) NOT NULL DEFAULT 0,
+    spent_amount        NUMERIC(20,6) NOT NULL DEFAULT 0,
+    stream_count        INTEGER NOT NULL DEFAULT 0,
+    status              VARCHAR(20) NOT NULL DEFAULT 'open',
+    closed_at           TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT chk_wallet_status CHECK (status IN ('open','closed')),
+    CONSTRAINT chk_wallet_hold_positive CHECK (hold_amount > 0),
+    CONSTRAINT chk_wallet_allocated_nonneg CHECK (allocated_amount >= 0),
+    CONSTRAINT chk_wallet_spent_nonneg CHECK (spent_amount >= 0),
+    CONSTRAINT chk_wallet_within_hold CHECK (allocated_amount + spent_amount <= hold_amount)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stream_wallets_buyer ON stream_wallets(buyer_addr);
+
+ALTER TABLE streams ADD COLUMN wallet_id VARCHAR(36) REFERENCES stream_wallets(id);
+CREATE INDEX IF NOT EXISTS idx_streams_wallet ON streams(wallet_id) WHERE wallet_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_streams_wallet;
+ALTER TABLE streams DROP COLUMN IF EXISTS wallet_id;
+DROP TABLE IF EXISTS stream_wallets;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- RFP auctions: buyer requests for proposals with open or sealed bidding
+
+CREATE TABLE rfps (
+    id              TEXT PRIMARY KEY,
+    buyer_addr      TEXT NOT NULL,
+    service_type    TEXT NOT NULL,
+    description     TEXT,
+    budget          NUMERIC(20,6) NOT NULL CHECK (budget > 0),
+    mode            TEXT NOT NULL DEFAULT 'open' CHECK (mode IN ('open', 'sealed')),
+    status          TEXT NOT NULL DEFAULT 'bidding' CHECK (status IN ('bidding', 'revealed', 'awarded', 'cancelled', 'expired')),
+    bid_count       INTEGER NOT NULL DEFAULT 0,
+    bid_deadline    TIMESTAMPTZ NOT NULL,
+    winning_bid_id  TEXT,
+    awarded_at      TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rfps_buyer ON rfps (buyer_addr);
+CREATE INDEX idx_rfps_service_type ON rfps (service_type) WHERE status = 'bidding';
+CREATE INDEX idx_rfps_deadline ON rfps (bid_deadline) WHERE status = 'bidding';
+CREATE INDEX idx_rfps_revealed ON rfps (updated_at) WHERE status = 'revealed';
+
+CREATE TABLE rfp_bids (
+    id          TEXT PRIMARY KEY,
+    rfp_id      TEXT NOT NULL REFERENCES rfps(id),
+    seller_addr TEXT NOT NULL,
+    price       NUMERIC(20,6) NOT NULL CHECK (price > 0),
+    message     TEXT,
+    status      TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'withdrawn', 'won', 'lost')),
+    rank        INTEGER NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rfp_bids_rfp ON rfp_bids (rfp_id);
+CREATE INDEX idx_rfp_bids_seller ON rfp_bids (seller_addr);
+
+-- +goose Down
+DROP TABLE IF EXISTS rfp_bids;
+DROP TABLE IF EXISTS rfps;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Automated bidding strategies: sellers auto-bid on matching RFPs
+
+CREATE TABLE bid_strategies (
+    id              TEXT PRIMARY KEY,
+    seller_addr     TEXT NOT NULL,
+    service_type    TEXT NOT NULL,
+    min_price       NUMERIC(20,6) NOT NULL CHECK (min_price > 0),
+    target_margin   NUMERIC(8,4) NOT NULL DEFAULT 0 CHECK (target_margin >= 0),
+    max_concurrent  INTEGER NOT NULL DEFAULT 5 CHECK (max_concurrent > 0),
+    min_buyer_score NUMERIC(8,4) NOT NULL DEFAULT 0 CHECK (min_buyer_score >= 0 AND min_buyer_score <= 100),
+    enabled         BOOLEAN NOT NULL DEFAULT TRUE,
+    bids_placed     INTEGER NOT NULL DEFAULT 0,
+    last_run_at     TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_bid_strategies_seller ON bid_strategies (seller_addr);
+CREATE INDEX idx_bid_strategies_enabled ON bid_strategies (service_type) WHERE enabled;
+
+-- +goose Down
+DROP TABLE IF EXISTS bid_strategies;
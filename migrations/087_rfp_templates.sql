@@ -0,0 +1,21 @@
+-- +goose Up
+-- Saved RFP templates for recurring buyers
+
+CREATE TABLE rfp_templates (
+    id            TEXT PRIMARY KEY,
+    buyer_addr    TEXT NOT NULL,
+    name          TEXT NOT NULL,
+    service_type  TEXT NOT NULL,
+    description   TEXT,
+    budget        NUMERIC(20,6) NOT NULL CHECK (budget > 0),
+    mode          TEXT NOT NULL DEFAULT 'open' CHECK (mode IN ('open', 'sealed')),
+    bids_close_in TEXT,
+    use_count     INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rfp_templates_buyer ON rfp_templates (buyer_addr);
+
+-- +goose Down
+DROP TABLE IF EXISTS rfp_templates;
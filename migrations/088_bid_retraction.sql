@@ -0,0 +1,15 @@
+-- +goose Up
+-- Bid retraction with penalties and per-RFP seller reputation floors
+
+ALTER TABLE rfps ADD COLUMN min_seller_score NUMERIC(8,4) NOT NULL DEFAULT 0
+    CHECK (min_seller_score >= 0 AND min_seller_score <= 100);
+
+ALTER TABLE rfp_bids DROP CONSTRAINT rfp_bids_status_check;
+ALTER TABLE rfp_bids ADD CONSTRAINT rfp_bids_status_check
+    CHECK (status IN ('active', 'withdrawn', 'retracted', 'invalidated', 'won', 'lost'));
+
+-- +goose Down
+ALTER TABLE rfp_bids DROP CONSTRAINT rfp_bids_status_check;
+ALTER TABLE rfp_bids ADD CONSTRAINT rfp_bids_status_check
+    CHECK (status IN ('active', 'withdrawn', 'won', 'lost'));
+ALTER TABLE rfps DROP COLUMN IF EXISTS min_seller_score;
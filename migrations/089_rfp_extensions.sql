@@ -0,0 +1,24 @@
+-- +goose Up
+-- Deadline extension requests for RFP auctions (buyer proposes, leading
+-- bidders approve by majority)
+CREATE TABLE IF NOT EXISTS rfp_extensions (
+    id TEXT PRIMARY KEY,
+    rfp_id TEXT NOT NULL,
+    proposed_by TEXT NOT NULL,
+    new_deadline TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'lapsed')),
+    approvals TEXT[] NOT NULL DEFAULT '{}',
+    rejections TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rfp_extensions_rfp ON rfp_extensions (rfp_id);
+-- At most one pending extension per RFP
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rfp_extensions_pending
+    ON rfp_extensions (rfp_id) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE IF EXISTS rfp_extensions;
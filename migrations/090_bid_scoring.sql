@@ -0,0 +1,19 @@
+-- +goose Up
+-- Customizable bid scoring rubric and auto-award for RFP auctions
+ALTER TABLE rfps ADD COLUMN rubric JSONB;
+ALTER TABLE rfps ADD COLUMN auto_award BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE rfp_bids ADD COLUMN latency_sla_ms INTEGER NOT NULL DEFAULT 0
+    CHECK (latency_sla_ms >= 0);
+ALTER TABLE rfp_bids ADD COLUMN penalty_offered NUMERIC(20,6)
+    CHECK (penalty_offered > 0);
+ALTER TABLE rfp_bids ADD COLUMN score NUMERIC(8,4) NOT NULL DEFAULT 0;
+ALTER TABLE rfp_bids ADD COLUMN score_breakdown JSONB;
+
+-- +goose Down
+ALTER TABLE rfp_bids DROP COLUMN IF EXISTS score_breakdown;
+ALTER TABLE rfp_bids DROP COLUMN IF EXISTS score;
+ALTER TABLE rfp_bids DROP COLUMN IF EXISTS penalty_offered;
+ALTER TABLE rfp_bids DROP COLUMN IF EXISTS latency_sla_ms;
+ALTER TABLE rfps DROP COLUMN IF EXISTS auto_award;
+ALTER TABLE rfps DROP COLUMN IF EXISTS rubric;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Visibility controls for private and invite-only RFPs
+ALTER TABLE rfps ADD COLUMN visibility TEXT NOT NULL DEFAULT 'public'
+    CHECK (visibility IN ('public', 'invite', 'tier'));
+ALTER TABLE rfps ADD COLUMN invited_addrs TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE rfps ADD COLUMN min_tier TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE rfps DROP COLUMN IF EXISTS min_tier;
+ALTER TABLE rfps DROP COLUMN IF EXISTS invited_addrs;
+ALTER TABLE rfps DROP COLUMN IF EXISTS visibility;
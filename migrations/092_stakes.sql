@@ -0,0 +1,73 @@
+-- +goose Up
+-- Revenue-share stakes: offerings, investor positions, distributions and
+-- the secondary market for position resale.
+CREATE TABLE IF NOT EXISTS stake_offerings (
+    id TEXT PRIMARY KEY,
+    agent_addr TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    revenue_share_bps INTEGER NOT NULL CHECK (revenue_share_bps BETWEEN 1 AND 10000),
+    total_shares INTEGER NOT NULL CHECK (total_shares > 0),
+    shares_sold INTEGER NOT NULL DEFAULT 0 CHECK (shares_sold >= 0),
+    price_per_share NUMERIC(20,6) NOT NULL CHECK (price_per_share > 0),
+    accrued NUMERIC(20,6) NOT NULL DEFAULT 0,
+    total_distributed NUMERIC(20,6) NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stake_offerings_agent ON stake_offerings (agent_addr);
+CREATE INDEX IF NOT EXISTS idx_stake_offerings_status ON stake_offerings (status);
+
+CREATE TABLE IF NOT EXISTS stake_positions (
+    id TEXT PRIMARY KEY,
+    offering_id TEXT NOT NULL REFERENCES stake_offerings(id),
+    agent_addr TEXT NOT NULL,
+    investor_addr TEXT NOT NULL,
+    shares INTEGER NOT NULL CHECK (shares > 0),
+    cost_basis NUMERIC(20,6) NOT NULL,
+    distributed_total NUMERIC(20,6) NOT NULL DEFAULT 0,
+    locked BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stake_positions_offering ON stake_positions (offering_id);
+CREATE INDEX IF NOT EXISTS idx_stake_positions_investor ON stake_positions (investor_addr);
+
+CREATE TABLE IF NOT EXISTS stake_distributions (
+    id TEXT PRIMARY KEY,
+    offering_id TEXT NOT NULL REFERENCES stake_offerings(id),
+    total_amount NUMERIC(20,6) NOT NULL,
+    payouts JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stake_distributions_offering ON stake_distributions (offering_id);
+
+CREATE TABLE IF NOT EXISTS stake_listings (
+    id TEXT PRIMARY KEY,
+    position_id TEXT NOT NULL REFERENCES stake_positions(id),
+    offering_id TEXT NOT NULL REFERENCES stake_offerings(id),
+    seller_addr TEXT NOT NULL,
+    price NUMERIC(20,6) NOT NULL CHECK (price > 0),
+    fee_bps INTEGER NOT NULL DEFAULT 0 CHECK (fee_bps BETWEEN 0 AND 10000),
+    status TEXT NOT NULL DEFAULT 'open',
+    buyer_addr TEXT,
+    fee_amount NUMERIC(20,6),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stake_listings_seller ON stake_listings (seller_addr);
+CREATE INDEX IF NOT EXISTS idx_stake_listings_status ON stake_listings (status);
+-- One open listing per position at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stake_listings_open
+    ON stake_listings (position_id) WHERE status = 'open';
+
+-- +goose Down
+DROP TABLE IF EXISTS stake_listings;
+DROP TABLE IF EXISTS stake_distributions;
+DROP TABLE IF EXISTS stake_positions;
+DROP TABLE IF EXISTS stake_offerings;
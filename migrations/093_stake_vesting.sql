@@ -0,0 +1,8 @@
+-- +goose Up
+-- Cliff + linear vesting for stake offerings.
+ALTER TABLE stake_offerings ADD COLUMN vesting JSONB;
+ALTER TABLE stake_positions ADD COLUMN vesting_start TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+-- +goose Down
+ALTER TABLE stake_positions DROP COLUMN IF EXISTS vesting_start;
+ALTER TABLE stake_offerings DROP COLUMN IF EXISTS vesting;
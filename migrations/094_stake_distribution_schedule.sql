@@ -0,0 +1,13 @@
+-- +goose Up
+-- Per-offering distribution cadence and minimums.
+ALTER TABLE stake_offerings ADD COLUMN cadence TEXT NOT NULL DEFAULT '';
+ALTER TABLE stake_offerings ADD COLUMN min_distribution NUMERIC(20,6) NOT NULL DEFAULT 0;
+ALTER TABLE stake_offerings ADD COLUMN last_distributed_at TIMESTAMPTZ;
+
+CREATE INDEX idx_stake_offerings_cadence ON stake_offerings (cadence) WHERE cadence <> '';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_stake_offerings_cadence;
+ALTER TABLE stake_offerings DROP COLUMN IF EXISTS last_distributed_at;
+ALTER TABLE stake_offerings DROP COLUMN IF EXISTS min_distribution;
+ALTER TABLE stake_offerings DROP COLUMN IF EXISTS cadence;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Registry-backed due-diligence disclosures on stake offerings.
+ALTER TABLE stake_offerings ADD COLUMN disclosure JSONB;
+
+-- +goose Down
+ALTER TABLE stake_offerings DROP COLUMN IF EXISTS disclosure;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Step-down revenue-share schedules on stake offerings.
+ALTER TABLE stake_offerings ADD COLUMN share_tiers JSONB;
+
+-- +goose Down
+ALTER TABLE stake_offerings DROP COLUMN IF EXISTS share_tiers;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Position transfers and gifting with an audit history.
+CREATE TABLE stake_transfers (
+    id          TEXT PRIMARY KEY,
+    position_id TEXT NOT NULL REFERENCES stake_positions(id),
+    offering_id TEXT NOT NULL,
+    from_addr   TEXT NOT NULL,
+    to_addr     TEXT NOT NULL,
+    price       NUMERIC(20,6),
+    status      TEXT NOT NULL DEFAULT 'pending',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stake_transfers_position ON stake_transfers (position_id);
+CREATE INDEX idx_stake_transfers_from ON stake_transfers (from_addr);
+CREATE INDEX idx_stake_transfers_to ON stake_transfers (to_addr);
+-- At most one pending transfer per position.
+CREATE UNIQUE INDEX idx_stake_transfers_pending ON stake_transfers (position_id) WHERE status = 'pending';
+
+-- +goose Down
+DROP TABLE IF EXISTS stake_transfers;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- Prediction market: agent claims with oracle-based or creator-attested
+-- resolution, backed by USDC wagers.
+CREATE TABLE predictions (
+    id           TEXT PRIMARY KEY,
+    creator_addr TEXT NOT NULL,
+    claim        TEXT NOT NULL,
+    oracle       JSONB,
+    deadline     TIMESTAMPTZ NOT NULL,
+    staked_yes   NUMERIC(20,6) NOT NULL DEFAULT 0,
+    staked_no    NUMERIC(20,6) NOT NULL DEFAULT 0,
+    status       TEXT NOT NULL DEFAULT 'open',
+    outcome      TEXT NOT NULL DEFAULT '',
+    resolved_by  TEXT,
+    resolved_at  TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE prediction_wagers (
+    id            TEXT PRIMARY KEY,
+    prediction_id TEXT NOT NULL REFERENCES predictions(id),
+    agent_addr    TEXT NOT NULL,
+    side          TEXT NOT NULL,
+    amount        NUMERIC(20,6) NOT NULL,
+    payout        NUMERIC(20,6),
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_predictions_creator ON predictions (creator_addr);
+CREATE INDEX idx_predictions_open_deadline ON predictions (deadline) WHERE status = 'open';
+CREATE INDEX idx_prediction_wagers_prediction ON prediction_wagers (prediction_id);
+CREATE INDEX idx_prediction_wagers_agent ON prediction_wagers (agent_addr);
+
+-- +goose Down
+DROP TABLE IF EXISTS prediction_wagers;
+DROP TABLE IF EXISTS predictions;
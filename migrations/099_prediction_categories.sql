@@ -0,0 +1,11 @@
+-- +goose Up
+-- Category tagging for predictions, feeding per-category leaderboards.
+ALTER TABLE predictions ADD COLUMN category TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_predictions_category ON predictions (category, status);
+-- Leaderboards scan resolved predictions by window.
+CREATE INDEX idx_predictions_resolved_at ON predictions (resolved_at) WHERE status = 'resolved';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_predictions_resolved_at;
+DROP INDEX IF EXISTS idx_predictions_category;
+ALTER TABLE predictions DROP COLUMN IF EXISTS category;
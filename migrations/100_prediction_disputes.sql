@@ -0,0 +1,33 @@
+-- +goose Up
+-- Challenge window: resolution records the outcome, settlement waits.
+ALTER TABLE predictions ADD COLUMN challenge_ends_at TIMESTAMPTZ;
+ALTER TABLE predictions ADD COLUMN settled_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS prediction_disputes (
+    id TEXT PRIMARY KEY,
+    prediction_id TEXT NOT NULL REFERENCES predictions(id),
+    challenger_addr TEXT NOT NULL,
+    evidence TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open',
+    note TEXT NOT NULL DEFAULT '',
+    ruled_by TEXT,
+    ruled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_prediction_disputes_prediction ON prediction_disputes (prediction_id);
+-- At most one open dispute per prediction; also serves the admin queue scan.
+CREATE UNIQUE INDEX idx_prediction_disputes_open ON prediction_disputes (prediction_id) WHERE status = 'open';
+
+-- Leaderboards now tally settled predictions, not merely resolved ones.
+DROP INDEX IF EXISTS idx_predictions_resolved_at;
+CREATE INDEX idx_predictions_resolved_at ON predictions (resolved_at) WHERE status = 'settled';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_predictions_resolved_at;
+CREATE INDEX idx_predictions_resolved_at ON predictions (resolved_at) WHERE status = 'resolved';
+DROP INDEX IF EXISTS idx_prediction_disputes_open;
+DROP INDEX IF EXISTS idx_prediction_disputes_prediction;
+DROP TABLE IF EXISTS prediction_disputes;
+ALTER TABLE predictions DROP COLUMN IF EXISTS settled_at;
+ALTER TABLE predictions DROP COLUMN IF EXISTS challenge_ends_at;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Follow/subscribe feeds: followers get notified about a predictor's new
+-- claims and resolutions.
+CREATE TABLE IF NOT EXISTS prediction_follows (
+    follower_addr TEXT NOT NULL,
+    predictor_addr TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_addr, predictor_addr)
+);
+
+CREATE INDEX idx_prediction_follows_predictor ON prediction_follows (predictor_addr);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_prediction_follows_predictor;
+DROP TABLE IF EXISTS prediction_follows;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- ERC-4337 smart accounts: agents register a smart-account wallet bound to
+-- their platform identity; the platform sponsors its UserOperations as a
+-- verifying paymaster.
+CREATE TABLE IF NOT EXISTS smart_accounts (
+    id TEXT PRIMARY KEY,
+    owner_addr TEXT NOT NULL,
+    account_addr TEXT NOT NULL,
+    entry_point TEXT NOT NULL,
+    chain TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_smart_accounts_owner ON smart_accounts (owner_addr);
+-- One active registration per smart-account address.
+CREATE UNIQUE INDEX idx_smart_accounts_addr_active ON smart_accounts (account_addr) WHERE status = 'active';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_smart_accounts_addr_active;
+DROP INDEX IF EXISTS idx_smart_accounts_owner;
+DROP TABLE IF EXISTS smart_accounts;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- Multisig proposals: large withdrawals and treasury moves route through a
+-- Safe-backed proposal/approval flow; the executor submits only after the
+-- approval threshold is met.
+CREATE TABLE IF NOT EXISTS multisig_proposals (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    safe_address TEXT NOT NULL,
+    to_addr TEXT NOT NULL,
+    amount TEXT NOT NULL,
+    reference TEXT NOT NULL,
+    agent_addr TEXT NOT NULL DEFAULT '',
+    proposed_by TEXT NOT NULL DEFAULT '',
+    threshold INTEGER NOT NULL,
+    approvals JSONB NOT NULL DEFAULT '[]',
+    status TEXT NOT NULL DEFAULT 'pending',
+    safe_tx_hash TEXT NOT NULL DEFAULT '',
+    tx_hash TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    executed_at TIMESTAMPTZ
+);
+
+-- Reference is the idempotency key for routed withdrawals.
+CREATE UNIQUE INDEX idx_multisig_proposals_reference ON multisig_proposals (reference);
+CREATE INDEX idx_multisig_proposals_status ON multisig_proposals (status, created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_multisig_proposals_status;
+DROP INDEX IF EXISTS idx_multisig_proposals_reference;
+DROP TABLE IF EXISTS multisig_proposals;
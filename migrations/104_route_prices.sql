@@ -0,0 +1,15 @@
+-- +goose Up
+-- Paid-route pricing table: operators reprice x402-protected endpoints
+-- without redeploying. Tier '' is the route's default price; other rows
+-- override it per caller reputation tier.
+CREATE TABLE IF NOT EXISTS route_prices (
+    route TEXT NOT NULL,
+    tier TEXT NOT NULL DEFAULT '',
+    amount TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (route, tier)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS route_prices;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Webhook secret rotation: the previous secret keeps verifying (deliveries
+-- are dual-signed) until prev_secret_expires_at, so receivers can cut over
+-- without dropping events.
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS prev_secret VARCHAR(64);
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS prev_secret_expires_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE webhooks DROP COLUMN IF EXISTS prev_secret_expires_at;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS prev_secret;
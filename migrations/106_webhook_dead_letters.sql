@@ -0,0 +1,21 @@
+-- +goose Up
+-- Webhook dead-letter queue: deliveries that exhaust their retries are
+-- kept for owner inspection and manual redelivery instead of being
+-- silently dropped.
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    id VARCHAR(36) PRIMARY KEY,
+    subscription_id VARCHAR(36) NOT NULL,
+    agent_address VARCHAR(42) NOT NULL,
+    event_id VARCHAR(64),
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    failed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_dead_letters_agent ON webhook_dead_letters (agent_address, failed_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhook_dead_letters_agent;
+DROP TABLE IF EXISTS webhook_dead_letters;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Per-endpoint payload schema pinning: deliveries are rendered in the
+-- version the subscription was created with, so payload changes in the
+-- dispatcher don't break existing consumers. Existing rows stay on v1.
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS payload_version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE webhooks DROP COLUMN IF EXISTS payload_version;
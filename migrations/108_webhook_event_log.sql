@@ -0,0 +1,17 @@
+-- +goose Up
+-- Durable log of dispatched webhook events. The sequence number is the
+-- replay cursor: consumers that missed deliveries request everything
+-- after the last seq they processed.
+CREATE TABLE IF NOT EXISTS webhook_event_log (
+    seq             BIGSERIAL PRIMARY KEY,
+    agent_address   VARCHAR(42),          -- NULL for broadcast events
+    event_id        VARCHAR(64) NOT NULL,
+    event_type      VARCHAR(64) NOT NULL,
+    event_timestamp TIMESTAMPTZ NOT NULL,
+    data            JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_event_log_timestamp ON webhook_event_log(event_timestamp);
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_event_log;
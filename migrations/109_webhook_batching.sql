@@ -0,0 +1,10 @@
+-- +goose Up
+-- Opt-in batched delivery: endpoints with either knob set receive events
+-- as arrays, flushed every batch_window_seconds or when batch_max_events
+-- are pending. Zero on both means immediate per-event delivery.
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS batch_window_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS batch_max_events INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE webhooks DROP COLUMN IF EXISTS batch_max_events;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS batch_window_seconds;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Transactional outbox for webhook dispatch: services write events here
+-- in the same transaction as the state change, and the relay dispatches
+-- unpublished rows, so no event is lost between commit and dispatch.
+CREATE TABLE IF NOT EXISTS webhook_outbox (
+    id TEXT PRIMARY KEY,
+    agent_address VARCHAR(42) NOT NULL DEFAULT '',  -- '' = broadcast by event type
+    event_type VARCHAR(64) NOT NULL,
+    data JSONB,
+    published BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ
+);
+
+-- Fast poll for unpublished events
+CREATE INDEX IF NOT EXISTS idx_webhook_outbox_unpublished ON webhook_outbox(created_at) WHERE published = FALSE;
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_outbox;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Optional per-endpoint payload template. NULL means the standard event
+-- payload; otherwise a JSON object with {{path}} placeholders rendered
+-- per delivery (see docs/webhooks.md).
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS template JSONB;
+
+-- +goose Down
+ALTER TABLE webhooks DROP COLUMN IF EXISTS template;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- Counterparty blocklists: the global operator-managed list plus per-agent
+-- personal denylists, with an audit trail of every mutation. Persisted so
+-- a payments-refusal control survives restarts.
+CREATE TABLE IF NOT EXISTS blocklist_entries (
+    scope      VARCHAR(42) NOT NULL, -- 'global' or the owning agent's address
+    address    VARCHAR(42) NOT NULL,
+    reason     TEXT NOT NULL DEFAULT '',
+    added_by   VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scope, address)
+);
+
+CREATE TABLE IF NOT EXISTS blocklist_audit (
+    id         BIGSERIAL PRIMARY KEY,
+    action     VARCHAR(16) NOT NULL, -- 'block', 'unblock', 'import'
+    address    VARCHAR(42) NOT NULL DEFAULT '',
+    scope      VARCHAR(42) NOT NULL,
+    actor      VARCHAR(64) NOT NULL DEFAULT '',
+    reason     TEXT NOT NULL DEFAULT '',
+    count      INTEGER NOT NULL DEFAULT 0, -- imported addresses
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocklist_audit_created ON blocklist_audit(created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_blocklist_audit_created;
+DROP TABLE IF EXISTS blocklist_audit;
+DROP TABLE IF EXISTS blocklist_entries;
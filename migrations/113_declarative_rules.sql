@@ -0,0 +1,12 @@
+-- +goose Up
+-- Admin-authored declarative risk rules, stored as JSONB so the rule
+-- shape can evolve without further migrations. Persisted so enabled
+-- deny rules survive restarts instead of living only in process memory.
+CREATE TABLE IF NOT EXISTS declarative_rules (
+    id         VARCHAR(64) PRIMARY KEY,
+    rule       JSONB NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS declarative_rules;